@@ -12,6 +12,7 @@ import (
 
 	"github.com/nicklaros/adol/internal/infrastructure/config"
 	"github.com/nicklaros/adol/internal/infrastructure/database"
+	grpcInfra "github.com/nicklaros/adol/internal/infrastructure/grpc"
 	httpInfra "github.com/nicklaros/adol/internal/infrastructure/http"
 	"github.com/nicklaros/adol/pkg/logger"
 )
@@ -50,6 +51,17 @@ func main() {
 		}
 	}()
 
+	// Initialize and start the gRPC server for internal services
+	var grpcServer *grpcInfra.Server
+	if cfg.GRPC.Enabled {
+		grpcServer = grpcInfra.NewServer(cfg, db, logger)
+		go func() {
+			if err := grpcServer.Start(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -60,6 +72,12 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			logger.WithField("error", err.Error()).Error("gRPC server forced to shutdown")
+		}
+	}
+
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
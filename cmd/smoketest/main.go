@@ -0,0 +1,350 @@
+// Command smoketest runs a scripted flow against a deployed environment to verify a
+// release end to end: it logs in, creates a product, stocks it, sells it, invoices the
+// sale, renders the invoice PDF and emails it to a sink address. Each step is reported
+// as PASS, FAIL or SKIP and the command exits non-zero if any step fails.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	cfg := config{}
+	flag.StringVar(&cfg.baseURL, "base-url", env("SMOKETEST_BASE_URL", "http://localhost:8080"), "base URL of the deployment")
+	flag.StringVar(&cfg.tenant, "tenant", env("SMOKETEST_TENANT", ""), "slug of the tenant to log in to")
+	flag.StringVar(&cfg.email, "email", env("SMOKETEST_EMAIL", ""), "email of the user to log in as")
+	flag.StringVar(&cfg.password, "password", env("SMOKETEST_PASSWORD", ""), "password of the user to log in as")
+	flag.StringVar(&cfg.sink, "sink", env("SMOKETEST_EMAIL_SINK", ""), "address the test invoice email is sent to")
+	flag.DurationVar(&cfg.timeout, "timeout", 15*time.Second, "timeout of each request")
+	flag.DurationVar(&cfg.emailWait, "email-wait", 2*time.Minute, "how long to wait for the queued email to be sent")
+	flag.Parse()
+
+	if cfg.tenant == "" || cfg.email == "" || cfg.password == "" || cfg.sink == "" {
+		fmt.Fprintln(os.Stderr, "smoketest: -tenant, -email, -password and -sink are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	s := &smokeTest{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.timeout},
+		runID:  time.Now().UTC().Format("20060102150405"),
+	}
+
+	if !s.run(context.Background()) {
+		os.Exit(1)
+	}
+}
+
+// config holds the target deployment and credentials of a smoke test run
+type config struct {
+	baseURL   string
+	tenant    string
+	email     string
+	password  string
+	sink      string
+	timeout   time.Duration
+	emailWait time.Duration
+}
+
+// step is one check of the scripted flow
+type step struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// smokeTest carries the state passed from one step to the next
+type smokeTest struct {
+	config
+	client *http.Client
+	runID  string
+
+	token     string
+	productID string
+	saleID    string
+	invoiceID string
+}
+
+// run executes the steps in order and reports each of them. Steps after a failure are
+// skipped since they depend on what the earlier steps created.
+func (s *smokeTest) run(ctx context.Context) bool {
+	steps := []step{
+		{"readiness", s.checkReady},
+		{"login", s.login},
+		{"create product", s.createProduct},
+		{"adjust stock", s.adjustStock},
+		{"create sale", s.createSale},
+		{"complete sale", s.completeSale},
+		{"create invoice", s.createInvoice},
+		{"generate invoice PDF", s.generateInvoicePDF},
+		{"send invoice email", s.sendInvoiceEmail},
+	}
+
+	fmt.Printf("Smoke testing %s (run %s)\n", s.baseURL, s.runID)
+
+	passed := true
+	for _, st := range steps {
+		if !passed {
+			fmt.Printf("SKIP  %s\n", st.name)
+			continue
+		}
+
+		start := time.Now()
+		err := st.run(ctx)
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			fmt.Printf("FAIL  %s (%s): %v\n", st.name, elapsed, err)
+			passed = false
+			continue
+		}
+		fmt.Printf("PASS  %s (%s)\n", st.name, elapsed)
+	}
+
+	if passed {
+		fmt.Println("Smoke test passed")
+	} else {
+		fmt.Println("Smoke test failed")
+	}
+	return passed
+}
+
+func (s *smokeTest) checkReady(ctx context.Context) error {
+	_, err := s.do(ctx, http.MethodGet, "/health/ready", nil, nil)
+	return err
+}
+
+func (s *smokeTest) login(ctx context.Context) error {
+	var out struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+		} `json:"data"`
+	}
+	body := map[string]interface{}{
+		"tenant_slug": s.tenant,
+		"email":       s.email,
+		"password":    s.password,
+	}
+	if _, err := s.do(ctx, http.MethodPost, "/api/v1/tenants/login", body, &out); err != nil {
+		return err
+	}
+	if out.Data.AccessToken == "" {
+		return fmt.Errorf("response has no access token")
+	}
+
+	s.token = out.Data.AccessToken
+	return nil
+}
+
+func (s *smokeTest) createProduct(ctx context.Context) error {
+	body := map[string]interface{}{
+		"sku":         "SMOKE-" + s.runID,
+		"name":        "Smoke test product " + s.runID,
+		"description": "Created by the deployment smoke test",
+		"category":    "Smoke Test",
+		"price":       "10.00",
+		"cost":        "5.00",
+		"unit":        "pcs",
+		"min_stock":   0,
+	}
+
+	var err error
+	s.productID, err = s.create(ctx, "/api/v1/products", body)
+	return err
+}
+
+func (s *smokeTest) adjustStock(ctx context.Context) error {
+	body := map[string]interface{}{
+		"product_id":    s.productID,
+		"quantity":      5,
+		"movement_type": "purchase",
+		"reason":        "Deployment smoke test",
+		"reference":     "SMOKE-" + s.runID,
+	}
+	_, err := s.do(ctx, http.MethodPost, "/api/v1/stock/adjust", body, nil)
+	return err
+}
+
+func (s *smokeTest) createSale(ctx context.Context) error {
+	body := map[string]interface{}{
+		"customer_name":  "Smoke Test",
+		"customer_email": s.sink,
+	}
+
+	var err error
+	s.saleID, err = s.create(ctx, "/api/v1/sales", body)
+	if err != nil {
+		return err
+	}
+
+	item := map[string]interface{}{
+		"product_id": s.productID,
+		"quantity":   1,
+	}
+	_, err = s.do(ctx, http.MethodPost, "/api/v1/sales/"+s.saleID+"/items", item, nil)
+	return err
+}
+
+func (s *smokeTest) completeSale(ctx context.Context) error {
+	body := map[string]interface{}{
+		"paid_amount":    "10.00",
+		"payment_method": "cash",
+		"notes":          "Deployment smoke test",
+	}
+	_, err := s.do(ctx, http.MethodPost, "/api/v1/sales/"+s.saleID+"/complete", body, nil)
+	return err
+}
+
+func (s *smokeTest) createInvoice(ctx context.Context) error {
+	body := map[string]interface{}{
+		"sale_id":    s.saleID,
+		"paper_size": "a4",
+		"notes":      "Deployment smoke test",
+	}
+
+	var err error
+	s.invoiceID, err = s.create(ctx, "/api/v1/invoices", body)
+	return err
+}
+
+func (s *smokeTest) generateInvoicePDF(ctx context.Context) error {
+	data, err := s.do(ctx, http.MethodGet, "/api/v1/invoices/"+s.invoiceID+"/pdf?paper_size=a4", nil, nil)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return fmt.Errorf("response is not a PDF")
+	}
+	return nil
+}
+
+// sendInvoiceEmail queues the invoice email to the sink and waits for the outbox worker
+// to report it sent
+func (s *smokeTest) sendInvoiceEmail(ctx context.Context) error {
+	body := map[string]interface{}{
+		"email_to":   s.sink,
+		"subject":    "Smoke test invoice " + s.runID,
+		"message":    "Sent by the deployment smoke test.",
+		"paper_size": "a4",
+	}
+	if _, err := s.do(ctx, http.MethodPost, "/api/v1/invoices/"+s.invoiceID+"/email", body, nil); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(s.emailWait)
+	for {
+		var out struct {
+			Data []struct {
+				Status    string `json:"status"`
+				LastError string `json:"last_error"`
+			} `json:"data"`
+		}
+		if _, err := s.do(ctx, http.MethodGet, "/api/v1/invoices/"+s.invoiceID+"/emails", nil, &out); err != nil {
+			return err
+		}
+		if len(out.Data) == 0 {
+			return fmt.Errorf("email was not queued")
+		}
+
+		switch email := out.Data[0]; email.Status {
+		case "sent":
+			return nil
+		case "failed":
+			return fmt.Errorf("email failed: %s", email.LastError)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("email still pending after %s", s.emailWait)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// create posts a resource and returns the id of the created resource
+func (s *smokeTest) create(ctx context.Context, path string, body interface{}) (string, error) {
+	var out struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if _, err := s.do(ctx, http.MethodPost, path, body, &out); err != nil {
+		return "", err
+	}
+	if out.Data.ID == "" {
+		return "", fmt.Errorf("response has no id")
+	}
+	return out.Data.ID, nil
+}
+
+// do sends a request authenticated as the logged in user and decodes a JSON response into
+// out when given. It returns the raw response body, or an error for a non-2xx status.
+func (s *smokeTest) do(ctx context.Context, method, path string, body, out interface{}) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(s.baseURL, "/")+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	req.Header.Set("X-Request-ID", "smoketest-"+s.runID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: failed to read response: %w", method, path, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, truncate(string(data), 200))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, fmt.Errorf("%s %s: failed to decode response: %w", method, path, err)
+		}
+	}
+
+	return data, nil
+}
+
+// env returns the value of an environment variable or a default
+func env(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// truncate shortens s to at most n bytes for error messages
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -2,11 +2,14 @@ package ports
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
 	"github.com/nicklaros/adol/pkg/utils"
 )
 
@@ -15,6 +18,11 @@ type DatabasePort interface {
 	// Transaction management
 	BeginTransaction(ctx context.Context) (TransactionPort, error)
 	Health(ctx context.Context) error
+
+	// ReadOnly marks a context whose repository reads may be served by a read replica, which
+	// can lag slightly behind the primary. Only use it for reads that do not follow a write of
+	// the same request. Writes and transactions always go to the primary.
+	ReadOnly(ctx context.Context) context.Context
 }
 
 // TransactionPort defines the interface for database transactions
@@ -29,6 +37,19 @@ type TransactionPort interface {
 	GetSaleItemRepository() repositories.SaleItemRepository
 	GetInvoiceRepository() repositories.InvoiceRepository
 	GetInvoiceItemRepository() repositories.InvoiceItemRepository
+	GetStockReservationRepository() repositories.StockReservationRepository
+	GetRefundRepository() repositories.RefundRepository
+	GetPurchaseOrderRepository() repositories.PurchaseOrderRepository
+	GetPurchaseOrderReceivingRepository() repositories.PurchaseOrderReceivingRepository
+	GetInventoryCostAdjustmentRepository() repositories.InventoryCostAdjustmentRepository
+	GetStockCountRepository() repositories.StockCountRepository
+	GetCouponRepository() repositories.CouponRepository
+	GetQuoteRepository() repositories.QuoteRepository
+	GetLocationRepository() repositories.LocationRepository
+	GetStockTransferRepository() repositories.StockTransferRepository
+	GetSerialNumberRepository() repositories.SerialNumberRepository
+	GetCostLayerRepository() repositories.CostLayerRepository
+	GetCustomerRepository() repositories.CustomerRepository
 }
 
 // CachePort defines the interface for caching operations
@@ -84,6 +105,9 @@ type FileStoragePort interface {
 	// Store stores a file and returns the file path
 	Store(ctx context.Context, filename string, data []byte) (string, error)
 	
+	// StoreFrom stores a file of the given size read from the start of body, for files too large to hold in memory
+	StoreFrom(ctx context.Context, filename string, body io.ReadSeeker, size int64) (string, error)
+	
 	// Retrieve retrieves a file by path
 	Retrieve(ctx context.Context, filepath string) ([]byte, error)
 	
@@ -163,6 +187,10 @@ type AuditPort interface {
 	
 	// Query queries audit events
 	Query(ctx context.Context, filter AuditFilter, pagination utils.PaginationInfo) ([]AuditEvent, utils.PaginationInfo, error)
+
+	// VerifyChain recomputes the hash chain of a tenant's audit events logged from the given time
+	// on and reports every event that was altered, removed or inserted since it was logged
+	VerifyChain(ctx context.Context, tenantID uuid.UUID, from time.Time) (*AuditChainVerification, error)
 }
 
 // AuditEvent represents an audit event
@@ -174,15 +202,50 @@ type AuditEvent struct {
 	ResourceID  string                 `json:"resource_id,omitempty"`
 	OldValue    map[string]interface{} `json:"old_value,omitempty"`
 	NewValue    map[string]interface{} `json:"new_value,omitempty"`
+	Changes     []audit.Change         `json:"changes,omitempty"` // Field-level changes computed from entity snapshots
 	IPAddress   string                 `json:"ip_address,omitempty"`
 	UserAgent   string                 `json:"user_agent,omitempty"`
 	Timestamp   time.Time              `json:"timestamp"`
 	Success     bool                   `json:"success"`
 	ErrorMessage string                `json:"error_message,omitempty"`
+
+	// Events are chained per tenant: each stores the hash of the tenant's previous event, so
+	// changing or removing one breaks the chain. Set when the event is stored.
+	Sequence     int64  `json:"sequence,omitempty"`
+	PreviousHash string `json:"previous_hash,omitempty"`
+	Hash         string `json:"hash,omitempty"`
+}
+
+// AuditChainBreak kinds
+const (
+	AuditChainAltered   = "altered"   // The event's content no longer matches its hash
+	AuditChainUnlinked  = "unlinked"  // The event does not follow the event before it; events were removed or inserted
+	AuditChainTruncated = "truncated" // The latest events of the chain were removed
+)
+
+// AuditChainBreak represents a point where a tenant's audit chain does not verify
+type AuditChainBreak struct {
+	Kind     string    `json:"kind"`
+	Sequence int64     `json:"sequence"`
+	EventID  uuid.UUID `json:"event_id,omitempty"`
+	Detail   string    `json:"detail"`
+}
+
+// AuditChainVerification represents the result of verifying a tenant's audit chain
+type AuditChainVerification struct {
+	TenantID      uuid.UUID         `json:"tenant_id"`
+	From          time.Time         `json:"from"`
+	CheckedEvents int64             `json:"checked_events"`
+	FirstSequence int64             `json:"first_sequence,omitempty"`
+	LastSequence  int64             `json:"last_sequence,omitempty"`
+	IsIntact      bool              `json:"is_intact"`
+	Breaks        []AuditChainBreak `json:"breaks"`
+	VerifiedAt    time.Time         `json:"verified_at"`
 }
 
 // AuditFilter represents audit event filter
 type AuditFilter struct {
+	TenantID   *uuid.UUID `json:"tenant_id,omitempty"`
 	UserID     *uuid.UUID `json:"user_id,omitempty"`
 	Action     string     `json:"action,omitempty"`
 	Resource   string     `json:"resource,omitempty"`
@@ -215,4 +278,169 @@ type MetricsPort interface {
 // TimerPort defines the interface for timing operations
 type TimerPort interface {
 	Stop()
-}
\ No newline at end of file
+}
+// DiagnosticsPort exposes in-process diagnostics that are not stored in the database
+type DiagnosticsPort interface {
+	// JobFailures returns background job runs that failed since the given time
+	JobFailures(since time.Time) []JobFailure
+
+	// TenantHealth returns a tenant's current health and the alerts raised for it since the given time
+	TenantHealth(ctx context.Context, tenantID uuid.UUID, since time.Time) (interface{}, error)
+
+	// ConfigSnapshot returns the running configuration with secrets redacted
+	ConfigSnapshot() map[string]interface{}
+}
+
+// JobFailure represents a failed background job run
+type JobFailure struct {
+	Job        string    `json:"job"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error"`
+}
+
+// SecurityAlertPort raises security alerts for a tenant through tenant monitoring, which routes
+// them to the tenant's alert channels
+type SecurityAlertPort interface {
+	// RaiseSecurityAlert raises a security alert
+	RaiseSecurityAlert(ctx context.Context, alert SecurityAlert) error
+}
+
+// SecurityAlert represents a security alert raised by the application
+type SecurityAlert struct {
+	TenantID    uuid.UUID
+	Severity    string // "info", "warning", "error" or "critical"
+	Title       string
+	Description string
+	Subject     string // What the alert is about; while an alert is open, repeats about the same subject are dropped
+	Metadata    map[string]interface{}
+}
+
+// WebhookPort defines the interface for sending webhook deliveries to tenant endpoints
+type WebhookPort interface {
+	// Post sends a JSON body with the given headers and returns the response status code
+	Post(ctx context.Context, request WebhookRequest) (int, error)
+}
+
+// ExchangeRatePort defines the interface for looking up current exchange rates
+type ExchangeRatePort interface {
+	// GetRate returns how many units of the to currency one unit of the from currency is
+	// worth. Both are ISO 4217 codes.
+	GetRate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
+
+// StorefrontPort defines the interface for updating stock levels on tenants' Shopify and
+// WooCommerce stores
+type StorefrontPort interface {
+	// SetStock sets the stock level of a product on a store. It returns the Shopify inventory
+	// item it set, resolved from the variant when the request has none, and is empty for
+	// WooCommerce.
+	SetStock(ctx context.Context, request StorefrontStockRequest) (string, error)
+}
+
+// StorefrontStockRequest represents setting the stock level of a product on a store
+type StorefrontStockRequest struct {
+	Platform        string // "shopify" or "woocommerce"
+	StoreURL        string
+	AccessToken     string // Shopify
+	ConsumerKey     string // WooCommerce
+	ConsumerSecret  string // WooCommerce
+	LocationID      string // Shopify location whose level is set
+	ProductID       string
+	VariantID       string // Shopify variant or WooCommerce variation; empty for simple WooCommerce products
+	InventoryItemID string // Shopify; resolved from the variant when empty
+	Quantity        int
+}
+
+// PaymentGatewayPort defines the interface for collecting card and digital wallet payments
+// through a payment gateway. Each provider has its own implementation.
+type PaymentGatewayPort interface {
+	// CreatePaymentIntent starts collecting a payment. Creating an intent again with the same
+	// reference does not charge the customer twice.
+	CreatePaymentIntent(ctx context.Context, request PaymentIntentRequest) (*PaymentIntent, error)
+
+	// ParseWebhook verifies a payment notification sent by the gateway and returns the update
+	// it carries, or nil for notifications about anything else
+	ParseWebhook(signature string, body []byte) (*PaymentGatewayEvent, error)
+}
+
+// PaymentIntentRequest represents a payment to collect through a payment gateway
+type PaymentIntentRequest struct {
+	Reference     string // Our transaction ID, sent along so the gateway's records can be matched
+	Amount        decimal.Decimal
+	Currency      string // ISO 4217 code
+	PaymentMethod string // "card" or "digital_wallet"
+	Description   string
+	CustomerEmail string
+}
+
+// PaymentIntent represents a payment created on a payment gateway and how the customer pays it
+type PaymentIntent struct {
+	ExternalID    string // Stripe payment intent or Midtrans order
+	ClientSecret  string // Stripe; confirms the payment on the client
+	RedirectURL   string // Midtrans; the payment page the customer is sent to
+	GatewayStatus string
+}
+
+// PaymentGatewayEvent represents a change in a payment's status reported by a payment gateway
+type PaymentGatewayEvent struct {
+	ExternalID    string
+	Status        string // "pending", "succeeded" or "failed"
+	GatewayStatus string // As reported by the gateway
+	Amount        decimal.Decimal
+	Currency      string
+}
+
+// TaxInvoiceExportPort writes tax invoices in the file format a tax authority imports, such as
+// the Indonesian e-Faktur CSV. Exporters are registered by format name.
+type TaxInvoiceExportPort interface {
+	// Format returns the name the format is requested by, e.g. "efaktur"
+	Format() string
+
+	// ContentType returns the MIME type of exported files
+	ContentType() string
+
+	// FileExtension returns the extension of exported files, without the dot
+	FileExtension() string
+
+	// Export writes the tax invoices issued by seller to w
+	Export(w io.Writer, seller TaxInvoiceParty, invoices []TaxInvoiceDocument) error
+}
+
+// TaxInvoiceParty represents the seller or buyer of a tax invoice
+type TaxInvoiceParty struct {
+	TaxID   string
+	Name    string
+	Address string
+}
+
+// TaxInvoiceDocument represents a tax invoice issued for a paid invoice. Amounts are in the
+// base currency.
+type TaxInvoiceDocument struct {
+	Serial        string // Tax invoice serial assigned from the tenant's ranges
+	InvoiceNumber string
+	Date          time.Time
+	Buyer         TaxInvoiceParty
+	TaxBase       decimal.Decimal // Amount the tax was charged on, after discounts
+	TaxAmount     decimal.Decimal
+	Lines         []TaxInvoiceLine
+}
+
+// TaxInvoiceLine represents an item of a tax invoice
+type TaxInvoiceLine struct {
+	Code       string
+	Name       string
+	UnitPrice  decimal.Decimal // Before tax
+	Quantity   int
+	TotalPrice decimal.Decimal // UnitPrice times Quantity
+	Discount   decimal.Decimal // Share of the invoice discount
+	TaxBase    decimal.Decimal
+	TaxAmount  decimal.Decimal
+}
+
+// WebhookRequest represents an outbound webhook HTTP request
+type WebhookRequest struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"-"`
+}
@@ -0,0 +1,388 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// maxAccountingJournalRange caps the date range of a single journal export
+	maxAccountingJournalRange = 366 * 24 * time.Hour
+
+	// journalDateLayout formats dates in journal descriptions and CSV exports
+	journalDateLayout = "2006-01-02"
+)
+
+// JournalFormat is the file format journal entries are exported in
+type JournalFormat string
+
+const (
+	// JournalFormatCSV is a plain CSV with one row per journal line
+	JournalFormatCSV JournalFormat = "csv"
+	// JournalFormatIIF is the QuickBooks Desktop Intuit Interchange Format
+	JournalFormatIIF JournalFormat = "iif"
+	// JournalFormatXero is the Xero manual journal import CSV
+	JournalFormatXero JournalFormat = "xero"
+	// JournalFormatAccurate is the Accurate Online journal voucher import CSV
+	JournalFormatAccurate JournalFormat = "accurate"
+)
+
+// ParseJournalFormat validates a requested journal format
+func ParseJournalFormat(format string) (JournalFormat, error) {
+	switch JournalFormat(format) {
+	case JournalFormatCSV, JournalFormatIIF, JournalFormatXero, JournalFormatAccurate:
+		return JournalFormat(format), nil
+	default:
+		return "", errors.NewValidationError("invalid format", "format must be json, csv, iif, xero or accurate")
+	}
+}
+
+// ContentType returns the MIME type of files in the format
+func (f JournalFormat) ContentType() string {
+	if f == JournalFormatIIF {
+		return "text/plain; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension returns the file extension of files in the format
+func (f JournalFormat) Extension() string {
+	if f == JournalFormatIIF {
+		return "iif"
+	}
+	return "csv"
+}
+
+// AccountingExportUseCase turns sales and refunds into general ledger journal entries posted to
+// each tenant's own chart of accounts, for import into accounting software
+type AccountingExportUseCase struct {
+	mappingRepo repositories.AccountingAccountMappingRepository
+	saleRepo    repositories.SaleRepository
+	database    ports.DatabasePort
+	audit       ports.AuditPort
+	logger      logger.Logger
+}
+
+// NewAccountingExportUseCase creates a new accounting export use case
+func NewAccountingExportUseCase(
+	mappingRepo repositories.AccountingAccountMappingRepository,
+	saleRepo repositories.SaleRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *AccountingExportUseCase {
+	return &AccountingExportUseCase{
+		mappingRepo: mappingRepo,
+		saleRepo:    saleRepo,
+		database:    database,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// AccountingJournalRequest represents a request for the journal entries of a date range
+type AccountingJournalRequest struct {
+	FromDate time.Time                `json:"from_date"`
+	ToDate   time.Time                `json:"to_date"` // Exclusive
+	GroupBy  entities.JournalGrouping `json:"group_by"`
+}
+
+// AccountingJournalResponse represents the journal entries of a date range
+type AccountingJournalResponse struct {
+	FromDate    time.Time                   `json:"from_date"`
+	ToDate      time.Time                   `json:"to_date"`
+	GroupBy     entities.JournalGrouping    `json:"group_by"`
+	Entries     []*entities.JournalEntry    `json:"entries"`
+	TotalDebit  decimal.Decimal             `json:"total_debit"`
+	TotalCredit decimal.Decimal             `json:"total_credit"`
+	Accounts    entities.AccountingAccounts `json:"-"` // Mapping the entries were posted with, used by exports
+}
+
+// GetAccountMapping retrieves the tenant's account mapping, or the default accounts when the
+// tenant has not configured any
+func (uc *AccountingExportUseCase) GetAccountMapping(ctx context.Context, tenantID uuid.UUID) (*entities.AccountingAccountMapping, error) {
+	mapping, err := uc.mappingRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return entities.NewAccountingAccountMapping(tenantID, uuid.Nil)
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get accounting account mapping")
+		return nil, errors.NewInternalError("failed to get accounting account mapping", err)
+	}
+
+	return mapping, nil
+}
+
+// UpdateAccountMapping replaces the accounts the tenant's journal entries are posted to
+func (uc *AccountingExportUseCase) UpdateAccountMapping(ctx context.Context, tenantID, userID uuid.UUID, accounts entities.AccountingAccounts) (*entities.AccountingAccountMapping, error) {
+	mapping, err := uc.GetAccountMapping(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldAccounts := mapping.Accounts
+	if err := mapping.Configure(accounts, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.mappingRepo.Save(ctx, mapping); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to save accounting account mapping")
+		return nil, errors.NewInternalError("failed to save accounting account mapping", err)
+	}
+
+	// Audit log
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "accounting_account_mapping",
+		ResourceID: mapping.ID.String(),
+		OldValue:   accountingAccountsAudit(oldAccounts),
+		NewValue:   accountingAccountsAudit(mapping.Accounts),
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"user_id":   userID,
+	}).Info("Accounting account mapping updated")
+
+	return mapping, nil
+}
+
+// GetJournal builds the journal entries of the tenant's sales and refunds in a date range, one
+// entry per day or a single entry for the whole range
+func (uc *AccountingExportUseCase) GetJournal(ctx context.Context, tenantID uuid.UUID, req AccountingJournalRequest) (*AccountingJournalResponse, error) {
+	if req.GroupBy == "" {
+		req.GroupBy = entities.JournalGroupingDay
+	}
+	if !req.GroupBy.IsValid() {
+		return nil, errors.NewValidationError("invalid group_by", "group_by must be day or period")
+	}
+	if !req.ToDate.After(req.FromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to must be after from")
+	}
+	if req.ToDate.Sub(req.FromDate) > maxAccountingJournalRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+
+	mapping, err := uc.GetAccountMapping(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := uc.saleRepo.GetAccountingLines(uc.database.ReadOnly(ctx), tenantID, req.FromDate, req.ToDate)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get accounting lines")
+		return nil, errors.NewInternalError("failed to generate accounting journal", err)
+	}
+
+	// Lines come ordered by day
+	days := map[int64]*entities.AccountingTotals{}
+	dates := []time.Time{}
+	for _, line := range lines {
+		totals, ok := days[line.Date.Unix()]
+		if !ok {
+			totals = entities.NewAccountingTotals()
+			days[line.Date.Unix()] = totals
+			dates = append(dates, line.Date)
+		}
+
+		switch line.Type {
+		case repositories.AccountingLinePayment:
+			totals.Payments[line.PaymentMethod] = totals.Payments[line.PaymentMethod].Add(line.Amount)
+		case repositories.AccountingLineTax:
+			totals.Tax = totals.Tax.Add(line.Amount)
+		case repositories.AccountingLineCost:
+			totals.CostOfGoods = totals.CostOfGoods.Add(line.Amount)
+		case repositories.AccountingLineRefund:
+			totals.Refunds[line.PaymentMethod] = totals.Refunds[line.PaymentMethod].Add(line.Amount)
+		case repositories.AccountingLineRefundTax:
+			totals.RefundTax = totals.RefundTax.Add(line.Amount)
+		case repositories.AccountingLineReturnedCost:
+			totals.ReturnedCost = totals.ReturnedCost.Add(line.Amount)
+		}
+	}
+
+	journal := &AccountingJournalResponse{
+		FromDate:    req.FromDate,
+		ToDate:      req.ToDate,
+		GroupBy:     req.GroupBy,
+		Entries:     []*entities.JournalEntry{},
+		TotalDebit:  decimal.Zero,
+		TotalCredit: decimal.Zero,
+		Accounts:    mapping.Accounts,
+	}
+
+	if req.GroupBy == entities.JournalGroupingPeriod {
+		// The period is posted on its last day
+		lastDay := req.ToDate.AddDate(0, 0, -1)
+		period := entities.NewAccountingTotals()
+		for _, totals := range days {
+			period.Add(totals)
+		}
+		reference := fmt.Sprintf("JE-%s-%s", req.FromDate.Format("20060102"), lastDay.Format("20060102"))
+		description := fmt.Sprintf("Sales %s to %s", req.FromDate.Format(journalDateLayout), lastDay.Format(journalDateLayout))
+		if entry := entities.NewJournalEntry(mapping.Accounts, lastDay, reference, description, period); entry != nil {
+			journal.Entries = append(journal.Entries, entry)
+		}
+	} else {
+		for _, date := range dates {
+			reference := "JE-" + date.Format("20060102")
+			description := "Sales " + date.Format(journalDateLayout)
+			if entry := entities.NewJournalEntry(mapping.Accounts, date, reference, description, days[date.Unix()]); entry != nil {
+				journal.Entries = append(journal.Entries, entry)
+			}
+		}
+	}
+
+	for _, entry := range journal.Entries {
+		journal.TotalDebit = journal.TotalDebit.Add(entry.TotalDebit())
+		journal.TotalCredit = journal.TotalCredit.Add(entry.TotalCredit())
+	}
+
+	return journal, nil
+}
+
+// ExportJournal renders the journal entries of a date range as a file for import into
+// accounting software
+func (uc *AccountingExportUseCase) ExportJournal(ctx context.Context, tenantID, userID uuid.UUID, req AccountingJournalRequest, format JournalFormat) ([]byte, error) {
+	journal, err := uc.GetJournal(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := renderJournal(journal, format)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"format":    format,
+			"error":     err.Error(),
+		}).Error("Failed to render accounting journal")
+		return nil, errors.NewInternalError("failed to export accounting journal", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Action:   "export",
+		Resource: "accounting_journal",
+		NewValue: map[string]interface{}{
+			"tenant_id": tenantID,
+			"format":    format,
+			"from_date": journal.FromDate,
+			"to_date":   journal.ToDate,
+			"group_by":  journal.GroupBy,
+			"entries":   len(journal.Entries),
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return data, nil
+}
+
+// renderJournal writes journal entries in an export format
+func renderJournal(journal *AccountingJournalResponse, format JournalFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	switch format {
+	case JournalFormatIIF:
+		// IIF is tab delimited and unquoted; account codes cannot contain tabs or quotes
+		w.Comma = '\t'
+		w.Write([]string{"!TRNS", "TRNSID", "TRNSTYPE", "DATE", "ACCNT", "AMOUNT", "DOCNUM", "MEMO"})
+		w.Write([]string{"!SPL", "SPLID", "TRNSTYPE", "DATE", "ACCNT", "AMOUNT", "DOCNUM", "MEMO"})
+		w.Write([]string{"!ENDTRNS"})
+		for _, entry := range journal.Entries {
+			for i, line := range entry.Lines {
+				kind := "SPL"
+				if i == 0 {
+					kind = "TRNS"
+				}
+				w.Write([]string{kind, "", "GENERAL JOURNAL", entry.Date.Format("01/02/2006"), line.AccountCode,
+					line.Amount().StringFixed(2), entry.Reference, iifText(line.Description)})
+			}
+			w.Write([]string{"ENDTRNS"})
+		}
+
+	case JournalFormatXero:
+		// Debits are positive and credits negative
+		w.Write([]string{"*Narration", "*Date", "Description", "*AccountCode", "*TaxRate", "*Amount"})
+		for _, entry := range journal.Entries {
+			narration := entry.Reference + " " + entry.Description
+			for _, line := range entry.Lines {
+				w.Write([]string{narration, entry.Date.Format("02/01/2006"), line.Description, line.AccountCode,
+					journal.Accounts.ZeroTaxRate, line.Amount().StringFixed(2)})
+			}
+		}
+
+	case JournalFormatAccurate:
+		w.Write([]string{"No. Bukti", "Tanggal", "Keterangan", "No. Akun", "Debit", "Kredit", "Catatan"})
+		for _, entry := range journal.Entries {
+			for _, line := range entry.Lines {
+				w.Write([]string{entry.Reference, entry.Date.Format("02/01/2006"), entry.Description, line.AccountCode,
+					line.Debit.StringFixed(2), line.Credit.StringFixed(2), line.Description})
+			}
+		}
+
+	default:
+		w.Write([]string{"date", "reference", "description", "account_code", "line_description", "debit", "credit"})
+		for _, entry := range journal.Entries {
+			for _, line := range entry.Lines {
+				w.Write([]string{entry.Date.Format(journalDateLayout), entry.Reference, entry.Description, line.AccountCode,
+					line.Description, line.Debit.StringFixed(2), line.Credit.StringFixed(2)})
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	data := buf.Bytes()
+	if format == JournalFormatIIF {
+		// QuickBooks expects Windows line endings
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
+	return data, nil
+}
+
+// accountingAccountsAudit lists mapped accounts for the audit log
+func accountingAccountsAudit(accounts entities.AccountingAccounts) map[string]interface{} {
+	return map[string]interface{}{
+		"sales_revenue":      accounts.SalesRevenue,
+		"sales_returns":      accounts.SalesReturns,
+		"tax_payable":        accounts.TaxPayable,
+		"cost_of_goods_sold": accounts.CostOfGoodsSold,
+		"inventory":          accounts.Inventory,
+		"cash":               accounts.Cash,
+		"card":               accounts.Card,
+		"digital_wallet":     accounts.DigitalWallet,
+		"bank_transfer":      accounts.BankTransfer,
+		"zero_tax_rate":      accounts.ZeroTaxRate,
+	}
+}
+
+// iifText strips the characters that would break an IIF field
+func iifText(value string) string {
+	return strings.NewReplacer("\t", " ", "\"", "'", "\r", " ", "\n", " ").Replace(value)
+}
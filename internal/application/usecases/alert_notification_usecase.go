@@ -0,0 +1,363 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// alertNotificationTimeout bounds each attempt to send an alert to one channel
+	alertNotificationTimeout = 10 * time.Second
+
+	// alertWebhookEvent is the event header alert webhook channels receive
+	alertWebhookEvent = "alert.raised"
+)
+
+// AlertNotificationUseCase handles the tenant's alert channels and routes monitoring alerts
+// to every channel that accepts their severity
+type AlertNotificationUseCase struct {
+	channelRepo  repositories.AlertChannelRepository
+	userRepo     repositories.UserRepository
+	notification ports.NotificationPort
+	sender       ports.WebhookPort
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewAlertNotificationUseCase creates a new alert notification use case
+func NewAlertNotificationUseCase(
+	channelRepo repositories.AlertChannelRepository,
+	userRepo repositories.UserRepository,
+	notification ports.NotificationPort,
+	sender ports.WebhookPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *AlertNotificationUseCase {
+	return &AlertNotificationUseCase{
+		channelRepo:  channelRepo,
+		userRepo:     userRepo,
+		notification: notification,
+		sender:       sender,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// CreateAlertChannelRequest represents create alert channel request. Email channels without
+// recipients notify the tenant's admins.
+type CreateAlertChannelRequest struct {
+	Name        string                    `json:"name" validate:"required"`
+	Type        entities.AlertChannelType `json:"type" validate:"required"`
+	MinSeverity entities.AlertSeverity    `json:"min_severity,omitempty"`
+	Recipients  []string                  `json:"recipients,omitempty"`
+	URL         string                    `json:"url,omitempty"`
+}
+
+// UpdateAlertChannelRequest represents update alert channel request
+type UpdateAlertChannelRequest struct {
+	Name        string                 `json:"name" validate:"required"`
+	MinSeverity entities.AlertSeverity `json:"min_severity,omitempty"`
+	Recipients  []string               `json:"recipients,omitempty"`
+	URL         string                 `json:"url,omitempty"`
+	IsActive    bool                   `json:"is_active"`
+}
+
+// AlertChannelResponse represents an alert channel. The signing secret of a webhook channel
+// is only returned when the channel is created.
+type AlertChannelResponse struct {
+	*entities.AlertChannel
+	Secret string `json:"secret,omitempty"`
+}
+
+// AlertNotice represents a monitoring alert sent to the tenant's alert channels. Webhook
+// channels receive it as their JSON body.
+type AlertNotice struct {
+	ID          uuid.UUID              `json:"id"`
+	TenantID    uuid.UUID              `json:"tenant_id"`
+	Type        string                 `json:"type"`
+	Severity    entities.AlertSeverity `json:"severity"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// ListChannels retrieves the tenant's alert channels
+func (uc *AlertNotificationUseCase) ListChannels(ctx context.Context, tenantID uuid.UUID) ([]*entities.AlertChannel, error) {
+	channels, err := uc.channelRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get alert channels")
+		return nil, errors.NewInternalError("failed to get alert channels", err)
+	}
+
+	return channels, nil
+}
+
+// GetChannel retrieves an alert channel by ID
+func (uc *AlertNotificationUseCase) GetChannel(ctx context.Context, tenantID, channelID uuid.UUID) (*entities.AlertChannel, error) {
+	channel, err := uc.channelRepo.GetByID(ctx, channelID)
+	if err != nil || channel.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("alert channel")
+	}
+
+	return channel, nil
+}
+
+// CreateChannel creates an alert channel for the tenant
+func (uc *AlertNotificationUseCase) CreateChannel(ctx context.Context, tenantID, userID uuid.UUID, req CreateAlertChannelRequest) (*AlertChannelResponse, error) {
+	channel, err := entities.NewAlertChannel(tenantID, req.Name, req.Type, req.MinSeverity, req.Recipients, req.URL, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.channelRepo.Create(ctx, channel); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create alert channel")
+		return nil, errors.NewInternalError("failed to create alert channel", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "alert_channel",
+		ResourceID: channel.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":         channel.Name,
+			"type":         channel.Type,
+			"min_severity": channel.MinSeverity,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"channel_id": channel.ID,
+		"type":       channel.Type,
+		"user_id":    userID,
+	}).Info("Alert channel created")
+
+	return &AlertChannelResponse{AlertChannel: channel, Secret: channel.Secret}, nil
+}
+
+// UpdateChannel updates an alert channel's settings
+func (uc *AlertNotificationUseCase) UpdateChannel(ctx context.Context, tenantID, userID, channelID uuid.UUID, req UpdateAlertChannelRequest) (*entities.AlertChannel, error) {
+	channel, err := uc.GetChannel(ctx, tenantID, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := channel.Update(req.Name, req.MinSeverity, req.Recipients, req.URL, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := uc.channelRepo.Update(ctx, channel); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"channel_id": channelID,
+			"error":      err.Error(),
+		}).Error("Failed to update alert channel")
+		return nil, errors.NewInternalError("failed to update alert channel", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "alert_channel",
+		ResourceID: channel.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":         channel.Name,
+			"min_severity": channel.MinSeverity,
+			"is_active":    channel.IsActive,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return channel, nil
+}
+
+// DeleteChannel deletes an alert channel
+func (uc *AlertNotificationUseCase) DeleteChannel(ctx context.Context, tenantID, userID, channelID uuid.UUID) error {
+	channel, err := uc.GetChannel(ctx, tenantID, channelID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.channelRepo.Delete(ctx, channel.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"channel_id": channelID,
+			"error":      err.Error(),
+		}).Error("Failed to delete alert channel")
+		return errors.NewInternalError("failed to delete alert channel", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "alert_channel",
+		ResourceID: channel.ID.String(),
+		OldValue: map[string]interface{}{
+			"name": channel.Name,
+			"type": channel.Type,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"channel_id": channel.ID,
+		"user_id":    userID,
+	}).Info("Alert channel deleted")
+
+	return nil
+}
+
+// Notify sends an alert to every active channel of its tenant that accepts its severity. A
+// channel that cannot be reached does not stop the others; the outcome is recorded on each
+// channel.
+func (uc *AlertNotificationUseCase) Notify(ctx context.Context, notice AlertNotice) {
+	channels, err := uc.channelRepo.GetActiveByTenant(ctx, notice.TenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": notice.TenantID,
+			"alert_id":  notice.ID,
+			"error":     err.Error(),
+		}).Error("Failed to get alert channels")
+		return
+	}
+
+	for _, channel := range channels {
+		if !channel.Accepts(notice.Severity) {
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, alertNotificationTimeout)
+		sendErr := uc.send(sendCtx, channel, notice)
+		cancel()
+
+		channel.RecordNotification(time.Now(), sendErr)
+		if err := uc.channelRepo.RecordNotification(ctx, channel); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"channel_id": channel.ID,
+				"error":      err.Error(),
+			}).Warn("Failed to record alert notification")
+		}
+
+		if sendErr != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id":  notice.TenantID,
+				"alert_id":   notice.ID,
+				"channel_id": channel.ID,
+				"type":       channel.Type,
+				"error":      sendErr.Error(),
+			}).Warn("Failed to send alert notification")
+		}
+	}
+}
+
+// send delivers an alert to one channel
+func (uc *AlertNotificationUseCase) send(ctx context.Context, channel *entities.AlertChannel, notice AlertNotice) error {
+	switch channel.Type {
+	case entities.AlertChannelEmail:
+		recipients := channel.Recipients
+		if len(recipients) == 0 {
+			admins, err := uc.userRepo.GetActiveEmailsByRole(ctx, channel.TenantID, entities.RoleAdmin)
+			if err != nil {
+				return fmt.Errorf("failed to get tenant admins: %w", err)
+			}
+			recipients = admins
+		}
+		if len(recipients) == 0 {
+			return fmt.Errorf("the tenant has no active admins to email")
+		}
+
+		priority := "normal"
+		if notice.Severity == entities.AlertSeverityError || notice.Severity == entities.AlertSeverityCritical {
+			priority = "high"
+		}
+
+		return uc.notification.SendEmail(ctx, ports.EmailNotification{
+			To:       recipients,
+			Subject:  fmt.Sprintf("[%s] %s", strings.ToUpper(string(notice.Severity)), notice.Title),
+			Body:     alertNoticeText(notice),
+			Priority: priority,
+		})
+	case entities.AlertChannelSlack:
+		body, err := json.Marshal(map[string]string{
+			"text": fmt.Sprintf("*[%s] %s*\n%s", strings.ToUpper(string(notice.Severity)), notice.Title, notice.Description),
+		})
+		if err != nil {
+			return err
+		}
+		return uc.post(ctx, ports.WebhookRequest{URL: channel.URL, Body: body})
+	case entities.AlertChannelWebhook:
+		body, err := json.Marshal(notice)
+		if err != nil {
+			return err
+		}
+		return uc.post(ctx, ports.WebhookRequest{
+			URL: channel.URL,
+			Headers: map[string]string{
+				entities.WebhookEventHeader:     alertWebhookEvent,
+				entities.WebhookSignatureHeader: entities.SignWebhookPayload(channel.Secret, time.Now(), body),
+			},
+			Body: body,
+		})
+	default:
+		return fmt.Errorf("unknown alert channel type %q", channel.Type)
+	}
+}
+
+// post sends a webhook request, treating any response other than 2xx as a failure
+func (uc *AlertNotificationUseCase) post(ctx context.Context, request ports.WebhookRequest) error {
+	status, err := uc.sender.Post(ctx, request)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("unexpected response status %d", status)
+	}
+	return nil
+}
+
+// alertNoticeText renders an alert as the plain text body of an email
+func alertNoticeText(notice AlertNotice) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", notice.Description)
+	fmt.Fprintf(&body, "Severity: %s\n", notice.Severity)
+	fmt.Fprintf(&body, "Type: %s\n", notice.Type)
+	fmt.Fprintf(&body, "Raised at: %s\n", notice.CreatedAt.UTC().Format(time.RFC3339))
+
+	keys := make([]string, 0, len(notice.Metadata))
+	for key := range notice.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&body, "%s: %v\n", key, notice.Metadata[key])
+	}
+
+	fmt.Fprintf(&body, "\nAlert ID: %s\n", notice.ID)
+	return body.String()
+}
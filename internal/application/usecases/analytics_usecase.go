@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// analyticsDateLayout is the date format of analytics query date ranges
+	analyticsDateLayout = "2006-01-02"
+	// slowAnalyticsQueryThreshold is how long an analytics query may run before it is logged as slow
+	slowAnalyticsQueryThreshold = 2 * time.Second
+)
+
+// AnalyticsUseCase runs declarative analytics queries for dashboard charts. Specs are checked
+// against a fixed catalog of dimensions and measures, so new charts do not need new endpoints.
+type AnalyticsUseCase struct {
+	analyticsRepo repositories.AnalyticsRepository
+	logger        logger.Logger
+}
+
+// NewAnalyticsUseCase creates a new analytics use case
+func NewAnalyticsUseCase(
+	analyticsRepo repositories.AnalyticsRepository,
+	logger logger.Logger,
+) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		analyticsRepo: analyticsRepo,
+		logger:        logger,
+	}
+}
+
+// AnalyticsCatalogResponse represents the sources, dimensions and measures queries can use
+type AnalyticsCatalogResponse struct {
+	Sources          []entities.AnalyticsSourceCatalog `json:"sources"`
+	DateGrains       []entities.AnalyticsDateGrain     `json:"date_grains"`
+	MaxDimensions    int                               `json:"max_dimensions"`
+	MaxMeasures      int                               `json:"max_measures"`
+	MaxFilters       int                               `json:"max_filters"`
+	MaxRowLimit      int                               `json:"max_row_limit"`
+	MaxDateRangeDays int                               `json:"max_date_range_days"`
+}
+
+// AnalyticsQueryRequest represents an analytics query request. Dates are YYYY-MM-DD in the
+// server's time zone and both ends are inclusive.
+type AnalyticsQueryRequest struct {
+	Source     entities.AnalyticsSource    `json:"source" validate:"required"`
+	Dimensions []string                    `json:"dimensions,omitempty"`
+	Measures   []string                    `json:"measures" validate:"required"`
+	Filters    []entities.AnalyticsFilter  `json:"filters,omitempty"`
+	DateGrain  entities.AnalyticsDateGrain `json:"date_grain,omitempty"`
+	FromDate   string                      `json:"from_date" validate:"required"`
+	ToDate     string                      `json:"to_date" validate:"required"`
+	OrderBy    string                      `json:"order_by,omitempty"`
+	OrderDir   string                      `json:"order_dir,omitempty"`
+	Limit      int                         `json:"limit,omitempty"`
+}
+
+// AnalyticsQueryResponse represents the result of an analytics query along with the normalized spec
+type AnalyticsQueryResponse struct {
+	Query entities.AnalyticsQuery `json:"query"`
+	*repositories.AnalyticsResult
+}
+
+// GetCatalog returns the sources, dimensions and measures analytics queries can use
+func (uc *AnalyticsUseCase) GetCatalog() *AnalyticsCatalogResponse {
+	return &AnalyticsCatalogResponse{
+		Sources: entities.AnalyticsCatalog(),
+		DateGrains: []entities.AnalyticsDateGrain{
+			entities.AnalyticsDateGrainHour,
+			entities.AnalyticsDateGrainDay,
+			entities.AnalyticsDateGrainWeek,
+			entities.AnalyticsDateGrainMonth,
+		},
+		MaxDimensions:    entities.MaxAnalyticsDimensions,
+		MaxMeasures:      entities.MaxAnalyticsMeasures,
+		MaxFilters:       entities.MaxAnalyticsFilters,
+		MaxRowLimit:      entities.MaxAnalyticsRowLimit,
+		MaxDateRangeDays: int(entities.MaxAnalyticsDateRange / (24 * time.Hour)),
+	}
+}
+
+// RunQuery validates an analytics query spec and runs it against the tenant's completed sales
+func (uc *AnalyticsUseCase) RunQuery(ctx context.Context, tenantID uuid.UUID, req AnalyticsQueryRequest) (*AnalyticsQueryResponse, error) {
+	fromDate, err := time.ParseInLocation(analyticsDateLayout, req.FromDate, time.Local)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid from_date", "from_date must be in YYYY-MM-DD format")
+	}
+	toDate, err := time.ParseInLocation(analyticsDateLayout, req.ToDate, time.Local)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid to_date", "to_date must be in YYYY-MM-DD format")
+	}
+
+	query := entities.AnalyticsQuery{
+		Source:     req.Source,
+		Dimensions: req.Dimensions,
+		Measures:   req.Measures,
+		Filters:    req.Filters,
+		DateGrain:  req.DateGrain,
+		DateFrom:   fromDate,
+		DateTo:     toDate.AddDate(0, 0, 1),
+		OrderBy:    req.OrderBy,
+		OrderDir:   req.OrderDir,
+		Limit:      req.Limit,
+	}
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	result, err := uc.analyticsRepo.Query(ctx, tenantID, query)
+	if err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"source":    query.Source,
+			"error":     err.Error(),
+		}).Error("Failed to run analytics query")
+		return nil, errors.NewInternalError("failed to run analytics query", err)
+	}
+
+	if elapsed := time.Since(started); elapsed > slowAnalyticsQueryThreshold {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":   tenantID,
+			"source":      query.Source,
+			"dimensions":  query.Dimensions,
+			"measures":    query.Measures,
+			"date_grain":  query.DateGrain,
+			"duration_ms": elapsed.Milliseconds(),
+		}).Warn("Slow analytics query")
+	}
+
+	return &AnalyticsQueryResponse{
+		Query:           query,
+		AnalyticsResult: result,
+	}, nil
+}
@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// apiKeyCacheTTL bounds how long a revoked key can keep working on other instances
+	apiKeyCacheTTL = 5 * time.Minute
+	// apiKeyUsageInterval throttles last-used tracking so busy integrations do not write on every request
+	apiKeyUsageInterval = time.Minute
+)
+
+// APIKeyUseCase manages API keys and authenticates the external systems that call the API with
+// them. Authentication is answered from the cache so the hot path does not touch the database.
+type APIKeyUseCase struct {
+	apiKeyRepo repositories.APIKeyRepository
+	cache      ports.CachePort
+	audit      ports.AuditPort
+	logger     logger.Logger
+}
+
+// NewAPIKeyUseCase creates a new API key use case
+func NewAPIKeyUseCase(
+	apiKeyRepo repositories.APIKeyRepository,
+	cache ports.CachePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		cache:      cache,
+		audit:      audit,
+		logger:     logger,
+	}
+}
+
+// CreateAPIKeyRequest represents create API key request
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateAPIKeyResponse represents a newly created API key.
+// The key is only ever returned here.
+type CreateAPIKeyResponse struct {
+	APIKey *entities.APIKey `json:"api_key"`
+	Key    string           `json:"key"`
+}
+
+// APIKeyPrincipal is the caller behind an authenticated API key. Requests act as the user who
+// created the key, limited to the key's scopes.
+type APIKeyPrincipal struct {
+	KeyID     uuid.UUID  `json:"key_id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Allows checks if the key grants an action on a resource
+func (p *APIKeyPrincipal) Allows(resource, action string) bool {
+	return entities.APIKeyScopesAllow(p.Scopes, resource, action)
+}
+
+// Authenticate resolves an API key to the principal it acts as and records its use
+func (uc *APIKeyUseCase) Authenticate(ctx context.Context, key, ip string) (*APIKeyPrincipal, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.NewUnauthorizedError("API key is required")
+	}
+
+	keyHash := entities.HashAPIKey(key)
+	cacheKey := apiKeyCacheKey(keyHash)
+
+	var principal APIKeyPrincipal
+	if err := uc.cache.Get(ctx, cacheKey, &principal); err != nil || principal.TenantID == uuid.Nil {
+		apiKey, err := uc.apiKeyRepo.GetByKeyHash(ctx, keyHash)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+				return nil, errors.NewUnauthorizedError("invalid API key")
+			}
+			uc.logger.WithField("error", err.Error()).Error("Failed to get API key")
+			return nil, errors.NewInternalError("failed to authenticate API key", err)
+		}
+
+		if !apiKey.IsActive {
+			return nil, errors.NewUnauthorizedError("API key has been revoked")
+		}
+
+		principal = APIKeyPrincipal{
+			KeyID:     apiKey.ID,
+			TenantID:  apiKey.TenantID,
+			CreatedBy: apiKey.CreatedBy,
+			Scopes:    apiKey.Scopes,
+			ExpiresAt: apiKey.ExpiresAt,
+		}
+		if err := uc.cache.Set(ctx, cacheKey, principal, apiKeyCacheTTL); err != nil {
+			uc.logger.WithField("error", err.Error()).Warn("Failed to cache API key")
+		}
+	}
+
+	now := time.Now()
+	if principal.ExpiresAt != nil && !now.Before(*principal.ExpiresAt) {
+		return nil, errors.NewUnauthorizedError("API key has expired")
+	}
+
+	uc.recordUse(ctx, principal.KeyID, now, ip)
+
+	return &principal, nil
+}
+
+// CreateAPIKey creates a new API key and issues its key
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, tenantID uuid.UUID, req CreateAPIKeyRequest, createdBy uuid.UUID) (*CreateAPIKeyResponse, error) {
+	apiKey, key, err := entities.NewAPIKey(tenantID, req.Name, req.Scopes, req.ExpiresAt, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"name":  req.Name,
+			"error": err.Error(),
+		}).Error("Failed to create API key")
+		return nil, errors.NewInternalError("failed to create API key", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     createdBy,
+		Action:     "create",
+		Resource:   "api_key",
+		ResourceID: apiKey.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":       apiKey.Name,
+			"key_prefix": apiKey.KeyPrefix,
+			"scopes":     apiKey.Scopes,
+			"expires_at": apiKey.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"api_key_id": apiKey.ID,
+		"name":       apiKey.Name,
+	}).Info("API key created")
+
+	return &CreateAPIKeyResponse{APIKey: apiKey, Key: key}, nil
+}
+
+// ListAPIKeys lists the API keys of a tenant
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context, tenantID uuid.UUID) ([]*entities.APIKey, error) {
+	apiKeys, err := uc.apiKeyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list API keys")
+		return nil, errors.NewInternalError("failed to list API keys", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey revokes an API key so it can no longer be used
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, tenantID, apiKeyID, revokedBy uuid.UUID) (*entities.APIKey, error) {
+	apiKey, err := uc.apiKeyRepo.GetByID(ctx, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("API key")
+	}
+
+	if err := apiKey.Revoke(revokedBy); err != nil {
+		return nil, err
+	}
+
+	if err := uc.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"api_key_id": apiKeyID,
+			"error":      err.Error(),
+		}).Error("Failed to revoke API key")
+		return nil, errors.NewInternalError("failed to revoke API key", err)
+	}
+
+	if err := uc.cache.Delete(ctx, apiKeyCacheKey(apiKey.KeyHash)); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to evict API key from cache")
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     revokedBy,
+		Action:     "revoke",
+		Resource:   "api_key",
+		ResourceID: apiKey.ID.String(),
+		NewValue: map[string]interface{}{
+			"is_active":  apiKey.IsActive,
+			"revoked_at": apiKey.RevokedAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return apiKey, nil
+}
+
+// Helper methods
+
+// recordUse records the use of an API key at most once per apiKeyUsageInterval. Failures are
+// only logged, last-used tracking must never fail a request.
+func (uc *APIKeyUseCase) recordUse(ctx context.Context, keyID uuid.UUID, at time.Time, ip string) {
+	usageKey := apiKeyUsageCacheKey(keyID)
+
+	var lastRecorded time.Time
+	if err := uc.cache.Get(ctx, usageKey, &lastRecorded); err == nil && at.Sub(lastRecorded) < apiKeyUsageInterval {
+		return
+	}
+
+	if err := uc.apiKeyRepo.RecordUse(ctx, keyID, at, ip); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"api_key_id": keyID,
+			"error":      err.Error(),
+		}).Warn("Failed to record API key use")
+		return
+	}
+
+	if err := uc.cache.Set(ctx, usageKey, at, apiKeyUsageInterval); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to cache API key use")
+	}
+}
+
+func apiKeyCacheKey(keyHash string) string {
+	return fmt.Sprintf("api_key:%s", keyHash)
+}
+
+func apiKeyUsageCacheKey(keyID uuid.UUID) string {
+	return fmt.Sprintf("api_key_usage:%s", keyID)
+}
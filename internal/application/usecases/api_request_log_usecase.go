@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// APIRequestLogUseCase handles storing and querying sampled API request captures
+type APIRequestLogUseCase struct {
+	logRepo repositories.APIRequestLogRepository
+	logger  logger.Logger
+}
+
+// NewAPIRequestLogUseCase creates a new API request log use case
+func NewAPIRequestLogUseCase(logRepo repositories.APIRequestLogRepository, logger logger.Logger) *APIRequestLogUseCase {
+	return &APIRequestLogUseCase{
+		logRepo: logRepo,
+		logger:  logger,
+	}
+}
+
+// Record stores a captured API request. Bodies must already be redacted by the caller.
+func (uc *APIRequestLogUseCase) Record(ctx context.Context, log *entities.APIRequestLog) error {
+	if err := uc.logRepo.Create(ctx, log); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"request_id": log.RequestID,
+			"error":      err.Error(),
+		}).Error("Failed to store API request log")
+		return errors.NewInternalError("failed to store API request log", err)
+	}
+
+	return nil
+}
+
+// GetByRequestID retrieves a captured API request by its request ID
+func (uc *APIRequestLogUseCase) GetByRequestID(ctx context.Context, requestID string) (*entities.APIRequestLog, error) {
+	requestID = strings.TrimSpace(requestID)
+	if requestID == "" {
+		return nil, errors.NewValidationError("request ID is required", "request_id cannot be empty")
+	}
+
+	log, err := uc.logRepo.GetByRequestID(ctx, requestID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Error("Failed to get API request log")
+		return nil, errors.NewInternalError("failed to get API request log", err)
+	}
+
+	return log, nil
+}
+
+// PurgeExpired deletes API request logs past their retention period. It is run periodically
+// by the scheduler.
+func (uc *APIRequestLogUseCase) PurgeExpired(ctx context.Context) error {
+	deleted, err := uc.logRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to purge API request logs: %w", err)
+	}
+
+	if deleted > 0 {
+		uc.logger.WithField("deleted", deleted).Info("Expired API request logs purged")
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// AttachmentUseCase handles files attached to invoices and sales. The content is kept in file
+// storage and only served through the API, so downloads are checked like any other request.
+type AttachmentUseCase struct {
+	attachmentRepo repositories.AttachmentRepository
+	invoiceRepo    repositories.InvoiceRepository
+	saleRepo       repositories.SaleRepository
+	fileStorage    ports.FileStoragePort
+	audit          ports.AuditPort
+	logger         logger.Logger
+}
+
+// NewAttachmentUseCase creates a new attachment use case
+func NewAttachmentUseCase(
+	attachmentRepo repositories.AttachmentRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	saleRepo repositories.SaleRepository,
+	fileStorage ports.FileStoragePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		invoiceRepo:    invoiceRepo,
+		saleRepo:       saleRepo,
+		fileStorage:    fileStorage,
+		audit:          audit,
+		logger:         logger,
+	}
+}
+
+// UploadAttachment attaches a file to one of the tenant's invoices or sales
+func (uc *AttachmentUseCase) UploadAttachment(ctx context.Context, tenantID, userID uuid.UUID, ownerType entities.AttachmentOwnerType, ownerID uuid.UUID, fileName string, data []byte) (*entities.Attachment, error) {
+	if err := uc.checkOwner(ctx, tenantID, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := entities.NewAttachment(tenantID, ownerType, ownerID, fileName, data, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.fileStorage.Store(ctx, attachment.StoragePath, data); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"owner_type": ownerType,
+			"owner_id":   ownerID,
+			"error":      err.Error(),
+		}).Error("Failed to store attachment")
+		return nil, errors.NewInternalError("failed to store attachment", err)
+	}
+
+	if err := uc.attachmentRepo.Create(ctx, attachment); err != nil {
+		// Do not leave the stored file behind without a record pointing to it
+		if deleteErr := uc.fileStorage.Delete(ctx, attachment.StoragePath); deleteErr != nil {
+			uc.logger.WithField("error", deleteErr.Error()).Error("Failed to delete orphaned attachment file")
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create attachment")
+		return nil, errors.NewInternalError("failed to create attachment", err)
+	}
+
+	uc.logAudit(ctx, userID, "create", attachment)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"owner_type":    ownerType,
+		"owner_id":      ownerID,
+		"size":          attachment.Size,
+		"user_id":       userID,
+	}).Info("Attachment uploaded")
+
+	return attachment, nil
+}
+
+// ListAttachments returns the files attached to one of the tenant's invoices or sales
+func (uc *AttachmentUseCase) ListAttachments(ctx context.Context, tenantID uuid.UUID, ownerType entities.AttachmentOwnerType, ownerID uuid.UUID) ([]*entities.Attachment, error) {
+	if err := uc.checkOwner(ctx, tenantID, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := uc.attachmentRepo.ListByOwner(ctx, ownerType, ownerID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list attachments")
+		return nil, errors.NewInternalError("failed to list attachments", err)
+	}
+
+	return attachments, nil
+}
+
+// DownloadAttachment returns an attachment with its content
+func (uc *AttachmentUseCase) DownloadAttachment(ctx context.Context, tenantID uuid.UUID, ownerType entities.AttachmentOwnerType, ownerID, id uuid.UUID) (*entities.Attachment, []byte, error) {
+	attachment, err := uc.getAttachment(ctx, tenantID, ownerType, ownerID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := uc.fileStorage.Retrieve(ctx, attachment.StoragePath)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"attachment_id": attachment.ID,
+			"error":         err.Error(),
+		}).Error("Failed to retrieve attachment")
+		return nil, nil, errors.NewInternalError("failed to retrieve attachment", err)
+	}
+
+	return attachment, data, nil
+}
+
+// DeleteAttachment removes a file from an invoice or sale
+func (uc *AttachmentUseCase) DeleteAttachment(ctx context.Context, tenantID, userID uuid.UUID, ownerType entities.AttachmentOwnerType, ownerID, id uuid.UUID) error {
+	attachment, err := uc.getAttachment(ctx, tenantID, ownerType, ownerID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to delete attachment")
+		return errors.NewInternalError("failed to delete attachment", err)
+	}
+
+	// The record is gone, so a file left behind is only logged
+	if err := uc.fileStorage.Delete(ctx, attachment.StoragePath); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"attachment_id": attachment.ID,
+			"storage_path":  attachment.StoragePath,
+			"error":         err.Error(),
+		}).Error("Failed to delete attachment file")
+	}
+
+	uc.logAudit(ctx, userID, "delete", attachment)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"user_id":       userID,
+	}).Info("Attachment deleted")
+
+	return nil
+}
+
+// getAttachment returns an attachment of one of the tenant's invoices or sales
+func (uc *AttachmentUseCase) getAttachment(ctx context.Context, tenantID uuid.UUID, ownerType entities.AttachmentOwnerType, ownerID, id uuid.UUID) (*entities.Attachment, error) {
+	attachment, err := uc.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get attachment")
+		return nil, errors.NewInternalError("failed to get attachment", err)
+	}
+	if attachment.TenantID != tenantID || !attachment.BelongsTo(ownerType, ownerID) {
+		return nil, errors.NewNotFoundError("attachment")
+	}
+
+	return attachment, nil
+}
+
+// checkOwner checks the invoice or sale files are attached to is the tenant's
+func (uc *AttachmentUseCase) checkOwner(ctx context.Context, tenantID uuid.UUID, ownerType entities.AttachmentOwnerType, ownerID uuid.UUID) error {
+	switch ownerType {
+	case entities.AttachmentOwnerInvoice:
+		invoice, err := uc.invoiceRepo.GetByID(ctx, ownerID)
+		if err != nil || invoice.TenantID != tenantID {
+			return errors.NewNotFoundError("invoice")
+		}
+	case entities.AttachmentOwnerSale:
+		sale, err := uc.saleRepo.GetByID(ctx, ownerID)
+		if err != nil || sale.TenantID != tenantID {
+			return errors.NewNotFoundError("sale")
+		}
+	default:
+		return entities.ValidateAttachmentOwnerType(ownerType)
+	}
+	return nil
+}
+
+func (uc *AttachmentUseCase) logAudit(ctx context.Context, userID uuid.UUID, action string, attachment *entities.Attachment) {
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "attachment",
+		ResourceID: attachment.ID.String(),
+		NewValue: map[string]interface{}{
+			"owner_type":   attachment.OwnerType,
+			"owner_id":     attachment.OwnerID,
+			"file_name":    attachment.FileName,
+			"content_type": attachment.ContentType,
+			"size":         attachment.Size,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+}
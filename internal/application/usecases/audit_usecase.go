@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// auditChainPurgeMargin is added to the audit log retention cutoff before verifying, so events
+// purged while a verification runs are not reported as removed
+const auditChainPurgeMargin = time.Hour
+
+// AuditUseCase handles reading and verifying the audit trail
+type AuditUseCase struct {
+	audit      ports.AuditPort
+	userRepo   repositories.UserRepository
+	policyRepo repositories.RetentionPolicyRepository
+	logger     logger.Logger
+}
+
+// NewAuditUseCase creates a new audit use case
+func NewAuditUseCase(
+	audit ports.AuditPort,
+	userRepo repositories.UserRepository,
+	policyRepo repositories.RetentionPolicyRepository,
+	logger logger.Logger,
+) *AuditUseCase {
+	return &AuditUseCase{
+		audit:      audit,
+		userRepo:   userRepo,
+		policyRepo: policyRepo,
+		logger:     logger,
+	}
+}
+
+// AuditHistoryEntry represents a single change in an entity's history
+type AuditHistoryEntry struct {
+	ID        uuid.UUID      `json:"id"`
+	Action    string         `json:"action"`
+	UserID    uuid.UUID      `json:"user_id"`
+	Username  string         `json:"username,omitempty"`
+	Changes   []audit.Change `json:"changes,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// AuditHistoryResponse represents an entity's audit history response
+type AuditHistoryResponse struct {
+	Resource   string               `json:"resource"`
+	ResourceID string               `json:"resource_id"`
+	Entries    []*AuditHistoryEntry `json:"entries"`
+	Pagination utils.PaginationInfo `json:"pagination"`
+}
+
+// GetResourceHistory retrieves who changed what and when for a single entity of the tenant, newest first
+func (uc *AuditUseCase) GetResourceHistory(ctx context.Context, tenantID uuid.UUID, resource, resourceID string, pagination utils.PaginationInfo) (*AuditHistoryResponse, error) {
+	success := true
+	filter := ports.AuditFilter{
+		TenantID:   &tenantID,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Success:    &success,
+		OrderBy:    "timestamp",
+		OrderDir:   "DESC",
+	}
+
+	events, paginationResult, err := uc.audit.Query(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"resource":    resource,
+			"resource_id": resourceID,
+			"error":       err.Error(),
+		}).Error("Failed to query audit history")
+		return nil, errors.NewInternalError("failed to get audit history", err)
+	}
+
+	usernames := make(map[uuid.UUID]string)
+	entries := make([]*AuditHistoryEntry, len(events))
+	for i, event := range events {
+		if _, ok := usernames[event.UserID]; !ok {
+			usernames[event.UserID] = uc.lookupUsername(ctx, event.UserID)
+		}
+
+		entries[i] = &AuditHistoryEntry{
+			ID:        event.ID,
+			Action:    event.Action,
+			UserID:    event.UserID,
+			Username:  usernames[event.UserID],
+			Changes:   event.Changes,
+			Timestamp: event.Timestamp,
+		}
+	}
+
+	return &AuditHistoryResponse{
+		Resource:   resource,
+		ResourceID: resourceID,
+		Entries:    entries,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// VerifyAuditTrail checks the tenant's audit trail for events that were altered, removed or
+// inserted after they were logged, from the given time on or from its first event. Events an
+// enabled audit log retention policy may have purged are left out.
+func (uc *AuditUseCase) VerifyAuditTrail(ctx context.Context, tenantID uuid.UUID, from *time.Time) (*ports.AuditChainVerification, error) {
+	var start time.Time
+	if from != nil {
+		start = *from
+	}
+
+	policies, err := uc.policyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get retention policies")
+		return nil, errors.NewInternalError("failed to verify audit trail", err)
+	}
+	for _, policy := range policies {
+		if policy.Entity != entities.RetentionEntityAuditLogs || !policy.IsEnabled {
+			continue
+		}
+		if cutoff := policy.Cutoff(time.Now()).Add(auditChainPurgeMargin); cutoff.After(start) {
+			start = cutoff
+		}
+	}
+
+	verification, err := uc.audit.VerifyChain(ctx, tenantID, start)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to verify audit trail")
+		return nil, errors.NewInternalError("failed to verify audit trail", err)
+	}
+
+	if !verification.IsIntact {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"from":      start,
+			"breaks":    len(verification.Breaks),
+		}).Warn("Audit trail failed verification")
+	}
+
+	return verification, nil
+}
+
+// lookupUsername resolves a user's username, returning an empty string for unknown or deleted users
+func (uc *AuditUseCase) lookupUsername(ctx context.Context, userID uuid.UUID) string {
+	if userID == uuid.Nil {
+		return ""
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ""
+	}
+
+	return user.Username
+}
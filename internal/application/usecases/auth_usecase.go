@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,46 +15,75 @@ import (
 	"github.com/nicklaros/adol/pkg/logger"
 )
 
+const (
+	// userSessionCacheTTL bounds how long a session is checked from the cache. Revoking a session
+	// evicts it, so revocation does not wait for the TTL.
+	userSessionCacheTTL = 5 * time.Minute
+	// userSessionRetention is how long expired and revoked sessions are kept before being purged
+	userSessionRetention = 30 * 24 * time.Hour
+	// loginHistoryWindow is how far back the networks a user signed in from count as familiar
+	loginHistoryWindow = 90 * 24 * time.Hour
+	// repeatedLockoutAlertThreshold is the lockouts in a row from which alerts are critical
+	repeatedLockoutAlertThreshold = 3
+)
+
 // AuthUseCase handles authentication-related operations
 type AuthUseCase struct {
 	userRepo    repositories.UserRepository
+	users       *UserUseCase
+	sessionRepo repositories.UserSessionRepository
+	lockoutRepo repositories.LoginLockoutRepository
 	authService services.AuthService
 	jwtService  services.JWTService
 	cache       ports.CachePort
 	audit       ports.AuditPort
+	alerts      ports.SecurityAlertPort
+	lockout     entities.LoginLockoutPolicy
 	logger      logger.Logger
 }
 
 // NewAuthUseCase creates a new authentication use case
 func NewAuthUseCase(
 	userRepo repositories.UserRepository,
+	users *UserUseCase,
+	sessionRepo repositories.UserSessionRepository,
+	lockoutRepo repositories.LoginLockoutRepository,
 	authService services.AuthService,
 	jwtService services.JWTService,
 	cache ports.CachePort,
 	audit ports.AuditPort,
+	alerts ports.SecurityAlertPort,
+	lockout entities.LoginLockoutPolicy,
 	logger logger.Logger,
 ) *AuthUseCase {
 	return &AuthUseCase{
 		userRepo:    userRepo,
+		users:       users,
+		sessionRepo: sessionRepo,
+		lockoutRepo: lockoutRepo,
 		authService: authService,
 		jwtService:  jwtService,
 		cache:       cache,
 		audit:       audit,
+		alerts:      alerts,
+		lockout:     lockout,
 		logger:      logger,
 	}
 }
 
 // LoginRequest represents login request
 type LoginRequest struct {
-	Username  string `json:"username" validate:"required"`
-	Password  string `json:"password" validate:"required"`
-	IPAddress string `json:"ip_address,omitempty"`
-	UserAgent string `json:"user_agent,omitempty"`
+	Username   string `json:"username" validate:"required"`
+	Password   string `json:"password" validate:"required"`
+	DeviceName string `json:"device_name,omitempty"`
+	IPAddress  string `json:"ip_address,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
 }
 
 // LoginResponse represents login response
 type LoginResponse struct {
 	User         *entities.User `json:"user"`
+	SessionID    uuid.UUID      `json:"session_id"`
 	AccessToken  string         `json:"access_token"`
 	RefreshToken string         `json:"refresh_token"`
 	ExpiresAt    time.Time      `json:"expires_at"`
@@ -63,6 +93,19 @@ type LoginResponse struct {
 // RefreshTokenRequest represents refresh token request
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
+	IPAddress    string `json:"ip_address,omitempty"`
+}
+
+// UserSessionResponse represents a session of a user, marking the one the request was made from
+type UserSessionResponse struct {
+	*entities.UserSession
+	IsCurrent bool `json:"is_current"`
+}
+
+// userSessionEntry is the cached view of an active session
+type userSessionEntry struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // ChangePasswordRequest represents change password request
@@ -71,6 +114,16 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
+// ChangeExpiredPasswordRequest represents the password change of a user who cannot sign in
+// until they change their password
+type ChangeExpiredPasswordRequest struct {
+	Username    string `json:"username" validate:"required"`
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+	IPAddress   string `json:"ip_address,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+}
+
 // ResetPasswordRequest represents reset password request
 type ResetPasswordRequest struct {
 	UserID      uuid.UUID `json:"user_id" validate:"required"`
@@ -106,20 +159,18 @@ func (uc *AuthUseCase) Login(ctx context.Context, req LoginRequest) (*LoginRespo
 		return nil, errors.NewUnauthorizedError("invalid credentials")
 	}
 
-	// Check if user is active
-	if !user.IsActive() {
-		uc.logger.WithField("user_id", user.ID).Warn("Login attempt with inactive user")
-		return nil, errors.NewForbiddenError("user account is not active")
+	if err := uc.VerifyCredentials(ctx, user, req); err != nil {
+		return nil, err
 	}
 
-	// Validate password
-	if !user.ValidatePassword(req.Password) {
-		uc.logger.WithField("user_id", user.ID).Warn("Login attempt with invalid password")
-		return nil, errors.NewUnauthorizedError("invalid credentials")
+	// Start a session for the device and bind the tokens to it
+	session, err := entities.NewUserSession(user.ID, user.TenantID, req.DeviceName, req.UserAgent, req.IPAddress)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate JWT tokens
-	tokenPair, err := uc.jwtService.GenerateTokenPair(user)
+	tokenPair, err := uc.jwtService.GenerateTokenPair(user, session.ID)
 	if err != nil {
 		uc.logger.WithFields(map[string]interface{}{
 			"user_id": user.ID,
@@ -128,6 +179,17 @@ func (uc *AuthUseCase) Login(ctx context.Context, req LoginRequest) (*LoginRespo
 		return nil, errors.NewInternalError("failed to generate tokens", err)
 	}
 
+	if err := session.IssueRefreshToken(tokenPair.RefreshToken, tokenPair.RefreshExpiry, ""); err != nil {
+		return nil, err
+	}
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Error("Failed to create user session")
+		return nil, errors.NewInternalError("failed to create session", err)
+	}
+
 	// Update last login time
 	user.UpdateLastLogin()
 	if err := uc.userRepo.Update(ctx, user); err != nil {
@@ -156,10 +218,14 @@ func (uc *AuthUseCase) Login(ctx context.Context, req LoginRequest) (*LoginRespo
 		// Don't fail the login for this
 	}
 
-	uc.logger.WithField("user_id", user.ID).Info("User logged in successfully")
+	uc.logger.WithFields(map[string]interface{}{
+		"user_id":    user.ID,
+		"session_id": session.ID,
+	}).Info("User logged in successfully")
 
 	return &LoginResponse{
 		User:         user,
+		SessionID:    session.ID,
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresAt:    tokenPair.AccessExpiry,
@@ -167,7 +233,8 @@ func (uc *AuthUseCase) Login(ctx context.Context, req LoginRequest) (*LoginRespo
 	}, nil
 }
 
-// RefreshToken refreshes an expired access token
+// RefreshToken refreshes an expired access token. The refresh token is rotated: the session
+// accepts only the new one, and presenting an old one again revokes the session.
 func (uc *AuthUseCase) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*LoginResponse, error) {
 	// Validate refresh token
 	claims, err := uc.jwtService.ValidateRefreshToken(req.RefreshToken)
@@ -176,6 +243,27 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req RefreshTokenRequest
 		return nil, errors.NewUnauthorizedError("invalid refresh token")
 	}
 
+	// Get the session the token was issued for
+	session, err := uc.sessionRepo.GetByID(ctx, claims.SessionID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, errors.NewUnauthorizedError("invalid refresh token")
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get user session")
+		return nil, errors.NewInternalError("failed to refresh token", err)
+	}
+	if session.UserID != claims.UserID {
+		return nil, errors.NewUnauthorizedError("invalid refresh token")
+	}
+	if !session.IsActive(time.Now()) {
+		return nil, errors.NewUnauthorizedError("session has expired or been revoked")
+	}
+
+	// A rotated token being presented again means it leaked, so end the session for everyone
+	if !session.MatchesRefreshToken(req.RefreshToken) {
+		return nil, uc.refreshTokenReused(ctx, session, req.IPAddress)
+	}
+
 	// Get user
 	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
@@ -189,8 +277,13 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req RefreshTokenRequest
 		return nil, errors.NewForbiddenError("user account is not active")
 	}
 
+	// Sessions do not outlive a required password change
+	if uc.users.PasswordChangeRequired(user, time.Now()) {
+		return nil, passwordChangeRequiredError()
+	}
+
 	// Generate new token pair
-	tokenPair, err := uc.jwtService.GenerateTokenPair(user)
+	tokenPair, err := uc.jwtService.GenerateTokenPair(user, session.ID)
 	if err != nil {
 		uc.logger.WithFields(map[string]interface{}{
 			"user_id": user.ID,
@@ -199,6 +292,27 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req RefreshTokenRequest
 		return nil, errors.NewInternalError("failed to generate tokens", err)
 	}
 
+	// Rotate the session's refresh token. The update only applies while the session still holds
+	// the presented token, so a concurrent refresh with the same token loses and counts as reuse.
+	previousHash := session.RefreshTokenHash
+	if err := session.IssueRefreshToken(tokenPair.RefreshToken, tokenPair.RefreshExpiry, req.IPAddress); err != nil {
+		return nil, err
+	}
+	if err := uc.sessionRepo.Update(ctx, session, previousHash); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			current, getErr := uc.sessionRepo.GetByID(ctx, session.ID)
+			if getErr != nil {
+				return nil, errors.NewInternalError("failed to refresh token", getErr)
+			}
+			return nil, uc.refreshTokenReused(ctx, current, req.IPAddress)
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to rotate refresh token")
+		return nil, errors.NewInternalError("failed to refresh token", err)
+	}
+
 	// Revoke old refresh token
 	if err := uc.jwtService.RevokeToken(req.RefreshToken); err != nil {
 		uc.logger.WithFields(map[string]interface{}{
@@ -212,6 +326,7 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req RefreshTokenRequest
 
 	return &LoginResponse{
 		User:         user,
+		SessionID:    session.ID,
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresAt:    tokenPair.AccessExpiry,
@@ -219,8 +334,18 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req RefreshTokenRequest
 	}, nil
 }
 
-// Logout logs out a user and revokes tokens
+// Logout logs out a user, ending the session the access token belongs to, and revokes tokens
 func (uc *AuthUseCase) Logout(ctx context.Context, userID uuid.UUID, accessToken string) error {
+	// End the session so its refresh token stops working too
+	if claims, err := uc.jwtService.ValidateAccessToken(accessToken); err == nil && claims.SessionID != uuid.Nil {
+		session, err := uc.sessionRepo.GetByID(ctx, claims.SessionID)
+		if err == nil && session.UserID == userID && session.RevokedAt == nil {
+			if err := uc.revokeSession(ctx, session, &userID, entities.SessionRevokedLogout); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Revoke access token
 	if err := uc.jwtService.RevokeToken(accessToken); err != nil {
 		uc.logger.WithFields(map[string]interface{}{
@@ -267,6 +392,11 @@ func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*entiti
 		return nil, errors.NewUnauthorizedError("invalid token")
 	}
 
+	// Reject tokens of sessions that were revoked since the token was issued
+	if err := uc.checkSession(ctx, claims); err != nil {
+		return nil, err
+	}
+
 	// Get user
 	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
@@ -298,19 +428,10 @@ func (uc *AuthUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, req
 	}
 
 	// Update password
-	if err := user.UpdatePassword(req.NewPassword); err != nil {
+	if err := uc.users.SetPassword(ctx, user, req.NewPassword); err != nil {
 		return err
 	}
 
-	// Save user
-	if err := uc.userRepo.Update(ctx, user); err != nil {
-		uc.logger.WithFields(map[string]interface{}{
-			"user_id": userID,
-			"error":   err.Error(),
-		}).Error("Failed to update user password")
-		return errors.NewInternalError("failed to update password", err)
-	}
-
 	// Audit log
 	auditEvent := ports.AuditEvent{
 		ID:         uuid.New(),
@@ -327,6 +448,60 @@ func (uc *AuthUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, req
 	return nil
 }
 
+// ChangeExpiredPassword changes the password of a user who has to change it before signing in,
+// checking the old password the way Login does
+func (uc *AuthUseCase) ChangeExpiredPassword(ctx context.Context, req ChangeExpiredPasswordRequest) error {
+	user, err := uc.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	if err := uc.checkLoginLockout(ctx, user); err != nil {
+		return err
+	}
+
+	if !user.IsActive() {
+		return errors.NewForbiddenError("user account is not active")
+	}
+
+	if !user.ValidatePassword(req.OldPassword) {
+		uc.logger.WithField("user_id", user.ID).Warn("Expired password change attempt with invalid old password")
+		login := LoginRequest{Username: req.Username, IPAddress: req.IPAddress, UserAgent: req.UserAgent}
+		uc.recordFailedLogin(ctx, user, login)
+		return errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	if !uc.users.PasswordChangeRequired(user, time.Now()) {
+		return errors.NewValidationError("password change not required", "sign in and change the password from the account instead")
+	}
+
+	if err := uc.users.SetPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := uc.lockoutRepo.Reset(ctx, user.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to reset login lockout")
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		Action:     "change_password",
+		Resource:   "user",
+		ResourceID: user.ID.String(),
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithField("user_id", user.ID).Info("Expired password changed successfully")
+	return nil
+}
+
 // ResetPassword resets a user's password (admin only)
 func (uc *AuthUseCase) ResetPassword(ctx context.Context, adminID uuid.UUID, req ResetPasswordRequest) error {
 	// Get admin user to check permissions
@@ -340,18 +515,17 @@ func (uc *AuthUseCase) ResetPassword(ctx context.Context, adminID uuid.UUID, req
 		return errors.NewForbiddenError("insufficient permissions to reset password")
 	}
 
-	// Get target user
+	// Get target user, treating users of other tenants as not found
 	user, err := uc.userRepo.GetByID(ctx, req.UserID)
-	if err != nil {
+	if err != nil || !admin.CanManageUser(user) {
 		return errors.NewNotFoundError("user")
 	}
 
-	// Update password
-	if err := user.UpdatePassword(req.NewPassword); err != nil {
+	// Update password, which the user has to change again as the admin knows it
+	if err := uc.users.SetPassword(ctx, user, req.NewPassword); err != nil {
 		return err
 	}
-
-	// Save user
+	user.RequirePasswordChange()
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		uc.logger.WithFields(map[string]interface{}{
 			"user_id":  req.UserID,
@@ -373,6 +547,19 @@ func (uc *AuthUseCase) ResetPassword(ctx context.Context, adminID uuid.UUID, req
 	}
 	uc.audit.Log(ctx, auditEvent)
 
+	// Sign the user out everywhere, whoever knew the old password
+	if _, err := uc.revokeUserSessions(ctx, req.UserID, &adminID, entities.SessionRevokedPasswordReset); err != nil {
+		return err
+	}
+
+	// The new password unlocks the account
+	if err := uc.lockoutRepo.Reset(ctx, req.UserID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": req.UserID,
+			"error":   err.Error(),
+		}).Warn("Failed to reset login lockout")
+	}
+
 	uc.logger.WithFields(map[string]interface{}{
 		"user_id":  req.UserID,
 		"admin_id": adminID,
@@ -403,4 +590,443 @@ func (uc *AuthUseCase) CheckPermission(ctx context.Context, userID uuid.UUID, re
 	}
 
 	return services.HasPermission(user.Role, resource, action), nil
-}
\ No newline at end of file
+}
+
+// ListSessions lists the active sessions of a user, marking the one accessToken belongs to
+func (uc *AuthUseCase) ListSessions(ctx context.Context, userID uuid.UUID, accessToken string) ([]*UserSessionResponse, error) {
+	sessions, err := uc.sessionRepo.GetActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list user sessions")
+		return nil, errors.NewInternalError("failed to list sessions", err)
+	}
+
+	var currentSessionID uuid.UUID
+	if claims, err := uc.jwtService.ValidateAccessToken(accessToken); err == nil {
+		currentSessionID = claims.SessionID
+	}
+
+	responses := make([]*UserSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, &UserSessionResponse{
+			UserSession: session,
+			IsCurrent:   session.ID == currentSessionID,
+		})
+	}
+
+	return responses, nil
+}
+
+// RevokeSession signs a user out of one of their own sessions
+func (uc *AuthUseCase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := uc.getUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return uc.revokeSession(ctx, session, &userID, entities.SessionRevokedByUser)
+}
+
+// ListUserSessions lists the active sessions of another user (admin only)
+func (uc *AuthUseCase) ListUserSessions(ctx context.Context, adminID, userID uuid.UUID) ([]*UserSessionResponse, error) {
+	if err := uc.requireUserManager(ctx, adminID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.ListSessions(ctx, userID, "")
+}
+
+// RevokeUserSession signs another user out of one session (admin only)
+func (uc *AuthUseCase) RevokeUserSession(ctx context.Context, adminID, userID, sessionID uuid.UUID) error {
+	if err := uc.requireUserManager(ctx, adminID, userID); err != nil {
+		return err
+	}
+
+	session, err := uc.getUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return uc.revokeSession(ctx, session, &adminID, entities.SessionRevokedByAdmin)
+}
+
+// RevokeAllUserSessions signs another user out of every session (admin only)
+func (uc *AuthUseCase) RevokeAllUserSessions(ctx context.Context, adminID, userID uuid.UUID) (int, error) {
+	if err := uc.requireUserManager(ctx, adminID, userID); err != nil {
+		return 0, err
+	}
+
+	return uc.revokeUserSessions(ctx, userID, &adminID, entities.SessionRevokedByAdmin)
+}
+
+// PurgeExpiredSessions deletes sessions that expired or were revoked longer than the
+// retention period ago
+func (uc *AuthUseCase) PurgeExpiredSessions(ctx context.Context) error {
+	deleted, err := uc.sessionRepo.DeleteExpired(ctx, time.Now().Add(-userSessionRetention))
+	if err != nil {
+		return fmt.Errorf("failed to purge user sessions: %w", err)
+	}
+
+	if deleted > 0 {
+		uc.logger.WithField("deleted", deleted).Info("Expired user sessions purged")
+	}
+
+	return nil
+}
+
+// VerifyCredentials checks a user's password the way Login does, also for actions a user
+// approves by entering their credentials. Locked accounts are refused before the password is
+// checked, wrong passwords count towards the lockout, and users who have to change their
+// password are refused until they do.
+func (uc *AuthUseCase) VerifyCredentials(ctx context.Context, user *entities.User, req LoginRequest) error {
+	// Refuse locked accounts before looking at the password, with the error of a wrong one
+	if err := uc.checkLoginLockout(ctx, user); err != nil {
+		return err
+	}
+
+	// Check if user is active
+	if !user.IsActive() {
+		uc.logger.WithField("user_id", user.ID).Warn("Login attempt with inactive user")
+		return errors.NewForbiddenError("user account is not active")
+	}
+
+	// Validate password
+	if !user.ValidatePassword(req.Password) {
+		uc.logger.WithField("user_id", user.ID).Warn("Login attempt with invalid password")
+		uc.recordFailedLogin(ctx, user, req)
+		return errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	// A successful login ends the backoff
+	if err := uc.lockoutRepo.Reset(ctx, user.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to reset login lockout")
+	}
+
+	// Expired and reset passwords have to be changed before signing in
+	if uc.users.PasswordChangeRequired(user, time.Now()) {
+		uc.logger.WithField("user_id", user.ID).Info("Login refused until password is changed")
+		return passwordChangeRequiredError()
+	}
+
+	return nil
+}
+
+// Helper methods
+
+// checkLoginLockout refuses logins to an account that is locked out. The error is the one of a
+// wrong password, so a response does not tell that the account exists or is locked.
+func (uc *AuthUseCase) checkLoginLockout(ctx context.Context, user *entities.User) error {
+	lockout, err := uc.lockoutRepo.GetByUser(ctx, user.ID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get login lockout")
+		return errors.NewInternalError("failed to check login lockout", err)
+	}
+
+	if lockout.IsLocked(time.Now()) {
+		uc.logger.WithField("user_id", user.ID).Warn("Login attempt on locked account")
+		return errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a failed login and locks the account once the policy's limit is
+// reached. Lockouts from a network the user has not signed in from raise a security alert.
+func (uc *AuthUseCase) recordFailedLogin(ctx context.Context, user *entities.User, req LoginRequest) {
+	now := time.Now()
+	lockout, err := uc.lockoutRepo.RecordFailure(ctx, user.ID, user.TenantID, req.IPAddress, now, uc.lockout.AttemptWindow)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Error("Failed to record failed login")
+		return
+	}
+
+	if !lockout.ShouldLock(uc.lockout) {
+		return
+	}
+
+	duration := lockout.Lock(uc.lockout, now)
+	if err := uc.lockoutRepo.Save(ctx, lockout); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Error("Failed to lock account")
+		return
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		Action:     "lockout",
+		Resource:   "user",
+		ResourceID: user.ID.String(),
+		NewValue: map[string]interface{}{
+			"locked_until": lockout.LockedUntil,
+			"lockouts":     lockout.Lockouts,
+			"duration":     duration.String(),
+		},
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Timestamp: now,
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"user_id":      user.ID,
+		"ip_address":   req.IPAddress,
+		"lockouts":     lockout.Lockouts,
+		"locked_until": lockout.LockedUntil,
+	}).Warn("Account locked after repeated failed logins")
+
+	uc.alertUnfamiliarLockout(ctx, user, lockout, req.IPAddress)
+}
+
+// alertUnfamiliarLockout raises a security alert when an account was locked out by failed logins
+// from a network the user has not signed in from recently
+func (uc *AuthUseCase) alertUnfamiliarLockout(ctx context.Context, user *entities.User, lockout *entities.LoginLockout, ip string) {
+	ipRange := entities.LoginIPRange(ip)
+	if ipRange == "" {
+		return
+	}
+
+	addresses, err := uc.sessionRepo.GetIPAddressesByUser(ctx, user.ID, time.Now().Add(-loginHistoryWindow))
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to get login history")
+		return
+	}
+	for _, address := range addresses {
+		if entities.LoginIPRange(address) == ipRange {
+			return
+		}
+	}
+
+	severity := "warning"
+	if lockout.Lockouts >= repeatedLockoutAlertThreshold {
+		severity = "critical"
+	}
+
+	alert := ports.SecurityAlert{
+		TenantID: user.TenantID,
+		Severity: severity,
+		Title:    "Repeated failed logins from an unfamiliar network",
+		Description: fmt.Sprintf("%s was locked out after repeated failed logins from %s, a network they have not signed in from",
+			user.Username, ipRange),
+		Subject: "user:" + user.ID.String(),
+		Metadata: map[string]interface{}{
+			"user_id":      user.ID,
+			"username":     user.Username,
+			"ip_address":   ip,
+			"ip_range":     ipRange,
+			"lockouts":     lockout.Lockouts,
+			"locked_until": lockout.LockedUntil,
+		},
+	}
+	if err := uc.alerts.RaiseSecurityAlert(ctx, alert); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to raise security alert")
+	}
+}
+
+// checkSession checks that the session an access token was issued for is still active
+func (uc *AuthUseCase) checkSession(ctx context.Context, claims *services.JWTClaims) error {
+	if claims.SessionID == uuid.Nil {
+		return errors.NewUnauthorizedError("invalid token")
+	}
+
+	now := time.Now()
+	cacheKey := userSessionCacheKey(claims.SessionID)
+
+	var entry userSessionEntry
+	if err := uc.cache.Get(ctx, cacheKey, &entry); err != nil || entry.UserID == uuid.Nil {
+		session, err := uc.sessionRepo.GetByID(ctx, claims.SessionID)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+				return errors.NewUnauthorizedError("session has expired or been revoked")
+			}
+			uc.logger.WithField("error", err.Error()).Error("Failed to get user session")
+			return errors.NewInternalError("failed to validate session", err)
+		}
+		if !session.IsActive(now) {
+			return errors.NewUnauthorizedError("session has expired or been revoked")
+		}
+
+		entry = userSessionEntry{UserID: session.UserID, ExpiresAt: session.ExpiresAt}
+
+		ttl := userSessionCacheTTL
+		if untilExpiry := session.ExpiresAt.Sub(now); untilExpiry < ttl {
+			ttl = untilExpiry
+		}
+		if err := uc.cache.Set(ctx, cacheKey, entry, ttl); err != nil {
+			uc.logger.WithField("error", err.Error()).Warn("Failed to cache user session")
+		}
+	}
+
+	if entry.UserID != claims.UserID || !now.Before(entry.ExpiresAt) {
+		return errors.NewUnauthorizedError("session has expired or been revoked")
+	}
+
+	return nil
+}
+
+// getUserSession gets a session of a user, treating other users' sessions as not found
+func (uc *AuthUseCase) getUserSession(ctx context.Context, userID, sessionID uuid.UUID) (*entities.UserSession, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, errors.NewNotFoundError("session")
+	}
+
+	return session, nil
+}
+
+// requireUserManager checks that adminID can manage users and that userID is a user of their tenant
+func (uc *AuthUseCase) requireUserManager(ctx context.Context, adminID, userID uuid.UUID) error {
+	admin, err := uc.userRepo.GetByID(ctx, adminID)
+	if err != nil {
+		return errors.NewNotFoundError("admin user")
+	}
+	if !admin.CanManageUsers() {
+		return errors.NewForbiddenError("insufficient permissions to manage sessions")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil || !admin.CanManageUser(user) {
+		return errors.NewNotFoundError("user")
+	}
+
+	return nil
+}
+
+// refreshTokenReused revokes a session whose rotated refresh token was presented again and
+// returns the error for the refresh attempt
+func (uc *AuthUseCase) refreshTokenReused(ctx context.Context, session *entities.UserSession, ipAddress string) error {
+	uc.logger.WithFields(map[string]interface{}{
+		"user_id":    session.UserID,
+		"session_id": session.ID,
+		"ip_address": ipAddress,
+	}).Warn("Refresh token reuse detected, revoking session")
+
+	if session.RevokedAt == nil {
+		if err := uc.revokeSession(ctx, session, nil, entities.SessionRevokedTokenReuse); err != nil {
+			return err
+		}
+	}
+	return errors.NewUnauthorizedError("refresh token has already been used")
+}
+
+// revokeSession revokes a session and evicts it from the cache so its access tokens stop
+// working immediately
+func (uc *AuthUseCase) revokeSession(ctx context.Context, session *entities.UserSession, revokedBy *uuid.UUID, reason entities.SessionRevokeReason) error {
+	if err := session.Revoke(revokedBy, reason); err != nil {
+		return err
+	}
+
+	err := uc.sessionRepo.Update(ctx, session, session.RefreshTokenHash)
+	if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+		// The refresh token was rotated since the session was read; revoke the current session
+		var current *entities.UserSession
+		if current, err = uc.sessionRepo.GetByID(ctx, session.ID); err == nil {
+			if current.RevokedAt != nil {
+				return nil
+			}
+			if err = current.Revoke(revokedBy, reason); err != nil {
+				return err
+			}
+			err = uc.sessionRepo.Update(ctx, current, current.RefreshTokenHash)
+		}
+	}
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to revoke user session")
+		return errors.NewInternalError("failed to revoke session", err)
+	}
+
+	uc.evictSessions(ctx, session.ID)
+
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     session.UserID,
+		Action:     "revoke",
+		Resource:   "session",
+		ResourceID: session.ID.String(),
+		NewValue: map[string]interface{}{
+			"user_id":       session.UserID,
+			"revoke_reason": reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	if revokedBy != nil {
+		auditEvent.UserID = *revokedBy
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return nil
+}
+
+// revokeUserSessions revokes every active session of a user and returns how many were revoked
+func (uc *AuthUseCase) revokeUserSessions(ctx context.Context, userID uuid.UUID, revokedBy *uuid.UUID, reason entities.SessionRevokeReason) (int, error) {
+	sessionIDs, err := uc.sessionRepo.RevokeAllByUser(ctx, userID, revokedBy, reason, time.Now())
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to revoke user sessions")
+		return 0, errors.NewInternalError("failed to revoke sessions", err)
+	}
+
+	uc.evictSessions(ctx, sessionIDs...)
+
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "revoke_all",
+		Resource:   "session",
+		ResourceID: userID.String(),
+		NewValue: map[string]interface{}{
+			"revoked_sessions": len(sessionIDs),
+			"revoke_reason":    reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	if revokedBy != nil {
+		auditEvent.UserID = *revokedBy
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return len(sessionIDs), nil
+}
+
+// evictSessions removes sessions from the cache
+func (uc *AuthUseCase) evictSessions(ctx context.Context, sessionIDs ...uuid.UUID) {
+	for _, sessionID := range sessionIDs {
+		if err := uc.cache.Delete(ctx, userSessionCacheKey(sessionID)); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"session_id": sessionID,
+				"error":      err.Error(),
+			}).Warn("Failed to evict user session from cache")
+		}
+	}
+}
+
+// passwordChangeRequiredError reports that the user has to change their password before signing in
+func passwordChangeRequiredError() error {
+	appErr := errors.NewAppError(errors.ErrorTypeForbidden, "password change required", nil)
+	appErr.Details = "the password has expired or was reset, change it before signing in"
+	return appErr
+}
+
+func userSessionCacheKey(sessionID uuid.UUID) string {
+	return fmt.Sprintf("user_session:%s", sessionID)
+}
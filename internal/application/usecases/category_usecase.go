@@ -0,0 +1,346 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// CategoryUseCase handles the tree of categories products are filed under
+type CategoryUseCase struct {
+	categoryRepo repositories.CategoryRepository
+	taxRates     *TaxRateUseCase
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewCategoryUseCase creates a new category use case
+func NewCategoryUseCase(
+	categoryRepo repositories.CategoryRepository,
+	taxRates *TaxRateUseCase,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *CategoryUseCase {
+	return &CategoryUseCase{
+		categoryRepo: categoryRepo,
+		taxRates:     taxRates,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// CreateCategoryRequest represents create category request
+type CreateCategoryRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Slug      string     `json:"slug,omitempty"` // Derived from the name when empty
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	SortOrder int        `json:"sort_order" validate:"min=0"`
+	TaxRateID *uuid.UUID `json:"tax_rate_id,omitempty"` // Products without a rate of their own are taxed at it
+}
+
+// UpdateCategoryRequest represents update category request
+type UpdateCategoryRequest struct {
+	Name         string     `json:"name,omitempty"`
+	Slug         string     `json:"slug,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	TopLevel     bool       `json:"top_level,omitempty"` // Move the category to the top level
+	SortOrder    *int       `json:"sort_order,omitempty"`
+	TaxRateID    *uuid.UUID `json:"tax_rate_id,omitempty"`
+	ClearTaxRate bool       `json:"clear_tax_rate,omitempty"` // Tax products at the parent category's rate
+}
+
+// ListCategories returns the tenant's categories as a flat list, in sort order
+func (uc *CategoryUseCase) ListCategories(ctx context.Context, tenantID uuid.UUID) ([]*entities.Category, error) {
+	categories, err := uc.categoryRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get categories")
+		return nil, errors.NewInternalError("failed to get categories", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategoryTree returns the tenant's categories arranged under their top-level categories
+func (uc *CategoryUseCase) GetCategoryTree(ctx context.Context, tenantID uuid.UUID) ([]*entities.CategoryNode, error) {
+	categories, err := uc.ListCategories(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.BuildCategoryTree(categories), nil
+}
+
+// GetCategory returns one of the tenant's categories
+func (uc *CategoryUseCase) GetCategory(ctx context.Context, tenantID, categoryID uuid.UUID) (*entities.Category, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get category")
+		return nil, errors.NewInternalError("failed to get category", err)
+	}
+	if category.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("category")
+	}
+
+	return category, nil
+}
+
+// ResolveCategory returns the category a product is filed under: the category with the ID
+// when one is given, otherwise the category with the name. Clients still sending category
+// names only, such as catalog syncs, get a new top-level category for a name not in use yet.
+func (uc *CategoryUseCase) ResolveCategory(ctx context.Context, tenantID, userID uuid.UUID, categoryID *uuid.UUID, name string) (*entities.Category, error) {
+	if categoryID != nil {
+		category, err := uc.GetCategory(ctx, tenantID, *categoryID)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+				return nil, errors.NewValidationError("unknown category", fmt.Sprintf("category '%s' does not exist", *categoryID))
+			}
+			return nil, err
+		}
+		return category, nil
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.NewValidationError("category is required", "category or category_id cannot be empty")
+	}
+
+	category, err := uc.categoryRepo.GetByName(ctx, tenantID, name)
+	if err == nil {
+		return category, nil
+	}
+	if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get category")
+		return nil, errors.NewInternalError("failed to get category", err)
+	}
+
+	return uc.CreateCategory(ctx, tenantID, userID, CreateCategoryRequest{Name: name})
+}
+
+// CreateCategory adds a category to the tenant's tree
+func (uc *CategoryUseCase) CreateCategory(ctx context.Context, tenantID, userID uuid.UUID, req CreateCategoryRequest) (*entities.Category, error) {
+	category, err := entities.NewCategory(tenantID, req.Name, req.Slug, req.SortOrder, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil {
+		categories, err := uc.ListCategories(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if err := category.MoveTo(req.ParentID, categories, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.TaxRateID != nil {
+		if err := uc.assignTaxRate(ctx, category, req.TaxRateID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.categoryRepo.Create(ctx, category); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create category")
+		return nil, errors.NewInternalError("failed to create category", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "category",
+		ResourceID: category.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":        category.Name,
+			"slug":        category.Slug,
+			"parent_id":   category.ParentID,
+			"sort_order":  category.SortOrder,
+			"tax_rate_id": category.TaxRateID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":   tenantID,
+		"category_id": category.ID,
+		"user_id":     userID,
+	}).Info("Category created")
+
+	return category, nil
+}
+
+// UpdateCategory renames, moves or reorders one of the tenant's categories. Its products are
+// renamed along with it.
+func (uc *CategoryUseCase) UpdateCategory(ctx context.Context, tenantID, userID, categoryID uuid.UUID, req UpdateCategoryRequest) (*entities.Category, error) {
+	if req.ParentID != nil && req.TopLevel {
+		return nil, errors.NewValidationError("invalid parent category", "parent_id and top_level cannot both be set")
+	}
+	if req.TaxRateID != nil && req.ClearTaxRate {
+		return nil, errors.NewValidationError("invalid tax rate", "tax_rate_id and clear_tax_rate cannot both be set")
+	}
+
+	category, err := uc.GetCategory(ctx, tenantID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := map[string]interface{}{
+		"name":        category.Name,
+		"slug":        category.Slug,
+		"parent_id":   category.ParentID,
+		"sort_order":  category.SortOrder,
+		"tax_rate_id": category.TaxRateID,
+	}
+	oldName := category.Name
+
+	if req.Name != "" || req.Slug != "" || req.SortOrder != nil {
+		name, slug, sortOrder := category.Name, category.Slug, category.SortOrder
+		if req.Name != "" {
+			name = req.Name
+		}
+		if req.Slug != "" {
+			slug = req.Slug
+		}
+		if req.SortOrder != nil {
+			sortOrder = *req.SortOrder
+		}
+		if err := category.Update(name, slug, sortOrder, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ParentID != nil || req.TopLevel {
+		categories, err := uc.ListCategories(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if err := category.MoveTo(req.ParentID, categories, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.TaxRateID != nil || req.ClearTaxRate {
+		if err := uc.assignTaxRate(ctx, category, req.TaxRateID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.categoryRepo.Update(ctx, category, oldName); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"category_id": category.ID,
+			"error":       err.Error(),
+		}).Error("Failed to update category")
+		return nil, errors.NewInternalError("failed to update category", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "category",
+		ResourceID: category.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"name":        category.Name,
+			"slug":        category.Slug,
+			"parent_id":   category.ParentID,
+			"sort_order":  category.SortOrder,
+			"tax_rate_id": category.TaxRateID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":   tenantID,
+		"category_id": category.ID,
+		"user_id":     userID,
+	}).Info("Category updated")
+
+	return category, nil
+}
+
+// assignTaxRate assigns the tax rate with the ID to the category, or clears it when the ID is nil
+func (uc *CategoryUseCase) assignTaxRate(ctx context.Context, category *entities.Category, taxRateID *uuid.UUID, userID uuid.UUID) error {
+	var taxRate *entities.TaxRate
+	if taxRateID != nil {
+		var err error
+		taxRate, err = uc.taxRates.ResolveTaxRate(ctx, category.TenantID, *taxRateID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return category.SetTaxRate(taxRate, userID)
+}
+
+// DeleteCategory removes one of the tenant's categories without subcategories or products
+func (uc *CategoryUseCase) DeleteCategory(ctx context.Context, tenantID, userID, categoryID uuid.UUID) error {
+	category, err := uc.GetCategory(ctx, tenantID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	count, err := uc.categoryRepo.CountProducts(ctx, category.ID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to count products by category")
+		return errors.NewInternalError("failed to delete category", err)
+	}
+	if count > 0 {
+		return errors.NewConflictError(fmt.Sprintf("category '%s' has %d products", category.Name, count))
+	}
+
+	// Subcategories are refused by the repository
+	if err := uc.categoryRepo.Delete(ctx, category.ID); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"category_id": category.ID,
+			"error":       err.Error(),
+		}).Error("Failed to delete category")
+		return errors.NewInternalError("failed to delete category", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "category",
+		ResourceID: category.ID.String(),
+		OldValue: map[string]interface{}{
+			"name":      category.Name,
+			"slug":      category.Slug,
+			"parent_id": category.ParentID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":   tenantID,
+		"category_id": category.ID,
+		"user_id":     userID,
+	}).Info("Category deleted")
+
+	return nil
+}
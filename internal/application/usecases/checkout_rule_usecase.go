@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// CheckoutRuleUseCase handles tenant-defined checkout validation rules
+type CheckoutRuleUseCase struct {
+	checkoutRuleRepo repositories.CheckoutRuleRepository
+	audit            ports.AuditPort
+	logger           logger.Logger
+}
+
+// NewCheckoutRuleUseCase creates a new checkout rule use case
+func NewCheckoutRuleUseCase(
+	checkoutRuleRepo repositories.CheckoutRuleRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *CheckoutRuleUseCase {
+	return &CheckoutRuleUseCase{
+		checkoutRuleRepo: checkoutRuleRepo,
+		audit:            audit,
+		logger:           logger,
+	}
+}
+
+// CreateCheckoutRuleRequest represents create checkout rule request
+type CreateCheckoutRuleRequest struct {
+	Name        string                           `json:"name" validate:"required"`
+	Description string                           `json:"description,omitempty"`
+	Conditions  []entities.CheckoutRuleCondition `json:"conditions" validate:"required,min=1"`
+	Message     string                           `json:"message" validate:"required"`
+}
+
+// UpdateCheckoutRuleRequest represents update checkout rule request
+type UpdateCheckoutRuleRequest struct {
+	Name        string                           `json:"name" validate:"required"`
+	Description string                           `json:"description,omitempty"`
+	Conditions  []entities.CheckoutRuleCondition `json:"conditions" validate:"required,min=1"`
+	Message     string                           `json:"message" validate:"required"`
+	IsActive    *bool                            `json:"is_active,omitempty"`
+}
+
+// CheckoutRuleResponse represents checkout rule response
+type CheckoutRuleResponse struct {
+	ID          uuid.UUID                        `json:"id"`
+	Name        string                           `json:"name"`
+	Description string                           `json:"description,omitempty"`
+	Conditions  []entities.CheckoutRuleCondition `json:"conditions"`
+	Message     string                           `json:"message"`
+	IsActive    bool                             `json:"is_active"`
+	CreatedAt   time.Time                        `json:"created_at"`
+	UpdatedAt   time.Time                        `json:"updated_at"`
+	CreatedBy   uuid.UUID                        `json:"created_by"`
+}
+
+// CreateCheckoutRule creates a new checkout rule for a tenant
+func (uc *CheckoutRuleUseCase) CreateCheckoutRule(ctx context.Context, tenantID, userID uuid.UUID, req CreateCheckoutRuleRequest) (*CheckoutRuleResponse, error) {
+	rule, err := entities.NewCheckoutRule(tenantID, req.Name, req.Description, req.Message, req.Conditions, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkoutRuleRepo.Create(ctx, rule); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"name":      req.Name,
+			"error":     err.Error(),
+		}).Error("Failed to create checkout rule")
+		return nil, errors.NewInternalError("failed to create checkout rule", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "checkout_rule",
+		ResourceID: rule.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":       rule.Name,
+			"conditions": rule.Conditions,
+			"message":    rule.Message,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"rule_id":   rule.ID,
+		"tenant_id": tenantID,
+		"user_id":   userID,
+	}).Info("Checkout rule created successfully")
+
+	return uc.toCheckoutRuleResponse(rule), nil
+}
+
+// GetCheckoutRule retrieves a checkout rule by ID
+func (uc *CheckoutRuleUseCase) GetCheckoutRule(ctx context.Context, tenantID, ruleID uuid.UUID) (*CheckoutRuleResponse, error) {
+	rule, err := uc.getTenantRule(ctx, tenantID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toCheckoutRuleResponse(rule), nil
+}
+
+// ListCheckoutRules retrieves all checkout rules for a tenant
+func (uc *CheckoutRuleUseCase) ListCheckoutRules(ctx context.Context, tenantID uuid.UUID) ([]*CheckoutRuleResponse, error) {
+	rules, err := uc.checkoutRuleRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list checkout rules")
+		return nil, errors.NewInternalError("failed to list checkout rules", err)
+	}
+
+	responses := make([]*CheckoutRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = uc.toCheckoutRuleResponse(rule)
+	}
+
+	return responses, nil
+}
+
+// UpdateCheckoutRule updates an existing checkout rule
+func (uc *CheckoutRuleUseCase) UpdateCheckoutRule(ctx context.Context, tenantID, userID, ruleID uuid.UUID, req UpdateCheckoutRuleRequest) (*CheckoutRuleResponse, error) {
+	rule, err := uc.getTenantRule(ctx, tenantID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(rule)
+
+	if err := rule.Update(req.Name, req.Description, req.Message, req.Conditions); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		if *req.IsActive {
+			rule.Activate()
+		} else {
+			rule.Deactivate()
+		}
+	}
+
+	if err := uc.checkoutRuleRepo.Update(ctx, rule); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"rule_id": ruleID,
+			"error":   err.Error(),
+		}).Error("Failed to update checkout rule")
+		return nil, errors.NewInternalError("failed to update checkout rule", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "checkout_rule",
+		ResourceID: ruleID.String(),
+		Changes:    audit.Diff(before, audit.TakeSnapshot(rule)),
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"rule_id": ruleID,
+		"user_id": userID,
+	}).Info("Checkout rule updated successfully")
+
+	return uc.toCheckoutRuleResponse(rule), nil
+}
+
+// DeleteCheckoutRule deletes a checkout rule
+func (uc *CheckoutRuleUseCase) DeleteCheckoutRule(ctx context.Context, tenantID, userID, ruleID uuid.UUID) error {
+	rule, err := uc.getTenantRule(ctx, tenantID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.checkoutRuleRepo.Delete(ctx, ruleID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"rule_id": ruleID,
+			"error":   err.Error(),
+		}).Error("Failed to delete checkout rule")
+		return errors.NewInternalError("failed to delete checkout rule", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "checkout_rule",
+		ResourceID: ruleID.String(),
+		OldValue: map[string]interface{}{
+			"name":       rule.Name,
+			"conditions": rule.Conditions,
+			"message":    rule.Message,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"rule_id": ruleID,
+		"user_id": userID,
+	}).Info("Checkout rule deleted successfully")
+
+	return nil
+}
+
+// getTenantRule retrieves a checkout rule and ensures it belongs to the tenant
+func (uc *CheckoutRuleUseCase) getTenantRule(ctx context.Context, tenantID, ruleID uuid.UUID) (*entities.CheckoutRule, error) {
+	rule, err := uc.checkoutRuleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("checkout rule")
+	}
+	if rule.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("checkout rule")
+	}
+	return rule, nil
+}
+
+// toCheckoutRuleResponse converts checkout rule entity to response
+func (uc *CheckoutRuleUseCase) toCheckoutRuleResponse(rule *entities.CheckoutRule) *CheckoutRuleResponse {
+	return &CheckoutRuleResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Conditions:  rule.Conditions,
+		Message:     rule.Message,
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+		CreatedBy:   rule.CreatedBy,
+	}
+}
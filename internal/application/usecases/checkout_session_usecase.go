@@ -0,0 +1,506 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// checkoutSessionExpiryBatchSize caps how many idle checkout sessions one job run expires
+const checkoutSessionExpiryBatchSize = 100
+
+// maxCheckoutCartLines caps how many products a checkout cart can hold
+const maxCheckoutCartLines = 200
+
+// CheckoutSessionUseCase takes a sale through checkout in explicit steps on behalf of thin
+// clients such as kiosks and mobile apps, so they do not have to orchestrate the sale
+// endpoints and recover from partial failures themselves. Every step is safe to retry.
+type CheckoutSessionUseCase struct {
+	sessionRepo repositories.CheckoutSessionRepository
+	sales       *SaleUseCase
+	logger      logger.Logger
+}
+
+// NewCheckoutSessionUseCase creates a new checkout session use case
+func NewCheckoutSessionUseCase(
+	sessionRepo repositories.CheckoutSessionRepository,
+	sales *SaleUseCase,
+	logger logger.Logger,
+) *CheckoutSessionUseCase {
+	return &CheckoutSessionUseCase{
+		sessionRepo: sessionRepo,
+		sales:       sales,
+		logger:      logger,
+	}
+}
+
+// StartCheckoutSessionRequest represents start checkout session request
+type StartCheckoutSessionRequest struct {
+	CustomerName  string               `json:"customer_name,omitempty"`
+	CustomerEmail string               `json:"customer_email,omitempty"`
+	CustomerPhone string               `json:"customer_phone,omitempty"`
+	Channel       entities.SaleChannel `json:"channel,omitempty"` // Defaults to in_store
+}
+
+// CheckoutCartLine represents a product and the quantity of it wanted in the cart
+type CheckoutCartLine struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+}
+
+// SetCheckoutCartRequest represents set checkout cart request. The items replace the whole
+// cart, so sending the same request again leaves the cart as it is.
+type SetCheckoutCartRequest struct {
+	Items []CheckoutCartLine `json:"items"`
+}
+
+// CheckoutSessionResponse represents a checkout session with its sale
+type CheckoutSessionResponse struct {
+	Session *entities.CheckoutSession `json:"session"`
+	Sale    *SaleResponse             `json:"sale"`
+}
+
+// StartSession creates a pending sale and starts a checkout session for it
+func (uc *CheckoutSessionUseCase) StartSession(ctx context.Context, tenantID, userID uuid.UUID, req StartCheckoutSessionRequest) (*CheckoutSessionResponse, error) {
+	sale, err := uc.sales.CreateSale(ctx, tenantID, userID, CreateSaleRequest{
+		CustomerName:  req.CustomerName,
+		CustomerEmail: req.CustomerEmail,
+		CustomerPhone: req.CustomerPhone,
+		Channel:       req.Channel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := entities.NewCheckoutSession(tenantID, sale.ID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": sale.ID,
+			"error":   err.Error(),
+		}).Error("Failed to create checkout session")
+		return nil, errors.NewInternalError("failed to create checkout session", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"session_id": session.ID,
+		"sale_id":    sale.ID,
+		"user_id":    userID,
+	}).Info("Checkout session started")
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// GetSession retrieves a checkout session with its sale, so a client can resume it
+func (uc *CheckoutSessionUseCase) GetSession(ctx context.Context, tenantID, sessionID uuid.UUID) (*CheckoutSessionResponse, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := uc.sales.GetSale(ctx, session.SaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// SetCart makes the cart hold exactly the given products and quantities, adding, updating and
+// removing sale items as needed. If a change fails part way, sending the same cart again
+// finishes the job.
+func (uc *CheckoutSessionUseCase) SetCart(ctx context.Context, tenantID, userID, sessionID uuid.UUID, req SetCheckoutCartRequest) (*CheckoutSessionResponse, error) {
+	wanted, err := validateCheckoutCart(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discard the evaluation before touching the cart, so it never outlives a partial change
+	if err := session.ResetToCart(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	sale, err := uc.sales.GetSale(ctx, session.SaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[uuid.UUID]int, len(sale.Items))
+	for _, item := range sale.Items {
+		current[item.ProductID] = item.Quantity
+	}
+
+	var marginWarnings []*entities.MarginViolation
+	for productID := range current {
+		if _, ok := wanted[productID]; ok {
+			continue
+		}
+		if sale, err = uc.sales.RemoveSaleItem(ctx, userID, session.SaleID, productID); err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range req.Items {
+		quantity, inCart := current[line.ProductID]
+		switch {
+		case !inCart:
+			sale, err = uc.sales.AddSaleItem(ctx, userID, session.SaleID, AddSaleItemRequest{ProductID: line.ProductID, Quantity: line.Quantity})
+			if err == nil {
+				marginWarnings = append(marginWarnings, sale.MarginWarnings...)
+			}
+		case quantity != line.Quantity:
+			sale, err = uc.sales.UpdateSaleItem(ctx, userID, session.SaleID, UpdateSaleItemRequest{ProductID: line.ProductID, Quantity: line.Quantity})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	sale.MarginWarnings = marginWarnings
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// SetCustomer attaches the customer to the session's sale
+func (uc *CheckoutSessionUseCase) SetCustomer(ctx context.Context, tenantID, userID, sessionID uuid.UUID, req UpdateSaleCustomerRequest) (*CheckoutSessionResponse, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checkout rules may depend on the customer, so the cart has to be evaluated again
+	if err := session.ResetToCart(time.Now()); err != nil {
+		return nil, err
+	}
+
+	sale, err := uc.sales.UpdateSaleCustomer(ctx, userID, session.SaleID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// Evaluate applies the current promotions to the cart and evaluates the tenant's checkout
+// rules against it
+func (uc *CheckoutSessionUseCase) Evaluate(ctx context.Context, tenantID, userID, sessionID uuid.UUID) (*CheckoutSessionResponse, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CheckActive(time.Now()); err != nil {
+		return nil, err
+	}
+
+	sale, evaluation, err := uc.sales.EvaluateSale(ctx, userID, session.SaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.RecordEvaluation(*evaluation, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// CreatePaymentIntent records how the customer intends to pay, checking the payment covers
+// the amount due including surcharges. The sale is not completed yet.
+func (uc *CheckoutSessionUseCase) CreatePaymentIntent(ctx context.Context, tenantID, userID, sessionID uuid.UUID, req CompleteSaleRequest) (*CheckoutSessionResponse, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CheckActive(time.Now()); err != nil {
+		return nil, err
+	}
+	if session.Evaluation == nil {
+		return nil, errors.NewValidationError("checkout not evaluated", "evaluate the cart before creating a payment intent")
+	}
+
+	preview, err := uc.sales.PreviewPayment(ctx, session.SaleID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sale endpoints can still change the cart behind the session's back
+	if !preview.Subtotal.Equal(session.Evaluation.Subtotal) {
+		return nil, uc.resetChangedCart(ctx, session, "cart changed since it was evaluated")
+	}
+
+	intent := entities.CheckoutPaymentIntent{
+		PaymentMethod:  req.PaymentMethod,
+		PaidAmount:     req.PaidAmount,
+		Payments:       req.Payments,
+		DiscountAmount: req.DiscountAmount,
+		CouponCode:     req.CouponCode,
+		TaxPercentage:  req.TaxPercentage,
+		Notes:          req.Notes,
+		AmountDue:      preview.TotalAmount,
+		ChangeAmount:   preview.ChangeAmount,
+	}
+	if err := session.SetPaymentIntent(intent, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"session_id": session.ID,
+		"amount_due": intent.AmountDue,
+		"user_id":    userID,
+	}).Info("Checkout payment intent created")
+
+	return &CheckoutSessionResponse{Session: session, Sale: preview}, nil
+}
+
+// Complete completes the session's sale with its payment intent. Completing a completed
+// session returns it as it is, so a client that lost the response can safely retry.
+func (uc *CheckoutSessionUseCase) Complete(ctx context.Context, tenantID, userID, sessionID uuid.UUID) (*CheckoutSessionResponse, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := uc.sales.GetSale(ctx, session.SaleID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == entities.CheckoutSessionStatusCompleted {
+		return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+	}
+
+	// The sale was completed by an earlier attempt that failed to record it on the session
+	if sale.Status == entities.SaleStatusCompleted && session.PaymentIntent != nil {
+		return uc.recordCompletion(ctx, session, sale)
+	}
+
+	if err := session.CheckActive(time.Now()); err != nil {
+		return nil, err
+	}
+	if session.PaymentIntent == nil {
+		return nil, errors.NewValidationError("no payment intent", "create a payment intent before completing the checkout")
+	}
+
+	req := CompleteSaleRequest{
+		PaidAmount:     session.PaymentIntent.PaidAmount,
+		PaymentMethod:  session.PaymentIntent.PaymentMethod,
+		Payments:       session.PaymentIntent.Payments,
+		DiscountAmount: session.PaymentIntent.DiscountAmount,
+		CouponCode:     session.PaymentIntent.CouponCode,
+		TaxPercentage:  session.PaymentIntent.TaxPercentage,
+		Notes:          session.PaymentIntent.Notes,
+	}
+
+	// Never charge a different amount than the customer agreed to
+	preview, err := uc.sales.PreviewPayment(ctx, session.SaleID, req)
+	if err != nil {
+		return nil, err
+	}
+	if !preview.TotalAmount.Equal(session.PaymentIntent.AmountDue) {
+		return nil, uc.resetChangedCart(ctx, session, "amount due changed since the payment intent was created")
+	}
+
+	sale, err = uc.sales.CompleteSale(ctx, userID, session.SaleID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.recordCompletion(ctx, session, sale)
+}
+
+// Cancel abandons the session and cancels its sale
+func (uc *CheckoutSessionUseCase) Cancel(ctx context.Context, tenantID, userID, sessionID uuid.UUID) (*CheckoutSessionResponse, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != entities.CheckoutSessionStatusActive {
+		return nil, errors.NewValidationError("invalid checkout session status", "only active checkout sessions can be cancelled")
+	}
+
+	if err := uc.sales.CancelSale(ctx, userID, session.SaleID); err != nil {
+		return nil, err
+	}
+
+	if err := session.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	sale, err := uc.sales.GetSale(ctx, session.SaleID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"session_id": session.ID,
+		"sale_id":    session.SaleID,
+		"user_id":    userID,
+	}).Info("Checkout session cancelled")
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// ExpireIdleSessions expires checkout sessions left idle and cancels their pending sales. A
+// session whose sale was completed after all is marked completed instead.
+func (uc *CheckoutSessionUseCase) ExpireIdleSessions(ctx context.Context) error {
+	sessions, err := uc.sessionRepo.GetIdle(ctx, time.Now(), checkoutSessionExpiryBatchSize)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get idle checkout sessions")
+		return errors.NewInternalError("failed to get idle checkout sessions", err)
+	}
+
+	expired := 0
+	for _, session := range sessions {
+		if err := uc.expireSession(ctx, session); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"session_id": session.ID,
+				"error":      err.Error(),
+			}).Warn("Failed to expire checkout session")
+			continue
+		}
+		expired++
+	}
+
+	if expired > 0 {
+		uc.logger.WithField("count", expired).Info("Idle checkout sessions expired")
+	}
+
+	return nil
+}
+
+// expireSession expires an idle session, cancelling its sale if still pending
+func (uc *CheckoutSessionUseCase) expireSession(ctx context.Context, session *entities.CheckoutSession) error {
+	sale, err := uc.sales.GetSale(ctx, session.SaleID)
+	if err != nil {
+		return err
+	}
+
+	if sale.Status == entities.SaleStatusCompleted && session.PaymentIntent != nil {
+		_, err := uc.recordCompletion(ctx, session, sale)
+		return err
+	}
+
+	if sale.Status == entities.SaleStatusPending {
+		if err := uc.sales.CancelSale(ctx, session.CreatedBy, session.SaleID); err != nil {
+			return err
+		}
+	}
+
+	if err := session.Expire(time.Now()); err != nil {
+		return err
+	}
+	return uc.updateSession(ctx, session)
+}
+
+// recordCompletion marks the session completed once its sale has been completed. The sale
+// is completed either way, so failing to record it is only logged; retrying Complete or the
+// expiry job records it later.
+func (uc *CheckoutSessionUseCase) recordCompletion(ctx context.Context, session *entities.CheckoutSession, sale *SaleResponse) (*CheckoutSessionResponse, error) {
+	if err := session.Complete(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sessionRepo.Update(ctx, session); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"sale_id":    session.SaleID,
+			"error":      err.Error(),
+		}).Error("Failed to record checkout session completion")
+	} else {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"sale_id":    session.SaleID,
+		}).Info("Checkout session completed")
+	}
+
+	return &CheckoutSessionResponse{Session: session, Sale: sale}, nil
+}
+
+// resetChangedCart sends a session whose cart changed outside of it back to the cart step
+func (uc *CheckoutSessionUseCase) resetChangedCart(ctx context.Context, session *entities.CheckoutSession, reason string) error {
+	if err := session.ResetToCart(time.Now()); err != nil {
+		return err
+	}
+	if err := uc.updateSession(ctx, session); err != nil {
+		return err
+	}
+
+	return errors.NewConflictError(reason + ", evaluate it again")
+}
+
+// getSession retrieves a checkout session of the tenant
+func (uc *CheckoutSessionUseCase) getSession(ctx context.Context, tenantID, sessionID uuid.UUID) (*entities.CheckoutSession, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("checkout session")
+	}
+
+	return session, nil
+}
+
+// updateSession saves a checkout session
+func (uc *CheckoutSessionUseCase) updateSession(ctx context.Context, session *entities.CheckoutSession) error {
+	if err := uc.sessionRepo.Update(ctx, session); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to update checkout session")
+		return errors.NewInternalError("failed to update checkout session", err)
+	}
+
+	return nil
+}
+
+// validateCheckoutCart validates the lines of a cart and returns the wanted quantity of each product
+func validateCheckoutCart(lines []CheckoutCartLine) (map[uuid.UUID]int, error) {
+	if len(lines) > maxCheckoutCartLines {
+		return nil, errors.NewValidationError("too many items", "a cart can hold at most 200 products")
+	}
+
+	wanted := make(map[uuid.UUID]int, len(lines))
+	for _, line := range lines {
+		if line.ProductID == uuid.Nil {
+			return nil, errors.NewValidationError("product ID is required", "product_id cannot be empty")
+		}
+		if line.Quantity <= 0 {
+			return nil, errors.NewInvalidQuantityError(line.Quantity)
+		}
+		if _, ok := wanted[line.ProductID]; ok {
+			return nil, errors.NewValidationError("duplicate product", "each product can only appear once in the cart")
+		}
+		wanted[line.ProductID] = line.Quantity
+	}
+
+	return wanted, nil
+}
@@ -0,0 +1,337 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// couponRedemptionListLimit caps how many redemptions of a coupon are listed
+	couponRedemptionListLimit = 100
+
+	// maxCouponReportRange caps the date range of a single coupon performance report
+	maxCouponReportRange = 366 * 24 * time.Hour
+)
+
+// CouponUseCase handles the tenant's coupon codes and reporting on their redemptions
+type CouponUseCase struct {
+	couponRepo repositories.CouponRepository
+	audit      ports.AuditPort
+	logger     logger.Logger
+}
+
+// NewCouponUseCase creates a new coupon use case
+func NewCouponUseCase(
+	couponRepo repositories.CouponRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *CouponUseCase {
+	return &CouponUseCase{
+		couponRepo: couponRepo,
+		audit:      audit,
+		logger:     logger,
+	}
+}
+
+// CreateCouponRequest represents create coupon request
+type CreateCouponRequest struct {
+	Code           string              `json:"code" validate:"required"`
+	Type           entities.CouponType `json:"type" validate:"required"`
+	Value          decimal.Decimal     `json:"value" validate:"required"`
+	MaxRedemptions int                 `json:"max_redemptions" validate:"min=0"` // Zero for unlimited
+	ExpiresAt      *time.Time          `json:"expires_at,omitempty"`
+}
+
+// UpdateCouponRequest represents update coupon request
+type UpdateCouponRequest struct {
+	Type           entities.CouponType `json:"type" validate:"required"`
+	Value          decimal.Decimal     `json:"value" validate:"required"`
+	MaxRedemptions int                 `json:"max_redemptions" validate:"min=0"`
+	ExpiresAt      *time.Time          `json:"expires_at,omitempty"`
+	IsActive       *bool               `json:"is_active,omitempty"`
+}
+
+// ValidateCouponRequest represents validate coupon request. When an amount is given, the
+// discount the coupon would give on it is worked out.
+type ValidateCouponRequest struct {
+	Code   string          `json:"code" validate:"required"`
+	Amount decimal.Decimal `json:"amount,omitempty"`
+}
+
+// CouponValidationResponse represents a coupon that can be redeemed and the discount it gives
+type CouponValidationResponse struct {
+	Coupon               *entities.Coupon `json:"coupon"`
+	DiscountAmount       decimal.Decimal  `json:"discount_amount"`
+	RemainingRedemptions int              `json:"remaining_redemptions"` // -1 when unlimited
+}
+
+// CouponPerformanceReportResponse represents how the tenant's coupons performed
+type CouponPerformanceReportResponse struct {
+	FromDate       time.Time                `json:"from_date"`
+	ToDate         time.Time                `json:"to_date"`
+	Redemptions    int                      `json:"redemptions"`
+	DiscountAmount decimal.Decimal          `json:"discount_amount"`
+	SalesTotal     decimal.Decimal          `json:"sales_total"`
+	Coupons        []*CouponPerformanceStat `json:"coupons"`
+}
+
+// CouponPerformanceStat represents how one coupon performed
+type CouponPerformanceStat struct {
+	CouponID        uuid.UUID       `json:"coupon_id"`
+	Code            string          `json:"code"`
+	Redemptions     int             `json:"redemptions"`
+	DiscountAmount  decimal.Decimal `json:"discount_amount"`
+	SalesTotal      decimal.Decimal `json:"sales_total"` // Paid by customers after the discount
+	AverageSale     decimal.Decimal `json:"average_sale"`
+	AverageDiscount decimal.Decimal `json:"average_discount"`
+}
+
+// CreateCoupon creates a coupon code for the tenant
+func (uc *CouponUseCase) CreateCoupon(ctx context.Context, tenantID, userID uuid.UUID, req CreateCouponRequest) (*entities.Coupon, error) {
+	coupon, err := entities.NewCoupon(tenantID, req.Code, req.Type, req.Value, req.MaxRedemptions, req.ExpiresAt, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.couponRepo.Create(ctx, coupon); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"code":      coupon.Code,
+			"error":     err.Error(),
+		}).Error("Failed to create coupon")
+		return nil, errors.NewInternalError("failed to create coupon", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "coupon",
+		ResourceID: coupon.ID.String(),
+		NewValue: map[string]interface{}{
+			"code":            coupon.Code,
+			"type":            coupon.Type,
+			"value":           coupon.Value,
+			"max_redemptions": coupon.MaxRedemptions,
+			"expires_at":      coupon.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"coupon_id": coupon.ID,
+		"user_id":   userID,
+	}).Info("Coupon created")
+
+	return coupon, nil
+}
+
+// ListCoupons retrieves all the tenant's coupons, newest first
+func (uc *CouponUseCase) ListCoupons(ctx context.Context, tenantID uuid.UUID) ([]*entities.Coupon, error) {
+	coupons, err := uc.couponRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list coupons")
+		return nil, errors.NewInternalError("failed to list coupons", err)
+	}
+
+	return coupons, nil
+}
+
+// GetCoupon retrieves one of the tenant's coupons
+func (uc *CouponUseCase) GetCoupon(ctx context.Context, tenantID, couponID uuid.UUID) (*entities.Coupon, error) {
+	coupon, err := uc.couponRepo.GetByID(ctx, couponID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get coupon")
+		return nil, errors.NewInternalError("failed to get coupon", err)
+	}
+	if coupon.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("coupon")
+	}
+
+	return coupon, nil
+}
+
+// UpdateCoupon updates the discount, limits or status of one of the tenant's coupons. The
+// code itself cannot change, since it may already be printed.
+func (uc *CouponUseCase) UpdateCoupon(ctx context.Context, tenantID, userID, couponID uuid.UUID, req UpdateCouponRequest) (*entities.Coupon, error) {
+	coupon, err := uc.GetCoupon(ctx, tenantID, couponID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(coupon)
+
+	if err := coupon.Update(req.Type, req.Value, req.MaxRedemptions, req.ExpiresAt, userID); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		if *req.IsActive {
+			coupon.Activate(userID)
+		} else {
+			coupon.Deactivate(userID)
+		}
+	}
+
+	if err := uc.couponRepo.Update(ctx, coupon); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"coupon_id": couponID,
+			"error":     err.Error(),
+		}).Error("Failed to update coupon")
+		return nil, errors.NewInternalError("failed to update coupon", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "coupon",
+		ResourceID: couponID.String(),
+		Changes:    audit.Diff(before, audit.TakeSnapshot(coupon)),
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"coupon_id": couponID,
+		"user_id":   userID,
+	}).Info("Coupon updated")
+
+	return coupon, nil
+}
+
+// ValidateCoupon checks that a code a customer presents can be redeemed, and works out the
+// discount it would give on the amount
+func (uc *CouponUseCase) ValidateCoupon(ctx context.Context, tenantID uuid.UUID, req ValidateCouponRequest) (*CouponValidationResponse, error) {
+	if req.Amount.LessThan(decimal.Zero) {
+		return nil, errors.NewValidationError("invalid amount", "amount cannot be negative")
+	}
+
+	coupon, err := findRedeemableCoupon(ctx, uc.couponRepo, tenantID, req.Code, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CouponValidationResponse{
+		Coupon:               coupon,
+		DiscountAmount:       coupon.Discount(req.Amount),
+		RemainingRedemptions: coupon.RemainingRedemptions(),
+	}, nil
+}
+
+// GetCouponRedemptions retrieves the latest redemptions of one of the tenant's coupons
+func (uc *CouponUseCase) GetCouponRedemptions(ctx context.Context, tenantID, couponID uuid.UUID) ([]*entities.CouponRedemption, error) {
+	coupon, err := uc.GetCoupon(ctx, tenantID, couponID)
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions, err := uc.couponRepo.GetRedemptionsByCoupon(ctx, coupon.ID, couponRedemptionListLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get coupon redemptions")
+		return nil, errors.NewInternalError("failed to get coupon redemptions", err)
+	}
+
+	return redemptions, nil
+}
+
+// GetPerformanceReport reports how often each of the tenant's coupons was redeemed between
+// the dates, most redeemed first, how much discount it gave and how much its sales brought
+// in. Refunded sales are left out.
+func (uc *CouponUseCase) GetPerformanceReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*CouponPerformanceReportResponse, error) {
+	if !toDate.After(fromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if toDate.Sub(fromDate) > maxCouponReportRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+
+	lines, err := uc.couponRepo.GetPerformanceLines(ctx, tenantID, fromDate, toDate)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get coupon performance lines")
+		return nil, errors.NewInternalError("failed to generate coupon performance report", err)
+	}
+
+	report := &CouponPerformanceReportResponse{
+		FromDate:       fromDate,
+		ToDate:         toDate,
+		DiscountAmount: decimal.Zero,
+		SalesTotal:     decimal.Zero,
+		Coupons:        make([]*CouponPerformanceStat, 0, len(lines)),
+	}
+
+	for _, line := range lines {
+		stat := &CouponPerformanceStat{
+			CouponID:        line.CouponID,
+			Code:            line.Code,
+			Redemptions:     line.Redemptions,
+			DiscountAmount:  line.DiscountAmount,
+			SalesTotal:      line.SalesTotal,
+			AverageSale:     decimal.Zero,
+			AverageDiscount: decimal.Zero,
+		}
+		if line.Redemptions > 0 {
+			count := decimal.NewFromInt(int64(line.Redemptions))
+			stat.AverageSale = line.SalesTotal.Div(count).Round(2)
+			stat.AverageDiscount = line.DiscountAmount.Div(count).Round(2)
+		}
+
+		report.Redemptions += line.Redemptions
+		report.DiscountAmount = report.DiscountAmount.Add(line.DiscountAmount)
+		report.SalesTotal = report.SalesTotal.Add(line.SalesTotal)
+		report.Coupons = append(report.Coupons, stat)
+	}
+
+	return report, nil
+}
+
+// findRedeemableCoupon looks up the tenant's coupon with the code a customer presented and
+// checks it can be redeemed now. With forUpdate the coupon is locked until the transaction
+// of the repository ends.
+func findRedeemableCoupon(ctx context.Context, couponRepo repositories.CouponRepository, tenantID uuid.UUID, code string, forUpdate bool) (*entities.Coupon, error) {
+	code = entities.NormalizeCouponCode(code)
+	if code == "" {
+		return nil, errors.NewValidationError("coupon code is required", "code cannot be empty")
+	}
+
+	var coupon *entities.Coupon
+	var err error
+	if forUpdate {
+		coupon, err = couponRepo.GetByCodeForUpdate(ctx, tenantID, code)
+	} else {
+		coupon, err = couponRepo.GetByCode(ctx, tenantID, code)
+	}
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, errors.NewValidationError("unknown coupon", fmt.Sprintf("coupon '%s' does not exist", code))
+		}
+		return nil, errors.NewInternalError("failed to get coupon", err)
+	}
+
+	if err := coupon.CheckRedeemable(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
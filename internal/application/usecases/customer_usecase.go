@@ -0,0 +1,425 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// customerLinkBatchSize is how many unlinked sales or invoices are read at a time
+	customerLinkBatchSize = 500
+	// maxCustomerDuplicatesListed caps the duplicate suggestions listed at once
+	maxCustomerDuplicatesListed = 100
+)
+
+// CustomerUseCase links sales and invoices to customers, suggests customers that look like the
+// same person, and merges them
+type CustomerUseCase struct {
+	database     ports.DatabasePort
+	customerRepo repositories.CustomerRepository
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewCustomerUseCase creates a new customer use case
+func NewCustomerUseCase(
+	database ports.DatabasePort,
+	customerRepo repositories.CustomerRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *CustomerUseCase {
+	return &CustomerUseCase{
+		database:     database,
+		customerRepo: customerRepo,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// MergeCustomersRequest represents a request to merge a duplicate customer into a survivor
+type MergeCustomersRequest struct {
+	SurvivorID  uuid.UUID `json:"survivor_id" validate:"required"`
+	DuplicateID uuid.UUID `json:"duplicate_id" validate:"required"`
+}
+
+// CustomerDuplicateResponse represents a duplicate suggestion with both of its customers
+type CustomerDuplicateResponse struct {
+	*entities.CustomerDuplicate
+	Customer  *entities.Customer `json:"customer"`
+	Duplicate *entities.Customer `json:"duplicate"`
+}
+
+// CustomerDuplicateListResponse represents the pending duplicate suggestions of a tenant
+type CustomerDuplicateListResponse struct {
+	Duplicates []*CustomerDuplicateResponse `json:"duplicates"`
+}
+
+// MergeCustomersResponse represents the result of merging two customers
+type MergeCustomersResponse struct {
+	Survivor *entities.Customer                `json:"survivor"`
+	Merged   *entities.Customer                `json:"merged"`
+	Relinked *repositories.CustomerMergeCounts `json:"relinked"`
+}
+
+// DetectDuplicates links settled sales and invoices to customers by their contact details, then
+// suggests merging each tenant's customers that look like the same person. Pairs already
+// suggested, merged or dismissed are not suggested again.
+func (uc *CustomerUseCase) DetectDuplicates(ctx context.Context) error {
+	for _, document := range []repositories.CustomerDocument{repositories.CustomerDocumentSale, repositories.CustomerDocumentInvoice} {
+		linked, err := uc.linkDocuments(ctx, document)
+		if err != nil {
+			return err
+		}
+		if linked > 0 {
+			uc.logger.WithFields(map[string]interface{}{
+				"document": document,
+				"count":    linked,
+			}).Info("Documents linked to customers")
+		}
+	}
+
+	tenantIDs, err := uc.customerRepo.ListTenantIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list customer tenants: %w", err)
+	}
+
+	suggested := 0
+	for _, tenantID := range tenantIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		count, err := uc.detectTenantDuplicates(ctx, tenantID)
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": tenantID,
+				"error":     err.Error(),
+			}).Error("Failed to detect duplicate customers")
+			continue
+		}
+		suggested += count
+	}
+
+	if suggested > 0 {
+		uc.logger.WithField("count", suggested).Info("Duplicate customers detected")
+	}
+
+	return nil
+}
+
+// detectTenantDuplicates records the duplicate suggestions among a tenant's customers
+func (uc *CustomerUseCase) detectTenantDuplicates(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	ctx = entities.WithTenantScope(ctx, tenantID)
+
+	customers, err := uc.customerRepo.ListActive(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	duplicates := entities.FindCustomerDuplicates(customers, time.Now())
+	if err := uc.customerRepo.SaveDuplicates(ctx, duplicates); err != nil {
+		return 0, err
+	}
+
+	return len(duplicates), nil
+}
+
+// linkDocuments links the settled documents of one kind that are not linked yet to the customer
+// with their contact details, creating customers as needed
+func (uc *CustomerUseCase) linkDocuments(ctx context.Context, document repositories.CustomerDocument) (int, error) {
+	// Customers resolved during this run, by tenant and contact key
+	resolved := make(map[string]*entities.Customer)
+
+	linked := 0
+	afterID := uuid.Nil
+	for {
+		if ctx.Err() != nil {
+			return linked, ctx.Err()
+		}
+
+		contacts, err := uc.customerRepo.ListUnlinkedContacts(ctx, document, afterID, customerLinkBatchSize)
+		if err != nil {
+			return linked, err
+		}
+
+		byCustomer := make(map[uuid.UUID][]uuid.UUID)
+		for _, contact := range contacts {
+			afterID = contact.DocumentID
+
+			customer, err := uc.resolveCustomer(ctx, contact, resolved)
+			if err != nil {
+				// Contact details that identify no one, such as a malformed email, stay unlinked
+				if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeValidation {
+					continue
+				}
+				return linked, err
+			}
+			byCustomer[customer.ID] = append(byCustomer[customer.ID], contact.DocumentID)
+		}
+
+		for customerID, documentIDs := range byCustomer {
+			if err := uc.customerRepo.LinkDocuments(ctx, document, customerID, documentIDs); err != nil {
+				return linked, err
+			}
+			linked += len(documentIDs)
+		}
+
+		if len(contacts) < customerLinkBatchSize {
+			return linked, nil
+		}
+	}
+}
+
+// resolveCustomer finds the customer with a document's contact details, following merges to the
+// survivor, or creates one
+func (uc *CustomerUseCase) resolveCustomer(ctx context.Context, contact *repositories.CustomerContact, resolved map[string]*entities.Customer) (*entities.Customer, error) {
+	candidate, err := entities.NewCustomer(contact.TenantID, contact.Name, contact.Email, contact.Phone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	key := contact.TenantID.String() + "|email:" + candidate.EmailKey
+	if candidate.EmailKey == "" {
+		key = contact.TenantID.String() + "|phone:" + candidate.PhoneKey
+	}
+	if customer, ok := resolved[key]; ok {
+		return customer, nil
+	}
+
+	ctx = entities.WithTenantScope(ctx, contact.TenantID)
+	customer, err := uc.customerRepo.GetByContact(ctx, contact.TenantID, candidate.EmailKey, candidate.PhoneKey)
+	if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+		customer = candidate
+		err = uc.customerRepo.Create(ctx, customer)
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			// Created concurrently by another run
+			customer, err = uc.customerRepo.GetByContact(ctx, contact.TenantID, candidate.EmailKey, candidate.PhoneKey)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if customer.IsMerged() {
+		if customer, err = uc.customerRepo.GetByID(ctx, *customer.MergedInto); err != nil {
+			return nil, err
+		}
+	}
+
+	resolved[key] = customer
+	return customer, nil
+}
+
+// GetCustomer retrieves a customer by ID
+func (uc *CustomerUseCase) GetCustomer(ctx context.Context, tenantID, customerID uuid.UUID) (*entities.Customer, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, customerID)
+	if err != nil || customer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer")
+	}
+
+	return customer, nil
+}
+
+// ListDuplicates retrieves the pending duplicate suggestions of a tenant, best match first
+func (uc *CustomerUseCase) ListDuplicates(ctx context.Context, tenantID uuid.UUID) (*CustomerDuplicateListResponse, error) {
+	duplicates, err := uc.customerRepo.ListPendingDuplicates(ctx, tenantID, maxCustomerDuplicatesListed)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list customer duplicates")
+		return nil, errors.NewInternalError("failed to list customer duplicates", err)
+	}
+
+	response := &CustomerDuplicateListResponse{Duplicates: make([]*CustomerDuplicateResponse, 0, len(duplicates))}
+	for _, duplicate := range duplicates {
+		customer, err := uc.GetCustomer(ctx, tenantID, duplicate.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		other, err := uc.GetCustomer(ctx, tenantID, duplicate.DuplicateID)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Duplicates = append(response.Duplicates, &CustomerDuplicateResponse{
+			CustomerDuplicate: duplicate,
+			Customer:          customer,
+			Duplicate:         other,
+		})
+	}
+
+	return response, nil
+}
+
+// DismissDuplicate records that the customers of a duplicate suggestion are different people
+func (uc *CustomerUseCase) DismissDuplicate(ctx context.Context, tenantID, userID, duplicateID uuid.UUID) (*entities.CustomerDuplicate, error) {
+	duplicate, err := uc.customerRepo.GetDuplicateByID(ctx, duplicateID)
+	if err != nil || duplicate.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer duplicate")
+	}
+
+	if err := duplicate.Dismiss(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.customerRepo.UpdateDuplicate(ctx, duplicate); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"duplicate_id": duplicateID,
+			"error":        err.Error(),
+		}).Error("Failed to dismiss customer duplicate")
+		return nil, errors.NewInternalError("failed to dismiss customer duplicate", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "dismiss_duplicate",
+		Resource:   "customer",
+		ResourceID: duplicate.CustomerID.String(),
+		NewValue: map[string]interface{}{
+			"duplicate_id": duplicate.DuplicateID,
+			"score":        duplicate.Score,
+			"reasons":      duplicate.Reasons,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return duplicate, nil
+}
+
+// MergeCustomers merges a duplicate customer into the surviving one. The survivor takes over the
+// duplicate's sales and invoices, and any contact details it lacks; the documents keep their own
+// snapshot of the customer's details. The duplicate is kept, marked as merged, for the audit trail.
+func (uc *CustomerUseCase) MergeCustomers(ctx context.Context, tenantID, userID uuid.UUID, req MergeCustomersRequest) (*MergeCustomersResponse, error) {
+	if req.SurvivorID == req.DuplicateID {
+		return nil, errors.NewValidationError("invalid merge", "a customer cannot be merged into itself")
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	customerRepo := tx.GetCustomerRepository()
+
+	// Lock both customers in ID order, so opposite merges of the same pair cannot deadlock
+	locked := make(map[uuid.UUID]*entities.Customer, 2)
+	first, second := req.SurvivorID, req.DuplicateID
+	if second.String() < first.String() {
+		first, second = second, first
+	}
+	for _, id := range []uuid.UUID{first, second} {
+		customer, err := customerRepo.GetByIDForUpdate(ctx, id)
+		if err != nil || customer.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("customer")
+		}
+		locked[id] = customer
+	}
+	survivor, duplicate := locked[req.SurvivorID], locked[req.DuplicateID]
+	survivorBefore, duplicateBefore := *survivor, *duplicate
+
+	if err := duplicate.MergeInto(survivor, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// The duplicate is marked merged first, so the contact details the survivor takes over are
+	// free for it
+	for _, customer := range []*entities.Customer{duplicate, survivor} {
+		if err := customerRepo.Update(ctx, customer); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"customer_id": customer.ID,
+				"error":       err.Error(),
+			}).Error("Failed to update customer")
+			return nil, errors.NewInternalError("failed to merge customers", err)
+		}
+	}
+
+	relinked, err := customerRepo.Relink(ctx, duplicate.ID, survivor.ID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"survivor_id":  survivor.ID,
+			"duplicate_id": duplicate.ID,
+			"error":        err.Error(),
+		}).Error("Failed to relink customer records")
+		return nil, errors.NewInternalError("failed to merge customers", err)
+	}
+
+	if err := customerRepo.ResolveMergedDuplicates(ctx, survivor.ID, duplicate.ID, userID, *duplicate.MergedAt); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"survivor_id":  survivor.ID,
+			"duplicate_id": duplicate.ID,
+			"error":        err.Error(),
+		}).Error("Failed to resolve customer duplicates")
+		return nil, errors.NewInternalError("failed to merge customers", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	merged := map[string]interface{}{
+		"survivor_id":       survivor.ID,
+		"merged_id":         duplicate.ID,
+		"sales_relinked":    relinked.Sales,
+		"invoices_relinked": relinked.Invoices,
+	}
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "merge",
+		Resource:   "customer",
+		ResourceID: survivor.ID.String(),
+		OldValue:   customerAuditValue(&survivorBefore),
+		NewValue:   merged,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "merged",
+		Resource:   "customer",
+		ResourceID: duplicate.ID.String(),
+		OldValue:   customerAuditValue(&duplicateBefore),
+		NewValue:   merged,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"survivor_id":       survivor.ID,
+		"merged_id":         duplicate.ID,
+		"sales_relinked":    relinked.Sales,
+		"invoices_relinked": relinked.Invoices,
+		"user_id":           userID,
+	}).Info("Customers merged")
+
+	return &MergeCustomersResponse{
+		Survivor: survivor,
+		Merged:   duplicate,
+		Relinked: relinked,
+	}, nil
+}
+
+// customerAuditValue returns the details of a customer recorded in the audit trail
+func customerAuditValue(customer *entities.Customer) map[string]interface{} {
+	return map[string]interface{}{
+		"id":    customer.ID,
+		"name":  customer.Name,
+		"email": customer.Email,
+		"phone": customer.Phone,
+	}
+}
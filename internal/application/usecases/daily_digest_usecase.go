@@ -0,0 +1,293 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+//go:embed templates/daily_digest.html
+var dailyDigestTemplateFS embed.FS
+
+// dailyDigestTemplate renders the end-of-day digest email
+var dailyDigestTemplate = template.Must(
+	template.New("daily_digest.html").Funcs(template.FuncMap{
+		"pct": func(d decimal.Decimal) string { return d.StringFixed(1) + "%" },
+	}).ParseFS(dailyDigestTemplateFS, "templates/daily_digest.html"),
+)
+
+// DailyDigestUseCase handles the opt-in end-of-day digest email sent to tenant owners
+type DailyDigestUseCase struct {
+	settingRepo   repositories.DailyDigestSettingRepository
+	tenantRepo    repositories.TenantRepository
+	reportUseCase *ReportUseCase
+	notification  ports.NotificationPort
+	audit         ports.AuditPort
+	logger        logger.Logger
+	dashboardURL  string
+}
+
+// NewDailyDigestUseCase creates a new daily digest use case
+func NewDailyDigestUseCase(
+	settingRepo repositories.DailyDigestSettingRepository,
+	tenantRepo repositories.TenantRepository,
+	reportUseCase *ReportUseCase,
+	notification ports.NotificationPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+	dashboardURL string,
+) *DailyDigestUseCase {
+	return &DailyDigestUseCase{
+		settingRepo:   settingRepo,
+		tenantRepo:    tenantRepo,
+		reportUseCase: reportUseCase,
+		notification:  notification,
+		audit:         audit,
+		logger:        logger,
+		dashboardURL:  strings.TrimRight(dashboardURL, "/"),
+	}
+}
+
+// UpdateDailyDigestSettingsRequest represents update daily digest settings request
+type UpdateDailyDigestSettingsRequest struct {
+	IsEnabled  bool     `json:"is_enabled"`
+	Recipients []string `json:"recipients"`
+	SendHour   int      `json:"send_hour"`
+	Timezone   string   `json:"timezone"`
+}
+
+// dailyDigestLinks holds the dashboard deep links rendered into the digest
+type dailyDigestLinks struct {
+	Reports         string
+	Products        string
+	LowStock        string
+	OverdueInvoices string
+	Settings        string
+}
+
+// dailyDigestView is the data passed to the digest template
+type dailyDigestView struct {
+	TenantName string
+	DateLabel  string
+	Summary    *DailySummaryResponse
+	Links      dailyDigestLinks
+	Currency   entities.CurrencyFormat
+}
+
+// GetSettings returns the tenant's digest settings, or the disabled defaults if none were saved
+func (uc *DailyDigestUseCase) GetSettings(ctx context.Context, tenantID uuid.UUID) (*entities.DailyDigestSetting, error) {
+	setting, err := uc.settingRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return entities.NewDailyDigestSetting(tenantID, uuid.Nil)
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get daily digest settings")
+		return nil, errors.NewInternalError("failed to get daily digest settings", err)
+	}
+
+	return setting, nil
+}
+
+// UpdateSettings opts the tenant in or out of the digest and updates its recipients and schedule
+func (uc *DailyDigestUseCase) UpdateSettings(ctx context.Context, tenantID, userID uuid.UUID, req UpdateDailyDigestSettingsRequest) (*entities.DailyDigestSetting, error) {
+	setting, err := uc.GetSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := map[string]interface{}{
+		"is_enabled": setting.IsEnabled,
+		"recipients": setting.Recipients,
+		"send_hour":  setting.SendHour,
+		"timezone":   setting.Timezone,
+	}
+
+	if err := setting.Configure(req.IsEnabled, req.Recipients, req.SendHour, req.Timezone, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.settingRepo.Save(ctx, setting); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to save daily digest settings")
+		return nil, errors.NewInternalError("failed to save daily digest settings", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "daily_digest_setting",
+		ResourceID: setting.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"is_enabled": setting.IsEnabled,
+			"recipients": setting.Recipients,
+			"send_hour":  setting.SendHour,
+			"timezone":   setting.Timezone,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"is_enabled": setting.IsEnabled,
+		"user_id":    userID,
+	}).Info("Daily digest settings updated")
+
+	return setting, nil
+}
+
+// PreviewDigest renders the digest for a business date without sending it.
+// A zero date previews the current day in the tenant's digest time zone.
+func (uc *DailyDigestUseCase) PreviewDigest(ctx context.Context, tenantID uuid.UUID, date time.Time) (string, error) {
+	setting, err := uc.GetSettings(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	loc := setting.Location()
+	if date.IsZero() {
+		date = time.Now().In(loc)
+	}
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+	_, body, err := uc.buildDigest(ctx, tenantID, date)
+	if err != nil {
+		return "", err
+	}
+
+	return body, nil
+}
+
+// SendDueDigests sends the digest to every enabled tenant whose local send hour has passed
+// and who has not yet received the digest for the day. It is run periodically by the scheduler.
+func (uc *DailyDigestUseCase) SendDueDigests(ctx context.Context) error {
+	settings, err := uc.settingRepo.ListEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list daily digest settings: %w", err)
+	}
+
+	now := time.Now()
+	sent := 0
+	for _, setting := range settings {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		date, due := setting.DueDate(now)
+		if !due {
+			continue
+		}
+
+		if err := uc.sendDigest(ctx, setting, date); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": setting.TenantID,
+				"date":      date.Format("2006-01-02"),
+				"error":     err.Error(),
+			}).Error("Failed to send daily digest")
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		uc.logger.WithField("count", sent).Info("Daily digests sent")
+	}
+
+	return nil
+}
+
+// sendDigest claims, builds and emails a single tenant's digest. The claim keeps several
+// server instances from sending the same digest; it is released if the send fails.
+func (uc *DailyDigestUseCase) sendDigest(ctx context.Context, setting *entities.DailyDigestSetting, date time.Time) error {
+	claimed, err := uc.settingRepo.ClaimDigest(ctx, setting.ID, date)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	subject, body, err := uc.buildDigest(ctx, setting.TenantID, date)
+	if err == nil {
+		err = uc.notification.SendEmail(ctx, ports.EmailNotification{
+			To:       setting.Recipients,
+			Subject:  subject,
+			Body:     body,
+			IsHTML:   true,
+			Priority: "normal",
+		})
+	}
+
+	if err != nil {
+		if releaseErr := uc.settingRepo.ReleaseDigest(ctx, setting.ID, date, setting.LastDigestDate); releaseErr != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": setting.TenantID,
+				"error":     releaseErr.Error(),
+			}).Error("Failed to release daily digest claim")
+		}
+		return err
+	}
+
+	setting.MarkSent(date)
+	return nil
+}
+
+// buildDigest renders the digest subject and HTML body for a tenant's business date
+func (uc *DailyDigestUseCase) buildDigest(ctx context.Context, tenantID uuid.UUID, date time.Time) (string, string, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", "", errors.NewNotFoundError("tenant")
+	}
+
+	summary, err := uc.reportUseCase.GetDailySummary(ctx, tenantID, date)
+	if err != nil {
+		return "", "", err
+	}
+
+	view := dailyDigestView{
+		TenantName: tenant.Name,
+		DateLabel:  date.Format("Monday, 2 January 2006"),
+		Summary:    summary,
+		Links:      uc.digestLinks(date),
+		Currency:   tenant.GetCurrencyFormat(),
+	}
+
+	var buf bytes.Buffer
+	if err := dailyDigestTemplate.Execute(&buf, view); err != nil {
+		return "", "", errors.NewInternalError("failed to render daily digest", err)
+	}
+
+	subject := fmt.Sprintf("%s daily digest for %s: %s revenue", tenant.Name, date.Format("2 Jan 2006"), view.Currency.Format(summary.Sales.TotalRevenue))
+	return subject, buf.String(), nil
+}
+
+// digestLinks builds the dashboard deep links for a business date
+func (uc *DailyDigestUseCase) digestLinks(date time.Time) dailyDigestLinks {
+	day := date.Format("2006-01-02")
+	return dailyDigestLinks{
+		Reports:         fmt.Sprintf("%s/reports?from_date=%s&to_date=%s", uc.dashboardURL, day, day),
+		Products:        uc.dashboardURL + "/products",
+		LowStock:        uc.dashboardURL + "/stock?status=low",
+		OverdueInvoices: uc.dashboardURL + "/invoices?status=overdue",
+		Settings:        uc.dashboardURL + "/settings/digest",
+	}
+}
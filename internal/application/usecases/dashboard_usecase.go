@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// inventoryDashboardCacheTTL bounds how stale the inventory landing page figures can be
+	inventoryDashboardCacheTTL = 45 * time.Second
+	// businessDashboardCacheTTL bounds how stale the main dashboard figures can be
+	businessDashboardCacheTTL = 60 * time.Second
+
+	// dashboardTrendDays is the length of the periods compared by the week-over-week trend
+	dashboardTrendDays = 7
+	// dashboardListLimit caps the top products and overdue invoices listed on the dashboard
+	dashboardListLimit = 5
+)
+
+// DashboardUseCase serves the aggregate figures shown on dashboard landing pages.
+// Figures are computed with aggregate queries and cached briefly, so they are soft real-time.
+type DashboardUseCase struct {
+	saleRepo    repositories.SaleRepository
+	stockRepo   repositories.StockRepository
+	invoiceRepo repositories.InvoiceRepository
+	cache       ports.CachePort
+	logger      logger.Logger
+}
+
+// NewDashboardUseCase creates a new dashboard use case
+func NewDashboardUseCase(
+	saleRepo repositories.SaleRepository,
+	stockRepo repositories.StockRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	cache ports.CachePort,
+	logger logger.Logger,
+) *DashboardUseCase {
+	return &DashboardUseCase{
+		saleRepo:    saleRepo,
+		stockRepo:   stockRepo,
+		invoiceRepo: invoiceRepo,
+		cache:       cache,
+		logger:      logger,
+	}
+}
+
+// BusinessDashboardResponse represents the figures shown on the main dashboard
+type BusinessDashboardResponse struct {
+	Date          time.Time                           `json:"date"`
+	Today         *repositories.SalesSummary          `json:"today"`
+	WeekOverWeek  DashboardTrend                      `json:"week_over_week"`
+	TopProducts   []*repositories.ProductSalesStats   `json:"top_products"` // Best sellers of the last 7 days
+	LowStockCount int                                 `json:"low_stock_count"`
+	OutOfStock    int                                 `json:"out_of_stock_count"`
+	Overdue       *repositories.OverdueInvoiceSummary `json:"overdue_invoices"`
+	GeneratedAt   time.Time                           `json:"generated_at"`
+}
+
+// DashboardTrend compares the sales of the 7 days up to and including today with the 7 days before
+type DashboardTrend struct {
+	ThisWeek             *repositories.SalesSummary `json:"this_week"`
+	LastWeek             *repositories.SalesSummary `json:"last_week"`
+	RevenueChangePercent decimal.Decimal            `json:"revenue_change_percent"` // Zero when last week had no revenue
+	SalesChangePercent   decimal.Decimal            `json:"sales_change_percent"`   // Zero when last week had no completed sales
+}
+
+// GetBusinessDashboard returns today's sales, the week-over-week trend, top products, stock
+// shortages and overdue invoices in one call, served from cache when fresh
+func (uc *DashboardUseCase) GetBusinessDashboard(ctx context.Context, tenantID uuid.UUID, now time.Time) (*BusinessDashboardResponse, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	cacheKey := businessDashboardCacheKey(tenantID, today)
+
+	var cached BusinessDashboardResponse
+	if err := uc.cache.Get(ctx, cacheKey, &cached); err == nil && !cached.GeneratedAt.IsZero() {
+		return &cached, nil
+	}
+
+	tomorrow := today.AddDate(0, 0, 1)
+	weekStart := tomorrow.AddDate(0, 0, -dashboardTrendDays)
+	lastWeekStart := weekStart.AddDate(0, 0, -dashboardTrendDays)
+
+	todaySales, err := uc.saleRepo.GetSalesSummary(ctx, tenantID, today, tomorrow)
+	if err != nil {
+		return nil, uc.dashboardError(tenantID, "Failed to get today's sales summary", err)
+	}
+
+	thisWeek, err := uc.saleRepo.GetSalesSummary(ctx, tenantID, weekStart, tomorrow)
+	if err != nil {
+		return nil, uc.dashboardError(tenantID, "Failed to get this week's sales summary", err)
+	}
+
+	lastWeek, err := uc.saleRepo.GetSalesSummary(ctx, tenantID, lastWeekStart, weekStart)
+	if err != nil {
+		return nil, uc.dashboardError(tenantID, "Failed to get last week's sales summary", err)
+	}
+
+	topProducts, err := uc.saleRepo.GetTopProducts(ctx, tenantID, weekStart, tomorrow, dashboardListLimit)
+	if err != nil {
+		return nil, uc.dashboardError(tenantID, "Failed to get top products", err)
+	}
+
+	inventory, err := uc.stockRepo.GetInventorySummary(ctx, tenantID)
+	if err != nil {
+		return nil, uc.dashboardError(tenantID, "Failed to get inventory summary", err)
+	}
+
+	overdue, err := uc.invoiceRepo.GetOverdueSummary(ctx, tenantID, now, dashboardListLimit)
+	if err != nil {
+		return nil, uc.dashboardError(tenantID, "Failed to get overdue invoices", err)
+	}
+
+	response := &BusinessDashboardResponse{
+		Date:  today,
+		Today: todaySales,
+		WeekOverWeek: DashboardTrend{
+			ThisWeek:             thisWeek,
+			LastWeek:             lastWeek,
+			RevenueChangePercent: percentOf(thisWeek.TotalRevenue.Sub(lastWeek.TotalRevenue), lastWeek.TotalRevenue),
+			SalesChangePercent: percentOf(
+				decimal.NewFromInt(int64(thisWeek.CompletedSales-lastWeek.CompletedSales)),
+				decimal.NewFromInt(int64(lastWeek.CompletedSales)),
+			),
+		},
+		TopProducts:   topProducts,
+		LowStockCount: inventory.LowStockCount,
+		OutOfStock:    inventory.OutOfStockCount,
+		Overdue:       overdue,
+		GeneratedAt:   time.Now(),
+	}
+
+	if err := uc.cache.Set(ctx, cacheKey, response, businessDashboardCacheTTL); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to cache business dashboard")
+	}
+
+	return response, nil
+}
+
+// InventoryDashboardResponse represents the figures shown on the inventory landing page
+type InventoryDashboardResponse struct {
+	repositories.InventorySummary
+	ItemsInTransit int       `json:"items_in_transit"` // Units on inbound shipments; no shipments are tracked yet
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// GetInventoryDashboard returns the tenant's inventory figures, served from cache when fresh
+func (uc *DashboardUseCase) GetInventoryDashboard(ctx context.Context, tenantID uuid.UUID) (*InventoryDashboardResponse, error) {
+	cacheKey := inventoryDashboardCacheKey(tenantID)
+
+	var cached InventoryDashboardResponse
+	if err := uc.cache.Get(ctx, cacheKey, &cached); err == nil && !cached.GeneratedAt.IsZero() {
+		return &cached, nil
+	}
+
+	summary, err := uc.stockRepo.GetInventorySummary(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get inventory summary")
+		return nil, errors.NewInternalError("failed to get inventory dashboard", err)
+	}
+
+	response := &InventoryDashboardResponse{
+		InventorySummary: *summary,
+		GeneratedAt:      time.Now(),
+	}
+
+	if err := uc.cache.Set(ctx, cacheKey, response, inventoryDashboardCacheTTL); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to cache inventory dashboard")
+	}
+
+	return response, nil
+}
+
+// Helper functions
+
+// dashboardError logs a failed dashboard query and wraps it as an internal error
+func (uc *DashboardUseCase) dashboardError(tenantID uuid.UUID, message string, err error) error {
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"error":     err.Error(),
+	}).Error(message)
+	return errors.NewInternalError("failed to get dashboard", err)
+}
+
+func businessDashboardCacheKey(tenantID uuid.UUID, date time.Time) string {
+	return fmt.Sprintf("dashboard:business:%s:%s", tenantID, date.Format("2006-01-02"))
+}
+
+func inventoryDashboardCacheKey(tenantID uuid.UUID) string {
+	return fmt.Sprintf("dashboard:inventory:%s", tenantID)
+}
@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// DeliveryPolicyUseCase handles the tenants' retry policies for outbound webhooks and emails.
+// It sends emails under the tenant's policy and pauses destinations that keep failing,
+// notifying the tenant's admins when it does.
+type DeliveryPolicyUseCase struct {
+	policyRepo      repositories.DeliveryPolicyRepository
+	suppressionRepo repositories.EmailSuppressionRepository
+	tenantRepo      repositories.TenantRepository
+	notification    ports.NotificationPort
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewDeliveryPolicyUseCase creates a new delivery policy use case
+func NewDeliveryPolicyUseCase(
+	policyRepo repositories.DeliveryPolicyRepository,
+	suppressionRepo repositories.EmailSuppressionRepository,
+	tenantRepo repositories.TenantRepository,
+	notification ports.NotificationPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *DeliveryPolicyUseCase {
+	return &DeliveryPolicyUseCase{
+		policyRepo:      policyRepo,
+		suppressionRepo: suppressionRepo,
+		tenantRepo:      tenantRepo,
+		notification:    notification,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// UpdateDeliveryPolicyRequest represents update delivery policy request
+type UpdateDeliveryPolicyRequest struct {
+	Webhook         entities.RetryPolicy `json:"webhook"`
+	Email           entities.RetryPolicy `json:"email"`
+	AlertRecipients []string             `json:"alert_recipients"`
+}
+
+// GetPolicy returns the tenant's delivery policy, or the defaults if none was saved
+func (uc *DeliveryPolicyUseCase) GetPolicy(ctx context.Context, tenantID uuid.UUID) (*entities.DeliveryPolicy, error) {
+	policy, err := uc.policyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return entities.NewDeliveryPolicy(tenantID, uuid.Nil)
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get delivery policy")
+		return nil, errors.NewInternalError("failed to get delivery policy", err)
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy changes the tenant's webhook and email retry policies and pause alert recipients
+func (uc *DeliveryPolicyUseCase) UpdatePolicy(ctx context.Context, tenantID, userID uuid.UUID, req UpdateDeliveryPolicyRequest) (*entities.DeliveryPolicy, error) {
+	policy, err := uc.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := map[string]interface{}{
+		"webhook":          policy.Webhook,
+		"email":            policy.Email,
+		"alert_recipients": policy.AlertRecipients,
+	}
+
+	if err := policy.Configure(req.Webhook, req.Email, req.AlertRecipients, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.policyRepo.Save(ctx, policy); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to save delivery policy")
+		return nil, errors.NewInternalError("failed to save delivery policy", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "delivery_policy",
+		ResourceID: policy.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"webhook":          policy.Webhook,
+			"email":            policy.Email,
+			"alert_recipients": policy.AlertRecipients,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"user_id":   userID,
+	}).Info("Delivery policy updated")
+
+	return policy, nil
+}
+
+// SendEmail sends an email to a single recipient under the tenant's email retry policy. Each
+// attempt is bounded by the policy's timeout and failed attempts are retried after its
+// backoff. A recipient that fails too many emails in a row is added to the tenant's
+// suppression list on behalf of the user sending, so bulk sends skip it, and the tenant's
+// admins are notified.
+func (uc *DeliveryPolicyUseCase) SendEmail(ctx context.Context, tenantID, userID uuid.UUID, recipient string, send func(ctx context.Context) error) error {
+	policy, err := uc.GetPolicy(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	retry := policy.Email
+
+	var sendErr error
+	for attempt := 1; attempt <= retry.MaxAttempts; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, retry.AttemptTimeout())
+		sendErr = send(attemptCtx)
+		cancel()
+		if sendErr == nil || attempt == retry.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retry.Backoff(attempt)):
+		}
+	}
+
+	uc.RecordEmailResult(ctx, policy, userID, recipient, sendErr)
+	return sendErr
+}
+
+// RecordEmailResult tracks the outcome of an email once all of its attempts are done. A sent
+// email clears the recipient's failures; a failed one counts towards pausing the recipient.
+func (uc *DeliveryPolicyUseCase) RecordEmailResult(ctx context.Context, policy *entities.DeliveryPolicy, userID uuid.UUID, recipient string, sendErr error) {
+	if sendErr == nil {
+		uc.ClearEmailFailures(ctx, policy.TenantID, recipient)
+		return
+	}
+
+	email := entities.NormalizeEmail(recipient)
+	failures, err := uc.policyRepo.RecordEmailFailure(ctx, policy.TenantID, email, sendErr.Error(), time.Now())
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": policy.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to record email failure")
+		return
+	}
+	if policy.Email.ShouldPause(failures) {
+		uc.pauseRecipient(ctx, policy, userID, email, failures, sendErr)
+	}
+}
+
+// ClearEmailFailures forgets the failed emails to an address, e.g. when it is removed from the
+// suppression list, so it is only paused again after failing as many times in a row
+func (uc *DeliveryPolicyUseCase) ClearEmailFailures(ctx context.Context, tenantID uuid.UUID, email string) error {
+	if err := uc.policyRepo.ResetEmailFailures(ctx, tenantID, entities.NormalizeEmail(email)); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to reset email failures")
+		return errors.NewInternalError("failed to reset email failures", err)
+	}
+
+	return nil
+}
+
+// NotifyDestinationPaused emails the tenant's pause alert recipients that a destination was
+// paused. Failures are logged, as the destination stays paused either way.
+func (uc *DeliveryPolicyUseCase) NotifyDestinationPaused(ctx context.Context, policy *entities.DeliveryPolicy, destination, reason, resume string) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, policy.TenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": policy.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get tenant for pause alert")
+		return
+	}
+
+	recipients := policy.AlertRecipientsOr(tenant.Configuration.BusinessInfo.Email)
+	if len(recipients) == 0 {
+		uc.logger.WithField("tenant_id", policy.TenantID).Warn("No recipients for delivery pause alert")
+		return
+	}
+
+	body := fmt.Sprintf("Deliveries to %s for %s have been paused: %s.\n\n%s\n", destination, tenant.Name, reason, resume)
+	err = uc.notification.SendEmail(ctx, ports.EmailNotification{
+		To:       recipients,
+		Subject:  fmt.Sprintf("%s: deliveries to %s paused", tenant.Name, destination),
+		Body:     body,
+		Priority: "high",
+	})
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": policy.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to send delivery pause alert")
+	}
+}
+
+// pauseRecipient suppresses an address that failed too many emails in a row
+func (uc *DeliveryPolicyUseCase) pauseRecipient(ctx context.Context, policy *entities.DeliveryPolicy, userID uuid.UUID, email string, failures int, sendErr error) {
+	reason := fmt.Sprintf("paused after %d consecutive failed emails", failures)
+	suppression, err := entities.NewEmailSuppression(policy.TenantID, email, reason, userID)
+	if err != nil {
+		return
+	}
+
+	if err := uc.suppressionRepo.Create(ctx, suppression); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": policy.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to suppress failing email address")
+		return
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": policy.TenantID,
+		"email":     email,
+		"failures":  failures,
+	}).Warn("Email address paused after repeated failures")
+
+	uc.NotifyDestinationPaused(ctx, policy, email,
+		fmt.Sprintf("%s, the last one with: %s", reason, sendErr.Error()),
+		"Bulk emails skip this address until it is removed from the email suppression list.")
+}
@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// DocumentSignatureUseCase handles capturing customer signatures against documents
+type DocumentSignatureUseCase struct {
+	signatureRepo repositories.DocumentSignatureRepository
+	invoiceRepo   repositories.InvoiceRepository
+	audit         ports.AuditPort
+	logger        logger.Logger
+}
+
+// NewDocumentSignatureUseCase creates a new document signature use case
+func NewDocumentSignatureUseCase(
+	signatureRepo repositories.DocumentSignatureRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *DocumentSignatureUseCase {
+	return &DocumentSignatureUseCase{
+		signatureRepo: signatureRepo,
+		invoiceRepo:   invoiceRepo,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+// CaptureSignatureRequest represents a drawn signature submitted by a signing device
+type CaptureSignatureRequest struct {
+	SignerName  string `json:"signer_name" validate:"required"`
+	SignerTitle string `json:"signer_title,omitempty"`
+	Image       string `json:"image" validate:"required"` // Base64 PNG or JPEG, optionally as a data URL
+}
+
+// SignatureClientInfo identifies the device a signature was captured on
+type SignatureClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// CaptureInvoiceSignature records the customer's signature against an invoice
+func (uc *DocumentSignatureUseCase) CaptureInvoiceSignature(ctx context.Context, tenantID, userID, invoiceID uuid.UUID, req CaptureSignatureRequest, client SignatureClientInfo) (*entities.DocumentSignature, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+	if invoice.Status == entities.InvoiceStatusCancelled {
+		return nil, errors.NewValidationError("invalid invoice status", "cancelled invoices cannot be signed")
+	}
+
+	imageData, err := decodeSignatureImage(req.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := entities.NewDocumentSignature(
+		tenantID,
+		entities.SignableDocumentInvoice,
+		invoiceID,
+		req.SignerName,
+		req.SignerTitle,
+		imageData,
+		client.IPAddress,
+		client.UserAgent,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.signatureRepo.Create(ctx, signature); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to store invoice signature")
+		return nil, errors.NewInternalError("failed to store signature", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "sign",
+		Resource:   "invoice",
+		ResourceID: invoiceID.String(),
+		NewValue: map[string]interface{}{
+			"signature_id": signature.ID,
+			"signer_name":  signature.SignerName,
+			"signer_title": signature.SignerTitle,
+			"signed_at":    signature.SignedAt,
+		},
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"invoice_id":     invoiceID,
+		"invoice_number": invoice.InvoiceNumber,
+		"user_id":        userID,
+	}).Info("Invoice signature captured")
+
+	return signature, nil
+}
+
+// GetInvoiceSignature retrieves the signature captured against an invoice
+func (uc *DocumentSignatureUseCase) GetInvoiceSignature(ctx context.Context, tenantID, invoiceID uuid.UUID) (*entities.DocumentSignature, error) {
+	signature, err := uc.signatureRepo.GetByDocument(ctx, entities.SignableDocumentInvoice, invoiceID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalError("failed to get signature", err)
+	}
+	if signature.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("document signature")
+	}
+
+	return signature, nil
+}
+
+// Helper functions
+
+// decodeSignatureImage decodes a base64 image, accepting the data URL form sent by canvas-based signature pads
+func decodeSignatureImage(image string) ([]byte, error) {
+	image = strings.TrimSpace(image)
+	if strings.HasPrefix(image, "data:") {
+		if comma := strings.Index(image, ","); comma >= 0 {
+			image = image[comma+1:]
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(image)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid signature image", "image must be base64 encoded")
+	}
+
+	return data, nil
+}
+
+// attachInvoiceSignature loads the invoice's signature, if any, so it is rendered on the PDF
+func attachInvoiceSignature(ctx context.Context, signatureRepo repositories.DocumentSignatureRepository, invoice *entities.Invoice) {
+	if signatureRepo == nil {
+		return
+	}
+	if signature, err := signatureRepo.GetByDocument(ctx, entities.SignableDocumentInvoice, invoice.ID); err == nil && signature.TenantID == invoice.TenantID {
+		invoice.Signature = signature
+	}
+}
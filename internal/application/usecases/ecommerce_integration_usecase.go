@@ -0,0 +1,797 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// ecommerceStockPushTimeout bounds each request setting a stock level on a store
+const ecommerceStockPushTimeout = 15 * time.Second
+
+// EcommerceIntegrationUseCase handles the tenants' Shopify and WooCommerce integrations. Orders
+// the stores send by webhook become online sales with their stock reserved, are completed when
+// fulfilled and cancelled with the order; stock levels of linked products are pushed back to
+// the stores.
+type EcommerceIntegrationUseCase struct {
+	ecommerceRepo      repositories.EcommerceRepository
+	productRepo        repositories.ProductRepository
+	stockRepo          repositories.StockRepository
+	saleUseCase        *SaleUseCase
+	reservationUseCase *StockReservationUseCase
+	storefront         ports.StorefrontPort
+	audit              ports.AuditPort
+	logger             logger.Logger
+}
+
+// NewEcommerceIntegrationUseCase creates a new e-commerce integration use case
+func NewEcommerceIntegrationUseCase(
+	ecommerceRepo repositories.EcommerceRepository,
+	productRepo repositories.ProductRepository,
+	stockRepo repositories.StockRepository,
+	saleUseCase *SaleUseCase,
+	reservationUseCase *StockReservationUseCase,
+	storefront ports.StorefrontPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *EcommerceIntegrationUseCase {
+	return &EcommerceIntegrationUseCase{
+		ecommerceRepo:      ecommerceRepo,
+		productRepo:        productRepo,
+		stockRepo:          stockRepo,
+		saleUseCase:        saleUseCase,
+		reservationUseCase: reservationUseCase,
+		storefront:         storefront,
+		audit:              audit,
+		logger:             logger,
+	}
+}
+
+// CreateEcommerceIntegrationRequest represents create e-commerce integration request
+type CreateEcommerceIntegrationRequest struct {
+	Platform    entities.EcommercePlatform    `json:"platform" validate:"required"`
+	Name        string                        `json:"name" validate:"required"`
+	StoreURL    string                        `json:"store_url" validate:"required"`
+	LocationID  string                        `json:"location_id,omitempty"`
+	PushStock   bool                          `json:"push_stock"`
+	Credentials entities.EcommerceCredentials `json:"credentials"`
+}
+
+// UpdateEcommerceIntegrationRequest represents update e-commerce integration request.
+// Credentials left empty are kept.
+type UpdateEcommerceIntegrationRequest struct {
+	Name        string                        `json:"name" validate:"required"`
+	StoreURL    string                        `json:"store_url" validate:"required"`
+	LocationID  string                        `json:"location_id,omitempty"`
+	PushStock   bool                          `json:"push_stock"`
+	IsActive    bool                          `json:"is_active"`
+	Credentials entities.EcommerceCredentials `json:"credentials"`
+}
+
+// CreateEcommerceProductLinkRequest represents create e-commerce product link request
+type CreateEcommerceProductLinkRequest struct {
+	ProductID         uuid.UUID `json:"product_id" validate:"required"`
+	ExternalProductID string    `json:"external_product_id" validate:"required"`
+	ExternalVariantID string    `json:"external_variant_id,omitempty"`
+}
+
+// EcommerceOrderListResponse represents a page of e-commerce orders
+type EcommerceOrderListResponse struct {
+	Orders     []*entities.EcommerceOrder `json:"orders"`
+	Pagination utils.PaginationInfo       `json:"pagination"`
+}
+
+// ListIntegrations retrieves the tenant's e-commerce integrations
+func (uc *EcommerceIntegrationUseCase) ListIntegrations(ctx context.Context, tenantID uuid.UUID) ([]*entities.EcommerceIntegration, error) {
+	integrations, err := uc.ecommerceRepo.GetIntegrationsByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get e-commerce integrations")
+		return nil, errors.NewInternalError("failed to get e-commerce integrations", err)
+	}
+
+	return integrations, nil
+}
+
+// GetIntegration retrieves an e-commerce integration by ID
+func (uc *EcommerceIntegrationUseCase) GetIntegration(ctx context.Context, tenantID, integrationID uuid.UUID) (*entities.EcommerceIntegration, error) {
+	integration, err := uc.ecommerceRepo.GetIntegrationByID(ctx, integrationID)
+	if err != nil || integration.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("e-commerce integration")
+	}
+
+	return integration, nil
+}
+
+// CreateIntegration connects the tenant to a store. Sales and reservations of the store's
+// orders are made on behalf of the user creating the integration.
+func (uc *EcommerceIntegrationUseCase) CreateIntegration(ctx context.Context, tenantID, userID uuid.UUID, req CreateEcommerceIntegrationRequest) (*entities.EcommerceIntegration, error) {
+	integration, err := entities.NewEcommerceIntegration(tenantID, req.Platform, req.Name, req.StoreURL, req.LocationID, req.PushStock, req.Credentials, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ecommerceRepo.CreateIntegration(ctx, integration); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create e-commerce integration")
+		return nil, errors.NewInternalError("failed to create e-commerce integration", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "ecommerce_integration",
+		ResourceID: integration.ID.String(),
+		NewValue: map[string]interface{}{
+			"platform":   integration.Platform,
+			"name":       integration.Name,
+			"store_url":  integration.StoreURL,
+			"push_stock": integration.PushStock,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      tenantID,
+		"integration_id": integration.ID,
+		"platform":       integration.Platform,
+		"user_id":        userID,
+	}).Info("E-commerce integration created")
+
+	return integration, nil
+}
+
+// UpdateIntegration updates an e-commerce integration's settings and credentials
+func (uc *EcommerceIntegrationUseCase) UpdateIntegration(ctx context.Context, tenantID, userID, integrationID uuid.UUID, req UpdateEcommerceIntegrationRequest) (*entities.EcommerceIntegration, error) {
+	integration, err := uc.GetIntegration(ctx, tenantID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := integration.Update(req.Name, req.StoreURL, req.LocationID, req.PushStock, req.IsActive, req.Credentials); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ecommerceRepo.UpdateIntegration(ctx, integration); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"integration_id": integrationID,
+			"error":          err.Error(),
+		}).Error("Failed to update e-commerce integration")
+		return nil, errors.NewInternalError("failed to update e-commerce integration", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "ecommerce_integration",
+		ResourceID: integration.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":                integration.Name,
+			"store_url":           integration.StoreURL,
+			"push_stock":          integration.PushStock,
+			"is_active":           integration.IsActive,
+			"credentials_changed": req.Credentials != (entities.EcommerceCredentials{}),
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return integration, nil
+}
+
+// DeleteIntegration disconnects the tenant from a store. Sales made from its orders are kept.
+func (uc *EcommerceIntegrationUseCase) DeleteIntegration(ctx context.Context, tenantID, userID, integrationID uuid.UUID) error {
+	integration, err := uc.GetIntegration(ctx, tenantID, integrationID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.ecommerceRepo.DeleteIntegration(ctx, integration.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"integration_id": integrationID,
+			"error":          err.Error(),
+		}).Error("Failed to delete e-commerce integration")
+		return errors.NewInternalError("failed to delete e-commerce integration", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "ecommerce_integration",
+		ResourceID: integration.ID.String(),
+		OldValue: map[string]interface{}{
+			"platform":  integration.Platform,
+			"name":      integration.Name,
+			"store_url": integration.StoreURL,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      tenantID,
+		"integration_id": integration.ID,
+		"user_id":        userID,
+	}).Info("E-commerce integration deleted")
+
+	return nil
+}
+
+// ListOrders retrieves the orders an integration received, newest first
+func (uc *EcommerceIntegrationUseCase) ListOrders(ctx context.Context, tenantID, integrationID uuid.UUID, status *entities.EcommerceOrderStatus, pagination utils.PaginationInfo) (*EcommerceOrderListResponse, error) {
+	if _, err := uc.GetIntegration(ctx, tenantID, integrationID); err != nil {
+		return nil, err
+	}
+
+	filter := repositories.EcommerceOrderFilter{IntegrationID: integrationID, Status: status}
+	orders, paginationResult, err := uc.ecommerceRepo.ListOrders(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list e-commerce orders")
+		return nil, errors.NewInternalError("failed to list e-commerce orders", err)
+	}
+
+	return &EcommerceOrderListResponse{
+		Orders:     orders,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// ListProductLinks retrieves the product links of an integration
+func (uc *EcommerceIntegrationUseCase) ListProductLinks(ctx context.Context, tenantID, integrationID uuid.UUID) ([]*entities.EcommerceProductLink, error) {
+	if _, err := uc.GetIntegration(ctx, tenantID, integrationID); err != nil {
+		return nil, err
+	}
+
+	links, err := uc.ecommerceRepo.GetProductLinks(ctx, integrationID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get e-commerce product links")
+		return nil, errors.NewInternalError("failed to get product links", err)
+	}
+
+	return links, nil
+}
+
+// CreateProductLink links a product to its product or variant on the integration's store so
+// its stock level is pushed there. Products sold through the store are linked automatically.
+func (uc *EcommerceIntegrationUseCase) CreateProductLink(ctx context.Context, tenantID, userID, integrationID uuid.UUID, req CreateEcommerceProductLinkRequest) (*entities.EcommerceProductLink, error) {
+	integration, err := uc.GetIntegration(ctx, tenantID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.productRepo.GetByID(ctx, req.ProductID); err != nil {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	link, err := entities.NewEcommerceProductLink(integration, req.ProductID, req.ExternalProductID, req.ExternalVariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ecommerceRepo.CreateProductLink(ctx, link); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create e-commerce product link")
+		return nil, errors.NewInternalError("failed to create product link", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "ecommerce_product_link",
+		ResourceID: link.ID.String(),
+		NewValue: map[string]interface{}{
+			"integration_id":      integration.ID,
+			"product_id":          link.ProductID,
+			"external_product_id": link.ExternalProductID,
+			"external_variant_id": link.ExternalVariantID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return link, nil
+}
+
+// DeleteProductLink unlinks a product from the integration's store
+func (uc *EcommerceIntegrationUseCase) DeleteProductLink(ctx context.Context, tenantID, userID, integrationID, linkID uuid.UUID) error {
+	if _, err := uc.GetIntegration(ctx, tenantID, integrationID); err != nil {
+		return err
+	}
+
+	link, err := uc.ecommerceRepo.GetProductLinkByID(ctx, linkID)
+	if err != nil || link.IntegrationID != integrationID {
+		return errors.NewNotFoundError("product link")
+	}
+
+	if err := uc.ecommerceRepo.DeleteProductLink(ctx, link.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"link_id": linkID,
+			"error":   err.Error(),
+		}).Error("Failed to delete e-commerce product link")
+		return errors.NewInternalError("failed to delete product link", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "ecommerce_product_link",
+		ResourceID: link.ID.String(),
+		OldValue: map[string]interface{}{
+			"integration_id":      integrationID,
+			"product_id":          link.ProductID,
+			"external_product_id": link.ExternalProductID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return nil
+}
+
+// HandleWebhook processes an order webhook a store sent to an integration. Webhooks of other
+// topics and of inactive integrations are acknowledged and ignored, returning a nil order.
+//
+// Orders that cannot become sales, e.g. because none of their lines matches a product or
+// stock ran out, are recorded as failed and do not return an error, so the store does not
+// keep retrying them. Internal errors are returned so the store retries the webhook later.
+func (uc *EcommerceIntegrationUseCase) HandleWebhook(ctx context.Context, integrationID uuid.UUID, topic, signature string, body []byte) (*entities.EcommerceOrder, error) {
+	integration, err := uc.ecommerceRepo.GetIntegrationByID(ctx, integrationID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("e-commerce integration")
+	}
+	if !integration.VerifySignature(body, signature) {
+		return nil, errors.NewUnauthorizedError("invalid webhook signature")
+	}
+	if !integration.IsActive || !isEcommerceOrderTopic(topic) {
+		return nil, nil
+	}
+
+	// Webhooks are not authenticated as a user, so restrict data access to the store's tenant
+	ctx = entities.WithTenantScope(ctx, integration.TenantID)
+
+	storefrontOrder, err := entities.ParseStorefrontOrder(integration.Platform, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ecommerceRepo.RecordOrderReceived(ctx, integration.ID, time.Now()); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"integration_id": integration.ID,
+			"error":          err.Error(),
+		}).Warn("Failed to record e-commerce order received")
+	}
+
+	return uc.syncOrder(ctx, integration, storefrontOrder)
+}
+
+// syncOrder brings the sale of a storefront order in line with the order's status. Webhooks
+// may arrive more than once and out of order, so only moves forward are applied.
+func (uc *EcommerceIntegrationUseCase) syncOrder(ctx context.Context, integration *entities.EcommerceIntegration, storefrontOrder *entities.StorefrontOrder) (*entities.EcommerceOrder, error) {
+	order, err := uc.ecommerceRepo.GetOrderByExternalID(ctx, integration.ID, storefrontOrder.ExternalID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			uc.logger.WithFields(map[string]interface{}{
+				"integration_id": integration.ID,
+				"external_id":    storefrontOrder.ExternalID,
+				"error":          err.Error(),
+			}).Error("Failed to get e-commerce order")
+			return nil, errors.NewInternalError("failed to get e-commerce order", err)
+		}
+
+		order, err = entities.NewEcommerceOrder(integration, storefrontOrder.ExternalID, storefrontOrder.Number)
+		if err != nil {
+			return nil, err
+		}
+		// Orders cancelled before they were first received never hold any stock
+		if storefrontOrder.Status == entities.EcommerceOrderStatusCancelled {
+			order.SetStatus(entities.EcommerceOrderStatusCancelled)
+		}
+		if err := uc.ecommerceRepo.CreateOrder(ctx, order); err != nil {
+			if _, ok := errors.IsAppError(err); ok {
+				return nil, err
+			}
+			uc.logger.WithField("error", err.Error()).Error("Failed to create e-commerce order")
+			return nil, errors.NewInternalError("failed to create e-commerce order", err)
+		}
+		if order.Status == entities.EcommerceOrderStatusCancelled {
+			return order, nil
+		}
+
+		if err := uc.openOrder(ctx, integration, order, storefrontOrder); err != nil {
+			return nil, err
+		}
+	} else if order.Status == entities.EcommerceOrderStatusFailed && storefrontOrder.Status != entities.EcommerceOrderStatusCancelled {
+		// Retry failed orders on later webhooks, e.g. once the missing products were added
+		if err := uc.openOrder(ctx, integration, order, storefrontOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	if order.Status != entities.EcommerceOrderStatusOpen {
+		return order, nil
+	}
+
+	switch storefrontOrder.Status {
+	case entities.EcommerceOrderStatusFulfilled:
+		err = uc.fulfillOrder(ctx, integration, order)
+	case entities.EcommerceOrderStatusCancelled:
+		err = uc.cancelOrder(ctx, integration, order)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// openOrder creates the pending online sale of an order with the lines that match a product
+// by SKU, and reserves their stock until the order is fulfilled or cancelled
+func (uc *EcommerceIntegrationUseCase) openOrder(ctx context.Context, integration *entities.EcommerceIntegration, order *entities.EcommerceOrder, storefrontOrder *entities.StorefrontOrder) error {
+	order.SaleID = nil
+	order.ReservationID = nil
+
+	sale, err := uc.saleUseCase.CreateSale(ctx, integration.TenantID, integration.CreatedBy, CreateSaleRequest{
+		CustomerName:  storefrontOrder.CustomerName,
+		CustomerEmail: storefrontOrder.CustomerEmail,
+		CustomerPhone: storefrontOrder.CustomerPhone,
+		Channel:       entities.SaleChannelOnline,
+		Currency:      storefrontOrder.Currency,
+	})
+	if err != nil {
+		return uc.failOrder(ctx, order, nil, err)
+	}
+	order.SaleID = &sale.ID
+
+	var items []StockReservationItemRequest
+	var skipped []string
+	for _, line := range storefrontOrder.Lines {
+		if line.SKU == "" || line.Quantity <= 0 {
+			skipped = append(skipped, fmt.Sprintf("%q has no SKU", line.Name))
+			continue
+		}
+
+		product, err := uc.productRepo.GetByTenantAndSKU(ctx, integration.TenantID, line.SKU)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s matches no product", line.SKU))
+			continue
+		}
+
+		if _, err := uc.saleUseCase.AddSaleItem(ctx, integration.CreatedBy, sale.ID, AddSaleItemRequest{
+			ProductID: product.ID,
+			Quantity:  line.Quantity,
+		}); err != nil {
+			appErr, ok := errors.IsAppError(err)
+			if !ok || appErr.Type == errors.ErrorTypeInternal {
+				return uc.failOrder(ctx, order, sale, err)
+			}
+			skipped = append(skipped, fmt.Sprintf("%s: %s", line.SKU, appErr.Message))
+			continue
+		}
+
+		items = append(items, StockReservationItemRequest{ProductID: product.ID, Quantity: line.Quantity})
+		uc.learnProductLink(ctx, integration, product.ID, line)
+	}
+
+	if len(items) == 0 {
+		return uc.failOrder(ctx, order, sale, errors.NewValidationError("no line of the order could be sold", strings.Join(skipped, "; ")))
+	}
+
+	reservation, err := uc.reservationUseCase.CreateReservation(ctx, integration.TenantID, integration.CreatedBy, CreateStockReservationRequest{
+		Reference:        order.Reference(integration.Platform),
+		Source:           string(integration.Platform),
+		Items:            items,
+		ExpiresInSeconds: int(entities.MaxStockReservationTTL.Seconds()),
+		Notes:            fmt.Sprintf("%s order %s", integration.Name, order.OrderNumber),
+	})
+	if err != nil {
+		return uc.failOrder(ctx, order, sale, err)
+	}
+
+	order.ReservationID = &reservation.ID
+	order.SetStatus(entities.EcommerceOrderStatusOpen)
+	if len(skipped) > 0 {
+		order.Error = "skipped lines: " + strings.Join(skipped, "; ")
+	}
+	if err := uc.saveOrder(ctx, order); err != nil {
+		return err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"integration_id": integration.ID,
+		"order_number":   order.OrderNumber,
+		"sale_id":        sale.ID,
+		"reservation_id": reservation.ID,
+		"skipped_lines":  len(skipped),
+	}).Info("E-commerce order opened")
+
+	return nil
+}
+
+// fulfillOrder releases the order's reservation and completes its sale, paid in full by the
+// store. The stock the reservation held is deducted by the sale instead. When the sale cannot
+// be completed it is cancelled and the order failed, so a later webhook opens it afresh.
+func (uc *EcommerceIntegrationUseCase) fulfillOrder(ctx context.Context, integration *entities.EcommerceIntegration, order *entities.EcommerceOrder) error {
+	if err := uc.releaseReservation(ctx, integration, order, "fulfilled"); err != nil {
+		return err
+	}
+
+	sale, err := uc.saleUseCase.GetSale(ctx, *order.SaleID)
+	if err != nil {
+		return uc.failOrder(ctx, order, nil, err)
+	}
+
+	// The store collected the total with tax and any surcharge
+	preview, err := uc.saleUseCase.PreviewTotal(ctx, sale.ID, PreviewSaleTotalRequest{PaymentMethod: entities.PaymentMethodCard})
+	if err != nil {
+		return uc.failOrder(ctx, order, sale, err)
+	}
+	payment := CompleteSaleRequest{
+		PaidAmount:    preview.TotalAmount,
+		PaymentMethod: entities.PaymentMethodCard,
+		Notes:         fmt.Sprintf("Paid on %s order %s", integration.Name, order.OrderNumber),
+	}
+
+	if _, err := uc.saleUseCase.CompleteSale(ctx, integration.CreatedBy, sale.ID, payment); err != nil {
+		return uc.failOrder(ctx, order, sale, err)
+	}
+
+	order.SetStatus(entities.EcommerceOrderStatusFulfilled)
+	if err := uc.saveOrder(ctx, order); err != nil {
+		return err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"integration_id": integration.ID,
+		"order_number":   order.OrderNumber,
+		"sale_id":        sale.ID,
+	}).Info("E-commerce order fulfilled")
+
+	return nil
+}
+
+// cancelOrder releases the order's reservation and cancels its sale
+func (uc *EcommerceIntegrationUseCase) cancelOrder(ctx context.Context, integration *entities.EcommerceIntegration, order *entities.EcommerceOrder) error {
+	if err := uc.releaseReservation(ctx, integration, order, "cancelled"); err != nil {
+		return err
+	}
+
+	if err := uc.saleUseCase.CancelSale(ctx, integration.CreatedBy, *order.SaleID); err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type == errors.ErrorTypeInternal {
+			return err
+		}
+	}
+
+	order.SetStatus(entities.EcommerceOrderStatusCancelled)
+	if err := uc.saveOrder(ctx, order); err != nil {
+		return err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"integration_id": integration.ID,
+		"order_number":   order.OrderNumber,
+	}).Info("E-commerce order cancelled")
+
+	return nil
+}
+
+// releaseReservation releases the stock the order's reservation still holds. Reservations
+// that expired or were settled otherwise are left alone.
+func (uc *EcommerceIntegrationUseCase) releaseReservation(ctx context.Context, integration *entities.EcommerceIntegration, order *entities.EcommerceOrder, outcome string) error {
+	if order.ReservationID == nil {
+		return nil
+	}
+
+	reservation, err := uc.reservationUseCase.GetReservation(ctx, integration.TenantID, *order.ReservationID)
+	if err != nil {
+		return nil
+	}
+	if reservation.Status != entities.StockReservationStatusActive && reservation.Status != entities.StockReservationStatusPartiallyConfirmed {
+		return nil
+	}
+
+	_, err = uc.reservationUseCase.ReleaseReservation(ctx, integration.TenantID, integration.CreatedBy, reservation.ID, ReleaseStockReservationRequest{
+		Notes: fmt.Sprintf("%s order %s %s", integration.Name, order.OrderNumber, outcome),
+	})
+	return err
+}
+
+// failOrder records why an order could not be processed, cancelling its sale when one was
+// created. Internal errors are returned so the store retries the webhook.
+func (uc *EcommerceIntegrationUseCase) failOrder(ctx context.Context, order *entities.EcommerceOrder, sale *SaleResponse, cause error) error {
+	if sale != nil {
+		if err := uc.saleUseCase.CancelSale(ctx, sale.CreatedBy, sale.ID); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id": sale.ID,
+				"error":   err.Error(),
+			}).Warn("Failed to cancel sale of failed e-commerce order")
+		}
+	}
+
+	reason := cause.Error()
+	appErr, ok := errors.IsAppError(cause)
+	if ok && appErr.Details != "" {
+		reason = appErr.Message + ": " + appErr.Details
+	}
+	order.Fail(reason)
+	if err := uc.saveOrder(ctx, order); err != nil {
+		return err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"integration_id": order.IntegrationID,
+		"order_number":   order.OrderNumber,
+		"reason":         reason,
+	}).Warn("E-commerce order failed")
+
+	if !ok || appErr.Type == errors.ErrorTypeInternal {
+		return cause
+	}
+	return nil
+}
+
+// saveOrder saves an order's status, sale, reservation and error
+func (uc *EcommerceIntegrationUseCase) saveOrder(ctx context.Context, order *entities.EcommerceOrder) error {
+	if err := uc.ecommerceRepo.UpdateOrder(ctx, order); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"order_id": order.ID,
+			"error":    err.Error(),
+		}).Error("Failed to update e-commerce order")
+		return errors.NewInternalError("failed to update e-commerce order", err)
+	}
+
+	return nil
+}
+
+// learnProductLink links a product sold through the store to the store product of the order
+// line, unless it is linked already
+func (uc *EcommerceIntegrationUseCase) learnProductLink(ctx context.Context, integration *entities.EcommerceIntegration, productID uuid.UUID, line entities.StorefrontOrderLine) {
+	link, err := entities.NewEcommerceProductLink(integration, productID, line.ExternalProductID, line.ExternalVariantID)
+	if err != nil {
+		return
+	}
+
+	if err := uc.ecommerceRepo.CreateProductLink(ctx, link); err != nil {
+		if _, ok := errors.IsAppError(err); !ok {
+			uc.logger.WithFields(map[string]interface{}{
+				"integration_id": integration.ID,
+				"product_id":     productID,
+				"error":          err.Error(),
+			}).Warn("Failed to link e-commerce product")
+		}
+	}
+}
+
+// PushStockLevels pushes the available stock of linked products to the stores of every
+// integration that pushes stock, skipping levels the store already has. A store that cannot be
+// reached does not stop the others; the outcome is recorded on each integration.
+func (uc *EcommerceIntegrationUseCase) PushStockLevels(ctx context.Context) error {
+	integrations, err := uc.ecommerceRepo.GetStockPushIntegrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get e-commerce integrations: %w", err)
+	}
+
+	for _, integration := range integrations {
+		uc.pushStock(entities.WithTenantScope(ctx, integration.TenantID), integration)
+	}
+
+	return nil
+}
+
+// pushStock pushes the stock levels of an integration's linked products that changed since
+// they were last pushed
+func (uc *EcommerceIntegrationUseCase) pushStock(ctx context.Context, integration *entities.EcommerceIntegration) {
+	links, err := uc.ecommerceRepo.GetProductLinks(ctx, integration.ID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"integration_id": integration.ID,
+			"error":          err.Error(),
+		}).Error("Failed to get e-commerce product links")
+		return
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	productIDs := make([]uuid.UUID, len(links))
+	for i, link := range links {
+		productIDs[i] = link.ProductID
+	}
+	stocks, err := uc.stockRepo.GetByProductIDs(ctx, productIDs)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"integration_id": integration.ID,
+			"error":          err.Error(),
+		}).Error("Failed to get stock of e-commerce product links")
+		return
+	}
+
+	var pushErr error
+	pushed := 0
+	for _, link := range links {
+		quantity := 0
+		if stock, ok := stocks[link.ProductID]; ok && stock.AvailableQty > 0 {
+			quantity = stock.AvailableQty
+		}
+		if !link.NeedsPush(quantity) {
+			continue
+		}
+
+		pushCtx, cancel := context.WithTimeout(ctx, ecommerceStockPushTimeout)
+		inventoryItemID, err := uc.storefront.SetStock(pushCtx, ports.StorefrontStockRequest{
+			Platform:        string(integration.Platform),
+			StoreURL:        integration.StoreURL,
+			AccessToken:     integration.AccessToken,
+			ConsumerKey:     integration.ConsumerKey,
+			ConsumerSecret:  integration.ConsumerSecret,
+			LocationID:      integration.LocationID,
+			ProductID:       link.ExternalProductID,
+			VariantID:       link.ExternalVariantID,
+			InventoryItemID: link.InventoryItemID,
+			Quantity:        quantity,
+		})
+		cancel()
+
+		if inventoryItemID != "" {
+			link.InventoryItemID = inventoryItemID
+		}
+		if err != nil {
+			pushErr = err
+			uc.logger.WithFields(map[string]interface{}{
+				"integration_id": integration.ID,
+				"product_id":     link.ProductID,
+				"error":          err.Error(),
+			}).Warn("Failed to push stock level to store")
+			continue
+		}
+
+		link.RecordPush(quantity, time.Now())
+		if err := uc.ecommerceRepo.UpdateProductLink(ctx, link); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"link_id": link.ID,
+				"error":   err.Error(),
+			}).Error("Failed to record pushed stock level")
+			continue
+		}
+		pushed++
+	}
+
+	if pushed == 0 && pushErr == nil {
+		return
+	}
+
+	integration.RecordStockPush(time.Now(), pushErr)
+	if err := uc.ecommerceRepo.RecordStockPush(ctx, integration); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"integration_id": integration.ID,
+			"error":          err.Error(),
+		}).Error("Failed to record e-commerce stock push")
+	}
+}
+
+// isEcommerceOrderTopic reports whether a webhook topic is about an order, e.g. Shopify's
+// orders/create or WooCommerce's order.updated
+func isEcommerceOrderTopic(topic string) bool {
+	return strings.HasPrefix(topic, "orders/") || strings.HasPrefix(topic, "order.")
+}
@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/mail"
+)
+
+const (
+	// emailOutboxBatchSize caps how many queued emails are claimed at once by the outbox worker
+	emailOutboxBatchSize = 50
+
+	// emailOutboxLease is how long a claimed email is hidden from other servers while it is sent
+	emailOutboxLease = 5 * time.Minute
+)
+
+// EmailOutboxUseCase sends the invoice emails queued in the outbox. Each email is attempted
+// once per run and retried on later runs with the backoff of the tenant's email retry policy.
+// Invoice PDFs larger than maxAttachmentSize are sent as a signed invoice portal link instead
+// of an attachment, which the email records.
+type EmailOutboxUseCase struct {
+	outboxRepo        repositories.EmailOutboxRepository
+	invoiceRepo       repositories.InvoiceRepository
+	tenantRepo        repositories.TenantRepository
+	signatureRepo     repositories.DocumentSignatureRepository
+	suppressionRepo   repositories.EmailSuppressionRepository
+	pdfService        services.InvoicePDFService
+	emailService      services.EmailService
+	delivery          *DeliveryPolicyUseCase
+	portal            *InvoicePortalUseCase
+	featureUsage      *FeatureUsageUseCase
+	maxAttachmentSize int
+	logger            logger.Logger
+}
+
+// NewEmailOutboxUseCase creates a new email outbox use case. A maxAttachmentSize of 0 always
+// attaches the invoice PDF.
+func NewEmailOutboxUseCase(
+	outboxRepo repositories.EmailOutboxRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	tenantRepo repositories.TenantRepository,
+	signatureRepo repositories.DocumentSignatureRepository,
+	suppressionRepo repositories.EmailSuppressionRepository,
+	pdfService services.InvoicePDFService,
+	emailService services.EmailService,
+	delivery *DeliveryPolicyUseCase,
+	portal *InvoicePortalUseCase,
+	featureUsage *FeatureUsageUseCase,
+	maxAttachmentSize int,
+	logger logger.Logger,
+) *EmailOutboxUseCase {
+	return &EmailOutboxUseCase{
+		outboxRepo:        outboxRepo,
+		invoiceRepo:       invoiceRepo,
+		tenantRepo:        tenantRepo,
+		signatureRepo:     signatureRepo,
+		suppressionRepo:   suppressionRepo,
+		pdfService:        pdfService,
+		emailService:      emailService,
+		delivery:          delivery,
+		portal:            portal,
+		featureUsage:      featureUsage,
+		maxAttachmentSize: maxAttachmentSize,
+		logger:            logger,
+	}
+}
+
+// GetInvoiceEmails returns the emails queued for a tenant's invoice and their delivery status
+func (uc *EmailOutboxUseCase) GetInvoiceEmails(ctx context.Context, tenantID, invoiceID uuid.UUID) ([]*entities.OutboxEmail, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	emails, err := uc.outboxRepo.GetByInvoice(ctx, invoiceID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get queued invoice emails")
+		return nil, errors.NewInternalError("failed to get invoice emails", err)
+	}
+
+	return emails, nil
+}
+
+// SendDue claims and sends the queued emails whose next attempt is due. It is run
+// periodically by the scheduler.
+func (uc *EmailOutboxUseCase) SendDue(ctx context.Context) error {
+	policies := make(map[uuid.UUID]*entities.DeliveryPolicy)
+	sent, failed := 0, 0
+
+	for {
+		now := time.Now()
+		emails, err := uc.outboxRepo.ClaimDue(ctx, now, now.Add(emailOutboxLease), emailOutboxBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim queued emails: %w", err)
+		}
+
+		for _, email := range emails {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			uc.send(ctx, email, policies)
+			switch email.Status {
+			case entities.OutboxEmailStatusSent:
+				sent++
+			case entities.OutboxEmailStatusFailed:
+				failed++
+			}
+		}
+
+		if len(emails) < emailOutboxBatchSize {
+			break
+		}
+	}
+
+	if sent > 0 || failed > 0 {
+		uc.logger.WithFields(map[string]interface{}{
+			"sent":   sent,
+			"failed": failed,
+		}).Info("Queued emails sent")
+	}
+
+	return nil
+}
+
+// send renders and emails a single queued invoice email and records the outcome. Policies
+// are cached for the run since most emails in a batch share a few tenants.
+func (uc *EmailOutboxUseCase) send(ctx context.Context, email *entities.OutboxEmail, policies map[uuid.UUID]*entities.DeliveryPolicy) {
+	policy, ok := policies[email.TenantID]
+	if !ok {
+		var err error
+		policy, err = uc.delivery.GetPolicy(ctx, email.TenantID)
+		if err != nil {
+			return
+		}
+		policies[email.TenantID] = policy
+	}
+
+	var invoice *entities.Invoice
+	var sendErr error
+	attempted := false
+	suppressed, err := uc.suppressionRepo.GetSuppressed(ctx, email.TenantID, []string{email.Recipient})
+	switch {
+	case err != nil:
+		uc.logger.WithFields(map[string]interface{}{
+			"email_id": email.ID,
+			"error":    err.Error(),
+		}).Error("Failed to check email suppressions")
+		return
+	case suppressed[email.Recipient]:
+		email.Fail("recipient is on the email suppression list")
+	default:
+		invoice, err = uc.invoiceRepo.GetByID(ctx, email.InvoiceID)
+		if err != nil {
+			email.Fail("invoice no longer exists")
+			break
+		}
+
+		sentAt := time.Now()
+		sendErr = uc.sendInvoice(ctx, invoice, email, policy.Email)
+		email.RecordAttempt(sentAt, sendErr, policy.Email)
+		if mail.IsPermanent(sendErr) {
+			// The mail provider rejected the message itself, so retrying it cannot succeed
+			email.Fail(email.LastError)
+		}
+		attempted = true
+	}
+
+	if err := uc.outboxRepo.Update(ctx, email); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"email_id": email.ID,
+			"error":    err.Error(),
+		}).Error("Failed to record queued email attempt")
+		return
+	}
+
+	switch email.Status {
+	case entities.OutboxEmailStatusSent:
+		// Mark invoice as sent
+		if invoice.IsGenerated() {
+			if err := invoice.MarkAsSent(); err == nil {
+				uc.invoiceRepo.Update(ctx, invoice)
+			}
+		}
+		uc.featureUsage.Track(ctx, email.TenantID, entities.FeatureEmail)
+	case entities.OutboxEmailStatusFailed:
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":  email.TenantID,
+			"email_id":   email.ID,
+			"invoice_id": email.InvoiceID,
+			"attempts":   email.Attempts,
+			"error":      email.LastError,
+		}).Warn("Queued invoice email failed")
+	}
+
+	// Only the final outcome of an email counts towards pausing its recipient
+	if attempted && email.Status != entities.OutboxEmailStatusPending {
+		uc.delivery.RecordEmailResult(ctx, policy, email.CreatedBy, email.Recipient, sendErr)
+	}
+}
+
+// sendInvoice renders the invoice PDF with the email's template and makes one attempt at
+// emailing it, bounded by the policy's timeout. A PDF too large to attach is replaced by a
+// download link, which renders it with the default template of the email's paper size.
+func (uc *EmailOutboxUseCase) sendInvoice(ctx context.Context, invoice *entities.Invoice, email *entities.OutboxEmail, retry entities.RetryPolicy) error {
+	template := email.Template
+	if template == nil {
+		template = uc.pdfService.GetDefaultTemplate(email.PaperSize)
+	}
+
+	// Generate PDF, including the customer's signature if captured
+	attachInvoiceSignature(ctx, uc.signatureRepo, invoice)
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, template)
+	if err != nil {
+		return fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, retry.AttemptTimeout())
+	defer cancel()
+
+	if uc.maxAttachmentSize > 0 && len(pdfData) > uc.maxAttachmentSize {
+		email.Delivery = entities.OutboxEmailDeliveryLink
+		downloadURL, expiresAt := uc.portal.DownloadURL(invoice.ID, email.PaperSize)
+
+		uc.logger.WithFields(map[string]interface{}{
+			"email_id":        email.ID,
+			"invoice_id":      invoice.ID,
+			"pdf_size":        len(pdfData),
+			"max_attachment":  uc.maxAttachmentSize,
+			"link_expires_at": expiresAt,
+		}).Info("Invoice PDF too large to attach, sending download link")
+
+		return uc.emailService.SendInvoiceLinkEmail(attemptCtx, invoice, email.Recipient, downloadURL, expiresAt)
+	}
+
+	email.Delivery = entities.OutboxEmailDeliveryAttachment
+	return uc.emailService.SendInvoiceEmail(attemptCtx, invoice, email.Recipient, pdfData)
+}
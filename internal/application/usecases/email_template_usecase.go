@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// EmailTemplateUseCase handles the tenant's overrides of the customer email templates
+type EmailTemplateUseCase struct {
+	templateRepo repositories.EmailTemplateRepository
+	invoiceRepo  repositories.InvoiceRepository
+	tenantRepo   repositories.TenantRepository
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewEmailTemplateUseCase creates a new email template use case
+func NewEmailTemplateUseCase(
+	templateRepo repositories.EmailTemplateRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	tenantRepo repositories.TenantRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *EmailTemplateUseCase {
+	return &EmailTemplateUseCase{
+		templateRepo: templateRepo,
+		invoiceRepo:  invoiceRepo,
+		tenantRepo:   tenantRepo,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// UpdateEmailTemplateRequest represents update email template request. An empty text body
+// uses the text body of the built-in template.
+type UpdateEmailTemplateRequest struct {
+	Subject  string `json:"subject" validate:"required"`
+	HTMLBody string `json:"html_body" validate:"required"`
+	TextBody string `json:"text_body,omitempty"`
+}
+
+// PreviewEmailTemplateRequest represents preview email template request. Without a subject
+// and HTML body the template in use is previewed; without an invoice, sample data is used.
+type PreviewEmailTemplateRequest struct {
+	Subject     string     `json:"subject,omitempty"`
+	HTMLBody    string     `json:"html_body,omitempty"`
+	TextBody    string     `json:"text_body,omitempty"`
+	InvoiceID   *uuid.UUID `json:"invoice_id,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"` // Previews an invoice email linking its PDF
+}
+
+// ListTemplates returns the template in use for every kind of email, the tenant's override
+// or the built-in default
+func (uc *EmailTemplateUseCase) ListTemplates(ctx context.Context, tenantID uuid.UUID) ([]*entities.EmailTemplate, error) {
+	overrides, err := uc.templateRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get email templates")
+		return nil, errors.NewInternalError("failed to get email templates", err)
+	}
+
+	byKind := make(map[entities.EmailTemplateKind]*entities.EmailTemplate, len(overrides))
+	for _, template := range overrides {
+		byKind[template.Kind] = template
+	}
+
+	templates := make([]*entities.EmailTemplate, 0, len(entities.EmailTemplateKinds()))
+	for _, kind := range entities.EmailTemplateKinds() {
+		if template, ok := byKind[kind]; ok {
+			templates = append(templates, template)
+		} else {
+			templates = append(templates, entities.DefaultEmailTemplate(kind))
+		}
+	}
+
+	return templates, nil
+}
+
+// GetTemplate returns the template in use for a kind of email
+func (uc *EmailTemplateUseCase) GetTemplate(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind) (*entities.EmailTemplate, error) {
+	template, err := uc.getOverride(ctx, tenantID, kind)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return entities.DefaultEmailTemplate(kind), nil
+	}
+
+	return template, nil
+}
+
+// UpdateTemplate creates or updates the tenant's override of the template of a kind
+func (uc *EmailTemplateUseCase) UpdateTemplate(ctx context.Context, tenantID, userID uuid.UUID, kind entities.EmailTemplateKind, req UpdateEmailTemplateRequest) (*entities.EmailTemplate, error) {
+	template, err := uc.getOverride(ctx, tenantID, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldValue map[string]interface{}
+	if template != nil {
+		oldValue = map[string]interface{}{
+			"subject":   template.Subject,
+			"html_body": template.HTMLBody,
+			"text_body": template.TextBody,
+		}
+		err = template.Update(req.Subject, req.HTMLBody, req.TextBody, userID)
+	} else {
+		template, err = entities.NewEmailTemplate(tenantID, kind, req.Subject, req.HTMLBody, req.TextBody, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Save(ctx, template); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"kind":  kind,
+			"error": err.Error(),
+		}).Error("Failed to save email template")
+		return nil, errors.NewInternalError("failed to save email template", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "email_template",
+		ResourceID: template.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"kind":      template.Kind,
+			"subject":   template.Subject,
+			"html_body": template.HTMLBody,
+			"text_body": template.TextBody,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"kind":      kind,
+		"user_id":   userID,
+	}).Info("Email template updated")
+
+	return template, nil
+}
+
+// ResetTemplate deletes the tenant's override of the template of a kind, restoring the
+// built-in default
+func (uc *EmailTemplateUseCase) ResetTemplate(ctx context.Context, tenantID, userID uuid.UUID, kind entities.EmailTemplateKind) (*entities.EmailTemplate, error) {
+	template, err := uc.getOverride(ctx, tenantID, kind)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return entities.DefaultEmailTemplate(kind), nil
+	}
+
+	if err := uc.templateRepo.Delete(ctx, tenantID, kind); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"kind":  kind,
+			"error": err.Error(),
+		}).Error("Failed to delete email template")
+		return nil, errors.NewInternalError("failed to reset email template", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "email_template",
+		ResourceID: template.ID.String(),
+		OldValue: map[string]interface{}{
+			"kind":      template.Kind,
+			"subject":   template.Subject,
+			"html_body": template.HTMLBody,
+			"text_body": template.TextBody,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"kind":      kind,
+		"user_id":   userID,
+	}).Info("Email template reset to default")
+
+	return entities.DefaultEmailTemplate(kind), nil
+}
+
+// PreviewTemplate renders a template of a kind without saving or sending it
+func (uc *EmailTemplateUseCase) PreviewTemplate(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind, req PreviewEmailTemplateRequest) (*entities.RenderedEmail, error) {
+	var template *entities.EmailTemplate
+	var err error
+	if req.Subject == "" && req.HTMLBody == "" {
+		template, err = uc.GetTemplate(ctx, tenantID, kind)
+	} else {
+		template, err = entities.NewEmailTemplate(tenantID, kind, req.Subject, req.HTMLBody, req.TextBody, uuid.Nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := entities.SampleEmailTemplateData()
+	if req.InvoiceID != nil {
+		invoice, err := uc.invoiceRepo.GetByID(ctx, *req.InvoiceID)
+		if err != nil || invoice.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("invoice")
+		}
+		attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+		data = entities.NewEmailTemplateData(invoice)
+	}
+	if req.DownloadURL != "" {
+		data.WithDownloadLink(req.DownloadURL, time.Now().AddDate(0, 0, 7))
+	}
+
+	rendered, err := template.Render(data)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid email template", err.Error())
+	}
+
+	return rendered, nil
+}
+
+// getOverride returns the tenant's override of the template of a kind, or nil if it has none
+func (uc *EmailTemplateUseCase) getOverride(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind) (*entities.EmailTemplate, error) {
+	if !kind.IsValid() {
+		return nil, errors.NewValidationError("invalid email template kind", "unsupported kind: "+string(kind))
+	}
+
+	template, err := uc.templateRepo.GetByKind(ctx, tenantID, kind)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, nil
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get email template")
+		return nil, errors.NewInternalError("failed to get email template", err)
+	}
+
+	return template, nil
+}
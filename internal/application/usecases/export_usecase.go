@@ -0,0 +1,415 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+	"github.com/nicklaros/adol/pkg/xlsx"
+)
+
+// exportPageSize is the number of records fetched per page while streaming an export
+const exportPageSize = 500
+
+// ExportFormat is the file format of a data export
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// ParseExportFormat validates a requested export format, defaulting to CSV
+func ParseExportFormat(format string) (ExportFormat, error) {
+	switch ExportFormat(format) {
+	case "", ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatXLSX:
+		return ExportFormatXLSX, nil
+	default:
+		return "", errors.NewValidationError("invalid format", "format must be csv or xlsx")
+	}
+}
+
+// ContentType returns the MIME type of files in the format
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// exportOrderColumns lists the columns each export may be ordered by. The repositories put
+// order_by into the query as is, so it must never reach them unchecked.
+var exportOrderColumns = map[string][]string{
+	"products":        productOrderColumns,
+	"sales":           {"sale_number", "customer_name", "total_amount", "status", "created_at", "completed_at"},
+	"invoices":        {"invoice_number", "customer_name", "total_amount", "status", "due_date", "paid_at", "created_at"},
+	"stock_movements": {"type", "reason", "quantity", "created_at"},
+}
+
+// exportTable writes the rows of an export in one file format
+type exportTable interface {
+	WriteRow(values ...interface{}) error
+	Flush() error
+	Close() error
+}
+
+// ExportUseCase streams products, sales, invoices and stock movements as CSV or XLSX files.
+// Records are fetched and written a page at a time, so exports never sit in memory.
+type ExportUseCase struct {
+	productRepo       repositories.ProductRepository
+	stockMovementRepo repositories.StockMovementRepository
+	saleRepo          repositories.SaleRepository
+	invoiceRepo       repositories.InvoiceRepository
+	audit             ports.AuditPort
+	logger            logger.Logger
+}
+
+// NewExportUseCase creates a new export use case
+func NewExportUseCase(
+	productRepo repositories.ProductRepository,
+	stockMovementRepo repositories.StockMovementRepository,
+	saleRepo repositories.SaleRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *ExportUseCase {
+	return &ExportUseCase{
+		productRepo:       productRepo,
+		stockMovementRepo: stockMovementRepo,
+		saleRepo:          saleRepo,
+		invoiceRepo:       invoiceRepo,
+		audit:             audit,
+		logger:            logger,
+	}
+}
+
+// ExportProducts writes the products matching filter to w
+func (uc *ExportUseCase) ExportProducts(ctx context.Context, tenantID, userID uuid.UUID, filter repositories.ProductFilter, format ExportFormat, w io.Writer) error {
+	if err := validateExportOrder("products", filter.OrderBy, filter.OrderDir); err != nil {
+		return err
+	}
+
+	header := []interface{}{"id", "sku", "barcode", "name", "description", "category", "unit", "price", "cost", "min_stock", "status", "publish_state", "supplier_id", "created_at", "updated_at"}
+
+	return uc.export(ctx, tenantID, userID, "products", filter, format, w, header, func(table exportTable, pagination utils.PaginationInfo) (int, utils.PaginationInfo, error) {
+		products, pagination, err := uc.productRepo.List(ctx, filter, pagination)
+		if err != nil {
+			return 0, pagination, err
+		}
+		for _, p := range products {
+			err := table.WriteRow(p.ID, p.SKU, p.Barcode, p.Name, p.Description, p.Category, p.Unit, p.Price, p.Cost, p.MinStock, p.Status, p.PublishState, p.SupplierID, p.CreatedAt, p.UpdatedAt)
+			if err != nil {
+				return 0, pagination, err
+			}
+		}
+		return len(products), pagination, nil
+	})
+}
+
+// ExportSales writes the sales matching filter to w, one row per sale
+func (uc *ExportUseCase) ExportSales(ctx context.Context, tenantID, userID uuid.UUID, filter repositories.SaleFilter, format ExportFormat, w io.Writer) error {
+	if err := validateExportOrder("sales", filter.OrderBy, filter.OrderDir); err != nil {
+		return err
+	}
+
+	header := []interface{}{"id", "sale_number", "status", "channel", "customer_name", "customer_email", "customer_phone", "payment_method", "subtotal", "discount_amount", "tax_amount", "surcharge_amount", "total_amount", "paid_amount", "change_amount", "currency", "exchange_rate", "base_total_amount", "created_by", "created_at", "completed_at"}
+
+	return uc.export(ctx, tenantID, userID, "sales", filter, format, w, header, func(table exportTable, pagination utils.PaginationInfo) (int, utils.PaginationInfo, error) {
+		sales, pagination, err := uc.saleRepo.List(ctx, filter, pagination)
+		if err != nil {
+			return 0, pagination, err
+		}
+		for _, s := range sales {
+			currency, rate := exportExchangeRate(s.ExchangeRate)
+			err := table.WriteRow(s.ID, s.SaleNumber, s.Status, s.Channel, s.CustomerName, s.CustomerEmail, s.CustomerPhone, s.PaymentMethod, s.Subtotal, s.DiscountAmount, s.TaxAmount, s.SurchargeAmount, s.TotalAmount, s.PaidAmount, s.ChangeAmount, currency, rate, s.ExchangeRate.ToBase(s.TotalAmount), s.CreatedBy, s.CreatedAt, s.CompletedAt)
+			if err != nil {
+				return 0, pagination, err
+			}
+		}
+		return len(sales), pagination, nil
+	})
+}
+
+// ExportInvoices writes the invoices matching filter to w, one row per invoice
+func (uc *ExportUseCase) ExportInvoices(ctx context.Context, tenantID, userID uuid.UUID, filter repositories.InvoiceFilter, format ExportFormat, w io.Writer) error {
+	if err := validateExportOrder("invoices", filter.OrderBy, filter.OrderDir); err != nil {
+		return err
+	}
+
+	header := []interface{}{"id", "invoice_number", "sale_id", "status", "customer_name", "customer_email", "customer_phone", "customer_address", "payment_method", "subtotal", "discount_amount", "tax_amount", "surcharge_amount", "total_amount", "paid_amount", "currency", "exchange_rate", "base_total_amount", "due_date", "paid_at", "created_by", "created_at"}
+
+	return uc.export(ctx, tenantID, userID, "invoices", filter, format, w, header, func(table exportTable, pagination utils.PaginationInfo) (int, utils.PaginationInfo, error) {
+		invoices, pagination, err := uc.invoiceRepo.List(ctx, filter, pagination)
+		if err != nil {
+			return 0, pagination, err
+		}
+		for _, i := range invoices {
+			currency, rate := exportExchangeRate(i.ExchangeRate)
+			err := table.WriteRow(i.ID, i.InvoiceNumber, i.SaleID, i.Status, i.CustomerName, i.CustomerEmail, i.CustomerPhone, i.CustomerAddress, i.PaymentMethod, i.Subtotal, i.DiscountAmount, i.TaxAmount, i.SurchargeAmount, i.TotalAmount, i.PaidAmount, currency, rate, i.ExchangeRate.ToBase(i.TotalAmount), i.DueDate, i.PaidAt, i.CreatedBy, i.CreatedAt)
+			if err != nil {
+				return 0, pagination, err
+			}
+		}
+		return len(invoices), pagination, nil
+	})
+}
+
+// ExportStockMovements writes the stock movements matching filter to w, with the SKU and
+// name of each movement's product
+func (uc *ExportUseCase) ExportStockMovements(ctx context.Context, tenantID, userID uuid.UUID, filter repositories.StockMovementFilter, format ExportFormat, w io.Writer) error {
+	if err := validateExportOrder("stock_movements", filter.OrderBy, filter.OrderDir); err != nil {
+		return err
+	}
+
+	header := []interface{}{"id", "product_id", "product_sku", "product_name", "type", "reason", "quantity", "reference", "notes", "created_by", "created_at"}
+
+	return uc.export(ctx, tenantID, userID, "stock_movements", filter, format, w, header, func(table exportTable, pagination utils.PaginationInfo) (int, utils.PaginationInfo, error) {
+		movements, pagination, err := uc.stockMovementRepo.List(ctx, filter, pagination)
+		if err != nil {
+			return 0, pagination, err
+		}
+
+		productIDs := make([]uuid.UUID, 0, len(movements))
+		for _, m := range movements {
+			productIDs = append(productIDs, m.ProductID)
+		}
+		products, err := uc.productRepo.GetByIDs(ctx, productIDs)
+		if err != nil {
+			return 0, pagination, err
+		}
+
+		for _, m := range movements {
+			var sku, name string
+			if product, ok := products[m.ProductID]; ok {
+				sku, name = product.SKU, product.Name
+			}
+			err := table.WriteRow(m.ID, m.ProductID, sku, name, m.Type, m.Reason, m.Quantity, m.Reference, m.Notes, m.CreatedBy, m.CreatedAt)
+			if err != nil {
+				return 0, pagination, err
+			}
+		}
+		return len(movements), pagination, nil
+	})
+}
+
+// export writes the header and then every page written by writePage, flushing after each
+// page so the client receives the file while it is produced. Once rows have been sent a
+// failure can no longer change the response, so the caller gets the error only to log it and
+// the file is left incomplete.
+func (uc *ExportUseCase) export(
+	ctx context.Context,
+	tenantID, userID uuid.UUID,
+	resource string,
+	filter interface{},
+	format ExportFormat,
+	w io.Writer,
+	header []interface{},
+	writePage func(exportTable, utils.PaginationInfo) (int, utils.PaginationInfo, error),
+) error {
+	table, err := newExportTable(format, w, resource)
+	if err != nil {
+		return errors.NewInternalError("failed to start export", err)
+	}
+
+	rows := 0
+	err = table.WriteRow(header...)
+	for page := 1; err == nil; page++ {
+		written, pagination, pageErr := writePage(table, utils.PaginationInfo{Page: page, Limit: exportPageSize})
+		if pageErr != nil {
+			err = pageErr
+			break
+		}
+		if err = table.Flush(); err != nil {
+			break
+		}
+
+		rows += written
+		if !pagination.HasNext || written == 0 {
+			break
+		}
+	}
+	if err == nil {
+		err = table.Close()
+	}
+	if err != nil {
+		uc.logExportFailure(ctx, tenantID, userID, resource, format, err)
+		return errors.NewInternalError(fmt.Sprintf("failed to export %s", resource), err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Action:   "export",
+		Resource: resource,
+		NewValue: map[string]interface{}{
+			"tenant_id": tenantID,
+			"format":    format,
+			"filter":    filter,
+			"rows":      rows,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return nil
+}
+
+// logExportFailure records an export that could not be completed
+func (uc *ExportUseCase) logExportFailure(ctx context.Context, tenantID, userID uuid.UUID, resource string, format ExportFormat, err error) {
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"user_id":   userID,
+		"resource":  resource,
+		"format":    format,
+		"error":     err.Error(),
+	}).Error("Export failed")
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Action:       "export",
+		Resource:     resource,
+		NewValue:     map[string]interface{}{"tenant_id": tenantID, "format": format},
+		Timestamp:    time.Now(),
+		Success:      false,
+		ErrorMessage: err.Error(),
+	})
+}
+
+// validateExportOrder checks an export's ordering against the columns it may be ordered by
+func validateExportOrder(resource, orderBy, orderDir string) error {
+	return validateListOrder(resource, exportOrderColumns[resource], orderBy, orderDir)
+}
+
+// validateListOrder checks a listing's ordering against the columns it may be ordered by
+func validateListOrder(resource string, columns []string, orderBy, orderDir string) error {
+	if orderDir != "" && orderDir != "ASC" && orderDir != "DESC" {
+		return errors.NewValidationError("invalid order_dir", "order_dir must be ASC or DESC")
+	}
+	if orderBy == "" {
+		return nil
+	}
+	for _, column := range columns {
+		if column == orderBy {
+			return nil
+		}
+	}
+	return errors.NewValidationError("invalid order_by", fmt.Sprintf("%s cannot be ordered by %q", resource, orderBy))
+}
+
+// newExportTable creates the table writer for a format
+func newExportTable(format ExportFormat, w io.Writer, sheet string) (exportTable, error) {
+	if format == ExportFormatXLSX {
+		writer, err := xlsx.NewWriter(w, xlsx.SheetName(sheet))
+		if err != nil {
+			return nil, err
+		}
+		return &xlsxExportTable{writer: writer}, nil
+	}
+	return &csvExportTable{writer: csv.NewWriter(w)}, nil
+}
+
+// csvExportTable writes exports as CSV
+type csvExportTable struct {
+	writer *csv.Writer
+}
+
+func (t *csvExportTable) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = exportText(value)
+	}
+	return t.writer.Write(record)
+}
+
+func (t *csvExportTable) Flush() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+func (t *csvExportTable) Close() error {
+	return t.Flush()
+}
+
+// xlsxExportTable writes exports as an XLSX workbook, keeping numbers and amounts numeric so
+// they can be summed in a spreadsheet
+type xlsxExportTable struct {
+	writer *xlsx.Writer
+}
+
+func (t *xlsxExportTable) WriteRow(values ...interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case int, string:
+			cells[i] = v
+		case decimal.Decimal:
+			cells[i] = v.InexactFloat64()
+		default:
+			cells[i] = exportText(v)
+		}
+	}
+	return t.writer.WriteRow(cells...)
+}
+
+func (t *xlsxExportTable) Flush() error {
+	return t.writer.Flush()
+}
+
+func (t *xlsxExportTable) Close() error {
+	return t.writer.Close()
+}
+
+// exportExchangeRate returns the currency and locked exchange rate a document is exported
+// with. Documents without a locked rate are in the base currency.
+func exportExchangeRate(rate *entities.ExchangeRate) (string, decimal.Decimal) {
+	if rate == nil {
+		return "", decimal.NewFromInt(1)
+	}
+	return rate.Currency, rate.Rate
+}
+
+// exportText formats a value for a text cell. Times are written in RFC 3339 and unset
+// optional values as empty cells.
+func exportText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case decimal.Decimal:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case uuid.UUID:
+		return v.String()
+	case *uuid.UUID:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
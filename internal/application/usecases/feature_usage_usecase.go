@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// maxFeatureAdoptionReportRange caps the date range of a single feature adoption report
+const maxFeatureAdoptionReportRange = 2 * 366 * 24 * time.Hour
+
+// FeatureUsageUseCase handles tracking which features tenants use and reporting their
+// adoption to the product team
+type FeatureUsageUseCase struct {
+	usageRepo repositories.FeatureUsageRepository
+	logger    logger.Logger
+}
+
+// NewFeatureUsageUseCase creates a new feature usage use case
+func NewFeatureUsageUseCase(usageRepo repositories.FeatureUsageRepository, logger logger.Logger) *FeatureUsageUseCase {
+	return &FeatureUsageUseCase{
+		usageRepo: usageRepo,
+		logger:    logger,
+	}
+}
+
+// FeatureAdoptionReportRequest represents feature adoption report request
+type FeatureAdoptionReportRequest struct {
+	FromDate time.Time                 `json:"from_date"`
+	ToDate   time.Time                 `json:"to_date"` // Exclusive
+	Period   repositories.ReportPeriod `json:"period,omitempty"`
+}
+
+// FeatureAdoptionReportResponse represents how widely each feature is adopted across tenants
+type FeatureAdoptionReportResponse struct {
+	FromDate     time.Time                 `json:"from_date"`
+	ToDate       time.Time                 `json:"to_date"`
+	Period       repositories.ReportPeriod `json:"period"`
+	TotalTenants int                       `json:"total_tenants"` // Active and trial tenants
+	Features     []*FeatureAdoptionStat    `json:"features"`
+}
+
+// FeatureAdoptionStat represents the adoption of one feature
+type FeatureAdoptionStat struct {
+	Feature         entities.Feature      `json:"feature"`
+	AdoptingTenants int                   `json:"adopting_tenants"` // Tenants that used it before the end of the range
+	AdoptionPercent float64               `json:"adoption_percent"`
+	NewAdopters     int                   `json:"new_adopters"` // Tenants that used it for the first time within the range
+	Events          int                   `json:"events"`
+	ByPeriod        []*FeatureUsagePeriod `json:"by_period"`
+}
+
+// FeatureUsagePeriod represents the usage of a feature within one period
+type FeatureUsagePeriod struct {
+	PeriodStart   time.Time `json:"period_start"`
+	ActiveTenants int       `json:"active_tenants"`
+	Events        int       `json:"events"`
+}
+
+// Track counts a use of the feature by the tenant. Tracking is best effort: failures are
+// logged and never fail the operation that used the feature.
+func (uc *FeatureUsageUseCase) Track(ctx context.Context, tenantID uuid.UUID, feature entities.Feature) {
+	usage, err := entities.NewFeatureUsage(tenantID, feature, time.Now())
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"feature":   feature,
+			"error":     err.Error(),
+		}).Warn("Invalid feature usage")
+		return
+	}
+
+	if err := uc.usageRepo.Record(ctx, usage); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"feature":   feature,
+			"error":     err.Error(),
+		}).Error("Failed to record feature usage")
+	}
+}
+
+// GetAdoptionReport reports, for every tracked feature, how many tenants have adopted it and
+// how its usage developed per period between the dates
+func (uc *FeatureUsageUseCase) GetAdoptionReport(ctx context.Context, req FeatureAdoptionReportRequest) (*FeatureAdoptionReportResponse, error) {
+	if req.Period == "" {
+		req.Period = repositories.ReportPeriodWeek
+	}
+	if err := validateReportPeriod(req.Period); err != nil {
+		return nil, err
+	}
+	if !req.ToDate.After(req.FromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if req.ToDate.Sub(req.FromDate) > maxFeatureAdoptionReportRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed two years")
+	}
+
+	totalTenants, err := uc.usageRepo.CountActiveTenants(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to count active tenants")
+		return nil, errors.NewInternalError("failed to generate feature adoption report", err)
+	}
+
+	adoptionLines, err := uc.usageRepo.GetAdoptionLines(ctx, req.FromDate, req.ToDate)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get feature adoption lines")
+		return nil, errors.NewInternalError("failed to generate feature adoption report", err)
+	}
+
+	usageLines, err := uc.usageRepo.GetUsageLines(ctx, req.FromDate, req.ToDate, req.Period)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get feature usage lines")
+		return nil, errors.NewInternalError("failed to generate feature adoption report", err)
+	}
+
+	// Every tracked feature is reported, including those nobody has used yet
+	report := &FeatureAdoptionReportResponse{
+		FromDate:     req.FromDate,
+		ToDate:       req.ToDate,
+		Period:       req.Period,
+		TotalTenants: totalTenants,
+		Features:     make([]*FeatureAdoptionStat, 0, len(entities.Features())),
+	}
+	stats := map[entities.Feature]*FeatureAdoptionStat{}
+	for _, feature := range entities.Features() {
+		stat := &FeatureAdoptionStat{Feature: feature, ByPeriod: []*FeatureUsagePeriod{}}
+		stats[feature] = stat
+		report.Features = append(report.Features, stat)
+	}
+
+	for _, line := range adoptionLines {
+		stat, ok := stats[line.Feature]
+		if !ok {
+			continue
+		}
+		stat.AdoptingTenants = line.AdoptingTenants
+		stat.NewAdopters = line.NewAdopters
+		if totalTenants > 0 {
+			stat.AdoptionPercent = float64(line.AdoptingTenants) / float64(totalTenants) * 100
+		}
+	}
+
+	// Lines are ordered by period, so each feature's periods come out in order
+	for _, line := range usageLines {
+		stat, ok := stats[line.Feature]
+		if !ok {
+			continue
+		}
+		stat.Events += line.Events
+		stat.ByPeriod = append(stat.ByPeriod, &FeatureUsagePeriod{
+			PeriodStart:   line.PeriodStart,
+			ActiveTenants: line.ActiveTenants,
+			Events:        line.Events,
+		})
+	}
+
+	return report, nil
+}
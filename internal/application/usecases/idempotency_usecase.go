@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// IdempotencyUseCase makes retried requests safe: the first request made with a key is
+// handled and its response stored, and later requests with the same key get that response
+type IdempotencyUseCase struct {
+	keyRepo repositories.IdempotencyKeyRepository
+	ttl     time.Duration
+	logger  logger.Logger
+}
+
+// NewIdempotencyUseCase creates a new idempotency use case. Responses are replayed for ttl
+// after the first request.
+func NewIdempotencyUseCase(keyRepo repositories.IdempotencyKeyRepository, ttl time.Duration, logger logger.Logger) *IdempotencyUseCase {
+	return &IdempotencyUseCase{
+		keyRepo: keyRepo,
+		ttl:     ttl,
+		logger:  logger,
+	}
+}
+
+// Begin claims a key for a request. It returns the claimed key when the request should be
+// handled, or the stored key when its response should be replayed instead. A key still held
+// by another request is a conflict, and a key reused for a different request is rejected.
+func (uc *IdempotencyUseCase) Begin(ctx context.Context, tenantID, userID uuid.UUID, key, method, path string, body []byte) (claimed, replay *entities.IdempotencyKey, err error) {
+	idempotencyKey, err := entities.NewIdempotencyKey(tenantID, userID, key, method, path, body, uc.ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ok, err := uc.keyRepo.Claim(ctx, idempotencyKey, time.Now().Add(-entities.IdempotencyLockTimeout))
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to claim idempotency key")
+		return nil, nil, errors.NewInternalError("failed to claim idempotency key", err)
+	}
+	if ok {
+		return idempotencyKey, nil, nil
+	}
+
+	existing, err := uc.keyRepo.GetByKey(ctx, tenantID, idempotencyKey.Key)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			// Released between the claim and the lookup; the client can simply retry
+			return nil, nil, errors.NewConflictError("a request with this idempotency key is being processed, retry shortly")
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get idempotency key")
+		return nil, nil, errors.NewInternalError("failed to get idempotency key", err)
+	}
+
+	if !existing.Matches(idempotencyKey) {
+		return nil, nil, errors.NewRuleViolationError("idempotency key reused", "the idempotency key was already used for a different request")
+	}
+	if !existing.IsCompleted() {
+		return nil, nil, errors.NewConflictError("a request with this idempotency key is being processed, retry shortly")
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"method":    method,
+		"path":      path,
+	}).Info("Replaying idempotent response")
+
+	return nil, existing, nil
+}
+
+// Complete stores the response of a claimed key for replays. Responses that are not
+// replayable release the key so the request can be retried.
+func (uc *IdempotencyUseCase) Complete(ctx context.Context, key *entities.IdempotencyKey, status int, body []byte) error {
+	if !entities.IsReplayableStatus(status) {
+		return uc.Release(ctx, key)
+	}
+
+	key.Complete(status, body)
+	if err := uc.keyRepo.Complete(ctx, key); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": key.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to store idempotent response")
+		return errors.NewInternalError("failed to store idempotent response", err)
+	}
+
+	return nil
+}
+
+// Release deletes a claimed key without storing a response, so a retry is handled afresh
+func (uc *IdempotencyUseCase) Release(ctx context.Context, key *entities.IdempotencyKey) error {
+	if err := uc.keyRepo.Delete(ctx, key.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": key.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to release idempotency key")
+		return errors.NewInternalError("failed to release idempotency key", err)
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes idempotency keys past their retention window. It is run periodically
+// by the scheduler.
+func (uc *IdempotencyUseCase) PurgeExpired(ctx context.Context) error {
+	deleted, err := uc.keyRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+
+	if deleted > 0 {
+		uc.logger.WithField("deleted", deleted).Info("Expired idempotency keys purged")
+	}
+
+	return nil
+}
@@ -0,0 +1,394 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// maxValuationReportRange caps the period whose write-downs a valuation report covers
+const maxValuationReportRange = 366 * 24 * time.Hour
+
+// InventoryValuationUseCase handles revaluing inventory and reporting its value at cost.
+// Write-downs are recorded in a cost adjustment journal, separate from the stock movements
+// that record quantity changes.
+type InventoryValuationUseCase struct {
+	adjustmentRepo repositories.InventoryCostAdjustmentRepository
+	stockRepo      repositories.StockRepository
+	database       ports.DatabasePort
+	audit          ports.AuditPort
+	logger         logger.Logger
+}
+
+// NewInventoryValuationUseCase creates a new inventory valuation use case
+func NewInventoryValuationUseCase(
+	adjustmentRepo repositories.InventoryCostAdjustmentRepository,
+	stockRepo repositories.StockRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *InventoryValuationUseCase {
+	return &InventoryValuationUseCase{
+		adjustmentRepo: adjustmentRepo,
+		stockRepo:      stockRepo,
+		database:       database,
+		audit:          audit,
+		logger:         logger,
+	}
+}
+
+// WriteDownInventoryRequest represents write down inventory request
+type WriteDownInventoryRequest struct {
+	ProductID uuid.UUID                     `json:"product_id" validate:"required"`
+	Quantity  int                           `json:"quantity" validate:"required,min=1"`
+	UnitCost  decimal.Decimal               `json:"unit_cost" validate:"required"` // Cost the written down units are carried at
+	Reason    entities.CostAdjustmentReason `json:"reason" validate:"required"`
+	Notes     string                        `json:"notes,omitempty"`
+}
+
+// InventoryCostAdjustmentListResponse represents inventory cost adjustment list response
+type InventoryCostAdjustmentListResponse struct {
+	Adjustments []*entities.InventoryCostAdjustment `json:"adjustments"`
+	Pagination  utils.PaginationInfo                `json:"pagination"`
+}
+
+// InventoryValuationReport represents the value at cost of the inventory on hand and the
+// write-downs recorded in a period
+type InventoryValuationReport struct {
+	FromDate           time.Time                           `json:"from_date"`
+	ToDate             time.Time                           `json:"to_date"`
+	Method             entities.ValuationMethod            `json:"method"`
+	TotalUnits         int                                 `json:"total_units"`
+	TotalValue         decimal.Decimal                     `json:"total_value"` // Units on hand valued at current cost
+	TotalWriteDowns    decimal.Decimal                     `json:"total_write_downs"`
+	WriteDownsByReason []*CostAdjustmentReasonSummary      `json:"write_downs_by_reason"`
+	WriteDowns         []*repositories.CostAdjustmentTotal `json:"write_downs"`
+	Categories         []*InventoryValuationCategory       `json:"categories"`
+	Locations          []*InventoryValuationLocation       `json:"locations"`
+	UnallocatedUnits   int                                 `json:"unallocated_units"` // Units on hand not recorded at any location
+	UnallocatedValue   decimal.Decimal                     `json:"unallocated_value"`
+	GeneratedAt        time.Time                           `json:"generated_at"`
+}
+
+// CostAdjustmentReasonSummary represents the write-downs of one reason in a period
+type CostAdjustmentReasonSummary struct {
+	Reason   entities.CostAdjustmentReason `json:"reason"`
+	Count    int                           `json:"count"`
+	Quantity int                           `json:"quantity"`
+	Amount   decimal.Decimal               `json:"amount"`
+}
+
+// InventoryValuationCategory represents the value at cost of one category's units on hand
+type InventoryValuationCategory struct {
+	Category   string                                 `json:"category"`
+	TotalUnits int                                    `json:"total_units"`
+	TotalValue decimal.Decimal                        `json:"total_value"`
+	WriteDowns decimal.Decimal                        `json:"write_downs"` // Write-downs of the category's products in the period
+	Items      []*repositories.InventoryValuationItem `json:"items"`
+}
+
+// InventoryValuationLocation represents the value at cost of the units held at one location
+type InventoryValuationLocation struct {
+	LocationID   uuid.UUID                              `json:"location_id"`
+	LocationCode string                                 `json:"location_code"`
+	LocationName string                                 `json:"location_name"`
+	TotalUnits   int                                    `json:"total_units"`
+	TotalValue   decimal.Decimal                        `json:"total_value"`
+	Items        []*repositories.InventoryValuationItem `json:"items"`
+}
+
+// WriteDown revalues some of a product's units on hand to a lower cost. The product's
+// average cost is lowered accordingly; its stock quantity is unchanged.
+func (uc *InventoryValuationUseCase) WriteDown(ctx context.Context, tenantID, userID uuid.UUID, req WriteDownInventoryRequest) (*entities.InventoryCostAdjustment, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	product, err := tx.GetProductRepository().GetByID(ctx, req.ProductID)
+	if err != nil || product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("stock record")
+	}
+
+	adjustment, err := entities.NewInventoryCostAdjustment(product, stock.TotalQty, req.Quantity, req.UnitCost, req.Reason, req.Notes, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetInventoryCostAdjustmentRepository().Create(ctx, adjustment); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": req.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to create inventory cost adjustment")
+		return nil, errors.NewInternalError("failed to create inventory cost adjustment", err)
+	}
+
+	if err := tx.GetProductRepository().Update(ctx, product); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": req.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to update product cost")
+		return nil, errors.NewInternalError("failed to update product cost", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "write_down",
+		Resource:   "inventory_cost_adjustment",
+		ResourceID: adjustment.ID.String(),
+		OldValue: map[string]interface{}{
+			"cost": adjustment.PreviousUnitCost,
+		},
+		NewValue: map[string]interface{}{
+			"product_id": adjustment.ProductID,
+			"cost":       adjustment.ResultingUnitCost,
+			"quantity":   adjustment.Quantity,
+			"unit_cost":  adjustment.AdjustedUnitCost,
+			"amount":     adjustment.Amount,
+			"reason":     adjustment.Reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"product_id": adjustment.ProductID,
+		"amount":     adjustment.Amount.String(),
+		"reason":     adjustment.Reason,
+		"user_id":    userID,
+	}).Info("Inventory written down successfully")
+
+	return adjustment, nil
+}
+
+// GetCostAdjustment retrieves a cost adjustment journal entry
+func (uc *InventoryValuationUseCase) GetCostAdjustment(ctx context.Context, tenantID, adjustmentID uuid.UUID) (*entities.InventoryCostAdjustment, error) {
+	adjustment, err := uc.adjustmentRepo.GetByID(ctx, adjustmentID)
+	if err != nil || adjustment.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("inventory cost adjustment")
+	}
+
+	return adjustment, nil
+}
+
+// ListCostAdjustments lists the cost adjustment journal with pagination and filtering
+func (uc *InventoryValuationUseCase) ListCostAdjustments(ctx context.Context, filter repositories.InventoryCostAdjustmentFilter, pagination utils.PaginationInfo) (*InventoryCostAdjustmentListResponse, error) {
+	adjustments, paginationResult, err := uc.adjustmentRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list inventory cost adjustments")
+		return nil, errors.NewInternalError("failed to list inventory cost adjustments", err)
+	}
+
+	return &InventoryCostAdjustmentListResponse{
+		Adjustments: adjustments,
+		Pagination:  paginationResult,
+	}, nil
+}
+
+// GetValuationReport reports the inventory on hand valued by product, category and location,
+// together with the write-downs recorded in [from, to). The average cost method values units at
+// the product's current, written down cost; the latest cost method at what the product was
+// last received at.
+func (uc *InventoryValuationUseCase) GetValuationReport(ctx context.Context, tenantID uuid.UUID, from, to time.Time, method entities.ValuationMethod) (*InventoryValuationReport, error) {
+	if method == "" {
+		method = entities.ValuationMethodAverageCost
+	}
+	if err := entities.ValidateValuationMethod(method); err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if to.Sub(from) > maxValuationReportRange {
+		return nil, errors.NewValidationError("date range too long", "a valuation report cannot cover more than 366 days")
+	}
+
+	items, err := uc.stockRepo.GetInventoryValuation(ctx, tenantID, method)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get inventory valuation")
+		return nil, errors.NewInternalError("failed to generate valuation report", err)
+	}
+
+	locationItems, err := uc.stockRepo.GetInventoryValuationByLocation(ctx, tenantID, method)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get inventory valuation by location")
+		return nil, errors.NewInternalError("failed to generate valuation report", err)
+	}
+
+	writeDowns, err := uc.adjustmentRepo.GetTotals(ctx, tenantID, from, to)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get inventory cost adjustment totals")
+		return nil, errors.NewInternalError("failed to generate valuation report", err)
+	}
+
+	report := buildInventoryValuationReport(from, to, items, writeDowns)
+	report.Method = method
+	addValuationLocations(report, locationItems)
+	return report, nil
+}
+
+// buildInventoryValuationReport groups the valuation by category and sums the write-downs
+func buildInventoryValuationReport(from, to time.Time, items []*repositories.InventoryValuationItem, writeDowns []*repositories.CostAdjustmentTotal) *InventoryValuationReport {
+	report := &InventoryValuationReport{
+		FromDate:           from,
+		ToDate:             to,
+		TotalValue:         decimal.Zero,
+		TotalWriteDowns:    decimal.Zero,
+		WriteDownsByReason: []*CostAdjustmentReasonSummary{},
+		WriteDowns:         writeDowns,
+		Categories:         []*InventoryValuationCategory{},
+		GeneratedAt:        time.Now(),
+	}
+
+	byCategory := make(map[string]*InventoryValuationCategory)
+	categoryOf := make(map[uuid.UUID]string)
+	for _, item := range items {
+		category, ok := byCategory[item.Category]
+		if !ok {
+			category = &InventoryValuationCategory{
+				Category:   item.Category,
+				TotalValue: decimal.Zero,
+				WriteDowns: decimal.Zero,
+			}
+			byCategory[item.Category] = category
+			report.Categories = append(report.Categories, category)
+		}
+		category.Items = append(category.Items, item)
+		category.TotalUnits += item.OnHandQty
+		category.TotalValue = category.TotalValue.Add(item.Value)
+		categoryOf[item.ProductID] = item.Category
+
+		report.TotalUnits += item.OnHandQty
+		report.TotalValue = report.TotalValue.Add(item.Value)
+	}
+
+	byReason := make(map[entities.CostAdjustmentReason]*CostAdjustmentReasonSummary)
+	for _, total := range writeDowns {
+		summary, ok := byReason[total.Reason]
+		if !ok {
+			summary = &CostAdjustmentReasonSummary{Reason: total.Reason, Amount: decimal.Zero}
+			byReason[total.Reason] = summary
+			report.WriteDownsByReason = append(report.WriteDownsByReason, summary)
+		}
+		summary.Count += total.Count
+		summary.Quantity += total.Quantity
+		summary.Amount = summary.Amount.Add(total.Amount)
+
+		// Products written off entirely or since sold out are no longer on hand
+		if category, ok := byCategory[categoryOf[total.ProductID]]; ok {
+			category.WriteDowns = category.WriteDowns.Add(total.Amount)
+		}
+
+		report.TotalWriteDowns = report.TotalWriteDowns.Add(total.Amount)
+	}
+
+	// Largest write-downs first
+	sort.SliceStable(report.WriteDownsByReason, func(i, j int) bool {
+		return report.WriteDownsByReason[i].Amount.LessThan(report.WriteDownsByReason[j].Amount)
+	})
+
+	return report
+}
+
+// addValuationLocations groups the valuation of the units held at locations by location. Units
+// on hand that no location records holding are reported as unallocated.
+func addValuationLocations(report *InventoryValuationReport, items []*repositories.LocationValuationItem) {
+	report.Locations = []*InventoryValuationLocation{}
+	byLocation := make(map[uuid.UUID]*InventoryValuationLocation)
+
+	allocatedUnits := 0
+	allocatedValue := decimal.Zero
+	for _, item := range items {
+		location, ok := byLocation[item.LocationID]
+		if !ok {
+			location = &InventoryValuationLocation{
+				LocationID:   item.LocationID,
+				LocationCode: item.LocationCode,
+				LocationName: item.LocationName,
+				TotalValue:   decimal.Zero,
+			}
+			byLocation[item.LocationID] = location
+			report.Locations = append(report.Locations, location)
+		}
+		valuationItem := item.InventoryValuationItem
+		location.Items = append(location.Items, &valuationItem)
+		location.TotalUnits += item.OnHandQty
+		location.TotalValue = location.TotalValue.Add(item.Value)
+
+		allocatedUnits += item.OnHandQty
+		allocatedValue = allocatedValue.Add(item.Value)
+	}
+
+	report.UnallocatedUnits = report.TotalUnits - allocatedUnits
+	report.UnallocatedValue = report.TotalValue.Sub(allocatedValue)
+}
+
+// InventoryValuationCSV renders the valuation of each product for accounting, first across all
+// locations and then per location, with a total row
+func InventoryValuationCSV(report *InventoryValuationReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	w.Write([]string{"location_code", "location_name", "category", "product_sku", "product_name", "on_hand_qty", "unit_cost", "value"})
+	row := func(locationCode, locationName string, item *repositories.InventoryValuationItem) {
+		w.Write([]string{
+			locationCode,
+			locationName,
+			item.Category,
+			item.ProductSKU,
+			item.ProductName,
+			strconv.Itoa(item.OnHandQty),
+			item.UnitCost.StringFixed(2),
+			item.Value.StringFixed(2),
+		})
+	}
+
+	for _, category := range report.Categories {
+		for _, item := range category.Items {
+			row("all", "", item)
+		}
+	}
+	for _, location := range report.Locations {
+		for _, item := range location.Items {
+			row(location.LocationCode, location.LocationName, item)
+		}
+	}
+	w.Write([]string{"total", "", "", "", "", strconv.Itoa(report.TotalUnits), "", report.TotalValue.StringFixed(2)})
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.NewInternalError("failed to render inventory valuation", err)
+	}
+
+	return buf.Bytes(), nil
+}
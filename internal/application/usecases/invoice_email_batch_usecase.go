@@ -0,0 +1,371 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// invoiceEmailBatchListLimit caps the number of recent batches listed
+const invoiceEmailBatchListLimit = 50
+
+// InvoiceEmailBatchUseCase handles emailing invoices in bulk and the email consent list it respects
+type InvoiceEmailBatchUseCase struct {
+	invoiceRepo     repositories.InvoiceRepository
+	tenantRepo      repositories.TenantRepository
+	batchRepo       repositories.InvoiceEmailBatchRepository
+	suppressionRepo repositories.EmailSuppressionRepository
+	pdfService      services.InvoicePDFService
+	emailService    services.EmailService
+	delivery        *DeliveryPolicyUseCase
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewInvoiceEmailBatchUseCase creates a new invoice email batch use case
+func NewInvoiceEmailBatchUseCase(
+	invoiceRepo repositories.InvoiceRepository,
+	tenantRepo repositories.TenantRepository,
+	batchRepo repositories.InvoiceEmailBatchRepository,
+	suppressionRepo repositories.EmailSuppressionRepository,
+	pdfService services.InvoicePDFService,
+	emailService services.EmailService,
+	delivery *DeliveryPolicyUseCase,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *InvoiceEmailBatchUseCase {
+	return &InvoiceEmailBatchUseCase{
+		invoiceRepo:     invoiceRepo,
+		tenantRepo:      tenantRepo,
+		batchRepo:       batchRepo,
+		suppressionRepo: suppressionRepo,
+		pdfService:      pdfService,
+		emailService:    emailService,
+		delivery:        delivery,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// BulkEmailInvoicesRequest represents bulk email invoices request.
+// Exactly one of InvoiceIDs or Filter selects the invoices to email.
+type BulkEmailInvoicesRequest struct {
+	InvoiceIDs []uuid.UUID                 `json:"invoice_ids,omitempty"`
+	Filter     *repositories.InvoiceFilter `json:"filter,omitempty"`
+}
+
+// CreateEmailSuppressionRequest represents create email suppression request
+type CreateEmailSuppressionRequest struct {
+	Email  string `json:"email" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// BulkEmailInvoices queues an email to the customer of every selected invoice and returns the
+// batch used to track progress. Delivery happens in the background.
+func (uc *InvoiceEmailBatchUseCase) BulkEmailInvoices(ctx context.Context, tenantID, userID uuid.UUID, req BulkEmailInvoicesRequest) (*entities.InvoiceEmailBatch, error) {
+	invoices, err := uc.selectInvoices(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]string, 0, len(invoices))
+	for _, invoice := range invoices {
+		if email := entities.NormalizeEmail(invoice.CustomerEmail); email != "" {
+			recipients = append(recipients, email)
+		}
+	}
+
+	optedOut, err := uc.suppressionRepo.GetSuppressed(ctx, tenantID, recipients)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get email suppressions")
+		return nil, errors.NewInternalError("failed to check email consent", err)
+	}
+
+	batch, err := entities.NewInvoiceEmailBatch(tenantID, userID, invoices, optedOut)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.batchRepo.Create(ctx, batch); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to create invoice email batch")
+		return nil, errors.NewInternalError("failed to queue invoice emails", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "bulk_email",
+		Resource:   "invoice_email_batch",
+		ResourceID: batch.ID.String(),
+		NewValue: map[string]interface{}{
+			"total_count":   batch.TotalCount,
+			"skipped_count": batch.SkippedCount,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"batch_id":      batch.ID,
+		"total_count":   batch.TotalCount,
+		"skipped_count": batch.SkippedCount,
+		"user_id":       userID,
+	}).Info("Invoice email batch queued")
+
+	// Sending can take a while for large batches, so it must outlive the request
+	go uc.ProcessBatch(context.WithoutCancel(ctx), batch.ID)
+
+	return batch, nil
+}
+
+// ProcessBatch emails every pending invoice of a batch, recording the outcome of each
+func (uc *InvoiceEmailBatchUseCase) ProcessBatch(ctx context.Context, batchID uuid.UUID) error {
+	batch, err := uc.batchRepo.GetByID(ctx, batchID)
+	if err != nil {
+		return errors.NewNotFoundError("invoice email batch")
+	}
+
+	if err := batch.Start(); err != nil {
+		return err
+	}
+	if err := uc.batchRepo.Update(ctx, batch); err != nil {
+		return errors.NewInternalError("failed to update invoice email batch", err)
+	}
+
+	template := uc.pdfService.GetDefaultTemplate(entities.PaperSizeA4)
+
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		if item.Outcome != entities.InvoiceEmailOutcomePending {
+			continue
+		}
+
+		if err := uc.sendInvoice(ctx, batch.TenantID, batch.RequestedBy, item, template); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"batch_id":   batch.ID,
+				"invoice_id": item.InvoiceID,
+				"error":      err.Error(),
+			}).Warn("Failed to email invoice in batch")
+			batch.RecordOutcome(i, entities.InvoiceEmailOutcomeFailed, err.Error())
+		} else {
+			batch.RecordOutcome(i, entities.InvoiceEmailOutcomeSent, "")
+		}
+
+		if err := uc.batchRepo.UpdateItem(ctx, item); err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to update invoice email batch item")
+		}
+		if err := uc.batchRepo.Update(ctx, batch); err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to update invoice email batch")
+		}
+	}
+
+	batch.Complete()
+	if err := uc.batchRepo.Update(ctx, batch); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"batch_id": batchID,
+			"error":    err.Error(),
+		}).Error("Failed to update invoice email batch")
+		return errors.NewInternalError("failed to update invoice email batch", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"batch_id":      batch.ID,
+		"sent_count":    batch.SentCount,
+		"failed_count":  batch.FailedCount,
+		"skipped_count": batch.SkippedCount,
+	}).Info("Invoice email batch completed")
+
+	return nil
+}
+
+// GetBatch returns a batch with the outcome of each invoice
+func (uc *InvoiceEmailBatchUseCase) GetBatch(ctx context.Context, tenantID, batchID uuid.UUID) (*entities.InvoiceEmailBatch, error) {
+	batch, err := uc.batchRepo.GetByID(ctx, batchID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("invoice email batch")
+	}
+	if batch.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice email batch")
+	}
+
+	return batch, nil
+}
+
+// ListBatches lists the tenant's most recent batches
+func (uc *InvoiceEmailBatchUseCase) ListBatches(ctx context.Context, tenantID uuid.UUID) ([]*entities.InvoiceEmailBatch, error) {
+	batches, err := uc.batchRepo.GetByTenant(ctx, tenantID, invoiceEmailBatchListLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list invoice email batches")
+		return nil, errors.NewInternalError("failed to list invoice email batches", err)
+	}
+
+	return batches, nil
+}
+
+// ListSuppressions lists the addresses that opted out of emails from the tenant
+func (uc *InvoiceEmailBatchUseCase) ListSuppressions(ctx context.Context, tenantID uuid.UUID) ([]*entities.EmailSuppression, error) {
+	suppressions, err := uc.suppressionRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list email suppressions")
+		return nil, errors.NewInternalError("failed to list email suppressions", err)
+	}
+
+	return suppressions, nil
+}
+
+// AddSuppression records that an address opted out of emails from the tenant
+func (uc *InvoiceEmailBatchUseCase) AddSuppression(ctx context.Context, tenantID, userID uuid.UUID, req CreateEmailSuppressionRequest) (*entities.EmailSuppression, error) {
+	suppression, err := entities.NewEmailSuppression(tenantID, req.Email, req.Reason, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.suppressionRepo.Create(ctx, suppression); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to create email suppression")
+		return nil, errors.NewInternalError("failed to create email suppression", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "email_suppression",
+		ResourceID: suppression.Email,
+		NewValue: map[string]interface{}{
+			"email":  suppression.Email,
+			"reason": suppression.Reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return suppression, nil
+}
+
+// RemoveSuppression allows emails to an address again after it opted back in
+func (uc *InvoiceEmailBatchUseCase) RemoveSuppression(ctx context.Context, tenantID, userID uuid.UUID, email string) error {
+	email = entities.NormalizeEmail(email)
+
+	if err := uc.suppressionRepo.Delete(ctx, tenantID, email); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to delete email suppression")
+		return errors.NewInternalError("failed to delete email suppression", err)
+	}
+
+	// An address paused for failing repeatedly starts over with a clean failure count
+	uc.delivery.ClearEmailFailures(ctx, tenantID, email)
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "email_suppression",
+		ResourceID: email,
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return nil
+}
+
+// Helper functions
+
+// selectInvoices resolves the tenant's invoices selected by ID list or filter
+func (uc *InvoiceEmailBatchUseCase) selectInvoices(ctx context.Context, tenantID uuid.UUID, req BulkEmailInvoicesRequest) ([]*entities.Invoice, error) {
+	if (len(req.InvoiceIDs) == 0) == (req.Filter == nil) {
+		return nil, errors.NewValidationError("invalid invoice selection", "provide either invoice_ids or filter")
+	}
+
+	if req.Filter != nil {
+		invoices, pagination, err := uc.invoiceRepo.List(ctx, *req.Filter, utils.PaginationInfo{
+			Page:  1,
+			Limit: entities.MaxInvoiceEmailBatchSize,
+		})
+		if err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to list invoices")
+			return nil, errors.NewInternalError("failed to select invoices", err)
+		}
+		if pagination.TotalCount > entities.MaxInvoiceEmailBatchSize {
+			return nil, errors.NewValidationError("too many invoices selected", "narrow the filter to at most 500 invoices")
+		}
+
+		selected := make([]*entities.Invoice, 0, len(invoices))
+		for _, invoice := range invoices {
+			if invoice.TenantID == tenantID {
+				selected = append(selected, invoice)
+			}
+		}
+		return selected, nil
+	}
+
+	if len(req.InvoiceIDs) > entities.MaxInvoiceEmailBatchSize {
+		return nil, errors.NewValidationError("too many invoices selected", "a batch cannot exceed 500 invoices")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.InvoiceIDs))
+	selected := make([]*entities.Invoice, 0, len(req.InvoiceIDs))
+	for _, id := range req.InvoiceIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		invoice, err := uc.invoiceRepo.GetByID(ctx, id)
+		if err != nil || invoice.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("invoice " + id.String())
+		}
+		selected = append(selected, invoice)
+	}
+
+	return selected, nil
+}
+
+// sendInvoice renders and emails a single batch item's invoice under the tenant's email retry policy
+func (uc *InvoiceEmailBatchUseCase) sendInvoice(ctx context.Context, tenantID, requestedBy uuid.UUID, item *entities.InvoiceEmailBatchItem, template *entities.InvoiceTemplate) error {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, item.InvoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return errors.NewNotFoundError("invoice")
+	}
+
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, template)
+	if err != nil {
+		return errors.NewInternalError("failed to generate PDF", err)
+	}
+
+	err = uc.delivery.SendEmail(ctx, tenantID, requestedBy, item.Recipient, func(ctx context.Context) error {
+		return uc.emailService.SendInvoiceEmail(ctx, invoice, item.Recipient, pdfData)
+	})
+	if err != nil {
+		return errors.NewInternalError("failed to send email", err)
+	}
+
+	// Mark invoice as sent
+	if invoice.IsGenerated() {
+		if err := invoice.MarkAsSent(); err == nil {
+			uc.invoiceRepo.Update(ctx, invoice)
+		}
+	}
+
+	return nil
+}
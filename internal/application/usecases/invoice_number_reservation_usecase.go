@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// InvoiceNumberReservationUseCase handles reserving invoice numbers for invoices issued by
+// external systems, and binding or voiding them afterwards
+type InvoiceNumberReservationUseCase struct {
+	reservationRepo repositories.InvoiceNumberReservationRepository
+	invoiceRepo     repositories.InvoiceRepository
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewInvoiceNumberReservationUseCase creates a new invoice number reservation use case
+func NewInvoiceNumberReservationUseCase(
+	reservationRepo repositories.InvoiceNumberReservationRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *InvoiceNumberReservationUseCase {
+	return &InvoiceNumberReservationUseCase{
+		reservationRepo: reservationRepo,
+		invoiceRepo:     invoiceRepo,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// ReserveInvoiceNumberRequest represents reserve invoice number request
+type ReserveInvoiceNumberRequest struct {
+	Reason            string `json:"reason" validate:"required"`
+	ExternalReference string `json:"external_reference,omitempty"` // Document ID in the external system
+}
+
+// BindInvoiceNumberRequest represents bind invoice number request
+type BindInvoiceNumberRequest struct {
+	InvoiceID uuid.UUID `json:"invoice_id" validate:"required"`
+}
+
+// VoidInvoiceNumberRequest represents void invoice number request
+type VoidInvoiceNumberRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// InvoiceNumberReservationListResponse represents invoice number reservation list response
+type InvoiceNumberReservationListResponse struct {
+	Reservations []*entities.InvoiceNumberReservation `json:"reservations"`
+	Pagination   utils.PaginationInfo                 `json:"pagination"`
+}
+
+// ReserveNumber takes the next invoice number of the tenant's sequence
+func (uc *InvoiceNumberReservationUseCase) ReserveNumber(ctx context.Context, tenantID, userID uuid.UUID, req ReserveInvoiceNumberRequest) (*entities.InvoiceNumberReservation, error) {
+	reservation, err := entities.NewInvoiceNumberReservation(tenantID, req.Reason, req.ExternalReference, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.reservationRepo.Reserve(ctx, reservation); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to reserve invoice number")
+		return nil, errors.NewInternalError("failed to reserve invoice number", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "reserve",
+		Resource:   "invoice_number",
+		ResourceID: reservation.ID.String(),
+		NewValue: map[string]interface{}{
+			"invoice_number":     reservation.InvoiceNumber,
+			"reason":             reservation.Reason,
+			"external_reference": reservation.ExternalReference,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      tenantID,
+		"reservation_id": reservation.ID,
+		"invoice_number": reservation.InvoiceNumber,
+		"user_id":        userID,
+	}).Info("Invoice number reserved")
+
+	return reservation, nil
+}
+
+// GetReservation retrieves an invoice number reservation by ID
+func (uc *InvoiceNumberReservationUseCase) GetReservation(ctx context.Context, tenantID, reservationID uuid.UUID) (*entities.InvoiceNumberReservation, error) {
+	reservation, err := uc.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil || reservation.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice number reservation")
+	}
+
+	return reservation, nil
+}
+
+// ListReservations retrieves invoice number reservations with pagination and filtering
+func (uc *InvoiceNumberReservationUseCase) ListReservations(ctx context.Context, filter repositories.InvoiceNumberReservationFilter, pagination utils.PaginationInfo) (*InvoiceNumberReservationListResponse, error) {
+	reservations, paginationResult, err := uc.reservationRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list invoice number reservations")
+		return nil, errors.NewInternalError("failed to list invoice number reservations", err)
+	}
+
+	return &InvoiceNumberReservationListResponse{
+		Reservations: reservations,
+		Pagination:   paginationResult,
+	}, nil
+}
+
+// BindReservation gives a reserved number to a draft invoice, replacing the number it was
+// created with
+func (uc *InvoiceNumberReservationUseCase) BindReservation(ctx context.Context, tenantID, userID, reservationID uuid.UUID, req BindInvoiceNumberRequest) (*entities.InvoiceNumberReservation, error) {
+	reservation, err := uc.GetReservation(ctx, tenantID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := uc.invoiceRepo.GetByID(ctx, req.InvoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+	if !invoice.IsDraft() {
+		return nil, errors.NewValidationError("invalid invoice status", "only draft invoices can be given a reserved number")
+	}
+
+	if err := reservation.Bind(invoice.ID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reservationRepo.Bind(ctx, reservation); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"reservation_id": reservationID,
+			"error":          err.Error(),
+		}).Error("Failed to bind invoice number")
+		return nil, errors.NewInternalError("failed to bind invoice number", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "bind",
+		Resource:   "invoice_number",
+		ResourceID: reservation.ID.String(),
+		OldValue: map[string]interface{}{
+			"invoice_id":     invoice.ID,
+			"invoice_number": invoice.InvoiceNumber,
+		},
+		NewValue: map[string]interface{}{
+			"invoice_id":     invoice.ID,
+			"invoice_number": reservation.InvoiceNumber,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      tenantID,
+		"reservation_id": reservation.ID,
+		"invoice_id":     invoice.ID,
+		"invoice_number": reservation.InvoiceNumber,
+		"user_id":        userID,
+	}).Info("Reserved invoice number bound to invoice")
+
+	return reservation, nil
+}
+
+// VoidReservation records that a reserved number will not be used. The number is not reused.
+func (uc *InvoiceNumberReservationUseCase) VoidReservation(ctx context.Context, tenantID, userID, reservationID uuid.UUID, req VoidInvoiceNumberRequest) (*entities.InvoiceNumberReservation, error) {
+	reservation, err := uc.GetReservation(ctx, tenantID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reservation.Void(req.Reason, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reservationRepo.Void(ctx, reservation); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"reservation_id": reservationID,
+			"error":          err.Error(),
+		}).Error("Failed to void invoice number")
+		return nil, errors.NewInternalError("failed to void invoice number", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "void",
+		Resource:   "invoice_number",
+		ResourceID: reservation.ID.String(),
+		NewValue: map[string]interface{}{
+			"invoice_number": reservation.InvoiceNumber,
+			"reason":         reservation.VoidReason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      tenantID,
+		"reservation_id": reservation.ID,
+		"invoice_number": reservation.InvoiceNumber,
+		"user_id":        userID,
+	}).Info("Reserved invoice number voided")
+
+	return reservation, nil
+}
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// InvoicePortalUseCase serves invoice PDFs to customers through signed download links, which
+// are emailed in place of PDFs too large to attach. Links carry no session; the signature
+// and expiry are the only access control.
+type InvoicePortalUseCase struct {
+	invoiceRepo   repositories.InvoiceRepository
+	tenantRepo    repositories.TenantRepository
+	signatureRepo repositories.DocumentSignatureRepository
+	pdfService    services.InvoicePDFService
+	baseURL       string
+	secret        string
+	linkExpiry    time.Duration
+	logger        logger.Logger
+}
+
+// NewInvoicePortalUseCase creates a new invoice portal use case. Links point at baseURL, the
+// public URL of the API, are signed with secret and stay valid for linkExpiry.
+func NewInvoicePortalUseCase(
+	invoiceRepo repositories.InvoiceRepository,
+	tenantRepo repositories.TenantRepository,
+	signatureRepo repositories.DocumentSignatureRepository,
+	pdfService services.InvoicePDFService,
+	baseURL string,
+	secret string,
+	linkExpiry time.Duration,
+	logger logger.Logger,
+) *InvoicePortalUseCase {
+	return &InvoicePortalUseCase{
+		invoiceRepo:   invoiceRepo,
+		tenantRepo:    tenantRepo,
+		signatureRepo: signatureRepo,
+		pdfService:    pdfService,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		secret:        secret,
+		linkExpiry:    linkExpiry,
+		logger:        logger,
+	}
+}
+
+// InvoiceDownloadRequest represents the query of a signed invoice download link
+type InvoiceDownloadRequest struct {
+	PaperSize entities.PaperSize `form:"paper_size"`
+	Expires   string             `form:"expires"`
+	Signature string             `form:"signature"`
+}
+
+// DownloadURL signs a link to the invoice PDF rendered on the given paper size. It returns
+// the link and when it expires.
+func (uc *InvoicePortalUseCase) DownloadURL(invoiceID uuid.UUID, paperSize entities.PaperSize) (string, time.Time) {
+	link := entities.NewInvoiceDownloadLink(uc.secret, invoiceID, paperSize, time.Now().Add(uc.linkExpiry))
+
+	query := url.Values{}
+	query.Set("paper_size", string(link.PaperSize))
+	query.Set("expires", strconv.FormatInt(link.ExpiresAt.Unix(), 10))
+	query.Set("signature", link.Signature)
+
+	return fmt.Sprintf("%s/api/v1/portal/invoices/%s/pdf?%s", uc.baseURL, invoiceID, query.Encode()), link.ExpiresAt
+}
+
+// DownloadInvoicePDF verifies a signed download link and renders the invoice PDF it grants
+// access to
+func (uc *InvoicePortalUseCase) DownloadInvoicePDF(ctx context.Context, invoiceID uuid.UUID, req InvoiceDownloadRequest) (*entities.Invoice, []byte, error) {
+	expires, err := strconv.ParseInt(req.Expires, 10, 64)
+	if err != nil {
+		return nil, nil, errors.NewUnauthorizedError("invalid download link")
+	}
+
+	link := &entities.InvoiceDownloadLink{
+		InvoiceID: invoiceID,
+		PaperSize: req.PaperSize,
+		ExpiresAt: time.Unix(expires, 0),
+		Signature: req.Signature,
+	}
+	if err := link.Verify(uc.secret, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	// The signed link identifies the invoice, and with it the tenant
+	invoice, err := uc.invoiceRepo.GetByID(entities.WithAllTenants(ctx), invoiceID)
+	if err != nil {
+		return nil, nil, errors.NewNotFoundError("invoice")
+	}
+	ctx = entities.WithTenantScope(ctx, invoice.TenantID)
+
+	// Generate PDF, including the customer's signature if captured
+	attachInvoiceSignature(ctx, uc.signatureRepo, invoice)
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, uc.pdfService.GetDefaultTemplate(link.PaperSize))
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to generate invoice PDF for download link")
+		return nil, nil, errors.NewInternalError("failed to generate PDF", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  invoice.TenantID,
+		"invoice_id": invoiceID,
+	}).Info("Invoice downloaded through portal link")
+
+	return invoice, pdfData, nil
+}
@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// invoiceReminderPageSize is how many invoices are loaded at a time when scanning for reminders
+const invoiceReminderPageSize = 200
+
+// InvoiceReminderUseCase emails payment reminders for invoices approaching or past their due
+// date. Each reminder is logged before it is sent, so customers never get the same one twice.
+type InvoiceReminderUseCase struct {
+	invoiceRepo     repositories.InvoiceRepository
+	reminderRepo    repositories.InvoiceReminderRepository
+	suppressionRepo repositories.EmailSuppressionRepository
+	tenantRepo      repositories.TenantRepository
+	emailService    services.EmailService
+	delivery        *DeliveryPolicyUseCase
+	logger          logger.Logger
+	schedule        entities.InvoiceReminderSchedule
+	sendHour        int
+}
+
+// NewInvoiceReminderUseCase creates a new invoice reminder use case. Reminders are sent from
+// sendHour, in UTC, each day.
+func NewInvoiceReminderUseCase(
+	invoiceRepo repositories.InvoiceRepository,
+	reminderRepo repositories.InvoiceReminderRepository,
+	suppressionRepo repositories.EmailSuppressionRepository,
+	tenantRepo repositories.TenantRepository,
+	emailService services.EmailService,
+	delivery *DeliveryPolicyUseCase,
+	logger logger.Logger,
+	schedule entities.InvoiceReminderSchedule,
+	sendHour int,
+) *InvoiceReminderUseCase {
+	return &InvoiceReminderUseCase{
+		invoiceRepo:     invoiceRepo,
+		reminderRepo:    reminderRepo,
+		suppressionRepo: suppressionRepo,
+		tenantRepo:      tenantRepo,
+		emailService:    emailService,
+		delivery:        delivery,
+		logger:          logger,
+		schedule:        schedule,
+		sendHour:        sendHour,
+	}
+}
+
+// SendDueReminders emails the reminders due today for all tenants' unpaid invoices. It is run
+// periodically by the scheduler and does nothing before the day's send hour; reminders
+// already sent are skipped, so later runs only pick up what is still due.
+func (uc *InvoiceReminderUseCase) SendDueReminders(ctx context.Context) error {
+	now := time.Now().UTC()
+	if now.Hour() < uc.sendHour {
+		return nil
+	}
+
+	from, to := uc.schedule.Window(now)
+	filter := repositories.InvoiceFilter{
+		DueFromDate: &from,
+		DueToDate:   &to,
+		OrderBy:     "due_date",
+	}
+
+	sent, failed := 0, 0
+	for page := 1; ; page++ {
+		invoices, pagination, err := uc.invoiceRepo.List(ctx, filter, utils.PaginationInfo{Page: page, Limit: invoiceReminderPageSize})
+		if err != nil {
+			return fmt.Errorf("failed to list invoices due for reminders: %w", err)
+		}
+
+		for _, invoice := range invoices {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			kind, sequence, due := uc.schedule.DueReminder(invoice, now)
+			if !due {
+				continue
+			}
+
+			ok, err := uc.sendReminder(ctx, invoice, kind, sequence)
+			if err != nil {
+				uc.logger.WithFields(map[string]interface{}{
+					"tenant_id":  invoice.TenantID,
+					"invoice_id": invoice.ID,
+					"kind":       kind,
+					"error":      err.Error(),
+				}).Error("Failed to send invoice reminder")
+				failed++
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if !pagination.HasNext {
+			break
+		}
+	}
+
+	if sent > 0 || failed > 0 {
+		uc.logger.WithFields(map[string]interface{}{
+			"sent":   sent,
+			"failed": failed,
+		}).Info("Invoice reminders sent")
+	}
+
+	return nil
+}
+
+// GetReminders returns the reminders sent for a tenant's invoice, most recent first
+func (uc *InvoiceReminderUseCase) GetReminders(ctx context.Context, tenantID, invoiceID uuid.UUID) ([]*entities.InvoiceReminder, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	reminders, err := uc.reminderRepo.GetByInvoice(ctx, invoiceID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get invoice reminders")
+		return nil, errors.NewInternalError("failed to get invoice reminders", err)
+	}
+
+	return reminders, nil
+}
+
+// sendReminder claims and emails a single reminder. It returns false without sending if the
+// invoice already got the reminder or its customer's address is suppressed. The claim is
+// released if the email fails, so the next run retries it.
+func (uc *InvoiceReminderUseCase) sendReminder(ctx context.Context, invoice *entities.Invoice, kind entities.InvoiceReminderKind, sequence int) (bool, error) {
+	reminder, err := entities.NewInvoiceReminder(invoice, kind, sequence)
+	if err != nil {
+		return false, err
+	}
+
+	suppressed, err := uc.suppressionRepo.GetSuppressed(ctx, invoice.TenantID, []string{reminder.Recipient})
+	if err != nil {
+		return false, err
+	}
+	if suppressed[reminder.Recipient] {
+		return false, nil
+	}
+
+	claimed, err := uc.reminderRepo.Claim(ctx, reminder)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+	err = uc.delivery.SendEmail(ctx, invoice.TenantID, invoice.CreatedBy, reminder.Recipient, func(ctx context.Context) error {
+		if kind == entities.InvoiceReminderKindOverdue {
+			return uc.emailService.SendOverdueNotice(ctx, invoice, reminder.Recipient)
+		}
+		return uc.emailService.SendInvoiceReminder(ctx, invoice, reminder.Recipient)
+	})
+	if err != nil {
+		if releaseErr := uc.reminderRepo.Release(ctx, reminder.ID); releaseErr != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"invoice_id": invoice.ID,
+				"error":      releaseErr.Error(),
+			}).Error("Failed to release invoice reminder claim")
+		}
+		return false, err
+	}
+
+	return true, nil
+}
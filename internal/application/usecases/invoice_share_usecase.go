@@ -0,0 +1,401 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+//go:embed templates/public_invoice.html
+var publicInvoiceTemplateFS embed.FS
+
+// publicInvoiceTemplate renders the page an invoice share link opens
+var publicInvoiceTemplate = template.Must(template.ParseFS(publicInvoiceTemplateFS, "templates/public_invoice.html"))
+
+// InvoiceShareUseCase manages public share links of invoices and serves what they open: an
+// HTML view of the invoice, its PDF and, when the link has a payment gateway, online payment.
+// The link's token is the only access control.
+type InvoiceShareUseCase struct {
+	linkRepo       repositories.InvoiceShareLinkRepository
+	invoiceRepo    repositories.InvoiceRepository
+	tenantRepo     repositories.TenantRepository
+	signatureRepo  repositories.DocumentSignatureRepository
+	pdfService     services.InvoicePDFService
+	paymentGateway *PaymentGatewayUseCase
+	baseURL        string
+	audit          ports.AuditPort
+	logger         logger.Logger
+}
+
+// NewInvoiceShareUseCase creates a new invoice share use case. Links point at baseURL, the
+// public URL of the API.
+func NewInvoiceShareUseCase(
+	linkRepo repositories.InvoiceShareLinkRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	tenantRepo repositories.TenantRepository,
+	signatureRepo repositories.DocumentSignatureRepository,
+	pdfService services.InvoicePDFService,
+	paymentGateway *PaymentGatewayUseCase,
+	baseURL string,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *InvoiceShareUseCase {
+	return &InvoiceShareUseCase{
+		linkRepo:       linkRepo,
+		invoiceRepo:    invoiceRepo,
+		tenantRepo:     tenantRepo,
+		signatureRepo:  signatureRepo,
+		pdfService:     pdfService,
+		paymentGateway: paymentGateway,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		audit:          audit,
+		logger:         logger,
+	}
+}
+
+// CreateInvoiceShareLinkRequest represents create invoice share link request
+type CreateInvoiceShareLinkRequest struct {
+	ExpiresAt       *time.Time                      `json:"expires_at,omitempty"`       // Defaults to 30 days from now
+	PaymentProvider entities.PaymentGatewayProvider `json:"payment_provider,omitempty"` // Adds a pay now button
+}
+
+// UpdateInvoiceShareLinkRequest represents update invoice share link request
+type UpdateInvoiceShareLinkRequest struct {
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
+// PayInvoiceShareLinkRequest represents a payment made from a share link
+type PayInvoiceShareLinkRequest struct {
+	PaymentMethod entities.PaymentMethod `json:"payment_method" form:"payment_method"`
+}
+
+// InvoiceShareLinkResponse represents a share link. The URL is only returned when the link is
+// created, as only the hash of its token is stored.
+type InvoiceShareLinkResponse struct {
+	*entities.InvoiceShareLink
+	URL      string `json:"url,omitempty"`
+	IsActive bool   `json:"is_active"`
+}
+
+// publicInvoiceStatusLabels are the statuses shown to customers on the public invoice page
+var publicInvoiceStatusLabels = map[entities.InvoiceStatus]string{
+	entities.InvoiceStatusGenerated:     "Awaiting payment",
+	entities.InvoiceStatusSent:          "Awaiting payment",
+	entities.InvoiceStatusPartiallyPaid: "Partially paid",
+	entities.InvoiceStatusPaid:          "Paid",
+	entities.InvoiceStatusCancelled:     "Cancelled",
+}
+
+// publicInvoiceView is the data of the public invoice page
+type publicInvoiceView struct {
+	Invoice      *entities.Invoice
+	BusinessName string
+	Status       string
+	AmountDue    decimal.Decimal
+	PDFURL       string
+	PayURL       string
+}
+
+// CreateShareLink creates a public link to an invoice. The invoice must have been generated and
+// not be cancelled; a payment gateway can only be set if it is configured.
+func (uc *InvoiceShareUseCase) CreateShareLink(ctx context.Context, tenantID, userID, invoiceID uuid.UUID, req CreateInvoiceShareLinkRequest) (*InvoiceShareLinkResponse, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+	if invoice.IsDraft() || invoice.IsCancelled() {
+		return nil, errors.NewValidationError("invalid invoice status", "only generated, sent or paid invoices can be shared")
+	}
+	if req.PaymentProvider != "" {
+		if _, err := uc.paymentGateway.gateway(req.PaymentProvider); err != nil {
+			return nil, err
+		}
+	}
+
+	expiresAt := time.Now().Add(entities.DefaultInvoiceShareLinkExpiry)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	link, token, err := entities.NewInvoiceShareLink(tenantID, invoiceID, req.PaymentProvider, expiresAt, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.linkRepo.Create(ctx, link); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to create invoice share link")
+		return nil, errors.NewInternalError("failed to create invoice share link", err)
+	}
+
+	// Audit log
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "invoice_share_link",
+		ResourceID: link.ID.String(),
+		NewValue: map[string]interface{}{
+			"invoice_id":       invoiceID,
+			"payment_provider": link.PaymentProvider,
+			"expires_at":       link.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	response := uc.toShareLinkResponse(link)
+	response.URL = fmt.Sprintf("%s/api/v1/public/invoices/%s", uc.baseURL, token)
+	return response, nil
+}
+
+// ListShareLinks lists the share links of an invoice, newest first
+func (uc *InvoiceShareUseCase) ListShareLinks(ctx context.Context, tenantID, invoiceID uuid.UUID) ([]*InvoiceShareLinkResponse, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	links, err := uc.linkRepo.ListByInvoice(ctx, invoiceID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list invoice share links")
+		return nil, errors.NewInternalError("failed to list invoice share links", err)
+	}
+
+	responses := make([]*InvoiceShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = uc.toShareLinkResponse(link)
+	}
+	return responses, nil
+}
+
+// UpdateShareLink extends or shortens a share link's expiry
+func (uc *InvoiceShareUseCase) UpdateShareLink(ctx context.Context, tenantID, userID, invoiceID, linkID uuid.UUID, req UpdateInvoiceShareLinkRequest) (*InvoiceShareLinkResponse, error) {
+	link, err := uc.getShareLink(ctx, tenantID, invoiceID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldExpiresAt := link.ExpiresAt
+	if err := link.SetExpiry(req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := uc.saveShareLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	// Audit log
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "invoice_share_link",
+		ResourceID: link.ID.String(),
+		OldValue:   map[string]interface{}{"expires_at": oldExpiresAt},
+		NewValue:   map[string]interface{}{"expires_at": link.ExpiresAt},
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	return uc.toShareLinkResponse(link), nil
+}
+
+// RevokeShareLink permanently disables a share link
+func (uc *InvoiceShareUseCase) RevokeShareLink(ctx context.Context, tenantID, userID, invoiceID, linkID uuid.UUID) error {
+	link, err := uc.getShareLink(ctx, tenantID, invoiceID, linkID)
+	if err != nil {
+		return err
+	}
+
+	if err := link.Revoke(); err != nil {
+		return err
+	}
+
+	if err := uc.saveShareLink(ctx, link); err != nil {
+		return err
+	}
+
+	// Audit log
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "revoke",
+		Resource:   "invoice_share_link",
+		ResourceID: link.ID.String(),
+		NewValue:   map[string]interface{}{"invoice_id": invoiceID},
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	return nil
+}
+
+// ViewInvoice renders the HTML page a share link opens and counts the view
+func (uc *InvoiceShareUseCase) ViewInvoice(ctx context.Context, token string) ([]byte, error) {
+	ctx, link, invoice, err := uc.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	view := publicInvoiceView{
+		Invoice:   invoice,
+		Status:    publicInvoiceStatusLabels[invoice.Status],
+		AmountDue: invoice.OutstandingAmount(),
+		PDFURL:    fmt.Sprintf("%s/api/v1/public/invoices/%s/pdf", uc.baseURL, token),
+	}
+	if tenant, err := uc.tenantRepo.GetByID(ctx, invoice.TenantID); err == nil {
+		view.BusinessName = tenant.Configuration.BusinessInfo.Name
+		if view.BusinessName == "" {
+			view.BusinessName = tenant.Name
+		}
+	}
+	if link.PaymentProvider != "" && canPayOnline(invoice) {
+		view.PayURL = fmt.Sprintf("%s/api/v1/public/invoices/%s/pay", uc.baseURL, token)
+	}
+
+	var buf bytes.Buffer
+	if err := publicInvoiceTemplate.Execute(&buf, view); err != nil {
+		return nil, errors.NewInternalError("failed to render invoice", err)
+	}
+
+	if err := uc.linkRepo.RecordView(ctx, link.ID, time.Now()); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"link_id": link.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to record invoice share link view")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DownloadInvoicePDF renders the PDF of the invoice a share link opens
+func (uc *InvoiceShareUseCase) DownloadInvoicePDF(ctx context.Context, token string) (*entities.Invoice, []byte, error) {
+	ctx, _, invoice, err := uc.resolve(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate PDF, including the customer's signature if captured
+	attachInvoiceSignature(ctx, uc.signatureRepo, invoice)
+	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, uc.pdfService.GetDefaultTemplate(entities.PaperSizeA4))
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoice.ID,
+			"error":      err.Error(),
+		}).Error("Failed to generate invoice PDF for share link")
+		return nil, nil, errors.NewInternalError("failed to generate PDF", err)
+	}
+
+	return invoice, pdfData, nil
+}
+
+// PayInvoice starts an online payment of the outstanding amount through the link's payment
+// gateway. The payment is made on behalf of the user who created the link.
+func (uc *InvoiceShareUseCase) PayInvoice(ctx context.Context, token string, req PayInvoiceShareLinkRequest) (*entities.PaymentTransaction, error) {
+	ctx, link, invoice, err := uc.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if link.PaymentProvider == "" {
+		return nil, errors.NewValidationError("online payment not available", "this link cannot be used to pay the invoice")
+	}
+	if !canPayOnline(invoice) {
+		return nil, errors.NewValidationError("invoice cannot be paid", "the invoice has nothing left to pay")
+	}
+	if req.PaymentMethod == "" {
+		req.PaymentMethod = entities.PaymentMethodCard
+	}
+
+	return uc.paymentGateway.CreateInvoicePaymentIntent(ctx, link.TenantID, link.CreatedBy, link.InvoiceID, CreateInvoicePaymentIntentRequest{
+		Provider:      link.PaymentProvider,
+		PaymentMethod: req.PaymentMethod,
+	})
+}
+
+// resolve finds the active share link of a token and the invoice it opens, with its currency
+// display attached. The returned context is scoped to the link's tenant.
+func (uc *InvoiceShareUseCase) resolve(ctx context.Context, token string) (context.Context, *entities.InvoiceShareLink, *entities.Invoice, error) {
+	if token == "" {
+		return nil, nil, nil, errors.NewNotFoundError("invoice")
+	}
+
+	link, err := uc.linkRepo.GetByTokenHash(ctx, entities.HashInvoiceShareLinkToken(token))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, nil, nil, errors.NewNotFoundError("invoice")
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get invoice share link")
+		return nil, nil, nil, errors.NewInternalError("failed to get invoice share link", err)
+	}
+	if !link.IsActive(time.Now()) {
+		return nil, nil, nil, errors.NewUnauthorizedError("share link has expired or been revoked")
+	}
+
+	// Share links are not authenticated as a user, so restrict data access to the link's tenant
+	ctx = entities.WithTenantScope(ctx, link.TenantID)
+
+	invoice, err := uc.invoiceRepo.GetByID(ctx, link.InvoiceID)
+	if err != nil || invoice.TenantID != link.TenantID {
+		return nil, nil, nil, errors.NewNotFoundError("invoice")
+	}
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+
+	return ctx, link, invoice, nil
+}
+
+// getShareLink retrieves a share link of a tenant's invoice
+func (uc *InvoiceShareUseCase) getShareLink(ctx context.Context, tenantID, invoiceID, linkID uuid.UUID) (*entities.InvoiceShareLink, error) {
+	link, err := uc.linkRepo.GetByID(ctx, linkID)
+	if err != nil || link.TenantID != tenantID || link.InvoiceID != invoiceID {
+		return nil, errors.NewNotFoundError("invoice share link")
+	}
+	return link, nil
+}
+
+// saveShareLink saves a share link's expiry and revocation
+func (uc *InvoiceShareUseCase) saveShareLink(ctx context.Context, link *entities.InvoiceShareLink) error {
+	if err := uc.linkRepo.Update(ctx, link); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"link_id": link.ID,
+			"error":   err.Error(),
+		}).Error("Failed to update invoice share link")
+		return errors.NewInternalError("failed to update invoice share link", err)
+	}
+	return nil
+}
+
+// toShareLinkResponse converts a share link to its response
+func (uc *InvoiceShareUseCase) toShareLinkResponse(link *entities.InvoiceShareLink) *InvoiceShareLinkResponse {
+	return &InvoiceShareLinkResponse{
+		InvoiceShareLink: link,
+		IsActive:         link.IsActive(time.Now()),
+	}
+}
+
+// canPayOnline reports whether an invoice has an outstanding amount that can be paid through a
+// payment gateway
+func canPayOnline(invoice *entities.Invoice) bool {
+	switch invoice.Status {
+	case entities.InvoiceStatusGenerated, entities.InvoiceStatusSent, entities.InvoiceStatusPartiallyPaid:
+		return invoice.OutstandingAmount().IsPositive()
+	default:
+		return false
+	}
+}
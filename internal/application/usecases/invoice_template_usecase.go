@@ -0,0 +1,382 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// InvoiceTemplateUseCase handles the invoice templates tenants design: their logo, header and
+// footer, accent color, columns and custom fields
+type InvoiceTemplateUseCase struct {
+	templateRepo repositories.InvoiceTemplateRepository
+	tenantRepo   repositories.TenantRepository
+	pdfService   services.InvoicePDFService
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewInvoiceTemplateUseCase creates a new invoice template use case
+func NewInvoiceTemplateUseCase(
+	templateRepo repositories.InvoiceTemplateRepository,
+	tenantRepo repositories.TenantRepository,
+	pdfService services.InvoicePDFService,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *InvoiceTemplateUseCase {
+	return &InvoiceTemplateUseCase{
+		templateRepo: templateRepo,
+		tenantRepo:   tenantRepo,
+		pdfService:   pdfService,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// SaveInvoiceTemplateRequest represents create or update invoice template request. The
+// template's currency defaults to the tenant's. A base64 logo in the template replaces the
+// stored logo.
+type SaveInvoiceTemplateRequest struct {
+	Name      string                   `json:"name" validate:"required"`
+	Template  entities.InvoiceTemplate `json:"template" validate:"required"`
+	IsDefault bool                     `json:"is_default,omitempty"`
+}
+
+// CreateTemplate creates a named invoice template, making it the tenant's default if asked
+func (uc *InvoiceTemplateUseCase) CreateTemplate(ctx context.Context, tenantID, userID uuid.UUID, req SaveInvoiceTemplateRequest) (*entities.CustomInvoiceTemplate, error) {
+	design, err := uc.completeDesign(ctx, tenantID, req.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := entities.NewCustomInvoiceTemplate(tenantID, req.Name, design, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create invoice template")
+		return nil, errors.NewInternalError("failed to create invoice template", err)
+	}
+
+	if req.IsDefault {
+		if err := uc.setDefault(ctx, template); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.logAudit(ctx, userID, "create", template)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"template_id": template.ID,
+		"tenant_id":   tenantID,
+		"user_id":     userID,
+	}).Info("Invoice template created")
+
+	return template, nil
+}
+
+// ListTemplates returns the tenant's invoice templates by name, only those for a paper size
+// when one is given
+func (uc *InvoiceTemplateUseCase) ListTemplates(ctx context.Context, tenantID uuid.UUID, paperSize entities.PaperSize) ([]*entities.CustomInvoiceTemplate, error) {
+	templates, err := uc.templateRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list invoice templates")
+		return nil, errors.NewInternalError("failed to list invoice templates", err)
+	}
+
+	if paperSize == "" {
+		return templates, nil
+	}
+	filtered := make([]*entities.CustomInvoiceTemplate, 0, len(templates))
+	for _, template := range templates {
+		if template.Template.PaperSize == paperSize {
+			filtered = append(filtered, template)
+		}
+	}
+	return filtered, nil
+}
+
+// GetTemplate returns one of the tenant's invoice templates
+func (uc *InvoiceTemplateUseCase) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*entities.CustomInvoiceTemplate, error) {
+	template, err := uc.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get invoice template")
+		return nil, errors.NewInternalError("failed to get invoice template", err)
+	}
+	if template.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice template")
+	}
+
+	return template, nil
+}
+
+// UpdateTemplate replaces an invoice template's name and design
+func (uc *InvoiceTemplateUseCase) UpdateTemplate(ctx context.Context, tenantID, userID, id uuid.UUID, req SaveInvoiceTemplateRequest) (*entities.CustomInvoiceTemplate, error) {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	design, err := uc.completeDesign(ctx, tenantID, req.Template)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Update(req.Name, design, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.save(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if req.IsDefault && !template.IsDefault {
+		if err := uc.setDefault(ctx, template); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.logAudit(ctx, userID, "update", template)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"template_id": template.ID,
+		"user_id":     userID,
+	}).Info("Invoice template updated")
+
+	return template, nil
+}
+
+// DeleteTemplate deletes an invoice template. Invoices of a tenant without a default template
+// use the built-in template.
+func (uc *InvoiceTemplateUseCase) DeleteTemplate(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.templateRepo.Delete(ctx, template.ID); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to delete invoice template")
+		return errors.NewInternalError("failed to delete invoice template", err)
+	}
+
+	uc.logAudit(ctx, userID, "delete", template)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"template_id": template.ID,
+		"user_id":     userID,
+	}).Info("Invoice template deleted")
+
+	return nil
+}
+
+// SetDefaultTemplate makes an invoice template the one used for invoices that do not name one
+func (uc *InvoiceTemplateUseCase) SetDefaultTemplate(ctx context.Context, tenantID, userID, id uuid.UUID) (*entities.CustomInvoiceTemplate, error) {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.setDefault(ctx, template); err != nil {
+		return nil, err
+	}
+
+	uc.logAudit(ctx, userID, "set_default", template)
+
+	return template, nil
+}
+
+// UploadLogo replaces an invoice template's logo with a PNG or JPEG image
+func (uc *InvoiceTemplateUseCase) UploadLogo(ctx context.Context, tenantID, userID, id uuid.UUID, data []byte) (*entities.CustomInvoiceTemplate, error) {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := template.SetLogo(data, userID); err != nil {
+		return nil, err
+	}
+	if err := uc.pdfService.ValidateTemplate(template.Design()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.save(ctx, template); err != nil {
+		return nil, err
+	}
+
+	uc.logAudit(ctx, userID, "upload_logo", template)
+
+	return template, nil
+}
+
+// GetLogo returns an invoice template's logo and its content type
+func (uc *InvoiceTemplateUseCase) GetLogo(ctx context.Context, tenantID, id uuid.UUID) ([]byte, string, error) {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(template.Logo) == 0 {
+		return nil, "", errors.NewNotFoundError("invoice template logo")
+	}
+
+	return template.Logo, template.LogoContentType, nil
+}
+
+// DeleteLogo removes an invoice template's logo
+func (uc *InvoiceTemplateUseCase) DeleteLogo(ctx context.Context, tenantID, userID, id uuid.UUID) (*entities.CustomInvoiceTemplate, error) {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := template.RemoveLogo(userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.save(ctx, template); err != nil {
+		return nil, err
+	}
+
+	uc.logAudit(ctx, userID, "delete_logo", template)
+
+	return template, nil
+}
+
+// PreviewTemplate renders a sample invoice with a stored invoice template
+func (uc *InvoiceTemplateUseCase) PreviewTemplate(ctx context.Context, tenantID, id uuid.UUID) ([]byte, error) {
+	template, err := uc.GetTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.renderSample(ctx, tenantID, template.Design())
+}
+
+// PreviewDesign renders a sample invoice with a design that has not been saved
+func (uc *InvoiceTemplateUseCase) PreviewDesign(ctx context.Context, tenantID uuid.UUID, design entities.InvoiceTemplate) ([]byte, error) {
+	completed, err := uc.completeDesign(ctx, tenantID, design)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidatePaperSize(completed.PaperSize); err != nil {
+		return nil, err
+	}
+
+	return uc.renderSample(ctx, tenantID, &completed)
+}
+
+// StoredTemplate returns the design of the template an invoice is rendered with: the named
+// template, else the tenant's default template, else nil for the built-in template
+func (uc *InvoiceTemplateUseCase) StoredTemplate(ctx context.Context, tenantID uuid.UUID, templateID *uuid.UUID) (*entities.InvoiceTemplate, error) {
+	if templateID != nil {
+		template, err := uc.GetTemplate(ctx, tenantID, *templateID)
+		if err != nil {
+			return nil, err
+		}
+		return template.Design(), nil
+	}
+
+	template, err := uc.templateRepo.GetDefault(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, nil
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get default invoice template")
+		return nil, errors.NewInternalError("failed to get default invoice template", err)
+	}
+
+	return template.Design(), nil
+}
+
+// renderSample renders the sample invoice in the tenant's currency display
+func (uc *InvoiceTemplateUseCase) renderSample(ctx context.Context, tenantID uuid.UUID, design *entities.InvoiceTemplate) ([]byte, error) {
+	if err := uc.pdfService.ValidateTemplate(design); err != nil {
+		return nil, err
+	}
+
+	invoice := entities.SampleInvoice()
+	invoice.TenantID = tenantID
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
+
+	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, design)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to render invoice template preview")
+		return nil, errors.NewInternalError("failed to render invoice template preview", err)
+	}
+
+	return pdfData, nil
+}
+
+// completeDesign defaults the currency of a design to the tenant's and checks it can be
+// rendered
+func (uc *InvoiceTemplateUseCase) completeDesign(ctx context.Context, tenantID uuid.UUID, design entities.InvoiceTemplate) (entities.InvoiceTemplate, error) {
+	if design.Currency == "" {
+		tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			return design, errors.NewInternalError("failed to get tenant", err)
+		}
+		design.Currency = tenant.GetCurrency()
+	}
+
+	if err := uc.pdfService.ValidateTemplate(&design); err != nil {
+		return design, err
+	}
+
+	return design, nil
+}
+
+func (uc *InvoiceTemplateUseCase) save(ctx context.Context, template *entities.CustomInvoiceTemplate) error {
+	if err := uc.templateRepo.Update(ctx, template); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			return err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"template_id": template.ID,
+			"error":       err.Error(),
+		}).Error("Failed to update invoice template")
+		return errors.NewInternalError("failed to update invoice template", err)
+	}
+	return nil
+}
+
+func (uc *InvoiceTemplateUseCase) setDefault(ctx context.Context, template *entities.CustomInvoiceTemplate) error {
+	if err := uc.templateRepo.SetDefault(ctx, template.TenantID, template.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"template_id": template.ID,
+			"error":       err.Error(),
+		}).Error("Failed to set default invoice template")
+		return errors.NewInternalError("failed to set default invoice template", err)
+	}
+	template.IsDefault = true
+	return nil
+}
+
+func (uc *InvoiceTemplateUseCase) logAudit(ctx context.Context, userID uuid.UUID, action string, template *entities.CustomInvoiceTemplate) {
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "invoice_template",
+		ResourceID: template.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":       template.Name,
+			"is_default": template.IsDefault,
+			"has_logo":   len(template.Logo) > 0,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+}
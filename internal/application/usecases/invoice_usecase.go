@@ -21,9 +21,15 @@ type InvoiceUseCase struct {
 	invoiceRepo     repositories.InvoiceRepository
 	invoiceItemRepo repositories.InvoiceItemRepository
 	saleRepo        repositories.SaleRepository
+	tenantRepo      repositories.TenantRepository
+	signatureRepo   repositories.DocumentSignatureRepository
+	outboxRepo      repositories.EmailOutboxRepository
 	pdfService      services.InvoicePDFService
-	emailService    services.EmailService
 	printService    services.PrintService
+	printerRepo     repositories.PrinterRepository
+	templates       *InvoiceTemplateUseCase
+	webhooks        *WebhookUseCase
+	featureUsage    *FeatureUsageUseCase
 	database        ports.DatabasePort
 	audit           ports.AuditPort
 	logger          logger.Logger
@@ -34,9 +40,15 @@ func NewInvoiceUseCase(
 	invoiceRepo repositories.InvoiceRepository,
 	invoiceItemRepo repositories.InvoiceItemRepository,
 	saleRepo repositories.SaleRepository,
+	tenantRepo repositories.TenantRepository,
+	signatureRepo repositories.DocumentSignatureRepository,
+	outboxRepo repositories.EmailOutboxRepository,
 	pdfService services.InvoicePDFService,
-	emailService services.EmailService,
 	printService services.PrintService,
+	printerRepo repositories.PrinterRepository,
+	templates *InvoiceTemplateUseCase,
+	webhooks *WebhookUseCase,
+	featureUsage *FeatureUsageUseCase,
 	database ports.DatabasePort,
 	audit ports.AuditPort,
 	logger logger.Logger,
@@ -45,9 +57,15 @@ func NewInvoiceUseCase(
 		invoiceRepo:     invoiceRepo,
 		invoiceItemRepo: invoiceItemRepo,
 		saleRepo:        saleRepo,
+		tenantRepo:      tenantRepo,
+		signatureRepo:   signatureRepo,
+		outboxRepo:      outboxRepo,
 		pdfService:      pdfService,
-		emailService:    emailService,
 		printService:    printService,
+		printerRepo:     printerRepo,
+		templates:       templates,
+		webhooks:        webhooks,
+		featureUsage:    featureUsage,
 		database:        database,
 		audit:           audit,
 		logger:          logger,
@@ -58,18 +76,22 @@ func NewInvoiceUseCase(
 type CreateInvoiceRequest struct {
 	SaleID          uuid.UUID `json:"sale_id" validate:"required"`
 	CustomerAddress string     `json:"customer_address,omitempty"`
+	CustomerTaxID   string     `json:"customer_tax_id,omitempty"`
 	DueDate         *time.Time  `json:"due_date,omitempty"`
 	Notes           string    `json:"notes,omitempty"`
 } 
 
-// GenerateInvoicePDFRequest represents generate invoice PDF request
+// GenerateInvoicePDFRequest represents generate invoice PDF request. Without a template, the
+// stored template named by template ID or else the tenant's default template is used.
 type GenerateInvoicePDFRequest struct {
-	InvoiceID uuid.UUID             `json:"invoice_id" validate:"required"`
-	PaperSize entities.PaperSize    `json:"paper_size,omitempty"`
-	Template  *entities.InvoiceTemplate `json:"template,omitempty"`
+	InvoiceID  uuid.UUID                 `json:"invoice_id" validate:"required"`
+	PaperSize  entities.PaperSize        `json:"paper_size,omitempty"`
+	Template   *entities.InvoiceTemplate `json:"template,omitempty"`
+	TemplateID *uuid.UUID                `json:"template_id,omitempty"`
 }
 
-// SendInvoiceEmailRequest represents send invoice email request
+// SendInvoiceEmailRequest represents send invoice email request. Templates are chosen as for
+// generating the PDF.
 type SendInvoiceEmailRequest struct {
 	InvoiceID   uuid.UUID             `json:"invoice_id" validate:"required"`
 	EmailTo     string                `json:"email_to" validate:"required,email"`
@@ -77,39 +99,50 @@ type SendInvoiceEmailRequest struct {
 	Message     string                `json:"message,omitempty"`
 	PaperSize   entities.PaperSize    `json:"paper_size,omitempty"`
 	Template    *entities.InvoiceTemplate `json:"template,omitempty"`
+	TemplateID  *uuid.UUID                `json:"template_id,omitempty"`
 }
 
-// PrintInvoiceRequest represents print invoice request
+// PrintInvoiceRequest represents print invoice request. Without a printer name, the invoice is
+// printed to the printer registered for the terminal. Templates are chosen as for generating
+// the PDF.
 type PrintInvoiceRequest struct {
 	InvoiceID   uuid.UUID             `json:"invoice_id" validate:"required"`
 	PrinterName string                `json:"printer_name,omitempty"`
+	Terminal    string                `json:"terminal,omitempty"`
 	PaperSize   entities.PaperSize    `json:"paper_size,omitempty"`
 	Template    *entities.InvoiceTemplate `json:"template,omitempty"`
+	TemplateID  *uuid.UUID                `json:"template_id,omitempty"`
 }
 
 // InvoiceResponse represents invoice response
 type InvoiceResponse struct {
-	ID              uuid.UUID                 `json:"id"`
-	InvoiceNumber   string                    `json:"invoice_number"`
-	SaleID          uuid.UUID                 `json:"sale_id"`
-	CustomerName    string                    `json:"customer_name"`
-	CustomerEmail   string                    `json:"customer_email,omitempty"`
-	CustomerPhone   string                    `json:"customer_phone,omitempty"`
-	CustomerAddress string                    `json:"customer_address,omitempty"`
-	Items           []*InvoiceItemResponse    `json:"items"`
-	Subtotal        decimal.Decimal           `json:"subtotal"`
-	TaxAmount       decimal.Decimal           `json:"tax_amount"`
-	DiscountAmount  decimal.Decimal           `json:"discount_amount"`
-	TotalAmount     decimal.Decimal           `json:"total_amount"`
-	PaidAmount      decimal.Decimal           `json:"paid_amount"`
-	PaymentMethod   entities.PaymentMethod    `json:"payment_method"`
-	Status          entities.InvoiceStatus    `json:"status"`
-	Notes           string                    `json:"notes,omitempty"`
-	DueDate         *time.Time                `json:"due_date,omitempty"`
-	PaidAt          *time.Time                `json:"paid_at,omitempty"`
-	CreatedAt       time.Time                 `json:"created_at"`
-	UpdatedAt       time.Time                 `json:"updated_at"`
-	CreatedBy       uuid.UUID                 `json:"created_by"`
+	ID                uuid.UUID                     `json:"id"`
+	InvoiceNumber     string                        `json:"invoice_number"`
+	SaleID            uuid.UUID                     `json:"sale_id"`
+	CustomerName      string                        `json:"customer_name"`
+	CustomerEmail     string                        `json:"customer_email,omitempty"`
+	CustomerPhone     string                        `json:"customer_phone,omitempty"`
+	CustomerAddress   string                        `json:"customer_address,omitempty"`
+	CustomerTaxID     string                        `json:"customer_tax_id,omitempty"`
+	Items             []*InvoiceItemResponse        `json:"items"`
+	Subtotal          decimal.Decimal               `json:"subtotal"`
+	TaxAmount         decimal.Decimal               `json:"tax_amount"`
+	DiscountAmount    decimal.Decimal               `json:"discount_amount"`
+	SurchargeAmount   decimal.Decimal               `json:"surcharge_amount"`
+	SurchargeLabel    string                        `json:"surcharge_label,omitempty"`
+	TotalAmount       decimal.Decimal               `json:"total_amount"`
+	PaidAmount        decimal.Decimal               `json:"paid_amount"`
+	OutstandingAmount decimal.Decimal               `json:"outstanding_amount"`
+	PaymentMethod     entities.PaymentMethod        `json:"payment_method"`
+	Status            entities.InvoiceStatus        `json:"status"`
+	Notes             string                        `json:"notes,omitempty"`
+	Currency          string                        `json:"currency,omitempty"` // Amounts are in it
+	DueDate           *time.Time                    `json:"due_date,omitempty"` // With a payment schedule, the due date of the next installment
+	PaymentSchedule   []entities.InvoiceInstallment `json:"payment_schedule,omitempty"`
+	PaidAt            *time.Time                    `json:"paid_at,omitempty"`
+	CreatedAt         time.Time                     `json:"created_at"`
+	UpdatedAt         time.Time                     `json:"updated_at"`
+	CreatedBy         uuid.UUID                     `json:"created_by"`
 }
 
 // InvoiceItemResponse represents invoice item response
@@ -124,6 +157,30 @@ type InvoiceItemResponse struct {
 	TotalPrice  decimal.Decimal `json:"total_price"`
 }
 
+// RecordInvoicePaymentRequest represents a payment received against an invoice
+type RecordInvoicePaymentRequest struct {
+	Amount        decimal.Decimal        `json:"amount" validate:"required"`
+	PaymentMethod entities.PaymentMethod `json:"payment_method" validate:"required"`
+	Reference     string                 `json:"reference,omitempty"`
+	PaidAt        *time.Time             `json:"paid_at,omitempty"` // Defaults to now
+}
+
+// SetInvoicePaymentScheduleRequest represents the installments an invoice is to be paid in.
+// An empty list removes the schedule.
+type SetInvoicePaymentScheduleRequest struct {
+	Installments []entities.InvoiceInstallment `json:"installments"`
+}
+
+// InvoicePaymentsResponse represents the payments recorded against an invoice
+type InvoicePaymentsResponse struct {
+	InvoiceID         uuid.UUID                  `json:"invoice_id"`
+	TotalAmount       decimal.Decimal            `json:"total_amount"`
+	PaidAmount        decimal.Decimal            `json:"paid_amount"`
+	OutstandingAmount decimal.Decimal            `json:"outstanding_amount"`
+	Status            entities.InvoiceStatus     `json:"status"`
+	Payments          []*entities.InvoicePayment `json:"payments"`
+}
+
 // InvoiceListResponse represents invoice list response
 type InvoiceListResponse struct {
 	Invoices   []*InvoiceResponse   `json:"invoices"`
@@ -160,7 +217,7 @@ func (uc *InvoiceUseCase) CreateInvoice(ctx context.Context, userID uuid.UUID, r
 	invoiceNumber := utils.GenerateInvoiceNumber()
 
 	// Create invoice entity
-	invoice, err := entities.NewInvoice(invoiceNumber, sale, userID)
+	invoice, err := entities.NewInvoice(sale.TenantID, invoiceNumber, sale, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +234,13 @@ func (uc *InvoiceUseCase) CreateInvoice(ctx context.Context, userID uuid.UUID, r
 		}
 	}
 
+	// Set customer tax ID if provided
+	if req.CustomerTaxID != "" {
+		if err := invoice.SetCustomerTaxID(req.CustomerTaxID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set due date if provided
 	if req.DueDate != nil {
 		if err := invoice.SetDueDate(*req.DueDate); err != nil {
@@ -245,11 +309,24 @@ func (uc *InvoiceUseCase) CreateInvoice(ctx context.Context, userID uuid.UUID, r
 		"user_id":        userID,
 	}).Info("Invoice created successfully")
 
+	uc.featureUsage.Track(ctx, invoice.TenantID, entities.FeatureInvoicing)
+
 	return uc.toInvoiceResponse(invoice), nil
 }
 
 // GetInvoice retrieves an invoice by ID
 func (uc *InvoiceUseCase) GetInvoice(ctx context.Context, invoiceID uuid.UUID) (*InvoiceResponse, error) {
+	invoice, err := uc.invoiceRepo.GetByID(uc.database.ReadOnly(ctx), invoiceID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	return uc.toInvoiceResponse(invoice), nil
+}
+
+// GetInvoiceForPayment retrieves an invoice by ID from the primary, so a payment taken against
+// its outstanding amount is not decided on a lagging replica
+func (uc *InvoiceUseCase) GetInvoiceForPayment(ctx context.Context, invoiceID uuid.UUID) (*InvoiceResponse, error) {
 	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("invoice")
@@ -258,9 +335,25 @@ func (uc *InvoiceUseCase) GetInvoice(ctx context.Context, invoiceID uuid.UUID) (
 	return uc.toInvoiceResponse(invoice), nil
 }
 
+// GetInvoicesBySaleIDs retrieves the invoices of the given sales in one query, keyed by sale ID.
+// Sales without an invoice are left out.
+func (uc *InvoiceUseCase) GetInvoicesBySaleIDs(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID]*InvoiceResponse, error) {
+	invoices, err := uc.invoiceRepo.GetBySaleIDs(uc.database.ReadOnly(ctx), saleIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get invoices")
+		return nil, errors.NewInternalError("failed to get invoices", err)
+	}
+
+	responses := make(map[uuid.UUID]*InvoiceResponse, len(invoices))
+	for saleID, invoice := range invoices {
+		responses[saleID] = uc.toInvoiceResponse(invoice)
+	}
+	return responses, nil
+}
+
 // GetInvoiceByNumber retrieves an invoice by invoice number
 func (uc *InvoiceUseCase) GetInvoiceByNumber(ctx context.Context, invoiceNumber string) (*InvoiceResponse, error) {
-	invoice, err := uc.invoiceRepo.GetByInvoiceNumber(ctx, invoiceNumber)
+	invoice, err := uc.invoiceRepo.GetByInvoiceNumber(uc.database.ReadOnly(ctx), invoiceNumber)
 	if err != nil {
 		return nil, errors.NewNotFoundError("invoice")
 	}
@@ -276,17 +369,15 @@ func (uc *InvoiceUseCase) GenerateInvoicePDF(ctx context.Context, req GenerateIn
 		return nil, errors.NewNotFoundError("invoice")
 	}
 
-	// Use provided template or get default
-	template := req.Template
-	if template == nil {
-		paperSize := req.PaperSize
-		if paperSize == "" {
-			paperSize = entities.PaperSizeA4
-		}
-		template = uc.pdfService.GetDefaultTemplate(paperSize)
+	// Use provided template, else the stored one, else the default
+	template, err := uc.invoiceTemplate(ctx, invoice, req.Template, req.TemplateID, req.PaperSize)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate PDF
+	// Generate PDF, including the customer's signature if captured
+	attachInvoiceSignature(ctx, uc.signatureRepo, invoice)
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
 	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, template)
 	if err != nil {
 		uc.logger.WithFields(map[string]interface{}{
@@ -312,49 +403,44 @@ func (uc *InvoiceUseCase) GenerateInvoicePDF(ctx context.Context, req GenerateIn
 	return pdfData, nil
 }
 
-// SendInvoiceEmail sends an invoice via email
-func (uc *InvoiceUseCase) SendInvoiceEmail(ctx context.Context, userID uuid.UUID, req SendInvoiceEmailRequest) error {
+// SendInvoiceEmail queues an invoice email to be sent by the outbox worker, so a slow mail
+// server never holds up the request. The returned email reports its delivery status.
+func (uc *InvoiceUseCase) SendInvoiceEmail(ctx context.Context, userID uuid.UUID, req SendInvoiceEmailRequest) (*entities.OutboxEmail, error) {
 	// Get invoice
 	invoice, err := uc.invoiceRepo.GetByID(ctx, req.InvoiceID)
 	if err != nil {
-		return errors.NewNotFoundError("invoice")
+		return nil, errors.NewNotFoundError("invoice")
 	}
 
-	// Use provided template or get default
-	template := req.Template
-	if template == nil {
-		paperSize := req.PaperSize
-		if paperSize == "" {
-			paperSize = entities.PaperSizeA4
+	if req.Template != nil {
+		if err := uc.pdfService.ValidateTemplate(req.Template); err != nil {
+			return nil, err
+		}
+	} else if uc.templates != nil {
+		// The stored template travels with the email, so later edits do not change it
+		stored, err := uc.templates.StoredTemplate(ctx, invoice.TenantID, req.TemplateID)
+		if err != nil {
+			return nil, err
 		}
-		template = uc.pdfService.GetDefaultTemplate(paperSize)
+		if stored != nil && req.PaperSize != "" {
+			stored.PaperSize = req.PaperSize
+		}
+		req.Template = stored
 	}
 
-	// Generate PDF
-	pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, template)
+	// Queue the email; the outbox worker renders the PDF and sends it with retries
+	email, err := entities.NewOutboxEmail(invoice, req.EmailTo, req.PaperSize, req.Template, userID)
 	if err != nil {
-		uc.logger.WithFields(map[string]interface{}{
-			"invoice_id": req.InvoiceID,
-			"error":      err.Error(),
-		}).Error("Failed to generate invoice PDF for email")
-		return errors.NewInternalError("failed to generate PDF", err)
+		return nil, err
 	}
 
-	// Send email
-	if err := uc.emailService.SendInvoiceEmail(ctx, invoice, req.EmailTo, pdfData); err != nil {
+	if err := uc.outboxRepo.Create(ctx, email); err != nil {
 		uc.logger.WithFields(map[string]interface{}{
 			"invoice_id": req.InvoiceID,
 			"email_to":   req.EmailTo,
 			"error":      err.Error(),
-		}).Error("Failed to send invoice email")
-		return errors.NewInternalError("failed to send email", err)
-	}
-
-	// Mark invoice as sent
-	if invoice.IsGenerated() {
-		if err := invoice.MarkAsSent(); err == nil {
-			uc.invoiceRepo.Update(ctx, invoice)
-		}
+		}).Error("Failed to queue invoice email")
+		return nil, errors.NewInternalError("failed to queue email", err)
 	}
 
 	// Audit log
@@ -365,8 +451,9 @@ func (uc *InvoiceUseCase) SendInvoiceEmail(ctx context.Context, userID uuid.UUID
 		Resource:   "invoice",
 		ResourceID: invoice.ID.String(),
 		NewValue: map[string]interface{}{
-			"email_to": req.EmailTo,
-			"status":   "sent",
+			"email_to": email.Recipient,
+			"email_id": email.ID,
+			"status":   "queued",
 		},
 		Timestamp: time.Now(),
 		Success:   true,
@@ -376,11 +463,12 @@ func (uc *InvoiceUseCase) SendInvoiceEmail(ctx context.Context, userID uuid.UUID
 	uc.logger.WithFields(map[string]interface{}{
 		"invoice_id":     req.InvoiceID,
 		"invoice_number": invoice.InvoiceNumber,
-		"email_to":       req.EmailTo,
+		"email_to":       email.Recipient,
+		"email_id":       email.ID,
 		"user_id":        userID,
-	}).Info("Invoice email sent successfully")
+	}).Info("Invoice email queued")
 
-	return nil
+	return email, nil
 }
 
 // PrintInvoice prints an invoice
@@ -391,17 +479,23 @@ func (uc *InvoiceUseCase) PrintInvoice(ctx context.Context, userID uuid.UUID, re
 		return errors.NewNotFoundError("invoice")
 	}
 
-	// Use provided template or get default
-	template := req.Template
-	if template == nil {
-		paperSize := req.PaperSize
-		if paperSize == "" {
-			paperSize = entities.PaperSizeA4
+	// Use provided template, else the stored one, else the default
+	template, err := uc.invoiceTemplate(ctx, invoice, req.Template, req.TemplateID, req.PaperSize)
+	if err != nil {
+		return err
+	}
+
+	// Pick the terminal's registered printer unless one is named
+	if req.PrinterName == "" {
+		printerName, err := uc.terminalPrinterName(ctx, invoice.TenantID, req.Terminal, template.PaperSize)
+		if err != nil {
+			return err
 		}
-		template = uc.pdfService.GetDefaultTemplate(paperSize)
+		req.PrinterName = printerName
 	}
 
 	// Print invoice
+	attachInvoiceCurrency(ctx, uc.tenantRepo, invoice)
 	if template.PaperSize == entities.PaperSizeReceipt {
 		err = uc.printService.PrintReceipt(ctx, invoice, template, req.PrinterName)
 	} else {
@@ -443,6 +537,21 @@ func (uc *InvoiceUseCase) PrintInvoice(ctx context.Context, userID uuid.UUID, re
 	return nil
 }
 
+// terminalPrinterName returns the name of the registered printer a terminal prints the paper
+// size to, or an empty name, meaning the print service's default printer, if none is registered
+func (uc *InvoiceUseCase) terminalPrinterName(ctx context.Context, tenantID uuid.UUID, terminal string, paperSize entities.PaperSize) (string, error) {
+	printer, err := uc.printerRepo.FindForTerminal(ctx, tenantID, terminal, paperSize == entities.PaperSizeReceipt)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return "", nil
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to find terminal printer")
+		return "", errors.NewInternalError("failed to find printer", err)
+	}
+
+	return printer.Name, nil
+}
+
 // MarkInvoiceAsPaid marks an invoice as paid
 func (uc *InvoiceUseCase) MarkInvoiceAsPaid(ctx context.Context, userID, invoiceID uuid.UUID) error {
 	// Get invoice
@@ -487,9 +596,167 @@ func (uc *InvoiceUseCase) MarkInvoiceAsPaid(ctx context.Context, userID, invoice
 		"user_id":        userID,
 	}).Info("Invoice marked as paid successfully")
 
+	uc.webhooks.Publish(ctx, invoice.TenantID, entities.WebhookEventInvoicePaid, uc.toInvoiceResponse(invoice))
+
 	return nil
 }
 
+// RecordInvoicePayment records a full or partial payment against an invoice. The invoice is
+// partially paid until its payments cover its total.
+func (uc *InvoiceUseCase) RecordInvoicePayment(ctx context.Context, userID, invoiceID uuid.UUID, req RecordInvoicePaymentRequest) (*InvoiceResponse, error) {
+	paidAt := time.Now()
+	if req.PaidAt != nil {
+		if req.PaidAt.After(paidAt) {
+			return nil, errors.NewValidationError("invalid payment date", "paid_at cannot be in the future")
+		}
+		paidAt = *req.PaidAt
+	}
+
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the invoice so concurrent payments cannot overpay it
+	invoice, err := tx.GetInvoiceRepository().GetByIDForUpdate(ctx, invoiceID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	payment, err := invoice.RecordPayment(req.Amount, req.PaymentMethod, req.Reference, paidAt, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetInvoiceRepository().CreatePayment(ctx, payment); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to record invoice payment")
+		return nil, errors.NewInternalError("failed to record invoice payment", err)
+	}
+
+	if err := tx.GetInvoiceRepository().Update(ctx, invoice); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to update invoice")
+		return nil, errors.NewInternalError("failed to update invoice", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "record_payment",
+		Resource:   "invoice",
+		ResourceID: invoiceID.String(),
+		NewValue: map[string]interface{}{
+			"payment_id":     payment.ID,
+			"amount":         payment.Amount,
+			"payment_method": payment.PaymentMethod,
+			"paid_amount":    invoice.PaidAmount,
+			"status":         invoice.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"invoice_id":     invoiceID,
+		"invoice_number": invoice.InvoiceNumber,
+		"amount":         payment.Amount,
+		"status":         invoice.Status,
+		"user_id":        userID,
+	}).Info("Invoice payment recorded")
+
+	response := uc.toInvoiceResponse(invoice)
+	if invoice.IsPaid() {
+		uc.webhooks.Publish(ctx, invoice.TenantID, entities.WebhookEventInvoicePaid, response)
+	}
+
+	return response, nil
+}
+
+// ListInvoicePayments retrieves the payments recorded against an invoice
+func (uc *InvoiceUseCase) ListInvoicePayments(ctx context.Context, invoiceID uuid.UUID) (*InvoicePaymentsResponse, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	payments, err := uc.invoiceRepo.GetPayments(ctx, invoiceID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to get invoice payments")
+		return nil, errors.NewInternalError("failed to get invoice payments", err)
+	}
+
+	return &InvoicePaymentsResponse{
+		InvoiceID:         invoice.ID,
+		TotalAmount:       invoice.TotalAmount,
+		PaidAmount:        invoice.PaidAmount,
+		OutstandingAmount: invoice.OutstandingAmount(),
+		Status:            invoice.Status,
+		Payments:          payments,
+	}, nil
+}
+
+// SetInvoicePaymentSchedule splits an invoice's total into installments. The invoice's due
+// date follows the first installment its payments do not cover yet.
+func (uc *InvoiceUseCase) SetInvoicePaymentSchedule(ctx context.Context, userID, invoiceID uuid.UUID, req SetInvoicePaymentScheduleRequest) (*InvoiceResponse, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	before := invoice.PaymentSchedule
+	if err := invoice.SetPaymentSchedule(req.Installments); err != nil {
+		return nil, err
+	}
+
+	if err := uc.invoiceRepo.Update(ctx, invoice); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to update invoice")
+		return nil, errors.NewInternalError("failed to update invoice", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "set_payment_schedule",
+		Resource:   "invoice",
+		ResourceID: invoiceID.String(),
+		OldValue: map[string]interface{}{
+			"payment_schedule": before,
+		},
+		NewValue: map[string]interface{}{
+			"payment_schedule": invoice.PaymentSchedule,
+			"due_date":         invoice.DueDate,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"invoice_id":   invoiceID,
+		"installments": len(invoice.PaymentSchedule),
+		"user_id":      userID,
+	}).Info("Invoice payment schedule set")
+
+	return uc.toInvoiceResponse(invoice), nil
+}
+
 // CancelInvoice cancels an invoice
 func (uc *InvoiceUseCase) CancelInvoice(ctx context.Context, userID, invoiceID uuid.UUID) error {
 	// Get invoice
@@ -538,7 +805,7 @@ func (uc *InvoiceUseCase) CancelInvoice(ctx context.Context, userID, invoiceID u
 
 // ListInvoices retrieves invoices with pagination and filtering
 func (uc *InvoiceUseCase) ListInvoices(ctx context.Context, filter repositories.InvoiceFilter, pagination utils.PaginationInfo) (*InvoiceListResponse, error) {
-	invoices, paginationResult, err := uc.invoiceRepo.List(ctx, filter, pagination)
+	invoices, paginationResult, err := uc.invoiceRepo.List(uc.database.ReadOnly(ctx), filter, pagination)
 	if err != nil {
 		uc.logger.WithField("error", err.Error()).Error("Failed to list invoices")
 		return nil, errors.NewInternalError("failed to list invoices", err)
@@ -557,7 +824,7 @@ func (uc *InvoiceUseCase) ListInvoices(ctx context.Context, filter repositories.
 
 // GetOverdueInvoices retrieves overdue invoices
 func (uc *InvoiceUseCase) GetOverdueInvoices(ctx context.Context, pagination utils.PaginationInfo) (*InvoiceListResponse, error) {
-	invoices, paginationResult, err := uc.invoiceRepo.GetOverdueInvoices(ctx, pagination)
+	invoices, paginationResult, err := uc.invoiceRepo.GetOverdueInvoices(uc.database.ReadOnly(ctx), pagination)
 	if err != nil {
 		uc.logger.WithField("error", err.Error()).Error("Failed to get overdue invoices")
 		return nil, errors.NewInternalError("failed to get overdue invoices", err)
@@ -591,26 +858,81 @@ func (uc *InvoiceUseCase) toInvoiceResponse(invoice *entities.Invoice) *InvoiceR
 	}
 
 	return &InvoiceResponse{
-		ID:              invoice.ID,
-		InvoiceNumber:   invoice.InvoiceNumber,
-		SaleID:          invoice.SaleID,
-		CustomerName:    invoice.CustomerName,
-		CustomerEmail:   invoice.CustomerEmail,
-		CustomerPhone:   invoice.CustomerPhone,
-		CustomerAddress: invoice.CustomerAddress,
-		Items:           items,
-		Subtotal:        invoice.Subtotal,
-		TaxAmount:       invoice.TaxAmount,
-		DiscountAmount:  invoice.DiscountAmount,
-		TotalAmount:     invoice.TotalAmount,
-		PaidAmount:      invoice.PaidAmount,
-		PaymentMethod:   invoice.PaymentMethod,
-		Status:          invoice.Status,
-		Notes:           invoice.Notes,
-		DueDate:         invoice.DueDate,
-		PaidAt:          invoice.PaidAt,
-		CreatedAt:       invoice.CreatedAt,
-		UpdatedAt:       invoice.UpdatedAt,
-		CreatedBy:       invoice.CreatedBy,
+		ID:                invoice.ID,
+		InvoiceNumber:     invoice.InvoiceNumber,
+		SaleID:            invoice.SaleID,
+		CustomerName:      invoice.CustomerName,
+		CustomerEmail:     invoice.CustomerEmail,
+		CustomerPhone:     invoice.CustomerPhone,
+		CustomerAddress:   invoice.CustomerAddress,
+		CustomerTaxID:     invoice.CustomerTaxID,
+		Items:             items,
+		Subtotal:          invoice.Subtotal,
+		TaxAmount:         invoice.TaxAmount,
+		DiscountAmount:    invoice.DiscountAmount,
+		SurchargeAmount:   invoice.SurchargeAmount,
+		SurchargeLabel:    invoice.SurchargeLabel,
+		TotalAmount:       invoice.TotalAmount,
+		PaidAmount:        invoice.PaidAmount,
+		OutstandingAmount: invoice.OutstandingAmount(),
+		PaymentMethod:     invoice.PaymentMethod,
+		Status:            invoice.Status,
+		Notes:             invoice.Notes,
+		Currency:          invoice.Currency,
+		DueDate:           invoice.DueDate,
+		PaymentSchedule:   invoice.PaymentSchedule,
+		PaidAt:            invoice.PaidAt,
+		CreatedAt:         invoice.CreatedAt,
+		UpdatedAt:         invoice.UpdatedAt,
+		CreatedBy:         invoice.CreatedBy,
+	}
+}
+
+// invoiceTemplate returns the template an invoice is rendered with: the provided template, else
+// the stored template named or the tenant's default, else the built-in template. A paper size
+// overrides the stored template's.
+func (uc *InvoiceUseCase) invoiceTemplate(ctx context.Context, invoice *entities.Invoice, provided *entities.InvoiceTemplate, templateID *uuid.UUID, paperSize entities.PaperSize) (*entities.InvoiceTemplate, error) {
+	if provided != nil {
+		return provided, nil
+	}
+
+	if uc.templates != nil {
+		stored, err := uc.templates.StoredTemplate(ctx, invoice.TenantID, templateID)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			if paperSize != "" {
+				stored.PaperSize = paperSize
+			}
+			return stored, nil
+		}
+	}
+
+	if paperSize == "" {
+		paperSize = entities.PaperSizeA4
+	}
+	return uc.pdfService.GetDefaultTemplate(paperSize), nil
+}
+
+// attachInvoiceCurrency loads the tenant's currency display so the invoice's amounts are
+// rendered the way the tenant configured them. Invoices made out in another currency than
+// the tenant's are rendered in that currency's conventional display.
+func attachInvoiceCurrency(ctx context.Context, tenantRepo repositories.TenantRepository, invoice *entities.Invoice) {
+	if tenantRepo == nil {
+		return
+	}
+	if tenant, err := tenantRepo.GetByID(ctx, invoice.TenantID); err == nil {
+		invoice.CurrencyFormat = invoiceCurrencyFormat(tenant, invoice)
+	}
+}
+
+// invoiceCurrencyFormat returns the display of an invoice's currency: the tenant's own
+// display for its base currency, the conventional display for other currencies
+func invoiceCurrencyFormat(tenant *entities.Tenant, invoice *entities.Invoice) *entities.CurrencyFormat {
+	format := tenant.GetCurrencyFormat()
+	if invoice.Currency != "" && invoice.Currency != tenant.GetCurrency() {
+		format = entities.DefaultCurrencyFormat(invoice.Currency)
 	}
+	return &format
 }
@@ -0,0 +1,316 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// LocationUseCase handles the locations a tenant keeps stock at and the stock held at each
+type LocationUseCase struct {
+	locationRepo repositories.LocationRepository
+	productRepo  repositories.ProductRepository
+	database     ports.DatabasePort
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewLocationUseCase creates a new location use case
+func NewLocationUseCase(
+	locationRepo repositories.LocationRepository,
+	productRepo repositories.ProductRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *LocationUseCase {
+	return &LocationUseCase{
+		locationRepo: locationRepo,
+		productRepo:  productRepo,
+		database:     database,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// CreateLocationRequest represents create location request
+type CreateLocationRequest struct {
+	Code    string `json:"code" validate:"required"`
+	Name    string `json:"name" validate:"required"`
+	Address string `json:"address,omitempty"`
+}
+
+// UpdateLocationRequest represents update location request
+type UpdateLocationRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Address  string `json:"address,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+}
+
+// SetLocationStockRequest represents the counted quantity of a product at a location
+type SetLocationStockRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"min=0"`
+}
+
+// LocationStockListResponse represents the stock held at a location
+type LocationStockListResponse struct {
+	Stock      []*entities.LocationStock `json:"stock"`
+	Pagination utils.PaginationInfo      `json:"pagination"`
+}
+
+// CreateLocation creates a new location
+func (uc *LocationUseCase) CreateLocation(ctx context.Context, tenantID, userID uuid.UUID, req CreateLocationRequest) (*entities.Location, error) {
+	location, err := entities.NewLocation(tenantID, req.Code, req.Name, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.locationRepo.Create(ctx, location); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"code":  location.Code,
+			"error": err.Error(),
+		}).Error("Failed to create location")
+		return nil, errors.NewInternalError("failed to create location", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "location",
+		ResourceID: location.ID.String(),
+		NewValue: map[string]interface{}{
+			"code": location.Code,
+			"name": location.Name,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return location, nil
+}
+
+// GetLocation retrieves a location by ID
+func (uc *LocationUseCase) GetLocation(ctx context.Context, tenantID, locationID uuid.UUID) (*entities.Location, error) {
+	location, err := uc.locationRepo.GetByID(ctx, locationID)
+	if err != nil || location.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("location")
+	}
+
+	return location, nil
+}
+
+// ListLocations retrieves the tenant's locations
+func (uc *LocationUseCase) ListLocations(ctx context.Context, includeInactive bool) ([]*entities.Location, error) {
+	locations, err := uc.locationRepo.List(ctx, includeInactive)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list locations")
+		return nil, errors.NewInternalError("failed to list locations", err)
+	}
+
+	if locations == nil {
+		locations = []*entities.Location{}
+	}
+
+	return locations, nil
+}
+
+// UpdateLocation updates the name and address of a location, and activates or deactivates it
+func (uc *LocationUseCase) UpdateLocation(ctx context.Context, tenantID, userID, locationID uuid.UUID, req UpdateLocationRequest) (*entities.Location, error) {
+	location, err := uc.GetLocation(ctx, tenantID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName, oldActive := location.Name, location.IsActive
+	if err := location.Update(req.Name, req.Address); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		location.SetActive(*req.IsActive)
+	}
+
+	if err := uc.locationRepo.Update(ctx, location); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"location_id": locationID,
+			"error":       err.Error(),
+		}).Error("Failed to update location")
+		return nil, errors.NewInternalError("failed to update location", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "location",
+		ResourceID: location.ID.String(),
+		OldValue: map[string]interface{}{
+			"name":      oldName,
+			"is_active": oldActive,
+		},
+		NewValue: map[string]interface{}{
+			"name":      location.Name,
+			"is_active": location.IsActive,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return location, nil
+}
+
+// ListLocationStock retrieves the products held at a location
+func (uc *LocationUseCase) ListLocationStock(ctx context.Context, tenantID, locationID uuid.UUID, pagination utils.PaginationInfo) (*LocationStockListResponse, error) {
+	if _, err := uc.GetLocation(ctx, tenantID, locationID); err != nil {
+		return nil, err
+	}
+
+	stock, paginationResult, err := uc.locationRepo.ListStock(ctx, locationID, pagination)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"location_id": locationID,
+			"error":       err.Error(),
+		}).Error("Failed to list location stock")
+		return nil, errors.NewInternalError("failed to list location stock", err)
+	}
+
+	if stock == nil {
+		stock = []*entities.LocationStock{}
+	}
+
+	return &LocationStockListResponse{
+		Stock:      stock,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// GetProductLocationStock retrieves how much of a product each location holds
+func (uc *LocationUseCase) GetProductLocationStock(ctx context.Context, tenantID, productID uuid.UUID) ([]*entities.LocationStock, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil || product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	stock, err := uc.locationRepo.GetProductStock(ctx, productID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to get product location stock")
+		return nil, errors.NewInternalError("failed to get product location stock", err)
+	}
+
+	if stock == nil {
+		stock = []*entities.LocationStock{}
+	}
+
+	return stock, nil
+}
+
+// SetLocationStock records how much of a product a location holds, e.g. after counting it
+// when the location is set up. The quantities held across locations cannot exceed the
+// product's stock.
+func (uc *LocationUseCase) SetLocationStock(ctx context.Context, tenantID, userID, locationID uuid.UUID, req SetLocationStockRequest) (*entities.LocationStock, error) {
+	location, err := uc.GetLocation(ctx, tenantID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	product, err := tx.GetProductRepository().GetByID(ctx, req.ProductID)
+	if err != nil || product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	// Locking the product's stock serializes changes to where it is held
+	stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, req.ProductID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("stock record")
+	}
+
+	held, err := tx.GetLocationRepository().GetProductStock(ctx, req.ProductID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to get product location stock", err)
+	}
+	allocated := req.Quantity
+	for _, other := range held {
+		if other.LocationID != locationID {
+			allocated += other.Quantity
+		}
+	}
+	if allocated > stock.TotalQty {
+		return nil, errors.NewValidationError("quantity exceeds stock", "the quantities held across locations cannot exceed the product's stock")
+	}
+
+	locationStock, err := tx.GetLocationRepository().GetStockForUpdate(ctx, locationID, req.ProductID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			return nil, errors.NewInternalError("failed to get location stock", err)
+		}
+		locationStock = entities.NewLocationStock(location, req.ProductID)
+	}
+
+	oldQuantity := locationStock.Quantity
+	if err := locationStock.SetQuantity(req.Quantity); err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetLocationRepository().SaveStock(ctx, locationStock); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"location_id": locationID,
+			"product_id":  req.ProductID,
+			"error":       err.Error(),
+		}).Error("Failed to save location stock")
+		return nil, errors.NewInternalError("failed to save location stock", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "set_stock",
+		Resource:   "location",
+		ResourceID: location.ID.String(),
+		OldValue: map[string]interface{}{
+			"product_id": req.ProductID,
+			"quantity":   oldQuantity,
+		},
+		NewValue: map[string]interface{}{
+			"product_id": req.ProductID,
+			"quantity":   locationStock.Quantity,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return locationStock, nil
+}
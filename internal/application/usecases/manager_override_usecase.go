@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// ManagerOverrideUseCase handles manager approvals for restricted actions performed by cashiers
+type ManagerOverrideUseCase struct {
+	overrideRepo repositories.ManagerOverrideRepository
+	userRepo     repositories.UserRepository
+	auth         *AuthUseCase
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewManagerOverrideUseCase creates a new manager override use case
+func NewManagerOverrideUseCase(
+	overrideRepo repositories.ManagerOverrideRepository,
+	userRepo repositories.UserRepository,
+	auth *AuthUseCase,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *ManagerOverrideUseCase {
+	return &ManagerOverrideUseCase{
+		overrideRepo: overrideRepo,
+		userRepo:     userRepo,
+		auth:         auth,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// IssueOverrideRequest represents a manager's credentials entered on a cashier's terminal
+type IssueOverrideRequest struct {
+	Username   string                  `json:"username" validate:"required"`
+	Password   string                  `json:"password" validate:"required"`
+	Action     entities.OverrideAction `json:"action" validate:"required"`
+	ResourceID *uuid.UUID              `json:"resource_id,omitempty"`
+	Reason     string                  `json:"reason" validate:"required"`
+	IPAddress  string                  `json:"ip_address,omitempty"`
+	UserAgent  string                  `json:"user_agent,omitempty"`
+}
+
+// IssueOverrideResponse represents an issued override token
+type IssueOverrideResponse struct {
+	Token       string                  `json:"token"`
+	Action      entities.OverrideAction `json:"action"`
+	ResourceID  *uuid.UUID              `json:"resource_id,omitempty"`
+	ApprovedBy  uuid.UUID               `json:"approved_by"`
+	ManagerName string                  `json:"manager_name"`
+	ExpiresAt   time.Time               `json:"expires_at"`
+}
+
+// IssueOverride verifies a manager's credentials and issues a one-time token that lets the
+// requesting cashier perform a single restricted action
+func (uc *ManagerOverrideUseCase) IssueOverride(ctx context.Context, tenantID, requestedBy uuid.UUID, req IssueOverrideRequest) (*IssueOverrideResponse, error) {
+	if err := entities.ValidateOverrideAction(req.Action); err != nil {
+		return nil, err
+	}
+
+	manager, err := uc.verifyManager(ctx, tenantID, req)
+	if err != nil {
+		// Audit log
+		auditEvent := ports.AuditEvent{
+			ID:       uuid.New(),
+			UserID:   requestedBy,
+			Action:   "override_denied",
+			Resource: "manager_override",
+			NewValue: map[string]interface{}{
+				"action":           req.Action,
+				"resource_id":      req.ResourceID,
+				"manager_username": req.Username,
+			},
+			IPAddress: req.IPAddress,
+			UserAgent: req.UserAgent,
+			Timestamp: time.Now(),
+			Success:   false,
+		}
+		uc.audit.Log(ctx, auditEvent)
+
+		uc.logger.WithFields(map[string]interface{}{
+			"requested_by":     requestedBy,
+			"manager_username": req.Username,
+			"action":           req.Action,
+		}).Warn("Manager override denied")
+
+		return nil, err
+	}
+
+	override, token, err := entities.NewManagerOverride(tenantID, req.Action, req.ResourceID, manager.ID, requestedBy, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.overrideRepo.Create(ctx, override); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"requested_by": requestedBy,
+			"approved_by":  manager.ID,
+			"error":        err.Error(),
+		}).Error("Failed to store manager override")
+		return nil, errors.NewInternalError("failed to issue manager override", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     manager.ID,
+		Action:     "approve_override",
+		Resource:   "manager_override",
+		ResourceID: override.ID.String(),
+		NewValue: map[string]interface{}{
+			"action":       override.Action,
+			"resource_id":  override.ResourceID,
+			"approved_by":  override.ApprovedBy,
+			"requested_by": override.RequestedBy,
+			"reason":       override.Reason,
+			"expires_at":   override.ExpiresAt,
+		},
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"override_id":  override.ID,
+		"action":       override.Action,
+		"approved_by":  override.ApprovedBy,
+		"requested_by": override.RequestedBy,
+	}).Info("Manager override issued")
+
+	return &IssueOverrideResponse{
+		Token:       token,
+		Action:      override.Action,
+		ResourceID:  override.ResourceID,
+		ApprovedBy:  manager.ID,
+		ManagerName: manager.GetFullName(),
+		ExpiresAt:   override.ExpiresAt,
+	}, nil
+}
+
+// ConsumeOverride uses an override token for a restricted action on a resource. The token is
+// spent even if the action itself later fails, so a fresh approval is needed to retry.
+func (uc *ManagerOverrideUseCase) ConsumeOverride(ctx context.Context, tenantID, userID uuid.UUID, token string, action entities.OverrideAction, resourceID uuid.UUID) (*entities.ManagerOverride, error) {
+	override, err := uc.overrideRepo.GetByTokenHash(ctx, entities.HashManagerOverrideToken(token))
+	if err != nil || override.TenantID != tenantID {
+		return nil, errors.NewForbiddenError("invalid manager override")
+	}
+
+	now := time.Now()
+	if err := override.Consume(action, resourceID, userID, now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.overrideRepo.MarkConsumed(ctx, override.ID, now); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"override_id": override.ID,
+			"error":       err.Error(),
+		}).Error("Failed to consume manager override")
+		return nil, errors.NewInternalError("failed to consume manager override", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "consume_override",
+		Resource:   "manager_override",
+		ResourceID: override.ID.String(),
+		NewValue: map[string]interface{}{
+			"action":       override.Action,
+			"resource_id":  resourceID,
+			"approved_by":  override.ApprovedBy,
+			"requested_by": override.RequestedBy,
+			"reason":       override.Reason,
+			"consumed_at":  now,
+		},
+		Timestamp: now,
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return override, nil
+}
+
+// verifyManager checks that the credentials belong to an active manager or admin of the tenant.
+// The password is checked the way a login checks it, so wrong passwords count towards the
+// manager's account lockout.
+func (uc *ManagerOverrideUseCase) verifyManager(ctx context.Context, tenantID uuid.UUID, req IssueOverrideRequest) (*entities.User, error) {
+	user, err := uc.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil || user.TenantID != tenantID {
+		return nil, errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	if err := uc.auth.VerifyCredentials(ctx, user, LoginRequest{
+		Username:  req.Username,
+		Password:  req.Password,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+	}); err != nil {
+		return nil, err
+	}
+
+	if user.Role != entities.RoleAdmin && user.Role != entities.RoleManager {
+		return nil, errors.NewForbiddenError("only managers can approve overrides")
+	}
+	return user, nil
+}
@@ -0,0 +1,551 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// maxMarginReportDays caps the period a margin violation report can cover
+const maxMarginReportDays = 92
+
+// marginReportEmailLimit caps how many individual violations are listed in the weekly email
+const marginReportEmailLimit = 20
+
+// MarginFloorUseCase handles per-category margin floors. Prices below a floor are never
+// blocked; the violation is returned as a warning for the cashier, recorded for the weekly
+// report and optionally emailed to the owner.
+type MarginFloorUseCase struct {
+	floorRepo    repositories.MarginFloorRepository
+	productRepo  repositories.ProductRepository
+	tenantRepo   repositories.TenantRepository
+	notification ports.NotificationPort
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewMarginFloorUseCase creates a new margin floor use case
+func NewMarginFloorUseCase(
+	floorRepo repositories.MarginFloorRepository,
+	productRepo repositories.ProductRepository,
+	tenantRepo repositories.TenantRepository,
+	notification ports.NotificationPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *MarginFloorUseCase {
+	return &MarginFloorUseCase{
+		floorRepo:    floorRepo,
+		productRepo:  productRepo,
+		tenantRepo:   tenantRepo,
+		notification: notification,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// SetMarginFloorRequest represents set margin floor request
+type SetMarginFloorRequest struct {
+	Category         string          `json:"category" validate:"required"`
+	MinMarginPercent decimal.Decimal `json:"min_margin_percent" validate:"required"`
+}
+
+// UpdateMarginAlertSettingsRequest represents update margin alert settings request
+type UpdateMarginAlertSettingsRequest struct {
+	Recipients       []string `json:"recipients"`
+	AlertOnViolation bool     `json:"alert_on_violation"`
+	WeeklyReport     bool     `json:"weekly_report"`
+}
+
+// MarginViolationReport represents the margin floor violations of a period
+type MarginViolationReport struct {
+	From            time.Time                        `json:"from"`
+	To              time.Time                        `json:"to"`
+	TotalViolations int                              `json:"total_violations"`
+	Categories      []MarginViolationCategorySummary `json:"categories"`
+	Violations      []*entities.MarginViolation      `json:"violations"`
+}
+
+// MarginViolationCategorySummary represents the violations of one category in a report
+type MarginViolationCategorySummary struct {
+	Category            string          `json:"category"`
+	Violations          int             `json:"violations"`
+	Products            int             `json:"products"`
+	LowestMarginPercent decimal.Decimal `json:"lowest_margin_percent"`
+	FloorPercent        decimal.Decimal `json:"floor_percent"`
+}
+
+// ListFloors returns the margin floors of a tenant
+func (uc *MarginFloorUseCase) ListFloors(ctx context.Context, tenantID uuid.UUID) ([]*entities.MarginFloor, error) {
+	floors, err := uc.floorRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list margin floors")
+		return nil, errors.NewInternalError("failed to list margin floors", err)
+	}
+	if floors == nil {
+		floors = []*entities.MarginFloor{}
+	}
+
+	return floors, nil
+}
+
+// SetFloor creates or updates the margin floor of a category
+func (uc *MarginFloorUseCase) SetFloor(ctx context.Context, tenantID, userID uuid.UUID, req SetMarginFloorRequest) (*entities.MarginFloor, error) {
+	var oldValue map[string]interface{}
+	floor, err := uc.floorRepo.GetByTenantAndCategory(ctx, tenantID, strings.TrimSpace(req.Category))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			uc.logger.WithField("error", err.Error()).Error("Failed to get margin floor")
+			return nil, errors.NewInternalError("failed to get margin floor", err)
+		}
+		floor, err = entities.NewMarginFloor(tenantID, req.Category, req.MinMarginPercent, userID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		oldValue = map[string]interface{}{"min_margin_percent": floor.MinMarginPercent}
+		if err := floor.Update(req.MinMarginPercent, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.floorRepo.Save(ctx, floor); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"category":  floor.Category,
+			"error":     err.Error(),
+		}).Error("Failed to save margin floor")
+		return nil, errors.NewInternalError("failed to save margin floor", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "set_margin_floor",
+		Resource:   "margin_floor",
+		ResourceID: floor.Category,
+		OldValue:   oldValue,
+		NewValue:   map[string]interface{}{"min_margin_percent": floor.MinMarginPercent},
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":          tenantID,
+		"category":           floor.Category,
+		"min_margin_percent": floor.MinMarginPercent,
+		"user_id":            userID,
+	}).Info("Margin floor set")
+
+	return floor, nil
+}
+
+// DeleteFloor removes the margin floor of a category
+func (uc *MarginFloorUseCase) DeleteFloor(ctx context.Context, tenantID, userID uuid.UUID, category string) error {
+	floor, err := uc.floorRepo.GetByTenantAndCategory(ctx, tenantID, strings.TrimSpace(category))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return appErr
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get margin floor")
+		return errors.NewInternalError("failed to get margin floor", err)
+	}
+
+	if err := uc.floorRepo.Delete(ctx, floor.ID); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"category":  floor.Category,
+			"error":     err.Error(),
+		}).Error("Failed to delete margin floor")
+		return errors.NewInternalError("failed to delete margin floor", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete_margin_floor",
+		Resource:   "margin_floor",
+		ResourceID: floor.Category,
+		OldValue:   map[string]interface{}{"min_margin_percent": floor.MinMarginPercent},
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return nil
+}
+
+// GetAlertSettings returns the tenant's margin alert settings, or settings that only record
+// violations if none were saved
+func (uc *MarginFloorUseCase) GetAlertSettings(ctx context.Context, tenantID uuid.UUID) (*entities.MarginAlertSetting, error) {
+	setting, err := uc.floorRepo.GetAlertSetting(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return entities.NewMarginAlertSetting(tenantID, uuid.Nil)
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get margin alert settings")
+		return nil, errors.NewInternalError("failed to get margin alert settings", err)
+	}
+
+	return setting, nil
+}
+
+// UpdateAlertSettings changes who is alerted about margin violations and whether the weekly
+// report is sent
+func (uc *MarginFloorUseCase) UpdateAlertSettings(ctx context.Context, tenantID, userID uuid.UUID, req UpdateMarginAlertSettingsRequest) (*entities.MarginAlertSetting, error) {
+	setting, err := uc.GetAlertSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := map[string]interface{}{
+		"recipients":         setting.Recipients,
+		"alert_on_violation": setting.AlertOnViolation,
+		"weekly_report":      setting.WeeklyReport,
+	}
+
+	if err := setting.Configure(req.Recipients, req.AlertOnViolation, req.WeeklyReport, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.floorRepo.SaveAlertSetting(ctx, setting); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to save margin alert settings")
+		return nil, errors.NewInternalError("failed to save margin alert settings", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "margin_alert_setting",
+		ResourceID: tenantID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"recipients":         setting.Recipients,
+			"alert_on_violation": setting.AlertOnViolation,
+			"weekly_report":      setting.WeeklyReport,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return setting, nil
+}
+
+// CheckSaleItems checks the net price of sale items against their category floors, after
+// promotions, overrides and the sale's discount. Violations are recorded and returned as
+// warnings; checking never fails the sale.
+func (uc *MarginFloorUseCase) CheckSaleItems(ctx context.Context, userID uuid.UUID, sale *entities.Sale, items []entities.SaleItem, source entities.MarginViolationSource) []*entities.MarginViolation {
+	floors := uc.getFloors(ctx, sale.TenantID)
+	if len(floors) == 0 || len(items) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	products, err := uc.productRepo.GetByIDs(ctx, productIDs)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": sale.ID,
+			"error":   err.Error(),
+		}).Error("Failed to get products for margin check")
+		return nil
+	}
+
+	var violations []*entities.MarginViolation
+	for _, item := range items {
+		product, ok := products[item.ProductID]
+		if !ok {
+			continue
+		}
+		floor, ok := floors[strings.ToLower(product.Category)]
+		if !ok {
+			continue
+		}
+
+		unitPrice := sale.NetUnitPrice(item)
+		if floor.IsBreachedBy(unitPrice, item.UnitCost) {
+			saleID := sale.ID
+			violations = append(violations, entities.NewMarginViolation(floor, source, product, unitPrice, item.UnitCost, &saleID, userID))
+		}
+	}
+
+	uc.recordViolations(ctx, sale.TenantID, violations)
+	return violations
+}
+
+// CheckProductPrices checks a product's regular and promotional price against its category
+// floor after a price change. Violations are recorded and returned as warnings.
+func (uc *MarginFloorUseCase) CheckProductPrices(ctx context.Context, userID uuid.UUID, product *entities.Product) []*entities.MarginViolation {
+	floors := uc.getFloors(ctx, product.TenantID)
+	floor, ok := floors[strings.ToLower(product.Category)]
+	if !ok {
+		return nil
+	}
+
+	prices := []decimal.Decimal{product.Price}
+	if product.PromoPrice != nil {
+		prices = append(prices, *product.PromoPrice)
+	}
+
+	var violations []*entities.MarginViolation
+	for _, price := range prices {
+		if floor.IsBreachedBy(price, product.Cost) {
+			violations = append(violations, entities.NewMarginViolation(floor, entities.MarginViolationSourcePriceChange, product, price, product.Cost, nil, userID))
+		}
+	}
+
+	uc.recordViolations(ctx, product.TenantID, violations)
+	return violations
+}
+
+// GetViolationReport summarises the margin floor violations of a period by category
+func (uc *MarginFloorUseCase) GetViolationReport(ctx context.Context, tenantID uuid.UUID, from, to time.Time) (*MarginViolationReport, error) {
+	if !to.After(from) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if to.Sub(from) > maxMarginReportDays*24*time.Hour {
+		return nil, errors.NewValidationError("date range too long", "a margin violation report cannot cover more than 92 days")
+	}
+
+	violations, err := uc.floorRepo.GetViolations(ctx, tenantID, from, to)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get margin violations")
+		return nil, errors.NewInternalError("failed to get margin violations", err)
+	}
+
+	return buildMarginViolationReport(from, to, violations), nil
+}
+
+// SendWeeklyReports emails last week's violation report to every tenant that opted in and
+// has not received it yet. It is run periodically by the scheduler.
+func (uc *MarginFloorUseCase) SendWeeklyReports(ctx context.Context) error {
+	settings, err := uc.floorRepo.ListWeeklyReportSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list margin alert settings: %w", err)
+	}
+
+	now := time.Now()
+	sent := 0
+	for _, setting := range settings {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		week, due := setting.DueReportWeek(now)
+		if !due {
+			continue
+		}
+
+		if err := uc.sendWeeklyReport(ctx, setting, week); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": setting.TenantID,
+				"week":      week.Format("2006-01-02"),
+				"error":     err.Error(),
+			}).Error("Failed to send weekly margin report")
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		uc.logger.WithField("count", sent).Info("Weekly margin reports sent")
+	}
+
+	return nil
+}
+
+// sendWeeklyReport claims, builds and emails a single tenant's weekly report. The claim keeps
+// several server instances from sending the same report; it is released if the send fails.
+func (uc *MarginFloorUseCase) sendWeeklyReport(ctx context.Context, setting *entities.MarginAlertSetting, week time.Time) error {
+	claimed, err := uc.floorRepo.ClaimWeeklyReport(ctx, setting.TenantID, week)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	err = uc.buildAndSendWeeklyReport(ctx, setting, week)
+	if err != nil {
+		if releaseErr := uc.floorRepo.ReleaseWeeklyReport(ctx, setting.TenantID, week, setting.LastReportWeek); releaseErr != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": setting.TenantID,
+				"error":     releaseErr.Error(),
+			}).Error("Failed to release weekly margin report claim")
+		}
+		return err
+	}
+
+	setting.LastReportWeek = &week
+	return nil
+}
+
+// buildAndSendWeeklyReport renders and emails the violation report of a week
+func (uc *MarginFloorUseCase) buildAndSendWeeklyReport(ctx context.Context, setting *entities.MarginAlertSetting, week time.Time) error {
+	tenant, err := uc.tenantRepo.GetByID(ctx, setting.TenantID)
+	if err != nil {
+		return errors.NewNotFoundError("tenant")
+	}
+
+	violations, err := uc.floorRepo.GetViolations(ctx, setting.TenantID, week, week.AddDate(0, 0, 7))
+	if err != nil {
+		return err
+	}
+	report := buildMarginViolationReport(week, week.AddDate(0, 0, 7), violations)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Margin floor violations for %s, week of %s\n\n", tenant.Name, week.Format("2 January 2006"))
+	if report.TotalViolations == 0 {
+		body.WriteString("No sale or price fell below its category's margin floor this week.\n")
+	}
+	for _, category := range report.Categories {
+		fmt.Fprintf(&body, "%s: %d violations across %d products, lowest margin %s%% (floor %s%%)\n",
+			category.Category, category.Violations, category.Products,
+			category.LowestMarginPercent.StringFixed(2), category.FloorPercent.StringFixed(2))
+	}
+	if report.TotalViolations > 0 {
+		body.WriteString("\nLatest violations:\n")
+		for i, violation := range report.Violations {
+			if i == marginReportEmailLimit {
+				fmt.Fprintf(&body, "... and %d more\n", report.TotalViolations-marginReportEmailLimit)
+				break
+			}
+			fmt.Fprintf(&body, "- %s %s (%s)\n", violation.CreatedAt.Format("2006-01-02 15:04"), violation.Message(), violation.Source)
+		}
+	}
+
+	return uc.notification.SendEmail(ctx, ports.EmailNotification{
+		To:       setting.Recipients,
+		Subject:  fmt.Sprintf("%s weekly margin report: %d violations", tenant.Name, report.TotalViolations),
+		Body:     body.String(),
+		Priority: "normal",
+	})
+}
+
+// getFloors returns the tenant's margin floors keyed by lower-cased category. Lookup failures
+// are logged and treated as no floors so that sales are never held up.
+func (uc *MarginFloorUseCase) getFloors(ctx context.Context, tenantID uuid.UUID) map[string]*entities.MarginFloor {
+	floors, err := uc.floorRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get margin floors")
+		return nil
+	}
+
+	byCategory := make(map[string]*entities.MarginFloor, len(floors))
+	for _, floor := range floors {
+		byCategory[strings.ToLower(floor.Category)] = floor
+	}
+	return byCategory
+}
+
+// recordViolations stores violations for the weekly report and alerts the owner if enabled
+func (uc *MarginFloorUseCase) recordViolations(ctx context.Context, tenantID uuid.UUID, violations []*entities.MarginViolation) {
+	if len(violations) == 0 {
+		return
+	}
+
+	if err := uc.floorRepo.CreateViolations(ctx, violations); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to record margin violations")
+	}
+
+	setting, err := uc.GetAlertSettings(ctx, tenantID)
+	if err != nil || !setting.ShouldAlert() {
+		return
+	}
+
+	var body strings.Builder
+	body.WriteString("The following prices fell below their category's margin floor:\n\n")
+	for _, violation := range violations {
+		fmt.Fprintf(&body, "- %s (%s)\n", violation.Message(), violation.Source)
+	}
+
+	err = uc.notification.SendEmail(ctx, ports.EmailNotification{
+		To:       setting.Recipients,
+		Subject:  fmt.Sprintf("Margin floor alert: %s", violations[0].ProductName),
+		Body:     body.String(),
+		Priority: "high",
+	})
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to send margin floor alert")
+	}
+}
+
+// buildMarginViolationReport groups violations by category
+func buildMarginViolationReport(from, to time.Time, violations []*entities.MarginViolation) *MarginViolationReport {
+	report := &MarginViolationReport{
+		From:            from,
+		To:              to,
+		TotalViolations: len(violations),
+		Categories:      []MarginViolationCategorySummary{},
+		Violations:      violations,
+	}
+	if report.Violations == nil {
+		report.Violations = []*entities.MarginViolation{}
+	}
+
+	summaries := make(map[string]*MarginViolationCategorySummary)
+	products := make(map[string]map[uuid.UUID]bool)
+	for _, violation := range violations {
+		summary, ok := summaries[violation.Category]
+		if !ok {
+			summary = &MarginViolationCategorySummary{
+				Category:            violation.Category,
+				LowestMarginPercent: violation.MarginPercent,
+				FloorPercent:        violation.FloorPercent,
+			}
+			summaries[violation.Category] = summary
+			products[violation.Category] = make(map[uuid.UUID]bool)
+		}
+		summary.Violations++
+		products[violation.Category][violation.ProductID] = true
+		if violation.MarginPercent.LessThan(summary.LowestMarginPercent) {
+			summary.LowestMarginPercent = violation.MarginPercent
+		}
+	}
+
+	for category, summary := range summaries {
+		summary.Products = len(products[category])
+		report.Categories = append(report.Categories, *summary)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if report.Categories[i].Violations != report.Categories[j].Violations {
+			return report.Categories[i].Violations > report.Categories[j].Violations
+		}
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	return report
+}
@@ -0,0 +1,471 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PaymentGatewayUseCase collects card and digital wallet payments of sales and invoices through
+// Stripe and Midtrans. The gateway confirms each payment by webhook, which completes the sale or
+// records the payment against the invoice.
+type PaymentGatewayUseCase struct {
+	transactionRepo repositories.PaymentTransactionRepository
+	tenantRepo      repositories.TenantRepository
+	saleUseCase     *SaleUseCase
+	invoiceUseCase  *InvoiceUseCase
+	gateways        map[entities.PaymentGatewayProvider]ports.PaymentGatewayPort
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewPaymentGatewayUseCase creates a new payment gateway use case. Only the gateways configured
+// with credentials are passed in; payments through the others are refused.
+func NewPaymentGatewayUseCase(
+	transactionRepo repositories.PaymentTransactionRepository,
+	tenantRepo repositories.TenantRepository,
+	saleUseCase *SaleUseCase,
+	invoiceUseCase *InvoiceUseCase,
+	gateways map[entities.PaymentGatewayProvider]ports.PaymentGatewayPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *PaymentGatewayUseCase {
+	return &PaymentGatewayUseCase{
+		transactionRepo: transactionRepo,
+		tenantRepo:      tenantRepo,
+		saleUseCase:     saleUseCase,
+		invoiceUseCase:  invoiceUseCase,
+		gateways:        gateways,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// CreateSalePaymentIntentRequest represents create sale payment intent request. The sale is
+// completed with the discount, coupon and tax once the gateway confirms the payment.
+type CreateSalePaymentIntentRequest struct {
+	Provider       entities.PaymentGatewayProvider `json:"provider" validate:"required"`
+	PaymentMethod  entities.PaymentMethod          `json:"payment_method" validate:"required"`
+	DiscountAmount decimal.Decimal                 `json:"discount_amount,omitempty"`
+	CouponCode     string                          `json:"coupon_code,omitempty"`
+	TaxPercentage  decimal.Decimal                 `json:"tax_percentage,omitempty"`
+}
+
+// CreateInvoicePaymentIntentRequest represents create invoice payment intent request
+type CreateInvoicePaymentIntentRequest struct {
+	Provider      entities.PaymentGatewayProvider `json:"provider" validate:"required"`
+	PaymentMethod entities.PaymentMethod          `json:"payment_method" validate:"required"`
+	Amount        *decimal.Decimal                `json:"amount,omitempty"` // Defaults to the outstanding amount
+}
+
+// PaymentTransactionListResponse represents payment transaction list response
+type PaymentTransactionListResponse struct {
+	Transactions []*entities.PaymentTransaction `json:"transactions"`
+	Pagination   utils.PaginationInfo           `json:"pagination"`
+}
+
+// CreateSalePaymentIntent starts collecting the amount due on a pending sale through a gateway.
+// The returned transaction holds the client secret or payment page the customer pays with.
+func (uc *PaymentGatewayUseCase) CreateSalePaymentIntent(ctx context.Context, tenantID, userID, saleID uuid.UUID, req CreateSalePaymentIntentRequest) (*entities.PaymentTransaction, error) {
+	gateway, err := uc.gateway(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := uc.saleUseCase.GetSale(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The amount due is the total with tax and the method's surcharge
+	preview, err := uc.saleUseCase.PreviewTotal(ctx, saleID, PreviewSaleTotalRequest{
+		PaymentMethod:  req.PaymentMethod,
+		DiscountAmount: req.DiscountAmount,
+		CouponCode:     req.CouponCode,
+		TaxPercentage:  req.TaxPercentage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := entities.NewSalePaymentTransaction(tenantID, saleID, req.Provider, req.PaymentMethod, preview.TotalAmount, sale.Currency, userID)
+	if err != nil {
+		return nil, err
+	}
+	transaction.DiscountAmount = req.DiscountAmount
+	transaction.CouponCode = req.CouponCode
+	transaction.TaxPercentage = req.TaxPercentage
+
+	description := fmt.Sprintf("Sale %s", sale.SaleNumber)
+	if err := uc.openTransaction(ctx, gateway, transaction, description, sale.CustomerEmail); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// CreateInvoicePaymentIntent starts collecting a payment of a generated or sent invoice through a
+// gateway. The payment cannot exceed the invoice's outstanding amount.
+func (uc *PaymentGatewayUseCase) CreateInvoicePaymentIntent(ctx context.Context, tenantID, userID, invoiceID uuid.UUID, req CreateInvoicePaymentIntentRequest) (*entities.PaymentTransaction, error) {
+	gateway, err := uc.gateway(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := uc.invoiceUseCase.GetInvoiceForPayment(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	switch invoice.Status {
+	case entities.InvoiceStatusGenerated, entities.InvoiceStatusSent, entities.InvoiceStatusPartiallyPaid:
+	default:
+		return nil, errors.NewValidationError("invalid invoice status", "only generated, sent or partially paid invoices can be paid")
+	}
+
+	amount := invoice.OutstandingAmount
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount.GreaterThan(invoice.OutstandingAmount) {
+		return nil, errors.NewValidationError("payment exceeds outstanding amount", "payment amount cannot exceed "+invoice.OutstandingAmount.StringFixed(2))
+	}
+
+	currency := invoice.Currency
+	if currency == "" {
+		tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("tenant")
+		}
+		currency = tenant.GetCurrency()
+	}
+
+	transaction, err := entities.NewInvoicePaymentTransaction(tenantID, invoiceID, req.Provider, req.PaymentMethod, amount, currency, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Invoice %s", invoice.InvoiceNumber)
+	if err := uc.openTransaction(ctx, gateway, transaction, description, invoice.CustomerEmail); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// openTransaction creates the payment on the gateway and saves the transaction with its
+// reference. A payment created on the gateway but not saved is never confirmed, as the customer
+// never gets to pay it.
+func (uc *PaymentGatewayUseCase) openTransaction(ctx context.Context, gateway ports.PaymentGatewayPort, transaction *entities.PaymentTransaction, description, customerEmail string) error {
+	intent, err := gateway.CreatePaymentIntent(ctx, ports.PaymentIntentRequest{
+		Reference:     transaction.ID.String(),
+		Amount:        transaction.Amount,
+		Currency:      transaction.Currency,
+		PaymentMethod: string(transaction.PaymentMethod),
+		Description:   description,
+		CustomerEmail: customerEmail,
+	})
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"provider": transaction.Provider,
+			"error":    err.Error(),
+		}).Error("Failed to create payment intent")
+		return errors.NewInternalError("failed to create payment intent", err)
+	}
+
+	if err := transaction.Open(intent.ExternalID, intent.ClientSecret, intent.RedirectURL, intent.GatewayStatus); err != nil {
+		return err
+	}
+
+	if err := uc.transactionRepo.Create(ctx, transaction); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"provider":    transaction.Provider,
+			"external_id": transaction.ExternalID,
+			"error":       err.Error(),
+		}).Error("Failed to create payment transaction")
+		return errors.NewInternalError("failed to create payment transaction", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     transaction.CreatedBy,
+		Action:     "create",
+		Resource:   "payment_transaction",
+		ResourceID: transaction.ID.String(),
+		NewValue: map[string]interface{}{
+			"provider":       transaction.Provider,
+			"external_id":    transaction.ExternalID,
+			"sale_id":        transaction.SaleID,
+			"invoice_id":     transaction.InvoiceID,
+			"payment_method": transaction.PaymentMethod,
+			"amount":         transaction.Amount,
+			"currency":       transaction.Currency,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      transaction.TenantID,
+		"transaction_id": transaction.ID,
+		"provider":       transaction.Provider,
+		"amount":         transaction.Amount,
+	}).Info("Payment intent created")
+
+	return nil
+}
+
+// GetTransaction retrieves a payment transaction of the tenant
+func (uc *PaymentGatewayUseCase) GetTransaction(ctx context.Context, tenantID, transactionID uuid.UUID) (*entities.PaymentTransaction, error) {
+	transaction, err := uc.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil || transaction.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("payment transaction")
+	}
+	return transaction, nil
+}
+
+// ListTransactions retrieves payment transactions with pagination and filtering, newest first
+func (uc *PaymentGatewayUseCase) ListTransactions(ctx context.Context, filter repositories.PaymentTransactionFilter, pagination utils.PaginationInfo) (*PaymentTransactionListResponse, error) {
+	transactions, paginationResult, err := uc.transactionRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list payment transactions")
+		return nil, errors.NewInternalError("failed to list payment transactions", err)
+	}
+
+	return &PaymentTransactionListResponse{
+		Transactions: transactions,
+		Pagination:   paginationResult,
+	}, nil
+}
+
+// HandleWebhook applies a payment notification sent by a gateway. When the gateway confirms a
+// payment, its sale is completed or the payment recorded against its invoice. Notifications
+// about anything else and about payments not made here are acknowledged and ignored, returning
+// a nil transaction.
+//
+// Gateways retry notifications until they are acknowledged, so they may arrive more than once
+// and out of order. A confirmed payment that cannot be applied, e.g. because the sale was
+// cancelled meanwhile, is recorded on the transaction to be refunded by hand and does not
+// return an error. Internal errors are returned so the gateway retries the notification later.
+func (uc *PaymentGatewayUseCase) HandleWebhook(ctx context.Context, provider entities.PaymentGatewayProvider, signature string, body []byte) (*entities.PaymentTransaction, error) {
+	gateway, ok := uc.gateways[provider]
+	if !ok {
+		return nil, errors.NewNotFoundError("payment gateway")
+	}
+
+	event, err := gateway.ParseWebhook(signature, body)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"provider": provider,
+			"error":    err.Error(),
+		}).Warn("Rejected payment gateway webhook")
+		return nil, errors.NewUnauthorizedError("invalid webhook signature")
+	}
+	if event == nil {
+		return nil, nil
+	}
+
+	// The payment, and with it the tenant, is only known from the gateway's reference
+	transaction, err := uc.transactionRepo.GetByExternalID(entities.WithAllTenants(ctx), provider, event.ExternalID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			uc.logger.WithFields(map[string]interface{}{
+				"provider":    provider,
+				"external_id": event.ExternalID,
+			}).Info("Ignored webhook for unknown payment")
+			return nil, nil
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"provider":    provider,
+			"external_id": event.ExternalID,
+			"error":       err.Error(),
+		}).Error("Failed to get payment transaction")
+		return nil, errors.NewInternalError("failed to get payment transaction", err)
+	}
+
+	// Webhooks are not authenticated as a user, so restrict data access to the payment's tenant
+	ctx = entities.WithTenantScope(ctx, transaction.TenantID)
+
+	if !transaction.Settle(entities.PaymentTransactionStatus(event.Status), event.GatewayStatus, time.Now()) {
+		if err := uc.saveTransaction(ctx, transaction); err != nil {
+			return nil, err
+		}
+		return transaction, nil
+	}
+
+	if transaction.Status == entities.PaymentTransactionStatusSucceeded {
+		if err := uc.applyPayment(ctx, transaction, event); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.saveTransaction(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     transaction.CreatedBy,
+		Action:     "payment_" + string(transaction.Status),
+		Resource:   "payment_transaction",
+		ResourceID: transaction.ID.String(),
+		NewValue: map[string]interface{}{
+			"provider":       transaction.Provider,
+			"external_id":    transaction.ExternalID,
+			"gateway_status": transaction.GatewayStatus,
+			"amount":         transaction.Amount,
+			"error":          transaction.Error,
+		},
+		Timestamp: time.Now(),
+		Success:   transaction.Error == "",
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      transaction.TenantID,
+		"transaction_id": transaction.ID,
+		"provider":       transaction.Provider,
+		"status":         transaction.Status,
+	}).Info("Payment transaction settled")
+
+	return transaction, nil
+}
+
+// applyPayment completes the sale of a confirmed payment or records it against the invoice.
+// Only internal errors are returned; other failures are recorded on the transaction.
+func (uc *PaymentGatewayUseCase) applyPayment(ctx context.Context, transaction *entities.PaymentTransaction, event *ports.PaymentGatewayEvent) error {
+	var err error
+	switch {
+	case !event.Amount.Equal(transaction.Amount) || !strings.EqualFold(event.Currency, transaction.Currency):
+		err = errors.NewValidationError("payment amount mismatch", fmt.Sprintf("gateway confirmed %s %s but %s %s was due",
+			event.Amount.StringFixed(2), event.Currency, transaction.Amount.StringFixed(2), transaction.Currency))
+	case transaction.SaleID != nil:
+		err = uc.completeSale(ctx, transaction)
+	case transaction.InvoiceID != nil:
+		err = uc.payInvoice(ctx, transaction)
+	}
+	if err == nil {
+		transaction.RecordError("")
+		return nil
+	}
+
+	appErr, ok := errors.IsAppError(err)
+	if !ok || appErr.Type == errors.ErrorTypeInternal {
+		return err
+	}
+
+	reason := appErr.Message
+	if appErr.Details != "" {
+		reason += ": " + appErr.Details
+	}
+	transaction.RecordError(reason)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"transaction_id": transaction.ID,
+		"provider":       transaction.Provider,
+		"external_id":    transaction.ExternalID,
+		"reason":         reason,
+	}).Warn("Confirmed payment could not be applied")
+
+	return nil
+}
+
+// completeSale completes the transaction's sale with the payment, as long as the amount due has
+// not changed since the payment intent was created. A sale already completed with this payment
+// is left alone.
+func (uc *PaymentGatewayUseCase) completeSale(ctx context.Context, transaction *entities.PaymentTransaction) error {
+	sale, err := uc.saleUseCase.GetSale(ctx, *transaction.SaleID)
+	if err != nil {
+		return err
+	}
+	if sale.Status == entities.SaleStatusCompleted && sale.PaymentReference == transaction.Reference() {
+		return nil
+	}
+
+	payment := CompleteSaleRequest{
+		PaidAmount:       transaction.Amount,
+		PaymentMethod:    transaction.PaymentMethod,
+		DiscountAmount:   transaction.DiscountAmount,
+		CouponCode:       transaction.CouponCode,
+		TaxPercentage:    transaction.TaxPercentage,
+		Notes:            "Paid through " + transaction.Reference(),
+		PaymentReference: transaction.Reference(),
+	}
+
+	// Never complete the sale for a different amount than the customer paid
+	preview, err := uc.saleUseCase.PreviewPayment(ctx, sale.ID, payment)
+	if err != nil {
+		return err
+	}
+	if !preview.TotalAmount.Equal(transaction.Amount) {
+		return errors.NewValidationError("amount due changed", "sale total is "+preview.TotalAmount.StringFixed(2)+" but "+transaction.Amount.StringFixed(2)+" was paid")
+	}
+
+	if _, err := uc.saleUseCase.CompleteSale(ctx, transaction.CreatedBy, sale.ID, payment); err != nil {
+		// A concurrent delivery of the same event may have completed the sale first
+		if completed, getErr := uc.saleUseCase.GetSale(ctx, sale.ID); getErr == nil &&
+			completed.Status == entities.SaleStatusCompleted && completed.PaymentReference == transaction.Reference() {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// payInvoice records the payment against the transaction's invoice. A payment already recorded
+// under the transaction's reference is not recorded again.
+func (uc *PaymentGatewayUseCase) payInvoice(ctx context.Context, transaction *entities.PaymentTransaction) error {
+	payments, err := uc.invoiceUseCase.ListInvoicePayments(ctx, *transaction.InvoiceID)
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments.Payments {
+		if payment.Reference == transaction.Reference() {
+			return nil
+		}
+	}
+
+	_, err = uc.invoiceUseCase.RecordInvoicePayment(ctx, transaction.CreatedBy, *transaction.InvoiceID, RecordInvoicePaymentRequest{
+		Amount:        transaction.Amount,
+		PaymentMethod: transaction.PaymentMethod,
+		Reference:     transaction.Reference(),
+		PaidAt:        transaction.SucceededAt,
+	})
+	return err
+}
+
+// saveTransaction saves a payment transaction's gateway reference, status and error
+func (uc *PaymentGatewayUseCase) saveTransaction(ctx context.Context, transaction *entities.PaymentTransaction) error {
+	if err := uc.transactionRepo.Update(ctx, transaction); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"transaction_id": transaction.ID,
+			"error":          err.Error(),
+		}).Error("Failed to update payment transaction")
+		return errors.NewInternalError("failed to update payment transaction", err)
+	}
+	return nil
+}
+
+// gateway returns the gateway payments through the provider are collected with
+func (uc *PaymentGatewayUseCase) gateway(provider entities.PaymentGatewayProvider) (ports.PaymentGatewayPort, error) {
+	if !provider.IsValid() {
+		return nil, errors.NewValidationError("invalid payment gateway", "provider must be one of: stripe, midtrans")
+	}
+	gateway, ok := uc.gateways[provider]
+	if !ok {
+		return nil, errors.NewValidationError("payment gateway not available", string(provider)+" is not configured")
+	}
+	return gateway, nil
+}
@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// PaymentSurchargeUseCase handles tenant-defined payment method surcharge rules
+type PaymentSurchargeUseCase struct {
+	surchargeRuleRepo repositories.PaymentSurchargeRuleRepository
+	audit             ports.AuditPort
+	logger            logger.Logger
+}
+
+// NewPaymentSurchargeUseCase creates a new payment surcharge use case
+func NewPaymentSurchargeUseCase(
+	surchargeRuleRepo repositories.PaymentSurchargeRuleRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *PaymentSurchargeUseCase {
+	return &PaymentSurchargeUseCase{
+		surchargeRuleRepo: surchargeRuleRepo,
+		audit:             audit,
+		logger:            logger,
+	}
+}
+
+// CreatePaymentSurchargeRuleRequest represents create payment surcharge rule request
+type CreatePaymentSurchargeRuleRequest struct {
+	PaymentMethod entities.PaymentMethod `json:"payment_method" validate:"required"`
+	Type          entities.SurchargeType `json:"type" validate:"required"`
+	Value         decimal.Decimal        `json:"value" validate:"required"`
+	MinAmount     decimal.Decimal        `json:"min_amount,omitempty"`
+	MaxAmount     decimal.Decimal        `json:"max_amount,omitempty"`
+	Label         string                 `json:"label" validate:"required"`
+	IsTaxable     bool                   `json:"is_taxable"`
+}
+
+// UpdatePaymentSurchargeRuleRequest represents update payment surcharge rule request
+type UpdatePaymentSurchargeRuleRequest struct {
+	Type      entities.SurchargeType `json:"type" validate:"required"`
+	Value     decimal.Decimal        `json:"value" validate:"required"`
+	MinAmount decimal.Decimal        `json:"min_amount,omitempty"`
+	MaxAmount decimal.Decimal        `json:"max_amount,omitempty"`
+	Label     string                 `json:"label" validate:"required"`
+	IsTaxable bool                   `json:"is_taxable"`
+	IsActive  *bool                  `json:"is_active,omitempty"`
+}
+
+// CreateSurchargeRule creates a surcharge rule for one of the tenant's payment methods
+func (uc *PaymentSurchargeUseCase) CreateSurchargeRule(ctx context.Context, tenantID, userID uuid.UUID, req CreatePaymentSurchargeRuleRequest) (*entities.PaymentSurchargeRule, error) {
+	rule, err := entities.NewPaymentSurchargeRule(tenantID, req.PaymentMethod, req.Type, req.Value, req.MinAmount, req.MaxAmount, req.Label, req.IsTaxable, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.surchargeRuleRepo.Create(ctx, rule); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":      tenantID,
+			"payment_method": req.PaymentMethod,
+			"error":          err.Error(),
+		}).Error("Failed to create payment surcharge rule")
+		return nil, errors.NewInternalError("failed to create payment surcharge rule", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "payment_surcharge",
+		ResourceID: rule.ID.String(),
+		NewValue: map[string]interface{}{
+			"payment_method": rule.PaymentMethod,
+			"type":           rule.Type,
+			"value":          rule.Value,
+			"min_amount":     rule.MinAmount,
+			"max_amount":     rule.MaxAmount,
+			"is_taxable":     rule.IsTaxable,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"rule_id":        rule.ID,
+		"tenant_id":      tenantID,
+		"payment_method": rule.PaymentMethod,
+		"user_id":        userID,
+	}).Info("Payment surcharge rule created successfully")
+
+	return rule, nil
+}
+
+// ListSurchargeRules retrieves all surcharge rules for a tenant
+func (uc *PaymentSurchargeUseCase) ListSurchargeRules(ctx context.Context, tenantID uuid.UUID) ([]*entities.PaymentSurchargeRule, error) {
+	rules, err := uc.surchargeRuleRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list payment surcharge rules")
+		return nil, errors.NewInternalError("failed to list payment surcharge rules", err)
+	}
+
+	return rules, nil
+}
+
+// UpdateSurchargeRule updates an existing surcharge rule
+func (uc *PaymentSurchargeUseCase) UpdateSurchargeRule(ctx context.Context, tenantID, userID, ruleID uuid.UUID, req UpdatePaymentSurchargeRuleRequest) (*entities.PaymentSurchargeRule, error) {
+	rule, err := uc.getTenantRule(ctx, tenantID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(rule)
+
+	if err := rule.Update(req.Type, req.Value, req.MinAmount, req.MaxAmount, req.Label, req.IsTaxable); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		if *req.IsActive {
+			rule.Activate()
+		} else {
+			rule.Deactivate()
+		}
+	}
+
+	if err := uc.surchargeRuleRepo.Update(ctx, rule); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"rule_id": ruleID,
+			"error":   err.Error(),
+		}).Error("Failed to update payment surcharge rule")
+		return nil, errors.NewInternalError("failed to update payment surcharge rule", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "payment_surcharge",
+		ResourceID: ruleID.String(),
+		Changes:    audit.Diff(before, audit.TakeSnapshot(rule)),
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"rule_id": ruleID,
+		"user_id": userID,
+	}).Info("Payment surcharge rule updated successfully")
+
+	return rule, nil
+}
+
+// DeleteSurchargeRule deletes a surcharge rule
+func (uc *PaymentSurchargeUseCase) DeleteSurchargeRule(ctx context.Context, tenantID, userID, ruleID uuid.UUID) error {
+	rule, err := uc.getTenantRule(ctx, tenantID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.surchargeRuleRepo.Delete(ctx, ruleID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"rule_id": ruleID,
+			"error":   err.Error(),
+		}).Error("Failed to delete payment surcharge rule")
+		return errors.NewInternalError("failed to delete payment surcharge rule", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "payment_surcharge",
+		ResourceID: ruleID.String(),
+		OldValue: map[string]interface{}{
+			"payment_method": rule.PaymentMethod,
+			"type":           rule.Type,
+			"value":          rule.Value,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"rule_id": ruleID,
+		"user_id": userID,
+	}).Info("Payment surcharge rule deleted successfully")
+
+	return nil
+}
+
+// getTenantRule retrieves a surcharge rule and ensures it belongs to the tenant
+func (uc *PaymentSurchargeUseCase) getTenantRule(ctx context.Context, tenantID, ruleID uuid.UUID) (*entities.PaymentSurchargeRule, error) {
+	rule, err := uc.surchargeRuleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("payment surcharge rule")
+	}
+	if rule.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("payment surcharge rule")
+	}
+	return rule, nil
+}
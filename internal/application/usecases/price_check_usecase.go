@@ -0,0 +1,321 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// kioskDeviceCacheTTL bounds how long a revoked token can keep working on other instances
+	kioskDeviceCacheTTL = 5 * time.Minute
+	// priceCheckCacheTTL bounds how stale a price or stock status shown on a kiosk can be
+	priceCheckCacheTTL = 30 * time.Second
+)
+
+// PriceCheckUseCase serves price lookups for customer-facing price-checker kiosks.
+// Kiosks authenticate with a device token instead of a user session, and lookups are
+// answered from a cached read model so the hot path does not touch the database.
+type PriceCheckUseCase struct {
+	productRepo     repositories.ProductRepository
+	stockRepo       repositories.StockRepository
+	kioskDeviceRepo repositories.KioskDeviceRepository
+	cache           ports.CachePort
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewPriceCheckUseCase creates a new price check use case
+func NewPriceCheckUseCase(
+	productRepo repositories.ProductRepository,
+	stockRepo repositories.StockRepository,
+	kioskDeviceRepo repositories.KioskDeviceRepository,
+	cache ports.CachePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *PriceCheckUseCase {
+	return &PriceCheckUseCase{
+		productRepo:     productRepo,
+		stockRepo:       stockRepo,
+		kioskDeviceRepo: kioskDeviceRepo,
+		cache:           cache,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// RegisterKioskDeviceRequest represents register kiosk device request
+type RegisterKioskDeviceRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// RegisterKioskDeviceResponse represents a newly registered kiosk device.
+// The device token is only ever returned here.
+type RegisterKioskDeviceResponse struct {
+	Device *entities.KioskDevice `json:"device"`
+	Token  string                `json:"token"`
+}
+
+// PriceCheckResponse represents the minimal product information shown on a kiosk
+type PriceCheckResponse struct {
+	Barcode     string           `json:"barcode"`
+	Name        string           `json:"name"`
+	Unit        string           `json:"unit"`
+	Price       decimal.Decimal  `json:"price"`
+	PromoPrice  *decimal.Decimal `json:"promo_price,omitempty"`
+	PromoEndsAt *time.Time       `json:"promo_ends_at,omitempty"`
+	StockStatus string           `json:"stock_status"`
+}
+
+// kioskDeviceEntry is the cached view of an authenticated kiosk device
+type kioskDeviceEntry struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+// priceCheckEntry is the cached read model behind a price check. The promotion window is
+// cached rather than the resolved promo price so a promotion starts and ends on time.
+type priceCheckEntry struct {
+	Barcode       string           `json:"barcode"`
+	Name          string           `json:"name"`
+	Unit          string           `json:"unit"`
+	Price         decimal.Decimal  `json:"price"`
+	PromoPrice    *decimal.Decimal `json:"promo_price,omitempty"`
+	PromoStartsAt *time.Time       `json:"promo_starts_at,omitempty"`
+	PromoEndsAt   *time.Time       `json:"promo_ends_at,omitempty"`
+	StockStatus   string           `json:"stock_status"`
+}
+
+// AuthenticateDevice resolves a kiosk device token to its tenant
+func (uc *PriceCheckUseCase) AuthenticateDevice(ctx context.Context, token string) (uuid.UUID, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return uuid.Nil, errors.NewUnauthorizedError("device token is required")
+	}
+
+	tokenHash := entities.HashKioskDeviceToken(token)
+	cacheKey := kioskDeviceCacheKey(tokenHash)
+
+	var entry kioskDeviceEntry
+	if err := uc.cache.Get(ctx, cacheKey, &entry); err == nil && entry.TenantID != uuid.Nil {
+		return entry.TenantID, nil
+	}
+
+	device, err := uc.kioskDeviceRepo.GetByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return uuid.Nil, errors.NewUnauthorizedError("invalid device token")
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get kiosk device")
+		return uuid.Nil, errors.NewInternalError("failed to authenticate device", err)
+	}
+
+	if !device.IsActive {
+		return uuid.Nil, errors.NewUnauthorizedError("device has been revoked")
+	}
+
+	entry = kioskDeviceEntry{ID: device.ID, TenantID: device.TenantID}
+	if err := uc.cache.Set(ctx, cacheKey, entry, kioskDeviceCacheTTL); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to cache kiosk device")
+	}
+
+	return device.TenantID, nil
+}
+
+// CheckPrice looks up the price, active promotion and stock status of a product by barcode
+func (uc *PriceCheckUseCase) CheckPrice(ctx context.Context, tenantID uuid.UUID, barcode string) (*PriceCheckResponse, error) {
+	barcode = strings.TrimSpace(barcode)
+	if barcode == "" {
+		return nil, errors.NewValidationError("barcode is required", "barcode query parameter cannot be empty")
+	}
+
+	// Kiosks are not authenticated as a user, so restrict data access to the device's tenant
+	ctx = entities.WithTenantScope(ctx, tenantID)
+
+	cacheKey := priceCheckCacheKey(tenantID, barcode)
+
+	var entry priceCheckEntry
+	if err := uc.cache.Get(ctx, cacheKey, &entry); err != nil || entry.Barcode == "" {
+		loaded, err := uc.loadPriceCheckEntry(ctx, tenantID, barcode)
+		if err != nil {
+			return nil, err
+		}
+		entry = *loaded
+
+		if err := uc.cache.Set(ctx, cacheKey, entry, priceCheckCacheTTL); err != nil {
+			uc.logger.WithField("error", err.Error()).Warn("Failed to cache price check")
+		}
+	}
+
+	return entry.toResponse(time.Now()), nil
+}
+
+// RegisterDevice registers a new kiosk device and issues its device token
+func (uc *PriceCheckUseCase) RegisterDevice(ctx context.Context, tenantID uuid.UUID, req RegisterKioskDeviceRequest, createdBy uuid.UUID) (*RegisterKioskDeviceResponse, error) {
+	device, token, err := entities.NewKioskDevice(tenantID, req.Name, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.kioskDeviceRepo.Create(ctx, device); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"name":  req.Name,
+			"error": err.Error(),
+		}).Error("Failed to create kiosk device")
+		return nil, errors.NewInternalError("failed to register kiosk device", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     createdBy,
+		Action:     "register",
+		Resource:   "kiosk_device",
+		ResourceID: device.ID.String(),
+		NewValue: map[string]interface{}{
+			"name": device.Name,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"device_id": device.ID,
+		"name":      device.Name,
+	}).Info("Kiosk device registered")
+
+	return &RegisterKioskDeviceResponse{Device: device, Token: token}, nil
+}
+
+// ListDevices lists the kiosk devices registered for a tenant
+func (uc *PriceCheckUseCase) ListDevices(ctx context.Context, tenantID uuid.UUID) ([]*entities.KioskDevice, error) {
+	devices, err := uc.kioskDeviceRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list kiosk devices")
+		return nil, errors.NewInternalError("failed to list kiosk devices", err)
+	}
+
+	return devices, nil
+}
+
+// RevokeDevice revokes a kiosk device so its token can no longer be used
+func (uc *PriceCheckUseCase) RevokeDevice(ctx context.Context, tenantID, deviceID, revokedBy uuid.UUID) (*entities.KioskDevice, error) {
+	device, err := uc.kioskDeviceRepo.GetByID(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("kiosk device")
+	}
+
+	if err := device.Revoke(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.kioskDeviceRepo.Update(ctx, device); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"device_id": deviceID,
+			"error":     err.Error(),
+		}).Error("Failed to revoke kiosk device")
+		return nil, errors.NewInternalError("failed to revoke kiosk device", err)
+	}
+
+	if err := uc.cache.Delete(ctx, kioskDeviceCacheKey(device.TokenHash)); err != nil {
+		uc.logger.WithField("error", err.Error()).Warn("Failed to evict kiosk device from cache")
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     revokedBy,
+		Action:     "revoke",
+		Resource:   "kiosk_device",
+		ResourceID: device.ID.String(),
+		NewValue: map[string]interface{}{
+			"is_active":  device.IsActive,
+			"revoked_at": device.RevokedAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return device, nil
+}
+
+// Helper methods
+
+// loadPriceCheckEntry builds the price check read model from the product and stock records
+func (uc *PriceCheckUseCase) loadPriceCheckEntry(ctx context.Context, tenantID uuid.UUID, barcode string) (*priceCheckEntry, error) {
+	product, err := uc.productRepo.GetByTenantAndBarcode(ctx, tenantID, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsSellable() {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	stockStatus := "Out of Stock"
+	stock, err := uc.stockRepo.GetByProductID(ctx, product.ID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": product.ID,
+				"error":      err.Error(),
+			}).Error("Failed to get stock for price check")
+			return nil, errors.NewInternalError("failed to check price", err)
+		}
+	} else {
+		stockStatus = stock.GetStockStatus()
+	}
+
+	return &priceCheckEntry{
+		Barcode:       product.Barcode,
+		Name:          product.Name,
+		Unit:          product.Unit,
+		Price:         product.Price,
+		PromoPrice:    product.PromoPrice,
+		PromoStartsAt: product.PromoStartsAt,
+		PromoEndsAt:   product.PromoEndsAt,
+		StockStatus:   stockStatus,
+	}, nil
+}
+
+// toResponse resolves the cached entry into a kiosk response at the given time
+func (e priceCheckEntry) toResponse(now time.Time) *PriceCheckResponse {
+	response := &PriceCheckResponse{
+		Barcode:     e.Barcode,
+		Name:        e.Name,
+		Unit:        e.Unit,
+		Price:       e.Price,
+		StockStatus: e.StockStatus,
+	}
+
+	product := entities.Product{
+		Price:         e.Price,
+		PromoPrice:    e.PromoPrice,
+		PromoStartsAt: e.PromoStartsAt,
+		PromoEndsAt:   e.PromoEndsAt,
+	}
+	if promoPrice := product.ActivePromoPrice(now); promoPrice != nil {
+		response.PromoPrice = promoPrice
+		response.PromoEndsAt = e.PromoEndsAt
+	}
+
+	return response
+}
+
+func kioskDeviceCacheKey(tokenHash string) string {
+	return fmt.Sprintf("kiosk_device:%s", tokenHash)
+}
+
+func priceCheckCacheKey(tenantID uuid.UUID, barcode string) string {
+	return fmt.Sprintf("price_check:%s:%s", tenantID, barcode)
+}
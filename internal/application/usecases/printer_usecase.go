@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// PrinterUseCase handles registering and testing the printers store terminals print invoices
+// and receipts to
+type PrinterUseCase struct {
+	printerRepo  repositories.PrinterRepository
+	printService services.PrintService
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewPrinterUseCase creates a new printer use case
+func NewPrinterUseCase(
+	printerRepo repositories.PrinterRepository,
+	printService services.PrintService,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *PrinterUseCase {
+	return &PrinterUseCase{
+		printerRepo:  printerRepo,
+		printService: printService,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// RegisterPrinterRequest represents register printer request. A printer without a terminal
+// is shared by all of the tenant's terminals.
+type RegisterPrinterRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description,omitempty"`
+	Terminal    string `json:"terminal,omitempty"`
+	IsThermal   bool   `json:"is_thermal"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+// ListPrinters retrieves the tenant's registered printers, limited to those a terminal can
+// print to when terminal is not empty
+func (uc *PrinterUseCase) ListPrinters(ctx context.Context, tenantID uuid.UUID, terminal string) ([]*entities.Printer, error) {
+	printers, err := uc.printerRepo.GetByTenant(ctx, tenantID, terminal)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get printers")
+		return nil, errors.NewInternalError("failed to get printers", err)
+	}
+
+	return printers, nil
+}
+
+// GetPrinter retrieves a registered printer by ID
+func (uc *PrinterUseCase) GetPrinter(ctx context.Context, tenantID, printerID uuid.UUID) (*entities.Printer, error) {
+	printer, err := uc.printerRepo.GetByID(ctx, printerID)
+	if err != nil || printer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("printer")
+	}
+
+	return printer, nil
+}
+
+// RegisterPrinter registers a printer for the tenant
+func (uc *PrinterUseCase) RegisterPrinter(ctx context.Context, tenantID, userID uuid.UUID, req RegisterPrinterRequest) (*entities.Printer, error) {
+	printer, err := entities.NewPrinter(tenantID, req.Name, req.Description, req.Terminal, req.IsThermal, req.IsDefault, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.printerRepo.Create(ctx, printer); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to register printer")
+		return nil, errors.NewInternalError("failed to register printer", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "printer",
+		ResourceID: printer.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":       printer.Name,
+			"terminal":   printer.Terminal,
+			"is_thermal": printer.IsThermal,
+			"is_default": printer.IsDefault,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"printer_id": printer.ID,
+		"name":       printer.Name,
+		"terminal":   printer.Terminal,
+		"user_id":    userID,
+	}).Info("Printer registered")
+
+	return printer, nil
+}
+
+// DeletePrinter removes a registered printer
+func (uc *PrinterUseCase) DeletePrinter(ctx context.Context, tenantID, userID, printerID uuid.UUID) error {
+	printer, err := uc.GetPrinter(ctx, tenantID, printerID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.printerRepo.Delete(ctx, printer.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"printer_id": printerID,
+			"error":      err.Error(),
+		}).Error("Failed to delete printer")
+		return errors.NewInternalError("failed to delete printer", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "printer",
+		ResourceID: printer.ID.String(),
+		OldValue: map[string]interface{}{
+			"name":     printer.Name,
+			"terminal": printer.Terminal,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"printer_id": printer.ID,
+		"user_id":    userID,
+	}).Info("Printer deleted")
+
+	return nil
+}
+
+// TestPrint prints a test page to a registered printer and records whether it succeeded. A
+// failed test print is reported in the printer's status rather than as an error.
+func (uc *PrinterUseCase) TestPrint(ctx context.Context, tenantID, userID, printerID uuid.UUID) (*entities.Printer, error) {
+	printer, err := uc.GetPrinter(ctx, tenantID, printerID)
+	if err != nil {
+		return nil, err
+	}
+
+	printErr := uc.printService.PrintTestPage(ctx, printer.Name)
+	printer.RecordTestPrint(printErr)
+
+	if err := uc.printerRepo.UpdateStatus(ctx, printer); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"printer_id": printerID,
+			"error":      err.Error(),
+		}).Error("Failed to update printer status")
+		return nil, errors.NewInternalError("failed to update printer status", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"printer_id": printer.ID,
+		"status":     printer.Status,
+		"user_id":    userID,
+	}).Info("Printer test print recorded")
+
+	return printer, nil
+}
@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// ProductUnitUseCase handles the unit catalog products are counted or measured in: the
+// built-in units and the tenant's own
+type ProductUnitUseCase struct {
+	unitRepo repositories.ProductUnitRepository
+	audit    ports.AuditPort
+	logger   logger.Logger
+}
+
+// NewProductUnitUseCase creates a new product unit use case
+func NewProductUnitUseCase(
+	unitRepo repositories.ProductUnitRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *ProductUnitUseCase {
+	return &ProductUnitUseCase{
+		unitRepo: unitRepo,
+		audit:    audit,
+		logger:   logger,
+	}
+}
+
+// CreateProductUnitRequest represents create product unit request
+type CreateProductUnitRequest struct {
+	Code          string            `json:"code" validate:"required"`
+	Names         map[string]string `json:"names" validate:"required"` // Display name by language, "en" is required
+	DecimalPlaces int               `json:"decimal_places"`
+}
+
+// UpdateProductUnitRequest represents update product unit request
+type UpdateProductUnitRequest struct {
+	Names         map[string]string `json:"names" validate:"required"`
+	DecimalPlaces int               `json:"decimal_places"`
+}
+
+// ProductUnitResponse represents product unit response, named in the requested language
+type ProductUnitResponse struct {
+	Code          string            `json:"code"`
+	Name          string            `json:"name"`
+	Names         map[string]string `json:"names"`
+	DecimalPlaces int               `json:"decimal_places"`
+	IsBuiltIn     bool              `json:"is_built_in"`
+}
+
+// ListUnits returns the built-in units followed by the tenant's own, named in the language
+func (uc *ProductUnitUseCase) ListUnits(ctx context.Context, tenantID uuid.UUID, language string) ([]*ProductUnitResponse, error) {
+	custom, err := uc.unitRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get product units")
+		return nil, errors.NewInternalError("failed to get product units", err)
+	}
+
+	units := append(entities.BuiltInProductUnits(), custom...)
+	responses := make([]*ProductUnitResponse, 0, len(units))
+	for _, unit := range units {
+		responses = append(responses, toProductUnitResponse(unit, language))
+	}
+
+	return responses, nil
+}
+
+// GetUnit returns a unit of the catalog, named in the language
+func (uc *ProductUnitUseCase) GetUnit(ctx context.Context, tenantID uuid.UUID, code, language string) (*ProductUnitResponse, error) {
+	unit, err := uc.ResolveUnit(ctx, tenantID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductUnitResponse(unit, language), nil
+}
+
+// ResolveUnit returns the built-in or tenant unit with the given code or one of its spellings.
+// Products may only reference units resolved here.
+func (uc *ProductUnitUseCase) ResolveUnit(ctx context.Context, tenantID uuid.UUID, code string) (*entities.ProductUnit, error) {
+	if unit := entities.BuiltInProductUnit(code); unit != nil {
+		return unit, nil
+	}
+
+	unit, err := uc.unitRepo.GetByCode(ctx, tenantID, entities.NormalizeUnitCode(code))
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, errors.NewValidationError("unknown unit", fmt.Sprintf("unit '%s' is not in the unit catalog", code))
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get product unit")
+		return nil, errors.NewInternalError("failed to get product unit", err)
+	}
+
+	return unit, nil
+}
+
+// CreateUnit adds a unit to the tenant's catalog
+func (uc *ProductUnitUseCase) CreateUnit(ctx context.Context, tenantID, userID uuid.UUID, req CreateProductUnitRequest) (*ProductUnitResponse, error) {
+	unit, err := entities.NewProductUnit(tenantID, req.Code, req.Names, req.DecimalPlaces, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.unitRepo.Create(ctx, unit); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to create product unit")
+		return nil, errors.NewInternalError("failed to create product unit", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "product_unit",
+		ResourceID: unit.ID.String(),
+		NewValue: map[string]interface{}{
+			"code":           unit.Code,
+			"names":          unit.Names,
+			"decimal_places": unit.DecimalPlaces,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"code":      unit.Code,
+		"user_id":   userID,
+	}).Info("Product unit created")
+
+	return toProductUnitResponse(unit, entities.DefaultUnitLanguage), nil
+}
+
+// UpdateUnit updates the names and allowed decimals of one of the tenant's units
+func (uc *ProductUnitUseCase) UpdateUnit(ctx context.Context, tenantID, userID uuid.UUID, code string, req UpdateProductUnitRequest) (*ProductUnitResponse, error) {
+	unit, err := uc.ResolveUnit(ctx, tenantID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := map[string]interface{}{
+		"names":          unit.Names,
+		"decimal_places": unit.DecimalPlaces,
+	}
+
+	if err := unit.Update(req.Names, req.DecimalPlaces, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.unitRepo.Update(ctx, unit); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"code":  unit.Code,
+			"error": err.Error(),
+		}).Error("Failed to update product unit")
+		return nil, errors.NewInternalError("failed to update product unit", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "product_unit",
+		ResourceID: unit.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"names":          unit.Names,
+			"decimal_places": unit.DecimalPlaces,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"code":      unit.Code,
+		"user_id":   userID,
+	}).Info("Product unit updated")
+
+	return toProductUnitResponse(unit, entities.DefaultUnitLanguage), nil
+}
+
+// DeleteUnit removes one of the tenant's units no product is measured in
+func (uc *ProductUnitUseCase) DeleteUnit(ctx context.Context, tenantID, userID uuid.UUID, code string) error {
+	unit, err := uc.ResolveUnit(ctx, tenantID, code)
+	if err != nil {
+		return err
+	}
+	if unit.IsBuiltIn {
+		return errors.NewValidationError("built-in unit", "built-in units cannot be deleted")
+	}
+
+	count, err := uc.unitRepo.CountProducts(ctx, tenantID, unit.Code)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to count products by unit")
+		return errors.NewInternalError("failed to delete product unit", err)
+	}
+	if count > 0 {
+		return errors.NewConflictError(fmt.Sprintf("unit '%s' is used by %d products", unit.Code, count))
+	}
+
+	if err := uc.unitRepo.Delete(ctx, unit.ID); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"code":  unit.Code,
+			"error": err.Error(),
+		}).Error("Failed to delete product unit")
+		return errors.NewInternalError("failed to delete product unit", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "product_unit",
+		ResourceID: unit.ID.String(),
+		OldValue: map[string]interface{}{
+			"code":           unit.Code,
+			"names":          unit.Names,
+			"decimal_places": unit.DecimalPlaces,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"code":      unit.Code,
+		"user_id":   userID,
+	}).Info("Product unit deleted")
+
+	return nil
+}
+
+func toProductUnitResponse(unit *entities.ProductUnit, language string) *ProductUnitResponse {
+	return &ProductUnitResponse{
+		Code:          unit.Code,
+		Name:          unit.DisplayName(language),
+		Names:         unit.Names,
+		DecimalPlaces: unit.DecimalPlaces,
+		IsBuiltIn:     unit.IsBuiltIn,
+	}
+}
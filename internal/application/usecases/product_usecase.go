@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,6 +11,7 @@ import (
 	"github.com/nicklaros/adol/internal/application/ports"
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/logger"
 	"github.com/nicklaros/adol/pkg/utils"
@@ -17,76 +19,183 @@ import (
 
 // ProductUseCase handles product management operations
 type ProductUseCase struct {
-	productRepo repositories.ProductRepository
-	stockRepo   repositories.StockRepository
-	database    ports.DatabasePort
-	audit       ports.AuditPort
-	logger      logger.Logger
+	productRepo  repositories.ProductRepository
+	stockRepo    repositories.StockRepository
+	marginFloors *MarginFloorUseCase
+	units        *ProductUnitUseCase
+	categories   *CategoryUseCase
+	taxRates     *TaxRateUseCase
+	webhooks     *WebhookUseCase
+	featureUsage *FeatureUsageUseCase
+	database     ports.DatabasePort
+	audit        ports.AuditPort
+	logger       logger.Logger
 }
 
 // NewProductUseCase creates a new product use case
 func NewProductUseCase(
 	productRepo repositories.ProductRepository,
 	stockRepo repositories.StockRepository,
+	marginFloors *MarginFloorUseCase,
+	units *ProductUnitUseCase,
+	categories *CategoryUseCase,
+	taxRates *TaxRateUseCase,
+	webhooks *WebhookUseCase,
+	featureUsage *FeatureUsageUseCase,
 	database ports.DatabasePort,
 	audit ports.AuditPort,
 	logger logger.Logger,
 ) *ProductUseCase {
 	return &ProductUseCase{
-		productRepo: productRepo,
-		stockRepo:   stockRepo,
-		database:    database,
-		audit:       audit,
-		logger:      logger,
+		productRepo:  productRepo,
+		stockRepo:    stockRepo,
+		marginFloors: marginFloors,
+		units:        units,
+		categories:   categories,
+		taxRates:     taxRates,
+		webhooks:     webhooks,
+		featureUsage: featureUsage,
+		database:     database,
+		audit:        audit,
+		logger:       logger,
 	}
 }
 
 // CreateProductRequest represents create product request
 type CreateProductRequest struct {
 	SKU          string          `json:"sku" validate:"required,min=3"`
+	Barcode      string          `json:"barcode,omitempty"`
 	Name         string          `json:"name" validate:"required"`
 	Description  string          `json:"description"`
-	Category     string          `json:"category" validate:"required"`
+	Category     string          `json:"category,omitempty"`    // Category name, for clients not sending category_id
+	CategoryID   *uuid.UUID      `json:"category_id,omitempty"` // Takes precedence over category
+	TaxRateID    *uuid.UUID      `json:"tax_rate_id,omitempty"` // Overrides the category's tax rate
 	Price        decimal.Decimal `json:"price" validate:"required"`
 	Cost         decimal.Decimal `json:"cost" validate:"required"`
+	Currency     string          `json:"currency,omitempty"` // Price and cost currency, defaults to the tenant's
 	Unit         string          `json:"unit" validate:"required"`
 	MinStock     int             `json:"min_stock" validate:"min=0"`
 	InitialStock int             `json:"initial_stock" validate:"min=0"`
+	Draft        bool            `json:"draft,omitempty"`         // Keep the product off POS terminals until it is reviewed
+	IsSerialized bool            `json:"is_serialized,omitempty"` // Capture a serial number for each unit sold
 }
 
 // UpdateProductRequest represents update product request
 type UpdateProductRequest struct {
-	Name        string                  `json:"name,omitempty"`
-	Description string                  `json:"description,omitempty"`
-	Category    string                  `json:"category,omitempty"`
-	Price       *decimal.Decimal        `json:"price,omitempty"`
-	Cost        *decimal.Decimal        `json:"cost,omitempty"`
-	Unit        string                  `json:"unit,omitempty"`
-	MinStock    *int                    `json:"min_stock,omitempty"`
-	Status      *entities.ProductStatus `json:"status,omitempty"`
+	Name              string                      `json:"name,omitempty"`
+	Description       string                      `json:"description,omitempty"`
+	Category          string                      `json:"category,omitempty"`
+	CategoryID        *uuid.UUID                  `json:"category_id,omitempty"`
+	TaxRateID         *uuid.UUID                  `json:"tax_rate_id,omitempty"`
+	ClearTaxRate      bool                        `json:"clear_tax_rate,omitempty"` // Tax the product at its category's rate
+	Price             *decimal.Decimal            `json:"price,omitempty"`
+	Cost              *decimal.Decimal            `json:"cost,omitempty"`
+	Currency          *string                     `json:"currency,omitempty"` // Empty string prices the product in the tenant's currency
+	Unit              string                      `json:"unit,omitempty"`
+	MinStock          *int                        `json:"min_stock,omitempty"`
+	Status            *entities.ProductStatus     `json:"status,omitempty"`
+	Barcode           *string                     `json:"barcode,omitempty"` // Empty string clears the barcode
+	Promotion         *ProductPromotionRequest    `json:"promotion,omitempty"`
+	ClearPromo        bool                        `json:"clear_promotion,omitempty"`
+	Availability      *ProductAvailabilityRequest `json:"availability,omitempty"`
+	ClearAvailability bool                        `json:"clear_availability,omitempty"`
+	IsSerialized      *bool                       `json:"is_serialized,omitempty"`
+}
+
+// ProductPromotionRequest represents a promotional price for a product
+type ProductPromotionRequest struct {
+	PromoPrice decimal.Decimal `json:"promo_price" validate:"required"`
+	StartsAt   *time.Time      `json:"starts_at,omitempty"`
+	EndsAt     *time.Time      `json:"ends_at,omitempty"`
+}
+
+// ProductAvailabilityRequest represents the period a product can be sold in
+type ProductAvailabilityRequest struct {
+	AvailableFrom  *time.Time `json:"available_from,omitempty"`
+	AvailableUntil *time.Time `json:"available_until,omitempty"`
+	IsSeasonal     bool       `json:"is_seasonal,omitempty"` // Repeat the window every year
+}
+
+// ApproveProductRequest represents approve product request
+type ApproveProductRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"` // Publish immediately when empty or in the past
+}
+
+// RejectProductRequest represents reject product request
+type RejectProductRequest struct {
+	Notes string `json:"notes" validate:"required"`
+}
+
+// UpsertCatalogItemRequest represents a catalog item pushed by an external system of record.
+// Fields describe the desired state, so sending the same request again changes nothing.
+type UpsertCatalogItemRequest struct {
+	Name        string                 `json:"name" validate:"required"`
+	Description string                 `json:"description"`
+	Category    string                 `json:"category,omitempty"`    // Category name, created at the top level if not in use yet
+	CategoryID  *uuid.UUID             `json:"category_id,omitempty"` // Takes precedence over category
+	Price       decimal.Decimal        `json:"price" validate:"required"`
+	Cost        decimal.Decimal        `json:"cost" validate:"required"`
+	Unit        string                 `json:"unit" validate:"required"`
+	MinStock    int                    `json:"min_stock" validate:"min=0"`
+	Barcode     *string                `json:"barcode,omitempty"`     // Left untouched when omitted, empty string clears it
+	Status      entities.ProductStatus `json:"status,omitempty"`      // Left untouched when omitted, new items are active
+	StockLevel  *int                   `json:"stock_level,omitempty"` // Available quantity to converge to, left untouched when omitted
+	Reference   string                 `json:"reference,omitempty"`   // Recorded on the stock movement, e.g. the ERP document number
+}
+
+// CatalogItemResponse represents the outcome of a catalog item upsert
+type CatalogItemResponse struct {
+	Product *ProductResponse `json:"product"`
+	Created bool             `json:"created"`
+	Changed bool             `json:"changed"` // False when the item already matched the request
+	Changes []audit.Change   `json:"changes,omitempty"`
 }
 
 // ProductResponse represents product response
 type ProductResponse struct {
-	ID             uuid.UUID              `json:"id"`
-	SKU            string                 `json:"sku"`
-	Name           string                 `json:"name"`
-	Description    string                 `json:"description"`
-	Category       string                 `json:"category"`
-	Price          decimal.Decimal        `json:"price"`
-	Cost           decimal.Decimal        `json:"cost"`
-	Status         entities.ProductStatus `json:"status"`
-	Unit           string                 `json:"unit"`
-	MinStock       int                    `json:"min_stock"`
-	AvailableStock int                    `json:"available_stock,omitempty"`
-	ReservedStock  int                    `json:"reserved_stock,omitempty"`
-	TotalStock     int                    `json:"total_stock,omitempty"`
-	ProfitMargin   decimal.Decimal        `json:"profit_margin"`
-	ProfitAmount   decimal.Decimal        `json:"profit_amount"`
-	StockStatus    string                 `json:"stock_status,omitempty"`
-	CreatedAt      time.Time              `json:"created_at"`
-	UpdatedAt      time.Time              `json:"updated_at"`
-	CreatedBy      uuid.UUID              `json:"created_by"`
+	ID             uuid.UUID                    `json:"id"`
+	SKU            string                       `json:"sku"`
+	Barcode        string                       `json:"barcode,omitempty"`
+	Name           string                       `json:"name"`
+	Description    string                       `json:"description"`
+	Category       string                       `json:"category"`
+	CategoryID     *uuid.UUID                   `json:"category_id,omitempty"`
+	TaxRateID      *uuid.UUID                   `json:"tax_rate_id,omitempty"`
+	Price          decimal.Decimal              `json:"price"`
+	Cost           decimal.Decimal              `json:"cost"`
+	Currency       string                       `json:"currency,omitempty"` // Empty when the tenant's currency
+	Status         entities.ProductStatus       `json:"status"`
+	Unit           string                       `json:"unit"`
+	MinStock       int                          `json:"min_stock"`
+	AvailableStock int                          `json:"available_stock,omitempty"`
+	ReservedStock  int                          `json:"reserved_stock,omitempty"`
+	TotalStock     int                          `json:"total_stock,omitempty"`
+	ProfitMargin   decimal.Decimal              `json:"profit_margin"`
+	ProfitAmount   decimal.Decimal              `json:"profit_amount"`
+	StockStatus    string                       `json:"stock_status,omitempty"`
+	PromoPrice     *decimal.Decimal             `json:"promo_price,omitempty"`
+	PromoStartsAt  *time.Time                   `json:"promo_starts_at,omitempty"`
+	PromoEndsAt    *time.Time                   `json:"promo_ends_at,omitempty"`
+	PublishState   entities.ProductPublishState `json:"publish_state"`
+	PublishAt      *time.Time                   `json:"publish_at,omitempty"`
+	PublishedAt    *time.Time                   `json:"published_at,omitempty"`
+	ReviewNotes    string                       `json:"review_notes,omitempty"`
+	SupplierID     *uuid.UUID                   `json:"supplier_id,omitempty"`
+	AvailableFrom  *time.Time                   `json:"available_from,omitempty"`
+	AvailableUntil *time.Time                   `json:"available_until,omitempty"`
+	IsSeasonal     bool                         `json:"is_seasonal"`
+	IsSerialized   bool                         `json:"is_serialized"`
+	MarginWarnings []*entities.MarginViolation  `json:"margin_warnings,omitempty"` // Prices below the category's margin floor
+	CreatedAt      time.Time                    `json:"created_at"`
+	UpdatedAt      time.Time                    `json:"updated_at"`
+	CreatedBy      uuid.UUID                    `json:"created_by"`
+}
+
+// productOrderColumns lists what product listings and exports may be ordered by. The
+// repository puts order_by into the query as is, so it must never reach it unchecked.
+var productOrderColumns = []string{
+	"name", "sku", "category", "price", "cost", "status", "created_at", "updated_at",
+	repositories.ProductOrderByStockStatus, repositories.ProductOrderByAvailableQty,
 }
 
 // ProductListResponse represents product list response
@@ -96,7 +205,7 @@ type ProductListResponse struct {
 }
 
 // CreateProduct creates a new product with initial stock
-func (uc *ProductUseCase) CreateProduct(ctx context.Context, userID uuid.UUID, req CreateProductRequest) (*ProductResponse, error) {
+func (uc *ProductUseCase) CreateProduct(ctx context.Context, tenantID, userID uuid.UUID, req CreateProductRequest) (*ProductResponse, error) {
 	// Validate SKU format
 	if !utils.IsValidSKU(req.SKU) {
 		return nil, errors.NewValidationError("invalid SKU format", "SKU must contain only alphanumeric characters, hyphens, and underscores")
@@ -120,12 +229,18 @@ func (uc *ProductUseCase) CreateProduct(ctx context.Context, userID uuid.UUID, r
 		return nil, errors.NewConflictError("SKU already exists")
 	}
 
+	category, err := uc.categories.ResolveCategory(ctx, tenantID, userID, req.CategoryID, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create product entity
 	product, err := entities.NewProduct(
+		tenantID,
 		req.SKU,
 		req.Name,
 		req.Description,
-		req.Category,
+		category.Name,
 		req.Unit,
 		req.Price,
 		req.Cost,
@@ -135,9 +250,33 @@ func (uc *ProductUseCase) CreateProduct(ctx context.Context, userID uuid.UUID, r
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.checkUnit(ctx, product); err != nil {
+		return nil, err
+	}
+	if err := product.SetCategory(category); err != nil {
+		return nil, err
+	}
+	if req.TaxRateID != nil {
+		if err := uc.assignTaxRate(ctx, product, req.TaxRateID); err != nil {
+			return nil, err
+		}
+	}
+	if err := product.SetBarcode(req.Barcode); err != nil {
+		return nil, err
+	}
+	if err := product.SetCurrency(req.Currency); err != nil {
+		return nil, err
+	}
+	if req.Draft {
+		product.MarkAsDraft()
+	}
+	product.SetSerialized(req.IsSerialized)
 
 	// Save product
 	if err := tx.GetProductRepository().Create(ctx, product); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
 		uc.logger.WithFields(map[string]interface{}{
 			"sku":   req.SKU,
 			"name":  req.Name,
@@ -180,6 +319,7 @@ func (uc *ProductUseCase) CreateProduct(ctx context.Context, userID uuid.UUID, r
 			"price":         product.Price,
 			"cost":          product.Cost,
 			"initial_stock": req.InitialStock,
+			"publish_state": product.PublishState,
 		},
 		Timestamp: time.Now(),
 		Success:   true,
@@ -198,12 +338,14 @@ func (uc *ProductUseCase) CreateProduct(ctx context.Context, userID uuid.UUID, r
 	response.ReservedStock = stock.ReservedQty
 	response.TotalStock = stock.TotalQty
 	response.StockStatus = stock.GetStockStatus()
+	response.MarginWarnings = uc.marginFloors.CheckProductPrices(ctx, userID, product)
 
 	return response, nil
 }
 
 // GetProduct retrieves a product by ID
 func (uc *ProductUseCase) GetProduct(ctx context.Context, productID uuid.UUID) (*ProductResponse, error) {
+	ctx = uc.database.ReadOnly(ctx)
 	product, err := uc.productRepo.GetByID(ctx, productID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("product")
@@ -222,8 +364,25 @@ func (uc *ProductUseCase) GetProduct(ctx context.Context, productID uuid.UUID) (
 	return response, nil
 }
 
+// GetProductsByIDs retrieves the products with the given IDs in one query, keyed by product ID.
+// Stock is not included; it is loaded separately by the callers that need it.
+func (uc *ProductUseCase) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]*ProductResponse, error) {
+	products, err := uc.productRepo.GetByIDs(uc.database.ReadOnly(ctx), productIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get products")
+		return nil, errors.NewInternalError("failed to get products", err)
+	}
+
+	responses := make(map[uuid.UUID]*ProductResponse, len(products))
+	for id, product := range products {
+		responses[id] = uc.toProductResponse(product)
+	}
+	return responses, nil
+}
+
 // GetProductBySKU retrieves a product by SKU
 func (uc *ProductUseCase) GetProductBySKU(ctx context.Context, sku string) (*ProductResponse, error) {
+	ctx = uc.database.ReadOnly(ctx)
 	product, err := uc.productRepo.GetBySKU(ctx, sku)
 	if err != nil {
 		return nil, errors.NewNotFoundError("product")
@@ -244,26 +403,21 @@ func (uc *ProductUseCase) GetProductBySKU(ctx context.Context, sku string) (*Pro
 
 // UpdateProduct updates an existing product
 func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID uuid.UUID, req UpdateProductRequest) (*ProductResponse, error) {
+	if req.TaxRateID != nil && req.ClearTaxRate {
+		return nil, errors.NewValidationError("invalid tax rate", "tax_rate_id and clear_tax_rate cannot both be set")
+	}
+
 	// Get existing product
 	product, err := uc.productRepo.GetByID(ctx, productID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("product")
 	}
 
-	// Store old values for audit log
-	oldValue := map[string]interface{}{
-		"name":        product.Name,
-		"description": product.Description,
-		"category":    product.Category,
-		"price":       product.Price,
-		"cost":        product.Cost,
-		"unit":        product.Unit,
-		"min_stock":   product.MinStock,
-		"status":      product.Status,
-	}
+	// Snapshot current state for audit log
+	before := audit.TakeSnapshot(product)
 
 	// Update product fields
-	if req.Name != "" || req.Description != "" || req.Category != "" || req.Unit != "" || req.Price != nil || req.Cost != nil || req.MinStock != nil {
+	if req.Name != "" || req.Description != "" || req.Category != "" || req.CategoryID != nil || req.Unit != "" || req.Price != nil || req.Cost != nil || req.MinStock != nil {
 		name := product.Name
 		description := product.Description
 		category := product.Category
@@ -278,8 +432,12 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 		if req.Description != "" {
 			description = req.Description
 		}
-		if req.Category != "" {
-			category = req.Category
+		var newCategory *entities.Category
+		if req.Category != "" || req.CategoryID != nil {
+			if newCategory, err = uc.categories.ResolveCategory(ctx, product.TenantID, userID, req.CategoryID, req.Category); err != nil {
+				return nil, err
+			}
+			category = newCategory.Name
 		}
 		if req.Unit != "" {
 			unit = req.Unit
@@ -297,6 +455,16 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 		if err := product.UpdateProduct(name, description, category, unit, price, cost, minStock); err != nil {
 			return nil, err
 		}
+		if newCategory != nil {
+			if err := product.SetCategory(newCategory); err != nil {
+				return nil, err
+			}
+		}
+		if req.Unit != "" {
+			if err := uc.checkUnit(ctx, product); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Update status if provided
@@ -306,8 +474,56 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 		}
 	}
 
+	// Update tax rate if provided
+	if req.TaxRateID != nil || req.ClearTaxRate {
+		if err := uc.assignTaxRate(ctx, product, req.TaxRateID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update barcode if provided
+	if req.Barcode != nil {
+		if err := product.SetBarcode(*req.Barcode); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update currency if provided
+	if req.Currency != nil {
+		if err := product.SetCurrency(*req.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update promotion if provided
+	if req.ClearPromo {
+		product.ClearPromotion()
+	} else if req.Promotion != nil {
+		if err := product.SetPromotion(req.Promotion.PromoPrice, req.Promotion.StartsAt, req.Promotion.EndsAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update availability window if provided
+	if req.ClearAvailability {
+		if err := product.SetAvailability(nil, nil, false); err != nil {
+			return nil, err
+		}
+	} else if req.Availability != nil {
+		if err := product.SetAvailability(req.Availability.AvailableFrom, req.Availability.AvailableUntil, req.Availability.IsSeasonal); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.IsSerialized != nil {
+		product.SetSerialized(*req.IsSerialized)
+	}
+
 	// Save product
 	if err := uc.productRepo.Update(ctx, product); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
 		uc.logger.WithFields(map[string]interface{}{
 			"product_id": productID,
 			"error":      err.Error(),
@@ -315,18 +531,6 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 		return nil, errors.NewInternalError("failed to update product", err)
 	}
 
-	// New values for audit log
-	newValue := map[string]interface{}{
-		"name":        product.Name,
-		"description": product.Description,
-		"category":    product.Category,
-		"price":       product.Price,
-		"cost":        product.Cost,
-		"unit":        product.Unit,
-		"min_stock":   product.MinStock,
-		"status":      product.Status,
-	}
-
 	// Audit log
 	auditEvent := ports.AuditEvent{
 		ID:         uuid.New(),
@@ -334,8 +538,7 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 		Action:     "update",
 		Resource:   "product",
 		ResourceID: productID.String(),
-		OldValue:   oldValue,
-		NewValue:   newValue,
+		Changes:    audit.Diff(before, audit.TakeSnapshot(product)),
 		Timestamp:  time.Now(),
 		Success:    true,
 	}
@@ -346,7 +549,15 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 		"user_id":    userID,
 	}).Info("Product updated successfully")
 
+	uc.webhooks.Publish(ctx, product.TenantID, entities.WebhookEventProductUpdated, uc.toProductResponse(product))
+	if req.Promotion != nil && !req.ClearPromo {
+		uc.featureUsage.Track(ctx, product.TenantID, entities.FeaturePromotions)
+	}
+
 	response := uc.toProductResponse(product)
+	if req.Price != nil || req.Cost != nil || req.Category != "" || req.CategoryID != nil || req.Promotion != nil {
+		response.MarginWarnings = uc.marginFloors.CheckProductPrices(ctx, userID, product)
+	}
 
 	// Get stock information
 	if stock, err := uc.stockRepo.GetByProductID(ctx, productID); err == nil {
@@ -359,6 +570,280 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, userID, productID u
 	return response, nil
 }
 
+// UpsertCatalogItem creates or updates the product with the given SKU together with its price
+// and stock level in one transaction. Stock converges to the requested level through a single
+// adjustment movement, and a catalog_item.upserted webhook is published only when something
+// actually changed.
+func (uc *ProductUseCase) UpsertCatalogItem(ctx context.Context, tenantID, userID uuid.UUID, sku string, req UpsertCatalogItemRequest) (*CatalogItemResponse, error) {
+	if !utils.IsValidSKU(sku) {
+		return nil, errors.NewValidationError("invalid SKU format", "SKU must contain only alphanumeric characters, hyphens, and underscores")
+	}
+	if req.Status != "" {
+		if err := entities.ValidateProductStatus(req.Status); err != nil {
+			return nil, err
+		}
+	}
+	if req.StockLevel != nil && *req.StockLevel < 0 {
+		return nil, errors.NewValidationError("invalid stock level", "stock level cannot be negative")
+	}
+	if _, err := uc.units.ResolveUnit(ctx, tenantID, req.Unit); err != nil {
+		return nil, err
+	}
+	category, err := uc.categories.ResolveCategory(ctx, tenantID, userID, req.CategoryID, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// SKUs are unique within a tenant only, so look the product up in the caller's tenant
+	product, err := tx.GetProductRepository().GetByTenantAndSKU(ctx, tenantID, sku)
+	exists := err == nil
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			uc.logger.WithField("error", err.Error()).Error("Failed to check SKU existence")
+			return nil, errors.NewInternalError("failed to check SKU", err)
+		}
+	}
+
+	var (
+		stock   *entities.Stock
+		changes []audit.Change
+	)
+
+	if !exists {
+		product, err = entities.NewProduct(tenantID, sku, req.Name, req.Description, category.Name, req.Unit, req.Price, req.Cost, req.MinStock, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.applyCatalogItem(product, category, req); err != nil {
+			return nil, err
+		}
+
+		initialStock := 0
+		if req.StockLevel != nil {
+			initialStock = *req.StockLevel
+		}
+		stock, err = entities.NewStock(product.ID, initialStock, req.MinStock)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.GetProductRepository().Create(ctx, product); err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return nil, appErr
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"sku":   sku,
+				"error": err.Error(),
+			}).Error("Failed to create product")
+			return nil, errors.NewInternalError("failed to create product", err)
+		}
+		if err := tx.GetStockRepository().Create(ctx, stock); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": product.ID,
+				"error":      err.Error(),
+			}).Error("Failed to create initial stock")
+			return nil, errors.NewInternalError("failed to create initial stock", err)
+		}
+	} else {
+		stock, err = tx.GetStockRepository().GetByProductIDForUpdate(ctx, product.ID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record")
+		}
+
+		before := audit.TakeSnapshot(product)
+		if err := product.UpdateProduct(req.Name, req.Description, category.Name, req.Unit, req.Price, req.Cost, req.MinStock); err != nil {
+			return nil, err
+		}
+		if err := uc.applyCatalogItem(product, category, req); err != nil {
+			return nil, err
+		}
+		changes = audit.Diff(before, audit.TakeSnapshot(product))
+
+		if len(changes) > 0 {
+			if err := tx.GetProductRepository().Update(ctx, product); err != nil {
+				if appErr, ok := errors.IsAppError(err); ok {
+					return nil, appErr
+				}
+				uc.logger.WithFields(map[string]interface{}{
+					"product_id": product.ID,
+					"error":      err.Error(),
+				}).Error("Failed to update product")
+				return nil, errors.NewInternalError("failed to update product", err)
+			}
+		}
+
+		stockChanges, err := uc.convergeStock(ctx, tx, userID, stock, req)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, stockChanges...)
+	}
+
+	response := &CatalogItemResponse{
+		Created: !exists,
+		Changed: !exists || len(changes) > 0,
+		Changes: changes,
+	}
+
+	if response.Changed {
+		// Commit transaction
+		if err := tx.Commit(); err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+			return nil, errors.NewInternalError("failed to commit transaction", err)
+		}
+
+		action := "upsert_update"
+		if response.Created {
+			action = "upsert_create"
+		}
+		auditEvent := ports.AuditEvent{
+			ID:         uuid.New(),
+			UserID:     userID,
+			Action:     action,
+			Resource:   "product",
+			ResourceID: product.ID.String(),
+			Changes:    changes,
+			NewValue: map[string]interface{}{
+				"sku":           product.SKU,
+				"price":         product.Price,
+				"cost":          product.Cost,
+				"available_qty": stock.AvailableQty,
+			},
+			Timestamp: time.Now(),
+			Success:   true,
+		}
+		uc.audit.Log(ctx, auditEvent)
+
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": product.ID,
+			"sku":        product.SKU,
+			"created":    response.Created,
+			"changes":    len(changes),
+			"user_id":    userID,
+		}).Info("Catalog item upserted successfully")
+	}
+
+	response.Product = uc.toProductResponse(product)
+	response.Product.AvailableStock = stock.AvailableQty
+	response.Product.ReservedStock = stock.ReservedQty
+	response.Product.TotalStock = stock.TotalQty
+	response.Product.StockStatus = stock.GetStockStatus()
+
+	if response.Changed {
+		response.Product.MarginWarnings = uc.marginFloors.CheckProductPrices(ctx, userID, product)
+		uc.webhooks.Publish(ctx, tenantID, entities.WebhookEventCatalogItemUpserted, response)
+	}
+
+	return response, nil
+}
+
+// checkUnit checks the product's unit is in the tenant's unit catalog
+func (uc *ProductUseCase) checkUnit(ctx context.Context, product *entities.Product) error {
+	_, err := uc.units.ResolveUnit(ctx, product.TenantID, product.Unit)
+	return err
+}
+
+// assignTaxRate assigns the tax rate with the ID to the product, or clears it when the ID is nil
+func (uc *ProductUseCase) assignTaxRate(ctx context.Context, product *entities.Product, taxRateID *uuid.UUID) error {
+	var taxRate *entities.TaxRate
+	if taxRateID != nil {
+		var err error
+		taxRate, err = uc.taxRates.ResolveTaxRate(ctx, product.TenantID, *taxRateID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return product.SetTaxRate(taxRate)
+}
+
+// applyCatalogItem files the product under the resolved category and applies the optional
+// barcode and status of an upsert request
+func (uc *ProductUseCase) applyCatalogItem(product *entities.Product, category *entities.Category, req UpsertCatalogItemRequest) error {
+	if product.CategoryID == nil || *product.CategoryID != category.ID {
+		if err := product.SetCategory(category); err != nil {
+			return err
+		}
+	}
+	if req.Barcode != nil && *req.Barcode != product.Barcode {
+		if err := product.SetBarcode(*req.Barcode); err != nil {
+			return err
+		}
+	}
+	if req.Status != "" && req.Status != product.Status {
+		if err := product.ChangeStatus(req.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convergeStock moves available stock to the requested level with a single adjustment
+// movement and keeps the reorder level in line with the product's minimum stock
+func (uc *ProductUseCase) convergeStock(ctx context.Context, tx ports.TransactionPort, userID uuid.UUID, stock *entities.Stock, req UpsertCatalogItemRequest) ([]audit.Change, error) {
+	var changes []audit.Change
+
+	if stock.ReorderLevel != req.MinStock {
+		changes = append(changes, audit.Change{Field: "stock.reorder_level", OldValue: stock.ReorderLevel, NewValue: req.MinStock})
+		if err := stock.UpdateReorderLevel(req.MinStock); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.StockLevel != nil && *req.StockLevel != stock.AvailableQty {
+		oldQty := stock.AvailableQty
+		delta := *req.StockLevel - oldQty
+
+		movementType := entities.StockMovementTypeIn
+		if delta > 0 {
+			if err := stock.AddStock(delta, entities.ReasonAdjustment); err != nil {
+				return nil, err
+			}
+		} else {
+			delta = -delta
+			movementType = entities.StockMovementTypeOut
+			if err := stock.RemoveStock(delta); err != nil {
+				return nil, err
+			}
+		}
+
+		movement, err := entities.NewStockMovement(stock.ProductID, movementType, entities.ReasonAdjustment, delta, req.Reference, "Catalog upsert", userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": stock.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create stock movement")
+			return nil, errors.NewInternalError("failed to create stock movement", err)
+		}
+		changes = append(changes, audit.Change{Field: "stock.available_qty", OldValue: oldQty, NewValue: stock.AvailableQty})
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": stock.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to update stock")
+		return nil, errors.NewInternalError("failed to update stock", err)
+	}
+
+	return changes, nil
+}
+
 // DeleteProduct deletes a product (soft delete)
 func (uc *ProductUseCase) DeleteProduct(ctx context.Context, userID, productID uuid.UUID) error {
 	// Get product to ensure it exists
@@ -412,18 +897,22 @@ func (uc *ProductUseCase) DeleteProduct(ctx context.Context, userID, productID u
 
 // ListProducts retrieves products with pagination and filtering
 func (uc *ProductUseCase) ListProducts(ctx context.Context, filter repositories.ProductFilter, pagination utils.PaginationInfo) (*ProductListResponse, error) {
-	products, paginationResult, err := uc.productRepo.List(ctx, filter, pagination)
+	if err := validateListOrder("products", productOrderColumns, filter.OrderBy, filter.OrderDir); err != nil {
+		return nil, err
+	}
+
+	// Stock is listed along with the products, which also lets them be ordered by stock status
+	items, paginationResult, err := uc.productRepo.ListWithStock(uc.database.ReadOnly(ctx), filter, pagination)
 	if err != nil {
 		uc.logger.WithField("error", err.Error()).Error("Failed to list products")
 		return nil, errors.NewInternalError("failed to list products", err)
 	}
 
-	productResponses := make([]*ProductResponse, len(products))
-	for i, product := range products {
-		response := uc.toProductResponse(product)
+	productResponses := make([]*ProductResponse, len(items))
+	for i, item := range items {
+		response := uc.toProductResponse(item.Product)
 
-		// Get stock information for each product
-		if stock, err := uc.stockRepo.GetByProductID(ctx, product.ID); err == nil {
+		if stock := item.Stock; stock != nil {
 			response.AvailableStock = stock.AvailableQty
 			response.ReservedStock = stock.ReservedQty
 			response.TotalStock = stock.TotalQty
@@ -508,23 +997,261 @@ func (uc *ProductUseCase) GetLowStockProducts(ctx context.Context, pagination ut
 	}, nil
 }
 
+// SubmitProductForReview submits a draft product for review before it is published
+func (uc *ProductUseCase) SubmitProductForReview(ctx context.Context, tenantID, userID, productID uuid.UUID) (*ProductResponse, error) {
+	product, err := uc.getTenantProduct(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(product)
+	if err := product.SubmitForReview(userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.savePublishState(ctx, userID, product, "submit_for_review", before); err != nil {
+		return nil, err
+	}
+
+	return uc.toProductResponse(product), nil
+}
+
+// ApproveProduct approves a product under review, publishing it now or at the requested time
+func (uc *ProductUseCase) ApproveProduct(ctx context.Context, tenantID, userID, productID uuid.UUID, req ApproveProductRequest) (*ProductResponse, error) {
+	product, err := uc.getTenantProduct(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(product)
+	if err := product.Approve(userID, req.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.savePublishState(ctx, userID, product, "approve", before); err != nil {
+		return nil, err
+	}
+
+	return uc.toProductResponse(product), nil
+}
+
+// RejectProduct sends a product under review back to draft
+func (uc *ProductUseCase) RejectProduct(ctx context.Context, tenantID, userID, productID uuid.UUID, req RejectProductRequest) (*ProductResponse, error) {
+	product, err := uc.getTenantProduct(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(product)
+	if err := product.Reject(userID, req.Notes); err != nil {
+		return nil, err
+	}
+
+	if err := uc.savePublishState(ctx, userID, product, "reject", before); err != nil {
+		return nil, err
+	}
+
+	return uc.toProductResponse(product), nil
+}
+
+// UnpublishProduct moves a product back to draft, hiding it from POS terminals
+func (uc *ProductUseCase) UnpublishProduct(ctx context.Context, tenantID, userID, productID uuid.UUID) (*ProductResponse, error) {
+	product, err := uc.getTenantProduct(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(product)
+	product.MarkAsDraft()
+
+	if err := uc.savePublishState(ctx, userID, product, "unpublish", before); err != nil {
+		return nil, err
+	}
+
+	return uc.toProductResponse(product), nil
+}
+
+// ListReviewQueue retrieves products waiting for review
+func (uc *ProductUseCase) ListReviewQueue(ctx context.Context, pagination utils.PaginationInfo) (*ProductListResponse, error) {
+	state := entities.ProductPublishStateInReview
+	filter := repositories.ProductFilter{
+		PublishState: &state,
+		OrderBy:      "updated_at",
+		OrderDir:     "ASC",
+	}
+
+	return uc.ListProducts(ctx, filter, pagination)
+}
+
+// PublishDueProducts publishes approved products whose scheduled publish time has passed.
+// It is run periodically by the scheduler.
+func (uc *ProductUseCase) PublishDueProducts(ctx context.Context) error {
+	now := time.Now()
+	products, err := uc.productRepo.GetDueForPublishing(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get products due for publishing: %w", err)
+	}
+
+	for _, product := range products {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !product.PublishIfDue(now) {
+			continue
+		}
+
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": product.ID,
+				"tenant_id":  product.TenantID,
+				"error":      err.Error(),
+			}).Error("Failed to publish scheduled product")
+			continue
+		}
+
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": product.ID,
+			"tenant_id":  product.TenantID,
+		}).Info("Scheduled product published")
+	}
+
+	return nil
+}
+
+// ApplyProductAvailability deactivates products whose availability window has closed and
+// reactivates seasonal products whose window has opened again. It is run periodically by the
+// scheduler.
+func (uc *ProductUseCase) ApplyProductAvailability(ctx context.Context) error {
+	now := time.Now()
+	products, err := uc.productRepo.GetWithAvailabilityWindow(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get products with availability window: %w", err)
+	}
+
+	for _, product := range products {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		previousStatus := product.Status
+		if !product.ApplyAvailability(now) {
+			continue
+		}
+
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": product.ID,
+				"tenant_id":  product.TenantID,
+				"error":      err.Error(),
+			}).Error("Failed to apply product availability")
+			continue
+		}
+
+		// Audit log
+		auditEvent := ports.AuditEvent{
+			ID:         uuid.New(),
+			Action:     "apply_availability",
+			Resource:   "product",
+			ResourceID: product.ID.String(),
+			OldValue:   map[string]interface{}{"status": previousStatus},
+			NewValue:   map[string]interface{}{"status": product.Status},
+			Timestamp:  now,
+			Success:    true,
+		}
+		uc.audit.Log(ctx, auditEvent)
+
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": product.ID,
+			"tenant_id":  product.TenantID,
+			"status":     product.Status,
+		}).Info("Product status changed by availability window")
+	}
+
+	return nil
+}
+
+// getTenantProduct retrieves a product and ensures it belongs to the tenant
+func (uc *ProductUseCase) getTenantProduct(ctx context.Context, tenantID, productID uuid.UUID) (*entities.Product, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("product")
+	}
+	if product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	return product, nil
+}
+
+// savePublishState persists a publishing workflow transition and records it in the audit log
+func (uc *ProductUseCase) savePublishState(ctx context.Context, userID uuid.UUID, product *entities.Product, action string, before audit.Snapshot) error {
+	if err := uc.productRepo.Update(ctx, product); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": product.ID,
+			"action":     action,
+			"error":      err.Error(),
+		}).Error("Failed to update product publish state")
+		return errors.NewInternalError("failed to update product publish state", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "product",
+		ResourceID: product.ID.String(),
+		Changes:    audit.Diff(before, audit.TakeSnapshot(product)),
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"product_id":    product.ID,
+		"publish_state": product.PublishState,
+		"user_id":       userID,
+	}).Info("Product publish state updated")
+
+	return nil
+}
+
 // toProductResponse converts product entity to response
 func (uc *ProductUseCase) toProductResponse(product *entities.Product) *ProductResponse {
 	return &ProductResponse{
-		ID:           product.ID,
-		SKU:          product.SKU,
-		Name:         product.Name,
-		Description:  product.Description,
-		Category:     product.Category,
-		Price:        product.Price,
-		Cost:         product.Cost,
-		Status:       product.Status,
-		Unit:         product.Unit,
-		MinStock:     product.MinStock,
-		ProfitMargin: product.GetProfitMargin(),
-		ProfitAmount: product.GetProfitAmount(),
-		CreatedAt:    product.CreatedAt,
-		UpdatedAt:    product.UpdatedAt,
-		CreatedBy:    product.CreatedBy,
+		ID:             product.ID,
+		SKU:            product.SKU,
+		Barcode:        product.Barcode,
+		Name:           product.Name,
+		Description:    product.Description,
+		Category:       product.Category,
+		CategoryID:     product.CategoryID,
+		TaxRateID:      product.TaxRateID,
+		Price:          product.Price,
+		Cost:           product.Cost,
+		Currency:       product.Currency,
+		Status:         product.Status,
+		Unit:           product.Unit,
+		MinStock:       product.MinStock,
+		ProfitMargin:   product.GetProfitMargin(),
+		ProfitAmount:   product.GetProfitAmount(),
+		PromoPrice:     product.PromoPrice,
+		PromoStartsAt:  product.PromoStartsAt,
+		PromoEndsAt:    product.PromoEndsAt,
+		PublishState:   product.PublishState,
+		PublishAt:      product.PublishAt,
+		PublishedAt:    product.PublishedAt,
+		ReviewNotes:    product.ReviewNotes,
+		SupplierID:     product.SupplierID,
+		AvailableFrom:  product.AvailableFrom,
+		AvailableUntil: product.AvailableUntil,
+		IsSeasonal:     product.IsSeasonal,
+		IsSerialized:   product.IsSerialized,
+		CreatedAt:      product.CreatedAt,
+		UpdatedAt:      product.UpdatedAt,
+		CreatedBy:      product.CreatedBy,
 	}
 }
@@ -0,0 +1,1040 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const (
+	// defaultReorderSalesDays is how many days of sales the daily velocity is averaged over
+	defaultReorderSalesDays = 30
+	// maxReorderSalesDays caps the sales window of reorder suggestions
+	maxReorderSalesDays = 365
+	// defaultReorderCoverDays is how many days after delivery a reorder should last
+	defaultReorderCoverDays = 14
+	// maxReorderCoverDays caps the cover days of reorder suggestions
+	maxReorderCoverDays = 180
+	// reorderSuggestionLimit caps the number of low stock products considered for reordering
+	reorderSuggestionLimit = 500
+)
+
+// PurchaseOrderUseCase handles ordering stock from suppliers and receiving deliveries, either
+// in one go or by scanning barcodes over one or more receiving sessions
+type PurchaseOrderUseCase struct {
+	purchaseOrderRepo repositories.PurchaseOrderRepository
+	receivingRepo     repositories.PurchaseOrderReceivingRepository
+	productRepo       repositories.ProductRepository
+	stockRepo         repositories.StockRepository
+	saleItemRepo      repositories.SaleItemRepository
+	database          ports.DatabasePort
+	audit             ports.AuditPort
+	logger            logger.Logger
+}
+
+// NewPurchaseOrderUseCase creates a new purchase order use case
+func NewPurchaseOrderUseCase(
+	purchaseOrderRepo repositories.PurchaseOrderRepository,
+	receivingRepo repositories.PurchaseOrderReceivingRepository,
+	productRepo repositories.ProductRepository,
+	stockRepo repositories.StockRepository,
+	saleItemRepo repositories.SaleItemRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *PurchaseOrderUseCase {
+	return &PurchaseOrderUseCase{
+		purchaseOrderRepo: purchaseOrderRepo,
+		receivingRepo:     receivingRepo,
+		productRepo:       productRepo,
+		stockRepo:         stockRepo,
+		saleItemRepo:      saleItemRepo,
+		database:          database,
+		audit:             audit,
+		logger:            logger,
+	}
+}
+
+// PurchaseOrderItemRequest represents a product line in a purchase order request
+type PurchaseOrderItemRequest struct {
+	ProductID uuid.UUID        `json:"product_id" validate:"required"`
+	Quantity  int              `json:"quantity" validate:"required,min=1"`
+	UnitCost  *decimal.Decimal `json:"unit_cost,omitempty"` // Defaults to the product cost
+}
+
+// CreatePurchaseOrderRequest represents create purchase order request
+type CreatePurchaseOrderRequest struct {
+	SupplierName  string                     `json:"supplier_name" validate:"required"`
+	SupplierEmail string                     `json:"supplier_email,omitempty"`
+	SupplierPhone string                     `json:"supplier_phone,omitempty"`
+	Items         []PurchaseOrderItemRequest `json:"items,omitempty"`
+	ExpectedAt    *time.Time                 `json:"expected_at,omitempty"`
+	Notes         string                     `json:"notes,omitempty"`
+}
+
+// UpdatePurchaseOrderRequest represents update purchase order request. The items replace
+// the existing items of the draft.
+type UpdatePurchaseOrderRequest struct {
+	SupplierName  string                     `json:"supplier_name" validate:"required"`
+	SupplierEmail string                     `json:"supplier_email,omitempty"`
+	SupplierPhone string                     `json:"supplier_phone,omitempty"`
+	Items         []PurchaseOrderItemRequest `json:"items,omitempty"`
+	ExpectedAt    *time.Time                 `json:"expected_at,omitempty"`
+	Notes         string                     `json:"notes,omitempty"`
+}
+
+// ReceivePurchaseOrderRequest represents receive purchase order request. Without items,
+// everything still outstanding is received.
+type ReceivePurchaseOrderRequest struct {
+	Items []entities.PurchaseOrderReceiptLine `json:"items,omitempty"`
+	Notes string                              `json:"notes,omitempty"`
+}
+
+// StartPurchaseOrderReceivingRequest represents start receiving session request
+type StartPurchaseOrderReceivingRequest struct {
+	Notes string `json:"notes,omitempty" validate:"max=500"`
+}
+
+// SubmitPurchaseOrderScansRequest represents a batch of barcodes scanned at a receiving station
+type SubmitPurchaseOrderScansRequest struct {
+	Scans []entities.PurchaseOrderReceivingScan `json:"scans" validate:"required,min=1,max=500,dive"`
+}
+
+// PurchaseOrderScanBatchResponse represents the outcome of a batch of scans, one result per
+// scan in the order submitted
+type PurchaseOrderScanBatchResponse struct {
+	ReceivingID  uuid.UUID                          `json:"receiving_id"`
+	Results      []entities.PurchaseOrderScanResult `json:"results"`
+	Recorded     int                                `json:"recorded"`      // Scans recorded, over-received ones included
+	OverReceived int                                `json:"over_received"` // Scans taking their item beyond its outstanding quantity
+	Rejected     int                                `json:"rejected"`      // Unknown barcodes, products not ordered and invalid scans
+}
+
+// CompletePurchaseOrderReceivingResponse represents a completed receiving session with the
+// purchase order it received
+type CompletePurchaseOrderReceivingResponse struct {
+	Receiving     *entities.PurchaseOrderReceiving `json:"receiving"`
+	PurchaseOrder *entities.PurchaseOrder          `json:"purchase_order"`
+}
+
+// ReorderSuggestionRequest represents the parameters of reorder suggestions
+type ReorderSuggestionRequest struct {
+	SalesDays  int        `json:"sales_days,omitempty"` // Days of sales to average; defaults to 30
+	CoverDays  int        `json:"cover_days,omitempty"` // Days a delivery should last; defaults to 14
+	SupplierID *uuid.UUID `json:"supplier_id,omitempty"`
+}
+
+// CreateReorderDraftsRequest represents create reorder drafts request. Without product IDs,
+// every product with a suggested quantity and a preferred supplier is ordered.
+type CreateReorderDraftsRequest struct {
+	ReorderSuggestionRequest
+	ProductIDs []uuid.UUID `json:"product_ids,omitempty"`
+}
+
+// ReorderSuggestionsResponse represents reorder suggestions for low stock products
+type ReorderSuggestionsResponse struct {
+	SalesDays   int                           `json:"sales_days"`
+	CoverDays   int                           `json:"cover_days"`
+	Suggestions []*entities.ReorderSuggestion `json:"suggestions"`
+}
+
+// ReorderDraftsResponse represents the draft purchase orders created from reorder suggestions,
+// and the suggestions left out because their product has no preferred supplier
+type ReorderDraftsResponse struct {
+	PurchaseOrders []*entities.PurchaseOrder     `json:"purchase_orders"`
+	Unassigned     []*entities.ReorderSuggestion `json:"unassigned"`
+}
+
+// PurchaseOrderListResponse represents purchase order list response
+type PurchaseOrderListResponse struct {
+	PurchaseOrders []*entities.PurchaseOrder `json:"purchase_orders"`
+	Pagination     utils.PaginationInfo      `json:"pagination"`
+}
+
+// CreatePurchaseOrder creates a new draft purchase order
+func (uc *PurchaseOrderUseCase) CreatePurchaseOrder(ctx context.Context, tenantID, userID uuid.UUID, req CreatePurchaseOrderRequest) (*entities.PurchaseOrder, error) {
+	order, err := entities.NewPurchaseOrder(tenantID, utils.GeneratePurchaseOrderNumber(), req.SupplierName, req.SupplierEmail, req.SupplierPhone, userID)
+	if err != nil {
+		return nil, err
+	}
+	order.ExpectedAt = req.ExpectedAt
+	order.Notes = req.Notes
+
+	if err := uc.addItems(ctx, order, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.Create(ctx, order); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"po_number": order.PONumber,
+			"error":     err.Error(),
+		}).Error("Failed to create purchase order")
+		return nil, errors.NewInternalError("failed to create purchase order", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "purchase_order",
+		ResourceID: order.ID.String(),
+		NewValue: map[string]interface{}{
+			"po_number":     order.PONumber,
+			"supplier_name": order.SupplierName,
+			"items":         order.Items,
+			"total_cost":    order.TotalCost,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"purchase_order_id": order.ID,
+		"po_number":         order.PONumber,
+		"user_id":           userID,
+	}).Info("Purchase order created successfully")
+
+	return order, nil
+}
+
+// GetPurchaseOrder retrieves a purchase order by ID
+func (uc *PurchaseOrderUseCase) GetPurchaseOrder(ctx context.Context, tenantID, orderID uuid.UUID) (*entities.PurchaseOrder, error) {
+	order, err := uc.purchaseOrderRepo.GetByID(ctx, orderID)
+	if err != nil || order.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("purchase order")
+	}
+
+	return order, nil
+}
+
+// ListPurchaseOrders retrieves purchase orders with pagination and filtering
+func (uc *PurchaseOrderUseCase) ListPurchaseOrders(ctx context.Context, filter repositories.PurchaseOrderFilter, pagination utils.PaginationInfo) (*PurchaseOrderListResponse, error) {
+	orders, paginationResult, err := uc.purchaseOrderRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list purchase orders")
+		return nil, errors.NewInternalError("failed to list purchase orders", err)
+	}
+
+	if orders == nil {
+		orders = []*entities.PurchaseOrder{}
+	}
+
+	return &PurchaseOrderListResponse{
+		PurchaseOrders: orders,
+		Pagination:     paginationResult,
+	}, nil
+}
+
+// UpdatePurchaseOrder updates the supplier details and items of a draft purchase order
+func (uc *PurchaseOrderUseCase) UpdatePurchaseOrder(ctx context.Context, tenantID, userID, orderID uuid.UUID, req UpdatePurchaseOrderRequest) (*entities.PurchaseOrder, error) {
+	order, err := uc.GetPurchaseOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldTotal := order.TotalCost
+
+	if err := order.UpdateSupplier(req.SupplierName, req.SupplierEmail, req.SupplierPhone); err != nil {
+		return nil, err
+	}
+	if err := order.ClearItems(); err != nil {
+		return nil, err
+	}
+	if err := uc.addItems(ctx, order, req.Items); err != nil {
+		return nil, err
+	}
+	order.ExpectedAt = req.ExpectedAt
+	order.Notes = req.Notes
+
+	if err := uc.purchaseOrderRepo.Update(ctx, order); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"purchase_order_id": orderID,
+			"error":             err.Error(),
+		}).Error("Failed to update purchase order")
+		return nil, errors.NewInternalError("failed to update purchase order", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "purchase_order",
+		ResourceID: order.ID.String(),
+		OldValue: map[string]interface{}{
+			"total_cost": oldTotal,
+		},
+		NewValue: map[string]interface{}{
+			"supplier_name": order.SupplierName,
+			"items":         order.Items,
+			"total_cost":    order.TotalCost,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return order, nil
+}
+
+// OrderPurchaseOrder marks a draft purchase order as sent to the supplier
+func (uc *PurchaseOrderUseCase) OrderPurchaseOrder(ctx context.Context, tenantID, userID, orderID uuid.UUID) (*entities.PurchaseOrder, error) {
+	return uc.changeStatus(ctx, tenantID, userID, orderID, "order", (*entities.PurchaseOrder).MarkOrdered)
+}
+
+// CancelPurchaseOrder cancels a purchase order that has not received any stock
+func (uc *PurchaseOrderUseCase) CancelPurchaseOrder(ctx context.Context, tenantID, userID, orderID uuid.UUID) (*entities.PurchaseOrder, error) {
+	return uc.changeStatus(ctx, tenantID, userID, orderID, "cancel", (*entities.PurchaseOrder).Cancel)
+}
+
+// ReceivePurchaseOrder records a delivery against an ordered purchase order and adds the
+// delivered quantities to stock. Each product received gets a purchase stock movement
+// referencing the PO number.
+func (uc *PurchaseOrderUseCase) ReceivePurchaseOrder(ctx context.Context, tenantID, userID, orderID uuid.UUID, req ReceivePurchaseOrderRequest) (*entities.PurchaseOrder, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the order so the same delivery cannot be received twice at once
+	order, err := tx.GetPurchaseOrderRepository().GetByIDForUpdate(ctx, orderID)
+	if err != nil || order.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("purchase order")
+	}
+
+	received, err := order.Receive(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := "Received from " + order.SupplierName
+	if req.Notes != "" {
+		notes += ": " + req.Notes
+	}
+	if err := uc.addReceivedStock(ctx, tx, order, received, notes, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetPurchaseOrderRepository().Update(ctx, order); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"purchase_order_id": orderID,
+			"error":             err.Error(),
+		}).Error("Failed to update purchase order")
+		return nil, errors.NewInternalError("failed to update purchase order", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "receive",
+		Resource:   "purchase_order",
+		ResourceID: order.ID.String(),
+		NewValue: map[string]interface{}{
+			"po_number": order.PONumber,
+			"received":  received,
+			"status":    order.Status,
+			"notes":     req.Notes,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"purchase_order_id": order.ID,
+		"po_number":         order.PONumber,
+		"lines":             len(received),
+		"status":            order.Status,
+		"user_id":           userID,
+	}).Info("Purchase order received successfully")
+
+	return order, nil
+}
+
+// StartReceiving starts a session receiving a delivery against an ordered purchase order by
+// scanning barcodes. A purchase order has one open receiving session at a time.
+func (uc *PurchaseOrderUseCase) StartReceiving(ctx context.Context, tenantID, userID, orderID uuid.UUID, req StartPurchaseOrderReceivingRequest) (*entities.PurchaseOrderReceiving, error) {
+	order, err := uc.GetPurchaseOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	receiving, err := entities.NewPurchaseOrderReceiving(order, req.Notes, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.receivingRepo.Create(ctx, receiving); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"purchase_order_id": orderID,
+			"error":             err.Error(),
+		}).Error("Failed to create receiving session")
+		return nil, errors.NewInternalError("failed to start receiving session", err)
+	}
+
+	uc.logReceivingEvent(ctx, userID, "start_receiving", order, receiving, nil)
+
+	return receiving, nil
+}
+
+// ListReceivings retrieves the receiving sessions of a purchase order, most recent first
+func (uc *PurchaseOrderUseCase) ListReceivings(ctx context.Context, tenantID, orderID uuid.UUID) ([]*entities.PurchaseOrderReceiving, error) {
+	if _, err := uc.GetPurchaseOrder(ctx, tenantID, orderID); err != nil {
+		return nil, err
+	}
+
+	receivings, err := uc.receivingRepo.ListByPurchaseOrder(ctx, orderID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list receiving sessions")
+		return nil, errors.NewInternalError("failed to list receiving sessions", err)
+	}
+
+	return receivings, nil
+}
+
+// GetReceiving retrieves a receiving session of a purchase order with its scanned lines
+func (uc *PurchaseOrderUseCase) GetReceiving(ctx context.Context, tenantID, orderID, receivingID uuid.UUID) (*entities.PurchaseOrderReceiving, error) {
+	receiving, err := uc.receivingRepo.GetByID(ctx, receivingID)
+	if err != nil || receiving.TenantID != tenantID || receiving.PurchaseOrderID != orderID {
+		return nil, errors.NewNotFoundError("receiving session")
+	}
+
+	return receiving, nil
+}
+
+// SubmitReceivingScans records a batch of barcodes scanned at a receiving station. Each scan
+// adds to the units of its purchase order item and gets its own result, flagging items
+// scanned beyond their outstanding quantity; a bad scan does not fail the batch. The session
+// is locked while the batch is recorded, so two stations scanning into it do not lose units.
+func (uc *PurchaseOrderUseCase) SubmitReceivingScans(ctx context.Context, tenantID, userID, orderID, receivingID uuid.UUID, req SubmitPurchaseOrderScansRequest) (*PurchaseOrderScanBatchResponse, error) {
+	if len(req.Scans) == 0 {
+		return nil, errors.NewValidationError("scans are required", "a batch needs at least one scan")
+	}
+	if len(req.Scans) > entities.MaxPurchaseOrderScanBatchSize {
+		return nil, errors.NewValidationError("too many scans", fmt.Sprintf("a batch cannot exceed %d scans", entities.MaxPurchaseOrderScanBatchSize))
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	receiving, err := tx.GetPurchaseOrderReceivingRepository().GetByIDForUpdate(ctx, receivingID)
+	if err != nil || receiving.TenantID != tenantID || receiving.PurchaseOrderID != orderID {
+		return nil, errors.NewNotFoundError("receiving session")
+	}
+	if !receiving.IsOpen() {
+		return nil, errors.NewValidationError("receiving session not open", "scans can only be submitted to open receiving sessions")
+	}
+
+	order, err := tx.GetPurchaseOrderRepository().GetByID(ctx, orderID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("purchase order")
+	}
+
+	response := &PurchaseOrderScanBatchResponse{
+		ReceivingID: receiving.ID,
+		Results:     make([]entities.PurchaseOrderScanResult, 0, len(req.Scans)),
+	}
+	changed := make(map[uuid.UUID]bool)
+	products := make(map[string]*entities.Product)
+	now := time.Now()
+
+	for _, scan := range req.Scans {
+		scan.Barcode = strings.TrimSpace(scan.Barcode)
+		product, err := uc.productByBarcode(ctx, tenantID, scan.Barcode, products)
+		if err != nil {
+			return nil, err
+		}
+
+		item, line, status, err := receiving.RecordScan(order, product, scan, now)
+		response.Results = append(response.Results, entities.NewPurchaseOrderScanResult(scan, status, item, line, err))
+		switch status {
+		case entities.PurchaseOrderScanStatusRecorded, entities.PurchaseOrderScanStatusOverReceived:
+			changed[line.ItemID] = true
+			response.Recorded++
+			if status == entities.PurchaseOrderScanStatusOverReceived {
+				response.OverReceived++
+			}
+		default:
+			response.Rejected++
+		}
+	}
+
+	lines := make([]*entities.PurchaseOrderReceivingLine, 0, len(changed))
+	for i := range receiving.Lines {
+		if changed[receiving.Lines[i].ItemID] {
+			lines = append(lines, &receiving.Lines[i])
+		}
+	}
+	if err := tx.GetPurchaseOrderReceivingRepository().SaveLines(ctx, lines); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"receiving_id": receiving.ID,
+			"error":        err.Error(),
+		}).Error("Failed to save receiving lines")
+		return nil, errors.NewInternalError("failed to record scans", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"receiving_id":  receiving.ID,
+		"scans":         len(req.Scans),
+		"recorded":      response.Recorded,
+		"over_received": response.OverReceived,
+		"rejected":      response.Rejected,
+		"user_id":       userID,
+	}).Info("Receiving scans recorded")
+
+	return response, nil
+}
+
+// GetReceivingDiscrepancies compares the units scanned in a receiving session with the units
+// outstanding on the purchase order, flagging items under- and over-received
+func (uc *PurchaseOrderUseCase) GetReceivingDiscrepancies(ctx context.Context, tenantID, orderID, receivingID uuid.UUID) (*entities.PurchaseOrderReceivingDiscrepancies, error) {
+	receiving, err := uc.GetReceiving(ctx, tenantID, orderID, receivingID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := uc.GetPurchaseOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return receiving.Discrepancies(order), nil
+}
+
+// CompleteReceiving closes a receiving session and receives its scanned units on the purchase
+// order, adding them to stock like any other delivery. Items scanned short stay outstanding
+// for a later session; over-received units must be taken back first.
+func (uc *PurchaseOrderUseCase) CompleteReceiving(ctx context.Context, tenantID, userID, orderID, receivingID uuid.UUID) (*CompletePurchaseOrderReceivingResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the order before the session, in the same order as scans and direct receipts
+	order, err := tx.GetPurchaseOrderRepository().GetByIDForUpdate(ctx, orderID)
+	if err != nil || order.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("purchase order")
+	}
+	receiving, err := tx.GetPurchaseOrderReceivingRepository().GetByIDForUpdate(ctx, receivingID)
+	if err != nil || receiving.TenantID != tenantID || receiving.PurchaseOrderID != orderID {
+		return nil, errors.NewNotFoundError("receiving session")
+	}
+
+	lines, err := receiving.Complete(order, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	received, err := order.Receive(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := "Received from " + order.SupplierName + " by scanning"
+	if receiving.Notes != "" {
+		notes += ": " + receiving.Notes
+	}
+	if err := uc.addReceivedStock(ctx, tx, order, received, notes, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetPurchaseOrderRepository().Update(ctx, order); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"purchase_order_id": orderID,
+			"error":             err.Error(),
+		}).Error("Failed to update purchase order")
+		return nil, errors.NewInternalError("failed to update purchase order", err)
+	}
+	if err := tx.GetPurchaseOrderReceivingRepository().Update(ctx, receiving); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"receiving_id": receiving.ID,
+			"error":        err.Error(),
+		}).Error("Failed to update receiving session")
+		return nil, errors.NewInternalError("failed to complete receiving session", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.logReceivingEvent(ctx, userID, "receive", order, receiving, map[string]interface{}{
+		"received": received,
+		"status":   order.Status,
+	})
+
+	return &CompletePurchaseOrderReceivingResponse{
+		Receiving:     receiving,
+		PurchaseOrder: order,
+	}, nil
+}
+
+// CancelReceiving abandons an open receiving session without receiving anything
+func (uc *PurchaseOrderUseCase) CancelReceiving(ctx context.Context, tenantID, userID, orderID, receivingID uuid.UUID) (*entities.PurchaseOrderReceiving, error) {
+	receiving, err := uc.GetReceiving(ctx, tenantID, orderID, receivingID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := uc.GetPurchaseOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := receiving.Cancel(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.receivingRepo.Update(ctx, receiving); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"receiving_id": receiving.ID,
+			"error":        err.Error(),
+		}).Error("Failed to update receiving session")
+		return nil, errors.NewInternalError("failed to cancel receiving session", err)
+	}
+
+	uc.logReceivingEvent(ctx, userID, "cancel_receiving", order, receiving, nil)
+
+	return receiving, nil
+}
+
+// GetReorderSuggestions suggests how much to order of each low stock product, from its
+// reorder level, its average daily sales and the lead time of its preferred supplier
+func (uc *PurchaseOrderUseCase) GetReorderSuggestions(ctx context.Context, tenantID uuid.UUID, req ReorderSuggestionRequest) (*ReorderSuggestionsResponse, error) {
+	if req.SalesDays == 0 {
+		req.SalesDays = defaultReorderSalesDays
+	}
+	if req.CoverDays == 0 {
+		req.CoverDays = defaultReorderCoverDays
+	}
+	if req.SalesDays < 1 || req.SalesDays > maxReorderSalesDays {
+		return nil, errors.NewValidationError("invalid sales days", fmt.Sprintf("sales days must be between 1 and %d", maxReorderSalesDays))
+	}
+	if req.CoverDays < 1 || req.CoverDays > maxReorderCoverDays {
+		return nil, errors.NewValidationError("invalid cover days", fmt.Sprintf("cover days must be between 1 and %d", maxReorderCoverDays))
+	}
+
+	items, err := uc.stockRepo.GetLowStockByTenant(ctx, tenantID, reorderSuggestionLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get low stock items")
+		return nil, errors.NewInternalError("failed to get reorder suggestions", err)
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if req.SupplierID != nil && (item.SupplierID == nil || *item.SupplierID != *req.SupplierID) {
+			continue
+		}
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	now := time.Now()
+	sold, err := uc.saleItemRepo.GetQuantitiesSold(ctx, tenantID, productIDs, now.AddDate(0, 0, -req.SalesDays), now)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get quantities sold")
+		return nil, errors.NewInternalError("failed to get reorder suggestions", err)
+	}
+
+	onOrder, err := uc.purchaseOrderRepo.GetOnOrderQuantities(ctx, tenantID, productIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get quantities on order")
+		return nil, errors.NewInternalError("failed to get reorder suggestions", err)
+	}
+
+	suggestions := []*entities.ReorderSuggestion{}
+	for _, item := range items {
+		if req.SupplierID != nil && (item.SupplierID == nil || *item.SupplierID != *req.SupplierID) {
+			continue
+		}
+
+		suggestion := &entities.ReorderSuggestion{
+			ProductID:     item.ProductID,
+			ProductSKU:    item.ProductSKU,
+			ProductName:   item.ProductName,
+			SupplierID:    item.SupplierID,
+			SupplierName:  item.SupplierName,
+			SupplierEmail: item.SupplierEmail,
+			SupplierPhone: item.SupplierPhone,
+			AvailableQty:  item.AvailableQty,
+			ReorderLevel:  item.ReorderLevel,
+			OnOrderQty:    onOrder[item.ProductID],
+			QuantitySold:  sold[item.ProductID],
+			LeadTimeDays:  item.LeadTimeDays,
+		}
+		suggestion.Calculate(req.SalesDays, req.CoverDays)
+		if suggestion.NeedsOrder() {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	return &ReorderSuggestionsResponse{
+		SalesDays:   req.SalesDays,
+		CoverDays:   req.CoverDays,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// CreateReorderDrafts creates one draft purchase order per preferred supplier holding the
+// suggested quantities of its low stock products. Either all drafts are created or none is.
+func (uc *PurchaseOrderUseCase) CreateReorderDrafts(ctx context.Context, tenantID, userID uuid.UUID, req CreateReorderDraftsRequest) (*ReorderDraftsResponse, error) {
+	suggestions, err := uc.GetReorderSuggestions(ctx, tenantID, req.ReorderSuggestionRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[uuid.UUID]bool, len(req.ProductIDs))
+	for _, productID := range req.ProductIDs {
+		selected[productID] = true
+	}
+
+	now := time.Now()
+	response := &ReorderDraftsResponse{
+		PurchaseOrders: []*entities.PurchaseOrder{},
+		Unassigned:     []*entities.ReorderSuggestion{},
+	}
+	bySupplier := make(map[uuid.UUID]*entities.PurchaseOrder)
+
+	for _, suggestion := range suggestions.Suggestions {
+		if len(selected) > 0 && !selected[suggestion.ProductID] {
+			continue
+		}
+		if suggestion.SupplierID == nil {
+			response.Unassigned = append(response.Unassigned, suggestion)
+			continue
+		}
+
+		order, ok := bySupplier[*suggestion.SupplierID]
+		if !ok {
+			order, err = entities.NewPurchaseOrder(tenantID, utils.GeneratePurchaseOrderNumber(), suggestion.SupplierName, suggestion.SupplierEmail, suggestion.SupplierPhone, userID)
+			if err != nil {
+				return nil, err
+			}
+			expectedAt := now.AddDate(0, 0, suggestion.LeadTimeDays)
+			order.ExpectedAt = &expectedAt
+			order.Notes = fmt.Sprintf("Reorder suggested from %d days of sales, covering %d days after delivery", suggestions.SalesDays, suggestions.CoverDays)
+			bySupplier[*suggestion.SupplierID] = order
+			response.PurchaseOrders = append(response.PurchaseOrders, order)
+		}
+
+		item := PurchaseOrderItemRequest{ProductID: suggestion.ProductID, Quantity: suggestion.SuggestedQty}
+		if err := uc.addItems(ctx, order, []PurchaseOrderItemRequest{item}); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(response.PurchaseOrders) == 0 {
+		return response, nil
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	for _, order := range response.PurchaseOrders {
+		if err := tx.GetPurchaseOrderRepository().Create(ctx, order); err != nil {
+			if _, ok := errors.IsAppError(err); ok {
+				return nil, err
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"po_number": order.PONumber,
+				"error":     err.Error(),
+			}).Error("Failed to create purchase order")
+			return nil, errors.NewInternalError("failed to create purchase order", err)
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	for _, order := range response.PurchaseOrders {
+		// Audit log
+		auditEvent := ports.AuditEvent{
+			ID:         uuid.New(),
+			UserID:     userID,
+			Action:     "create_reorder_draft",
+			Resource:   "purchase_order",
+			ResourceID: order.ID.String(),
+			NewValue: map[string]interface{}{
+				"po_number":     order.PONumber,
+				"supplier_name": order.SupplierName,
+				"items":         order.Items,
+				"total_cost":    order.TotalCost,
+			},
+			Timestamp: time.Now(),
+			Success:   true,
+		}
+		uc.audit.Log(ctx, auditEvent)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"purchase_orders": len(response.PurchaseOrders),
+		"unassigned":      len(response.Unassigned),
+		"user_id":         userID,
+	}).Info("Reorder draft purchase orders created")
+
+	return response, nil
+}
+
+// Helper methods
+
+// addItems adds the requested product lines to a draft purchase order
+func (uc *PurchaseOrderUseCase) addItems(ctx context.Context, order *entities.PurchaseOrder, items []PurchaseOrderItemRequest) error {
+	for _, item := range items {
+		product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil || product.TenantID != order.TenantID {
+			return errors.NewNotFoundError("product " + item.ProductID.String())
+		}
+
+		unitCost := product.Cost
+		if item.UnitCost != nil {
+			unitCost = *item.UnitCost
+		}
+
+		if err := order.AddItem(product, item.Quantity, unitCost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addReceivedStock adds the items received on a purchase order to stock, averaging their cost
+// into their products' cost. Each product received gets a purchase stock movement referencing
+// the PO number.
+func (uc *PurchaseOrderUseCase) addReceivedStock(ctx context.Context, tx ports.TransactionPort, order *entities.PurchaseOrder, received []entities.PurchaseOrderItem, notes string, userID uuid.UUID) error {
+	for _, item := range received {
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return errors.NewNotFoundError("stock record")
+		}
+
+		if err := uc.recordReceiptCost(ctx, tx, order, item, stock.TotalQty); err != nil {
+			return err
+		}
+
+		if err := stock.AddStock(item.Quantity, entities.ReasonPurchase); err != nil {
+			return err
+		}
+
+		movement, err := entities.NewStockMovement(
+			item.ProductID,
+			entities.StockMovementTypeIn,
+			entities.ReasonPurchase,
+			item.Quantity,
+			order.PONumber,
+			notes,
+			userID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create stock movement")
+			return errors.NewInternalError("failed to create stock movement", err)
+		}
+
+		if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to update stock")
+			return errors.NewInternalError("failed to update stock", err)
+		}
+	}
+
+	return nil
+}
+
+// recordReceiptCost averages the cost of a delivered item into the cost of the onHand units of
+// its product, and records the delivery as a cost layer for tenants costing sales first in,
+// first out
+func (uc *PurchaseOrderUseCase) recordReceiptCost(ctx context.Context, tx ports.TransactionPort, order *entities.PurchaseOrder, item entities.PurchaseOrderItem, onHand int) error {
+	product, err := tx.GetProductRepository().GetByID(ctx, item.ProductID)
+	if err != nil {
+		return errors.NewNotFoundError("product")
+	}
+
+	if err := product.ReceiveAtCost(onHand, item.Quantity, item.UnitCost); err != nil {
+		return err
+	}
+	if err := tx.GetProductRepository().Update(ctx, product); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": item.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to update product cost")
+		return errors.NewInternalError("failed to update product cost", err)
+	}
+
+	layer, err := entities.NewCostLayer(order.TenantID, item.ProductID, item.Quantity, item.UnitCost, order.PONumber)
+	if err != nil {
+		return err
+	}
+	if err := tx.GetCostLayerRepository().Create(ctx, layer); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": item.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to create cost layer")
+		return errors.NewInternalError("failed to create cost layer", err)
+	}
+
+	return nil
+}
+
+// productByBarcode looks up the tenant's product with a barcode, nil when there is none.
+// Lookups are cached for the batch, as the same product is scanned repeatedly.
+func (uc *PurchaseOrderUseCase) productByBarcode(ctx context.Context, tenantID uuid.UUID, barcode string, cache map[string]*entities.Product) (*entities.Product, error) {
+	if product, ok := cache[barcode]; ok {
+		return product, nil
+	}
+	if barcode == "" {
+		return nil, nil
+	}
+
+	product, err := uc.productRepo.GetByTenantAndBarcode(ctx, tenantID, barcode)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			cache[barcode] = nil
+			return nil, nil
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"barcode": barcode,
+			"error":   err.Error(),
+		}).Error("Failed to look up product by barcode")
+		return nil, errors.NewInternalError("failed to record scans", err)
+	}
+
+	cache[barcode] = product
+	return product, nil
+}
+
+// logReceivingEvent records a receiving session event against its purchase order in the
+// audit log
+func (uc *PurchaseOrderUseCase) logReceivingEvent(ctx context.Context, userID uuid.UUID, action string, order *entities.PurchaseOrder, receiving *entities.PurchaseOrderReceiving, newValue map[string]interface{}) {
+	if newValue == nil {
+		newValue = map[string]interface{}{}
+	}
+	newValue["po_number"] = order.PONumber
+	newValue["receiving_id"] = receiving.ID
+	newValue["receiving_status"] = receiving.Status
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "purchase_order",
+		ResourceID: order.ID.String(),
+		NewValue:   newValue,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"purchase_order_id": order.ID,
+		"po_number":         order.PONumber,
+		"receiving_id":      receiving.ID,
+		"status":            order.Status,
+		"user_id":           userID,
+		"action":            action,
+	}).Info("Purchase order receiving event recorded")
+}
+
+// changeStatus applies a status transition to a purchase order and records it
+func (uc *PurchaseOrderUseCase) changeStatus(ctx context.Context, tenantID, userID, orderID uuid.UUID, action string, transition func(*entities.PurchaseOrder) error) (*entities.PurchaseOrder, error) {
+	order, err := uc.GetPurchaseOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStatus := order.Status
+	if err := transition(order); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.Update(ctx, order); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"purchase_order_id": orderID,
+			"error":             err.Error(),
+		}).Error("Failed to update purchase order")
+		return nil, errors.NewInternalError("failed to update purchase order", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "purchase_order",
+		ResourceID: order.ID.String(),
+		OldValue: map[string]interface{}{
+			"status": oldStatus,
+		},
+		NewValue: map[string]interface{}{
+			"status": order.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"purchase_order_id": order.ID,
+		"po_number":         order.PONumber,
+		"status":            order.Status,
+	}).Info("Purchase order status changed")
+
+	return order, nil
+}
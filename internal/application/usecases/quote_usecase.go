@@ -0,0 +1,698 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const (
+	// defaultQuoteValidDays is how long a quote's prices hold unless it is given an expiry
+	defaultQuoteValidDays = 30
+
+	// quoteExpiryBatchSize bounds how many expired quotes are closed per run
+	quoteExpiryBatchSize = 200
+)
+
+// QuoteUseCase handles quoting prices to customers and converting accepted quotes into sales
+type QuoteUseCase struct {
+	quoteRepo       repositories.QuoteRepository
+	productRepo     repositories.ProductRepository
+	tenantRepo      repositories.TenantRepository
+	suppressionRepo repositories.EmailSuppressionRepository
+	pdfService      services.InvoicePDFService
+	emailService    services.EmailService
+	delivery        *DeliveryPolicyUseCase
+	sales           *SaleUseCase
+	database        ports.DatabasePort
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewQuoteUseCase creates a new quote use case
+func NewQuoteUseCase(
+	quoteRepo repositories.QuoteRepository,
+	productRepo repositories.ProductRepository,
+	tenantRepo repositories.TenantRepository,
+	suppressionRepo repositories.EmailSuppressionRepository,
+	pdfService services.InvoicePDFService,
+	emailService services.EmailService,
+	delivery *DeliveryPolicyUseCase,
+	sales *SaleUseCase,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *QuoteUseCase {
+	return &QuoteUseCase{
+		quoteRepo:       quoteRepo,
+		productRepo:     productRepo,
+		tenantRepo:      tenantRepo,
+		suppressionRepo: suppressionRepo,
+		pdfService:      pdfService,
+		emailService:    emailService,
+		delivery:        delivery,
+		sales:           sales,
+		database:        database,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// QuoteItemRequest represents a product line in a quote request
+type QuoteItemRequest struct {
+	ProductID uuid.UUID        `json:"product_id" validate:"required"`
+	Quantity  int              `json:"quantity" validate:"required,min=1"`
+	UnitPrice *decimal.Decimal `json:"unit_price,omitempty"` // Defaults to the product's current price in the quote's currency
+}
+
+// CreateQuoteRequest represents create quote request
+type CreateQuoteRequest struct {
+	CustomerName  string             `json:"customer_name" validate:"required"`
+	CustomerEmail string             `json:"customer_email,omitempty"`
+	CustomerPhone string             `json:"customer_phone,omitempty"`
+	Currency      string             `json:"currency,omitempty"`   // Defaults to the tenant's currency
+	ExpiresAt     *time.Time         `json:"expires_at,omitempty"` // Defaults to 30 days from now
+	Items         []QuoteItemRequest `json:"items,omitempty"`
+	Notes         string             `json:"notes,omitempty"`
+}
+
+// UpdateQuoteRequest represents update quote request. The items replace the existing items
+// of the draft.
+type UpdateQuoteRequest struct {
+	CustomerName  string             `json:"customer_name" validate:"required"`
+	CustomerEmail string             `json:"customer_email,omitempty"`
+	CustomerPhone string             `json:"customer_phone,omitempty"`
+	ExpiresAt     *time.Time         `json:"expires_at,omitempty"` // Keeps the current expiry when empty
+	Items         []QuoteItemRequest `json:"items,omitempty"`
+	Notes         string             `json:"notes,omitempty"`
+}
+
+// GenerateQuotePDFRequest represents generate quote PDF request
+type GenerateQuotePDFRequest struct {
+	QuoteID   uuid.UUID                 `json:"quote_id" validate:"required"`
+	PaperSize entities.PaperSize        `json:"paper_size,omitempty"`
+	Template  *entities.InvoiceTemplate `json:"template,omitempty"`
+}
+
+// SendQuoteRequest represents send quote request
+type SendQuoteRequest struct {
+	EmailTo   string                    `json:"email_to,omitempty"` // Defaults to the customer's email
+	PaperSize entities.PaperSize        `json:"paper_size,omitempty"`
+	Template  *entities.InvoiceTemplate `json:"template,omitempty"`
+}
+
+// QuoteListResponse represents quote list response
+type QuoteListResponse struct {
+	Quotes     []*entities.Quote    `json:"quotes"`
+	Pagination utils.PaginationInfo `json:"pagination"`
+}
+
+// CreateQuote creates a new draft quote
+func (uc *QuoteUseCase) CreateQuote(ctx context.Context, tenantID, userID uuid.UUID, req CreateQuoteRequest) (*entities.Quote, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get tenant for quote")
+		return nil, errors.NewInternalError("failed to create quote", err)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = tenant.GetCurrency()
+	}
+	expiresAt := time.Now().AddDate(0, 0, defaultQuoteValidDays)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	quote, err := entities.NewQuote(tenantID, utils.GenerateQuoteNumber(), req.CustomerName, req.CustomerEmail, req.CustomerPhone, currency, expiresAt, userID)
+	if err != nil {
+		return nil, err
+	}
+	quote.Notes = req.Notes
+
+	if err := uc.addItems(ctx, tenant, quote, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := uc.quoteRepo.Create(ctx, quote); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_number": quote.QuoteNumber,
+			"error":        err.Error(),
+		}).Error("Failed to create quote")
+		return nil, errors.NewInternalError("failed to create quote", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "quote",
+		ResourceID: quote.ID.String(),
+		NewValue: map[string]interface{}{
+			"quote_number":  quote.QuoteNumber,
+			"customer_name": quote.CustomerName,
+			"items":         quote.Items,
+			"total_amount":  quote.TotalAmount,
+			"currency":      quote.Currency,
+			"expires_at":    quote.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"quote_id":     quote.ID,
+		"quote_number": quote.QuoteNumber,
+		"user_id":      userID,
+	}).Info("Quote created successfully")
+
+	return quote, nil
+}
+
+// GetQuote retrieves a quote by ID
+func (uc *QuoteUseCase) GetQuote(ctx context.Context, tenantID, quoteID uuid.UUID) (*entities.Quote, error) {
+	quote, err := uc.quoteRepo.GetByID(ctx, quoteID)
+	if err != nil || quote.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("quote")
+	}
+
+	return quote, nil
+}
+
+// ListQuotes retrieves quotes with pagination and filtering
+func (uc *QuoteUseCase) ListQuotes(ctx context.Context, filter repositories.QuoteFilter, pagination utils.PaginationInfo) (*QuoteListResponse, error) {
+	quotes, paginationResult, err := uc.quoteRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list quotes")
+		return nil, errors.NewInternalError("failed to list quotes", err)
+	}
+
+	if quotes == nil {
+		quotes = []*entities.Quote{}
+	}
+
+	return &QuoteListResponse{
+		Quotes:     quotes,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// UpdateQuote updates the customer details, expiry and items of a draft quote
+func (uc *QuoteUseCase) UpdateQuote(ctx context.Context, tenantID, userID, quoteID uuid.UUID, req UpdateQuoteRequest) (*entities.Quote, error) {
+	quote, err := uc.GetQuote(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to update quote", err)
+	}
+
+	oldTotal := quote.TotalAmount
+
+	if err := quote.UpdateCustomer(req.CustomerName, req.CustomerEmail, req.CustomerPhone); err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil {
+		if err := quote.SetExpiry(*req.ExpiresAt, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if err := quote.ClearItems(); err != nil {
+		return nil, err
+	}
+	if err := uc.addItems(ctx, tenant, quote, req.Items); err != nil {
+		return nil, err
+	}
+	quote.Notes = req.Notes
+
+	if err := uc.quoteRepo.Update(ctx, quote); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quoteID,
+			"error":    err.Error(),
+		}).Error("Failed to update quote")
+		return nil, errors.NewInternalError("failed to update quote", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "quote",
+		ResourceID: quote.ID.String(),
+		OldValue: map[string]interface{}{
+			"total_amount": oldTotal,
+		},
+		NewValue: map[string]interface{}{
+			"customer_name": quote.CustomerName,
+			"items":         quote.Items,
+			"total_amount":  quote.TotalAmount,
+			"expires_at":    quote.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return quote, nil
+}
+
+// GenerateQuotePDF renders a quote as a PDF
+func (uc *QuoteUseCase) GenerateQuotePDF(ctx context.Context, tenantID uuid.UUID, req GenerateQuotePDFRequest) (*entities.Quote, []byte, error) {
+	quote, err := uc.GetQuote(ctx, tenantID, req.QuoteID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pdfData, err := uc.renderPDF(ctx, quote, req.PaperSize, req.Template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return quote, pdfData, nil
+}
+
+// SendQuote emails a quote with its PDF to the customer and marks it sent. A sent quote can
+// be sent again, e.g. after extending its expiry.
+func (uc *QuoteUseCase) SendQuote(ctx context.Context, tenantID, userID, quoteID uuid.UUID, req SendQuoteRequest) (*entities.Quote, error) {
+	quote, err := uc.GetQuote(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient := entities.NormalizeEmail(req.EmailTo)
+	if recipient == "" {
+		recipient = quote.CustomerEmail
+	}
+	if recipient == "" {
+		return nil, errors.NewValidationError("email address is required", "the quote has no customer email to send it to")
+	}
+
+	// Check the quote can be sent before emailing it
+	now := time.Now()
+	if err := quote.MarkSent(now); err != nil {
+		return nil, err
+	}
+
+	suppressed, err := uc.suppressionRepo.GetSuppressed(ctx, tenantID, []string{recipient})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to check email suppression", err)
+	}
+	if suppressed[recipient] {
+		return nil, errors.NewValidationError("email address suppressed", "emails to "+recipient+" are suppressed")
+	}
+
+	pdfData, err := uc.renderPDF(ctx, quote, req.PaperSize, req.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.delivery.SendEmail(ctx, tenantID, userID, recipient, func(ctx context.Context) error {
+		return uc.emailService.SendQuoteEmail(ctx, quote, recipient, pdfData)
+	})
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quoteID,
+			"email_to": recipient,
+			"error":    err.Error(),
+		}).Error("Failed to send quote email")
+		return nil, errors.NewInternalError("failed to send quote email", err)
+	}
+
+	if err := uc.quoteRepo.Update(ctx, quote); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quoteID,
+			"error":    err.Error(),
+		}).Error("Failed to update quote")
+		return nil, errors.NewInternalError("failed to update quote", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "send_email",
+		Resource:   "quote",
+		ResourceID: quote.ID.String(),
+		NewValue: map[string]interface{}{
+			"email_to": recipient,
+			"status":   quote.Status,
+		},
+		Timestamp: now,
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"quote_id":     quote.ID,
+		"quote_number": quote.QuoteNumber,
+		"email_to":     recipient,
+		"user_id":      userID,
+	}).Info("Quote email sent")
+
+	return quote, nil
+}
+
+// AcceptQuote records that the customer accepted a quote that is still valid
+func (uc *QuoteUseCase) AcceptQuote(ctx context.Context, tenantID, userID, quoteID uuid.UUID) (*entities.Quote, error) {
+	return uc.changeStatus(ctx, tenantID, userID, quoteID, "accept", (*entities.Quote).Accept)
+}
+
+// RejectQuote records that the customer turned a quote down
+func (uc *QuoteUseCase) RejectQuote(ctx context.Context, tenantID, userID, quoteID uuid.UUID) (*entities.Quote, error) {
+	return uc.changeStatus(ctx, tenantID, userID, quoteID, "reject", (*entities.Quote).Reject)
+}
+
+// ConvertQuoteToSale creates a pending sale from an accepted quote, in the quote's currency
+// and charging the quoted prices. The quote is locked while converting so it becomes only
+// one sale; the sale is then completed like any other sale.
+func (uc *QuoteUseCase) ConvertQuoteToSale(ctx context.Context, tenantID, userID, quoteID uuid.UUID) (*SaleResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	quote, err := tx.GetQuoteRepository().GetByIDForUpdate(ctx, quoteID)
+	if err != nil || quote.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("quote")
+	}
+	if quote.Status != entities.QuoteStatusAccepted {
+		return nil, errors.NewValidationError("invalid quote status", "only accepted quotes can be converted to a sale")
+	}
+
+	sale, err := entities.NewSale(tenantID, utils.GenerateSaleNumber(), quote.CustomerName, quote.CustomerEmail, quote.CustomerPhone, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.sales.applyTenantPricing(ctx, tenantID, sale, quote.Currency); err != nil {
+		return nil, err
+	}
+	sale.AddNotes("Converted from quote " + quote.QuoteNumber)
+
+	if err := tx.GetSaleRepository().Create(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quoteID,
+			"error":    err.Error(),
+		}).Error("Failed to create sale from quote")
+		return nil, errors.NewInternalError("failed to create sale", err)
+	}
+
+	for _, item := range quote.Items {
+		saleItem, err := uc.quotedSaleItem(ctx, tx, quote, sale, item)
+		if err != nil {
+			return nil, err
+		}
+		if err := sale.AddItem(saleItem); err != nil {
+			return nil, err
+		}
+
+		if err := tx.GetSaleItemRepository().Create(ctx, saleItem); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id":    sale.ID,
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create sale item")
+			return nil, errors.NewInternalError("failed to create sale item", err)
+		}
+	}
+
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": sale.ID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale")
+		return nil, errors.NewInternalError("failed to update sale", err)
+	}
+
+	if err := quote.MarkConverted(sale.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := tx.GetQuoteRepository().Update(ctx, quote); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quoteID,
+			"error":    err.Error(),
+		}).Error("Failed to update quote")
+		return nil, errors.NewInternalError("failed to update quote", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "convert",
+		Resource:   "quote",
+		ResourceID: quote.ID.String(),
+		NewValue: map[string]interface{}{
+			"sale_id":      sale.ID,
+			"sale_number":  sale.SaleNumber,
+			"total_amount": sale.TotalAmount,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"quote_id":     quote.ID,
+		"quote_number": quote.QuoteNumber,
+		"sale_id":      sale.ID,
+		"user_id":      userID,
+	}).Info("Quote converted to sale")
+
+	return uc.sales.toSaleResponse(sale), nil
+}
+
+// ExpireQuotes marks draft and sent quotes past their expiry as expired. It is run
+// periodically by the scheduler.
+func (uc *QuoteUseCase) ExpireQuotes(ctx context.Context) error {
+	quotes, err := uc.quoteRepo.GetExpired(ctx, time.Now(), quoteExpiryBatchSize)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get expired quotes")
+		return errors.NewInternalError("failed to get expired quotes", err)
+	}
+
+	expired := 0
+	for _, quote := range quotes {
+		if err := quote.Expire(time.Now()); err != nil {
+			continue
+		}
+		if err := uc.quoteRepo.Update(ctx, quote); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"quote_id": quote.ID,
+				"error":    err.Error(),
+			}).Warn("Failed to expire quote")
+			continue
+		}
+		expired++
+	}
+
+	if expired > 0 {
+		uc.logger.WithField("count", expired).Info("Expired quotes closed")
+	}
+
+	return nil
+}
+
+// quotedSaleItem creates the sale item for a quote item. The item starts from the product's
+// current price like any sale item, so its cost and tax are recorded, and is then charged at
+// the quoted price.
+func (uc *QuoteUseCase) quotedSaleItem(ctx context.Context, tx ports.TransactionPort, quote *entities.Quote, sale *entities.Sale, item entities.QuoteItem) (*entities.SaleItem, error) {
+	product, err := tx.GetProductRepository().GetByID(ctx, item.ProductID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("product")
+	}
+	if !product.IsActive() {
+		return nil, errors.NewValidationError("product not active", product.Name+" is no longer active")
+	}
+
+	stock, err := tx.GetStockRepository().GetByProductID(ctx, item.ProductID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("stock record")
+	}
+	if !stock.CanFulfillOrder(item.Quantity) {
+		return nil, errors.NewInsufficientStockError(product.Name, stock.AvailableQty, item.Quantity)
+	}
+
+	toSaleCurrency, err := uc.sales.saleCurrencyConverter(ctx, sale, product)
+	if err != nil {
+		return nil, err
+	}
+
+	saleItem, err := entities.NewSaleItem(sale.ID, product.ID, product.SKU, product.Name, item.Quantity, toSaleCurrency(product.Price))
+	if err != nil {
+		return nil, err
+	}
+	if err := saleItem.SetUnitCost(toSaleCurrency(product.Cost)); err != nil {
+		return nil, err
+	}
+
+	taxRate, err := uc.sales.taxRates.ProductTaxRate(ctx, product)
+	if err != nil {
+		return nil, err
+	}
+	saleItem.AssignTaxRate(taxRate)
+
+	if err := quote.ApplyQuotedPrice(saleItem, item); err != nil {
+		return nil, err
+	}
+
+	return saleItem, nil
+}
+
+// addItems adds the requested products to a quote, at the product's current price in the
+// quote's currency unless a price is quoted
+func (uc *QuoteUseCase) addItems(ctx context.Context, tenant *entities.Tenant, quote *entities.Quote, items []QuoteItemRequest) error {
+	for _, item := range items {
+		product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil || product.TenantID != quote.TenantID {
+			return errors.NewNotFoundError("product " + item.ProductID.String())
+		}
+
+		unitPrice := product.Price
+		if promoPrice := product.ActivePromoPrice(time.Now()); promoPrice != nil {
+			unitPrice = *promoPrice
+		}
+		if item.UnitPrice != nil {
+			unitPrice = *item.UnitPrice
+		} else {
+			unitPrice, err = uc.toQuoteCurrency(ctx, tenant, quote, product, unitPrice)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := quote.AddItem(product, item.Quantity, unitPrice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toQuoteCurrency converts a price of the product into the quote's currency at the current
+// rates, through the tenant's currency
+func (uc *QuoteUseCase) toQuoteCurrency(ctx context.Context, tenant *entities.Tenant, quote *entities.Quote, product *entities.Product, amount decimal.Decimal) (decimal.Decimal, error) {
+	baseCurrency := tenant.GetCurrency()
+	productCurrency := product.PriceCurrency(baseCurrency)
+	if productCurrency == quote.Currency {
+		return amount, nil
+	}
+
+	toBase, err := uc.sales.getExchangeRate(ctx, productCurrency, baseCurrency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	fromBase, err := uc.sales.getExchangeRate(ctx, quote.Currency, baseCurrency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rate, err := entities.NewExchangeRate(quote.Currency, baseCurrency, fromBase, time.Now())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return rate.FromBase(amount.Mul(toBase)), nil
+}
+
+// renderPDF renders a quote with the given or default template, with its amounts displayed
+// the way the tenant configured them
+func (uc *QuoteUseCase) renderPDF(ctx context.Context, quote *entities.Quote, paperSize entities.PaperSize, template *entities.InvoiceTemplate) ([]byte, error) {
+	if template != nil {
+		if err := uc.pdfService.ValidateTemplate(template); err != nil {
+			return nil, err
+		}
+	} else {
+		if paperSize == "" {
+			paperSize = entities.PaperSizeA4
+		}
+		template = uc.pdfService.GetDefaultTemplate(paperSize)
+	}
+
+	if tenant, err := uc.tenantRepo.GetByID(ctx, quote.TenantID); err == nil {
+		format := tenant.GetCurrencyFormat()
+		if quote.Currency != tenant.GetCurrency() {
+			format = entities.DefaultCurrencyFormat(quote.Currency)
+		}
+		quote.CurrencyFormat = &format
+	}
+
+	pdfData, err := uc.pdfService.GenerateQuotePDF(ctx, quote, template)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quote.ID,
+			"error":    err.Error(),
+		}).Error("Failed to generate quote PDF")
+		return nil, errors.NewInternalError("failed to generate PDF", err)
+	}
+
+	return pdfData, nil
+}
+
+// changeStatus applies a status transition to a quote and records it
+func (uc *QuoteUseCase) changeStatus(ctx context.Context, tenantID, userID, quoteID uuid.UUID, action string, transition func(*entities.Quote, time.Time) error) (*entities.Quote, error) {
+	quote, err := uc.GetQuote(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStatus := quote.Status
+	if err := transition(quote, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.quoteRepo.Update(ctx, quote); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"quote_id": quoteID,
+			"error":    err.Error(),
+		}).Error("Failed to update quote")
+		return nil, errors.NewInternalError("failed to update quote", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "quote",
+		ResourceID: quote.ID.String(),
+		OldValue: map[string]interface{}{
+			"status": oldStatus,
+		},
+		NewValue: map[string]interface{}{
+			"status": quote.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return quote, nil
+}
@@ -0,0 +1,260 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// Formats a register report can be rendered in
+const (
+	RegisterReportFormatJSON    = "json"
+	RegisterReportFormatPDF     = "pdf"     // A4 sheets
+	RegisterReportFormatReceipt = "receipt" // 80mm thermal receipt
+)
+
+// RegisterReportUseCase handles X-reports, the mid-shift snapshots of open register sessions,
+// and Z-reports, the closing reports of closed sessions, and rendering and printing them
+type RegisterReportUseCase struct {
+	sessionRepo  repositories.RegisterSessionRepository
+	reportRepo   repositories.RegisterReportRepository
+	printerRepo  repositories.PrinterRepository
+	pdfService   services.InvoicePDFService
+	printService services.PrintService
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewRegisterReportUseCase creates a new register report use case
+func NewRegisterReportUseCase(
+	sessionRepo repositories.RegisterSessionRepository,
+	reportRepo repositories.RegisterReportRepository,
+	printerRepo repositories.PrinterRepository,
+	pdfService services.InvoicePDFService,
+	printService services.PrintService,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *RegisterReportUseCase {
+	return &RegisterReportUseCase{
+		sessionRepo:  sessionRepo,
+		reportRepo:   reportRepo,
+		printerRepo:  printerRepo,
+		pdfService:   pdfService,
+		printService: printService,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// RegisterReportFormatRequest represents the format a register report is returned in
+type RegisterReportFormatRequest struct {
+	Format string `form:"format"` // json (default), pdf or receipt
+}
+
+// PrintRegisterReportRequest represents print register report request
+type PrintRegisterReportRequest struct {
+	Type      entities.RegisterReportType `json:"type" validate:"required"`
+	PrinterID uuid.UUID                   `json:"printer_id" validate:"required"`
+}
+
+// GetXReport takes a mid-shift snapshot of an open session. It changes nothing and can be
+// taken any number of times.
+func (uc *RegisterReportUseCase) GetXReport(ctx context.Context, tenantID, userID, sessionID uuid.UUID) (*entities.RegisterReport, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, errors.NewValidationError("session closed", "X-reports can only be taken on open sessions, take a Z-report instead")
+	}
+
+	now := time.Now()
+	sales, cash, err := uc.sessionTotals(ctx, session, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewXReport(session, *sales, *cash, userID, now)
+}
+
+// GenerateZReport takes the closing report of a closed session, numbered after the register's
+// previous Z-report. A session has one Z-report: taking it again returns the stored one, so it
+// can be reprinted.
+func (uc *RegisterReportUseCase) GenerateZReport(ctx context.Context, tenantID, userID, sessionID uuid.UUID) (*entities.RegisterReport, error) {
+	session, err := uc.getSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsOpen() {
+		return nil, errors.NewValidationError("session open", "Z-reports can only be taken once the session is closed")
+	}
+
+	if existing, err := uc.reportRepo.GetZReportBySession(ctx, session.ID); err == nil {
+		return existing, nil
+	}
+
+	var previous *entities.RegisterReport
+	if latest, err := uc.reportRepo.GetLatestZReport(ctx, tenantID, session.Register); err == nil {
+		previous = latest
+	}
+
+	sales, cash, err := uc.sessionTotals(ctx, session, *session.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := entities.NewZReport(session, *sales, *cash, previous, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.reportRepo.CreateZReport(ctx, report); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to store Z-report")
+		return nil, errors.NewInternalError("failed to take Z-report", err)
+	}
+
+	uc.logReportEvent(ctx, userID, "z_report", report, map[string]interface{}{
+		"number":      report.Number,
+		"net_sales":   report.Sales.NetSales,
+		"grand_total": report.GrandTotal,
+	})
+
+	return report, nil
+}
+
+// RenderReport renders a report as a printable PDF, on A4 sheets or as a thermal receipt
+func (uc *RegisterReportUseCase) RenderReport(ctx context.Context, report *entities.RegisterReport, format string) ([]byte, error) {
+	var data []byte
+	var err error
+	switch format {
+	case RegisterReportFormatPDF:
+		data, err = uc.pdfService.GenerateRegisterReportPDF(ctx, report)
+	case RegisterReportFormatReceipt:
+		data, err = uc.pdfService.GenerateRegisterReportReceiptPDF(ctx, report)
+	default:
+		return nil, errors.NewValidationError("invalid report format", "format must be one of: json, pdf, receipt")
+	}
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": report.SessionID,
+			"error":      err.Error(),
+		}).Error("Failed to render register report")
+		return nil, errors.NewInternalError("failed to render register report", err)
+	}
+
+	return data, nil
+}
+
+// PrintReport prints a session's X- or Z-report to a registered printer, as a receipt on
+// thermal printers. Printing the Z-report takes it if it has not been taken yet.
+func (uc *RegisterReportUseCase) PrintReport(ctx context.Context, tenantID, userID, sessionID uuid.UUID, req PrintRegisterReportRequest) (*entities.RegisterReport, error) {
+	if err := entities.ValidateRegisterReportType(req.Type); err != nil {
+		return nil, err
+	}
+
+	printer, err := uc.printerRepo.GetByID(ctx, req.PrinterID)
+	if err != nil || printer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("printer")
+	}
+
+	var report *entities.RegisterReport
+	if req.Type == entities.RegisterReportTypeZ {
+		report, err = uc.GenerateZReport(ctx, tenantID, userID, sessionID)
+	} else {
+		report, err = uc.GetXReport(ctx, tenantID, userID, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.printService.PrintRegisterReport(ctx, report, printer.Name, printer.IsThermal); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": sessionID,
+			"printer_id": printer.ID,
+			"error":      err.Error(),
+		}).Error("Failed to print register report")
+		return nil, errors.NewInternalError("failed to print register report", err)
+	}
+
+	uc.logReportEvent(ctx, userID, "print_"+string(report.Type)+"_report", report, map[string]interface{}{
+		"number":     report.Number,
+		"printer_id": printer.ID,
+	})
+
+	return report, nil
+}
+
+// getSession retrieves a register session of the tenant
+func (uc *RegisterReportUseCase) getSession(ctx context.Context, tenantID, sessionID uuid.UUID) (*entities.RegisterSession, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("register session")
+	}
+
+	return session, nil
+}
+
+// sessionTotals sums the sales, refunds and cash of the session's cashier from opening to end
+func (uc *RegisterReportUseCase) sessionTotals(ctx context.Context, session *entities.RegisterSession, end time.Time) (*entities.RegisterSalesTotals, *entities.RegisterCashTotals, error) {
+	sales, err := uc.sessionRepo.GetSalesTotals(ctx, session.TenantID, session.OpenedBy, session.OpenedAt, end)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to sum register session sales")
+		return nil, nil, errors.NewInternalError("failed to take register report", err)
+	}
+
+	cash, err := uc.sessionRepo.GetCashTotals(ctx, session.TenantID, session.OpenedBy, session.OpenedAt, end)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to sum register session cash")
+		return nil, nil, errors.NewInternalError("failed to take register report", err)
+	}
+
+	return sales, cash, nil
+}
+
+// logReportEvent records a register report event in the audit log
+func (uc *RegisterReportUseCase) logReportEvent(ctx context.Context, userID uuid.UUID, action string, report *entities.RegisterReport, newValue map[string]interface{}) {
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "register_session",
+		ResourceID: report.SessionID.String(),
+		NewValue:   newValue,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"session_id": report.SessionID,
+		"register":   report.Register,
+		"type":       report.Type,
+		"user_id":    userID,
+		"action":     action,
+	}).Info("Register report event recorded")
+}
@@ -0,0 +1,281 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// RegisterSessionUseCase handles cash drawer sessions: opening a register with a float, cash
+// movements, closing it with a count and reconciling the count with the session's cash sales
+type RegisterSessionUseCase struct {
+	sessionRepo repositories.RegisterSessionRepository
+	audit       ports.AuditPort
+	logger      logger.Logger
+}
+
+// NewRegisterSessionUseCase creates a new register session use case
+func NewRegisterSessionUseCase(
+	sessionRepo repositories.RegisterSessionRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *RegisterSessionUseCase {
+	return &RegisterSessionUseCase{
+		sessionRepo: sessionRepo,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// OpenRegisterSessionRequest represents open register session request
+type OpenRegisterSessionRequest struct {
+	Register     string          `json:"register" validate:"required"`
+	OpeningFloat decimal.Decimal `json:"opening_float"`
+}
+
+// CashMovementRequest represents cash in or cash out request
+type CashMovementRequest struct {
+	Type   entities.CashMovementType `json:"type" validate:"required"`
+	Amount decimal.Decimal           `json:"amount" validate:"required"`
+	Reason string                    `json:"reason" validate:"required"`
+}
+
+// CloseRegisterSessionRequest represents close register session request
+type CloseRegisterSessionRequest struct {
+	CountedCash decimal.Decimal `json:"counted_cash"`
+	Notes       string          `json:"notes,omitempty"`
+}
+
+// DailyRegisterReconciliationRequest represents end-of-day reconciliation request
+type DailyRegisterReconciliationRequest struct {
+	Date     time.Time // Midnight of the day in Location
+	Location *time.Location
+}
+
+// RegisterSessionListResponse represents register session list response
+type RegisterSessionListResponse struct {
+	Sessions   []*entities.RegisterSession `json:"sessions"`
+	Pagination utils.PaginationInfo        `json:"pagination"`
+}
+
+// OpenSession opens a session on a register with the float put in its drawer
+func (uc *RegisterSessionUseCase) OpenSession(ctx context.Context, tenantID, userID uuid.UUID, req OpenRegisterSessionRequest) (*entities.RegisterSession, error) {
+	session, err := entities.NewRegisterSession(tenantID, req.Register, req.OpeningFloat, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.sessionRepo.GetOpenByRegister(ctx, tenantID, session.Register); err == nil {
+		return nil, errors.NewConflictError("register already has an open session")
+	}
+
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"register": session.Register,
+			"error":    err.Error(),
+		}).Error("Failed to open register session")
+		return nil, errors.NewInternalError("failed to open register session", err)
+	}
+
+	uc.logSessionEvent(ctx, userID, "open", session, map[string]interface{}{
+		"register":      session.Register,
+		"opening_float": session.OpeningFloat,
+	})
+
+	return session, nil
+}
+
+// GetSession retrieves a register session by ID
+func (uc *RegisterSessionUseCase) GetSession(ctx context.Context, tenantID, sessionID uuid.UUID) (*entities.RegisterSession, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("register session")
+	}
+
+	return session, nil
+}
+
+// GetOpenSession retrieves the session currently open on a register
+func (uc *RegisterSessionUseCase) GetOpenSession(ctx context.Context, tenantID uuid.UUID, register string) (*entities.RegisterSession, error) {
+	return uc.sessionRepo.GetOpenByRegister(ctx, tenantID, register)
+}
+
+// ListSessions retrieves register sessions with pagination and filtering
+func (uc *RegisterSessionUseCase) ListSessions(ctx context.Context, filter repositories.RegisterSessionFilter, pagination utils.PaginationInfo) (*RegisterSessionListResponse, error) {
+	sessions, paginationResult, err := uc.sessionRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list register sessions")
+		return nil, errors.NewInternalError("failed to list register sessions", err)
+	}
+
+	return &RegisterSessionListResponse{
+		Sessions:   sessions,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// RecordCashMovement records cash put into or taken out of an open session's drawer
+func (uc *RegisterSessionUseCase) RecordCashMovement(ctx context.Context, tenantID, userID, sessionID uuid.UUID, req CashMovementRequest) (*entities.RegisterSession, error) {
+	session, err := uc.GetSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := session.AddMovement(req.Type, req.Amount, req.Reason, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	uc.logSessionEvent(ctx, userID, string(movement.Type), session, map[string]interface{}{
+		"movement_id": movement.ID,
+		"amount":      movement.Amount,
+		"reason":      movement.Reason,
+	})
+
+	return session, nil
+}
+
+// CloseSession closes a session with the cash counted in its drawer and returns its
+// reconciliation
+func (uc *RegisterSessionUseCase) CloseSession(ctx context.Context, tenantID, userID, sessionID uuid.UUID, req CloseRegisterSessionRequest) (*entities.RegisterReconciliation, error) {
+	session, err := uc.GetSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, errors.NewValidationError("session closed", "session has already been closed")
+	}
+
+	now := time.Now()
+	totals, err := uc.cashTotals(ctx, session, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Close(*totals, req.CountedCash, req.Notes, userID, now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	reconciliation := entities.NewRegisterReconciliation(session, *totals)
+
+	uc.logSessionEvent(ctx, userID, "close", session, map[string]interface{}{
+		"expected_cash": reconciliation.ExpectedCash,
+		"counted_cash":  reconciliation.CountedCash,
+		"difference":    reconciliation.Difference,
+	})
+
+	return reconciliation, nil
+}
+
+// GetReconciliation compares the cash expected in a session's drawer with the cash counted
+// at close. Open sessions are reconciled up to now.
+func (uc *RegisterSessionUseCase) GetReconciliation(ctx context.Context, tenantID, sessionID uuid.UUID) (*entities.RegisterReconciliation, error) {
+	session, err := uc.GetSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.cashTotals(ctx, session, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewRegisterReconciliation(session, *totals), nil
+}
+
+// GetDailyReconciliation reconciles every session opened on a day
+func (uc *RegisterSessionUseCase) GetDailyReconciliation(ctx context.Context, tenantID uuid.UUID, req DailyRegisterReconciliationRequest) (*entities.DailyRegisterReconciliation, error) {
+	start := req.Date
+	end := start.AddDate(0, 0, 1)
+
+	sessions, err := uc.sessionRepo.GetOpenedBetween(ctx, tenantID, start, end)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get register sessions")
+		return nil, errors.NewInternalError("failed to get register sessions", err)
+	}
+
+	now := time.Now()
+	reconciliations := make([]*entities.RegisterReconciliation, 0, len(sessions))
+	for _, session := range sessions {
+		totals, err := uc.cashTotals(ctx, session, now)
+		if err != nil {
+			return nil, err
+		}
+		reconciliations = append(reconciliations, entities.NewRegisterReconciliation(session, *totals))
+	}
+
+	return entities.NewDailyRegisterReconciliation(start.Format("2006-01-02"), req.Location.String(), reconciliations), nil
+}
+
+// cashTotals sums the cash sales and refunds of the session's cashier from opening to close,
+// or to now for an open session
+func (uc *RegisterSessionUseCase) cashTotals(ctx context.Context, session *entities.RegisterSession, now time.Time) (*entities.RegisterCashTotals, error) {
+	end := now
+	if session.ClosedAt != nil {
+		end = *session.ClosedAt
+	}
+
+	totals, err := uc.sessionRepo.GetCashTotals(ctx, session.TenantID, session.OpenedBy, session.OpenedAt, end)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to sum register session cash")
+		return nil, errors.NewInternalError("failed to reconcile register session", err)
+	}
+
+	return totals, nil
+}
+
+// updateSession saves a change to a register session
+func (uc *RegisterSessionUseCase) updateSession(ctx context.Context, session *entities.RegisterSession) error {
+	if err := uc.sessionRepo.Update(ctx, session); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		}).Error("Failed to update register session")
+		return errors.NewInternalError("failed to update register session", err)
+	}
+	return nil
+}
+
+// logSessionEvent records a register session event in the audit log
+func (uc *RegisterSessionUseCase) logSessionEvent(ctx context.Context, userID uuid.UUID, action string, session *entities.RegisterSession, newValue map[string]interface{}) {
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "register_session",
+		ResourceID: session.ID.String(),
+		NewValue:   newValue,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"session_id": session.ID,
+		"register":   session.Register,
+		"user_id":    userID,
+		"action":     action,
+	}).Info("Register session event recorded")
+}
@@ -0,0 +1,906 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// maxDiscountReportRange caps the date range of a single discount report
+	maxDiscountReportRange = 366 * 24 * time.Hour
+	// maxTaxSummaryRange caps the date range of a single tax summary
+	maxTaxSummaryRange = 366 * 24 * time.Hour
+	// maxSalesReportRange caps the date range of a single sales, invoice or product profitability report
+	maxSalesReportRange = 366 * 24 * time.Hour
+)
+
+const (
+	// dailySummaryListLimit caps the top products, low stock and overdue lists of a daily summary
+	dailySummaryListLimit = 5
+	// dailySummaryTrailingDays is how many previous days the daily revenue is compared against
+	dailySummaryTrailingDays = 7
+	// lowStockReportLimit caps the number of products in the low stock report
+	lowStockReportLimit = 500
+	// defaultProductProfitabilityLimit is how many products the profitability report lists by default
+	defaultProductProfitabilityLimit = 20
+	// maxProductProfitabilityLimit caps the number of products in the profitability report
+	maxProductProfitabilityLimit = 500
+)
+
+const (
+	// staleSummaryLookback is how far back the summary job looks for changed sales and invoices.
+	// Older days are summarized when a report first needs them.
+	staleSummaryLookback = 24 * time.Hour
+	// staleSummaryBatchSize caps the number of days the summary job recomputes per run
+	staleSummaryBatchSize = 500
+)
+
+// Anomaly thresholds used by the daily summary
+var (
+	anomalyRevenueDropRatio     = decimal.NewFromFloat(0.5)
+	anomalyRevenueSpikeRatio    = decimal.NewFromInt(2)
+	anomalyCancellationRatePct  = decimal.NewFromInt(20)
+	anomalyCancellationMinSales = 5
+	anomalyDiscountRatePct      = decimal.NewFromInt(15)
+)
+
+// ReportUseCase handles business reporting. Reports are read from the read replica when one
+// is available.
+type ReportUseCase struct {
+	saleRepo    repositories.SaleRepository
+	stockRepo   repositories.StockRepository
+	invoiceRepo repositories.InvoiceRepository
+	summaryRepo repositories.ReportSummaryRepository
+	database    ports.DatabasePort
+	logger      logger.Logger
+}
+
+// NewReportUseCase creates a new report use case
+func NewReportUseCase(
+	saleRepo repositories.SaleRepository,
+	stockRepo repositories.StockRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	summaryRepo repositories.ReportSummaryRepository,
+	database ports.DatabasePort,
+	logger logger.Logger,
+) *ReportUseCase {
+	return &ReportUseCase{
+		saleRepo:    saleRepo,
+		stockRepo:   stockRepo,
+		invoiceRepo: invoiceRepo,
+		summaryRepo: summaryRepo,
+		database:    database,
+		logger:      logger,
+	}
+}
+
+// DiscountReportRequest represents discount report request
+type DiscountReportRequest struct {
+	FromDate  time.Time                 `json:"from_date"`
+	ToDate    time.Time                 `json:"to_date"` // Exclusive
+	Period    repositories.ReportPeriod `json:"period,omitempty"`
+	CashierID *uuid.UUID                `json:"cashier_id,omitempty"`
+	Category  string                    `json:"category,omitempty"`
+}
+
+// DiscountSummary represents how much revenue was given away and its effect on margin
+type DiscountSummary struct {
+	ItemsSold             int             `json:"items_sold"`
+	ListRevenue           decimal.Decimal `json:"list_revenue"`
+	ManualDiscount        decimal.Decimal `json:"manual_discount"`
+	PromotionDiscount     decimal.Decimal `json:"promotion_discount"`
+	PriceOverrideDiscount decimal.Decimal `json:"price_override_discount"`
+	TotalDiscount         decimal.Decimal `json:"total_discount"`
+	NetRevenue            decimal.Decimal `json:"net_revenue"`
+	CostOfGoods           decimal.Decimal `json:"cost_of_goods"`
+	DiscountRate          decimal.Decimal `json:"discount_rate"`       // Total discount as a percentage of list revenue
+	ListMarginPercent     decimal.Decimal `json:"list_margin_percent"` // Margin had everything sold at list price
+	NetMarginPercent      decimal.Decimal `json:"net_margin_percent"`  // Margin actually realised
+	MarginImpact          decimal.Decimal `json:"margin_impact"`       // Margin percentage points lost to discounts
+}
+
+// DiscountPeriodStat represents discounts given within one period
+type DiscountPeriodStat struct {
+	PeriodStart time.Time `json:"period_start"`
+	DiscountSummary
+}
+
+// DiscountCashierStat represents discounts given by one cashier
+type DiscountCashierStat struct {
+	CashierID   uuid.UUID `json:"cashier_id"`
+	CashierName string    `json:"cashier_name"`
+	DiscountSummary
+}
+
+// DiscountCategoryStat represents discounts given on one product category
+type DiscountCategoryStat struct {
+	Category string `json:"category"`
+	DiscountSummary
+}
+
+// DiscountReportResponse represents discount report response
+type DiscountReportResponse struct {
+	FromDate   time.Time                 `json:"from_date"`
+	ToDate     time.Time                 `json:"to_date"`
+	Period     repositories.ReportPeriod `json:"period"`
+	Totals     DiscountSummary           `json:"totals"`
+	ByPeriod   []*DiscountPeriodStat     `json:"by_period"`
+	ByCashier  []*DiscountCashierStat    `json:"by_cashier"`
+	ByCategory []*DiscountCategoryStat   `json:"by_category"`
+}
+
+// AnomalySeverity represents how urgently an anomaly needs attention
+type AnomalySeverity string
+
+const (
+	AnomalySeverityInfo    AnomalySeverity = "info"
+	AnomalySeverityWarning AnomalySeverity = "warning"
+)
+
+// Anomaly represents an unusual pattern detected in a day's trading
+type Anomaly struct {
+	Type     string          `json:"type"`
+	Severity AnomalySeverity `json:"severity"`
+	Message  string          `json:"message"`
+}
+
+// DailySummaryResponse represents an end-of-day summary of a tenant's trading
+type DailySummaryResponse struct {
+	Date                   time.Time                           `json:"date"`
+	Sales                  *repositories.SalesSummary          `json:"sales"`
+	TrailingAverageRevenue decimal.Decimal                     `json:"trailing_average_revenue"` // Average daily revenue over the previous 7 days
+	Discounts              DiscountSummary                     `json:"discounts"`
+	TopProducts            []*repositories.ProductSalesStats   `json:"top_products"`
+	LowStockItems          []*repositories.LowStockItem        `json:"low_stock_items"`
+	Overdue                *repositories.OverdueInvoiceSummary `json:"overdue"`
+	Anomalies              []*Anomaly                          `json:"anomalies"`
+}
+
+// ReorderGroup represents the low stock products to reorder from one supplier. Products
+// without an active preferred supplier are grouped together with a nil SupplierID.
+type ReorderGroup struct {
+	SupplierID    *uuid.UUID                   `json:"supplier_id,omitempty"`
+	SupplierName  string                       `json:"supplier_name,omitempty"`
+	SupplierEmail string                       `json:"supplier_email,omitempty"`
+	SupplierPhone string                       `json:"supplier_phone,omitempty"`
+	LeadTimeDays  int                          `json:"lead_time_days"`
+	ExpectedAt    *time.Time                   `json:"expected_at,omitempty"` // When an order placed now should arrive
+	Items         []*repositories.LowStockItem `json:"items"`
+}
+
+// LowStockReportResponse represents the low stock report grouped by suggested supplier
+type LowStockReportResponse struct {
+	TotalItems int             `json:"total_items"`
+	Groups     []*ReorderGroup `json:"groups"`
+}
+
+// TaxSummary represents the taxable base and tax collected on sales and given back on credit
+// notes, as declared on the periodic tax return
+type TaxSummary struct {
+	SalesCount            int             `json:"sales_count"`
+	SalesTaxableBase      decimal.Decimal `json:"sales_taxable_base"`
+	SalesNonTaxableAmount decimal.Decimal `json:"sales_non_taxable_amount"`
+	SalesTax              decimal.Decimal `json:"sales_tax"`
+	CreditNoteCount       int             `json:"credit_note_count"`
+	CreditNoteTaxableBase decimal.Decimal `json:"credit_note_taxable_base"`
+	CreditNoteTax         decimal.Decimal `json:"credit_note_tax"`
+	NetTaxableBase        decimal.Decimal `json:"net_taxable_base"` // Sales less credit notes
+	NetTax                decimal.Decimal `json:"net_tax"`          // Tax payable for the period
+}
+
+// TaxRateSummary represents the tax totals of one tax rate
+type TaxRateSummary struct {
+	TaxRate decimal.Decimal `json:"tax_rate"`
+	TaxSummary
+}
+
+// TaxMonthSummary represents the tax totals of one month, overall and per tax rate
+type TaxMonthSummary struct {
+	Month time.Time `json:"month"`
+	TaxSummary
+	ByRate []*TaxRateSummary `json:"by_rate"`
+}
+
+// TaxSummaryResponse represents the sales tax summary report
+type TaxSummaryResponse struct {
+	FromDate time.Time          `json:"from_date"`
+	ToDate   time.Time          `json:"to_date"`
+	Totals   TaxSummary         `json:"totals"`
+	ByRate   []*TaxRateSummary  `json:"by_rate"`
+	ByMonth  []*TaxMonthSummary `json:"by_month"`
+}
+
+// ProductProfitability represents the gross profit made on a product
+type ProductProfitability struct {
+	*repositories.ProductSalesStats
+	MarginPercent decimal.Decimal `json:"margin_percent"`
+}
+
+// ProductProfitabilityResponse represents the products sold in a date range by gross profit
+type ProductProfitabilityResponse struct {
+	FromDate time.Time               `json:"from_date"`
+	ToDate   time.Time               `json:"to_date"`
+	Products []*ProductProfitability `json:"products"`
+}
+
+// GetSalesReport reports sales, revenue, cost of goods sold and gross profit for a date range.
+// The totals are read from the daily sales summaries; days not yet summarized are summarized
+// first, and recompute summarizes every day of the range again.
+func (uc *ReportUseCase) GetSalesReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, recompute bool) (*repositories.SalesReport, error) {
+	if err := validateSummaryReportRange(fromDate, toDate); err != nil {
+		return nil, err
+	}
+
+	refreshed, err := uc.prepareSummaries(ctx, tenantID, fromDate, toDate, recompute,
+		uc.summaryRepo.GetMissingSalesDays, uc.summaryRepo.RefreshSalesSummaries)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to summarize sales")
+		return nil, errors.NewInternalError("failed to generate sales report", err)
+	}
+
+	report, err := uc.summaryRepo.GetSalesReport(uc.summaryReader(ctx, refreshed), tenantID, fromDate, toDate)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get sales report")
+		return nil, errors.NewInternalError("failed to generate sales report", err)
+	}
+
+	return report, nil
+}
+
+// GetInvoiceReport reports invoice totals, balances and payment times for a date range. Like
+// the sales report, the totals are read from daily summaries.
+func (uc *ReportUseCase) GetInvoiceReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, recompute bool) (*repositories.InvoiceReport, error) {
+	if err := validateSummaryReportRange(fromDate, toDate); err != nil {
+		return nil, err
+	}
+
+	refreshed, err := uc.prepareSummaries(ctx, tenantID, fromDate, toDate, recompute,
+		uc.summaryRepo.GetMissingInvoiceDays, uc.summaryRepo.RefreshInvoiceSummaries)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to summarize invoices")
+		return nil, errors.NewInternalError("failed to generate invoice report", err)
+	}
+
+	report, err := uc.summaryRepo.GetInvoiceReport(uc.summaryReader(ctx, refreshed), tenantID, fromDate, toDate)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get invoice report")
+		return nil, errors.NewInternalError("failed to generate invoice report", err)
+	}
+
+	return report, nil
+}
+
+// RefreshStaleSummaries recomputes the daily sales and invoice summaries of the days whose
+// sales, refunds or invoices changed since they were last summarized. It is run periodically.
+func (uc *ReportUseCase) RefreshStaleSummaries(ctx context.Context) error {
+	days, err := uc.summaryRepo.GetStaleDays(ctx, time.Now().Add(-staleSummaryLookback), staleSummaryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list stale summary days: %w", err)
+	}
+
+	failed := 0
+	for _, day := range days {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := uc.refreshDaySummaries(ctx, day.TenantID, day.Date); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": day.TenantID,
+				"date":      day.Date.Format("2006-01-02"),
+				"error":     err.Error(),
+			}).Error("Failed to refresh report summaries")
+			failed++
+		}
+	}
+
+	if len(days) > 0 {
+		uc.logger.WithFields(map[string]interface{}{
+			"refreshed": len(days) - failed,
+			"failed":    failed,
+		}).Info("Refreshed report summaries")
+	}
+
+	return nil
+}
+
+// refreshDaySummaries recomputes a tenant's sales and invoice summaries of the day containing date
+func (uc *ReportUseCase) refreshDaySummaries(ctx context.Context, tenantID uuid.UUID, date time.Time) error {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	next := day.AddDate(0, 0, 1)
+
+	if err := uc.summaryRepo.RefreshSalesSummaries(ctx, tenantID, day, next); err != nil {
+		return err
+	}
+	return uc.summaryRepo.RefreshInvoiceSummaries(ctx, tenantID, day, next)
+}
+
+// GetDailySalesReport reports a day's sales, revenue, cost of goods sold and gross profit
+func (uc *ReportUseCase) GetDailySalesReport(ctx context.Context, date time.Time) (*repositories.DailySalesReport, error) {
+	ctx = uc.database.ReadOnly(ctx)
+	report, err := uc.saleRepo.GetDailySales(ctx, date)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get daily sales report")
+		return nil, errors.NewInternalError("failed to generate daily sales report", err)
+	}
+
+	return report, nil
+}
+
+// GetProductProfitability lists the products sold on completed sales in a date range by gross
+// profit, at the unit costs recorded when the sales were completed
+func (uc *ReportUseCase) GetProductProfitability(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, limit int) (*ProductProfitabilityResponse, error) {
+	if !toDate.After(fromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if toDate.Sub(fromDate) > maxSalesReportRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+	if limit <= 0 {
+		limit = defaultProductProfitabilityLimit
+	}
+	if limit > maxProductProfitabilityLimit {
+		limit = maxProductProfitabilityLimit
+	}
+
+	stats, err := uc.saleRepo.GetProductProfitability(uc.database.ReadOnly(ctx), tenantID, fromDate, toDate, limit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get product profitability")
+		return nil, errors.NewInternalError("failed to generate product profitability report", err)
+	}
+
+	products := make([]*ProductProfitability, 0, len(stats))
+	for _, stat := range stats {
+		products = append(products, &ProductProfitability{
+			ProductSalesStats: stat,
+			MarginPercent:     percentOf(stat.TotalProfit, stat.TotalRevenue),
+		})
+	}
+
+	return &ProductProfitabilityResponse{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Products: products,
+	}, nil
+}
+
+// GetDailySummary summarizes revenue, top products, low stock, overdue invoices and anomalies
+// for the business day starting at date (local midnight in the tenant's time zone)
+func (uc *ReportUseCase) GetDailySummary(ctx context.Context, tenantID uuid.UUID, date time.Time) (*DailySummaryResponse, error) {
+	ctx = uc.database.ReadOnly(ctx)
+	dayEnd := date.AddDate(0, 0, 1)
+	trailingStart := date.AddDate(0, 0, -dailySummaryTrailingDays)
+
+	sales, err := uc.saleRepo.GetSalesSummary(ctx, tenantID, date, dayEnd)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get sales summary")
+		return nil, errors.NewInternalError("failed to generate daily summary", err)
+	}
+
+	trailing, err := uc.saleRepo.GetSalesSummary(ctx, tenantID, trailingStart, date)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get trailing sales summary")
+		return nil, errors.NewInternalError("failed to generate daily summary", err)
+	}
+
+	discounts, err := uc.GetDiscountReport(ctx, tenantID, DiscountReportRequest{
+		FromDate: date,
+		ToDate:   dayEnd,
+		Period:   repositories.ReportPeriodDay,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topProducts, err := uc.saleRepo.GetTopProducts(ctx, tenantID, date, dayEnd, dailySummaryListLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get top products")
+		return nil, errors.NewInternalError("failed to generate daily summary", err)
+	}
+
+	lowStock, err := uc.stockRepo.GetLowStockByTenant(ctx, tenantID, dailySummaryListLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get low stock items")
+		return nil, errors.NewInternalError("failed to generate daily summary", err)
+	}
+
+	overdue, err := uc.invoiceRepo.GetOverdueSummary(ctx, tenantID, dayEnd, dailySummaryListLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get overdue invoices")
+		return nil, errors.NewInternalError("failed to generate daily summary", err)
+	}
+
+	summary := &DailySummaryResponse{
+		Date:                   date,
+		Sales:                  sales,
+		TrailingAverageRevenue: trailing.TotalRevenue.Div(decimal.NewFromInt(dailySummaryTrailingDays)).Round(2),
+		Discounts:              discounts.Totals,
+		TopProducts:            topProducts,
+		LowStockItems:          lowStock,
+		Overdue:                overdue,
+	}
+	summary.Anomalies = detectAnomalies(summary)
+
+	return summary, nil
+}
+
+// GetLowStockReport lists products at or below their reorder level, grouped by the
+// preferred supplier to reorder from
+func (uc *ReportUseCase) GetLowStockReport(ctx context.Context, tenantID uuid.UUID) (*LowStockReportResponse, error) {
+	ctx = uc.database.ReadOnly(ctx)
+	items, err := uc.stockRepo.GetLowStockByTenant(ctx, tenantID, lowStockReportLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get low stock items")
+		return nil, errors.NewInternalError("failed to generate low stock report", err)
+	}
+
+	now := time.Now()
+	var unassigned *ReorderGroup
+	groups := []*ReorderGroup{}
+	bySupplier := make(map[uuid.UUID]*ReorderGroup)
+
+	for _, item := range items {
+		if item.SupplierID == nil {
+			if unassigned == nil {
+				unassigned = &ReorderGroup{}
+			}
+			unassigned.Items = append(unassigned.Items, item)
+			continue
+		}
+
+		group, ok := bySupplier[*item.SupplierID]
+		if !ok {
+			expectedAt := now.AddDate(0, 0, item.LeadTimeDays)
+			group = &ReorderGroup{
+				SupplierID:    item.SupplierID,
+				SupplierName:  item.SupplierName,
+				SupplierEmail: item.SupplierEmail,
+				SupplierPhone: item.SupplierPhone,
+				LeadTimeDays:  item.LeadTimeDays,
+				ExpectedAt:    &expectedAt,
+			}
+			bySupplier[*item.SupplierID] = group
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, item)
+	}
+
+	// Suppliers with the longest lead time first, since those orders are most urgent
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].LeadTimeDays != groups[j].LeadTimeDays {
+			return groups[i].LeadTimeDays > groups[j].LeadTimeDays
+		}
+		return groups[i].SupplierName < groups[j].SupplierName
+	})
+	if unassigned != nil {
+		groups = append(groups, unassigned)
+	}
+
+	return &LowStockReportResponse{
+		TotalItems: len(items),
+		Groups:     groups,
+	}, nil
+}
+
+// GetDiscountReport reports manual discounts, promotion discounts and price overrides
+// on completed sales, broken down by period, cashier and category
+func (uc *ReportUseCase) GetDiscountReport(ctx context.Context, tenantID uuid.UUID, req DiscountReportRequest) (*DiscountReportResponse, error) {
+	if req.Period == "" {
+		req.Period = repositories.ReportPeriodDay
+	}
+	if err := validateReportPeriod(req.Period); err != nil {
+		return nil, err
+	}
+	if !req.ToDate.After(req.FromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if req.ToDate.Sub(req.FromDate) > maxDiscountReportRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+
+	lines, err := uc.saleRepo.GetDiscountLines(uc.database.ReadOnly(ctx), repositories.DiscountReportFilter{
+		TenantID:  tenantID,
+		FromDate:  req.FromDate,
+		ToDate:    req.ToDate,
+		Period:    req.Period,
+		CashierID: req.CashierID,
+		Category:  req.Category,
+	})
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get discount lines")
+		return nil, errors.NewInternalError("failed to generate discount report", err)
+	}
+
+	totals := &discountAccumulator{}
+	periods := map[time.Time]*discountAccumulator{}
+	cashiers := map[uuid.UUID]*discountAccumulator{}
+	cashierNames := map[uuid.UUID]string{}
+	categories := map[string]*discountAccumulator{}
+
+	for _, line := range lines {
+		totals.add(line)
+		accumulatorFor(periods, line.PeriodStart).add(line)
+		accumulatorFor(cashiers, line.CashierID).add(line)
+		accumulatorFor(categories, line.Category).add(line)
+		cashierNames[line.CashierID] = line.CashierName
+	}
+
+	report := &DiscountReportResponse{
+		FromDate:   req.FromDate,
+		ToDate:     req.ToDate,
+		Period:     req.Period,
+		Totals:     totals.summary(),
+		ByPeriod:   make([]*DiscountPeriodStat, 0, len(periods)),
+		ByCashier:  make([]*DiscountCashierStat, 0, len(cashiers)),
+		ByCategory: make([]*DiscountCategoryStat, 0, len(categories)),
+	}
+
+	for periodStart, acc := range periods {
+		report.ByPeriod = append(report.ByPeriod, &DiscountPeriodStat{PeriodStart: periodStart, DiscountSummary: acc.summary()})
+	}
+	sort.Slice(report.ByPeriod, func(i, j int) bool {
+		return report.ByPeriod[i].PeriodStart.Before(report.ByPeriod[j].PeriodStart)
+	})
+
+	for cashierID, acc := range cashiers {
+		report.ByCashier = append(report.ByCashier, &DiscountCashierStat{
+			CashierID:       cashierID,
+			CashierName:     cashierNames[cashierID],
+			DiscountSummary: acc.summary(),
+		})
+	}
+	sort.Slice(report.ByCashier, func(i, j int) bool {
+		return report.ByCashier[i].TotalDiscount.GreaterThan(report.ByCashier[j].TotalDiscount)
+	})
+
+	for category, acc := range categories {
+		report.ByCategory = append(report.ByCategory, &DiscountCategoryStat{Category: category, DiscountSummary: acc.summary()})
+	}
+	sort.Slice(report.ByCategory, func(i, j int) bool {
+		return report.ByCategory[i].TotalDiscount.GreaterThan(report.ByCategory[j].TotalDiscount)
+	})
+
+	return report, nil
+}
+
+// GetTaxSummary reports the taxable base and tax of sales and credit notes per tax rate, with
+// a breakdown per month. Invoices are issued for sales and are not counted separately.
+func (uc *ReportUseCase) GetTaxSummary(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*TaxSummaryResponse, error) {
+	if !toDate.After(fromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to must be after from")
+	}
+	if toDate.Sub(fromDate) > maxTaxSummaryRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+
+	lines, err := uc.saleRepo.GetTaxSummaryLines(uc.database.ReadOnly(ctx), tenantID, fromDate, toDate)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get tax summary lines")
+		return nil, errors.NewInternalError("failed to generate tax summary", err)
+	}
+
+	report := &TaxSummaryResponse{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		ByRate:   []*TaxRateSummary{},
+		ByMonth:  []*TaxMonthSummary{},
+	}
+	rates := map[string]*TaxRateSummary{}
+	months := map[time.Time]*TaxMonthSummary{}
+	monthRates := map[time.Time]map[string]*TaxRateSummary{}
+
+	for _, line := range lines {
+		report.Totals.add(line)
+		taxRateSummaryFor(rates, &report.ByRate, line.TaxRate).add(line)
+
+		month, ok := months[line.Month]
+		if !ok {
+			month = &TaxMonthSummary{Month: line.Month, ByRate: []*TaxRateSummary{}}
+			months[line.Month] = month
+			monthRates[line.Month] = map[string]*TaxRateSummary{}
+			report.ByMonth = append(report.ByMonth, month)
+		}
+		month.add(line)
+		taxRateSummaryFor(monthRates[line.Month], &month.ByRate, line.TaxRate).add(line)
+	}
+
+	sort.Slice(report.ByMonth, func(i, j int) bool {
+		return report.ByMonth[i].Month.Before(report.ByMonth[j].Month)
+	})
+	sortTaxRates(report.ByRate)
+	for _, month := range report.ByMonth {
+		sortTaxRates(month.ByRate)
+	}
+
+	return report, nil
+}
+
+// TaxSummaryCSV renders a tax summary as CSV with one row per month and tax rate, a total row
+// per month and a grand total row
+func TaxSummaryCSV(report *TaxSummaryResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	w.Write([]string{"month", "tax_rate", "sales_count", "sales_taxable_base", "sales_non_taxable_amount", "sales_tax",
+		"credit_note_count", "credit_note_taxable_base", "credit_note_tax", "net_taxable_base", "net_tax"})
+	row := func(month, rate string, summary TaxSummary) {
+		w.Write([]string{
+			month,
+			rate,
+			strconv.Itoa(summary.SalesCount),
+			summary.SalesTaxableBase.StringFixed(2),
+			summary.SalesNonTaxableAmount.StringFixed(2),
+			summary.SalesTax.StringFixed(2),
+			strconv.Itoa(summary.CreditNoteCount),
+			summary.CreditNoteTaxableBase.StringFixed(2),
+			summary.CreditNoteTax.StringFixed(2),
+			summary.NetTaxableBase.StringFixed(2),
+			summary.NetTax.StringFixed(2),
+		})
+	}
+
+	for _, month := range report.ByMonth {
+		label := month.Month.Format("2006-01")
+		for _, rate := range month.ByRate {
+			row(label, rate.TaxRate.String(), rate.TaxSummary)
+		}
+		row(label, "all", month.TaxSummary)
+	}
+	row("total", "all", report.Totals)
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.NewInternalError("failed to render tax summary", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Helper functions
+
+// detectAnomalies flags unusual revenue, cancellations, discounting and stock-outs of best sellers
+func detectAnomalies(summary *DailySummaryResponse) []*Anomaly {
+	anomalies := []*Anomaly{}
+	revenue := summary.Sales.TotalRevenue
+	average := summary.TrailingAverageRevenue
+
+	if average.GreaterThan(decimal.Zero) {
+		switch {
+		case summary.Sales.CompletedSales == 0:
+			anomalies = append(anomalies, &Anomaly{
+				Type:     "no_sales",
+				Severity: AnomalySeverityWarning,
+				Message:  "No sales were completed, against a 7-day average of " + average.StringFixed(2) + " per day.",
+			})
+		case revenue.LessThan(average.Mul(anomalyRevenueDropRatio)):
+			anomalies = append(anomalies, &Anomaly{
+				Type:     "revenue_drop",
+				Severity: AnomalySeverityWarning,
+				Message:  "Revenue was " + percentOf(revenue, average).StringFixed(0) + "% of the 7-day daily average.",
+			})
+		case revenue.GreaterThan(average.Mul(anomalyRevenueSpikeRatio)):
+			anomalies = append(anomalies, &Anomaly{
+				Type:     "revenue_spike",
+				Severity: AnomalySeverityInfo,
+				Message:  "Revenue was " + percentOf(revenue, average).StringFixed(0) + "% of the 7-day daily average.",
+			})
+		}
+	}
+
+	if summary.Sales.TotalSales >= anomalyCancellationMinSales {
+		cancellationRate := percentOf(decimal.NewFromInt(int64(summary.Sales.CancelledSales)), decimal.NewFromInt(int64(summary.Sales.TotalSales)))
+		if cancellationRate.GreaterThan(anomalyCancellationRatePct) {
+			anomalies = append(anomalies, &Anomaly{
+				Type:     "high_cancellations",
+				Severity: AnomalySeverityWarning,
+				Message:  cancellationRate.StringFixed(0) + "% of sales were cancelled.",
+			})
+		}
+	}
+
+	if summary.Discounts.DiscountRate.GreaterThan(anomalyDiscountRatePct) {
+		anomalies = append(anomalies, &Anomaly{
+			Type:     "high_discounts",
+			Severity: AnomalySeverityWarning,
+			Message:  summary.Discounts.DiscountRate.StringFixed(1) + "% of list revenue was given away in discounts, promotions and price overrides.",
+		})
+	}
+
+	outOfStock := make(map[uuid.UUID]bool, len(summary.LowStockItems))
+	for _, item := range summary.LowStockItems {
+		if item.AvailableQty <= 0 {
+			outOfStock[item.ProductID] = true
+		}
+	}
+	for _, product := range summary.TopProducts {
+		if outOfStock[product.ProductID] {
+			anomalies = append(anomalies, &Anomaly{
+				Type:     "top_seller_out_of_stock",
+				Severity: AnomalySeverityWarning,
+				Message:  product.ProductName + " was one of the day's best sellers and is now out of stock.",
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// discountAccumulator sums discount report lines
+type discountAccumulator struct {
+	itemsSold             int
+	listRevenue           decimal.Decimal
+	itemRevenue           decimal.Decimal
+	costOfGoods           decimal.Decimal
+	manualDiscount        decimal.Decimal
+	promotionDiscount     decimal.Decimal
+	priceOverrideDiscount decimal.Decimal
+}
+
+func accumulatorFor[K comparable](accumulators map[K]*discountAccumulator, key K) *discountAccumulator {
+	acc, ok := accumulators[key]
+	if !ok {
+		acc = &discountAccumulator{}
+		accumulators[key] = acc
+	}
+	return acc
+}
+
+func (a *discountAccumulator) add(line *repositories.DiscountReportLine) {
+	a.itemsSold += line.ItemsSold
+	a.listRevenue = a.listRevenue.Add(line.ListRevenue)
+	a.itemRevenue = a.itemRevenue.Add(line.ItemRevenue)
+	a.costOfGoods = a.costOfGoods.Add(line.CostOfGoods)
+	a.manualDiscount = a.manualDiscount.Add(line.ManualDiscount)
+	a.promotionDiscount = a.promotionDiscount.Add(line.PromotionDiscount)
+	a.priceOverrideDiscount = a.priceOverrideDiscount.Add(line.PriceOverrideDiscount)
+}
+
+func (a *discountAccumulator) summary() DiscountSummary {
+	netRevenue := a.itemRevenue.Sub(a.manualDiscount)
+	totalDiscount := a.manualDiscount.Add(a.promotionDiscount).Add(a.priceOverrideDiscount)
+	listMargin := marginPercent(a.listRevenue, a.costOfGoods)
+	netMargin := marginPercent(netRevenue, a.costOfGoods)
+
+	return DiscountSummary{
+		ItemsSold:             a.itemsSold,
+		ListRevenue:           a.listRevenue.Round(2),
+		ManualDiscount:        a.manualDiscount.Round(2),
+		PromotionDiscount:     a.promotionDiscount.Round(2),
+		PriceOverrideDiscount: a.priceOverrideDiscount.Round(2),
+		TotalDiscount:         totalDiscount.Round(2),
+		NetRevenue:            netRevenue.Round(2),
+		CostOfGoods:           a.costOfGoods.Round(2),
+		DiscountRate:          percentOf(totalDiscount, a.listRevenue),
+		ListMarginPercent:     listMargin,
+		NetMarginPercent:      netMargin,
+		MarginImpact:          listMargin.Sub(netMargin),
+	}
+}
+
+// add adds a tax summary line to the totals
+func (t *TaxSummary) add(line *repositories.TaxSummaryLine) {
+	switch line.DocumentType {
+	case repositories.TaxDocumentSale:
+		t.SalesCount += line.DocumentCount
+		t.SalesTaxableBase = t.SalesTaxableBase.Add(line.TaxableBase)
+		t.SalesNonTaxableAmount = t.SalesNonTaxableAmount.Add(line.NonTaxableAmount)
+		t.SalesTax = t.SalesTax.Add(line.TaxAmount)
+	case repositories.TaxDocumentCreditNote:
+		t.CreditNoteCount += line.DocumentCount
+		t.CreditNoteTaxableBase = t.CreditNoteTaxableBase.Add(line.TaxableBase)
+		t.CreditNoteTax = t.CreditNoteTax.Add(line.TaxAmount)
+	}
+	t.NetTaxableBase = t.SalesTaxableBase.Sub(t.CreditNoteTaxableBase)
+	t.NetTax = t.SalesTax.Sub(t.CreditNoteTax)
+}
+
+// taxRateSummaryFor returns the summary of a tax rate, adding it to the list when it is new.
+// Rates are keyed by their text as decimals are not comparable.
+func taxRateSummaryFor(summaries map[string]*TaxRateSummary, list *[]*TaxRateSummary, rate decimal.Decimal) *TaxRateSummary {
+	key := rate.String()
+	summary, ok := summaries[key]
+	if !ok {
+		summary = &TaxRateSummary{TaxRate: rate}
+		summaries[key] = summary
+		*list = append(*list, summary)
+	}
+	return summary
+}
+
+// sortTaxRates orders tax rate summaries from the lowest rate up
+func sortTaxRates(rates []*TaxRateSummary) {
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].TaxRate.LessThan(rates[j].TaxRate)
+	})
+}
+
+// marginPercent returns the gross margin of revenue over cost as a percentage
+func marginPercent(revenue, cost decimal.Decimal) decimal.Decimal {
+	return percentOf(revenue.Sub(cost), revenue)
+}
+
+// percentOf returns part as a percentage of whole, rounded to two decimals
+func percentOf(part, whole decimal.Decimal) decimal.Decimal {
+	if whole.IsZero() {
+		return decimal.Zero
+	}
+	return part.Mul(decimal.NewFromInt(100)).Div(whole).Round(2)
+}
+
+// prepareSummaries makes sure every day of a report's date range is summarized, recomputing
+// the whole range when asked to and otherwise only the days missing a summary. It reports
+// whether any summaries were written. Missing days are looked up on the read replica; a
+// day the replica has not caught up on yet is summarized again, which is harmless.
+func (uc *ReportUseCase) prepareSummaries(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	fromDate, toDate time.Time,
+	recompute bool,
+	missingDays func(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]time.Time, error),
+	refresh func(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) error,
+) (bool, error) {
+	if recompute {
+		return true, refresh(ctx, tenantID, fromDate, toDate)
+	}
+
+	missing, err := missingDays(uc.database.ReadOnly(ctx), tenantID, fromDate, toDate)
+	if err != nil {
+		return false, err
+	}
+	if len(missing) == 0 {
+		return false, nil
+	}
+
+	// Missing days are days no report has asked for yet, usually one run of them, so
+	// summarize from the first to the last rather than one by one
+	return true, refresh(ctx, tenantID, missing[0], missing[len(missing)-1].AddDate(0, 0, 1))
+}
+
+// summaryReader returns the context a report's summaries are read with: the read replica
+// unless summaries were just written, which the replica may not have yet
+func (uc *ReportUseCase) summaryReader(ctx context.Context, refreshed bool) context.Context {
+	if refreshed {
+		return ctx
+	}
+	return uc.database.ReadOnly(ctx)
+}
+
+// validateSummaryReportRange validates the date range of a report read from daily summaries
+func validateSummaryReportRange(fromDate, toDate time.Time) error {
+	if !toDate.After(fromDate) {
+		return errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if toDate.Sub(fromDate) > maxSalesReportRange {
+		return errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+	return nil
+}
+
+// validateReportPeriod validates a report grouping period
+func validateReportPeriod(period repositories.ReportPeriod) error {
+	switch period {
+	case repositories.ReportPeriodDay, repositories.ReportPeriodWeek, repositories.ReportPeriodMonth:
+		return nil
+	default:
+		return errors.NewValidationError("invalid period", "period must be one of: day, week, month")
+	}
+}
@@ -0,0 +1,509 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const (
+	// retentionPurgeBatchSize is the number of records deleted per statement, keeping locks short
+	retentionPurgeBatchSize = 1000
+	// retentionPurgeMaxBatches caps the batches purged per policy in one run; the rest is left to the next run
+	retentionPurgeMaxBatches = 50
+)
+
+// RetentionUseCase handles per-tenant data retention policies, their purge job, retention holds
+// and the recovery of soft-deleted records before they are purged
+type RetentionUseCase struct {
+	policyRepo  repositories.RetentionPolicyRepository
+	holdRepo    repositories.RetentionHoldRepository
+	deletedRepo repositories.DeletedRecordRepository
+	audit       ports.AuditPort
+	logger      logger.Logger
+}
+
+// NewRetentionUseCase creates a new retention use case
+func NewRetentionUseCase(
+	policyRepo repositories.RetentionPolicyRepository,
+	holdRepo repositories.RetentionHoldRepository,
+	deletedRepo repositories.DeletedRecordRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *RetentionUseCase {
+	return &RetentionUseCase{
+		policyRepo:  policyRepo,
+		holdRepo:    holdRepo,
+		deletedRepo: deletedRepo,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// UpdateRetentionPolicyRequest represents update retention policy request
+type UpdateRetentionPolicyRequest struct {
+	RetentionDays int  `json:"retention_days" binding:"required"`
+	IsEnabled     bool `json:"is_enabled"`
+}
+
+// CreateRetentionHoldRequest represents create retention hold request
+type CreateRetentionHoldRequest struct {
+	Entity   entities.RetentionEntity `json:"entity" binding:"required"`
+	RecordID uuid.UUID                `json:"record_id" binding:"required"`
+	Reason   string                   `json:"reason" binding:"required"`
+}
+
+// RetentionPolicyResponse represents the retention policy of one entity
+type RetentionPolicyResponse struct {
+	Entity        entities.RetentionEntity `json:"entity"`
+	IsConfigured  bool                     `json:"is_configured"` // Unconfigured entities are kept forever
+	IsEnabled     bool                     `json:"is_enabled"`
+	RetentionDays int                      `json:"retention_days,omitempty"`
+	MinimumDays   int                      `json:"minimum_days"`
+	LastPurgedAt  *time.Time               `json:"last_purged_at,omitempty"`
+	UpdatedAt     *time.Time               `json:"updated_at,omitempty"`
+}
+
+// RetentionPreviewLine represents the records one policy would purge if it ran now
+type RetentionPreviewLine struct {
+	Entity        entities.RetentionEntity `json:"entity"`
+	IsEnabled     bool                     `json:"is_enabled"`
+	RetentionDays int                      `json:"retention_days"`
+	Cutoff        time.Time                `json:"cutoff"`
+	RecordCount   int64                    `json:"record_count"`
+}
+
+// RetentionPreviewResponse represents a dry run of the purge job for a tenant
+type RetentionPreviewResponse struct {
+	GeneratedAt time.Time               `json:"generated_at"`
+	Lines       []*RetentionPreviewLine `json:"lines"`
+}
+
+// DeletedRecordListResponse represents a page of soft-deleted records
+type DeletedRecordListResponse struct {
+	Records    []*entities.DeletedRecord `json:"records"`
+	Pagination utils.PaginationInfo      `json:"pagination"`
+}
+
+// GetPolicies returns the retention policy of every supported entity
+func (uc *RetentionUseCase) GetPolicies(ctx context.Context, tenantID uuid.UUID) ([]*RetentionPolicyResponse, error) {
+	policies, err := uc.policyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get retention policies")
+		return nil, errors.NewInternalError("failed to get retention policies", err)
+	}
+
+	byEntity := make(map[entities.RetentionEntity]*entities.RetentionPolicy, len(policies))
+	for _, policy := range policies {
+		byEntity[policy.Entity] = policy
+	}
+
+	responses := make([]*RetentionPolicyResponse, 0, len(entities.RetentionEntities()))
+	for _, entity := range entities.RetentionEntities() {
+		response := &RetentionPolicyResponse{
+			Entity:      entity,
+			MinimumDays: entity.MinimumDays(),
+		}
+		if policy, ok := byEntity[entity]; ok {
+			response.IsConfigured = true
+			response.IsEnabled = policy.IsEnabled
+			response.RetentionDays = policy.RetentionDays
+			response.LastPurgedAt = policy.LastPurgedAt
+			response.UpdatedAt = &policy.UpdatedAt
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// UpdatePolicy creates or updates the retention policy of an entity
+func (uc *RetentionUseCase) UpdatePolicy(ctx context.Context, tenantID, userID uuid.UUID, entity entities.RetentionEntity, req UpdateRetentionPolicyRequest) (*entities.RetentionPolicy, error) {
+	if !entity.IsValid() {
+		return nil, errors.NewValidationError("invalid retention entity", "unsupported entity: "+string(entity))
+	}
+
+	policy, err := uc.getPolicy(ctx, tenantID, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldValue map[string]interface{}
+	if policy != nil {
+		oldValue = map[string]interface{}{
+			"retention_days": policy.RetentionDays,
+			"is_enabled":     policy.IsEnabled,
+		}
+		err = policy.Update(req.RetentionDays, req.IsEnabled, userID)
+	} else {
+		policy, err = entities.NewRetentionPolicy(tenantID, entity, req.RetentionDays, req.IsEnabled, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.policyRepo.Save(ctx, policy); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"entity": entity,
+			"error":  err.Error(),
+		}).Error("Failed to save retention policy")
+		return nil, errors.NewInternalError("failed to save retention policy", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "retention_policy",
+		ResourceID: policy.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"entity":         policy.Entity,
+			"retention_days": policy.RetentionDays,
+			"is_enabled":     policy.IsEnabled,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":      tenantID,
+		"entity":         entity,
+		"retention_days": policy.RetentionDays,
+		"is_enabled":     policy.IsEnabled,
+	}).Info("Retention policy updated")
+
+	return policy, nil
+}
+
+// PreviewPurge reports how many records each configured policy would purge if the job ran now,
+// whether or not the policy is enabled, so a policy can be checked before it is switched on
+func (uc *RetentionUseCase) PreviewPurge(ctx context.Context, tenantID uuid.UUID) (*RetentionPreviewResponse, error) {
+	policies, err := uc.policyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get retention policies")
+		return nil, errors.NewInternalError("failed to preview retention purge", err)
+	}
+
+	now := time.Now()
+	preview := &RetentionPreviewResponse{
+		GeneratedAt: now,
+		Lines:       make([]*RetentionPreviewLine, 0, len(policies)),
+	}
+
+	for _, policy := range policies {
+		cutoff := policy.Cutoff(now)
+		count, err := uc.policyRepo.CountExpired(ctx, tenantID, policy.Entity, cutoff)
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"entity": policy.Entity,
+				"error":  err.Error(),
+			}).Error("Failed to count expired records")
+			return nil, errors.NewInternalError("failed to preview retention purge", err)
+		}
+
+		preview.Lines = append(preview.Lines, &RetentionPreviewLine{
+			Entity:        policy.Entity,
+			IsEnabled:     policy.IsEnabled,
+			RetentionDays: policy.RetentionDays,
+			Cutoff:        cutoff,
+			RecordCount:   count,
+		})
+	}
+
+	return preview, nil
+}
+
+// PurgeExpired enforces every enabled retention policy across tenants. It is run periodically by the scheduler.
+func (uc *RetentionUseCase) PurgeExpired(ctx context.Context) error {
+	policies, err := uc.policyRepo.ListEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list retention policies: %w", err)
+	}
+
+	for _, policy := range policies {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := uc.purgePolicy(ctx, policy); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": policy.TenantID,
+				"entity":    policy.Entity,
+				"error":     err.Error(),
+			}).Error("Failed to enforce retention policy")
+		}
+	}
+
+	return nil
+}
+
+// purgePolicy deletes a tenant's expired records of one entity in batches
+func (uc *RetentionUseCase) purgePolicy(ctx context.Context, policy *entities.RetentionPolicy) error {
+	now := time.Now()
+	cutoff := policy.Cutoff(now)
+
+	var deleted int64
+	for batch := 0; batch < retentionPurgeMaxBatches; batch++ {
+		count, err := uc.policyRepo.PurgeExpired(ctx, policy.TenantID, policy.Entity, cutoff, retentionPurgeBatchSize)
+		deleted += count
+		if err != nil {
+			return err
+		}
+		if count < retentionPurgeBatchSize {
+			break
+		}
+	}
+
+	policy.MarkPurged(now)
+	if err := uc.policyRepo.Save(ctx, policy); err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return nil
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     policy.UpdatedBy,
+		Action:     "purge",
+		Resource:   "retention_policy",
+		ResourceID: policy.ID.String(),
+		NewValue: map[string]interface{}{
+			"entity":         policy.Entity,
+			"retention_days": policy.RetentionDays,
+			"cutoff":         cutoff,
+			"deleted":        deleted,
+		},
+		Timestamp: now,
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": policy.TenantID,
+		"entity":    policy.Entity,
+		"cutoff":    cutoff,
+		"deleted":   deleted,
+	}).Info("Expired records purged")
+
+	return nil
+}
+
+// PlaceHold exempts a record from retention purges while it is under dispute or audit
+func (uc *RetentionUseCase) PlaceHold(ctx context.Context, tenantID, userID uuid.UUID, req CreateRetentionHoldRequest) (*entities.RetentionHold, error) {
+	hold, err := entities.NewRetentionHold(tenantID, req.Entity, req.RecordID, req.Reason, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.holdRepo.GetActiveByRecord(ctx, tenantID, req.Entity, req.RecordID)
+	if err == nil {
+		return nil, errors.NewConflictError("record is already on hold")
+	}
+	if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get retention hold")
+		return nil, errors.NewInternalError("failed to place retention hold", err)
+	}
+
+	if err := uc.holdRepo.Create(ctx, hold); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"entity":    req.Entity,
+			"record_id": req.RecordID,
+			"error":     err.Error(),
+		}).Error("Failed to create retention hold")
+		return nil, errors.NewInternalError("failed to place retention hold", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "place_hold",
+		Resource:   "retention_hold",
+		ResourceID: hold.ID.String(),
+		NewValue: map[string]interface{}{
+			"entity":    hold.Entity,
+			"record_id": hold.RecordID,
+			"reason":    hold.Reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"hold_id":   hold.ID,
+		"entity":    hold.Entity,
+		"record_id": hold.RecordID,
+	}).Info("Retention hold placed")
+
+	return hold, nil
+}
+
+// ReleaseHold lifts a retention hold
+func (uc *RetentionUseCase) ReleaseHold(ctx context.Context, tenantID, userID, holdID uuid.UUID) (*entities.RetentionHold, error) {
+	hold, err := uc.holdRepo.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("retention hold")
+	}
+	if hold.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("retention hold")
+	}
+
+	if err := hold.Release(userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.holdRepo.Update(ctx, hold); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"hold_id": holdID,
+			"error":   err.Error(),
+		}).Error("Failed to update retention hold")
+		return nil, errors.NewInternalError("failed to release retention hold", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "release_hold",
+		Resource:   "retention_hold",
+		ResourceID: hold.ID.String(),
+		OldValue: map[string]interface{}{
+			"entity":    hold.Entity,
+			"record_id": hold.RecordID,
+			"reason":    hold.Reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"hold_id": hold.ID,
+		"user_id": userID,
+	}).Info("Retention hold released")
+
+	return hold, nil
+}
+
+// ListHolds lists the retention holds of a tenant
+func (uc *RetentionUseCase) ListHolds(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]*entities.RetentionHold, error) {
+	holds, err := uc.holdRepo.GetByTenant(ctx, tenantID, activeOnly)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list retention holds")
+		return nil, errors.NewInternalError("failed to list retention holds", err)
+	}
+
+	return holds, nil
+}
+
+// ListDeletedRecords lists the tenant's soft-deleted sales or invoices with when each will be
+// purged under the tenant's retention policy
+func (uc *RetentionUseCase) ListDeletedRecords(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, pagination utils.PaginationInfo) (*DeletedRecordListResponse, error) {
+	if !entity.IsRestorable() {
+		return nil, errors.NewValidationError("invalid retention entity", "records of "+string(entity)+" cannot be restored")
+	}
+
+	records, paginationResult, err := uc.deletedRepo.List(ctx, tenantID, entity, pagination)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"entity": entity,
+			"error":  err.Error(),
+		}).Error("Failed to list deleted records")
+		return nil, errors.NewInternalError("failed to list deleted records", err)
+	}
+
+	policy, err := uc.getPolicy(ctx, tenantID, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		record.SchedulePurge(policy)
+	}
+
+	return &DeletedRecordListResponse{
+		Records:    records,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// RestoreDeletedRecord undoes the soft deletion of a sale or invoice. Only the deletion is undone:
+// stock, payments and numbering are left as they are.
+func (uc *RetentionUseCase) RestoreDeletedRecord(ctx context.Context, tenantID, userID uuid.UUID, entity entities.RetentionEntity, recordID uuid.UUID) error {
+	if !entity.IsRestorable() {
+		return errors.NewValidationError("invalid retention entity", "records of "+string(entity)+" cannot be restored")
+	}
+
+	if err := uc.deletedRepo.Restore(ctx, tenantID, entity, recordID); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"entity":    entity,
+			"record_id": recordID,
+			"error":     err.Error(),
+		}).Error("Failed to restore deleted record")
+		return errors.NewInternalError("failed to restore deleted record", err)
+	}
+
+	resource := "sale"
+	if entity == entities.RetentionEntityDeletedInvoices {
+		resource = "invoice"
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "restore",
+		Resource:   resource,
+		ResourceID: recordID.String(),
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"entity":    entity,
+		"record_id": recordID,
+		"user_id":   userID,
+	}).Info("Deleted record restored")
+
+	return nil
+}
+
+// Helper functions
+
+// getPolicy returns the tenant's policy for an entity, or nil if none is configured
+func (uc *RetentionUseCase) getPolicy(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity) (*entities.RetentionPolicy, error) {
+	policies, err := uc.policyRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get retention policies")
+		return nil, errors.NewInternalError("failed to get retention policies", err)
+	}
+
+	for _, policy := range policies {
+		if policy.Entity == entity {
+			return policy, nil
+		}
+	}
+
+	return nil, nil
+}
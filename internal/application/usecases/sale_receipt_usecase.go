@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// SaleReceiptUseCase handles the receipts of completed sales, for stores that do not issue an
+// invoice for every sale
+type SaleReceiptUseCase struct {
+	saleRepo        repositories.SaleRepository
+	tenantRepo      repositories.TenantRepository
+	printerRepo     repositories.PrinterRepository
+	suppressionRepo repositories.EmailSuppressionRepository
+	pdfService      services.InvoicePDFService
+	emailService    services.EmailService
+	printService    services.PrintService
+	delivery        *DeliveryPolicyUseCase
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewSaleReceiptUseCase creates a new sale receipt use case
+func NewSaleReceiptUseCase(
+	saleRepo repositories.SaleRepository,
+	tenantRepo repositories.TenantRepository,
+	printerRepo repositories.PrinterRepository,
+	suppressionRepo repositories.EmailSuppressionRepository,
+	pdfService services.InvoicePDFService,
+	emailService services.EmailService,
+	printService services.PrintService,
+	delivery *DeliveryPolicyUseCase,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *SaleReceiptUseCase {
+	return &SaleReceiptUseCase{
+		saleRepo:        saleRepo,
+		tenantRepo:      tenantRepo,
+		printerRepo:     printerRepo,
+		suppressionRepo: suppressionRepo,
+		pdfService:      pdfService,
+		emailService:    emailService,
+		printService:    printService,
+		delivery:        delivery,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// SaleReceiptRequest represents sale receipt request. Without email or print the receipt is
+// only rendered, to be downloaded.
+type SaleReceiptRequest struct {
+	Email       bool                      `json:"email,omitempty"`
+	EmailTo     string                    `json:"email_to,omitempty"` // Defaults to the customer's email
+	Print       bool                      `json:"print,omitempty"`
+	PrinterName string                    `json:"printer_name,omitempty"` // Defaults to the terminal's printer
+	Terminal    string                    `json:"terminal,omitempty"`
+	PaperSize   entities.PaperSize        `json:"paper_size,omitempty"` // Defaults to receipt
+	Template    *entities.InvoiceTemplate `json:"template,omitempty"`
+}
+
+// SaleReceiptResult is the rendered receipt of a sale and where it was sent
+type SaleReceiptResult struct {
+	Sale        *entities.Sale `json:"-"`
+	PDF         []byte         `json:"-"`
+	EmailedTo   string         `json:"emailed_to,omitempty"`
+	PrinterName string         `json:"printer_name,omitempty"`
+}
+
+// IssueReceipt renders the receipt of a completed sale and emails and/or prints it as
+// requested. The receipt is rendered from the sale itself, so no invoice is needed.
+func (uc *SaleReceiptUseCase) IssueReceipt(ctx context.Context, tenantID, userID, saleID uuid.UUID, req SaleReceiptRequest) (*SaleReceiptResult, error) {
+	sale, err := uc.saleRepo.GetByID(ctx, saleID)
+	if err != nil || sale.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("sale")
+	}
+	if !sale.IsCompleted() {
+		return nil, errors.NewValidationError("sale not completed", "receipts can only be issued for completed sales")
+	}
+
+	// Resolve where the receipt goes before rendering it
+	result := &SaleReceiptResult{Sale: sale}
+	if req.Email {
+		result.EmailedTo = entities.NormalizeEmail(req.EmailTo)
+		if result.EmailedTo == "" {
+			result.EmailedTo = sale.CustomerEmail
+		}
+		if result.EmailedTo == "" {
+			return nil, errors.NewValidationError("email address is required", "the sale has no customer email to send the receipt to")
+		}
+
+		suppressed, err := uc.suppressionRepo.GetSuppressed(ctx, tenantID, []string{result.EmailedTo})
+		if err != nil {
+			return nil, errors.NewInternalError("failed to check email suppression", err)
+		}
+		if suppressed[result.EmailedTo] {
+			return nil, errors.NewValidationError("email address suppressed", "emails to "+result.EmailedTo+" are suppressed")
+		}
+	}
+
+	template, err := uc.template(req.PaperSize, req.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Print {
+		result.PrinterName = req.PrinterName
+		if result.PrinterName == "" {
+			result.PrinterName, err = uc.terminalPrinterName(ctx, tenantID, req.Terminal, template.PaperSize)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if result.PrinterName == "" {
+			return nil, errors.NewValidationError("printer is required", "no printer is registered for the terminal, give a printer_name")
+		}
+	}
+
+	uc.attachCurrency(ctx, sale)
+	result.PDF, err = uc.pdfService.GenerateSaleReceiptPDF(ctx, sale, template)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to generate sale receipt PDF")
+		return nil, errors.NewInternalError("failed to generate PDF", err)
+	}
+
+	if result.EmailedTo != "" {
+		err = uc.delivery.SendEmail(ctx, tenantID, userID, result.EmailedTo, func(ctx context.Context) error {
+			return uc.emailService.SendSaleReceiptEmail(ctx, sale, result.EmailedTo, result.PDF)
+		})
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id":  saleID,
+				"email_to": result.EmailedTo,
+				"error":    err.Error(),
+			}).Error("Failed to send sale receipt email")
+			return nil, errors.NewInternalError("failed to send receipt email", err)
+		}
+		uc.logReceiptEvent(ctx, userID, "email_receipt", sale, map[string]interface{}{"email_to": result.EmailedTo})
+	}
+
+	if result.PrinterName != "" {
+		if err := uc.printService.PrintSaleReceipt(ctx, sale, template, result.PrinterName); err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return nil, appErr
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id":      saleID,
+				"printer_name": result.PrinterName,
+				"error":        err.Error(),
+			}).Error("Failed to print sale receipt")
+			return nil, errors.NewInternalError("failed to print receipt", err)
+		}
+		uc.logReceiptEvent(ctx, userID, "print_receipt", sale, map[string]interface{}{"printer_name": result.PrinterName})
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":      sale.ID,
+		"sale_number":  sale.SaleNumber,
+		"email_to":     result.EmailedTo,
+		"printer_name": result.PrinterName,
+		"user_id":      userID,
+	}).Info("Sale receipt issued")
+
+	return result, nil
+}
+
+// template returns the given template or the default one of the paper size, a receipt roll
+// unless another size is asked for
+func (uc *SaleReceiptUseCase) template(paperSize entities.PaperSize, template *entities.InvoiceTemplate) (*entities.InvoiceTemplate, error) {
+	if template != nil {
+		if err := uc.pdfService.ValidateTemplate(template); err != nil {
+			return nil, err
+		}
+		return template, nil
+	}
+
+	if paperSize == "" {
+		paperSize = entities.PaperSizeReceipt
+	}
+	return uc.pdfService.GetDefaultTemplate(paperSize), nil
+}
+
+// terminalPrinterName returns the printer a terminal prints the paper size to, or an empty
+// name when none is registered
+func (uc *SaleReceiptUseCase) terminalPrinterName(ctx context.Context, tenantID uuid.UUID, terminal string, paperSize entities.PaperSize) (string, error) {
+	printer, err := uc.printerRepo.FindForTerminal(ctx, tenantID, terminal, paperSize == entities.PaperSizeReceipt)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return "", nil
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to find terminal printer")
+		return "", errors.NewInternalError("failed to find printer", err)
+	}
+
+	return printer.Name, nil
+}
+
+// attachCurrency sets the display of the sale's currency: the tenant's own display for its
+// base currency, the conventional display for other currencies
+func (uc *SaleReceiptUseCase) attachCurrency(ctx context.Context, sale *entities.Sale) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, sale.TenantID)
+	if err != nil {
+		return
+	}
+
+	format := tenant.GetCurrencyFormat()
+	if sale.Currency != "" && sale.Currency != tenant.GetCurrency() {
+		format = entities.DefaultCurrencyFormat(sale.Currency)
+	}
+	sale.CurrencyFormat = &format
+}
+
+// logReceiptEvent records a receipt sent for a sale
+func (uc *SaleReceiptUseCase) logReceiptEvent(ctx context.Context, userID uuid.UUID, action string, sale *entities.Sale, values map[string]interface{}) {
+	values["sale_number"] = sale.SaleNumber
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "sale",
+		ResourceID: sale.ID.String(),
+		NewValue:   values,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+}
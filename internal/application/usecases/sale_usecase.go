@@ -2,6 +2,9 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +18,12 @@ import (
 	"github.com/nicklaros/adol/pkg/utils"
 )
 
+// heldSaleExpiryBatchSize caps how many expired held sales one job run cancels
+const heldSaleExpiryBatchSize = 100
+
+// maxSaleItemBatchSize caps the items added to a sale in one request
+const maxSaleItemBatchSize = 100
+
 // SaleUseCase handles sales management operations
 type SaleUseCase struct {
 	saleRepo          repositories.SaleRepository
@@ -22,7 +31,19 @@ type SaleUseCase struct {
 	productRepo       repositories.ProductRepository
 	stockRepo         repositories.StockRepository
 	stockMovementRepo repositories.StockMovementRepository
+	invoiceRepo       repositories.InvoiceRepository
+	checkoutRuleRepo  repositories.CheckoutRuleRepository
+	surchargeRuleRepo repositories.PaymentSurchargeRuleRepository
+	refundRepo        repositories.RefundRepository
+	tenantRepo        repositories.TenantRepository
+	couponRepo        repositories.CouponRepository
+	summaryRepo       repositories.ReportSummaryRepository
+	taxRates          *TaxRateUseCase
+	marginFloors      *MarginFloorUseCase
+	webhooks          *WebhookUseCase
+	heldSaleTTL       time.Duration
 	database          ports.DatabasePort
+	exchangeRates     ports.ExchangeRatePort
 	audit             ports.AuditPort
 	logger            logger.Logger
 }
@@ -34,7 +55,19 @@ func NewSaleUseCase(
 	productRepo repositories.ProductRepository,
 	stockRepo repositories.StockRepository,
 	stockMovementRepo repositories.StockMovementRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	checkoutRuleRepo repositories.CheckoutRuleRepository,
+	surchargeRuleRepo repositories.PaymentSurchargeRuleRepository,
+	refundRepo repositories.RefundRepository,
+	tenantRepo repositories.TenantRepository,
+	couponRepo repositories.CouponRepository,
+	summaryRepo repositories.ReportSummaryRepository,
+	taxRates *TaxRateUseCase,
+	marginFloors *MarginFloorUseCase,
+	webhooks *WebhookUseCase,
+	heldSaleTTL time.Duration,
 	database ports.DatabasePort,
+	exchangeRates ports.ExchangeRatePort,
 	audit ports.AuditPort,
 	logger logger.Logger,
 ) *SaleUseCase {
@@ -44,7 +77,19 @@ func NewSaleUseCase(
 		productRepo:       productRepo,
 		stockRepo:         stockRepo,
 		stockMovementRepo: stockMovementRepo,
+		invoiceRepo:       invoiceRepo,
+		checkoutRuleRepo:  checkoutRuleRepo,
+		surchargeRuleRepo: surchargeRuleRepo,
+		refundRepo:        refundRepo,
+		tenantRepo:        tenantRepo,
+		couponRepo:        couponRepo,
+		summaryRepo:       summaryRepo,
+		taxRates:          taxRates,
+		marginFloors:      marginFloors,
+		webhooks:          webhooks,
+		heldSaleTTL:       heldSaleTTL,
 		database:          database,
+		exchangeRates:     exchangeRates,
 		audit:             audit,
 		logger:            logger,
 	}
@@ -52,15 +97,43 @@ func NewSaleUseCase(
 
 // CreateSaleRequest represents create sale request
 type CreateSaleRequest struct {
-	CustomerName  string `json:"customer_name,omitempty"`
-	CustomerEmail string `json:"customer_email,omitempty"`
-	CustomerPhone string `json:"customer_phone,omitempty"`
+	CustomerName  string               `json:"customer_name,omitempty"`
+	CustomerEmail string               `json:"customer_email,omitempty"`
+	CustomerPhone string               `json:"customer_phone,omitempty"`
+	Channel       entities.SaleChannel `json:"channel,omitempty"`  // Defaults to in_store
+	Currency      string               `json:"currency,omitempty"` // ISO 4217 code, defaults to the tenant's currency
 }
 
 // AddSaleItemRequest represents add sale item request
 type AddSaleItemRequest struct {
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	ProductID       uuid.UUID  `json:"product_id" validate:"required"`
+	Quantity        int        `json:"quantity" validate:"required,min=1"`
+	SellOutOfSeason bool       `json:"sell_out_of_season,omitempty"` // Sell a product outside its availability window
+	ApprovedBy      *uuid.UUID `json:"-"`                            // Manager who approved selling out of season on a cashier's behalf
+}
+
+// AddSaleItemsRequest represents a request adding several items to a sale at once
+type AddSaleItemsRequest struct {
+	Items []AddSaleItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// SaleItemLineResult represents the outcome of one line of a batch of sale items. Line is the
+// line's 1-based position in the request.
+type SaleItemLineResult struct {
+	Line      int              `json:"line"`
+	ProductID uuid.UUID        `json:"product_id"`
+	Quantity  int              `json:"quantity"`
+	Error     *errors.AppError `json:"error,omitempty"`
+}
+
+// AddSaleItemsResponse represents the outcome of a batch of sale items. The batch is applied
+// only when every line is valid: Sale is then the updated sale, otherwise it is nil and the
+// failed lines carry their errors.
+type AddSaleItemsResponse struct {
+	Sale   *SaleResponse        `json:"sale,omitempty"`
+	Lines  []SaleItemLineResult `json:"lines"`
+	Added  int                  `json:"added"`
+	Failed int                  `json:"failed"`
 }
 
 // UpdateSaleItemRequest represents update sale item request
@@ -69,48 +142,148 @@ type UpdateSaleItemRequest struct {
 	Quantity  int       `json:"quantity" validate:"required,min=1"`
 }
 
-// CompleteSaleRequest represents complete sale request
+// OverrideSaleItemPriceRequest represents override sale item price request
+type OverrideSaleItemPriceRequest struct {
+	ProductID  uuid.UUID       `json:"product_id" validate:"required"`
+	UnitPrice  decimal.Decimal `json:"unit_price" validate:"required"`
+	Reason     string          `json:"reason" validate:"required"`
+	ApprovedBy *uuid.UUID      `json:"-"` // Manager who approved the override on a cashier's behalf
+}
+
+// SetSaleItemSerialNumbersRequest represents the serial numbers captured for a serialized
+// product in a sale
+type SetSaleItemSerialNumbersRequest struct {
+	ProductID     uuid.UUID `json:"product_id" validate:"required"`
+	SerialNumbers []string  `json:"serial_numbers"` // Replaces those captured before, empty clears them
+}
+
+// CompleteSaleRequest represents complete sale request. A sale is paid either with a single
+// PaidAmount and PaymentMethod or split across several Payments.
 type CompleteSaleRequest struct {
-	PaidAmount     decimal.Decimal        `json:"paid_amount" validate:"required"`
-	PaymentMethod  entities.PaymentMethod `json:"payment_method" validate:"required"`
+	PaidAmount     decimal.Decimal        `json:"paid_amount,omitempty" validate:"required_without=Payments"`
+	PaymentMethod  entities.PaymentMethod `json:"payment_method,omitempty" validate:"required_without=Payments"`
+	Payments       []entities.PaymentLine `json:"payments,omitempty"`
 	DiscountAmount decimal.Decimal        `json:"discount_amount,omitempty"`
+	CouponCode     string                 `json:"coupon_code,omitempty"` // Its discount is added to the discount amount
 	TaxPercentage  decimal.Decimal        `json:"tax_percentage,omitempty"`
 	Notes          string                 `json:"notes,omitempty"`
+
+	// PaymentReference is the gateway payment that paid the sale, set only when a payment
+	// gateway completes it
+	PaymentReference string `json:"-"`
+}
+
+// PreviewSaleTotalRequest represents the discount, coupon, tax and payment method the total due
+// on a pending sale is worked out with
+type PreviewSaleTotalRequest struct {
+	PaymentMethod  entities.PaymentMethod `json:"payment_method" validate:"required"`
+	DiscountAmount decimal.Decimal        `json:"discount_amount,omitempty"`
+	CouponCode     string                 `json:"coupon_code,omitempty"`
+	TaxPercentage  decimal.Decimal        `json:"tax_percentage,omitempty"`
+}
+
+// UpdateSaleCustomerRequest represents update sale customer request
+type UpdateSaleCustomerRequest struct {
+	CustomerName  string `json:"customer_name,omitempty"`
+	CustomerEmail string `json:"customer_email,omitempty"`
+	CustomerPhone string `json:"customer_phone,omitempty"`
+}
+
+// HoldSaleRequest represents hold sale request
+type HoldSaleRequest struct {
+	Label string `json:"label,omitempty"` // Helps the cashier find the parked sale, e.g. the customer's name
+}
+
+// RefundSaleRequest represents refund sale request. Without items, everything not yet
+// refunded is refunded.
+type RefundSaleRequest struct {
+	Items               []entities.RefundLine  `json:"items,omitempty"`
+	RefundMethod        entities.PaymentMethod `json:"refund_method,omitempty"` // Defaults to the sale's payment method
+	Reason              string                 `json:"reason" validate:"required"`
+	ReceiptToken        string                 `json:"receipt_token,omitempty"`         // Scanned from the receipt QR code
+	ReturnOutsideWindow bool                   `json:"return_outside_window,omitempty"` // Refund after the tenant's return window
+	ApprovedBy          *uuid.UUID             `json:"-"`                               // Manager who approved the refund on a cashier's behalf
+}
+
+// ReturnLookupRequest represents the lookup of a sale brought back for a return
+type ReturnLookupRequest struct {
+	SaleNumber   string `form:"sale_number" validate:"required"`
+	ReceiptToken string `form:"receipt_token"` // Scanned from the receipt QR code
+}
+
+// ReturnLookupResponse represents a sale brought back for a return, with what can still be
+// returned and until when
+type ReturnLookupResponse struct {
+	Sale               *SaleResponse `json:"sale"`
+	ReceiptVerified    bool          `json:"receipt_verified"`
+	ReturnWindowDays   int           `json:"return_window_days,omitempty"`
+	ReturnDeadline     *time.Time    `json:"return_deadline,omitempty"`
+	WithinReturnWindow bool          `json:"within_return_window"` // Returns outside the window need a manager override
+	Returnable         bool          `json:"returnable"`
 }
 
 // SaleResponse represents sale response
 type SaleResponse struct {
-	ID             uuid.UUID              `json:"id"`
-	SaleNumber     string                 `json:"sale_number"`
-	CustomerName   string                 `json:"customer_name,omitempty"`
-	CustomerEmail  string                 `json:"customer_email,omitempty"`
-	CustomerPhone  string                 `json:"customer_phone,omitempty"`
-	Items          []*SaleItemResponse    `json:"items"`
-	Subtotal       decimal.Decimal        `json:"subtotal"`
-	TaxAmount      decimal.Decimal        `json:"tax_amount"`
-	DiscountAmount decimal.Decimal        `json:"discount_amount"`
-	TotalAmount    decimal.Decimal        `json:"total_amount"`
-	PaidAmount     decimal.Decimal        `json:"paid_amount"`
-	ChangeAmount   decimal.Decimal        `json:"change_amount"`
-	PaymentMethod  entities.PaymentMethod `json:"payment_method,omitempty"`
-	Status         entities.SaleStatus    `json:"status"`
-	Notes          string                 `json:"notes,omitempty"`
-	CreatedAt      time.Time              `json:"created_at"`
-	UpdatedAt      time.Time              `json:"updated_at"`
-	CreatedBy      uuid.UUID              `json:"created_by"`
-	CompletedAt    *time.Time             `json:"completed_at,omitempty"`
+	ID              uuid.UUID                   `json:"id"`
+	SaleNumber      string                      `json:"sale_number"`
+	CustomerName    string                      `json:"customer_name,omitempty"`
+	CustomerEmail   string                      `json:"customer_email,omitempty"`
+	CustomerPhone   string                      `json:"customer_phone,omitempty"`
+	Items           []*SaleItemResponse         `json:"items"`
+	Subtotal        decimal.Decimal             `json:"subtotal"`
+	TaxRate         decimal.Decimal             `json:"tax_rate"` // Items without a tax rate of their own are taxed at it
+	TaxAmount       decimal.Decimal             `json:"tax_amount"`
+	TaxInclusive    bool                        `json:"tax_inclusive"` // Item prices include tax
+	TaxSummary      []entities.TaxRateSummary   `json:"tax_summary"`
+	DiscountAmount  decimal.Decimal             `json:"discount_amount"`
+	SurchargeAmount decimal.Decimal             `json:"surcharge_amount"`
+	SurchargeLabel  string                      `json:"surcharge_label,omitempty"`
+	TotalAmount     decimal.Decimal             `json:"total_amount"`
+	PaidAmount      decimal.Decimal             `json:"paid_amount"`
+	ChangeAmount    decimal.Decimal             `json:"change_amount"`
+	PaymentMethod   entities.PaymentMethod      `json:"payment_method,omitempty"`
+	Channel         entities.SaleChannel        `json:"channel"`
+	Status          entities.SaleStatus         `json:"status"`
+	Payments        []entities.SalePayment      `json:"payments,omitempty"`
+	Notes           string                      `json:"notes,omitempty"`
+	Currency        string                      `json:"currency,omitempty"` // Amounts are in it
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+	CreatedBy       uuid.UUID                   `json:"created_by"`
+	CompletedAt     *time.Time                  `json:"completed_at,omitempty"`
+	HeldAt          *time.Time                  `json:"held_at,omitempty"`
+	HeldBy          *uuid.UUID                  `json:"held_by,omitempty"`
+	HoldExpiresAt   *time.Time                  `json:"hold_expires_at,omitempty"`
+	HoldLabel       string                      `json:"hold_label,omitempty"`
+	ReceiptToken    string                      `json:"receipt_token,omitempty"` // Printed as a QR code on the receipt to verify returns
+	ExchangeRate    *entities.ExchangeRate      `json:"exchange_rate,omitempty"` // Locked when the sale was created
+	CouponCode      string                      `json:"coupon_code,omitempty"`
+	CouponDiscount  *decimal.Decimal            `json:"coupon_discount,omitempty"` // Part of the discount amount given by the coupon
+	MarginWarnings  []*entities.MarginViolation `json:"margin_warnings,omitempty"` // Items priced below their category's margin floor
+
+	// PaymentReference is the gateway payment that paid the sale, e.g. "stripe:pi_123"
+	PaymentReference string `json:"payment_reference,omitempty"`
 }
 
 // SaleItemResponse represents sale item response
 type SaleItemResponse struct {
-	ID          uuid.UUID       `json:"id"`
-	ProductID   uuid.UUID       `json:"product_id"`
-	ProductSKU  string          `json:"product_sku"`
-	ProductName string          `json:"product_name"`
-	Quantity    int             `json:"quantity"`
-	UnitPrice   decimal.Decimal `json:"unit_price"`
-	TotalPrice  decimal.Decimal `json:"total_price"`
-	CreatedAt   time.Time       `json:"created_at"`
+	ID                 uuid.UUID                    `json:"id"`
+	ProductID          uuid.UUID                    `json:"product_id"`
+	ProductSKU         string                       `json:"product_sku"`
+	ProductName        string                       `json:"product_name"`
+	Quantity           int                          `json:"quantity"`
+	UnitPrice          decimal.Decimal              `json:"unit_price"`
+	TotalPrice         decimal.Decimal              `json:"total_price"`
+	ListPrice          decimal.Decimal              `json:"list_price"`
+	PriceSource        entities.SaleItemPriceSource `json:"price_source"`
+	OverrideReason     string                       `json:"override_reason,omitempty"`
+	TaxRateID          *uuid.UUID                   `json:"tax_rate_id,omitempty"`
+	TaxRate            decimal.Decimal              `json:"tax_rate"`
+	TaxAmount          decimal.Decimal              `json:"tax_amount"`
+	ReturnedQuantity   int                          `json:"returned_quantity"`
+	ReturnableQuantity int                          `json:"returnable_quantity"`
+	SerialNumbers      []string                     `json:"serial_numbers,omitempty"`
+	CreatedAt          time.Time                    `json:"created_at"`
 }
 
 // SaleListResponse represents sale list response
@@ -120,12 +293,13 @@ type SaleListResponse struct {
 }
 
 // CreateSale creates a new sale
-func (uc *SaleUseCase) CreateSale(ctx context.Context, userID uuid.UUID, req CreateSaleRequest) (*SaleResponse, error) {
+func (uc *SaleUseCase) CreateSale(ctx context.Context, tenantID, userID uuid.UUID, req CreateSaleRequest) (*SaleResponse, error) {
 	// Generate sale number
 	saleNumber := utils.GenerateSaleNumber()
 
 	// Create sale entity
 	sale, err := entities.NewSale(
+		tenantID,
 		saleNumber,
 		req.CustomerName,
 		req.CustomerEmail,
@@ -136,6 +310,16 @@ func (uc *SaleUseCase) CreateSale(ctx context.Context, userID uuid.UUID, req Cre
 		return nil, err
 	}
 
+	if req.Channel != "" {
+		if err := sale.SetChannel(req.Channel); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.applyTenantPricing(ctx, tenantID, sale, req.Currency); err != nil {
+		return nil, err
+	}
+
 	// Save sale
 	if err := uc.saleRepo.Create(ctx, sale); err != nil {
 		uc.logger.WithFields(map[string]interface{}{
@@ -158,6 +342,7 @@ func (uc *SaleUseCase) CreateSale(ctx context.Context, userID uuid.UUID, req Cre
 			"customer_name":  sale.CustomerName,
 			"customer_email": sale.CustomerEmail,
 			"customer_phone": sale.CustomerPhone,
+			"channel":        sale.Channel,
 		},
 		Timestamp: time.Now(),
 		Success:   true,
@@ -183,6 +368,21 @@ func (uc *SaleUseCase) GetSale(ctx context.Context, saleID uuid.UUID) (*SaleResp
 	return uc.toSaleResponse(sale), nil
 }
 
+// GetSalesByIDs retrieves the sales with the given IDs in one query, keyed by sale ID
+func (uc *SaleUseCase) GetSalesByIDs(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID]*SaleResponse, error) {
+	sales, err := uc.saleRepo.GetByIDs(uc.database.ReadOnly(ctx), saleIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get sales")
+		return nil, errors.NewInternalError("failed to get sales", err)
+	}
+
+	responses := make(map[uuid.UUID]*SaleResponse, len(sales))
+	for id, sale := range sales {
+		responses[id] = uc.toSaleResponse(sale)
+	}
+	return responses, nil
+}
+
 // GetSaleBySaleNumber retrieves a sale by sale number
 func (uc *SaleUseCase) GetSaleBySaleNumber(ctx context.Context, saleNumber string) (*SaleResponse, error) {
 	sale, err := uc.saleRepo.GetBySaleNumber(ctx, saleNumber)
@@ -214,36 +414,7 @@ func (uc *SaleUseCase) AddSaleItem(ctx context.Context, userID, saleID uuid.UUID
 		return nil, errors.NewValidationError("invalid sale status", "can only modify pending sales")
 	}
 
-	// Get product
-	product, err := tx.GetProductRepository().GetByID(ctx, req.ProductID)
-	if err != nil {
-		return nil, errors.NewNotFoundError("product")
-	}
-
-	// Check if product is active
-	if !product.IsActive() {
-		return nil, errors.NewValidationError("product not active", "cannot add inactive product to sale")
-	}
-
-	// Check stock availability
-	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
-	if err != nil {
-		return nil, errors.NewNotFoundError("stock record")
-	}
-
-	if !stock.CanFulfillOrder(req.Quantity) {
-		return nil, errors.NewInsufficientStockError(product.Name, stock.AvailableQty, req.Quantity)
-	}
-
-	// Create sale item
-	saleItem, err := entities.NewSaleItem(
-		saleID,
-		req.ProductID,
-		product.SKU,
-		product.Name,
-		req.Quantity,
-		product.Price,
-	)
+	saleItem, product, outOfSeason, err := uc.newSaleItem(ctx, tx, sale, req, req.Quantity)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +449,10 @@ func (uc *SaleUseCase) AddSaleItem(ctx context.Context, userID, saleID uuid.UUID
 		return nil, errors.NewInternalError("failed to commit transaction", err)
 	}
 
+	if outOfSeason {
+		uc.logSellUnavailable(ctx, userID, saleID, product, req)
+	}
+
 	uc.logger.WithFields(map[string]interface{}{
 		"sale_id":    saleID,
 		"product_id": req.ProductID,
@@ -285,7 +460,234 @@ func (uc *SaleUseCase) AddSaleItem(ctx context.Context, userID, saleID uuid.UUID
 		"user_id":    userID,
 	}).Info("Sale item added successfully")
 
-	return uc.toSaleResponse(sale), nil
+	response := uc.toSaleResponse(sale)
+	response.MarginWarnings = uc.marginFloors.CheckSaleItems(ctx, userID, sale, []entities.SaleItem{*saleItem}, entities.MarginViolationSourceSaleItem)
+	return response, nil
+}
+
+// AddSaleItems adds several items to a pending sale in one transaction. Every line is checked
+// before any is applied, with lines for the same product checked against its stock together, so
+// either all lines are added or none are and the response reports why each failed line failed.
+func (uc *SaleUseCase) AddSaleItems(ctx context.Context, userID, saleID uuid.UUID, req AddSaleItemsRequest) (*AddSaleItemsResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, errors.NewValidationError("items are required", "a batch needs at least one item")
+	}
+	if len(req.Items) > maxSaleItemBatchSize {
+		return nil, errors.NewValidationError("too many items", fmt.Sprintf("a batch cannot exceed %d items", maxSaleItemBatchSize))
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale
+	sale, err := tx.GetSaleRepository().GetByID(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	// Check if sale is still pending
+	if sale.Status != entities.SaleStatusPending {
+		return nil, errors.NewValidationError("invalid sale status", "can only modify pending sales")
+	}
+
+	// Validate every line up front
+	response := &AddSaleItemsResponse{Lines: make([]SaleItemLineResult, len(req.Items))}
+	saleItems := make([]*entities.SaleItem, len(req.Items))
+	products := make([]*entities.Product, len(req.Items))
+	outOfSeason := make([]bool, len(req.Items))
+	requested := make(map[uuid.UUID]int)
+	for i, line := range req.Items {
+		response.Lines[i] = SaleItemLineResult{Line: i + 1, ProductID: line.ProductID, Quantity: line.Quantity}
+
+		saleItems[i], products[i], outOfSeason[i], err = uc.newSaleItem(ctx, tx, sale, line, requested[line.ProductID]+line.Quantity)
+		if err != nil {
+			appErr, ok := errors.IsAppError(err)
+			if !ok || appErr.Type == errors.ErrorTypeInternal {
+				return nil, err
+			}
+			response.Lines[i].Error = appErr
+			response.Failed++
+			continue
+		}
+		requested[line.ProductID] += line.Quantity
+	}
+	if response.Failed > 0 {
+		return response, nil
+	}
+
+	// Products already on the sale have their item's quantity increased rather than a new item
+	existing := make(map[uuid.UUID]bool, len(sale.Items))
+	for _, item := range sale.Items {
+		existing[item.ProductID] = true
+	}
+	for _, saleItem := range saleItems {
+		if err := sale.AddItem(saleItem); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated []*entities.SaleItem
+	added := make([]entities.SaleItem, 0, len(requested))
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		if _, ok := requested[item.ProductID]; !ok {
+			continue
+		}
+		added = append(added, *item)
+		if existing[item.ProductID] {
+			updated = append(updated, item)
+			continue
+		}
+
+		// Save sale item
+		if err := tx.GetSaleItemRepository().Create(ctx, item); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id":    saleID,
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create sale item")
+			return nil, errors.NewInternalError("failed to create sale item", err)
+		}
+	}
+	if len(updated) > 0 {
+		if err := tx.GetSaleItemRepository().BulkUpdate(ctx, updated); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id": saleID,
+				"error":   err.Error(),
+			}).Error("Failed to update sale items")
+			return nil, errors.NewInternalError("failed to update sale items", err)
+		}
+	}
+
+	// Update sale
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale")
+		return nil, errors.NewInternalError("failed to update sale", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	for i, line := range req.Items {
+		if outOfSeason[i] {
+			uc.logSellUnavailable(ctx, userID, saleID, products[i], line)
+		}
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id": saleID,
+		"lines":   len(req.Items),
+		"user_id": userID,
+	}).Info("Sale items added successfully")
+
+	response.Sale = uc.toSaleResponse(sale)
+	response.Sale.MarginWarnings = uc.marginFloors.CheckSaleItems(ctx, userID, sale, added, entities.MarginViolationSourceSaleItem)
+	response.Added = len(req.Items)
+	return response, nil
+}
+
+// logSellUnavailable audits a product sold outside its availability window
+func (uc *SaleUseCase) logSellUnavailable(ctx context.Context, userID, saleID uuid.UUID, product *entities.Product, req AddSaleItemRequest) {
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "sell_unavailable",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		NewValue: map[string]interface{}{
+			"product_id":      req.ProductID,
+			"quantity":        req.Quantity,
+			"available_from":  product.AvailableFrom,
+			"available_until": product.AvailableUntil,
+			"approved_by":     req.ApprovedBy,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+}
+
+// newSaleItem prices a product as an item of a pending sale, checking it can be sold and that
+// its stock covers requestedQty, the quantity of the product being added in all
+func (uc *SaleUseCase) newSaleItem(ctx context.Context, tx ports.TransactionPort, sale *entities.Sale, req AddSaleItemRequest, requestedQty int) (*entities.SaleItem, *entities.Product, bool, error) {
+	// Get product
+	product, err := tx.GetProductRepository().GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, nil, false, errors.NewNotFoundError("product")
+	}
+
+	// Products outside their availability window can only be sold with an override. This also
+	// covers products the scheduler deactivated when their window closed.
+	outOfSeason := !product.IsAvailableAt(time.Now())
+	if outOfSeason && !req.SellOutOfSeason {
+		return nil, nil, false, errors.NewValidationError("product not available", "product is outside its availability period and needs a manager override")
+	}
+
+	// Check if product is active
+	if !product.IsActive() && !(outOfSeason && product.Status == entities.ProductStatusInactive) {
+		return nil, nil, false, errors.NewValidationError("product not active", "cannot add inactive product to sale")
+	}
+	if !product.IsPublished() {
+		return nil, nil, false, errors.NewValidationError("product not published", "cannot add unpublished product to sale")
+	}
+
+	// Check stock availability
+	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
+	if err != nil {
+		return nil, nil, false, errors.NewNotFoundError("stock record")
+	}
+
+	if !stock.CanFulfillOrder(requestedQty) {
+		return nil, nil, false, errors.NewInsufficientStockError(product.Name, stock.AvailableQty, requestedQty)
+	}
+
+	// Products may be priced in another currency than the sale
+	toSaleCurrency, err := uc.saleCurrencyConverter(ctx, sale, product)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	// Create sale item
+	saleItem, err := entities.NewSaleItem(
+		sale.ID,
+		req.ProductID,
+		product.SKU,
+		product.Name,
+		req.Quantity,
+		toSaleCurrency(product.Price),
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	// Record cost for margin reporting and charge the promotional price while a promotion runs
+	if err := saleItem.SetUnitCost(toSaleCurrency(product.Cost)); err != nil {
+		return nil, nil, false, err
+	}
+	if promoPrice := product.ActivePromoPrice(time.Now()); promoPrice != nil {
+		if err := saleItem.ApplyPromotion(toSaleCurrency(*promoPrice)); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	// Tax the item at its product's or category's rate, or the sale's when it has none
+	taxRate, err := uc.taxRates.ProductTaxRate(ctx, product)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	saleItem.AssignTaxRate(taxRate)
+
+	return saleItem, product, outOfSeason, nil
 }
 
 // UpdateSaleItem updates the quantity of a sale item
@@ -363,8 +765,8 @@ func (uc *SaleUseCase) UpdateSaleItem(ctx context.Context, userID, saleID uuid.U
 	return uc.toSaleResponse(sale), nil
 }
 
-// RemoveSaleItem removes an item from a sale
-func (uc *SaleUseCase) RemoveSaleItem(ctx context.Context, userID, saleID, productID uuid.UUID) (*SaleResponse, error) {
+// OverrideSaleItemPrice manually overrides the charged price of a sale item
+func (uc *SaleUseCase) OverrideSaleItemPrice(ctx context.Context, userID, saleID uuid.UUID, req OverrideSaleItemPriceRequest) (*SaleResponse, error) {
 	// Start transaction
 	tx, err := uc.database.BeginTransaction(ctx)
 	if err != nil {
@@ -384,28 +786,25 @@ func (uc *SaleUseCase) RemoveSaleItem(ctx context.Context, userID, saleID, produ
 		return nil, errors.NewValidationError("invalid sale status", "can only modify pending sales")
 	}
 
-	// Remove item from sale
-	if err := sale.RemoveItem(productID); err != nil {
-		return nil, err
+	var previousPrice decimal.Decimal
+	for _, item := range sale.Items {
+		if item.ProductID == req.ProductID {
+			previousPrice = item.UnitPrice
+		}
 	}
 
-	// Delete sale item from database
-	saleItems, err := tx.GetSaleItemRepository().GetBySaleID(ctx, saleID)
-	if err != nil {
-		return nil, errors.NewInternalError("failed to get sale items", err)
+	// Override sale item price
+	if err := sale.OverrideItemPrice(req.ProductID, req.UnitPrice, req.Reason); err != nil {
+		return nil, err
 	}
 
-	for _, item := range saleItems {
-		if item.ProductID == productID {
-			if err := tx.GetSaleItemRepository().Delete(ctx, item.ID); err != nil {
-				uc.logger.WithFields(map[string]interface{}{
-					"sale_item_id": item.ID,
-					"error":        err.Error(),
-				}).Error("Failed to delete sale item")
-				return nil, errors.NewInternalError("failed to delete sale item", err)
-			}
-			break
-		}
+	// Update sale items in database
+	if err := tx.GetSaleItemRepository().BulkUpdate(ctx, convertSaleItemsToEntities(sale.Items)); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale items")
+		return nil, errors.NewInternalError("failed to update sale items", err)
 	}
 
 	// Update sale
@@ -423,17 +822,46 @@ func (uc *SaleUseCase) RemoveSaleItem(ctx context.Context, userID, saleID, produ
 		return nil, errors.NewInternalError("failed to commit transaction", err)
 	}
 
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "override_price",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		OldValue: map[string]interface{}{
+			"product_id": req.ProductID,
+			"unit_price": previousPrice,
+		},
+		NewValue: map[string]interface{}{
+			"product_id":  req.ProductID,
+			"unit_price":  req.UnitPrice,
+			"reason":      req.Reason,
+			"approved_by": req.ApprovedBy,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
 	uc.logger.WithFields(map[string]interface{}{
 		"sale_id":    saleID,
-		"product_id": productID,
+		"product_id": req.ProductID,
+		"unit_price": req.UnitPrice,
 		"user_id":    userID,
-	}).Info("Sale item removed successfully")
+	}).Info("Sale item price overridden")
 
-	return uc.toSaleResponse(sale), nil
+	response := uc.toSaleResponse(sale)
+	for _, item := range sale.Items {
+		if item.ProductID == req.ProductID {
+			response.MarginWarnings = uc.marginFloors.CheckSaleItems(ctx, userID, sale, []entities.SaleItem{item}, entities.MarginViolationSourcePriceOverride)
+		}
+	}
+	return response, nil
 }
 
-// CompleteSale completes a sale with payment
-func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUID, req CompleteSaleRequest) (*SaleResponse, error) {
+// SetSaleItemSerialNumbers captures the serial numbers of the units of a serialized product
+// in a pending sale. They are checked against the serial number registry when the sale is
+// completed.
+func (uc *SaleUseCase) SetSaleItemSerialNumbers(ctx context.Context, userID, saleID uuid.UUID, req SetSaleItemSerialNumbersRequest) (*SaleResponse, error) {
 	// Start transaction
 	tx, err := uc.database.BeginTransaction(ctx)
 	if err != nil {
@@ -442,34 +870,180 @@ func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUI
 	}
 	defer tx.Rollback()
 
-	// Get sale with items
+	// Get sale
 	sale, err := tx.GetSaleRepository().GetByID(ctx, saleID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("sale")
 	}
 
-	// Apply discount if provided
-	if req.DiscountAmount.GreaterThan(decimal.Zero) {
-		if err := sale.ApplyDiscount(req.DiscountAmount); err != nil {
-			return nil, err
-		}
+	product, err := tx.GetProductRepository().GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("product")
 	}
 
-	// Apply tax if provided
-	if req.TaxPercentage.GreaterThan(decimal.Zero) {
-		if err := sale.ApplyTax(req.TaxPercentage); err != nil {
-			return nil, err
+	var previous []string
+	for _, item := range sale.Items {
+		if item.ProductID == req.ProductID {
+			previous = item.SerialNumbers
 		}
 	}
 
-	// Process payment
-	if err := sale.ProcessPayment(req.PaidAmount, req.PaymentMethod); err != nil {
+	if err := sale.SetItemSerialNumbers(product, req.SerialNumbers); err != nil {
 		return nil, err
 	}
 
-	// Add notes if provided
-	if req.Notes != "" {
-		sale.AddNotes(req.Notes)
+	// Update sale items in database
+	if err := tx.GetSaleItemRepository().BulkUpdate(ctx, convertSaleItemsToEntities(sale.Items)); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale items")
+		return nil, errors.NewInternalError("failed to update sale items", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	var serials []string
+	for _, item := range sale.Items {
+		if item.ProductID == req.ProductID {
+			serials = item.SerialNumbers
+		}
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "set_serial_numbers",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		OldValue: map[string]interface{}{
+			"product_id":     req.ProductID,
+			"serial_numbers": previous,
+		},
+		NewValue: map[string]interface{}{
+			"product_id":     req.ProductID,
+			"serial_numbers": serials,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":    saleID,
+		"product_id": req.ProductID,
+		"count":      len(serials),
+		"user_id":    userID,
+	}).Info("Sale item serial numbers captured")
+
+	return uc.toSaleResponse(sale), nil
+}
+
+// RemoveSaleItem removes an item from a sale
+func (uc *SaleUseCase) RemoveSaleItem(ctx context.Context, userID, saleID, productID uuid.UUID) (*SaleResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale
+	sale, err := tx.GetSaleRepository().GetByID(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	// Check if sale is still pending
+	if sale.Status != entities.SaleStatusPending {
+		return nil, errors.NewValidationError("invalid sale status", "can only modify pending sales")
+	}
+
+	// Remove item from sale
+	if err := sale.RemoveItem(productID); err != nil {
+		return nil, err
+	}
+
+	// Delete sale item from database
+	saleItems, err := tx.GetSaleItemRepository().GetBySaleID(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to get sale items", err)
+	}
+
+	for _, item := range saleItems {
+		if item.ProductID == productID {
+			if err := tx.GetSaleItemRepository().Delete(ctx, item.ID); err != nil {
+				uc.logger.WithFields(map[string]interface{}{
+					"sale_item_id": item.ID,
+					"error":        err.Error(),
+				}).Error("Failed to delete sale item")
+				return nil, errors.NewInternalError("failed to delete sale item", err)
+			}
+			break
+		}
+	}
+
+	// Update sale
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale")
+		return nil, errors.NewInternalError("failed to update sale", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":    saleID,
+		"product_id": productID,
+		"user_id":    userID,
+	}).Info("Sale item removed successfully")
+
+	return uc.toSaleResponse(sale), nil
+}
+
+// CompleteSale completes a sale with payment
+func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUID, req CompleteSaleRequest) (*SaleResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale with items
+	sale, err := tx.GetSaleRepository().GetByIDForUpdate(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	// The coupon stays locked until commit so concurrent sales cannot redeem it past its limit
+	var coupon *entities.Coupon
+	if req.CouponCode != "" {
+		coupon, err = findRedeemableCoupon(ctx, tx.GetCouponRepository(), sale.TenantID, req.CouponCode, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	couponDiscount, err := uc.applyPayment(ctx, sale, req, coupon)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce tenant checkout rules
+	if err := uc.checkCheckoutRules(ctx, sale); err != nil {
+		return nil, err
 	}
 
 	// Complete the sale
@@ -477,17 +1051,29 @@ func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUI
 		return nil, err
 	}
 
-	// Update stock for each item
-	for _, item := range sale.Items {
-		stock, err := tx.GetStockRepository().GetByProductID(ctx, item.ProductID)
+	costMethod, err := uc.costMethod(ctx, sale.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update stock for each item, noting the products this sale takes below their reorder level.
+	// Stock rows are locked until commit so concurrent sales of the last units cannot both
+	// succeed; they are locked in product order so that two sales never wait on each other.
+	var lowStocks []*entities.Stock
+	for _, item := range saleItemsInLockOrder(sale.Items) {
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
 		if err != nil {
 			return nil, errors.NewNotFoundError("stock record")
 		}
+		wasLow := stock.IsLowStock()
 
 		// Remove stock
 		if err := stock.RemoveStock(item.Quantity); err != nil {
 			return nil, err
 		}
+		if !wasLow && stock.IsLowStock() {
+			lowStocks = append(lowStocks, stock)
+		}
 
 		// Create stock movement
 		movement, err := entities.NewStockMovement(
@@ -520,10 +1106,39 @@ func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUI
 			}).Error("Failed to update stock")
 			return nil, errors.NewInternalError("failed to update stock", err)
 		}
+
+		if err := uc.sellSerialNumbers(ctx, tx, sale, item); err != nil {
+			return nil, err
+		}
+
+		// Snapshot what the units sold cost for profit reporting
+		unitCost, err := uc.unitCostAtCompletion(ctx, tx, sale, item, costMethod)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sale.Items {
+			if sale.Items[i].ID == item.ID {
+				if err := sale.Items[i].SetUnitCost(unitCost); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 
-	// Update sale
+	// Save the tax each item was charged and the cost of its units
+	if err := tx.GetSaleItemRepository().BulkUpdate(ctx, convertSaleItemsToEntities(sale.Items)); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale items")
+		return nil, errors.NewInternalError("failed to update sale items", err)
+	}
+
+	// Update sale; a gateway payment can pay only one sale
 	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			return nil, appErr
+		}
 		uc.logger.WithFields(map[string]interface{}{
 			"sale_id": saleID,
 			"error":   err.Error(),
@@ -531,6 +1146,25 @@ func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUI
 		return nil, errors.NewInternalError("failed to update sale", err)
 	}
 
+	// Record the coupon redemption against the sale
+	if coupon != nil {
+		redemption, err := coupon.Redeem(sale, couponDiscount, userID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.GetCouponRepository().CreateRedemption(ctx, redemption); err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return nil, appErr
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id":   saleID,
+				"coupon_id": coupon.ID,
+				"error":     err.Error(),
+			}).Error("Failed to record coupon redemption")
+			return nil, errors.NewInternalError("failed to record coupon redemption", err)
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
@@ -545,10 +1179,13 @@ func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUI
 		Resource:   "sale",
 		ResourceID: saleID.String(),
 		NewValue: map[string]interface{}{
-			"total_amount":   sale.TotalAmount,
-			"paid_amount":    sale.PaidAmount,
-			"payment_method": sale.PaymentMethod,
-			"status":         sale.Status,
+			"total_amount":     sale.TotalAmount,
+			"surcharge_amount": sale.SurchargeAmount,
+			"paid_amount":      sale.PaidAmount,
+			"payment_method":   sale.PaymentMethod,
+			"payment_count":    len(sale.Payments),
+			"coupon_code":      req.CouponCode,
+			"status":           sale.Status,
 		},
 		Timestamp: time.Now(),
 		Success:   true,
@@ -562,118 +1199,1312 @@ func (uc *SaleUseCase) CompleteSale(ctx context.Context, userID, saleID uuid.UUI
 		"user_id":      userID,
 	}).Info("Sale completed successfully")
 
-	return uc.toSaleResponse(sale), nil
-}
-
-// CancelSale cancels a sale
-func (uc *SaleUseCase) CancelSale(ctx context.Context, userID, saleID uuid.UUID) error {
-	// Get sale
-	sale, err := uc.saleRepo.GetByID(ctx, saleID)
-	if err != nil {
-		return errors.NewNotFoundError("sale")
+	response := uc.toSaleResponse(sale)
+	if coupon != nil {
+		response.CouponCode = coupon.Code
+		response.CouponDiscount = &couponDiscount
 	}
+	uc.webhooks.Publish(ctx, sale.TenantID, entities.WebhookEventSaleCompleted, response)
+	uc.publishLowStock(ctx, sale.TenantID, lowStocks)
+	uc.refreshSalesSummary(ctx, sale)
 
-	// Cancel sale
-	if err := sale.CancelSale(); err != nil {
-		return err
+	if sale.DiscountAmount.GreaterThan(decimal.Zero) {
+		response.MarginWarnings = uc.marginFloors.CheckSaleItems(ctx, userID, sale, sale.Items, entities.MarginViolationSourceSaleDiscount)
 	}
+	return response, nil
+}
 
-	// Update sale
-	if err := uc.saleRepo.Update(ctx, sale); err != nil {
+// refreshSalesSummary recomputes the daily sales summary of the day a sale was made, so the
+// sales report includes it without waiting for the summary job
+func (uc *SaleUseCase) refreshSalesSummary(ctx context.Context, sale *entities.Sale) {
+	day := time.Date(sale.CreatedAt.Year(), sale.CreatedAt.Month(), sale.CreatedAt.Day(), 0, 0, 0, 0, sale.CreatedAt.Location())
+	if err := uc.summaryRepo.RefreshSalesSummaries(ctx, sale.TenantID, day, day.AddDate(0, 0, 1)); err != nil {
 		uc.logger.WithFields(map[string]interface{}{
-			"sale_id": saleID,
+			"sale_id": sale.ID,
 			"error":   err.Error(),
-		}).Error("Failed to cancel sale")
-		return errors.NewInternalError("failed to cancel sale", err)
+		}).Warn("Failed to refresh sales summary")
 	}
+}
 
-	// Audit log
-	auditEvent := ports.AuditEvent{
-		ID:         uuid.New(),
-		UserID:     userID,
-		Action:     "cancel",
-		Resource:   "sale",
-		ResourceID: saleID.String(),
-		NewValue: map[string]interface{}{
-			"status": sale.Status,
-		},
-		Timestamp: time.Now(),
-		Success:   true,
+// PreviewPayment works out the totals, surcharges and change of completing a pending sale with
+// the given payment, without completing it
+func (uc *SaleUseCase) PreviewPayment(ctx context.Context, saleID uuid.UUID, req CompleteSaleRequest) (*SaleResponse, error) {
+	sale, coupon, err := uc.getPayableSale(ctx, saleID, req.CouponCode)
+	if err != nil {
+		return nil, err
 	}
-	uc.audit.Log(ctx, auditEvent)
 
-	uc.logger.WithFields(map[string]interface{}{
-		"sale_id":     saleID,
-		"sale_number": sale.SaleNumber,
-		"user_id":     userID,
-	}).Info("Sale cancelled successfully")
+	couponDiscount, err := uc.applyPayment(ctx, sale, req, coupon)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil
+	return uc.toPreviewResponse(sale, coupon, couponDiscount), nil
 }
 
-// ListSales retrieves sales with pagination and filtering
-func (uc *SaleUseCase) ListSales(ctx context.Context, filter repositories.SaleFilter, pagination utils.PaginationInfo) (*SaleListResponse, error) {
-	sales, paginationResult, err := uc.saleRepo.List(ctx, filter, pagination)
+// PreviewTotal works out the total due on a pending sale when it is paid with the given payment
+// method, including the method's surcharge, without taking a payment or completing the sale
+func (uc *SaleUseCase) PreviewTotal(ctx context.Context, saleID uuid.UUID, req PreviewSaleTotalRequest) (*SaleResponse, error) {
+	sale, coupon, err := uc.getPayableSale(ctx, saleID, req.CouponCode)
 	if err != nil {
-		uc.logger.WithField("error", err.Error()).Error("Failed to list sales")
-		return nil, errors.NewInternalError("failed to list sales", err)
+		return nil, err
 	}
 
-	saleResponses := make([]*SaleResponse, len(sales))
-	for i, sale := range sales {
-		saleResponses[i] = uc.toSaleResponse(sale)
+	couponDiscount, err := uc.applyAdjustments(sale, req.DiscountAmount, req.TaxPercentage, coupon)
+	if err != nil {
+		return nil, err
 	}
 
-	return &SaleListResponse{
-		Sales:      saleResponses,
-		Pagination: paginationResult,
-	}, nil
+	surcharge, err := uc.getSurchargeRule(ctx, sale.TenantID, req.PaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := sale.PriceForPayment(req.PaymentMethod, surcharge); err != nil {
+		return nil, err
+	}
+
+	return uc.toPreviewResponse(sale, coupon, couponDiscount), nil
 }
 
-// toSaleResponse converts sale entity to response
-func (uc *SaleUseCase) toSaleResponse(sale *entities.Sale) *SaleResponse {
-	items := make([]*SaleItemResponse, len(sale.Items))
-	for i, item := range sale.Items {
-		items[i] = &SaleItemResponse{
-			ID:          item.ID,
-			ProductID:   item.ProductID,
-			ProductSKU:  item.ProductSKU,
-			ProductName: item.ProductName,
-			Quantity:    item.Quantity,
-			UnitPrice:   item.UnitPrice,
-			TotalPrice:  item.TotalPrice,
-			CreatedAt:   item.CreatedAt,
+// getPayableSale retrieves a pending sale with items to preview paying for, along with the
+// coupon to redeem on it, if any
+func (uc *SaleUseCase) getPayableSale(ctx context.Context, saleID uuid.UUID, couponCode string) (*entities.Sale, *entities.Coupon, error) {
+	sale, err := uc.saleRepo.GetByID(ctx, saleID)
+	if err != nil {
+		return nil, nil, errors.NewNotFoundError("sale")
+	}
+	if sale.Status != entities.SaleStatusPending {
+		return nil, nil, errors.NewValidationError("invalid sale status", "only pending sales can be paid")
+	}
+	if len(sale.Items) == 0 {
+		return nil, nil, errors.NewValidationError("empty sale", "cannot pay for a sale without items")
+	}
+
+	var coupon *entities.Coupon
+	if couponCode != "" {
+		coupon, err = findRedeemableCoupon(ctx, uc.couponRepo, sale.TenantID, couponCode, false)
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
-	return &SaleResponse{
-		ID:             sale.ID,
-		SaleNumber:     sale.SaleNumber,
-		CustomerName:   sale.CustomerName,
-		CustomerEmail:  sale.CustomerEmail,
-		CustomerPhone:  sale.CustomerPhone,
-		Items:          items,
-		Subtotal:       sale.Subtotal,
-		TaxAmount:      sale.TaxAmount,
-		DiscountAmount: sale.DiscountAmount,
-		TotalAmount:    sale.TotalAmount,
-		PaidAmount:     sale.PaidAmount,
-		ChangeAmount:   sale.ChangeAmount,
-		PaymentMethod:  sale.PaymentMethod,
-		Status:         sale.Status,
-		Notes:          sale.Notes,
-		CreatedAt:      sale.CreatedAt,
-		UpdatedAt:      sale.UpdatedAt,
-		CreatedBy:      sale.CreatedBy,
-		CompletedAt:    sale.CompletedAt,
+	return sale, coupon, nil
+}
+
+// toPreviewResponse converts a sale priced for payment to a response with the coupon's discount
+func (uc *SaleUseCase) toPreviewResponse(sale *entities.Sale, coupon *entities.Coupon, couponDiscount decimal.Decimal) *SaleResponse {
+	response := uc.toSaleResponse(sale)
+	if coupon != nil {
+		response.CouponCode = coupon.Code
+		response.CouponDiscount = &couponDiscount
 	}
+	return response
 }
 
-// convertSaleItemsToEntities converts sale items to entities
-func convertSaleItemsToEntities(items []entities.SaleItem) []*entities.SaleItem {
-	entities := make([]*entities.SaleItem, len(items))
-	for i := range items {
-		entities[i] = &items[i]
+// applyAdjustments applies a discount, coupon and tax to a sale. It returns the discount the
+// coupon gave, which applies to the subtotal left after the manual discount.
+func (uc *SaleUseCase) applyAdjustments(sale *entities.Sale, discountAmount, taxPercentage decimal.Decimal, coupon *entities.Coupon) (decimal.Decimal, error) {
+	discount := discountAmount
+	couponDiscount := decimal.Zero
+	if coupon != nil && discountAmount.LessThan(sale.Subtotal) {
+		couponDiscount = coupon.Discount(sale.Subtotal.Sub(discountAmount))
+		discount = discount.Add(couponDiscount)
 	}
-	return entities
+
+	// Apply discount if provided
+	if discount.GreaterThan(decimal.Zero) {
+		if err := sale.ApplyDiscount(discount); err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	// Apply tax at the given percentage to items without a tax rate of their own; items with
+	// one are taxed even when no percentage is given
+	if err := sale.ApplyTax(taxPercentage); err != nil {
+		return decimal.Zero, err
+	}
+
+	return couponDiscount, nil
+}
+
+// applyPayment applies the discount, coupon, tax and payment of a complete sale request to a
+// sale, passing on the tenant's surcharge for each payment method. It returns the discount the
+// coupon gave, which applies to the subtotal left after the manual discount.
+func (uc *SaleUseCase) applyPayment(ctx context.Context, sale *entities.Sale, req CompleteSaleRequest, coupon *entities.Coupon) (decimal.Decimal, error) {
+	couponDiscount, err := uc.applyAdjustments(sale, req.DiscountAmount, req.TaxPercentage, coupon)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	// Process payment
+	if len(req.Payments) > 0 {
+		surcharges := make(map[entities.PaymentMethod]*entities.PaymentSurchargeRule, len(req.Payments))
+		for _, line := range req.Payments {
+			surcharge, err := uc.getSurchargeRule(ctx, sale.TenantID, line.PaymentMethod)
+			if err != nil {
+				return decimal.Zero, err
+			}
+			surcharges[line.PaymentMethod] = surcharge
+		}
+		if err := sale.ProcessSplitPayment(req.Payments, surcharges); err != nil {
+			return decimal.Zero, err
+		}
+	} else {
+		surcharge, err := uc.getSurchargeRule(ctx, sale.TenantID, req.PaymentMethod)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if err := sale.ProcessPayment(req.PaidAmount, req.PaymentMethod, surcharge); err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	// Add notes if provided
+	if req.Notes != "" {
+		sale.AddNotes(req.Notes)
+	}
+	if req.PaymentReference != "" {
+		sale.PaymentReference = req.PaymentReference
+	}
+
+	return couponDiscount, nil
+}
+
+// UpdateSaleCustomer attaches the customer to a pending sale
+func (uc *SaleUseCase) UpdateSaleCustomer(ctx context.Context, userID, saleID uuid.UUID, req UpdateSaleCustomerRequest) (*SaleResponse, error) {
+	sale, err := uc.saleRepo.GetByID(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	if err := sale.SetCustomer(req.CustomerName, req.CustomerEmail, req.CustomerPhone); err != nil {
+		return nil, err
+	}
+
+	if err := uc.saleRepo.Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to update sale customer")
+		return nil, errors.NewInternalError("failed to update sale", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id": saleID,
+		"user_id": userID,
+	}).Info("Sale customer updated")
+
+	return uc.toSaleResponse(sale), nil
+}
+
+// EvaluateSale charges the items of a pending sale at their products' current promotional
+// prices and evaluates the tenant's checkout rules against it, so the customer sees the price
+// they will pay and what would block the sale before paying
+func (uc *SaleUseCase) EvaluateSale(ctx context.Context, userID, saleID uuid.UUID) (*SaleResponse, *entities.CheckoutEvaluation, error) {
+	sale, err := uc.saleRepo.GetByID(ctx, saleID)
+	if err != nil {
+		return nil, nil, errors.NewNotFoundError("sale")
+	}
+	if sale.Status != entities.SaleStatusPending {
+		return nil, nil, errors.NewValidationError("invalid sale status", "only pending sales can be evaluated")
+	}
+	if len(sale.Items) == 0 {
+		return nil, nil, errors.NewValidationError("empty sale", "cannot evaluate a sale without items")
+	}
+
+	// Promotions may have started or ended since the items were added
+	now := time.Now()
+	repriced := make([]entities.SaleItemRepricing, 0)
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			return nil, nil, errors.NewNotFoundError("product")
+		}
+
+		toSaleCurrency, err := uc.saleCurrencyConverter(ctx, sale, product)
+		if err != nil {
+			return nil, nil, err
+		}
+		promoPrice := product.ActivePromoPrice(now)
+		if promoPrice != nil {
+			converted := toSaleCurrency(*promoPrice)
+			promoPrice = &converted
+		}
+
+		previousPrice := item.UnitPrice
+		if item.RefreshPromotion(promoPrice) {
+			repriced = append(repriced, entities.SaleItemRepricing{
+				ProductID:     item.ProductID,
+				ProductName:   item.ProductName,
+				PreviousPrice: previousPrice,
+				UnitPrice:     item.UnitPrice,
+				PriceSource:   item.PriceSource,
+			})
+		}
+	}
+
+	if len(repriced) > 0 {
+		sale.RecalculateTotals()
+		if err := uc.saleRepo.Update(ctx, sale); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id": saleID,
+				"error":   err.Error(),
+			}).Error("Failed to update repriced sale")
+			return nil, nil, errors.NewInternalError("failed to update sale", err)
+		}
+
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id":  saleID,
+			"repriced": len(repriced),
+			"user_id":  userID,
+		}).Info("Sale items repriced for current promotions")
+	}
+
+	violations, err := uc.evaluateCheckoutRules(ctx, sale)
+	if err != nil {
+		return nil, nil, err
+	}
+	if violations == nil {
+		violations = []entities.CheckoutRuleViolation{}
+	}
+
+	evaluation := &entities.CheckoutEvaluation{
+		RepricedItems: repriced,
+		Violations:    violations,
+		Subtotal:      sale.Subtotal,
+	}
+	return uc.toSaleResponse(sale), evaluation, nil
+}
+
+// publishLowStock raises a stock.low webhook event for each stock record that fell to or
+// below its reorder level
+func (uc *SaleUseCase) publishLowStock(ctx context.Context, tenantID uuid.UUID, stocks []*entities.Stock) {
+	if len(stocks) == 0 {
+		return
+	}
+
+	productIDs := make([]uuid.UUID, len(stocks))
+	for i, stock := range stocks {
+		productIDs[i] = stock.ProductID
+	}
+	products, err := uc.productRepo.GetByIDs(ctx, productIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get products for low stock events")
+		return
+	}
+
+	for _, stock := range stocks {
+		if product, ok := products[stock.ProductID]; ok {
+			uc.webhooks.Publish(ctx, tenantID, entities.WebhookEventStockLow, NewStockLowEvent(stock, product))
+		}
+	}
+}
+
+// CancelSale cancels a sale. Cancelling a held sale returns its reserved stock.
+func (uc *SaleUseCase) CancelSale(ctx context.Context, userID, saleID uuid.UUID) error {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale
+	sale, err := tx.GetSaleRepository().GetByIDForUpdate(ctx, saleID)
+	if err != nil {
+		return errors.NewNotFoundError("sale")
+	}
+	wasHeld := sale.IsHeld()
+
+	// Cancel sale
+	if err := sale.CancelSale(); err != nil {
+		return err
+	}
+
+	if wasHeld {
+		if err := uc.releaseHeldStock(ctx, tx, sale, userID, "Held sale cancelled"); err != nil {
+			return err
+		}
+	}
+
+	// Update sale
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to cancel sale")
+		return errors.NewInternalError("failed to cancel sale", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "cancel",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		NewValue: map[string]interface{}{
+			"status": sale.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":     saleID,
+		"sale_number": sale.SaleNumber,
+		"user_id":     userID,
+	}).Info("Sale cancelled successfully")
+
+	return nil
+}
+
+// HoldSale parks a pending sale so it can be resumed later, possibly on another terminal.
+// The sale's stock is reserved while it is held so it cannot be sold from under the customer.
+func (uc *SaleUseCase) HoldSale(ctx context.Context, userID, saleID uuid.UUID, req HoldSaleRequest) (*SaleResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale with items
+	sale, err := tx.GetSaleRepository().GetByIDForUpdate(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	if err := sale.Hold(userID, req.Label, uc.heldSaleTTL); err != nil {
+		return nil, err
+	}
+
+	// Reserve stock for each item
+	for _, item := range sale.Items {
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record")
+		}
+
+		if err := stock.ReserveStock(item.Quantity); err != nil {
+			return nil, err
+		}
+
+		if err := uc.recordMovement(ctx, tx, stock, item.ProductID, entities.StockMovementTypeReserved, entities.ReasonReservation, item.Quantity, sale.SaleNumber, "Sale held", userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update sale
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to hold sale")
+		return nil, errors.NewInternalError("failed to hold sale", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "hold",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		NewValue: map[string]interface{}{
+			"status":          sale.Status,
+			"hold_label":      sale.HoldLabel,
+			"hold_expires_at": sale.HoldExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":     saleID,
+		"sale_number": sale.SaleNumber,
+		"user_id":     userID,
+	}).Info("Sale held successfully")
+
+	return uc.toSaleResponse(sale), nil
+}
+
+// ResumeSale returns a held sale to pending and releases its reserved stock for checkout
+func (uc *SaleUseCase) ResumeSale(ctx context.Context, userID, saleID uuid.UUID) (*SaleResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale with items
+	sale, err := tx.GetSaleRepository().GetByIDForUpdate(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+	heldBy := sale.HeldBy
+
+	if err := sale.Resume(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.releaseHeldStock(ctx, tx, sale, userID, "Sale resumed"); err != nil {
+		return nil, err
+	}
+
+	// Update sale
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to resume sale")
+		return nil, errors.NewInternalError("failed to resume sale", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "resume",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		OldValue: map[string]interface{}{
+			"status":  entities.SaleStatusHeld,
+			"held_by": heldBy,
+		},
+		NewValue: map[string]interface{}{
+			"status": sale.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":     saleID,
+		"sale_number": sale.SaleNumber,
+		"user_id":     userID,
+	}).Info("Sale resumed successfully")
+
+	return uc.toSaleResponse(sale), nil
+}
+
+// ExpireHeldSales cancels held sales whose hold has lapsed and returns their reserved stock.
+// It runs as a scheduled job across all tenants.
+func (uc *SaleUseCase) ExpireHeldSales(ctx context.Context) error {
+	sales, err := uc.saleRepo.GetExpiredHolds(ctx, time.Now(), heldSaleExpiryBatchSize)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get expired held sales")
+		return errors.NewInternalError("failed to get expired held sales", err)
+	}
+
+	expired := 0
+	for _, candidate := range sales {
+		if err := uc.expireHeldSale(ctx, candidate.ID); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id": candidate.ID,
+				"error":   err.Error(),
+			}).Warn("Failed to expire held sale")
+			continue
+		}
+		expired++
+	}
+
+	if expired > 0 {
+		uc.logger.WithField("count", expired).Info("Expired held sales cancelled")
+	}
+
+	return nil
+}
+
+// RefundSale refunds some or all items of a completed sale and returns them to stock. The
+// sale is marked refunded once every item has been refunded. Sales past the tenant's return
+// window can only be refunded with a manager override, and a scanned receipt must match.
+func (uc *SaleUseCase) RefundSale(ctx context.Context, userID, saleID uuid.UUID, req RefundSaleRequest) (*entities.Refund, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Get sale with items
+	sale, err := tx.GetSaleRepository().GetByIDForUpdate(ctx, saleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	if req.ReceiptToken != "" {
+		if err := sale.VerifyReceiptToken(req.ReceiptToken); err != nil {
+			return nil, err
+		}
+	}
+
+	windowDays, err := uc.returnWindowDays(ctx, sale.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	withinWindow := sale.IsWithinReturnWindow(windowDays, time.Now())
+	if !withinWindow && !req.ReturnOutsideWindow {
+		return nil, errors.NewValidationError("return window has passed", fmt.Sprintf("sale is past its %d-day return window and needs a manager override", windowDays))
+	}
+
+	refund, err := entities.NewRefund(sale, utils.GenerateRefundNumber(), req.Items, sale.ReturnedQuantities(), req.RefundMethod, req.Reason, userID)
+	if err != nil {
+		return nil, err
+	}
+	refund.ApprovedBy = req.ApprovedBy
+
+	// Return refunded items to stock
+	for _, item := range refund.Items {
+		// Guards against concurrent refunds of the same items
+		if err := tx.GetSaleItemRepository().AddReturnedQuantity(ctx, item.SaleItemID, item.Quantity); err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return nil, appErr
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_item_id": item.SaleItemID,
+				"error":        err.Error(),
+			}).Error("Failed to record returned quantity")
+			return nil, errors.NewInternalError("failed to record returned quantity", err)
+		}
+
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record")
+		}
+
+		if err := stock.AddStock(item.Quantity, entities.ReasonReturn); err != nil {
+			return nil, err
+		}
+
+		movement, err := entities.NewStockMovement(
+			item.ProductID,
+			entities.StockMovementTypeIn,
+			entities.ReasonReturn,
+			item.Quantity,
+			refund.RefundNumber,
+			"Refund of sale "+sale.SaleNumber,
+			userID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create stock movement")
+			return nil, errors.NewInternalError("failed to create stock movement", err)
+		}
+
+		if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to update stock")
+			return nil, errors.NewInternalError("failed to update stock", err)
+		}
+	}
+	if err := sale.RecordReturn(refund); err != nil {
+		return nil, err
+	}
+	if err := uc.returnSerialNumbers(ctx, tx, sale, req.Items); err != nil {
+		return nil, err
+	}
+
+	// Save refund
+	if err := tx.GetRefundRepository().Create(ctx, refund); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to create refund")
+		return nil, errors.NewInternalError("failed to create refund", err)
+	}
+
+	// Mark the sale refunded once nothing is left to refund
+	if sale.IsFullyRefunded(sale.ReturnedQuantities()) {
+		if err := sale.RefundSale(); err != nil {
+			return nil, err
+		}
+		if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"sale_id": saleID,
+				"error":   err.Error(),
+			}).Error("Failed to update sale")
+			return nil, errors.NewInternalError("failed to update sale", err)
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "refund",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		NewValue: map[string]interface{}{
+			"refund_id":     refund.ID,
+			"refund_number": refund.RefundNumber,
+			"items":         refund.Items,
+			"total_amount":  refund.TotalAmount,
+			"refund_method": refund.RefundMethod,
+			"reason":        refund.Reason,
+			"approved_by":   refund.ApprovedBy,
+			"sale_status":   sale.Status,
+
+			"receipt_verified":      req.ReceiptToken != "",
+			"outside_return_window": !withinWindow,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":       saleID,
+		"sale_number":   sale.SaleNumber,
+		"refund_number": refund.RefundNumber,
+		"total_amount":  refund.TotalAmount,
+		"user_id":       userID,
+	}).Info("Sale refunded successfully")
+
+	return refund, nil
+}
+
+// GetSaleRefunds retrieves all refunds of a sale
+func (uc *SaleUseCase) GetSaleRefunds(ctx context.Context, saleID uuid.UUID) ([]*entities.Refund, error) {
+	if _, err := uc.saleRepo.GetByID(ctx, saleID); err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	refunds, err := uc.refundRepo.GetBySaleID(ctx, saleID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": saleID,
+			"error":   err.Error(),
+		}).Error("Failed to get sale refunds")
+		return nil, errors.NewInternalError("failed to get sale refunds", err)
+	}
+
+	return refunds, nil
+}
+
+// LookupSaleForReturn finds a sale brought back for a return by its sale number, verifying the
+// receipt token scanned from its receipt if given
+func (uc *SaleUseCase) LookupSaleForReturn(ctx context.Context, req ReturnLookupRequest) (*ReturnLookupResponse, error) {
+	sale, err := uc.saleRepo.GetBySaleNumber(ctx, strings.TrimSpace(req.SaleNumber))
+	if err != nil {
+		return nil, errors.NewNotFoundError("sale")
+	}
+
+	if req.ReceiptToken != "" {
+		if err := sale.VerifyReceiptToken(req.ReceiptToken); err != nil {
+			return nil, err
+		}
+	}
+
+	windowDays, err := uc.returnWindowDays(ctx, sale.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnable := false
+	if sale.IsCompleted() {
+		for _, item := range sale.Items {
+			if item.ReturnableQuantity() > 0 {
+				returnable = true
+				break
+			}
+		}
+	}
+
+	return &ReturnLookupResponse{
+		Sale:               uc.toSaleResponse(sale),
+		ReceiptVerified:    req.ReceiptToken != "",
+		ReturnWindowDays:   windowDays,
+		ReturnDeadline:     sale.ReturnDeadline(windowDays),
+		WithinReturnWindow: sale.IsWithinReturnWindow(windowDays, time.Now()),
+		Returnable:         returnable,
+	}, nil
+}
+
+// applyTenantPricing fixes how a new sale is priced from the tenant's settings: whether its
+// prices include tax, and the rate its amounts convert to the tenant's currency with. A sale
+// made out in another currency than the tenant's takes the current rate from the exchange
+// rate provider and keeps it from then on.
+func (uc *SaleUseCase) applyTenantPricing(ctx context.Context, tenantID uuid.UUID, sale *entities.Sale, currency string) error {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get tenant for sale pricing")
+		return errors.NewInternalError("failed to create sale", err)
+	}
+
+	if err := sale.SetTaxPricingMode(tenant.GetTaxPricingMode()); err != nil {
+		return err
+	}
+
+	baseCurrency := tenant.GetCurrency()
+	if currency == "" {
+		currency = baseCurrency
+	}
+	currency, err = entities.NormalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	rate, err := uc.getExchangeRate(ctx, currency, baseCurrency)
+	if err != nil {
+		return err
+	}
+
+	lockedRate, err := entities.NewExchangeRate(currency, baseCurrency, rate, sale.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return sale.LockExchangeRate(lockedRate)
+}
+
+// getExchangeRate returns the current rate from currency to the tenant's base currency
+func (uc *SaleUseCase) getExchangeRate(ctx context.Context, currency, baseCurrency string) (decimal.Decimal, error) {
+	if currency == baseCurrency {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rate, err := uc.exchangeRates.GetRate(ctx, currency, baseCurrency)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"currency":      currency,
+			"base_currency": baseCurrency,
+			"error":         err.Error(),
+		}).Error("Failed to get exchange rate")
+		return decimal.Zero, errors.NewInternalError("failed to get exchange rate", err)
+	}
+
+	return rate, nil
+}
+
+// saleCurrencyConverter returns a function converting the product's prices and cost into the
+// sale's currency. Prices in the tenant's currency convert at the sale's locked rate; prices
+// in a third currency convert to the tenant's currency at the current rate first.
+func (uc *SaleUseCase) saleCurrencyConverter(ctx context.Context, sale *entities.Sale, product *entities.Product) (func(decimal.Decimal) decimal.Decimal, error) {
+	if sale.ExchangeRate == nil {
+		return func(amount decimal.Decimal) decimal.Decimal { return amount }, nil
+	}
+
+	baseCurrency := sale.ExchangeRate.BaseCurrency
+	productCurrency := product.PriceCurrency(baseCurrency)
+	if productCurrency == sale.ExchangeRate.Currency {
+		return func(amount decimal.Decimal) decimal.Decimal { return amount }, nil
+	}
+
+	rate, err := uc.getExchangeRate(ctx, productCurrency, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(amount decimal.Decimal) decimal.Decimal {
+		return sale.ExchangeRate.FromBase(amount.Mul(rate))
+	}, nil
+}
+
+// returnWindowDays returns the tenant's return window in days, 0 when returns are not time limited
+func (uc *SaleUseCase) returnWindowDays(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get tenant for return window")
+		return 0, errors.NewInternalError("failed to get return window", err)
+	}
+
+	return tenant.GetReturnWindowDays(), nil
+}
+
+// costMethod returns how the tenant's sold units are costed
+func (uc *SaleUseCase) costMethod(ctx context.Context, tenantID uuid.UUID) (entities.CostMethod, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to get tenant for cost method")
+		return "", errors.NewInternalError("failed to get cost method", err)
+	}
+
+	return tenant.GetCostMethod(), nil
+}
+
+// ListSales retrieves sales with pagination and filtering
+func (uc *SaleUseCase) ListSales(ctx context.Context, filter repositories.SaleFilter, pagination utils.PaginationInfo) (*SaleListResponse, error) {
+	sales, paginationResult, err := uc.saleRepo.List(uc.database.ReadOnly(ctx), filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list sales")
+		return nil, errors.NewInternalError("failed to list sales", err)
+	}
+
+	saleResponses := make([]*SaleResponse, len(sales))
+	for i, sale := range sales {
+		saleResponses[i] = uc.toSaleResponse(sale)
+	}
+
+	return &SaleListResponse{
+		Sales:      saleResponses,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// toSaleResponse converts sale entity to response
+func (uc *SaleUseCase) toSaleResponse(sale *entities.Sale) *SaleResponse {
+	items := make([]*SaleItemResponse, len(sale.Items))
+	for i, item := range sale.Items {
+		items[i] = &SaleItemResponse{
+			ID:                 item.ID,
+			ProductID:          item.ProductID,
+			ProductSKU:         item.ProductSKU,
+			ProductName:        item.ProductName,
+			Quantity:           item.Quantity,
+			UnitPrice:          item.UnitPrice,
+			TotalPrice:         item.TotalPrice,
+			ListPrice:          item.ListPrice,
+			PriceSource:        item.PriceSource,
+			OverrideReason:     item.OverrideReason,
+			TaxRateID:          item.TaxRateID,
+			TaxRate:            item.TaxRate,
+			TaxAmount:          item.TaxAmount,
+			ReturnedQuantity:   item.ReturnedQuantity,
+			ReturnableQuantity: item.ReturnableQuantity(),
+			SerialNumbers:      item.SerialNumbers,
+			CreatedAt:          item.CreatedAt,
+		}
+	}
+
+	return &SaleResponse{
+		ID:              sale.ID,
+		SaleNumber:      sale.SaleNumber,
+		CustomerName:    sale.CustomerName,
+		CustomerEmail:   sale.CustomerEmail,
+		CustomerPhone:   sale.CustomerPhone,
+		Items:           items,
+		Subtotal:        sale.Subtotal,
+		TaxRate:         sale.TaxRate,
+		TaxAmount:       sale.TaxAmount,
+		TaxInclusive:    sale.TaxInclusive,
+		TaxSummary:      sale.TaxSummary(),
+		DiscountAmount:  sale.DiscountAmount,
+		SurchargeAmount: sale.SurchargeAmount,
+		SurchargeLabel:  sale.SurchargeLabel,
+		TotalAmount:     sale.TotalAmount,
+		PaidAmount:      sale.PaidAmount,
+		ChangeAmount:    sale.ChangeAmount,
+		PaymentMethod:   sale.PaymentMethod,
+		Channel:         sale.Channel,
+		Status:          sale.Status,
+		Payments:        sale.Payments,
+		Notes:           sale.Notes,
+		CreatedAt:       sale.CreatedAt,
+		UpdatedAt:       sale.UpdatedAt,
+		CreatedBy:       sale.CreatedBy,
+		CompletedAt:     sale.CompletedAt,
+		HeldAt:          sale.HeldAt,
+		HeldBy:          sale.HeldBy,
+		HoldExpiresAt:   sale.HoldExpiresAt,
+		HoldLabel:       sale.HoldLabel,
+		ReceiptToken:    sale.ReceiptToken,
+		Currency:        sale.Currency,
+		ExchangeRate:    sale.ExchangeRate,
+
+		PaymentReference: sale.PaymentReference,
+	}
+}
+
+// expireHeldSale cancels a single held sale past its hold expiry and releases its stock
+func (uc *SaleUseCase) expireHeldSale(ctx context.Context, saleID uuid.UUID) error {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		return errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	sale, err := tx.GetSaleRepository().GetByIDForUpdate(ctx, saleID)
+	if err != nil {
+		return errors.NewNotFoundError("sale")
+	}
+
+	// The release movements are attributed to whoever parked the sale
+	userID := sale.CreatedBy
+	if sale.HeldBy != nil {
+		userID = *sale.HeldBy
+	}
+
+	if err := sale.ExpireHold(time.Now()); err != nil {
+		return err
+	}
+
+	if err := uc.releaseHeldStock(ctx, tx, sale, userID, "Sale hold expired"); err != nil {
+		return err
+	}
+
+	if err := tx.GetSaleRepository().Update(ctx, sale); err != nil {
+		return errors.NewInternalError("failed to update sale", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "expire_hold",
+		Resource:   "sale",
+		ResourceID: saleID.String(),
+		NewValue: map[string]interface{}{
+			"status": sale.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return nil
+}
+
+// releaseHeldStock returns the stock reserved for a held sale to available
+func (uc *SaleUseCase) releaseHeldStock(ctx context.Context, tx ports.TransactionPort, sale *entities.Sale, userID uuid.UUID, notes string) error {
+	for _, item := range sale.Items {
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return errors.NewNotFoundError("stock record")
+		}
+
+		if err := stock.ReleaseReservedStock(item.Quantity); err != nil {
+			return err
+		}
+
+		if err := uc.recordMovement(ctx, tx, stock, item.ProductID, entities.StockMovementTypeReleased, entities.ReasonRelease, item.Quantity, sale.SaleNumber, notes, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordMovement saves a stock movement and the updated stock record within a transaction
+func (uc *SaleUseCase) recordMovement(ctx context.Context, tx ports.TransactionPort, stock *entities.Stock, productID uuid.UUID, movementType entities.StockMovementType, reason entities.StockMovementReason, quantity int, reference, notes string, userID uuid.UUID) error {
+	movement, err := entities.NewStockMovement(productID, movementType, reason, quantity, reference, notes, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to create stock movement")
+		return errors.NewInternalError("failed to create stock movement", err)
+	}
+
+	if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to update stock")
+		return errors.NewInternalError("failed to update stock", err)
+	}
+
+	return nil
+}
+
+// checkCheckoutRules enforces the tenant's active checkout rules on a sale
+func (uc *SaleUseCase) checkCheckoutRules(ctx context.Context, sale *entities.Sale) error {
+	violations, err := uc.evaluateCheckoutRules(ctx, sale)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Message
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"sale_id":    sale.ID,
+		"violations": violations,
+	}).Info("Sale blocked by checkout rules")
+
+	return errors.NewRuleViolationError("sale blocked by checkout rules", strings.Join(messages, "; "))
+}
+
+// evaluateCheckoutRules evaluates the tenant's active checkout rules against a sale and returns
+// the rules that block it
+func (uc *SaleUseCase) evaluateCheckoutRules(ctx context.Context, sale *entities.Sale) ([]entities.CheckoutRuleViolation, error) {
+	rules, err := uc.checkoutRuleRepo.GetActiveByTenant(ctx, sale.TenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"sale_id": sale.ID,
+			"error":   err.Error(),
+		}).Error("Failed to load checkout rules")
+		return nil, errors.NewInternalError("failed to load checkout rules", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	checkout := entities.CheckoutContext{Sale: sale}
+
+	// Only look up the customer's overdue invoices when a rule needs them
+	for _, rule := range rules {
+		if rule.UsesField(entities.CheckoutFieldCustomerOverdueCount) || rule.UsesField(entities.CheckoutFieldCustomerOverdueAmount) {
+			summary, err := uc.invoiceRepo.GetCustomerOverdueSummary(ctx, sale.TenantID, sale.CustomerEmail, sale.CustomerPhone)
+			if err != nil {
+				uc.logger.WithFields(map[string]interface{}{
+					"sale_id": sale.ID,
+					"error":   err.Error(),
+				}).Error("Failed to get customer overdue invoices")
+				return nil, errors.NewInternalError("failed to get customer overdue invoices", err)
+			}
+			checkout.OverdueInvoiceCount = summary.InvoiceCount
+			checkout.OverdueInvoiceAmount = summary.OutstandingAmount
+			break
+		}
+	}
+
+	return entities.EvaluateCheckoutRules(rules, checkout), nil
+}
+
+// getSurchargeRule retrieves the tenant's active surcharge rule for a payment method, if any
+func (uc *SaleUseCase) getSurchargeRule(ctx context.Context, tenantID uuid.UUID, paymentMethod entities.PaymentMethod) (*entities.PaymentSurchargeRule, error) {
+	rule, err := uc.surchargeRuleRepo.GetActiveByPaymentMethod(ctx, tenantID, paymentMethod)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, nil
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":      tenantID,
+			"payment_method": paymentMethod,
+			"error":          err.Error(),
+		}).Error("Failed to load payment surcharge rule")
+		return nil, errors.NewInternalError("failed to load payment surcharge rule", err)
+	}
+	return rule, nil
+}
+
+// convertSaleItemsToEntities converts sale items to entities
+func convertSaleItemsToEntities(items []entities.SaleItem) []*entities.SaleItem {
+	entities := make([]*entities.SaleItem, len(items))
+	for i := range items {
+		entities[i] = &items[i]
+	}
+	return entities
+}
+
+// sellSerialNumbers records the serial numbers captured on a sale item as sold, registering
+// the ones not seen before. A serial number still sold in another sale is refused.
+func (uc *SaleUseCase) sellSerialNumbers(ctx context.Context, tx ports.TransactionPort, sale *entities.Sale, item entities.SaleItem) error {
+	if len(item.SerialNumbers) > item.Quantity {
+		return errors.NewValidationError("too many serial numbers", fmt.Sprintf("%d serial numbers captured for a quantity of %d", len(item.SerialNumbers), item.Quantity))
+	}
+
+	// Registry rows are locked in serial order so that concurrent sales cannot deadlock
+	serials := make([]string, len(item.SerialNumbers))
+	copy(serials, item.SerialNumbers)
+	sort.Strings(serials)
+
+	for _, serial := range serials {
+		serialNumber, err := tx.GetSerialNumberRepository().GetByProductAndSerialForUpdate(ctx, item.ProductID, serial)
+		isNew := false
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+				uc.logger.WithFields(map[string]interface{}{
+					"product_id": item.ProductID,
+					"serial":     serial,
+					"error":      err.Error(),
+				}).Error("Failed to get serial number")
+				return errors.NewInternalError("failed to get serial number", err)
+			}
+			if serialNumber, err = entities.NewSerialNumber(sale.TenantID, item.ProductID, serial); err != nil {
+				return err
+			}
+			isNew = true
+		}
+
+		if err := serialNumber.Sell(sale.ID, item.ID); err != nil {
+			return err
+		}
+
+		if isNew {
+			err = tx.GetSerialNumberRepository().Create(ctx, serialNumber)
+		} else {
+			err = tx.GetSerialNumberRepository().Update(ctx, serialNumber)
+		}
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok {
+				return appErr
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": item.ProductID,
+				"serial":     serial,
+				"error":      err.Error(),
+			}).Error("Failed to save serial number")
+			return errors.NewInternalError("failed to save serial number", err)
+		}
+	}
+
+	return nil
+}
+
+// returnSerialNumbers records the serial numbers brought back in a refund as returned. Items
+// refunded in full return all of their serial numbers still sold, even when none are listed.
+func (uc *SaleUseCase) returnSerialNumbers(ctx context.Context, tx ports.TransactionPort, sale *entities.Sale, lines []entities.RefundLine) error {
+	listed := make(map[uuid.UUID][]string, len(lines))
+	for _, line := range lines {
+		serials, err := entities.NormalizeSerialNumbers(line.SerialNumbers)
+		if err != nil {
+			return err
+		}
+		listed[line.SaleItemID] = serials
+	}
+
+	for _, item := range saleItemsInLockOrder(sale.Items) {
+		serials := listed[item.ID]
+		fullyReturned := item.ReturnableQuantity() == 0
+		if fullyReturned {
+			serials = item.SerialNumbers
+		}
+		serials = append([]string(nil), serials...)
+		sort.Strings(serials)
+
+		for _, serial := range serials {
+			serialNumber, err := tx.GetSerialNumberRepository().GetByProductAndSerialForUpdate(ctx, item.ProductID, serial)
+			if err != nil {
+				if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+					continue
+				}
+				uc.logger.WithFields(map[string]interface{}{
+					"product_id": item.ProductID,
+					"serial":     serial,
+					"error":      err.Error(),
+				}).Error("Failed to get serial number")
+				return errors.NewInternalError("failed to get serial number", err)
+			}
+
+			if !serialNumber.IsSoldIn(item.ID) {
+				// Returned by an earlier refund of the item
+				if fullyReturned {
+					continue
+				}
+				return errors.NewValidationError("serial number already returned", fmt.Sprintf("serial number '%s' is not sold in this sale anymore", serial))
+			}
+			if err := serialNumber.Return(); err != nil {
+				return err
+			}
+
+			if err := tx.GetSerialNumberRepository().Update(ctx, serialNumber); err != nil {
+				uc.logger.WithFields(map[string]interface{}{
+					"product_id": item.ProductID,
+					"serial":     serial,
+					"error":      err.Error(),
+				}).Error("Failed to update serial number")
+				return errors.NewInternalError("failed to update serial number", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// unitCostAtCompletion works out what each unit of a sale item cost, in the sale currency. With
+// moving average costing that is the product's average cost; first in, first out uses up the
+// oldest cost layers of the product, with units not covered by a layer at the average cost.
+func (uc *SaleUseCase) unitCostAtCompletion(ctx context.Context, tx ports.TransactionPort, sale *entities.Sale, item entities.SaleItem, method entities.CostMethod) (decimal.Decimal, error) {
+	product, err := tx.GetProductRepository().GetByID(ctx, item.ProductID)
+	if err != nil {
+		return decimal.Zero, errors.NewNotFoundError("product")
+	}
+
+	toSaleCurrency, err := uc.saleCurrencyConverter(ctx, sale, product)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if method != entities.CostMethodFIFO {
+		return toSaleCurrency(product.Cost), nil
+	}
+
+	layers, err := tx.GetCostLayerRepository().GetOpenByProductForUpdate(ctx, item.ProductID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": item.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to get cost layers")
+		return decimal.Zero, errors.NewInternalError("failed to get cost layers", err)
+	}
+
+	unitCost, consumed := entities.ConsumeCostLayers(layers, item.Quantity, product.Cost)
+	for _, layer := range consumed {
+		if err := tx.GetCostLayerRepository().UpdateRemaining(ctx, layer); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"cost_layer_id": layer.ID,
+				"error":         err.Error(),
+			}).Error("Failed to update cost layer")
+			return decimal.Zero, errors.NewInternalError("failed to update cost layer", err)
+		}
+	}
+
+	return toSaleCurrency(unitCost), nil
+}
+
+// saleItemsInLockOrder returns the sale items ordered by product ID, the order stock rows are
+// locked in so that concurrent transactions cannot deadlock
+func saleItemsInLockOrder(items []entities.SaleItem) []entities.SaleItem {
+	ordered := make([]entities.SaleItem, len(items))
+	copy(ordered, items)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ProductID.String() < ordered[j].ProductID.String()
+	})
+	return ordered
 }
@@ -0,0 +1,218 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// maxSerialNumbersPerRegistration caps how many serial numbers can be registered at once
+const maxSerialNumbersPerRegistration = 500
+
+// SerialNumberUseCase handles the registry of serial numbers of serialized products
+type SerialNumberUseCase struct {
+	serialNumberRepo repositories.SerialNumberRepository
+	productRepo      repositories.ProductRepository
+	saleRepo         repositories.SaleRepository
+	database         ports.DatabasePort
+	audit            ports.AuditPort
+	logger           logger.Logger
+}
+
+// NewSerialNumberUseCase creates a new serial number use case
+func NewSerialNumberUseCase(
+	serialNumberRepo repositories.SerialNumberRepository,
+	productRepo repositories.ProductRepository,
+	saleRepo repositories.SaleRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *SerialNumberUseCase {
+	return &SerialNumberUseCase{
+		serialNumberRepo: serialNumberRepo,
+		productRepo:      productRepo,
+		saleRepo:         saleRepo,
+		database:         database,
+		audit:            audit,
+		logger:           logger,
+	}
+}
+
+// RegisterSerialNumbersRequest represents serial numbers of a product received into stock
+type RegisterSerialNumbersRequest struct {
+	ProductID     uuid.UUID `json:"product_id" validate:"required"`
+	SerialNumbers []string  `json:"serial_numbers" validate:"required,min=1"`
+}
+
+// SerialNumberListResponse represents serial number list response
+type SerialNumberListResponse struct {
+	SerialNumbers []*entities.SerialNumber `json:"serial_numbers"`
+	Pagination    utils.PaginationInfo     `json:"pagination"`
+}
+
+// SerialNumberLookupResponse represents a serial number with the product it belongs to and the
+// sale it was last sold in, as needed to answer a warranty query
+type SerialNumberLookupResponse struct {
+	*entities.SerialNumber
+	ProductSKU    string `json:"product_sku"`
+	ProductName   string `json:"product_name"`
+	SaleNumber    string `json:"sale_number,omitempty"`
+	CustomerName  string `json:"customer_name,omitempty"`
+	CustomerEmail string `json:"customer_email,omitempty"`
+	CustomerPhone string `json:"customer_phone,omitempty"`
+}
+
+// RegisterSerialNumbers registers serial numbers of a serialized product as in stock. Either
+// all of them are registered or none is.
+func (uc *SerialNumberUseCase) RegisterSerialNumbers(ctx context.Context, tenantID, userID uuid.UUID, req RegisterSerialNumbersRequest) ([]*entities.SerialNumber, error) {
+	if len(req.SerialNumbers) == 0 {
+		return nil, errors.NewValidationError("serial numbers are required", "at least one serial number must be given")
+	}
+	if len(req.SerialNumbers) > maxSerialNumbersPerRegistration {
+		return nil, errors.NewValidationError("too many serial numbers", fmt.Sprintf("at most %d serial numbers can be registered at once", maxSerialNumbersPerRegistration))
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil || product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("product")
+	}
+	if !product.IsSerialized {
+		return nil, errors.NewValidationError("product is not serialized", fmt.Sprintf("product '%s' does not track serial numbers", product.SKU))
+	}
+
+	serials, err := entities.NormalizeSerialNumbers(req.SerialNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	serialNumbers := make([]*entities.SerialNumber, 0, len(serials))
+	for _, serial := range serials {
+		serialNumber, err := entities.NewSerialNumber(tenantID, product.ID, serial)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.GetSerialNumberRepository().Create(ctx, serialNumber); err != nil {
+			if _, ok := errors.IsAppError(err); ok {
+				return nil, err
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": product.ID,
+				"serial":     serial,
+				"error":      err.Error(),
+			}).Error("Failed to register serial number")
+			return nil, errors.NewInternalError("failed to register serial number", err)
+		}
+		serialNumbers = append(serialNumbers, serialNumber)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "register_serial_numbers",
+		Resource:   "product",
+		ResourceID: product.ID.String(),
+		NewValue: map[string]interface{}{
+			"serial_numbers": serials,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"product_id": product.ID,
+		"count":      len(serialNumbers),
+		"user_id":    userID,
+	}).Info("Serial numbers registered")
+
+	return serialNumbers, nil
+}
+
+// ListSerialNumbers retrieves serial numbers with pagination and filtering
+func (uc *SerialNumberUseCase) ListSerialNumbers(ctx context.Context, filter repositories.SerialNumberFilter, pagination utils.PaginationInfo) (*SerialNumberListResponse, error) {
+	serialNumbers, paginationResult, err := uc.serialNumberRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list serial numbers")
+		return nil, errors.NewInternalError("failed to list serial numbers", err)
+	}
+
+	if serialNumbers == nil {
+		serialNumbers = []*entities.SerialNumber{}
+	}
+
+	return &SerialNumberListResponse{
+		SerialNumbers: serialNumbers,
+		Pagination:    paginationResult,
+	}, nil
+}
+
+// LookupSerialNumber finds a serial number for a warranty query, with the product and the
+// sale it was last sold in. Several products may share a serial, so all matches are returned.
+func (uc *SerialNumberUseCase) LookupSerialNumber(ctx context.Context, tenantID uuid.UUID, serial string) ([]*SerialNumberLookupResponse, error) {
+	serial, err := entities.NormalizeSerialNumber(serial)
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumbers, err := uc.serialNumberRepo.FindBySerial(ctx, serial)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"serial": serial,
+			"error":  err.Error(),
+		}).Error("Failed to look up serial number")
+		return nil, errors.NewInternalError("failed to look up serial number", err)
+	}
+
+	responses := make([]*SerialNumberLookupResponse, 0, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		if serialNumber.TenantID != tenantID {
+			continue
+		}
+
+		response := &SerialNumberLookupResponse{SerialNumber: serialNumber}
+		if product, err := uc.productRepo.GetByID(ctx, serialNumber.ProductID); err == nil {
+			response.ProductSKU = product.SKU
+			response.ProductName = product.Name
+		}
+		if serialNumber.SaleID != nil {
+			if sale, err := uc.saleRepo.GetByID(ctx, *serialNumber.SaleID); err == nil {
+				response.SaleNumber = sale.SaleNumber
+				response.CustomerName = sale.CustomerName
+				response.CustomerEmail = sale.CustomerEmail
+				response.CustomerPhone = sale.CustomerPhone
+			}
+		}
+		responses = append(responses, response)
+	}
+
+	if len(responses) == 0 {
+		return nil, errors.NewNotFoundError("serial number")
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// ShelfLabelUseCase prints price tags and shelf labels for products in batches
+type ShelfLabelUseCase struct {
+	productRepo repositories.ProductRepository
+	tenantRepo  repositories.TenantRepository
+	labelPDF    services.ShelfLabelPDFService
+	logger      logger.Logger
+}
+
+// NewShelfLabelUseCase creates a new shelf label use case
+func NewShelfLabelUseCase(
+	productRepo repositories.ProductRepository,
+	tenantRepo repositories.TenantRepository,
+	labelPDF services.ShelfLabelPDFService,
+	logger logger.Logger,
+) *ShelfLabelUseCase {
+	return &ShelfLabelUseCase{
+		productRepo: productRepo,
+		tenantRepo:  tenantRepo,
+		labelPDF:    labelPDF,
+		logger:      logger,
+	}
+}
+
+// PrintShelfLabelsRequest represents print shelf labels request. Products are selected
+// either by ID or by category; a custom layout overrides the named preset.
+type PrintShelfLabelsRequest struct {
+	ProductIDs   []uuid.UUID           `json:"product_ids,omitempty"`
+	Category     string                `json:"category,omitempty"`
+	Layout       string                `json:"layout,omitempty"`
+	CustomLayout *entities.LabelLayout `json:"custom_layout,omitempty"`
+	Copies       int                   `json:"copies,omitempty"` // Labels per product, defaults to 1
+}
+
+// PrintShelfLabels renders shelf labels for the selected products as a PDF
+func (uc *ShelfLabelUseCase) PrintShelfLabels(ctx context.Context, tenantID uuid.UUID, req PrintShelfLabelsRequest) ([]byte, error) {
+	layout, err := resolveLabelLayout(req)
+	if err != nil {
+		return nil, err
+	}
+
+	copies := req.Copies
+	if copies == 0 {
+		copies = 1
+	}
+	if copies < 1 || copies > 100 {
+		return nil, errors.NewValidationError("invalid copies", "copies must be between 1 and 100")
+	}
+
+	products, err := uc.selectProducts(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, errors.NewValidationError("no products selected", "no active products match the selection")
+	}
+	if len(products)*copies > entities.MaxShelfLabelsPerBatch {
+		return nil, errors.NewValidationError("too many labels", fmt.Sprintf("a print job cannot exceed %d labels", entities.MaxShelfLabelsPerBatch))
+	}
+
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get tenant for shelf labels")
+		return nil, errors.NewInternalError("failed to print shelf labels", err)
+	}
+
+	now := time.Now()
+	labels := make([]entities.ShelfLabel, 0, len(products)*copies)
+	for _, product := range products {
+		label := entities.NewShelfLabel(product, tenant.GetCurrencyFormat(), now)
+		for i := 0; i < copies; i++ {
+			labels = append(labels, label)
+		}
+	}
+
+	data, err := uc.labelPDF.GenerateShelfLabelsPDF(ctx, labels, layout)
+	if err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to generate shelf labels")
+		return nil, errors.NewInternalError("failed to print shelf labels", err)
+	}
+
+	return data, nil
+}
+
+// ListLabelLayouts returns the preset label layouts
+func (uc *ShelfLabelUseCase) ListLabelLayouts() []entities.LabelLayout {
+	return entities.LabelLayouts()
+}
+
+// Helper methods
+
+// resolveLabelLayout picks the custom layout, the named preset or the default preset
+func resolveLabelLayout(req PrintShelfLabelsRequest) (entities.LabelLayout, error) {
+	if req.CustomLayout != nil {
+		layout := *req.CustomLayout
+		if layout.Name == "" {
+			layout.Name = "custom"
+		}
+		if err := layout.Validate(); err != nil {
+			return entities.LabelLayout{}, err
+		}
+		return layout, nil
+	}
+
+	name := strings.TrimSpace(req.Layout)
+	if name == "" {
+		name = entities.DefaultLabelLayout
+	}
+	return entities.GetLabelLayout(name)
+}
+
+// selectProducts resolves the tenant's active products selected by ID list or category
+func (uc *ShelfLabelUseCase) selectProducts(ctx context.Context, tenantID uuid.UUID, req PrintShelfLabelsRequest) ([]*entities.Product, error) {
+	category := strings.TrimSpace(req.Category)
+	if (len(req.ProductIDs) == 0) == (category == "") {
+		return nil, errors.NewValidationError("invalid product selection", "provide either product_ids or category")
+	}
+
+	if category != "" {
+		status := entities.ProductStatusActive
+		products, _, err := uc.productRepo.List(ctx, repositories.ProductFilter{
+			Category: category,
+			Status:   &status,
+			OrderBy:  "name",
+			OrderDir: "ASC",
+		}, utils.PaginationInfo{
+			Page:  1,
+			Limit: entities.MaxShelfLabelsPerBatch + 1,
+		})
+		if err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to list products")
+			return nil, errors.NewInternalError("failed to select products", err)
+		}
+
+		selected := make([]*entities.Product, 0, len(products))
+		for _, product := range products {
+			if product.TenantID == tenantID {
+				selected = append(selected, product)
+			}
+		}
+		return selected, nil
+	}
+
+	if len(req.ProductIDs) > entities.MaxShelfLabelsPerBatch {
+		return nil, errors.NewValidationError("too many products selected", fmt.Sprintf("a print job cannot exceed %d labels", entities.MaxShelfLabelsPerBatch))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.ProductIDs))
+	selected := make([]*entities.Product, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		product, err := uc.productRepo.GetByID(ctx, id)
+		if err != nil || product.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("product " + id.String())
+		}
+		selected = append(selected, product)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,695 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// StockCountUseCase handles stocktakes: starting a count, taking batches of scans from
+// handheld scanners with immediate variance feedback, importing counted quantities, and
+// posting the variances to stock
+type StockCountUseCase struct {
+	countRepo    repositories.StockCountRepository
+	productRepo  repositories.ProductRepository
+	stockRepo    repositories.StockRepository
+	locationRepo repositories.LocationRepository
+	categoryRepo repositories.CategoryRepository
+	database     ports.DatabasePort
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewStockCountUseCase creates a new stock count use case
+func NewStockCountUseCase(
+	countRepo repositories.StockCountRepository,
+	productRepo repositories.ProductRepository,
+	stockRepo repositories.StockRepository,
+	locationRepo repositories.LocationRepository,
+	categoryRepo repositories.CategoryRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *StockCountUseCase {
+	return &StockCountUseCase{
+		countRepo:    countRepo,
+		productRepo:  productRepo,
+		stockRepo:    stockRepo,
+		locationRepo: locationRepo,
+		categoryRepo: categoryRepo,
+		database:     database,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// StartStockCountRequest represents start stock count request
+type StartStockCountRequest struct {
+	Name       string     `json:"name" validate:"required,max=100"`
+	Notes      string     `json:"notes,omitempty" validate:"max=500"`
+	LocationID *uuid.UUID `json:"location_id,omitempty"`
+	CategoryID *uuid.UUID `json:"category_id,omitempty"`
+}
+
+// SubmitStockCountScansRequest represents a batch of scans uploaded by a handheld scanner
+type SubmitStockCountScansRequest struct {
+	Scans []entities.StockCountScan `json:"scans" validate:"required,min=1,max=500,dive"`
+}
+
+// StockCountBatchResponse represents the outcome of a batch of scans, one result per scan in
+// the order submitted
+type StockCountBatchResponse struct {
+	CountID    uuid.UUID                       `json:"count_id"`
+	Results    []entities.StockCountScanResult `json:"results"`
+	Recorded   int                             `json:"recorded"`   // Recorded or updated counts
+	Duplicates int                             `json:"duplicates"` // Duplicate and stale scans ignored
+	Rejected   int                             `json:"rejected"`   // Unknown barcodes and invalid scans
+}
+
+// StockCountListResponse represents stock count list response
+type StockCountListResponse struct {
+	Counts     []*entities.StockCount `json:"counts"`
+	Pagination utils.PaginationInfo   `json:"pagination"`
+}
+
+// stockCountEntry is a counted quantity of a product waiting to be recorded on a count. The
+// product is nil when no product matched the scan.
+type stockCountEntry struct {
+	scan    entities.StockCountScan
+	product *entities.Product
+}
+
+// StartCount starts a stock count, optionally limited to a location and a category
+func (uc *StockCountUseCase) StartCount(ctx context.Context, tenantID, userID uuid.UUID, req StartStockCountRequest) (*entities.StockCount, error) {
+	count, err := entities.NewStockCount(tenantID, req.Name, req.Notes, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var location *entities.Location
+	if req.LocationID != nil {
+		location, err = uc.locationRepo.GetByID(ctx, *req.LocationID)
+		if err != nil || location.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("location")
+		}
+	}
+	var category *entities.Category
+	if req.CategoryID != nil {
+		category, err = uc.categoryRepo.GetByID(ctx, *req.CategoryID)
+		if err != nil || category.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("category")
+		}
+	}
+	if err := count.ScopeTo(location, category); err != nil {
+		return nil, err
+	}
+
+	if err := uc.countRepo.Create(ctx, count); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to create stock count")
+		return nil, errors.NewInternalError("failed to start stock count", err)
+	}
+
+	uc.logCountEvent(ctx, userID, "start", count, map[string]interface{}{
+		"name":        count.Name,
+		"location_id": count.LocationID,
+		"category_id": count.CategoryID,
+	})
+
+	return count, nil
+}
+
+// GetCount retrieves a stock count of the tenant with its lines, largest variance first
+func (uc *StockCountUseCase) GetCount(ctx context.Context, tenantID, countID uuid.UUID) (*entities.StockCount, error) {
+	count, err := uc.countRepo.GetByID(ctx, countID)
+	if err != nil || count.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock count")
+	}
+
+	return count, nil
+}
+
+// ListCounts retrieves stock counts with pagination and filtering
+func (uc *StockCountUseCase) ListCounts(ctx context.Context, filter repositories.StockCountFilter, pagination utils.PaginationInfo) (*StockCountListResponse, error) {
+	counts, paginationResult, err := uc.countRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list stock counts")
+		return nil, errors.NewInternalError("failed to list stock counts", err)
+	}
+
+	return &StockCountListResponse{
+		Counts:     counts,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// SubmitScans records a batch of scans from a handheld scanner. Scanners upload what they
+// have whenever they have a connection, so a batch may repeat scans already submitted or
+// arrive after a later one: rescans are deduplicated on the scanner's timestamp. Each scan
+// gets its own result with the product's variance, and a bad scan does not fail the batch.
+func (uc *StockCountUseCase) SubmitScans(ctx context.Context, tenantID, userID, countID uuid.UUID, req SubmitStockCountScansRequest) (*StockCountBatchResponse, error) {
+	if len(req.Scans) == 0 {
+		return nil, errors.NewValidationError("scans are required", "a batch needs at least one scan")
+	}
+	if len(req.Scans) > entities.MaxStockCountBatchSize {
+		return nil, errors.NewValidationError("too many scans", fmt.Sprintf("a batch cannot exceed %d scans", entities.MaxStockCountBatchSize))
+	}
+
+	count, err := uc.GetCount(ctx, tenantID, countID)
+	if err != nil {
+		return nil, err
+	}
+	if !count.IsOpen() {
+		return nil, errors.NewValidationError("stock count not open", "scans can only be submitted to open stock counts")
+	}
+
+	entries := make([]stockCountEntry, 0, len(req.Scans))
+	products := make(map[string]*entities.Product)
+	for _, scan := range req.Scans {
+		scan.Barcode = strings.TrimSpace(scan.Barcode)
+		product, err := uc.productByBarcode(ctx, tenantID, scan.Barcode, products)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, stockCountEntry{scan: scan, product: product})
+	}
+
+	response, err := uc.recordCounts(ctx, tenantID, userID, count, entries, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"count_id":   count.ID,
+		"scans":      len(req.Scans),
+		"recorded":   response.Recorded,
+		"duplicates": response.Duplicates,
+		"rejected":   response.Rejected,
+		"user_id":    userID,
+	}).Info("Stock count scans recorded")
+
+	return response, nil
+}
+
+// ImportCounts records counted quantities from a CSV file with a header row. Products are
+// identified by a "barcode" or "sku" column and counted in a "quantity" column; the
+// quantities of rows for the same product are added up, as a product may be counted on
+// several shelves. Each product replaces its recorded count, and each rejected row gets its
+// own result.
+func (uc *StockCountUseCase) ImportCounts(ctx context.Context, tenantID, userID, countID uuid.UUID, file io.Reader) (*StockCountBatchResponse, error) {
+	count, err := uc.GetCount(ctx, tenantID, countID)
+	if err != nil {
+		return nil, err
+	}
+	if !count.IsOpen() {
+		return nil, errors.NewValidationError("stock count not open", "counts can only be imported to open stock counts")
+	}
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.NewValidationError("invalid CSV file", "the file needs a header row")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	quantityColumn, ok := columns["quantity"]
+	if !ok {
+		return nil, errors.NewValidationError("invalid CSV file", "the file needs a quantity column")
+	}
+	barcodeColumn, hasBarcode := columns["barcode"]
+	skuColumn, hasSKU := columns["sku"]
+	if !hasBarcode && !hasSKU {
+		return nil, errors.NewValidationError("invalid CSV file", "the file needs a barcode or sku column")
+	}
+
+	now := time.Now()
+	rejected := []entities.StockCountScanResult{}
+	entries := []stockCountEntry{}
+	byProduct := make(map[uuid.UUID]int)
+	byBarcode := make(map[string]*entities.Product)
+	bySKU := make(map[string]*entities.Product)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("invalid CSV file", err.Error())
+		}
+		if row > entities.MaxStockCountImportRows {
+			return nil, errors.NewValidationError("too many rows", fmt.Sprintf("a file cannot exceed %d rows", entities.MaxStockCountImportRows))
+		}
+
+		field := func(column int, ok bool) string {
+			if !ok || column >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[column])
+		}
+		scan := entities.StockCountScan{Barcode: field(barcodeColumn, hasBarcode), ScannedAt: now}
+		sku := field(skuColumn, hasSKU)
+
+		quantity, err := strconv.Atoi(field(quantityColumn, true))
+		if err != nil || quantity < 0 {
+			if scan.Barcode == "" {
+				scan.Barcode = sku
+			}
+			rejected = append(rejected, entities.NewStockCountScanResult(scan, entities.StockCountScanStatusInvalid, nil,
+				errors.NewValidationError("invalid quantity", fmt.Sprintf("row %d: quantity must be a whole number of at least 0", row))))
+			continue
+		}
+		scan.Quantity = quantity
+
+		var product *entities.Product
+		if scan.Barcode != "" {
+			product, err = uc.productByBarcode(ctx, tenantID, scan.Barcode, byBarcode)
+		} else {
+			product, err = uc.productBySKU(ctx, tenantID, sku, bySKU)
+			scan.Barcode = sku
+		}
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			rejected = append(rejected, entities.NewStockCountScanResult(scan, entities.StockCountScanStatusUnknownBarcode, nil, nil))
+			continue
+		}
+
+		if i, ok := byProduct[product.ID]; ok {
+			entries[i].scan.Quantity += quantity
+			continue
+		}
+		byProduct[product.ID] = len(entries)
+		entries = append(entries, stockCountEntry{scan: scan, product: product})
+	}
+
+	response, err := uc.recordCounts(ctx, tenantID, userID, count, entries, now)
+	if err != nil {
+		return nil, err
+	}
+	response.Results = append(response.Results, rejected...)
+	response.Rejected += len(rejected)
+
+	uc.logCountEvent(ctx, userID, "import", count, map[string]interface{}{
+		"recorded": response.Recorded,
+		"rejected": response.Rejected,
+	})
+
+	return response, nil
+}
+
+// GetVariances summarizes the differences a stock count found between the counted and the
+// expected quantities
+func (uc *StockCountUseCase) GetVariances(ctx context.Context, tenantID, countID uuid.UUID) (*entities.StockCountVariances, error) {
+	count, err := uc.GetCount(ctx, tenantID, countID)
+	if err != nil {
+		return nil, err
+	}
+
+	return count.Variances(), nil
+}
+
+// CompleteCount approves a stock count and posts each line's variance to stock as a
+// stocktake movement referencing the count. Variances are taken against the stock on record
+// when the line was scanned, so sales made since then are not undone. A count of a location
+// also posts its variances to the stock held there.
+func (uc *StockCountUseCase) CompleteCount(ctx context.Context, tenantID, userID, countID uuid.UUID) (*entities.StockCount, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	count, err := tx.GetStockCountRepository().GetByIDForUpdate(ctx, countID)
+	if err != nil || count.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock count")
+	}
+
+	if err := count.Complete(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var location *entities.Location
+	if count.LocationID != nil {
+		location, err = tx.GetLocationRepository().GetByID(ctx, *count.LocationID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("location")
+		}
+	}
+
+	notes := "Stock count: " + count.Name
+	adjusted := 0
+	for _, line := range count.Lines {
+		if line.Variance == 0 {
+			continue
+		}
+
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, line.ProductID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record of " + line.ProductSKU)
+		}
+
+		movementType := entities.StockMovementTypeIn
+		quantity := line.Variance
+		if quantity > 0 {
+			err = stock.AddStock(quantity, entities.ReasonAdjustment)
+		} else {
+			movementType = entities.StockMovementTypeOut
+			quantity = -quantity
+			err = stock.RemoveStock(quantity)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		movement, err := entities.NewStockMovement(
+			line.ProductID,
+			movementType,
+			entities.ReasonStocktake,
+			quantity,
+			count.ID.String(),
+			notes,
+			userID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		movement.LocationID = count.LocationID
+
+		if location != nil {
+			if err := uc.postLocationVariance(ctx, tx, location, line); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": line.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create stock movement")
+			return nil, errors.NewInternalError("failed to create stock movement", err)
+		}
+
+		if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": line.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to update stock")
+			return nil, errors.NewInternalError("failed to update stock", err)
+		}
+		adjusted++
+	}
+
+	if err := tx.GetStockCountRepository().Update(ctx, count); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"count_id": count.ID,
+			"error":    err.Error(),
+		}).Error("Failed to update stock count")
+		return nil, errors.NewInternalError("failed to complete stock count", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.logCountEvent(ctx, userID, "complete", count, map[string]interface{}{
+		"lines":    len(count.Lines),
+		"adjusted": adjusted,
+	})
+
+	return count, nil
+}
+
+// CancelCount abandons an open stock count without changing stock
+func (uc *StockCountUseCase) CancelCount(ctx context.Context, tenantID, userID, countID uuid.UUID) (*entities.StockCount, error) {
+	count, err := uc.GetCount(ctx, tenantID, countID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := count.Cancel(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.countRepo.Update(ctx, count); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"count_id": count.ID,
+			"error":    err.Error(),
+		}).Error("Failed to update stock count")
+		return nil, errors.NewInternalError("failed to cancel stock count", err)
+	}
+
+	uc.logCountEvent(ctx, userID, "cancel", count, map[string]interface{}{
+		"lines": len(count.Lines),
+	})
+
+	return count, nil
+}
+
+// Helper methods
+
+// productByBarcode looks up the tenant's product with a barcode, nil when there is none.
+// Lookups are cached for the batch, as counters often scan the same product repeatedly.
+func (uc *StockCountUseCase) productByBarcode(ctx context.Context, tenantID uuid.UUID, barcode string, cache map[string]*entities.Product) (*entities.Product, error) {
+	if product, ok := cache[barcode]; ok {
+		return product, nil
+	}
+	if barcode == "" {
+		return nil, nil
+	}
+
+	product, err := uc.productRepo.GetByTenantAndBarcode(ctx, tenantID, barcode)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			cache[barcode] = nil
+			return nil, nil
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"barcode": barcode,
+			"error":   err.Error(),
+		}).Error("Failed to look up product by barcode")
+		return nil, errors.NewInternalError("failed to record scans", err)
+	}
+
+	cache[barcode] = product
+	return product, nil
+}
+
+// recordCounts records counted quantities on an open count and saves the lines that
+// changed. Products outside the count's category are rejected.
+func (uc *StockCountUseCase) recordCounts(ctx context.Context, tenantID, userID uuid.UUID, count *entities.StockCount, entries []stockCountEntry, now time.Time) (*StockCountBatchResponse, error) {
+	categoryIDs, err := uc.categoryIDs(ctx, tenantID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StockCountBatchResponse{
+		CountID: count.ID,
+		Results: make([]entities.StockCountScanResult, 0, len(entries)),
+	}
+	changed := make(map[uuid.UUID]bool)
+
+	for _, entry := range entries {
+		scan, product := entry.scan, entry.product
+		if product == nil {
+			response.Results = append(response.Results, entities.NewStockCountScanResult(scan, entities.StockCountScanStatusUnknownBarcode, nil, nil))
+			response.Rejected++
+			continue
+		}
+		if !count.Covers(product, categoryIDs) {
+			response.Results = append(response.Results, entities.NewStockCountScanResult(scan, entities.StockCountScanStatusInvalid, nil,
+				errors.NewValidationError("product not counted", product.Name+" is not in the "+count.Category+" category")))
+			response.Rejected++
+			continue
+		}
+
+		expected, err := uc.expectedQty(ctx, count, product.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		line, status, err := count.RecordScan(product, expected, scan, userID, now)
+		response.Results = append(response.Results, entities.NewStockCountScanResult(scan, status, line, err))
+		switch status {
+		case entities.StockCountScanStatusRecorded, entities.StockCountScanStatusUpdated:
+			changed[line.ProductID] = true
+			response.Recorded++
+		case entities.StockCountScanStatusDuplicate, entities.StockCountScanStatusStale:
+			response.Duplicates++
+		default:
+			response.Rejected++
+		}
+	}
+
+	lines := make([]*entities.StockCountLine, 0, len(changed))
+	for i := range count.Lines {
+		if changed[count.Lines[i].ProductID] {
+			lines = append(lines, &count.Lines[i])
+		}
+	}
+	if err := uc.countRepo.SaveLines(ctx, lines); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"count_id": count.ID,
+			"error":    err.Error(),
+		}).Error("Failed to save stock count lines")
+		return nil, errors.NewInternalError("failed to record scans", err)
+	}
+
+	return response, nil
+}
+
+// productBySKU looks up the tenant's product with a SKU, nil when there is none. Lookups are
+// cached for the import.
+func (uc *StockCountUseCase) productBySKU(ctx context.Context, tenantID uuid.UUID, sku string, cache map[string]*entities.Product) (*entities.Product, error) {
+	if product, ok := cache[sku]; ok {
+		return product, nil
+	}
+	if sku == "" {
+		return nil, nil
+	}
+
+	product, err := uc.productRepo.GetByTenantAndSKU(ctx, tenantID, sku)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			cache[sku] = nil
+			return nil, nil
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"sku":   sku,
+			"error": err.Error(),
+		}).Error("Failed to look up product by SKU")
+		return nil, errors.NewInternalError("failed to import counts", err)
+	}
+	cache[sku] = product
+	return product, nil
+}
+
+// categoryIDs returns the count's category and its subcategories, nil when the count is not
+// limited to a category
+func (uc *StockCountUseCase) categoryIDs(ctx context.Context, tenantID uuid.UUID, count *entities.StockCount) (map[uuid.UUID]bool, error) {
+	if count.CategoryID == nil {
+		return nil, nil
+	}
+
+	categories, err := uc.categoryRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"count_id": count.ID,
+			"error":    err.Error(),
+		}).Error("Failed to get categories for stock count")
+		return nil, errors.NewInternalError("failed to record scans", err)
+	}
+
+	return entities.CategorySubtree(*count.CategoryID, categories), nil
+}
+
+// postLocationVariance applies a line's variance to the stock held at the count's location,
+// never taking it below zero
+func (uc *StockCountUseCase) postLocationVariance(ctx context.Context, tx ports.TransactionPort, location *entities.Location, line entities.StockCountLine) error {
+	stock, err := tx.GetLocationRepository().GetStockForUpdate(ctx, location.ID, line.ProductID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			return errors.NewInternalError("failed to get location stock", err)
+		}
+		stock = entities.NewLocationStock(location, line.ProductID)
+	}
+
+	quantity := stock.Quantity + line.Variance
+	if quantity < 0 {
+		quantity = 0
+	}
+	if err := stock.SetQuantity(quantity); err != nil {
+		return err
+	}
+
+	if err := tx.GetLocationRepository().SaveStock(ctx, stock); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"location_id": location.ID,
+			"product_id":  line.ProductID,
+			"error":       err.Error(),
+		}).Error("Failed to save location stock")
+		return errors.NewInternalError("failed to save location stock", err)
+	}
+
+	return nil
+}
+
+// expectedQty returns the units of a product on record, on the shelves or held for orders,
+// or the units held at the count's location
+func (uc *StockCountUseCase) expectedQty(ctx context.Context, count *entities.StockCount, productID uuid.UUID) (int, error) {
+	if count.LocationID != nil {
+		stock, err := uc.locationRepo.GetStock(ctx, *count.LocationID, productID)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+				return 0, nil
+			}
+			uc.logger.WithFields(map[string]interface{}{
+				"location_id": count.LocationID,
+				"product_id":  productID,
+				"error":       err.Error(),
+			}).Error("Failed to get location stock for stock count")
+			return 0, errors.NewInternalError("failed to record scans", err)
+		}
+		return stock.Quantity, nil
+	}
+
+	stock, err := uc.stockRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return 0, nil
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to get stock for stock count")
+		return 0, errors.NewInternalError("failed to record scans", err)
+	}
+
+	return stock.TotalQty, nil
+}
+
+// logCountEvent records a stock count event in the audit log
+func (uc *StockCountUseCase) logCountEvent(ctx context.Context, userID uuid.UUID, action string, count *entities.StockCount, newValue map[string]interface{}) {
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "stock_count",
+		ResourceID: count.ID.String(),
+		NewValue:   newValue,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"count_id": count.ID,
+		"status":   count.Status,
+		"user_id":  userID,
+		"action":   action,
+	}).Info("Stock count event recorded")
+}
@@ -0,0 +1,534 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// reservationExpiryBatchSize caps how many expired reservations one job run releases
+const reservationExpiryBatchSize = 100
+
+// StockReservationUseCase handles stock reservations for external order sources
+type StockReservationUseCase struct {
+	stockReservationRepo repositories.StockReservationRepository
+	database             ports.DatabasePort
+	audit                ports.AuditPort
+	logger               logger.Logger
+}
+
+// NewStockReservationUseCase creates a new stock reservation use case
+func NewStockReservationUseCase(
+	stockReservationRepo repositories.StockReservationRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *StockReservationUseCase {
+	return &StockReservationUseCase{
+		stockReservationRepo: stockReservationRepo,
+		database:             database,
+		audit:                audit,
+		logger:               logger,
+	}
+}
+
+// StockReservationItemRequest represents a product line in a reservation request
+type StockReservationItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+}
+
+// CreateStockReservationRequest represents create stock reservation request
+type CreateStockReservationRequest struct {
+	Reference        string                        `json:"reference" validate:"required"`
+	Source           string                        `json:"source,omitempty"`
+	Items            []StockReservationItemRequest `json:"items" validate:"required,min=1"`
+	ExpiresInSeconds int                           `json:"expires_in_seconds,omitempty"` // Defaults to 15 minutes
+	Notes            string                        `json:"notes,omitempty"`
+}
+
+// ConfirmStockReservationRequest represents confirm stock reservation request.
+// When Items is empty, all outstanding quantities are confirmed.
+type ConfirmStockReservationRequest struct {
+	Items []StockReservationItemRequest `json:"items,omitempty"`
+	Notes string                        `json:"notes,omitempty"`
+}
+
+// ReleaseStockReservationRequest represents release stock reservation request
+type ReleaseStockReservationRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// ExtendStockReservationRequest represents extend stock reservation request
+type ExtendStockReservationRequest struct {
+	ExpiresInSeconds int `json:"expires_in_seconds" validate:"required,min=1"`
+}
+
+// StockReservationResponse represents stock reservation response
+type StockReservationResponse struct {
+	ID        uuid.UUID                       `json:"id"`
+	Reference string                          `json:"reference"`
+	Source    string                          `json:"source,omitempty"`
+	Status    entities.StockReservationStatus `json:"status"`
+	Items     []*StockReservationItemResponse `json:"items"`
+	Notes     string                          `json:"notes,omitempty"`
+	ExpiresAt time.Time                       `json:"expires_at"`
+	CreatedAt time.Time                       `json:"created_at"`
+	UpdatedAt time.Time                       `json:"updated_at"`
+	CreatedBy uuid.UUID                       `json:"created_by"`
+}
+
+// StockReservationItemResponse represents stock reservation item response
+type StockReservationItemResponse struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	Quantity       int       `json:"quantity"`
+	ConfirmedQty   int       `json:"confirmed_qty"`
+	ReleasedQty    int       `json:"released_qty"`
+	OutstandingQty int       `json:"outstanding_qty"`
+}
+
+// StockReservationListResponse represents stock reservation list response
+type StockReservationListResponse struct {
+	Reservations []*StockReservationResponse `json:"reservations"`
+	Pagination   utils.PaginationInfo        `json:"pagination"`
+}
+
+// CreateReservation reserves stock for an external order
+func (uc *StockReservationUseCase) CreateReservation(ctx context.Context, tenantID, userID uuid.UUID, req CreateStockReservationRequest) (*StockReservationResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, errors.NewValidationError("reservation items are required", "at least one item must be reserved")
+	}
+
+	reservation, err := entities.NewStockReservation(tenantID, req.Reference, req.Source, time.Duration(req.ExpiresInSeconds)*time.Second, userID)
+	if err != nil {
+		return nil, err
+	}
+	reservation.Notes = req.Notes
+
+	for _, item := range req.Items {
+		if err := reservation.AddItem(item.ProductID, item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range reservation.Items {
+		if _, err := tx.GetProductRepository().GetByID(ctx, item.ProductID); err != nil {
+			return nil, errors.NewNotFoundError("product")
+		}
+
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record")
+		}
+
+		if err := stock.ReserveStock(item.Quantity); err != nil {
+			return nil, err
+		}
+
+		if err := uc.recordMovement(ctx, tx, stock, item.ProductID, entities.StockMovementTypeReserved, entities.ReasonReservation, item.Quantity, reservation.Reference, req.Notes, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.GetStockReservationRepository().Create(ctx, reservation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"reference": reservation.Reference,
+			"error":     err.Error(),
+		}).Error("Failed to create stock reservation")
+		return nil, errors.NewInternalError("failed to create stock reservation", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "stock_reservation",
+		ResourceID: reservation.ID.String(),
+		NewValue: map[string]interface{}{
+			"reference":  reservation.Reference,
+			"source":     reservation.Source,
+			"items":      reservation.Items,
+			"expires_at": reservation.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"reservation_id": reservation.ID,
+		"reference":      reservation.Reference,
+		"source":         reservation.Source,
+		"user_id":        userID,
+	}).Info("Stock reservation created successfully")
+
+	return uc.toStockReservationResponse(reservation), nil
+}
+
+// GetReservation retrieves a stock reservation by ID
+func (uc *StockReservationUseCase) GetReservation(ctx context.Context, tenantID, reservationID uuid.UUID) (*StockReservationResponse, error) {
+	reservation, err := uc.stockReservationRepo.GetByID(ctx, reservationID)
+	if err != nil || reservation.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock reservation")
+	}
+
+	return uc.toStockReservationResponse(reservation), nil
+}
+
+// ListReservations retrieves stock reservations with pagination and filtering
+func (uc *StockReservationUseCase) ListReservations(ctx context.Context, filter repositories.StockReservationFilter, pagination utils.PaginationInfo) (*StockReservationListResponse, error) {
+	reservations, paginationResult, err := uc.stockReservationRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list stock reservations")
+		return nil, errors.NewInternalError("failed to list stock reservations", err)
+	}
+
+	responses := make([]*StockReservationResponse, len(reservations))
+	for i, reservation := range reservations {
+		responses[i] = uc.toStockReservationResponse(reservation)
+	}
+
+	return &StockReservationListResponse{
+		Reservations: responses,
+		Pagination:   paginationResult,
+	}, nil
+}
+
+// GetReservationsByReference retrieves all stock reservations for an external order reference
+func (uc *StockReservationUseCase) GetReservationsByReference(ctx context.Context, tenantID uuid.UUID, reference string) ([]*StockReservationResponse, error) {
+	reservations, err := uc.stockReservationRepo.GetByReference(ctx, reference)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get stock reservations by reference")
+		return nil, errors.NewInternalError("failed to get stock reservations", err)
+	}
+
+	responses := make([]*StockReservationResponse, 0, len(reservations))
+	for _, reservation := range reservations {
+		if reservation.TenantID != tenantID {
+			continue
+		}
+		responses = append(responses, uc.toStockReservationResponse(reservation))
+	}
+
+	return responses, nil
+}
+
+// ConfirmReservation confirms part or all of a reservation, turning reserved stock into sold stock
+func (uc *StockReservationUseCase) ConfirmReservation(ctx context.Context, tenantID, userID, reservationID uuid.UUID, req ConfirmStockReservationRequest) (*StockReservationResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	reservation, err := tx.GetStockReservationRepository().GetByIDForUpdate(ctx, reservationID)
+	if err != nil || reservation.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock reservation")
+	}
+
+	items := req.Items
+	if len(items) == 0 {
+		for _, item := range reservation.OutstandingItems() {
+			items = append(items, StockReservationItemRequest{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
+	}
+
+	for _, item := range items {
+		if err := reservation.Confirm(item.ProductID, item.Quantity); err != nil {
+			return nil, err
+		}
+
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record")
+		}
+
+		if err := stock.ConfirmReservedStock(item.Quantity); err != nil {
+			return nil, err
+		}
+
+		if err := uc.recordMovement(ctx, tx, stock, item.ProductID, entities.StockMovementTypeOut, entities.ReasonSale, item.Quantity, reservation.Reference, req.Notes, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.GetStockReservationRepository().Update(ctx, reservation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"reservation_id": reservationID,
+			"error":          err.Error(),
+		}).Error("Failed to update stock reservation")
+		return nil, errors.NewInternalError("failed to update stock reservation", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "confirm",
+		Resource:   "stock_reservation",
+		ResourceID: reservationID.String(),
+		NewValue: map[string]interface{}{
+			"status":          reservation.Status,
+			"confirmed_items": items,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"reservation_id": reservationID,
+		"reference":      reservation.Reference,
+		"status":         reservation.Status,
+		"user_id":        userID,
+	}).Info("Stock reservation confirmed successfully")
+
+	return uc.toStockReservationResponse(reservation), nil
+}
+
+// ReleaseReservation releases all outstanding reserved stock back to available
+func (uc *StockReservationUseCase) ReleaseReservation(ctx context.Context, tenantID, userID, reservationID uuid.UUID, req ReleaseStockReservationRequest) (*StockReservationResponse, error) {
+	reservation, err := uc.settleReservation(ctx, reservationID, userID, req.Notes, func(reservation *entities.StockReservation) error {
+		if reservation.TenantID != tenantID {
+			return errors.NewNotFoundError("stock reservation")
+		}
+		return reservation.Release()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "release",
+		Resource:   "stock_reservation",
+		ResourceID: reservationID.String(),
+		NewValue: map[string]interface{}{
+			"status": reservation.Status,
+			"notes":  req.Notes,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"reservation_id": reservationID,
+		"reference":      reservation.Reference,
+		"user_id":        userID,
+	}).Info("Stock reservation released successfully")
+
+	return uc.toStockReservationResponse(reservation), nil
+}
+
+// ExtendReservation pushes out the expiry of an open reservation
+func (uc *StockReservationUseCase) ExtendReservation(ctx context.Context, tenantID, userID, reservationID uuid.UUID, req ExtendStockReservationRequest) (*StockReservationResponse, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the reservation so it cannot be released or expired while it is extended
+	reservation, err := tx.GetStockReservationRepository().GetByIDForUpdate(ctx, reservationID)
+	if err != nil || reservation.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock reservation")
+	}
+
+	if err := reservation.ExtendExpiry(time.Duration(req.ExpiresInSeconds) * time.Second); err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetStockReservationRepository().Update(ctx, reservation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"reservation_id": reservationID,
+			"error":          err.Error(),
+		}).Error("Failed to extend stock reservation")
+		return nil, errors.NewInternalError("failed to extend stock reservation", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"reservation_id": reservationID,
+		"expires_at":     reservation.ExpiresAt,
+		"user_id":        userID,
+	}).Info("Stock reservation extended successfully")
+
+	return uc.toStockReservationResponse(reservation), nil
+}
+
+// ExpireReservations releases stock held by reservations that passed their expiry. It runs as
+// a scheduled job across all tenants; stock movements are attributed to the reservation creator.
+func (uc *StockReservationUseCase) ExpireReservations(ctx context.Context) error {
+	reservations, err := uc.stockReservationRepo.GetExpired(ctx, time.Now(), reservationExpiryBatchSize)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get expired stock reservations")
+		return errors.NewInternalError("failed to get expired stock reservations", err)
+	}
+
+	expired := 0
+	for _, candidate := range reservations {
+		_, err := uc.settleReservation(ctx, candidate.ID, candidate.CreatedBy, "Reservation expired", func(reservation *entities.StockReservation) error {
+			return reservation.Expire()
+		})
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"reservation_id": candidate.ID,
+				"error":          err.Error(),
+			}).Warn("Failed to expire stock reservation")
+			continue
+		}
+		expired++
+	}
+
+	if expired > 0 {
+		uc.logger.WithField("count", expired).Info("Expired stock reservations released")
+	}
+
+	return nil
+}
+
+// settleReservation applies a closing transition to a reservation and returns its outstanding stock to available
+func (uc *StockReservationUseCase) settleReservation(ctx context.Context, reservationID, userID uuid.UUID, notes string, transition func(*entities.StockReservation) error) (*entities.StockReservation, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	reservation, err := tx.GetStockReservationRepository().GetByIDForUpdate(ctx, reservationID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("stock reservation")
+	}
+
+	outstanding := reservation.OutstandingItems()
+	if err := transition(reservation); err != nil {
+		return nil, err
+	}
+
+	for _, item := range outstanding {
+		stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("stock record")
+		}
+
+		if err := stock.ReleaseReservedStock(item.Quantity); err != nil {
+			return nil, err
+		}
+
+		if err := uc.recordMovement(ctx, tx, stock, item.ProductID, entities.StockMovementTypeReleased, entities.ReasonRelease, item.Quantity, reservation.Reference, notes, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.GetStockReservationRepository().Update(ctx, reservation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"reservation_id": reservationID,
+			"error":          err.Error(),
+		}).Error("Failed to update stock reservation")
+		return nil, errors.NewInternalError("failed to update stock reservation", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	return reservation, nil
+}
+
+// recordMovement saves a stock movement and the updated stock record within a transaction
+func (uc *StockReservationUseCase) recordMovement(ctx context.Context, tx ports.TransactionPort, stock *entities.Stock, productID uuid.UUID, movementType entities.StockMovementType, reason entities.StockMovementReason, quantity int, reference, notes string, userID uuid.UUID) error {
+	movement, err := entities.NewStockMovement(productID, movementType, reason, quantity, reference, notes, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to create stock movement")
+		return errors.NewInternalError("failed to create stock movement", err)
+	}
+
+	if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to update stock")
+		return errors.NewInternalError("failed to update stock", err)
+	}
+
+	return nil
+}
+
+// toStockReservationResponse converts stock reservation entity to response
+func (uc *StockReservationUseCase) toStockReservationResponse(reservation *entities.StockReservation) *StockReservationResponse {
+	items := make([]*StockReservationItemResponse, len(reservation.Items))
+	for i, item := range reservation.Items {
+		items[i] = &StockReservationItemResponse{
+			ProductID:      item.ProductID,
+			Quantity:       item.Quantity,
+			ConfirmedQty:   item.ConfirmedQty,
+			ReleasedQty:    item.ReleasedQty,
+			OutstandingQty: item.OutstandingQty(),
+		}
+	}
+
+	return &StockReservationResponse{
+		ID:        reservation.ID,
+		Reference: reservation.Reference,
+		Source:    reservation.Source,
+		Status:    reservation.Status,
+		Items:     items,
+		Notes:     reservation.Notes,
+		ExpiresAt: reservation.ExpiresAt,
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+		CreatedBy: reservation.CreatedBy,
+	}
+}
@@ -0,0 +1,349 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// StockTransferUseCase handles moving stock between locations
+type StockTransferUseCase struct {
+	transferRepo repositories.StockTransferRepository
+	locationRepo repositories.LocationRepository
+	productRepo  repositories.ProductRepository
+	database     ports.DatabasePort
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewStockTransferUseCase creates a new stock transfer use case
+func NewStockTransferUseCase(
+	transferRepo repositories.StockTransferRepository,
+	locationRepo repositories.LocationRepository,
+	productRepo repositories.ProductRepository,
+	database ports.DatabasePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *StockTransferUseCase {
+	return &StockTransferUseCase{
+		transferRepo: transferRepo,
+		locationRepo: locationRepo,
+		productRepo:  productRepo,
+		database:     database,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// StockTransferItemRequest represents a product line in a stock transfer request
+type StockTransferItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+}
+
+// CreateStockTransferRequest represents create stock transfer request
+type CreateStockTransferRequest struct {
+	FromLocationID uuid.UUID                  `json:"from_location_id" validate:"required"`
+	ToLocationID   uuid.UUID                  `json:"to_location_id" validate:"required"`
+	Items          []StockTransferItemRequest `json:"items" validate:"required,min=1"`
+	Notes          string                     `json:"notes,omitempty"`
+}
+
+// StockTransferListResponse represents stock transfer list response
+type StockTransferListResponse struct {
+	Transfers  []*entities.StockTransfer `json:"transfers"`
+	Pagination utils.PaginationInfo      `json:"pagination"`
+}
+
+// CreateTransfer requests moving stock from one location to another. Stock leaves the
+// source location only when the transfer is shipped.
+func (uc *StockTransferUseCase) CreateTransfer(ctx context.Context, tenantID, userID uuid.UUID, req CreateStockTransferRequest) (*entities.StockTransfer, error) {
+	from, err := uc.getLocation(ctx, tenantID, req.FromLocationID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := uc.getLocation(ctx, tenantID, req.ToLocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := entities.NewStockTransfer(tenantID, utils.GenerateStockTransferNumber(), from, to, req.Notes, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range req.Items {
+		product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil || product.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("product " + item.ProductID.String())
+		}
+		if err := transfer.AddItem(product, item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+	if len(transfer.Items) == 0 {
+		return nil, errors.NewValidationError("transfer has no items", "a transfer needs at least one item")
+	}
+
+	if err := uc.transferRepo.Create(ctx, transfer); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"transfer_number": transfer.TransferNumber,
+			"error":           err.Error(),
+		}).Error("Failed to create stock transfer")
+		return nil, errors.NewInternalError("failed to create stock transfer", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "stock_transfer",
+		ResourceID: transfer.ID.String(),
+		NewValue: map[string]interface{}{
+			"transfer_number":  transfer.TransferNumber,
+			"from_location_id": transfer.FromLocationID,
+			"to_location_id":   transfer.ToLocationID,
+			"items":            transfer.Items,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"transfer_id":     transfer.ID,
+		"transfer_number": transfer.TransferNumber,
+		"user_id":         userID,
+	}).Info("Stock transfer requested")
+
+	return transfer, nil
+}
+
+// GetTransfer retrieves a stock transfer by ID
+func (uc *StockTransferUseCase) GetTransfer(ctx context.Context, tenantID, transferID uuid.UUID) (*entities.StockTransfer, error) {
+	transfer, err := uc.transferRepo.GetByID(ctx, transferID)
+	if err != nil || transfer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock transfer")
+	}
+
+	return transfer, nil
+}
+
+// ListTransfers retrieves stock transfers with pagination and filtering
+func (uc *StockTransferUseCase) ListTransfers(ctx context.Context, filter repositories.StockTransferFilter, pagination utils.PaginationInfo) (*StockTransferListResponse, error) {
+	transfers, paginationResult, err := uc.transferRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list stock transfers")
+		return nil, errors.NewInternalError("failed to list stock transfers", err)
+	}
+
+	if transfers == nil {
+		transfers = []*entities.StockTransfer{}
+	}
+
+	return &StockTransferListResponse{
+		Transfers:  transfers,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// ListInTransitTransfers retrieves the transfers shipped but not received yet, optionally
+// only those from or to a location
+func (uc *StockTransferUseCase) ListInTransitTransfers(ctx context.Context, locationID *uuid.UUID, pagination utils.PaginationInfo) (*StockTransferListResponse, error) {
+	status := entities.StockTransferStatusInTransit
+	return uc.ListTransfers(ctx, repositories.StockTransferFilter{
+		Status:     &status,
+		LocationID: locationID,
+	}, pagination)
+}
+
+// ShipTransfer takes the transfer's quantities out of the source location and marks it in
+// transit, recording an outgoing transfer movement per product at the source location
+func (uc *StockTransferUseCase) ShipTransfer(ctx context.Context, tenantID, userID, transferID uuid.UUID) (*entities.StockTransfer, error) {
+	return uc.moveStock(ctx, tenantID, userID, transferID, "ship", func(transfer *entities.StockTransfer, now time.Time) error {
+		return transfer.Ship(userID, now)
+	})
+}
+
+// ReceiveTransfer puts the transfer's quantities into the destination location and marks it
+// received, recording an incoming transfer movement per product at the destination location
+func (uc *StockTransferUseCase) ReceiveTransfer(ctx context.Context, tenantID, userID, transferID uuid.UUID) (*entities.StockTransfer, error) {
+	return uc.moveStock(ctx, tenantID, userID, transferID, "receive", func(transfer *entities.StockTransfer, now time.Time) error {
+		return transfer.Receive(userID, now)
+	})
+}
+
+// CancelTransfer cancels a transfer that has not been shipped yet
+func (uc *StockTransferUseCase) CancelTransfer(ctx context.Context, tenantID, userID, transferID uuid.UUID) (*entities.StockTransfer, error) {
+	transfer, err := uc.GetTransfer(ctx, tenantID, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transfer.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.transferRepo.Update(ctx, transfer); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"transfer_id": transferID,
+			"error":       err.Error(),
+		}).Error("Failed to update stock transfer")
+		return nil, errors.NewInternalError("failed to update stock transfer", err)
+	}
+
+	uc.logTransferEvent(ctx, userID, "cancel", transfer)
+
+	return transfer, nil
+}
+
+// moveStock applies a shipping or receiving transition to a transfer and moves its
+// quantities out of the source or into the destination location. The transfer and the
+// location stock are locked so concurrent requests cannot move the same stock twice.
+func (uc *StockTransferUseCase) moveStock(ctx context.Context, tenantID, userID, transferID uuid.UUID, action string, transition func(*entities.StockTransfer, time.Time) error) (*entities.StockTransfer, error) {
+	// Start transaction
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	transfer, err := tx.GetStockTransferRepository().GetByIDForUpdate(ctx, transferID)
+	if err != nil || transfer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock transfer")
+	}
+
+	if err := transition(transfer, time.Now()); err != nil {
+		return nil, err
+	}
+
+	locationID := transfer.FromLocationID
+	if transfer.Status == entities.StockTransferStatusReceived {
+		locationID = transfer.ToLocationID
+	}
+	location, err := tx.GetLocationRepository().GetByID(ctx, locationID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("location")
+	}
+	if !location.IsActive {
+		return nil, errors.NewValidationError("location not active", location.Name+" is not active")
+	}
+
+	for _, item := range transferItemsInLockOrder(transfer.Items) {
+		stock, err := tx.GetLocationRepository().GetStockForUpdate(ctx, location.ID, item.ProductID)
+		if err != nil {
+			if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+				return nil, errors.NewInternalError("failed to get location stock", err)
+			}
+			stock = entities.NewLocationStock(location, item.ProductID)
+		}
+
+		if transfer.Status == entities.StockTransferStatusReceived {
+			err = stock.Add(item.Quantity)
+		} else {
+			err = stock.Remove(item.Quantity, item.ProductName)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.GetLocationRepository().SaveStock(ctx, stock); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"location_id": location.ID,
+				"product_id":  item.ProductID,
+				"error":       err.Error(),
+			}).Error("Failed to save location stock")
+			return nil, errors.NewInternalError("failed to save location stock", err)
+		}
+
+		movement, err := transfer.StockMovement(item, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.GetStockMovementRepository().Create(ctx, movement); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"product_id": item.ProductID,
+				"error":      err.Error(),
+			}).Error("Failed to create stock movement")
+			return nil, errors.NewInternalError("failed to create stock movement", err)
+		}
+	}
+
+	if err := tx.GetStockTransferRepository().Update(ctx, transfer); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"transfer_id": transferID,
+			"error":       err.Error(),
+		}).Error("Failed to update stock transfer")
+		return nil, errors.NewInternalError("failed to update stock transfer", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.logTransferEvent(ctx, userID, action, transfer)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"transfer_id":     transfer.ID,
+		"transfer_number": transfer.TransferNumber,
+		"status":          transfer.Status,
+		"user_id":         userID,
+	}).Info("Stock transfer updated")
+
+	return transfer, nil
+}
+
+// getLocation retrieves a location of the tenant
+func (uc *StockTransferUseCase) getLocation(ctx context.Context, tenantID, locationID uuid.UUID) (*entities.Location, error) {
+	location, err := uc.locationRepo.GetByID(ctx, locationID)
+	if err != nil || location.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("location")
+	}
+
+	return location, nil
+}
+
+// logTransferEvent records a status change of a transfer in the audit log
+func (uc *StockTransferUseCase) logTransferEvent(ctx context.Context, userID uuid.UUID, action string, transfer *entities.StockTransfer) {
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "stock_transfer",
+		ResourceID: transfer.ID.String(),
+		NewValue: map[string]interface{}{
+			"transfer_number": transfer.TransferNumber,
+			"status":          transfer.Status,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+}
+
+// transferItemsInLockOrder returns the transfer items ordered by product ID, the order
+// location stock rows are locked in so that concurrent transactions cannot deadlock
+func transferItemsInLockOrder(items []entities.StockTransferItem) []entities.StockTransferItem {
+	ordered := make([]entities.StockTransferItem, len(items))
+	copy(ordered, items)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ProductID.String() < ordered[j].ProductID.String()
+	})
+	return ordered
+}
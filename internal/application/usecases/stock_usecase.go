@@ -14,11 +14,15 @@ import (
 	"github.com/nicklaros/adol/pkg/utils"
 )
 
+// maxStockLedgerRange caps the period a product stock ledger can cover
+const maxStockLedgerRange = 366 * 24 * time.Hour
+
 // StockUseCase handles stock management operations
 type StockUseCase struct {
 	stockRepo         repositories.StockRepository
 	stockMovementRepo repositories.StockMovementRepository
 	productRepo       repositories.ProductRepository
+	webhooks          *WebhookUseCase
 	database          ports.DatabasePort
 	audit             ports.AuditPort
 	logger            logger.Logger
@@ -29,6 +33,7 @@ func NewStockUseCase(
 	stockRepo repositories.StockRepository,
 	stockMovementRepo repositories.StockMovementRepository,
 	productRepo repositories.ProductRepository,
+	webhooks *WebhookUseCase,
 	database ports.DatabasePort,
 	audit ports.AuditPort,
 	logger logger.Logger,
@@ -37,6 +42,7 @@ func NewStockUseCase(
 		stockRepo:         stockRepo,
 		stockMovementRepo: stockMovementRepo,
 		productRepo:       productRepo,
+		webhooks:          webhooks,
 		database:          database,
 		audit:             audit,
 		logger:            logger,
@@ -80,6 +86,7 @@ type StockMovementResponse struct {
 	Quantity    int                          `json:"quantity"`
 	Reference   string                       `json:"reference,omitempty"`
 	Notes       string                       `json:"notes,omitempty"`
+	ReversalOf  *uuid.UUID                   `json:"reversal_of,omitempty"`
 	CreatedAt   time.Time                    `json:"created_at"`
 	CreatedBy   uuid.UUID                    `json:"created_by"`
 }
@@ -96,6 +103,13 @@ type StockMovementListResponse struct {
 	Pagination utils.PaginationInfo     `json:"pagination"`
 }
 
+// StockLedgerResponse represents a product's running-balance stock ledger
+type StockLedgerResponse struct {
+	ProductSKU  string `json:"product_sku"`
+	ProductName string `json:"product_name"`
+	*repositories.StockLedger
+}
+
 // ReserveStockRequest represents reserve stock request
 type ReserveStockRequest struct {
 	ProductID uuid.UUID `json:"product_id" validate:"required"`
@@ -120,14 +134,15 @@ func (uc *StockUseCase) AdjustStock(ctx context.Context, userID uuid.UUID, req S
 		return nil, errors.NewNotFoundError("product")
 	}
 
-	// Get stock record
-	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
+	// Get stock record, locked so concurrent updates cannot overwrite each other
+	stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, req.ProductID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("stock record")
 	}
 
 	// Store old quantity for audit
 	oldQty := stock.AvailableQty
+	wasLow := stock.IsLowStock()
 
 	// Adjust stock based on type
 	switch req.Type {
@@ -210,9 +225,128 @@ func (uc *StockUseCase) AdjustStock(ctx context.Context, userID uuid.UUID, req S
 		"user_id":     userID,
 	}).Info("Stock adjusted successfully")
 
+	if !wasLow && stock.IsLowStock() {
+		uc.webhooks.Publish(ctx, product.TenantID, entities.WebhookEventStockLow, NewStockLowEvent(stock, product))
+	}
+
 	return uc.toStockResponse(stock, product), nil
 }
 
+// ReverseStockMovementRequest represents reverse stock movement request
+type ReverseStockMovementRequest struct {
+	Notes string `json:"notes" validate:"required"` // Why the movement is reversed
+}
+
+// ReverseStockMovement undoes a mistaken stock adjustment by recording the opposite movement
+// and restoring the stock it changed. A movement can be reversed only once.
+func (uc *StockUseCase) ReverseStockMovement(ctx context.Context, tenantID, userID, movementID uuid.UUID, req ReverseStockMovementRequest) (*StockMovementResponse, error) {
+	tx, err := uc.database.BeginTransaction(ctx)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to begin transaction")
+		return nil, errors.NewInternalError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	original, err := tx.GetStockMovementRepository().GetByID(ctx, movementID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("stock movement")
+	}
+
+	// Movements belong to the tenant of their product
+	product, err := tx.GetProductRepository().GetByID(ctx, original.ProductID)
+	if err != nil || product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("stock movement")
+	}
+
+	// Lock the stock record first, so two reversals of the movement cannot both pass the check
+	stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, original.ProductID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("stock record")
+	}
+
+	if _, err := tx.GetStockMovementRepository().GetReversal(ctx, original.ID); err == nil {
+		return nil, errors.NewConflictError("stock movement has already been reversed")
+	} else if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+		uc.logger.WithField("error", err.Error()).Error("Failed to check stock movement reversal")
+		return nil, errors.NewInternalError("failed to check stock movement reversal", err)
+	}
+
+	reversal, err := original.NewReversal(req.Notes, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldQty := stock.AvailableQty
+	wasLow := stock.IsLowStock()
+
+	if reversal.Type == entities.StockMovementTypeIn {
+		err = stock.AddStock(reversal.Quantity, reversal.Reason)
+	} else {
+		err = stock.RemoveStock(reversal.Quantity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.GetStockMovementRepository().Create(ctx, reversal); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"movement_id": movementID,
+			"error":       err.Error(),
+		}).Error("Failed to create stock movement reversal")
+		return nil, errors.NewInternalError("failed to create stock movement", err)
+	}
+
+	if err := tx.GetStockRepository().Update(ctx, stock); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": original.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to update stock")
+		return nil, errors.NewInternalError("failed to update stock", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to commit transaction")
+		return nil, errors.NewInternalError("failed to commit transaction", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "reverse_stock_movement",
+		Resource:   "stock",
+		ResourceID: stock.ID.String(),
+		OldValue: map[string]interface{}{
+			"available_qty": oldQty,
+		},
+		NewValue: map[string]interface{}{
+			"available_qty": stock.AvailableQty,
+			"movement_id":   original.ID,
+			"reversal_id":   reversal.ID,
+			"type":          reversal.Type,
+			"quantity":      reversal.Quantity,
+			"notes":         reversal.Notes,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"movement_id": movementID,
+		"reversal_id": reversal.ID,
+		"product_sku": product.SKU,
+		"user_id":     userID,
+	}).Info("Stock movement reversed")
+
+	if !wasLow && stock.IsLowStock() {
+		uc.webhooks.Publish(ctx, product.TenantID, entities.WebhookEventStockLow, NewStockLowEvent(stock, product))
+	}
+
+	return uc.toStockMovementResponse(reversal, product), nil
+}
+
 // ReserveStock reserves stock for an order
 func (uc *StockUseCase) ReserveStock(ctx context.Context, userID uuid.UUID, req ReserveStockRequest) (*StockResponse, error) {
 	// Start transaction
@@ -229,8 +363,8 @@ func (uc *StockUseCase) ReserveStock(ctx context.Context, userID uuid.UUID, req
 		return nil, errors.NewNotFoundError("product")
 	}
 
-	// Get stock record
-	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
+	// Get stock record, locked so concurrent updates cannot overwrite each other
+	stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, req.ProductID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("stock record")
 	}
@@ -304,8 +438,8 @@ func (uc *StockUseCase) ReleaseReservedStock(ctx context.Context, userID uuid.UU
 		return nil, errors.NewNotFoundError("product")
 	}
 
-	// Get stock record
-	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
+	// Get stock record, locked so concurrent updates cannot overwrite each other
+	stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, req.ProductID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("stock record")
 	}
@@ -379,8 +513,8 @@ func (uc *StockUseCase) ConfirmReservedStock(ctx context.Context, userID uuid.UU
 		return nil, errors.NewNotFoundError("product")
 	}
 
-	// Get stock record
-	stock, err := tx.GetStockRepository().GetByProductID(ctx, req.ProductID)
+	// Get stock record, locked so concurrent updates cannot overwrite each other
+	stock, err := tx.GetStockRepository().GetByProductIDForUpdate(ctx, req.ProductID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("stock record")
 	}
@@ -453,6 +587,29 @@ func (uc *StockUseCase) GetStock(ctx context.Context, productID uuid.UUID) (*Sto
 	return uc.toStockResponse(stock, product), nil
 }
 
+// GetStockByProductIDs retrieves the stock of the given products in one query, keyed by
+// product ID. Products without a stock record are left out.
+func (uc *StockUseCase) GetStockByProductIDs(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]*StockResponse, error) {
+	stocks, err := uc.stockRepo.GetByProductIDs(ctx, productIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get stock")
+		return nil, errors.NewInternalError("failed to get stock", err)
+	}
+
+	products, err := uc.getProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[uuid.UUID]*StockResponse, len(stocks))
+	for productID, stock := range stocks {
+		if product, ok := products[productID]; ok {
+			responses[productID] = uc.toStockResponse(stock, product)
+		}
+	}
+	return responses, nil
+}
+
 // ListStock retrieves stock records with pagination and filtering
 func (uc *StockUseCase) ListStock(ctx context.Context, filter repositories.StockFilter, pagination utils.PaginationInfo) (*StockListResponse, error) {
 	stocks, paginationResult, err := uc.stockRepo.List(ctx, filter, pagination)
@@ -461,14 +618,22 @@ func (uc *StockUseCase) ListStock(ctx context.Context, filter repositories.Stock
 		return nil, errors.NewInternalError("failed to list stock", err)
 	}
 
+	productIDs := make([]uuid.UUID, len(stocks))
+	for i, stock := range stocks {
+		productIDs[i] = stock.ProductID
+	}
+	products, err := uc.getProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	stockResponses := make([]*StockResponse, len(stocks))
 	for i, stock := range stocks {
-		product, err := uc.productRepo.GetByID(ctx, stock.ProductID)
-		if err != nil {
+		product, ok := products[stock.ProductID]
+		if !ok {
 			uc.logger.WithFields(map[string]interface{}{
 				"stock_id":   stock.ID,
 				"product_id": stock.ProductID,
-				"error":      err.Error(),
 			}).Warn("Failed to get product for stock record")
 			continue
 		}
@@ -489,14 +654,22 @@ func (uc *StockUseCase) GetLowStockItems(ctx context.Context, pagination utils.P
 		return nil, errors.NewInternalError("failed to get low stock items", err)
 	}
 
+	productIDs := make([]uuid.UUID, len(stocks))
+	for i, stock := range stocks {
+		productIDs[i] = stock.ProductID
+	}
+	products, err := uc.getProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	stockResponses := make([]*StockResponse, len(stocks))
 	for i, stock := range stocks {
-		product, err := uc.productRepo.GetByID(ctx, stock.ProductID)
-		if err != nil {
+		product, ok := products[stock.ProductID]
+		if !ok {
 			uc.logger.WithFields(map[string]interface{}{
 				"stock_id":   stock.ID,
 				"product_id": stock.ProductID,
-				"error":      err.Error(),
 			}).Warn("Failed to get product for stock record")
 			continue
 		}
@@ -517,14 +690,22 @@ func (uc *StockUseCase) GetStockMovements(ctx context.Context, filter repositori
 		return nil, errors.NewInternalError("failed to list stock movements", err)
 	}
 
+	productIDs := make([]uuid.UUID, len(movements))
+	for i, movement := range movements {
+		productIDs[i] = movement.ProductID
+	}
+	products, err := uc.getProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	movementResponses := make([]*StockMovementResponse, len(movements))
 	for i, movement := range movements {
-		product, err := uc.productRepo.GetByID(ctx, movement.ProductID)
-		if err != nil {
+		product, ok := products[movement.ProductID]
+		if !ok {
 			uc.logger.WithFields(map[string]interface{}{
 				"movement_id": movement.ID,
 				"product_id":  movement.ProductID,
-				"error":       err.Error(),
 			}).Warn("Failed to get product for stock movement")
 			continue
 		}
@@ -561,6 +742,47 @@ func (uc *StockUseCase) GetProductStockMovements(ctx context.Context, productID
 	}, nil
 }
 
+// GetProductStockLedger retrieves a product's stock ledger over [fromDate, toDate): the opening
+// balance, each movement with the balance it left and the closing balance
+func (uc *StockUseCase) GetProductStockLedger(ctx context.Context, productID uuid.UUID, fromDate, toDate time.Time) (*StockLedgerResponse, error) {
+	if !toDate.After(fromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if toDate.Sub(fromDate) > maxStockLedgerRange {
+		return nil, errors.NewValidationError("date range too long", "a stock ledger cannot cover more than 366 days")
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	ledger, err := uc.stockMovementRepo.GetLedger(ctx, productID, fromDate, toDate)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to get product stock ledger")
+		return nil, errors.NewInternalError("failed to get product stock ledger", err)
+	}
+
+	return &StockLedgerResponse{
+		ProductSKU:  product.SKU,
+		ProductName: product.Name,
+		StockLedger: ledger,
+	}, nil
+}
+
+// getProducts loads the products of a page of stock records in one query, keyed by product ID
+func (uc *StockUseCase) getProducts(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]*entities.Product, error) {
+	products, err := uc.productRepo.GetByIDs(ctx, productIDs)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get products for stock records")
+		return nil, errors.NewInternalError("failed to get products", err)
+	}
+	return products, nil
+}
+
 // toStockResponse converts stock entity to response
 func (uc *StockUseCase) toStockResponse(stock *entities.Stock, product *entities.Product) *StockResponse {
 	return &StockResponse{
@@ -591,6 +813,7 @@ func (uc *StockUseCase) toStockMovementResponse(movement *entities.StockMovement
 		Quantity:    movement.Quantity,
 		Reference:   movement.Reference,
 		Notes:       movement.Notes,
+		ReversalOf:  movement.ReversalOf,
 		CreatedAt:   movement.CreatedAt,
 		CreatedBy:   movement.CreatedBy,
 	}
@@ -0,0 +1,313 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// SupplierUseCase handles the supplier directory and preferred suppliers of products
+type SupplierUseCase struct {
+	supplierRepo repositories.SupplierRepository
+	productRepo  repositories.ProductRepository
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewSupplierUseCase creates a new supplier use case
+func NewSupplierUseCase(
+	supplierRepo repositories.SupplierRepository,
+	productRepo repositories.ProductRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *SupplierUseCase {
+	return &SupplierUseCase{
+		supplierRepo: supplierRepo,
+		productRepo:  productRepo,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// SupplierRequest represents create and update supplier request
+type SupplierRequest struct {
+	Name             string `json:"name" validate:"required"`
+	ContactName      string `json:"contact_name,omitempty"`
+	Email            string `json:"email,omitempty"`
+	Phone            string `json:"phone,omitempty"`
+	Address          string `json:"address,omitempty"`
+	PaymentTermsDays int    `json:"payment_terms_days" validate:"min=0,max=365"`
+	LeadTimeDays     int    `json:"lead_time_days" validate:"min=0,max=365"`
+	Notes            string `json:"notes,omitempty"`
+	IsActive         *bool  `json:"is_active,omitempty"` // Only applied on update
+}
+
+// AssignProductSupplierRequest represents a request to set or clear a product's preferred supplier
+type AssignProductSupplierRequest struct {
+	SupplierID *uuid.UUID `json:"supplier_id"` // Null clears the preferred supplier
+}
+
+// SupplierListResponse represents supplier list response
+type SupplierListResponse struct {
+	Suppliers  []*entities.Supplier `json:"suppliers"`
+	Pagination utils.PaginationInfo `json:"pagination"`
+}
+
+// SupplierProductsResponse represents the products that prefer a supplier
+type SupplierProductsResponse struct {
+	Products   []*entities.Product  `json:"products"`
+	Pagination utils.PaginationInfo `json:"pagination"`
+}
+
+// CreateSupplier creates a new supplier
+func (uc *SupplierUseCase) CreateSupplier(ctx context.Context, tenantID, userID uuid.UUID, req SupplierRequest) (*entities.Supplier, error) {
+	supplier, err := entities.NewSupplier(tenantID, req.details(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.supplierRepo.Create(ctx, supplier); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"name":  supplier.Name,
+			"error": err.Error(),
+		}).Error("Failed to create supplier")
+		return nil, errors.NewInternalError("failed to create supplier", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "supplier",
+		ResourceID: supplier.ID.String(),
+		NewValue: map[string]interface{}{
+			"name":               supplier.Name,
+			"payment_terms_days": supplier.PaymentTermsDays,
+			"lead_time_days":     supplier.LeadTimeDays,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return supplier, nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (uc *SupplierUseCase) GetSupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (*entities.Supplier, error) {
+	supplier, err := uc.supplierRepo.GetByID(ctx, supplierID)
+	if err != nil || supplier.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("supplier")
+	}
+
+	return supplier, nil
+}
+
+// ListSuppliers retrieves suppliers with pagination and filtering
+func (uc *SupplierUseCase) ListSuppliers(ctx context.Context, filter repositories.SupplierFilter, pagination utils.PaginationInfo) (*SupplierListResponse, error) {
+	suppliers, paginationResult, err := uc.supplierRepo.List(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list suppliers")
+		return nil, errors.NewInternalError("failed to list suppliers", err)
+	}
+
+	if suppliers == nil {
+		suppliers = []*entities.Supplier{}
+	}
+
+	return &SupplierListResponse{
+		Suppliers:  suppliers,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// UpdateSupplier updates a supplier's details and active flag
+func (uc *SupplierUseCase) UpdateSupplier(ctx context.Context, tenantID, userID, supplierID uuid.UUID, req SupplierRequest) (*entities.Supplier, error) {
+	supplier, err := uc.GetSupplier(ctx, tenantID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSupplier := *supplier
+
+	if err := supplier.Update(req.details()); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		if *req.IsActive {
+			supplier.Activate()
+		} else {
+			supplier.Deactivate()
+		}
+	}
+
+	if err := uc.supplierRepo.Update(ctx, supplier); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"supplier_id": supplierID,
+			"error":       err.Error(),
+		}).Error("Failed to update supplier")
+		return nil, errors.NewInternalError("failed to update supplier", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "supplier",
+		ResourceID: supplier.ID.String(),
+		OldValue: map[string]interface{}{
+			"name":               oldSupplier.Name,
+			"payment_terms_days": oldSupplier.PaymentTermsDays,
+			"lead_time_days":     oldSupplier.LeadTimeDays,
+			"is_active":          oldSupplier.IsActive,
+		},
+		NewValue: map[string]interface{}{
+			"name":               supplier.Name,
+			"payment_terms_days": supplier.PaymentTermsDays,
+			"lead_time_days":     supplier.LeadTimeDays,
+			"is_active":          supplier.IsActive,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return supplier, nil
+}
+
+// DeleteSupplier deletes a supplier. Products that preferred it no longer have a preferred supplier.
+func (uc *SupplierUseCase) DeleteSupplier(ctx context.Context, tenantID, userID, supplierID uuid.UUID) error {
+	supplier, err := uc.GetSupplier(ctx, tenantID, supplierID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.supplierRepo.Delete(ctx, supplierID); err != nil {
+		if _, ok := errors.IsAppError(err); ok {
+			return err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"supplier_id": supplierID,
+			"error":       err.Error(),
+		}).Error("Failed to delete supplier")
+		return errors.NewInternalError("failed to delete supplier", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "supplier",
+		ResourceID: supplierID.String(),
+		OldValue: map[string]interface{}{
+			"name": supplier.Name,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return nil
+}
+
+// ListSupplierProducts retrieves the products that prefer a supplier
+func (uc *SupplierUseCase) ListSupplierProducts(ctx context.Context, tenantID, supplierID uuid.UUID, pagination utils.PaginationInfo) (*SupplierProductsResponse, error) {
+	if _, err := uc.GetSupplier(ctx, tenantID, supplierID); err != nil {
+		return nil, err
+	}
+
+	products, paginationResult, err := uc.productRepo.List(ctx, repositories.ProductFilter{SupplierID: &supplierID}, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list supplier products")
+		return nil, errors.NewInternalError("failed to list supplier products", err)
+	}
+
+	if products == nil {
+		products = []*entities.Product{}
+	}
+
+	return &SupplierProductsResponse{
+		Products:   products,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// AssignProductSupplier sets or clears the preferred supplier of a product
+func (uc *SupplierUseCase) AssignProductSupplier(ctx context.Context, tenantID, userID, productID uuid.UUID, req AssignProductSupplierRequest) (*entities.Product, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil || product.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("product")
+	}
+
+	if req.SupplierID != nil {
+		supplier, err := uc.GetSupplier(ctx, tenantID, *req.SupplierID)
+		if err != nil {
+			return nil, err
+		}
+		if !supplier.IsActive {
+			return nil, errors.NewValidationError("supplier is inactive", "activate the supplier before assigning products to it")
+		}
+	}
+
+	oldSupplierID := product.SupplierID
+	product.SetPreferredSupplier(req.SupplierID)
+
+	if err := uc.productRepo.Update(ctx, product); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Error("Failed to assign product supplier")
+		return nil, errors.NewInternalError("failed to assign product supplier", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "assign_supplier",
+		Resource:   "product",
+		ResourceID: productID.String(),
+		OldValue: map[string]interface{}{
+			"supplier_id": oldSupplierID,
+		},
+		NewValue: map[string]interface{}{
+			"supplier_id": product.SupplierID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return product, nil
+}
+
+// details converts the request into entity supplier details
+func (req SupplierRequest) details() entities.SupplierDetails {
+	return entities.SupplierDetails{
+		Name:             req.Name,
+		ContactName:      req.ContactName,
+		Email:            req.Email,
+		Phone:            req.Phone,
+		Address:          req.Address,
+		PaymentTermsDays: req.PaymentTermsDays,
+		LeadTimeDays:     req.LeadTimeDays,
+		Notes:            req.Notes,
+	}
+}
@@ -0,0 +1,353 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// supportBundleFormatVersion identifies the bundle layout for support tooling
+	supportBundleFormatVersion = "1"
+	// supportBundleDefaultWindow is the time window covered when none is requested
+	supportBundleDefaultWindow = 24 * time.Hour
+	// supportBundleMaxWindow caps the time window a bundle can cover
+	supportBundleMaxWindow = 7 * 24 * time.Hour
+	// supportBundleDefaultSlowThreshold marks requests slower than this as slow
+	supportBundleDefaultSlowThreshold = time.Second
+	// supportBundleRequestLimit caps the number of captured requests per section
+	supportBundleRequestLimit = 500
+	// supportBundleEmailBatchLimit caps the number of recent email batches inspected
+	supportBundleEmailBatchLimit = 50
+)
+
+// SupportBundleUseCase assembles diagnostic bundles for merchant-reported problems
+type SupportBundleUseCase struct {
+	tenantRepo            repositories.TenantRepository
+	apiRequestLogRepo     repositories.APIRequestLogRepository
+	invoiceEmailBatchRepo repositories.InvoiceEmailBatchRepository
+	tenantExportRepo      repositories.TenantExportRepository
+	warehouseExportRepo   repositories.WarehouseExportRepository
+	diagnostics           ports.DiagnosticsPort
+	audit                 ports.AuditPort
+	logger                logger.Logger
+}
+
+// NewSupportBundleUseCase creates a new support bundle use case
+func NewSupportBundleUseCase(
+	tenantRepo repositories.TenantRepository,
+	apiRequestLogRepo repositories.APIRequestLogRepository,
+	invoiceEmailBatchRepo repositories.InvoiceEmailBatchRepository,
+	tenantExportRepo repositories.TenantExportRepository,
+	warehouseExportRepo repositories.WarehouseExportRepository,
+	diagnostics ports.DiagnosticsPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *SupportBundleUseCase {
+	return &SupportBundleUseCase{
+		tenantRepo:            tenantRepo,
+		apiRequestLogRepo:     apiRequestLogRepo,
+		invoiceEmailBatchRepo: invoiceEmailBatchRepo,
+		tenantExportRepo:      tenantExportRepo,
+		warehouseExportRepo:   warehouseExportRepo,
+		diagnostics:           diagnostics,
+		audit:                 audit,
+		logger:                logger,
+	}
+}
+
+// SupportBundleRequest represents support bundle request. The window defaults to the last
+// 24 hours.
+type SupportBundleRequest struct {
+	FromDate      *time.Time
+	ToDate        *time.Time
+	SlowThreshold time.Duration // Defaults to one second
+}
+
+// SupportBundle represents a generated support bundle archive
+type SupportBundle struct {
+	FileName string
+	Content  []byte
+}
+
+// supportBundleManifest describes the contents of a support bundle
+type supportBundleManifest struct {
+	FormatVersion   string         `json:"format_version"`
+	TenantID        uuid.UUID      `json:"tenant_id"`
+	FromDate        time.Time      `json:"from_date"`
+	ToDate          time.Time      `json:"to_date"`
+	SlowThresholdMs int64          `json:"slow_threshold_ms"`
+	GeneratedAt     time.Time      `json:"generated_at"`
+	GeneratedBy     uuid.UUID      `json:"generated_by"`
+	RecordCounts    map[string]int `json:"record_counts"`
+	Files           []string       `json:"files"`
+	Notes           []string       `json:"notes"`
+}
+
+// supportBundleFailedEmail is a failed invoice email delivery in a support bundle
+type supportBundleFailedEmail struct {
+	BatchID       uuid.UUID  `json:"batch_id"`
+	InvoiceID     uuid.UUID  `json:"invoice_id"`
+	InvoiceNumber string     `json:"invoice_number"`
+	Reason        string     `json:"reason"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+}
+
+// supportBundleFailedExports collects export failures in a support bundle
+type supportBundleFailedExports struct {
+	TenantExports   []*entities.TenantExport         `json:"tenant_exports"`
+	WarehouseExport *entities.WarehouseExportSetting `json:"warehouse_export,omitempty"`
+}
+
+// GenerateBundle assembles a zip of recent errors, slow requests, failed jobs, emails and
+// exports, a redacted configuration snapshot and the health history of a tenant
+func (uc *SupportBundleUseCase) GenerateBundle(ctx context.Context, tenantID, userID uuid.UUID, req SupportBundleRequest) (*SupportBundle, error) {
+	now := time.Now()
+	toDate := now
+	if req.ToDate != nil {
+		toDate = *req.ToDate
+	}
+	fromDate := toDate.Add(-supportBundleDefaultWindow)
+	if req.FromDate != nil {
+		fromDate = *req.FromDate
+	}
+	if !fromDate.Before(toDate) {
+		return nil, errors.NewValidationError("invalid date range", "from date must be before to date")
+	}
+	if toDate.Sub(fromDate) > supportBundleMaxWindow {
+		return nil, errors.NewValidationError("date range too long", "a support bundle can cover at most 7 days")
+	}
+	slowThreshold := req.SlowThreshold
+	if slowThreshold <= 0 {
+		slowThreshold = supportBundleDefaultSlowThreshold
+	}
+
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("tenant")
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	manifest := supportBundleManifest{
+		FormatVersion:   supportBundleFormatVersion,
+		TenantID:        tenantID,
+		FromDate:        fromDate,
+		ToDate:          toDate,
+		SlowThresholdMs: slowThreshold.Milliseconds(),
+		GeneratedAt:     now,
+		GeneratedBy:     userID,
+		RecordCounts:    map[string]int{},
+		Notes: []string{
+			"API requests are sampled and their bodies redacted at capture; errors and slow requests list only captured requests",
+			"failed_jobs.json covers jobs of every tenant and only failures since the last restart",
+			"there are no outgoing webhooks, so no webhook deliveries are included",
+		},
+	}
+
+	writeFile := func(name string, count int, content interface{}) error {
+		w, err := archive.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", name, err)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if count >= 0 {
+			manifest.RecordCounts[name] = count
+		}
+		manifest.Files = append(manifest.Files, name)
+		return nil
+	}
+
+	// section records a section that could not be collected instead of failing the whole bundle
+	section := func(name string, err error) {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"section":   name,
+			"error":     err.Error(),
+		}).Warn("Failed to collect support bundle section")
+		manifest.Notes = append(manifest.Notes, fmt.Sprintf("%s could not be collected: %s", name, err.Error()))
+	}
+
+	if err := writeFile("tenant.json", -1, tenant); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	requestSections := []struct {
+		name   string
+		filter repositories.APIRequestLogFilter
+	}{
+		{"errors.json", repositories.APIRequestLogFilter{MinStatusCode: http.StatusInternalServerError}},
+		{"slow_requests.json", repositories.APIRequestLogFilter{MinDurationMs: slowThreshold.Milliseconds()}},
+	}
+	for _, requests := range requestSections {
+		filter := requests.filter
+		filter.TenantID = tenantID
+		filter.FromDate = fromDate
+		filter.ToDate = toDate
+		filter.Limit = supportBundleRequestLimit
+
+		logs, err := uc.apiRequestLogRepo.List(ctx, filter)
+		if err != nil {
+			section(requests.name, err)
+			continue
+		}
+		if logs == nil {
+			logs = []*entities.APIRequestLog{}
+		}
+		if err := writeFile(requests.name, len(logs), logs); err != nil {
+			return nil, errors.NewInternalError("failed to build support bundle", err)
+		}
+	}
+
+	jobFailures := uc.diagnostics.JobFailures(fromDate)
+	if jobFailures == nil {
+		jobFailures = []ports.JobFailure{}
+	}
+	if err := writeFile("failed_jobs.json", len(jobFailures), jobFailures); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	if emails, err := uc.collectFailedEmails(ctx, tenantID, fromDate, toDate); err != nil {
+		section("failed_emails.json", err)
+	} else if err := writeFile("failed_emails.json", len(emails), emails); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	if exports, err := uc.collectFailedExports(ctx, tenantID, fromDate, toDate); err != nil {
+		section("failed_exports.json", err)
+	} else if err := writeFile("failed_exports.json", len(exports.TenantExports), exports); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	if err := writeFile("config.json", -1, uc.diagnostics.ConfigSnapshot()); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	if health, err := uc.diagnostics.TenantHealth(ctx, tenantID, fromDate); err != nil {
+		section("health.json", err)
+	} else if err := writeFile("health.json", -1, health); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	if err := writeFile("manifest.json", -1, manifest); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+	if err := archive.Close(); err != nil {
+		return nil, errors.NewInternalError("failed to build support bundle", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "generate_support_bundle",
+		Resource:   "tenant",
+		ResourceID: tenantID.String(),
+		NewValue: map[string]interface{}{
+			"from_date":     fromDate,
+			"to_date":       toDate,
+			"record_counts": manifest.RecordCounts,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID,
+		"user_id":   userID,
+		"size":      buf.Len(),
+	}).Info("Support bundle generated")
+
+	return &SupportBundle{
+		FileName: fmt.Sprintf("support-bundle-%s-%s.zip", tenant.Slug, now.Format("20060102-150405")),
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// collectFailedEmails lists failed invoice email deliveries processed within the window.
+// Recipients are left out as they are customer contact details.
+func (uc *SupportBundleUseCase) collectFailedEmails(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*supportBundleFailedEmail, error) {
+	batches, err := uc.invoiceEmailBatchRepo.GetByTenant(ctx, tenantID, supportBundleEmailBatchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := []*supportBundleFailedEmail{}
+	for _, summary := range batches {
+		if summary.FailedCount == 0 || summary.CreatedAt.After(toDate) {
+			continue
+		}
+		if summary.CompletedAt != nil && summary.CompletedAt.Before(fromDate) {
+			continue
+		}
+
+		batch, err := uc.invoiceEmailBatchRepo.GetByID(ctx, summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range batch.Items {
+			if item.Outcome != entities.InvoiceEmailOutcomeFailed {
+				continue
+			}
+			if item.ProcessedAt != nil && (item.ProcessedAt.Before(fromDate) || item.ProcessedAt.After(toDate)) {
+				continue
+			}
+			failed = append(failed, &supportBundleFailedEmail{
+				BatchID:       batch.ID,
+				InvoiceID:     item.InvoiceID,
+				InvoiceNumber: item.InvoiceNumber,
+				Reason:        item.Reason,
+				ProcessedAt:   item.ProcessedAt,
+			})
+		}
+	}
+
+	return failed, nil
+}
+
+// collectFailedExports lists failed tenant exports created within the window and the state of
+// the warehouse export when its last run failed
+func (uc *SupportBundleUseCase) collectFailedExports(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*supportBundleFailedExports, error) {
+	exports, err := uc.tenantExportRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &supportBundleFailedExports{TenantExports: []*entities.TenantExport{}}
+	for _, export := range exports {
+		if export.Status == entities.TenantExportStatusFailed &&
+			!export.CreatedAt.Before(fromDate) && !export.CreatedAt.After(toDate) {
+			result.TenantExports = append(result.TenantExports, export)
+		}
+	}
+
+	setting, err := uc.warehouseExportRepo.GetSettingByTenant(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return result, nil
+		}
+		return nil, err
+	}
+	if setting.LastError != "" {
+		result.WarehouseExport = setting
+	}
+
+	return result, nil
+}
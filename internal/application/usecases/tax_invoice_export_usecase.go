@@ -0,0 +1,389 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// maxTaxInvoiceExportRange caps the payment date range of a single tax invoice export
+const maxTaxInvoiceExportRange = 366 * 24 * time.Hour
+
+// TaxInvoiceExportUseCase issues tax invoices for paid invoices and exports them in the format
+// of the tax authority's import tool, such as the Indonesian e-Faktur. Each exported invoice is
+// given the next serial of the tenant's serial ranges once and keeps it on later exports.
+type TaxInvoiceExportUseCase struct {
+	invoiceRepo repositories.InvoiceRepository
+	serialRepo  repositories.TaxInvoiceSerialRepository
+	tenantRepo  repositories.TenantRepository
+	exporters   map[string]ports.TaxInvoiceExportPort
+	audit       ports.AuditPort
+	logger      logger.Logger
+}
+
+// NewTaxInvoiceExportUseCase creates a new tax invoice export use case with the given export formats
+func NewTaxInvoiceExportUseCase(
+	invoiceRepo repositories.InvoiceRepository,
+	serialRepo repositories.TaxInvoiceSerialRepository,
+	tenantRepo repositories.TenantRepository,
+	exporters []ports.TaxInvoiceExportPort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *TaxInvoiceExportUseCase {
+	byFormat := make(map[string]ports.TaxInvoiceExportPort, len(exporters))
+	for _, exporter := range exporters {
+		byFormat[exporter.Format()] = exporter
+	}
+
+	return &TaxInvoiceExportUseCase{
+		invoiceRepo: invoiceRepo,
+		serialRepo:  serialRepo,
+		tenantRepo:  tenantRepo,
+		exporters:   byFormat,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// CreateTaxInvoiceSerialRangeRequest represents a request to register a range of serials
+// allocated by the tax authority
+type CreateTaxInvoiceSerialRangeRequest struct {
+	BranchCode  string `json:"branch_code,omitempty"`
+	Year        int    `json:"year" validate:"required"`
+	FirstNumber int64  `json:"first_number" validate:"required"`
+	LastNumber  int64  `json:"last_number" validate:"required"`
+}
+
+// TaxInvoiceSerialRangeResponse represents a range of serials and how many are left
+type TaxInvoiceSerialRangeResponse struct {
+	*entities.TaxInvoiceSerialRange
+	FirstSerial string `json:"first_serial"`
+	LastSerial  string `json:"last_serial"`
+	Remaining   int64  `json:"remaining"`
+}
+
+// ExportTaxInvoicesRequest represents a request to export the invoices paid in a date range
+type ExportTaxInvoicesRequest struct {
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"` // Exclusive
+	Format   string    `json:"format"`
+}
+
+// TaxInvoiceExportFile represents an exported tax invoice file
+type TaxInvoiceExportFile struct {
+	Data          []byte
+	ContentType   string
+	FileExtension string
+	Invoices      int
+}
+
+// CreateSerialRange registers a range of serials. Ranges of the same branch and year cannot
+// share serials.
+func (uc *TaxInvoiceExportUseCase) CreateSerialRange(ctx context.Context, tenantID, userID uuid.UUID, req CreateTaxInvoiceSerialRangeRequest) (*TaxInvoiceSerialRangeResponse, error) {
+	serialRange, err := entities.NewTaxInvoiceSerialRange(tenantID, req.BranchCode, req.Year, req.FirstNumber, req.LastNumber, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.serialRepo.ListRanges(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list tax invoice serial ranges")
+		return nil, errors.NewInternalError("failed to list tax invoice serial ranges", err)
+	}
+	for _, other := range existing {
+		if serialRange.Overlaps(other) {
+			return nil, errors.NewConflictError(fmt.Sprintf("serial range overlaps %s to %s", other.Serial(other.FirstNumber), other.Serial(other.LastNumber)))
+		}
+	}
+
+	if err := uc.serialRepo.CreateRange(ctx, serialRange); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeConflict {
+			return nil, err
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to create tax invoice serial range")
+		return nil, errors.NewInternalError("failed to create tax invoice serial range", err)
+	}
+
+	// Audit log
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "tax_invoice_serial_range",
+		ResourceID: serialRange.ID.String(),
+		NewValue: map[string]interface{}{
+			"branch_code":  serialRange.BranchCode,
+			"year":         serialRange.Year,
+			"first_number": serialRange.FirstNumber,
+			"last_number":  serialRange.LastNumber,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return toTaxInvoiceSerialRangeResponse(serialRange), nil
+}
+
+// ListSerialRanges lists the tenant's serial ranges
+func (uc *TaxInvoiceExportUseCase) ListSerialRanges(ctx context.Context, tenantID uuid.UUID) ([]*TaxInvoiceSerialRangeResponse, error) {
+	ranges, err := uc.serialRepo.ListRanges(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list tax invoice serial ranges")
+		return nil, errors.NewInternalError("failed to list tax invoice serial ranges", err)
+	}
+
+	responses := make([]*TaxInvoiceSerialRangeResponse, len(ranges))
+	for i, serialRange := range ranges {
+		responses[i] = toTaxInvoiceSerialRangeResponse(serialRange)
+	}
+	return responses, nil
+}
+
+// SetCustomerTaxID sets the tax ID of an invoice's customer. It cannot be changed once the
+// invoice has been issued a tax invoice, as the exported tax invoice would no longer match.
+func (uc *TaxInvoiceExportUseCase) SetCustomerTaxID(ctx context.Context, tenantID, userID, invoiceID uuid.UUID, taxID string) (*entities.Invoice, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	if serial, err := uc.serialRepo.GetByInvoiceID(ctx, invoiceID); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("invoice was issued tax invoice %s", serial.Serial))
+	} else if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get tax invoice serial")
+		return nil, errors.NewInternalError("failed to get tax invoice serial", err)
+	}
+
+	oldTaxID := invoice.CustomerTaxID
+	if err := invoice.SetCustomerTaxID(taxID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.invoiceRepo.Update(ctx, invoice); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoiceID,
+			"error":      err.Error(),
+		}).Error("Failed to update invoice")
+		return nil, errors.NewInternalError("failed to update invoice", err)
+	}
+
+	// Audit log
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "invoice",
+		ResourceID: invoiceID.String(),
+		OldValue:   map[string]interface{}{"customer_tax_id": oldTaxID},
+		NewValue:   map[string]interface{}{"customer_tax_id": invoice.CustomerTaxID},
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	return invoice, nil
+}
+
+// ExportPaidInvoices exports a tax invoice for every invoice paid in a date range, in payment
+// order. Invoices without a serial are given the next serial of the year they were paid in.
+// Amounts are converted to the base currency at the rate locked on each invoice.
+func (uc *TaxInvoiceExportUseCase) ExportPaidInvoices(ctx context.Context, tenantID, userID uuid.UUID, req ExportTaxInvoicesRequest) (*TaxInvoiceExportFile, error) {
+	exporter, ok := uc.exporters[req.Format]
+	if !ok {
+		return nil, errors.NewValidationError("invalid format", fmt.Sprintf("format %q is not supported", req.Format))
+	}
+	if !req.ToDate.After(req.FromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to must be after from")
+	}
+	if req.ToDate.Sub(req.FromDate) > maxTaxInvoiceExportRange {
+		return nil, errors.NewValidationError("invalid date range", "date range cannot exceed one year")
+	}
+
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("tenant")
+	}
+	business := tenant.Configuration.BusinessInfo
+	if business.TaxID == "" {
+		return nil, errors.NewValidationError("tax ID is required", "set the business tax ID in the tenant configuration before exporting tax invoices")
+	}
+	seller := ports.TaxInvoiceParty{TaxID: business.TaxID, Name: business.Name, Address: business.Address}
+
+	status := entities.InvoiceStatusPaid
+	filter := repositories.InvoiceFilter{
+		Status:       &status,
+		PaidFromDate: &req.FromDate,
+		PaidToDate:   &req.ToDate,
+		OrderBy:      "paid_at",
+		OrderDir:     "ASC",
+	}
+
+	documents := []ports.TaxInvoiceDocument{}
+	for page := 1; ; page++ {
+		invoices, pagination, err := uc.invoiceRepo.List(ctx, filter, utils.PaginationInfo{Page: page, Limit: exportPageSize})
+		if err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to list paid invoices")
+			return nil, errors.NewInternalError("failed to list paid invoices", err)
+		}
+
+		for _, invoice := range invoices {
+			issuedAt := invoice.CreatedAt
+			if invoice.PaidAt != nil {
+				issuedAt = *invoice.PaidAt
+			}
+
+			serial, err := uc.serialRepo.Assign(ctx, tenantID, invoice.ID, issuedAt.Year(), userID)
+			if err != nil {
+				if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+					return nil, errors.NewValidationError("no tax invoice serials left",
+						fmt.Sprintf("register a serial range for %d to issue a tax invoice for invoice %s", issuedAt.Year(), invoice.InvoiceNumber))
+				}
+				uc.logger.WithFields(map[string]interface{}{
+					"invoice_id": invoice.ID,
+					"error":      err.Error(),
+				}).Error("Failed to assign tax invoice serial")
+				return nil, errors.NewInternalError("failed to assign tax invoice serial", err)
+			}
+
+			documents = append(documents, toTaxInvoiceDocument(invoice, serial.Serial, issuedAt))
+		}
+
+		if !pagination.HasNext || len(invoices) == 0 {
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Export(&buf, seller, documents); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"format":    req.Format,
+			"error":     err.Error(),
+		}).Error("Failed to export tax invoices")
+		return nil, errors.NewValidationError("failed to export tax invoices", err.Error())
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Action:   "export",
+		Resource: "tax_invoices",
+		NewValue: map[string]interface{}{
+			"tenant_id": tenantID,
+			"format":    req.Format,
+			"from_date": req.FromDate,
+			"to_date":   req.ToDate,
+			"invoices":  len(documents),
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	return &TaxInvoiceExportFile{
+		Data:          buf.Bytes(),
+		ContentType:   exporter.ContentType(),
+		FileExtension: exporter.FileExtension(),
+		Invoices:      len(documents),
+	}, nil
+}
+
+// toTaxInvoiceSerialRangeResponse converts a serial range to its response
+func toTaxInvoiceSerialRangeResponse(serialRange *entities.TaxInvoiceSerialRange) *TaxInvoiceSerialRangeResponse {
+	return &TaxInvoiceSerialRangeResponse{
+		TaxInvoiceSerialRange: serialRange,
+		FirstSerial:           serialRange.Serial(serialRange.FirstNumber),
+		LastSerial:            serialRange.Serial(serialRange.LastNumber),
+		Remaining:             serialRange.Remaining(),
+	}
+}
+
+// toTaxInvoiceDocument converts an invoice to a tax invoice in the base currency. The invoice
+// discount is spread over the items as it was when their tax was computed, and tax-inclusive
+// prices have their tax taken out. A taxed surcharge is added as a line of its own.
+func toTaxInvoiceDocument(invoice *entities.Invoice, serial string, issuedAt time.Time) ports.TaxInvoiceDocument {
+	rate := invoice.ExchangeRate
+	document := ports.TaxInvoiceDocument{
+		Serial:        serial,
+		InvoiceNumber: invoice.InvoiceNumber,
+		Date:          issuedAt,
+		Buyer: ports.TaxInvoiceParty{
+			TaxID:   invoice.CustomerTaxID,
+			Name:    invoice.CustomerName,
+			Address: invoice.CustomerAddress,
+		},
+		TaxBase:   decimal.Zero,
+		TaxAmount: rate.ToBase(invoice.TaxAmount),
+		Lines:     []ports.TaxInvoiceLine{},
+	}
+	for _, summary := range invoice.TaxSummary {
+		document.TaxBase = document.TaxBase.Add(rate.ToBase(summary.TaxableAmount))
+	}
+
+	hundred := decimal.NewFromInt(100)
+	allocated := decimal.Zero
+	for i, item := range invoice.Items {
+		share := decimal.Zero
+		if invoice.Subtotal.IsPositive() {
+			if i == len(invoice.Items)-1 {
+				share = invoice.DiscountAmount.Sub(allocated)
+			} else {
+				share = item.TotalPrice.Mul(invoice.DiscountAmount).Div(invoice.Subtotal).Round(2)
+			}
+		}
+		allocated = allocated.Add(share)
+
+		total := item.TotalPrice
+		taxBase := item.TotalPrice.Sub(share)
+		if invoice.TaxInclusive {
+			total = total.Mul(hundred).Div(hundred.Add(item.TaxRate)).Round(2)
+			taxBase = taxBase.Sub(item.TaxAmount)
+		}
+		unitPrice := total
+		if item.Quantity > 0 {
+			unitPrice = total.Div(decimal.NewFromInt(int64(item.Quantity))).Round(2)
+		}
+
+		document.Lines = append(document.Lines, ports.TaxInvoiceLine{
+			Code:       item.ProductSKU,
+			Name:       item.ProductName,
+			UnitPrice:  rate.ToBase(unitPrice),
+			Quantity:   item.Quantity,
+			TotalPrice: rate.ToBase(total),
+			Discount:   rate.ToBase(total.Sub(taxBase)),
+			TaxBase:    rate.ToBase(taxBase),
+			TaxAmount:  rate.ToBase(item.TaxAmount),
+		})
+	}
+
+	if invoice.SurchargeTaxAmount.IsPositive() {
+		surcharge := rate.ToBase(invoice.SurchargeAmount)
+		name := invoice.SurchargeLabel
+		if name == "" {
+			name = "Surcharge"
+		}
+		document.Lines = append(document.Lines, ports.TaxInvoiceLine{
+			Name:       name,
+			UnitPrice:  surcharge,
+			Quantity:   1,
+			TotalPrice: surcharge,
+			Discount:   decimal.Zero,
+			TaxBase:    surcharge,
+			TaxAmount:  rate.ToBase(invoice.SurchargeTaxAmount),
+		})
+	}
+
+	return document
+}
@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// TaxRateUseCase handles the tax rates a tenant assigns to categories and products
+type TaxRateUseCase struct {
+	taxRateRepo  repositories.TaxRateRepository
+	categoryRepo repositories.CategoryRepository
+	audit        ports.AuditPort
+	logger       logger.Logger
+}
+
+// NewTaxRateUseCase creates a new tax rate use case
+func NewTaxRateUseCase(
+	taxRateRepo repositories.TaxRateRepository,
+	categoryRepo repositories.CategoryRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *TaxRateUseCase {
+	return &TaxRateUseCase{
+		taxRateRepo:  taxRateRepo,
+		categoryRepo: categoryRepo,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// CreateTaxRateRequest represents create tax rate request
+type CreateTaxRateRequest struct {
+	Name string          `json:"name" validate:"required"`
+	Rate decimal.Decimal `json:"rate" validate:"required"` // Percentage, e.g. 7.5
+}
+
+// UpdateTaxRateRequest represents update tax rate request
+type UpdateTaxRateRequest struct {
+	Name     string           `json:"name,omitempty"`
+	Rate     *decimal.Decimal `json:"rate,omitempty"`
+	IsActive *bool            `json:"is_active,omitempty"`
+}
+
+// CreateTaxRate creates a tax rate for the tenant
+func (uc *TaxRateUseCase) CreateTaxRate(ctx context.Context, tenantID, userID uuid.UUID, req CreateTaxRateRequest) (*entities.TaxRate, error) {
+	taxRate, err := entities.NewTaxRate(tenantID, req.Name, req.Rate, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.taxRateRepo.Create(ctx, taxRate); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"name":      taxRate.Name,
+			"error":     err.Error(),
+		}).Error("Failed to create tax rate")
+		return nil, errors.NewInternalError("failed to create tax rate", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "tax_rate",
+		ResourceID: taxRate.ID.String(),
+		NewValue: map[string]interface{}{
+			"name": taxRate.Name,
+			"rate": taxRate.Rate,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":   tenantID,
+		"tax_rate_id": taxRate.ID,
+		"user_id":     userID,
+	}).Info("Tax rate created")
+
+	return taxRate, nil
+}
+
+// ListTaxRates retrieves all the tenant's tax rates, ordered by rate
+func (uc *TaxRateUseCase) ListTaxRates(ctx context.Context, tenantID uuid.UUID) ([]*entities.TaxRate, error) {
+	taxRates, err := uc.taxRateRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list tax rates")
+		return nil, errors.NewInternalError("failed to list tax rates", err)
+	}
+
+	return taxRates, nil
+}
+
+// GetTaxRate retrieves one of the tenant's tax rates
+func (uc *TaxRateUseCase) GetTaxRate(ctx context.Context, tenantID, taxRateID uuid.UUID) (*entities.TaxRate, error) {
+	taxRate, err := uc.taxRateRepo.GetByID(ctx, taxRateID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get tax rate")
+		return nil, errors.NewInternalError("failed to get tax rate", err)
+	}
+	if taxRate.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("tax rate")
+	}
+
+	return taxRate, nil
+}
+
+// UpdateTaxRate renames, changes the percentage of or deactivates one of the tenant's tax
+// rates. Completed sales and their invoices keep the rate they were taxed at.
+func (uc *TaxRateUseCase) UpdateTaxRate(ctx context.Context, tenantID, userID, taxRateID uuid.UUID, req UpdateTaxRateRequest) (*entities.TaxRate, error) {
+	taxRate, err := uc.GetTaxRate(ctx, tenantID, taxRateID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := audit.TakeSnapshot(taxRate)
+
+	if req.Name != "" || req.Rate != nil {
+		name, rate := taxRate.Name, taxRate.Rate
+		if req.Name != "" {
+			name = req.Name
+		}
+		if req.Rate != nil {
+			rate = *req.Rate
+		}
+		if err := taxRate.Update(name, rate, userID); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		if *req.IsActive {
+			taxRate.Activate(userID)
+		} else {
+			taxRate.Deactivate(userID)
+		}
+	}
+
+	if err := uc.taxRateRepo.Update(ctx, taxRate); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"tax_rate_id": taxRateID,
+			"error":       err.Error(),
+		}).Error("Failed to update tax rate")
+		return nil, errors.NewInternalError("failed to update tax rate", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "tax_rate",
+		ResourceID: taxRateID.String(),
+		Changes:    audit.Diff(before, audit.TakeSnapshot(taxRate)),
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tax_rate_id": taxRateID,
+		"user_id":     userID,
+	}).Info("Tax rate updated")
+
+	return taxRate, nil
+}
+
+// ResolveTaxRate returns the tax rate with the ID for assigning to a category or product
+func (uc *TaxRateUseCase) ResolveTaxRate(ctx context.Context, tenantID, taxRateID uuid.UUID) (*entities.TaxRate, error) {
+	taxRate, err := uc.GetTaxRate(ctx, tenantID, taxRateID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, errors.NewValidationError("unknown tax rate", fmt.Sprintf("tax rate '%s' does not exist", taxRateID))
+		}
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+// ProductTaxRate returns the tax rate a product is sold at, from the product itself or its
+// categories. It returns nil when the product is taxed at the sale's rate.
+func (uc *TaxRateUseCase) ProductTaxRate(ctx context.Context, product *entities.Product) (*entities.TaxRate, error) {
+	if product.TaxRateID == nil && product.CategoryID == nil {
+		return nil, nil
+	}
+
+	var categories []*entities.Category
+	if product.TaxRateID == nil {
+		var err error
+		categories, err = uc.categoryRepo.GetByTenant(ctx, product.TenantID)
+		if err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to get categories")
+			return nil, errors.NewInternalError("failed to get categories", err)
+		}
+	}
+
+	taxRateID := entities.ResolveTaxRateID(product, categories)
+	if taxRateID == nil {
+		return nil, nil
+	}
+
+	return uc.GetTaxRate(ctx, product.TenantID, *taxRateID)
+}
@@ -0,0 +1,516 @@
+package usecases
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const (
+	// tenantExportPageSize is the number of records fetched per page while exporting
+	tenantExportPageSize = 500
+	// tenantExportFormatVersion identifies the archive layout for consumers of the export
+	tenantExportFormatVersion = "1"
+	// tenantExportLinkExpiry is how long a signed download link stays valid
+	tenantExportLinkExpiry = 1 * time.Hour
+	// tenantExportLease is how long an export may stay processing before another run resumes it.
+	// It must exceed the scheduler timeout of ProcessDue.
+	tenantExportLease = 1 * time.Hour
+)
+
+// TenantExportUseCase handles full-tenant data exports for offboarding
+type TenantExportUseCase struct {
+	tenantExportRepo     repositories.TenantExportRepository
+	tenantRepo           repositories.TenantRepository
+	userRepo             repositories.UserRepository
+	productRepo          repositories.ProductRepository
+	stockRepo            repositories.StockRepository
+	stockMovementRepo    repositories.StockMovementRepository
+	saleRepo             repositories.SaleRepository
+	invoiceRepo          repositories.InvoiceRepository
+	checkoutRuleRepo     repositories.CheckoutRuleRepository
+	stockReservationRepo repositories.StockReservationRepository
+	pdfService           services.InvoicePDFService
+	fileStorage          ports.FileStoragePort
+	audit                ports.AuditPort
+	logger               logger.Logger
+}
+
+// NewTenantExportUseCase creates a new tenant export use case
+func NewTenantExportUseCase(
+	tenantExportRepo repositories.TenantExportRepository,
+	tenantRepo repositories.TenantRepository,
+	userRepo repositories.UserRepository,
+	productRepo repositories.ProductRepository,
+	stockRepo repositories.StockRepository,
+	stockMovementRepo repositories.StockMovementRepository,
+	saleRepo repositories.SaleRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	checkoutRuleRepo repositories.CheckoutRuleRepository,
+	stockReservationRepo repositories.StockReservationRepository,
+	pdfService services.InvoicePDFService,
+	fileStorage ports.FileStoragePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *TenantExportUseCase {
+	return &TenantExportUseCase{
+		tenantExportRepo:     tenantExportRepo,
+		tenantRepo:           tenantRepo,
+		userRepo:             userRepo,
+		productRepo:          productRepo,
+		stockRepo:            stockRepo,
+		stockMovementRepo:    stockMovementRepo,
+		saleRepo:             saleRepo,
+		invoiceRepo:          invoiceRepo,
+		checkoutRuleRepo:     checkoutRuleRepo,
+		stockReservationRepo: stockReservationRepo,
+		pdfService:           pdfService,
+		fileStorage:          fileStorage,
+		audit:                audit,
+		logger:               logger,
+	}
+}
+
+// TenantExportResponse represents tenant export response
+type TenantExportResponse struct {
+	ID           uuid.UUID                   `json:"id"`
+	Status       entities.TenantExportStatus `json:"status"`
+	FileSize     int64                       `json:"file_size"`
+	RecordCounts map[string]int              `json:"record_counts,omitempty"`
+	ErrorMessage string                      `json:"error_message,omitempty"`
+	RequestedBy  uuid.UUID                   `json:"requested_by"`
+	StartedAt    *time.Time                  `json:"started_at,omitempty"`
+	CompletedAt  *time.Time                  `json:"completed_at,omitempty"`
+	ExpiresAt    *time.Time                  `json:"expires_at,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at"`
+}
+
+// TenantExportDownloadResponse represents a signed download link for an export archive
+type TenantExportDownloadResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// tenantExportManifest describes the contents of an export archive
+type tenantExportManifest struct {
+	FormatVersion string         `json:"format_version"`
+	ExportID      uuid.UUID      `json:"export_id"`
+	TenantID      uuid.UUID      `json:"tenant_id"`
+	GeneratedAt   time.Time      `json:"generated_at"`
+	RecordCounts  map[string]int `json:"record_counts"`
+	Files         []string       `json:"files"`
+}
+
+// RequestExport creates a pending export job for the tenant. The archive is built by ProcessDue.
+func (uc *TenantExportUseCase) RequestExport(ctx context.Context, tenantID, userID uuid.UUID) (*TenantExportResponse, error) {
+	existing, err := uc.tenantExportRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get tenant exports")
+		return nil, errors.NewInternalError("failed to get tenant exports", err)
+	}
+	for _, export := range existing {
+		if export.IsInProgress() {
+			return nil, errors.NewConflictError("a tenant export is already in progress")
+		}
+	}
+
+	export, err := entities.NewTenantExport(tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tenantExportRepo.Create(ctx, export); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to create tenant export")
+		return nil, errors.NewInternalError("failed to create tenant export", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "request_export",
+		Resource:   "tenant_export",
+		ResourceID: export.ID.String(),
+		NewValue: map[string]interface{}{
+			"tenant_id": tenantID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"export_id": export.ID,
+		"tenant_id": tenantID,
+		"user_id":   userID,
+	}).Info("Tenant export requested")
+
+	return uc.toTenantExportResponse(export), nil
+}
+
+// ProcessDue claims and builds the pending export jobs, resuming jobs whose worker stopped
+// before finishing. It is run periodically by the scheduler.
+func (uc *TenantExportUseCase) ProcessDue(ctx context.Context) error {
+	for {
+		now := time.Now()
+		exports, err := uc.tenantExportRepo.ClaimDue(ctx, now, now.Add(-tenantExportLease), 1)
+		if err != nil {
+			return fmt.Errorf("failed to claim tenant exports: %w", err)
+		}
+		if len(exports) == 0 {
+			return nil
+		}
+
+		uc.processExport(ctx, exports[0])
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// processExport builds and stores the archive for a claimed export job and records the outcome
+func (uc *TenantExportUseCase) processExport(ctx context.Context, export *entities.TenantExport) {
+	filePath, fileSize, recordCounts, err := uc.storeArchive(entities.WithTenantScope(ctx, export.TenantID), export)
+	if err == nil {
+		err = export.Complete(filePath, fileSize, recordCounts)
+	}
+
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"export_id": export.ID,
+			"tenant_id": export.TenantID,
+			"error":     err.Error(),
+		}).Error("Failed to build tenant export")
+		export.Fail(err.Error())
+	}
+
+	if err := uc.tenantExportRepo.Update(ctx, export); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"export_id": export.ID,
+			"error":     err.Error(),
+		}).Error("Failed to update tenant export")
+		return
+	}
+
+	if export.Status == entities.TenantExportStatusCompleted {
+		uc.logger.WithFields(map[string]interface{}{
+			"export_id": export.ID,
+			"tenant_id": export.TenantID,
+			"file_size": export.FileSize,
+		}).Info("Tenant export completed")
+	}
+}
+
+// storeArchive builds the export archive in a temporary file and streams it to file storage
+func (uc *TenantExportUseCase) storeArchive(ctx context.Context, export *entities.TenantExport) (string, int64, map[string]int, error) {
+	file, err := os.CreateTemp("", "tenant-export-*.zip")
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	recordCounts, err := uc.buildArchive(ctx, export, file)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to stat archive file: %w", err)
+	}
+
+	filePath, err := uc.fileStorage.StoreFrom(ctx, fmt.Sprintf("exports/%s/%s.zip", export.TenantID, export.ID), file, info.Size())
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	return filePath, info.Size(), recordCounts, nil
+}
+
+// GetExport retrieves an export job by ID
+func (uc *TenantExportUseCase) GetExport(ctx context.Context, tenantID, exportID uuid.UUID) (*TenantExportResponse, error) {
+	export, err := uc.getTenantExport(ctx, tenantID, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toTenantExportResponse(export), nil
+}
+
+// ListExports retrieves all export jobs for a tenant
+func (uc *TenantExportUseCase) ListExports(ctx context.Context, tenantID uuid.UUID) ([]*TenantExportResponse, error) {
+	exports, err := uc.tenantExportRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list tenant exports")
+		return nil, errors.NewInternalError("failed to list tenant exports", err)
+	}
+
+	responses := make([]*TenantExportResponse, len(exports))
+	for i, export := range exports {
+		responses[i] = uc.toTenantExportResponse(export)
+	}
+
+	return responses, nil
+}
+
+// GetDownloadLink returns a short-lived signed link to a completed export archive
+func (uc *TenantExportUseCase) GetDownloadLink(ctx context.Context, tenantID, userID, exportID uuid.UUID) (*TenantExportDownloadResponse, error) {
+	export, err := uc.getTenantExport(ctx, tenantID, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !export.IsDownloadable(time.Now()) {
+		return nil, errors.NewValidationError("export not available", "export must be completed and not expired to be downloaded")
+	}
+
+	url, err := uc.fileStorage.GetSignedURL(ctx, export.FilePath, tenantExportLinkExpiry)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"export_id": exportID,
+			"error":     err.Error(),
+		}).Error("Failed to sign tenant export link")
+		return nil, errors.NewInternalError("failed to create download link", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "download_export",
+		Resource:   "tenant_export",
+		ResourceID: exportID.String(),
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return &TenantExportDownloadResponse{
+		URL:       url,
+		ExpiresAt: time.Now().Add(tenantExportLinkExpiry),
+	}, nil
+}
+
+// buildArchive writes every tenant entity as JSONL, plus invoice PDFs and a manifest, as a zip
+// archive to out. The context must be scoped to the export's tenant so every listing is filtered
+// by tenant in the database.
+func (uc *TenantExportUseCase) buildArchive(ctx context.Context, export *entities.TenantExport, out io.Writer) (map[string]int, error) {
+	tenantID := export.TenantID
+	archive := zip.NewWriter(out)
+	manifest := tenantExportManifest{
+		FormatVersion: tenantExportFormatVersion,
+		ExportID:      export.ID,
+		TenantID:      tenantID,
+		GeneratedAt:   time.Now(),
+		RecordCounts:  map[string]int{},
+	}
+
+	writeEntity := func(name string, write func(*json.Encoder) (int, error)) error {
+		file := name + ".jsonl"
+		w, err := archive.Create(file)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", file, err)
+		}
+		count, err := write(json.NewEncoder(w))
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", name, err)
+		}
+		manifest.RecordCounts[name] = count
+		manifest.Files = append(manifest.Files, file)
+		return nil
+	}
+
+	err := writeEntity("users", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.User, utils.PaginationInfo, error) {
+			return uc.userRepo.List(ctx, repositories.UserFilter{OrderBy: "created_at"}, p)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeEntity("products", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.Product, utils.PaginationInfo, error) {
+			return uc.productRepo.List(ctx, repositories.ProductFilter{OrderBy: "created_at"}, p)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeEntity("stock", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.Stock, utils.PaginationInfo, error) {
+			return uc.stockRepo.List(ctx, repositories.StockFilter{}, p)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeEntity("stock_movements", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.StockMovement, utils.PaginationInfo, error) {
+			return uc.stockMovementRepo.List(ctx, repositories.StockMovementFilter{OrderBy: "created_at"}, p)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeEntity("stock_reservations", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.StockReservation, utils.PaginationInfo, error) {
+			return uc.stockReservationRepo.List(ctx, repositories.StockReservationFilter{TenantID: tenantID, OrderBy: "created_at"}, p)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeEntity("sales", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.Sale, utils.PaginationInfo, error) {
+			return uc.saleRepo.List(ctx, repositories.SaleFilter{OrderBy: "created_at"}, p)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var invoices []*entities.Invoice
+	err = writeEntity("invoices", func(enc *json.Encoder) (int, error) {
+		return exportPages(enc, func(p utils.PaginationInfo) ([]*entities.Invoice, utils.PaginationInfo, error) {
+			page, pagination, err := uc.invoiceRepo.List(ctx, repositories.InvoiceFilter{OrderBy: "created_at"}, p)
+			invoices = append(invoices, page...)
+			return page, pagination, err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeEntity("checkout_rules", func(enc *json.Encoder) (int, error) {
+		rules, err := uc.checkoutRuleRepo.GetByTenant(ctx, tenantID)
+		if err != nil {
+			return 0, err
+		}
+		for _, rule := range rules {
+			if err := enc.Encode(rule); err != nil {
+				return 0, err
+			}
+		}
+		return len(rules), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Invoice PDFs, with amounts in their currency's display
+	template := uc.pdfService.GetDefaultTemplate(entities.PaperSizeA4)
+	var tenant *entities.Tenant
+	if t, err := uc.tenantRepo.GetByID(ctx, tenantID); err == nil {
+		tenant = t
+	}
+	for _, invoice := range invoices {
+		if tenant != nil {
+			invoice.CurrencyFormat = invoiceCurrencyFormat(tenant, invoice)
+		}
+		pdfData, err := uc.pdfService.GenerateInvoicePDF(ctx, invoice, template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate PDF for invoice %s: %w", invoice.InvoiceNumber, err)
+		}
+
+		file := fmt.Sprintf("invoices/%s.pdf", invoice.InvoiceNumber)
+		w, err := archive.Create(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", file, err)
+		}
+		if _, err := w.Write(pdfData); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	manifest.RecordCounts["invoice_pdfs"] = len(invoices)
+
+	// Manifest
+	w, err := archive.Create("manifest.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest: %w", err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	return manifest.RecordCounts, nil
+}
+
+// exportPages pages through a repository listing and writes every record as a JSON line
+func exportPages[T any](enc *json.Encoder, fetch func(utils.PaginationInfo) ([]T, utils.PaginationInfo, error)) (int, error) {
+	count := 0
+	for page := 1; ; page++ {
+		records, pagination, err := fetch(utils.PaginationInfo{Page: page, Limit: tenantExportPageSize})
+		if err != nil {
+			return count, err
+		}
+
+		for _, record := range records {
+			if err := enc.Encode(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if !pagination.HasNext || len(records) == 0 {
+			return count, nil
+		}
+	}
+}
+
+// getTenantExport retrieves an export job and ensures it belongs to the tenant
+func (uc *TenantExportUseCase) getTenantExport(ctx context.Context, tenantID, exportID uuid.UUID) (*entities.TenantExport, error) {
+	export, err := uc.tenantExportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("tenant export")
+	}
+	if export.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("tenant export")
+	}
+	return export, nil
+}
+
+// toTenantExportResponse converts tenant export entity to response
+func (uc *TenantExportUseCase) toTenantExportResponse(export *entities.TenantExport) *TenantExportResponse {
+	return &TenantExportResponse{
+		ID:           export.ID,
+		Status:       export.Status,
+		FileSize:     export.FileSize,
+		RecordCounts: export.RecordCounts,
+		ErrorMessage: export.ErrorMessage,
+		RequestedBy:  export.RequestedBy,
+		StartedAt:    export.StartedAt,
+		CompletedAt:  export.CompletedAt,
+		ExpiresAt:    export.ExpiresAt,
+		CreatedAt:    export.CreatedAt,
+	}
+}
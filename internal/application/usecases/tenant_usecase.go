@@ -103,6 +103,19 @@ type UpdateTenantSettingsRequest struct {
 	Settings map[string]interface{}   `json:"settings" validate:"required"`
 }
 
+// CurrencyFormatResponse represents the tenant's currency display
+type CurrencyFormatResponse struct {
+	Currency  string                  `json:"currency"`
+	Format    entities.CurrencyFormat `json:"format"`
+	IsDefault bool                    `json:"is_default"` // Whether the conventional display of the currency is used
+}
+
+// UpdateCurrencyFormatRequest represents update currency format request. A missing format
+// restores the conventional display of the tenant's currency.
+type UpdateCurrencyFormatRequest struct {
+	Format *entities.CurrencyFormat `json:"format"`
+}
+
 // RegisterTenant registers a new tenant with admin user and subscription
 func (uc *TenantUseCase) RegisterTenant(ctx context.Context, req RegisterTenantRequest) (*RegisterTenantResponse, error) {
 	// Audit logging
@@ -358,6 +371,53 @@ func (uc *TenantUseCase) UpdateTenantSettings(ctx context.Context, req UpdateTen
 	return nil
 }
 
+// GetCurrencyFormat retrieves how the tenant's amounts are displayed
+func (uc *TenantUseCase) GetCurrencyFormat(ctx context.Context, tenantID uuid.UUID) (*CurrencyFormatResponse, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCurrencyFormatResponse(tenant), nil
+}
+
+// UpdateCurrencyFormat changes how the tenant's amounts are displayed in documents and emails
+func (uc *TenantUseCase) UpdateCurrencyFormat(ctx context.Context, tenantID, userID uuid.UUID, req UpdateCurrencyFormatRequest) (*CurrencyFormatResponse, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldFormat := tenant.GetCurrencyFormat()
+	if err := tenant.SetCurrencyFormat(req.Format); err != nil {
+		return nil, err
+	}
+
+	if err := uc.tenantRepo.Update(ctx, tenant); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to update currency format")
+		return nil, errors.NewInternalError("failed to update currency format", err)
+	}
+
+	// Audit logging
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "currency_format_update",
+		Resource:   "tenant",
+		ResourceID: tenantID.String(),
+		OldValue:   map[string]interface{}{"currency_format": oldFormat},
+		NewValue:   map[string]interface{}{"currency_format": tenant.GetCurrencyFormat()},
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return toCurrencyFormatResponse(tenant), nil
+}
+
 // ActivateTenant activates a tenant
 func (uc *TenantUseCase) ActivateTenant(ctx context.Context, tenantID uuid.UUID, userID uuid.UUID) error {
 	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
@@ -420,4 +480,13 @@ func (uc *TenantUseCase) SuspendTenant(ctx context.Context, tenantID uuid.UUID,
 
 func generateSlugFromName(name string) string {
 	return entities.GenerateSlugFromName(name)
-}
\ No newline at end of file
+}
+
+// toCurrencyFormatResponse converts the tenant's currency display to a response
+func toCurrencyFormatResponse(tenant *entities.Tenant) *CurrencyFormatResponse {
+	return &CurrencyFormatResponse{
+		Currency:  tenant.GetCurrency(),
+		Format:    tenant.GetCurrencyFormat(),
+		IsDefault: tenant.Configuration.CurrencyFormat == nil,
+	}
+}
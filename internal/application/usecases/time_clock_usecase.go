@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// TimeClockUseCase handles staff clocking in and out, breaks and timesheets
+type TimeClockUseCase struct {
+	timeClockRepo   repositories.TimeClockRepository
+	kioskDeviceRepo repositories.KioskDeviceRepository
+	audit           ports.AuditPort
+	logger          logger.Logger
+}
+
+// NewTimeClockUseCase creates a new time clock use case
+func NewTimeClockUseCase(
+	timeClockRepo repositories.TimeClockRepository,
+	kioskDeviceRepo repositories.KioskDeviceRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *TimeClockUseCase {
+	return &TimeClockUseCase{
+		timeClockRepo:   timeClockRepo,
+		kioskDeviceRepo: kioskDeviceRepo,
+		audit:           audit,
+		logger:          logger,
+	}
+}
+
+// ClockInRequest represents clock in request
+type ClockInRequest struct {
+	DeviceID *uuid.UUID `json:"device_id,omitempty"`
+}
+
+// ClockOutRequest represents clock out request
+type ClockOutRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// TimesheetRequest represents timesheet request for a pay period
+type TimesheetRequest struct {
+	FromDate time.Time
+	ToDate   time.Time // Exclusive
+	Location *time.Location
+	UserID   *uuid.UUID
+}
+
+// ClockIn starts a shift for a staff member
+func (uc *TimeClockUseCase) ClockIn(ctx context.Context, tenantID, userID uuid.UUID, req ClockInRequest) (*entities.TimeClockEntry, error) {
+	if req.DeviceID != nil {
+		device, err := uc.kioskDeviceRepo.GetByID(ctx, *req.DeviceID)
+		if err != nil || device.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("device")
+		}
+		if !device.IsActive {
+			return nil, errors.NewValidationError("device has been revoked", "cannot clock in on a revoked device")
+		}
+	}
+
+	if _, err := uc.timeClockRepo.GetOpenByUser(ctx, tenantID, userID); err == nil {
+		return nil, errors.NewConflictError("user is already clocked in")
+	}
+
+	entry, err := entities.NewTimeClockEntry(tenantID, userID, req.DeviceID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.timeClockRepo.Create(ctx, entry); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return nil, appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to clock in")
+		return nil, errors.NewInternalError("failed to clock in", err)
+	}
+
+	uc.logClockEvent(ctx, userID, "clock_in", entry)
+
+	return entry, nil
+}
+
+// ClockOut ends the staff member's current shift
+func (uc *TimeClockUseCase) ClockOut(ctx context.Context, tenantID, userID uuid.UUID, req ClockOutRequest) (*entities.TimeClockEntry, error) {
+	entry, err := uc.timeClockRepo.GetOpenByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, errors.NewValidationError("not clocked in", "there is no open shift to clock out of")
+	}
+
+	if err := entry.ClockOut(time.Now(), req.Notes); err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	uc.logClockEvent(ctx, userID, "clock_out", entry)
+
+	return entry, nil
+}
+
+// StartBreak starts a break in the staff member's current shift
+func (uc *TimeClockUseCase) StartBreak(ctx context.Context, tenantID, userID uuid.UUID) (*entities.TimeClockEntry, error) {
+	entry, err := uc.timeClockRepo.GetOpenByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, errors.NewValidationError("not clocked in", "clock in before starting a break")
+	}
+
+	if err := entry.StartBreak(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// EndBreak ends the break in progress in the staff member's current shift
+func (uc *TimeClockUseCase) EndBreak(ctx context.Context, tenantID, userID uuid.UUID) (*entities.TimeClockEntry, error) {
+	entry, err := uc.timeClockRepo.GetOpenByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, errors.NewValidationError("not clocked in", "there is no open shift")
+	}
+
+	if err := entry.EndBreak(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.updateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetCurrentShift retrieves the shift the staff member is currently clocked in to
+func (uc *TimeClockUseCase) GetCurrentShift(ctx context.Context, tenantID, userID uuid.UUID) (*entities.TimeClockEntry, error) {
+	return uc.timeClockRepo.GetOpenByUser(ctx, tenantID, userID)
+}
+
+// GetTimesheet builds the timesheet for a pay period
+func (uc *TimeClockUseCase) GetTimesheet(ctx context.Context, tenantID uuid.UUID, req TimesheetRequest) (*entities.Timesheet, error) {
+	if err := entities.ValidateTimesheetPeriod(req.FromDate, req.ToDate); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.timeClockRepo.GetByPeriod(ctx, tenantID, req.FromDate, req.ToDate, req.UserID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get time clock entries")
+		return nil, errors.NewInternalError("failed to get timesheet", err)
+	}
+
+	return entities.NewTimesheet(entries, req.FromDate, req.ToDate, req.Location, time.Now())
+}
+
+// updateEntry saves a change to a shift
+func (uc *TimeClockUseCase) updateEntry(ctx context.Context, entry *entities.TimeClockEntry) error {
+	if err := uc.timeClockRepo.Update(ctx, entry); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"entry_id": entry.ID,
+			"error":    err.Error(),
+		}).Error("Failed to update time clock entry")
+		return errors.NewInternalError("failed to update time clock entry", err)
+	}
+	return nil
+}
+
+// logClockEvent records clocking in or out in the audit log
+func (uc *TimeClockUseCase) logClockEvent(ctx context.Context, userID uuid.UUID, action string, entry *entities.TimeClockEntry) {
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		Resource:   "time_clock_entry",
+		ResourceID: entry.ID.String(),
+		NewValue: map[string]interface{}{
+			"device_id":    entry.DeviceID,
+			"clock_in_at":  entry.ClockInAt,
+			"clock_out_at": entry.ClockOutAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"entry_id": entry.ID,
+		"user_id":  userID,
+		"action":   action,
+	}).Info("Time clock event recorded")
+}
@@ -0,0 +1,359 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// totalsRecalculationListLimit caps the number of recent recalculations listed
+const totalsRecalculationListLimit = 50
+
+// TotalsRecalculationUseCase handles recomputing the stored totals of sales and invoices after
+// a calculation fix. Every run is previewed as a dry run first and applying it records an audit
+// entry with the old and new totals of each corrected document.
+type TotalsRecalculationUseCase struct {
+	saleRepo          repositories.SaleRepository
+	invoiceRepo       repositories.InvoiceRepository
+	recalculationRepo repositories.TotalsRecalculationRepository
+	audit             ports.AuditPort
+	logger            logger.Logger
+}
+
+// NewTotalsRecalculationUseCase creates a new totals recalculation use case
+func NewTotalsRecalculationUseCase(
+	saleRepo repositories.SaleRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	recalculationRepo repositories.TotalsRecalculationRepository,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *TotalsRecalculationUseCase {
+	return &TotalsRecalculationUseCase{
+		saleRepo:          saleRepo,
+		invoiceRepo:       invoiceRepo,
+		recalculationRepo: recalculationRepo,
+		audit:             audit,
+		logger:            logger,
+	}
+}
+
+// PreviewTotalsRecalculationRequest represents preview totals recalculation request.
+// The filter matching the document type selects the documents to recalculate.
+type PreviewTotalsRecalculationRequest struct {
+	DocumentType  entities.TotalsDocumentType `json:"document_type" binding:"required"`
+	Reason        string                      `json:"reason" binding:"required"`
+	SaleFilter    *repositories.SaleFilter    `json:"sale_filter,omitempty"`
+	InvoiceFilter *repositories.InvoiceFilter `json:"invoice_filter,omitempty"`
+}
+
+// PreviewRecalculation recomputes the totals of the selected documents without changing them
+// and stores the differences as a dry run that can be applied later
+func (uc *TotalsRecalculationUseCase) PreviewRecalculation(ctx context.Context, tenantID, userID uuid.UUID, req PreviewTotalsRecalculationRequest) (*entities.TotalsRecalculation, error) {
+	if err := entities.ValidateTotalsDocumentType(req.DocumentType); err != nil {
+		return nil, err
+	}
+
+	var scanned int
+	var corrections []entities.TotalsCorrection
+	var err error
+	switch req.DocumentType {
+	case entities.TotalsDocumentTypeSale:
+		scanned, corrections, err = uc.previewSales(ctx, tenantID, req.SaleFilter)
+	case entities.TotalsDocumentTypeInvoice:
+		scanned, corrections, err = uc.previewInvoices(ctx, tenantID, req.InvoiceFilter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	recalculation, err := entities.NewTotalsRecalculation(tenantID, userID, req.DocumentType, req.Reason, scanned, corrections)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recalculationRepo.Create(ctx, recalculation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to create totals recalculation")
+		return nil, errors.NewInternalError("failed to save totals recalculation", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "preview",
+		Resource:   "totals_recalculation",
+		ResourceID: recalculation.ID.String(),
+		NewValue: map[string]interface{}{
+			"document_type": recalculation.DocumentType,
+			"reason":        recalculation.Reason,
+			"scanned_count": recalculation.ScannedCount,
+			"changed_count": recalculation.ChangedCount,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"recalculation_id": recalculation.ID,
+		"document_type":    recalculation.DocumentType,
+		"scanned_count":    recalculation.ScannedCount,
+		"changed_count":    recalculation.ChangedCount,
+		"user_id":          userID,
+	}).Info("Totals recalculation previewed")
+
+	return recalculation, nil
+}
+
+// ApplyRecalculation applies the corrections of a previewed recalculation. A document whose
+// totals changed since the preview is skipped rather than overwritten, and each document is
+// saved on its own so one failure does not hold back the rest.
+func (uc *TotalsRecalculationUseCase) ApplyRecalculation(ctx context.Context, tenantID, userID, recalculationID uuid.UUID) (*entities.TotalsRecalculation, error) {
+	recalculation, err := uc.GetRecalculation(ctx, tenantID, recalculationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recalculation.StartApply(); err != nil {
+		return nil, err
+	}
+
+	for i := range recalculation.Corrections {
+		correction := &recalculation.Corrections[i]
+		if correction.Outcome != entities.TotalsCorrectionOutcomePending {
+			continue
+		}
+
+		outcome, reason := uc.applyCorrection(ctx, tenantID, userID, recalculation, correction)
+		recalculation.RecordOutcome(i, outcome, reason)
+
+		if err := uc.recalculationRepo.UpdateCorrection(ctx, correction); err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to update totals correction")
+		}
+	}
+
+	recalculation.MarkApplied(userID)
+	if err := uc.recalculationRepo.Update(ctx, recalculation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"recalculation_id": recalculation.ID,
+			"error":            err.Error(),
+		}).Error("Failed to update totals recalculation")
+		return nil, errors.NewInternalError("failed to update totals recalculation", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "apply",
+		Resource:   "totals_recalculation",
+		ResourceID: recalculation.ID.String(),
+		NewValue: map[string]interface{}{
+			"applied_count": recalculation.AppliedCount,
+			"skipped_count": recalculation.SkippedCount,
+			"failed_count":  recalculation.FailedCount,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"recalculation_id": recalculation.ID,
+		"applied_count":    recalculation.AppliedCount,
+		"skipped_count":    recalculation.SkippedCount,
+		"failed_count":     recalculation.FailedCount,
+		"user_id":          userID,
+	}).Info("Totals recalculation applied")
+
+	return recalculation, nil
+}
+
+// GetRecalculation returns a recalculation with its corrections
+func (uc *TotalsRecalculationUseCase) GetRecalculation(ctx context.Context, tenantID, recalculationID uuid.UUID) (*entities.TotalsRecalculation, error) {
+	recalculation, err := uc.recalculationRepo.GetByID(ctx, recalculationID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("totals recalculation")
+	}
+	if recalculation.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("totals recalculation")
+	}
+
+	return recalculation, nil
+}
+
+// ListRecalculations lists the tenant's most recent recalculations
+func (uc *TotalsRecalculationUseCase) ListRecalculations(ctx context.Context, tenantID uuid.UUID) ([]*entities.TotalsRecalculation, error) {
+	recalculations, err := uc.recalculationRepo.GetByTenant(ctx, tenantID, totalsRecalculationListLimit)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list totals recalculations")
+		return nil, errors.NewInternalError("failed to list totals recalculations", err)
+	}
+
+	return recalculations, nil
+}
+
+// previewSales recalculates the selected sales in memory and returns those whose totals change
+func (uc *TotalsRecalculationUseCase) previewSales(ctx context.Context, tenantID uuid.UUID, filter *repositories.SaleFilter) (int, []entities.TotalsCorrection, error) {
+	if filter == nil {
+		return 0, nil, errors.NewValidationError("sale filter is required", "provide sale_filter to select the sales to recalculate")
+	}
+
+	sales, pagination, err := uc.saleRepo.List(ctx, *filter, utils.PaginationInfo{
+		Page:  1,
+		Limit: entities.MaxTotalsRecalculationSize,
+	})
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list sales")
+		return 0, nil, errors.NewInternalError("failed to select sales", err)
+	}
+	if pagination.TotalCount > entities.MaxTotalsRecalculationSize {
+		return 0, nil, errors.NewValidationError("too many sales selected", "narrow the filter to at most 500 sales")
+	}
+
+	scanned := 0
+	corrections := []entities.TotalsCorrection{}
+	for _, sale := range sales {
+		if sale.TenantID != tenantID {
+			continue
+		}
+		scanned++
+
+		before := sale.Totals()
+		sale.RecalculateTotals()
+		if after := sale.Totals(); !after.Equal(before) {
+			corrections = append(corrections, entities.NewTotalsCorrection(sale.ID, sale.SaleNumber, before, after))
+		}
+	}
+
+	return scanned, corrections, nil
+}
+
+// previewInvoices recalculates the selected invoices in memory at the tax rate of their sale and
+// returns those whose totals change
+func (uc *TotalsRecalculationUseCase) previewInvoices(ctx context.Context, tenantID uuid.UUID, filter *repositories.InvoiceFilter) (int, []entities.TotalsCorrection, error) {
+	if filter == nil {
+		return 0, nil, errors.NewValidationError("invoice filter is required", "provide invoice_filter to select the invoices to recalculate")
+	}
+
+	invoices, pagination, err := uc.invoiceRepo.List(ctx, *filter, utils.PaginationInfo{
+		Page:  1,
+		Limit: entities.MaxTotalsRecalculationSize,
+	})
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list invoices")
+		return 0, nil, errors.NewInternalError("failed to select invoices", err)
+	}
+	if pagination.TotalCount > entities.MaxTotalsRecalculationSize {
+		return 0, nil, errors.NewValidationError("too many invoices selected", "narrow the filter to at most 500 invoices")
+	}
+
+	scanned := 0
+	corrections := []entities.TotalsCorrection{}
+	for _, invoice := range invoices {
+		if invoice.TenantID != tenantID {
+			continue
+		}
+		scanned++
+
+		sale, err := uc.saleRepo.GetByID(ctx, invoice.SaleID)
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"invoice_id": invoice.ID,
+				"error":      err.Error(),
+			}).Warn("Skipping invoice without a readable sale in totals recalculation")
+			continue
+		}
+
+		before := invoice.Totals()
+		invoice.RecalculateTotals(sale.TaxRate)
+		if after := invoice.Totals(); !after.Equal(before) {
+			corrections = append(corrections, entities.NewTotalsCorrection(invoice.ID, invoice.InvoiceNumber, before, after))
+		}
+	}
+
+	return scanned, corrections, nil
+}
+
+// applyCorrection recalculates and saves one document if it still matches the preview, recording
+// its old and new totals in the audit log
+func (uc *TotalsRecalculationUseCase) applyCorrection(ctx context.Context, tenantID, userID uuid.UUID, recalculation *entities.TotalsRecalculation, correction *entities.TotalsCorrection) (entities.TotalsCorrectionOutcome, string) {
+	var before, after entities.DocumentTotals
+	var save func() error
+
+	switch recalculation.DocumentType {
+	case entities.TotalsDocumentTypeSale:
+		sale, err := uc.saleRepo.GetByID(ctx, correction.DocumentID)
+		if err != nil || sale.TenantID != tenantID {
+			return entities.TotalsCorrectionOutcomeSkipped, entities.TotalsCorrectionSkipMissingDocument
+		}
+		before = sale.Totals()
+		sale.RecalculateTotals()
+		after = sale.Totals()
+		save = func() error { return uc.saleRepo.Update(ctx, sale) }
+	case entities.TotalsDocumentTypeInvoice:
+		invoice, err := uc.invoiceRepo.GetByID(ctx, correction.DocumentID)
+		if err != nil || invoice.TenantID != tenantID {
+			return entities.TotalsCorrectionOutcomeSkipped, entities.TotalsCorrectionSkipMissingDocument
+		}
+		sale, err := uc.saleRepo.GetByID(ctx, invoice.SaleID)
+		if err != nil {
+			return entities.TotalsCorrectionOutcomeSkipped, entities.TotalsCorrectionSkipMissingDocument
+		}
+		before = invoice.Totals()
+		invoice.RecalculateTotals(sale.TaxRate)
+		after = invoice.Totals()
+		save = func() error { return uc.invoiceRepo.Update(ctx, invoice) }
+	}
+
+	// Only apply exactly what was previewed
+	if !before.Equal(correction.Before) || !after.Equal(correction.After) {
+		return entities.TotalsCorrectionOutcomeSkipped, entities.TotalsCorrectionSkipDocumentChanged
+	}
+
+	if err := save(); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"recalculation_id": recalculation.ID,
+			"document_id":      correction.DocumentID,
+			"error":            err.Error(),
+		}).Error("Failed to apply totals correction")
+		return entities.TotalsCorrectionOutcomeFailed, err.Error()
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "recalculate_totals",
+		Resource:   string(recalculation.DocumentType),
+		ResourceID: correction.DocumentID.String(),
+		OldValue: map[string]interface{}{
+			"subtotal":     before.Subtotal,
+			"tax_amount":   before.TaxAmount,
+			"total_amount": before.TotalAmount,
+		},
+		NewValue: map[string]interface{}{
+			"subtotal":         after.Subtotal,
+			"tax_amount":       after.TaxAmount,
+			"total_amount":     after.TotalAmount,
+			"recalculation_id": recalculation.ID,
+			"reason":           recalculation.Reason,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return entities.TotalsCorrectionOutcomeApplied, ""
+}
@@ -0,0 +1,413 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// UserInvitationUseCase invites users to a tenant. Invited users choose their own password
+// through a signed activation link emailed to them, instead of an admin setting it.
+type UserInvitationUseCase struct {
+	userRepo       repositories.UserRepository
+	invitationRepo repositories.UserInvitationRepository
+	users          *UserUseCase
+	emailService   services.EmailService
+	audit          ports.AuditPort
+	dashboardURL   string
+	secret         string
+	expiry         time.Duration
+	logger         logger.Logger
+}
+
+// NewUserInvitationUseCase creates a new user invitation use case. Activation links point at
+// the activation page of the dashboard at dashboardURL, are signed with secret and stay valid
+// for expiry.
+func NewUserInvitationUseCase(
+	userRepo repositories.UserRepository,
+	invitationRepo repositories.UserInvitationRepository,
+	users *UserUseCase,
+	emailService services.EmailService,
+	audit ports.AuditPort,
+	dashboardURL string,
+	secret string,
+	expiry time.Duration,
+	logger logger.Logger,
+) *UserInvitationUseCase {
+	return &UserInvitationUseCase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		users:          users,
+		emailService:   emailService,
+		audit:          audit,
+		dashboardURL:   strings.TrimRight(dashboardURL, "/"),
+		secret:         secret,
+		expiry:         expiry,
+		logger:         logger,
+	}
+}
+
+// InviteUserRequest represents invite user request
+type InviteUserRequest struct {
+	Username  string            `json:"username" validate:"required,min=3"`
+	Email     string            `json:"email" validate:"required,email"`
+	FirstName string            `json:"first_name" validate:"required"`
+	LastName  string            `json:"last_name" validate:"required"`
+	Role      entities.UserRole `json:"role" validate:"required"`
+}
+
+// InvitationLinkRequest represents the signed query of an activation link
+type InvitationLinkRequest struct {
+	Expires   string `form:"expires" json:"expires"`
+	Signature string `form:"signature" json:"signature"`
+}
+
+// AcceptInvitationRequest represents the password an invited user chose through their
+// activation link
+type AcceptInvitationRequest struct {
+	InvitationLinkRequest
+	Password  string `json:"password" validate:"required,min=8"`
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// UserInvitationResponse represents an invitation with its current status
+type UserInvitationResponse struct {
+	*entities.UserInvitation
+	Status    entities.UserInvitationStatus `json:"status"`
+	EmailSent bool                          `json:"email_sent"`
+}
+
+// InviteUserResponse represents the invited user and their invitation
+type InviteUserResponse struct {
+	User       *UserResponse           `json:"user"`
+	Invitation *UserInvitationResponse `json:"invitation"`
+}
+
+// InvitationDetailsResponse represents what an activation link shows the invited user
+type InvitationDetailsResponse struct {
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// InviteUser creates a user in the invited state and emails them an activation link. The
+// invitation is kept when the email cannot be sent, so it can be resent.
+func (uc *UserInvitationUseCase) InviteUser(ctx context.Context, adminID, tenantID uuid.UUID, req InviteUserRequest) (*InviteUserResponse, error) {
+	if err := uc.users.checkUserAvailable(ctx, req.Username, req.Email); err != nil {
+		return nil, err
+	}
+
+	user, err := entities.NewInvitedUser(tenantID, req.Username, req.Email, req.FirstName, req.LastName, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"username": req.Username,
+			"error":    err.Error(),
+		}).Error("Failed to create invited user")
+		return nil, errors.NewInternalError("failed to create user", err)
+	}
+
+	invitation, err := entities.NewUserInvitation(user, adminID, uc.expiry)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.invitationRepo.Create(ctx, invitation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Error("Failed to create user invitation")
+		return nil, errors.NewInternalError("failed to create invitation", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     adminID,
+		Action:     "invite",
+		Resource:   "user",
+		ResourceID: user.ID.String(),
+		NewValue: map[string]interface{}{
+			"username":   user.Username,
+			"email":      user.Email,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"role":       user.Role,
+			"expires_at": invitation.ExpiresAt,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"user_id":  user.ID,
+		"admin_id": adminID,
+	}).Info("User invited successfully")
+
+	return &InviteUserResponse{
+		User:       uc.users.toUserResponse(user),
+		Invitation: uc.sendInvitation(ctx, invitation, user),
+	}, nil
+}
+
+// GetUserInvitation retrieves the latest invitation of a user of the tenant
+func (uc *UserInvitationUseCase) GetUserInvitation(ctx context.Context, tenantID, userID uuid.UUID) (*UserInvitationResponse, error) {
+	invitation, err := uc.invitationRepo.GetLatestByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, uc.invitationError(err)
+	}
+
+	return &UserInvitationResponse{
+		UserInvitation: invitation,
+		Status:         invitation.Status(time.Now()),
+	}, nil
+}
+
+// ResendInvitation emails an invited user a new activation link, extending their invitation.
+// Links sent before stop working. A revoked invitation is replaced by a new one.
+func (uc *UserInvitationUseCase) ResendInvitation(ctx context.Context, adminID, tenantID, userID uuid.UUID) (*UserInvitationResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil || user.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("user")
+	}
+	if user.Status != entities.UserStatusInvited {
+		return nil, errors.NewConflictError("user has already accepted their invitation")
+	}
+
+	now := time.Now()
+	invitation, err := uc.invitationRepo.GetLatestByUser(ctx, tenantID, userID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+			return nil, uc.invitationError(err)
+		}
+	}
+
+	if invitation == nil || invitation.Status(now) == entities.UserInvitationRevoked {
+		if invitation, err = entities.NewUserInvitation(user, adminID, uc.expiry); err != nil {
+			return nil, err
+		}
+		err = uc.invitationRepo.Create(ctx, invitation)
+	} else {
+		if err := invitation.Resend(uc.expiry, now); err != nil {
+			return nil, err
+		}
+		err = uc.invitationRepo.Update(ctx, invitation)
+	}
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Error("Failed to save user invitation")
+		return nil, errors.NewInternalError("failed to resend invitation", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     adminID,
+		Action:     "resend_invitation",
+		Resource:   "user",
+		ResourceID: userID.String(),
+		NewValue: map[string]interface{}{
+			"invitation_id": invitation.ID,
+			"expires_at":    invitation.ExpiresAt,
+		},
+		Timestamp: now,
+		Success:   true,
+	})
+
+	return uc.sendInvitation(ctx, invitation, user), nil
+}
+
+// RevokeInvitation withdraws the invitation of a user, so their activation links stop working.
+// The user stays invited until the invitation is resent or the user is deleted.
+func (uc *UserInvitationUseCase) RevokeInvitation(ctx context.Context, adminID, tenantID, userID uuid.UUID) error {
+	invitation, err := uc.invitationRepo.GetLatestByUser(ctx, tenantID, userID)
+	if err != nil {
+		return uc.invitationError(err)
+	}
+
+	if err := invitation.Revoke(adminID, time.Now()); err != nil {
+		return err
+	}
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invitation_id": invitation.ID,
+			"error":         err.Error(),
+		}).Error("Failed to revoke user invitation")
+		return errors.NewInternalError("failed to revoke invitation", err)
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     adminID,
+		Action:     "revoke_invitation",
+		Resource:   "user",
+		ResourceID: userID.String(),
+		NewValue: map[string]interface{}{
+			"invitation_id": invitation.ID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	})
+
+	uc.logger.WithFields(map[string]interface{}{
+		"user_id":  userID,
+		"admin_id": adminID,
+	}).Info("User invitation revoked")
+
+	return nil
+}
+
+// GetInvitationDetails verifies an activation link and returns who it invites
+func (uc *UserInvitationUseCase) GetInvitationDetails(ctx context.Context, invitationID uuid.UUID, req InvitationLinkRequest) (*InvitationDetailsResponse, error) {
+	invitation, err := uc.verifyLink(ctx, invitationID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, invitation.UserID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid invitation link")
+	}
+
+	return &InvitationDetailsResponse{
+		Username:  user.Username,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation verifies an activation link and activates the invited user with the
+// password they chose, which has to meet the password policy
+func (uc *UserInvitationUseCase) AcceptInvitation(ctx context.Context, invitationID uuid.UUID, req AcceptInvitationRequest) error {
+	invitation, err := uc.verifyLink(ctx, invitationID, req.InvitationLinkRequest)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, invitation.UserID)
+	if err != nil {
+		return errors.NewUnauthorizedError("invalid invitation link")
+	}
+
+	if err := uc.users.ActivateInvitedUser(ctx, user, req.Password); err != nil {
+		return err
+	}
+
+	if err := invitation.Accept(time.Now()); err != nil {
+		return err
+	}
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		// The user is active already, so the invitation only stays pending in the records
+		uc.logger.WithFields(map[string]interface{}{
+			"invitation_id": invitation.ID,
+			"error":         err.Error(),
+		}).Warn("Failed to mark user invitation accepted")
+	}
+
+	uc.audit.Log(ctx, ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		Action:     "accept_invitation",
+		Resource:   "user",
+		ResourceID: user.ID.String(),
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+		Timestamp:  time.Now(),
+		Success:    true,
+	})
+
+	uc.logger.WithField("user_id", user.ID).Info("User invitation accepted")
+	return nil
+}
+
+// Helper methods
+
+// verifyLink checks the signature of an activation link before looking the invitation up, and
+// that the link is the last one sent for an invitation that can still be accepted
+func (uc *UserInvitationUseCase) verifyLink(ctx context.Context, invitationID uuid.UUID, req InvitationLinkRequest) (*entities.UserInvitation, error) {
+	expires, err := strconv.ParseInt(req.Expires, 10, 64)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid invitation link")
+	}
+
+	link := &entities.UserInvitationLink{
+		InvitationID: invitationID,
+		ExpiresAt:    time.Unix(expires, 0),
+		Signature:    req.Signature,
+	}
+	now := time.Now()
+	if err := link.Verify(uc.secret, now); err != nil {
+		return nil, err
+	}
+
+	invitation, err := uc.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid invitation link")
+	}
+	if err := invitation.CheckLink(link, uc.secret, now); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// sendInvitation emails the activation link of an invitation. A failure is logged rather than
+// returned, since the invitation is saved and can be resent.
+func (uc *UserInvitationUseCase) sendInvitation(ctx context.Context, invitation *entities.UserInvitation, user *entities.User) *UserInvitationResponse {
+	response := &UserInvitationResponse{
+		UserInvitation: invitation,
+		Status:         invitation.Status(time.Now()),
+	}
+
+	if err := uc.emailService.SendUserInvitationEmail(ctx, invitation, user, uc.activationURL(invitation)); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"invitation_id": invitation.ID,
+			"user_id":       user.ID,
+			"error":         err.Error(),
+		}).Error("Failed to send user invitation email")
+		return response
+	}
+
+	response.EmailSent = true
+	return response
+}
+
+// activationURL signs the link to the dashboard's activation page for an invitation
+func (uc *UserInvitationUseCase) activationURL(invitation *entities.UserInvitation) string {
+	link := entities.NewUserInvitationLink(uc.secret, invitation)
+
+	query := url.Values{}
+	query.Set("invitation", link.InvitationID.String())
+	query.Set("expires", strconv.FormatInt(link.ExpiresAt.Unix(), 10))
+	query.Set("signature", link.Signature)
+
+	return fmt.Sprintf("%s/activate?%s", uc.dashboardURL, query.Encode())
+}
+
+// invitationError maps a repository error of an invitation lookup
+func (uc *UserInvitationUseCase) invitationError(err error) error {
+	if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+		return errors.NewNotFoundError("invitation")
+	}
+	uc.logger.WithField("error", err.Error()).Error("Failed to get user invitation")
+	return errors.NewInternalError("failed to get invitation", err)
+}
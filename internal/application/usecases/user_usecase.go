@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,28 +10,38 @@ import (
 	"github.com/nicklaros/adol/internal/application/ports"
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/audit"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/logger"
 	"github.com/nicklaros/adol/pkg/utils"
 )
 
+// maxPasswordChangeUsers caps the users that can be listed in one password change request
+const maxPasswordChangeUsers = 500
+
 // UserUseCase handles user management operations
 type UserUseCase struct {
-	userRepo repositories.UserRepository
-	audit    ports.AuditPort
-	logger   logger.Logger
+	userRepo            repositories.UserRepository
+	passwordHistoryRepo repositories.PasswordHistoryRepository
+	audit               ports.AuditPort
+	passwordPolicy      entities.PasswordPolicy
+	logger              logger.Logger
 }
 
 // NewUserUseCase creates a new user use case
 func NewUserUseCase(
 	userRepo repositories.UserRepository,
+	passwordHistoryRepo repositories.PasswordHistoryRepository,
 	audit ports.AuditPort,
+	passwordPolicy entities.PasswordPolicy,
 	logger logger.Logger,
 ) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
-		audit:    audit,
-		logger:   logger,
+		userRepo:            userRepo,
+		passwordHistoryRepo: passwordHistoryRepo,
+		audit:               audit,
+		passwordPolicy:      passwordPolicy,
+		logger:              logger,
 	}
 }
 
@@ -54,18 +65,33 @@ type UpdateUserRequest struct {
 	Status    *entities.UserStatus `json:"status,omitempty"`
 }
 
+// RequirePasswordChangeRequest selects the users that must change their password on next
+// login: the listed users, or every user with the role, or everyone when neither is given
+type RequirePasswordChangeRequest struct {
+	UserIDs []uuid.UUID        `json:"user_ids,omitempty"`
+	Role    *entities.UserRole `json:"role,omitempty"`
+}
+
+// RequirePasswordChangeResponse represents the users that must change their password
+type RequirePasswordChangeResponse struct {
+	UserIDs []uuid.UUID `json:"user_ids"`
+	Count   int         `json:"count"`
+}
+
 // UserResponse represents user response
 type UserResponse struct {
-	ID          uuid.UUID           `json:"id"`
-	Username    string              `json:"username"`
-	Email       string              `json:"email"`
-	FirstName   string              `json:"first_name"`
-	LastName    string              `json:"last_name"`
-	Role        entities.UserRole   `json:"role"`
-	Status      entities.UserStatus `json:"status"`
-	CreatedAt   time.Time           `json:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at"`
-	LastLoginAt *time.Time          `json:"last_login_at,omitempty"`
+	ID                 uuid.UUID           `json:"id"`
+	Username           string              `json:"username"`
+	Email              string              `json:"email"`
+	FirstName          string              `json:"first_name"`
+	LastName           string              `json:"last_name"`
+	Role               entities.UserRole   `json:"role"`
+	Status             entities.UserStatus `json:"status"`
+	PasswordChangedAt  time.Time           `json:"password_changed_at"`
+	MustChangePassword bool                `json:"must_change_password"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	LastLoginAt        *time.Time          `json:"last_login_at,omitempty"`
 }
 
 // UserListResponse represents user list response
@@ -76,24 +102,9 @@ type UserListResponse struct {
 
 // CreateUser creates a new user
 func (uc *UserUseCase) CreateUser(ctx context.Context, adminID uuid.UUID, req CreateUserRequest) (*UserResponse, error) {
-	// Check if username already exists
-	exists, err := uc.userRepo.ExistsByUsername(ctx, req.Username)
-	if err != nil {
-		uc.logger.WithField("error", err.Error()).Error("Failed to check username existence")
-		return nil, errors.NewInternalError("failed to check username", err)
-	}
-	if exists {
-		return nil, errors.NewConflictError("username already exists")
-	}
-
-	// Check if email already exists
-	exists, err = uc.userRepo.ExistsByEmail(ctx, req.Email)
-	if err != nil {
-		uc.logger.WithField("error", err.Error()).Error("Failed to check email existence")
-		return nil, errors.NewInternalError("failed to check email", err)
-	}
-	if exists {
-		return nil, errors.NewConflictError("email already exists")
+	// Check if username or email already exists
+	if err := uc.checkUserAvailable(ctx, req.Username, req.Email); err != nil {
+		return nil, err
 	}
 
 	// Set default status if not provided
@@ -102,6 +113,10 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, adminID uuid.UUID, req Cr
 		status = entities.UserStatusActive
 	}
 
+	if err := uc.passwordPolicy.Validate(req.Password); err != nil {
+		return nil, err
+	}
+
 	// Create user entity
 	user, err := entities.NewUser(
 		req.Username,
@@ -129,6 +144,7 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, adminID uuid.UUID, req Cr
 		}).Error("Failed to create user")
 		return nil, errors.NewInternalError("failed to create user", err)
 	}
+	uc.recordPasswordHistory(ctx, user)
 
 	// Audit log
 	auditEvent := ports.AuditEvent{
@@ -187,14 +203,8 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, adminID, userID uuid.UUID
 		return nil, errors.NewNotFoundError("user")
 	}
 
-	// Store old values for audit log
-	oldValue := map[string]interface{}{
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"email":      user.Email,
-		"role":       user.Role,
-		"status":     user.Status,
-	}
+	// Snapshot current state for audit log
+	before := audit.TakeSnapshot(user)
 
 	// Update profile if provided
 	if req.FirstName != "" || req.LastName != "" || req.Email != "" {
@@ -251,15 +261,6 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, adminID, userID uuid.UUID
 		return nil, errors.NewInternalError("failed to update user", err)
 	}
 
-	// New values for audit log
-	newValue := map[string]interface{}{
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"email":      user.Email,
-		"role":       user.Role,
-		"status":     user.Status,
-	}
-
 	// Audit log
 	auditEvent := ports.AuditEvent{
 		ID:         uuid.New(),
@@ -267,8 +268,7 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, adminID, userID uuid.UUID
 		Action:     "update",
 		Resource:   "user",
 		ResourceID: userID.String(),
-		OldValue:   oldValue,
-		NewValue:   newValue,
+		Changes:    audit.Diff(before, audit.TakeSnapshot(user)),
 		Timestamp:  time.Now(),
 		Success:    true,
 	}
@@ -366,6 +366,149 @@ func (uc *UserUseCase) SuspendUser(ctx context.Context, adminID, userID uuid.UUI
 	return uc.changeUserStatus(ctx, adminID, userID, entities.UserStatusSuspended, "suspend")
 }
 
+// SetPassword changes a user's password after checking it against the password policy and the
+// user's previous passwords, and saves the user
+func (uc *UserUseCase) SetPassword(ctx context.Context, user *entities.User, newPassword string) error {
+	return uc.savePassword(ctx, user, newPassword, user.UpdatePassword)
+}
+
+// ActivateInvitedUser sets the password an invited user chose, checked like SetPassword, and
+// activates their account
+func (uc *UserUseCase) ActivateInvitedUser(ctx context.Context, user *entities.User, password string) error {
+	return uc.savePassword(ctx, user, password, user.AcceptInvitation)
+}
+
+// savePassword checks a new password against the policy and history, sets it on the user with
+// set, and saves the user
+func (uc *UserUseCase) savePassword(ctx context.Context, user *entities.User, newPassword string, set func(string) error) error {
+	if err := uc.passwordPolicy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	if uc.passwordPolicy.HistorySize > 0 {
+		previous, err := uc.passwordHistoryRepo.GetRecentHashesByUser(ctx, user.ID, uc.passwordPolicy.HistorySize)
+		if err != nil {
+			uc.logger.WithField("error", err.Error()).Error("Failed to get password history")
+			return errors.NewInternalError("failed to check password history", err)
+		}
+		// Users created before the history was kept have only their current password to compare
+		if entities.PasswordReused(newPassword, append(previous, user.PasswordHash)) {
+			return errors.NewValidationError("password was used recently",
+				fmt.Sprintf("password must differ from the last %d passwords", uc.passwordPolicy.HistorySize))
+		}
+	}
+
+	if err := set(newPassword); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Error("Failed to update user password")
+		return errors.NewInternalError("failed to update password", err)
+	}
+	uc.recordPasswordHistory(ctx, user)
+
+	return nil
+}
+
+// PasswordChangeRequired checks if a user has to change their password before signing in
+func (uc *UserUseCase) PasswordChangeRequired(user *entities.User, at time.Time) bool {
+	return user.PasswordChangeRequired(uc.passwordPolicy, at)
+}
+
+// RequirePasswordChange makes the selected users of a tenant change their password on next login
+func (uc *UserUseCase) RequirePasswordChange(ctx context.Context, adminID, tenantID uuid.UUID, req RequirePasswordChangeRequest) (*RequirePasswordChangeResponse, error) {
+	if req.Role != nil {
+		if err := entities.ValidateUserRole(*req.Role); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.UserIDs) > maxPasswordChangeUsers {
+		return nil, errors.NewValidationError("too many users",
+			fmt.Sprintf("at most %d users can be listed, select them by role instead", maxPasswordChangeUsers))
+	}
+
+	userIDs, err := uc.userRepo.RequirePasswordChange(ctx, tenantID, req.UserIDs, req.Role, time.Now())
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to require password change")
+		return nil, errors.NewInternalError("failed to require password change", err)
+	}
+
+	for _, userID := range userIDs {
+		uc.audit.Log(ctx, ports.AuditEvent{
+			ID:         uuid.New(),
+			UserID:     adminID,
+			Action:     "require_password_change",
+			Resource:   "user",
+			ResourceID: userID.String(),
+			NewValue: map[string]interface{}{
+				"must_change_password": true,
+			},
+			Timestamp: time.Now(),
+			Success:   true,
+		})
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"admin_id": adminID,
+		"users":    len(userIDs),
+	}).Info("Password change required")
+
+	return &RequirePasswordChangeResponse{
+		UserIDs: userIDs,
+		Count:   len(userIDs),
+	}, nil
+}
+
+// checkUserAvailable refuses a username or email another user already has
+func (uc *UserUseCase) checkUserAvailable(ctx context.Context, username, email string) error {
+	exists, err := uc.userRepo.ExistsByUsername(ctx, username)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to check username existence")
+		return errors.NewInternalError("failed to check username", err)
+	}
+	if exists {
+		return errors.NewConflictError("username already exists")
+	}
+
+	exists, err = uc.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to check email existence")
+		return errors.NewInternalError("failed to check email", err)
+	}
+	if exists {
+		return errors.NewConflictError("email already exists")
+	}
+
+	return nil
+}
+
+// recordPasswordHistory remembers the user's current password so it is not reused, forgetting
+// passwords beyond the policy's history size
+func (uc *UserUseCase) recordPasswordHistory(ctx context.Context, user *entities.User) {
+	if uc.passwordPolicy.HistorySize <= 0 {
+		return
+	}
+
+	if err := uc.passwordHistoryRepo.Create(ctx, entities.NewPasswordHistoryEntry(user)); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to record password history")
+		return
+	}
+
+	if err := uc.passwordHistoryRepo.PruneByUser(ctx, user.ID, uc.passwordPolicy.HistorySize); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		}).Warn("Failed to prune password history")
+	}
+}
+
 // changeUserStatus is a helper method to change user status
 func (uc *UserUseCase) changeUserStatus(ctx context.Context, adminID, userID uuid.UUID, status entities.UserStatus, action string) error {
 	// Get user
@@ -421,15 +564,17 @@ func (uc *UserUseCase) changeUserStatus(ctx context.Context, adminID, userID uui
 // toUserResponse converts user entity to response
 func (uc *UserUseCase) toUserResponse(user *entities.User) *UserResponse {
 	return &UserResponse{
-		ID:          user.ID,
-		Username:    user.Username,
-		Email:       user.Email,
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		Role:        user.Role,
-		Status:      user.Status,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
-		LastLoginAt: user.LastLoginAt,
+		ID:                 user.ID,
+		Username:           user.Username,
+		Email:              user.Email,
+		FirstName:          user.FirstName,
+		LastName:           user.LastName,
+		Role:               user.Role,
+		Status:             user.Status,
+		PasswordChangedAt:  user.PasswordChangedAt,
+		MustChangePassword: user.MustChangePassword,
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+		LastLoginAt:        user.LastLoginAt,
 	}
 }
@@ -0,0 +1,593 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/parquet"
+)
+
+const (
+	// warehouseExportFormatVersion identifies the file layout for consumers of the manifest
+	warehouseExportFormatVersion = "1"
+	// warehouseExportMaxWindowsPerRun bounds how many days a tenant catches up per scheduler tick
+	warehouseExportMaxWindowsPerRun = 7
+	// warehouseExportLinkExpiry is how long the signed file links in the manifest stay valid
+	warehouseExportLinkExpiry = 1 * time.Hour
+)
+
+// warehouseDatasetSpec describes how a dataset is laid out in the warehouse
+type warehouseDatasetSpec struct {
+	Description string
+	PrimaryKey  []string
+	Dedupe      string
+	Columns     []parquet.Column
+}
+
+// warehouseDatasetSpecs holds the schema of every exported dataset
+var warehouseDatasetSpecs = map[entities.WarehouseDataset]warehouseDatasetSpec{
+	entities.WarehouseDatasetSales: {
+		Description: "Sales created, updated or deleted during the export window",
+		PrimaryKey:  []string{"sale_id"},
+		Dedupe:      "A sale is re-exported whenever it changes; keep the row with the latest updated_at per sale_id",
+		Columns: []parquet.Column{
+			{Name: "sale_id", Type: parquet.String},
+			{Name: "sale_number", Type: parquet.String},
+			{Name: "status", Type: parquet.String},
+			{Name: "channel", Type: parquet.String},
+			{Name: "customer_name", Type: parquet.String},
+			{Name: "payment_method", Type: parquet.String},
+			{Name: "subtotal", Type: parquet.Decimal},
+			{Name: "discount_amount", Type: parquet.Decimal},
+			{Name: "tax_amount", Type: parquet.Decimal},
+			{Name: "surcharge_amount", Type: parquet.Decimal},
+			{Name: "total_amount", Type: parquet.Decimal},
+			{Name: "currency", Type: parquet.String},
+			{Name: "exchange_rate", Type: parquet.Decimal},
+			{Name: "created_by", Type: parquet.String},
+			{Name: "created_at", Type: parquet.Timestamp},
+			{Name: "updated_at", Type: parquet.Timestamp},
+			{Name: "completed_at", Type: parquet.Timestamp},
+			{Name: "deleted_at", Type: parquet.Timestamp},
+		},
+	},
+	entities.WarehouseDatasetSaleItems: {
+		Description: "Items of the sales exported in the same window",
+		PrimaryKey:  []string{"sale_item_id"},
+		Dedupe:      "Items are re-exported with their sale; keep the rows with the latest sale_updated_at per sale_id",
+		Columns: []parquet.Column{
+			{Name: "sale_item_id", Type: parquet.String},
+			{Name: "sale_id", Type: parquet.String},
+			{Name: "sale_number", Type: parquet.String},
+			{Name: "product_id", Type: parquet.String},
+			{Name: "product_sku", Type: parquet.String},
+			{Name: "product_name", Type: parquet.String},
+			{Name: "quantity", Type: parquet.Int64},
+			{Name: "list_price", Type: parquet.Decimal},
+			{Name: "unit_price", Type: parquet.Decimal},
+			{Name: "unit_cost", Type: parquet.Decimal},
+			{Name: "total_price", Type: parquet.Decimal},
+			{Name: "price_source", Type: parquet.String},
+			{Name: "sale_updated_at", Type: parquet.Timestamp},
+		},
+	},
+	entities.WarehouseDatasetPayments: {
+		Description: "Sale payments and refunds; refund amounts are negative",
+		PrimaryKey:  []string{"payment_id", "kind"},
+		Dedupe:      "Append-only; each payment and refund is exported once",
+		Columns: []parquet.Column{
+			{Name: "payment_id", Type: parquet.String},
+			{Name: "kind", Type: parquet.String},
+			{Name: "sale_id", Type: parquet.String},
+			{Name: "sale_number", Type: parquet.String},
+			{Name: "reference", Type: parquet.String},
+			{Name: "method", Type: parquet.String},
+			{Name: "amount", Type: parquet.Decimal},
+			{Name: "tendered", Type: parquet.Decimal},
+			{Name: "change", Type: parquet.Decimal},
+			{Name: "surcharge", Type: parquet.Decimal},
+			{Name: "occurred_at", Type: parquet.Timestamp},
+		},
+	},
+	entities.WarehouseDatasetStockMovements: {
+		Description: "Stock movements recorded during the export window",
+		PrimaryKey:  []string{"movement_id"},
+		Dedupe:      "Append-only; each movement is exported once",
+		Columns: []parquet.Column{
+			{Name: "movement_id", Type: parquet.String},
+			{Name: "product_id", Type: parquet.String},
+			{Name: "product_sku", Type: parquet.String},
+			{Name: "product_name", Type: parquet.String},
+			{Name: "type", Type: parquet.String},
+			{Name: "reason", Type: parquet.String},
+			{Name: "quantity", Type: parquet.Int64},
+			{Name: "reference", Type: parquet.String},
+			{Name: "notes", Type: parquet.String},
+			{Name: "created_by", Type: parquet.String},
+			{Name: "created_at", Type: parquet.Timestamp},
+		},
+	},
+	entities.WarehouseDatasetCostAdjustments: {
+		Description: "Inventory write-downs recorded during the export window; amounts are the change in inventory value",
+		PrimaryKey:  []string{"adjustment_id"},
+		Dedupe:      "Append-only; each adjustment is exported once",
+		Columns: []parquet.Column{
+			{Name: "adjustment_id", Type: parquet.String},
+			{Name: "product_id", Type: parquet.String},
+			{Name: "product_sku", Type: parquet.String},
+			{Name: "product_name", Type: parquet.String},
+			{Name: "reason", Type: parquet.String},
+			{Name: "quantity", Type: parquet.Int64},
+			{Name: "on_hand_qty", Type: parquet.Int64},
+			{Name: "previous_unit_cost", Type: parquet.Decimal},
+			{Name: "adjusted_unit_cost", Type: parquet.Decimal},
+			{Name: "resulting_unit_cost", Type: parquet.Decimal},
+			{Name: "amount", Type: parquet.Decimal},
+			{Name: "notes", Type: parquet.String},
+			{Name: "created_by", Type: parquet.String},
+			{Name: "created_at", Type: parquet.Timestamp},
+		},
+	},
+}
+
+// WarehouseExportUseCase handles the scheduled Parquet export of tenant sales and stock data
+// to S3-compatible storage for querying with Athena, BigQuery and similar engines
+type WarehouseExportUseCase struct {
+	exportRepo  repositories.WarehouseExportRepository
+	sourceRepo  repositories.WarehouseSourceRepository
+	tenantRepo  repositories.TenantRepository
+	fileStorage ports.FileStoragePort
+	audit       ports.AuditPort
+	logger      logger.Logger
+}
+
+// NewWarehouseExportUseCase creates a new warehouse export use case
+func NewWarehouseExportUseCase(
+	exportRepo repositories.WarehouseExportRepository,
+	sourceRepo repositories.WarehouseSourceRepository,
+	tenantRepo repositories.TenantRepository,
+	fileStorage ports.FileStoragePort,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *WarehouseExportUseCase {
+	return &WarehouseExportUseCase{
+		exportRepo:  exportRepo,
+		sourceRepo:  sourceRepo,
+		tenantRepo:  tenantRepo,
+		fileStorage: fileStorage,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// UpdateWarehouseExportSettingsRequest represents update warehouse export settings request
+type UpdateWarehouseExportSettingsRequest struct {
+	IsEnabled bool `json:"is_enabled"`
+}
+
+// WarehouseManifestRequest represents warehouse manifest request
+type WarehouseManifestRequest struct {
+	Dataset  *entities.WarehouseDataset
+	DateFrom *time.Time
+	DateTo   *time.Time
+}
+
+// WarehouseManifestResponse describes the files and schemas in a tenant's warehouse prefix
+type WarehouseManifestResponse struct {
+	FormatVersion   string                     `json:"format_version"`
+	TenantID        uuid.UUID                  `json:"tenant_id"`
+	Prefix          string                     `json:"prefix"`
+	IsEnabled       bool                       `json:"is_enabled"`
+	ExportedThrough *time.Time                 `json:"exported_through,omitempty"`
+	LinksExpireAt   time.Time                  `json:"links_expire_at"`
+	Datasets        []WarehouseDatasetManifest `json:"datasets"`
+	GeneratedAt     time.Time                  `json:"generated_at"`
+}
+
+// WarehouseDatasetManifest describes a dataset's schema and files
+type WarehouseDatasetManifest struct {
+	Name         entities.WarehouseDataset `json:"name"`
+	Description  string                    `json:"description"`
+	Location     string                    `json:"location"`
+	PartitionKey string                    `json:"partition_key"`
+	PrimaryKey   []string                  `json:"primary_key"`
+	Dedupe       string                    `json:"dedupe"`
+	Columns      []WarehouseColumn         `json:"columns"`
+	RowCount     int                       `json:"row_count"`
+	Files        []WarehouseManifestFile   `json:"files"`
+}
+
+// WarehouseColumn describes a column of a dataset
+type WarehouseColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// WarehouseManifestFile describes an exported Parquet file
+type WarehouseManifestFile struct {
+	Key         string    `json:"key"`
+	URL         string    `json:"url"`
+	Date        string    `json:"date"`
+	RowCount    int       `json:"row_count"`
+	SizeBytes   int64     `json:"size_bytes"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+}
+
+// GetSettings returns the tenant's warehouse export settings, or the disabled defaults if none were saved
+func (uc *WarehouseExportUseCase) GetSettings(ctx context.Context, tenantID uuid.UUID) (*entities.WarehouseExportSetting, error) {
+	setting, err := uc.exportRepo.GetSettingByTenant(ctx, tenantID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return entities.NewWarehouseExportSetting(tenantID, uuid.Nil)
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get warehouse export settings")
+		return nil, errors.NewInternalError("failed to get warehouse export settings", err)
+	}
+
+	return setting, nil
+}
+
+// UpdateSettings opts the tenant in or out of the scheduled warehouse export
+func (uc *WarehouseExportUseCase) UpdateSettings(ctx context.Context, tenantID, userID uuid.UUID, req UpdateWarehouseExportSettingsRequest) (*entities.WarehouseExportSetting, error) {
+	setting, err := uc.GetSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasEnabled := setting.IsEnabled
+	setting.Configure(req.IsEnabled, userID)
+
+	if err := uc.exportRepo.SaveSetting(ctx, setting); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to save warehouse export settings")
+		return nil, errors.NewInternalError("failed to save warehouse export settings", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "warehouse_export_setting",
+		ResourceID: setting.ID.String(),
+		OldValue:   map[string]interface{}{"is_enabled": wasEnabled},
+		NewValue:   map[string]interface{}{"is_enabled": setting.IsEnabled},
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":  tenantID,
+		"is_enabled": setting.IsEnabled,
+		"user_id":    userID,
+	}).Info("Warehouse export settings updated")
+
+	return setting, nil
+}
+
+// GetManifest lists the tenant's exported files with their schemas and short-lived signed links
+func (uc *WarehouseExportUseCase) GetManifest(ctx context.Context, tenantID uuid.UUID, req WarehouseManifestRequest) (*WarehouseManifestResponse, error) {
+	if req.Dataset != nil {
+		if err := entities.ValidateWarehouseDataset(*req.Dataset); err != nil {
+			return nil, err
+		}
+	}
+	if req.DateFrom != nil && req.DateTo != nil && req.DateTo.Before(*req.DateFrom) {
+		return nil, errors.NewValidationError("invalid date range", "to date must not be before from date")
+	}
+
+	setting, err := uc.GetSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := uc.exportRepo.ListFiles(ctx, tenantID, repositories.WarehouseExportFileFilter{
+		Dataset:  req.Dataset,
+		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+	})
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list warehouse export files")
+		return nil, errors.NewInternalError("failed to list warehouse export files", err)
+	}
+
+	now := time.Now()
+	manifest := &WarehouseManifestResponse{
+		FormatVersion:   warehouseExportFormatVersion,
+		TenantID:        tenantID,
+		Prefix:          entities.WarehousePrefix(tenantID),
+		IsEnabled:       setting.IsEnabled,
+		ExportedThrough: setting.ExportedThrough,
+		LinksExpireAt:   now.Add(warehouseExportLinkExpiry),
+		Datasets:        []WarehouseDatasetManifest{},
+		GeneratedAt:     now,
+	}
+
+	byDataset := make(map[entities.WarehouseDataset]*WarehouseDatasetManifest)
+	for _, dataset := range entities.WarehouseDatasets() {
+		if req.Dataset != nil && *req.Dataset != dataset {
+			continue
+		}
+		spec := warehouseDatasetSpecs[dataset]
+		columns := make([]WarehouseColumn, len(spec.Columns))
+		for i, column := range spec.Columns {
+			columns[i] = WarehouseColumn{Name: column.Name, Type: warehouseColumnType(column.Type)}
+		}
+		manifest.Datasets = append(manifest.Datasets, WarehouseDatasetManifest{
+			Name:         dataset,
+			Description:  spec.Description,
+			Location:     fmt.Sprintf("%s/%s/", manifest.Prefix, dataset),
+			PartitionKey: "dt",
+			PrimaryKey:   spec.PrimaryKey,
+			Dedupe:       spec.Dedupe,
+			Columns:      columns,
+			Files:        []WarehouseManifestFile{},
+		})
+	}
+	for i := range manifest.Datasets {
+		byDataset[manifest.Datasets[i].Name] = &manifest.Datasets[i]
+	}
+
+	for _, file := range files {
+		datasetManifest, ok := byDataset[file.Dataset]
+		if !ok {
+			continue
+		}
+
+		url, err := uc.fileStorage.GetSignedURL(ctx, file.ObjectKey, warehouseExportLinkExpiry)
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"object_key": file.ObjectKey,
+				"error":      err.Error(),
+			}).Error("Failed to sign warehouse export link")
+			return nil, errors.NewInternalError("failed to create download link", err)
+		}
+
+		datasetManifest.RowCount += file.RowCount
+		datasetManifest.Files = append(datasetManifest.Files, WarehouseManifestFile{
+			Key:         file.ObjectKey,
+			URL:         url,
+			Date:        file.PartitionDate.Format("2006-01-02"),
+			RowCount:    file.RowCount,
+			SizeBytes:   file.SizeBytes,
+			WindowStart: file.WindowStart,
+			WindowEnd:   file.WindowEnd,
+		})
+	}
+
+	return manifest, nil
+}
+
+// ExportDue exports every enabled tenant's changes since its watermark, up to a few days per
+// tenant per run so a long backlog is caught up over several runs. It is run periodically by the scheduler.
+func (uc *WarehouseExportUseCase) ExportDue(ctx context.Context) error {
+	settings, err := uc.exportRepo.ListEnabledSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list warehouse export settings: %w", err)
+	}
+
+	exported := 0
+	for _, setting := range settings {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		count, err := uc.exportTenant(ctx, setting)
+		exported += count
+
+		lastError := ""
+		if err != nil {
+			lastError = err.Error()
+			uc.logger.WithFields(map[string]interface{}{
+				"tenant_id": setting.TenantID,
+				"error":     err.Error(),
+			}).Error("Failed to export warehouse data")
+		}
+		if count > 0 || err != nil {
+			if recordErr := uc.exportRepo.RecordRun(ctx, setting.ID, time.Now(), lastError); recordErr != nil {
+				uc.logger.WithFields(map[string]interface{}{
+					"tenant_id": setting.TenantID,
+					"error":     recordErr.Error(),
+				}).Error("Failed to record warehouse export run")
+			}
+		}
+	}
+
+	if exported > 0 {
+		uc.logger.WithField("count", exported).Info("Warehouse export windows exported")
+	}
+
+	return nil
+}
+
+// exportTenant exports the pending windows of a tenant and returns how many were exported
+func (uc *WarehouseExportUseCase) exportTenant(ctx context.Context, setting *entities.WarehouseExportSetting) (int, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, setting.TenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	for exported < warehouseExportMaxWindowsPerRun {
+		if ctx.Err() != nil {
+			return exported, ctx.Err()
+		}
+
+		start, end, due := setting.NextWindow(time.Now(), tenant.CreatedAt)
+		if !due {
+			return exported, nil
+		}
+
+		done, err := uc.exportWindow(ctx, setting, start, end)
+		if err != nil {
+			return exported, err
+		}
+		if !done {
+			// Another instance holds this tenant's watermark
+			return exported, nil
+		}
+
+		setting.ExportedThrough = &end
+		exported++
+	}
+
+	return exported, nil
+}
+
+// exportWindow claims, extracts and uploads a single window. The claim keeps several server
+// instances from exporting the same window; it is released if any dataset fails.
+func (uc *WarehouseExportUseCase) exportWindow(ctx context.Context, setting *entities.WarehouseExportSetting, start, end time.Time) (bool, error) {
+	claimed, err := uc.exportRepo.ClaimWindow(ctx, setting.ID, setting.ExportedThrough, end)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	for _, dataset := range entities.WarehouseDatasets() {
+		if err := uc.exportDataset(ctx, setting.TenantID, dataset, start, end); err != nil {
+			if releaseErr := uc.exportRepo.ReleaseWindow(ctx, setting.ID, end, setting.ExportedThrough); releaseErr != nil {
+				uc.logger.WithFields(map[string]interface{}{
+					"tenant_id": setting.TenantID,
+					"error":     releaseErr.Error(),
+				}).Error("Failed to release warehouse export window")
+			}
+			return false, fmt.Errorf("failed to export %s for %s to %s: %w",
+				dataset, start.Format(time.RFC3339), end.Format(time.RFC3339), err)
+		}
+	}
+
+	return true, nil
+}
+
+// exportDataset writes one dataset's rows for a window as a Parquet file. Empty windows
+// produce no file.
+func (uc *WarehouseExportUseCase) exportDataset(ctx context.Context, tenantID uuid.UUID, dataset entities.WarehouseDataset, start, end time.Time) error {
+	writer, err := uc.extract(ctx, tenantID, dataset, start, end)
+	if err != nil {
+		return err
+	}
+	if writer.Rows() == 0 {
+		return nil
+	}
+
+	data := writer.Bytes()
+	file, err := entities.NewWarehouseExportFile(tenantID, dataset, start, end, writer.Rows(), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.fileStorage.Store(ctx, file.ObjectKey, data); err != nil {
+		return err
+	}
+
+	return uc.exportRepo.SaveFile(ctx, file)
+}
+
+// extract reads a dataset's rows for a window into a Parquet writer
+func (uc *WarehouseExportUseCase) extract(ctx context.Context, tenantID uuid.UUID, dataset entities.WarehouseDataset, start, end time.Time) (*parquet.Writer, error) {
+	writer := parquet.NewWriter(warehouseDatasetSpecs[dataset].Columns)
+
+	switch dataset {
+	case entities.WarehouseDatasetSales:
+		sales, err := uc.sourceRepo.GetSales(ctx, tenantID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, sale := range sales {
+			err := writer.Write(sale.ID, sale.SaleNumber, sale.Status, sale.Channel, sale.CustomerName, sale.PaymentMethod,
+				sale.Subtotal, sale.DiscountAmount, sale.TaxAmount, sale.SurchargeAmount, sale.TotalAmount,
+				sale.Currency, sale.ExchangeRate, sale.CreatedBy, sale.CreatedAt, sale.UpdatedAt, sale.CompletedAt, sale.DeletedAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case entities.WarehouseDatasetSaleItems:
+		items, err := uc.sourceRepo.GetSaleItems(ctx, tenantID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			err := writer.Write(item.ID, item.SaleID, item.SaleNumber, item.ProductID, item.ProductSKU, item.ProductName,
+				item.Quantity, item.ListPrice, item.UnitPrice, item.UnitCost, item.TotalPrice, item.PriceSource,
+				item.SaleUpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case entities.WarehouseDatasetPayments:
+		payments, err := uc.sourceRepo.GetPayments(ctx, tenantID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, payment := range payments {
+			err := writer.Write(payment.ID, payment.Kind, payment.SaleID, payment.SaleNumber, payment.Reference,
+				payment.Method, payment.Amount, payment.Tendered, payment.Change, payment.Surcharge, payment.OccurredAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case entities.WarehouseDatasetStockMovements:
+		movements, err := uc.sourceRepo.GetStockMovements(ctx, tenantID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, movement := range movements {
+			err := writer.Write(movement.ID, movement.ProductID, movement.ProductSKU, movement.ProductName,
+				movement.Type, movement.Reason, movement.Quantity, movement.Reference, movement.Notes,
+				movement.CreatedBy, movement.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case entities.WarehouseDatasetCostAdjustments:
+		adjustments, err := uc.sourceRepo.GetCostAdjustments(ctx, tenantID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, adjustment := range adjustments {
+			err := writer.Write(adjustment.ID, adjustment.ProductID, adjustment.ProductSKU, adjustment.ProductName,
+				adjustment.Reason, adjustment.Quantity, adjustment.OnHandQty, adjustment.PreviousUnitCost,
+				adjustment.AdjustedUnitCost, adjustment.ResultingUnitCost, adjustment.Amount, adjustment.Notes,
+				adjustment.CreatedBy, adjustment.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, entities.ValidateWarehouseDataset(dataset)
+	}
+
+	return writer, nil
+}
+
+// warehouseColumnType returns the SQL type name of a Parquet column for the manifest
+func warehouseColumnType(t parquet.Type) string {
+	switch t {
+	case parquet.Int64:
+		return "bigint"
+	case parquet.Double:
+		return "double"
+	case parquet.Boolean:
+		return "boolean"
+	case parquet.Timestamp:
+		return "timestamp"
+	case parquet.Decimal:
+		return fmt.Sprintf("decimal(18,%d)", parquet.DecimalScale)
+	default:
+		return "string"
+	}
+}
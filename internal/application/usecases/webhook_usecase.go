@@ -0,0 +1,772 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const (
+	// maxWebhookEndpoints caps how many webhook endpoints a tenant can register
+	maxWebhookEndpoints = 10
+
+	// webhookDeliveryBatchSize caps how many deliveries are claimed at once by the delivery job
+	webhookDeliveryBatchSize = 50
+
+	// webhookDeliveryLease is how long a claimed delivery is hidden from other servers while it is sent
+	webhookDeliveryLease = 5 * time.Minute
+
+	// maxWebhookReplayDays caps the period a single replay can cover
+	maxWebhookReplayDays = 31
+
+	// maxWebhookReplayDeliveries caps how many deliveries a single replay queues
+	maxWebhookReplayDeliveries = 1000
+)
+
+// WebhookUseCase handles webhook endpoints and the delivery of signed events to them.
+// Events are persisted as one delivery per subscribed endpoint and sent by a background
+// job, so publishing never slows down or fails the operation that raised the event.
+type WebhookUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	sender      ports.WebhookPort
+	delivery    *DeliveryPolicyUseCase
+	audit       ports.AuditPort
+	logger      logger.Logger
+}
+
+// NewWebhookUseCase creates a new webhook use case. Deliveries are retried under the
+// tenant's webhook retry policy.
+func NewWebhookUseCase(
+	webhookRepo repositories.WebhookRepository,
+	sender ports.WebhookPort,
+	delivery *DeliveryPolicyUseCase,
+	audit ports.AuditPort,
+	logger logger.Logger,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		delivery:    delivery,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// CreateWebhookEndpointRequest represents create webhook endpoint request
+type CreateWebhookEndpointRequest struct {
+	URL         string                      `json:"url" validate:"required,url"`
+	Events      []entities.WebhookEventType `json:"events" validate:"required,min=1"`
+	Description string                      `json:"description,omitempty"`
+}
+
+// UpdateWebhookEndpointRequest represents update webhook endpoint request
+type UpdateWebhookEndpointRequest struct {
+	URL         string                      `json:"url" validate:"required,url"`
+	Events      []entities.WebhookEventType `json:"events" validate:"required,min=1"`
+	Description string                      `json:"description,omitempty"`
+	IsActive    bool                        `json:"is_active"`
+}
+
+// WebhookEndpointResponse represents webhook endpoint response. The secret is only returned
+// when the endpoint is created and when its secret is rotated.
+type WebhookEndpointResponse struct {
+	*entities.WebhookEndpoint
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookDeliveryListResponse represents webhook delivery list response
+type WebhookDeliveryListResponse struct {
+	Deliveries []*entities.WebhookDelivery `json:"deliveries"`
+	Pagination utils.PaginationInfo        `json:"pagination"`
+}
+
+// ReplayWebhookEventsRequest represents a request to replay an endpoint's events of a period,
+// e.g. after the consumer was down. Only failed deliveries are replayed unless
+// IncludeDelivered is set.
+type ReplayWebhookEventsRequest struct {
+	FromDate         time.Time `json:"from_date" validate:"required"`
+	ToDate           time.Time `json:"to_date" validate:"required"`
+	IncludeDelivered bool      `json:"include_delivered,omitempty"`
+}
+
+// WebhookReplayResponse represents the outcome of a replay
+type WebhookReplayResponse struct {
+	Queued    int  `json:"queued"`
+	Skipped   int  `json:"skipped"`   // Deliveries that already had a replay pending
+	Truncated bool `json:"truncated"` // More deliveries matched than a single replay queues
+}
+
+// WebhookDeliveryDetail represents a delivery together with the exact request body and
+// headers it is sent with, and its replays
+type WebhookDeliveryDetail struct {
+	*entities.WebhookDelivery
+	Body           string                      `json:"body"` // Serialized payload, byte for byte as signed
+	Headers        map[string]string           `json:"headers"`
+	IdempotencyKey uuid.UUID                   `json:"idempotency_key"`
+	Replays        []*entities.WebhookDelivery `json:"replays"`
+}
+
+// StockLowEvent is the data of a stock.low webhook event
+type StockLowEvent struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductSKU   string    `json:"product_sku"`
+	ProductName  string    `json:"product_name"`
+	AvailableQty int       `json:"available_qty"`
+	ReservedQty  int       `json:"reserved_qty"`
+	ReorderLevel int       `json:"reorder_level"`
+	StockStatus  string    `json:"stock_status"`
+}
+
+// NewStockLowEvent builds the data of a stock.low event
+func NewStockLowEvent(stock *entities.Stock, product *entities.Product) StockLowEvent {
+	return StockLowEvent{
+		ProductID:    product.ID,
+		ProductSKU:   product.SKU,
+		ProductName:  product.Name,
+		AvailableQty: stock.AvailableQty,
+		ReservedQty:  stock.ReservedQty,
+		ReorderLevel: stock.ReorderLevel,
+		StockStatus:  stock.GetStockStatus(),
+	}
+}
+
+// ListEndpoints returns the webhook endpoints of a tenant
+func (uc *WebhookUseCase) ListEndpoints(ctx context.Context, tenantID uuid.UUID) ([]*entities.WebhookEndpoint, error) {
+	endpoints, err := uc.webhookRepo.GetEndpointsByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list webhook endpoints")
+		return nil, errors.NewInternalError("failed to list webhook endpoints", err)
+	}
+	if endpoints == nil {
+		endpoints = []*entities.WebhookEndpoint{}
+	}
+
+	return endpoints, nil
+}
+
+// CreateEndpoint registers a webhook endpoint and returns its signing secret
+func (uc *WebhookUseCase) CreateEndpoint(ctx context.Context, tenantID, userID uuid.UUID, req CreateWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	existing, err := uc.webhookRepo.GetEndpointsByTenant(ctx, tenantID)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list webhook endpoints")
+		return nil, errors.NewInternalError("failed to list webhook endpoints", err)
+	}
+	if len(existing) >= maxWebhookEndpoints {
+		return nil, errors.NewConflictError(fmt.Sprintf("a tenant can register at most %d webhook endpoints", maxWebhookEndpoints))
+	}
+
+	endpoint, err := entities.NewWebhookEndpoint(tenantID, req.URL, req.Events, req.Description, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"error":     err.Error(),
+		}).Error("Failed to create webhook endpoint")
+		return nil, errors.NewInternalError("failed to create webhook endpoint", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "create",
+		Resource:   "webhook_endpoint",
+		ResourceID: endpoint.ID.String(),
+		NewValue: map[string]interface{}{
+			"url":    endpoint.URL,
+			"events": endpoint.Events,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":   tenantID,
+		"endpoint_id": endpoint.ID,
+		"user_id":     userID,
+	}).Info("Webhook endpoint created")
+
+	return &WebhookEndpointResponse{WebhookEndpoint: endpoint, Secret: endpoint.Secret}, nil
+}
+
+// UpdateEndpoint changes a webhook endpoint's URL, events and active flag
+func (uc *WebhookUseCase) UpdateEndpoint(ctx context.Context, tenantID, userID, endpointID uuid.UUID, req UpdateWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	endpoint, err := uc.getEndpoint(ctx, tenantID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue := map[string]interface{}{
+		"url":       endpoint.URL,
+		"events":    endpoint.Events,
+		"is_active": endpoint.IsActive,
+	}
+
+	if err := endpoint.Update(req.URL, req.Events, req.Description, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := uc.saveEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "update",
+		Resource:   "webhook_endpoint",
+		ResourceID: endpoint.ID.String(),
+		OldValue:   oldValue,
+		NewValue: map[string]interface{}{
+			"url":       endpoint.URL,
+			"events":    endpoint.Events,
+			"is_active": endpoint.IsActive,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return &WebhookEndpointResponse{WebhookEndpoint: endpoint}, nil
+}
+
+// RotateEndpointSecret replaces a webhook endpoint's signing secret and returns the new one
+func (uc *WebhookUseCase) RotateEndpointSecret(ctx context.Context, tenantID, userID, endpointID uuid.UUID) (*WebhookEndpointResponse, error) {
+	endpoint, err := uc.getEndpoint(ctx, tenantID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := endpoint.RotateSecret(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.saveEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "rotate_secret",
+		Resource:   "webhook_endpoint",
+		ResourceID: endpoint.ID.String(),
+		Timestamp:  time.Now(),
+		Success:    true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return &WebhookEndpointResponse{WebhookEndpoint: endpoint, Secret: endpoint.Secret}, nil
+}
+
+// DeleteEndpoint removes a webhook endpoint together with its delivery history
+func (uc *WebhookUseCase) DeleteEndpoint(ctx context.Context, tenantID, userID, endpointID uuid.UUID) error {
+	endpoint, err := uc.getEndpoint(ctx, tenantID, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.DeleteEndpoint(ctx, endpoint.ID); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"endpoint_id": endpointID,
+			"error":       err.Error(),
+		}).Error("Failed to delete webhook endpoint")
+		return errors.NewInternalError("failed to delete webhook endpoint", err)
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "delete",
+		Resource:   "webhook_endpoint",
+		ResourceID: endpoint.ID.String(),
+		OldValue: map[string]interface{}{
+			"url":    endpoint.URL,
+			"events": endpoint.Events,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return nil
+}
+
+// ListDeliveries returns the webhook deliveries of the current tenant, newest first
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, filter repositories.WebhookDeliveryFilter, pagination utils.PaginationInfo) (*WebhookDeliveryListResponse, error) {
+	deliveries, paginationResult, err := uc.webhookRepo.ListDeliveries(ctx, filter, pagination)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to list webhook deliveries")
+		return nil, errors.NewInternalError("failed to list webhook deliveries", err)
+	}
+	if deliveries == nil {
+		deliveries = []*entities.WebhookDelivery{}
+	}
+
+	return &WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Pagination: paginationResult,
+	}, nil
+}
+
+// GetDelivery returns a delivery with the serialized payload and headers that were sent. The
+// signature header is left out; it is computed from the secret and the time of each attempt.
+func (uc *WebhookUseCase) GetDelivery(ctx context.Context, tenantID, deliveryID uuid.UUID) (*WebhookDeliveryDetail, error) {
+	delivery, err := uc.getDelivery(ctx, tenantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	replays, err := uc.webhookRepo.GetReplays(ctx, delivery.IdempotencyKey())
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get webhook replays")
+		return nil, errors.NewInternalError("failed to get webhook replays", err)
+	}
+	if replays == nil {
+		replays = []*entities.WebhookDelivery{}
+	}
+
+	headers := webhookHeaders(delivery)
+	headers["Content-Type"] = "application/json"
+
+	return &WebhookDeliveryDetail{
+		WebhookDelivery: delivery,
+		Body:            string(delivery.Payload),
+		Headers:         headers,
+		IdempotencyKey:  delivery.IdempotencyKey(),
+		Replays:         replays,
+	}, nil
+}
+
+// RedeliverDelivery queues the payload of a delivery to be sent to its endpoint again. The
+// replay carries the original delivery's idempotency key so a consumer that already
+// processed the event can discard it.
+func (uc *WebhookUseCase) RedeliverDelivery(ctx context.Context, tenantID, userID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := uc.getDelivery(ctx, tenantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := uc.getEndpoint(ctx, tenantID, delivery.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.IsActive {
+		return nil, errors.NewConflictError("webhook endpoint is disabled")
+	}
+
+	replay, err := entities.NewWebhookReplay(delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.webhookRepo.CreateReplay(ctx, replay)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"delivery_id": deliveryID,
+			"error":       err.Error(),
+		}).Error("Failed to queue webhook replay")
+		return nil, errors.NewInternalError("failed to queue webhook replay", err)
+	}
+	if !created {
+		return nil, errors.NewConflictError("a redelivery of this event is already pending")
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "redeliver",
+		Resource:   "webhook_delivery",
+		ResourceID: delivery.ID.String(),
+		NewValue: map[string]interface{}{
+			"replay_id":   replay.ID,
+			"endpoint_id": replay.EndpointID,
+			"event_id":    replay.EventID,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	return replay, nil
+}
+
+// ReplayEndpoint queues replays of an endpoint's deliveries created in a period, e.g. after
+// the consumer was down. Deliveries with a replay already pending, or already replayed
+// successfully, are not queued again.
+func (uc *WebhookUseCase) ReplayEndpoint(ctx context.Context, tenantID, userID, endpointID uuid.UUID, req ReplayWebhookEventsRequest) (*WebhookReplayResponse, error) {
+	if !req.ToDate.After(req.FromDate) {
+		return nil, errors.NewValidationError("invalid date range", "to_date must be after from_date")
+	}
+	if req.ToDate.Sub(req.FromDate) > maxWebhookReplayDays*24*time.Hour {
+		return nil, errors.NewValidationError("date range too long", fmt.Sprintf("a replay can cover at most %d days", maxWebhookReplayDays))
+	}
+
+	endpoint, err := uc.getEndpoint(ctx, tenantID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.IsActive {
+		return nil, errors.NewConflictError("webhook endpoint is disabled")
+	}
+
+	candidates, err := uc.webhookRepo.GetReplayCandidates(ctx, endpoint.ID, req.FromDate, req.ToDate, req.IncludeDelivered, maxWebhookReplayDeliveries+1)
+	if err != nil {
+		uc.logger.WithField("error", err.Error()).Error("Failed to get webhook deliveries to replay")
+		return nil, errors.NewInternalError("failed to get webhook deliveries to replay", err)
+	}
+
+	response := &WebhookReplayResponse{}
+	if len(candidates) > maxWebhookReplayDeliveries {
+		candidates = candidates[:maxWebhookReplayDeliveries]
+		response.Truncated = true
+	}
+
+	for _, delivery := range candidates {
+		replay, err := entities.NewWebhookReplay(delivery)
+		if err != nil {
+			response.Skipped++
+			continue
+		}
+
+		created, err := uc.webhookRepo.CreateReplay(ctx, replay)
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"endpoint_id": endpointID,
+				"error":       err.Error(),
+			}).Error("Failed to queue webhook replay")
+			return nil, errors.NewInternalError("failed to queue webhook replay", err)
+		}
+		if !created {
+			response.Skipped++
+			continue
+		}
+		response.Queued++
+	}
+
+	// Audit log
+	auditEvent := ports.AuditEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     "replay",
+		Resource:   "webhook_endpoint",
+		ResourceID: endpoint.ID.String(),
+		NewValue: map[string]interface{}{
+			"from_date":         req.FromDate,
+			"to_date":           req.ToDate,
+			"include_delivered": req.IncludeDelivered,
+			"queued":            response.Queued,
+			"skipped":           response.Skipped,
+		},
+		Timestamp: time.Now(),
+		Success:   true,
+	}
+	uc.audit.Log(ctx, auditEvent)
+
+	uc.logger.WithFields(map[string]interface{}{
+		"endpoint_id": endpointID,
+		"queued":      response.Queued,
+		"skipped":     response.Skipped,
+		"user_id":     userID,
+	}).Info("Webhook events replayed")
+
+	return response, nil
+}
+
+// Publish queues an event for every active endpoint of the tenant subscribed to it. Failures
+// are logged rather than returned so the operation that raised the event is never affected.
+func (uc *WebhookUseCase) Publish(ctx context.Context, tenantID uuid.UUID, eventType entities.WebhookEventType, data interface{}) {
+	endpoints, err := uc.webhookRepo.GetActiveEndpointsForEvent(ctx, tenantID, eventType)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":  tenantID,
+			"event_type": eventType,
+			"error":      err.Error(),
+		}).Error("Failed to get webhook endpoints")
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	eventID := uuid.New()
+	payload, err := entities.NewWebhookPayload(eventID, eventType, tenantID, time.Now(), data)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":  tenantID,
+			"event_type": eventType,
+			"error":      err.Error(),
+		}).Error("Failed to build webhook payload")
+		return
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = entities.NewWebhookDelivery(endpoint, eventID, eventType, payload)
+	}
+
+	if err := uc.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":  tenantID,
+			"event_type": eventType,
+			"event_id":   eventID,
+			"error":      err.Error(),
+		}).Error("Failed to queue webhook deliveries")
+	}
+}
+
+// DeliverDue sends pending deliveries whose next attempt is due, across all tenants. Failed
+// sends are rescheduled under the tenant's retry policy, and endpoints that fail too many
+// attempts in a row are paused. It is run periodically by the scheduler.
+func (uc *WebhookUseCase) DeliverDue(ctx context.Context) error {
+	endpoints := make(map[uuid.UUID]*entities.WebhookEndpoint)
+	policies := make(map[uuid.UUID]*entities.DeliveryPolicy)
+	delivered, failed := 0, 0
+
+	for {
+		now := time.Now()
+		deliveries, err := uc.webhookRepo.ClaimDueDeliveries(ctx, now, now.Add(webhookDeliveryLease), webhookDeliveryBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			uc.deliver(ctx, delivery, endpoints, policies)
+			switch delivery.Status {
+			case entities.WebhookDeliveryStatusDelivered:
+				delivered++
+			case entities.WebhookDeliveryStatusFailed:
+				failed++
+			}
+		}
+
+		if len(deliveries) < webhookDeliveryBatchSize {
+			break
+		}
+	}
+
+	if delivered > 0 || failed > 0 {
+		uc.logger.WithFields(map[string]interface{}{
+			"delivered": delivered,
+			"failed":    failed,
+		}).Info("Webhook deliveries sent")
+	}
+
+	return nil
+}
+
+// deliver signs and sends a single delivery and records the outcome. Endpoints and policies
+// are cached for the run since most deliveries in a batch share a few of them.
+func (uc *WebhookUseCase) deliver(ctx context.Context, delivery *entities.WebhookDelivery, endpoints map[uuid.UUID]*entities.WebhookEndpoint, policies map[uuid.UUID]*entities.DeliveryPolicy) {
+	endpoint, ok := endpoints[delivery.EndpointID]
+	if !ok {
+		var err error
+		endpoint, err = uc.webhookRepo.GetEndpointByID(ctx, delivery.EndpointID)
+		if err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"delivery_id": delivery.ID,
+				"endpoint_id": delivery.EndpointID,
+				"error":       err.Error(),
+			}).Error("Failed to get webhook endpoint")
+			return
+		}
+		endpoints[delivery.EndpointID] = endpoint
+	}
+
+	policy, ok := policies[delivery.TenantID]
+	if !ok {
+		var err error
+		policy, err = uc.delivery.GetPolicy(ctx, delivery.TenantID)
+		if err != nil {
+			return
+		}
+		policies[delivery.TenantID] = policy
+	}
+
+	attempted := false
+	switch {
+	case endpoint.IsPaused():
+		delivery.Fail("endpoint was paused after repeated failures")
+	case !endpoint.IsActive:
+		delivery.Fail("endpoint is disabled")
+	default:
+		sentAt := time.Now()
+		headers := webhookHeaders(delivery)
+		headers[entities.WebhookSignatureHeader] = entities.SignWebhookPayload(endpoint.Secret, sentAt, delivery.Payload)
+
+		attemptCtx, cancel := context.WithTimeout(ctx, policy.Webhook.AttemptTimeout())
+		status, sendErr := uc.sender.Post(attemptCtx, ports.WebhookRequest{
+			URL:     endpoint.URL,
+			Headers: headers,
+			Body:    delivery.Payload,
+		})
+		cancel()
+		delivery.RecordAttempt(sentAt, status, sendErr, policy.Webhook)
+		attempted = true
+	}
+
+	if err := uc.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"delivery_id": delivery.ID,
+			"error":       err.Error(),
+		}).Error("Failed to record webhook delivery attempt")
+		return
+	}
+
+	if delivery.Status == entities.WebhookDeliveryStatusFailed {
+		uc.logger.WithFields(map[string]interface{}{
+			"tenant_id":   delivery.TenantID,
+			"delivery_id": delivery.ID,
+			"endpoint_id": delivery.EndpointID,
+			"attempts":    delivery.Attempts,
+			"error":       delivery.LastError,
+		}).Warn("Webhook delivery failed")
+	}
+
+	if attempted {
+		uc.recordEndpointHealth(ctx, endpoint, delivery, policy)
+	}
+}
+
+// recordEndpointHealth tracks an endpoint's consecutive failed attempts and pauses it once
+// they reach the tenant's threshold. The tenant's admins are notified of the pause; its
+// pending deliveries fail and can be replayed after the endpoint is activated again.
+func (uc *WebhookUseCase) recordEndpointHealth(ctx context.Context, endpoint *entities.WebhookEndpoint, delivery *entities.WebhookDelivery, policy *entities.DeliveryPolicy) {
+	if delivery.Status == entities.WebhookDeliveryStatusDelivered {
+		if endpoint.ConsecutiveFailures == 0 {
+			return
+		}
+		if err := uc.webhookRepo.ResetEndpointFailures(ctx, endpoint.ID); err != nil {
+			uc.logger.WithFields(map[string]interface{}{
+				"endpoint_id": endpoint.ID,
+				"error":       err.Error(),
+			}).Error("Failed to reset webhook endpoint failures")
+			return
+		}
+		endpoint.ConsecutiveFailures = 0
+		return
+	}
+
+	failures, err := uc.webhookRepo.RecordEndpointFailure(ctx, endpoint.ID)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"endpoint_id": endpoint.ID,
+			"error":       err.Error(),
+		}).Error("Failed to record webhook endpoint failure")
+		return
+	}
+	endpoint.ConsecutiveFailures = failures
+	if !policy.Webhook.ShouldPause(failures) {
+		return
+	}
+
+	reason := fmt.Sprintf("paused after %d consecutive failed attempts", failures)
+	endpoint.Pause(reason)
+	paused, err := uc.webhookRepo.PauseEndpoint(ctx, endpoint)
+	if err != nil {
+		uc.logger.WithFields(map[string]interface{}{
+			"endpoint_id": endpoint.ID,
+			"error":       err.Error(),
+		}).Error("Failed to pause webhook endpoint")
+		return
+	}
+	if !paused {
+		return
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"tenant_id":   endpoint.TenantID,
+		"endpoint_id": endpoint.ID,
+		"failures":    failures,
+	}).Warn("Webhook endpoint paused after repeated failures")
+
+	uc.delivery.NotifyDestinationPaused(ctx, policy, "webhook endpoint "+endpoint.URL,
+		fmt.Sprintf("%s, the last one with: %s", reason, delivery.LastError),
+		"Pending deliveries to the endpoint have failed. Activate the endpoint again once it is fixed and replay the events it missed.")
+}
+
+// getDelivery retrieves a tenant's webhook delivery, passing not found errors through
+func (uc *WebhookUseCase) getDelivery(ctx context.Context, tenantID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := uc.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, appErr
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get webhook delivery")
+		return nil, errors.NewInternalError("failed to get webhook delivery", err)
+	}
+	if delivery.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("webhook delivery")
+	}
+
+	return delivery, nil
+}
+
+// getEndpoint retrieves a tenant's webhook endpoint, passing not found errors through
+func (uc *WebhookUseCase) getEndpoint(ctx context.Context, tenantID, endpointID uuid.UUID) (*entities.WebhookEndpoint, error) {
+	endpoint, err := uc.webhookRepo.GetEndpointByID(ctx, endpointID)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+			return nil, appErr
+		}
+		uc.logger.WithField("error", err.Error()).Error("Failed to get webhook endpoint")
+		return nil, errors.NewInternalError("failed to get webhook endpoint", err)
+	}
+	if endpoint.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("webhook endpoint")
+	}
+
+	return endpoint, nil
+}
+
+// saveEndpoint persists changes to a webhook endpoint
+func (uc *WebhookUseCase) saveEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	if err := uc.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			return appErr
+		}
+		uc.logger.WithFields(map[string]interface{}{
+			"endpoint_id": endpoint.ID,
+			"error":       err.Error(),
+		}).Error("Failed to update webhook endpoint")
+		return errors.NewInternalError("failed to update webhook endpoint", err)
+	}
+
+	return nil
+}
+
+// webhookHeaders returns the headers a delivery is sent with, apart from its signature
+func webhookHeaders(delivery *entities.WebhookDelivery) map[string]string {
+	headers := map[string]string{
+		entities.WebhookDeliveryHeader: delivery.IdempotencyKey().String(),
+		entities.WebhookEventHeader:    string(delivery.EventType),
+	}
+	if delivery.IsReplay() {
+		headers[entities.WebhookReplayHeader] = "true"
+	}
+	return headers
+}
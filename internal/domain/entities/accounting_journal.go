@@ -0,0 +1,297 @@
+package entities
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// maxAccountCodeLength caps the length of an account code in the chart of accounts
+const maxAccountCodeLength = 50
+
+// AccountingAccounts maps the postings of sales, refunds and their cost of goods to accounts
+// in a tenant's chart of accounts. Payments are posted to one account per payment method.
+type AccountingAccounts struct {
+	SalesRevenue    string `json:"sales_revenue"`
+	SalesReturns    string `json:"sales_returns"`
+	TaxPayable      string `json:"tax_payable"`
+	CostOfGoodsSold string `json:"cost_of_goods_sold"`
+	Inventory       string `json:"inventory"`
+	Cash            string `json:"cash"`
+	Card            string `json:"card"`
+	DigitalWallet   string `json:"digital_wallet"`
+	BankTransfer    string `json:"bank_transfer"`
+	ZeroTaxRate     string `json:"zero_tax_rate"` // Tax rate name put on exported lines, as tax is posted separately
+}
+
+// DefaultAccountingAccounts returns the accounts of a generic chart of accounts
+func DefaultAccountingAccounts() AccountingAccounts {
+	return AccountingAccounts{
+		SalesRevenue:    "4000",
+		SalesReturns:    "4100",
+		TaxPayable:      "2200",
+		CostOfGoodsSold: "5000",
+		Inventory:       "1300",
+		Cash:            "1000",
+		Card:            "1010",
+		DigitalWallet:   "1020",
+		BankTransfer:    "1030",
+		ZeroTaxRate:     "Tax Exempt",
+	}
+}
+
+// PaymentAccount returns the account payments of a method are posted to
+func (a AccountingAccounts) PaymentAccount(method PaymentMethod) string {
+	switch method {
+	case PaymentMethodCard:
+		return a.Card
+	case PaymentMethodDigitalWallet:
+		return a.DigitalWallet
+	case PaymentMethodBankTransfer:
+		return a.BankTransfer
+	default:
+		return a.Cash
+	}
+}
+
+// normalize trims every account and checks it can be written to a journal file. Tabs, line
+// breaks and quotes would corrupt IIF and CSV files.
+func (a AccountingAccounts) normalize() (AccountingAccounts, error) {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"sales_revenue", &a.SalesRevenue},
+		{"sales_returns", &a.SalesReturns},
+		{"tax_payable", &a.TaxPayable},
+		{"cost_of_goods_sold", &a.CostOfGoodsSold},
+		{"inventory", &a.Inventory},
+		{"cash", &a.Cash},
+		{"card", &a.Card},
+		{"digital_wallet", &a.DigitalWallet},
+		{"bank_transfer", &a.BankTransfer},
+		{"zero_tax_rate", &a.ZeroTaxRate},
+	}
+
+	for _, field := range fields {
+		*field.value = strings.TrimSpace(*field.value)
+		if *field.value == "" {
+			return a, errors.NewValidationError("account is required", field.name+" cannot be empty")
+		}
+		if len(*field.value) > maxAccountCodeLength {
+			return a, errors.NewValidationError("account too long", field.name+" cannot exceed 50 characters")
+		}
+		if strings.IndexFunc(*field.value, func(r rune) bool { return unicode.IsControl(r) || r == '"' }) >= 0 {
+			return a, errors.NewValidationError("invalid account", field.name+" cannot contain quotes, tabs or line breaks")
+		}
+	}
+
+	return a, nil
+}
+
+// AccountingAccountMapping represents the accounts a tenant's journal entries are posted to
+type AccountingAccountMapping struct {
+	ID        uuid.UUID          `json:"id"`
+	TenantID  uuid.UUID          `json:"tenant_id"`
+	Accounts  AccountingAccounts `json:"accounts"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	UpdatedBy uuid.UUID          `json:"updated_by"`
+}
+
+// NewAccountingAccountMapping creates an account mapping with the default accounts
+func NewAccountingAccountMapping(tenantID, updatedBy uuid.UUID) (*AccountingAccountMapping, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	return &AccountingAccountMapping{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Accounts:  DefaultAccountingAccounts(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		UpdatedBy: updatedBy,
+	}, nil
+}
+
+// Configure replaces the mapped accounts
+func (m *AccountingAccountMapping) Configure(accounts AccountingAccounts, updatedBy uuid.UUID) error {
+	accounts, err := accounts.normalize()
+	if err != nil {
+		return err
+	}
+
+	m.Accounts = accounts
+	m.UpdatedBy = updatedBy
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// JournalGrouping is how sales and refunds are grouped into journal entries
+type JournalGrouping string
+
+const (
+	// JournalGroupingDay posts one journal entry per day
+	JournalGroupingDay JournalGrouping = "day"
+	// JournalGroupingPeriod posts one journal entry for the whole period
+	JournalGroupingPeriod JournalGrouping = "period"
+)
+
+// IsValid reports whether the grouping is known
+func (g JournalGrouping) IsValid() bool {
+	return g == JournalGroupingDay || g == JournalGroupingPeriod
+}
+
+// AccountingTotals holds the amounts of sales and refunds posted in one journal entry, in the
+// base currency
+type AccountingTotals struct {
+	Payments     map[PaymentMethod]decimal.Decimal `json:"payments"` // Received per payment method
+	Tax          decimal.Decimal                   `json:"tax"`
+	CostOfGoods  decimal.Decimal                   `json:"cost_of_goods"`
+	Refunds      map[PaymentMethod]decimal.Decimal `json:"refunds"` // Paid back per refund method
+	RefundTax    decimal.Decimal                   `json:"refund_tax"`
+	ReturnedCost decimal.Decimal                   `json:"returned_cost"` // Cost of goods returned to stock by refunds
+}
+
+// NewAccountingTotals creates empty totals
+func NewAccountingTotals() *AccountingTotals {
+	return &AccountingTotals{
+		Payments: map[PaymentMethod]decimal.Decimal{},
+		Refunds:  map[PaymentMethod]decimal.Decimal{},
+	}
+}
+
+// Add adds other to the totals
+func (t *AccountingTotals) Add(other *AccountingTotals) {
+	for method, amount := range other.Payments {
+		t.Payments[method] = t.Payments[method].Add(amount)
+	}
+	for method, amount := range other.Refunds {
+		t.Refunds[method] = t.Refunds[method].Add(amount)
+	}
+	t.Tax = t.Tax.Add(other.Tax)
+	t.CostOfGoods = t.CostOfGoods.Add(other.CostOfGoods)
+	t.RefundTax = t.RefundTax.Add(other.RefundTax)
+	t.ReturnedCost = t.ReturnedCost.Add(other.ReturnedCost)
+}
+
+// JournalLine is a debit or credit to one account
+type JournalLine struct {
+	AccountCode string          `json:"account_code"`
+	Description string          `json:"description"`
+	Debit       decimal.Decimal `json:"debit"`
+	Credit      decimal.Decimal `json:"credit"`
+}
+
+// Amount returns the line as a signed amount, debits positive and credits negative
+func (l JournalLine) Amount() decimal.Decimal {
+	return l.Debit.Sub(l.Credit)
+}
+
+// JournalEntry is a balanced set of journal lines posted on one date
+type JournalEntry struct {
+	Date        time.Time     `json:"date"`
+	Reference   string        `json:"reference"`
+	Description string        `json:"description"`
+	Lines       []JournalLine `json:"lines"`
+}
+
+// journalPaymentMethods orders the payment lines of a journal entry
+var journalPaymentMethods = []PaymentMethod{PaymentMethodCash, PaymentMethodCard, PaymentMethodDigitalWallet, PaymentMethodBankTransfer}
+
+// NewJournalEntry posts sales and refunds totals to the mapped accounts:
+//   - payments received debit their payment accounts, crediting sales revenue and tax payable
+//   - cost of goods sold is debited and inventory credited
+//   - refunds debit sales returns and tax payable, crediting their payment accounts
+//   - goods returned to stock debit inventory and credit cost of goods sold
+//
+// Amounts are rounded to cents. Revenue is what was received less the tax, so the entry
+// balances whatever the rounding. It returns nil when there is nothing to post.
+func NewJournalEntry(accounts AccountingAccounts, date time.Time, reference, description string, totals *AccountingTotals) *JournalEntry {
+	entry := &JournalEntry{
+		Date:        date,
+		Reference:   reference,
+		Description: description,
+		Lines:       []JournalLine{},
+	}
+
+	received := decimal.Zero
+	for _, method := range journalPaymentMethods {
+		amount := totals.Payments[method].Round(2)
+		received = received.Add(amount)
+		entry.post(accounts.PaymentAccount(method), "Sales received by "+string(method), amount)
+	}
+	tax := totals.Tax.Round(2)
+	entry.post(accounts.SalesRevenue, "Sales revenue", received.Sub(tax).Neg())
+	entry.post(accounts.TaxPayable, "Tax on sales", tax.Neg())
+
+	cost := totals.CostOfGoods.Round(2)
+	entry.post(accounts.CostOfGoodsSold, "Cost of goods sold", cost)
+	entry.post(accounts.Inventory, "Goods sold from inventory", cost.Neg())
+
+	refunded := decimal.Zero
+	for _, method := range journalPaymentMethods {
+		refunded = refunded.Add(totals.Refunds[method].Round(2))
+	}
+	refundTax := totals.RefundTax.Round(2)
+	entry.post(accounts.SalesReturns, "Sales returns", refunded.Sub(refundTax))
+	entry.post(accounts.TaxPayable, "Tax on sales returns", refundTax)
+	for _, method := range journalPaymentMethods {
+		entry.post(accounts.PaymentAccount(method), "Refunds paid by "+string(method), totals.Refunds[method].Round(2).Neg())
+	}
+
+	returnedCost := totals.ReturnedCost.Round(2)
+	entry.post(accounts.Inventory, "Goods returned to inventory", returnedCost)
+	entry.post(accounts.CostOfGoodsSold, "Cost of goods returned", returnedCost.Neg())
+
+	if len(entry.Lines) == 0 {
+		return nil
+	}
+	return entry
+}
+
+// post adds a line debiting a positive amount or crediting a negative one. Zero amounts are
+// not posted.
+func (e *JournalEntry) post(account, description string, amount decimal.Decimal) {
+	if amount.IsZero() {
+		return
+	}
+
+	line := JournalLine{AccountCode: account, Description: description, Debit: decimal.Zero, Credit: decimal.Zero}
+	if amount.IsPositive() {
+		line.Debit = amount
+	} else {
+		line.Credit = amount.Neg()
+	}
+	e.Lines = append(e.Lines, line)
+}
+
+// TotalDebit returns the sum of the entry's debits
+func (e *JournalEntry) TotalDebit() decimal.Decimal {
+	total := decimal.Zero
+	for _, line := range e.Lines {
+		total = total.Add(line.Debit)
+	}
+	return total
+}
+
+// TotalCredit returns the sum of the entry's credits
+func (e *JournalEntry) TotalCredit() decimal.Decimal {
+	total := decimal.Zero
+	for _, line := range e.Lines {
+		total = total.Add(line.Credit)
+	}
+	return total
+}
+
+// IsBalanced reports whether the entry's debits equal its credits
+func (e *JournalEntry) IsBalanced() bool {
+	return e.TotalDebit().Equal(e.TotalCredit())
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountingAccountMapping(t *testing.T) {
+	t.Run("valid mapping creation", func(t *testing.T) {
+		tenantID := uuid.New()
+
+		mapping, err := NewAccountingAccountMapping(tenantID, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, mapping.TenantID)
+		assert.Equal(t, DefaultAccountingAccounts(), mapping.Accounts)
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		mapping, err := NewAccountingAccountMapping(uuid.Nil, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, mapping)
+	})
+}
+
+func TestAccountingAccountMapping_Configure(t *testing.T) {
+	t.Run("trims accounts", func(t *testing.T) {
+		mapping, err := NewAccountingAccountMapping(uuid.New(), uuid.New())
+		require.NoError(t, err)
+		accounts := DefaultAccountingAccounts()
+		accounts.SalesRevenue = " 4-1000 "
+
+		require.NoError(t, mapping.Configure(accounts, uuid.New()))
+		assert.Equal(t, "4-1000", mapping.Accounts.SalesRevenue)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		mapping, err := NewAccountingAccountMapping(uuid.New(), uuid.New())
+		require.NoError(t, err)
+		accounts := DefaultAccountingAccounts()
+		accounts.Inventory = "  "
+
+		assert.Error(t, mapping.Configure(accounts, uuid.New()))
+		assert.Equal(t, "1300", mapping.Accounts.Inventory)
+	})
+
+	t.Run("account with tab", func(t *testing.T) {
+		mapping, err := NewAccountingAccountMapping(uuid.New(), uuid.New())
+		require.NoError(t, err)
+		accounts := DefaultAccountingAccounts()
+		accounts.Cash = "Cash\tDrawer"
+
+		assert.Error(t, mapping.Configure(accounts, uuid.New()))
+	})
+}
+
+func TestNewJournalEntry(t *testing.T) {
+	accounts := DefaultAccountingAccounts()
+	date := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("sales and refunds balance", func(t *testing.T) {
+		totals := NewAccountingTotals()
+		totals.Payments[PaymentMethodCash] = decimal.NewFromFloat(110.004)
+		totals.Payments[PaymentMethodCard] = decimal.NewFromInt(55)
+		totals.Tax = decimal.NewFromFloat(15.005)
+		totals.CostOfGoods = decimal.NewFromInt(80)
+		totals.Refunds[PaymentMethodCash] = decimal.NewFromInt(11)
+		totals.RefundTax = decimal.NewFromInt(1)
+		totals.ReturnedCost = decimal.NewFromInt(6)
+
+		entry := NewJournalEntry(accounts, date, "JE-20261015", "Sales 2026-10-15", totals)
+
+		require.NotNil(t, entry)
+		assert.True(t, entry.IsBalanced())
+		assert.True(t, decimal.NewFromInt(165+80+10+1+6).Equal(entry.TotalDebit()))
+
+		lines := map[string]decimal.Decimal{}
+		for _, line := range entry.Lines {
+			lines[line.AccountCode] = lines[line.AccountCode].Add(line.Amount())
+		}
+		assert.True(t, decimal.NewFromInt(99).Equal(lines[accounts.Cash]))
+		assert.True(t, decimal.NewFromInt(55).Equal(lines[accounts.Card]))
+		assert.True(t, decimal.NewFromFloat(-149.99).Equal(lines[accounts.SalesRevenue]))
+		assert.True(t, decimal.NewFromFloat(-14.01).Equal(lines[accounts.TaxPayable]))
+		assert.True(t, decimal.NewFromInt(10).Equal(lines[accounts.SalesReturns]))
+		assert.True(t, decimal.NewFromInt(74).Equal(lines[accounts.CostOfGoodsSold]))
+		assert.True(t, decimal.NewFromInt(-74).Equal(lines[accounts.Inventory]))
+	})
+
+	t.Run("nothing to post", func(t *testing.T) {
+		assert.Nil(t, NewJournalEntry(accounts, date, "JE-20261015", "Sales 2026-10-15", NewAccountingTotals()))
+	})
+}
+
+func TestAccountingTotals_Add(t *testing.T) {
+	totals := NewAccountingTotals()
+	day := NewAccountingTotals()
+	day.Payments[PaymentMethodCash] = decimal.NewFromInt(10)
+	day.Tax = decimal.NewFromInt(1)
+
+	totals.Add(day)
+	totals.Add(day)
+
+	assert.True(t, decimal.NewFromInt(20).Equal(totals.Payments[PaymentMethodCash]))
+	assert.True(t, decimal.NewFromInt(2).Equal(totals.Tax))
+}
+
+func TestJournalGrouping_IsValid(t *testing.T) {
+	assert.True(t, JournalGroupingDay.IsValid())
+	assert.True(t, JournalGroupingPeriod.IsValid())
+	assert.False(t, JournalGrouping("week").IsValid())
+}
@@ -0,0 +1,195 @@
+package entities
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxAlertChannelRecipients caps how many addresses an email alert channel notifies
+const MaxAlertChannelRecipients = 10
+
+// AlertSeverity represents how urgent a monitoring alert is
+type AlertSeverity string
+
+const (
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityError    AlertSeverity = "error"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+// rank orders severities from least to most urgent, zero meaning unknown
+func (s AlertSeverity) rank() int {
+	switch s {
+	case AlertSeverityInfo:
+		return 1
+	case AlertSeverityWarning:
+		return 2
+	case AlertSeverityError:
+		return 3
+	case AlertSeverityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// IsValid reports whether the severity is known
+func (s AlertSeverity) IsValid() bool {
+	return s.rank() > 0
+}
+
+// AlertChannelType represents where alert notifications are sent
+type AlertChannelType string
+
+const (
+	AlertChannelEmail   AlertChannelType = "email"   // Emailed to the recipients, or to the tenant's admins
+	AlertChannelSlack   AlertChannelType = "slack"   // Posted to a Slack incoming webhook
+	AlertChannelWebhook AlertChannelType = "webhook" // Posted as signed JSON to any URL
+)
+
+// IsValid reports whether the channel type is known
+func (t AlertChannelType) IsValid() bool {
+	switch t {
+	case AlertChannelEmail, AlertChannelSlack, AlertChannelWebhook:
+		return true
+	default:
+		return false
+	}
+}
+
+// AlertChannel represents a destination a tenant's monitoring alerts of a minimum severity
+// are sent to
+type AlertChannel struct {
+	ID             uuid.UUID        `json:"id"`
+	TenantID       uuid.UUID        `json:"tenant_id"`
+	Name           string           `json:"name"`
+	Type           AlertChannelType `json:"type"`
+	MinSeverity    AlertSeverity    `json:"min_severity"`         // Least severe alert sent to the channel
+	Recipients     []string         `json:"recipients,omitempty"` // Email channels only; the tenant's admins when empty
+	URL            string           `json:"url,omitempty"`        // Slack and webhook channels only
+	Secret         string           `json:"-"`                    // Webhook channels only; signs the posted body
+	IsActive       bool             `json:"is_active"`
+	LastNotifiedAt *time.Time       `json:"last_notified_at,omitempty"`
+	LastError      string           `json:"last_error,omitempty"` // Why the latest notification failed
+	CreatedBy      uuid.UUID        `json:"created_by"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// NewAlertChannel creates an active alert channel. Webhook channels get a freshly generated
+// signing secret.
+func NewAlertChannel(tenantID uuid.UUID, name string, channelType AlertChannelType, minSeverity AlertSeverity, recipients []string, channelURL string, createdBy uuid.UUID) (*AlertChannel, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if !channelType.IsValid() {
+		return nil, errors.NewValidationError("invalid channel type", "channel type must be one of: email, slack, webhook")
+	}
+
+	now := time.Now()
+	channel := &AlertChannel{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Type:      channelType,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := channel.Update(name, minSeverity, recipients, channelURL, true); err != nil {
+		return nil, err
+	}
+
+	if channelType == AlertChannelWebhook {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		channel.Secret = secret
+	}
+
+	return channel, nil
+}
+
+// Update changes the channel's name, minimum severity, destination and active flag. The
+// channel type cannot be changed.
+func (c *AlertChannel) Update(name string, minSeverity AlertSeverity, recipients []string, channelURL string, isActive bool) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("channel name is required", "channel name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.NewValidationError("channel name too long", "channel name cannot exceed 100 characters")
+	}
+
+	if minSeverity == "" {
+		minSeverity = AlertSeverityWarning
+	}
+	if !minSeverity.IsValid() {
+		return errors.NewValidationError("invalid minimum severity", "minimum severity must be one of: info, warning, error, critical")
+	}
+
+	cleaned := make([]string, 0, len(recipients))
+	channelURL = strings.TrimSpace(channelURL)
+	switch c.Type {
+	case AlertChannelEmail:
+		seen := make(map[string]bool, len(recipients))
+		for _, recipient := range recipients {
+			recipient = NormalizeEmail(recipient)
+			if recipient == "" || seen[recipient] {
+				continue
+			}
+			if !isValidEmail(recipient) {
+				return errors.NewValidationError("invalid recipient", "recipient "+recipient+" is not a valid email address")
+			}
+			seen[recipient] = true
+			cleaned = append(cleaned, recipient)
+		}
+		if len(cleaned) > MaxAlertChannelRecipients {
+			return errors.NewValidationError("too many recipients", "an alert channel can notify at most 10 recipients")
+		}
+		channelURL = ""
+	case AlertChannelSlack:
+		if err := validateWebhookURL(channelURL); err != nil {
+			return err
+		}
+		if parsed, _ := url.Parse(channelURL); parsed.Scheme != "https" {
+			return errors.NewValidationError("invalid Slack webhook URL", "Slack webhook URLs must use https")
+		}
+	case AlertChannelWebhook:
+		if err := validateWebhookURL(channelURL); err != nil {
+			return err
+		}
+	}
+
+	c.Name = name
+	c.MinSeverity = minSeverity
+	c.Recipients = cleaned
+	c.URL = channelURL
+	c.IsActive = isActive
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Accepts reports whether the channel is active and receives alerts of the severity
+func (c *AlertChannel) Accepts(severity AlertSeverity) bool {
+	return c.IsActive && severity.rank() >= c.MinSeverity.rank()
+}
+
+// RecordNotification records the outcome of sending an alert to the channel, nil meaning it
+// was sent
+func (c *AlertChannel) RecordNotification(at time.Time, sendErr error) {
+	c.UpdatedAt = at
+	if sendErr != nil {
+		c.LastError = sendErr.Error()
+		return
+	}
+
+	c.LastNotifiedAt = &at
+	c.LastError = ""
+}
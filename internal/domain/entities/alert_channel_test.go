@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertChannel(t *testing.T) {
+	t.Run("email channel with recipients", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		channel, err := NewAlertChannel(tenantID, " Owners ", AlertChannelEmail, AlertSeverityError,
+			[]string{" Owner@Example.com", "owner@example.com", "", "ops@example.com"}, "https://ignored.example.com", createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, channel.ID)
+		assert.Equal(t, tenantID, channel.TenantID)
+		assert.Equal(t, "Owners", channel.Name)
+		assert.Equal(t, AlertSeverityError, channel.MinSeverity)
+		assert.Equal(t, []string{"owner@example.com", "ops@example.com"}, channel.Recipients)
+		assert.Empty(t, channel.URL)
+		assert.Empty(t, channel.Secret)
+		assert.True(t, channel.IsActive)
+		assert.Equal(t, createdBy, channel.CreatedBy)
+	})
+
+	t.Run("email channel without recipients notifies admins", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "Admins", AlertChannelEmail, "", nil, "", uuid.New())
+
+		require.NoError(t, err)
+		assert.Empty(t, channel.Recipients)
+		assert.Equal(t, AlertSeverityWarning, channel.MinSeverity)
+	})
+
+	t.Run("webhook channel gets a signing secret", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "Pager", AlertChannelWebhook, AlertSeverityCritical, nil, " https://pager.example.com/hook ", uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://pager.example.com/hook", channel.URL)
+		assert.True(t, strings.HasPrefix(channel.Secret, "whsec_"))
+	})
+
+	t.Run("slack channel requires https", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "Ops", AlertChannelSlack, AlertSeverityWarning, nil, "http://hooks.slack.com/services/T0/B0/x", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, channel)
+		assert.Contains(t, err.Error(), "invalid Slack webhook URL")
+	})
+
+	t.Run("invalid channel type", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "SMS", AlertChannelType("sms"), AlertSeverityWarning, nil, "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, channel)
+		assert.Contains(t, err.Error(), "invalid channel type")
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "Ops", AlertChannelEmail, AlertSeverity("urgent"), nil, "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, channel)
+		assert.Contains(t, err.Error(), "invalid minimum severity")
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "Ops", AlertChannelEmail, AlertSeverityWarning, []string{"not-an-email"}, "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, channel)
+		assert.Contains(t, err.Error(), "invalid recipient")
+	})
+
+	t.Run("missing webhook URL", func(t *testing.T) {
+		channel, err := NewAlertChannel(uuid.New(), "Pager", AlertChannelWebhook, AlertSeverityWarning, nil, "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, channel)
+	})
+}
+
+func TestAlertChannel_Accepts(t *testing.T) {
+	channel, err := NewAlertChannel(uuid.New(), "Ops", AlertChannelEmail, AlertSeverityError, nil, "", uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, channel.Accepts(AlertSeverityInfo))
+	assert.False(t, channel.Accepts(AlertSeverityWarning))
+	assert.True(t, channel.Accepts(AlertSeverityError))
+	assert.True(t, channel.Accepts(AlertSeverityCritical))
+	assert.False(t, channel.Accepts(AlertSeverity("unknown")))
+
+	channel.IsActive = false
+	assert.False(t, channel.Accepts(AlertSeverityCritical))
+}
+
+func TestAlertChannel_RecordNotification(t *testing.T) {
+	channel, err := NewAlertChannel(uuid.New(), "Ops", AlertChannelEmail, AlertSeverityWarning, nil, "", uuid.New())
+	require.NoError(t, err)
+
+	failedAt := time.Now()
+	channel.RecordNotification(failedAt, errors.New("connection refused"))
+	assert.Equal(t, "connection refused", channel.LastError)
+	assert.Nil(t, channel.LastNotifiedAt)
+
+	sentAt := failedAt.Add(time.Minute)
+	channel.RecordNotification(sentAt, nil)
+	assert.Empty(t, channel.LastError)
+	require.NotNil(t, channel.LastNotifiedAt)
+	assert.Equal(t, sentAt, *channel.LastNotifiedAt)
+}
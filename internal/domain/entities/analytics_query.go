@@ -0,0 +1,308 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// AnalyticsSource identifies the fact table an analytics query aggregates. Both sources only
+// cover completed sales and bucket them by completion time.
+type AnalyticsSource string
+
+const (
+	AnalyticsSourceSales     AnalyticsSource = "sales"      // One row per completed sale
+	AnalyticsSourceSaleItems AnalyticsSource = "sale_items" // One row per line of a completed sale
+)
+
+// AnalyticsDateGrain represents the time bucket used to group analytics rows
+type AnalyticsDateGrain string
+
+const (
+	AnalyticsDateGrainHour  AnalyticsDateGrain = "hour"
+	AnalyticsDateGrainDay   AnalyticsDateGrain = "day"
+	AnalyticsDateGrainWeek  AnalyticsDateGrain = "week"
+	AnalyticsDateGrainMonth AnalyticsDateGrain = "month"
+)
+
+// AnalyticsFieldType represents the type of the values of an analytics column
+type AnalyticsFieldType string
+
+const (
+	AnalyticsFieldTypeString  AnalyticsFieldType = "string"
+	AnalyticsFieldTypeUUID    AnalyticsFieldType = "uuid"
+	AnalyticsFieldTypeInteger AnalyticsFieldType = "integer"
+	AnalyticsFieldTypeDecimal AnalyticsFieldType = "decimal"
+	AnalyticsFieldTypeTime    AnalyticsFieldType = "time"
+)
+
+// AnalyticsFilterOperator represents how a filter compares a dimension to its values
+type AnalyticsFilterOperator string
+
+const (
+	AnalyticsFilterEquals    AnalyticsFilterOperator = "eq"
+	AnalyticsFilterNotEquals AnalyticsFilterOperator = "neq"
+	AnalyticsFilterIn        AnalyticsFilterOperator = "in"
+	AnalyticsFilterNotIn     AnalyticsFilterOperator = "not_in"
+)
+
+// AnalyticsPeriodColumn is the name of the column holding the time bucket when a date grain is set
+const AnalyticsPeriodColumn = "period"
+
+// Analytics query guardrails
+const (
+	MaxAnalyticsDimensions   = 3
+	MaxAnalyticsMeasures     = 6
+	MaxAnalyticsFilters      = 10
+	MaxAnalyticsFilterValues = 50
+	MaxAnalyticsRowLimit     = 1000
+	DefaultAnalyticsRowLimit = 100
+	MaxAnalyticsDateRange    = 366 * 24 * time.Hour
+	// MaxAnalyticsHourlyRange keeps hourly queries from producing more buckets than a chart can show
+	MaxAnalyticsHourlyRange = 31 * 24 * time.Hour
+)
+
+// AnalyticsField describes a dimension or measure that analytics queries can select
+type AnalyticsField struct {
+	Name        string             `json:"name"`
+	Type        AnalyticsFieldType `json:"type"`
+	Description string             `json:"description"`
+}
+
+// AnalyticsSourceCatalog lists the dimensions and measures available on a source
+type AnalyticsSourceCatalog struct {
+	Source     AnalyticsSource  `json:"source"`
+	Dimensions []AnalyticsField `json:"dimensions"`
+	Measures   []AnalyticsField `json:"measures"`
+}
+
+// Dimensions shared by both sources, taken from the sale
+var analyticsSaleDimensions = []AnalyticsField{
+	{Name: "channel", Type: AnalyticsFieldTypeString, Description: "Sales channel the sale came from"},
+	{Name: "payment_method", Type: AnalyticsFieldTypeString, Description: "Payment method of the sale"},
+	{Name: "cashier_id", Type: AnalyticsFieldTypeUUID, Description: "User who rang up the sale"},
+	{Name: "cashier_name", Type: AnalyticsFieldTypeString, Description: "Username of the cashier"},
+}
+
+// Dimensions only available per sale line
+var analyticsItemDimensions = []AnalyticsField{
+	{Name: "product_id", Type: AnalyticsFieldTypeUUID, Description: "Product sold"},
+	{Name: "product_sku", Type: AnalyticsFieldTypeString, Description: "SKU of the product at the time of sale"},
+	{Name: "product_name", Type: AnalyticsFieldTypeString, Description: "Name of the product at the time of sale"},
+	{Name: "category", Type: AnalyticsFieldTypeString, Description: "Current category of the product"},
+	{Name: "price_source", Type: AnalyticsFieldTypeString, Description: "Whether the item sold at list, promotion or override price"},
+}
+
+var analyticsSaleMeasures = []AnalyticsField{
+	{Name: "sale_count", Type: AnalyticsFieldTypeInteger, Description: "Number of sales"},
+	{Name: "revenue", Type: AnalyticsFieldTypeDecimal, Description: "Total amount of the sales"},
+	{Name: "discount_amount", Type: AnalyticsFieldTypeDecimal, Description: "Manual discounts given on the sales"},
+	{Name: "tax_amount", Type: AnalyticsFieldTypeDecimal, Description: "Tax collected on the sales"},
+	{Name: "average_order_value", Type: AnalyticsFieldTypeDecimal, Description: "Average total amount per sale"},
+}
+
+var analyticsItemMeasures = []AnalyticsField{
+	{Name: "sale_count", Type: AnalyticsFieldTypeInteger, Description: "Number of distinct sales the items appear on"},
+	{Name: "quantity_sold", Type: AnalyticsFieldTypeInteger, Description: "Units sold"},
+	{Name: "item_revenue", Type: AnalyticsFieldTypeDecimal, Description: "Revenue of the items before sale-level discounts"},
+	{Name: "cost_of_goods", Type: AnalyticsFieldTypeDecimal, Description: "Cost of the units sold"},
+	{Name: "gross_profit", Type: AnalyticsFieldTypeDecimal, Description: "Item revenue less cost of goods"},
+}
+
+// AnalyticsCatalog returns the dimensions and measures of every analytics source
+func AnalyticsCatalog() []AnalyticsSourceCatalog {
+	itemDimensions := append(append([]AnalyticsField{}, analyticsSaleDimensions...), analyticsItemDimensions...)
+
+	return []AnalyticsSourceCatalog{
+		{
+			Source:     AnalyticsSourceSales,
+			Dimensions: append([]AnalyticsField{}, analyticsSaleDimensions...),
+			Measures:   append([]AnalyticsField{}, analyticsSaleMeasures...),
+		},
+		{
+			Source:     AnalyticsSourceSaleItems,
+			Dimensions: itemDimensions,
+			Measures:   append([]AnalyticsField{}, analyticsItemMeasures...),
+		},
+	}
+}
+
+// AnalyticsFilter restricts an analytics query to rows whose dimension matches the values
+type AnalyticsFilter struct {
+	Dimension string                  `json:"dimension"`
+	Operator  AnalyticsFilterOperator `json:"operator"`
+	Values    []string                `json:"values"`
+}
+
+// AnalyticsQuery is a declarative analytics query spec. It is validated against the catalog
+// before it is compiled to SQL, so only known columns ever reach the database.
+type AnalyticsQuery struct {
+	Source     AnalyticsSource    `json:"source"`
+	Dimensions []string           `json:"dimensions,omitempty"`
+	Measures   []string           `json:"measures"`
+	Filters    []AnalyticsFilter  `json:"filters,omitempty"`
+	DateGrain  AnalyticsDateGrain `json:"date_grain,omitempty"` // Adds a period column when set
+	DateFrom   time.Time          `json:"date_from"`
+	DateTo     time.Time          `json:"date_to"`            // Exclusive
+	OrderBy    string             `json:"order_by,omitempty"` // A selected dimension, measure or period
+	OrderDir   string             `json:"order_dir,omitempty"`
+	Limit      int                `json:"limit,omitempty"`
+}
+
+// Normalize applies defaults and validates the query against the catalog and guardrails
+func (q *AnalyticsQuery) Normalize() error {
+	catalog, ok := analyticsCatalogFor(q.Source)
+	if !ok {
+		return errors.NewValidationError("invalid source", "source must be one of: sales, sale_items")
+	}
+
+	if len(q.Measures) == 0 {
+		return errors.NewValidationError("measures are required", "select at least one measure")
+	}
+	if len(q.Measures) > MaxAnalyticsMeasures {
+		return errors.NewValidationError("too many measures", fmt.Sprintf("a query can select at most %d measures", MaxAnalyticsMeasures))
+	}
+	if len(q.Dimensions) > MaxAnalyticsDimensions {
+		return errors.NewValidationError("too many dimensions", fmt.Sprintf("a query can group by at most %d dimensions", MaxAnalyticsDimensions))
+	}
+	if len(q.Filters) > MaxAnalyticsFilters {
+		return errors.NewValidationError("too many filters", fmt.Sprintf("a query can have at most %d filters", MaxAnalyticsFilters))
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range q.Dimensions {
+		if _, ok := findAnalyticsField(catalog.Dimensions, name); !ok {
+			return errors.NewValidationError("unknown dimension", fmt.Sprintf("%s is not a dimension of %s", name, q.Source))
+		}
+		if selected[name] {
+			return errors.NewValidationError("duplicate dimension", fmt.Sprintf("%s is selected more than once", name))
+		}
+		selected[name] = true
+	}
+	for _, name := range q.Measures {
+		if _, ok := findAnalyticsField(catalog.Measures, name); !ok {
+			return errors.NewValidationError("unknown measure", fmt.Sprintf("%s is not a measure of %s", name, q.Source))
+		}
+		if selected[name] {
+			return errors.NewValidationError("duplicate measure", fmt.Sprintf("%s is selected more than once", name))
+		}
+		selected[name] = true
+	}
+
+	for i := range q.Filters {
+		if err := q.Filters[i].validate(catalog); err != nil {
+			return err
+		}
+	}
+
+	if q.DateFrom.IsZero() || q.DateTo.IsZero() {
+		return errors.NewValidationError("date range is required", "date_from and date_to must be set")
+	}
+	if !q.DateTo.After(q.DateFrom) {
+		return errors.NewValidationError("invalid date range", "date_to must be after date_from")
+	}
+	if q.DateTo.Sub(q.DateFrom) > MaxAnalyticsDateRange {
+		return errors.NewValidationError("date range too long", "date range cannot exceed 366 days")
+	}
+
+	switch q.DateGrain {
+	case "":
+	case AnalyticsDateGrainHour:
+		if q.DateTo.Sub(q.DateFrom) > MaxAnalyticsHourlyRange {
+			return errors.NewValidationError("date range too long", "hourly queries cannot exceed 31 days")
+		}
+		selected[AnalyticsPeriodColumn] = true
+	case AnalyticsDateGrainDay, AnalyticsDateGrainWeek, AnalyticsDateGrainMonth:
+		selected[AnalyticsPeriodColumn] = true
+	default:
+		return errors.NewValidationError("invalid date grain", "date_grain must be one of: hour, day, week, month")
+	}
+
+	if q.OrderBy != "" && !selected[q.OrderBy] {
+		return errors.NewValidationError("invalid order", "order_by must be a selected dimension, measure or period")
+	}
+	q.OrderDir = strings.ToUpper(q.OrderDir)
+	if q.OrderDir != "ASC" && q.OrderDir != "DESC" {
+		q.OrderDir = "DESC"
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultAnalyticsRowLimit
+	}
+	if q.Limit > MaxAnalyticsRowLimit {
+		return errors.NewValidationError("limit too high", fmt.Sprintf("limit cannot exceed %d rows", MaxAnalyticsRowLimit))
+	}
+
+	return nil
+}
+
+// Field returns the catalog definition of a selected dimension or measure
+func (q *AnalyticsQuery) Field(name string) (AnalyticsField, bool) {
+	if name == AnalyticsPeriodColumn {
+		return AnalyticsField{Name: AnalyticsPeriodColumn, Type: AnalyticsFieldTypeTime}, true
+	}
+
+	catalog, ok := analyticsCatalogFor(q.Source)
+	if !ok {
+		return AnalyticsField{}, false
+	}
+	if field, ok := findAnalyticsField(catalog.Dimensions, name); ok {
+		return field, true
+	}
+	return findAnalyticsField(catalog.Measures, name)
+}
+
+// validate validates a filter against the dimensions of a source
+func (f *AnalyticsFilter) validate(catalog AnalyticsSourceCatalog) error {
+	field, ok := findAnalyticsField(catalog.Dimensions, f.Dimension)
+	if !ok {
+		return errors.NewValidationError("unknown filter dimension", fmt.Sprintf("%s is not a dimension of %s", f.Dimension, catalog.Source))
+	}
+
+	switch f.Operator {
+	case AnalyticsFilterEquals, AnalyticsFilterNotEquals:
+		if len(f.Values) != 1 {
+			return errors.NewValidationError("invalid filter", fmt.Sprintf("%s filter on %s takes exactly one value", f.Operator, f.Dimension))
+		}
+	case AnalyticsFilterIn, AnalyticsFilterNotIn:
+		if len(f.Values) == 0 || len(f.Values) > MaxAnalyticsFilterValues {
+			return errors.NewValidationError("invalid filter", fmt.Sprintf("%s filter on %s takes between 1 and %d values", f.Operator, f.Dimension, MaxAnalyticsFilterValues))
+		}
+	default:
+		return errors.NewValidationError("invalid filter operator", "operator must be one of: eq, neq, in, not_in")
+	}
+
+	if field.Type == AnalyticsFieldTypeUUID {
+		for _, value := range f.Values {
+			if _, err := uuid.Parse(value); err != nil {
+				return errors.NewValidationError("invalid filter value", fmt.Sprintf("%s values must be UUIDs", f.Dimension))
+			}
+		}
+	}
+
+	return nil
+}
+
+// analyticsCatalogFor returns the catalog of a source
+func analyticsCatalogFor(source AnalyticsSource) (AnalyticsSourceCatalog, bool) {
+	for _, catalog := range AnalyticsCatalog() {
+		if catalog.Source == source {
+			return catalog, true
+		}
+	}
+	return AnalyticsSourceCatalog{}, false
+}
+
+// findAnalyticsField finds a field by name
+func findAnalyticsField(fields []AnalyticsField, name string) (AnalyticsField, bool) {
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return AnalyticsField{}, false
+}
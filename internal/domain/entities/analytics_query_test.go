@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validAnalyticsQuery() AnalyticsQuery {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	return AnalyticsQuery{
+		Source:     AnalyticsSourceSaleItems,
+		Dimensions: []string{"category"},
+		Measures:   []string{"item_revenue", "quantity_sold"},
+		DateGrain:  AnalyticsDateGrainDay,
+		DateFrom:   from,
+		DateTo:     from.AddDate(0, 0, 14),
+	}
+}
+
+func TestAnalyticsQuery_Normalize(t *testing.T) {
+	t.Run("valid query gets defaults", func(t *testing.T) {
+		query := validAnalyticsQuery()
+
+		require.NoError(t, query.Normalize())
+		assert.Equal(t, DefaultAnalyticsRowLimit, query.Limit)
+		assert.Equal(t, "DESC", query.OrderDir)
+	})
+
+	t.Run("order by period or selected field", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.OrderBy = AnalyticsPeriodColumn
+		query.OrderDir = "asc"
+		require.NoError(t, query.Normalize())
+		assert.Equal(t, "ASC", query.OrderDir)
+
+		query = validAnalyticsQuery()
+		query.OrderBy = "cost_of_goods"
+		assert.Error(t, query.Normalize())
+
+		query = validAnalyticsQuery()
+		query.DateGrain = ""
+		query.OrderBy = AnalyticsPeriodColumn
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Source = "invoices"
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("fields must belong to the source", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Source = AnalyticsSourceSales
+		assert.Error(t, query.Normalize(), "category is only a sale item dimension")
+
+		query = validAnalyticsQuery()
+		query.Measures = []string{"revenue"}
+		assert.Error(t, query.Normalize(), "revenue is only a sale measure")
+
+		query = validAnalyticsQuery()
+		query.Dimensions = []string{"total_amount; DROP TABLE sales"}
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("measures required and capped", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Measures = nil
+		assert.Error(t, query.Normalize())
+
+		query = validAnalyticsQuery()
+		query.Measures = []string{"sale_count", "quantity_sold", "item_revenue", "cost_of_goods", "gross_profit", "sale_count", "quantity_sold"}
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("duplicate fields", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Dimensions = []string{"category", "category"}
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("too many dimensions", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Dimensions = []string{"category", "channel", "product_id", "cashier_id"}
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("date range guardrails", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.DateTo = query.DateFrom
+		assert.Error(t, query.Normalize())
+
+		query = validAnalyticsQuery()
+		query.DateTo = query.DateFrom.Add(MaxAnalyticsDateRange + time.Hour)
+		assert.Error(t, query.Normalize())
+
+		query = validAnalyticsQuery()
+		query.DateGrain = AnalyticsDateGrainHour
+		query.DateTo = query.DateFrom.AddDate(0, 2, 0)
+		assert.Error(t, query.Normalize())
+
+		query = validAnalyticsQuery()
+		query.DateGrain = "minute"
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("row limit", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Limit = MaxAnalyticsRowLimit
+		require.NoError(t, query.Normalize())
+
+		query.Limit = MaxAnalyticsRowLimit + 1
+		assert.Error(t, query.Normalize())
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		query := validAnalyticsQuery()
+		query.Filters = []AnalyticsFilter{
+			{Dimension: "channel", Operator: AnalyticsFilterIn, Values: []string{"online", "marketplace"}},
+			{Dimension: "product_id", Operator: AnalyticsFilterEquals, Values: []string{uuid.New().String()}},
+		}
+		require.NoError(t, query.Normalize())
+
+		query.Filters = []AnalyticsFilter{{Dimension: "product_id", Operator: AnalyticsFilterEquals, Values: []string{"abc"}}}
+		assert.Error(t, query.Normalize(), "uuid dimensions need uuid values")
+
+		query.Filters = []AnalyticsFilter{{Dimension: "channel", Operator: AnalyticsFilterEquals, Values: []string{"online", "in_store"}}}
+		assert.Error(t, query.Normalize(), "eq takes one value")
+
+		query.Filters = []AnalyticsFilter{{Dimension: "channel", Operator: "like", Values: []string{"on%"}}}
+		assert.Error(t, query.Normalize())
+
+		query.Filters = []AnalyticsFilter{{Dimension: "item_revenue", Operator: AnalyticsFilterEquals, Values: []string{"1"}}}
+		assert.Error(t, query.Normalize(), "measures cannot be filtered")
+	})
+}
+
+func TestAnalyticsQuery_Field(t *testing.T) {
+	query := validAnalyticsQuery()
+
+	field, ok := query.Field("product_id")
+	require.True(t, ok)
+	assert.Equal(t, AnalyticsFieldTypeUUID, field.Type)
+
+	field, ok = query.Field(AnalyticsPeriodColumn)
+	require.True(t, ok)
+	assert.Equal(t, AnalyticsFieldTypeTime, field.Type)
+
+	_, ok = query.Field("revenue")
+	assert.False(t, ok)
+}
@@ -0,0 +1,188 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// apiKeyPrefix makes API keys recognisable in logs and secret scanners
+	apiKeyPrefix = "adk_"
+
+	// apiKeyDisplayLength is how much of a key is kept in the clear to tell keys apart
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+	// MaxAPIKeyScopes caps the scopes granted to one key
+	MaxAPIKeyScopes = 50
+)
+
+// apiKeyResources are the permission resources an API key can be granted. Administration of
+// users, API keys, retention and the tenant itself always needs a user session.
+var apiKeyResources = map[string]bool{
+	"products":        true,
+	"stock":           true,
+	"sales":           true,
+	"invoices":        true,
+	"quotes":          true,
+	"coupons":         true,
+	"tax_rates":       true,
+	"locations":       true,
+	"suppliers":       true,
+	"purchase_orders": true,
+	"reports":         true,
+}
+
+// APIKey represents a credential an external system, such as an e-commerce site, uses to call
+// the API without user credentials. Each scope grants one permission as "resource:action",
+// or every action on a resource as "resource:*".
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"` // The start of the key, to tell keys apart
+	KeyHash    string     `json:"-"`          // SHA-256 of the key, the key itself is never stored
+	Scopes     []string   `json:"scopes"`
+	IsActive   bool       `json:"is_active"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy  *uuid.UUID `json:"revoked_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+}
+
+// NewAPIKey creates a new API key and returns it with its plain key. The key is only available
+// at creation time.
+func NewAPIKey(tenantID uuid.UUID, name string, scopes []string, expiresAt *time.Time, createdBy uuid.UUID) (*APIKey, string, error) {
+	if tenantID == uuid.Nil {
+		return nil, "", errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.NewValidationError("API key name is required", "name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, "", errors.NewValidationError("API key name too long", "name cannot exceed 100 characters")
+	}
+
+	scopes, err := normalizeAPIKeyScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", errors.NewValidationError("invalid expiry", "expires_at must be in the future")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.NewInternalError("failed to generate API key", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	apiKey := &APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		KeyPrefix: key[:apiKeyDisplayLength],
+		KeyHash:   HashAPIKey(key),
+		Scopes:    scopes,
+		IsActive:  true,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+	}
+
+	return apiKey, key, nil
+}
+
+// Allows checks if the key grants an action on a resource
+func (k *APIKey) Allows(resource, action string) bool {
+	return APIKeyScopesAllow(k.Scopes, resource, action)
+}
+
+// IsUsable checks if the key can authenticate requests at the given time
+func (k *APIKey) IsUsable(at time.Time) bool {
+	return k.IsActive && (k.ExpiresAt == nil || at.Before(*k.ExpiresAt))
+}
+
+// Revoke permanently disables the key
+func (k *APIKey) Revoke(revokedBy uuid.UUID) error {
+	if !k.IsActive {
+		return errors.NewValidationError("API key already revoked", "API key is no longer active")
+	}
+
+	now := time.Now()
+	k.IsActive = false
+	k.RevokedAt = &now
+	k.RevokedBy = &revokedBy
+	k.UpdatedAt = now
+	return nil
+}
+
+// HashAPIKey returns the stored representation of an API key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyScopesAllow checks if scopes grant an action on a resource
+func APIKeyScopesAllow(scopes []string, resource, action string) bool {
+	if !apiKeyResources[resource] {
+		return false
+	}
+
+	for _, scope := range scopes {
+		if scope == resource+":"+action || scope == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAPIKeyScopes validates scopes and returns them sorted without duplicates
+func normalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if seen[scope] {
+			continue
+		}
+
+		resource, action, ok := strings.Cut(scope, ":")
+		if !ok || action == "" || strings.ContainsAny(action, ": ") {
+			return nil, errors.NewValidationError("invalid scope", "scope '"+scope+"' must be resource:action")
+		}
+		if !apiKeyResources[resource] {
+			return nil, errors.NewValidationError("invalid scope", "API keys cannot be granted access to "+resource)
+		}
+
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+
+	if len(normalized) == 0 {
+		return nil, errors.NewValidationError("scopes are required", "an API key needs at least one scope")
+	}
+	if len(normalized) > MaxAPIKeyScopes {
+		return nil, errors.NewValidationError("too many scopes", "an API key cannot have more than "+strconv.Itoa(MaxAPIKeyScopes)+" scopes")
+	}
+
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Run("valid key creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+		expiresAt := time.Now().Add(24 * time.Hour)
+
+		apiKey, key, err := NewAPIKey(tenantID, " Web shop ", []string{"sales:create", " Products:Read ", "sales:create"}, &expiresAt, createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, apiKey.ID)
+		assert.Equal(t, tenantID, apiKey.TenantID)
+		assert.Equal(t, "Web shop", apiKey.Name)
+		assert.Equal(t, []string{"products:read", "sales:create"}, apiKey.Scopes)
+		assert.True(t, apiKey.IsActive)
+		assert.Equal(t, createdBy, apiKey.CreatedBy)
+		assert.True(t, strings.HasPrefix(key, apiKeyPrefix))
+		assert.True(t, strings.HasPrefix(key, apiKey.KeyPrefix))
+		assert.Equal(t, HashAPIKey(key), apiKey.KeyHash)
+		assert.NotContains(t, apiKey.KeyHash, key)
+	})
+
+	t.Run("keys are unique", func(t *testing.T) {
+		_, first, err := NewAPIKey(uuid.New(), "Shop", []string{"products:read"}, nil, uuid.New())
+		require.NoError(t, err)
+		_, second, err := NewAPIKey(uuid.New(), "Shop", []string{"products:read"}, nil, uuid.New())
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("scopes are required", func(t *testing.T) {
+		apiKey, key, err := NewAPIKey(uuid.New(), "Shop", nil, nil, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, apiKey)
+		assert.Empty(t, key)
+	})
+
+	t.Run("invalid scope format", func(t *testing.T) {
+		_, _, err := NewAPIKey(uuid.New(), "Shop", []string{"products"}, nil, uuid.New())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid scope")
+	})
+
+	t.Run("administrative resources cannot be granted", func(t *testing.T) {
+		_, _, err := NewAPIKey(uuid.New(), "Shop", []string{"api_keys:manage"}, nil, uuid.New())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid scope")
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+
+		_, _, err := NewAPIKey(uuid.New(), "Shop", []string{"products:read"}, &expiresAt, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, _, err := NewAPIKey(uuid.New(), " ", []string{"products:read"}, nil, uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAPIKey_Allows(t *testing.T) {
+	apiKey, _, err := NewAPIKey(uuid.New(), "Shop", []string{"products:read", "sales:*"}, nil, uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, apiKey.Allows("products", "read"))
+	assert.False(t, apiKey.Allows("products", "update"))
+	assert.True(t, apiKey.Allows("sales", "create"))
+	assert.True(t, apiKey.Allows("sales", "refund"))
+	assert.False(t, apiKey.Allows("invoices", "read"))
+	assert.False(t, APIKeyScopesAllow([]string{"users:*"}, "users", "read"))
+}
+
+func TestAPIKey_IsUsable(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	apiKey, _, err := NewAPIKey(uuid.New(), "Shop", []string{"products:read"}, &expiresAt, uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, apiKey.IsUsable(now))
+	assert.False(t, apiKey.IsUsable(expiresAt))
+
+	revokedBy := uuid.New()
+	require.NoError(t, apiKey.Revoke(revokedBy))
+	assert.False(t, apiKey.IsUsable(now))
+	assert.Equal(t, revokedBy, *apiKey.RevokedBy)
+	assert.NotNil(t, apiKey.RevokedAt)
+	assert.Error(t, apiKey.Revoke(revokedBy))
+}
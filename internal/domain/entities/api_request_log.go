@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIRequestBodyOmission explains why a request or response body was not stored
+type APIRequestBodyOmission string
+
+const (
+	APIRequestBodyOmittedPCI       APIRequestBodyOmission = "pci_sensitive" // Payload carried cardholder data
+	APIRequestBodyOmittedNotJSON   APIRequestBodyOmission = "not_json"      // Body could not be redacted
+	APIRequestBodyOmittedTruncated APIRequestBodyOmission = "truncated"     // Body exceeded the capture limit
+)
+
+// APIRequestLog represents a sampled, redacted capture of an API request and its response,
+// kept for a short period to debug integration issues
+type APIRequestLog struct {
+	ID              uuid.UUID              `json:"id"`
+	RequestID       string                 `json:"request_id"`
+	TenantID        *uuid.UUID             `json:"tenant_id,omitempty"`
+	UserID          *uuid.UUID             `json:"user_id,omitempty"`
+	Method          string                 `json:"method"`
+	Path            string                 `json:"path"`
+	Query           string                 `json:"query,omitempty"`
+	StatusCode      int                    `json:"status_code"`
+	DurationMs      int64                  `json:"duration_ms"`
+	RequestHeaders  map[string]string      `json:"request_headers"`
+	RequestBody     string                 `json:"request_body,omitempty"`
+	RequestOmitted  APIRequestBodyOmission `json:"request_body_omitted,omitempty"`
+	ResponseBody    string                 `json:"response_body,omitempty"`
+	ResponseOmitted APIRequestBodyOmission `json:"response_body_omitted,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	ExpiresAt       time.Time              `json:"expires_at"`
+}
+
+// NewAPIRequestLog creates a new API request log that expires after the retention period
+func NewAPIRequestLog(requestID, method, path, query string, statusCode int, duration time.Duration, retention time.Duration) *APIRequestLog {
+	now := time.Now()
+	return &APIRequestLog{
+		ID:             uuid.New(),
+		RequestID:      requestID,
+		Method:         method,
+		Path:           path,
+		Query:          query,
+		StatusCode:     statusCode,
+		DurationMs:     duration.Milliseconds(),
+		RequestHeaders: make(map[string]string),
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(retention),
+	}
+}
+
+// IsExpired checks if the log is past its retention period
+func (l *APIRequestLog) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// AttachmentOwnerType represents the kind of record a file is attached to
+type AttachmentOwnerType string
+
+const (
+	AttachmentOwnerInvoice AttachmentOwnerType = "invoice"
+	AttachmentOwnerSale    AttachmentOwnerType = "sale"
+)
+
+const (
+	// MaxAttachmentSize is the largest file that can be attached, in bytes
+	MaxAttachmentSize = 10 * 1024 * 1024
+
+	// maxAttachmentFileNameLength caps the stored file name
+	maxAttachmentFileNameLength = 255
+)
+
+// attachmentContentTypes are the file types that can be attached, as sniffed from the content
+var attachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"text/plain":      true,
+}
+
+// attachmentArchiveTypes are the ZIP-based document types that can be attached, by extension
+var attachmentArchiveTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Attachment is a file, such as a delivery note or a signed proof, attached to an invoice or
+// a sale. The content lives in file storage.
+type Attachment struct {
+	ID          uuid.UUID           `json:"id"`
+	TenantID    uuid.UUID           `json:"tenant_id"`
+	OwnerType   AttachmentOwnerType `json:"owner_type"`
+	OwnerID     uuid.UUID           `json:"owner_id"`
+	FileName    string              `json:"file_name"`
+	ContentType string              `json:"content_type"`
+	Size        int64               `json:"size"`
+	StoragePath string              `json:"-"`
+	UploadedBy  uuid.UUID           `json:"uploaded_by"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// NewAttachment creates an attachment for a file's content, validating its size and type. The
+// type is taken from the content rather than trusted from the client.
+func NewAttachment(tenantID uuid.UUID, ownerType AttachmentOwnerType, ownerID uuid.UUID, fileName string, data []byte, uploadedBy uuid.UUID) (*Attachment, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if err := ValidateAttachmentOwnerType(ownerType); err != nil {
+		return nil, err
+	}
+	if ownerID == uuid.Nil {
+		return nil, errors.NewValidationError("owner ID is required", "owner ID cannot be empty")
+	}
+
+	// Keep only the base name of paths sent by browsers on any platform
+	fileName = strings.TrimSpace(path.Base(strings.ReplaceAll(fileName, `\`, "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, errors.NewValidationError("file name is required", "file name cannot be empty")
+	}
+	if len(fileName) > maxAttachmentFileNameLength {
+		return nil, errors.NewValidationError("file name too long", "file name cannot exceed 255 characters")
+	}
+
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("file is required", "file cannot be empty")
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, errors.NewValidationError("file too large", "attachments cannot exceed 10 MB")
+	}
+
+	contentType := DetectAttachmentContentType(fileName, data)
+	if contentType == "" {
+		return nil, errors.NewValidationError("unsupported file type", "attachments must be PDF, PNG, JPEG, GIF, WebP, text, CSV, DOCX or XLSX files")
+	}
+
+	id := uuid.New()
+	return &Attachment{
+		ID:          id,
+		TenantID:    tenantID,
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StoragePath: "attachments/" + tenantID.String() + "/" + string(ownerType) + "/" + ownerID.String() + "/" + id.String() + strings.ToLower(filepath.Ext(fileName)),
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// BelongsTo reports whether the attachment is attached to a record
+func (a *Attachment) BelongsTo(ownerType AttachmentOwnerType, ownerID uuid.UUID) bool {
+	return a.OwnerType == ownerType && a.OwnerID == ownerID
+}
+
+// DetectAttachmentContentType returns the type of a file that can be attached, or "" if the
+// type is not accepted. Office documents and CSV files are told apart from other archives and
+// text by their extension.
+func DetectAttachmentContentType(fileName string, data []byte) string {
+	sniffed, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	ext := strings.ToLower(filepath.Ext(fileName))
+
+	switch {
+	case sniffed == "application/zip":
+		return attachmentArchiveTypes[ext]
+	case sniffed == "text/plain" && ext == ".csv":
+		return "text/csv"
+	case attachmentContentTypes[sniffed]:
+		return sniffed
+	default:
+		return ""
+	}
+}
+
+// ValidateAttachmentOwnerType validates an attachment owner type
+func ValidateAttachmentOwnerType(ownerType AttachmentOwnerType) error {
+	switch ownerType {
+	case AttachmentOwnerInvoice, AttachmentOwnerSale:
+		return nil
+	default:
+		return errors.NewValidationError("invalid attachment owner type", "attachments can only be added to invoices and sales")
+	}
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAttachment(t *testing.T) {
+	tenantID := uuid.New()
+	ownerID := uuid.New()
+	pdfData := []byte("%PDF-1.4\nsigned delivery note")
+
+	t.Run("valid attachment", func(t *testing.T) {
+		attachment, err := NewAttachment(tenantID, AttachmentOwnerInvoice, ownerID, `C:\Scans\Delivery Note.PDF`, pdfData, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Delivery Note.PDF", attachment.FileName)
+		assert.Equal(t, "application/pdf", attachment.ContentType)
+		assert.Equal(t, int64(len(pdfData)), attachment.Size)
+		assert.True(t, strings.HasSuffix(attachment.StoragePath, attachment.ID.String()+".pdf"))
+		assert.True(t, attachment.BelongsTo(AttachmentOwnerInvoice, ownerID))
+		assert.False(t, attachment.BelongsTo(AttachmentOwnerSale, ownerID))
+	})
+
+	t.Run("invalid owner type", func(t *testing.T) {
+		_, err := NewAttachment(tenantID, "quote", ownerID, "note.pdf", pdfData, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, err := NewAttachment(tenantID, AttachmentOwnerSale, ownerID, "note.pdf", nil, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		_, err := NewAttachment(tenantID, AttachmentOwnerSale, ownerID, "note.pdf", make([]byte, MaxAttachmentSize+1), uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := NewAttachment(tenantID, AttachmentOwnerSale, ownerID, "setup.exe", []byte("MZ\x90\x00\x03"), uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDetectAttachmentContentType(t *testing.T) {
+	zip := []byte("PK\x03\x04\x14\x00\x06\x00")
+
+	assert.Equal(t, "image/png", DetectAttachmentContentType("proof.png", []byte("\x89PNG\r\n\x1a\n")))
+	assert.Equal(t, "text/csv", DetectAttachmentContentType("items.csv", []byte("sku,qty\nA-1,2\n")))
+	assert.Equal(t, "text/plain", DetectAttachmentContentType("notes.txt", []byte("left at the front desk")))
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", DetectAttachmentContentType("Items.XLSX", zip))
+
+	// A renamed archive or executable is not accepted
+	assert.Empty(t, DetectAttachmentContentType("items.zip", zip))
+	assert.Empty(t, DetectAttachmentContentType("note.pdf", []byte("MZ\x90\x00\x03")))
+}
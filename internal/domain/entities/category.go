@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxCategoryDepth is the number of levels a category tree can have, top-level categories
+// being the first
+const MaxCategoryDepth = 5
+
+var (
+	// categorySlugPattern matches the slugs categories are addressed by in URLs
+	categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// nonSlugChars matches the runs of characters a slug replaces with a hyphen
+	nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Category represents a product category. Categories form a tree per tenant: a category
+// without a parent is a top-level category. Names are unique within a tenant, since
+// reports and margin floors group products by category name.
+type Category struct {
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`                  // e.g. "soft-drinks"
+	SortOrder int        `json:"sort_order"`            // Position among its siblings, lowest first
+	TaxRateID *uuid.UUID `json:"tax_rate_id,omitempty"` // Applies to its products and subcategories without their own
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	UpdatedBy uuid.UUID  `json:"updated_by"`
+}
+
+// CategoryNode is a category with its subcategories, in sort order
+type CategoryNode struct {
+	*Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// NewCategory creates a top-level category. The slug is derived from the name when empty.
+func NewCategory(tenantID uuid.UUID, name, slug string, sortOrder int, createdBy uuid.UUID) (*Category, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	category := &Category{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		CreatedAt: now,
+	}
+	if err := category.Update(name, slug, sortOrder, createdBy); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// Update renames the category and changes its slug and sort order. The slug is derived
+// from the name when empty.
+func (c *Category) Update(name, slug string, sortOrder int, updatedBy uuid.UUID) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("category name is required", "name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.NewValidationError("category name too long", "name cannot exceed 100 characters")
+	}
+
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		slug = Slugify(name)
+	}
+	if !categorySlugPattern.MatchString(slug) || len(slug) > 100 {
+		return errors.NewValidationError("invalid category slug", "slug must contain at most 100 lowercase letters and digits, separated by single hyphens")
+	}
+	if sortOrder < 0 {
+		return errors.NewValidationError("invalid sort order", "sort order cannot be negative")
+	}
+
+	c.Name = name
+	c.Slug = slug
+	c.SortOrder = sortOrder
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = updatedBy
+	return nil
+}
+
+// MoveTo makes the category a subcategory of the parent, or a top-level category when
+// parentID is nil. categories are all the tenant's categories; the parent must be one of
+// them, must not be the category or one of its subcategories, and the tree must not get
+// deeper than MaxCategoryDepth.
+func (c *Category) MoveTo(parentID *uuid.UUID, categories []*Category, updatedBy uuid.UUID) error {
+	if parentID != nil {
+		byID := make(map[uuid.UUID]*Category, len(categories))
+		for _, category := range categories {
+			byID[category.ID] = category
+		}
+
+		parent, ok := byID[*parentID]
+		if !ok || parent.TenantID != c.TenantID {
+			return errors.NewValidationError("unknown parent category", "parent category does not exist")
+		}
+
+		depth := 1
+		for ancestor := parent; ancestor != nil && depth <= len(categories); depth++ {
+			if ancestor.ID == c.ID {
+				return errors.NewValidationError("invalid parent category", "a category cannot be moved under itself or one of its subcategories")
+			}
+			if ancestor.ParentID == nil {
+				break
+			}
+			ancestor = byID[*ancestor.ParentID]
+		}
+
+		if depth+categoryHeight(c.ID, categories) > MaxCategoryDepth {
+			return errors.NewValidationError("category tree too deep", "categories cannot be nested more than 5 levels deep")
+		}
+
+		id := *parentID
+		parentID = &id
+	}
+
+	c.ParentID = parentID
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = updatedBy
+	return nil
+}
+
+// SetTaxRate taxes the category's products at the tax rate, or clears the category's rate
+// when taxRate is nil
+func (c *Category) SetTaxRate(taxRate *TaxRate, updatedBy uuid.UUID) error {
+	if taxRate != nil {
+		if err := taxRate.checkAssignable(c.TenantID); err != nil {
+			return err
+		}
+		id := taxRate.ID
+		c.TaxRateID = &id
+	} else {
+		c.TaxRateID = nil
+	}
+
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = updatedBy
+	return nil
+}
+
+// Slugify derives a slug from a name, e.g. "Soft Drinks & Juices" becomes
+// "soft-drinks-juices". Names without letters or digits have no slug.
+func Slugify(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// BuildCategoryTree arranges categories into trees under their top-level categories, each
+// level in sort order and then by name. Categories whose parent is missing are placed at
+// the top level.
+func BuildCategoryTree(categories []*Category) []*CategoryNode {
+	nodes := make(map[uuid.UUID]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{Category: category, Children: []*CategoryNode{}}
+	}
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	sortCategoryNodes(roots)
+	return roots
+}
+
+// CategorySubtree returns the IDs of a category and all its subcategories
+func CategorySubtree(categoryID uuid.UUID, categories []*Category) map[uuid.UUID]bool {
+	ids := map[uuid.UUID]bool{categoryID: true}
+	for added := true; added; {
+		added = false
+		for _, category := range categories {
+			if category.ParentID != nil && ids[*category.ParentID] && !ids[category.ID] {
+				ids[category.ID] = true
+				added = true
+			}
+		}
+	}
+	return ids
+}
+
+// categoryHeight counts the levels of the category's subtree, the category included
+func categoryHeight(categoryID uuid.UUID, categories []*Category) int {
+	height := 0
+	for _, category := range categories {
+		if category.ParentID != nil && *category.ParentID == categoryID {
+			if h := categoryHeight(category.ID, categories); h > height {
+				height = h
+			}
+		}
+	}
+	return height + 1
+}
+
+func sortCategoryNodes(nodes []*CategoryNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].SortOrder != nodes[j].SortOrder {
+			return nodes[i].SortOrder < nodes[j].SortOrder
+		}
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, node := range nodes {
+		sortCategoryNodes(node.Children)
+	}
+}
@@ -0,0 +1,238 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "soft-drinks-juices", Slugify("Soft Drinks & Juices"))
+	assert.Equal(t, "t-shirts", Slugify("  T-Shirts!  "))
+	assert.Equal(t, "", Slugify("¿?"))
+}
+
+func TestNewCategory(t *testing.T) {
+	t.Run("valid category creation", func(t *testing.T) {
+		tenantID := uuid.New()
+
+		category, err := NewCategory(tenantID, " Soft Drinks ", "", 2, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, category.TenantID)
+		assert.Equal(t, "Soft Drinks", category.Name)
+		assert.Equal(t, "soft-drinks", category.Slug)
+		assert.Equal(t, 2, category.SortOrder)
+		assert.Nil(t, category.ParentID)
+	})
+
+	t.Run("explicit slug", func(t *testing.T) {
+		category, err := NewCategory(uuid.New(), "Minuman Ringan", "soft-drinks", 0, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "soft-drinks", category.Slug)
+	})
+
+	t.Run("invalid slug", func(t *testing.T) {
+		_, err := NewCategory(uuid.New(), "Drinks", "Soft Drinks", 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid category slug")
+	})
+
+	t.Run("name without a slug needs an explicit one", func(t *testing.T) {
+		_, err := NewCategory(uuid.New(), "¿?", "", 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid category slug")
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		_, err := NewCategory(uuid.New(), "  ", "drinks", 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "category name is required")
+	})
+
+	t.Run("negative sort order", func(t *testing.T) {
+		_, err := NewCategory(uuid.New(), "Drinks", "", -1, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid sort order")
+	})
+}
+
+func TestCategory_MoveTo(t *testing.T) {
+	tenantID := uuid.New()
+	newCategory := func(name string, parent *Category) *Category {
+		category, err := NewCategory(tenantID, name, "", 0, uuid.New())
+		require.NoError(t, err)
+		if parent != nil {
+			category.ParentID = &parent.ID
+		}
+		return category
+	}
+
+	drinks := newCategory("Drinks", nil)
+	soft := newCategory("Soft Drinks", drinks)
+	cola := newCategory("Cola", soft)
+	snacks := newCategory("Snacks", nil)
+	categories := []*Category{drinks, soft, cola, snacks}
+
+	t.Run("move under another category", func(t *testing.T) {
+		category := *soft
+
+		err := category.MoveTo(&snacks.ID, categories, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, snacks.ID, *category.ParentID)
+	})
+
+	t.Run("move to the top level", func(t *testing.T) {
+		category := *soft
+
+		err := category.MoveTo(nil, categories, uuid.New())
+
+		require.NoError(t, err)
+		assert.Nil(t, category.ParentID)
+	})
+
+	t.Run("cannot move under itself", func(t *testing.T) {
+		err := drinks.MoveTo(&drinks.ID, categories, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid parent category")
+	})
+
+	t.Run("cannot move under a subcategory", func(t *testing.T) {
+		err := drinks.MoveTo(&cola.ID, categories, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid parent category")
+		assert.Nil(t, drinks.ParentID)
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		parentID := uuid.New()
+
+		err := snacks.MoveTo(&parentID, categories, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown parent category")
+	})
+
+	t.Run("parent of another tenant", func(t *testing.T) {
+		other, err := NewCategory(uuid.New(), "Other", "", 0, uuid.New())
+		require.NoError(t, err)
+
+		err = snacks.MoveTo(&other.ID, append(categories, other), uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown parent category")
+	})
+
+	t.Run("tree too deep", func(t *testing.T) {
+		level4 := newCategory("Diet Cola", cola)
+		chips := newCategory("Chips", snacks)
+		crisps := newCategory("Potato Chips", chips)
+		all := append(categories, level4, chips, crisps)
+
+		// Snacks > Chips > Potato Chips under Diet Cola would be 7 levels deep
+		err := snacks.MoveTo(&level4.ID, all, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "category tree too deep")
+
+		// Potato Chips alone would be the fifth level
+		require.NoError(t, crisps.MoveTo(&level4.ID, all, uuid.New()))
+	})
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	tenantID := uuid.New()
+	newCategory := func(name string, sortOrder int, parent *Category) *Category {
+		category, err := NewCategory(tenantID, name, "", sortOrder, uuid.New())
+		require.NoError(t, err)
+		if parent != nil {
+			category.ParentID = &parent.ID
+		}
+		return category
+	}
+
+	drinks := newCategory("Drinks", 1, nil)
+	snacks := newCategory("Snacks", 0, nil)
+	water := newCategory("Water", 0, drinks)
+	juice := newCategory("Juice", 0, drinks)
+	coffee := newCategory("Coffee", 0, drinks)
+	coffee.SortOrder = 5
+	orphanParent := uuid.New()
+	orphan := newCategory("Orphan", 9, nil)
+	orphan.ParentID = &orphanParent
+
+	tree := BuildCategoryTree([]*Category{water, coffee, drinks, juice, orphan, snacks})
+
+	require.Len(t, tree, 3)
+	assert.Equal(t, "Snacks", tree[0].Name)
+	assert.Equal(t, "Drinks", tree[1].Name)
+	assert.Equal(t, "Orphan", tree[2].Name)
+	assert.Empty(t, tree[0].Children)
+
+	require.Len(t, tree[1].Children, 3)
+	assert.Equal(t, "Juice", tree[1].Children[0].Name)
+	assert.Equal(t, "Water", tree[1].Children[1].Name)
+	assert.Equal(t, "Coffee", tree[1].Children[2].Name)
+}
+
+func TestCategorySubtree(t *testing.T) {
+	tenantID := uuid.New()
+	newCategory := func(name string, parent *Category) *Category {
+		category, err := NewCategory(tenantID, name, "", 0, uuid.New())
+		require.NoError(t, err)
+		if parent != nil {
+			category.ParentID = &parent.ID
+		}
+		return category
+	}
+
+	drinks := newCategory("Drinks", nil)
+	cola := newCategory("Cola", nil)
+	soft := newCategory("Soft Drinks", drinks)
+	cola.ParentID = &soft.ID // Listed before its parent
+	snacks := newCategory("Snacks", nil)
+
+	ids := CategorySubtree(drinks.ID, []*Category{cola, drinks, soft, snacks})
+
+	assert.Len(t, ids, 3)
+	assert.True(t, ids[cola.ID])
+	assert.False(t, ids[snacks.ID])
+}
+
+func TestProduct_SetCategory(t *testing.T) {
+	tenantID := uuid.New()
+	product, err := NewProduct(tenantID, "SKU001", "Cola", "", "Drinks", "pcs", decimal.NewFromInt(10), decimal.NewFromInt(5), 0, uuid.New())
+	require.NoError(t, err)
+
+	t.Run("files the product under the category", func(t *testing.T) {
+		category, err := NewCategory(tenantID, "Soft Drinks", "", 0, uuid.New())
+		require.NoError(t, err)
+
+		require.NoError(t, product.SetCategory(category))
+
+		assert.Equal(t, category.ID, *product.CategoryID)
+		assert.Equal(t, "Soft Drinks", product.Category)
+	})
+
+	t.Run("category of another tenant", func(t *testing.T) {
+		category, err := NewCategory(uuid.New(), "Other", "", 0, uuid.New())
+		require.NoError(t, err)
+
+		err = product.SetCategory(category)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown category")
+		assert.Equal(t, "Soft Drinks", product.Category)
+	})
+}
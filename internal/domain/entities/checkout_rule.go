@@ -0,0 +1,323 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// CheckoutRuleField represents a checkout fact that a rule condition can inspect
+type CheckoutRuleField string
+
+const (
+	CheckoutFieldSaleTotal             CheckoutRuleField = "sale.total_amount"
+	CheckoutFieldSaleSubtotal          CheckoutRuleField = "sale.subtotal"
+	CheckoutFieldSaleDiscount          CheckoutRuleField = "sale.discount_amount"
+	CheckoutFieldSaleItemCount         CheckoutRuleField = "sale.item_count"
+	CheckoutFieldSalePaymentMethod     CheckoutRuleField = "sale.payment_method"
+	CheckoutFieldSaleChannel           CheckoutRuleField = "sale.channel"
+	CheckoutFieldCustomerName          CheckoutRuleField = "customer.name"
+	CheckoutFieldCustomerEmail         CheckoutRuleField = "customer.email"
+	CheckoutFieldCustomerPhone         CheckoutRuleField = "customer.phone"
+	CheckoutFieldCustomerOverdueCount  CheckoutRuleField = "customer.overdue_invoice_count"
+	CheckoutFieldCustomerOverdueAmount CheckoutRuleField = "customer.overdue_invoice_amount"
+)
+
+// CheckoutRuleOperator represents a comparison operator in a rule condition
+type CheckoutRuleOperator string
+
+const (
+	CheckoutOperatorEquals             CheckoutRuleOperator = "eq"
+	CheckoutOperatorNotEquals          CheckoutRuleOperator = "neq"
+	CheckoutOperatorGreaterThan        CheckoutRuleOperator = "gt"
+	CheckoutOperatorGreaterThanOrEqual CheckoutRuleOperator = "gte"
+	CheckoutOperatorLessThan           CheckoutRuleOperator = "lt"
+	CheckoutOperatorLessThanOrEqual    CheckoutRuleOperator = "lte"
+	CheckoutOperatorIsEmpty            CheckoutRuleOperator = "empty"
+	CheckoutOperatorIsNotEmpty         CheckoutRuleOperator = "not_empty"
+)
+
+// CheckoutRuleCondition represents a single comparison against a checkout fact
+type CheckoutRuleCondition struct {
+	Field    CheckoutRuleField    `json:"field"`
+	Operator CheckoutRuleOperator `json:"operator"`
+	Value    string               `json:"value,omitempty"`
+}
+
+// CheckoutRule represents a tenant-defined validation evaluated when a sale is completed.
+// The sale is blocked when all of the rule's conditions match.
+type CheckoutRule struct {
+	ID          uuid.UUID               `json:"id"`
+	TenantID    uuid.UUID               `json:"tenant_id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	Conditions  []CheckoutRuleCondition `json:"conditions"`
+	Message     string                  `json:"message"` // Shown to the cashier when the rule blocks a sale
+	IsActive    bool                    `json:"is_active"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+	CreatedBy   uuid.UUID               `json:"created_by"`
+}
+
+// CheckoutContext holds the facts a checkout rule is evaluated against
+type CheckoutContext struct {
+	Sale                 *Sale
+	OverdueInvoiceCount  int
+	OverdueInvoiceAmount decimal.Decimal
+}
+
+// CheckoutRuleViolation describes a rule that blocked a sale
+type CheckoutRuleViolation struct {
+	RuleID   uuid.UUID `json:"rule_id"`
+	RuleName string    `json:"rule_name"`
+	Message  string    `json:"message"`
+}
+
+// NewCheckoutRule creates a new checkout rule
+func NewCheckoutRule(tenantID uuid.UUID, name, description, message string, conditions []CheckoutRuleCondition, createdBy uuid.UUID) (*CheckoutRule, error) {
+	if err := validateCheckoutRuleInput(name, message, conditions); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule := &CheckoutRule{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		Conditions:  conditions,
+		Message:     strings.TrimSpace(message),
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   createdBy,
+	}
+
+	return rule, nil
+}
+
+// Update updates the checkout rule definition
+func (r *CheckoutRule) Update(name, description, message string, conditions []CheckoutRuleCondition) error {
+	if err := validateCheckoutRuleInput(name, message, conditions); err != nil {
+		return err
+	}
+
+	r.Name = strings.TrimSpace(name)
+	r.Description = strings.TrimSpace(description)
+	r.Message = strings.TrimSpace(message)
+	r.Conditions = conditions
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Activate enables the checkout rule
+func (r *CheckoutRule) Activate() {
+	r.IsActive = true
+	r.UpdatedAt = time.Now()
+}
+
+// Deactivate disables the checkout rule without deleting it
+func (r *CheckoutRule) Deactivate() {
+	r.IsActive = false
+	r.UpdatedAt = time.Now()
+}
+
+// UsesField checks if any of the rule's conditions inspect the given field
+func (r *CheckoutRule) UsesField(field CheckoutRuleField) bool {
+	for _, condition := range r.Conditions {
+		if condition.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if all of the rule's conditions hold for the checkout context
+func (r *CheckoutRule) Matches(checkout CheckoutContext) bool {
+	for _, condition := range r.Conditions {
+		if !condition.Matches(checkout) {
+			return false
+		}
+	}
+	return len(r.Conditions) > 0
+}
+
+// Matches checks if the condition holds for the checkout context
+func (c CheckoutRuleCondition) Matches(checkout CheckoutContext) bool {
+	if isNumericCheckoutField(c.Field) {
+		actual := checkoutNumericFact(c.Field, checkout)
+		switch c.Operator {
+		case CheckoutOperatorIsEmpty:
+			return actual.IsZero()
+		case CheckoutOperatorIsNotEmpty:
+			return !actual.IsZero()
+		}
+
+		expected, err := decimal.NewFromString(strings.TrimSpace(c.Value))
+		if err != nil {
+			return false
+		}
+		switch c.Operator {
+		case CheckoutOperatorEquals:
+			return actual.Equal(expected)
+		case CheckoutOperatorNotEquals:
+			return !actual.Equal(expected)
+		case CheckoutOperatorGreaterThan:
+			return actual.GreaterThan(expected)
+		case CheckoutOperatorGreaterThanOrEqual:
+			return actual.GreaterThanOrEqual(expected)
+		case CheckoutOperatorLessThan:
+			return actual.LessThan(expected)
+		case CheckoutOperatorLessThanOrEqual:
+			return actual.LessThanOrEqual(expected)
+		}
+		return false
+	}
+
+	actual := strings.TrimSpace(checkoutStringFact(c.Field, checkout))
+	switch c.Operator {
+	case CheckoutOperatorEquals:
+		return strings.EqualFold(actual, strings.TrimSpace(c.Value))
+	case CheckoutOperatorNotEquals:
+		return !strings.EqualFold(actual, strings.TrimSpace(c.Value))
+	case CheckoutOperatorIsEmpty:
+		return actual == ""
+	case CheckoutOperatorIsNotEmpty:
+		return actual != ""
+	}
+	return false
+}
+
+// EvaluateCheckoutRules evaluates active rules against a checkout and returns the violations
+func EvaluateCheckoutRules(rules []*CheckoutRule, checkout CheckoutContext) []CheckoutRuleViolation {
+	var violations []CheckoutRuleViolation
+	for _, rule := range rules {
+		if !rule.IsActive || !rule.Matches(checkout) {
+			continue
+		}
+		violations = append(violations, CheckoutRuleViolation{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Message:  rule.Message,
+		})
+	}
+	return violations
+}
+
+// ValidateCheckoutRuleCondition validates a checkout rule condition
+func ValidateCheckoutRuleCondition(condition CheckoutRuleCondition) error {
+	switch condition.Field {
+	case CheckoutFieldSaleTotal, CheckoutFieldSaleSubtotal, CheckoutFieldSaleDiscount, CheckoutFieldSaleItemCount,
+		CheckoutFieldSalePaymentMethod, CheckoutFieldSaleChannel, CheckoutFieldCustomerName, CheckoutFieldCustomerEmail,
+		CheckoutFieldCustomerPhone, CheckoutFieldCustomerOverdueCount, CheckoutFieldCustomerOverdueAmount:
+	default:
+		return errors.NewValidationError("invalid rule field", "field must be one of: sale.total_amount, sale.subtotal, sale.discount_amount, sale.item_count, sale.payment_method, sale.channel, customer.name, customer.email, customer.phone, customer.overdue_invoice_count, customer.overdue_invoice_amount")
+	}
+
+	switch condition.Operator {
+	case CheckoutOperatorIsEmpty, CheckoutOperatorIsNotEmpty:
+		return nil
+	case CheckoutOperatorEquals, CheckoutOperatorNotEquals:
+		if strings.TrimSpace(condition.Value) == "" {
+			return errors.NewValidationError("rule value is required", "value cannot be empty for operator "+string(condition.Operator))
+		}
+	case CheckoutOperatorGreaterThan, CheckoutOperatorGreaterThanOrEqual, CheckoutOperatorLessThan, CheckoutOperatorLessThanOrEqual:
+		if !isNumericCheckoutField(condition.Field) {
+			return errors.NewValidationError("invalid rule operator", "operator "+string(condition.Operator)+" can only be used with numeric fields")
+		}
+	default:
+		return errors.NewValidationError("invalid rule operator", "operator must be one of: eq, neq, gt, gte, lt, lte, empty, not_empty")
+	}
+
+	if isNumericCheckoutField(condition.Field) {
+		if _, err := decimal.NewFromString(strings.TrimSpace(condition.Value)); err != nil {
+			return errors.NewValidationError("invalid rule value", "value for field "+string(condition.Field)+" must be a number")
+		}
+	}
+
+	return nil
+}
+
+// validateCheckoutRuleInput validates checkout rule input
+func validateCheckoutRuleInput(name, message string, conditions []CheckoutRuleCondition) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.NewValidationError("rule name is required", "name cannot be empty")
+	}
+	if len(name) > 255 {
+		return errors.NewValidationError("rule name too long", "name cannot exceed 255 characters")
+	}
+	if strings.TrimSpace(message) == "" {
+		return errors.NewValidationError("rule message is required", "message cannot be empty")
+	}
+	if len(conditions) == 0 {
+		return errors.NewValidationError("rule conditions are required", "at least one condition must be defined")
+	}
+	for _, condition := range conditions {
+		if err := ValidateCheckoutRuleCondition(condition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNumericCheckoutField checks if a field holds a numeric fact
+func isNumericCheckoutField(field CheckoutRuleField) bool {
+	switch field {
+	case CheckoutFieldSaleTotal, CheckoutFieldSaleSubtotal, CheckoutFieldSaleDiscount, CheckoutFieldSaleItemCount,
+		CheckoutFieldCustomerOverdueCount, CheckoutFieldCustomerOverdueAmount:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkoutNumericFact resolves a numeric fact from the checkout context
+func checkoutNumericFact(field CheckoutRuleField, checkout CheckoutContext) decimal.Decimal {
+	switch field {
+	case CheckoutFieldCustomerOverdueCount:
+		return decimal.NewFromInt(int64(checkout.OverdueInvoiceCount))
+	case CheckoutFieldCustomerOverdueAmount:
+		return checkout.OverdueInvoiceAmount
+	}
+
+	if checkout.Sale == nil {
+		return decimal.Zero
+	}
+	switch field {
+	case CheckoutFieldSaleTotal:
+		return checkout.Sale.TotalAmount
+	case CheckoutFieldSaleSubtotal:
+		return checkout.Sale.Subtotal
+	case CheckoutFieldSaleDiscount:
+		return checkout.Sale.DiscountAmount
+	case CheckoutFieldSaleItemCount:
+		return decimal.NewFromInt(int64(checkout.Sale.GetItemCount()))
+	default:
+		return decimal.Zero
+	}
+}
+
+// checkoutStringFact resolves a text fact from the checkout context
+func checkoutStringFact(field CheckoutRuleField, checkout CheckoutContext) string {
+	if checkout.Sale == nil {
+		return ""
+	}
+	switch field {
+	case CheckoutFieldSalePaymentMethod:
+		return string(checkout.Sale.PaymentMethod)
+	case CheckoutFieldSaleChannel:
+		return string(checkout.Sale.Channel)
+	case CheckoutFieldCustomerName:
+		return checkout.Sale.CustomerName
+	case CheckoutFieldCustomerEmail:
+		return checkout.Sale.CustomerEmail
+	case CheckoutFieldCustomerPhone:
+		return checkout.Sale.CustomerPhone
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,208 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCheckoutRule(t *testing.T) {
+	t.Run("valid checkout rule creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+		conditions := []CheckoutRuleCondition{
+			{Field: CheckoutFieldCustomerOverdueCount, Operator: CheckoutOperatorGreaterThan, Value: "0"},
+		}
+
+		rule, err := NewCheckoutRule(tenantID, " Overdue customers ", "Block overdue customers", "Customer has overdue invoices", conditions, createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, rule.ID)
+		assert.Equal(t, tenantID, rule.TenantID)
+		assert.Equal(t, "Overdue customers", rule.Name)
+		assert.Equal(t, "Customer has overdue invoices", rule.Message)
+		assert.Equal(t, conditions, rule.Conditions)
+		assert.True(t, rule.IsActive)
+		assert.Equal(t, createdBy, rule.CreatedBy)
+		assert.WithinDuration(t, time.Now(), rule.CreatedAt, time.Second)
+	})
+
+	t.Run("invalid name - empty", func(t *testing.T) {
+		conditions := []CheckoutRuleCondition{
+			{Field: CheckoutFieldSaleTotal, Operator: CheckoutOperatorGreaterThan, Value: "100"},
+		}
+
+		rule, err := NewCheckoutRule(uuid.New(), "", "", "message", conditions, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, rule)
+		assert.Contains(t, err.Error(), "rule name is required")
+	})
+
+	t.Run("invalid message - empty", func(t *testing.T) {
+		conditions := []CheckoutRuleCondition{
+			{Field: CheckoutFieldSaleTotal, Operator: CheckoutOperatorGreaterThan, Value: "100"},
+		}
+
+		rule, err := NewCheckoutRule(uuid.New(), "Large sale", "", " ", conditions, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, rule)
+		assert.Contains(t, err.Error(), "rule message is required")
+	})
+
+	t.Run("invalid conditions - empty", func(t *testing.T) {
+		rule, err := NewCheckoutRule(uuid.New(), "Large sale", "", "message", nil, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, rule)
+		assert.Contains(t, err.Error(), "rule conditions are required")
+	})
+}
+
+func TestCheckoutRule_Update(t *testing.T) {
+	rule := createValidCheckoutRule(t)
+	conditions := []CheckoutRuleCondition{
+		{Field: CheckoutFieldSaleChannel, Operator: CheckoutOperatorEquals, Value: "online"},
+	}
+
+	t.Run("valid update", func(t *testing.T) {
+		err := rule.Update("Online orders", "Online only", "Online orders need review", conditions)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Online orders", rule.Name)
+		assert.Equal(t, "Online only", rule.Description)
+		assert.Equal(t, "Online orders need review", rule.Message)
+		assert.Equal(t, conditions, rule.Conditions)
+	})
+
+	t.Run("invalid update keeps previous definition", func(t *testing.T) {
+		err := rule.Update("", "", "message", conditions)
+
+		assert.Error(t, err)
+		assert.Equal(t, "Online orders", rule.Name)
+	})
+}
+
+func TestCheckoutRule_ActivateDeactivate(t *testing.T) {
+	rule := createValidCheckoutRule(t)
+
+	rule.Deactivate()
+	assert.False(t, rule.IsActive)
+
+	rule.Activate()
+	assert.True(t, rule.IsActive)
+}
+
+func TestCheckoutRule_Matches(t *testing.T) {
+	sale := createSaleWithItems(t)
+
+	t.Run("overdue invoices above threshold", func(t *testing.T) {
+		rule := createValidCheckoutRule(t)
+
+		assert.True(t, rule.Matches(CheckoutContext{Sale: sale, OverdueInvoiceCount: 2}))
+		assert.False(t, rule.Matches(CheckoutContext{Sale: sale, OverdueInvoiceCount: 0}))
+	})
+
+	t.Run("require phone for totals above threshold", func(t *testing.T) {
+		rule, err := NewCheckoutRule(uuid.New(), "Phone for large sales", "", "Customer phone is required for sales above 1000", []CheckoutRuleCondition{
+			{Field: CheckoutFieldSaleTotal, Operator: CheckoutOperatorGreaterThan, Value: "1000"},
+			{Field: CheckoutFieldCustomerPhone, Operator: CheckoutOperatorIsEmpty},
+		}, uuid.New())
+		require.NoError(t, err)
+
+		assert.False(t, rule.Matches(CheckoutContext{Sale: sale}))
+
+		withoutPhone := *sale
+		withoutPhone.CustomerPhone = ""
+		assert.True(t, rule.Matches(CheckoutContext{Sale: &withoutPhone}))
+
+		smallSale := withoutPhone
+		smallSale.TotalAmount = decimal.NewFromInt(500)
+		assert.False(t, rule.Matches(CheckoutContext{Sale: &smallSale}))
+	})
+
+	t.Run("string comparison is case insensitive", func(t *testing.T) {
+		condition := CheckoutRuleCondition{Field: CheckoutFieldSaleChannel, Operator: CheckoutOperatorEquals, Value: "IN_STORE"}
+
+		assert.True(t, condition.Matches(CheckoutContext{Sale: sale}))
+	})
+
+	t.Run("item count comparison", func(t *testing.T) {
+		condition := CheckoutRuleCondition{Field: CheckoutFieldSaleItemCount, Operator: CheckoutOperatorGreaterThanOrEqual, Value: "3"}
+
+		assert.True(t, condition.Matches(CheckoutContext{Sale: sale}))
+	})
+}
+
+func TestEvaluateCheckoutRules(t *testing.T) {
+	sale := createSaleWithItems(t)
+	overdueRule := createValidCheckoutRule(t)
+	inactiveRule := createValidCheckoutRule(t)
+	inactiveRule.Deactivate()
+
+	violations := EvaluateCheckoutRules([]*CheckoutRule{overdueRule, inactiveRule}, CheckoutContext{
+		Sale:                 sale,
+		OverdueInvoiceCount:  1,
+		OverdueInvoiceAmount: decimal.NewFromInt(250),
+	})
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, overdueRule.ID, violations[0].RuleID)
+	assert.Equal(t, overdueRule.Name, violations[0].RuleName)
+	assert.Equal(t, overdueRule.Message, violations[0].Message)
+
+	assert.Empty(t, EvaluateCheckoutRules([]*CheckoutRule{overdueRule}, CheckoutContext{Sale: sale}))
+}
+
+func TestValidateCheckoutRuleCondition(t *testing.T) {
+	testCases := []struct {
+		name          string
+		condition     CheckoutRuleCondition
+		expectedError bool
+	}{
+		{"valid numeric comparison", CheckoutRuleCondition{Field: CheckoutFieldSaleTotal, Operator: CheckoutOperatorGreaterThan, Value: "1000000"}, false},
+		{"valid decimal value", CheckoutRuleCondition{Field: CheckoutFieldCustomerOverdueAmount, Operator: CheckoutOperatorGreaterThanOrEqual, Value: "99.50"}, false},
+		{"valid string equality", CheckoutRuleCondition{Field: CheckoutFieldSalePaymentMethod, Operator: CheckoutOperatorEquals, Value: "credit"}, false},
+		{"valid empty check", CheckoutRuleCondition{Field: CheckoutFieldCustomerPhone, Operator: CheckoutOperatorIsEmpty}, false},
+		{"invalid field", CheckoutRuleCondition{Field: "customer.age", Operator: CheckoutOperatorEquals, Value: "1"}, true},
+		{"invalid operator", CheckoutRuleCondition{Field: CheckoutFieldSaleTotal, Operator: "between", Value: "1"}, true},
+		{"ordering operator on text field", CheckoutRuleCondition{Field: CheckoutFieldCustomerName, Operator: CheckoutOperatorGreaterThan, Value: "a"}, true},
+		{"non numeric value for numeric field", CheckoutRuleCondition{Field: CheckoutFieldSaleTotal, Operator: CheckoutOperatorLessThan, Value: "lots"}, true},
+		{"missing value for equality", CheckoutRuleCondition{Field: CheckoutFieldSaleChannel, Operator: CheckoutOperatorEquals}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCheckoutRuleCondition(tc.condition)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func createValidCheckoutRule(t *testing.T) *CheckoutRule {
+	rule, err := NewCheckoutRule(
+		uuid.New(),
+		"Overdue customers",
+		"Block customers with unpaid overdue invoices",
+		"Customer has overdue invoices",
+		[]CheckoutRuleCondition{
+			{Field: CheckoutFieldCustomerOverdueCount, Operator: CheckoutOperatorGreaterThan, Value: "0"},
+		},
+		uuid.New(),
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+
+	return rule
+}
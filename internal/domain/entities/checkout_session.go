@@ -0,0 +1,231 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// CheckoutSessionIdleTimeout is how long a checkout session stays open without any step
+// being taken
+const CheckoutSessionIdleTimeout = 30 * time.Minute
+
+// CheckoutSessionStatus represents the status of a checkout session
+type CheckoutSessionStatus string
+
+const (
+	CheckoutSessionStatusActive    CheckoutSessionStatus = "active"
+	CheckoutSessionStatusCompleted CheckoutSessionStatus = "completed"
+	CheckoutSessionStatusCancelled CheckoutSessionStatus = "cancelled"
+	CheckoutSessionStatusExpired   CheckoutSessionStatus = "expired"
+)
+
+// CheckoutStep represents how far a checkout session has progressed
+type CheckoutStep string
+
+const (
+	CheckoutStepCart    CheckoutStep = "cart"    // Building the cart and attaching the customer
+	CheckoutStepReview  CheckoutStep = "review"  // Promotions and checkout rules evaluated
+	CheckoutStepPayment CheckoutStep = "payment" // Payment intent created, ready to complete
+	CheckoutStepDone    CheckoutStep = "done"
+)
+
+// CheckoutSession walks a pending sale through checkout in explicit steps: building the cart,
+// attaching the customer, evaluating promotions and checkout rules, creating a payment intent
+// and completing the sale. The server holds the state between steps, so a client that loses
+// its connection can resume where it left off. Changing the cart or customer sends the session
+// back to the cart step, as promotions, rules and the amount due have to be evaluated again.
+type CheckoutSession struct {
+	ID            uuid.UUID              `json:"id"`
+	TenantID      uuid.UUID              `json:"tenant_id"`
+	SaleID        uuid.UUID              `json:"sale_id"`
+	Status        CheckoutSessionStatus  `json:"status"`
+	Step          CheckoutStep           `json:"step"`
+	Evaluation    *CheckoutEvaluation    `json:"evaluation,omitempty"`
+	PaymentIntent *CheckoutPaymentIntent `json:"payment_intent,omitempty"`
+	ExpiresAt     time.Time              `json:"expires_at"` // Pushed back by every step
+	CompletedAt   *time.Time             `json:"completed_at,omitempty"`
+	CreatedBy     uuid.UUID              `json:"created_by"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// CheckoutEvaluation records the promotions and checkout rules evaluated against the cart
+type CheckoutEvaluation struct {
+	RepricedItems []SaleItemRepricing     `json:"repriced_items"` // Items whose promotion started or ended since they were added
+	Violations    []CheckoutRuleViolation `json:"violations"`     // Checkout rules blocking the sale
+	Subtotal      decimal.Decimal         `json:"subtotal"`
+	EvaluatedAt   time.Time               `json:"evaluated_at"`
+}
+
+// SaleItemRepricing describes a sale item charged at a different price after its product's
+// promotion was evaluated again
+type SaleItemRepricing struct {
+	ProductID     uuid.UUID           `json:"product_id"`
+	ProductName   string              `json:"product_name"`
+	PreviousPrice decimal.Decimal     `json:"previous_price"`
+	UnitPrice     decimal.Decimal     `json:"unit_price"`
+	PriceSource   SaleItemPriceSource `json:"price_source"`
+}
+
+// CheckoutPaymentIntent records how the customer intends to pay and the amount due, checked
+// against the cart before the sale is completed with it
+type CheckoutPaymentIntent struct {
+	PaymentMethod  PaymentMethod   `json:"payment_method,omitempty"`
+	PaidAmount     decimal.Decimal `json:"paid_amount"`
+	Payments       []PaymentLine   `json:"payments,omitempty"`
+	DiscountAmount decimal.Decimal `json:"discount_amount"`
+	CouponCode     string          `json:"coupon_code,omitempty"`
+	TaxPercentage  decimal.Decimal `json:"tax_percentage"`
+	Notes          string          `json:"notes,omitempty"`
+	AmountDue      decimal.Decimal `json:"amount_due"` // Sale total including tax and surcharges
+	ChangeAmount   decimal.Decimal `json:"change_amount"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// NewCheckoutSession starts a checkout session for a pending sale
+func NewCheckoutSession(tenantID, saleID, createdBy uuid.UUID, now time.Time) (*CheckoutSession, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if saleID == uuid.Nil {
+		return nil, errors.NewValidationError("sale ID is required", "sale ID cannot be empty")
+	}
+
+	return &CheckoutSession{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		SaleID:    saleID,
+		Status:    CheckoutSessionStatusActive,
+		Step:      CheckoutStepCart,
+		ExpiresAt: now.Add(CheckoutSessionIdleTimeout),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsExpired checks if an active session has been idle for too long
+func (c *CheckoutSession) IsExpired(now time.Time) bool {
+	return c.Status == CheckoutSessionStatusExpired ||
+		(c.Status == CheckoutSessionStatusActive && now.After(c.ExpiresAt))
+}
+
+// CheckActive checks a step can be taken on the session
+func (c *CheckoutSession) CheckActive(now time.Time) error {
+	if c.IsExpired(now) {
+		return errors.NewValidationError("checkout session expired", "checkout session has expired, start a new one")
+	}
+
+	switch c.Status {
+	case CheckoutSessionStatusCompleted:
+		return errors.NewValidationError("checkout session completed", "checkout session has already been completed")
+	case CheckoutSessionStatusCancelled:
+		return errors.NewValidationError("checkout session cancelled", "checkout session has been cancelled")
+	}
+	return nil
+}
+
+// ResetToCart sends the session back to the cart step after the cart or customer changed,
+// discarding the evaluation and payment intent
+func (c *CheckoutSession) ResetToCart(now time.Time) error {
+	if err := c.CheckActive(now); err != nil {
+		return err
+	}
+
+	c.Step = CheckoutStepCart
+	c.Evaluation = nil
+	c.PaymentIntent = nil
+	c.touch(now)
+	return nil
+}
+
+// RecordEvaluation records the promotions and checkout rules evaluated against the cart,
+// discarding any earlier payment intent
+func (c *CheckoutSession) RecordEvaluation(evaluation CheckoutEvaluation, now time.Time) error {
+	if err := c.CheckActive(now); err != nil {
+		return err
+	}
+
+	evaluation.EvaluatedAt = now
+	c.Step = CheckoutStepReview
+	c.Evaluation = &evaluation
+	c.PaymentIntent = nil
+	c.touch(now)
+	return nil
+}
+
+// SetPaymentIntent records how the customer intends to pay. The cart must have been evaluated
+// without checkout rules blocking it.
+func (c *CheckoutSession) SetPaymentIntent(intent CheckoutPaymentIntent, now time.Time) error {
+	if err := c.CheckActive(now); err != nil {
+		return err
+	}
+	if c.Evaluation == nil {
+		return errors.NewValidationError("checkout not evaluated", "evaluate the cart before creating a payment intent")
+	}
+	if len(c.Evaluation.Violations) > 0 {
+		messages := make([]string, len(c.Evaluation.Violations))
+		for i, violation := range c.Evaluation.Violations {
+			messages[i] = violation.Message
+		}
+		return errors.NewRuleViolationError("sale blocked by checkout rules", strings.Join(messages, "; "))
+	}
+	if len(intent.Notes) > 500 {
+		return errors.NewValidationError("notes too long", "notes cannot exceed 500 characters")
+	}
+
+	intent.CreatedAt = now
+	c.Step = CheckoutStepPayment
+	c.PaymentIntent = &intent
+	c.touch(now)
+	return nil
+}
+
+// Complete marks the session completed once its sale has been completed with the payment
+// intent. The sale may have been completed just before the session went idle for too long.
+func (c *CheckoutSession) Complete(now time.Time) error {
+	if c.Status != CheckoutSessionStatusActive {
+		return errors.NewValidationError("invalid checkout session status", "only active checkout sessions can be completed")
+	}
+	if c.PaymentIntent == nil {
+		return errors.NewValidationError("no payment intent", "create a payment intent before completing the checkout")
+	}
+
+	c.Status = CheckoutSessionStatusCompleted
+	c.Step = CheckoutStepDone
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// Cancel abandons the session
+func (c *CheckoutSession) Cancel(now time.Time) error {
+	if c.Status != CheckoutSessionStatusActive {
+		return errors.NewValidationError("invalid checkout session status", "only active checkout sessions can be cancelled")
+	}
+
+	c.Status = CheckoutSessionStatusCancelled
+	c.UpdatedAt = now
+	return nil
+}
+
+// Expire marks an idle session expired
+func (c *CheckoutSession) Expire(now time.Time) error {
+	if c.Status != CheckoutSessionStatusActive || !now.After(c.ExpiresAt) {
+		return errors.NewValidationError("checkout session not expired", "only idle active checkout sessions can be expired")
+	}
+
+	c.Status = CheckoutSessionStatusExpired
+	c.UpdatedAt = now
+	return nil
+}
+
+func (c *CheckoutSession) touch(now time.Time) {
+	c.ExpiresAt = now.Add(CheckoutSessionIdleTimeout)
+	c.UpdatedAt = now
+}
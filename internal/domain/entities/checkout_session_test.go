@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCheckoutSession(t *testing.T) {
+	t.Run("valid session", func(t *testing.T) {
+		tenantID := uuid.New()
+		saleID := uuid.New()
+		userID := uuid.New()
+		now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+		session, err := NewCheckoutSession(tenantID, saleID, userID, now)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, session.ID)
+		assert.Equal(t, tenantID, session.TenantID)
+		assert.Equal(t, saleID, session.SaleID)
+		assert.Equal(t, CheckoutSessionStatusActive, session.Status)
+		assert.Equal(t, CheckoutStepCart, session.Step)
+		assert.Equal(t, now.Add(CheckoutSessionIdleTimeout), session.ExpiresAt)
+		assert.Equal(t, userID, session.CreatedBy)
+	})
+
+	t.Run("invalid sale ID", func(t *testing.T) {
+		session, err := NewCheckoutSession(uuid.New(), uuid.Nil, uuid.New(), time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+		assert.Contains(t, err.Error(), "sale ID is required")
+	})
+}
+
+func TestCheckoutSession_Steps(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("through to completion", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+
+		err := session.RecordEvaluation(CheckoutEvaluation{Subtotal: decimal.NewFromInt(100)}, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutStepReview, session.Step)
+		assert.Equal(t, now.Add(time.Minute), session.Evaluation.EvaluatedAt)
+
+		err = session.SetPaymentIntent(CheckoutPaymentIntent{PaymentMethod: PaymentMethodCash, AmountDue: decimal.NewFromInt(100)}, now.Add(2*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutStepPayment, session.Step)
+		assert.Equal(t, now.Add(2*time.Minute+CheckoutSessionIdleTimeout), session.ExpiresAt)
+
+		err = session.Complete(now.Add(3 * time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutSessionStatusCompleted, session.Status)
+		assert.Equal(t, CheckoutStepDone, session.Step)
+		require.NotNil(t, session.CompletedAt)
+
+		err = session.ResetToCart(now.Add(4 * time.Minute))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "checkout session completed")
+	})
+
+	t.Run("payment intent needs an evaluation", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+
+		err := session.SetPaymentIntent(CheckoutPaymentIntent{PaymentMethod: PaymentMethodCash}, now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "checkout not evaluated")
+	})
+
+	t.Run("payment intent blocked by checkout rules", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+		violations := []CheckoutRuleViolation{{RuleID: uuid.New(), RuleName: "No credit", Message: "Customer has overdue invoices"}}
+		require.NoError(t, session.RecordEvaluation(CheckoutEvaluation{Violations: violations}, now))
+
+		err := session.SetPaymentIntent(CheckoutPaymentIntent{PaymentMethod: PaymentMethodCash}, now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "sale blocked by checkout rules")
+		assert.Nil(t, session.PaymentIntent)
+	})
+
+	t.Run("changing the cart discards the evaluation and payment intent", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+		require.NoError(t, session.RecordEvaluation(CheckoutEvaluation{}, now))
+		require.NoError(t, session.SetPaymentIntent(CheckoutPaymentIntent{PaymentMethod: PaymentMethodCash}, now))
+
+		err := session.ResetToCart(now.Add(time.Minute))
+
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutStepCart, session.Step)
+		assert.Nil(t, session.Evaluation)
+		assert.Nil(t, session.PaymentIntent)
+	})
+
+	t.Run("complete needs a payment intent", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+		require.NoError(t, session.RecordEvaluation(CheckoutEvaluation{}, now))
+
+		err := session.Complete(now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no payment intent")
+	})
+}
+
+func TestCheckoutSession_Expiry(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("idle session cannot take steps", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+		idle := now.Add(CheckoutSessionIdleTimeout + time.Second)
+
+		assert.True(t, session.IsExpired(idle))
+		err := session.RecordEvaluation(CheckoutEvaluation{}, idle)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "checkout session expired")
+	})
+
+	t.Run("steps push back the expiry", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+		require.NoError(t, session.ResetToCart(now.Add(20*time.Minute)))
+
+		assert.False(t, session.IsExpired(now.Add(CheckoutSessionIdleTimeout+time.Second)))
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+
+		err := session.Expire(now.Add(time.Minute))
+		assert.Error(t, err)
+
+		err = session.Expire(now.Add(CheckoutSessionIdleTimeout + time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutSessionStatusExpired, session.Status)
+		assert.Error(t, session.Cancel(now))
+	})
+
+	t.Run("a sale completed just before expiry still completes the session", func(t *testing.T) {
+		session := createValidCheckoutSession(t, now)
+		require.NoError(t, session.RecordEvaluation(CheckoutEvaluation{}, now))
+		require.NoError(t, session.SetPaymentIntent(CheckoutPaymentIntent{PaymentMethod: PaymentMethodCash}, now))
+
+		err := session.Complete(now.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutSessionStatusCompleted, session.Status)
+	})
+}
+
+func createValidCheckoutSession(t *testing.T, now time.Time) *CheckoutSession {
+	session, err := NewCheckoutSession(uuid.New(), uuid.New(), uuid.New(), now)
+	require.NoError(t, err)
+	return session
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// CostMethod represents how the cost of sold units is worked out
+type CostMethod string
+
+const (
+	CostMethodMovingAverage CostMethod = "moving_average" // Units cost the product's average cost
+	CostMethodFIFO          CostMethod = "fifo"           // Units cost what the oldest units still on hand were bought at
+)
+
+// ValidateCostMethod validates a cost method
+func ValidateCostMethod(method CostMethod) error {
+	switch method {
+	case CostMethodMovingAverage, CostMethodFIFO:
+		return nil
+	default:
+		return errors.NewValidationError("invalid cost method", "cost method must be one of: moving_average, fifo")
+	}
+}
+
+// CostLayer represents units of a product received at the same unit cost. Layers are used up
+// oldest first as the units are sold when the tenant costs sales first in, first out.
+type CostLayer struct {
+	ID           uuid.UUID       `json:"id"`
+	TenantID     uuid.UUID       `json:"tenant_id"`
+	ProductID    uuid.UUID       `json:"product_id"`
+	Reference    string          `json:"reference"` // What brought the units in, e.g. a PO number
+	Quantity     int             `json:"quantity"`
+	RemainingQty int             `json:"remaining_qty"`
+	UnitCost     decimal.Decimal `json:"unit_cost"`
+	ReceivedAt   time.Time       `json:"received_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// NewCostLayer records units of a product received at a unit cost
+func NewCostLayer(tenantID, productID uuid.UUID, quantity int, unitCost decimal.Decimal, reference string) (*CostLayer, error) {
+	if quantity <= 0 {
+		return nil, errors.NewValidationError("invalid quantity", "quantity must be positive")
+	}
+	if unitCost.IsNegative() {
+		return nil, errors.NewValidationError("invalid unit cost", "unit cost cannot be negative")
+	}
+
+	now := time.Now()
+	return &CostLayer{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		ProductID:    productID,
+		Reference:    strings.TrimSpace(reference),
+		Quantity:     quantity,
+		RemainingQty: quantity,
+		UnitCost:     unitCost,
+		ReceivedAt:   now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// IsExhausted reports whether every unit of the layer has been used up
+func (l *CostLayer) IsExhausted() bool {
+	return l.RemainingQty <= 0
+}
+
+// ConsumeCostLayers uses up quantity units from layers, which must be ordered oldest first,
+// and returns the average unit cost of the units used. Units beyond what the layers hold, such
+// as stock counted in without a purchase, cost fallbackCost. The layers used are returned so
+// their remaining quantities can be saved.
+func ConsumeCostLayers(layers []*CostLayer, quantity int, fallbackCost decimal.Decimal) (decimal.Decimal, []*CostLayer) {
+	if quantity <= 0 {
+		return fallbackCost, nil
+	}
+
+	var consumed []*CostLayer
+	total := decimal.Zero
+	remaining := quantity
+	for _, layer := range layers {
+		if remaining == 0 {
+			break
+		}
+		if layer.IsExhausted() {
+			continue
+		}
+
+		take := layer.RemainingQty
+		if take > remaining {
+			take = remaining
+		}
+		layer.RemainingQty -= take
+		layer.UpdatedAt = time.Now()
+		remaining -= take
+		total = total.Add(layer.UnitCost.Mul(decimal.NewFromInt(int64(take))))
+		consumed = append(consumed, layer)
+	}
+
+	total = total.Add(fallbackCost.Mul(decimal.NewFromInt(int64(remaining))))
+	return total.Div(decimal.NewFromInt(int64(quantity))).Round(2), consumed
+}
+
+// ValuationMethod represents the unit cost inventory on hand is valued at
+type ValuationMethod string
+
+const (
+	ValuationMethodAverageCost ValuationMethod = "average_cost" // The product's average cost
+	ValuationMethodLatestCost  ValuationMethod = "latest_cost"  // What the product was last received at
+)
+
+// ValidateValuationMethod validates an inventory valuation method
+func ValidateValuationMethod(method ValuationMethod) error {
+	switch method {
+	case ValuationMethodAverageCost, ValuationMethodLatestCost:
+		return nil
+	default:
+		return errors.NewValidationError("invalid valuation method", "valuation method must be one of: average_cost, latest_cost")
+	}
+}
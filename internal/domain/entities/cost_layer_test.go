@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCostMethod(t *testing.T) {
+	assert.NoError(t, ValidateCostMethod(CostMethodMovingAverage))
+	assert.NoError(t, ValidateCostMethod(CostMethodFIFO))
+	assert.Error(t, ValidateCostMethod("lifo"))
+}
+
+func TestValidateValuationMethod(t *testing.T) {
+	assert.NoError(t, ValidateValuationMethod(ValuationMethodAverageCost))
+	assert.NoError(t, ValidateValuationMethod(ValuationMethodLatestCost))
+	assert.Error(t, ValidateValuationMethod("fifo"))
+}
+
+func TestNewCostLayer(t *testing.T) {
+	t.Run("valid layer", func(t *testing.T) {
+		layer, err := NewCostLayer(uuid.New(), uuid.New(), 10, decimal.NewFromInt(4), " PO-1 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "PO-1", layer.Reference)
+		assert.Equal(t, 10, layer.RemainingQty)
+		assert.False(t, layer.IsExhausted())
+	})
+
+	t.Run("invalid quantity", func(t *testing.T) {
+		_, err := NewCostLayer(uuid.New(), uuid.New(), 0, decimal.NewFromInt(4), "PO-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("negative cost", func(t *testing.T) {
+		_, err := NewCostLayer(uuid.New(), uuid.New(), 10, decimal.NewFromInt(-1), "PO-1")
+		assert.Error(t, err)
+	})
+}
+
+func TestConsumeCostLayers(t *testing.T) {
+	newLayers := func() []*CostLayer {
+		return []*CostLayer{
+			{ID: uuid.New(), Quantity: 5, RemainingQty: 2, UnitCost: decimal.NewFromInt(3)},
+			{ID: uuid.New(), Quantity: 5, RemainingQty: 5, UnitCost: decimal.NewFromInt(6)},
+		}
+	}
+
+	t.Run("uses the oldest layer first", func(t *testing.T) {
+		layers := newLayers()
+
+		unitCost, consumed := ConsumeCostLayers(layers, 2, decimal.NewFromInt(10))
+
+		assert.True(t, decimal.NewFromInt(3).Equal(unitCost))
+		assert.Len(t, consumed, 1)
+		assert.True(t, layers[0].IsExhausted())
+		assert.Equal(t, 5, layers[1].RemainingQty)
+	})
+
+	t.Run("spans layers", func(t *testing.T) {
+		layers := newLayers()
+
+		unitCost, consumed := ConsumeCostLayers(layers, 4, decimal.NewFromInt(10))
+
+		// 2 at 3 and 2 at 6
+		assert.True(t, decimal.NewFromFloat(4.5).Equal(unitCost))
+		assert.Len(t, consumed, 2)
+		assert.Equal(t, 3, layers[1].RemainingQty)
+	})
+
+	t.Run("costs units beyond the layers at the fallback", func(t *testing.T) {
+		layers := newLayers()
+
+		unitCost, consumed := ConsumeCostLayers(layers, 10, decimal.NewFromInt(10))
+
+		// 2 at 3, 5 at 6 and 3 at 10
+		assert.True(t, decimal.NewFromFloat(6.6).Equal(unitCost))
+		assert.Len(t, consumed, 2)
+		assert.True(t, layers[1].IsExhausted())
+	})
+
+	t.Run("skips exhausted layers", func(t *testing.T) {
+		layers := newLayers()
+		layers[0].RemainingQty = 0
+
+		unitCost, consumed := ConsumeCostLayers(layers, 1, decimal.NewFromInt(10))
+
+		assert.True(t, decimal.NewFromInt(6).Equal(unitCost))
+		assert.Len(t, consumed, 1)
+	})
+}
@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// CouponType represents how a coupon discount is calculated
+type CouponType string
+
+const (
+	CouponTypePercentage CouponType = "percentage"
+	CouponTypeFixed      CouponType = "fixed"
+)
+
+// couponCodePattern matches the characters allowed in a normalized coupon code
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Coupon represents a tenant's discount code customers present at checkout. A coupon is
+// redeemed at most once per sale, and at most MaxRedemptions times overall when set.
+type Coupon struct {
+	ID              uuid.UUID       `json:"id"`
+	TenantID        uuid.UUID       `json:"tenant_id"`
+	Code            string          `json:"code"` // Uppercase, unique per tenant
+	Type            CouponType      `json:"type"`
+	Value           decimal.Decimal `json:"value"`           // Percentage of the sale subtotal or fixed amount
+	MaxRedemptions  int             `json:"max_redemptions"` // Zero for unlimited
+	RedemptionCount int             `json:"redemption_count"`
+	ExpiresAt       *time.Time      `json:"expires_at,omitempty"`
+	IsActive        bool            `json:"is_active"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	UpdatedBy       uuid.UUID       `json:"updated_by"`
+}
+
+// CouponRedemption represents a coupon applied to a completed sale
+type CouponRedemption struct {
+	ID             uuid.UUID       `json:"id"`
+	TenantID       uuid.UUID       `json:"tenant_id"`
+	CouponID       uuid.UUID       `json:"coupon_id"`
+	SaleID         uuid.UUID       `json:"sale_id"`
+	Code           string          `json:"code"`
+	DiscountAmount decimal.Decimal `json:"discount_amount"`
+	SaleTotal      decimal.Decimal `json:"sale_total"` // Total the customer paid after the discount
+	RedeemedBy     uuid.UUID       `json:"redeemed_by"`
+	RedeemedAt     time.Time       `json:"redeemed_at"`
+}
+
+// NewCoupon creates a new active coupon
+func NewCoupon(tenantID uuid.UUID, code string, couponType CouponType, value decimal.Decimal, maxRedemptions int, expiresAt *time.Time, createdBy uuid.UUID) (*Coupon, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant is required", "tenant_id cannot be empty")
+	}
+
+	code = NormalizeCouponCode(code)
+	if !couponCodePattern.MatchString(code) {
+		return nil, errors.NewValidationError("invalid coupon code", "code must be 3 to 32 letters, digits, dashes or underscores")
+	}
+	if err := validateCouponInput(couponType, value, maxRedemptions); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Coupon{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		Code:           code,
+		Type:           couponType,
+		Value:          value,
+		MaxRedemptions: maxRedemptions,
+		ExpiresAt:      expiresAt,
+		IsActive:       true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		UpdatedBy:      createdBy,
+	}, nil
+}
+
+// NormalizeCouponCode returns the code as stored, so customers can type it in any case
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Update updates the discount and limits of the coupon. The redemption limit cannot be
+// lowered below the redemptions already made.
+func (c *Coupon) Update(couponType CouponType, value decimal.Decimal, maxRedemptions int, expiresAt *time.Time, updatedBy uuid.UUID) error {
+	if err := validateCouponInput(couponType, value, maxRedemptions); err != nil {
+		return err
+	}
+	if maxRedemptions > 0 && maxRedemptions < c.RedemptionCount {
+		return errors.NewValidationError("invalid max redemptions",
+			fmt.Sprintf("coupon has already been redeemed %d times", c.RedemptionCount))
+	}
+
+	c.Type = couponType
+	c.Value = value
+	c.MaxRedemptions = maxRedemptions
+	c.ExpiresAt = expiresAt
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = updatedBy
+	return nil
+}
+
+// Activate enables the coupon
+func (c *Coupon) Activate(updatedBy uuid.UUID) {
+	c.IsActive = true
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = updatedBy
+}
+
+// Deactivate disables the coupon without deleting its redemptions
+func (c *Coupon) Deactivate(updatedBy uuid.UUID) {
+	c.IsActive = false
+	c.UpdatedAt = time.Now()
+	c.UpdatedBy = updatedBy
+}
+
+// IsExpired checks if the coupon is past its expiry
+func (c *Coupon) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && !now.Before(*c.ExpiresAt)
+}
+
+// RemainingRedemptions returns how many more times the coupon can be redeemed, or -1 when
+// it is unlimited
+func (c *Coupon) RemainingRedemptions() int {
+	if c.MaxRedemptions == 0 {
+		return -1
+	}
+	if remaining := c.MaxRedemptions - c.RedemptionCount; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// CheckRedeemable checks that the coupon can be applied to a sale at the given time
+func (c *Coupon) CheckRedeemable(now time.Time) error {
+	if !c.IsActive {
+		return errors.NewValidationError("coupon is not active", fmt.Sprintf("coupon '%s' is no longer active", c.Code))
+	}
+	if c.IsExpired(now) {
+		return errors.NewValidationError("coupon has expired", fmt.Sprintf("coupon '%s' expired on %s", c.Code, c.ExpiresAt.Format("2006-01-02")))
+	}
+	if c.RemainingRedemptions() == 0 {
+		return errors.NewValidationError("coupon is fully redeemed", fmt.Sprintf("coupon '%s' has reached its redemption limit", c.Code))
+	}
+	return nil
+}
+
+// Discount returns the discount the coupon gives on an amount, never more than the amount
+// itself, rounded to cents
+func (c *Coupon) Discount(amount decimal.Decimal) decimal.Decimal {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+
+	discount := c.Value
+	if c.Type == CouponTypePercentage {
+		discount = amount.Mul(c.Value).Div(decimal.NewFromInt(100))
+	}
+	if discount.GreaterThan(amount) {
+		discount = amount
+	}
+
+	return discount.Round(2)
+}
+
+// Redeem records the coupon as applied to a completed sale
+func (c *Coupon) Redeem(sale *Sale, discountAmount decimal.Decimal, redeemedBy uuid.UUID, now time.Time) (*CouponRedemption, error) {
+	if sale.TenantID != c.TenantID {
+		return nil, errors.NewValidationError("invalid coupon", "coupon belongs to another tenant")
+	}
+	if err := c.CheckRedeemable(now); err != nil {
+		return nil, err
+	}
+
+	c.RedemptionCount++
+	c.UpdatedAt = now
+
+	return &CouponRedemption{
+		ID:             uuid.New(),
+		TenantID:       c.TenantID,
+		CouponID:       c.ID,
+		SaleID:         sale.ID,
+		Code:           c.Code,
+		DiscountAmount: discountAmount,
+		SaleTotal:      sale.TotalAmount,
+		RedeemedBy:     redeemedBy,
+		RedeemedAt:     now,
+	}, nil
+}
+
+// ValidateCouponType validates coupon type
+func ValidateCouponType(couponType CouponType) error {
+	switch couponType {
+	case CouponTypePercentage, CouponTypeFixed:
+		return nil
+	default:
+		return errors.NewValidationError("invalid coupon type", "type must be one of: percentage, fixed")
+	}
+}
+
+// validateCouponInput validates coupon input
+func validateCouponInput(couponType CouponType, value decimal.Decimal, maxRedemptions int) error {
+	if err := ValidateCouponType(couponType); err != nil {
+		return err
+	}
+	if value.LessThanOrEqual(decimal.Zero) {
+		return errors.NewValidationError("invalid coupon value", "value must be greater than zero")
+	}
+	if couponType == CouponTypePercentage && value.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.NewValidationError("invalid coupon value", "percentage cannot exceed 100")
+	}
+	if maxRedemptions < 0 {
+		return errors.NewValidationError("invalid max redemptions", "max_redemptions cannot be negative")
+	}
+	return nil
+}
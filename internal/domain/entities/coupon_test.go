@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCoupon(t *testing.T) {
+	t.Run("valid coupon", func(t *testing.T) {
+		tenantID := uuid.New()
+		coupon, err := NewCoupon(tenantID, " summer-10 ", CouponTypePercentage, decimal.NewFromInt(10), 100, nil, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, coupon.TenantID)
+		assert.Equal(t, "SUMMER-10", coupon.Code)
+		assert.Equal(t, 100, coupon.MaxRedemptions)
+		assert.Zero(t, coupon.RedemptionCount)
+		assert.True(t, coupon.IsActive)
+	})
+
+	tests := []struct {
+		name           string
+		tenantID       uuid.UUID
+		code           string
+		couponType     CouponType
+		value          decimal.Decimal
+		maxRedemptions int
+		errContains    string
+	}{
+		{"missing tenant", uuid.Nil, "SAVE5", CouponTypeFixed, decimal.NewFromInt(5), 0, "tenant is required"},
+		{"code too short", uuid.New(), "AB", CouponTypeFixed, decimal.NewFromInt(5), 0, "invalid coupon code"},
+		{"code with spaces", uuid.New(), "SAVE 5", CouponTypeFixed, decimal.NewFromInt(5), 0, "invalid coupon code"},
+		{"invalid type", uuid.New(), "SAVE5", "bogo", decimal.NewFromInt(5), 0, "invalid coupon type"},
+		{"zero value", uuid.New(), "SAVE5", CouponTypeFixed, decimal.Zero, 0, "invalid coupon value"},
+		{"percentage over 100", uuid.New(), "SAVE5", CouponTypePercentage, decimal.NewFromInt(101), 0, "invalid coupon value"},
+		{"negative max redemptions", uuid.New(), "SAVE5", CouponTypeFixed, decimal.NewFromInt(5), -1, "invalid max redemptions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon, err := NewCoupon(tt.tenantID, tt.code, tt.couponType, tt.value, tt.maxRedemptions, nil, uuid.New())
+
+			assert.Error(t, err)
+			assert.Nil(t, coupon)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestCoupon_Discount(t *testing.T) {
+	tests := []struct {
+		name       string
+		couponType CouponType
+		value      float64
+		amount     float64
+		expected   float64
+	}{
+		{"percentage", CouponTypePercentage, 15, 80, 12},
+		{"percentage rounded to cents", CouponTypePercentage, 12.5, 9.99, 1.25},
+		{"fixed", CouponTypeFixed, 5, 80, 5},
+		{"fixed capped to amount", CouponTypeFixed, 50, 30, 30},
+		{"zero amount", CouponTypeFixed, 5, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon, err := NewCoupon(uuid.New(), "CODE", tt.couponType, decimal.NewFromFloat(tt.value), 0, nil, uuid.New())
+			require.NoError(t, err)
+
+			discount := coupon.Discount(decimal.NewFromFloat(tt.amount))
+
+			assert.True(t, decimal.NewFromFloat(tt.expected).Equal(discount), "expected %v, got %s", tt.expected, discount)
+		})
+	}
+}
+
+func TestCoupon_CheckRedeemable(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("redeemable", func(t *testing.T) {
+		expiresAt := now.Add(time.Hour)
+		coupon, err := NewCoupon(uuid.New(), "CODE", CouponTypeFixed, decimal.NewFromInt(5), 2, &expiresAt, uuid.New())
+		require.NoError(t, err)
+
+		assert.NoError(t, coupon.CheckRedeemable(now))
+		assert.Equal(t, 2, coupon.RemainingRedemptions())
+	})
+
+	t.Run("inactive", func(t *testing.T) {
+		coupon, err := NewCoupon(uuid.New(), "CODE", CouponTypeFixed, decimal.NewFromInt(5), 0, nil, uuid.New())
+		require.NoError(t, err)
+		coupon.Deactivate(uuid.New())
+
+		err = coupon.CheckRedeemable(now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not active")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		coupon, err := NewCoupon(uuid.New(), "CODE", CouponTypeFixed, decimal.NewFromInt(5), 0, &now, uuid.New())
+		require.NoError(t, err)
+
+		err = coupon.CheckRedeemable(now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("fully redeemed", func(t *testing.T) {
+		coupon, err := NewCoupon(uuid.New(), "CODE", CouponTypeFixed, decimal.NewFromInt(5), 1, nil, uuid.New())
+		require.NoError(t, err)
+		coupon.RedemptionCount = 1
+
+		err = coupon.CheckRedeemable(now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "fully redeemed")
+		assert.Equal(t, 0, coupon.RemainingRedemptions())
+	})
+}
+
+func TestCoupon_Redeem(t *testing.T) {
+	now := time.Now()
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	coupon, err := NewCoupon(tenantID, "ONCE", CouponTypeFixed, decimal.NewFromInt(5), 1, nil, userID)
+	require.NoError(t, err)
+
+	sale, err := NewSale(tenantID, "S-001", "", "", "", userID)
+	require.NoError(t, err)
+	sale.TotalAmount = decimal.NewFromInt(45)
+
+	t.Run("records the redemption", func(t *testing.T) {
+		redemption, err := coupon.Redeem(sale, decimal.NewFromInt(5), userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, coupon.ID, redemption.CouponID)
+		assert.Equal(t, sale.ID, redemption.SaleID)
+		assert.Equal(t, "ONCE", redemption.Code)
+		assert.True(t, decimal.NewFromInt(5).Equal(redemption.DiscountAmount))
+		assert.True(t, decimal.NewFromInt(45).Equal(redemption.SaleTotal))
+		assert.Equal(t, 1, coupon.RedemptionCount)
+	})
+
+	t.Run("refuses past the limit", func(t *testing.T) {
+		redemption, err := coupon.Redeem(sale, decimal.NewFromInt(5), userID, now)
+
+		assert.Error(t, err)
+		assert.Nil(t, redemption)
+		assert.Equal(t, 1, coupon.RedemptionCount)
+	})
+
+	t.Run("refuses another tenant's sale", func(t *testing.T) {
+		other, err := NewCoupon(uuid.New(), "OTHER", CouponTypeFixed, decimal.NewFromInt(5), 0, nil, userID)
+		require.NoError(t, err)
+
+		redemption, err := other.Redeem(sale, decimal.NewFromInt(5), userID, now)
+
+		assert.Error(t, err)
+		assert.Nil(t, redemption)
+	})
+}
+
+func TestCoupon_Update(t *testing.T) {
+	coupon, err := NewCoupon(uuid.New(), "CODE", CouponTypeFixed, decimal.NewFromInt(5), 10, nil, uuid.New())
+	require.NoError(t, err)
+	coupon.RedemptionCount = 4
+
+	err = coupon.Update(CouponTypePercentage, decimal.NewFromInt(20), 3, nil, uuid.New())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid max redemptions")
+
+	require.NoError(t, coupon.Update(CouponTypePercentage, decimal.NewFromInt(20), 0, nil, uuid.New()))
+	assert.Equal(t, CouponTypePercentage, coupon.Type)
+	assert.Equal(t, -1, coupon.RemainingRedemptions())
+}
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// MaxCurrencyDecimalPlaces is the largest number of decimal places an amount can be displayed with
+const MaxCurrencyDecimalPlaces = 4
+
+// CurrencySymbolPosition represents where the currency symbol is placed around an amount
+type CurrencySymbolPosition string
+
+const (
+	CurrencySymbolBefore CurrencySymbolPosition = "before"
+	CurrencySymbolAfter  CurrencySymbolPosition = "after"
+)
+
+// CurrencyFormat describes how a tenant's amounts are displayed in documents, emails and
+// API formatting helpers. Amounts themselves are stored unrounded; the format only affects
+// their presentation.
+type CurrencyFormat struct {
+	Symbol             string                 `json:"symbol"`
+	DecimalPlaces      int                    `json:"decimal_places"`
+	ThousandsSeparator string                 `json:"thousands_separator"`
+	DecimalSeparator   string                 `json:"decimal_separator"`
+	SymbolPosition     CurrencySymbolPosition `json:"symbol_position"`
+	SymbolSpacing      bool                   `json:"symbol_spacing"` // Whether a space separates the symbol from the amount
+}
+
+// defaultCurrencyFormats holds the conventional display of the common currencies
+var defaultCurrencyFormats = map[string]CurrencyFormat{
+	"USD": {Symbol: "$", DecimalPlaces: 2, ThousandsSeparator: ",", DecimalSeparator: ".", SymbolPosition: CurrencySymbolBefore},
+	"EUR": {Symbol: "€", DecimalPlaces: 2, ThousandsSeparator: ".", DecimalSeparator: ",", SymbolPosition: CurrencySymbolBefore},
+	"GBP": {Symbol: "£", DecimalPlaces: 2, ThousandsSeparator: ",", DecimalSeparator: ".", SymbolPosition: CurrencySymbolBefore},
+	"JPY": {Symbol: "¥", DecimalPlaces: 0, ThousandsSeparator: ",", DecimalSeparator: ".", SymbolPosition: CurrencySymbolBefore},
+	"SGD": {Symbol: "S$", DecimalPlaces: 2, ThousandsSeparator: ",", DecimalSeparator: ".", SymbolPosition: CurrencySymbolBefore},
+	"MYR": {Symbol: "RM", DecimalPlaces: 2, ThousandsSeparator: ",", DecimalSeparator: ".", SymbolPosition: CurrencySymbolBefore},
+	"IDR": {Symbol: "Rp", DecimalPlaces: 0, ThousandsSeparator: ".", DecimalSeparator: ",", SymbolPosition: CurrencySymbolBefore, SymbolSpacing: true},
+}
+
+// DefaultCurrencyFormat returns the conventional display of a currency. Unknown currencies
+// are shown with their code and two decimal places.
+func DefaultCurrencyFormat(currency string) CurrencyFormat {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if format, ok := defaultCurrencyFormats[currency]; ok {
+		return format
+	}
+	if currency == "" {
+		return defaultCurrencyFormats["USD"]
+	}
+	return CurrencyFormat{
+		Symbol:             currency,
+		DecimalPlaces:      2,
+		ThousandsSeparator: ",",
+		DecimalSeparator:   ".",
+		SymbolPosition:     CurrencySymbolBefore,
+		SymbolSpacing:      true,
+	}
+}
+
+// Validate checks the currency format
+func (f CurrencyFormat) Validate() error {
+	if f.Symbol == "" || utf8.RuneCountInString(f.Symbol) > 8 {
+		return errors.NewValidationError("invalid currency symbol", "symbol must be 1 to 8 characters")
+	}
+	if f.DecimalPlaces < 0 || f.DecimalPlaces > MaxCurrencyDecimalPlaces {
+		return errors.NewValidationError("invalid decimal places", "decimal_places must be between 0 and 4")
+	}
+	switch f.ThousandsSeparator {
+	case "", ",", ".", " ", "'":
+	default:
+		return errors.NewValidationError("invalid thousands separator", "thousands_separator must be empty, comma, period, space or apostrophe")
+	}
+	switch f.DecimalSeparator {
+	case ".", ",":
+	default:
+		return errors.NewValidationError("invalid decimal separator", "decimal_separator must be a period or comma")
+	}
+	if f.ThousandsSeparator == f.DecimalSeparator {
+		return errors.NewValidationError("invalid separators", "thousands and decimal separators must differ")
+	}
+	switch f.SymbolPosition {
+	case CurrencySymbolBefore, CurrencySymbolAfter:
+	default:
+		return errors.NewValidationError("invalid symbol position", "symbol_position must be before or after")
+	}
+	return nil
+}
+
+// Format renders an amount with the format's symbol, separators and decimal places
+func (f CurrencyFormat) Format(amount decimal.Decimal) string {
+	number := utils.FormatDecimal(amount.Abs(), f.DecimalPlaces, f.ThousandsSeparator, f.DecimalSeparator)
+
+	spacing := ""
+	if f.SymbolSpacing {
+		spacing = " "
+	}
+
+	text := f.Symbol + spacing + number
+	if f.SymbolPosition == CurrencySymbolAfter {
+		text = number + spacing + f.Symbol
+	}
+
+	// An amount that rounds to zero is shown without a sign
+	if amount.Round(int32(f.DecimalPlaces)).IsNegative() {
+		return "-" + text
+	}
+	return text
+}
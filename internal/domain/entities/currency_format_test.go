@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyFormat_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   CurrencyFormat
+		amount   string
+		expected string
+	}{
+		{"usd", DefaultCurrencyFormat("USD"), "1234567.891", "$1,234,567.89"},
+		{"idr has no decimals", DefaultCurrencyFormat("IDR"), "18000", "Rp 18.000"},
+		{"idr rounds", DefaultCurrencyFormat("idr"), "999.5", "Rp 1.000"},
+		{"eur separators", DefaultCurrencyFormat("EUR"), "1234.5", "€1.234,50"},
+		{"negative", DefaultCurrencyFormat("USD"), "-42.1", "-$42.10"},
+		{"rounds to zero without sign", DefaultCurrencyFormat("USD"), "-0.001", "$0.00"},
+		{"unknown currency uses code", DefaultCurrencyFormat("CHF"), "12", "CHF 12.00"},
+		{
+			"symbol after without grouping",
+			CurrencyFormat{Symbol: "kr", DecimalPlaces: 3, DecimalSeparator: ",", SymbolPosition: CurrencySymbolAfter, SymbolSpacing: true},
+			"12345.6789",
+			"12345,679 kr",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.format.Format(decimal.RequireFromString(tt.amount)))
+		})
+	}
+}
+
+func TestCurrencyFormat_Validate(t *testing.T) {
+	for _, code := range []string{"USD", "EUR", "GBP", "JPY", "SGD", "MYR", "IDR", "XYZ"} {
+		assert.NoError(t, DefaultCurrencyFormat(code).Validate(), code)
+	}
+
+	valid := DefaultCurrencyFormat("USD")
+	invalid := []func(f *CurrencyFormat){
+		func(f *CurrencyFormat) { f.Symbol = "" },
+		func(f *CurrencyFormat) { f.DecimalPlaces = 5 },
+		func(f *CurrencyFormat) { f.DecimalPlaces = -1 },
+		func(f *CurrencyFormat) { f.ThousandsSeparator = "_" },
+		func(f *CurrencyFormat) { f.DecimalSeparator = "" },
+		func(f *CurrencyFormat) { f.ThousandsSeparator = "." },
+		func(f *CurrencyFormat) { f.SymbolPosition = "middle" },
+	}
+	for i, mutate := range invalid {
+		format := valid
+		mutate(&format)
+		assert.Error(t, format.Validate(), i)
+	}
+}
+
+func TestTenant_CurrencyFormat(t *testing.T) {
+	tenant := &Tenant{Configuration: TenantConfiguration{BusinessInfo: BusinessInfo{Name: "Toko", Currency: "IDR"}}}
+
+	assert.Equal(t, DefaultCurrencyFormat("IDR"), tenant.GetCurrencyFormat())
+
+	custom := CurrencyFormat{Symbol: "IDR", DecimalPlaces: 2, ThousandsSeparator: ",", DecimalSeparator: ".", SymbolPosition: CurrencySymbolAfter, SymbolSpacing: true}
+	require.NoError(t, tenant.SetCurrencyFormat(&custom))
+	assert.Equal(t, "18,000.00 IDR", tenant.GetCurrencyFormat().Format(decimal.NewFromInt(18000)))
+
+	custom.DecimalPlaces = 9
+	assert.Error(t, tenant.SetCurrencyFormat(&custom))
+
+	require.NoError(t, tenant.SetCurrencyFormat(nil))
+	assert.Equal(t, "Rp", tenant.GetCurrencyFormat().Symbol)
+}
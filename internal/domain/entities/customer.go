@@ -0,0 +1,399 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// MinCustomerDuplicateScore is the lowest match score suggested as a duplicate
+	MinCustomerDuplicateScore = 0.7
+
+	// customerNameSimilarity is the similarity from which two names are taken to be the same
+	customerNameSimilarity = 0.85
+	// minCustomerPhoneDigits is the fewest digits a phone number is matched on when only its
+	// trailing digits agree, as when one carries a country code and the other a trunk prefix
+	minCustomerPhoneDigits = 8
+	// maxCustomerDuplicateBlock caps the customers compared pairwise under one blocking key, so
+	// a common key cannot make detection quadratic in the tenant's customers
+	maxCustomerDuplicateBlock = 200
+)
+
+// CustomerMatchReason tells why two customers were taken to be the same person
+type CustomerMatchReason string
+
+const (
+	CustomerMatchEmail        CustomerMatchReason = "email"         // Same email once normalized
+	CustomerMatchSimilarEmail CustomerMatchReason = "similar_email" // Emails one typo apart
+	CustomerMatchPhone        CustomerMatchReason = "phone"         // Same phone number once normalized
+	CustomerMatchSimilarPhone CustomerMatchReason = "similar_phone" // Phone numbers one digit apart
+	CustomerMatchName         CustomerMatchReason = "name"          // Similar names
+)
+
+// customerMatchScores weighs each reason. A contact detail alone sets the score; every further
+// reason adds customerMatchBonus. Names alone never make a match, they are too often shared.
+var customerMatchScores = map[CustomerMatchReason]float64{
+	CustomerMatchEmail:        1,
+	CustomerMatchPhone:        0.9,
+	CustomerMatchSimilarEmail: 0.65,
+	CustomerMatchSimilarPhone: 0.6,
+}
+
+const customerMatchBonus = 0.15
+
+// CustomerDuplicateStatus represents the status of a duplicate customer suggestion
+type CustomerDuplicateStatus string
+
+const (
+	CustomerDuplicateStatusPending   CustomerDuplicateStatus = "pending"
+	CustomerDuplicateStatusMerged    CustomerDuplicateStatus = "merged"
+	CustomerDuplicateStatusDismissed CustomerDuplicateStatus = "dismissed"
+)
+
+// Customer represents a person the tenant sells to. Sales and invoices keep their own snapshot
+// of the customer's details and are linked to the customer record by their contact details.
+type Customer struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	Name       string     `json:"name,omitempty"`
+	Email      string     `json:"email,omitempty"`
+	Phone      string     `json:"phone,omitempty"`
+	EmailKey   string     `json:"-"`                     // Normalized email the customer is looked up by
+	PhoneKey   string     `json:"-"`                     // Normalized phone number the customer is looked up by
+	MergedInto *uuid.UUID `json:"merged_into,omitempty"` // Surviving customer this one was merged into
+	MergedAt   *time.Time `json:"merged_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewCustomer creates a customer from the contact details of a sale or invoice. An email or
+// phone number is required, a name alone does not identify anyone.
+func NewCustomer(tenantID uuid.UUID, name, email, phone string, now time.Time) (*Customer, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	customer := &Customer{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      strings.TrimSpace(name),
+		Email:     strings.TrimSpace(email),
+		Phone:     strings.TrimSpace(phone),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if len(customer.Name) > 255 || len(customer.Email) > 255 || len(customer.Phone) > 255 {
+		return nil, errors.NewValidationError("customer details too long", "customer name, email and phone cannot exceed 255 characters")
+	}
+
+	customer.EmailKey = NormalizeCustomerEmail(customer.Email)
+	customer.PhoneKey = NormalizeCustomerPhone(customer.Phone)
+	if customer.EmailKey == "" && customer.PhoneKey == "" {
+		return nil, errors.NewValidationError("customer contact required", "customer needs an email or phone number")
+	}
+
+	return customer, nil
+}
+
+// IsMerged reports whether the customer was merged into another one
+func (c *Customer) IsMerged() bool {
+	return c.MergedInto != nil
+}
+
+// MergeInto merges the customer into the surviving customer. The survivor keeps its own details
+// and takes over the ones it is missing; the caller re-links the merged customer's records.
+func (c *Customer) MergeInto(survivor *Customer, now time.Time) error {
+	if c.ID == survivor.ID {
+		return errors.NewValidationError("invalid merge", "a customer cannot be merged into itself")
+	}
+	if c.TenantID != survivor.TenantID {
+		return errors.NewValidationError("invalid merge", "customers belong to different tenants")
+	}
+	if c.IsMerged() || survivor.IsMerged() {
+		return errors.NewValidationError("customer already merged", "merged customers cannot be merged again")
+	}
+
+	if survivor.Name == "" {
+		survivor.Name = c.Name
+	}
+	if survivor.Email == "" {
+		survivor.Email, survivor.EmailKey = c.Email, c.EmailKey
+	}
+	if survivor.Phone == "" {
+		survivor.Phone, survivor.PhoneKey = c.Phone, c.PhoneKey
+	}
+	survivor.UpdatedAt = now
+
+	c.MergedInto = &survivor.ID
+	c.MergedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// NormalizeCustomerEmail normalizes an email for matching: case and surrounding spaces are
+// dropped, as are "+tag" suffixes, and the dots Gmail ignores
+func NormalizeCustomerEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return ""
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+	if domain == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + domain
+}
+
+// NormalizeCustomerPhone normalizes a phone number for matching: only its digits are kept,
+// without the international "00" or trunk "0" prefix. Numbers written with and without their
+// country code still differ; they are matched on their trailing digits.
+func NormalizeCustomerPhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(digits.String(), "0")
+}
+
+// normalizeCustomerName normalizes a name for matching: lower case words without punctuation,
+// sorted so that "Santoso, Budi" matches "Budi Santoso"
+func normalizeCustomerName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// MatchCustomers scores how likely two customers are the same person, from 0 to 1, with the
+// reasons behind the score
+func MatchCustomers(a, b *Customer) (float64, []CustomerMatchReason) {
+	var reasons []CustomerMatchReason
+
+	switch {
+	case a.EmailKey != "" && a.EmailKey == b.EmailKey:
+		reasons = append(reasons, CustomerMatchEmail)
+	case similarEmails(a.EmailKey, b.EmailKey):
+		reasons = append(reasons, CustomerMatchSimilarEmail)
+	}
+	switch {
+	case samePhones(a.PhoneKey, b.PhoneKey):
+		reasons = append(reasons, CustomerMatchPhone)
+	case similarPhones(a.PhoneKey, b.PhoneKey):
+		reasons = append(reasons, CustomerMatchSimilarPhone)
+	}
+	if len(reasons) == 0 {
+		return 0, nil
+	}
+	if similarity(normalizeCustomerName(a.Name), normalizeCustomerName(b.Name)) >= customerNameSimilarity {
+		reasons = append(reasons, CustomerMatchName)
+	}
+
+	score := 0.0
+	for _, reason := range reasons {
+		score = math.Max(score, customerMatchScores[reason])
+	}
+	score = math.Min(1, score+customerMatchBonus*float64(len(reasons)-1))
+	return math.Round(score*100) / 100, reasons
+}
+
+// samePhones reports whether two normalized phone numbers are the same number, allowing one to
+// carry a country code the other lacks
+func samePhones(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == b || (len(a) >= minCustomerPhoneDigits && strings.HasSuffix(b, a))
+}
+
+// similarPhones reports whether two normalized phone numbers of the same length differ in a
+// single digit
+func similarPhones(a, b string) bool {
+	return len(a) >= minCustomerPhoneDigits && len(a) == len(b) && editDistance(a, b) == 1
+}
+
+// similarEmails reports whether two normalized emails are one typo apart, in either the part
+// before the "@" or the domain
+func similarEmails(a, b string) bool {
+	if a == "" || b == "" || a == b {
+		return false
+	}
+	localA, domainA, _ := strings.Cut(a, "@")
+	localB, domainB, _ := strings.Cut(b, "@")
+	if domainA == domainB {
+		return len(localA) >= 5 && editDistance(localA, localB) == 1
+	}
+	return localA == localB && editDistance(domainA, domainB) == 1
+}
+
+// similarity returns how alike two strings are, from 0 to 1, by their edit distance
+func similarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	longest := math.Max(float64(len([]rune(a))), float64(len([]rune(b))))
+	return 1 - float64(editDistance(a, b))/longest
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// CustomerDuplicate is a suggestion that two customers are the same person and should be merged
+type CustomerDuplicate struct {
+	ID          uuid.UUID               `json:"id"`
+	TenantID    uuid.UUID               `json:"tenant_id"`
+	CustomerID  uuid.UUID               `json:"customer_id"`  // The older customer, suggested to survive the merge
+	DuplicateID uuid.UUID               `json:"duplicate_id"` // The newer customer, suggested to be merged away
+	Score       float64                 `json:"score"`
+	Reasons     []CustomerMatchReason   `json:"reasons"`
+	Status      CustomerDuplicateStatus `json:"status"`
+	ResolvedBy  *uuid.UUID              `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time              `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// NewCustomerDuplicate suggests merging two customers when they match well enough. The older
+// customer is suggested to survive.
+func NewCustomerDuplicate(a, b *Customer, now time.Time) (*CustomerDuplicate, bool) {
+	if a.ID == b.ID || a.TenantID != b.TenantID || a.IsMerged() || b.IsMerged() {
+		return nil, false
+	}
+
+	score, reasons := MatchCustomers(a, b)
+	if score < MinCustomerDuplicateScore {
+		return nil, false
+	}
+
+	if b.CreatedAt.Before(a.CreatedAt) || (b.CreatedAt.Equal(a.CreatedAt) && b.ID.String() < a.ID.String()) {
+		a, b = b, a
+	}
+	return &CustomerDuplicate{
+		ID:          uuid.New(),
+		TenantID:    a.TenantID,
+		CustomerID:  a.ID,
+		DuplicateID: b.ID,
+		Score:       score,
+		Reasons:     reasons,
+		Status:      CustomerDuplicateStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, true
+}
+
+// Dismiss records that the suggested customers are different people
+func (d *CustomerDuplicate) Dismiss(userID uuid.UUID, now time.Time) error {
+	if d.Status != CustomerDuplicateStatusPending {
+		return errors.NewValidationError("invalid duplicate status", "only pending duplicates can be dismissed")
+	}
+
+	d.Status = CustomerDuplicateStatusDismissed
+	d.ResolvedBy = &userID
+	d.ResolvedAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// FindCustomerDuplicates suggests merging the customers of a tenant that match each other.
+// Only customers sharing a blocking key are compared: the domain and start of their email, the
+// part before the "@" of their email, or one of two stretches of their phone number's trailing
+// digits, so that a single typo leaves at least one key intact.
+func FindCustomerDuplicates(customers []*Customer, now time.Time) []*CustomerDuplicate {
+	blocks := make(map[string][]*Customer)
+	for _, customer := range customers {
+		if customer.IsMerged() {
+			continue
+		}
+		for _, key := range customer.duplicateKeys() {
+			blocks[key] = append(blocks[key], customer)
+		}
+	}
+
+	keys := make([]string, 0, len(blocks))
+	for key := range blocks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[[2]uuid.UUID]bool)
+	duplicates := []*CustomerDuplicate{}
+	for _, key := range keys {
+		block := blocks[key]
+		if len(block) > maxCustomerDuplicateBlock {
+			continue
+		}
+		for i := 0; i < len(block); i++ {
+			for j := i + 1; j < len(block); j++ {
+				duplicate, ok := NewCustomerDuplicate(block[i], block[j], now)
+				if !ok {
+					continue
+				}
+				pair := [2]uuid.UUID{duplicate.CustomerID, duplicate.DuplicateID}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+				duplicates = append(duplicates, duplicate)
+			}
+		}
+	}
+
+	return duplicates
+}
+
+// duplicateKeys returns the blocking keys the customer is compared under
+func (c *Customer) duplicateKeys() []string {
+	var keys []string
+	if local, domain, ok := strings.Cut(c.EmailKey, "@"); ok {
+		prefix := []rune(local)
+		keys = append(keys, "email:"+domain+":"+string(prefix[:min(3, len(prefix))]), "local:"+local)
+	}
+	if digits := len(c.PhoneKey); digits >= 5 {
+		keys = append(keys, "phone:"+c.PhoneKey[digits-5:])
+		if digits >= 8 {
+			keys = append(keys, "phone_prefix:"+c.PhoneKey[max(0, digits-10):digits-5])
+		}
+	}
+	return keys
+}
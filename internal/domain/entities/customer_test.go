@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCustomer(t *testing.T, tenantID uuid.UUID, name, email, phone string, createdAt time.Time) *Customer {
+	customer, err := NewCustomer(tenantID, name, email, phone, createdAt)
+	require.NoError(t, err)
+	return customer
+}
+
+func TestNewCustomer(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tenantID := uuid.New()
+
+	t.Run("valid customer", func(t *testing.T) {
+		customer, err := NewCustomer(tenantID, " Budi Santoso ", "Budi.Santoso@Gmail.com", "+62 812-3456-7890", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Budi Santoso", customer.Name)
+		assert.Equal(t, "budisantoso@gmail.com", customer.EmailKey)
+		assert.Equal(t, "6281234567890", customer.PhoneKey)
+		assert.False(t, customer.IsMerged())
+	})
+
+	t.Run("name only", func(t *testing.T) {
+		_, err := NewCustomer(tenantID, "Budi Santoso", "", "-", now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "customer contact required")
+	})
+}
+
+func TestNormalizeCustomerEmail(t *testing.T) {
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{" Ann@Example.com ", "ann@example.com"},
+		{"ann+shop@example.com", "ann@example.com"},
+		{"a.n.n@googlemail.com", "ann@gmail.com"},
+		{"a.nn@example.com", "a.nn@example.com"},
+		{"not-an-email", ""},
+		{"@example.com", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, NormalizeCustomerEmail(tt.email), tt.email)
+	}
+}
+
+func TestNormalizeCustomerPhone(t *testing.T) {
+	assert.Equal(t, "81234567890", NormalizeCustomerPhone("0812-3456-7890"))
+	assert.Equal(t, "6281234567890", NormalizeCustomerPhone("0062 812 3456 7890"))
+	assert.Equal(t, "", NormalizeCustomerPhone("n/a"))
+}
+
+func TestMatchCustomers(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tenantID := uuid.New()
+
+	tests := []struct {
+		name     string
+		a, b     [3]string
+		score    float64
+		expected []CustomerMatchReason
+	}{
+		{
+			name:     "same email spelled differently",
+			a:        [3]string{"Budi", "budi.santoso@gmail.com", ""},
+			b:        [3]string{"Pak Budi", "BudiSantoso+toko@gmail.com", ""},
+			score:    1,
+			expected: []CustomerMatchReason{CustomerMatchEmail},
+		},
+		{
+			name:     "phone with and without country code",
+			a:        [3]string{"Ann Lee", "", "0812-3456-7890"},
+			b:        [3]string{"Lee, Ann", "", "+62 812 3456 7890"},
+			score:    1,
+			expected: []CustomerMatchReason{CustomerMatchPhone, CustomerMatchName},
+		},
+		{
+			name:     "mistyped phone with the same name",
+			a:        [3]string{"Siti Rahma", "", "081234567890"},
+			b:        [3]string{"Siti Rahmah", "", "081234567891"},
+			score:    0.75,
+			expected: []CustomerMatchReason{CustomerMatchSimilarPhone, CustomerMatchName},
+		},
+		{
+			name:     "mistyped phone alone",
+			a:        [3]string{"Siti Rahma", "", "081234567890"},
+			b:        [3]string{"Joko", "", "081234567891"},
+			score:    0.6,
+			expected: []CustomerMatchReason{CustomerMatchSimilarPhone},
+		},
+		{
+			name:     "mistyped email domain",
+			a:        [3]string{"", "ann@gmail.com", ""},
+			b:        [3]string{"", "ann@gmal.com", ""},
+			score:    0.65,
+			expected: []CustomerMatchReason{CustomerMatchSimilarEmail},
+		},
+		{
+			name: "same name only",
+			a:    [3]string{"Ann Lee", "ann@example.com", ""},
+			b:    [3]string{"Ann Lee", "", "081234567890"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestCustomer(t, tenantID, tt.a[0], tt.a[1], tt.a[2], now)
+			b := newTestCustomer(t, tenantID, tt.b[0], tt.b[1], tt.b[2], now)
+
+			score, reasons := MatchCustomers(a, b)
+
+			assert.Equal(t, tt.score, score)
+			assert.Equal(t, tt.expected, reasons)
+		})
+	}
+}
+
+func TestCustomer_MergeInto(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tenantID := uuid.New()
+
+	t.Run("survivor takes over missing details", func(t *testing.T) {
+		survivor := newTestCustomer(t, tenantID, "Ann Lee", "ann@example.com", "", now)
+		duplicate := newTestCustomer(t, tenantID, "Ann", "ann@example.com", "0812 3456 7890", now)
+
+		err := duplicate.MergeInto(survivor, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, survivor.ID, *duplicate.MergedInto)
+		assert.Equal(t, "Ann Lee", survivor.Name)
+		assert.Equal(t, "0812 3456 7890", survivor.Phone)
+		assert.Equal(t, "81234567890", survivor.PhoneKey)
+	})
+
+	t.Run("already merged", func(t *testing.T) {
+		survivor := newTestCustomer(t, tenantID, "Ann Lee", "ann@example.com", "", now)
+		duplicate := newTestCustomer(t, tenantID, "Ann", "ann@example.com", "", now)
+		require.NoError(t, duplicate.MergeInto(survivor, now))
+
+		err := duplicate.MergeInto(newTestCustomer(t, tenantID, "Ann", "ann@example.com", "", now), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "customer already merged")
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		survivor := newTestCustomer(t, tenantID, "Ann Lee", "ann@example.com", "", now)
+		duplicate := newTestCustomer(t, uuid.New(), "Ann", "ann@example.com", "", now)
+
+		err := duplicate.MergeInto(survivor, now)
+
+		assert.Error(t, err)
+		assert.False(t, duplicate.IsMerged())
+	})
+}
+
+func TestFindCustomerDuplicates(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tenantID := uuid.New()
+	older := newTestCustomer(t, tenantID, "Budi Santoso", "", "0812-3456-7890", now.Add(-time.Hour))
+	newer := newTestCustomer(t, tenantID, "Budi Santoso", "budi@example.com", "+62 812 3456 7890", now)
+	typo := newTestCustomer(t, tenantID, "Budi Santosa", "", "0812-3456-7899", now)
+	other := newTestCustomer(t, tenantID, "Ann Lee", "ann@example.com", "0813-1111-2222", now)
+	merged := newTestCustomer(t, tenantID, "Budi", "budi@example.com", "", now)
+	require.NoError(t, merged.MergeInto(newer, now))
+
+	duplicates := FindCustomerDuplicates([]*Customer{newer, other, older, typo, merged}, now)
+
+	pairs := map[[2]uuid.UUID]*CustomerDuplicate{}
+	for _, duplicate := range duplicates {
+		pairs[[2]uuid.UUID{duplicate.CustomerID, duplicate.DuplicateID}] = duplicate
+		assert.Equal(t, CustomerDuplicateStatusPending, duplicate.Status)
+		assert.NotEqual(t, merged.ID, duplicate.DuplicateID)
+		assert.NotEqual(t, other.ID, duplicate.DuplicateID)
+	}
+	require.Contains(t, pairs, [2]uuid.UUID{older.ID, newer.ID})
+	assert.Equal(t, 1.0, pairs[[2]uuid.UUID{older.ID, newer.ID}].Score)
+	require.Contains(t, pairs, [2]uuid.UUID{older.ID, typo.ID})
+	assert.Equal(t, []CustomerMatchReason{CustomerMatchSimilarPhone, CustomerMatchName}, pairs[[2]uuid.UUID{older.ID, typo.ID}].Reasons)
+}
+
+func TestCustomerDuplicate_Dismiss(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tenantID := uuid.New()
+	a := newTestCustomer(t, tenantID, "Ann", "ann@example.com", "", now)
+	b := newTestCustomer(t, tenantID, "Ann", "ann@example.com", "", now)
+	duplicate, ok := NewCustomerDuplicate(a, b, now)
+	require.True(t, ok)
+	userID := uuid.New()
+
+	require.NoError(t, duplicate.Dismiss(userID, now))
+	assert.Equal(t, CustomerDuplicateStatusDismissed, duplicate.Status)
+	assert.Equal(t, userID, *duplicate.ResolvedBy)
+
+	assert.Error(t, duplicate.Dismiss(userID, now))
+}
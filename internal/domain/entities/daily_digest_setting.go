@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// DefaultDailyDigestSendHour is the local hour the end-of-day digest is sent at by default
+	DefaultDailyDigestSendHour = 21
+	// MaxDailyDigestRecipients caps how many addresses a tenant's digest is sent to
+	MaxDailyDigestRecipients = 10
+)
+
+// DailyDigestSetting represents a tenant's opt-in configuration for the end-of-day digest email
+type DailyDigestSetting struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	IsEnabled      bool       `json:"is_enabled"`
+	Recipients     []string   `json:"recipients"`
+	SendHour       int        `json:"send_hour"` // Local hour of day (0-23) in Timezone
+	Timezone       string     `json:"timezone"`  // IANA time zone name, e.g. "Asia/Jakarta"
+	LastDigestDate *time.Time `json:"last_digest_date,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	UpdatedBy      uuid.UUID  `json:"updated_by"`
+}
+
+// NewDailyDigestSetting creates a disabled digest setting with default schedule
+func NewDailyDigestSetting(tenantID, updatedBy uuid.UUID) (*DailyDigestSetting, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	setting := &DailyDigestSetting{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		IsEnabled:  false,
+		Recipients: []string{},
+		SendHour:   DefaultDailyDigestSendHour,
+		Timezone:   "UTC",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		UpdatedBy:  updatedBy,
+	}
+
+	return setting, nil
+}
+
+// Configure updates the digest opt-in, recipients and schedule
+func (s *DailyDigestSetting) Configure(enabled bool, recipients []string, sendHour int, timezone string, updatedBy uuid.UUID) error {
+	cleaned := make([]string, 0, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		recipient = strings.ToLower(strings.TrimSpace(recipient))
+		if recipient == "" || seen[recipient] {
+			continue
+		}
+		if !isValidEmail(recipient) {
+			return errors.NewValidationError("invalid recipient", "recipient "+recipient+" is not a valid email address")
+		}
+		seen[recipient] = true
+		cleaned = append(cleaned, recipient)
+	}
+
+	if enabled && len(cleaned) == 0 {
+		return errors.NewValidationError("recipients are required", "at least one recipient is required to enable the digest")
+	}
+	if len(cleaned) > MaxDailyDigestRecipients {
+		return errors.NewValidationError("too many recipients", "the digest can be sent to at most 10 recipients")
+	}
+	if sendHour < 0 || sendHour > 23 {
+		return errors.NewValidationError("invalid send hour", "send hour must be between 0 and 23")
+	}
+
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.NewValidationError("invalid timezone", "timezone must be a valid IANA time zone name")
+	}
+
+	s.IsEnabled = enabled
+	s.Recipients = cleaned
+	s.SendHour = sendHour
+	s.Timezone = timezone
+	s.UpdatedBy = updatedBy
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Location returns the tenant's digest time zone, falling back to UTC
+func (s *DailyDigestSetting) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DueDate reports whether a digest is due at the given instant and, if so, the local
+// business date it should cover
+func (s *DailyDigestSetting) DueDate(now time.Time) (time.Time, bool) {
+	if !s.IsEnabled || len(s.Recipients) == 0 {
+		return time.Time{}, false
+	}
+
+	local := now.In(s.Location())
+	if local.Hour() < s.SendHour {
+		return time.Time{}, false
+	}
+
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	if s.LastDigestDate != nil && !s.LastDigestDate.Before(date) {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+// MarkSent records the business date of the latest digest sent
+func (s *DailyDigestSetting) MarkSent(date time.Time) {
+	s.LastDigestDate = &date
+	s.UpdatedAt = time.Now()
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDailyDigestSetting(t *testing.T) {
+	t.Run("valid setting creation", func(t *testing.T) {
+		tenantID := uuid.New()
+
+		setting, err := NewDailyDigestSetting(tenantID, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, setting.TenantID)
+		assert.False(t, setting.IsEnabled)
+		assert.Equal(t, DefaultDailyDigestSendHour, setting.SendHour)
+		assert.Equal(t, "UTC", setting.Timezone)
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		setting, err := NewDailyDigestSetting(uuid.Nil, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, setting)
+	})
+}
+
+func TestDailyDigestSetting_Configure(t *testing.T) {
+	t.Run("enable with recipients", func(t *testing.T) {
+		setting := createValidDailyDigestSetting(t)
+
+		err := setting.Configure(true, []string{" Owner@Example.com ", "owner@example.com", "", "manager@example.com"}, 20, "Asia/Jakarta", uuid.New())
+
+		require.NoError(t, err)
+		assert.True(t, setting.IsEnabled)
+		assert.Equal(t, []string{"owner@example.com", "manager@example.com"}, setting.Recipients)
+		assert.Equal(t, 20, setting.SendHour)
+		assert.Equal(t, "Asia/Jakarta", setting.Timezone)
+	})
+
+	t.Run("enable without recipients", func(t *testing.T) {
+		setting := createValidDailyDigestSetting(t)
+
+		err := setting.Configure(true, nil, 20, "UTC", uuid.New())
+
+		assert.Error(t, err)
+		assert.False(t, setting.IsEnabled)
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		setting := createValidDailyDigestSetting(t)
+
+		assert.Error(t, setting.Configure(true, []string{"not-an-email"}, 20, "UTC", uuid.New()))
+	})
+
+	t.Run("invalid send hour", func(t *testing.T) {
+		setting := createValidDailyDigestSetting(t)
+
+		assert.Error(t, setting.Configure(true, []string{"owner@example.com"}, 24, "UTC", uuid.New()))
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		setting := createValidDailyDigestSetting(t)
+
+		assert.Error(t, setting.Configure(true, []string{"owner@example.com"}, 20, "Mars/Olympus", uuid.New()))
+	})
+}
+
+func TestDailyDigestSetting_DueDate(t *testing.T) {
+	setting := createValidDailyDigestSetting(t)
+	require.NoError(t, setting.Configure(true, []string{"owner@example.com"}, 21, "UTC", uuid.New()))
+
+	t.Run("not due before send hour", func(t *testing.T) {
+		_, due := setting.DueDate(time.Date(2026, 10, 15, 20, 59, 0, 0, time.UTC))
+
+		assert.False(t, due)
+	})
+
+	t.Run("due after send hour", func(t *testing.T) {
+		date, due := setting.DueDate(time.Date(2026, 10, 15, 21, 5, 0, 0, time.UTC))
+
+		assert.True(t, due)
+		assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), date)
+	})
+
+	t.Run("not due once sent for the day", func(t *testing.T) {
+		setting.MarkSent(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+
+		_, due := setting.DueDate(time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC))
+		assert.False(t, due)
+
+		_, due = setting.DueDate(time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC))
+		assert.True(t, due)
+	})
+
+	t.Run("not due when disabled", func(t *testing.T) {
+		disabled := createValidDailyDigestSetting(t)
+
+		_, due := disabled.DueDate(time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC))
+
+		assert.False(t, due)
+	})
+}
+
+func createValidDailyDigestSetting(t *testing.T) *DailyDigestSetting {
+	setting, err := NewDailyDigestSetting(uuid.New(), uuid.New())
+
+	require.NoError(t, err)
+	require.NotNil(t, setting)
+
+	return setting
+}
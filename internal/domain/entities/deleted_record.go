@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DeletedRecord represents a soft-deleted sale or invoice. It can be restored until the tenant's
+// retention policy for its entity purges it.
+type DeletedRecord struct {
+	Entity       RetentionEntity `json:"entity"`
+	ID           uuid.UUID       `json:"id"`
+	Number       string          `json:"number"`
+	CustomerName string          `json:"customer_name,omitempty"`
+	TotalAmount  decimal.Decimal `json:"total_amount"`
+	Status       string          `json:"status"`
+	CreatedAt    time.Time       `json:"created_at"`
+	DeletedAt    time.Time       `json:"deleted_at"`
+	IsOnHold     bool            `json:"is_on_hold"`
+	PurgeAfter   *time.Time      `json:"purge_after,omitempty"` // Unset while the record is kept indefinitely
+}
+
+// SchedulePurge sets when the purge job may delete the record under the tenant's retention
+// policy for its entity. Records on hold, and records without an enabled policy, are kept.
+func (r *DeletedRecord) SchedulePurge(policy *RetentionPolicy) {
+	r.PurgeAfter = nil
+	if policy == nil || !policy.IsEnabled || policy.Entity != r.Entity || r.IsOnHold {
+		return
+	}
+
+	purgeAfter := r.DeletedAt.AddDate(0, 0, policy.RetentionDays)
+	r.PurgeAfter = &purgeAfter
+}
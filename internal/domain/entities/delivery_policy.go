@@ -0,0 +1,209 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxDeliveryAlertRecipients caps how many addresses are notified when a destination is paused
+const MaxDeliveryAlertRecipients = 10
+
+// RetryPolicy controls how often an outbound delivery is attempted, how long to wait between
+// attempts and when a destination that keeps failing is paused
+type RetryPolicy struct {
+	MaxAttempts           int `json:"max_attempts"`
+	InitialBackoffSeconds int `json:"initial_backoff_seconds"` // Wait after the first failed attempt, doubled after each further one
+	MaxBackoffSeconds     int `json:"max_backoff_seconds"`
+	TimeoutSeconds        int `json:"timeout_seconds"`      // Bound on a single attempt
+	PauseAfterFailures    int `json:"pause_after_failures"` // Consecutive failures that pause the destination, 0 to never pause
+}
+
+// retryPolicyLimits bounds the retry policies tenants can configure for a channel
+type retryPolicyLimits struct {
+	maxAttempts       int
+	minBackoffSeconds int
+	maxBackoffSeconds int
+	maxTimeoutSeconds int
+	minPauseAfter     int
+	maxPauseAfter     int
+}
+
+var (
+	// Webhook retries are scheduled by the delivery job, so they can be spread over hours
+	webhookRetryLimits = retryPolicyLimits{
+		maxAttempts:       20,
+		minBackoffSeconds: 5,
+		maxBackoffSeconds: 24 * 60 * 60,
+		maxTimeoutSeconds: 30,
+		minPauseAfter:     5,
+		maxPauseAfter:     1000,
+	}
+
+	// Email retries happen while the email is being sent, so their backoff is kept short
+	emailRetryLimits = retryPolicyLimits{
+		maxAttempts:       5,
+		minBackoffSeconds: 1,
+		maxBackoffSeconds: 60,
+		maxTimeoutSeconds: 120,
+		minPauseAfter:     2,
+		maxPauseAfter:     100,
+	}
+)
+
+// DefaultWebhookRetryPolicy returns the webhook retry policy of tenants that did not configure one
+func DefaultWebhookRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:           MaxWebhookAttempts,
+		InitialBackoffSeconds: int(webhookBaseBackoff / time.Second),
+		MaxBackoffSeconds:     int(webhookMaxBackoff / time.Second),
+		TimeoutSeconds:        10,
+		PauseAfterFailures:    50,
+	}
+}
+
+// DefaultEmailRetryPolicy returns the email retry policy of tenants that did not configure one
+func DefaultEmailRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:           3,
+		InitialBackoffSeconds: 2,
+		MaxBackoffSeconds:     10,
+		TimeoutSeconds:        30,
+		PauseAfterFailures:    5,
+	}
+}
+
+// Backoff returns how long to wait before retrying after the given number of attempts: the
+// initial backoff doubling on each attempt, capped at the maximum backoff
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	backoff := time.Duration(p.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(p.MaxBackoffSeconds) * time.Second
+
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
+
+// AttemptTimeout returns how long a single attempt may take
+func (p RetryPolicy) AttemptTimeout() time.Duration {
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+// ShouldPause reports whether a destination that failed the given number of times in a row
+// should be paused
+func (p RetryPolicy) ShouldPause(consecutiveFailures int) bool {
+	return p.PauseAfterFailures > 0 && consecutiveFailures >= p.PauseAfterFailures
+}
+
+// validate checks the policy against the limits of its channel
+func (p RetryPolicy) validate(channel string, limits retryPolicyLimits) error {
+	if p.MaxAttempts < 1 || p.MaxAttempts > limits.maxAttempts {
+		return errors.NewValidationError("invalid retry policy",
+			fmt.Sprintf("%s max attempts must be between 1 and %d", channel, limits.maxAttempts))
+	}
+	if p.InitialBackoffSeconds < limits.minBackoffSeconds || p.InitialBackoffSeconds > limits.maxBackoffSeconds {
+		return errors.NewValidationError("invalid retry policy",
+			fmt.Sprintf("%s initial backoff must be between %d and %d seconds", channel, limits.minBackoffSeconds, limits.maxBackoffSeconds))
+	}
+	if p.MaxBackoffSeconds < p.InitialBackoffSeconds || p.MaxBackoffSeconds > limits.maxBackoffSeconds {
+		return errors.NewValidationError("invalid retry policy",
+			fmt.Sprintf("%s max backoff must be between the initial backoff and %d seconds", channel, limits.maxBackoffSeconds))
+	}
+	if p.TimeoutSeconds < 1 || p.TimeoutSeconds > limits.maxTimeoutSeconds {
+		return errors.NewValidationError("invalid retry policy",
+			fmt.Sprintf("%s timeout must be between 1 and %d seconds", channel, limits.maxTimeoutSeconds))
+	}
+	if p.PauseAfterFailures != 0 && (p.PauseAfterFailures < limits.minPauseAfter || p.PauseAfterFailures > limits.maxPauseAfter) {
+		return errors.NewValidationError("invalid retry policy",
+			fmt.Sprintf("%s pause after failures must be 0 or between %d and %d", channel, limits.minPauseAfter, limits.maxPauseAfter))
+	}
+	return nil
+}
+
+// DeliveryPolicy represents a tenant's retry policies for outbound webhooks and emails, and
+// who is notified when a destination is paused because it keeps failing
+type DeliveryPolicy struct {
+	ID              uuid.UUID   `json:"id"`
+	TenantID        uuid.UUID   `json:"tenant_id"`
+	Webhook         RetryPolicy `json:"webhook"`
+	Email           RetryPolicy `json:"email"`
+	AlertRecipients []string    `json:"alert_recipients"` // Falls back to the tenant's business email when empty
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	UpdatedBy       uuid.UUID   `json:"updated_by"`
+}
+
+// NewDeliveryPolicy creates a delivery policy with the default retry policies
+func NewDeliveryPolicy(tenantID, updatedBy uuid.UUID) (*DeliveryPolicy, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	policy := &DeliveryPolicy{
+		ID:              uuid.New(),
+		TenantID:        tenantID,
+		Webhook:         DefaultWebhookRetryPolicy(),
+		Email:           DefaultEmailRetryPolicy(),
+		AlertRecipients: []string{},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		UpdatedBy:       updatedBy,
+	}
+
+	return policy, nil
+}
+
+// Configure updates the retry policies and the addresses notified about paused destinations
+func (p *DeliveryPolicy) Configure(webhook, email RetryPolicy, alertRecipients []string, updatedBy uuid.UUID) error {
+	if err := webhook.validate("webhook", webhookRetryLimits); err != nil {
+		return err
+	}
+	if err := email.validate("email", emailRetryLimits); err != nil {
+		return err
+	}
+
+	cleaned := make([]string, 0, len(alertRecipients))
+	seen := make(map[string]bool, len(alertRecipients))
+	for _, recipient := range alertRecipients {
+		recipient = NormalizeEmail(recipient)
+		if recipient == "" || seen[recipient] {
+			continue
+		}
+		if !isValidEmail(recipient) {
+			return errors.NewValidationError("invalid recipient", "recipient "+recipient+" is not a valid email address")
+		}
+		seen[recipient] = true
+		cleaned = append(cleaned, recipient)
+	}
+	if len(cleaned) > MaxDeliveryAlertRecipients {
+		return errors.NewValidationError("too many recipients", "pause alerts can be sent to at most 10 recipients")
+	}
+
+	p.Webhook = webhook
+	p.Email = email
+	p.AlertRecipients = cleaned
+	p.UpdatedBy = updatedBy
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// AlertRecipientsOr returns the addresses notified about paused destinations, falling back
+// to the given address when none are configured
+func (p *DeliveryPolicy) AlertRecipientsOr(fallback string) []string {
+	if len(p.AlertRecipients) > 0 {
+		return p.AlertRecipients
+	}
+	if fallback = strings.TrimSpace(fallback); fallback != "" {
+		return []string{fallback}
+	}
+	return nil
+}
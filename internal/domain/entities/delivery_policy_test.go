@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeliveryPolicy(t *testing.T) {
+	policy, err := NewDeliveryPolicy(uuid.New(), uuid.Nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultWebhookRetryPolicy(), policy.Webhook)
+	assert.Equal(t, DefaultEmailRetryPolicy(), policy.Email)
+	assert.Empty(t, policy.AlertRecipients)
+
+	_, err = NewDeliveryPolicy(uuid.Nil, uuid.Nil)
+	assert.Error(t, err)
+}
+
+func TestDeliveryPolicy_Configure(t *testing.T) {
+	newPolicy := func() *DeliveryPolicy {
+		policy, err := NewDeliveryPolicy(uuid.New(), uuid.Nil)
+		require.NoError(t, err)
+		return policy
+	}
+
+	t.Run("valid policies", func(t *testing.T) {
+		policy := newPolicy()
+		webhook := RetryPolicy{MaxAttempts: 12, InitialBackoffSeconds: 60, MaxBackoffSeconds: 3600, TimeoutSeconds: 5, PauseAfterFailures: 0}
+		email := RetryPolicy{MaxAttempts: 2, InitialBackoffSeconds: 1, MaxBackoffSeconds: 1, TimeoutSeconds: 60, PauseAfterFailures: 3}
+
+		require.NoError(t, policy.Configure(webhook, email, []string{" Ops@Example.com ", "ops@example.com", ""}, uuid.New()))
+		assert.Equal(t, webhook, policy.Webhook)
+		assert.Equal(t, email, policy.Email)
+		assert.Equal(t, []string{"ops@example.com"}, policy.AlertRecipients)
+	})
+
+	t.Run("invalid webhook policy", func(t *testing.T) {
+		email := DefaultEmailRetryPolicy()
+		for _, webhook := range []RetryPolicy{
+			{MaxAttempts: 0, InitialBackoffSeconds: 30, MaxBackoffSeconds: 60, TimeoutSeconds: 10},
+			{MaxAttempts: 21, InitialBackoffSeconds: 30, MaxBackoffSeconds: 60, TimeoutSeconds: 10},
+			{MaxAttempts: 5, InitialBackoffSeconds: 1, MaxBackoffSeconds: 60, TimeoutSeconds: 10},
+			{MaxAttempts: 5, InitialBackoffSeconds: 60, MaxBackoffSeconds: 30, TimeoutSeconds: 10},
+			{MaxAttempts: 5, InitialBackoffSeconds: 30, MaxBackoffSeconds: 60, TimeoutSeconds: 31},
+			{MaxAttempts: 5, InitialBackoffSeconds: 30, MaxBackoffSeconds: 60, TimeoutSeconds: 10, PauseAfterFailures: 1},
+		} {
+			assert.Error(t, newPolicy().Configure(webhook, email, nil, uuid.New()), "%+v", webhook)
+		}
+	})
+
+	t.Run("email backoff is kept short", func(t *testing.T) {
+		email := RetryPolicy{MaxAttempts: 3, InitialBackoffSeconds: 2, MaxBackoffSeconds: 300, TimeoutSeconds: 30}
+		assert.Error(t, newPolicy().Configure(DefaultWebhookRetryPolicy(), email, nil, uuid.New()))
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		err := newPolicy().Configure(DefaultWebhookRetryPolicy(), DefaultEmailRetryPolicy(), []string{"not-an-email"}, uuid.New())
+		assert.Error(t, err)
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoffSeconds: 2, MaxBackoffSeconds: 10}
+
+	assert.Equal(t, 2*time.Second, policy.Backoff(1))
+	assert.Equal(t, 4*time.Second, policy.Backoff(2))
+	assert.Equal(t, 8*time.Second, policy.Backoff(3))
+	assert.Equal(t, 10*time.Second, policy.Backoff(4))
+}
+
+func TestRetryPolicy_ShouldPause(t *testing.T) {
+	assert.False(t, RetryPolicy{PauseAfterFailures: 5}.ShouldPause(4))
+	assert.True(t, RetryPolicy{PauseAfterFailures: 5}.ShouldPause(5))
+	assert.False(t, RetryPolicy{PauseAfterFailures: 0}.ShouldPause(1000))
+}
+
+func TestDeliveryPolicy_AlertRecipientsOr(t *testing.T) {
+	policy, err := NewDeliveryPolicy(uuid.New(), uuid.Nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"owner@example.com"}, policy.AlertRecipientsOr("owner@example.com"))
+	assert.Nil(t, policy.AlertRecipientsOr(""))
+
+	policy.AlertRecipients = []string{"ops@example.com"}
+	assert.Equal(t, []string{"ops@example.com"}, policy.AlertRecipientsOr("owner@example.com"))
+}
@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// SignableDocumentType represents a kind of document that can be signed by a customer
+type SignableDocumentType string
+
+const (
+	SignableDocumentInvoice SignableDocumentType = "invoice"
+)
+
+// MaxSignatureImageSize is the largest drawn signature image accepted, in bytes
+const MaxSignatureImageSize = 512 * 1024
+
+// Signature image content types
+const (
+	SignatureImagePNG  = "image/png"
+	SignatureImageJPEG = "image/jpeg"
+)
+
+// DocumentSignature represents a drawn signature captured against a document, such as a
+// customer confirming delivery of an invoiced order. A document has at most one signature.
+type DocumentSignature struct {
+	ID           uuid.UUID            `json:"id"`
+	TenantID     uuid.UUID            `json:"tenant_id"`
+	DocumentType SignableDocumentType `json:"document_type"`
+	DocumentID   uuid.UUID            `json:"document_id"`
+	SignerName   string               `json:"signer_name"`
+	SignerTitle  string               `json:"signer_title,omitempty"` // e.g. "Warehouse Supervisor"
+	ImageData    []byte               `json:"-"`
+	ContentType  string               `json:"content_type"`
+	IPAddress    string               `json:"ip_address"`
+	UserAgent    string               `json:"user_agent,omitempty"`
+	SignedAt     time.Time            `json:"signed_at"`
+	CapturedBy   uuid.UUID            `json:"captured_by"`
+}
+
+// NewDocumentSignature creates a new document signature from a drawn PNG or JPEG image
+func NewDocumentSignature(tenantID uuid.UUID, documentType SignableDocumentType, documentID uuid.UUID, signerName, signerTitle string, imageData []byte, ipAddress, userAgent string, capturedBy uuid.UUID) (*DocumentSignature, error) {
+	if err := ValidateSignableDocumentType(documentType); err != nil {
+		return nil, err
+	}
+
+	signerName = strings.TrimSpace(signerName)
+	if signerName == "" {
+		return nil, errors.NewValidationError("signer name is required", "signer_name cannot be empty")
+	}
+	if len(signerName) > 100 || len(signerTitle) > 100 {
+		return nil, errors.NewValidationError("signer details too long", "signer name and title cannot exceed 100 characters")
+	}
+
+	if len(imageData) == 0 {
+		return nil, errors.NewValidationError("signature image is required", "image cannot be empty")
+	}
+	if len(imageData) > MaxSignatureImageSize {
+		return nil, errors.NewValidationError("signature image too large", "image cannot exceed 512 KB")
+	}
+	contentType := detectImageType(imageData)
+	if contentType == "" {
+		return nil, errors.NewValidationError("invalid signature image", "image must be a PNG or JPEG")
+	}
+
+	signature := &DocumentSignature{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		DocumentType: documentType,
+		DocumentID:   documentID,
+		SignerName:   signerName,
+		SignerTitle:  strings.TrimSpace(signerTitle),
+		ImageData:    imageData,
+		ContentType:  contentType,
+		IPAddress:    ipAddress,
+		UserAgent:    userAgent,
+		SignedAt:     time.Now(),
+		CapturedBy:   capturedBy,
+	}
+
+	return signature, nil
+}
+
+// ValidateSignableDocumentType validates if documents of the type can be signed
+func ValidateSignableDocumentType(documentType SignableDocumentType) error {
+	switch documentType {
+	case SignableDocumentInvoice:
+		return nil
+	default:
+		return errors.NewValidationError("invalid document type", "document type must be: invoice")
+	}
+}
+
+// Helper functions
+
+// detectImageType returns the content type of a PNG or JPEG image from its magic bytes
+func detectImageType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return SignatureImagePNG
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return SignatureImageJPEG
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDocumentSignature(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nrest-of-image")
+
+	t.Run("valid signature", func(t *testing.T) {
+		signature, err := NewDocumentSignature(uuid.New(), SignableDocumentInvoice, uuid.New(), " Budi Santoso ", "Supervisor", png, "10.0.0.1", "tablet", uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Budi Santoso", signature.SignerName)
+		assert.Equal(t, SignatureImagePNG, signature.ContentType)
+		assert.Equal(t, "10.0.0.1", signature.IPAddress)
+		assert.False(t, signature.SignedAt.IsZero())
+	})
+
+	t.Run("jpeg image", func(t *testing.T) {
+		signature, err := NewDocumentSignature(uuid.New(), SignableDocumentInvoice, uuid.New(), "Budi", "", []byte{0xFF, 0xD8, 0xFF, 0xE0}, "", "", uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, SignatureImageJPEG, signature.ContentType)
+	})
+
+	t.Run("missing signer name", func(t *testing.T) {
+		_, err := NewDocumentSignature(uuid.New(), SignableDocumentInvoice, uuid.New(), " ", "", png, "", "", uuid.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported image", func(t *testing.T) {
+		_, err := NewDocumentSignature(uuid.New(), SignableDocumentInvoice, uuid.New(), "Budi", "", []byte("<svg/>"), "", "", uuid.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("image too large", func(t *testing.T) {
+		large := append(png, make([]byte, MaxSignatureImageSize)...)
+		_, err := NewDocumentSignature(uuid.New(), SignableDocumentInvoice, uuid.New(), "Budi", "", large, "", "", uuid.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid document type", func(t *testing.T) {
+		_, err := NewDocumentSignature(uuid.New(), SignableDocumentType("receipt"), uuid.New(), "Budi", "", png, "", "", uuid.New())
+		assert.Error(t, err)
+	})
+}
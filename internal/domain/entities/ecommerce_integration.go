@@ -0,0 +1,518 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// EcommercePlatform represents the storefront platform an integration connects to
+type EcommercePlatform string
+
+const (
+	EcommercePlatformShopify     EcommercePlatform = "shopify"
+	EcommercePlatformWooCommerce EcommercePlatform = "woocommerce"
+)
+
+// IsValid reports whether the platform is supported
+func (p EcommercePlatform) IsValid() bool {
+	switch p {
+	case EcommercePlatformShopify, EcommercePlatformWooCommerce:
+		return true
+	default:
+		return false
+	}
+}
+
+// EcommerceOrderStatus represents how far a storefront order has been processed
+type EcommerceOrderStatus string
+
+const (
+	EcommerceOrderStatusOpen      EcommerceOrderStatus = "open"      // Sale pending, its stock reserved
+	EcommerceOrderStatusFulfilled EcommerceOrderStatus = "fulfilled" // Sale completed
+	EcommerceOrderStatusCancelled EcommerceOrderStatus = "cancelled" // Sale cancelled, its stock released
+	EcommerceOrderStatusFailed    EcommerceOrderStatus = "failed"    // Could not be turned into a sale; see the error
+)
+
+// IsValid reports whether the order status is known
+func (s EcommerceOrderStatus) IsValid() bool {
+	switch s {
+	case EcommerceOrderStatusOpen, EcommerceOrderStatusFulfilled, EcommerceOrderStatusCancelled, EcommerceOrderStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// EcommerceCredentials represents the secrets an integration uses to talk to its storefront.
+// Empty fields leave the stored value unchanged when credentials are updated.
+type EcommerceCredentials struct {
+	WebhookSecret  string `json:"webhook_secret,omitempty"`  // Signs the order webhooks the storefront sends
+	AccessToken    string `json:"access_token,omitempty"`    // Shopify Admin API access token
+	ConsumerKey    string `json:"consumer_key,omitempty"`    // WooCommerce REST API key
+	ConsumerSecret string `json:"consumer_secret,omitempty"` // WooCommerce REST API secret
+}
+
+// EcommerceIntegration represents a tenant's connection to a Shopify or WooCommerce store.
+// Orders placed on the store become sales with reserved stock, and stock levels of linked
+// products are pushed back to the store.
+type EcommerceIntegration struct {
+	ID              uuid.UUID         `json:"id"`
+	TenantID        uuid.UUID         `json:"tenant_id"`
+	Platform        EcommercePlatform `json:"platform"`
+	Name            string            `json:"name"`
+	StoreURL        string            `json:"store_url"` // e.g. https://example.myshopify.com
+	WebhookSecret   string            `json:"-"`
+	AccessToken     string            `json:"-"`
+	ConsumerKey     string            `json:"-"`
+	ConsumerSecret  string            `json:"-"`
+	LocationID      string            `json:"location_id,omitempty"` // Shopify location whose stock is set
+	PushStock       bool              `json:"push_stock"`            // Push stock levels of linked products to the store
+	IsActive        bool              `json:"is_active"`
+	LastOrderAt     *time.Time        `json:"last_order_at,omitempty"`
+	LastStockPushAt *time.Time        `json:"last_stock_push_at,omitempty"`
+	LastError       string            `json:"last_error,omitempty"` // Why the latest stock push failed
+	CreatedBy       uuid.UUID         `json:"created_by"`           // Sales and reservations of the store's orders are made on their behalf
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// NewEcommerceIntegration creates an active integration with a store
+func NewEcommerceIntegration(tenantID uuid.UUID, platform EcommercePlatform, name, storeURL, locationID string, pushStock bool, credentials EcommerceCredentials, createdBy uuid.UUID) (*EcommerceIntegration, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if !platform.IsValid() {
+		return nil, errors.NewValidationError("invalid platform", "platform must be one of: shopify, woocommerce")
+	}
+
+	now := time.Now()
+	integration := &EcommerceIntegration{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Platform:  platform,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := integration.Update(name, storeURL, locationID, pushStock, true, credentials); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+// Update changes the integration's settings and replaces the credentials that are given. The
+// platform cannot be changed.
+func (i *EcommerceIntegration) Update(name, storeURL, locationID string, pushStock, isActive bool, credentials EcommerceCredentials) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("integration name is required", "integration name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.NewValidationError("integration name too long", "integration name cannot exceed 100 characters")
+	}
+
+	storeURL = strings.TrimRight(strings.TrimSpace(storeURL), "/")
+	if err := validateStoreURL(storeURL); err != nil {
+		return err
+	}
+
+	updated := *i
+	updated.Name = name
+	updated.StoreURL = storeURL
+	updated.LocationID = strings.TrimSpace(locationID)
+	updated.PushStock = pushStock
+	updated.IsActive = isActive
+	replaceCredential(&updated.WebhookSecret, credentials.WebhookSecret)
+	replaceCredential(&updated.AccessToken, credentials.AccessToken)
+	replaceCredential(&updated.ConsumerKey, credentials.ConsumerKey)
+	replaceCredential(&updated.ConsumerSecret, credentials.ConsumerSecret)
+
+	if updated.WebhookSecret == "" {
+		return errors.NewValidationError("webhook secret is required", "webhook_secret is needed to verify the store's order webhooks")
+	}
+	if updated.PushStock {
+		switch updated.Platform {
+		case EcommercePlatformShopify:
+			if updated.AccessToken == "" || updated.LocationID == "" {
+				return errors.NewValidationError("missing Shopify credentials", "pushing stock to Shopify needs an access_token and a location_id")
+			}
+		case EcommercePlatformWooCommerce:
+			if updated.ConsumerKey == "" || updated.ConsumerSecret == "" {
+				return errors.NewValidationError("missing WooCommerce credentials", "pushing stock to WooCommerce needs a consumer_key and a consumer_secret")
+			}
+		}
+	}
+
+	updated.UpdatedAt = time.Now()
+	*i = updated
+	return nil
+}
+
+// VerifySignature reports whether signature is the base64 HMAC-SHA256 of body keyed with the
+// webhook secret, which is how both Shopify and WooCommerce sign their webhooks
+func (i *EcommerceIntegration) VerifySignature(body []byte, signature string) bool {
+	if i.WebhookSecret == "" || signature == "" {
+		return false
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(i.WebhookSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// RecordStockPush records the outcome of pushing stock levels to the store, nil meaning every
+// level was pushed
+func (i *EcommerceIntegration) RecordStockPush(at time.Time, pushErr error) {
+	i.UpdatedAt = at
+	if pushErr != nil {
+		i.LastError = pushErr.Error()
+		return
+	}
+
+	i.LastStockPushAt = &at
+	i.LastError = ""
+}
+
+// EcommerceOrder represents a storefront order received by an integration and the sale it
+// was turned into
+type EcommerceOrder struct {
+	ID            uuid.UUID            `json:"id"`
+	TenantID      uuid.UUID            `json:"tenant_id"`
+	IntegrationID uuid.UUID            `json:"integration_id"`
+	ExternalID    string               `json:"external_id"` // Order ID on the storefront
+	OrderNumber   string               `json:"order_number"`
+	Status        EcommerceOrderStatus `json:"status"`
+	SaleID        *uuid.UUID           `json:"sale_id,omitempty"`
+	ReservationID *uuid.UUID           `json:"reservation_id,omitempty"`
+	Error         string               `json:"error,omitempty"` // Why the order failed, or which lines were skipped
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// NewEcommerceOrder creates an open order received by an integration
+func NewEcommerceOrder(integration *EcommerceIntegration, externalID, orderNumber string) (*EcommerceOrder, error) {
+	externalID = strings.TrimSpace(externalID)
+	if externalID == "" {
+		return nil, errors.NewValidationError("order ID is required", "the storefront order has no ID")
+	}
+	orderNumber = strings.TrimSpace(orderNumber)
+	if orderNumber == "" {
+		orderNumber = externalID
+	}
+
+	now := time.Now()
+	return &EcommerceOrder{
+		ID:            uuid.New(),
+		TenantID:      integration.TenantID,
+		IntegrationID: integration.ID,
+		ExternalID:    externalID,
+		OrderNumber:   orderNumber,
+		Status:        EcommerceOrderStatusOpen,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// Reference returns the reference the order's stock reservation is made under
+func (o *EcommerceOrder) Reference(platform EcommercePlatform) string {
+	return fmt.Sprintf("%s:%s", platform, o.OrderNumber)
+}
+
+// IsSettled reports whether the order reached a status that later webhooks cannot change
+func (o *EcommerceOrder) IsSettled() bool {
+	return o.Status == EcommerceOrderStatusFulfilled || o.Status == EcommerceOrderStatusCancelled
+}
+
+// Fail marks the order as failed with the reason
+func (o *EcommerceOrder) Fail(reason string) {
+	o.Status = EcommerceOrderStatusFailed
+	o.Error = reason
+	o.UpdatedAt = time.Now()
+}
+
+// SetStatus moves the order to a new status
+func (o *EcommerceOrder) SetStatus(status EcommerceOrderStatus) {
+	o.Status = status
+	o.UpdatedAt = time.Now()
+}
+
+// EcommerceProductLink maps a product to its product or variant on an integration's store, so
+// its stock level can be pushed there
+type EcommerceProductLink struct {
+	ID                uuid.UUID  `json:"id"`
+	TenantID          uuid.UUID  `json:"tenant_id"`
+	IntegrationID     uuid.UUID  `json:"integration_id"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	ExternalProductID string     `json:"external_product_id"`
+	ExternalVariantID string     `json:"external_variant_id,omitempty"`
+	InventoryItemID   string     `json:"inventory_item_id,omitempty"` // Shopify only; resolved from the variant on the first push
+	LastPushedQty     *int       `json:"last_pushed_qty,omitempty"`
+	LastPushedAt      *time.Time `json:"last_pushed_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// NewEcommerceProductLink links a product to its product or variant on an integration's store.
+// Shopify tracks stock per variant, so Shopify links need a variant ID.
+func NewEcommerceProductLink(integration *EcommerceIntegration, productID uuid.UUID, externalProductID, externalVariantID string) (*EcommerceProductLink, error) {
+	if productID == uuid.Nil {
+		return nil, errors.NewValidationError("product ID is required", "product ID cannot be empty")
+	}
+	externalProductID = strings.TrimSpace(externalProductID)
+	externalVariantID = strings.TrimSpace(externalVariantID)
+	if externalProductID == "" {
+		return nil, errors.NewValidationError("external product ID is required", "external_product_id cannot be empty")
+	}
+	if integration.Platform == EcommercePlatformShopify && externalVariantID == "" {
+		return nil, errors.NewValidationError("external variant ID is required", "Shopify products are linked by variant")
+	}
+
+	now := time.Now()
+	return &EcommerceProductLink{
+		ID:                uuid.New(),
+		TenantID:          integration.TenantID,
+		IntegrationID:     integration.ID,
+		ProductID:         productID,
+		ExternalProductID: externalProductID,
+		ExternalVariantID: externalVariantID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// NeedsPush reports whether the store may show a different stock level than quantity
+func (l *EcommerceProductLink) NeedsPush(quantity int) bool {
+	return l.LastPushedQty == nil || *l.LastPushedQty != quantity
+}
+
+// RecordPush records that quantity was pushed to the store
+func (l *EcommerceProductLink) RecordPush(quantity int, at time.Time) {
+	l.LastPushedQty = &quantity
+	l.LastPushedAt = &at
+	l.UpdatedAt = at
+}
+
+// StorefrontOrder represents the parts of a Shopify or WooCommerce order webhook needed to
+// turn it into a sale
+type StorefrontOrder struct {
+	ExternalID    string
+	Number        string
+	Status        EcommerceOrderStatus
+	CustomerName  string
+	CustomerEmail string
+	CustomerPhone string
+	Currency      string
+	Lines         []StorefrontOrderLine
+}
+
+// StorefrontOrderLine represents a line of a storefront order
+type StorefrontOrderLine struct {
+	SKU               string
+	Name              string
+	Quantity          int
+	UnitPrice         decimal.Decimal
+	ExternalProductID string
+	ExternalVariantID string
+}
+
+// ParseStorefrontOrder parses the body of an order webhook sent by the platform
+func ParseStorefrontOrder(platform EcommercePlatform, body []byte) (*StorefrontOrder, error) {
+	switch platform {
+	case EcommercePlatformShopify:
+		return parseShopifyOrder(body)
+	case EcommercePlatformWooCommerce:
+		return parseWooCommerceOrder(body)
+	default:
+		return nil, errors.NewValidationError("invalid platform", "platform must be one of: shopify, woocommerce")
+	}
+}
+
+// shopifyOrder is the subset of a Shopify order webhook body that is read
+type shopifyOrder struct {
+	ID                json.Number `json:"id"`
+	Name              string      `json:"name"` // e.g. #1001
+	Email             string      `json:"email"`
+	Phone             string      `json:"phone"`
+	Currency          string      `json:"currency"`
+	CancelledAt       *string     `json:"cancelled_at"`
+	FulfillmentStatus *string     `json:"fulfillment_status"`
+	Customer          *struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Phone     string `json:"phone"`
+	} `json:"customer"`
+	LineItems []struct {
+		SKU       string          `json:"sku"`
+		Name      string          `json:"name"`
+		Quantity  int             `json:"quantity"`
+		Price     decimal.Decimal `json:"price"`
+		ProductID json.Number     `json:"product_id"`
+		VariantID json.Number     `json:"variant_id"`
+	} `json:"line_items"`
+}
+
+func parseShopifyOrder(body []byte) (*StorefrontOrder, error) {
+	var payload shopifyOrder
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.NewValidationError("invalid order payload", err.Error())
+	}
+
+	order := &StorefrontOrder{
+		ExternalID:    payload.ID.String(),
+		Number:        strings.TrimPrefix(payload.Name, "#"),
+		Status:        EcommerceOrderStatusOpen,
+		CustomerEmail: payload.Email,
+		CustomerPhone: payload.Phone,
+		Currency:      payload.Currency,
+	}
+	switch {
+	case payload.CancelledAt != nil && *payload.CancelledAt != "":
+		order.Status = EcommerceOrderStatusCancelled
+	case payload.FulfillmentStatus != nil && *payload.FulfillmentStatus == "fulfilled":
+		order.Status = EcommerceOrderStatusFulfilled
+	}
+	if payload.Customer != nil {
+		order.CustomerName = strings.TrimSpace(payload.Customer.FirstName + " " + payload.Customer.LastName)
+		if order.CustomerPhone == "" {
+			order.CustomerPhone = payload.Customer.Phone
+		}
+	}
+	for _, item := range payload.LineItems {
+		order.Lines = append(order.Lines, StorefrontOrderLine{
+			SKU:               strings.TrimSpace(item.SKU),
+			Name:              item.Name,
+			Quantity:          item.Quantity,
+			UnitPrice:         item.Price,
+			ExternalProductID: item.ProductID.String(),
+			ExternalVariantID: item.VariantID.String(),
+		})
+	}
+
+	return order, order.validate()
+}
+
+// wooCommerceOrder is the subset of a WooCommerce order webhook body that is read
+type wooCommerceOrder struct {
+	ID       json.Number `json:"id"`
+	Number   string      `json:"number"`
+	Status   string      `json:"status"`
+	Currency string      `json:"currency"`
+	Billing  struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Email     string `json:"email"`
+		Phone     string `json:"phone"`
+	} `json:"billing"`
+	LineItems []struct {
+		SKU         string          `json:"sku"`
+		Name        string          `json:"name"`
+		Quantity    int             `json:"quantity"`
+		Price       decimal.Decimal `json:"price"`
+		ProductID   json.Number     `json:"product_id"`
+		VariationID json.Number     `json:"variation_id"`
+	} `json:"line_items"`
+}
+
+func parseWooCommerceOrder(body []byte) (*StorefrontOrder, error) {
+	var payload wooCommerceOrder
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.NewValidationError("invalid order payload", err.Error())
+	}
+
+	order := &StorefrontOrder{
+		ExternalID:    payload.ID.String(),
+		Number:        payload.Number,
+		Status:        EcommerceOrderStatusOpen,
+		CustomerName:  strings.TrimSpace(payload.Billing.FirstName + " " + payload.Billing.LastName),
+		CustomerEmail: payload.Billing.Email,
+		CustomerPhone: payload.Billing.Phone,
+		Currency:      payload.Currency,
+	}
+	switch payload.Status {
+	case "completed":
+		order.Status = EcommerceOrderStatusFulfilled
+	case "cancelled", "refunded", "failed":
+		order.Status = EcommerceOrderStatusCancelled
+	}
+	for _, item := range payload.LineItems {
+		variationID := item.VariationID.String()
+		if variationID == "0" {
+			variationID = ""
+		}
+		order.Lines = append(order.Lines, StorefrontOrderLine{
+			SKU:               strings.TrimSpace(item.SKU),
+			Name:              item.Name,
+			Quantity:          item.Quantity,
+			UnitPrice:         item.Price,
+			ExternalProductID: item.ProductID.String(),
+			ExternalVariantID: variationID,
+		})
+	}
+
+	return order, order.validate()
+}
+
+func (o *StorefrontOrder) validate() error {
+	if o.ExternalID == "" {
+		return errors.NewValidationError("invalid order payload", "order has no ID")
+	}
+	if o.Number == "" {
+		o.Number = o.ExternalID
+	}
+	return nil
+}
+
+// replaceCredential sets a stored credential to value unless value is empty
+func replaceCredential(stored *string, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		*stored = value
+	}
+}
+
+// validateStoreURL checks that a store URL is an absolute https URL without credentials that
+// does not point to a local address
+func validateStoreURL(storeURL string) error {
+	if storeURL == "" {
+		return errors.NewValidationError("store URL is required", "store_url cannot be empty")
+	}
+	if len(storeURL) > 2048 {
+		return errors.NewValidationError("store URL too long", "store_url cannot exceed 2048 characters")
+	}
+
+	parsed, err := url.Parse(storeURL)
+	if err != nil || parsed.Host == "" || parsed.Scheme != "https" {
+		return errors.NewValidationError("invalid store URL", "store_url must be an absolute https URL")
+	}
+	if parsed.User != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return errors.NewValidationError("invalid store URL", "store_url cannot contain credentials, a query or a fragment")
+	}
+
+	// Hosts resolving to internal addresses are refused again when the store is called
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.NewValidationError("invalid store URL", "store_url cannot point to a local address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicWebhookAddress(addr) {
+		return errors.NewValidationError("invalid store URL", "store_url cannot point to a loopback, private or link-local address")
+	}
+
+	return nil
+}
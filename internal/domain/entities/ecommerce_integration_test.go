@@ -0,0 +1,314 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEcommerceIntegration(t *testing.T, platform EcommercePlatform) *EcommerceIntegration {
+	t.Helper()
+
+	integration, err := NewEcommerceIntegration(uuid.New(), platform, "Web store", "https://shop.example.com", "", false,
+		EcommerceCredentials{WebhookSecret: "hook-secret"}, uuid.New())
+	require.NoError(t, err)
+	return integration
+}
+
+func TestNewEcommerceIntegration(t *testing.T) {
+	t.Run("shopify integration pushing stock", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		integration, err := NewEcommerceIntegration(tenantID, EcommercePlatformShopify, " Shopify ", " https://example.myshopify.com/ ", " 655441491 ", true,
+			EcommerceCredentials{WebhookSecret: "hook-secret", AccessToken: "shpat_token"}, createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, integration.ID)
+		assert.Equal(t, tenantID, integration.TenantID)
+		assert.Equal(t, "Shopify", integration.Name)
+		assert.Equal(t, "https://example.myshopify.com", integration.StoreURL)
+		assert.Equal(t, "655441491", integration.LocationID)
+		assert.Equal(t, "hook-secret", integration.WebhookSecret)
+		assert.Equal(t, "shpat_token", integration.AccessToken)
+		assert.True(t, integration.PushStock)
+		assert.True(t, integration.IsActive)
+		assert.Equal(t, createdBy, integration.CreatedBy)
+	})
+
+	t.Run("webhook secret is required", func(t *testing.T) {
+		integration, err := NewEcommerceIntegration(uuid.New(), EcommercePlatformWooCommerce, "Woo", "https://shop.example.com", "", false,
+			EcommerceCredentials{}, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, integration)
+		assert.Contains(t, err.Error(), "webhook secret is required")
+	})
+
+	t.Run("pushing stock to shopify needs a location", func(t *testing.T) {
+		_, err := NewEcommerceIntegration(uuid.New(), EcommercePlatformShopify, "Shopify", "https://example.myshopify.com", "", true,
+			EcommerceCredentials{WebhookSecret: "hook-secret", AccessToken: "shpat_token"}, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing Shopify credentials")
+	})
+
+	t.Run("pushing stock to woocommerce needs API keys", func(t *testing.T) {
+		_, err := NewEcommerceIntegration(uuid.New(), EcommercePlatformWooCommerce, "Woo", "https://shop.example.com", "", true,
+			EcommerceCredentials{WebhookSecret: "hook-secret", ConsumerKey: "ck_key"}, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing WooCommerce credentials")
+	})
+
+	t.Run("store URL must use https", func(t *testing.T) {
+		_, err := NewEcommerceIntegration(uuid.New(), EcommercePlatformWooCommerce, "Woo", "http://shop.example.com", "", false,
+			EcommerceCredentials{WebhookSecret: "hook-secret"}, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid store URL")
+	})
+
+	t.Run("store URL must be public", func(t *testing.T) {
+		for _, storeURL := range []string{"https://localhost", "https://127.0.0.1:8443", "https://169.254.169.254", "https://[::1]", "https://10.0.0.5"} {
+			_, err := NewEcommerceIntegration(uuid.New(), EcommercePlatformWooCommerce, "Woo", storeURL, "", false,
+				EcommerceCredentials{WebhookSecret: "hook-secret"}, uuid.New())
+
+			assert.Error(t, err, storeURL)
+			assert.Contains(t, err.Error(), "invalid store URL", storeURL)
+		}
+	})
+
+	t.Run("invalid platform", func(t *testing.T) {
+		_, err := NewEcommerceIntegration(uuid.New(), EcommercePlatform("magento"), "Magento", "https://shop.example.com", "", false,
+			EcommerceCredentials{WebhookSecret: "hook-secret"}, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid platform")
+	})
+}
+
+func TestEcommerceIntegration_Update(t *testing.T) {
+	t.Run("keeps credentials that are not given", func(t *testing.T) {
+		integration := newTestEcommerceIntegration(t, EcommercePlatformWooCommerce)
+
+		err := integration.Update("Woo", "https://shop.example.com", "", true, false,
+			EcommerceCredentials{ConsumerKey: "ck_key", ConsumerSecret: "cs_secret"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "hook-secret", integration.WebhookSecret)
+		assert.Equal(t, "ck_key", integration.ConsumerKey)
+		assert.Equal(t, "cs_secret", integration.ConsumerSecret)
+		assert.True(t, integration.PushStock)
+		assert.False(t, integration.IsActive)
+	})
+
+	t.Run("leaves the integration unchanged when invalid", func(t *testing.T) {
+		integration := newTestEcommerceIntegration(t, EcommercePlatformWooCommerce)
+
+		err := integration.Update("Renamed", "https://shop.example.com", "", true, true, EcommerceCredentials{})
+
+		assert.Error(t, err)
+		assert.Equal(t, "Web store", integration.Name)
+		assert.False(t, integration.PushStock)
+	})
+}
+
+func TestEcommerceIntegration_VerifySignature(t *testing.T) {
+	integration := newTestEcommerceIntegration(t, EcommercePlatformShopify)
+	body := []byte(`{"id":820982911946154500}`)
+
+	mac := hmac.New(sha256.New, []byte("hook-secret"))
+	mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, integration.VerifySignature(body, signature))
+	assert.False(t, integration.VerifySignature([]byte(`{"id":1}`), signature))
+	assert.False(t, integration.VerifySignature(body, ""))
+	assert.False(t, integration.VerifySignature(body, "not base64"))
+}
+
+func TestEcommerceIntegration_RecordStockPush(t *testing.T) {
+	integration := newTestEcommerceIntegration(t, EcommercePlatformShopify)
+	at := time.Now()
+
+	integration.RecordStockPush(at, assert.AnError)
+	assert.Equal(t, assert.AnError.Error(), integration.LastError)
+	assert.Nil(t, integration.LastStockPushAt)
+
+	integration.RecordStockPush(at, nil)
+	assert.Empty(t, integration.LastError)
+	require.NotNil(t, integration.LastStockPushAt)
+	assert.Equal(t, at, *integration.LastStockPushAt)
+}
+
+func TestNewEcommerceOrder(t *testing.T) {
+	integration := newTestEcommerceIntegration(t, EcommercePlatformShopify)
+
+	t.Run("defaults the number to the external ID", func(t *testing.T) {
+		order, err := NewEcommerceOrder(integration, "450789469", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, integration.TenantID, order.TenantID)
+		assert.Equal(t, integration.ID, order.IntegrationID)
+		assert.Equal(t, "450789469", order.OrderNumber)
+		assert.Equal(t, EcommerceOrderStatusOpen, order.Status)
+		assert.Equal(t, "shopify:450789469", order.Reference(integration.Platform))
+		assert.False(t, order.IsSettled())
+	})
+
+	t.Run("requires an external ID", func(t *testing.T) {
+		_, err := NewEcommerceOrder(integration, " ", "1001")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("fulfilled and cancelled orders are settled", func(t *testing.T) {
+		order, err := NewEcommerceOrder(integration, "450789469", "1001")
+		require.NoError(t, err)
+
+		order.Fail("no line matches a product")
+		assert.False(t, order.IsSettled())
+		assert.Equal(t, "no line matches a product", order.Error)
+
+		order.SetStatus(EcommerceOrderStatusFulfilled)
+		assert.True(t, order.IsSettled())
+	})
+}
+
+func TestNewEcommerceProductLink(t *testing.T) {
+	t.Run("shopify links need a variant", func(t *testing.T) {
+		integration := newTestEcommerceIntegration(t, EcommercePlatformShopify)
+
+		_, err := NewEcommerceProductLink(integration, uuid.New(), "632910392", "")
+		assert.Error(t, err)
+
+		link, err := NewEcommerceProductLink(integration, uuid.New(), "632910392", "808950810")
+		require.NoError(t, err)
+		assert.Equal(t, "808950810", link.ExternalVariantID)
+	})
+
+	t.Run("woocommerce links may omit the variation", func(t *testing.T) {
+		integration := newTestEcommerceIntegration(t, EcommercePlatformWooCommerce)
+
+		link, err := NewEcommerceProductLink(integration, uuid.New(), "93", "")
+		require.NoError(t, err)
+		assert.Equal(t, integration.TenantID, link.TenantID)
+		assert.Empty(t, link.ExternalVariantID)
+	})
+
+	t.Run("pushes until the level was pushed", func(t *testing.T) {
+		integration := newTestEcommerceIntegration(t, EcommercePlatformWooCommerce)
+		link, err := NewEcommerceProductLink(integration, uuid.New(), "93", "")
+		require.NoError(t, err)
+
+		assert.True(t, link.NeedsPush(0))
+		link.RecordPush(5, time.Now())
+		assert.False(t, link.NeedsPush(5))
+		assert.True(t, link.NeedsPush(4))
+	})
+}
+
+func TestParseStorefrontOrder(t *testing.T) {
+	t.Run("shopify order", func(t *testing.T) {
+		body := []byte(`{
+			"id": 450789469,
+			"name": "#1001",
+			"email": "bob@example.com",
+			"currency": "USD",
+			"cancelled_at": null,
+			"fulfillment_status": null,
+			"customer": {"first_name": "Bob", "last_name": "Norman", "phone": "+15551234567"},
+			"line_items": [
+				{"sku": "IPOD-8GB ", "name": "IPod Nano - 8gb", "quantity": 2, "price": "199.00", "product_id": 632910392, "variant_id": 808950810}
+			]
+		}`)
+
+		order, err := ParseStorefrontOrder(EcommercePlatformShopify, body)
+
+		require.NoError(t, err)
+		assert.Equal(t, "450789469", order.ExternalID)
+		assert.Equal(t, "1001", order.Number)
+		assert.Equal(t, EcommerceOrderStatusOpen, order.Status)
+		assert.Equal(t, "Bob Norman", order.CustomerName)
+		assert.Equal(t, "bob@example.com", order.CustomerEmail)
+		assert.Equal(t, "+15551234567", order.CustomerPhone)
+		assert.Equal(t, "USD", order.Currency)
+		require.Len(t, order.Lines, 1)
+		assert.Equal(t, "IPOD-8GB", order.Lines[0].SKU)
+		assert.Equal(t, 2, order.Lines[0].Quantity)
+		assert.True(t, decimal.NewFromInt(199).Equal(order.Lines[0].UnitPrice))
+		assert.Equal(t, "632910392", order.Lines[0].ExternalProductID)
+		assert.Equal(t, "808950810", order.Lines[0].ExternalVariantID)
+	})
+
+	t.Run("shopify order states", func(t *testing.T) {
+		fulfilled, err := ParseStorefrontOrder(EcommercePlatformShopify, []byte(`{"id": 1, "fulfillment_status": "fulfilled"}`))
+		require.NoError(t, err)
+		assert.Equal(t, EcommerceOrderStatusFulfilled, fulfilled.Status)
+
+		partial, err := ParseStorefrontOrder(EcommercePlatformShopify, []byte(`{"id": 1, "fulfillment_status": "partial"}`))
+		require.NoError(t, err)
+		assert.Equal(t, EcommerceOrderStatusOpen, partial.Status)
+
+		cancelled, err := ParseStorefrontOrder(EcommercePlatformShopify, []byte(`{"id": 1, "cancelled_at": "2026-10-15T10:00:00-04:00", "fulfillment_status": "fulfilled"}`))
+		require.NoError(t, err)
+		assert.Equal(t, EcommerceOrderStatusCancelled, cancelled.Status)
+	})
+
+	t.Run("woocommerce order", func(t *testing.T) {
+		body := []byte(`{
+			"id": 727,
+			"number": "727",
+			"status": "processing",
+			"currency": "EUR",
+			"billing": {"first_name": "John", "last_name": "Doe", "email": "john.doe@example.com", "phone": "(555) 555-5555"},
+			"line_items": [
+				{"sku": "WOO-1", "name": "Woo Single #1", "quantity": 2, "price": 3, "product_id": 93, "variation_id": 0},
+				{"sku": "", "name": "Ship Your Idea", "quantity": 1, "price": 20.5, "product_id": 22, "variation_id": 23}
+			]
+		}`)
+
+		order, err := ParseStorefrontOrder(EcommercePlatformWooCommerce, body)
+
+		require.NoError(t, err)
+		assert.Equal(t, "727", order.ExternalID)
+		assert.Equal(t, EcommerceOrderStatusOpen, order.Status)
+		assert.Equal(t, "John Doe", order.CustomerName)
+		assert.Equal(t, "john.doe@example.com", order.CustomerEmail)
+		require.Len(t, order.Lines, 2)
+		assert.Empty(t, order.Lines[0].ExternalVariantID)
+		assert.True(t, decimal.NewFromInt(3).Equal(order.Lines[0].UnitPrice))
+		assert.Equal(t, "23", order.Lines[1].ExternalVariantID)
+	})
+
+	t.Run("woocommerce order states", func(t *testing.T) {
+		for status, expected := range map[string]EcommerceOrderStatus{
+			"pending":    EcommerceOrderStatusOpen,
+			"on-hold":    EcommerceOrderStatusOpen,
+			"completed":  EcommerceOrderStatusFulfilled,
+			"cancelled":  EcommerceOrderStatusCancelled,
+			"refunded":   EcommerceOrderStatusCancelled,
+			"processing": EcommerceOrderStatusOpen,
+		} {
+			order, err := ParseStorefrontOrder(EcommercePlatformWooCommerce, []byte(`{"id": 1, "status": "`+status+`"}`))
+			require.NoError(t, err)
+			assert.Equal(t, expected, order.Status, status)
+		}
+	})
+
+	t.Run("invalid payloads", func(t *testing.T) {
+		_, err := ParseStorefrontOrder(EcommercePlatformShopify, []byte(`not json`))
+		assert.Error(t, err)
+
+		_, err = ParseStorefrontOrder(EcommercePlatformWooCommerce, []byte(`{"status": "processing"}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// OutboxEmailStatus represents the state of a queued email
+type OutboxEmailStatus string
+
+const (
+	OutboxEmailStatusPending OutboxEmailStatus = "pending" // Waiting for its next attempt
+	OutboxEmailStatusSent    OutboxEmailStatus = "sent"    // Accepted by the mail server
+	OutboxEmailStatusFailed  OutboxEmailStatus = "failed"  // Gave up after the last attempt
+)
+
+// OutboxEmailDelivery records how the invoice PDF reached the recipient
+type OutboxEmailDelivery string
+
+const (
+	OutboxEmailDeliveryAttachment OutboxEmailDelivery = "attachment" // PDF attached to the email
+	OutboxEmailDeliveryLink       OutboxEmailDelivery = "link"       // PDF too large to attach, signed portal link sent instead
+)
+
+// OutboxEmail represents an invoice email queued to be sent by the background worker, so a
+// slow mail server never holds up the request that asked for it. The invoice PDF is
+// rendered with the stored template when the email is sent.
+type OutboxEmail struct {
+	ID            uuid.UUID           `json:"id"`
+	TenantID      uuid.UUID           `json:"tenant_id"`
+	InvoiceID     uuid.UUID           `json:"invoice_id"`
+	Recipient     string              `json:"recipient"`
+	Template      *InvoiceTemplate    `json:"template,omitempty"` // Nil to use the default template of the paper size
+	PaperSize     PaperSize           `json:"paper_size"`
+	Status        OutboxEmailStatus   `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	NextAttemptAt *time.Time          `json:"next_attempt_at,omitempty"`
+	LastAttemptAt *time.Time          `json:"last_attempt_at,omitempty"`
+	LastError     string              `json:"last_error,omitempty"`
+	Delivery      OutboxEmailDelivery `json:"delivery,omitempty"` // Set by the last attempt
+	SentAt        *time.Time          `json:"sent_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	CreatedBy     uuid.UUID           `json:"created_by"`
+}
+
+// NewOutboxEmail queues an invoice email to a recipient, due immediately
+func NewOutboxEmail(invoice *Invoice, recipient string, paperSize PaperSize, template *InvoiceTemplate, createdBy uuid.UUID) (*OutboxEmail, error) {
+	recipient = NormalizeEmail(recipient)
+	if !isValidEmail(recipient) {
+		return nil, errors.NewValidationError("invalid recipient", "recipient must be a valid email address")
+	}
+	if paperSize == "" {
+		paperSize = PaperSizeA4
+	}
+
+	now := time.Now()
+	return &OutboxEmail{
+		ID:            uuid.New(),
+		TenantID:      invoice.TenantID,
+		InvoiceID:     invoice.ID,
+		Recipient:     recipient,
+		Template:      template,
+		PaperSize:     paperSize,
+		Status:        OutboxEmailStatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		CreatedBy:     createdBy,
+	}, nil
+}
+
+// RecordAttempt records the outcome of sending the email. A failed attempt is retried with
+// the policy's backoff until its attempts run out.
+func (e *OutboxEmail) RecordAttempt(at time.Time, sendErr error, policy RetryPolicy) {
+	e.Attempts++
+	e.LastAttemptAt = &at
+	e.UpdatedAt = time.Now()
+
+	if sendErr == nil {
+		e.Status = OutboxEmailStatusSent
+		e.SentAt = &at
+		e.NextAttemptAt = nil
+		e.LastError = ""
+		return
+	}
+
+	e.LastError = sendErr.Error()
+	if e.Attempts >= policy.MaxAttempts {
+		e.Status = OutboxEmailStatusFailed
+		e.NextAttemptAt = nil
+		return
+	}
+
+	next := at.Add(policy.Backoff(e.Attempts))
+	e.Status = OutboxEmailStatusPending
+	e.NextAttemptAt = &next
+}
+
+// Fail marks the email failed without attempting it, e.g. when its recipient was suppressed
+func (e *OutboxEmail) Fail(reason string) {
+	e.Status = OutboxEmailStatusFailed
+	e.NextAttemptAt = nil
+	e.LastError = reason
+	e.UpdatedAt = time.Now()
+}
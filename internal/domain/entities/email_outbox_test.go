@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutboxEmail(t *testing.T) {
+	invoice := &Invoice{ID: uuid.New(), TenantID: uuid.New()}
+
+	email, err := NewOutboxEmail(invoice, " Customer@Example.com ", "", nil, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, invoice.ID, email.InvoiceID)
+	assert.Equal(t, invoice.TenantID, email.TenantID)
+	assert.Equal(t, "customer@example.com", email.Recipient)
+	assert.Equal(t, PaperSizeA4, email.PaperSize)
+	assert.Equal(t, OutboxEmailStatusPending, email.Status)
+	assert.NotNil(t, email.NextAttemptAt)
+
+	_, err = NewOutboxEmail(invoice, "not-an-email", PaperSizeA4, nil, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestOutboxEmail_RecordAttempt(t *testing.T) {
+	invoice := &Invoice{ID: uuid.New(), TenantID: uuid.New()}
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoffSeconds: 2, MaxBackoffSeconds: 10, TimeoutSeconds: 30}
+
+	t.Run("sent", func(t *testing.T) {
+		email, err := NewOutboxEmail(invoice, "customer@example.com", PaperSizeA4, nil, uuid.New())
+		require.NoError(t, err)
+		now := time.Now()
+
+		email.RecordAttempt(now, nil, policy)
+
+		assert.Equal(t, OutboxEmailStatusSent, email.Status)
+		assert.Equal(t, 1, email.Attempts)
+		assert.Nil(t, email.NextAttemptAt)
+		assert.Equal(t, now, *email.SentAt)
+	})
+
+	t.Run("retries with backoff then fails", func(t *testing.T) {
+		email, err := NewOutboxEmail(invoice, "customer@example.com", PaperSizeA4, nil, uuid.New())
+		require.NoError(t, err)
+		now := time.Now()
+
+		email.RecordAttempt(now, fmt.Errorf("connection refused"), policy)
+		assert.Equal(t, OutboxEmailStatusPending, email.Status)
+		assert.Equal(t, now.Add(2*time.Second), *email.NextAttemptAt)
+		assert.Equal(t, "connection refused", email.LastError)
+
+		email.RecordAttempt(now, fmt.Errorf("timeout"), policy)
+		assert.Equal(t, now.Add(4*time.Second), *email.NextAttemptAt)
+
+		email.RecordAttempt(now, fmt.Errorf("timeout"), policy)
+		assert.Equal(t, OutboxEmailStatusFailed, email.Status)
+		assert.Nil(t, email.NextAttemptAt)
+		assert.Nil(t, email.SentAt)
+	})
+}
+
+func TestOutboxEmail_Fail(t *testing.T) {
+	email, err := NewOutboxEmail(&Invoice{ID: uuid.New(), TenantID: uuid.New()}, "customer@example.com", PaperSizeA4, nil, uuid.New())
+	require.NoError(t, err)
+
+	email.Fail("recipient is suppressed")
+
+	assert.Equal(t, OutboxEmailStatusFailed, email.Status)
+	assert.Nil(t, email.NextAttemptAt)
+	assert.Equal(t, "recipient is suppressed", email.LastError)
+	assert.Equal(t, 0, email.Attempts)
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// EmailSuppression records that an email address has withdrawn consent to receive emails
+// from a tenant. Bulk sends skip suppressed addresses.
+type EmailSuppression struct {
+	ID        uuid.UUID `json:"id"`
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy uuid.UUID `json:"created_by"`
+}
+
+// NewEmailSuppression creates a new email suppression for an address
+func NewEmailSuppression(tenantID uuid.UUID, email, reason string, createdBy uuid.UUID) (*EmailSuppression, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	email = NormalizeEmail(email)
+	if !isValidEmail(email) {
+		return nil, errors.NewValidationError("invalid email address", "email must be a valid address")
+	}
+
+	suppression := &EmailSuppression{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Email:     email,
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+
+	return suppression, nil
+}
+
+// NormalizeEmail returns the canonical form of an email address used for suppression lookups
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
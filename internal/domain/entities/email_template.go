@@ -0,0 +1,369 @@
+package entities
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxEmailTemplateSize caps the size of each part of an email template, in bytes
+const MaxEmailTemplateSize = 64 << 10
+
+// EmailTemplateKind represents an email sent to customers about an invoice, a quote or a sale,
+// or to a user invited to the tenant
+type EmailTemplateKind string
+
+const (
+	EmailTemplateInvoice             EmailTemplateKind = "invoice"
+	EmailTemplateReceipt             EmailTemplateKind = "receipt"
+	EmailTemplatePaymentConfirmation EmailTemplateKind = "payment_confirmation"
+	EmailTemplateReminder            EmailTemplateKind = "reminder"
+	EmailTemplateOverdueNotice       EmailTemplateKind = "overdue_notice"
+	EmailTemplateQuote               EmailTemplateKind = "quote"
+	EmailTemplateUserInvitation      EmailTemplateKind = "user_invitation"
+	EmailTemplateSaleReceipt         EmailTemplateKind = "sale_receipt"
+)
+
+// EmailTemplateKinds returns all kinds of email that can be templated
+func EmailTemplateKinds() []EmailTemplateKind {
+	return []EmailTemplateKind{
+		EmailTemplateInvoice,
+		EmailTemplateReceipt,
+		EmailTemplatePaymentConfirmation,
+		EmailTemplateReminder,
+		EmailTemplateOverdueNotice,
+		EmailTemplateQuote,
+		EmailTemplateUserInvitation,
+		EmailTemplateSaleReceipt,
+	}
+}
+
+// IsValid checks if the email template kind is supported
+func (k EmailTemplateKind) IsValid() bool {
+	_, ok := defaultEmailTemplates[k]
+	return ok
+}
+
+// EmailTemplate represents the subject and bodies of one kind of customer email. The subject
+// and text body are text/template templates and the HTML body an html/template template,
+// all executed with EmailTemplateData. Tenants can override the built-in default of a kind.
+type EmailTemplate struct {
+	ID        uuid.UUID         `json:"id"`
+	TenantID  uuid.UUID         `json:"tenant_id"`
+	Kind      EmailTemplateKind `json:"kind"`
+	Subject   string            `json:"subject"`
+	HTMLBody  string            `json:"html_body"`
+	TextBody  string            `json:"text_body"`
+	IsDefault bool              `json:"is_default"` // Built-in template, not stored
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	UpdatedBy uuid.UUID         `json:"updated_by"`
+}
+
+// DefaultEmailTemplate returns the built-in template of a kind, or nil for an unknown kind
+func DefaultEmailTemplate(kind EmailTemplateKind) *EmailTemplate {
+	template, ok := defaultEmailTemplates[kind]
+	if !ok {
+		return nil
+	}
+
+	template.Kind = kind
+	template.IsDefault = true
+	return &template
+}
+
+// NewEmailTemplate creates a tenant's override of the template of a kind
+func NewEmailTemplate(tenantID uuid.UUID, kind EmailTemplateKind, subject, htmlBody, textBody string, updatedBy uuid.UUID) (*EmailTemplate, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if !kind.IsValid() {
+		return nil, errors.NewValidationError("invalid email template kind", "unsupported kind: "+string(kind))
+	}
+
+	now := time.Now()
+	template := &EmailTemplate{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Kind:      kind,
+		CreatedAt: now,
+	}
+
+	if err := template.Update(subject, htmlBody, textBody, updatedBy); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Update replaces the template's subject and bodies. An empty text body falls back to the
+// text body of the kind's default template, so tenants only need to design the HTML.
+func (t *EmailTemplate) Update(subject, htmlBody, textBody string, updatedBy uuid.UUID) error {
+	subject = strings.TrimSpace(subject)
+	if textBody == "" {
+		textBody = defaultEmailTemplates[t.Kind].TextBody
+	}
+
+	candidate := &EmailTemplate{Kind: t.Kind, Subject: subject, HTMLBody: htmlBody, TextBody: textBody}
+	if err := candidate.validate(); err != nil {
+		return err
+	}
+
+	t.Subject = subject
+	t.HTMLBody = htmlBody
+	t.TextBody = textBody
+	t.UpdatedBy = updatedBy
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// validate checks each part is present, within size and renders the sample data
+func (t *EmailTemplate) validate() error {
+	if t.Subject == "" {
+		return errors.NewValidationError("subject is required", "subject cannot be empty")
+	}
+	if strings.TrimSpace(t.HTMLBody) == "" {
+		return errors.NewValidationError("HTML body is required", "html_body cannot be empty")
+	}
+	if len(t.Subject) > 255 {
+		return errors.NewValidationError("subject too long", "subject cannot exceed 255 characters")
+	}
+	if len(t.HTMLBody) > MaxEmailTemplateSize || len(t.TextBody) > MaxEmailTemplateSize {
+		return errors.NewValidationError("template too large", "html_body and text_body cannot exceed 64 KB each")
+	}
+
+	// Render both with the PDF attached and linked, so both branches of a template are checked
+	sample := SampleEmailTemplateData()
+	for _, data := range []*EmailTemplateData{sample, sample.withSampleDownloadLink()} {
+		if _, err := t.Render(data); err != nil {
+			return errors.NewValidationError("invalid email template", err.Error())
+		}
+	}
+	return nil
+}
+
+// RenderedEmail is an email template executed for one invoice
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// Render executes the template with the given data
+func (t *EmailTemplate) Render(data *EmailTemplateData) (*RenderedEmail, error) {
+	subject, err := executeTextTemplate("subject", t.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := executeTextTemplate("text_body", t.TextBody, data)
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.New("html_body").Option("missingkey=error").Parse(t.HTMLBody)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := html.Execute(&b, data); err != nil {
+		return nil, err
+	}
+
+	// A subject spans a single header line
+	subject = strings.Join(strings.Fields(subject), " ")
+
+	return &RenderedEmail{Subject: subject, HTML: b.String(), Text: text}, nil
+}
+
+func executeTextTemplate(name, text string, data *EmailTemplateData) (string, error) {
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// EmailTemplateData is the data email templates are executed with. Amounts are formatted in
+// the tenant's currency and dates are spelled out, e.g. "January 2, 2006". Optional values
+// are empty when not applicable.
+type EmailTemplateData struct {
+	CustomerName    string
+	InvoiceNumber   string
+	InvoiceDate     string
+	DueDate         string
+	PaidDate        string
+	IsPaid          bool
+	PaymentMethod   string
+	Subtotal        string
+	TaxAmount       string
+	DiscountAmount  string
+	SurchargeLabel  string
+	SurchargeAmount string // Empty without a surcharge
+	TotalAmount     string
+	Items           []EmailTemplateItem
+	DownloadURL     string // Set when the invoice PDF is linked instead of attached
+	LinkExpiresAt   string
+	QuoteNumber     string // Set in quote emails, which leave the invoice values empty
+	QuoteDate       string
+	ValidUntil      string
+	InviteeName     string // Set in user invitation emails, with LinkExpiresAt
+	Username        string
+	ActivationURL   string
+	SaleNumber      string // Set in sale receipt emails, which leave the invoice values empty
+	SaleDate        string
+}
+
+// EmailTemplateItem is an invoice, quote or sale line in EmailTemplateData
+type EmailTemplateItem struct {
+	Name     string
+	Quantity int
+	Total    string
+}
+
+// emailDateLayout is how dates are spelled out in emails
+const emailDateLayout = "January 2, 2006"
+
+// NewEmailTemplateData builds the template data of an invoice
+func NewEmailTemplateData(invoice *Invoice) *EmailTemplateData {
+	data := &EmailTemplateData{
+		CustomerName:   invoice.CustomerName,
+		InvoiceNumber:  invoice.InvoiceNumber,
+		InvoiceDate:    invoice.CreatedAt.Format(emailDateLayout),
+		IsPaid:         invoice.Status == InvoiceStatusPaid,
+		PaymentMethod:  string(invoice.PaymentMethod),
+		Subtotal:       invoice.FormatAmount(invoice.Subtotal),
+		TaxAmount:      invoice.FormatAmount(invoice.TaxAmount),
+		DiscountAmount: invoice.FormatAmount(invoice.DiscountAmount),
+		TotalAmount:    invoice.FormatAmount(invoice.TotalAmount),
+		Items:          make([]EmailTemplateItem, 0, len(invoice.Items)),
+	}
+
+	if invoice.DueDate != nil {
+		data.DueDate = invoice.DueDate.Format(emailDateLayout)
+	}
+	if invoice.PaidAt != nil {
+		data.PaidDate = invoice.PaidAt.Format(emailDateLayout)
+	}
+	if invoice.SurchargeAmount.IsPositive() {
+		data.SurchargeLabel = invoice.SurchargeLabel
+		data.SurchargeAmount = invoice.FormatAmount(invoice.SurchargeAmount)
+	}
+	for _, item := range invoice.Items {
+		data.Items = append(data.Items, EmailTemplateItem{
+			Name:     item.ProductName,
+			Quantity: item.Quantity,
+			Total:    invoice.FormatAmount(item.TotalPrice),
+		})
+	}
+
+	return data
+}
+
+// NewQuoteEmailTemplateData builds the template data of a quote
+func NewQuoteEmailTemplateData(quote *Quote) *EmailTemplateData {
+	data := &EmailTemplateData{
+		CustomerName: quote.CustomerName,
+		QuoteNumber:  quote.QuoteNumber,
+		QuoteDate:    quote.CreatedAt.Format(emailDateLayout),
+		ValidUntil:   quote.ExpiresAt.Format(emailDateLayout),
+		TotalAmount:  quote.FormatAmount(quote.TotalAmount),
+		Items:        make([]EmailTemplateItem, 0, len(quote.Items)),
+	}
+
+	for _, item := range quote.Items {
+		data.Items = append(data.Items, EmailTemplateItem{
+			Name:     item.ProductName,
+			Quantity: item.Quantity,
+			Total:    quote.FormatAmount(item.TotalPrice),
+		})
+	}
+
+	return data
+}
+
+// NewSaleReceiptEmailTemplateData builds the template data of a completed sale's receipt
+func NewSaleReceiptEmailTemplateData(sale *Sale) *EmailTemplateData {
+	data := &EmailTemplateData{
+		CustomerName:   sale.CustomerName,
+		SaleNumber:     sale.SaleNumber,
+		SaleDate:       sale.CreatedAt.Format(emailDateLayout),
+		IsPaid:         true,
+		PaymentMethod:  string(sale.PaymentMethod),
+		Subtotal:       sale.FormatAmount(sale.Subtotal),
+		TaxAmount:      sale.FormatAmount(sale.TaxAmount),
+		DiscountAmount: sale.FormatAmount(sale.DiscountAmount),
+		TotalAmount:    sale.FormatAmount(sale.TotalAmount),
+		Items:          make([]EmailTemplateItem, 0, len(sale.Items)),
+	}
+
+	if sale.CompletedAt != nil {
+		data.SaleDate = sale.CompletedAt.Format(emailDateLayout)
+		data.PaidDate = data.SaleDate
+	}
+	if sale.SurchargeAmount.IsPositive() {
+		data.SurchargeLabel = sale.SurchargeLabel
+		data.SurchargeAmount = sale.FormatAmount(sale.SurchargeAmount)
+	}
+	for _, item := range sale.Items {
+		data.Items = append(data.Items, EmailTemplateItem{
+			Name:     item.ProductName,
+			Quantity: item.Quantity,
+			Total:    sale.FormatAmount(item.TotalPrice),
+		})
+	}
+
+	return data
+}
+
+// NewUserInvitationEmailTemplateData builds the template data of a user invitation
+func NewUserInvitationEmailTemplateData(user *User, activationURL string, expiresAt time.Time) *EmailTemplateData {
+	return &EmailTemplateData{
+		InviteeName:   user.GetFullName(),
+		Username:      user.Username,
+		ActivationURL: activationURL,
+		LinkExpiresAt: expiresAt.Format(emailDateLayout),
+	}
+}
+
+// WithDownloadLink sets the link the invoice PDF can be downloaded from instead of an attachment
+func (d *EmailTemplateData) WithDownloadLink(downloadURL string, expiresAt time.Time) *EmailTemplateData {
+	d.DownloadURL = downloadURL
+	d.LinkExpiresAt = expiresAt.Format(emailDateLayout)
+	return d
+}
+
+// SampleEmailTemplateData returns the data of a made-up invoice, used to validate and
+// preview templates. The quote, invitation and sale values are filled in too, so their templates
+// can be previewed with the same data.
+func SampleEmailTemplateData() *EmailTemplateData {
+	invoice := SampleInvoice()
+	issued := invoice.CreatedAt
+
+	data := NewEmailTemplateData(invoice)
+	data.QuoteNumber = "Q-20260115-0001"
+	data.QuoteDate = issued.Format(emailDateLayout)
+	data.ValidUntil = issued.AddDate(0, 0, 30).Format(emailDateLayout)
+	data.InviteeName = "John Smith"
+	data.Username = "jsmith"
+	data.ActivationURL = "https://example.com/activate?invitation=sample"
+	data.SaleNumber = "SALE-20260115-1000-042"
+	data.SaleDate = issued.Format(emailDateLayout)
+	return data
+}
+
+// withSampleDownloadLink returns a copy of the data linking a made-up PDF
+func (d *EmailTemplateData) withSampleDownloadLink() *EmailTemplateData {
+	linked := *d
+	return linked.WithDownloadLink("https://example.com/api/v1/portal/invoices/sample/pdf", time.Date(2026, time.January, 22, 0, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,293 @@
+package entities
+
+// defaultEmailTemplates holds the built-in template of each kind, used unless a tenant
+// overrides it
+var defaultEmailTemplates = map[EmailTemplateKind]EmailTemplate{
+	EmailTemplateInvoice: {
+		Subject: "Invoice {{.InvoiceNumber}} - {{.CustomerName}}",
+		TextBody: `Dear {{.CustomerName}},
+
+{{if .DownloadURL -}}
+Thank you for your business! Your invoice {{.InvoiceNumber}} is available to download at:
+
+{{.DownloadURL}}
+
+This link is valid until {{.LinkExpiresAt}}.
+{{- else -}}
+Thank you for your business! Please find attached your invoice {{.InvoiceNumber}}.
+{{- end}}
+
+Invoice Details:
+Invoice Number: {{.InvoiceNumber}}
+Invoice Date: {{.InvoiceDate}}
+{{if .SurchargeAmount}}{{.SurchargeLabel}}: {{.SurchargeAmount}}
+{{end -}}
+Total Amount: {{.TotalAmount}}
+{{if .DueDate}}Due Date: {{.DueDate}}
+{{end}}
+{{if .IsPaid -}}
+This invoice has been paid. Thank you!
+{{- else -}}
+Please process payment by the due date to avoid any late fees.
+{{- end}}
+
+If you have any questions about this invoice, please contact us.
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Dear {{.CustomerName}},</p>
+{{if .DownloadURL}}
+<p>Thank you for your business! Your invoice {{.InvoiceNumber}} is available to download:</p>
+<p><a href="{{.DownloadURL}}" style="display:inline-block;padding:10px 20px;background:#2563eb;color:#ffffff;text-decoration:none;border-radius:4px">Download invoice</a></p>
+<p style="color:#6b7280;font-size:13px">This link is valid until {{.LinkExpiresAt}}.</p>
+{{else}}
+<p>Thank you for your business! Please find attached your invoice {{.InvoiceNumber}}.</p>
+{{end}}
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Invoice Number</td><td align="right">{{.InvoiceNumber}}</td></tr>
+<tr><td>Invoice Date</td><td align="right">{{.InvoiceDate}}</td></tr>
+{{if .SurchargeAmount}}<tr><td>{{.SurchargeLabel}}</td><td align="right">{{.SurchargeAmount}}</td></tr>{{end}}
+<tr><td><strong>Total Amount</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+{{if .DueDate}}<tr><td>Due Date</td><td align="right">{{.DueDate}}</td></tr>{{end}}
+</table>
+{{if .IsPaid}}
+<p>This invoice has been paid. Thank you!</p>
+{{else}}
+<p>Please process payment by the due date to avoid any late fees.</p>
+{{end}}
+<p>If you have any questions about this invoice, please contact us.</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+
+	EmailTemplateReceipt: {
+		Subject: "Receipt - Invoice #{{.InvoiceNumber}}",
+		TextBody: `Dear {{.CustomerName}},
+
+Thank you for your purchase! Please find your receipt details below.
+
+Receipt Details:
+Invoice Number: {{.InvoiceNumber}}
+Invoice Date: {{.InvoiceDate}}
+Total Amount: {{.TotalAmount}}
+{{if .PaymentMethod}}Payment Method: {{.PaymentMethod}}
+{{end}}
+Items Purchased:
+{{range .Items}}- {{.Name}} x{{.Quantity}}: {{.Total}}
+{{end -}}
+{{if .SurchargeAmount}}- {{.SurchargeLabel}}: {{.SurchargeAmount}}
+{{end}}
+Thank you for shopping with us!
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Dear {{.CustomerName}},</p>
+<p>Thank you for your purchase! Please find your receipt details below.</p>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Invoice Number</td><td align="right">{{.InvoiceNumber}}</td></tr>
+<tr><td>Invoice Date</td><td align="right">{{.InvoiceDate}}</td></tr>
+{{if .PaymentMethod}}<tr><td>Payment Method</td><td align="right">{{.PaymentMethod}}</td></tr>{{end}}
+</table>
+<h3>Items Purchased</h3>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+{{range .Items}}<tr><td>{{.Name}} &times; {{.Quantity}}</td><td align="right">{{.Total}}</td></tr>
+{{end}}{{if .SurchargeAmount}}<tr><td>{{.SurchargeLabel}}</td><td align="right">{{.SurchargeAmount}}</td></tr>{{end}}
+<tr><td><strong>Total Amount</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+</table>
+<p>Thank you for shopping with us!</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+
+	EmailTemplatePaymentConfirmation: {
+		Subject: "Payment Confirmation - Invoice {{.InvoiceNumber}}",
+		TextBody: `Dear {{.CustomerName}},
+
+Thank you! We have received your payment for invoice {{.InvoiceNumber}}.
+
+Payment Details:
+Invoice Number: {{.InvoiceNumber}}
+Invoice Date: {{.InvoiceDate}}
+Total Amount: {{.TotalAmount}}
+{{if .PaidDate}}Payment Date: {{.PaidDate}}
+{{end}}
+Your payment has been processed successfully and your account is now up to date.
+
+Thank you for your business!
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Dear {{.CustomerName}},</p>
+<p>Thank you! We have received your payment for invoice {{.InvoiceNumber}}.</p>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Invoice Number</td><td align="right">{{.InvoiceNumber}}</td></tr>
+<tr><td>Invoice Date</td><td align="right">{{.InvoiceDate}}</td></tr>
+<tr><td><strong>Total Amount</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+{{if .PaidDate}}<tr><td>Payment Date</td><td align="right">{{.PaidDate}}</td></tr>{{end}}
+</table>
+<p>Your payment has been processed successfully and your account is now up to date.</p>
+<p>Thank you for your business!</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+
+	EmailTemplateReminder: {
+		Subject: "Payment Reminder - Invoice {{.InvoiceNumber}}",
+		TextBody: `Dear {{.CustomerName}},
+
+This is a friendly reminder that your invoice {{.InvoiceNumber}} is pending payment.
+
+Invoice Details:
+Invoice Number: {{.InvoiceNumber}}
+Invoice Date: {{.InvoiceDate}}
+Total Amount: {{.TotalAmount}}
+{{if .DueDate}}Due Date: {{.DueDate}}
+{{end}}
+Please process payment at your earliest convenience.
+
+If you have already made payment, please disregard this reminder.
+If you have any questions, please contact us.
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Dear {{.CustomerName}},</p>
+<p>This is a friendly reminder that your invoice {{.InvoiceNumber}} is pending payment.</p>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Invoice Number</td><td align="right">{{.InvoiceNumber}}</td></tr>
+<tr><td>Invoice Date</td><td align="right">{{.InvoiceDate}}</td></tr>
+<tr><td><strong>Total Amount</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+{{if .DueDate}}<tr><td>Due Date</td><td align="right">{{.DueDate}}</td></tr>{{end}}
+</table>
+<p>Please process payment at your earliest convenience.</p>
+<p>If you have already made payment, please disregard this reminder. If you have any questions, please contact us.</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+
+	EmailTemplateOverdueNotice: {
+		Subject: "OVERDUE PAYMENT NOTICE - Invoice {{.InvoiceNumber}}",
+		TextBody: `Dear {{.CustomerName}},
+
+URGENT: Your invoice {{.InvoiceNumber}} is now OVERDUE and requires immediate payment.
+
+Invoice Details:
+Invoice Number: {{.InvoiceNumber}}
+Invoice Date: {{.InvoiceDate}}
+{{if .DueDate}}Due Date: {{.DueDate}}
+{{end -}}
+Total Amount: {{.TotalAmount}}
+
+Please make payment immediately to avoid additional late fees or collection actions.
+
+If you have any questions or need to arrange a payment plan, please contact us urgently.
+
+Regards,
+ADOL Point of Sale Accounts Department`,
+		HTMLBody: emailHTML(`<p>Dear {{.CustomerName}},</p>
+<p style="color:#b91c1c"><strong>URGENT: Your invoice {{.InvoiceNumber}} is now OVERDUE and requires immediate payment.</strong></p>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Invoice Number</td><td align="right">{{.InvoiceNumber}}</td></tr>
+<tr><td>Invoice Date</td><td align="right">{{.InvoiceDate}}</td></tr>
+{{if .DueDate}}<tr><td>Due Date</td><td align="right">{{.DueDate}}</td></tr>{{end}}
+<tr><td><strong>Total Amount</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+</table>
+<p>Please make payment immediately to avoid additional late fees or collection actions.</p>
+<p>If you have any questions or need to arrange a payment plan, please contact us urgently.</p>
+<p>Regards,<br>ADOL Point of Sale Accounts Department</p>`),
+	},
+
+	EmailTemplateQuote: {
+		Subject: "Quote {{.QuoteNumber}} - {{.CustomerName}}",
+		TextBody: `Dear {{.CustomerName}},
+
+Thank you for your interest! Please find attached our quote {{.QuoteNumber}}.
+
+Quote Details:
+Quote Number: {{.QuoteNumber}}
+Quote Date: {{.QuoteDate}}
+{{range .Items}}{{.Quantity}} x {{.Name}}: {{.Total}}
+{{end -}}
+Total Amount: {{.TotalAmount}}
+Valid Until: {{.ValidUntil}}
+
+The quoted prices hold until the quote expires. To go ahead, simply reply to this email
+or visit us with your quote number.
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Dear {{.CustomerName}},</p>
+<p>Thank you for your interest! Please find attached our quote {{.QuoteNumber}}.</p>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Quote Number</td><td align="right">{{.QuoteNumber}}</td></tr>
+<tr><td>Quote Date</td><td align="right">{{.QuoteDate}}</td></tr>
+{{range .Items}}<tr><td>{{.Quantity}} x {{.Name}}</td><td align="right">{{.Total}}</td></tr>{{end}}
+<tr><td><strong>Total Amount</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+<tr><td>Valid Until</td><td align="right">{{.ValidUntil}}</td></tr>
+</table>
+<p>The quoted prices hold until the quote expires. To go ahead, simply reply to this email or visit us with your quote number.</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+
+	EmailTemplateUserInvitation: {
+		Subject: "You have been invited to ADOL Point of Sale",
+		TextBody: `Hello {{.InviteeName}},
+
+An account has been created for you on ADOL Point of Sale with the username {{.Username}}.
+To activate it, choose your password at:
+
+{{.ActivationURL}}
+
+This link is valid until {{.LinkExpiresAt}}. If you were not expecting this invitation,
+you can ignore this email.
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Hello {{.InviteeName}},</p>
+<p>An account has been created for you on ADOL Point of Sale with the username <strong>{{.Username}}</strong>. To activate it, choose your password:</p>
+<p><a href="{{.ActivationURL}}" style="display:inline-block;padding:10px 18px;background:#2563eb;color:#ffffff;text-decoration:none;border-radius:4px">Activate your account</a></p>
+<p>This link is valid until {{.LinkExpiresAt}}. If you were not expecting this invitation, you can ignore this email.</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+	EmailTemplateSaleReceipt: {
+		Subject: "Receipt - Sale {{.SaleNumber}}",
+		TextBody: `Dear {{if .CustomerName}}{{.CustomerName}}{{else}}Customer{{end}},
+
+Thank you for your purchase! Please find attached your receipt.
+
+Receipt Details:
+Sale Number: {{.SaleNumber}}
+Date: {{.SaleDate}}
+{{range .Items}}{{.Quantity}} x {{.Name}}: {{.Total}}
+{{end -}}
+{{if .SurchargeAmount}}{{.SurchargeLabel}}: {{.SurchargeAmount}}
+{{end -}}
+Total Paid: {{.TotalAmount}}
+Payment Method: {{.PaymentMethod}}
+
+We appreciate your business and look forward to serving you again.
+
+Best regards,
+ADOL Point of Sale Team`,
+		HTMLBody: emailHTML(`<p>Dear {{if .CustomerName}}{{.CustomerName}}{{else}}Customer{{end}},</p>
+<p>Thank you for your purchase! Please find attached your receipt.</p>
+<table cellpadding="6" style="border-collapse:collapse;width:100%">
+<tr><td>Sale Number</td><td align="right">{{.SaleNumber}}</td></tr>
+<tr><td>Date</td><td align="right">{{.SaleDate}}</td></tr>
+{{range .Items}}<tr><td>{{.Quantity}} x {{.Name}}</td><td align="right">{{.Total}}</td></tr>{{end}}
+{{if .SurchargeAmount}}<tr><td>{{.SurchargeLabel}}</td><td align="right">{{.SurchargeAmount}}</td></tr>{{end}}
+<tr><td><strong>Total Paid</strong></td><td align="right"><strong>{{.TotalAmount}}</strong></td></tr>
+<tr><td>Payment Method</td><td align="right">{{.PaymentMethod}}</td></tr>
+</table>
+<p>We appreciate your business and look forward to serving you again.</p>
+<p>Best regards,<br>ADOL Point of Sale Team</p>`),
+	},
+}
+
+// emailHTML wraps the content of a default HTML email in its page layout
+func emailHTML(content string) string {
+	return `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"></head>
+<body style="margin:0;padding:24px;background:#f3f4f6;font-family:Helvetica,Arial,sans-serif;font-size:15px;color:#111827">
+<div style="max-width:600px;margin:0 auto;padding:24px;background:#ffffff;border-radius:6px">
+` + content + `
+</div>
+</body>
+</html>`
+}
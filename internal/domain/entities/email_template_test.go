@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultEmailTemplates(t *testing.T) {
+	for _, kind := range EmailTemplateKinds() {
+		template := DefaultEmailTemplate(kind)
+		require.NotNil(t, template, kind)
+		assert.True(t, template.IsDefault)
+		assert.NoError(t, template.validate(), kind)
+	}
+
+	assert.Nil(t, DefaultEmailTemplate("unknown"))
+	assert.False(t, EmailTemplateKind("unknown").IsValid())
+}
+
+func TestEmailTemplate_RenderInvoice(t *testing.T) {
+	template := DefaultEmailTemplate(EmailTemplateInvoice)
+
+	rendered, err := template.Render(SampleEmailTemplateData())
+	require.NoError(t, err)
+	assert.Equal(t, "Invoice INV-20260115-0001 - Jane Doe", rendered.Subject)
+	assert.Contains(t, rendered.Text, "Please find attached your invoice INV-20260115-0001.\n\nInvoice Details:")
+	assert.Contains(t, rendered.Text, "Card surcharge: $3.00\nTotal Amount: $169.50\nDue Date: January 29, 2026\n")
+	assert.Contains(t, rendered.HTML, "<strong>$169.50</strong>")
+	assert.NotContains(t, rendered.HTML, "Download invoice")
+
+	linked := SampleEmailTemplateData().WithDownloadLink("https://api.example.com/invoice.pdf?a=1&b=2", time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC))
+	rendered, err = template.Render(linked)
+	require.NoError(t, err)
+	assert.Contains(t, rendered.Text, "https://api.example.com/invoice.pdf?a=1&b=2\n\nThis link is valid until February 1, 2026.")
+	assert.Contains(t, rendered.HTML, `href="https://api.example.com/invoice.pdf?a=1&amp;b=2"`)
+}
+
+func TestEmailTemplate_RenderSaleReceipt(t *testing.T) {
+	completed := time.Date(2026, time.January, 15, 10, 0, 0, 0, time.UTC)
+	sale := &Sale{
+		SaleNumber:    "SALE-20260115-1000-042",
+		Subtotal:      decimal.RequireFromString("150.00"),
+		TaxAmount:     decimal.RequireFromString("16.50"),
+		TotalAmount:   decimal.RequireFromString("166.50"),
+		PaymentMethod: PaymentMethodCash,
+		Status:        SaleStatusCompleted,
+		CompletedAt:   &completed,
+		Items: []SaleItem{
+			{ProductName: "Ceramic Mug", Quantity: 2, TotalPrice: decimal.RequireFromString("150.00")},
+		},
+	}
+
+	rendered, err := DefaultEmailTemplate(EmailTemplateSaleReceipt).Render(NewSaleReceiptEmailTemplateData(sale))
+	require.NoError(t, err)
+	assert.Equal(t, "Receipt - Sale SALE-20260115-1000-042", rendered.Subject)
+	assert.Contains(t, rendered.Text, "Dear Customer,")
+	assert.Contains(t, rendered.Text, "Date: January 15, 2026\n2 x Ceramic Mug: $150.00\nTotal Paid: $166.50\nPayment Method: cash\n")
+	assert.Contains(t, rendered.HTML, "<strong>$166.50</strong>")
+}
+
+func TestEmailTemplate_RenderEscapesHTML(t *testing.T) {
+	data := SampleEmailTemplateData()
+	data.CustomerName = "<script>alert(1)</script>"
+
+	rendered, err := DefaultEmailTemplate(EmailTemplateReceipt).Render(data)
+	require.NoError(t, err)
+	assert.NotContains(t, rendered.HTML, "<script>")
+	assert.Contains(t, rendered.HTML, "&lt;script&gt;")
+	assert.Contains(t, rendered.Text, "Dear <script>alert(1)</script>,")
+}
+
+func TestNewEmailTemplate(t *testing.T) {
+	tenantID := uuid.New()
+
+	template, err := NewEmailTemplate(tenantID, EmailTemplateReminder, " Reminder for {{.InvoiceNumber}} ", "<p>Hi {{.CustomerName}}</p>", "", uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, "Reminder for {{.InvoiceNumber}}", template.Subject)
+	assert.Equal(t, DefaultEmailTemplate(EmailTemplateReminder).TextBody, template.TextBody)
+	assert.False(t, template.IsDefault)
+
+	tests := []struct {
+		name     string
+		kind     EmailTemplateKind
+		subject  string
+		htmlBody string
+		textBody string
+	}{
+		{"unknown kind", "unknown", "Subject", "<p>Body</p>", ""},
+		{"missing subject", EmailTemplateReminder, " ", "<p>Body</p>", ""},
+		{"missing HTML body", EmailTemplateReminder, "Subject", "", ""},
+		{"syntax error", EmailTemplateReminder, "Subject", "<p>{{.CustomerName</p>", ""},
+		{"unknown field", EmailTemplateReminder, "Subject {{.Customer}}", "<p>Body</p>", ""},
+		{"unknown field in link branch", EmailTemplateInvoice, "Subject", "{{if .DownloadURL}}{{.Link}}{{end}}", ""},
+		{"invalid text body", EmailTemplateReminder, "Subject", "<p>Body</p>", "{{range .Items}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmailTemplate(tenantID, tt.kind, tt.subject, tt.htmlBody, tt.textBody, uuid.New())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEmailTemplate_Update(t *testing.T) {
+	template, err := NewEmailTemplate(uuid.New(), EmailTemplateInvoice, "Invoice {{.InvoiceNumber}}", "<p>Invoice</p>", "Invoice", uuid.New())
+	require.NoError(t, err)
+
+	err = template.Update("Invoice {{.Missing}}", "<p>Updated</p>", "Updated", uuid.New())
+	assert.Error(t, err)
+	assert.Equal(t, "Invoice {{.InvoiceNumber}}", template.Subject, "a rejected update leaves the template unchanged")
+
+	updatedBy := uuid.New()
+	require.NoError(t, template.Update("Your invoice {{.InvoiceNumber}}", "<p>Updated</p>", "Updated", updatedBy))
+	assert.Equal(t, "<p>Updated</p>", template.HTMLBody)
+	assert.Equal(t, "Updated", template.TextBody)
+	assert.Equal(t, updatedBy, template.UpdatedBy)
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// ExchangeRate is the rate between the currency a sale or invoice is made out in and the
+// tenant's base currency, locked when the document is created. Reports and exports convert
+// the document's amounts with it rather than with the rate of the day they are run, so a
+// document's figures do not move once it has been issued.
+type ExchangeRate struct {
+	Currency     string          `json:"currency"`      // ISO 4217 code the document is made out in
+	BaseCurrency string          `json:"base_currency"` // Tenant's currency reports are totalled in
+	Rate         decimal.Decimal `json:"rate"`          // Units of base currency per unit of currency
+	LockedAt     time.Time       `json:"locked_at"`
+}
+
+// NewExchangeRate locks the rate from currency to baseCurrency
+func NewExchangeRate(currency, baseCurrency string, rate decimal.Decimal, now time.Time) (*ExchangeRate, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	baseCurrency, err = NormalizeCurrency(baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if !rate.IsPositive() {
+		return nil, errors.NewValidationError("invalid exchange rate", "exchange rate must be positive")
+	}
+	if currency == baseCurrency && !rate.Equal(decimal.NewFromInt(1)) {
+		return nil, errors.NewValidationError("invalid exchange rate", "exchange rate of a currency to itself must be 1")
+	}
+
+	return &ExchangeRate{
+		Currency:     currency,
+		BaseCurrency: baseCurrency,
+		Rate:         rate,
+		LockedAt:     now,
+	}, nil
+}
+
+// NormalizeCurrency validates an ISO 4217 currency code and returns it in uppercase
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return "", errors.NewValidationError("invalid currency", "currency must be a 3-letter ISO 4217 code")
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return "", errors.NewValidationError("invalid currency", "currency must be a 3-letter ISO 4217 code")
+		}
+	}
+	return currency, nil
+}
+
+// ToBase converts an amount of the document's currency to the base currency
+func (r *ExchangeRate) ToBase(amount decimal.Decimal) decimal.Decimal {
+	if r == nil {
+		return amount
+	}
+	return amount.Mul(r.Rate).Round(2)
+}
+
+// FromBase converts an amount of the base currency to the document's currency
+func (r *ExchangeRate) FromBase(amount decimal.Decimal) decimal.Decimal {
+	if r == nil {
+		return amount
+	}
+	return amount.Div(r.Rate).Round(2)
+}
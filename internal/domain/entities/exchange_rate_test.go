@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExchangeRate(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("foreign currency", func(t *testing.T) {
+		rate, err := NewExchangeRate(" usd", "IDR ", decimal.NewFromInt(15500), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "USD", rate.Currency)
+		assert.Equal(t, "IDR", rate.BaseCurrency)
+		assert.True(t, decimal.NewFromInt(15500).Equal(rate.Rate))
+		assert.Equal(t, now, rate.LockedAt)
+	})
+
+	t.Run("base currency", func(t *testing.T) {
+		rate, err := NewExchangeRate("IDR", "IDR", decimal.NewFromInt(1), now)
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(1).Equal(rate.Rate))
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		_, err := NewExchangeRate("RUPIAH", "IDR", decimal.NewFromInt(1), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid currency")
+	})
+
+	t.Run("non-positive rate", func(t *testing.T) {
+		_, err := NewExchangeRate("USD", "IDR", decimal.Zero, now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid exchange rate")
+	})
+
+	t.Run("base currency at another rate", func(t *testing.T) {
+		_, err := NewExchangeRate("IDR", "IDR", decimal.NewFromInt(2), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid exchange rate")
+	})
+}
+
+func TestExchangeRate_ToBase(t *testing.T) {
+	rate, err := NewExchangeRate("USD", "EUR", decimal.RequireFromString("0.92345"), time.Now())
+	require.NoError(t, err)
+
+	assert.True(t, decimal.RequireFromString("92.35").Equal(rate.ToBase(decimal.NewFromInt(100))))
+
+	var unlocked *ExchangeRate
+	assert.True(t, decimal.NewFromInt(100).Equal(unlocked.ToBase(decimal.NewFromInt(100))))
+}
+
+func TestExchangeRate_FromBase(t *testing.T) {
+	rate, err := NewExchangeRate("USD", "IDR", decimal.NewFromInt(15750), time.Now())
+	require.NoError(t, err)
+
+	assert.True(t, decimal.RequireFromString("3.17").Equal(rate.FromBase(decimal.NewFromInt(50000))))
+
+	var unlocked *ExchangeRate
+	assert.True(t, decimal.NewFromInt(100).Equal(unlocked.FromBase(decimal.NewFromInt(100))))
+}
+
+func TestProduct_SetCurrency(t *testing.T) {
+	product, err := NewProduct(uuid.New(), "TEA-01", "Green Tea", "", "Drinks", "pcs", decimal.NewFromInt(4), decimal.NewFromInt(2), 0, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, "IDR", product.PriceCurrency("IDR"))
+
+	require.NoError(t, product.SetCurrency(" usd "))
+	assert.Equal(t, "USD", product.Currency)
+	assert.Equal(t, "USD", product.PriceCurrency("IDR"))
+
+	err = product.SetCurrency("dollar")
+	assert.Error(t, err)
+	assert.Equal(t, "USD", product.Currency)
+
+	require.NoError(t, product.SetCurrency(""))
+	assert.Equal(t, "IDR", product.PriceCurrency("IDR"))
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// Feature represents a major feature whose adoption across tenants is tracked
+type Feature string
+
+const (
+	FeatureInvoicing  Feature = "invoicing"  // An invoice was created
+	FeatureEmail      Feature = "email"      // An invoice email was delivered
+	FeaturePromotions Feature = "promotions" // A promotional price was set on a product
+)
+
+// Features returns all features whose adoption is tracked
+func Features() []Feature {
+	return []Feature{
+		FeatureInvoicing,
+		FeatureEmail,
+		FeaturePromotions,
+	}
+}
+
+// IsValid checks if the feature is tracked
+func (f Feature) IsValid() bool {
+	for _, feature := range Features() {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// FeatureUsage represents how often a tenant used a feature on one day. Usage is kept as a
+// daily counter rather than one row per use, so tracking stays cheap on busy tenants.
+type FeatureUsage struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	Feature    Feature   `json:"feature"`
+	Day        time.Time `json:"day"`
+	Events     int       `json:"events"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// NewFeatureUsage records a single use of the feature by the tenant at the given time
+func NewFeatureUsage(tenantID uuid.UUID, feature Feature, at time.Time) (*FeatureUsage, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant is required", "tenant_id cannot be empty")
+	}
+	if !feature.IsValid() {
+		return nil, errors.NewValidationError("invalid feature", "feature '"+string(feature)+"' is not tracked")
+	}
+
+	return &FeatureUsage{
+		TenantID:   tenantID,
+		Feature:    feature,
+		Day:        time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location()),
+		Events:     1,
+		LastUsedAt: at,
+	}, nil
+}
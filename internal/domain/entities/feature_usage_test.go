@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeature_IsValid(t *testing.T) {
+	for _, feature := range Features() {
+		assert.True(t, feature.IsValid(), string(feature))
+	}
+
+	assert.False(t, Feature("multi_location").IsValid())
+	assert.False(t, Feature("").IsValid())
+}
+
+func TestNewFeatureUsage(t *testing.T) {
+	t.Run("valid usage", func(t *testing.T) {
+		tenantID := uuid.New()
+		at := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+
+		usage, err := NewFeatureUsage(tenantID, FeatureInvoicing, at)
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, usage.TenantID)
+		assert.Equal(t, FeatureInvoicing, usage.Feature)
+		assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), usage.Day)
+		assert.Equal(t, 1, usage.Events)
+		assert.Equal(t, at, usage.LastUsedAt)
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		usage, err := NewFeatureUsage(uuid.Nil, FeatureEmail, time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, usage)
+	})
+
+	t.Run("untracked feature", func(t *testing.T) {
+		usage, err := NewFeatureUsage(uuid.New(), Feature("loyalty"), time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, usage)
+	})
+}
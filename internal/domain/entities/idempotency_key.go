@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients set to make a retried request safe
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader marks responses replayed from a stored idempotency key
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	// IdempotencyKeyMaxLength is the longest idempotency key accepted
+	IdempotencyKeyMaxLength = 255
+	// IdempotencyLockTimeout is how long a request may hold its key before another request
+	// with the same key may take it over, e.g. after the server crashed mid-request
+	IdempotencyLockTimeout = time.Minute
+)
+
+// IdempotencyKeyStatus represents the state of an idempotent request
+type IdempotencyKeyStatus string
+
+const (
+	IdempotencyKeyStatusInProgress IdempotencyKeyStatus = "in_progress" // The first request is still being handled
+	IdempotencyKeyStatusCompleted  IdempotencyKeyStatus = "completed"   // The response is stored for replays
+)
+
+// IdempotencyKey records a request made with an Idempotency-Key header and, once handled,
+// its response, so a client retrying the request gets the same response instead of creating
+// a duplicate
+type IdempotencyKey struct {
+	ID             uuid.UUID            `json:"id"`
+	TenantID       uuid.UUID            `json:"tenant_id"`
+	Key            string               `json:"key"`
+	UserID         uuid.UUID            `json:"user_id"`
+	Method         string               `json:"method"`
+	Path           string               `json:"path"`
+	RequestHash    string               `json:"request_hash"` // Fingerprint of the user, method, path and body
+	Status         IdempotencyKeyStatus `json:"status"`
+	ResponseStatus int                  `json:"response_status,omitempty"`
+	ResponseBody   []byte               `json:"response_body,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	CompletedAt    *time.Time           `json:"completed_at,omitempty"`
+	ExpiresAt      time.Time            `json:"expires_at"`
+}
+
+// NewIdempotencyKey creates an in-progress idempotency key for a request. Replays are served
+// until the key expires after ttl.
+func NewIdempotencyKey(tenantID, userID uuid.UUID, key, method, path string, body []byte, ttl time.Duration) (*IdempotencyKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.NewValidationError("invalid idempotency key", "idempotency key cannot be empty")
+	}
+	if len(key) > IdempotencyKeyMaxLength {
+		return nil, errors.NewValidationError("invalid idempotency key", "idempotency key cannot be longer than 255 characters")
+	}
+
+	now := time.Now()
+	return &IdempotencyKey{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Key:         key,
+		UserID:      userID,
+		Method:      method,
+		Path:        path,
+		RequestHash: IdempotencyRequestHash(userID, method, path, body),
+		Status:      IdempotencyKeyStatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// IdempotencyRequestHash fingerprints a request, so a key reused for a different request is
+// rejected rather than answered with an unrelated response
+func IdempotencyRequestHash(userID uuid.UUID, method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(userID.String() + "\n" + method + "\n" + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Matches checks whether another request made with the same key is the same request
+func (k *IdempotencyKey) Matches(other *IdempotencyKey) bool {
+	return k.RequestHash == other.RequestHash
+}
+
+// Complete stores the response of the request for replays
+func (k *IdempotencyKey) Complete(status int, body []byte) {
+	now := time.Now()
+	k.Status = IdempotencyKeyStatusCompleted
+	k.ResponseStatus = status
+	k.ResponseBody = body
+	k.CompletedAt = &now
+}
+
+// IsCompleted checks if the response is stored and can be replayed
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.Status == IdempotencyKeyStatusCompleted
+}
+
+// IsExpired checks if the key is past its retention period
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
+
+// IsReplayableStatus checks if a response with the status is stored for replays. Server
+// errors, conflicts and rate limiting are transient, so the key is released and a retry is
+// handled afresh.
+func IsReplayableStatus(status int) bool {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return false
+	case status == http.StatusConflict, status == http.StatusTooManyRequests:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	tenantID, userID := uuid.New(), uuid.New()
+
+	t.Run("valid key", func(t *testing.T) {
+		key, err := NewIdempotencyKey(tenantID, userID, " retry-1 ", http.MethodPost, "/api/v1/sales", []byte(`{}`), 24*time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, "retry-1", key.Key)
+		assert.Equal(t, IdempotencyKeyStatusInProgress, key.Status)
+		assert.False(t, key.IsCompleted())
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), key.ExpiresAt, time.Minute)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		for _, value := range []string{"", "  ", strings.Repeat("k", IdempotencyKeyMaxLength+1)} {
+			_, err := NewIdempotencyKey(tenantID, userID, value, http.MethodPost, "/api/v1/sales", nil, time.Hour)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestIdempotencyKeyMatches(t *testing.T) {
+	tenantID, userID := uuid.New(), uuid.New()
+	newKey := func(userID uuid.UUID, path, body string) *IdempotencyKey {
+		key, err := NewIdempotencyKey(tenantID, userID, "retry-1", http.MethodPost, path, []byte(body), time.Hour)
+		require.NoError(t, err)
+		return key
+	}
+
+	original := newKey(userID, "/api/v1/sales", `{"notes":"a"}`)
+
+	assert.True(t, original.Matches(newKey(userID, "/api/v1/sales", `{"notes":"a"}`)))
+	assert.False(t, original.Matches(newKey(userID, "/api/v1/sales", `{"notes":"b"}`)))
+	assert.False(t, original.Matches(newKey(userID, "/api/v1/sales/1/complete", `{"notes":"a"}`)))
+	assert.False(t, original.Matches(newKey(uuid.New(), "/api/v1/sales", `{"notes":"a"}`)))
+}
+
+func TestIdempotencyKeyComplete(t *testing.T) {
+	key, err := NewIdempotencyKey(uuid.New(), uuid.New(), "retry-1", http.MethodPost, "/api/v1/sales", nil, time.Hour)
+	require.NoError(t, err)
+
+	key.Complete(http.StatusCreated, []byte(`{"data":{}}`))
+
+	assert.True(t, key.IsCompleted())
+	assert.Equal(t, http.StatusCreated, key.ResponseStatus)
+	assert.Equal(t, `{"data":{}}`, string(key.ResponseBody))
+	require.NotNil(t, key.CompletedAt)
+	assert.False(t, key.IsExpired(time.Now()))
+	assert.True(t, key.IsExpired(key.ExpiresAt))
+}
+
+func TestIsReplayableStatus(t *testing.T) {
+	assert.True(t, IsReplayableStatus(http.StatusCreated))
+	assert.True(t, IsReplayableStatus(http.StatusBadRequest))
+	assert.True(t, IsReplayableStatus(http.StatusNotFound))
+	assert.False(t, IsReplayableStatus(http.StatusConflict))
+	assert.False(t, IsReplayableStatus(http.StatusTooManyRequests))
+	assert.False(t, IsReplayableStatus(http.StatusInternalServerError))
+	assert.False(t, IsReplayableStatus(http.StatusServiceUnavailable))
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// CostAdjustmentReason represents why inventory was revalued
+type CostAdjustmentReason string
+
+const (
+	CostAdjustmentReasonDamaged     CostAdjustmentReason = "damaged"
+	CostAdjustmentReasonObsolete    CostAdjustmentReason = "obsolete"
+	CostAdjustmentReasonExpired     CostAdjustmentReason = "expired"
+	CostAdjustmentReasonMarketValue CostAdjustmentReason = "market_value" // Cost above what the stock can be sold for
+	CostAdjustmentReasonOther       CostAdjustmentReason = "other"
+)
+
+// IsValid reports whether the reason is known
+func (r CostAdjustmentReason) IsValid() bool {
+	switch r {
+	case CostAdjustmentReasonDamaged, CostAdjustmentReasonObsolete, CostAdjustmentReasonExpired,
+		CostAdjustmentReasonMarketValue, CostAdjustmentReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// InventoryCostAdjustment is a valuation journal entry writing down the cost of units on
+// hand. It changes what the stock is worth, not how much of it there is; quantity changes
+// are recorded as stock movements.
+//
+// Inventory is carried at a single average cost per product, so writing down some of the
+// units lowers the product's cost by the write-down spread over all units on hand.
+type InventoryCostAdjustment struct {
+	ID                uuid.UUID            `json:"id"`
+	TenantID          uuid.UUID            `json:"tenant_id"`
+	ProductID         uuid.UUID            `json:"product_id"`
+	Reason            CostAdjustmentReason `json:"reason"`
+	Quantity          int                  `json:"quantity"`            // Units written down
+	OnHandQty         int                  `json:"on_hand_qty"`         // Units on hand when written down
+	PreviousUnitCost  decimal.Decimal      `json:"previous_unit_cost"`  // Product cost before the write-down
+	AdjustedUnitCost  decimal.Decimal      `json:"adjusted_unit_cost"`  // Cost the written down units are carried at
+	ResultingUnitCost decimal.Decimal      `json:"resulting_unit_cost"` // Product cost after the write-down
+	Amount            decimal.Decimal      `json:"amount"`              // Change in inventory value; negative for a write-down
+	Notes             string               `json:"notes,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	CreatedBy         uuid.UUID            `json:"created_by"`
+}
+
+// NewInventoryCostAdjustment writes down quantity of the onHand units of a product to
+// adjustedUnitCost and applies the resulting average cost to the product
+func NewInventoryCostAdjustment(product *Product, onHand, quantity int, adjustedUnitCost decimal.Decimal, reason CostAdjustmentReason, notes string, createdBy uuid.UUID) (*InventoryCostAdjustment, error) {
+	if !reason.IsValid() {
+		return nil, errors.NewValidationError("invalid cost adjustment reason", "reason must be damaged, obsolete, expired, market_value or other")
+	}
+	if onHand <= 0 {
+		return nil, errors.NewValidationError("nothing to revalue", "product has no units on hand")
+	}
+	if quantity <= 0 || quantity > onHand {
+		return nil, errors.NewValidationError("invalid quantity", "quantity must be between 1 and the units on hand")
+	}
+	if adjustedUnitCost.IsNegative() {
+		return nil, errors.NewValidationError("invalid unit cost", "unit cost cannot be negative")
+	}
+	if !adjustedUnitCost.LessThan(product.Cost) {
+		return nil, errors.NewValidationError("invalid unit cost", "unit cost must be below the current cost of "+product.Cost.StringFixed(2))
+	}
+
+	notes = strings.TrimSpace(notes)
+	if reason == CostAdjustmentReasonOther && notes == "" {
+		return nil, errors.NewValidationError("notes are required", "describe the reason for the write-down")
+	}
+
+	amount := adjustedUnitCost.Sub(product.Cost).Mul(decimal.NewFromInt(int64(quantity))).Round(2)
+	resultingCost := product.Cost.Add(amount.Div(decimal.NewFromInt(int64(onHand)))).Round(2)
+	if resultingCost.IsNegative() {
+		resultingCost = decimal.Zero
+	}
+
+	adjustment := &InventoryCostAdjustment{
+		ID:                uuid.New(),
+		TenantID:          product.TenantID,
+		ProductID:         product.ID,
+		Reason:            reason,
+		Quantity:          quantity,
+		OnHandQty:         onHand,
+		PreviousUnitCost:  product.Cost,
+		AdjustedUnitCost:  adjustedUnitCost,
+		ResultingUnitCost: resultingCost,
+		Amount:            amount,
+		Notes:             notes,
+		CreatedAt:         time.Now(),
+		CreatedBy:         createdBy,
+	}
+
+	if err := product.UpdateCost(resultingCost); err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInventoryCostAdjustment(t *testing.T) {
+	newProduct := func(t *testing.T) *Product {
+		product, err := NewProduct(uuid.New(), "TEA-001", "Green Tea", "", "Drinks", "box", decimal.NewFromInt(15), decimal.NewFromInt(10), 5, uuid.New())
+		require.NoError(t, err)
+		return product
+	}
+
+	t.Run("writes down part of the stock", func(t *testing.T) {
+		product := newProduct(t)
+		userID := uuid.New()
+
+		adjustment, err := NewInventoryCostAdjustment(product, 40, 10, decimal.NewFromInt(2), CostAdjustmentReasonDamaged, " crushed boxes ", userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, adjustment.ProductID)
+		assert.Equal(t, product.TenantID, adjustment.TenantID)
+		assert.True(t, adjustment.PreviousUnitCost.Equal(decimal.NewFromInt(10)))
+		assert.True(t, adjustment.Amount.Equal(decimal.NewFromInt(-80)), adjustment.Amount.String())
+		assert.True(t, adjustment.ResultingUnitCost.Equal(decimal.NewFromInt(8)), adjustment.ResultingUnitCost.String())
+		assert.True(t, product.Cost.Equal(decimal.NewFromInt(8)))
+		assert.Equal(t, "crushed boxes", adjustment.Notes)
+		assert.Equal(t, userID, adjustment.CreatedBy)
+	})
+
+	t.Run("writes off the whole stock", func(t *testing.T) {
+		product := newProduct(t)
+
+		adjustment, err := NewInventoryCostAdjustment(product, 3, 3, decimal.Zero, CostAdjustmentReasonObsolete, "", uuid.New())
+
+		require.NoError(t, err)
+		assert.True(t, adjustment.Amount.Equal(decimal.NewFromInt(-30)))
+		assert.True(t, product.Cost.IsZero())
+	})
+
+	t.Run("rejects invalid write-downs", func(t *testing.T) {
+		product := newProduct(t)
+		userID := uuid.New()
+
+		_, err := NewInventoryCostAdjustment(product, 0, 1, decimal.NewFromInt(5), CostAdjustmentReasonDamaged, "", userID)
+		assert.Error(t, err)
+
+		_, err = NewInventoryCostAdjustment(product, 5, 6, decimal.NewFromInt(5), CostAdjustmentReasonDamaged, "", userID)
+		assert.Error(t, err)
+
+		_, err = NewInventoryCostAdjustment(product, 5, 2, decimal.NewFromInt(10), CostAdjustmentReasonDamaged, "", userID)
+		assert.Error(t, err, "not below current cost")
+
+		_, err = NewInventoryCostAdjustment(product, 5, 2, decimal.NewFromInt(-1), CostAdjustmentReasonDamaged, "", userID)
+		assert.Error(t, err)
+
+		_, err = NewInventoryCostAdjustment(product, 5, 2, decimal.NewFromInt(5), "lost", "", userID)
+		assert.Error(t, err)
+
+		_, err = NewInventoryCostAdjustment(product, 5, 2, decimal.NewFromInt(5), CostAdjustmentReasonOther, " ", userID)
+		assert.Error(t, err, "other requires notes")
+
+		assert.True(t, product.Cost.Equal(decimal.NewFromInt(10)), "product cost unchanged")
+	})
+}
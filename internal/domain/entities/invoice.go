@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -13,13 +15,17 @@ import (
 type InvoiceStatus string
 
 const (
-	InvoiceStatusDraft     InvoiceStatus = "draft"
-	InvoiceStatusGenerated InvoiceStatus = "generated"
-	InvoiceStatusSent      InvoiceStatus = "sent"
-	InvoiceStatusPaid      InvoiceStatus = "paid"
-	InvoiceStatusCancelled InvoiceStatus = "cancelled"
+	InvoiceStatusDraft         InvoiceStatus = "draft"
+	InvoiceStatusGenerated     InvoiceStatus = "generated"
+	InvoiceStatusSent          InvoiceStatus = "sent"
+	InvoiceStatusPartiallyPaid InvoiceStatus = "partially_paid" // Payments recorded short of the total
+	InvoiceStatusPaid          InvoiceStatus = "paid"
+	InvoiceStatusCancelled     InvoiceStatus = "cancelled"
 )
 
+// maxCustomerTaxIDLength caps the length of a customer's taxpayer ID
+const maxCustomerTaxIDLength = 30
+
 // PaperSize represents paper size for invoice printing
 type PaperSize string
 
@@ -33,28 +39,45 @@ const (
 
 // Invoice represents an invoice
 type Invoice struct {
-	ID              uuid.UUID       `json:"id"`
-	TenantID        uuid.UUID       `json:"tenant_id"`
-	InvoiceNumber   string          `json:"invoice_number"`
-	SaleID          uuid.UUID       `json:"sale_id"`
-	CustomerName    string          `json:"customer_name"`
-	CustomerEmail   string          `json:"customer_email,omitempty"`
-	CustomerPhone   string          `json:"customer_phone,omitempty"`
-	CustomerAddress string          `json:"customer_address,omitempty"`
-	Items           []InvoiceItem   `json:"items"`
-	Subtotal        decimal.Decimal `json:"subtotal"`
-	TaxAmount       decimal.Decimal `json:"tax_amount"`
-	DiscountAmount  decimal.Decimal `json:"discount_amount"`
-	TotalAmount     decimal.Decimal `json:"total_amount"`
-	PaidAmount      decimal.Decimal `json:"paid_amount"`
-	PaymentMethod   PaymentMethod   `json:"payment_method"`
-	Status          InvoiceStatus   `json:"status"`
-	Notes           string          `json:"notes,omitempty"`
-	DueDate         *time.Time      `json:"due_date,omitempty"`
-	PaidAt          *time.Time      `json:"paid_at,omitempty"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
-	CreatedBy       uuid.UUID       `json:"created_by"`
+	ID                 uuid.UUID            `json:"id"`
+	TenantID           uuid.UUID            `json:"tenant_id"`
+	InvoiceNumber      string               `json:"invoice_number"`
+	SaleID             uuid.UUID            `json:"sale_id"`
+	CustomerName       string               `json:"customer_name"`
+	CustomerEmail      string               `json:"customer_email,omitempty"`
+	CustomerPhone      string               `json:"customer_phone,omitempty"`
+	CustomerAddress    string               `json:"customer_address,omitempty"`
+	CustomerTaxID      string               `json:"customer_tax_id,omitempty"` // Taxpayer ID of the customer, e.g. an Indonesian NPWP
+	Items              []InvoiceItem        `json:"items"`
+	Subtotal           decimal.Decimal      `json:"subtotal"`
+	TaxAmount          decimal.Decimal      `json:"tax_amount"`
+	TaxInclusive       bool                 `json:"tax_inclusive"`
+	TaxSummary         []TaxRateSummary     `json:"tax_summary"` // Tax grouped by rate, taken from the sale
+	DiscountAmount     decimal.Decimal      `json:"discount_amount"`
+	SurchargeAmount    decimal.Decimal      `json:"surcharge_amount"`
+	SurchargeTaxAmount decimal.Decimal      `json:"surcharge_tax_amount"`
+	SurchargeLabel     string               `json:"surcharge_label,omitempty"`
+	TotalAmount        decimal.Decimal      `json:"total_amount"`
+	PaidAmount         decimal.Decimal      `json:"paid_amount"`
+	PaymentMethod      PaymentMethod        `json:"payment_method"`
+	Status             InvoiceStatus        `json:"status"`
+	Notes              string               `json:"notes,omitempty"`
+	DueDate            *time.Time           `json:"due_date,omitempty"`
+	PaymentSchedule    []InvoiceInstallment `json:"payment_schedule,omitempty"` // Installments in due date order
+	PaidAt             *time.Time           `json:"paid_at,omitempty"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+	CreatedBy          uuid.UUID            `json:"created_by"`
+	Currency           string               `json:"currency,omitempty"`      // ISO 4217 code of the invoiced sale
+	ExchangeRate       *ExchangeRate        `json:"exchange_rate,omitempty"` // The rate locked on the invoiced sale
+
+	// Signature is the customer's signature, attached when rendering the invoice. It is stored
+	// separately and not loaded by the invoice repository.
+	Signature *DocumentSignature `json:"signature,omitempty"`
+
+	// CurrencyFormat is the display of the invoice's currency, attached when rendering the
+	// invoice into a PDF or email
+	CurrencyFormat *CurrencyFormat `json:"-"`
 }
 
 // InvoiceItem represents an item in an invoice
@@ -68,6 +91,9 @@ type InvoiceItem struct {
 	Quantity    int             `json:"quantity"`
 	UnitPrice   decimal.Decimal `json:"unit_price"`
 	TotalPrice  decimal.Decimal `json:"total_price"`
+	TaxRateID   *uuid.UUID      `json:"tax_rate_id,omitempty"` // Rate of the product or its category, nil for the sale's rate
+	TaxRate     decimal.Decimal `json:"tax_rate"`
+	TaxAmount   decimal.Decimal `json:"tax_amount"`
 }
 
 // CompanyInfo represents company information for invoice
@@ -82,15 +108,20 @@ type CompanyInfo struct {
 
 // InvoiceTemplate represents invoice template configuration
 type InvoiceTemplate struct {
-	PaperSize   PaperSize       `json:"paper_size"`
-	CompanyInfo CompanyInfo     `json:"company_info"`
-	ShowLogo    bool            `json:"show_logo"`
-	LogoPath    string          `json:"logo_path,omitempty"`
-	Footer      string          `json:"footer,omitempty"`
-	IncludeTax  bool            `json:"include_tax"`
-	TaxRate     decimal.Decimal `json:"tax_rate"`
-	Currency    string          `json:"currency"`
-	Locale      string          `json:"locale"`
+	PaperSize     PaperSize              `json:"paper_size"`
+	CompanyInfo   CompanyInfo            `json:"company_info"`
+	ShowLogo      bool                   `json:"show_logo"`
+	LogoPath      string                 `json:"logo_path,omitempty"`
+	Logo          []byte                 `json:"logo,omitempty"` // PNG or JPEG, base64 encoded in JSON
+	Header        string                 `json:"header,omitempty"`
+	Footer        string                 `json:"footer,omitempty"`
+	AccentColor   string                 `json:"accent_color,omitempty"`   // Hex color of titles and rules, e.g. "#2E86AB"
+	HiddenColumns []InvoiceColumn        `json:"hidden_columns,omitempty"` // Item columns left out of the table
+	CustomFields  []InvoiceTemplateField `json:"custom_fields,omitempty"`  // Printed with the invoice details
+	IncludeTax    bool                   `json:"include_tax"`
+	TaxRate       decimal.Decimal        `json:"tax_rate"`
+	Currency      string                 `json:"currency"`
+	Locale        string                 `json:"locale"`
 }
 
 // NewInvoice creates a new invoice from a sale
@@ -107,25 +138,32 @@ func NewInvoice(tenantID uuid.UUID, invoiceNumber string, sale *Sale, createdBy
 
 	now := time.Now()
 	invoice := &Invoice{
-		ID:             uuid.New(),
-		TenantID:       tenantID,
-		InvoiceNumber:  invoiceNumber,
-		SaleID:         sale.ID,
-		CustomerName:   sale.CustomerName,
-		CustomerEmail:  sale.CustomerEmail,
-		CustomerPhone:  sale.CustomerPhone,
-		Items:          convertSaleItemsToInvoiceItems(sale.Items),
-		Subtotal:       sale.Subtotal,
-		TaxAmount:      sale.TaxAmount,
-		DiscountAmount: sale.DiscountAmount,
-		TotalAmount:    sale.TotalAmount,
-		PaidAmount:     sale.PaidAmount,
-		PaymentMethod:  sale.PaymentMethod,
-		Status:         InvoiceStatusDraft,
-		Notes:          sale.Notes,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-		CreatedBy:      createdBy,
+		ID:                 uuid.New(),
+		TenantID:           tenantID,
+		InvoiceNumber:      invoiceNumber,
+		SaleID:             sale.ID,
+		CustomerName:       sale.CustomerName,
+		CustomerEmail:      sale.CustomerEmail,
+		CustomerPhone:      sale.CustomerPhone,
+		Items:              convertSaleItemsToInvoiceItems(sale.Items),
+		Subtotal:           sale.Subtotal,
+		TaxAmount:          sale.TaxAmount,
+		TaxInclusive:       sale.TaxInclusive,
+		TaxSummary:         sale.TaxSummary(),
+		DiscountAmount:     sale.DiscountAmount,
+		SurchargeAmount:    sale.SurchargeAmount,
+		SurchargeTaxAmount: sale.SurchargeTaxAmount,
+		SurchargeLabel:     sale.SurchargeLabel,
+		TotalAmount:        sale.TotalAmount,
+		PaidAmount:         sale.PaidAmount,
+		PaymentMethod:      sale.PaymentMethod,
+		Status:             InvoiceStatusDraft,
+		Notes:              sale.Notes,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		CreatedBy:          createdBy,
+		Currency:           sale.Currency,
+		ExchangeRate:       sale.ExchangeRate,
 	}
 
 	return invoice, nil
@@ -178,6 +216,24 @@ func (i *Invoice) UpdateCustomerInfo(name, email, phone, address string) error {
 	return nil
 }
 
+// SetCustomerTaxID sets the customer's taxpayer ID printed on tax invoices. Digits, letters and
+// the separators used to write tax IDs are accepted; an empty ID clears it.
+func (i *Invoice) SetCustomerTaxID(taxID string) error {
+	taxID = strings.TrimSpace(taxID)
+	if len(taxID) > maxCustomerTaxIDLength {
+		return errors.NewValidationError("customer tax ID too long", "customer_tax_id cannot exceed 30 characters")
+	}
+	for _, r := range taxID {
+		if !unicode.IsDigit(r) && !unicode.IsLetter(r) && !strings.ContainsRune(".-/ ", r) {
+			return errors.NewValidationError("invalid customer tax ID", "customer_tax_id can only contain digits, letters, dots, dashes, slashes and spaces")
+		}
+	}
+
+	i.CustomerTaxID = taxID
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
 // SetDueDate sets the due date for the invoice
 func (i *Invoice) SetDueDate(dueDate time.Time) error {
 	if dueDate.Before(i.CreatedAt) {
@@ -239,6 +295,9 @@ func (i *Invoice) Cancel() error {
 	if i.Status == InvoiceStatusCancelled {
 		return errors.NewValidationError("invalid invoice status", "invoice is already cancelled")
 	}
+	if i.Status == InvoiceStatusPartiallyPaid {
+		return errors.NewValidationError("invalid invoice status", "partially paid invoices cannot be cancelled")
+	}
 
 	i.Status = InvoiceStatusCancelled
 	i.UpdatedAt = time.Now()
@@ -267,6 +326,11 @@ func (i *Invoice) IsSent() bool {
 	return i.Status == InvoiceStatusSent
 }
 
+// IsPartiallyPaid checks if the invoice has payments short of its total
+func (i *Invoice) IsPartiallyPaid() bool {
+	return i.Status == InvoiceStatusPartiallyPaid
+}
+
 // IsPaid checks if the invoice is paid
 func (i *Invoice) IsPaid() bool {
 	return i.Status == InvoiceStatusPaid
@@ -294,6 +358,59 @@ func (i *Invoice) GetItemCount() int {
 	return count
 }
 
+// FormatAmount formats an amount of the invoice for display, using the attached currency
+// display or the conventional display of the invoice's currency
+func (i *Invoice) FormatAmount(amount decimal.Decimal) string {
+	if i.CurrencyFormat != nil {
+		return i.CurrencyFormat.Format(amount)
+	}
+	return DefaultCurrencyFormat(i.Currency).Format(amount)
+}
+
+// Totals returns the stored totals of the invoice
+func (i *Invoice) Totals() DocumentTotals {
+	return DocumentTotals{
+		Subtotal:    i.Subtotal,
+		TaxAmount:   i.TaxAmount,
+		TotalAmount: i.TotalAmount,
+	}
+}
+
+// RecalculateTotals recomputes the item totals, tax, tax summary and total of the invoice from
+// its items and discount, rounding tax to cents per item as it is stored. The invoice does not
+// store a tax rate, so the rate of its sale is passed in for items without a rate of their own.
+func (i *Invoice) RecalculateTotals(taxRate decimal.Decimal) {
+	i.Subtotal = decimal.Zero
+	lines := make([]taxLine, len(i.Items))
+	for idx := range i.Items {
+		item := &i.Items[idx]
+		item.TotalPrice = item.UnitPrice.Mul(decimal.NewFromInt(int64(item.Quantity)))
+		i.Subtotal = i.Subtotal.Add(item.TotalPrice)
+
+		rate := taxRate
+		if item.TaxRateID != nil {
+			rate = item.TaxRate
+		}
+		lines[idx] = taxLine{amount: item.TotalPrice, rate: rate}
+	}
+
+	taxable, taxes := computeLineTaxes(lines, i.DiscountAmount, i.TaxInclusive)
+	goodsTax := decimal.Zero
+	for idx := range i.Items {
+		i.Items[idx].TaxRate = lines[idx].rate
+		i.Items[idx].TaxAmount = taxes[idx]
+		goodsTax = goodsTax.Add(taxes[idx])
+	}
+
+	i.TaxAmount = goodsTax.Add(i.SurchargeTaxAmount)
+	i.TaxSummary = summarizeTax(lines, taxable, taxes, i.SurchargeAmount, i.SurchargeTaxAmount, taxRate)
+	i.TotalAmount = i.Subtotal.Sub(i.DiscountAmount).Add(i.SurchargeAmount).Add(i.TaxAmount)
+	if i.TaxInclusive {
+		i.TotalAmount = i.TotalAmount.Sub(goodsTax)
+	}
+	i.UpdatedAt = time.Now()
+}
+
 // GetPaperSizeDimensions returns paper size dimensions in points (1 point = 1/72 inch)
 func GetPaperSizeDimensions(size PaperSize) (width, height float64) {
 	switch size {
@@ -324,6 +441,9 @@ func convertSaleItemsToInvoiceItems(saleItems []SaleItem) []InvoiceItem {
 			Quantity:    saleItem.Quantity,
 			UnitPrice:   saleItem.UnitPrice,
 			TotalPrice:  saleItem.TotalPrice,
+			TaxRateID:   saleItem.TaxRateID,
+			TaxRate:     saleItem.TaxRate,
+			TaxAmount:   saleItem.TaxAmount,
 		}
 	}
 	return invoiceItems
@@ -332,10 +452,10 @@ func convertSaleItemsToInvoiceItems(saleItems []SaleItem) []InvoiceItem {
 // ValidateInvoiceStatus validates invoice status
 func ValidateInvoiceStatus(status InvoiceStatus) error {
 	switch status {
-	case InvoiceStatusDraft, InvoiceStatusGenerated, InvoiceStatusSent, InvoiceStatusPaid, InvoiceStatusCancelled:
+	case InvoiceStatusDraft, InvoiceStatusGenerated, InvoiceStatusSent, InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusCancelled:
 		return nil
 	default:
-		return errors.NewValidationError("invalid invoice status", "status must be one of: draft, generated, sent, paid, cancelled")
+		return errors.NewValidationError("invalid invoice status", "status must be one of: draft, generated, sent, partially_paid, paid, cancelled")
 	}
 }
 
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// InvoiceDownloadLink grants access to an invoice PDF through the invoice portal without a
+// user session. It is sent to customers in place of an attachment too large to email, and is
+// valid until it expires.
+type InvoiceDownloadLink struct {
+	InvoiceID uuid.UUID
+	PaperSize PaperSize
+	ExpiresAt time.Time
+	Signature string
+}
+
+// NewInvoiceDownloadLink signs a link to an invoice PDF rendered on the given paper size
+func NewInvoiceDownloadLink(secret string, invoiceID uuid.UUID, paperSize PaperSize, expiresAt time.Time) *InvoiceDownloadLink {
+	link := &InvoiceDownloadLink{
+		InvoiceID: invoiceID,
+		PaperSize: paperSize,
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0),
+	}
+	link.Signature = hex.EncodeToString(link.mac(secret))
+	return link
+}
+
+// Verify checks the link was signed with secret and has not expired at now
+func (l *InvoiceDownloadLink) Verify(secret string, now time.Time) error {
+	signature, err := hex.DecodeString(l.Signature)
+	if err != nil || !hmac.Equal(signature, l.mac(secret)) {
+		return errors.NewUnauthorizedError("invalid download link")
+	}
+	if now.After(l.ExpiresAt) {
+		return errors.NewUnauthorizedError("download link has expired")
+	}
+	return nil
+}
+
+// mac returns the HMAC-SHA256 of the link's invoice, paper size and expiry
+func (l *InvoiceDownloadLink) mac(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s.%s.%d", l.InvoiceID, l.PaperSize, l.ExpiresAt.Unix())
+	return mac.Sum(nil)
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoiceDownloadLink_Verify(t *testing.T) {
+	now := time.Now()
+	link := NewInvoiceDownloadLink("secret", uuid.New(), PaperSizeA4, now.Add(time.Hour))
+
+	assert.NoError(t, link.Verify("secret", now))
+	assert.Error(t, link.Verify("other-secret", now))
+	assert.Error(t, link.Verify("secret", now.Add(2*time.Hour)))
+
+	tampered := *link
+	tampered.PaperSize = PaperSizeReceipt
+	assert.Error(t, tampered.Verify("secret", now))
+
+	tampered = *link
+	tampered.ExpiresAt = link.ExpiresAt.Add(24 * time.Hour)
+	assert.Error(t, tampered.Verify("secret", now))
+
+	tampered = *link
+	tampered.Signature = "not-hex"
+	assert.Error(t, tampered.Verify("secret", now))
+}
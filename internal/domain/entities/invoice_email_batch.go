@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// InvoiceEmailBatchStatus represents the status of a bulk invoice email batch
+type InvoiceEmailBatchStatus string
+
+const (
+	InvoiceEmailBatchStatusPending    InvoiceEmailBatchStatus = "pending"
+	InvoiceEmailBatchStatusProcessing InvoiceEmailBatchStatus = "processing"
+	InvoiceEmailBatchStatusCompleted  InvoiceEmailBatchStatus = "completed"
+)
+
+// InvoiceEmailOutcome represents the delivery outcome of a single invoice in a batch
+type InvoiceEmailOutcome string
+
+const (
+	InvoiceEmailOutcomePending InvoiceEmailOutcome = "pending"
+	InvoiceEmailOutcomeSent    InvoiceEmailOutcome = "sent"
+	InvoiceEmailOutcomeFailed  InvoiceEmailOutcome = "failed"
+	InvoiceEmailOutcomeSkipped InvoiceEmailOutcome = "skipped"
+)
+
+// Reasons an invoice in a batch is skipped instead of emailed
+const (
+	InvoiceEmailSkipMissingEmail = "missing_email"
+	InvoiceEmailSkipInvalidEmail = "invalid_email"
+	InvoiceEmailSkipOptedOut     = "email_opted_out"
+	InvoiceEmailSkipCancelled    = "invoice_cancelled"
+)
+
+// MaxInvoiceEmailBatchSize caps the number of invoices emailed in a single batch
+const MaxInvoiceEmailBatchSize = 500
+
+// InvoiceEmailBatch represents a bulk request to email invoices to their customers
+type InvoiceEmailBatch struct {
+	ID           uuid.UUID               `json:"id"`
+	TenantID     uuid.UUID               `json:"tenant_id"`
+	Status       InvoiceEmailBatchStatus `json:"status"`
+	Items        []InvoiceEmailBatchItem `json:"items,omitempty"`
+	TotalCount   int                     `json:"total_count"`
+	SentCount    int                     `json:"sent_count"`
+	FailedCount  int                     `json:"failed_count"`
+	SkippedCount int                     `json:"skipped_count"`
+	RequestedBy  uuid.UUID               `json:"requested_by"`
+	StartedAt    *time.Time              `json:"started_at,omitempty"`
+	CompletedAt  *time.Time              `json:"completed_at,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+// InvoiceEmailBatchItem represents the delivery of one invoice in a batch
+type InvoiceEmailBatchItem struct {
+	ID            uuid.UUID           `json:"id"`
+	BatchID       uuid.UUID           `json:"batch_id"`
+	InvoiceID     uuid.UUID           `json:"invoice_id"`
+	InvoiceNumber string              `json:"invoice_number"`
+	Recipient     string              `json:"recipient,omitempty"`
+	Outcome       InvoiceEmailOutcome `json:"outcome"`
+	Reason        string              `json:"reason,omitempty"` // Skip reason or delivery error
+	ProcessedAt   *time.Time          `json:"processed_at,omitempty"`
+}
+
+// NewInvoiceEmailBatch creates a batch for the given invoices. Invoices without a usable
+// address, whose address has opted out of email, or that are cancelled are skipped up front.
+func NewInvoiceEmailBatch(tenantID, requestedBy uuid.UUID, invoices []*Invoice, optedOut map[string]bool) (*InvoiceEmailBatch, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if len(invoices) == 0 {
+		return nil, errors.NewValidationError("no invoices selected", "at least one invoice must match")
+	}
+	if len(invoices) > MaxInvoiceEmailBatchSize {
+		return nil, errors.NewValidationError("too many invoices selected", "a batch cannot exceed 500 invoices")
+	}
+
+	now := time.Now()
+	batch := &InvoiceEmailBatch{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Status:      InvoiceEmailBatchStatusPending,
+		Items:       make([]InvoiceEmailBatchItem, 0, len(invoices)),
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	for _, invoice := range invoices {
+		recipient := NormalizeEmail(invoice.CustomerEmail)
+		item := InvoiceEmailBatchItem{
+			ID:            uuid.New(),
+			BatchID:       batch.ID,
+			InvoiceID:     invoice.ID,
+			InvoiceNumber: invoice.InvoiceNumber,
+			Recipient:     recipient,
+			Outcome:       InvoiceEmailOutcomePending,
+		}
+
+		switch {
+		case invoice.Status == InvoiceStatusCancelled:
+			item.skip(InvoiceEmailSkipCancelled, now)
+		case recipient == "":
+			item.skip(InvoiceEmailSkipMissingEmail, now)
+		case !isValidEmail(recipient):
+			item.skip(InvoiceEmailSkipInvalidEmail, now)
+		case optedOut[recipient]:
+			item.skip(InvoiceEmailSkipOptedOut, now)
+		}
+
+		batch.Items = append(batch.Items, item)
+	}
+
+	batch.recount()
+	return batch, nil
+}
+
+// Start marks the batch as being processed
+func (b *InvoiceEmailBatch) Start() error {
+	if b.Status != InvoiceEmailBatchStatusPending {
+		return errors.NewValidationError("invalid batch status", "only pending batches can be started")
+	}
+
+	now := time.Now()
+	b.Status = InvoiceEmailBatchStatusProcessing
+	b.StartedAt = &now
+	b.UpdatedAt = now
+	return nil
+}
+
+// RecordOutcome records the delivery outcome of the item at index
+func (b *InvoiceEmailBatch) RecordOutcome(index int, outcome InvoiceEmailOutcome, reason string) {
+	now := time.Now()
+	b.Items[index].Outcome = outcome
+	b.Items[index].Reason = reason
+	b.Items[index].ProcessedAt = &now
+	b.UpdatedAt = now
+	b.recount()
+}
+
+// Complete marks the batch as completed once every item has an outcome
+func (b *InvoiceEmailBatch) Complete() {
+	now := time.Now()
+	b.Status = InvoiceEmailBatchStatusCompleted
+	b.CompletedAt = &now
+	b.UpdatedAt = now
+}
+
+// PendingCount returns the number of items still waiting to be sent
+func (b *InvoiceEmailBatch) PendingCount() int {
+	return b.TotalCount - b.SentCount - b.FailedCount - b.SkippedCount
+}
+
+// recount recomputes the outcome counters from the items
+func (b *InvoiceEmailBatch) recount() {
+	b.TotalCount = len(b.Items)
+	b.SentCount, b.FailedCount, b.SkippedCount = 0, 0, 0
+	for _, item := range b.Items {
+		switch item.Outcome {
+		case InvoiceEmailOutcomeSent:
+			b.SentCount++
+		case InvoiceEmailOutcomeFailed:
+			b.FailedCount++
+		case InvoiceEmailOutcomeSkipped:
+			b.SkippedCount++
+		}
+	}
+}
+
+// skip marks the item as skipped for the given reason
+func (i *InvoiceEmailBatchItem) skip(reason string, at time.Time) {
+	i.Outcome = InvoiceEmailOutcomeSkipped
+	i.Reason = reason
+	i.ProcessedAt = &at
+}
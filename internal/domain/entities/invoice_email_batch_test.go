@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvoiceEmailBatch(t *testing.T) {
+	t.Run("skips invoices that cannot be emailed", func(t *testing.T) {
+		invoices := []*Invoice{
+			{ID: uuid.New(), InvoiceNumber: "INV-1", CustomerEmail: " Buyer@Example.com ", Status: InvoiceStatusGenerated},
+			{ID: uuid.New(), InvoiceNumber: "INV-2", CustomerEmail: "", Status: InvoiceStatusGenerated},
+			{ID: uuid.New(), InvoiceNumber: "INV-3", CustomerEmail: "not-an-email", Status: InvoiceStatusGenerated},
+			{ID: uuid.New(), InvoiceNumber: "INV-4", CustomerEmail: "optout@example.com", Status: InvoiceStatusSent},
+			{ID: uuid.New(), InvoiceNumber: "INV-5", CustomerEmail: "buyer@example.com", Status: InvoiceStatusCancelled},
+		}
+		optedOut := map[string]bool{"optout@example.com": true}
+
+		batch, err := NewInvoiceEmailBatch(uuid.New(), uuid.New(), invoices, optedOut)
+
+		require.NoError(t, err)
+		assert.Equal(t, InvoiceEmailBatchStatusPending, batch.Status)
+		assert.Equal(t, 5, batch.TotalCount)
+		assert.Equal(t, 4, batch.SkippedCount)
+		assert.Equal(t, 1, batch.PendingCount())
+
+		assert.Equal(t, "buyer@example.com", batch.Items[0].Recipient)
+		assert.Equal(t, InvoiceEmailOutcomePending, batch.Items[0].Outcome)
+		assert.Equal(t, InvoiceEmailSkipMissingEmail, batch.Items[1].Reason)
+		assert.Equal(t, InvoiceEmailSkipInvalidEmail, batch.Items[2].Reason)
+		assert.Equal(t, InvoiceEmailSkipOptedOut, batch.Items[3].Reason)
+		assert.Equal(t, InvoiceEmailSkipCancelled, batch.Items[4].Reason)
+	})
+
+	t.Run("no invoices", func(t *testing.T) {
+		batch, err := NewInvoiceEmailBatch(uuid.New(), uuid.New(), nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, batch)
+	})
+
+	t.Run("too many invoices", func(t *testing.T) {
+		invoices := make([]*Invoice, MaxInvoiceEmailBatchSize+1)
+		for i := range invoices {
+			invoices[i] = &Invoice{ID: uuid.New(), CustomerEmail: "buyer@example.com"}
+		}
+
+		batch, err := NewInvoiceEmailBatch(uuid.New(), uuid.New(), invoices, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, batch)
+	})
+}
+
+func TestInvoiceEmailBatch_Lifecycle(t *testing.T) {
+	invoices := []*Invoice{
+		{ID: uuid.New(), InvoiceNumber: "INV-1", CustomerEmail: "a@example.com", Status: InvoiceStatusGenerated},
+		{ID: uuid.New(), InvoiceNumber: "INV-2", CustomerEmail: "b@example.com", Status: InvoiceStatusGenerated},
+	}
+	batch, err := NewInvoiceEmailBatch(uuid.New(), uuid.New(), invoices, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, batch.Start())
+	assert.Equal(t, InvoiceEmailBatchStatusProcessing, batch.Status)
+	assert.Error(t, batch.Start())
+
+	batch.RecordOutcome(0, InvoiceEmailOutcomeSent, "")
+	batch.RecordOutcome(1, InvoiceEmailOutcomeFailed, "mailbox unavailable")
+	batch.Complete()
+
+	assert.Equal(t, InvoiceEmailBatchStatusCompleted, batch.Status)
+	assert.Equal(t, 1, batch.SentCount)
+	assert.Equal(t, 1, batch.FailedCount)
+	assert.Equal(t, 0, batch.PendingCount())
+	assert.NotNil(t, batch.Items[1].ProcessedAt)
+	assert.NotNil(t, batch.CompletedAt)
+}
+
+func TestNewEmailSuppression(t *testing.T) {
+	t.Run("normalizes address", func(t *testing.T) {
+		suppression, err := NewEmailSuppression(uuid.New(), " Buyer@Example.COM ", "unsubscribed", uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "buyer@example.com", suppression.Email)
+		assert.Equal(t, "unsubscribed", suppression.Reason)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		suppression, err := NewEmailSuppression(uuid.New(), "nope", "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, suppression)
+	})
+}
@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// InvoiceNumberReservationStatus represents the lifecycle status of an invoice number reservation
+type InvoiceNumberReservationStatus string
+
+const (
+	InvoiceNumberReservationStatusReserved InvoiceNumberReservationStatus = "reserved"
+	InvoiceNumberReservationStatusBound    InvoiceNumberReservationStatus = "bound"
+	InvoiceNumberReservationStatusVoided   InvoiceNumberReservationStatus = "voided"
+)
+
+// InvoiceNumberReservation represents an invoice number taken from the tenant's sequence for an
+// invoice issued by an external system. Numbers are never reused: a reservation is either bound
+// to the invoice created with its number or voided with a reason, so every number in the
+// sequence is accounted for.
+type InvoiceNumberReservation struct {
+	ID                uuid.UUID                      `json:"id"`
+	TenantID          uuid.UUID                      `json:"tenant_id"`
+	SequenceNumber    int64                          `json:"sequence_number"`
+	InvoiceNumber     string                         `json:"invoice_number"`
+	Status            InvoiceNumberReservationStatus `json:"status"`
+	Reason            string                         `json:"reason"`                       // Why the number was reserved
+	ExternalReference string                         `json:"external_reference,omitempty"` // Document ID in the external system
+	InvoiceID         *uuid.UUID                     `json:"invoice_id,omitempty"`
+	VoidReason        string                         `json:"void_reason,omitempty"`
+	ReservedBy        uuid.UUID                      `json:"reserved_by"`
+	BoundBy           *uuid.UUID                     `json:"bound_by,omitempty"`
+	VoidedBy          *uuid.UUID                     `json:"voided_by,omitempty"`
+	BoundAt           *time.Time                     `json:"bound_at,omitempty"`
+	VoidedAt          *time.Time                     `json:"voided_at,omitempty"`
+	CreatedAt         time.Time                      `json:"created_at"`
+	UpdatedAt         time.Time                      `json:"updated_at"`
+}
+
+// NewInvoiceNumberReservation creates a new invoice number reservation. Its number is assigned
+// from the tenant's sequence when the reservation is stored.
+func NewInvoiceNumberReservation(tenantID uuid.UUID, reason, externalReference string, reservedBy uuid.UUID) (*InvoiceNumberReservation, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.NewValidationError("reason is required", "reason cannot be empty")
+	}
+	if len(reason) > 500 {
+		return nil, errors.NewValidationError("reason too long", "reason cannot exceed 500 characters")
+	}
+
+	externalReference = strings.TrimSpace(externalReference)
+	if len(externalReference) > 100 {
+		return nil, errors.NewValidationError("external reference too long", "external reference cannot exceed 100 characters")
+	}
+
+	now := time.Now()
+	return &InvoiceNumberReservation{
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		Status:            InvoiceNumberReservationStatusReserved,
+		Reason:            reason,
+		ExternalReference: externalReference,
+		ReservedBy:        reservedBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// AssignSequenceNumber sets the reservation's position in the tenant's sequence and the invoice
+// number formatted from it
+func (r *InvoiceNumberReservation) AssignSequenceNumber(sequenceNumber int64) {
+	r.SequenceNumber = sequenceNumber
+	r.InvoiceNumber = FormatSequentialInvoiceNumber(sequenceNumber)
+}
+
+// Bind records that the reserved number was given to an invoice
+func (r *InvoiceNumberReservation) Bind(invoiceID, userID uuid.UUID) error {
+	if r.Status != InvoiceNumberReservationStatusReserved {
+		return errors.NewValidationError("invalid reservation status", "only reserved invoice numbers can be bound")
+	}
+	if invoiceID == uuid.Nil {
+		return errors.NewValidationError("invoice ID is required", "invoice ID cannot be empty")
+	}
+
+	now := time.Now()
+	r.Status = InvoiceNumberReservationStatusBound
+	r.InvoiceID = &invoiceID
+	r.BoundBy = &userID
+	r.BoundAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Void records that the reserved number will not be used. The number stays taken.
+func (r *InvoiceNumberReservation) Void(reason string, userID uuid.UUID) error {
+	if r.Status != InvoiceNumberReservationStatusReserved {
+		return errors.NewValidationError("invalid reservation status", "only reserved invoice numbers can be voided")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.NewValidationError("void reason is required", "void reason cannot be empty")
+	}
+	if len(reason) > 500 {
+		return errors.NewValidationError("void reason too long", "void reason cannot exceed 500 characters")
+	}
+
+	now := time.Now()
+	r.Status = InvoiceNumberReservationStatusVoided
+	r.VoidReason = reason
+	r.VoidedBy = &userID
+	r.VoidedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// IsReserved checks if the number is still waiting to be bound or voided
+func (r *InvoiceNumberReservation) IsReserved() bool {
+	return r.Status == InvoiceNumberReservationStatusReserved
+}
+
+// FormatSequentialInvoiceNumber formats a position in a tenant's invoice number sequence
+func FormatSequentialInvoiceNumber(sequenceNumber int64) string {
+	return fmt.Sprintf("INV-%06d", sequenceNumber)
+}
+
+// ValidateInvoiceNumberReservationStatus validates invoice number reservation status
+func ValidateInvoiceNumberReservationStatus(status InvoiceNumberReservationStatus) error {
+	switch status {
+	case InvoiceNumberReservationStatusReserved, InvoiceNumberReservationStatusBound, InvoiceNumberReservationStatusVoided:
+		return nil
+	default:
+		return errors.NewValidationError("invalid reservation status", "status must be one of: reserved, bound, voided")
+	}
+}
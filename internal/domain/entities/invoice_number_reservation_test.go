@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvoiceNumberReservation(t *testing.T) {
+	t.Run("valid reservation creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		reservedBy := uuid.New()
+
+		reservation, err := NewInvoiceNumberReservation(tenantID, " Issued from ERP ", "ERP-1001", reservedBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, reservation.ID)
+		assert.Equal(t, tenantID, reservation.TenantID)
+		assert.Equal(t, InvoiceNumberReservationStatusReserved, reservation.Status)
+		assert.Equal(t, "Issued from ERP", reservation.Reason)
+		assert.Equal(t, "ERP-1001", reservation.ExternalReference)
+		assert.Equal(t, reservedBy, reservation.ReservedBy)
+		assert.Empty(t, reservation.InvoiceNumber)
+		assert.True(t, reservation.IsReserved())
+	})
+
+	t.Run("invalid reason - empty", func(t *testing.T) {
+		reservation, err := NewInvoiceNumberReservation(uuid.New(), " ", "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, reservation)
+		assert.Contains(t, err.Error(), "reason is required")
+	})
+
+	t.Run("invalid reason - too long", func(t *testing.T) {
+		reservation, err := NewInvoiceNumberReservation(uuid.New(), strings.Repeat("a", 501), "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, reservation)
+		assert.Contains(t, err.Error(), "reason too long")
+	})
+
+	t.Run("invalid external reference - too long", func(t *testing.T) {
+		reservation, err := NewInvoiceNumberReservation(uuid.New(), "Issued from ERP", strings.Repeat("a", 101), uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, reservation)
+		assert.Contains(t, err.Error(), "external reference too long")
+	})
+}
+
+func TestInvoiceNumberReservation_AssignSequenceNumber(t *testing.T) {
+	reservation := createValidInvoiceNumberReservation(t)
+
+	reservation.AssignSequenceNumber(42)
+
+	assert.Equal(t, int64(42), reservation.SequenceNumber)
+	assert.Equal(t, "INV-000042", reservation.InvoiceNumber)
+}
+
+func TestInvoiceNumberReservation_Bind(t *testing.T) {
+	t.Run("bind reserved number", func(t *testing.T) {
+		reservation := createValidInvoiceNumberReservation(t)
+		invoiceID := uuid.New()
+		userID := uuid.New()
+
+		err := reservation.Bind(invoiceID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, InvoiceNumberReservationStatusBound, reservation.Status)
+		assert.Equal(t, invoiceID, *reservation.InvoiceID)
+		assert.Equal(t, userID, *reservation.BoundBy)
+		assert.NotNil(t, reservation.BoundAt)
+	})
+
+	t.Run("cannot bind twice", func(t *testing.T) {
+		reservation := createValidInvoiceNumberReservation(t)
+		require.NoError(t, reservation.Bind(uuid.New(), uuid.New()))
+
+		err := reservation.Bind(uuid.New(), uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid reservation status")
+	})
+
+	t.Run("cannot bind voided number", func(t *testing.T) {
+		reservation := createValidInvoiceNumberReservation(t)
+		require.NoError(t, reservation.Void("Document cancelled", uuid.New()))
+
+		err := reservation.Bind(uuid.New(), uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, reservation.InvoiceID)
+	})
+}
+
+func TestInvoiceNumberReservation_Void(t *testing.T) {
+	t.Run("void reserved number", func(t *testing.T) {
+		reservation := createValidInvoiceNumberReservation(t)
+		userID := uuid.New()
+
+		err := reservation.Void(" Document cancelled ", userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, InvoiceNumberReservationStatusVoided, reservation.Status)
+		assert.Equal(t, "Document cancelled", reservation.VoidReason)
+		assert.Equal(t, userID, *reservation.VoidedBy)
+		assert.NotNil(t, reservation.VoidedAt)
+		assert.Equal(t, "INV-000007", reservation.InvoiceNumber)
+	})
+
+	t.Run("void reason is required", func(t *testing.T) {
+		reservation := createValidInvoiceNumberReservation(t)
+
+		err := reservation.Void("", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "void reason is required")
+		assert.True(t, reservation.IsReserved())
+	})
+
+	t.Run("cannot void bound number", func(t *testing.T) {
+		reservation := createValidInvoiceNumberReservation(t)
+		require.NoError(t, reservation.Bind(uuid.New(), uuid.New()))
+
+		err := reservation.Void("Document cancelled", uuid.New())
+
+		assert.Error(t, err)
+		assert.Equal(t, InvoiceNumberReservationStatusBound, reservation.Status)
+	})
+}
+
+func TestValidateInvoiceNumberReservationStatus(t *testing.T) {
+	assert.NoError(t, ValidateInvoiceNumberReservationStatus(InvoiceNumberReservationStatusReserved))
+	assert.NoError(t, ValidateInvoiceNumberReservationStatus(InvoiceNumberReservationStatusBound))
+	assert.NoError(t, ValidateInvoiceNumberReservationStatus(InvoiceNumberReservationStatusVoided))
+	assert.Error(t, ValidateInvoiceNumberReservationStatus("expired"))
+}
+
+func createValidInvoiceNumberReservation(t *testing.T) *InvoiceNumberReservation {
+	reservation, err := NewInvoiceNumberReservation(uuid.New(), "Issued from ERP", "ERP-1001", uuid.New())
+	require.NoError(t, err)
+	reservation.AssignSequenceNumber(7)
+	return reservation
+}
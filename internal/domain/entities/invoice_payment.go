@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// InvoicePayment represents a payment received against an invoice. An invoice can be paid in
+// several partial payments.
+type InvoicePayment struct {
+	ID            uuid.UUID       `json:"id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	InvoiceID     uuid.UUID       `json:"invoice_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	PaymentMethod PaymentMethod   `json:"payment_method"`
+	Reference     string          `json:"reference,omitempty"` // e.g. the bank transfer reference
+	PaidAt        time.Time       `json:"paid_at"`
+	RecordedBy    uuid.UUID       `json:"recorded_by"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// InvoiceInstallment represents a part of an invoice's total due by a date
+type InvoiceInstallment struct {
+	DueDate time.Time       `json:"due_date"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// OutstandingAmount returns what is left to pay on the invoice
+func (i *Invoice) OutstandingAmount() decimal.Decimal {
+	outstanding := i.TotalAmount.Sub(i.PaidAmount)
+	if outstanding.IsNegative() {
+		return decimal.Zero
+	}
+	return outstanding
+}
+
+// RecordPayment records a payment against a generated or sent invoice. The invoice is paid
+// once its payments cover its total and partially paid until then. A payment cannot exceed the
+// outstanding amount.
+func (i *Invoice) RecordPayment(amount decimal.Decimal, method PaymentMethod, reference string, paidAt time.Time, recordedBy uuid.UUID) (*InvoicePayment, error) {
+	if i.Status == InvoiceStatusCancelled {
+		return nil, errors.NewValidationError("invalid invoice status", "cannot record payments on cancelled invoices")
+	}
+	if i.Status == InvoiceStatusPaid {
+		return nil, errors.NewValidationError("invalid invoice status", "invoice is already paid")
+	}
+	if i.Status == InvoiceStatusDraft {
+		return nil, errors.NewValidationError("invalid invoice status", "invoice must be generated before payments are recorded")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("invalid payment amount", "payment amount must be positive")
+	}
+	if !amount.Equal(amount.Round(2)) {
+		return nil, errors.NewValidationError("invalid payment amount", "payment amount cannot have more than 2 decimal places")
+	}
+	if amount.GreaterThan(i.OutstandingAmount()) {
+		return nil, errors.NewValidationError("payment exceeds outstanding amount", "payment amount cannot exceed "+i.OutstandingAmount().StringFixed(2))
+	}
+	if err := ValidatePaymentMethod(method); err != nil {
+		return nil, err
+	}
+	reference = strings.TrimSpace(reference)
+	if len(reference) > 100 {
+		return nil, errors.NewValidationError("payment reference too long", "reference cannot exceed 100 characters")
+	}
+
+	now := time.Now()
+	payment := &InvoicePayment{
+		ID:            uuid.New(),
+		TenantID:      i.TenantID,
+		InvoiceID:     i.ID,
+		Amount:        amount,
+		PaymentMethod: method,
+		Reference:     reference,
+		PaidAt:        paidAt,
+		RecordedBy:    recordedBy,
+		CreatedAt:     now,
+	}
+
+	i.PaidAmount = i.PaidAmount.Add(amount)
+	if i.OutstandingAmount().IsZero() {
+		i.Status = InvoiceStatusPaid
+		i.PaidAt = &paidAt
+	} else {
+		i.Status = InvoiceStatusPartiallyPaid
+	}
+	i.updateDueDate()
+	i.UpdatedAt = now
+
+	return payment, nil
+}
+
+// SetPaymentSchedule splits the invoice's total into installments due by their dates, in date
+// order. The invoice's due date becomes the due date of the first installment its payments do
+// not cover yet. An empty schedule removes the schedule and keeps the current due date.
+func (i *Invoice) SetPaymentSchedule(installments []InvoiceInstallment) error {
+	if i.Status == InvoiceStatusPaid || i.Status == InvoiceStatusCancelled {
+		return errors.NewValidationError("invalid invoice status", "cannot schedule payments of paid or cancelled invoices")
+	}
+
+	if len(installments) > 0 {
+		total := decimal.Zero
+		for n, installment := range installments {
+			if !installment.Amount.IsPositive() {
+				return errors.NewValidationError("invalid installment amount", "installment amounts must be positive")
+			}
+			if n == 0 && installment.DueDate.Before(i.CreatedAt) {
+				return errors.NewValidationError("invalid installment due date", "installments cannot be due before the invoice was created")
+			}
+			if n > 0 && !installment.DueDate.After(installments[n-1].DueDate) {
+				return errors.NewValidationError("invalid installment due date", "installments must be in due date order")
+			}
+			total = total.Add(installment.Amount)
+		}
+		if !total.Equal(i.TotalAmount) {
+			return errors.NewValidationError("invalid payment schedule", "installments must add up to the invoice total of "+i.TotalAmount.StringFixed(2))
+		}
+	}
+
+	i.PaymentSchedule = installments
+	i.updateDueDate()
+	i.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// updateDueDate moves the due date of an invoice with a payment schedule to the first
+// installment its payments do not cover yet, or the last installment once all are covered
+func (i *Invoice) updateDueDate() {
+	if len(i.PaymentSchedule) == 0 {
+		return
+	}
+
+	covered := i.PaidAmount
+	for _, installment := range i.PaymentSchedule {
+		if covered.LessThan(installment.Amount) {
+			dueDate := installment.DueDate
+			i.DueDate = &dueDate
+			return
+		}
+		covered = covered.Sub(installment.Amount)
+	}
+
+	dueDate := i.PaymentSchedule[len(i.PaymentSchedule)-1].DueDate
+	i.DueDate = &dueDate
+}
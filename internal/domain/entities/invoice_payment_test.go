@@ -0,0 +1,178 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSentInvoice returns a sent invoice of 1000 with nothing paid on it
+func newSentInvoice(t *testing.T) *Invoice {
+	tenantID := uuid.New()
+	sale, err := NewSale(tenantID, "S-001", "Jane", "", "", uuid.New())
+	require.NoError(t, err)
+	item, err := NewSaleItem(sale.ID, uuid.New(), "DESK-01", "Desk", 1, decimal.NewFromInt(1000))
+	require.NoError(t, err)
+	require.NoError(t, sale.AddItem(item))
+	sale.Status = SaleStatusCompleted
+
+	invoice, err := NewInvoice(tenantID, "INV-001", sale, uuid.New())
+	require.NoError(t, err)
+	invoice.PaidAmount = decimal.Zero
+	require.NoError(t, invoice.MarkAsGenerated())
+	require.NoError(t, invoice.MarkAsSent())
+	return invoice
+}
+
+func TestInvoice_RecordPayment(t *testing.T) {
+	paidAt := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	t.Run("partial then full payment", func(t *testing.T) {
+		invoice := newSentInvoice(t)
+
+		payment, err := invoice.RecordPayment(decimal.NewFromInt(400), PaymentMethodBankTransfer, " TXN-1 ", paidAt, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, invoice.ID, payment.InvoiceID)
+		assert.Equal(t, invoice.TenantID, payment.TenantID)
+		assert.Equal(t, "TXN-1", payment.Reference)
+		assert.Equal(t, InvoiceStatusPartiallyPaid, invoice.Status)
+		assert.True(t, decimal.NewFromInt(600).Equal(invoice.OutstandingAmount()))
+		assert.Nil(t, invoice.PaidAt)
+
+		_, err = invoice.RecordPayment(decimal.NewFromInt(600), PaymentMethodCash, "", paidAt, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, InvoiceStatusPaid, invoice.Status)
+		assert.True(t, invoice.OutstandingAmount().IsZero())
+		assert.Equal(t, paidAt, *invoice.PaidAt)
+	})
+
+	t.Run("refuses more than outstanding", func(t *testing.T) {
+		invoice := newSentInvoice(t)
+
+		_, err := invoice.RecordPayment(decimal.NewFromFloat(1000.01), PaymentMethodCash, "", paidAt, userID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds outstanding amount")
+		assert.Equal(t, InvoiceStatusSent, invoice.Status)
+	})
+
+	tests := []struct {
+		name        string
+		prepare     func(invoice *Invoice)
+		amount      decimal.Decimal
+		method      PaymentMethod
+		errContains string
+	}{
+		{"zero amount", nil, decimal.Zero, PaymentMethodCash, "invalid payment amount"},
+		{"fractional cents", nil, decimal.NewFromFloat(10.005), PaymentMethodCash, "invalid payment amount"},
+		{"invalid method", nil, decimal.NewFromInt(10), "cheque", "invalid payment method"},
+		{"draft invoice", func(invoice *Invoice) { invoice.Status = InvoiceStatusDraft }, decimal.NewFromInt(10), PaymentMethodCash, "invalid invoice status"},
+		{"cancelled invoice", func(invoice *Invoice) { require.NoError(t, invoice.Cancel()) }, decimal.NewFromInt(10), PaymentMethodCash, "invalid invoice status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := newSentInvoice(t)
+			if tt.prepare != nil {
+				tt.prepare(invoice)
+			}
+
+			payment, err := invoice.RecordPayment(tt.amount, tt.method, "", paidAt, userID)
+
+			assert.Error(t, err)
+			assert.Nil(t, payment)
+			assert.Contains(t, err.Error(), tt.errContains)
+			assert.True(t, invoice.PaidAmount.IsZero())
+		})
+	}
+
+	t.Run("partially paid invoices cannot be cancelled", func(t *testing.T) {
+		invoice := newSentInvoice(t)
+		_, err := invoice.RecordPayment(decimal.NewFromInt(100), PaymentMethodCash, "", paidAt, userID)
+		require.NoError(t, err)
+
+		err = invoice.Cancel()
+
+		assert.Error(t, err)
+		assert.Equal(t, InvoiceStatusPartiallyPaid, invoice.Status)
+	})
+}
+
+func TestInvoice_SetPaymentSchedule(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("due date follows the first uncovered installment", func(t *testing.T) {
+		invoice := newSentInvoice(t)
+		first := invoice.CreatedAt.AddDate(0, 1, 0)
+		second := invoice.CreatedAt.AddDate(0, 2, 0)
+
+		require.NoError(t, invoice.SetPaymentSchedule([]InvoiceInstallment{
+			{DueDate: first, Amount: decimal.NewFromInt(300)},
+			{DueDate: second, Amount: decimal.NewFromInt(700)},
+		}))
+		assert.Equal(t, first, *invoice.DueDate)
+
+		_, err := invoice.RecordPayment(decimal.NewFromInt(200), PaymentMethodCash, "", time.Now(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, first, *invoice.DueDate)
+
+		_, err = invoice.RecordPayment(decimal.NewFromInt(100), PaymentMethodCash, "", time.Now(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, second, *invoice.DueDate)
+	})
+
+	t.Run("empty schedule keeps the due date", func(t *testing.T) {
+		invoice := newSentInvoice(t)
+		dueDate := invoice.CreatedAt.AddDate(0, 0, 30)
+		require.NoError(t, invoice.SetDueDate(dueDate))
+
+		require.NoError(t, invoice.SetPaymentSchedule(nil))
+
+		assert.Nil(t, invoice.PaymentSchedule)
+		assert.Equal(t, dueDate, *invoice.DueDate)
+	})
+
+	tests := []struct {
+		name         string
+		installments func(createdAt time.Time) []InvoiceInstallment
+		errContains  string
+	}{
+		{"not adding up to the total", func(createdAt time.Time) []InvoiceInstallment {
+			return []InvoiceInstallment{{DueDate: createdAt.AddDate(0, 1, 0), Amount: decimal.NewFromInt(900)}}
+		}, "invalid payment schedule"},
+		{"out of order", func(createdAt time.Time) []InvoiceInstallment {
+			return []InvoiceInstallment{
+				{DueDate: createdAt.AddDate(0, 2, 0), Amount: decimal.NewFromInt(500)},
+				{DueDate: createdAt.AddDate(0, 1, 0), Amount: decimal.NewFromInt(500)},
+			}
+		}, "invalid installment due date"},
+		{"due before the invoice", func(createdAt time.Time) []InvoiceInstallment {
+			return []InvoiceInstallment{{DueDate: createdAt.AddDate(0, 0, -1), Amount: decimal.NewFromInt(1000)}}
+		}, "invalid installment due date"},
+		{"zero installment", func(createdAt time.Time) []InvoiceInstallment {
+			return []InvoiceInstallment{
+				{DueDate: createdAt.AddDate(0, 1, 0), Amount: decimal.NewFromInt(1000)},
+				{DueDate: createdAt.AddDate(0, 2, 0), Amount: decimal.Zero},
+			}
+		}, "invalid installment amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := newSentInvoice(t)
+
+			err := invoice.SetPaymentSchedule(tt.installments(invoice.CreatedAt))
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+			assert.Nil(t, invoice.PaymentSchedule)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// InvoiceReminderKind represents which payment reminder was sent for an invoice
+type InvoiceReminderKind string
+
+const (
+	InvoiceReminderKindDueSoon InvoiceReminderKind = "due_soon"
+	InvoiceReminderKindOverdue InvoiceReminderKind = "overdue"
+)
+
+// InvoiceReminderSchedule decides when payment reminders are sent for unpaid invoices
+type InvoiceReminderSchedule struct {
+	DaysBeforeDue     int // Reminder sent this many days before the due date, 0 to skip it
+	OverdueEveryDays  int // Days between overdue notices
+	MaxOverdueNotices int // Overdue notices sent per invoice, 0 to send none
+}
+
+// Window returns the range of due dates of invoices that may need a reminder at the given time
+func (s InvoiceReminderSchedule) Window(now time.Time) (from, to time.Time) {
+	day := 24 * time.Hour
+	from = now.Add(-time.Duration(s.MaxOverdueNotices*s.OverdueEveryDays) * day)
+	to = now.Add(time.Duration(s.DaysBeforeDue) * day)
+	return from, to
+}
+
+// DueReminder returns the reminder an invoice should have received by the given time,
+// identified by its kind and sequence, or false if it needs none. An invoice gets one
+// reminder before its due date and overdue notices every OverdueEveryDays after it.
+// Reminders missed while the server was down are not caught up on; only the latest is sent.
+func (s InvoiceReminderSchedule) DueReminder(invoice *Invoice, now time.Time) (InvoiceReminderKind, int, bool) {
+	if invoice.DueDate == nil || invoice.CustomerEmail == "" {
+		return "", 0, false
+	}
+	if invoice.IsDraft() || invoice.IsPaid() || invoice.IsCancelled() {
+		return "", 0, false
+	}
+
+	day := 24 * time.Hour
+	due := *invoice.DueDate
+	if now.Before(due) {
+		if s.DaysBeforeDue > 0 && !now.Before(due.Add(-time.Duration(s.DaysBeforeDue)*day)) {
+			return InvoiceReminderKindDueSoon, 1, true
+		}
+		return "", 0, false
+	}
+
+	sequence := int(now.Sub(due)/(time.Duration(s.OverdueEveryDays)*day)) + 1
+	if sequence > s.MaxOverdueNotices {
+		return "", 0, false
+	}
+	return InvoiceReminderKindOverdue, sequence, true
+}
+
+// InvoiceReminder records a payment reminder sent for an invoice. An invoice gets each
+// reminder once per due date, so changing the due date restarts its reminders.
+type InvoiceReminder struct {
+	ID        uuid.UUID           `json:"id"`
+	TenantID  uuid.UUID           `json:"tenant_id"`
+	InvoiceID uuid.UUID           `json:"invoice_id"`
+	Kind      InvoiceReminderKind `json:"kind"`
+	Sequence  int                 `json:"sequence"` // 1 for the first overdue notice, 2 for the next, and so on
+	DueDate   time.Time           `json:"due_date"`
+	Recipient string              `json:"recipient"`
+	SentAt    time.Time           `json:"sent_at"`
+}
+
+// NewInvoiceReminder creates the record of a reminder about to be sent for an invoice
+func NewInvoiceReminder(invoice *Invoice, kind InvoiceReminderKind, sequence int) (*InvoiceReminder, error) {
+	if invoice.DueDate == nil {
+		return nil, errors.NewValidationError("due date is required", "reminders are only sent for invoices with a due date")
+	}
+	if kind != InvoiceReminderKindDueSoon && kind != InvoiceReminderKindOverdue {
+		return nil, errors.NewValidationError("invalid reminder kind", "reminder kind must be due_soon or overdue")
+	}
+	if sequence < 1 {
+		return nil, errors.NewValidationError("invalid reminder sequence", "reminder sequence must be at least 1")
+	}
+
+	return &InvoiceReminder{
+		ID:        uuid.New(),
+		TenantID:  invoice.TenantID,
+		InvoiceID: invoice.ID,
+		Kind:      kind,
+		Sequence:  sequence,
+		DueDate:   *invoice.DueDate,
+		Recipient: NormalizeEmail(invoice.CustomerEmail),
+		SentAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceReminderSchedule_DueReminder(t *testing.T) {
+	schedule := InvoiceReminderSchedule{DaysBeforeDue: 3, OverdueEveryDays: 7, MaxOverdueNotices: 2}
+	due := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	newInvoice := func() *Invoice {
+		return &Invoice{
+			ID:            uuid.New(),
+			TenantID:      uuid.New(),
+			CustomerEmail: "customer@example.com",
+			Status:        InvoiceStatusSent,
+			DueDate:       &due,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		kind     InvoiceReminderKind
+		sequence int
+		ok       bool
+	}{
+		{"well before due date", due.Add(-4 * day), "", 0, false},
+		{"within days before due", due.Add(-3 * day), InvoiceReminderKindDueSoon, 1, true},
+		{"on due date", due, InvoiceReminderKindOverdue, 1, true},
+		{"first week overdue", due.Add(6 * day), InvoiceReminderKindOverdue, 1, true},
+		{"second week overdue", due.Add(7 * day), InvoiceReminderKindOverdue, 2, true},
+		{"past last notice", due.Add(14 * day), "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, sequence, ok := schedule.DueReminder(newInvoice(), tt.now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.kind, kind)
+			assert.Equal(t, tt.sequence, sequence)
+		})
+	}
+
+	t.Run("paid, draft and cancelled invoices get no reminders", func(t *testing.T) {
+		for _, status := range []InvoiceStatus{InvoiceStatusPaid, InvoiceStatusDraft, InvoiceStatusCancelled} {
+			invoice := newInvoice()
+			invoice.Status = status
+			_, _, ok := schedule.DueReminder(invoice, due.Add(day))
+			assert.False(t, ok, status)
+		}
+	})
+
+	t.Run("invoices without email or due date get no reminders", func(t *testing.T) {
+		invoice := newInvoice()
+		invoice.CustomerEmail = ""
+		_, _, ok := schedule.DueReminder(invoice, due.Add(day))
+		assert.False(t, ok)
+
+		invoice = newInvoice()
+		invoice.DueDate = nil
+		_, _, ok = schedule.DueReminder(invoice, due.Add(day))
+		assert.False(t, ok)
+	})
+
+	t.Run("no reminder before due when disabled", func(t *testing.T) {
+		noDueSoon := InvoiceReminderSchedule{DaysBeforeDue: 0, OverdueEveryDays: 7, MaxOverdueNotices: 2}
+		_, _, ok := noDueSoon.DueReminder(newInvoice(), due.Add(-time.Hour))
+		assert.False(t, ok)
+	})
+}
+
+func TestInvoiceReminderSchedule_Window(t *testing.T) {
+	schedule := InvoiceReminderSchedule{DaysBeforeDue: 3, OverdueEveryDays: 7, MaxOverdueNotices: 2}
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	from, to := schedule.Window(now)
+	assert.Equal(t, now.Add(-14*24*time.Hour), from)
+	assert.Equal(t, now.Add(3*24*time.Hour), to)
+}
+
+func TestNewInvoiceReminder(t *testing.T) {
+	due := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	invoice := &Invoice{ID: uuid.New(), TenantID: uuid.New(), CustomerEmail: " Customer@Example.com ", DueDate: &due}
+
+	reminder, err := NewInvoiceReminder(invoice, InvoiceReminderKindOverdue, 2)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.ID, reminder.InvoiceID)
+	assert.Equal(t, invoice.TenantID, reminder.TenantID)
+	assert.Equal(t, due, reminder.DueDate)
+	assert.Equal(t, "customer@example.com", reminder.Recipient)
+
+	_, err = NewInvoiceReminder(invoice, "weekly", 1)
+	assert.Error(t, err)
+
+	_, err = NewInvoiceReminder(invoice, InvoiceReminderKindDueSoon, 0)
+	assert.Error(t, err)
+
+	invoice.DueDate = nil
+	_, err = NewInvoiceReminder(invoice, InvoiceReminderKindDueSoon, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// invoiceShareLinkTokenPrefix makes share link tokens recognisable in logs and secret scanners
+	invoiceShareLinkTokenPrefix = "isl_"
+
+	// DefaultInvoiceShareLinkExpiry is how long a share link stays valid when no expiry is given
+	DefaultInvoiceShareLinkExpiry = 30 * 24 * time.Hour
+
+	// MaxInvoiceShareLinkExpiry is the furthest ahead a share link may expire
+	MaxInvoiceShareLinkExpiry = 366 * 24 * time.Hour
+)
+
+// InvoiceShareLink grants anyone holding its URL a read-only view of an invoice, its PDF and,
+// when a payment gateway is set, a way to pay it online. The token is part of the URL and only
+// its hash is stored.
+type InvoiceShareLink struct {
+	ID              uuid.UUID              `json:"id"`
+	TenantID        uuid.UUID              `json:"tenant_id"`
+	InvoiceID       uuid.UUID              `json:"invoice_id"`
+	TokenHash       string                 `json:"-"`
+	PaymentProvider PaymentGatewayProvider `json:"payment_provider,omitempty"` // Empty when the link cannot be paid through
+	ExpiresAt       time.Time              `json:"expires_at"`
+	RevokedAt       *time.Time             `json:"revoked_at,omitempty"`
+	ViewCount       int                    `json:"view_count"`
+	LastViewedAt    *time.Time             `json:"last_viewed_at,omitempty"`
+	CreatedBy       uuid.UUID              `json:"created_by"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// NewInvoiceShareLink creates a share link expiring at expiresAt and returns it with its plain
+// token. The token is only available at creation time.
+func NewInvoiceShareLink(tenantID, invoiceID uuid.UUID, provider PaymentGatewayProvider, expiresAt time.Time, createdBy uuid.UUID) (*InvoiceShareLink, string, error) {
+	if tenantID == uuid.Nil {
+		return nil, "", errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if invoiceID == uuid.Nil {
+		return nil, "", errors.NewValidationError("invoice ID is required", "invoice ID cannot be empty")
+	}
+	if provider != "" && !provider.IsValid() {
+		return nil, "", errors.NewValidationError("invalid payment gateway", "payment_provider must be one of: stripe, midtrans")
+	}
+
+	now := time.Now()
+	if err := validateInvoiceShareLinkExpiry(expiresAt, now); err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.NewInternalError("failed to generate share link token", err)
+	}
+	token := invoiceShareLinkTokenPrefix + hex.EncodeToString(secret)
+
+	link := &InvoiceShareLink{
+		ID:              uuid.New(),
+		TenantID:        tenantID,
+		InvoiceID:       invoiceID,
+		TokenHash:       HashInvoiceShareLinkToken(token),
+		PaymentProvider: provider,
+		ExpiresAt:       expiresAt,
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	return link, token, nil
+}
+
+// IsActive reports whether the link can be used at the given time
+func (l *InvoiceShareLink) IsActive(at time.Time) bool {
+	return l.RevokedAt == nil && at.Before(l.ExpiresAt)
+}
+
+// SetExpiry moves the link's expiry, extending or shortening it. Revoked links stay revoked.
+func (l *InvoiceShareLink) SetExpiry(expiresAt time.Time) error {
+	if l.RevokedAt != nil {
+		return errors.NewValidationError("share link revoked", "a revoked share link cannot be extended")
+	}
+
+	now := time.Now()
+	if err := validateInvoiceShareLinkExpiry(expiresAt, now); err != nil {
+		return err
+	}
+
+	l.ExpiresAt = expiresAt
+	l.UpdatedAt = now
+	return nil
+}
+
+// Revoke permanently disables the link
+func (l *InvoiceShareLink) Revoke() error {
+	if l.RevokedAt != nil {
+		return errors.NewValidationError("share link already revoked", "share link is no longer active")
+	}
+
+	now := time.Now()
+	l.RevokedAt = &now
+	l.UpdatedAt = now
+	return nil
+}
+
+// RecordView counts a view of the invoice through the link
+func (l *InvoiceShareLink) RecordView(at time.Time) {
+	l.ViewCount++
+	l.LastViewedAt = &at
+}
+
+// validateInvoiceShareLinkExpiry checks an expiry is in the future but not too far ahead
+func validateInvoiceShareLinkExpiry(expiresAt, now time.Time) error {
+	if !expiresAt.After(now) {
+		return errors.NewValidationError("invalid expiry", "expires_at must be in the future")
+	}
+	if expiresAt.Sub(now) > MaxInvoiceShareLinkExpiry {
+		return errors.NewValidationError("invalid expiry", "expires_at cannot be more than a year ahead")
+	}
+	return nil
+}
+
+// HashInvoiceShareLinkToken returns the stored representation of a share link token
+func HashInvoiceShareLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvoiceShareLink(t *testing.T) {
+	t.Run("valid link creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		invoiceID := uuid.New()
+		expiresAt := time.Now().Add(DefaultInvoiceShareLinkExpiry)
+
+		link, token, err := NewInvoiceShareLink(tenantID, invoiceID, PaymentGatewayMidtrans, expiresAt, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, link.TenantID)
+		assert.Equal(t, invoiceID, link.InvoiceID)
+		assert.Equal(t, PaymentGatewayMidtrans, link.PaymentProvider)
+		assert.True(t, strings.HasPrefix(token, invoiceShareLinkTokenPrefix))
+		assert.Equal(t, HashInvoiceShareLinkToken(token), link.TokenHash)
+		assert.True(t, link.IsActive(time.Now()))
+		assert.False(t, link.IsActive(expiresAt))
+	})
+
+	t.Run("without payment", func(t *testing.T) {
+		link, _, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "", time.Now().Add(time.Hour), uuid.New())
+
+		require.NoError(t, err)
+		assert.Empty(t, link.PaymentProvider)
+	})
+
+	t.Run("invalid payment gateway", func(t *testing.T) {
+		link, token, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "paypal", time.Now().Add(time.Hour), uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, link)
+		assert.Empty(t, token)
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		_, _, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "", time.Now().Add(-time.Minute), uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("expiry too far ahead", func(t *testing.T) {
+		_, _, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "", time.Now().Add(MaxInvoiceShareLinkExpiry+time.Hour), uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestInvoiceShareLink_SetExpiry(t *testing.T) {
+	link, _, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "", time.Now().Add(time.Hour), uuid.New())
+	require.NoError(t, err)
+
+	extended := time.Now().Add(48 * time.Hour)
+	require.NoError(t, link.SetExpiry(extended))
+	assert.Equal(t, extended, link.ExpiresAt)
+
+	assert.Error(t, link.SetExpiry(time.Now().Add(-time.Hour)))
+
+	require.NoError(t, link.Revoke())
+	assert.Error(t, link.SetExpiry(time.Now().Add(time.Hour)))
+}
+
+func TestInvoiceShareLink_Revoke(t *testing.T) {
+	link, _, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "", time.Now().Add(time.Hour), uuid.New())
+	require.NoError(t, err)
+
+	require.NoError(t, link.Revoke())
+	assert.NotNil(t, link.RevokedAt)
+	assert.False(t, link.IsActive(time.Now()))
+
+	assert.Error(t, link.Revoke())
+}
+
+func TestInvoiceShareLink_RecordView(t *testing.T) {
+	link, _, err := NewInvoiceShareLink(uuid.New(), uuid.New(), "", time.Now().Add(time.Hour), uuid.New())
+	require.NoError(t, err)
+
+	viewedAt := time.Now()
+	link.RecordView(viewedAt)
+	link.RecordView(viewedAt)
+
+	assert.Equal(t, 2, link.ViewCount)
+	assert.Equal(t, viewedAt, *link.LastViewedAt)
+}
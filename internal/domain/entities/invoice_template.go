@@ -0,0 +1,270 @@
+package entities
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// InvoiceColumn represents an optional column of the item table on an invoice. The product
+// name and line total are always shown.
+type InvoiceColumn string
+
+const (
+	InvoiceColumnSKU         InvoiceColumn = "sku"
+	InvoiceColumnDescription InvoiceColumn = "description"
+	InvoiceColumnQuantity    InvoiceColumn = "quantity"
+	InvoiceColumnUnitPrice   InvoiceColumn = "unit_price"
+	InvoiceColumnTaxRate     InvoiceColumn = "tax_rate"
+)
+
+const (
+	// MaxInvoiceLogoSize is the largest logo accepted on an invoice template, in bytes
+	MaxInvoiceLogoSize = 256 * 1024
+
+	// MaxInvoiceTemplateCustomFields caps the custom fields of an invoice template
+	MaxInvoiceTemplateCustomFields = 10
+
+	// maxInvoiceTemplateTextLength caps the header and footer of an invoice template
+	maxInvoiceTemplateTextLength = 500
+)
+
+// accentColorPattern matches a hex color such as "#2E86AB"
+var accentColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// InvoiceTemplateField is a label and value printed with the invoice details, such as a bank
+// account or a business registration number
+type InvoiceTemplateField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// ValidateDesign checks the parts of the template tenants design: the logo, header and footer,
+// accent color, hidden columns and custom fields
+func (t *InvoiceTemplate) ValidateDesign() error {
+	if len(t.Logo) > 0 {
+		if err := validateInvoiceLogo(t.Logo); err != nil {
+			return err
+		}
+	}
+	if len(t.Header) > maxInvoiceTemplateTextLength || len(t.Footer) > maxInvoiceTemplateTextLength {
+		return errors.NewValidationError("template text too long", "header and footer cannot exceed 500 characters")
+	}
+	if t.AccentColor != "" && !accentColorPattern.MatchString(t.AccentColor) {
+		return errors.NewValidationError("invalid accent color", "accent_color must be a hex color such as #2E86AB")
+	}
+	for _, column := range t.HiddenColumns {
+		if err := ValidateInvoiceColumn(column); err != nil {
+			return err
+		}
+	}
+
+	if len(t.CustomFields) > MaxInvoiceTemplateCustomFields {
+		return errors.NewValidationError("too many custom fields", "a template can have at most 10 custom fields")
+	}
+	for _, field := range t.CustomFields {
+		if strings.TrimSpace(field.Label) == "" {
+			return errors.NewValidationError("custom field label is required", "custom field labels cannot be empty")
+		}
+		if len(field.Label) > 50 || len(field.Value) > 200 {
+			return errors.NewValidationError("custom field too long", "custom field labels cannot exceed 50 characters and values 200")
+		}
+	}
+
+	return nil
+}
+
+// ShowsColumn reports whether the item table includes a column
+func (t *InvoiceTemplate) ShowsColumn(column InvoiceColumn) bool {
+	for _, hidden := range t.HiddenColumns {
+		if hidden == column {
+			return false
+		}
+	}
+	return true
+}
+
+// AccentRGB returns the red, green and blue components of the accent color, black when the
+// template has none
+func (t *InvoiceTemplate) AccentRGB() (r, g, b uint8) {
+	if !accentColorPattern.MatchString(t.AccentColor) {
+		return 0, 0, 0
+	}
+	rgb, _ := strconv.ParseUint(t.AccentColor[1:], 16, 32)
+	return uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb)
+}
+
+// CustomInvoiceTemplate is an invoice template a tenant designed and stored under a name.
+// The tenant's default template is used for invoices that do not name one. The logo is
+// stored apart from the rest of the design.
+type CustomInvoiceTemplate struct {
+	ID              uuid.UUID       `json:"id"`
+	TenantID        uuid.UUID       `json:"tenant_id"`
+	Name            string          `json:"name"`
+	Template        InvoiceTemplate `json:"template"`
+	Logo            []byte          `json:"-"`
+	LogoContentType string          `json:"logo_content_type,omitempty"` // Empty without a logo
+	IsDefault       bool            `json:"is_default"`
+	CreatedBy       uuid.UUID       `json:"created_by"`
+	UpdatedBy       uuid.UUID       `json:"updated_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// NewCustomInvoiceTemplate creates a named template from a design. A logo in the design is
+// stored as the template's logo.
+func NewCustomInvoiceTemplate(tenantID uuid.UUID, name string, design InvoiceTemplate, createdBy uuid.UUID) (*CustomInvoiceTemplate, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	template := &CustomInvoiceTemplate{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+
+	if err := template.Update(name, design, createdBy); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Update replaces the template's name and design. A logo in the design replaces the stored
+// logo; without one the stored logo is kept.
+func (t *CustomInvoiceTemplate) Update(name string, design InvoiceTemplate, updatedBy uuid.UUID) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("template name is required", "name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.NewValidationError("template name too long", "name cannot exceed 100 characters")
+	}
+	if err := ValidatePaperSize(design.PaperSize); err != nil {
+		return err
+	}
+	if err := design.ValidateDesign(); err != nil {
+		return err
+	}
+
+	if len(design.Logo) > 0 {
+		t.Logo = design.Logo
+		t.LogoContentType = detectImageType(design.Logo)
+		design.Logo = nil
+	}
+
+	t.Name = name
+	t.Template = design
+	t.UpdatedBy = updatedBy
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetLogo replaces the template's logo with a PNG or JPEG image and shows it
+func (t *CustomInvoiceTemplate) SetLogo(data []byte, updatedBy uuid.UUID) error {
+	if err := validateInvoiceLogo(data); err != nil {
+		return err
+	}
+
+	t.Logo = data
+	t.LogoContentType = detectImageType(data)
+	t.Template.ShowLogo = true
+	t.UpdatedBy = updatedBy
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveLogo deletes the template's logo
+func (t *CustomInvoiceTemplate) RemoveLogo(updatedBy uuid.UUID) error {
+	if len(t.Logo) == 0 {
+		return errors.NewValidationError("template has no logo", "there is no logo to remove")
+	}
+
+	t.Logo = nil
+	t.LogoContentType = ""
+	t.Template.ShowLogo = false
+	t.UpdatedBy = updatedBy
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Design returns the template to render invoices with, its logo attached
+func (t *CustomInvoiceTemplate) Design() *InvoiceTemplate {
+	design := t.Template
+	design.Logo = t.Logo
+	return &design
+}
+
+// ValidateInvoiceColumn validates an optional invoice column
+func ValidateInvoiceColumn(column InvoiceColumn) error {
+	switch column {
+	case InvoiceColumnSKU, InvoiceColumnDescription, InvoiceColumnQuantity, InvoiceColumnUnitPrice, InvoiceColumnTaxRate:
+		return nil
+	default:
+		return errors.NewValidationError("invalid invoice column", "hidden columns must be one of: sku, description, quantity, unit_price, tax_rate")
+	}
+}
+
+// validateInvoiceLogo checks a logo is a PNG or JPEG image within size
+func validateInvoiceLogo(data []byte) error {
+	if len(data) == 0 {
+		return errors.NewValidationError("logo is required", "logo cannot be empty")
+	}
+	if len(data) > MaxInvoiceLogoSize {
+		return errors.NewValidationError("logo too large", "logo cannot exceed 256 KB")
+	}
+	if detectImageType(data) == "" {
+		return errors.NewValidationError("invalid logo", "logo must be a PNG or JPEG")
+	}
+	return nil
+}
+
+// SampleInvoice returns a made-up invoice, used to preview email and invoice templates
+func SampleInvoice() *Invoice {
+	issued := time.Date(2026, time.January, 15, 10, 0, 0, 0, time.UTC)
+	due := issued.AddDate(0, 0, 14)
+
+	return &Invoice{
+		InvoiceNumber:   "INV-20260115-0001",
+		CustomerName:    "Jane Doe",
+		CustomerEmail:   "jane.doe@example.com",
+		CustomerAddress: "42 Market Street, Springfield",
+		Subtotal:        decimal.RequireFromString("150.00"),
+		TaxAmount:       decimal.RequireFromString("16.50"),
+		SurchargeAmount: decimal.RequireFromString("3.00"),
+		SurchargeLabel:  "Card surcharge",
+		TotalAmount:     decimal.RequireFromString("169.50"),
+		PaymentMethod:   PaymentMethodCard,
+		Status:          InvoiceStatusSent,
+		DueDate:         &due,
+		CreatedAt:       issued,
+		Items: []InvoiceItem{
+			{
+				ProductSKU:  "COF-ARA-250",
+				ProductName: "Arabica Coffee Beans 250g",
+				Description: "Medium roast, whole beans",
+				Quantity:    2,
+				UnitPrice:   decimal.RequireFromString("45.00"),
+				TotalPrice:  decimal.RequireFromString("90.00"),
+				TaxRate:     decimal.RequireFromString("11"),
+			},
+			{
+				ProductSKU:  "MUG-CER-01",
+				ProductName: "Ceramic Mug",
+				Quantity:    1,
+				UnitPrice:   decimal.RequireFromString("60.00"),
+				TotalPrice:  decimal.RequireFromString("60.00"),
+				TaxRate:     decimal.RequireFromString("11"),
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samplePNG is the signature of a PNG image, enough for the logo type check
+var samplePNG = []byte("\x89PNG\r\n\x1a\nsample")
+
+func TestInvoiceTemplate_ValidateDesign(t *testing.T) {
+	t.Run("valid design", func(t *testing.T) {
+		template := &InvoiceTemplate{
+			PaperSize:     PaperSizeA4,
+			Logo:          samplePNG,
+			Header:        "Tax invoice",
+			AccentColor:   "#2E86AB",
+			HiddenColumns: []InvoiceColumn{InvoiceColumnSKU, InvoiceColumnTaxRate},
+			CustomFields:  []InvoiceTemplateField{{Label: "Bank account", Value: "BCA 123-456-789"}},
+		}
+
+		assert.NoError(t, template.ValidateDesign())
+		assert.False(t, template.ShowsColumn(InvoiceColumnSKU))
+		assert.True(t, template.ShowsColumn(InvoiceColumnQuantity))
+	})
+
+	t.Run("invalid accent color", func(t *testing.T) {
+		template := &InvoiceTemplate{AccentColor: "blue"}
+
+		assert.Error(t, template.ValidateDesign())
+	})
+
+	t.Run("invalid column", func(t *testing.T) {
+		template := &InvoiceTemplate{HiddenColumns: []InvoiceColumn{"product_name"}}
+
+		assert.Error(t, template.ValidateDesign())
+	})
+
+	t.Run("custom field without label", func(t *testing.T) {
+		template := &InvoiceTemplate{CustomFields: []InvoiceTemplateField{{Value: "123"}}}
+
+		assert.Error(t, template.ValidateDesign())
+	})
+
+	t.Run("too many custom fields", func(t *testing.T) {
+		template := &InvoiceTemplate{CustomFields: make([]InvoiceTemplateField, MaxInvoiceTemplateCustomFields+1)}
+		for i := range template.CustomFields {
+			template.CustomFields[i] = InvoiceTemplateField{Label: "Field"}
+		}
+
+		assert.Error(t, template.ValidateDesign())
+	})
+
+	t.Run("logo not an image", func(t *testing.T) {
+		template := &InvoiceTemplate{Logo: []byte("GIF89a")}
+
+		assert.Error(t, template.ValidateDesign())
+	})
+}
+
+func TestInvoiceTemplate_AccentRGB(t *testing.T) {
+	r, g, b := (&InvoiceTemplate{AccentColor: "#2E86AB"}).AccentRGB()
+	assert.Equal(t, []uint8{0x2E, 0x86, 0xAB}, []uint8{r, g, b})
+
+	r, g, b = (&InvoiceTemplate{}).AccentRGB()
+	assert.Equal(t, []uint8{0, 0, 0}, []uint8{r, g, b})
+}
+
+func TestNewCustomInvoiceTemplate(t *testing.T) {
+	t.Run("valid template", func(t *testing.T) {
+		tenantID := uuid.New()
+		design := InvoiceTemplate{PaperSize: PaperSizeA5, Logo: samplePNG, ShowLogo: true, Currency: "IDR"}
+
+		template, err := NewCustomInvoiceTemplate(tenantID, "  Wholesale  ", design, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, template.TenantID)
+		assert.Equal(t, "Wholesale", template.Name)
+		assert.Nil(t, template.Template.Logo)
+		assert.Equal(t, samplePNG, template.Logo)
+		assert.Equal(t, SignatureImagePNG, template.LogoContentType)
+		assert.Equal(t, samplePNG, template.Design().Logo)
+		assert.Equal(t, PaperSizeA5, template.Design().PaperSize)
+	})
+
+	t.Run("name required", func(t *testing.T) {
+		_, err := NewCustomInvoiceTemplate(uuid.New(), " ", InvoiceTemplate{PaperSize: PaperSizeA4}, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid paper size", func(t *testing.T) {
+		_, err := NewCustomInvoiceTemplate(uuid.New(), "Retail", InvoiceTemplate{PaperSize: "b5"}, uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCustomInvoiceTemplate_Logo(t *testing.T) {
+	template, err := NewCustomInvoiceTemplate(uuid.New(), "Retail", InvoiceTemplate{PaperSize: PaperSizeA4}, uuid.New())
+	require.NoError(t, err)
+
+	assert.Error(t, template.RemoveLogo(uuid.New()))
+	assert.Error(t, template.SetLogo([]byte("not an image"), uuid.New()))
+
+	require.NoError(t, template.SetLogo(samplePNG, uuid.New()))
+	assert.True(t, template.Template.ShowLogo)
+
+	// Updating the design without a logo keeps the stored one
+	require.NoError(t, template.Update("Retail", InvoiceTemplate{PaperSize: PaperSizeA4, ShowLogo: true}, uuid.New()))
+	assert.Equal(t, samplePNG, template.Logo)
+
+	require.NoError(t, template.RemoveLogo(uuid.New()))
+	assert.Empty(t, template.LogoContentType)
+	assert.False(t, template.Template.ShowLogo)
+}
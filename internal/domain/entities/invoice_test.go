@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +45,18 @@ func TestNewInvoice(t *testing.T) {
 		assert.Nil(t, invoice.PaidAt)
 	})
 
+	t.Run("keeps the exchange rate locked on the sale", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		rate, err := NewExchangeRate("USD", "IDR", decimal.NewFromInt(15500), sale.CreatedAt)
+		require.NoError(t, err)
+		require.NoError(t, sale.LockExchangeRate(rate))
+
+		invoice, err := NewInvoice("INV-001", sale, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, rate, invoice.ExchangeRate)
+	})
+
 	t.Run("invalid invoice - empty invoice number", func(t *testing.T) {
 		sale := createCompletedSale(t)
 		createdBy := uuid.New()
@@ -211,6 +224,37 @@ func TestInvoice_UpdateCustomerInfo(t *testing.T) {
 	})
 }
 
+func TestInvoice_SetCustomerTaxID(t *testing.T) {
+	t.Run("valid tax ID", func(t *testing.T) {
+		invoice := createValidInvoice(t)
+
+		err := invoice.SetCustomerTaxID(" 01.234.567.8-901.000 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "01.234.567.8-901.000", invoice.CustomerTaxID)
+	})
+
+	t.Run("clear tax ID", func(t *testing.T) {
+		invoice := createValidInvoice(t)
+		require.NoError(t, invoice.SetCustomerTaxID("012345678901000"))
+
+		require.NoError(t, invoice.SetCustomerTaxID(""))
+		assert.Empty(t, invoice.CustomerTaxID)
+	})
+
+	t.Run("invalid characters", func(t *testing.T) {
+		invoice := createValidInvoice(t)
+
+		assert.Error(t, invoice.SetCustomerTaxID("0123;DROP"))
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		invoice := createValidInvoice(t)
+
+		assert.Error(t, invoice.SetCustomerTaxID(strings.Repeat("1", 31)))
+	})
+}
+
 func TestInvoice_SetDueDate(t *testing.T) {
 	t.Run("valid due date", func(t *testing.T) {
 		invoice := createValidInvoice(t)
@@ -535,6 +579,7 @@ func TestValidateInvoiceStatus(t *testing.T) {
 		{"valid draft status", InvoiceStatusDraft, false},
 		{"valid generated status", InvoiceStatusGenerated, false},
 		{"valid sent status", InvoiceStatusSent, false},
+		{"valid partially paid status", InvoiceStatusPartiallyPaid, false},
 		{"valid paid status", InvoiceStatusPaid, false},
 		{"valid cancelled status", InvoiceStatusCancelled, false},
 		{"invalid status", "invalid", true},
@@ -606,7 +651,7 @@ func createCompletedSale(t *testing.T) *Sale {
 	require.NoError(t, err)
 
 	// Process payment
-	err = sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash)
+	err = sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash, nil)
 	require.NoError(t, err)
 
 	// Complete sale
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// kioskDeviceTokenPrefix makes device tokens recognisable in logs and secret scanners
+const kioskDeviceTokenPrefix = "kdt_"
+
+// KioskDevice represents a customer-facing device (e.g. an aisle price checker) that
+// authenticates with a long-lived device token instead of a user session
+type KioskDevice struct {
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	Name      string     `json:"name"`
+	TokenHash string     `json:"-"` // SHA-256 of the device token, the token itself is never stored
+	IsActive  bool       `json:"is_active"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+}
+
+// NewKioskDevice registers a new kiosk device and returns it with its plain device token.
+// The token is only available at registration time.
+func NewKioskDevice(tenantID uuid.UUID, name string, createdBy uuid.UUID) (*KioskDevice, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.NewValidationError("device name is required", "name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, "", errors.NewValidationError("device name too long", "name cannot exceed 100 characters")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.NewInternalError("failed to generate device token", err)
+	}
+	token := kioskDeviceTokenPrefix + hex.EncodeToString(secret)
+
+	now := time.Now()
+	device := &KioskDevice{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		TokenHash: HashKioskDeviceToken(token),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+	}
+
+	return device, token, nil
+}
+
+// Revoke permanently disables the device token
+func (d *KioskDevice) Revoke() error {
+	if !d.IsActive {
+		return errors.NewValidationError("device already revoked", "device token is no longer active")
+	}
+
+	now := time.Now()
+	d.IsActive = false
+	d.RevokedAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// HashKioskDeviceToken returns the stored representation of a device token
+func HashKioskDeviceToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
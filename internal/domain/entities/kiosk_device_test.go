@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKioskDevice(t *testing.T) {
+	t.Run("valid device registration", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		device, token, err := NewKioskDevice(tenantID, " Aisle 3 price checker ", createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, device.ID)
+		assert.Equal(t, tenantID, device.TenantID)
+		assert.Equal(t, "Aisle 3 price checker", device.Name)
+		assert.True(t, device.IsActive)
+		assert.Equal(t, createdBy, device.CreatedBy)
+		assert.True(t, strings.HasPrefix(token, kioskDeviceTokenPrefix))
+		assert.Equal(t, HashKioskDeviceToken(token), device.TokenHash)
+		assert.NotContains(t, device.TokenHash, token)
+	})
+
+	t.Run("tokens are unique", func(t *testing.T) {
+		_, first, err := NewKioskDevice(uuid.New(), "Kiosk", uuid.New())
+		require.NoError(t, err)
+		_, second, err := NewKioskDevice(uuid.New(), "Kiosk", uuid.New())
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		device, token, err := NewKioskDevice(uuid.New(), " ", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, device)
+		assert.Empty(t, token)
+	})
+}
+
+func TestKioskDevice_Revoke(t *testing.T) {
+	device, _, err := NewKioskDevice(uuid.New(), "Kiosk", uuid.New())
+	require.NoError(t, err)
+
+	require.NoError(t, device.Revoke())
+	assert.False(t, device.IsActive)
+	assert.NotNil(t, device.RevokedAt)
+
+	assert.Error(t, device.Revoke())
+}
@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// Location represents a place a tenant keeps stock, such as a store or a warehouse
+type Location struct {
+	ID        uuid.UUID `json:"id"`
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Code      string    `json:"code"` // Short unique code, e.g. "WH1"
+	Name      string    `json:"name"`
+	Address   string    `json:"address,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LocationStock represents the quantity of a product held at a location. The product's
+// stock record holds the total across locations; location stock records where it is.
+type LocationStock struct {
+	ID         uuid.UUID `json:"id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	LocationID uuid.UUID `json:"location_id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	Quantity   int       `json:"quantity"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewLocation creates a new active location
+func NewLocation(tenantID uuid.UUID, code, name, address string) (*Location, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, errors.NewValidationError("code is required", "location code cannot be empty")
+	}
+	if len(code) > 20 || strings.ContainsAny(code, " \t") {
+		return nil, errors.NewValidationError("invalid code", "location code must be at most 20 characters without spaces")
+	}
+
+	now := time.Now()
+	location := &Location{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Code:      code,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := location.Update(name, address); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// Update updates the name and address of the location
+func (l *Location) Update(name, address string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("name is required", "location name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.NewValidationError("name too long", "location name cannot exceed 100 characters")
+	}
+
+	l.Name = name
+	l.Address = strings.TrimSpace(address)
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetActive activates or deactivates the location. Inactive locations cannot send or
+// receive stock transfers.
+func (l *Location) SetActive(active bool) {
+	l.IsActive = active
+	l.UpdatedAt = time.Now()
+}
+
+// NewLocationStock creates an empty stock record of a product at a location
+func NewLocationStock(location *Location, productID uuid.UUID) *LocationStock {
+	return &LocationStock{
+		ID:         uuid.New(),
+		TenantID:   location.TenantID,
+		LocationID: location.ID,
+		ProductID:  productID,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// Add increases the quantity held at the location
+func (s *LocationStock) Add(quantity int) error {
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+
+	s.Quantity += quantity
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Remove decreases the quantity held at the location
+func (s *LocationStock) Remove(quantity int, productName string) error {
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+	if s.Quantity < quantity {
+		return errors.NewInsufficientStockError(productName, s.Quantity, quantity)
+	}
+
+	s.Quantity -= quantity
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetQuantity replaces the quantity held at the location, e.g. after counting it
+func (s *LocationStock) SetQuantity(quantity int) error {
+	if quantity < 0 {
+		return errors.NewValidationError("invalid quantity", "quantity cannot be negative")
+	}
+
+	s.Quantity = quantity
+	s.UpdatedAt = time.Now()
+	return nil
+}
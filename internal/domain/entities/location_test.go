@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocation(t *testing.T) {
+	location, err := NewLocation(uuid.New(), " wh-1 ", " Main Warehouse ", " 12 Harbour Road ")
+	require.NoError(t, err)
+
+	assert.Equal(t, "WH-1", location.Code)
+	assert.Equal(t, "Main Warehouse", location.Name)
+	assert.Equal(t, "12 Harbour Road", location.Address)
+	assert.True(t, location.IsActive)
+
+	tests := []struct {
+		name     string
+		tenantID uuid.UUID
+		code     string
+		locName  string
+	}{
+		{"missing tenant", uuid.Nil, "WH-1", "Warehouse"},
+		{"missing code", uuid.New(), " ", "Warehouse"},
+		{"code with spaces", uuid.New(), "WH 1", "Warehouse"},
+		{"code too long", uuid.New(), "WAREHOUSE-NUMBER-ONE-", "Warehouse"},
+		{"missing name", uuid.New(), "WH-1", " "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := NewLocation(tt.tenantID, tt.code, tt.locName, "")
+
+			assert.Error(t, err)
+			assert.Nil(t, location)
+		})
+	}
+}
+
+func TestLocationStock(t *testing.T) {
+	location, err := NewLocation(uuid.New(), "STORE", "Store", "")
+	require.NoError(t, err)
+
+	stock := NewLocationStock(location, uuid.New())
+	assert.Equal(t, location.TenantID, stock.TenantID)
+	assert.Equal(t, location.ID, stock.LocationID)
+	assert.Equal(t, 0, stock.Quantity)
+
+	require.NoError(t, stock.Add(10))
+	require.NoError(t, stock.Remove(4, "Desk"))
+	assert.Equal(t, 6, stock.Quantity)
+
+	assert.Error(t, stock.Remove(7, "Desk"))
+	assert.Error(t, stock.Add(0))
+	assert.Equal(t, 6, stock.Quantity)
+
+	require.NoError(t, stock.SetQuantity(0))
+	assert.Equal(t, 0, stock.Quantity)
+	assert.Error(t, stock.SetQuantity(-1))
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLoginLockoutPolicy() LoginLockoutPolicy {
+	return LoginLockoutPolicy{
+		MaxAttempts:        5,
+		AttemptWindow:      15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		MaxLockoutDuration: 2 * time.Hour,
+	}
+}
+
+func TestLoginLockoutPolicy_LockoutFor(t *testing.T) {
+	policy := testLoginLockoutPolicy()
+
+	assert.Equal(t, 15*time.Minute, policy.LockoutFor(1))
+	assert.Equal(t, 30*time.Minute, policy.LockoutFor(2))
+	assert.Equal(t, time.Hour, policy.LockoutFor(3))
+	assert.Equal(t, 2*time.Hour, policy.LockoutFor(4))
+	assert.Equal(t, 2*time.Hour, policy.LockoutFor(50))
+}
+
+func TestLoginLockout_ShouldLock(t *testing.T) {
+	policy := testLoginLockoutPolicy()
+	lockout := &LoginLockout{UserID: uuid.New(), FailedAttempts: 4}
+
+	assert.False(t, lockout.ShouldLock(policy))
+
+	lockout.FailedAttempts = 5
+	assert.True(t, lockout.ShouldLock(policy))
+
+	policy.MaxAttempts = 0
+	assert.False(t, lockout.ShouldLock(policy))
+}
+
+func TestLoginLockout_Lock(t *testing.T) {
+	policy := testLoginLockoutPolicy()
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	lockout := &LoginLockout{UserID: uuid.New(), FailedAttempts: 5}
+
+	duration := lockout.Lock(policy, now)
+
+	assert.Equal(t, 15*time.Minute, duration)
+	assert.Equal(t, 1, lockout.Lockouts)
+	assert.Equal(t, 0, lockout.FailedAttempts)
+	assert.True(t, lockout.IsLocked(now.Add(14*time.Minute)))
+	assert.False(t, lockout.IsLocked(now.Add(15*time.Minute)))
+
+	t.Run("backs off exponentially", func(t *testing.T) {
+		duration := lockout.Lock(policy, now.Add(20*time.Minute))
+
+		assert.Equal(t, 30*time.Minute, duration)
+		assert.Equal(t, 2, lockout.Lockouts)
+	})
+
+	t.Run("starts over after the longest lockout passed", func(t *testing.T) {
+		require.NotNil(t, lockout.LockedUntil)
+		later := lockout.LockedUntil.Add(3 * time.Hour)
+
+		duration := lockout.Lock(policy, later)
+
+		assert.Equal(t, 15*time.Minute, duration)
+		assert.Equal(t, 1, lockout.Lockouts)
+	})
+}
+
+func TestLoginIPRange(t *testing.T) {
+	assert.Equal(t, "203.0.113.0/24", LoginIPRange("203.0.113.7"))
+	assert.Equal(t, LoginIPRange("203.0.113.7"), LoginIPRange("203.0.113.250"))
+	assert.NotEqual(t, LoginIPRange("203.0.113.7"), LoginIPRange("203.0.114.7"))
+	assert.Equal(t, "2001:db8:1234::/48", LoginIPRange("2001:db8:1234:5678::1"))
+	assert.Empty(t, LoginIPRange("not-an-ip"))
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// managerOverrideTokenPrefix makes override tokens recognisable in logs and secret scanners
+const managerOverrideTokenPrefix = "mot_"
+
+// ManagerOverrideTTL is how long an override token can be used after the manager approves it
+const ManagerOverrideTTL = 2 * time.Minute
+
+// OverrideAction represents a restricted action that needs a manager's approval
+type OverrideAction string
+
+const (
+	OverrideActionPriceOverride   OverrideAction = "price_override"
+	OverrideActionRefund          OverrideAction = "refund"
+	OverrideActionSellUnavailable OverrideAction = "sell_unavailable" // Sell a product outside its availability window
+	OverrideActionLateReturn      OverrideAction = "late_return"      // Refund a sale after its return window, covers the refund itself
+)
+
+// ManagerOverride represents a manager's one-time approval for a restricted action, entered on
+// a cashier's terminal. The approval is bound to the cashier, the action and optionally the
+// resource it applies to, and is consumed by the request that performs the action.
+type ManagerOverride struct {
+	ID          uuid.UUID      `json:"id"`
+	TenantID    uuid.UUID      `json:"tenant_id"`
+	Action      OverrideAction `json:"action"`
+	ResourceID  *uuid.UUID     `json:"resource_id,omitempty"` // Limits the override to one resource, e.g. a sale
+	TokenHash   string         `json:"-"`                     // SHA-256 of the override token, the token itself is never stored
+	ApprovedBy  uuid.UUID      `json:"approved_by"`           // Manager who entered their credentials
+	RequestedBy uuid.UUID      `json:"requested_by"`          // Cashier the override was issued to
+	Reason      string         `json:"reason"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	ConsumedAt  *time.Time     `json:"consumed_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// NewManagerOverride creates a new manager override and returns it with its plain token.
+// The token is only available at creation time.
+func NewManagerOverride(tenantID uuid.UUID, action OverrideAction, resourceID *uuid.UUID, approvedBy, requestedBy uuid.UUID, reason string) (*ManagerOverride, string, error) {
+	if err := ValidateOverrideAction(action); err != nil {
+		return nil, "", err
+	}
+	if approvedBy == requestedBy {
+		return nil, "", errors.NewValidationError("invalid override approver", "managers cannot approve their own override")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, "", errors.NewValidationError("override reason is required", "reason cannot be empty")
+	}
+	if len(reason) > 255 {
+		return nil, "", errors.NewValidationError("override reason too long", "reason cannot exceed 255 characters")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.NewInternalError("failed to generate override token", err)
+	}
+	token := managerOverrideTokenPrefix + hex.EncodeToString(secret)
+
+	now := time.Now()
+	override := &ManagerOverride{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Action:      action,
+		ResourceID:  resourceID,
+		TokenHash:   HashManagerOverrideToken(token),
+		ApprovedBy:  approvedBy,
+		RequestedBy: requestedBy,
+		Reason:      reason,
+		ExpiresAt:   now.Add(ManagerOverrideTTL),
+		CreatedAt:   now,
+	}
+
+	return override, token, nil
+}
+
+// Consume marks the override as used for an action on a resource by a user. It fails when the
+// override was already used, has expired or was issued for a different action, resource or user.
+func (o *ManagerOverride) Consume(action OverrideAction, resourceID, userID uuid.UUID, now time.Time) error {
+	if o.ConsumedAt != nil {
+		return errors.NewForbiddenError("manager override has already been used")
+	}
+	if !now.Before(o.ExpiresAt) {
+		return errors.NewForbiddenError("manager override has expired")
+	}
+	if o.Action != action {
+		return errors.NewForbiddenError("manager override does not cover this action")
+	}
+	if o.ResourceID != nil && *o.ResourceID != resourceID {
+		return errors.NewForbiddenError("manager override does not cover this resource")
+	}
+	if o.RequestedBy != userID {
+		return errors.NewForbiddenError("manager override was issued to another user")
+	}
+
+	o.ConsumedAt = &now
+	return nil
+}
+
+// HashManagerOverrideToken returns the stored representation of an override token
+func HashManagerOverrideToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateOverrideAction validates override action
+func ValidateOverrideAction(action OverrideAction) error {
+	switch action {
+	case OverrideActionPriceOverride, OverrideActionRefund, OverrideActionSellUnavailable, OverrideActionLateReturn:
+		return nil
+	default:
+		return errors.NewValidationError("invalid override action", "action must be one of: price_override, refund, sell_unavailable, late_return")
+	}
+}
@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManagerOverride(t *testing.T) {
+	t.Run("valid override", func(t *testing.T) {
+		tenantID := uuid.New()
+		saleID := uuid.New()
+		managerID := uuid.New()
+		cashierID := uuid.New()
+
+		override, token, err := NewManagerOverride(tenantID, OverrideActionPriceOverride, &saleID, managerID, cashierID, " Damaged packaging ")
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, override.TenantID)
+		assert.Equal(t, OverrideActionPriceOverride, override.Action)
+		assert.Equal(t, &saleID, override.ResourceID)
+		assert.Equal(t, managerID, override.ApprovedBy)
+		assert.Equal(t, cashierID, override.RequestedBy)
+		assert.Equal(t, "Damaged packaging", override.Reason)
+		assert.WithinDuration(t, time.Now().Add(ManagerOverrideTTL), override.ExpiresAt, time.Second)
+		assert.Nil(t, override.ConsumedAt)
+		assert.True(t, strings.HasPrefix(token, managerOverrideTokenPrefix))
+		assert.Equal(t, HashManagerOverrideToken(token), override.TokenHash)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		userID := uuid.New()
+
+		_, _, err := NewManagerOverride(uuid.New(), OverrideAction("void"), nil, uuid.New(), userID, "reason")
+		assert.Error(t, err)
+
+		_, _, err = NewManagerOverride(uuid.New(), OverrideActionRefund, nil, userID, userID, "reason")
+		assert.Error(t, err)
+
+		_, token, err := NewManagerOverride(uuid.New(), OverrideActionRefund, nil, uuid.New(), userID, " ")
+		assert.Error(t, err)
+		assert.Empty(t, token)
+	})
+}
+
+func TestManagerOverride_Consume(t *testing.T) {
+	saleID := uuid.New()
+	cashierID := uuid.New()
+
+	newOverride := func(t *testing.T) *ManagerOverride {
+		override, _, err := NewManagerOverride(uuid.New(), OverrideActionPriceOverride, &saleID, uuid.New(), cashierID, "Price match")
+		require.NoError(t, err)
+		return override
+	}
+
+	t.Run("consumes once", func(t *testing.T) {
+		override := newOverride(t)
+		now := time.Now()
+
+		require.NoError(t, override.Consume(OverrideActionPriceOverride, saleID, cashierID, now))
+		assert.Equal(t, &now, override.ConsumedAt)
+
+		assert.Error(t, override.Consume(OverrideActionPriceOverride, saleID, cashierID, now))
+	})
+
+	t.Run("rejects expired override", func(t *testing.T) {
+		override := newOverride(t)
+
+		err := override.Consume(OverrideActionPriceOverride, saleID, cashierID, override.ExpiresAt)
+
+		assert.Error(t, err)
+		assert.Nil(t, override.ConsumedAt)
+	})
+
+	t.Run("rejects other scopes", func(t *testing.T) {
+		override := newOverride(t)
+		now := time.Now()
+
+		assert.Error(t, override.Consume(OverrideActionRefund, saleID, cashierID, now))
+		assert.Error(t, override.Consume(OverrideActionPriceOverride, uuid.New(), cashierID, now))
+		assert.Error(t, override.Consume(OverrideActionPriceOverride, saleID, uuid.New(), now))
+		assert.Nil(t, override.ConsumedAt)
+	})
+
+	t.Run("unscoped override covers any resource", func(t *testing.T) {
+		override, _, err := NewManagerOverride(uuid.New(), OverrideActionRefund, nil, uuid.New(), cashierID, "Customer return")
+		require.NoError(t, err)
+
+		assert.NoError(t, override.Consume(OverrideActionRefund, uuid.New(), cashierID, time.Now()))
+	})
+}
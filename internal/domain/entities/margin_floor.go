@@ -0,0 +1,232 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxMarginAlertRecipients caps how many addresses margin alerts and reports are sent to
+const MaxMarginAlertRecipients = 10
+
+// MarginViolationSource represents what pushed a price below its category's margin floor
+type MarginViolationSource string
+
+const (
+	MarginViolationSourceSaleItem      MarginViolationSource = "sale_item"      // Item added to a sale, after promotions
+	MarginViolationSourcePriceOverride MarginViolationSource = "price_override" // Sale item price overridden by hand
+	MarginViolationSourceSaleDiscount  MarginViolationSource = "sale_discount"  // Sale-level discount spread over the items
+	MarginViolationSourcePriceChange   MarginViolationSource = "price_change"   // Catalog or promotional price changed
+)
+
+// MarginFloor represents the minimum gross margin a tenant accepts on products of a category.
+// Prices below the floor are not blocked; the cashier is warned and the owner alerted.
+type MarginFloor struct {
+	ID               uuid.UUID       `json:"id"`
+	TenantID         uuid.UUID       `json:"tenant_id"`
+	Category         string          `json:"category"`
+	MinMarginPercent decimal.Decimal `json:"min_margin_percent"` // Gross margin over the selling price
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	UpdatedBy        uuid.UUID       `json:"updated_by"`
+}
+
+// MarginViolation records a price that fell below its category's margin floor
+type MarginViolation struct {
+	ID            uuid.UUID             `json:"id"`
+	TenantID      uuid.UUID             `json:"tenant_id"`
+	Source        MarginViolationSource `json:"source"`
+	ProductID     uuid.UUID             `json:"product_id"`
+	ProductSKU    string                `json:"product_sku"`
+	ProductName   string                `json:"product_name"`
+	Category      string                `json:"category"`
+	SaleID        *uuid.UUID            `json:"sale_id,omitempty"`
+	UnitPrice     decimal.Decimal       `json:"unit_price"` // Effective price after promotions and discounts
+	UnitCost      decimal.Decimal       `json:"unit_cost"`
+	MarginPercent decimal.Decimal       `json:"margin_percent"`
+	FloorPercent  decimal.Decimal       `json:"floor_percent"`
+	UserID        uuid.UUID             `json:"user_id"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+// MarginAlertSetting represents where a tenant's margin floor alerts and weekly violation
+// reports are sent
+type MarginAlertSetting struct {
+	TenantID         uuid.UUID  `json:"tenant_id"`
+	Recipients       []string   `json:"recipients"`
+	AlertOnViolation bool       `json:"alert_on_violation"` // Email the recipients as soon as a floor is breached
+	WeeklyReport     bool       `json:"weekly_report"`
+	LastReportWeek   *time.Time `json:"last_report_week,omitempty"` // Start of the last week reported on
+	UpdatedAt        time.Time  `json:"updated_at"`
+	UpdatedBy        uuid.UUID  `json:"updated_by"`
+}
+
+// NewMarginFloor creates a margin floor for a category
+func NewMarginFloor(tenantID uuid.UUID, category string, minMarginPercent decimal.Decimal, updatedBy uuid.UUID) (*MarginFloor, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return nil, errors.NewValidationError("category is required", "category cannot be empty")
+	}
+	if len(category) > 100 {
+		return nil, errors.NewValidationError("category too long", "category cannot exceed 100 characters")
+	}
+	if err := validateMinMarginPercent(minMarginPercent); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	floor := &MarginFloor{
+		ID:               uuid.New(),
+		TenantID:         tenantID,
+		Category:         category,
+		MinMarginPercent: minMarginPercent,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		UpdatedBy:        updatedBy,
+	}
+
+	return floor, nil
+}
+
+// Update changes the minimum margin of the floor
+func (f *MarginFloor) Update(minMarginPercent decimal.Decimal, updatedBy uuid.UUID) error {
+	if err := validateMinMarginPercent(minMarginPercent); err != nil {
+		return err
+	}
+
+	f.MinMarginPercent = minMarginPercent
+	f.UpdatedBy = updatedBy
+	f.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsBreachedBy checks if selling at unitPrice with the given unit cost falls below the floor.
+// Items without a recorded cost cannot be checked.
+func (f *MarginFloor) IsBreachedBy(unitPrice, unitCost decimal.Decimal) bool {
+	if unitCost.LessThanOrEqual(decimal.Zero) {
+		return false
+	}
+	return GrossMarginPercent(unitPrice, unitCost).LessThan(f.MinMarginPercent)
+}
+
+// GrossMarginPercent returns the margin of price over cost as a percentage of the price
+func GrossMarginPercent(price, cost decimal.Decimal) decimal.Decimal {
+	if price.LessThanOrEqual(decimal.Zero) {
+		return decimal.NewFromInt(-100)
+	}
+	return price.Sub(cost).Mul(decimal.NewFromInt(100)).Div(price).Round(2)
+}
+
+// NewMarginViolation records a product price that breached the floor of its category
+func NewMarginViolation(floor *MarginFloor, source MarginViolationSource, product *Product, unitPrice, unitCost decimal.Decimal, saleID *uuid.UUID, userID uuid.UUID) *MarginViolation {
+	return &MarginViolation{
+		ID:            uuid.New(),
+		TenantID:      floor.TenantID,
+		Source:        source,
+		ProductID:     product.ID,
+		ProductSKU:    product.SKU,
+		ProductName:   product.Name,
+		Category:      product.Category,
+		SaleID:        saleID,
+		UnitPrice:     unitPrice,
+		UnitCost:      unitCost,
+		MarginPercent: GrossMarginPercent(unitPrice, unitCost),
+		FloorPercent:  floor.MinMarginPercent,
+		UserID:        userID,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Message describes the violation for the cashier and the owner
+func (v *MarginViolation) Message() string {
+	return fmt.Sprintf("%s sells at a %s%% margin, below the %s%% floor for %s",
+		v.ProductName, v.MarginPercent.StringFixed(2), v.FloorPercent.StringFixed(2), v.Category)
+}
+
+// NewMarginAlertSetting creates alert settings that only record violations
+func NewMarginAlertSetting(tenantID, updatedBy uuid.UUID) (*MarginAlertSetting, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	return &MarginAlertSetting{
+		TenantID:   tenantID,
+		Recipients: []string{},
+		UpdatedAt:  time.Now(),
+		UpdatedBy:  updatedBy,
+	}, nil
+}
+
+// Configure updates the alert recipients and which notifications are sent
+func (s *MarginAlertSetting) Configure(recipients []string, alertOnViolation, weeklyReport bool, updatedBy uuid.UUID) error {
+	cleaned := make([]string, 0, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		recipient = strings.ToLower(strings.TrimSpace(recipient))
+		if recipient == "" || seen[recipient] {
+			continue
+		}
+		if !isValidEmail(recipient) {
+			return errors.NewValidationError("invalid recipient", "recipient "+recipient+" is not a valid email address")
+		}
+		seen[recipient] = true
+		cleaned = append(cleaned, recipient)
+	}
+
+	if (alertOnViolation || weeklyReport) && len(cleaned) == 0 {
+		return errors.NewValidationError("recipients are required", "at least one recipient is required to send margin alerts or reports")
+	}
+	if len(cleaned) > MaxMarginAlertRecipients {
+		return errors.NewValidationError("too many recipients", "margin alerts can be sent to at most 10 recipients")
+	}
+
+	s.Recipients = cleaned
+	s.AlertOnViolation = alertOnViolation
+	s.WeeklyReport = weeklyReport
+	s.UpdatedBy = updatedBy
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// ShouldAlert checks if violations are emailed as they happen
+func (s *MarginAlertSetting) ShouldAlert() bool {
+	return s.AlertOnViolation && len(s.Recipients) > 0
+}
+
+// DueReportWeek reports whether a weekly report is due at the given instant and, if so, the
+// start of the week it covers. Weeks run Monday to Sunday in UTC and are reported once over.
+func (s *MarginAlertSetting) DueReportWeek(now time.Time) (time.Time, bool) {
+	if !s.WeeklyReport || len(s.Recipients) == 0 {
+		return time.Time{}, false
+	}
+
+	week := MarginReportWeekStart(now).AddDate(0, 0, -7)
+	if s.LastReportWeek != nil && !s.LastReportWeek.Before(week) {
+		return time.Time{}, false
+	}
+
+	return week, true
+}
+
+// MarginReportWeekStart returns the Monday midnight UTC starting the week of the given time
+func MarginReportWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// validateMinMarginPercent validates a margin floor percentage
+func validateMinMarginPercent(minMarginPercent decimal.Decimal) error {
+	if minMarginPercent.LessThan(decimal.Zero) || minMarginPercent.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+		return errors.NewValidationError("invalid minimum margin", "minimum margin must be at least 0 and below 100 percent")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMarginFloor(t *testing.T) {
+	t.Run("valid floor", func(t *testing.T) {
+		floor, err := NewMarginFloor(uuid.New(), "  Beverages ", decimal.NewFromInt(20), uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Beverages", floor.Category)
+		assert.True(t, decimal.NewFromInt(20).Equal(floor.MinMarginPercent))
+	})
+
+	t.Run("missing category", func(t *testing.T) {
+		floor, err := NewMarginFloor(uuid.New(), " ", decimal.NewFromInt(20), uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, floor)
+	})
+
+	t.Run("margin out of range", func(t *testing.T) {
+		_, err := NewMarginFloor(uuid.New(), "Beverages", decimal.NewFromInt(-1), uuid.New())
+		assert.Error(t, err)
+
+		_, err = NewMarginFloor(uuid.New(), "Beverages", decimal.NewFromInt(100), uuid.New())
+		assert.Error(t, err)
+	})
+}
+
+func TestMarginFloor_IsBreachedBy(t *testing.T) {
+	floor, err := NewMarginFloor(uuid.New(), "Beverages", decimal.NewFromInt(20), uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, floor.IsBreachedBy(decimal.NewFromInt(100), decimal.NewFromInt(80)))
+	assert.True(t, floor.IsBreachedBy(decimal.NewFromInt(100), decimal.NewFromInt(81)))
+	assert.True(t, floor.IsBreachedBy(decimal.Zero, decimal.NewFromInt(10)))
+	assert.False(t, floor.IsBreachedBy(decimal.NewFromInt(10), decimal.Zero))
+}
+
+func TestNewMarginViolation(t *testing.T) {
+	floor, err := NewMarginFloor(uuid.New(), "Beverages", decimal.NewFromInt(20), uuid.New())
+	require.NoError(t, err)
+	product := &Product{ID: uuid.New(), SKU: "BEV-1", Name: "Cola", Category: "Beverages"}
+
+	violation := NewMarginViolation(floor, MarginViolationSourceSaleItem, product, decimal.NewFromInt(10), decimal.NewFromInt(9), nil, uuid.New())
+
+	assert.Equal(t, floor.TenantID, violation.TenantID)
+	assert.True(t, decimal.NewFromInt(10).Equal(violation.MarginPercent))
+	assert.Equal(t, "Cola sells at a 10.00% margin, below the 20.00% floor for Beverages", violation.Message())
+}
+
+func TestSale_NetUnitPrice(t *testing.T) {
+	item := SaleItem{UnitPrice: decimal.NewFromInt(50), Quantity: 2, TotalPrice: decimal.NewFromInt(100)}
+	sale := &Sale{Items: []SaleItem{item}, Subtotal: decimal.NewFromInt(100)}
+
+	assert.True(t, decimal.NewFromInt(50).Equal(sale.NetUnitPrice(item)))
+
+	sale.DiscountAmount = decimal.NewFromInt(10)
+	assert.True(t, decimal.NewFromInt(45).Equal(sale.NetUnitPrice(item)))
+}
+
+func TestMarginAlertSetting(t *testing.T) {
+	t.Run("configure", func(t *testing.T) {
+		setting, err := NewMarginAlertSetting(uuid.New(), uuid.New())
+		require.NoError(t, err)
+
+		require.NoError(t, setting.Configure([]string{" Owner@Example.com", "owner@example.com"}, true, true, uuid.New()))
+
+		assert.Equal(t, []string{"owner@example.com"}, setting.Recipients)
+		assert.True(t, setting.ShouldAlert())
+	})
+
+	t.Run("recipients required when sending", func(t *testing.T) {
+		setting, err := NewMarginAlertSetting(uuid.New(), uuid.New())
+		require.NoError(t, err)
+
+		assert.Error(t, setting.Configure(nil, true, false, uuid.New()))
+		assert.NoError(t, setting.Configure(nil, false, false, uuid.New()))
+	})
+
+	t.Run("weekly report due once per week", func(t *testing.T) {
+		setting, err := NewMarginAlertSetting(uuid.New(), uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, setting.Configure([]string{"owner@example.com"}, false, true, uuid.New()))
+
+		wednesday := time.Date(2026, time.October, 14, 9, 0, 0, 0, time.UTC)
+		week, due := setting.DueReportWeek(wednesday)
+		require.True(t, due)
+		assert.Equal(t, time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC), week)
+
+		setting.LastReportWeek = &week
+		_, due = setting.DueReportWeek(wednesday)
+		assert.False(t, due)
+
+		_, due = setting.DueReportWeek(wednesday.AddDate(0, 0, 7))
+		assert.True(t, due)
+	})
+}
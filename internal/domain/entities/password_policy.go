@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PasswordPolicy configures the passwords users may choose and how long they last
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	HistorySize   int           // Previous passwords that may not be reused, 0 allows any
+	MaxAge        time.Duration // Passwords older than this must be changed, 0 never expires them
+}
+
+// Validate checks a password against the policy, listing every requirement it misses
+func (p PasswordPolicy) Validate(password string) error {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	minLength := p.MinLength
+	if minLength < 8 {
+		minLength = 8
+	}
+
+	var missing []string
+	if len([]rune(password)) < minLength {
+		missing = append(missing, fmt.Sprintf("be at least %d characters long", minLength))
+	}
+	if p.RequireUpper && !hasUpper {
+		missing = append(missing, "contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		missing = append(missing, "contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		missing = append(missing, "contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		missing = append(missing, "contain a symbol")
+	}
+
+	if len(missing) > 0 {
+		return errors.NewValidationError("password does not meet the password policy", "password must "+strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// IsExpired checks if a password changed at the given time has expired
+func (p PasswordPolicy) IsExpired(changedAt, at time.Time) bool {
+	return p.MaxAge > 0 && !at.Before(changedAt.Add(p.MaxAge))
+}
+
+// PasswordHistoryEntry records a password a user had, so it is not chosen again
+type PasswordHistoryEntry struct {
+	ID           uuid.UUID `json:"id"`
+	TenantID     uuid.UUID `json:"tenant_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewPasswordHistoryEntry records the current password of a user
+func NewPasswordHistoryEntry(user *User) *PasswordHistoryEntry {
+	return &PasswordHistoryEntry{
+		ID:           uuid.New(),
+		TenantID:     user.TenantID,
+		UserID:       user.ID,
+		PasswordHash: user.PasswordHash,
+		CreatedAt:    user.PasswordChangedAt,
+	}
+}
+
+// PasswordReused checks if a password matches any of the given previous password hashes
+func PasswordReused(password string, previousHashes []string) bool {
+	for _, hash := range previousHashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+func testPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    10,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+		HistorySize:  3,
+		MaxAge:       90 * 24 * time.Hour,
+	}
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := testPasswordPolicy()
+
+	t.Run("accepts a compliant password", func(t *testing.T) {
+		assert.NoError(t, policy.Validate("Correct1Horse"))
+	})
+
+	t.Run("lists every missing requirement", func(t *testing.T) {
+		err := policy.Validate("short")
+		require.Error(t, err)
+
+		appErr, ok := errors.IsAppError(err)
+		require.True(t, ok)
+		assert.Equal(t, errors.ErrorTypeValidation, appErr.Type)
+		assert.Contains(t, appErr.Details, "at least 10 characters")
+		assert.Contains(t, appErr.Details, "uppercase letter")
+		assert.Contains(t, appErr.Details, "digit")
+		assert.NotContains(t, appErr.Details, "lowercase letter")
+	})
+
+	t.Run("requires a symbol when configured", func(t *testing.T) {
+		policy.RequireSymbol = true
+
+		assert.Error(t, policy.Validate("Correct1Horse"))
+		assert.NoError(t, policy.Validate("Correct1Horse!"))
+	})
+
+	t.Run("never allows fewer than 8 characters", func(t *testing.T) {
+		assert.Error(t, PasswordPolicy{MinLength: 4}.Validate("abc123"))
+	})
+}
+
+func TestPasswordPolicy_IsExpired(t *testing.T) {
+	policy := testPasswordPolicy()
+	changedAt := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.False(t, policy.IsExpired(changedAt, changedAt.Add(89*24*time.Hour)))
+	assert.True(t, policy.IsExpired(changedAt, changedAt.Add(90*24*time.Hour)))
+
+	policy.MaxAge = 0
+	assert.False(t, policy.IsExpired(changedAt, changedAt.Add(10*365*24*time.Hour)))
+}
+
+func TestUser_PasswordChangeRequired(t *testing.T) {
+	policy := testPasswordPolicy()
+	user, err := NewUser(uuid.New(), "jdoe", "jdoe@example.com", "John", "Doe", "Correct1Horse", RoleCashier)
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.False(t, user.PasswordChangeRequired(policy, now))
+	assert.True(t, user.PasswordChangeRequired(policy, now.Add(policy.MaxAge)))
+
+	user.RequirePasswordChange()
+	assert.True(t, user.PasswordChangeRequired(policy, now))
+
+	t.Run("changing the password clears the requirement", func(t *testing.T) {
+		require.NoError(t, user.UpdatePassword("Another2Horse"))
+
+		assert.False(t, user.MustChangePassword)
+		assert.False(t, user.PasswordChangeRequired(policy, time.Now()))
+	})
+}
+
+func TestPasswordReused(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct1Horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	history := []string{string(hash)}
+	assert.True(t, PasswordReused("Correct1Horse", history))
+	assert.False(t, PasswordReused("Another2Horse", history))
+	assert.False(t, PasswordReused("Correct1Horse", nil))
+}
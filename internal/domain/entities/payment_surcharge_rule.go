@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// SurchargeType represents how a payment surcharge is calculated
+type SurchargeType string
+
+const (
+	SurchargeTypePercentage SurchargeType = "percentage"
+	SurchargeTypeFixed      SurchargeType = "fixed"
+)
+
+// PaymentSurchargeRule represents a tenant-defined fee passed on to customers paying with a
+// given payment method, such as card processing fees. A tenant has at most one rule per method.
+type PaymentSurchargeRule struct {
+	ID            uuid.UUID       `json:"id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	PaymentMethod PaymentMethod   `json:"payment_method"`
+	Type          SurchargeType   `json:"type"`
+	Value         decimal.Decimal `json:"value"`      // Percentage of the sale total or fixed amount
+	MinAmount     decimal.Decimal `json:"min_amount"` // Lower bound of the surcharge, zero for none
+	MaxAmount     decimal.Decimal `json:"max_amount"` // Upper bound of the surcharge, zero for none
+	Label         string          `json:"label"`      // Shown on receipts and invoices
+	IsTaxable     bool            `json:"is_taxable"`
+	IsActive      bool            `json:"is_active"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	CreatedBy     uuid.UUID       `json:"created_by"`
+}
+
+// NewPaymentSurchargeRule creates a new payment surcharge rule
+func NewPaymentSurchargeRule(tenantID uuid.UUID, paymentMethod PaymentMethod, surchargeType SurchargeType, value, minAmount, maxAmount decimal.Decimal, label string, isTaxable bool, createdBy uuid.UUID) (*PaymentSurchargeRule, error) {
+	if err := ValidatePaymentMethod(paymentMethod); err != nil {
+		return nil, err
+	}
+	if err := validatePaymentSurchargeInput(surchargeType, value, minAmount, maxAmount, label); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule := &PaymentSurchargeRule{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		PaymentMethod: paymentMethod,
+		Type:          surchargeType,
+		Value:         value,
+		MinAmount:     minAmount,
+		MaxAmount:     maxAmount,
+		Label:         strings.TrimSpace(label),
+		IsTaxable:     isTaxable,
+		IsActive:      true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		CreatedBy:     createdBy,
+	}
+
+	return rule, nil
+}
+
+// Update updates the surcharge calculation of the rule
+func (r *PaymentSurchargeRule) Update(surchargeType SurchargeType, value, minAmount, maxAmount decimal.Decimal, label string, isTaxable bool) error {
+	if err := validatePaymentSurchargeInput(surchargeType, value, minAmount, maxAmount, label); err != nil {
+		return err
+	}
+
+	r.Type = surchargeType
+	r.Value = value
+	r.MinAmount = minAmount
+	r.MaxAmount = maxAmount
+	r.Label = strings.TrimSpace(label)
+	r.IsTaxable = isTaxable
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Activate enables the surcharge rule
+func (r *PaymentSurchargeRule) Activate() {
+	r.IsActive = true
+	r.UpdatedAt = time.Now()
+}
+
+// Deactivate disables the surcharge rule without deleting it
+func (r *PaymentSurchargeRule) Deactivate() {
+	r.IsActive = false
+	r.UpdatedAt = time.Now()
+}
+
+// Calculate returns the surcharge for a sale amount, clamped to the rule's bounds and
+// rounded to cents
+func (r *PaymentSurchargeRule) Calculate(amount decimal.Decimal) decimal.Decimal {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+
+	surcharge := r.Value
+	if r.Type == SurchargeTypePercentage {
+		surcharge = amount.Mul(r.Value).Div(decimal.NewFromInt(100))
+	}
+
+	if r.MinAmount.GreaterThan(decimal.Zero) && surcharge.LessThan(r.MinAmount) {
+		surcharge = r.MinAmount
+	}
+	if r.MaxAmount.GreaterThan(decimal.Zero) && surcharge.GreaterThan(r.MaxAmount) {
+		surcharge = r.MaxAmount
+	}
+
+	return surcharge.Round(2)
+}
+
+// ValidateSurchargeType validates surcharge type
+func ValidateSurchargeType(surchargeType SurchargeType) error {
+	switch surchargeType {
+	case SurchargeTypePercentage, SurchargeTypeFixed:
+		return nil
+	default:
+		return errors.NewValidationError("invalid surcharge type", "type must be one of: percentage, fixed")
+	}
+}
+
+// validatePaymentSurchargeInput validates payment surcharge rule input
+func validatePaymentSurchargeInput(surchargeType SurchargeType, value, minAmount, maxAmount decimal.Decimal, label string) error {
+	if err := ValidateSurchargeType(surchargeType); err != nil {
+		return err
+	}
+	if value.LessThanOrEqual(decimal.Zero) {
+		return errors.NewValidationError("invalid surcharge value", "value must be greater than zero")
+	}
+	if surchargeType == SurchargeTypePercentage && value.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.NewValidationError("invalid surcharge value", "percentage cannot exceed 100")
+	}
+	if minAmount.LessThan(decimal.Zero) || maxAmount.LessThan(decimal.Zero) {
+		return errors.NewValidationError("invalid surcharge bounds", "min and max amounts cannot be negative")
+	}
+	if maxAmount.GreaterThan(decimal.Zero) && minAmount.GreaterThan(maxAmount) {
+		return errors.NewValidationError("invalid surcharge bounds", "min amount cannot be greater than max amount")
+	}
+	if strings.TrimSpace(label) == "" {
+		return errors.NewValidationError("surcharge label is required", "label cannot be empty")
+	}
+	if len(label) > 100 {
+		return errors.NewValidationError("surcharge label too long", "label cannot exceed 100 characters")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaymentSurchargeRule(t *testing.T) {
+	t.Run("valid rule", func(t *testing.T) {
+		rule, err := NewPaymentSurchargeRule(uuid.New(), PaymentMethodCard, SurchargeTypePercentage,
+			decimal.NewFromFloat(2.5), decimal.NewFromInt(1), decimal.NewFromInt(20), " Card fee ", true, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, PaymentMethodCard, rule.PaymentMethod)
+		assert.Equal(t, "Card fee", rule.Label)
+		assert.True(t, rule.IsTaxable)
+		assert.True(t, rule.IsActive)
+	})
+
+	tests := []struct {
+		name          string
+		paymentMethod PaymentMethod
+		surchargeType SurchargeType
+		value         decimal.Decimal
+		minAmount     decimal.Decimal
+		maxAmount     decimal.Decimal
+		label         string
+		errContains   string
+	}{
+		{"invalid payment method", "cheque", SurchargeTypeFixed, decimal.NewFromInt(1), decimal.Zero, decimal.Zero, "Fee", "invalid payment method"},
+		{"invalid type", PaymentMethodCard, "tiered", decimal.NewFromInt(1), decimal.Zero, decimal.Zero, "Fee", "invalid surcharge type"},
+		{"zero value", PaymentMethodCard, SurchargeTypeFixed, decimal.Zero, decimal.Zero, decimal.Zero, "Fee", "invalid surcharge value"},
+		{"percentage over 100", PaymentMethodCard, SurchargeTypePercentage, decimal.NewFromInt(101), decimal.Zero, decimal.Zero, "Fee", "invalid surcharge value"},
+		{"negative bound", PaymentMethodCard, SurchargeTypePercentage, decimal.NewFromInt(2), decimal.NewFromInt(-1), decimal.Zero, "Fee", "invalid surcharge bounds"},
+		{"min above max", PaymentMethodCard, SurchargeTypePercentage, decimal.NewFromInt(2), decimal.NewFromInt(10), decimal.NewFromInt(5), "Fee", "invalid surcharge bounds"},
+		{"missing label", PaymentMethodCard, SurchargeTypeFixed, decimal.NewFromInt(1), decimal.Zero, decimal.Zero, " ", "surcharge label is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewPaymentSurchargeRule(uuid.New(), tt.paymentMethod, tt.surchargeType,
+				tt.value, tt.minAmount, tt.maxAmount, tt.label, false, uuid.New())
+
+			assert.Error(t, err)
+			assert.Nil(t, rule)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestPaymentSurchargeRule_Calculate(t *testing.T) {
+	tests := []struct {
+		name          string
+		surchargeType SurchargeType
+		value         float64
+		minAmount     float64
+		maxAmount     float64
+		amount        float64
+		expected      float64
+	}{
+		{"percentage", SurchargeTypePercentage, 2.5, 0, 0, 100, 2.5},
+		{"percentage rounded to cents", SurchargeTypePercentage, 2.5, 0, 0, 33.33, 0.83},
+		{"percentage raised to minimum", SurchargeTypePercentage, 2.5, 1, 0, 10, 1},
+		{"percentage capped at maximum", SurchargeTypePercentage, 2.5, 0, 20, 1000, 20},
+		{"fixed", SurchargeTypeFixed, 1.5, 0, 0, 100, 1.5},
+		{"nothing to charge", SurchargeTypeFixed, 1.5, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewPaymentSurchargeRule(uuid.New(), PaymentMethodCard, tt.surchargeType,
+				decimal.NewFromFloat(tt.value), decimal.NewFromFloat(tt.minAmount), decimal.NewFromFloat(tt.maxAmount), "Card fee", false, uuid.New())
+			require.NoError(t, err)
+
+			surcharge := rule.Calculate(decimal.NewFromFloat(tt.amount))
+
+			assert.True(t, decimal.NewFromFloat(tt.expected).Equal(surcharge), "expected %v, got %s", tt.expected, surcharge)
+		})
+	}
+}
+
+func TestPaymentSurchargeRule_Update(t *testing.T) {
+	rule, err := NewPaymentSurchargeRule(uuid.New(), PaymentMethodCard, SurchargeTypeFixed,
+		decimal.NewFromInt(1), decimal.Zero, decimal.Zero, "Card fee", false, uuid.New())
+	require.NoError(t, err)
+
+	err = rule.Update(SurchargeTypePercentage, decimal.NewFromInt(3), decimal.Zero, decimal.Zero, "Card processing fee", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, SurchargeTypePercentage, rule.Type)
+	assert.Equal(t, "Card processing fee", rule.Label)
+	assert.True(t, rule.IsTaxable)
+
+	rule.Deactivate()
+	assert.False(t, rule.IsActive)
+}
@@ -0,0 +1,203 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PaymentGatewayProvider represents the payment gateway a payment is collected through
+type PaymentGatewayProvider string
+
+const (
+	PaymentGatewayStripe   PaymentGatewayProvider = "stripe"
+	PaymentGatewayMidtrans PaymentGatewayProvider = "midtrans"
+)
+
+// IsValid reports whether the payment gateway is supported
+func (p PaymentGatewayProvider) IsValid() bool {
+	switch p {
+	case PaymentGatewayStripe, PaymentGatewayMidtrans:
+		return true
+	default:
+		return false
+	}
+}
+
+// PaymentTransactionStatus represents the state of a payment collected through a gateway
+type PaymentTransactionStatus string
+
+const (
+	PaymentTransactionStatusPending   PaymentTransactionStatus = "pending"   // Waiting for the customer to pay
+	PaymentTransactionStatusSucceeded PaymentTransactionStatus = "succeeded" // Confirmed by the gateway
+	PaymentTransactionStatusFailed    PaymentTransactionStatus = "failed"    // Declined, cancelled or expired; the customer may still pay it
+)
+
+// IsValid reports whether the payment transaction status is known
+func (s PaymentTransactionStatus) IsValid() bool {
+	switch s {
+	case PaymentTransactionStatusPending, PaymentTransactionStatusSucceeded, PaymentTransactionStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// PaymentTransaction represents a card or digital wallet payment of a sale or invoice collected
+// through a payment gateway. The gateway confirms the payment asynchronously, after which the
+// sale is completed or the payment recorded against the invoice.
+type PaymentTransaction struct {
+	ID             uuid.UUID                `json:"id"`
+	TenantID       uuid.UUID                `json:"tenant_id"`
+	Provider       PaymentGatewayProvider   `json:"provider"`
+	SaleID         *uuid.UUID               `json:"sale_id,omitempty"`
+	InvoiceID      *uuid.UUID               `json:"invoice_id,omitempty"`
+	PaymentMethod  PaymentMethod            `json:"payment_method"`
+	Amount         decimal.Decimal          `json:"amount"`
+	Currency       string                   `json:"currency"`
+	DiscountAmount decimal.Decimal          `json:"discount_amount"`         // Sale only; the sale is completed with it
+	CouponCode     string                   `json:"coupon_code,omitempty"`   // Sale only
+	TaxPercentage  decimal.Decimal          `json:"tax_percentage"`          // Sale only
+	ExternalID     string                   `json:"external_id"`             // Stripe payment intent or Midtrans order
+	ClientSecret   string                   `json:"client_secret,omitempty"` // Stripe; confirms the payment on the client
+	RedirectURL    string                   `json:"redirect_url,omitempty"`  // Midtrans; the payment page the customer is sent to
+	Status         PaymentTransactionStatus `json:"status"`
+	GatewayStatus  string                   `json:"gateway_status,omitempty"` // Last status reported by the gateway
+	Error          string                   `json:"error,omitempty"`          // Why a confirmed payment could not be applied
+	SucceededAt    *time.Time               `json:"succeeded_at,omitempty"`
+	CreatedBy      uuid.UUID                `json:"created_by"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// NewSalePaymentTransaction starts collecting the payment of a pending sale through a gateway
+func NewSalePaymentTransaction(tenantID, saleID uuid.UUID, provider PaymentGatewayProvider, method PaymentMethod, amount decimal.Decimal, currency string, createdBy uuid.UUID) (*PaymentTransaction, error) {
+	if saleID == uuid.Nil {
+		return nil, errors.NewValidationError("sale ID is required", "payment transaction must have a sale")
+	}
+
+	transaction, err := newPaymentTransaction(tenantID, provider, method, amount, currency, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	transaction.SaleID = &saleID
+
+	return transaction, nil
+}
+
+// NewInvoicePaymentTransaction starts collecting a payment of an invoice through a gateway
+func NewInvoicePaymentTransaction(tenantID, invoiceID uuid.UUID, provider PaymentGatewayProvider, method PaymentMethod, amount decimal.Decimal, currency string, createdBy uuid.UUID) (*PaymentTransaction, error) {
+	if invoiceID == uuid.Nil {
+		return nil, errors.NewValidationError("invoice ID is required", "payment transaction must have an invoice")
+	}
+
+	transaction, err := newPaymentTransaction(tenantID, provider, method, amount, currency, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	transaction.InvoiceID = &invoiceID
+
+	return transaction, nil
+}
+
+// newPaymentTransaction validates what every gateway payment has in common
+func newPaymentTransaction(tenantID uuid.UUID, provider PaymentGatewayProvider, method PaymentMethod, amount decimal.Decimal, currency string, createdBy uuid.UUID) (*PaymentTransaction, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "payment transaction must belong to a tenant")
+	}
+	if !provider.IsValid() {
+		return nil, errors.NewValidationError("invalid payment gateway", "provider must be one of: stripe, midtrans")
+	}
+	if method != PaymentMethodCard && method != PaymentMethodDigitalWallet {
+		return nil, errors.NewValidationError("invalid payment method", "gateway payments must be card or digital_wallet")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("invalid payment amount", "payment amount must be positive")
+	}
+	if !amount.Equal(amount.Round(2)) {
+		return nil, errors.NewValidationError("invalid payment amount", "payment amount cannot have more than 2 decimal places")
+	}
+
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Midtrans only charges whole rupiah
+	if provider == PaymentGatewayMidtrans {
+		if currency != "IDR" {
+			return nil, errors.NewValidationError("unsupported currency", "midtrans only accepts payments in IDR")
+		}
+		if !amount.Equal(amount.Truncate(0)) {
+			return nil, errors.NewValidationError("invalid payment amount", "midtrans payment amounts must be whole rupiah")
+		}
+	}
+
+	now := time.Now()
+	return &PaymentTransaction{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		Provider:       provider,
+		PaymentMethod:  method,
+		Amount:         amount,
+		Currency:       currency,
+		DiscountAmount: decimal.Zero,
+		TaxPercentage:  decimal.Zero,
+		Status:         PaymentTransactionStatusPending,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// Open records the payment the gateway created for the transaction and how the customer pays it
+func (t *PaymentTransaction) Open(externalID, clientSecret, redirectURL, gatewayStatus string) error {
+	if externalID == "" {
+		return errors.NewValidationError("external ID is required", "gateway did not return a payment reference")
+	}
+
+	t.ExternalID = externalID
+	t.ClientSecret = clientSecret
+	t.RedirectURL = redirectURL
+	t.GatewayStatus = gatewayStatus
+	t.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Settle applies a status reported by the gateway and returns whether the transaction changed
+// status. Gateways may report a status more than once and out of order, so a succeeded
+// transaction stays succeeded. A failed payment can still succeed when the customer retries it.
+func (t *PaymentTransaction) Settle(status PaymentTransactionStatus, gatewayStatus string, at time.Time) bool {
+	if t.Status == PaymentTransactionStatusSucceeded {
+		return false
+	}
+
+	t.GatewayStatus = gatewayStatus
+	t.UpdatedAt = time.Now()
+
+	if status == t.Status || status == PaymentTransactionStatusPending {
+		return false
+	}
+
+	t.Status = status
+	if status == PaymentTransactionStatusSucceeded {
+		t.SucceededAt = &at
+	}
+	return true
+}
+
+// RecordError records why a confirmed payment could not be applied to its sale or invoice,
+// which then has to be refunded or settled by hand
+func (t *PaymentTransaction) RecordError(cause string) {
+	t.Error = cause
+	t.UpdatedAt = time.Now()
+}
+
+// Reference identifies the payment on the gateway, e.g. "stripe:pi_123"
+func (t *PaymentTransaction) Reference() string {
+	return string(t.Provider) + ":" + t.ExternalID
+}
@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPaymentTransaction(t *testing.T) *PaymentTransaction {
+	t.Helper()
+
+	transaction, err := NewSalePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayStripe, PaymentMethodCard,
+		decimal.RequireFromString("42.50"), "usd", uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, transaction.Open("pi_123", "pi_123_secret_abc", "", "requires_payment_method"))
+	return transaction
+}
+
+func TestNewSalePaymentTransaction(t *testing.T) {
+	t.Run("card payment", func(t *testing.T) {
+		tenantID := uuid.New()
+		saleID := uuid.New()
+		createdBy := uuid.New()
+
+		transaction, err := NewSalePaymentTransaction(tenantID, saleID, PaymentGatewayStripe, PaymentMethodCard,
+			decimal.RequireFromString("42.50"), " usd ", createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, transaction.ID)
+		assert.Equal(t, tenantID, transaction.TenantID)
+		require.NotNil(t, transaction.SaleID)
+		assert.Equal(t, saleID, *transaction.SaleID)
+		assert.Nil(t, transaction.InvoiceID)
+		assert.Equal(t, "USD", transaction.Currency)
+		assert.Equal(t, PaymentTransactionStatusPending, transaction.Status)
+		assert.Equal(t, createdBy, transaction.CreatedBy)
+	})
+
+	t.Run("sale is required", func(t *testing.T) {
+		_, err := NewSalePaymentTransaction(uuid.New(), uuid.Nil, PaymentGatewayStripe, PaymentMethodCard,
+			decimal.NewFromInt(10), "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "sale ID is required")
+	})
+
+	t.Run("cash cannot be collected through a gateway", func(t *testing.T) {
+		_, err := NewSalePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayStripe, PaymentMethodCash,
+			decimal.NewFromInt(10), "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payment method")
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := NewSalePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayProvider("paypal"), PaymentMethodCard,
+			decimal.NewFromInt(10), "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payment gateway")
+	})
+
+	t.Run("amount must be positive", func(t *testing.T) {
+		_, err := NewSalePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayStripe, PaymentMethodCard,
+			decimal.Zero, "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payment amount")
+	})
+
+	t.Run("amount cannot have fractions of a cent", func(t *testing.T) {
+		_, err := NewSalePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayStripe, PaymentMethodCard,
+			decimal.RequireFromString("10.005"), "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payment amount")
+	})
+}
+
+func TestNewInvoicePaymentTransaction(t *testing.T) {
+	t.Run("midtrans wallet payment in rupiah", func(t *testing.T) {
+		invoiceID := uuid.New()
+
+		transaction, err := NewInvoicePaymentTransaction(uuid.New(), invoiceID, PaymentGatewayMidtrans, PaymentMethodDigitalWallet,
+			decimal.NewFromInt(150000), "IDR", uuid.New())
+
+		require.NoError(t, err)
+		require.NotNil(t, transaction.InvoiceID)
+		assert.Equal(t, invoiceID, *transaction.InvoiceID)
+		assert.Nil(t, transaction.SaleID)
+	})
+
+	t.Run("midtrans only accepts rupiah", func(t *testing.T) {
+		_, err := NewInvoicePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayMidtrans, PaymentMethodCard,
+			decimal.NewFromInt(100), "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported currency")
+	})
+
+	t.Run("midtrans only charges whole rupiah", func(t *testing.T) {
+		_, err := NewInvoicePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayMidtrans, PaymentMethodCard,
+			decimal.RequireFromString("150000.50"), "IDR", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payment amount")
+	})
+
+	t.Run("invoice is required", func(t *testing.T) {
+		_, err := NewInvoicePaymentTransaction(uuid.New(), uuid.Nil, PaymentGatewayStripe, PaymentMethodCard,
+			decimal.NewFromInt(10), "USD", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invoice ID is required")
+	})
+}
+
+func TestPaymentTransaction_Open(t *testing.T) {
+	transaction, err := NewInvoicePaymentTransaction(uuid.New(), uuid.New(), PaymentGatewayMidtrans, PaymentMethodDigitalWallet,
+		decimal.NewFromInt(150000), "IDR", uuid.New())
+	require.NoError(t, err)
+
+	assert.Error(t, transaction.Open("", "", "", ""))
+
+	require.NoError(t, transaction.Open(transaction.ID.String(), "", "https://app.midtrans.com/snap/v4/redirection/abc", ""))
+	assert.Equal(t, transaction.ID.String(), transaction.ExternalID)
+	assert.Equal(t, "https://app.midtrans.com/snap/v4/redirection/abc", transaction.RedirectURL)
+	assert.Equal(t, "midtrans:"+transaction.ID.String(), transaction.Reference())
+}
+
+func TestPaymentTransaction_Settle(t *testing.T) {
+	now := time.Now()
+
+	t.Run("pending status only updates the gateway status", func(t *testing.T) {
+		transaction := newTestPaymentTransaction(t)
+
+		assert.False(t, transaction.Settle(PaymentTransactionStatusPending, "processing", now))
+		assert.Equal(t, PaymentTransactionStatusPending, transaction.Status)
+		assert.Equal(t, "processing", transaction.GatewayStatus)
+	})
+
+	t.Run("success is recorded once", func(t *testing.T) {
+		transaction := newTestPaymentTransaction(t)
+
+		assert.True(t, transaction.Settle(PaymentTransactionStatusSucceeded, "succeeded", now))
+		assert.Equal(t, PaymentTransactionStatusSucceeded, transaction.Status)
+		require.NotNil(t, transaction.SucceededAt)
+		assert.Equal(t, now, *transaction.SucceededAt)
+
+		assert.False(t, transaction.Settle(PaymentTransactionStatusSucceeded, "succeeded", now.Add(time.Minute)))
+		assert.Equal(t, now, *transaction.SucceededAt)
+	})
+
+	t.Run("late failure does not undo success", func(t *testing.T) {
+		transaction := newTestPaymentTransaction(t)
+		transaction.Settle(PaymentTransactionStatusSucceeded, "succeeded", now)
+
+		assert.False(t, transaction.Settle(PaymentTransactionStatusFailed, "payment_failed", now))
+		assert.Equal(t, PaymentTransactionStatusSucceeded, transaction.Status)
+		assert.Equal(t, "succeeded", transaction.GatewayStatus)
+	})
+
+	t.Run("failed payment can still succeed", func(t *testing.T) {
+		transaction := newTestPaymentTransaction(t)
+
+		assert.True(t, transaction.Settle(PaymentTransactionStatusFailed, "requires_payment_method", now))
+		assert.Equal(t, PaymentTransactionStatusFailed, transaction.Status)
+		assert.Nil(t, transaction.SucceededAt)
+
+		assert.True(t, transaction.Settle(PaymentTransactionStatusSucceeded, "succeeded", now))
+		assert.Equal(t, PaymentTransactionStatusSucceeded, transaction.Status)
+	})
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PrinterStatus represents the outcome of the latest test print of a printer
+type PrinterStatus string
+
+const (
+	PrinterStatusUnknown PrinterStatus = "unknown"
+	PrinterStatusReady   PrinterStatus = "ready"
+	PrinterStatusError   PrinterStatus = "error"
+)
+
+// Printer represents a printer registered by a tenant. Print requests that do not name a
+// printer go to the printer registered for the requesting store terminal, or else to the
+// tenant's printer shared by all terminals.
+type Printer struct {
+	ID            uuid.UUID     `json:"id"`
+	TenantID      uuid.UUID     `json:"tenant_id"`
+	Name          string        `json:"name"` // Printer name as known to the print service
+	Description   string        `json:"description,omitempty"`
+	Terminal      string        `json:"terminal,omitempty"` // Store terminal the printer serves; empty for all terminals
+	IsThermal     bool          `json:"is_thermal"`         // Prints receipts rather than sheet paper
+	IsDefault     bool          `json:"is_default"`         // Preferred among the terminal's printers of its kind
+	Status        PrinterStatus `json:"status"`
+	LastTestedAt  *time.Time    `json:"last_tested_at,omitempty"`
+	LastTestError string        `json:"last_test_error,omitempty"`
+	CreatedBy     uuid.UUID     `json:"created_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// NewPrinter registers a new printer
+func NewPrinter(tenantID uuid.UUID, name, description, terminal string, isThermal, isDefault bool, createdBy uuid.UUID) (*Printer, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.NewValidationError("printer name is required", "printer name cannot be empty")
+	}
+	if len(name) > 255 {
+		return nil, errors.NewValidationError("printer name too long", "printer name cannot exceed 255 characters")
+	}
+
+	terminal = strings.TrimSpace(terminal)
+	if len(terminal) > 100 {
+		return nil, errors.NewValidationError("terminal too long", "terminal cannot exceed 100 characters")
+	}
+
+	description = strings.TrimSpace(description)
+	if len(description) > 500 {
+		return nil, errors.NewValidationError("description too long", "description cannot exceed 500 characters")
+	}
+
+	now := time.Now()
+	return &Printer{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Name:        name,
+		Description: description,
+		Terminal:    terminal,
+		IsThermal:   isThermal,
+		IsDefault:   isDefault,
+		Status:      PrinterStatusUnknown,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// SupportsPaperSize checks if the printer can print on the paper size. Thermal printers only
+// print receipts.
+func (p *Printer) SupportsPaperSize(paperSize PaperSize) bool {
+	return p.IsThermal == (paperSize == PaperSizeReceipt)
+}
+
+// RecordTestPrint records the outcome of a test print, nil meaning it succeeded
+func (p *Printer) RecordTestPrint(testErr error) {
+	now := time.Now()
+	p.LastTestedAt = &now
+	p.UpdatedAt = now
+
+	if testErr != nil {
+		p.Status = PrinterStatusError
+		p.LastTestError = testErr.Error()
+		return
+	}
+
+	p.Status = PrinterStatusReady
+	p.LastTestError = ""
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrinter(t *testing.T) {
+	t.Run("valid printer registration", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		printer, err := NewPrinter(tenantID, " EPSON_TM_T82 ", "Front counter", " counter-1 ", true, true, createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, printer.ID)
+		assert.Equal(t, tenantID, printer.TenantID)
+		assert.Equal(t, "EPSON_TM_T82", printer.Name)
+		assert.Equal(t, "Front counter", printer.Description)
+		assert.Equal(t, "counter-1", printer.Terminal)
+		assert.True(t, printer.IsThermal)
+		assert.True(t, printer.IsDefault)
+		assert.Equal(t, PrinterStatusUnknown, printer.Status)
+		assert.Nil(t, printer.LastTestedAt)
+		assert.Equal(t, createdBy, printer.CreatedBy)
+	})
+
+	t.Run("invalid name - empty", func(t *testing.T) {
+		printer, err := NewPrinter(uuid.New(), " ", "", "", false, false, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, printer)
+		assert.Contains(t, err.Error(), "printer name is required")
+	})
+
+	t.Run("invalid terminal - too long", func(t *testing.T) {
+		printer, err := NewPrinter(uuid.New(), "Office Laser", "", strings.Repeat("a", 101), false, false, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, printer)
+		assert.Contains(t, err.Error(), "terminal too long")
+	})
+}
+
+func TestPrinter_SupportsPaperSize(t *testing.T) {
+	thermal, err := NewPrinter(uuid.New(), "Receipt Printer", "", "", true, false, uuid.New())
+	require.NoError(t, err)
+	laser, err := NewPrinter(uuid.New(), "Office Laser", "", "", false, false, uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, thermal.SupportsPaperSize(PaperSizeReceipt))
+	assert.False(t, thermal.SupportsPaperSize(PaperSizeA4))
+	assert.True(t, laser.SupportsPaperSize(PaperSizeA4))
+	assert.True(t, laser.SupportsPaperSize(PaperSizeA5))
+	assert.False(t, laser.SupportsPaperSize(PaperSizeReceipt))
+}
+
+func TestPrinter_RecordTestPrint(t *testing.T) {
+	printer, err := NewPrinter(uuid.New(), "Receipt Printer", "", "", true, false, uuid.New())
+	require.NoError(t, err)
+
+	printer.RecordTestPrint(fmt.Errorf("printer offline"))
+
+	assert.Equal(t, PrinterStatusError, printer.Status)
+	assert.Equal(t, "printer offline", printer.LastTestError)
+	assert.NotNil(t, printer.LastTestedAt)
+
+	printer.RecordTestPrint(nil)
+
+	assert.Equal(t, PrinterStatusReady, printer.Status)
+	assert.Empty(t, printer.LastTestError)
+}
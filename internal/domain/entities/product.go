@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,22 +19,51 @@ const (
 	ProductStatusDiscontinued ProductStatus = "discontinued"
 )
 
+// ProductPublishState represents where a product is in the publishing workflow. Only
+// published products are visible to POS terminals and channel syncs.
+type ProductPublishState string
+
+const (
+	ProductPublishStateDraft     ProductPublishState = "draft"
+	ProductPublishStateInReview  ProductPublishState = "in_review"
+	ProductPublishStateScheduled ProductPublishState = "scheduled" // Approved, waiting for publish_at
+	ProductPublishStatePublished ProductPublishState = "published"
+)
+
 // Product represents a product in the system
 type Product struct {
-	ID          uuid.UUID       `json:"id"`
-	TenantID    uuid.UUID       `json:"tenant_id"`
-	SKU         string          `json:"sku"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Category    string          `json:"category"`
-	Price       decimal.Decimal `json:"price"`
-	Cost        decimal.Decimal `json:"cost"`
-	Status      ProductStatus   `json:"status"`
-	Unit        string          `json:"unit"` // e.g., "pcs", "kg", "ltr"
-	MinStock    int             `json:"min_stock"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	CreatedBy   uuid.UUID       `json:"created_by"`
+	ID             uuid.UUID           `json:"id"`
+	TenantID       uuid.UUID           `json:"tenant_id"`
+	SKU            string              `json:"sku"`
+	Barcode        string              `json:"barcode,omitempty"` // e.g. EAN-13 / UPC-A printed on the packaging
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Category       string              `json:"category"` // Name of the category at CategoryID
+	CategoryID     *uuid.UUID          `json:"category_id,omitempty"`
+	TaxRateID      *uuid.UUID          `json:"tax_rate_id,omitempty"` // Overrides the category's tax rate
+	Currency       string              `json:"currency,omitempty"`    // Price and cost currency, the tenant's base currency when empty
+	Price          decimal.Decimal     `json:"price"`
+	Cost           decimal.Decimal     `json:"cost"`
+	Status         ProductStatus       `json:"status"`
+	Unit           string              `json:"unit"` // Unit catalog code, e.g., "pcs", "kg", "liter"
+	MinStock       int                 `json:"min_stock"`
+	PromoPrice     *decimal.Decimal    `json:"promo_price,omitempty"`
+	PromoStartsAt  *time.Time          `json:"promo_starts_at,omitempty"`
+	PromoEndsAt    *time.Time          `json:"promo_ends_at,omitempty"`
+	PublishState   ProductPublishState `json:"publish_state"`
+	PublishAt      *time.Time          `json:"publish_at,omitempty"`
+	PublishedAt    *time.Time          `json:"published_at,omitempty"`
+	SubmittedBy    *uuid.UUID          `json:"submitted_by,omitempty"`
+	ReviewedBy     *uuid.UUID          `json:"reviewed_by,omitempty"`
+	ReviewNotes    string              `json:"review_notes,omitempty"`
+	SupplierID     *uuid.UUID          `json:"supplier_id,omitempty"` // Preferred supplier, suggested when the product runs low
+	AvailableFrom  *time.Time          `json:"available_from,omitempty"`
+	AvailableUntil *time.Time          `json:"available_until,omitempty"`
+	IsSeasonal     bool                `json:"is_seasonal"`   // Availability window repeats every year
+	IsSerialized   bool                `json:"is_serialized"` // Each unit sold is recorded by its serial number
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	CreatedBy      uuid.UUID           `json:"created_by"`
 }
 
 // NewProduct creates a new product
@@ -44,20 +74,22 @@ func NewProduct(tenantID uuid.UUID, sku, name, description, category, unit strin
 
 	now := time.Now()
 	product := &Product{
-		ID:          uuid.New(),
-		TenantID:    tenantID,
-		SKU:         sku,
-		Name:        name,
-		Description: description,
-		Category:    category,
-		Price:       price,
-		Cost:        cost,
-		Status:      ProductStatusActive,
-		Unit:        unit,
-		MinStock:    minStock,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-		CreatedBy:   createdBy,
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		SKU:          sku,
+		Name:         name,
+		Description:  description,
+		Category:     category,
+		Price:        price,
+		Cost:         cost,
+		Status:       ProductStatusActive,
+		Unit:         NormalizeUnitCode(unit),
+		MinStock:     minStock,
+		PublishState: ProductPublishStatePublished,
+		PublishedAt:  &now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		CreatedBy:    createdBy,
 	}
 
 	return product, nil
@@ -74,13 +106,70 @@ func (p *Product) UpdateProduct(name, description, category, unit string, price,
 	p.Category = category
 	p.Price = price
 	p.Cost = cost
-	p.Unit = unit
+	p.Unit = NormalizeUnitCode(unit)
 	p.MinStock = minStock
 	p.UpdatedAt = time.Now()
 
 	return nil
 }
 
+// SetCategory files the product under the category
+func (p *Product) SetCategory(category *Category) error {
+	if category == nil {
+		return errors.NewValidationError("category is required", "category cannot be empty")
+	}
+	if category.TenantID != p.TenantID {
+		return errors.NewValidationError("unknown category", "category does not belong to the product's tenant")
+	}
+
+	id := category.ID
+	p.CategoryID = &id
+	p.Category = category.Name
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetTaxRate taxes the product at the tax rate, or at its category's rate when taxRate is nil
+func (p *Product) SetTaxRate(taxRate *TaxRate) error {
+	if taxRate == nil {
+		p.TaxRateID = nil
+		p.UpdatedAt = time.Now()
+		return nil
+	}
+	if err := taxRate.checkAssignable(p.TenantID); err != nil {
+		return err
+	}
+
+	id := taxRate.ID
+	p.TaxRateID = &id
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCurrency sets the currency the product's price and cost are in. An empty currency
+// prices the product in the tenant's base currency.
+func (p *Product) SetCurrency(currency string) error {
+	if currency != "" {
+		normalized, err := NormalizeCurrency(currency)
+		if err != nil {
+			return err
+		}
+		currency = normalized
+	}
+
+	p.Currency = currency
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// PriceCurrency returns the currency the product's price and cost are in
+func (p *Product) PriceCurrency(baseCurrency string) string {
+	if p.Currency != "" {
+		return p.Currency
+	}
+	return baseCurrency
+}
+
 // UpdatePrice updates the product price
 func (p *Product) UpdatePrice(newPrice decimal.Decimal) error {
 	if newPrice.LessThanOrEqual(decimal.Zero) {
@@ -103,6 +192,23 @@ func (p *Product) UpdateCost(newCost decimal.Decimal) error {
 	return nil
 }
 
+// ReceiveAtCost averages the cost of quantity units received at unitCost into the cost of the
+// onHand units already in stock
+func (p *Product) ReceiveAtCost(onHand, quantity int, unitCost decimal.Decimal) error {
+	if quantity <= 0 {
+		return errors.NewValidationError("invalid quantity", "quantity must be positive")
+	}
+	if unitCost.IsNegative() {
+		return errors.NewValidationError("invalid cost", "cost cannot be negative")
+	}
+	if onHand <= 0 {
+		return p.UpdateCost(unitCost)
+	}
+
+	value := p.Cost.Mul(decimal.NewFromInt(int64(onHand))).Add(unitCost.Mul(decimal.NewFromInt(int64(quantity))))
+	return p.UpdateCost(value.Div(decimal.NewFromInt(int64(onHand + quantity))).Round(2))
+}
+
 // ChangeStatus changes the product status
 func (p *Product) ChangeStatus(status ProductStatus) error {
 	if err := ValidateProductStatus(status); err != nil {
@@ -125,11 +231,245 @@ func (p *Product) UpdateMinStock(minStock int) error {
 	return nil
 }
 
+// SetBarcode sets or clears the product barcode
+func (p *Product) SetBarcode(barcode string) error {
+	barcode = strings.TrimSpace(barcode)
+	if len(barcode) > 50 {
+		return errors.NewValidationError("barcode too long", "barcode cannot exceed 50 characters")
+	}
+	if strings.ContainsAny(barcode, " \t") {
+		return errors.NewValidationError("invalid barcode", "barcode cannot contain whitespace")
+	}
+
+	p.Barcode = barcode
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetPreferredSupplier links the product to the supplier it is usually reordered from, or
+// unlinks it when supplierID is nil
+func (p *Product) SetPreferredSupplier(supplierID *uuid.UUID) {
+	p.SupplierID = supplierID
+	p.UpdatedAt = time.Now()
+}
+
+// SetSerialized flags whether each unit of the product is tracked by its serial number
+func (p *Product) SetSerialized(serialized bool) {
+	p.IsSerialized = serialized
+	p.UpdatedAt = time.Now()
+}
+
+// SetPromotion sets a promotional price, optionally bounded by a start and end time
+func (p *Product) SetPromotion(promoPrice decimal.Decimal, startsAt, endsAt *time.Time) error {
+	if promoPrice.LessThanOrEqual(decimal.Zero) {
+		return errors.NewInvalidPriceError(promoPrice.InexactFloat64())
+	}
+	if promoPrice.GreaterThanOrEqual(p.Price) {
+		return errors.NewValidationError("invalid promo price", "promo price must be lower than the regular price")
+	}
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return errors.NewValidationError("invalid promo period", "promo end must be after promo start")
+	}
+
+	p.PromoPrice = &promoPrice
+	p.PromoStartsAt = startsAt
+	p.PromoEndsAt = endsAt
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// ClearPromotion removes the promotional price
+func (p *Product) ClearPromotion() {
+	p.PromoPrice = nil
+	p.PromoStartsAt = nil
+	p.PromoEndsAt = nil
+	p.UpdatedAt = time.Now()
+}
+
+// ActivePromoPrice returns the promotional price if a promotion is running at the given time
+func (p *Product) ActivePromoPrice(now time.Time) *decimal.Decimal {
+	if p.PromoPrice == nil {
+		return nil
+	}
+	if p.PromoStartsAt != nil && now.Before(*p.PromoStartsAt) {
+		return nil
+	}
+	if p.PromoEndsAt != nil && !now.Before(*p.PromoEndsAt) {
+		return nil
+	}
+	return p.PromoPrice
+}
+
+// SetAvailability limits when the product can be sold. Either bound may be nil. A seasonal
+// product repeats its window every year, so both bounds are required and the window must be
+// shorter than a year.
+func (p *Product) SetAvailability(from, until *time.Time, seasonal bool) error {
+	if from != nil && until != nil && !until.After(*from) {
+		return errors.NewValidationError("invalid availability period", "available until must be after available from")
+	}
+	if seasonal {
+		if from == nil || until == nil {
+			return errors.NewValidationError("invalid availability period", "seasonal products require both available from and available until")
+		}
+		if !until.Before(from.AddDate(1, 0, 0)) {
+			return errors.NewValidationError("invalid availability period", "a seasonal window must be shorter than a year")
+		}
+	}
+
+	p.AvailableFrom = from
+	p.AvailableUntil = until
+	p.IsSeasonal = seasonal
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasAvailabilityWindow checks if the product is only sold during a limited period
+func (p *Product) HasAvailabilityWindow() bool {
+	return p.AvailableFrom != nil || p.AvailableUntil != nil
+}
+
+// IsAvailableAt checks if the given time falls within the product's availability window.
+// Products without a window are always available.
+func (p *Product) IsAvailableAt(now time.Time) bool {
+	from, until := p.availabilityWindow(now)
+	if from != nil && now.Before(*from) {
+		return false
+	}
+	if until != nil && !now.Before(*until) {
+		return false
+	}
+	return true
+}
+
+// ApplyAvailability moves an active product to inactive once it falls outside its availability
+// window, and reactivates it when its window opens again. Products deactivated by hand during
+// the window are left alone; only products that have been inactive since before the window
+// opened are reactivated. It reports whether the status changed.
+func (p *Product) ApplyAvailability(now time.Time) bool {
+	if !p.HasAvailabilityWindow() {
+		return false
+	}
+
+	available := p.IsAvailableAt(now)
+	switch {
+	case p.Status == ProductStatusActive && !available:
+		p.Status = ProductStatusInactive
+	case p.Status == ProductStatusInactive && available:
+		from, _ := p.availabilityWindow(now)
+		if from == nil || !p.UpdatedAt.Before(*from) {
+			return false
+		}
+		p.Status = ProductStatusActive
+	default:
+		return false
+	}
+
+	p.UpdatedAt = now
+	return true
+}
+
+// availabilityWindow returns the availability window that applies at the given time. For
+// seasonal products this is the most recent occurrence that started at or before now.
+func (p *Product) availabilityWindow(now time.Time) (*time.Time, *time.Time) {
+	if !p.IsSeasonal || p.AvailableFrom == nil || p.AvailableUntil == nil {
+		return p.AvailableFrom, p.AvailableUntil
+	}
+
+	years := now.Year() - p.AvailableFrom.Year()
+	from := p.AvailableFrom.AddDate(years, 0, 0)
+	if from.After(now) {
+		years--
+		from = p.AvailableFrom.AddDate(years, 0, 0)
+	}
+	until := p.AvailableUntil.AddDate(years, 0, 0)
+	return &from, &until
+}
+
 // IsActive checks if the product is active
 func (p *Product) IsActive() bool {
 	return p.Status == ProductStatusActive
 }
 
+// MarkAsDraft moves a product back to draft so it can be edited without being sold.
+// Newly created products are published unless they are marked as draft.
+func (p *Product) MarkAsDraft() {
+	p.PublishState = ProductPublishStateDraft
+	p.PublishAt = nil
+	p.PublishedAt = nil
+	p.UpdatedAt = time.Now()
+}
+
+// SubmitForReview submits a draft product for review
+func (p *Product) SubmitForReview(submittedBy uuid.UUID) error {
+	if p.PublishState != ProductPublishStateDraft {
+		return errors.NewValidationError("invalid publish state", "only draft products can be submitted for review")
+	}
+
+	p.PublishState = ProductPublishStateInReview
+	p.SubmittedBy = &submittedBy
+	p.ReviewedBy = nil
+	p.ReviewNotes = ""
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Approve approves a product under review. The product is published immediately unless
+// publishAt is in the future, in which case it is scheduled until then.
+func (p *Product) Approve(reviewedBy uuid.UUID, publishAt *time.Time, now time.Time) error {
+	if p.PublishState != ProductPublishStateInReview {
+		return errors.NewValidationError("invalid publish state", "only products in review can be approved")
+	}
+
+	p.ReviewedBy = &reviewedBy
+	p.UpdatedAt = now
+	if publishAt != nil && publishAt.After(now) {
+		p.PublishState = ProductPublishStateScheduled
+		p.PublishAt = publishAt
+		return nil
+	}
+
+	p.publish(now)
+	return nil
+}
+
+// Reject sends a product under review back to draft with the reviewer's notes
+func (p *Product) Reject(reviewedBy uuid.UUID, notes string) error {
+	if p.PublishState != ProductPublishStateInReview {
+		return errors.NewValidationError("invalid publish state", "only products in review can be rejected")
+	}
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return errors.NewValidationError("review notes are required", "notes must explain why the product was rejected")
+	}
+
+	p.PublishState = ProductPublishStateDraft
+	p.ReviewedBy = &reviewedBy
+	p.ReviewNotes = notes
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// PublishIfDue publishes a scheduled product once its publish time has passed
+func (p *Product) PublishIfDue(now time.Time) bool {
+	if p.PublishState != ProductPublishStateScheduled || p.PublishAt == nil || now.Before(*p.PublishAt) {
+		return false
+	}
+
+	p.publish(now)
+	p.UpdatedAt = now
+	return true
+}
+
+// IsPublished checks if the product is visible to POS terminals and channel syncs
+func (p *Product) IsPublished() bool {
+	return p.PublishState == ProductPublishStatePublished
+}
+
+// IsSellable checks if the product can be added to a sale
+func (p *Product) IsSellable() bool {
+	return p.IsActive() && p.IsPublished()
+}
+
 // GetProfitMargin calculates the profit margin percentage
 func (p *Product) GetProfitMargin() decimal.Decimal {
 	if p.Cost.IsZero() {
@@ -156,8 +496,24 @@ func ValidateProductStatus(status ProductStatus) error {
 	}
 }
 
+// ValidateProductPublishState validates if the publish state is valid
+func ValidateProductPublishState(state ProductPublishState) error {
+	switch state {
+	case ProductPublishStateDraft, ProductPublishStateInReview, ProductPublishStateScheduled, ProductPublishStatePublished:
+		return nil
+	default:
+		return errors.NewValidationError("invalid publish state", "publish state must be one of: draft, in_review, scheduled, published")
+	}
+}
+
 // Helper functions
 
+func (p *Product) publish(now time.Time) {
+	p.PublishState = ProductPublishStatePublished
+	p.PublishAt = nil
+	p.PublishedAt = &now
+}
+
 func validateProductInput(sku, name, category, unit string, price, cost decimal.Decimal, minStock int) error {
 	if sku == "" {
 		return errors.NewValidationError("SKU is required", "sku cannot be empty")
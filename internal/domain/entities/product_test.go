@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -437,6 +438,34 @@ func TestProduct_UpdateCost(t *testing.T) {
 	})
 }
 
+func TestProduct_ReceiveAtCost(t *testing.T) {
+	t.Run("averages into the cost on hand", func(t *testing.T) {
+		product := &Product{ID: uuid.New(), Cost: decimal.NewFromInt(10)}
+
+		err := product.ReceiveAtCost(30, 10, decimal.NewFromInt(14))
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(11).Equal(product.Cost))
+	})
+
+	t.Run("takes the received cost without stock on hand", func(t *testing.T) {
+		product := &Product{ID: uuid.New(), Cost: decimal.NewFromInt(10)}
+
+		err := product.ReceiveAtCost(0, 5, decimal.NewFromFloat(12.5))
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(12.5).Equal(product.Cost))
+	})
+
+	t.Run("invalid quantity", func(t *testing.T) {
+		product := &Product{ID: uuid.New(), Cost: decimal.NewFromInt(10)}
+
+		assert.Error(t, product.ReceiveAtCost(5, 0, decimal.NewFromInt(12)))
+		assert.Error(t, product.ReceiveAtCost(5, 1, decimal.NewFromInt(-1)))
+		assert.True(t, decimal.NewFromInt(10).Equal(product.Cost))
+	})
+}
+
 func TestProduct_ChangeStatus(t *testing.T) {
 	t.Run("valid status changes", func(t *testing.T) {
 		product := createValidProduct(t)
@@ -625,6 +654,245 @@ func TestProduct_GetProfitAmount(t *testing.T) {
 	})
 }
 
+func TestProduct_SetBarcode(t *testing.T) {
+	product := createValidProduct(t)
+
+	require.NoError(t, product.SetBarcode(" 8991234567890 "))
+	assert.Equal(t, "8991234567890", product.Barcode)
+
+	assert.Error(t, product.SetBarcode("899 123"))
+	assert.Error(t, product.SetBarcode(strings.Repeat("1", 51)))
+
+	require.NoError(t, product.SetBarcode(""))
+	assert.Empty(t, product.Barcode)
+}
+
+func TestProduct_SetPromotion(t *testing.T) {
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	t.Run("open-ended promotion", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		err := product.SetPromotion(decimal.NewFromFloat(80), nil, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, product.ActivePromoPrice(now))
+		assert.True(t, decimal.NewFromFloat(80).Equal(*product.ActivePromoPrice(now)))
+	})
+
+	t.Run("bounded promotion", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		require.NoError(t, product.SetPromotion(decimal.NewFromFloat(80), &yesterday, &tomorrow))
+
+		assert.NotNil(t, product.ActivePromoPrice(now))
+		assert.Nil(t, product.ActivePromoPrice(yesterday.Add(-time.Hour)))
+		assert.Nil(t, product.ActivePromoPrice(tomorrow))
+	})
+
+	t.Run("promo price must be below regular price", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		err := product.SetPromotion(product.Price, nil, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid promo price")
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		err := product.SetPromotion(decimal.NewFromFloat(80), &tomorrow, &yesterday)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid promo period")
+	})
+
+	t.Run("clear promotion", func(t *testing.T) {
+		product := createValidProduct(t)
+		require.NoError(t, product.SetPromotion(decimal.NewFromFloat(80), nil, nil))
+
+		product.ClearPromotion()
+
+		assert.Nil(t, product.PromoPrice)
+		assert.Nil(t, product.ActivePromoPrice(now))
+	})
+}
+
+func TestProduct_Availability(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("fixed window", func(t *testing.T) {
+		product := createValidProduct(t)
+		from := date(2026, time.March, 1)
+		until := date(2026, time.April, 1)
+
+		require.NoError(t, product.SetAvailability(&from, &until, false))
+
+		assert.False(t, product.IsAvailableAt(date(2026, time.February, 28)))
+		assert.True(t, product.IsAvailableAt(date(2026, time.March, 15)))
+		assert.False(t, product.IsAvailableAt(until))
+		assert.False(t, product.IsAvailableAt(date(2027, time.March, 15)))
+	})
+
+	t.Run("seasonal window spanning the new year", func(t *testing.T) {
+		product := createValidProduct(t)
+		from := date(2025, time.November, 15)
+		until := date(2026, time.January, 10)
+
+		require.NoError(t, product.SetAvailability(&from, &until, true))
+
+		assert.True(t, product.IsAvailableAt(date(2026, time.December, 20)))
+		assert.True(t, product.IsAvailableAt(date(2027, time.January, 5)))
+		assert.False(t, product.IsAvailableAt(date(2027, time.February, 1)))
+		assert.False(t, product.IsAvailableAt(date(2027, time.November, 1)))
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		assert.False(t, product.HasAvailabilityWindow())
+		assert.True(t, product.IsAvailableAt(time.Now()))
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		product := createValidProduct(t)
+		from := date(2026, time.March, 1)
+		until := date(2026, time.February, 1)
+
+		err := product.SetAvailability(&from, &until, false)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid availability period")
+	})
+
+	t.Run("seasonal requires both bounds", func(t *testing.T) {
+		product := createValidProduct(t)
+		from := date(2026, time.March, 1)
+
+		assert.Error(t, product.SetAvailability(&from, nil, true))
+	})
+
+	t.Run("seasonal window must be shorter than a year", func(t *testing.T) {
+		product := createValidProduct(t)
+		from := date(2026, time.March, 1)
+		until := date(2027, time.March, 1)
+
+		assert.Error(t, product.SetAvailability(&from, &until, true))
+	})
+}
+
+func TestProduct_ApplyAvailability(t *testing.T) {
+	from := time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("deactivates after the season and reactivates next season", func(t *testing.T) {
+		product := createValidProduct(t)
+		require.NoError(t, product.SetAvailability(&from, &until, true))
+
+		february := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+		assert.True(t, product.ApplyAvailability(february))
+		assert.Equal(t, ProductStatusInactive, product.Status)
+		assert.False(t, product.ApplyAvailability(february))
+
+		nextSeason := time.Date(2026, time.November, 16, 0, 0, 0, 0, time.UTC)
+		assert.True(t, product.ApplyAvailability(nextSeason))
+		assert.Equal(t, ProductStatusActive, product.Status)
+	})
+
+	t.Run("leaves products deactivated during the season alone", func(t *testing.T) {
+		product := createValidProduct(t)
+		require.NoError(t, product.SetAvailability(&from, &until, true))
+		product.Status = ProductStatusInactive
+		product.UpdatedAt = time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+		assert.False(t, product.ApplyAvailability(time.Date(2025, time.December, 2, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, ProductStatusInactive, product.Status)
+	})
+
+	t.Run("ignores discontinued products", func(t *testing.T) {
+		product := createValidProduct(t)
+		require.NoError(t, product.SetAvailability(&from, &until, true))
+		product.Status = ProductStatusDiscontinued
+
+		assert.False(t, product.ApplyAvailability(time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("ignores products without a window", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		assert.False(t, product.ApplyAvailability(time.Now()))
+		assert.Equal(t, ProductStatusActive, product.Status)
+	})
+}
+
+func TestProduct_PublishingWorkflow(t *testing.T) {
+	t.Run("new products are published", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		assert.Equal(t, ProductPublishStatePublished, product.PublishState)
+		assert.NotNil(t, product.PublishedAt)
+		assert.True(t, product.IsSellable())
+	})
+
+	t.Run("draft is hidden until approved", func(t *testing.T) {
+		product := createValidProduct(t)
+		product.MarkAsDraft()
+		assert.False(t, product.IsSellable())
+
+		submitter := uuid.New()
+		require.NoError(t, product.SubmitForReview(submitter))
+		assert.Equal(t, ProductPublishStateInReview, product.PublishState)
+		assert.Equal(t, submitter, *product.SubmittedBy)
+
+		now := time.Now()
+		require.NoError(t, product.Approve(uuid.New(), nil, now))
+		assert.Equal(t, ProductPublishStatePublished, product.PublishState)
+		assert.Equal(t, now, *product.PublishedAt)
+		assert.True(t, product.IsSellable())
+	})
+
+	t.Run("approval with future publish time schedules the product", func(t *testing.T) {
+		product := createValidProduct(t)
+		product.MarkAsDraft()
+		require.NoError(t, product.SubmitForReview(uuid.New()))
+
+		now := time.Now()
+		publishAt := now.Add(time.Hour)
+		require.NoError(t, product.Approve(uuid.New(), &publishAt, now))
+		assert.Equal(t, ProductPublishStateScheduled, product.PublishState)
+		assert.False(t, product.IsPublished())
+
+		assert.False(t, product.PublishIfDue(now))
+		assert.True(t, product.PublishIfDue(publishAt))
+		assert.Equal(t, ProductPublishStatePublished, product.PublishState)
+		assert.Nil(t, product.PublishAt)
+	})
+
+	t.Run("reject returns product to draft", func(t *testing.T) {
+		product := createValidProduct(t)
+		product.MarkAsDraft()
+		require.NoError(t, product.SubmitForReview(uuid.New()))
+
+		assert.Error(t, product.Reject(uuid.New(), " "))
+		require.NoError(t, product.Reject(uuid.New(), "missing images"))
+		assert.Equal(t, ProductPublishStateDraft, product.PublishState)
+		assert.Equal(t, "missing images", product.ReviewNotes)
+	})
+
+	t.Run("invalid transitions", func(t *testing.T) {
+		product := createValidProduct(t)
+
+		assert.Error(t, product.SubmitForReview(uuid.New()))
+		assert.Error(t, product.Approve(uuid.New(), nil, time.Now()))
+		assert.Error(t, product.Reject(uuid.New(), "notes"))
+	})
+}
+
 func TestValidateProductStatus(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -0,0 +1,195 @@
+package entities
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// DefaultUnitLanguage is the language unit names fall back to
+	DefaultUnitLanguage = "en"
+	// MaxUnitDecimalPlaces is the finest precision quantities in a unit can have
+	MaxUnitDecimalPlaces = 3
+)
+
+// unitCodePattern matches the codes units are referenced by
+var unitCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// ProductUnit represents a unit products are counted or measured in. Built-in units are
+// available to every tenant; tenants can add their own.
+type ProductUnit struct {
+	ID            uuid.UUID         `json:"id"`
+	TenantID      uuid.UUID         `json:"tenant_id"`
+	Code          string            `json:"code"`
+	Names         map[string]string `json:"names"`          // Display name by language, e.g. "en" or "id"
+	DecimalPlaces int               `json:"decimal_places"` // Decimals allowed in quantities, 0 for whole quantities
+	IsBuiltIn     bool              `json:"is_built_in"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	UpdatedBy     uuid.UUID         `json:"updated_by"`
+}
+
+// builtInProductUnits is the catalog of units every tenant can use
+var builtInProductUnits = []ProductUnit{
+	{Code: "pcs", Names: map[string]string{"en": "Pieces", "id": "Buah"}},
+	{Code: "box", Names: map[string]string{"en": "Box", "id": "Kotak"}},
+	{Code: "pack", Names: map[string]string{"en": "Pack", "id": "Bungkus"}},
+	{Code: "dozen", Names: map[string]string{"en": "Dozen", "id": "Lusin"}},
+	{Code: "kg", Names: map[string]string{"en": "Kilogram", "id": "Kilogram"}, DecimalPlaces: 3},
+	{Code: "g", Names: map[string]string{"en": "Gram", "id": "Gram"}},
+	{Code: "liter", Names: map[string]string{"en": "Liter", "id": "Liter"}, DecimalPlaces: 3},
+	{Code: "ml", Names: map[string]string{"en": "Milliliter", "id": "Mililiter"}},
+	{Code: "m", Names: map[string]string{"en": "Meter", "id": "Meter"}, DecimalPlaces: 2},
+}
+
+// unitCodeAliases maps spellings of built-in units found in free-text units to their codes
+var unitCodeAliases = map[string]string{
+	"pc":       "pcs",
+	"piece":    "pcs",
+	"pieces":   "pcs",
+	"kilogram": "kg",
+	"kgs":      "kg",
+	"gram":     "g",
+	"gr":       "g",
+	"l":        "liter",
+	"ltr":      "liter",
+	"litre":    "liter",
+	"meter":    "m",
+}
+
+// BuiltInProductUnits returns the built-in unit catalog
+func BuiltInProductUnits() []*ProductUnit {
+	units := make([]*ProductUnit, 0, len(builtInProductUnits))
+	for _, unit := range builtInProductUnits {
+		units = append(units, builtInProductUnit(unit))
+	}
+	return units
+}
+
+// BuiltInProductUnit returns the built-in unit with the given code, or nil if there is none
+func BuiltInProductUnit(code string) *ProductUnit {
+	code = NormalizeUnitCode(code)
+	for _, unit := range builtInProductUnits {
+		if unit.Code == code {
+			return builtInProductUnit(unit)
+		}
+	}
+	return nil
+}
+
+func builtInProductUnit(unit ProductUnit) *ProductUnit {
+	names := make(map[string]string, len(unit.Names))
+	for language, name := range unit.Names {
+		names[language] = name
+	}
+	unit.Names = names
+	unit.IsBuiltIn = true
+	return &unit
+}
+
+// NormalizeUnitCode lowercases a unit code and maps common spellings of built-in units, such
+// as "ltr" or "Pieces", to their codes
+func NormalizeUnitCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if alias, ok := unitCodeAliases[code]; ok {
+		return alias
+	}
+	return code
+}
+
+// NewProductUnit creates a tenant's own unit
+func NewProductUnit(tenantID uuid.UUID, code string, names map[string]string, decimalPlaces int, createdBy uuid.UUID) (*ProductUnit, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	code = NormalizeUnitCode(code)
+	if !unitCodePattern.MatchString(code) {
+		return nil, errors.NewValidationError("invalid unit code", "code must start with a letter and contain at most 20 lowercase letters, digits and underscores")
+	}
+	if BuiltInProductUnit(code) != nil {
+		return nil, errors.NewConflictError("unit '" + code + "' is a built-in unit")
+	}
+
+	now := time.Now()
+	unit := &ProductUnit{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Code:      code,
+		CreatedAt: now,
+	}
+
+	if err := unit.Update(names, decimalPlaces, createdBy); err != nil {
+		return nil, err
+	}
+
+	return unit, nil
+}
+
+// Update replaces the unit's display names and allowed decimals
+func (u *ProductUnit) Update(names map[string]string, decimalPlaces int, updatedBy uuid.UUID) error {
+	if u.IsBuiltIn {
+		return errors.NewValidationError("built-in unit", "built-in units cannot be changed")
+	}
+	if decimalPlaces < 0 || decimalPlaces > MaxUnitDecimalPlaces {
+		return errors.NewValidationError("invalid decimal places", "decimal places must be between 0 and 3")
+	}
+
+	cleaned := make(map[string]string, len(names))
+	for language, name := range names {
+		language = strings.ToLower(strings.TrimSpace(language))
+		name = strings.TrimSpace(name)
+		if len(language) < 2 || len(language) > 10 {
+			return errors.NewValidationError("invalid language", "language must be a code such as \"en\" or \"id\"")
+		}
+		if name == "" || len(name) > 50 {
+			return errors.NewValidationError("invalid unit name", "names must be between 1 and 50 characters")
+		}
+		cleaned[language] = name
+	}
+	if cleaned[DefaultUnitLanguage] == "" {
+		return errors.NewValidationError("unit name is required", "an English (\"en\") name is required")
+	}
+
+	u.Names = cleaned
+	u.DecimalPlaces = decimalPlaces
+	u.UpdatedBy = updatedBy
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// DisplayName returns the unit's name in a language such as "id" or "id-ID", falling back to
+// the base language, then English
+func (u *ProductUnit) DisplayName(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if name, ok := u.Names[language]; ok {
+		return name
+	}
+	if base, _, found := strings.Cut(language, "-"); found {
+		if name, ok := u.Names[base]; ok {
+			return name
+		}
+	}
+	if name, ok := u.Names[DefaultUnitLanguage]; ok {
+		return name
+	}
+	return u.Code
+}
+
+// ValidateQuantity checks a quantity has no more decimals than the unit allows
+func (u *ProductUnit) ValidateQuantity(quantity decimal.Decimal) error {
+	if !quantity.Equal(quantity.Truncate(int32(u.DecimalPlaces))) {
+		if u.DecimalPlaces == 0 {
+			return errors.NewValidationError("invalid quantity", "quantities in "+u.Code+" must be whole numbers")
+		}
+		return errors.NewValidationError("invalid quantity", "quantities in "+u.Code+" allow at most "+strconv.Itoa(u.DecimalPlaces)+" decimal places")
+	}
+	return nil
+}
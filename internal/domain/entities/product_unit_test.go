@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeUnitCode(t *testing.T) {
+	assert.Equal(t, "pcs", NormalizeUnitCode(" PCS "))
+	assert.Equal(t, "pcs", NormalizeUnitCode("Pieces"))
+	assert.Equal(t, "liter", NormalizeUnitCode("ltr"))
+	assert.Equal(t, "kg", NormalizeUnitCode("Kilogram"))
+	assert.Equal(t, "crate", NormalizeUnitCode("Crate"))
+}
+
+func TestBuiltInProductUnit(t *testing.T) {
+	t.Run("known unit", func(t *testing.T) {
+		unit := BuiltInProductUnit("ltr")
+
+		require.NotNil(t, unit)
+		assert.Equal(t, "liter", unit.Code)
+		assert.True(t, unit.IsBuiltIn)
+		assert.Equal(t, 3, unit.DecimalPlaces)
+	})
+
+	t.Run("unknown unit", func(t *testing.T) {
+		assert.Nil(t, BuiltInProductUnit("crate"))
+	})
+
+	t.Run("catalog cannot be modified through a copy", func(t *testing.T) {
+		BuiltInProductUnit("pcs").Names["en"] = "Changed"
+
+		assert.Equal(t, "Pieces", BuiltInProductUnit("pcs").Names["en"])
+	})
+
+	t.Run("built-in units cannot be updated", func(t *testing.T) {
+		err := BuiltInProductUnit("pcs").Update(map[string]string{"en": "Units"}, 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "built-in unit")
+	})
+}
+
+func TestNewProductUnit(t *testing.T) {
+	t.Run("valid unit creation", func(t *testing.T) {
+		tenantID := uuid.New()
+
+		unit, err := NewProductUnit(tenantID, "Crate", map[string]string{"EN": " Crate ", "id": "Peti"}, 0, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, unit.TenantID)
+		assert.Equal(t, "crate", unit.Code)
+		assert.Equal(t, map[string]string{"en": "Crate", "id": "Peti"}, unit.Names)
+		assert.False(t, unit.IsBuiltIn)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		unit, err := NewProductUnit(uuid.New(), "2 crates", map[string]string{"en": "Crate"}, 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, unit)
+		assert.Contains(t, err.Error(), "invalid unit code")
+	})
+
+	t.Run("built-in code", func(t *testing.T) {
+		unit, err := NewProductUnit(uuid.New(), "Litre", map[string]string{"en": "Litre"}, 3, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, unit)
+		assert.Contains(t, err.Error(), "built-in unit")
+	})
+
+	t.Run("english name is required", func(t *testing.T) {
+		unit, err := NewProductUnit(uuid.New(), "crate", map[string]string{"id": "Peti"}, 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, unit)
+		assert.Contains(t, err.Error(), "unit name is required")
+	})
+
+	t.Run("invalid decimal places", func(t *testing.T) {
+		unit, err := NewProductUnit(uuid.New(), "crate", map[string]string{"en": "Crate"}, MaxUnitDecimalPlaces+1, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, unit)
+		assert.Contains(t, err.Error(), "invalid decimal places")
+	})
+}
+
+func TestProductUnit_DisplayName(t *testing.T) {
+	unit := BuiltInProductUnit("pcs")
+
+	assert.Equal(t, "Buah", unit.DisplayName("id"))
+	assert.Equal(t, "Buah", unit.DisplayName("id-ID"))
+	assert.Equal(t, "Pieces", unit.DisplayName("fr"))
+	assert.Equal(t, "Pieces", unit.DisplayName(""))
+}
+
+func TestProductUnit_ValidateQuantity(t *testing.T) {
+	assert.NoError(t, BuiltInProductUnit("pcs").ValidateQuantity(decimal.NewFromInt(3)))
+	assert.Error(t, BuiltInProductUnit("pcs").ValidateQuantity(decimal.RequireFromString("1.5")))
+	assert.NoError(t, BuiltInProductUnit("kg").ValidateQuantity(decimal.RequireFromString("1.255")))
+
+	err := BuiltInProductUnit("kg").ValidateQuantity(decimal.RequireFromString("1.2555"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid quantity")
+}
+
+func TestNewProduct_NormalizesUnit(t *testing.T) {
+	product, err := NewProduct(uuid.New(), "MLK-001", "Fresh Milk", "", "Dairy", "Ltr", decimal.NewFromInt(18000), decimal.NewFromInt(15000), 5, uuid.New())
+
+	require.NoError(t, err)
+	assert.Equal(t, "liter", product.Unit)
+}
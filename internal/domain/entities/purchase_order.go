@@ -0,0 +1,310 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PurchaseOrderStatus represents the lifecycle status of a purchase order
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft     PurchaseOrderStatus = "draft"     // Being prepared, items can still change
+	PurchaseOrderStatusOrdered   PurchaseOrderStatus = "ordered"   // Sent to the supplier, awaiting delivery
+	PurchaseOrderStatusReceived  PurchaseOrderStatus = "received"  // Every item has been delivered
+	PurchaseOrderStatusCancelled PurchaseOrderStatus = "cancelled" // Abandoned before anything was received
+)
+
+// PurchaseOrder represents an order of stock from a supplier
+type PurchaseOrder struct {
+	ID            uuid.UUID           `json:"id"`
+	TenantID      uuid.UUID           `json:"tenant_id"`
+	PONumber      string              `json:"po_number"`
+	SupplierName  string              `json:"supplier_name"`
+	SupplierEmail string              `json:"supplier_email,omitempty"`
+	SupplierPhone string              `json:"supplier_phone,omitempty"`
+	Status        PurchaseOrderStatus `json:"status"`
+	Items         []PurchaseOrderItem `json:"items"`
+	TotalCost     decimal.Decimal     `json:"total_cost"`
+	Notes         string              `json:"notes,omitempty"`
+	ExpectedAt    *time.Time          `json:"expected_at,omitempty"` // Expected delivery date
+	OrderedAt     *time.Time          `json:"ordered_at,omitempty"`
+	ReceivedAt    *time.Time          `json:"received_at,omitempty"` // When the last item was received
+	CancelledAt   *time.Time          `json:"cancelled_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	CreatedBy     uuid.UUID           `json:"created_by"`
+}
+
+// PurchaseOrderItem represents a product line on a purchase order
+type PurchaseOrderItem struct {
+	ID              uuid.UUID       `json:"id"`
+	PurchaseOrderID uuid.UUID       `json:"purchase_order_id"`
+	ProductID       uuid.UUID       `json:"product_id"`
+	ProductSKU      string          `json:"product_sku"`
+	ProductName     string          `json:"product_name"`
+	Quantity        int             `json:"quantity"`
+	ReceivedQty     int             `json:"received_qty"`
+	UnitCost        decimal.Decimal `json:"unit_cost"`
+	TotalCost       decimal.Decimal `json:"total_cost"`
+}
+
+// PurchaseOrderReceiptLine requests a delivered quantity of a purchase order item to be received
+type PurchaseOrderReceiptLine struct {
+	ItemID   uuid.UUID `json:"item_id" validate:"required"`
+	Quantity int       `json:"quantity" validate:"required,min=1"`
+}
+
+// NewPurchaseOrder creates a new draft purchase order
+func NewPurchaseOrder(tenantID uuid.UUID, poNumber, supplierName, supplierEmail, supplierPhone string, createdBy uuid.UUID) (*PurchaseOrder, error) {
+	if strings.TrimSpace(poNumber) == "" {
+		return nil, errors.NewValidationError("PO number is required", "po number cannot be empty")
+	}
+	if err := validateSupplierInput(supplierName, supplierEmail); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order := &PurchaseOrder{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		PONumber:      poNumber,
+		SupplierName:  strings.TrimSpace(supplierName),
+		SupplierEmail: strings.TrimSpace(supplierEmail),
+		SupplierPhone: strings.TrimSpace(supplierPhone),
+		Status:        PurchaseOrderStatusDraft,
+		Items:         []PurchaseOrderItem{},
+		TotalCost:     decimal.Zero,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		CreatedBy:     createdBy,
+	}
+
+	return order, nil
+}
+
+// UpdateSupplier updates the supplier details of a draft purchase order
+func (po *PurchaseOrder) UpdateSupplier(supplierName, supplierEmail, supplierPhone string) error {
+	if !po.IsDraft() {
+		return errors.NewValidationError("invalid purchase order status", "only draft purchase orders can be modified")
+	}
+	if err := validateSupplierInput(supplierName, supplierEmail); err != nil {
+		return err
+	}
+
+	po.SupplierName = strings.TrimSpace(supplierName)
+	po.SupplierEmail = strings.TrimSpace(supplierEmail)
+	po.SupplierPhone = strings.TrimSpace(supplierPhone)
+	po.UpdatedAt = time.Now()
+	return nil
+}
+
+// AddItem adds a product line to a draft purchase order. Adding a product already on the
+// order increases its quantity and replaces its unit cost.
+func (po *PurchaseOrder) AddItem(product *Product, quantity int, unitCost decimal.Decimal) error {
+	if !po.IsDraft() {
+		return errors.NewValidationError("invalid purchase order status", "only draft purchase orders can be modified")
+	}
+	if product == nil {
+		return errors.NewValidationError("product is required", "product cannot be nil")
+	}
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+	if unitCost.LessThan(decimal.Zero) {
+		return errors.NewValidationError("invalid unit cost", "unit cost cannot be negative")
+	}
+
+	for i := range po.Items {
+		if po.Items[i].ProductID == product.ID {
+			po.Items[i].Quantity += quantity
+			po.Items[i].UnitCost = unitCost
+			po.Items[i].TotalCost = unitCost.Mul(decimal.NewFromInt(int64(po.Items[i].Quantity)))
+			po.calculateTotal()
+			return nil
+		}
+	}
+
+	po.Items = append(po.Items, PurchaseOrderItem{
+		ID:              uuid.New(),
+		PurchaseOrderID: po.ID,
+		ProductID:       product.ID,
+		ProductSKU:      product.SKU,
+		ProductName:     product.Name,
+		Quantity:        quantity,
+		UnitCost:        unitCost,
+		TotalCost:       unitCost.Mul(decimal.NewFromInt(int64(quantity))),
+	})
+	po.calculateTotal()
+	return nil
+}
+
+// ClearItems removes all product lines from a draft purchase order
+func (po *PurchaseOrder) ClearItems() error {
+	if !po.IsDraft() {
+		return errors.NewValidationError("invalid purchase order status", "only draft purchase orders can be modified")
+	}
+
+	po.Items = []PurchaseOrderItem{}
+	po.calculateTotal()
+	return nil
+}
+
+// MarkOrdered records that the purchase order has been sent to the supplier
+func (po *PurchaseOrder) MarkOrdered() error {
+	if !po.IsDraft() {
+		return errors.NewValidationError("invalid purchase order status", "only draft purchase orders can be ordered")
+	}
+	if len(po.Items) == 0 {
+		return errors.NewValidationError("purchase order has no items", "add at least one item before ordering")
+	}
+
+	now := time.Now()
+	po.Status = PurchaseOrderStatusOrdered
+	po.OrderedAt = &now
+	po.UpdatedAt = now
+	return nil
+}
+
+// Receive records delivered quantities and returns the items received, with Quantity set to
+// the amount received now. Without lines, everything still outstanding is received. The
+// order becomes received once every item has been delivered in full.
+func (po *PurchaseOrder) Receive(lines []PurchaseOrderReceiptLine) ([]PurchaseOrderItem, error) {
+	if po.Status != PurchaseOrderStatusOrdered {
+		return nil, errors.NewValidationError("invalid purchase order status", "only ordered purchase orders can be received")
+	}
+
+	if len(lines) == 0 {
+		for _, item := range po.Items {
+			if outstanding := item.OutstandingQty(); outstanding > 0 {
+				lines = append(lines, PurchaseOrderReceiptLine{ItemID: item.ID, Quantity: outstanding})
+			}
+		}
+	}
+
+	// Validate every line before recording anything
+	requested := make(map[uuid.UUID]int)
+	for _, line := range lines {
+		item := po.findItem(line.ItemID)
+		if item == nil {
+			return nil, errors.NewNotFoundError("purchase order item")
+		}
+		if line.Quantity <= 0 {
+			return nil, errors.NewInvalidQuantityError(line.Quantity)
+		}
+		requested[line.ItemID] += line.Quantity
+		if requested[line.ItemID] > item.OutstandingQty() {
+			return nil, errors.NewValidationError("received quantity too large", "cannot receive more than the outstanding quantity of "+item.ProductName)
+		}
+	}
+
+	var received []PurchaseOrderItem
+	for i := range po.Items {
+		item := &po.Items[i]
+		quantity, ok := requested[item.ID]
+		if !ok {
+			continue
+		}
+
+		item.ReceivedQty += quantity
+		delivered := *item
+		delivered.Quantity = quantity
+		received = append(received, delivered)
+	}
+
+	now := time.Now()
+	if po.IsFullyReceived() {
+		po.Status = PurchaseOrderStatusReceived
+		po.ReceivedAt = &now
+	}
+	po.UpdatedAt = now
+	return received, nil
+}
+
+// Cancel cancels a purchase order that has not received any stock
+func (po *PurchaseOrder) Cancel() error {
+	if po.Status != PurchaseOrderStatusDraft && po.Status != PurchaseOrderStatusOrdered {
+		return errors.NewValidationError("invalid purchase order status", "only draft or ordered purchase orders can be cancelled")
+	}
+	for _, item := range po.Items {
+		if item.ReceivedQty > 0 {
+			return errors.NewValidationError("purchase order partially received", "purchase orders with received stock cannot be cancelled")
+		}
+	}
+
+	now := time.Now()
+	po.Status = PurchaseOrderStatusCancelled
+	po.CancelledAt = &now
+	po.UpdatedAt = now
+	return nil
+}
+
+// IsDraft checks if the purchase order can still be edited
+func (po *PurchaseOrder) IsDraft() bool {
+	return po.Status == PurchaseOrderStatusDraft
+}
+
+// IsFullyReceived checks if every item has been delivered in full
+func (po *PurchaseOrder) IsFullyReceived() bool {
+	for _, item := range po.Items {
+		if item.OutstandingQty() > 0 {
+			return false
+		}
+	}
+	return len(po.Items) > 0
+}
+
+// OutstandingQty returns the quantity still awaiting delivery
+func (i *PurchaseOrderItem) OutstandingQty() int {
+	return i.Quantity - i.ReceivedQty
+}
+
+// ValidatePurchaseOrderStatus validates purchase order status
+func ValidatePurchaseOrderStatus(status PurchaseOrderStatus) error {
+	switch status {
+	case PurchaseOrderStatusDraft, PurchaseOrderStatusOrdered, PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled:
+		return nil
+	default:
+		return errors.NewValidationError("invalid purchase order status", "status must be one of: draft, ordered, received, cancelled")
+	}
+}
+
+// findItem finds a purchase order item by ID
+func (po *PurchaseOrder) findItem(itemID uuid.UUID) *PurchaseOrderItem {
+	for i := range po.Items {
+		if po.Items[i].ID == itemID {
+			return &po.Items[i]
+		}
+	}
+	return nil
+}
+
+// calculateTotal recalculates the order total from its items
+func (po *PurchaseOrder) calculateTotal() {
+	total := decimal.Zero
+	for _, item := range po.Items {
+		total = total.Add(item.TotalCost)
+	}
+	po.TotalCost = total
+	po.UpdatedAt = time.Now()
+}
+
+// validateSupplierInput validates supplier details
+func validateSupplierInput(name, email string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("supplier name is required", "supplier name cannot be empty")
+	}
+	if len(name) > 255 {
+		return errors.NewValidationError("supplier name too long", "supplier name cannot exceed 255 characters")
+	}
+	if email = strings.TrimSpace(email); email != "" && !strings.Contains(email, "@") {
+		return errors.NewValidationError("invalid supplier email", "supplier email must be a valid email address")
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxPurchaseOrderScanBatchSize caps the scans a receiving station submits in one batch
+const MaxPurchaseOrderScanBatchSize = 500
+
+// PurchaseOrderReceivingStatus represents the status of a receiving session
+type PurchaseOrderReceivingStatus string
+
+const (
+	PurchaseOrderReceivingStatusOpen      PurchaseOrderReceivingStatus = "open"      // Being scanned
+	PurchaseOrderReceivingStatusCompleted PurchaseOrderReceivingStatus = "completed" // Scanned quantities received into stock
+	PurchaseOrderReceivingStatusCancelled PurchaseOrderReceivingStatus = "cancelled"
+)
+
+// PurchaseOrderScanStatus represents what became of a barcode scanned while receiving
+type PurchaseOrderScanStatus string
+
+const (
+	PurchaseOrderScanStatusRecorded       PurchaseOrderScanStatus = "recorded"        // Within the quantity still outstanding
+	PurchaseOrderScanStatusOverReceived   PurchaseOrderScanStatus = "over_received"   // Recorded, but more is now scanned than is outstanding
+	PurchaseOrderScanStatusNotOrdered     PurchaseOrderScanStatus = "not_ordered"     // The product is not on the purchase order
+	PurchaseOrderScanStatusUnknownBarcode PurchaseOrderScanStatus = "unknown_barcode" // No product has the barcode
+	PurchaseOrderScanStatusInvalid        PurchaseOrderScanStatus = "invalid"
+)
+
+// PurchaseOrderReceiptFlag compares the units of an item scanned in a session with the units
+// still outstanding on the order
+type PurchaseOrderReceiptFlag string
+
+const (
+	PurchaseOrderReceiptUnder    PurchaseOrderReceiptFlag = "under"    // Fewer scanned than outstanding; the rest stays on order
+	PurchaseOrderReceiptComplete PurchaseOrderReceiptFlag = "complete" // Everything outstanding scanned
+	PurchaseOrderReceiptOver     PurchaseOrderReceiptFlag = "over"     // More scanned than outstanding
+)
+
+// PurchaseOrderReceiving represents a session receiving a delivery against a purchase order by
+// scanning product barcodes. A purchase order may be received over several sessions, one open
+// at a time; completing a session receives its scanned quantities into stock.
+type PurchaseOrderReceiving struct {
+	ID              uuid.UUID                    `json:"id"`
+	TenantID        uuid.UUID                    `json:"tenant_id"`
+	PurchaseOrderID uuid.UUID                    `json:"purchase_order_id"`
+	Status          PurchaseOrderReceivingStatus `json:"status"`
+	Notes           string                       `json:"notes,omitempty"`
+	Lines           []PurchaseOrderReceivingLine `json:"lines"`
+	StartedBy       uuid.UUID                    `json:"started_by"`
+	StartedAt       time.Time                    `json:"started_at"`
+	CompletedBy     *uuid.UUID                   `json:"completed_by,omitempty"`
+	CompletedAt     *time.Time                   `json:"completed_at,omitempty"` // Also set when cancelled
+	CreatedAt       time.Time                    `json:"created_at"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+}
+
+// PurchaseOrderReceivingLine represents the units of a purchase order item scanned in a
+// session. An item has one line per session; each scan adds to its quantity.
+type PurchaseOrderReceivingLine struct {
+	ID          uuid.UUID `json:"id"`
+	ReceivingID uuid.UUID `json:"receiving_id"`
+	ItemID      uuid.UUID `json:"item_id"` // Purchase order item
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductSKU  string    `json:"product_sku"`
+	ProductName string    `json:"product_name"`
+	Barcode     string    `json:"barcode"` // Last scanned
+	ScannedQty  int       `json:"scanned_qty"`
+	ScanCount   int       `json:"scan_count"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PurchaseOrderReceivingScan represents a barcode scanned at a receiving station
+type PurchaseOrderReceivingScan struct {
+	Barcode  string `json:"barcode" validate:"required"`
+	Quantity int    `json:"quantity,omitempty"` // Units scanned, 1 when omitted; negative takes back units scanned by mistake
+}
+
+// PurchaseOrderScanResult represents the outcome of a scan, returned to the receiving station
+// straight away so an over-receipt can be set aside before the session is completed
+type PurchaseOrderScanResult struct {
+	Barcode        string                   `json:"barcode"`
+	Status         PurchaseOrderScanStatus  `json:"status"`
+	Message        string                   `json:"message,omitempty"`
+	ItemID         *uuid.UUID               `json:"item_id,omitempty"`
+	ProductSKU     string                   `json:"product_sku,omitempty"`
+	ProductName    string                   `json:"product_name,omitempty"`
+	OrderedQty     *int                     `json:"ordered_qty,omitempty"`
+	OutstandingQty *int                     `json:"outstanding_qty,omitempty"` // Awaiting delivery before this session
+	ScannedQty     *int                     `json:"scanned_qty,omitempty"`     // Scanned in this session so far
+	Receipt        PurchaseOrderReceiptFlag `json:"receipt,omitempty"`
+}
+
+// PurchaseOrderReceivingDiscrepancies summarizes how the units scanned in a session differ from
+// the units outstanding on the purchase order
+type PurchaseOrderReceivingDiscrepancies struct {
+	ReceivingID uuid.UUID                           `json:"receiving_id"`
+	UnitsOver   int                                 `json:"units_over"`  // Scanned beyond the outstanding quantities
+	UnitsShort  int                                 `json:"units_short"` // Outstanding but not scanned
+	Lines       []PurchaseOrderReceivingDiscrepancy `json:"lines"`       // Items not received in full, or over-received
+}
+
+// PurchaseOrderReceivingDiscrepancy compares an item's scanned and outstanding units
+type PurchaseOrderReceivingDiscrepancy struct {
+	ItemID         uuid.UUID                `json:"item_id"`
+	ProductID      uuid.UUID                `json:"product_id"`
+	ProductSKU     string                   `json:"product_sku"`
+	ProductName    string                   `json:"product_name"`
+	OutstandingQty int                      `json:"outstanding_qty"`
+	ScannedQty     int                      `json:"scanned_qty"`
+	Difference     int                      `json:"difference"` // Scanned less outstanding
+	Receipt        PurchaseOrderReceiptFlag `json:"receipt"`
+}
+
+// NewPurchaseOrderReceiving starts a receiving session against an ordered purchase order
+func NewPurchaseOrderReceiving(order *PurchaseOrder, notes string, startedBy uuid.UUID, now time.Time) (*PurchaseOrderReceiving, error) {
+	if order == nil {
+		return nil, errors.NewValidationError("purchase order is required", "purchase order cannot be nil")
+	}
+	if startedBy == uuid.Nil {
+		return nil, errors.NewValidationError("user ID is required", "user ID cannot be empty")
+	}
+	if order.Status != PurchaseOrderStatusOrdered {
+		return nil, errors.NewValidationError("invalid purchase order status", "only ordered purchase orders can be received")
+	}
+
+	notes = strings.TrimSpace(notes)
+	if len(notes) > 500 {
+		return nil, errors.NewValidationError("notes too long", "notes cannot exceed 500 characters")
+	}
+
+	return &PurchaseOrderReceiving{
+		ID:              uuid.New(),
+		TenantID:        order.TenantID,
+		PurchaseOrderID: order.ID,
+		Status:          PurchaseOrderReceivingStatusOpen,
+		Notes:           notes,
+		Lines:           []PurchaseOrderReceivingLine{},
+		StartedBy:       startedBy,
+		StartedAt:       now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// IsOpen checks if the session is still being scanned
+func (r *PurchaseOrderReceiving) IsOpen() bool {
+	return r.Status == PurchaseOrderReceivingStatusOpen
+}
+
+// RecordScan adds the units of a scanned product to the line of its purchase order item. A
+// scan taking the item above its outstanding quantity is recorded but reported as
+// over-received, as the excess cannot be received until it is taken back. It returns the
+// item and its line.
+func (r *PurchaseOrderReceiving) RecordScan(order *PurchaseOrder, product *Product, scan PurchaseOrderReceivingScan, now time.Time) (*PurchaseOrderItem, *PurchaseOrderReceivingLine, PurchaseOrderScanStatus, error) {
+	if !r.IsOpen() {
+		return nil, nil, "", errors.NewValidationError("receiving session not open", "scans can only be recorded on open receiving sessions")
+	}
+	if product == nil {
+		return nil, nil, PurchaseOrderScanStatusUnknownBarcode, errors.NewValidationError("unknown barcode", "no product has the barcode")
+	}
+
+	quantity := scan.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	var item *PurchaseOrderItem
+	for i := range order.Items {
+		if order.Items[i].ProductID == product.ID {
+			item = &order.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, nil, PurchaseOrderScanStatusNotOrdered, errors.NewValidationError("product not ordered", product.Name+" is not on purchase order "+order.PONumber)
+	}
+
+	line := r.findLine(item.ID)
+	scanned := quantity
+	if line != nil {
+		scanned += line.ScannedQty
+	}
+	if scanned < 0 {
+		return item, line, PurchaseOrderScanStatusInvalid, errors.NewValidationError("invalid quantity", "cannot take back more units of "+item.ProductName+" than were scanned")
+	}
+
+	if line == nil {
+		r.Lines = append(r.Lines, PurchaseOrderReceivingLine{
+			ID:          uuid.New(),
+			ReceivingID: r.ID,
+			ItemID:      item.ID,
+			ProductID:   item.ProductID,
+			ProductSKU:  item.ProductSKU,
+			ProductName: item.ProductName,
+		})
+		line = &r.Lines[len(r.Lines)-1]
+	}
+	line.Barcode = strings.TrimSpace(scan.Barcode)
+	line.ScannedQty = scanned
+	line.ScanCount++
+	line.UpdatedAt = now
+	r.UpdatedAt = now
+
+	if scanned > item.OutstandingQty() {
+		return item, line, PurchaseOrderScanStatusOverReceived, nil
+	}
+	return item, line, PurchaseOrderScanStatusRecorded, nil
+}
+
+// Discrepancies compares the units scanned in the session with the units outstanding on each
+// item of the order. Items not scanned at all count as short.
+func (r *PurchaseOrderReceiving) Discrepancies(order *PurchaseOrder) *PurchaseOrderReceivingDiscrepancies {
+	discrepancies := &PurchaseOrderReceivingDiscrepancies{
+		ReceivingID: r.ID,
+		Lines:       []PurchaseOrderReceivingDiscrepancy{},
+	}
+	for _, item := range order.Items {
+		scanned := 0
+		if line := r.findLine(item.ID); line != nil {
+			scanned = line.ScannedQty
+		}
+		outstanding := item.OutstandingQty()
+		difference := scanned - outstanding
+		if difference == 0 {
+			continue
+		}
+
+		if difference > 0 {
+			discrepancies.UnitsOver += difference
+		} else {
+			discrepancies.UnitsShort -= difference
+		}
+		discrepancies.Lines = append(discrepancies.Lines, PurchaseOrderReceivingDiscrepancy{
+			ItemID:         item.ID,
+			ProductID:      item.ProductID,
+			ProductSKU:     item.ProductSKU,
+			ProductName:    item.ProductName,
+			OutstandingQty: outstanding,
+			ScannedQty:     scanned,
+			Difference:     difference,
+			Receipt:        receiptFlag(scanned, outstanding),
+		})
+	}
+	return discrepancies
+}
+
+// Complete closes the session and returns the receipt lines of the units scanned, to be
+// received on the purchase order. Over-received units must be taken back first.
+func (r *PurchaseOrderReceiving) Complete(order *PurchaseOrder, userID uuid.UUID, now time.Time) ([]PurchaseOrderReceiptLine, error) {
+	if !r.IsOpen() {
+		return nil, errors.NewValidationError("receiving session not open", "only open receiving sessions can be completed")
+	}
+
+	var lines []PurchaseOrderReceiptLine
+	for _, line := range r.Lines {
+		if line.ScannedQty == 0 {
+			continue
+		}
+		item := order.findItem(line.ItemID)
+		if item == nil {
+			return nil, errors.NewNotFoundError("purchase order item")
+		}
+		if line.ScannedQty > item.OutstandingQty() {
+			return nil, errors.NewValidationError("over-received", "take back the units of "+item.ProductName+" scanned beyond the outstanding quantity before completing")
+		}
+		lines = append(lines, PurchaseOrderReceiptLine{ItemID: line.ItemID, Quantity: line.ScannedQty})
+	}
+	if len(lines) == 0 {
+		return nil, errors.NewValidationError("receiving session empty", "scan at least one unit before completing the receiving session")
+	}
+
+	r.Status = PurchaseOrderReceivingStatusCompleted
+	r.CompletedBy = &userID
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+	return lines, nil
+}
+
+// Cancel abandons the session without receiving anything
+func (r *PurchaseOrderReceiving) Cancel(userID uuid.UUID, now time.Time) error {
+	if !r.IsOpen() {
+		return errors.NewValidationError("receiving session not open", "only open receiving sessions can be cancelled")
+	}
+
+	r.Status = PurchaseOrderReceivingStatusCancelled
+	r.CompletedBy = &userID
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// NewPurchaseOrderScanResult reports the outcome of a scan with the item's line as it stands
+func NewPurchaseOrderScanResult(scan PurchaseOrderReceivingScan, status PurchaseOrderScanStatus, item *PurchaseOrderItem, line *PurchaseOrderReceivingLine, err error) PurchaseOrderScanResult {
+	result := PurchaseOrderScanResult{
+		Barcode: scan.Barcode,
+		Status:  status,
+	}
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			result.Message = appErr.Details
+		} else {
+			result.Message = err.Error()
+		}
+	}
+	if item != nil {
+		itemID := item.ID
+		ordered, outstanding, scanned := item.Quantity, item.OutstandingQty(), 0
+		if line != nil {
+			scanned = line.ScannedQty
+		}
+		result.ItemID = &itemID
+		result.ProductSKU = item.ProductSKU
+		result.ProductName = item.ProductName
+		result.OrderedQty = &ordered
+		result.OutstandingQty = &outstanding
+		result.ScannedQty = &scanned
+		result.Receipt = receiptFlag(scanned, outstanding)
+	}
+	return result
+}
+
+// ValidatePurchaseOrderReceivingStatus validates receiving session status
+func ValidatePurchaseOrderReceivingStatus(status PurchaseOrderReceivingStatus) error {
+	switch status {
+	case PurchaseOrderReceivingStatusOpen, PurchaseOrderReceivingStatusCompleted, PurchaseOrderReceivingStatusCancelled:
+		return nil
+	default:
+		return errors.NewValidationError("invalid receiving session status", "status must be one of: open, completed, cancelled")
+	}
+}
+
+// findLine finds the line of a purchase order item
+func (r *PurchaseOrderReceiving) findLine(itemID uuid.UUID) *PurchaseOrderReceivingLine {
+	for i := range r.Lines {
+		if r.Lines[i].ItemID == itemID {
+			return &r.Lines[i]
+		}
+	}
+	return nil
+}
+
+// receiptFlag compares scanned units with outstanding units
+func receiptFlag(scanned, outstanding int) PurchaseOrderReceiptFlag {
+	switch {
+	case scanned < outstanding:
+		return PurchaseOrderReceiptUnder
+	case scanned > outstanding:
+		return PurchaseOrderReceiptOver
+	default:
+		return PurchaseOrderReceiptComplete
+	}
+}
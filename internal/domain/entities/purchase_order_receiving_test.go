@@ -0,0 +1,236 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReceivingOrder(t *testing.T, products ...*Product) *PurchaseOrder {
+	order, err := NewPurchaseOrder(uuid.New(), "PO-001", "Acme Supplies", "", "", uuid.New())
+	require.NoError(t, err)
+	for _, product := range products {
+		require.NoError(t, order.AddItem(product, 10, decimal.NewFromInt(5)))
+	}
+	require.NoError(t, order.MarkOrdered())
+	return order
+}
+
+func TestNewPurchaseOrderReceiving(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	product := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget"}
+
+	t.Run("valid receiving session", func(t *testing.T) {
+		order := newReceivingOrder(t, product)
+		userID := uuid.New()
+
+		receiving, err := NewPurchaseOrderReceiving(order, " dock 2 ", userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.TenantID, receiving.TenantID)
+		assert.Equal(t, order.ID, receiving.PurchaseOrderID)
+		assert.Equal(t, PurchaseOrderReceivingStatusOpen, receiving.Status)
+		assert.Equal(t, "dock 2", receiving.Notes)
+		assert.Empty(t, receiving.Lines)
+		assert.Equal(t, userID, receiving.StartedBy)
+	})
+
+	t.Run("draft order", func(t *testing.T) {
+		order, err := NewPurchaseOrder(uuid.New(), "PO-002", "Acme Supplies", "", "", uuid.New())
+		require.NoError(t, err)
+
+		_, err = NewPurchaseOrderReceiving(order, "", uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid purchase order status")
+	})
+}
+
+func TestPurchaseOrderReceiving_RecordScan(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	widget := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget"}
+	gadget := &Product{ID: uuid.New(), SKU: "SKU-2", Name: "Gadget"}
+
+	newReceiving := func(t *testing.T) (*PurchaseOrder, *PurchaseOrderReceiving) {
+		order := newReceivingOrder(t, widget)
+		receiving, err := NewPurchaseOrderReceiving(order, "", uuid.New(), now)
+		require.NoError(t, err)
+		return order, receiving
+	}
+
+	t.Run("each scan adds a unit", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+
+		_, _, _, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123"}, now)
+		require.NoError(t, err)
+		item, line, status, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123"}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusRecorded, status)
+		assert.Equal(t, order.Items[0].ID, item.ID)
+		assert.Equal(t, 2, line.ScannedQty)
+		assert.Equal(t, 2, line.ScanCount)
+		assert.Len(t, receiving.Lines, 1)
+	})
+
+	t.Run("scanning beyond the outstanding quantity is flagged", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+		order.Items[0].ReceivedQty = 8
+
+		_, line, status, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: 3}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusOverReceived, status)
+		assert.Equal(t, 3, line.ScannedQty)
+	})
+
+	t.Run("negative quantity takes back units", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+
+		_, _, _, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: 3}, now)
+		require.NoError(t, err)
+		_, line, status, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: -2}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusRecorded, status)
+		assert.Equal(t, 1, line.ScannedQty)
+	})
+
+	t.Run("cannot take back more than scanned", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+
+		_, _, status, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: -1}, now)
+
+		assert.Error(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusInvalid, status)
+		assert.Empty(t, receiving.Lines)
+	})
+
+	t.Run("product not on the order", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+
+		_, _, status, err := receiving.RecordScan(order, gadget, PurchaseOrderReceivingScan{Barcode: "456"}, now)
+
+		assert.Error(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusNotOrdered, status)
+	})
+
+	t.Run("unknown barcode", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+
+		_, _, status, err := receiving.RecordScan(order, nil, PurchaseOrderReceivingScan{Barcode: "999"}, now)
+
+		assert.Error(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusUnknownBarcode, status)
+	})
+
+	t.Run("closed session", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+		require.NoError(t, receiving.Cancel(uuid.New(), now))
+
+		_, _, _, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123"}, now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "receiving session not open")
+	})
+}
+
+func TestPurchaseOrderReceiving_Discrepancies(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	widget := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget"}
+	gadget := &Product{ID: uuid.New(), SKU: "SKU-2", Name: "Gadget"}
+	order := newReceivingOrder(t, widget, gadget)
+	receiving, err := NewPurchaseOrderReceiving(order, "", uuid.New(), now)
+	require.NoError(t, err)
+
+	_, _, _, err = receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: 12}, now)
+	require.NoError(t, err)
+
+	discrepancies := receiving.Discrepancies(order)
+
+	assert.Equal(t, 2, discrepancies.UnitsOver)
+	assert.Equal(t, 10, discrepancies.UnitsShort)
+	require.Len(t, discrepancies.Lines, 2)
+	assert.Equal(t, PurchaseOrderReceiptOver, discrepancies.Lines[0].Receipt)
+	assert.Equal(t, 2, discrepancies.Lines[0].Difference)
+	assert.Equal(t, PurchaseOrderReceiptUnder, discrepancies.Lines[1].Receipt)
+	assert.Equal(t, 0, discrepancies.Lines[1].ScannedQty)
+}
+
+func TestPurchaseOrderReceiving_Complete(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	widget := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget"}
+
+	newReceiving := func(t *testing.T) (*PurchaseOrder, *PurchaseOrderReceiving) {
+		order := newReceivingOrder(t, widget)
+		receiving, err := NewPurchaseOrderReceiving(order, "", uuid.New(), now)
+		require.NoError(t, err)
+		return order, receiving
+	}
+
+	t.Run("partial receipt across sessions", func(t *testing.T) {
+		order, first := newReceiving(t)
+		userID := uuid.New()
+		_, _, _, err := first.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: 4}, now)
+		require.NoError(t, err)
+
+		lines, err := first.Complete(order, userID, now)
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderReceivingStatusCompleted, first.Status)
+		assert.Equal(t, []PurchaseOrderReceiptLine{{ItemID: order.Items[0].ID, Quantity: 4}}, lines)
+		_, err = order.Receive(lines)
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderStatusOrdered, order.Status)
+
+		second, err := NewPurchaseOrderReceiving(order, "", userID, now)
+		require.NoError(t, err)
+		_, _, status, err := second.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: 6}, now)
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderScanStatusRecorded, status)
+
+		lines, err = second.Complete(order, userID, now)
+		require.NoError(t, err)
+		_, err = order.Receive(lines)
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderStatusReceived, order.Status)
+	})
+
+	t.Run("over-received units must be taken back", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+		_, _, _, err := receiving.RecordScan(order, widget, PurchaseOrderReceivingScan{Barcode: "123", Quantity: 11}, now)
+		require.NoError(t, err)
+
+		_, err = receiving.Complete(order, uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "over-received")
+		assert.True(t, receiving.IsOpen())
+	})
+
+	t.Run("nothing scanned", func(t *testing.T) {
+		order, receiving := newReceiving(t)
+
+		_, err := receiving.Complete(order, uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "receiving session empty")
+	})
+}
+
+func TestNewPurchaseOrderScanResult(t *testing.T) {
+	item := &PurchaseOrderItem{ID: uuid.New(), ProductSKU: "SKU-1", ProductName: "Widget", Quantity: 10, ReceivedQty: 4}
+	line := &PurchaseOrderReceivingLine{ItemID: item.ID, ScannedQty: 6}
+
+	result := NewPurchaseOrderScanResult(PurchaseOrderReceivingScan{Barcode: "123"}, PurchaseOrderScanStatusRecorded, item, line, nil)
+
+	assert.Equal(t, "123", result.Barcode)
+	assert.Equal(t, item.ID, *result.ItemID)
+	assert.Equal(t, 10, *result.OrderedQty)
+	assert.Equal(t, 6, *result.OutstandingQty)
+	assert.Equal(t, 6, *result.ScannedQty)
+	assert.Equal(t, PurchaseOrderReceiptComplete, result.Receipt)
+}
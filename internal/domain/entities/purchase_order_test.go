@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPurchaseOrder(t *testing.T) {
+	t.Run("valid purchase order", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		order, err := NewPurchaseOrder(tenantID, "PO-001", " Acme Supplies ", "orders@acme.test", "0812", createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, order.ID)
+		assert.Equal(t, tenantID, order.TenantID)
+		assert.Equal(t, "Acme Supplies", order.SupplierName)
+		assert.Equal(t, PurchaseOrderStatusDraft, order.Status)
+		assert.Empty(t, order.Items)
+		assert.True(t, decimal.Zero.Equal(order.TotalCost))
+		assert.Equal(t, createdBy, order.CreatedBy)
+	})
+
+	t.Run("supplier name required", func(t *testing.T) {
+		order, err := NewPurchaseOrder(uuid.New(), "PO-001", " ", "", "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, order)
+	})
+
+	t.Run("invalid supplier email", func(t *testing.T) {
+		order, err := NewPurchaseOrder(uuid.New(), "PO-001", "Acme Supplies", "acme", "", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, order)
+	})
+}
+
+func TestPurchaseOrder_AddItem(t *testing.T) {
+	order := createValidPurchaseOrder(t)
+	product := createValidProduct(t)
+
+	require.NoError(t, order.AddItem(product, 5, decimal.NewFromInt(70)))
+	require.NoError(t, order.AddItem(product, 5, decimal.NewFromInt(72)))
+
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, 10, order.Items[0].Quantity)
+	assert.Equal(t, product.SKU, order.Items[0].ProductSKU)
+	assert.True(t, decimal.NewFromInt(720).Equal(order.Items[0].TotalCost))
+	assert.True(t, decimal.NewFromInt(720).Equal(order.TotalCost))
+
+	assert.Error(t, order.AddItem(product, 0, decimal.NewFromInt(70)))
+	assert.Error(t, order.AddItem(product, 1, decimal.NewFromInt(-1)))
+
+	require.NoError(t, order.MarkOrdered())
+	assert.Error(t, order.AddItem(product, 1, decimal.NewFromInt(70)))
+}
+
+func TestPurchaseOrder_MarkOrdered(t *testing.T) {
+	order := createValidPurchaseOrder(t)
+
+	err := order.MarkOrdered()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "purchase order has no items")
+
+	require.NoError(t, order.AddItem(createValidProduct(t), 1, decimal.NewFromInt(70)))
+	require.NoError(t, order.MarkOrdered())
+	assert.Equal(t, PurchaseOrderStatusOrdered, order.Status)
+	assert.NotNil(t, order.OrderedAt)
+}
+
+func TestPurchaseOrder_Receive(t *testing.T) {
+	t.Run("partial then full delivery", func(t *testing.T) {
+		order := createOrderedPurchaseOrder(t)
+		laptop := order.Items[0]
+
+		received, err := order.Receive([]PurchaseOrderReceiptLine{{ItemID: laptop.ID, Quantity: 4}})
+
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.Equal(t, 4, received[0].Quantity)
+		assert.Equal(t, 4, order.Items[0].ReceivedQty)
+		assert.Equal(t, PurchaseOrderStatusOrdered, order.Status)
+		assert.Nil(t, order.ReceivedAt)
+
+		received, err = order.Receive(nil)
+
+		require.NoError(t, err)
+		require.Len(t, received, 2)
+		assert.Equal(t, 6, received[0].Quantity)
+		assert.Equal(t, 3, received[1].Quantity)
+		assert.Equal(t, PurchaseOrderStatusReceived, order.Status)
+		assert.NotNil(t, order.ReceivedAt)
+	})
+
+	t.Run("cannot receive more than outstanding", func(t *testing.T) {
+		order := createOrderedPurchaseOrder(t)
+		laptop := order.Items[0]
+
+		received, err := order.Receive([]PurchaseOrderReceiptLine{
+			{ItemID: laptop.ID, Quantity: 6},
+			{ItemID: laptop.ID, Quantity: 6},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, received)
+		assert.Contains(t, err.Error(), "received quantity too large")
+		assert.Equal(t, 0, order.Items[0].ReceivedQty)
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		order := createOrderedPurchaseOrder(t)
+
+		_, err := order.Receive([]PurchaseOrderReceiptLine{{ItemID: uuid.New(), Quantity: 1}})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("draft cannot be received", func(t *testing.T) {
+		order := createValidPurchaseOrder(t)
+
+		_, err := order.Receive(nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid purchase order status")
+	})
+}
+
+func TestPurchaseOrder_Cancel(t *testing.T) {
+	t.Run("ordered purchase order", func(t *testing.T) {
+		order := createOrderedPurchaseOrder(t)
+
+		require.NoError(t, order.Cancel())
+		assert.Equal(t, PurchaseOrderStatusCancelled, order.Status)
+		assert.NotNil(t, order.CancelledAt)
+	})
+
+	t.Run("partially received", func(t *testing.T) {
+		order := createOrderedPurchaseOrder(t)
+		_, err := order.Receive([]PurchaseOrderReceiptLine{{ItemID: order.Items[0].ID, Quantity: 1}})
+		require.NoError(t, err)
+
+		err = order.Cancel()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "purchase order partially received")
+	})
+}
+
+func TestValidatePurchaseOrderStatus(t *testing.T) {
+	assert.NoError(t, ValidatePurchaseOrderStatus(PurchaseOrderStatusOrdered))
+	assert.Error(t, ValidatePurchaseOrderStatus("shipped"))
+}
+
+// Helper functions to create purchase orders for testing
+func createValidPurchaseOrder(t *testing.T) *PurchaseOrder {
+	order, err := NewPurchaseOrder(uuid.New(), "PO-20261015-0001", "Acme Supplies", "orders@acme.test", "", uuid.New())
+	require.NoError(t, err)
+	return order
+}
+
+func createOrderedPurchaseOrder(t *testing.T) *PurchaseOrder {
+	order := createValidPurchaseOrder(t)
+	mouse, err := NewProduct(order.TenantID, "MOUSE001", "Wireless Mouse", "", "Electronics", "pcs", decimal.NewFromInt(25), decimal.NewFromInt(12), 5, uuid.New())
+	require.NoError(t, err)
+
+	require.NoError(t, order.AddItem(createValidProduct(t), 10, decimal.NewFromInt(70)))
+	require.NoError(t, order.AddItem(mouse, 3, decimal.NewFromInt(12)))
+	require.NoError(t, order.MarkOrdered())
+	return order
+}
@@ -0,0 +1,325 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// QuoteStatus represents the lifecycle status of a quote
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft     QuoteStatus = "draft"     // Being prepared, items can still change
+	QuoteStatusSent      QuoteStatus = "sent"      // Sent to the customer, awaiting their answer
+	QuoteStatusAccepted  QuoteStatus = "accepted"  // Accepted by the customer, ready to become a sale
+	QuoteStatusRejected  QuoteStatus = "rejected"  // Turned down by the customer
+	QuoteStatusExpired   QuoteStatus = "expired"   // Not accepted before it expired
+	QuoteStatusConverted QuoteStatus = "converted" // Turned into a sale
+)
+
+// Quote represents an estimate of a sale given to a customer. The quoted prices hold until
+// the quote expires, and an accepted quote is converted into a pending sale at those prices.
+type Quote struct {
+	ID            uuid.UUID       `json:"id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	QuoteNumber   string          `json:"quote_number"`
+	CustomerName  string          `json:"customer_name"`
+	CustomerEmail string          `json:"customer_email,omitempty"`
+	CustomerPhone string          `json:"customer_phone,omitempty"`
+	Status        QuoteStatus     `json:"status"`
+	Items         []QuoteItem     `json:"items"`
+	TotalAmount   decimal.Decimal `json:"total_amount"`
+	Currency      string          `json:"currency"` // ISO 4217 code the items are priced in
+	Notes         string          `json:"notes,omitempty"`
+	ExpiresAt     time.Time       `json:"expires_at"` // The quoted prices hold until then
+	SentAt        *time.Time      `json:"sent_at,omitempty"`
+	AcceptedAt    *time.Time      `json:"accepted_at,omitempty"`
+	RejectedAt    *time.Time      `json:"rejected_at,omitempty"`
+	ExpiredAt     *time.Time      `json:"expired_at,omitempty"`
+	ConvertedAt   *time.Time      `json:"converted_at,omitempty"`
+	SaleID        *uuid.UUID      `json:"sale_id,omitempty"` // The sale the quote was converted into
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	CreatedBy     uuid.UUID       `json:"created_by"`
+
+	// CurrencyFormat is the display of the quote's currency, attached when rendering the
+	// quote for the customer
+	CurrencyFormat *CurrencyFormat `json:"-"`
+}
+
+// QuoteItem represents a product line on a quote at its quoted price
+type QuoteItem struct {
+	ID          uuid.UUID       `json:"id"`
+	QuoteID     uuid.UUID       `json:"quote_id"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	ProductSKU  string          `json:"product_sku"`
+	ProductName string          `json:"product_name"`
+	Quantity    int             `json:"quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+	TotalPrice  decimal.Decimal `json:"total_price"`
+}
+
+// NewQuote creates a new draft quote for a customer, priced in the given currency and
+// valid until expiresAt
+func NewQuote(tenantID uuid.UUID, quoteNumber, customerName, customerEmail, customerPhone, currency string, expiresAt time.Time, createdBy uuid.UUID) (*Quote, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant is required", "tenant ID cannot be empty")
+	}
+	if strings.TrimSpace(quoteNumber) == "" {
+		return nil, errors.NewValidationError("quote number is required", "quote number cannot be empty")
+	}
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	quote := &Quote{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		QuoteNumber: quoteNumber,
+		Status:      QuoteStatusDraft,
+		Items:       []QuoteItem{},
+		TotalAmount: decimal.Zero,
+		Currency:    currency,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   createdBy,
+	}
+
+	if err := quote.UpdateCustomer(customerName, customerEmail, customerPhone); err != nil {
+		return nil, err
+	}
+	if err := quote.SetExpiry(expiresAt, now); err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// UpdateCustomer updates the customer details of a draft quote
+func (q *Quote) UpdateCustomer(customerName, customerEmail, customerPhone string) error {
+	if !q.IsDraft() {
+		return errors.NewValidationError("invalid quote status", "only draft quotes can be modified")
+	}
+
+	customerName = strings.TrimSpace(customerName)
+	if customerName == "" {
+		return errors.NewValidationError("customer name is required", "customer name cannot be empty")
+	}
+	if len(customerName) > 255 {
+		return errors.NewValidationError("customer name too long", "customer name cannot exceed 255 characters")
+	}
+	customerEmail = NormalizeEmail(customerEmail)
+	if customerEmail != "" && !isValidEmail(customerEmail) {
+		return errors.NewValidationError("invalid customer email", "customer email must be a valid email address")
+	}
+
+	q.CustomerName = customerName
+	q.CustomerEmail = customerEmail
+	q.CustomerPhone = strings.TrimSpace(customerPhone)
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetExpiry sets until when the quoted prices hold. A quote can be extended until the
+// customer has answered it.
+func (q *Quote) SetExpiry(expiresAt, now time.Time) error {
+	if q.Status != QuoteStatusDraft && q.Status != QuoteStatusSent {
+		return errors.NewValidationError("invalid quote status", "only draft or sent quotes can have their expiry changed")
+	}
+	if !expiresAt.After(now) {
+		return errors.NewValidationError("invalid expiry", "a quote must expire in the future")
+	}
+
+	q.ExpiresAt = expiresAt
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+// AddItem adds a product line to a draft quote at the quoted unit price. Adding a product
+// already on the quote increases its quantity and replaces its unit price.
+func (q *Quote) AddItem(product *Product, quantity int, unitPrice decimal.Decimal) error {
+	if !q.IsDraft() {
+		return errors.NewValidationError("invalid quote status", "only draft quotes can be modified")
+	}
+	if product == nil {
+		return errors.NewValidationError("product is required", "product cannot be nil")
+	}
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+	if !unitPrice.IsPositive() {
+		return errors.NewInvalidPriceError(unitPrice.InexactFloat64())
+	}
+	unitPrice = unitPrice.Round(2)
+
+	for i := range q.Items {
+		if q.Items[i].ProductID == product.ID {
+			q.Items[i].Quantity += quantity
+			q.Items[i].UnitPrice = unitPrice
+			q.Items[i].TotalPrice = unitPrice.Mul(decimal.NewFromInt(int64(q.Items[i].Quantity)))
+			q.calculateTotal()
+			return nil
+		}
+	}
+
+	q.Items = append(q.Items, QuoteItem{
+		ID:          uuid.New(),
+		QuoteID:     q.ID,
+		ProductID:   product.ID,
+		ProductSKU:  product.SKU,
+		ProductName: product.Name,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		TotalPrice:  unitPrice.Mul(decimal.NewFromInt(int64(quantity))),
+	})
+	q.calculateTotal()
+	return nil
+}
+
+// ClearItems removes all product lines from a draft quote
+func (q *Quote) ClearItems() error {
+	if !q.IsDraft() {
+		return errors.NewValidationError("invalid quote status", "only draft quotes can be modified")
+	}
+
+	q.Items = []QuoteItem{}
+	q.calculateTotal()
+	return nil
+}
+
+// MarkSent records that the quote has been sent to the customer. A sent quote can be sent
+// again, e.g. to another address.
+func (q *Quote) MarkSent(now time.Time) error {
+	if q.Status != QuoteStatusDraft && q.Status != QuoteStatusSent {
+		return errors.NewValidationError("invalid quote status", "only draft or sent quotes can be sent")
+	}
+	if len(q.Items) == 0 {
+		return errors.NewValidationError("quote has no items", "add at least one item before sending the quote")
+	}
+	if q.IsExpired(now) {
+		return errors.NewValidationError("quote expired", "extend the quote's expiry before sending it")
+	}
+
+	q.Status = QuoteStatusSent
+	q.SentAt = &now
+	q.UpdatedAt = now
+	return nil
+}
+
+// Accept records the customer's acceptance of the quote, which must still be valid
+func (q *Quote) Accept(now time.Time) error {
+	if q.Status != QuoteStatusDraft && q.Status != QuoteStatusSent {
+		return errors.NewValidationError("invalid quote status", "only draft or sent quotes can be accepted")
+	}
+	if len(q.Items) == 0 {
+		return errors.NewValidationError("quote has no items", "quotes without items cannot be accepted")
+	}
+	if q.IsExpired(now) {
+		return errors.NewValidationError("quote expired", "the quote expired on "+q.ExpiresAt.Format("2006-01-02"))
+	}
+
+	q.Status = QuoteStatusAccepted
+	q.AcceptedAt = &now
+	q.UpdatedAt = now
+	return nil
+}
+
+// Reject records that the customer turned the quote down
+func (q *Quote) Reject(now time.Time) error {
+	if q.Status != QuoteStatusDraft && q.Status != QuoteStatusSent {
+		return errors.NewValidationError("invalid quote status", "only draft or sent quotes can be rejected")
+	}
+
+	q.Status = QuoteStatusRejected
+	q.RejectedAt = &now
+	q.UpdatedAt = now
+	return nil
+}
+
+// Expire marks a quote the customer did not answer before its expiry as expired
+func (q *Quote) Expire(now time.Time) error {
+	if q.Status != QuoteStatusDraft && q.Status != QuoteStatusSent {
+		return errors.NewValidationError("invalid quote status", "only draft or sent quotes can expire")
+	}
+	if !q.IsExpired(now) {
+		return errors.NewValidationError("quote not expired", "the quote is valid until "+q.ExpiresAt.Format("2006-01-02"))
+	}
+
+	q.Status = QuoteStatusExpired
+	q.ExpiredAt = &now
+	q.UpdatedAt = now
+	return nil
+}
+
+// MarkConverted records the sale an accepted quote was converted into. The customer accepted
+// the quote while it was valid, so it can be converted after its expiry.
+func (q *Quote) MarkConverted(saleID uuid.UUID, now time.Time) error {
+	if q.Status != QuoteStatusAccepted {
+		return errors.NewValidationError("invalid quote status", "only accepted quotes can be converted to a sale")
+	}
+
+	q.Status = QuoteStatusConverted
+	q.SaleID = &saleID
+	q.ConvertedAt = &now
+	q.UpdatedAt = now
+	return nil
+}
+
+// IsDraft checks if the quote can still be edited
+func (q *Quote) IsDraft() bool {
+	return q.Status == QuoteStatusDraft
+}
+
+// IsExpired checks if the quoted prices no longer hold at the given time
+func (q *Quote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// FormatAmount formats an amount of the quote for display, using the attached currency
+// display or the conventional display of the quote's currency
+func (q *Quote) FormatAmount(amount decimal.Decimal) string {
+	if q.CurrencyFormat != nil {
+		return q.CurrencyFormat.Format(amount)
+	}
+	return DefaultCurrencyFormat(q.Currency).Format(amount)
+}
+
+// ValidateQuoteStatus validates quote status
+func ValidateQuoteStatus(status QuoteStatus) error {
+	switch status {
+	case QuoteStatusDraft, QuoteStatusSent, QuoteStatusAccepted, QuoteStatusRejected, QuoteStatusExpired, QuoteStatusConverted:
+		return nil
+	default:
+		return errors.NewValidationError("invalid quote status", "status must be one of: draft, sent, accepted, rejected, expired, converted")
+	}
+}
+
+// calculateTotal recalculates the quote total from its items
+func (q *Quote) calculateTotal() {
+	total := decimal.Zero
+	for _, item := range q.Items {
+		total = total.Add(item.TotalPrice)
+	}
+	q.TotalAmount = total
+	q.UpdatedAt = time.Now()
+}
+
+// ApplyQuotedPrice charges a sale item converted from a quote item at its quoted price. A
+// quoted price that differs from the product's current price is recorded as a price override
+// referencing the quote, so it is not replaced by later price changes or promotions.
+func (q *Quote) ApplyQuotedPrice(saleItem *SaleItem, item QuoteItem) error {
+	if saleItem.ProductID != item.ProductID {
+		return errors.NewValidationError("quote item mismatch", "sale item is not for the quoted product")
+	}
+	if saleItem.UnitPrice.Equal(item.UnitPrice) {
+		return nil
+	}
+	return saleItem.OverridePrice(item.UnitPrice, "Quoted in "+q.QuoteNumber)
+}
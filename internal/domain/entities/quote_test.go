@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDraftQuote returns a draft quote valid for 30 days with two desks at 450
+func newDraftQuote(t *testing.T, now time.Time) (*Quote, *Product) {
+	tenantID := uuid.New()
+	quote, err := NewQuote(tenantID, "Q-001", "Jane", " JANE@Example.com ", "", "usd", now.AddDate(0, 0, 30), uuid.New())
+	require.NoError(t, err)
+
+	product, err := NewProduct(tenantID, "DESK-01", "Desk", "", "Furniture", "pcs", decimal.NewFromInt(500), decimal.NewFromInt(300), 0, uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, quote.AddItem(product, 2, decimal.NewFromInt(450)))
+	return quote, product
+}
+
+func TestNewQuote(t *testing.T) {
+	now := time.Now()
+	quote, _ := newDraftQuote(t, now)
+
+	assert.Equal(t, QuoteStatusDraft, quote.Status)
+	assert.Equal(t, "USD", quote.Currency)
+	assert.Equal(t, "jane@example.com", quote.CustomerEmail)
+	assert.True(t, decimal.NewFromInt(900).Equal(quote.TotalAmount))
+
+	tests := []struct {
+		name          string
+		customerName  string
+		customerEmail string
+		currency      string
+		expiresAt     time.Time
+	}{
+		{"missing customer", " ", "", "USD", now.AddDate(0, 0, 30)},
+		{"invalid email", "Jane", "jane@", "USD", now.AddDate(0, 0, 30)},
+		{"invalid currency", "Jane", "", "US", now.AddDate(0, 0, 30)},
+		{"expiry in the past", "Jane", "", "USD", now.Add(-time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := NewQuote(uuid.New(), "Q-001", tt.customerName, tt.customerEmail, "", tt.currency, tt.expiresAt, uuid.New())
+
+			assert.Error(t, err)
+			assert.Nil(t, quote)
+		})
+	}
+}
+
+func TestQuote_AddItem(t *testing.T) {
+	now := time.Now()
+
+	t.Run("same product merges", func(t *testing.T) {
+		quote, product := newDraftQuote(t, now)
+
+		require.NoError(t, quote.AddItem(product, 1, decimal.NewFromFloat(420.004)))
+
+		require.Len(t, quote.Items, 1)
+		assert.Equal(t, 3, quote.Items[0].Quantity)
+		assert.True(t, decimal.NewFromInt(420).Equal(quote.Items[0].UnitPrice))
+		assert.True(t, decimal.NewFromInt(1260).Equal(quote.TotalAmount))
+	})
+
+	t.Run("refuses non-positive prices", func(t *testing.T) {
+		quote, product := newDraftQuote(t, now)
+
+		assert.Error(t, quote.AddItem(product, 1, decimal.Zero))
+		assert.True(t, decimal.NewFromInt(900).Equal(quote.TotalAmount))
+	})
+
+	t.Run("only drafts can change", func(t *testing.T) {
+		quote, product := newDraftQuote(t, now)
+		require.NoError(t, quote.MarkSent(now))
+
+		assert.Error(t, quote.AddItem(product, 1, decimal.NewFromInt(450)))
+		assert.Error(t, quote.ClearItems())
+	})
+}
+
+func TestQuote_Lifecycle(t *testing.T) {
+	now := time.Now()
+
+	t.Run("sent quote is accepted and converted", func(t *testing.T) {
+		quote, _ := newDraftQuote(t, now)
+		saleID := uuid.New()
+
+		require.NoError(t, quote.MarkSent(now))
+		require.NoError(t, quote.Accept(now.Add(time.Hour)))
+		require.NoError(t, quote.MarkConverted(saleID, now.AddDate(0, 0, 31)))
+
+		assert.Equal(t, QuoteStatusConverted, quote.Status)
+		assert.Equal(t, saleID, *quote.SaleID)
+		assert.Error(t, quote.MarkConverted(uuid.New(), now), "a quote converts only once")
+	})
+
+	t.Run("expired quote cannot be accepted", func(t *testing.T) {
+		quote, _ := newDraftQuote(t, now)
+
+		err := quote.Accept(quote.ExpiresAt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "quote expired")
+		assert.Equal(t, QuoteStatusDraft, quote.Status)
+	})
+
+	t.Run("expires only after its expiry", func(t *testing.T) {
+		quote, _ := newDraftQuote(t, now)
+
+		assert.Error(t, quote.Expire(now))
+		require.NoError(t, quote.Expire(quote.ExpiresAt.Add(time.Second)))
+		assert.Equal(t, QuoteStatusExpired, quote.Status)
+		assert.Error(t, quote.Accept(now))
+	})
+
+	t.Run("empty quote cannot be sent", func(t *testing.T) {
+		quote, _ := newDraftQuote(t, now)
+		require.NoError(t, quote.ClearItems())
+
+		assert.Error(t, quote.MarkSent(now))
+	})
+
+	t.Run("rejected quote cannot be converted", func(t *testing.T) {
+		quote, _ := newDraftQuote(t, now)
+		require.NoError(t, quote.Reject(now))
+
+		assert.Error(t, quote.MarkConverted(uuid.New(), now))
+	})
+}
+
+func TestQuote_ApplyQuotedPrice(t *testing.T) {
+	quote, product := newDraftQuote(t, time.Now())
+	item := quote.Items[0]
+
+	t.Run("quoted discount becomes an override", func(t *testing.T) {
+		saleItem, err := NewSaleItem(uuid.New(), product.ID, product.SKU, product.Name, item.Quantity, decimal.NewFromInt(500))
+		require.NoError(t, err)
+
+		require.NoError(t, quote.ApplyQuotedPrice(saleItem, item))
+
+		assert.True(t, decimal.NewFromInt(450).Equal(saleItem.UnitPrice))
+		assert.True(t, decimal.NewFromInt(900).Equal(saleItem.TotalPrice))
+		assert.Equal(t, SaleItemPriceSourceOverride, saleItem.PriceSource)
+		assert.Equal(t, "Quoted in Q-001", saleItem.OverrideReason)
+	})
+
+	t.Run("unchanged price stays a list price", func(t *testing.T) {
+		saleItem, err := NewSaleItem(uuid.New(), product.ID, product.SKU, product.Name, item.Quantity, decimal.NewFromInt(450))
+		require.NoError(t, err)
+
+		require.NoError(t, quote.ApplyQuotedPrice(saleItem, item))
+
+		assert.NotEqual(t, SaleItemPriceSourceOverride, saleItem.PriceSource)
+	})
+
+	t.Run("refuses another product", func(t *testing.T) {
+		saleItem, err := NewSaleItem(uuid.New(), uuid.New(), "CHAIR-01", "Chair", 1, decimal.NewFromInt(100))
+		require.NoError(t, err)
+
+		assert.Error(t, quote.ApplyQuotedPrice(saleItem, item))
+	})
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// Refund represents money returned to a customer for some or all items of a completed sale.
+// Discount and tax are refunded in proportion to the items returned; payment surcharges
+// are not refunded.
+type Refund struct {
+	ID             uuid.UUID       `json:"id"`
+	TenantID       uuid.UUID       `json:"tenant_id"`
+	SaleID         uuid.UUID       `json:"sale_id"`
+	RefundNumber   string          `json:"refund_number"`
+	Items          []RefundItem    `json:"items"`
+	Subtotal       decimal.Decimal `json:"subtotal"`
+	DiscountAmount decimal.Decimal `json:"discount_amount"` // Share of the sale discount given back
+	TaxAmount      decimal.Decimal `json:"tax_amount"`
+	TotalAmount    decimal.Decimal `json:"total_amount"`
+	RefundMethod   PaymentMethod   `json:"refund_method"`
+	Reason         string          `json:"reason"`
+	ApprovedBy     *uuid.UUID      `json:"approved_by,omitempty"` // Manager who approved a cashier's refund
+	CreatedAt      time.Time       `json:"created_at"`
+	CreatedBy      uuid.UUID       `json:"created_by"`
+}
+
+// RefundItem represents a quantity of a sale item returned in a refund
+type RefundItem struct {
+	ID          uuid.UUID       `json:"id"`
+	RefundID    uuid.UUID       `json:"refund_id"`
+	SaleItemID  uuid.UUID       `json:"sale_item_id"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	ProductSKU  string          `json:"product_sku"`
+	ProductName string          `json:"product_name"`
+	Quantity    int             `json:"quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+	TotalPrice  decimal.Decimal `json:"total_price"`
+}
+
+// RefundLine requests a quantity of a sale item to be refunded
+type RefundLine struct {
+	SaleItemID    uuid.UUID `json:"sale_item_id" validate:"required"`
+	Quantity      int       `json:"quantity" validate:"required,min=1"`
+	SerialNumbers []string  `json:"serial_numbers,omitempty"` // Units brought back, for serialized products
+}
+
+// NewRefund creates a refund for a completed sale. refundedQty holds the quantity of each
+// sale item already refunded by earlier refunds. Without lines, everything not yet refunded
+// is refunded. The refund method defaults to the sale's payment method.
+func NewRefund(sale *Sale, refundNumber string, lines []RefundLine, refundedQty map[uuid.UUID]int, method PaymentMethod, reason string, createdBy uuid.UUID) (*Refund, error) {
+	if sale == nil {
+		return nil, errors.NewValidationError("sale is required", "sale cannot be nil")
+	}
+	if !sale.IsCompleted() {
+		return nil, errors.NewValidationError("invalid sale status", "only completed sales can be refunded")
+	}
+	if refundNumber == "" {
+		return nil, errors.NewValidationError("refund number is required", "refund_number cannot be empty")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.NewValidationError("refund reason is required", "reason cannot be empty")
+	}
+	if len(reason) > 255 {
+		return nil, errors.NewValidationError("refund reason too long", "reason cannot exceed 255 characters")
+	}
+	if method == "" {
+		method = sale.PaymentMethod
+	}
+	if err := ValidatePaymentMethod(method); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		for _, item := range sale.Items {
+			if remaining := item.Quantity - refundedQty[item.ID]; remaining > 0 {
+				lines = append(lines, RefundLine{SaleItemID: item.ID, Quantity: remaining})
+			}
+		}
+		if len(lines) == 0 {
+			return nil, errors.NewValidationError("nothing to refund", "all items of the sale have already been refunded")
+		}
+	}
+
+	refund := &Refund{
+		ID:           uuid.New(),
+		TenantID:     sale.TenantID,
+		SaleID:       sale.ID,
+		RefundNumber: refundNumber,
+		Items:        make([]RefundItem, 0, len(lines)),
+		Subtotal:     decimal.Zero,
+		RefundMethod: method,
+		Reason:       reason,
+		CreatedAt:    time.Now(),
+		CreatedBy:    createdBy,
+	}
+
+	seen := make(map[uuid.UUID]bool, len(lines))
+	for _, line := range lines {
+		if seen[line.SaleItemID] {
+			return nil, errors.NewValidationError("duplicate refund item", "each sale item can only be listed once")
+		}
+		seen[line.SaleItemID] = true
+
+		item := sale.findItem(line.SaleItemID)
+		if item == nil {
+			return nil, errors.NewNotFoundError("sale item")
+		}
+		if line.Quantity <= 0 {
+			return nil, errors.NewInvalidQuantityError(line.Quantity)
+		}
+		if line.Quantity > item.Quantity-refundedQty[item.ID] {
+			return nil, errors.NewValidationError("refund quantity too large", "cannot refund more than was sold and not yet refunded")
+		}
+		if err := validateRefundSerialNumbers(item, line); err != nil {
+			return nil, err
+		}
+
+		totalPrice := item.UnitPrice.Mul(decimal.NewFromInt(int64(line.Quantity)))
+		refund.Items = append(refund.Items, RefundItem{
+			ID:          uuid.New(),
+			RefundID:    refund.ID,
+			SaleItemID:  item.ID,
+			ProductID:   item.ProductID,
+			ProductSKU:  item.ProductSKU,
+			ProductName: item.ProductName,
+			Quantity:    line.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  totalPrice,
+		})
+		refund.Subtotal = refund.Subtotal.Add(totalPrice)
+	}
+
+	refund.DiscountAmount = decimal.Zero
+	if sale.Subtotal.GreaterThan(decimal.Zero) {
+		refund.DiscountAmount = sale.DiscountAmount.Mul(refund.Subtotal).Div(sale.Subtotal).Round(2)
+	}
+	taxable := refund.Subtotal.Sub(refund.DiscountAmount)
+	refund.TaxAmount = taxable.Mul(sale.TaxRate).Div(decimal.NewFromInt(100)).Round(2)
+	refund.TotalAmount = taxable.Add(refund.TaxAmount)
+
+	return refund, nil
+}
+
+// IsFullyRefunded checks if every item of the sale has been refunded, given the quantity of
+// each sale item refunded so far
+func (s *Sale) IsFullyRefunded(refundedQty map[uuid.UUID]int) bool {
+	for _, item := range s.Items {
+		if refundedQty[item.ID] < item.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// findItem returns the sale item with the given ID, if any
+func (s *Sale) findItem(itemID uuid.UUID) *SaleItem {
+	for i := range s.Items {
+		if s.Items[i].ID == itemID {
+			return &s.Items[i]
+		}
+	}
+	return nil
+}
+
+// validateRefundSerialNumbers checks the serial numbers of a refund line were sold with the item
+func validateRefundSerialNumbers(item *SaleItem, line RefundLine) error {
+	if len(line.SerialNumbers) == 0 {
+		return nil
+	}
+	if len(line.SerialNumbers) > line.Quantity {
+		return errors.NewValidationError("too many serial numbers", "cannot return more serial numbers than the quantity refunded")
+	}
+
+	serials, err := NormalizeSerialNumbers(line.SerialNumbers)
+	if err != nil {
+		return err
+	}
+	for _, serial := range serials {
+		if !item.HasSerialNumber(serial) {
+			return errors.NewValidationError("unknown serial number", "serial number '"+serial+"' was not sold with this item")
+		}
+	}
+	return nil
+}
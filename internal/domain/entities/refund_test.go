@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefund(t *testing.T) {
+	t.Run("partial refund", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		laptop := sale.Items[0]
+		createdBy := uuid.New()
+
+		refund, err := NewRefund(sale, "RFD-001", []RefundLine{{SaleItemID: laptop.ID, Quantity: 1}}, nil, "", "damaged in transit", createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, refund.ID)
+		assert.Equal(t, sale.ID, refund.SaleID)
+		assert.Equal(t, sale.TenantID, refund.TenantID)
+		assert.Equal(t, PaymentMethodCash, refund.RefundMethod)
+		require.Len(t, refund.Items, 1)
+		assert.Equal(t, laptop.ProductID, refund.Items[0].ProductID)
+		assert.Equal(t, 1, refund.Items[0].Quantity)
+		assert.True(t, decimal.NewFromFloat(999.99).Equal(refund.Subtotal))
+		assert.True(t, decimal.NewFromFloat(100.00).Equal(refund.TaxAmount))
+		assert.True(t, decimal.NewFromFloat(1099.99).Equal(refund.TotalAmount))
+		assert.Equal(t, createdBy, refund.CreatedBy)
+	})
+
+	t.Run("refunds share of discount", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.ApplyDiscount(sale.Subtotal.Div(decimal.NewFromInt(10))))
+		require.NoError(t, sale.ProcessPayment(sale.TotalAmount, PaymentMethodCard, nil))
+		require.NoError(t, sale.CompleteSale())
+		mouse := sale.Items[1]
+
+		refund, err := NewRefund(sale, "RFD-001", []RefundLine{{SaleItemID: mouse.ID, Quantity: 1}}, nil, "", "wrong color", uuid.New())
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(8.00).Equal(refund.DiscountAmount))
+		assert.True(t, decimal.NewFromFloat(71.99).Equal(refund.TotalAmount))
+		assert.Equal(t, PaymentMethodCard, refund.RefundMethod)
+	})
+
+	t.Run("refunds everything remaining without lines", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		refunded := map[uuid.UUID]int{sale.Items[0].ID: 1}
+
+		refund, err := NewRefund(sale, "RFD-002", nil, refunded, PaymentMethodBankTransfer, "customer return", uuid.New())
+
+		require.NoError(t, err)
+		require.Len(t, refund.Items, 2)
+		assert.Equal(t, 1, refund.Items[0].Quantity)
+		assert.Equal(t, 1, refund.Items[1].Quantity)
+		assert.Equal(t, PaymentMethodBankTransfer, refund.RefundMethod)
+	})
+
+	t.Run("cannot refund more than remaining", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		laptop := sale.Items[0]
+		refunded := map[uuid.UUID]int{laptop.ID: 1}
+
+		refund, err := NewRefund(sale, "RFD-003", []RefundLine{{SaleItemID: laptop.ID, Quantity: 2}}, refunded, "", "customer return", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+		assert.Contains(t, err.Error(), "refund quantity too large")
+	})
+
+	t.Run("unknown sale item", func(t *testing.T) {
+		sale := createCompletedSale(t)
+
+		refund, err := NewRefund(sale, "RFD-004", []RefundLine{{SaleItemID: uuid.New(), Quantity: 1}}, nil, "", "customer return", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+	})
+
+	t.Run("pending sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		refund, err := NewRefund(sale, "RFD-005", nil, nil, "", "customer return", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+		assert.Contains(t, err.Error(), "invalid sale status")
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		sale := createCompletedSale(t)
+
+		refund, err := NewRefund(sale, "RFD-006", nil, nil, "", " ", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+		assert.Contains(t, err.Error(), "refund reason is required")
+	})
+
+	t.Run("serial numbers sold with the item", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		sale.Items[0].SerialNumbers = []string{"SN-001", "SN-002"}
+		laptop := sale.Items[0]
+
+		refund, err := NewRefund(sale, "RFD-007", []RefundLine{{SaleItemID: laptop.ID, Quantity: 1, SerialNumbers: []string{" sn-002"}}}, nil, "", "customer return", uuid.New())
+
+		require.NoError(t, err)
+		assert.Len(t, refund.Items, 1)
+	})
+
+	t.Run("serial number not sold with the item", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		sale.Items[0].SerialNumbers = []string{"SN-001"}
+		laptop := sale.Items[0]
+
+		refund, err := NewRefund(sale, "RFD-008", []RefundLine{{SaleItemID: laptop.ID, Quantity: 1, SerialNumbers: []string{"SN-999"}}}, nil, "", "customer return", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+		assert.Contains(t, err.Error(), "unknown serial number")
+	})
+
+	t.Run("more serial numbers than refunded", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		sale.Items[0].SerialNumbers = []string{"SN-001", "SN-002"}
+		laptop := sale.Items[0]
+
+		refund, err := NewRefund(sale, "RFD-009", []RefundLine{{SaleItemID: laptop.ID, Quantity: 1, SerialNumbers: []string{"SN-001", "SN-002"}}}, nil, "", "customer return", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+		assert.Contains(t, err.Error(), "too many serial numbers")
+	})
+}
+
+func TestSale_IsFullyRefunded(t *testing.T) {
+	sale := createCompletedSale(t)
+
+	assert.False(t, sale.IsFullyRefunded(map[uuid.UUID]int{sale.Items[0].ID: 2}))
+	assert.True(t, sale.IsFullyRefunded(map[uuid.UUID]int{sale.Items[0].ID: 2, sale.Items[1].ID: 1}))
+}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// RegisterReportType represents the kind of end-of-day summary of a register session
+type RegisterReportType string
+
+const (
+	RegisterReportTypeX RegisterReportType = "x" // Mid-shift snapshot of an open session, can be taken any number of times
+	RegisterReportTypeZ RegisterReportType = "z" // Closing report of a closed session, taken once
+)
+
+// RegisterPaymentTotal represents the amount taken or given back with a payment method
+type RegisterPaymentTotal struct {
+	PaymentMethod PaymentMethod   `json:"payment_method"`
+	Count         int             `json:"count"`
+	Amount        decimal.Decimal `json:"amount"`
+}
+
+// RegisterSalesTotals represents what a cashier's sales and refunds came to during a register
+// session
+type RegisterSalesTotals struct {
+	SaleCount       int                    `json:"sale_count"`
+	ItemsSold       int                    `json:"items_sold"`
+	GrossSales      decimal.Decimal        `json:"gross_sales"` // Before discounts, tax and surcharges
+	DiscountAmount  decimal.Decimal        `json:"discount_amount"`
+	TaxAmount       decimal.Decimal        `json:"tax_amount"`
+	SurchargeAmount decimal.Decimal        `json:"surcharge_amount"`
+	NetSales        decimal.Decimal        `json:"net_sales"` // Sale totals as charged to customers
+	Payments        []RegisterPaymentTotal `json:"payments"`
+	RefundCount     int                    `json:"refund_count"`
+	RefundAmount    decimal.Decimal        `json:"refund_amount"`
+	Refunds         []RegisterPaymentTotal `json:"refunds"`
+}
+
+// RegisterReport summarizes a register session's sales, refunds and drawer. An X-report is a
+// snapshot of an open session taken mid-shift; it changes nothing. A Z-report closes the
+// register's day once its session is closed: the counters of the next session start from zero,
+// while the Z number and the grand total carry on from one Z-report to the next.
+type RegisterReport struct {
+	ID          uuid.UUID               `json:"id"`
+	TenantID    uuid.UUID               `json:"tenant_id"`
+	SessionID   uuid.UUID               `json:"session_id"`
+	Register    string                  `json:"register"`
+	Type        RegisterReportType      `json:"type"`
+	Number      int                     `json:"number,omitempty"`      // Z-reports only, counts up per register
+	GrandTotal  *decimal.Decimal        `json:"grand_total,omitempty"` // Z-reports only, net sales of every Z-report of the register so far
+	Sales       RegisterSalesTotals     `json:"sales"`
+	Drawer      *RegisterReconciliation `json:"drawer"`
+	GeneratedBy uuid.UUID               `json:"generated_by"`
+	GeneratedAt time.Time               `json:"generated_at"`
+}
+
+// NewXReport takes a mid-shift snapshot of an open session
+func NewXReport(session *RegisterSession, sales RegisterSalesTotals, cash RegisterCashTotals, userID uuid.UUID, now time.Time) (*RegisterReport, error) {
+	if !session.IsOpen() {
+		return nil, errors.NewValidationError("session closed", "X-reports can only be taken on open sessions, take a Z-report instead")
+	}
+
+	return newRegisterReport(RegisterReportTypeX, session, sales, cash, userID, now), nil
+}
+
+// NewZReport takes the closing report of a closed session, numbered after the register's
+// previous Z-report, if any
+func NewZReport(session *RegisterSession, sales RegisterSalesTotals, cash RegisterCashTotals, previous *RegisterReport, userID uuid.UUID, now time.Time) (*RegisterReport, error) {
+	if session.IsOpen() {
+		return nil, errors.NewValidationError("session open", "Z-reports can only be taken once the session is closed")
+	}
+	if previous != nil && (previous.Type != RegisterReportTypeZ || previous.Register != session.Register) {
+		return nil, errors.NewValidationError("invalid previous report", "previous report must be a Z-report of the same register")
+	}
+
+	report := newRegisterReport(RegisterReportTypeZ, session, sales, cash, userID, now)
+	report.Number = 1
+	grandTotal := sales.NetSales
+	if previous != nil {
+		report.Number = previous.Number + 1
+		if previous.GrandTotal != nil {
+			grandTotal = previous.GrandTotal.Add(grandTotal)
+		}
+	}
+	report.GrandTotal = &grandTotal
+
+	return report, nil
+}
+
+func newRegisterReport(reportType RegisterReportType, session *RegisterSession, sales RegisterSalesTotals, cash RegisterCashTotals, userID uuid.UUID, now time.Time) *RegisterReport {
+	return &RegisterReport{
+		ID:          uuid.New(),
+		TenantID:    session.TenantID,
+		SessionID:   session.ID,
+		Register:    session.Register,
+		Type:        reportType,
+		Sales:       sales,
+		Drawer:      NewRegisterReconciliation(session, cash),
+		GeneratedBy: userID,
+		GeneratedAt: now,
+	}
+}
+
+// ValidateRegisterReportType validates register report type
+func ValidateRegisterReportType(reportType RegisterReportType) error {
+	switch reportType {
+	case RegisterReportTypeX, RegisterReportTypeZ:
+		return nil
+	default:
+		return errors.NewValidationError("invalid report type", "type must be one of: x, z")
+	}
+}
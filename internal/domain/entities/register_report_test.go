@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewXReport(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("open session", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+		userID := uuid.New()
+		sales := RegisterSalesTotals{SaleCount: 3, NetSales: decimal.NewFromInt(150)}
+		cash := RegisterCashTotals{CashSales: decimal.NewFromInt(100), SaleCount: 2}
+
+		report, err := NewXReport(session, sales, cash, userID, now.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, RegisterReportTypeX, report.Type)
+		assert.Equal(t, session.ID, report.SessionID)
+		assert.Equal(t, "front-1", report.Register)
+		assert.Zero(t, report.Number)
+		assert.Nil(t, report.GrandTotal)
+		assert.Equal(t, 3, report.Sales.SaleCount)
+		assert.True(t, decimal.NewFromInt(200).Equal(report.Drawer.ExpectedCash))
+		assert.Equal(t, userID, report.GeneratedBy)
+	})
+
+	t.Run("closed session", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+		require.NoError(t, session.Close(RegisterCashTotals{}, decimal.NewFromInt(200), "", uuid.New(), now.Add(time.Hour)))
+
+		report, err := NewXReport(session, RegisterSalesTotals{}, RegisterCashTotals{}, uuid.New(), now.Add(time.Hour))
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		assert.Contains(t, err.Error(), "session closed")
+	})
+}
+
+func TestNewZReport(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	closedSession := func(t *testing.T) *RegisterSession {
+		session := createValidRegisterSession(t, now)
+		require.NoError(t, session.Close(RegisterCashTotals{}, decimal.NewFromInt(200), "", uuid.New(), now.Add(8*time.Hour)))
+		return session
+	}
+
+	t.Run("first Z-report of the register", func(t *testing.T) {
+		session := closedSession(t)
+
+		report, err := NewZReport(session, RegisterSalesTotals{NetSales: decimal.NewFromInt(500)}, RegisterCashTotals{}, nil, uuid.New(), now.Add(8*time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, RegisterReportTypeZ, report.Type)
+		assert.Equal(t, 1, report.Number)
+		require.NotNil(t, report.GrandTotal)
+		assert.True(t, decimal.NewFromInt(500).Equal(*report.GrandTotal))
+		require.NotNil(t, report.Drawer.CountedCash)
+	})
+
+	t.Run("carries on from the previous Z-report", func(t *testing.T) {
+		session := closedSession(t)
+		grandTotal := decimal.NewFromInt(1200)
+		previous := &RegisterReport{Type: RegisterReportTypeZ, Register: "front-1", Number: 41, GrandTotal: &grandTotal}
+
+		report, err := NewZReport(session, RegisterSalesTotals{NetSales: decimal.NewFromInt(300)}, RegisterCashTotals{}, previous, uuid.New(), now.Add(8*time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, report.Number)
+		assert.True(t, decimal.NewFromInt(1500).Equal(*report.GrandTotal))
+	})
+
+	t.Run("open session", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		report, err := NewZReport(session, RegisterSalesTotals{}, RegisterCashTotals{}, nil, uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		assert.Contains(t, err.Error(), "session open")
+	})
+
+	t.Run("previous report of another register", func(t *testing.T) {
+		session := closedSession(t)
+		previous := &RegisterReport{Type: RegisterReportTypeZ, Register: "front-2", Number: 3}
+
+		_, err := NewZReport(session, RegisterSalesTotals{}, RegisterCashTotals{}, previous, uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid previous report")
+	})
+}
+
+func TestValidateRegisterReportType(t *testing.T) {
+	assert.NoError(t, ValidateRegisterReportType(RegisterReportTypeX))
+	assert.NoError(t, ValidateRegisterReportType(RegisterReportTypeZ))
+	assert.Error(t, ValidateRegisterReportType(RegisterReportType("y")))
+}
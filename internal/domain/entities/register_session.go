@@ -0,0 +1,310 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// RegisterSessionStatus represents the status of a register session
+type RegisterSessionStatus string
+
+const (
+	RegisterSessionStatusOpen   RegisterSessionStatus = "open"
+	RegisterSessionStatusClosed RegisterSessionStatus = "closed"
+)
+
+// CashMovementType represents the direction of cash put into or taken out of a drawer
+// outside of sales
+type CashMovementType string
+
+const (
+	CashMovementTypeIn  CashMovementType = "cash_in"  // e.g. extra change brought to the drawer
+	CashMovementTypeOut CashMovementType = "cash_out" // e.g. a cash drop to the safe or a petty cash payout
+)
+
+// RegisterSession represents a cashier's shift on a register's cash drawer, from opening it
+// with a float to counting it at close. Cash taken by the cashier's sales during the session
+// is expected to be in the drawer.
+type RegisterSession struct {
+	ID           uuid.UUID             `json:"id"`
+	TenantID     uuid.UUID             `json:"tenant_id"`
+	Register     string                `json:"register"` // Name of the register, e.g. "front-1"
+	Status       RegisterSessionStatus `json:"status"`
+	OpeningFloat decimal.Decimal       `json:"opening_float"`
+	Movements    []CashMovement        `json:"movements"`
+	// Set when the session is closed
+	CashSales    *decimal.Decimal `json:"cash_sales,omitempty"`
+	CashRefunds  *decimal.Decimal `json:"cash_refunds,omitempty"`
+	ExpectedCash *decimal.Decimal `json:"expected_cash,omitempty"`
+	CountedCash  *decimal.Decimal `json:"counted_cash,omitempty"`
+	Notes        string           `json:"notes,omitempty"`
+	OpenedBy     uuid.UUID        `json:"opened_by"`
+	OpenedAt     time.Time        `json:"opened_at"`
+	ClosedBy     *uuid.UUID       `json:"closed_by,omitempty"`
+	ClosedAt     *time.Time       `json:"closed_at,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// CashMovement represents cash put into or taken out of a drawer outside of sales
+type CashMovement struct {
+	ID        uuid.UUID        `json:"id"`
+	SessionID uuid.UUID        `json:"session_id"`
+	Type      CashMovementType `json:"type"`
+	Amount    decimal.Decimal  `json:"amount"`
+	Reason    string           `json:"reason"`
+	CreatedBy uuid.UUID        `json:"created_by"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// RegisterCashTotals represents the cash taken and given back by a cashier's sales and refunds
+// during a register session
+type RegisterCashTotals struct {
+	CashSales   decimal.Decimal `json:"cash_sales"`
+	CashRefunds decimal.Decimal `json:"cash_refunds"`
+	SaleCount   int             `json:"sale_count"`
+	RefundCount int             `json:"refund_count"`
+}
+
+// NewRegisterSession opens a register session with the cash float put in the drawer
+func NewRegisterSession(tenantID uuid.UUID, register string, openingFloat decimal.Decimal, openedBy uuid.UUID, now time.Time) (*RegisterSession, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if openedBy == uuid.Nil {
+		return nil, errors.NewValidationError("user ID is required", "user ID cannot be empty")
+	}
+
+	register = strings.TrimSpace(register)
+	if register == "" {
+		return nil, errors.NewValidationError("register is required", "register cannot be empty")
+	}
+	if len(register) > 100 {
+		return nil, errors.NewValidationError("register too long", "register cannot exceed 100 characters")
+	}
+	if openingFloat.IsNegative() {
+		return nil, errors.NewValidationError("invalid opening float", "opening float cannot be negative")
+	}
+
+	return &RegisterSession{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		Register:     register,
+		Status:       RegisterSessionStatusOpen,
+		OpeningFloat: openingFloat,
+		Movements:    []CashMovement{},
+		OpenedBy:     openedBy,
+		OpenedAt:     now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// IsOpen checks if the session is still open
+func (s *RegisterSession) IsOpen() bool {
+	return s.Status == RegisterSessionStatusOpen
+}
+
+// AddMovement records cash put into or taken out of the drawer
+func (s *RegisterSession) AddMovement(movementType CashMovementType, amount decimal.Decimal, reason string, userID uuid.UUID, now time.Time) (*CashMovement, error) {
+	if !s.IsOpen() {
+		return nil, errors.NewValidationError("session closed", "cash movements can only be recorded on open sessions")
+	}
+	if movementType != CashMovementTypeIn && movementType != CashMovementTypeOut {
+		return nil, errors.NewValidationError("invalid movement type", "type must be one of: cash_in, cash_out")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("invalid amount", "amount must be positive")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.NewValidationError("reason is required", "reason cannot be empty")
+	}
+	if len(reason) > 255 {
+		return nil, errors.NewValidationError("reason too long", "reason cannot exceed 255 characters")
+	}
+
+	s.Movements = append(s.Movements, CashMovement{
+		ID:        uuid.New(),
+		SessionID: s.ID,
+		Type:      movementType,
+		Amount:    amount,
+		Reason:    reason,
+		CreatedBy: userID,
+		CreatedAt: now,
+	})
+	s.UpdatedAt = now
+
+	return &s.Movements[len(s.Movements)-1], nil
+}
+
+// CashIn returns the total cash put into the drawer outside of sales
+func (s *RegisterSession) CashIn() decimal.Decimal {
+	return s.movementTotal(CashMovementTypeIn)
+}
+
+// CashOut returns the total cash taken out of the drawer outside of sales
+func (s *RegisterSession) CashOut() decimal.Decimal {
+	return s.movementTotal(CashMovementTypeOut)
+}
+
+// ExpectedCashWith returns the cash expected in the drawer given the session's sales and
+// refunds: the opening float, plus cash sales and cash in, minus cash refunds and cash out
+func (s *RegisterSession) ExpectedCashWith(totals RegisterCashTotals) decimal.Decimal {
+	return s.OpeningFloat.
+		Add(totals.CashSales).
+		Sub(totals.CashRefunds).
+		Add(s.CashIn()).
+		Sub(s.CashOut())
+}
+
+// Close closes the session with the cash counted in the drawer, recording the cash expected
+// from the session's sales and refunds
+func (s *RegisterSession) Close(totals RegisterCashTotals, countedCash decimal.Decimal, notes string, userID uuid.UUID, now time.Time) error {
+	if !s.IsOpen() {
+		return errors.NewValidationError("session closed", "session has already been closed")
+	}
+	if countedCash.IsNegative() {
+		return errors.NewValidationError("invalid counted cash", "counted cash cannot be negative")
+	}
+	if len(notes) > 500 {
+		return errors.NewValidationError("notes too long", "notes cannot exceed 500 characters")
+	}
+
+	expected := s.ExpectedCashWith(totals)
+	s.Status = RegisterSessionStatusClosed
+	s.CashSales = &totals.CashSales
+	s.CashRefunds = &totals.CashRefunds
+	s.ExpectedCash = &expected
+	s.CountedCash = &countedCash
+	s.Notes = strings.TrimSpace(notes)
+	s.ClosedBy = &userID
+	s.ClosedAt = &now
+	s.UpdatedAt = now
+	return nil
+}
+
+func (s *RegisterSession) movementTotal(movementType CashMovementType) decimal.Decimal {
+	total := decimal.Zero
+	for _, movement := range s.Movements {
+		if movement.Type == movementType {
+			total = total.Add(movement.Amount)
+		}
+	}
+	return total
+}
+
+// ValidateRegisterSessionStatus validates register session status
+func ValidateRegisterSessionStatus(status RegisterSessionStatus) error {
+	switch status {
+	case RegisterSessionStatusOpen, RegisterSessionStatusClosed:
+		return nil
+	default:
+		return errors.NewValidationError("invalid session status", "status must be one of: open, closed")
+	}
+}
+
+// RegisterReconciliation compares the cash expected in a register's drawer with the cash
+// counted at close. An open session is reconciled up to now and has no counted cash.
+type RegisterReconciliation struct {
+	SessionID    uuid.UUID             `json:"session_id"`
+	Register     string                `json:"register"`
+	Status       RegisterSessionStatus `json:"status"`
+	OpenedBy     uuid.UUID             `json:"opened_by"`
+	OpenedAt     time.Time             `json:"opened_at"`
+	ClosedAt     *time.Time            `json:"closed_at,omitempty"`
+	OpeningFloat decimal.Decimal       `json:"opening_float"`
+	CashSales    decimal.Decimal       `json:"cash_sales"`
+	CashRefunds  decimal.Decimal       `json:"cash_refunds"`
+	CashIn       decimal.Decimal       `json:"cash_in"`
+	CashOut      decimal.Decimal       `json:"cash_out"`
+	SaleCount    int                   `json:"sale_count"`
+	RefundCount  int                   `json:"refund_count"`
+	ExpectedCash decimal.Decimal       `json:"expected_cash"`
+	CountedCash  *decimal.Decimal      `json:"counted_cash,omitempty"`
+	Difference   *decimal.Decimal      `json:"difference,omitempty"` // Counted minus expected; negative when cash is short
+}
+
+// NewRegisterReconciliation reconciles a session with its sales and refunds totals. Closed
+// sessions are reconciled with the figures recorded at close.
+func NewRegisterReconciliation(session *RegisterSession, totals RegisterCashTotals) *RegisterReconciliation {
+	reconciliation := &RegisterReconciliation{
+		SessionID:    session.ID,
+		Register:     session.Register,
+		Status:       session.Status,
+		OpenedBy:     session.OpenedBy,
+		OpenedAt:     session.OpenedAt,
+		ClosedAt:     session.ClosedAt,
+		OpeningFloat: session.OpeningFloat,
+		CashSales:    totals.CashSales,
+		CashRefunds:  totals.CashRefunds,
+		CashIn:       session.CashIn(),
+		CashOut:      session.CashOut(),
+		SaleCount:    totals.SaleCount,
+		RefundCount:  totals.RefundCount,
+		ExpectedCash: session.ExpectedCashWith(totals),
+	}
+
+	if !session.IsOpen() {
+		if session.CashSales != nil {
+			reconciliation.CashSales = *session.CashSales
+		}
+		if session.CashRefunds != nil {
+			reconciliation.CashRefunds = *session.CashRefunds
+		}
+		if session.ExpectedCash != nil {
+			reconciliation.ExpectedCash = *session.ExpectedCash
+		}
+		if session.CountedCash != nil {
+			difference := session.CountedCash.Sub(reconciliation.ExpectedCash)
+			reconciliation.CountedCash = session.CountedCash
+			reconciliation.Difference = &difference
+		}
+	}
+
+	return reconciliation
+}
+
+// DailyRegisterReconciliation summarizes the reconciliations of the register sessions opened
+// on a day
+type DailyRegisterReconciliation struct {
+	Date         string                    `json:"date"`
+	Timezone     string                    `json:"timezone"`
+	Sessions     []*RegisterReconciliation `json:"sessions"`
+	OpenSessions int                       `json:"open_sessions"`
+	ExpectedCash decimal.Decimal           `json:"expected_cash"`
+	CountedCash  decimal.Decimal           `json:"counted_cash"` // Of closed sessions
+	Difference   decimal.Decimal           `json:"difference"`   // Of closed sessions
+}
+
+// NewDailyRegisterReconciliation totals the reconciliations of a day's sessions
+func NewDailyRegisterReconciliation(date, timezone string, sessions []*RegisterReconciliation) *DailyRegisterReconciliation {
+	daily := &DailyRegisterReconciliation{
+		Date:         date,
+		Timezone:     timezone,
+		Sessions:     sessions,
+		ExpectedCash: decimal.Zero,
+		CountedCash:  decimal.Zero,
+		Difference:   decimal.Zero,
+	}
+
+	for _, session := range sessions {
+		daily.ExpectedCash = daily.ExpectedCash.Add(session.ExpectedCash)
+		if session.Status == RegisterSessionStatusOpen {
+			daily.OpenSessions++
+			continue
+		}
+		if session.CountedCash != nil {
+			daily.CountedCash = daily.CountedCash.Add(*session.CountedCash)
+			daily.Difference = daily.Difference.Add(*session.Difference)
+		}
+	}
+
+	return daily
+}
@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegisterSession(t *testing.T) {
+	t.Run("valid session", func(t *testing.T) {
+		tenantID := uuid.New()
+		userID := uuid.New()
+		now := time.Now()
+
+		session, err := NewRegisterSession(tenantID, " front-1 ", decimal.NewFromInt(200), userID, now)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, session.ID)
+		assert.Equal(t, tenantID, session.TenantID)
+		assert.Equal(t, "front-1", session.Register)
+		assert.True(t, session.IsOpen())
+		assert.True(t, decimal.NewFromInt(200).Equal(session.OpeningFloat))
+		assert.Equal(t, userID, session.OpenedBy)
+		assert.Equal(t, now, session.OpenedAt)
+		assert.Empty(t, session.Movements)
+	})
+
+	t.Run("invalid register - empty", func(t *testing.T) {
+		session, err := NewRegisterSession(uuid.New(), "  ", decimal.Zero, uuid.New(), time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+		assert.Contains(t, err.Error(), "register is required")
+	})
+
+	t.Run("invalid opening float - negative", func(t *testing.T) {
+		session, err := NewRegisterSession(uuid.New(), "front-1", decimal.NewFromInt(-1), uuid.New(), time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+		assert.Contains(t, err.Error(), "invalid opening float")
+	})
+}
+
+func TestRegisterSession_AddMovement(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("cash in and out", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		_, err := session.AddMovement(CashMovementTypeIn, decimal.NewFromInt(50), "Change from safe", uuid.New(), now.Add(time.Hour))
+		require.NoError(t, err)
+		movement, err := session.AddMovement(CashMovementTypeOut, decimal.NewFromInt(120), "Cash drop", uuid.New(), now.Add(2*time.Hour))
+		require.NoError(t, err)
+
+		assert.Equal(t, session.ID, movement.SessionID)
+		assert.Len(t, session.Movements, 2)
+		assert.True(t, decimal.NewFromInt(50).Equal(session.CashIn()))
+		assert.True(t, decimal.NewFromInt(120).Equal(session.CashOut()))
+	})
+
+	t.Run("invalid amount - zero", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		_, err := session.AddMovement(CashMovementTypeIn, decimal.Zero, "Change", uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid amount")
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		_, err := session.AddMovement(CashMovementType("transfer"), decimal.NewFromInt(10), "Change", uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid movement type")
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		_, err := session.AddMovement(CashMovementTypeOut, decimal.NewFromInt(10), " ", uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "reason is required")
+	})
+
+	t.Run("closed session", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+		require.NoError(t, session.Close(RegisterCashTotals{}, decimal.NewFromInt(100), "", uuid.New(), now.Add(time.Hour)))
+
+		_, err := session.AddMovement(CashMovementTypeIn, decimal.NewFromInt(10), "Change", uuid.New(), now.Add(2*time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "session closed")
+	})
+}
+
+func TestRegisterSession_Close(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	totals := RegisterCashTotals{
+		CashSales:   decimal.RequireFromString("450.50"),
+		CashRefunds: decimal.RequireFromString("20.50"),
+		SaleCount:   12,
+		RefundCount: 1,
+	}
+
+	t.Run("records expected cash", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+		_, err := session.AddMovement(CashMovementTypeIn, decimal.NewFromInt(50), "Change from safe", uuid.New(), now)
+		require.NoError(t, err)
+		_, err = session.AddMovement(CashMovementTypeOut, decimal.NewFromInt(300), "Cash drop", uuid.New(), now)
+		require.NoError(t, err)
+		closedBy := uuid.New()
+
+		err = session.Close(totals, decimal.NewFromInt(375), " Short on coins ", closedBy, now.Add(8*time.Hour))
+
+		require.NoError(t, err)
+		assert.False(t, session.IsOpen())
+		// 100 float + 450.50 sales - 20.50 refunds + 50 in - 300 out
+		assert.True(t, decimal.NewFromInt(280).Equal(*session.ExpectedCash))
+		assert.True(t, decimal.NewFromInt(375).Equal(*session.CountedCash))
+		assert.Equal(t, "Short on coins", session.Notes)
+		assert.Equal(t, &closedBy, session.ClosedBy)
+	})
+
+	t.Run("cannot close twice", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+		require.NoError(t, session.Close(totals, decimal.NewFromInt(530), "", uuid.New(), now.Add(time.Hour)))
+
+		err := session.Close(totals, decimal.NewFromInt(530), "", uuid.New(), now.Add(2*time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "session closed")
+	})
+
+	t.Run("invalid counted cash - negative", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		err := session.Close(totals, decimal.NewFromInt(-1), "", uuid.New(), now.Add(time.Hour))
+
+		assert.Error(t, err)
+		assert.True(t, session.IsOpen())
+	})
+}
+
+func TestNewRegisterReconciliation(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	totals := RegisterCashTotals{CashSales: decimal.NewFromInt(400), SaleCount: 5}
+
+	t.Run("open session has no difference", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+
+		reconciliation := NewRegisterReconciliation(session, totals)
+
+		assert.True(t, decimal.NewFromInt(500).Equal(reconciliation.ExpectedCash))
+		assert.Nil(t, reconciliation.CountedCash)
+		assert.Nil(t, reconciliation.Difference)
+		assert.Equal(t, 5, reconciliation.SaleCount)
+	})
+
+	t.Run("closed session is short", func(t *testing.T) {
+		session := createValidRegisterSession(t, now)
+		require.NoError(t, session.Close(totals, decimal.NewFromInt(490), "", uuid.New(), now.Add(time.Hour)))
+
+		// Sales recorded after close do not change the reconciliation
+		later := RegisterCashTotals{CashSales: decimal.NewFromInt(450), SaleCount: 6}
+		reconciliation := NewRegisterReconciliation(session, later)
+
+		assert.True(t, decimal.NewFromInt(500).Equal(reconciliation.ExpectedCash))
+		assert.True(t, decimal.NewFromInt(400).Equal(reconciliation.CashSales))
+		assert.True(t, decimal.NewFromInt(-10).Equal(*reconciliation.Difference))
+	})
+}
+
+func TestNewDailyRegisterReconciliation(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	totals := RegisterCashTotals{CashSales: decimal.NewFromInt(400)}
+
+	closed := createValidRegisterSession(t, now)
+	require.NoError(t, closed.Close(totals, decimal.NewFromInt(505), "", uuid.New(), now.Add(time.Hour)))
+	open := createValidRegisterSession(t, now)
+
+	daily := NewDailyRegisterReconciliation("2026-10-12", "UTC", []*RegisterReconciliation{
+		NewRegisterReconciliation(closed, totals),
+		NewRegisterReconciliation(open, totals),
+	})
+
+	assert.Len(t, daily.Sessions, 2)
+	assert.Equal(t, 1, daily.OpenSessions)
+	assert.True(t, decimal.NewFromInt(1000).Equal(daily.ExpectedCash))
+	assert.True(t, decimal.NewFromInt(505).Equal(daily.CountedCash))
+	assert.True(t, decimal.NewFromInt(5).Equal(daily.Difference))
+}
+
+func createValidRegisterSession(t *testing.T, now time.Time) *RegisterSession {
+	t.Helper()
+
+	session, err := NewRegisterSession(uuid.New(), "front-1", decimal.NewFromInt(100), uuid.New(), now)
+	require.NoError(t, err)
+	return session
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReorderSuggestion represents how much of a low-stock product should be ordered so that it
+// lasts until the supplier delivers and for a number of days after
+type ReorderSuggestion struct {
+	ProductID     uuid.UUID       `json:"product_id"`
+	ProductSKU    string          `json:"product_sku"`
+	ProductName   string          `json:"product_name"`
+	SupplierID    *uuid.UUID      `json:"supplier_id,omitempty"`
+	SupplierName  string          `json:"supplier_name,omitempty"`
+	SupplierEmail string          `json:"supplier_email,omitempty"`
+	SupplierPhone string          `json:"supplier_phone,omitempty"`
+	AvailableQty  int             `json:"available_qty"`
+	ReorderLevel  int             `json:"reorder_level"`
+	OnOrderQty    int             `json:"on_order_qty"`  // Still to be delivered on open purchase orders
+	QuantitySold  int             `json:"quantity_sold"` // Net of returns, over the sales window
+	DailyVelocity decimal.Decimal `json:"daily_velocity"`
+	LeadTimeDays  int             `json:"lead_time_days"`
+	CoverDays     int             `json:"cover_days"`
+	SuggestedQty  int             `json:"suggested_qty"`
+}
+
+// Calculate works out the daily sales velocity over salesDays and the quantity to order. The
+// order has to cover the expected sales during the supplier lead time plus coverDays, on top of
+// the reorder level, less what is available and already on order. A product with no sales is
+// still topped up just above its reorder level.
+func (r *ReorderSuggestion) Calculate(salesDays, coverDays int) {
+	r.CoverDays = coverDays
+	if salesDays <= 0 || r.QuantitySold <= 0 {
+		r.DailyVelocity = decimal.Zero
+	} else {
+		r.DailyVelocity = decimal.NewFromInt(int64(r.QuantitySold)).Div(decimal.NewFromInt(int64(salesDays)))
+	}
+
+	demand := int(r.DailyVelocity.Mul(decimal.NewFromInt(int64(r.LeadTimeDays + coverDays))).Ceil().IntPart())
+	if demand < 1 {
+		demand = 1
+	}
+
+	r.SuggestedQty = r.ReorderLevel + demand - r.AvailableQty - r.OnOrderQty
+	if r.SuggestedQty < 0 {
+		r.SuggestedQty = 0
+	}
+	r.DailyVelocity = r.DailyVelocity.Round(2)
+}
+
+// NeedsOrder reports whether anything should be ordered
+func (r *ReorderSuggestion) NeedsOrder() bool {
+	return r.SuggestedQty > 0
+}
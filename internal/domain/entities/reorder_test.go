@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorderSuggestion_Calculate(t *testing.T) {
+	t.Run("covers lead time and cover days", func(t *testing.T) {
+		suggestion := &ReorderSuggestion{AvailableQty: 4, ReorderLevel: 10, QuantitySold: 60, LeadTimeDays: 5}
+
+		suggestion.Calculate(30, 10)
+
+		// 2 a day over 15 days on top of the reorder level, less what is available
+		assert.True(t, decimal.NewFromInt(2).Equal(suggestion.DailyVelocity))
+		assert.Equal(t, 10, suggestion.CoverDays)
+		assert.Equal(t, 36, suggestion.SuggestedQty)
+		assert.True(t, suggestion.NeedsOrder())
+	})
+
+	t.Run("rounds expected demand up", func(t *testing.T) {
+		suggestion := &ReorderSuggestion{AvailableQty: 0, ReorderLevel: 5, QuantitySold: 10, LeadTimeDays: 2}
+
+		suggestion.Calculate(30, 5)
+
+		assert.True(t, decimal.NewFromFloat(0.33).Equal(suggestion.DailyVelocity))
+		assert.Equal(t, 8, suggestion.SuggestedQty)
+	})
+
+	t.Run("subtracts quantity on order", func(t *testing.T) {
+		suggestion := &ReorderSuggestion{AvailableQty: 4, ReorderLevel: 10, OnOrderQty: 30, QuantitySold: 60, LeadTimeDays: 5}
+
+		suggestion.Calculate(30, 10)
+
+		assert.Equal(t, 6, suggestion.SuggestedQty)
+	})
+
+	t.Run("nothing to order when already covered", func(t *testing.T) {
+		suggestion := &ReorderSuggestion{AvailableQty: 2, ReorderLevel: 10, OnOrderQty: 50, QuantitySold: 30, LeadTimeDays: 3}
+
+		suggestion.Calculate(30, 7)
+
+		assert.Equal(t, 0, suggestion.SuggestedQty)
+		assert.False(t, suggestion.NeedsOrder())
+	})
+
+	t.Run("tops up above the reorder level without sales", func(t *testing.T) {
+		suggestion := &ReorderSuggestion{AvailableQty: 3, ReorderLevel: 3, LeadTimeDays: 7}
+
+		suggestion.Calculate(30, 14)
+
+		assert.True(t, decimal.Zero.Equal(suggestion.DailyVelocity))
+		assert.Equal(t, 1, suggestion.SuggestedQty)
+	})
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// RetentionHold exempts a single record from retention purges while it is under dispute or audit.
+// A hold on any record also keeps the audit log entries of that record.
+type RetentionHold struct {
+	ID         uuid.UUID       `json:"id"`
+	TenantID   uuid.UUID       `json:"tenant_id"`
+	Entity     RetentionEntity `json:"entity"`
+	RecordID   uuid.UUID       `json:"record_id"`
+	Reason     string          `json:"reason"`
+	CreatedAt  time.Time       `json:"created_at"`
+	CreatedBy  uuid.UUID       `json:"created_by"`
+	ReleasedAt *time.Time      `json:"released_at,omitempty"`
+	ReleasedBy *uuid.UUID      `json:"released_by,omitempty"`
+}
+
+// NewRetentionHold places a hold on a record
+func NewRetentionHold(tenantID uuid.UUID, entity RetentionEntity, recordID uuid.UUID, reason string, createdBy uuid.UUID) (*RetentionHold, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if !entity.IsValid() {
+		return nil, errors.NewValidationError("invalid retention entity", "unsupported entity: "+string(entity))
+	}
+	if recordID == uuid.Nil {
+		return nil, errors.NewValidationError("record ID is required", "record ID cannot be empty")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.NewValidationError("hold reason is required", "reason cannot be empty")
+	}
+	if len(reason) > 500 {
+		return nil, errors.NewValidationError("hold reason too long", "reason cannot exceed 500 characters")
+	}
+
+	hold := &RetentionHold{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Entity:    entity,
+		RecordID:  recordID,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+
+	return hold, nil
+}
+
+// IsActive checks if the hold still protects its record
+func (h *RetentionHold) IsActive() bool {
+	return h.ReleasedAt == nil
+}
+
+// Release lifts the hold so the record becomes subject to its retention policy again
+func (h *RetentionHold) Release(releasedBy uuid.UUID) error {
+	if !h.IsActive() {
+		return errors.NewValidationError("hold already released", "retention hold is no longer active")
+	}
+
+	now := time.Now()
+	h.ReleasedAt = &now
+	h.ReleasedBy = &releasedBy
+	return nil
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// RetentionEntity represents a kind of record that can be purged by a retention policy
+type RetentionEntity string
+
+const (
+	RetentionEntityAuditLogs       RetentionEntity = "audit_logs"
+	RetentionEntityStockMovements  RetentionEntity = "stock_movements"
+	RetentionEntityArchivedSales   RetentionEntity = "archived_sales"   // Soft-deleted sales that are not invoiced
+	RetentionEntityDeletedInvoices RetentionEntity = "deleted_invoices" // Soft-deleted invoices
+)
+
+// retentionMinimumDays is the shortest retention period allowed per entity, so records
+// needed for reconciliation and compliance cannot be purged by mistake
+var retentionMinimumDays = map[RetentionEntity]int{
+	RetentionEntityAuditLogs:       365,
+	RetentionEntityStockMovements:  90,
+	RetentionEntityArchivedSales:   30,
+	RetentionEntityDeletedInvoices: 30,
+}
+
+// RetentionEntities returns all entities that support retention policies
+func RetentionEntities() []RetentionEntity {
+	return []RetentionEntity{
+		RetentionEntityAuditLogs,
+		RetentionEntityStockMovements,
+		RetentionEntityArchivedSales,
+		RetentionEntityDeletedInvoices,
+	}
+}
+
+// IsValid checks if the retention entity is supported
+func (e RetentionEntity) IsValid() bool {
+	_, ok := retentionMinimumDays[e]
+	return ok
+}
+
+// IsRestorable checks if the entity's records are soft-deleted and can be restored until purged
+func (e RetentionEntity) IsRestorable() bool {
+	return e == RetentionEntityArchivedSales || e == RetentionEntityDeletedInvoices
+}
+
+// MinimumDays returns the shortest retention period allowed for the entity
+func (e RetentionEntity) MinimumDays() int {
+	return retentionMinimumDays[e]
+}
+
+// RetentionPolicy represents how long a tenant keeps records of one entity before they are purged.
+// Records are kept forever unless an enabled policy exists for the entity.
+type RetentionPolicy struct {
+	ID            uuid.UUID       `json:"id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	Entity        RetentionEntity `json:"entity"`
+	RetentionDays int             `json:"retention_days"`
+	IsEnabled     bool            `json:"is_enabled"`
+	LastPurgedAt  *time.Time      `json:"last_purged_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	UpdatedBy     uuid.UUID       `json:"updated_by"`
+}
+
+// NewRetentionPolicy creates a new retention policy for an entity
+func NewRetentionPolicy(tenantID uuid.UUID, entity RetentionEntity, retentionDays int, enabled bool, updatedBy uuid.UUID) (*RetentionPolicy, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if !entity.IsValid() {
+		return nil, errors.NewValidationError("invalid retention entity", "unsupported entity: "+string(entity))
+	}
+
+	now := time.Now()
+	policy := &RetentionPolicy{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Entity:    entity,
+		CreatedAt: now,
+	}
+
+	if err := policy.Update(retentionDays, enabled, updatedBy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Update changes the retention period and whether the policy is enforced
+func (p *RetentionPolicy) Update(retentionDays int, enabled bool, updatedBy uuid.UUID) error {
+	if minimum := p.Entity.MinimumDays(); retentionDays < minimum {
+		return errors.NewValidationError("retention period too short",
+			"retention for "+string(p.Entity)+" must be at least "+strconv.Itoa(minimum)+" days")
+	}
+
+	p.RetentionDays = retentionDays
+	p.IsEnabled = enabled
+	p.UpdatedBy = updatedBy
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cutoff returns the instant before which records are eligible for purging
+func (p *RetentionPolicy) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -p.RetentionDays)
+}
+
+// MarkPurged records when the policy was last enforced
+func (p *RetentionPolicy) MarkPurged(at time.Time) {
+	p.LastPurgedAt = &at
+	p.UpdatedAt = at
+}
@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetentionPolicy(t *testing.T) {
+	t.Run("valid policy creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		userID := uuid.New()
+
+		policy, err := NewRetentionPolicy(tenantID, RetentionEntityStockMovements, 180, true, userID)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, policy.ID)
+		assert.Equal(t, tenantID, policy.TenantID)
+		assert.Equal(t, RetentionEntityStockMovements, policy.Entity)
+		assert.Equal(t, 180, policy.RetentionDays)
+		assert.True(t, policy.IsEnabled)
+		assert.Equal(t, userID, policy.UpdatedBy)
+		assert.Nil(t, policy.LastPurgedAt)
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		policy, err := NewRetentionPolicy(uuid.Nil, RetentionEntityAuditLogs, 365, true, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("unsupported entity", func(t *testing.T) {
+		policy, err := NewRetentionPolicy(uuid.New(), RetentionEntity("customers"), 365, true, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("retention below entity minimum", func(t *testing.T) {
+		policy, err := NewRetentionPolicy(uuid.New(), RetentionEntityAuditLogs, 30, true, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, policy)
+	})
+}
+
+func TestRetentionPolicy_Update(t *testing.T) {
+	policy, err := NewRetentionPolicy(uuid.New(), RetentionEntityArchivedSales, 30, false, uuid.New())
+	require.NoError(t, err)
+
+	require.NoError(t, policy.Update(90, true, uuid.New()))
+	assert.Equal(t, 90, policy.RetentionDays)
+	assert.True(t, policy.IsEnabled)
+
+	assert.Error(t, policy.Update(7, true, uuid.New()))
+	assert.Equal(t, 90, policy.RetentionDays)
+}
+
+func TestRetentionPolicy_Cutoff(t *testing.T) {
+	policy, err := NewRetentionPolicy(uuid.New(), RetentionEntityStockMovements, 90, true, uuid.New())
+	require.NoError(t, err)
+
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC), policy.Cutoff(now))
+}
+
+func TestNewRetentionHold(t *testing.T) {
+	t.Run("valid hold creation", func(t *testing.T) {
+		recordID := uuid.New()
+
+		hold, err := NewRetentionHold(uuid.New(), RetentionEntityArchivedSales, recordID, "  chargeback dispute  ", uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, recordID, hold.RecordID)
+		assert.Equal(t, "chargeback dispute", hold.Reason)
+		assert.True(t, hold.IsActive())
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		hold, err := NewRetentionHold(uuid.New(), RetentionEntityArchivedSales, uuid.New(), " ", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, hold)
+	})
+
+	t.Run("record required", func(t *testing.T) {
+		hold, err := NewRetentionHold(uuid.New(), RetentionEntityAuditLogs, uuid.Nil, "tax audit", uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, hold)
+	})
+}
+
+func TestRetentionHold_Release(t *testing.T) {
+	hold, err := NewRetentionHold(uuid.New(), RetentionEntityStockMovements, uuid.New(), "stock audit", uuid.New())
+	require.NoError(t, err)
+
+	releasedBy := uuid.New()
+	require.NoError(t, hold.Release(releasedBy))
+
+	assert.False(t, hold.IsActive())
+	assert.NotNil(t, hold.ReleasedAt)
+	assert.Equal(t, releasedBy, *hold.ReleasedBy)
+	assert.Error(t, hold.Release(releasedBy))
+}
+
+func TestRetentionEntity_IsRestorable(t *testing.T) {
+	assert.True(t, RetentionEntityArchivedSales.IsRestorable())
+	assert.True(t, RetentionEntityDeletedInvoices.IsRestorable())
+	assert.False(t, RetentionEntityAuditLogs.IsRestorable())
+	assert.False(t, RetentionEntityStockMovements.IsRestorable())
+}
+
+func TestDeletedRecord_SchedulePurge(t *testing.T) {
+	deletedAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	policy, err := NewRetentionPolicy(uuid.New(), RetentionEntityDeletedInvoices, 30, true, uuid.New())
+	require.NoError(t, err)
+
+	t.Run("enabled policy", func(t *testing.T) {
+		record := &DeletedRecord{Entity: RetentionEntityDeletedInvoices, DeletedAt: deletedAt}
+		record.SchedulePurge(policy)
+
+		require.NotNil(t, record.PurgeAfter)
+		assert.Equal(t, time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC), *record.PurgeAfter)
+	})
+
+	t.Run("kept without an enabled policy", func(t *testing.T) {
+		record := &DeletedRecord{Entity: RetentionEntityDeletedInvoices, DeletedAt: deletedAt}
+		record.SchedulePurge(nil)
+		assert.Nil(t, record.PurgeAfter)
+
+		disabled, err := NewRetentionPolicy(uuid.New(), RetentionEntityDeletedInvoices, 30, false, uuid.New())
+		require.NoError(t, err)
+		record.SchedulePurge(disabled)
+		assert.Nil(t, record.PurgeAfter)
+	})
+
+	t.Run("kept while on hold", func(t *testing.T) {
+		record := &DeletedRecord{Entity: RetentionEntityDeletedInvoices, DeletedAt: deletedAt, IsOnHold: true}
+		record.SchedulePurge(policy)
+
+		assert.Nil(t, record.PurgeAfter)
+	})
+
+	t.Run("policy of another entity", func(t *testing.T) {
+		record := &DeletedRecord{Entity: RetentionEntityArchivedSales, DeletedAt: deletedAt}
+		record.SchedulePurge(policy)
+
+		assert.Nil(t, record.PurgeAfter)
+	})
+}
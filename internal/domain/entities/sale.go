@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,8 +19,12 @@ const (
 	SaleStatusCompleted SaleStatus = "completed"
 	SaleStatusCancelled SaleStatus = "cancelled"
 	SaleStatusRefunded  SaleStatus = "refunded"
+	SaleStatusHeld      SaleStatus = "held" // Parked at the till; its stock is reserved until resumed or expired
 )
 
+// MaxSaleHoldLabelLength caps the label a cashier gives a held sale
+const MaxSaleHoldLabelLength = 100
+
 // PaymentMethod represents payment method
 type PaymentMethod string
 
@@ -29,41 +35,88 @@ const (
 	PaymentMethodBankTransfer  PaymentMethod = "bank_transfer"
 )
 
+// SaleChannel represents the channel a sale originated from
+type SaleChannel string
+
+const (
+	SaleChannelInStore     SaleChannel = "in_store"
+	SaleChannelOnline      SaleChannel = "online"
+	SaleChannelMarketplace SaleChannel = "marketplace"
+	SaleChannelPhoneOrder  SaleChannel = "phone_order"
+)
+
+// SaleItemPriceSource represents where the charged unit price of a sale item came from
+type SaleItemPriceSource string
+
+const (
+	SaleItemPriceSourceList      SaleItemPriceSource = "list"
+	SaleItemPriceSourcePromotion SaleItemPriceSource = "promotion"
+	SaleItemPriceSourceOverride  SaleItemPriceSource = "override"
+)
+
 // Sale represents a sales transaction
 type Sale struct {
-	ID             uuid.UUID       `json:"id"`
-	TenantID       uuid.UUID       `json:"tenant_id"`
-	SaleNumber     string          `json:"sale_number"`
-	CustomerName   string          `json:"customer_name,omitempty"`
-	CustomerEmail  string          `json:"customer_email,omitempty"`
-	CustomerPhone  string          `json:"customer_phone,omitempty"`
-	Items          []SaleItem      `json:"items"`
-	Subtotal       decimal.Decimal `json:"subtotal"`
-	TaxAmount      decimal.Decimal `json:"tax_amount"`
-	DiscountAmount decimal.Decimal `json:"discount_amount"`
-	TotalAmount    decimal.Decimal `json:"total_amount"`
-	PaidAmount     decimal.Decimal `json:"paid_amount"`
-	ChangeAmount   decimal.Decimal `json:"change_amount"`
-	PaymentMethod  PaymentMethod   `json:"payment_method"`
-	Status         SaleStatus      `json:"status"`
-	Notes          string          `json:"notes,omitempty"`
-	CreatedAt      time.Time       `json:"created_at"`
-	UpdatedAt      time.Time       `json:"updated_at"`
-	CreatedBy      uuid.UUID       `json:"created_by"`
-	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	ID                 uuid.UUID       `json:"id"`
+	TenantID           uuid.UUID       `json:"tenant_id"`
+	SaleNumber         string          `json:"sale_number"`
+	CustomerName       string          `json:"customer_name,omitempty"`
+	CustomerEmail      string          `json:"customer_email,omitempty"`
+	CustomerPhone      string          `json:"customer_phone,omitempty"`
+	Items              []SaleItem      `json:"items"`
+	Subtotal           decimal.Decimal `json:"subtotal"`
+	TaxRate            decimal.Decimal `json:"tax_rate"`
+	TaxAmount          decimal.Decimal `json:"tax_amount"`    // Includes tax on the surcharge
+	TaxInclusive       bool            `json:"tax_inclusive"` // Item prices include tax, taken from the tenant's pricing mode
+	DiscountAmount     decimal.Decimal `json:"discount_amount"`
+	SurchargeAmount    decimal.Decimal `json:"surcharge_amount"`
+	SurchargeTaxAmount decimal.Decimal `json:"surcharge_tax_amount"`
+	SurchargeLabel     string          `json:"surcharge_label,omitempty"`
+	TotalAmount        decimal.Decimal `json:"total_amount"`
+	PaidAmount         decimal.Decimal `json:"paid_amount"`
+	ChangeAmount       decimal.Decimal `json:"change_amount"`
+	PaymentMethod      PaymentMethod   `json:"payment_method"` // Method that paid the largest part of a split payment
+	Payments           []SalePayment   `json:"payments,omitempty"`
+	Channel            SaleChannel     `json:"channel"`
+	Status             SaleStatus      `json:"status"`
+	Notes              string          `json:"notes,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	CreatedBy          uuid.UUID       `json:"created_by"`
+	CompletedAt        *time.Time      `json:"completed_at,omitempty"`
+	HeldAt             *time.Time      `json:"held_at,omitempty"`
+	HeldBy             *uuid.UUID      `json:"held_by,omitempty"`
+	HoldExpiresAt      *time.Time      `json:"hold_expires_at,omitempty"`
+	HoldLabel          string          `json:"hold_label,omitempty"`
+	ReceiptToken       string          `json:"receipt_token,omitempty"`     // Printed as a QR code on the receipt to verify returns
+	PaymentReference   string          `json:"payment_reference,omitempty"` // Gateway payment that paid the sale, e.g. "stripe:pi_123"
+	Currency           string          `json:"currency,omitempty"`          // ISO 4217 code the sale is made out in
+	ExchangeRate       *ExchangeRate   `json:"exchange_rate,omitempty"`     // Locked when the sale is created
+
+	// CurrencyFormat is the display of the sale's currency, attached when rendering its
+	// receipt for the customer
+	CurrencyFormat *CurrencyFormat `json:"-"`
 }
 
 // SaleItem represents an item in a sale
 type SaleItem struct {
-	ID          uuid.UUID       `json:"id"`
-	SaleID      uuid.UUID       `json:"sale_id"`
-	ProductID   uuid.UUID       `json:"product_id"`
-	ProductSKU  string          `json:"product_sku"`
-	ProductName string          `json:"product_name"`
-	Quantity    int             `json:"quantity"`
-	UnitPrice   decimal.Decimal `json:"unit_price"`
-	TotalPrice  decimal.Decimal `json:"total_price"`
-	CreatedAt   time.Time       `json:"created_at"`
+	ID               uuid.UUID           `json:"id"`
+	SaleID           uuid.UUID           `json:"sale_id"`
+	ProductID        uuid.UUID           `json:"product_id"`
+	ProductSKU       string              `json:"product_sku"`
+	ProductName      string              `json:"product_name"`
+	Quantity         int                 `json:"quantity"`
+	UnitPrice        decimal.Decimal     `json:"unit_price"`
+	TotalPrice       decimal.Decimal     `json:"total_price"`
+	ListPrice        decimal.Decimal     `json:"list_price"` // Catalog price at the time of sale
+	UnitCost         decimal.Decimal     `json:"unit_cost"`  // Product cost at the time of sale
+	PriceSource      SaleItemPriceSource `json:"price_source"`
+	OverrideReason   string              `json:"override_reason,omitempty"`
+	TaxRateID        *uuid.UUID          `json:"tax_rate_id,omitempty"` // Rate of the product or its category, nil for the sale's rate
+	TaxRate          decimal.Decimal     `json:"tax_rate"`
+	TaxAmount        decimal.Decimal     `json:"tax_amount"` // After the item's share of the sale discount
+	CreatedAt        time.Time           `json:"created_at"`
+	ReturnedQuantity int                 `json:"returned_quantity"`        // Quantity refunded so far
+	SerialNumbers    []string            `json:"serial_numbers,omitempty"` // Units sold, for serialized products
 }
 
 // NewSale creates a new sale
@@ -74,23 +127,27 @@ func NewSale(tenantID uuid.UUID, saleNumber, customerName, customerEmail, custom
 
 	now := time.Now()
 	sale := &Sale{
-		ID:             uuid.New(),
-		TenantID:       tenantID,
-		SaleNumber:     saleNumber,
-		CustomerName:   customerName,
-		CustomerEmail:  customerEmail,
-		CustomerPhone:  customerPhone,
-		Items:          make([]SaleItem, 0),
-		Subtotal:       decimal.Zero,
-		TaxAmount:      decimal.Zero,
-		DiscountAmount: decimal.Zero,
-		TotalAmount:    decimal.Zero,
-		PaidAmount:     decimal.Zero,
-		ChangeAmount:   decimal.Zero,
-		Status:         SaleStatusPending,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-		CreatedBy:      createdBy,
+		ID:                 uuid.New(),
+		TenantID:           tenantID,
+		SaleNumber:         saleNumber,
+		CustomerName:       customerName,
+		CustomerEmail:      customerEmail,
+		CustomerPhone:      customerPhone,
+		Items:              make([]SaleItem, 0),
+		Subtotal:           decimal.Zero,
+		TaxRate:            decimal.Zero,
+		TaxAmount:          decimal.Zero,
+		DiscountAmount:     decimal.Zero,
+		SurchargeAmount:    decimal.Zero,
+		SurchargeTaxAmount: decimal.Zero,
+		TotalAmount:        decimal.Zero,
+		PaidAmount:         decimal.Zero,
+		ChangeAmount:       decimal.Zero,
+		Channel:            SaleChannelInStore,
+		Status:             SaleStatusPending,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		CreatedBy:          createdBy,
 	}
 
 	return sale, nil
@@ -122,12 +179,111 @@ func NewSaleItem(saleID, productID uuid.UUID, productSKU, productName string, qu
 		Quantity:    quantity,
 		UnitPrice:   unitPrice,
 		TotalPrice:  totalPrice,
+		ListPrice:   unitPrice,
+		UnitCost:    decimal.Zero,
+		PriceSource: SaleItemPriceSourceList,
+		TaxRate:     decimal.Zero,
+		TaxAmount:   decimal.Zero,
 		CreatedAt:   time.Now(),
 	}
 
 	return item, nil
 }
 
+// SetUnitCost records the product cost at the time of sale for margin reporting
+func (i *SaleItem) SetUnitCost(unitCost decimal.Decimal) error {
+	if unitCost.LessThan(decimal.Zero) {
+		return errors.NewValidationError("invalid cost", "unit cost cannot be negative")
+	}
+
+	i.UnitCost = unitCost
+	return nil
+}
+
+// AssignTaxRate taxes the item at the tax rate of its product or category, or at the sale's
+// rate when taxRate is nil or no longer active
+func (i *SaleItem) AssignTaxRate(taxRate *TaxRate) {
+	if taxRate == nil || !taxRate.IsActive {
+		i.TaxRateID = nil
+		return
+	}
+
+	id := taxRate.ID
+	i.TaxRateID = &id
+	i.TaxRate = taxRate.Rate
+}
+
+// ApplyPromotion charges the item at a promotional price below its list price
+func (i *SaleItem) ApplyPromotion(promoPrice decimal.Decimal) error {
+	if promoPrice.LessThanOrEqual(decimal.Zero) {
+		return errors.NewInvalidPriceError(promoPrice.InexactFloat64())
+	}
+	if promoPrice.GreaterThanOrEqual(i.ListPrice) {
+		return errors.NewValidationError("invalid promotion price", "promotion price must be lower than the list price")
+	}
+
+	i.UnitPrice = promoPrice
+	i.PriceSource = SaleItemPriceSourcePromotion
+	i.OverrideReason = ""
+	i.recalculateTotal()
+	return nil
+}
+
+// RefreshPromotion charges the item at its product's current promotional price, or back at
+// its list price once the promotion has ended. Overridden prices are left alone. It reports
+// whether the charged price changed.
+func (i *SaleItem) RefreshPromotion(promoPrice *decimal.Decimal) bool {
+	if i.PriceSource == SaleItemPriceSourceOverride {
+		return false
+	}
+
+	if promoPrice != nil && promoPrice.IsPositive() && promoPrice.LessThan(i.ListPrice) {
+		if i.PriceSource == SaleItemPriceSourcePromotion && i.UnitPrice.Equal(*promoPrice) {
+			return false
+		}
+		i.UnitPrice = *promoPrice
+		i.PriceSource = SaleItemPriceSourcePromotion
+		i.recalculateTotal()
+		return true
+	}
+
+	if i.PriceSource != SaleItemPriceSourcePromotion {
+		return false
+	}
+	i.UnitPrice = i.ListPrice
+	i.PriceSource = SaleItemPriceSourceList
+	i.recalculateTotal()
+	return true
+}
+
+// OverridePrice manually overrides the charged unit price of the item
+func (i *SaleItem) OverridePrice(unitPrice decimal.Decimal, reason string) error {
+	if unitPrice.LessThanOrEqual(decimal.Zero) {
+		return errors.NewInvalidPriceError(unitPrice.InexactFloat64())
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.NewValidationError("override reason is required", "a reason must be given when overriding a price")
+	}
+
+	i.UnitPrice = unitPrice
+	i.PriceSource = SaleItemPriceSourceOverride
+	i.OverrideReason = reason
+	i.recalculateTotal()
+	return nil
+}
+
+// PriceDiscount returns how much below list price the item was charged in total.
+// A negative value means the price was overridden above the list price.
+func (i *SaleItem) PriceDiscount() decimal.Decimal {
+	return i.ListPrice.Sub(i.UnitPrice).Mul(decimal.NewFromInt(int64(i.Quantity)))
+}
+
+// recalculateTotal recalculates the total price of the item
+func (i *SaleItem) recalculateTotal() {
+	i.TotalPrice = i.UnitPrice.Mul(decimal.NewFromInt(int64(i.Quantity)))
+}
+
 // AddItem adds an item to the sale
 func (s *Sale) AddItem(item *SaleItem) error {
 	if item == nil {
@@ -175,6 +331,9 @@ func (s *Sale) UpdateItemQuantity(productID uuid.UUID, newQuantity int) error {
 
 	for i, item := range s.Items {
 		if item.ProductID == productID {
+			if newQuantity < len(item.SerialNumbers) {
+				return errors.NewValidationError("too many serial numbers", "quantity cannot be less than the number of serial numbers captured")
+			}
 			s.Items[i].Quantity = newQuantity
 			s.Items[i].TotalPrice = item.UnitPrice.Mul(decimal.NewFromInt(int64(newQuantity)))
 			s.UpdatedAt = time.Now()
@@ -185,6 +344,62 @@ func (s *Sale) UpdateItemQuantity(productID uuid.UUID, newQuantity int) error {
 	return errors.NewNotFoundError("sale item")
 }
 
+// OverrideItemPrice manually overrides the charged unit price of an item
+func (s *Sale) OverrideItemPrice(productID uuid.UUID, unitPrice decimal.Decimal, reason string) error {
+	for i, item := range s.Items {
+		if item.ProductID == productID {
+			if err := s.Items[i].OverridePrice(unitPrice, reason); err != nil {
+				return err
+			}
+			s.UpdatedAt = time.Now()
+			s.recalculateAmounts()
+			return nil
+		}
+	}
+	return errors.NewNotFoundError("sale item")
+}
+
+// SetItemSerialNumbers captures the serial numbers of the units of a serialized product in
+// the sale, replacing any captured before. Fewer serials than the quantity may be given.
+func (s *Sale) SetItemSerialNumbers(product *Product, serials []string) error {
+	if s.Status != SaleStatusPending {
+		return errors.NewValidationError("invalid sale status", "can only modify pending sales")
+	}
+	if product == nil {
+		return errors.NewValidationError("product is required", "product cannot be nil")
+	}
+	if !product.IsSerialized {
+		return errors.NewValidationError("product is not serialized", fmt.Sprintf("product '%s' does not track serial numbers", product.SKU))
+	}
+
+	serials, err := NormalizeSerialNumbers(serials)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range s.Items {
+		if item.ProductID == product.ID {
+			if len(serials) > item.Quantity {
+				return errors.NewValidationError("too many serial numbers", fmt.Sprintf("%d serial numbers given for a quantity of %d", len(serials), item.Quantity))
+			}
+			s.Items[i].SerialNumbers = serials
+			s.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return errors.NewNotFoundError("sale item")
+}
+
+// HasSerialNumber reports whether the serial number was captured on the item
+func (i *SaleItem) HasSerialNumber(serial string) bool {
+	for _, captured := range i.SerialNumbers {
+		if captured == serial {
+			return true
+		}
+	}
+	return false
+}
+
 // ApplyDiscount applies a discount to the sale
 func (s *Sale) ApplyDiscount(discountAmount decimal.Decimal) error {
 	if discountAmount.LessThan(decimal.Zero) {
@@ -206,19 +421,76 @@ func (s *Sale) ApplyTax(taxPercentage decimal.Decimal) error {
 		return errors.NewValidationError("invalid tax", "tax percentage cannot be negative")
 	}
 
-	taxableAmount := s.Subtotal.Sub(s.DiscountAmount)
-	s.TaxAmount = taxableAmount.Mul(taxPercentage).Div(decimal.NewFromInt(100))
+	s.TaxRate = taxPercentage
+	s.TaxAmount = s.applyItemTax().Add(s.SurchargeTaxAmount)
 	s.UpdatedAt = time.Now()
 	s.recalculateAmounts()
 	return nil
 }
 
-// ProcessPayment processes payment for the sale
-func (s *Sale) ProcessPayment(paidAmount decimal.Decimal, paymentMethod PaymentMethod) error {
+// SetTaxPricingMode sets whether the sale's item prices include tax
+func (s *Sale) SetTaxPricingMode(mode TaxPricingMode) error {
+	if err := ValidateTaxPricingMode(mode); err != nil {
+		return err
+	}
+
+	s.TaxInclusive = mode == TaxPricingModeInclusive
+	s.UpdatedAt = time.Now()
+	s.recalculateAmounts()
+	return nil
+}
+
+// TaxSummary returns the sale's tax grouped by rate, as printed on invoices
+func (s *Sale) TaxSummary() []TaxRateSummary {
+	lines := s.taxLines()
+	taxable, taxes := computeLineTaxes(lines, s.DiscountAmount, s.TaxInclusive)
+	return summarizeTax(lines, taxable, taxes, s.SurchargeAmount, s.SurchargeTaxAmount, s.TaxRate)
+}
+
+// Totals returns the stored totals of the sale
+func (s *Sale) Totals() DocumentTotals {
+	return DocumentTotals{
+		Subtotal:    s.Subtotal,
+		TaxAmount:   s.TaxAmount,
+		TotalAmount: s.TotalAmount,
+	}
+}
+
+// RecalculateTotals recomputes the item totals, tax and total of the sale from its items,
+// discount and tax rate. Tax is rounded to cents as it is stored; the surcharge and payments
+// are left as they are.
+func (s *Sale) RecalculateTotals() {
+	for i := range s.Items {
+		s.Items[i].recalculateTotal()
+	}
+	s.recalculateAmounts()
+
+	s.TaxAmount = s.applyItemTax().Add(s.SurchargeTaxAmount)
+	s.UpdatedAt = time.Now()
+	s.recalculateAmounts()
+}
+
+// PriceForPayment works out the surcharge and total of paying for the sale with a payment method,
+// without taking a payment. The surcharge rule is the tenant's rule for the method, if any.
+func (s *Sale) PriceForPayment(paymentMethod PaymentMethod, surcharge *PaymentSurchargeRule) error {
+	if err := ValidatePaymentMethod(paymentMethod); err != nil {
+		return err
+	}
+
+	return s.applySurcharge(paymentMethod, surcharge)
+}
+
+// ProcessPayment processes payment for the sale. The surcharge rule, if any, is the tenant's
+// rule for the payment method and is added to the total before the payment is checked.
+func (s *Sale) ProcessPayment(paidAmount decimal.Decimal, paymentMethod PaymentMethod, surcharge *PaymentSurchargeRule) error {
 	if err := ValidatePaymentMethod(paymentMethod); err != nil {
 		return err
 	}
 
+	if err := s.applySurcharge(paymentMethod, surcharge); err != nil {
+		return err
+	}
+
 	if paidAmount.LessThan(s.TotalAmount) {
 		return errors.NewValidationError("insufficient payment", "paid amount is less than total amount")
 	}
@@ -226,6 +498,103 @@ func (s *Sale) ProcessPayment(paidAmount decimal.Decimal, paymentMethod PaymentM
 	s.PaidAmount = paidAmount
 	s.ChangeAmount = paidAmount.Sub(s.TotalAmount)
 	s.PaymentMethod = paymentMethod
+	s.Payments = []SalePayment{newSalePayment(s.ID, paymentMethod, s.TotalAmount, paidAmount, s.SurchargeAmount)}
+	s.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ProcessSplitPayment settles the sale with several payment methods, e.g. part cash and part
+// card. Each method's surcharge rule is charged on top of the part of the sale that method
+// pays for, and change can only be given from cash.
+func (s *Sale) ProcessSplitPayment(lines []PaymentLine, surcharges map[PaymentMethod]*PaymentSurchargeRule) error {
+	if len(lines) == 0 {
+		return errors.NewValidationError("no payments", "at least one payment is required")
+	}
+	if len(lines) > MaxSalePaymentLines {
+		return errors.NewValidationError("too many payments", "a sale can be split across at most 5 payments")
+	}
+
+	seen := make(map[PaymentMethod]bool, len(lines))
+	tendered := decimal.Zero
+	cash := decimal.Zero
+	for _, line := range lines {
+		if err := ValidatePaymentMethod(line.PaymentMethod); err != nil {
+			return err
+		}
+		if seen[line.PaymentMethod] {
+			return errors.NewValidationError("duplicate payment method", "each payment method can only be used once per sale")
+		}
+		seen[line.PaymentMethod] = true
+		if line.Amount.LessThanOrEqual(decimal.Zero) {
+			return errors.NewValidationError("invalid payment amount", "payment amounts must be greater than zero")
+		}
+
+		tendered = tendered.Add(line.Amount)
+		if line.PaymentMethod == PaymentMethodCash {
+			cash = line.Amount
+		}
+	}
+
+	// Drop any surcharge from an earlier payment attempt to get the amount due for the goods
+	goodsTax := s.TaxAmount.Sub(s.SurchargeTaxAmount)
+	s.SurchargeAmount = decimal.Zero
+	s.SurchargeTaxAmount = decimal.Zero
+	s.SurchargeLabel = ""
+	s.TaxAmount = goodsTax
+	s.recalculateAmounts()
+
+	if tendered.LessThan(s.TotalAmount) {
+		return errors.NewValidationError("insufficient payment", "paid amount is less than total amount")
+	}
+	change := tendered.Sub(s.TotalAmount)
+	if change.GreaterThan(decimal.Zero) && change.GreaterThanOrEqual(cash) {
+		return errors.NewValidationError("invalid change", "change can only be given from the cash payment")
+	}
+
+	payments := make([]SalePayment, 0, len(lines))
+	var labels []string
+	for _, line := range lines {
+		applied := line.Amount
+		if line.PaymentMethod == PaymentMethodCash {
+			applied = applied.Sub(change)
+		}
+
+		surchargeAmount := decimal.Zero
+		surchargeTax := decimal.Zero
+		if rule := surcharges[line.PaymentMethod]; rule != nil && rule.IsActive {
+			if rule.PaymentMethod != line.PaymentMethod {
+				return errors.NewValidationError("invalid surcharge", "surcharge rule does not apply to payment method "+string(line.PaymentMethod))
+			}
+			surchargeAmount = rule.Calculate(applied)
+			if rule.IsTaxable {
+				surchargeTax = surchargeAmount.Mul(s.TaxRate).Div(decimal.NewFromInt(100)).Round(2)
+			}
+			if surchargeAmount.GreaterThan(decimal.Zero) {
+				labels = append(labels, rule.Label)
+			}
+		}
+
+		s.SurchargeAmount = s.SurchargeAmount.Add(surchargeAmount)
+		s.SurchargeTaxAmount = s.SurchargeTaxAmount.Add(surchargeTax)
+		payments = append(payments, newSalePayment(s.ID, line.PaymentMethod, applied.Add(surchargeAmount).Add(surchargeTax), line.Amount, surchargeAmount))
+	}
+
+	s.SurchargeLabel = strings.Join(labels, ", ")
+	s.TaxAmount = goodsTax.Add(s.SurchargeTaxAmount)
+	s.recalculateAmounts()
+
+	primary := payments[0]
+	for _, payment := range payments[1:] {
+		if payment.Amount.GreaterThan(primary.Amount) {
+			primary = payment
+		}
+	}
+
+	s.PaidAmount = tendered.Add(s.SurchargeAmount).Add(s.SurchargeTaxAmount)
+	s.ChangeAmount = change
+	s.PaymentMethod = primary.PaymentMethod
+	s.Payments = payments
 	s.UpdatedAt = time.Now()
 
 	return nil
@@ -243,7 +612,13 @@ func (s *Sale) CompleteSale() error {
 		return errors.NewValidationError("incomplete payment", "sale payment is incomplete")
 	}
 
+	receiptToken, err := newReceiptToken()
+	if err != nil {
+		return err
+	}
+
 	s.Status = SaleStatusCompleted
+	s.ReceiptToken = receiptToken
 	now := time.Now()
 	s.CompletedAt = &now
 	s.UpdatedAt = now
@@ -266,6 +641,66 @@ func (s *Sale) CancelSale() error {
 	return nil
 }
 
+// Hold parks a pending sale so it can be resumed later, possibly on another terminal.
+// The hold lapses after the given TTL.
+func (s *Sale) Hold(userID uuid.UUID, label string, ttl time.Duration) error {
+	if s.Status != SaleStatusPending {
+		return errors.NewValidationError("invalid sale status", "only pending sales can be held")
+	}
+	if len(s.Items) == 0 {
+		return errors.NewValidationError("empty sale", "sale must have at least one item")
+	}
+	if ttl <= 0 {
+		return errors.NewValidationError("invalid hold duration", "hold duration must be positive")
+	}
+	label = strings.TrimSpace(label)
+	if len(label) > MaxSaleHoldLabelLength {
+		return errors.NewValidationError("invalid hold label", "hold label must not exceed 100 characters")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	s.Status = SaleStatusHeld
+	s.HeldAt = &now
+	s.HeldBy = &userID
+	s.HoldExpiresAt = &expiresAt
+	s.HoldLabel = label
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// Resume returns a held sale to pending so checkout can continue
+func (s *Sale) Resume(now time.Time) error {
+	if s.Status != SaleStatusHeld {
+		return errors.NewValidationError("invalid sale status", "only held sales can be resumed")
+	}
+	if s.IsHoldExpired(now) {
+		return errors.NewValidationError("hold expired", "the hold on this sale has expired")
+	}
+
+	s.Status = SaleStatusPending
+	s.clearHold()
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// ExpireHold cancels a held sale whose hold has lapsed
+func (s *Sale) ExpireHold(now time.Time) error {
+	if s.Status != SaleStatusHeld {
+		return errors.NewValidationError("invalid sale status", "only held sales can expire")
+	}
+	if !s.IsHoldExpired(now) {
+		return errors.NewValidationError("hold not expired", "the hold on this sale has not expired yet")
+	}
+
+	s.Status = SaleStatusCancelled
+	s.UpdatedAt = now
+
+	return nil
+}
+
 // RefundSale refunds the sale
 func (s *Sale) RefundSale() error {
 	if s.Status != SaleStatusCompleted {
@@ -278,6 +713,53 @@ func (s *Sale) RefundSale() error {
 	return nil
 }
 
+// SetChannel sets the channel the sale originated from
+func (s *Sale) SetChannel(channel SaleChannel) error {
+	if err := ValidateSaleChannel(channel); err != nil {
+		return err
+	}
+	if s.Status != SaleStatusPending {
+		return errors.NewValidationError("invalid sale status", "channel can only be changed on pending sales")
+	}
+
+	s.Channel = channel
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCustomer attaches the customer to a pending sale
+func (s *Sale) SetCustomer(name, email, phone string) error {
+	if s.Status != SaleStatusPending {
+		return errors.NewValidationError("invalid sale status", "customer can only be changed on pending sales")
+	}
+
+	name, email, phone = strings.TrimSpace(name), strings.TrimSpace(email), strings.TrimSpace(phone)
+	if len(name) > 255 || len(email) > 255 || len(phone) > 255 {
+		return errors.NewValidationError("customer details too long", "customer name, email and phone cannot exceed 255 characters")
+	}
+
+	s.CustomerName = name
+	s.CustomerEmail = email
+	s.CustomerPhone = phone
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// LockExchangeRate locks the exchange rate the sale's amounts convert to the base currency
+// with. It is locked once, when the sale is created, and never moves afterwards.
+func (s *Sale) LockExchangeRate(rate *ExchangeRate) error {
+	if rate == nil {
+		return errors.NewValidationError("exchange rate is required", "exchange rate cannot be nil")
+	}
+	if s.ExchangeRate != nil {
+		return errors.NewValidationError("exchange rate already locked", "the exchange rate of a sale cannot be changed once locked")
+	}
+
+	s.Currency = rate.Currency
+	s.ExchangeRate = rate
+	return nil
+}
+
 // AddNotes adds notes to the sale
 func (s *Sale) AddNotes(notes string) {
 	s.Notes = notes
@@ -293,6 +775,25 @@ func (s *Sale) GetItemCount() int {
 	return count
 }
 
+// NetUnitPrice returns the unit price of an item after its share of the sale-level discount,
+// which is spread over the items in proportion to their totals
+func (s *Sale) NetUnitPrice(item SaleItem) decimal.Decimal {
+	if s.DiscountAmount.IsZero() || s.Subtotal.LessThanOrEqual(decimal.Zero) {
+		return item.UnitPrice
+	}
+	share := s.Subtotal.Sub(s.DiscountAmount).Div(s.Subtotal)
+	return item.UnitPrice.Mul(share).Round(2)
+}
+
+// FormatAmount formats an amount of the sale for display, using the attached currency
+// display or the conventional display of the sale's currency
+func (s *Sale) FormatAmount(amount decimal.Decimal) string {
+	if s.CurrencyFormat != nil {
+		return s.CurrencyFormat.Format(amount)
+	}
+	return DefaultCurrencyFormat(s.Currency).Format(amount)
+}
+
 // IsCompleted checks if the sale is completed
 func (s *Sale) IsCompleted() bool {
 	return s.Status == SaleStatusCompleted
@@ -308,14 +809,94 @@ func (s *Sale) IsRefunded() bool {
 	return s.Status == SaleStatusRefunded
 }
 
-// recalculateAmounts recalculates subtotal and total amounts
+// IsHeld checks if the sale is held
+func (s *Sale) IsHeld() bool {
+	return s.Status == SaleStatusHeld
+}
+
+// IsHoldExpired checks if the sale is held past its hold expiry
+func (s *Sale) IsHoldExpired(now time.Time) bool {
+	return s.Status == SaleStatusHeld && s.HoldExpiresAt != nil && !now.Before(*s.HoldExpiresAt)
+}
+
+// clearHold removes the hold details from the sale
+func (s *Sale) clearHold() {
+	s.HeldAt = nil
+	s.HeldBy = nil
+	s.HoldExpiresAt = nil
+	s.HoldLabel = ""
+}
+
+// applySurcharge replaces any previously applied surcharge with the one charged for the
+// payment method. The surcharge is a percentage of the sale total and is taxed at the sale's
+// tax rate when the rule is taxable.
+func (s *Sale) applySurcharge(paymentMethod PaymentMethod, surcharge *PaymentSurchargeRule) error {
+	if surcharge != nil && surcharge.PaymentMethod != paymentMethod {
+		return errors.NewValidationError("invalid surcharge", "surcharge rule does not apply to payment method "+string(paymentMethod))
+	}
+
+	goodsTax := s.TaxAmount.Sub(s.SurchargeTaxAmount)
+	s.SurchargeAmount = decimal.Zero
+	s.SurchargeTaxAmount = decimal.Zero
+	s.SurchargeLabel = ""
+
+	if surcharge != nil && surcharge.IsActive {
+		base := s.Subtotal.Sub(s.DiscountAmount)
+		if !s.TaxInclusive {
+			base = base.Add(goodsTax)
+		}
+		s.SurchargeAmount = surcharge.Calculate(base)
+		s.SurchargeLabel = surcharge.Label
+		if surcharge.IsTaxable {
+			s.SurchargeTaxAmount = s.SurchargeAmount.Mul(s.TaxRate).Div(decimal.NewFromInt(100)).Round(2)
+		}
+	}
+
+	s.TaxAmount = goodsTax.Add(s.SurchargeTaxAmount)
+	s.recalculateAmounts()
+	return nil
+}
+
+// taxLines returns the sale's items as tax lines, each taxed at its own rate or the sale's
+func (s *Sale) taxLines() []taxLine {
+	lines := make([]taxLine, len(s.Items))
+	for i, item := range s.Items {
+		rate := s.TaxRate
+		if item.TaxRateID != nil {
+			rate = item.TaxRate
+		}
+		lines[i] = taxLine{amount: item.TotalPrice, rate: rate}
+	}
+	return lines
+}
+
+// applyItemTax computes the tax of each item at its rate after its share of the sale
+// discount, and returns the tax on all items
+func (s *Sale) applyItemTax() decimal.Decimal {
+	lines := s.taxLines()
+	_, taxes := computeLineTaxes(lines, s.DiscountAmount, s.TaxInclusive)
+
+	goodsTax := decimal.Zero
+	for i := range s.Items {
+		s.Items[i].TaxRate = lines[i].rate
+		s.Items[i].TaxAmount = taxes[i]
+		goodsTax = goodsTax.Add(taxes[i])
+	}
+	return goodsTax
+}
+
+// recalculateAmounts recalculates subtotal and total amounts. With tax-inclusive pricing the
+// tax on the items is already part of the subtotal; tax on the surcharge is always added.
 func (s *Sale) recalculateAmounts() {
 	s.Subtotal = decimal.Zero
 	for _, item := range s.Items {
 		s.Subtotal = s.Subtotal.Add(item.TotalPrice)
 	}
 
-	s.TotalAmount = s.Subtotal.Sub(s.DiscountAmount).Add(s.TaxAmount)
+	s.TotalAmount = s.Subtotal.Sub(s.DiscountAmount).Add(s.SurchargeAmount).Add(s.TaxAmount)
+	if s.TaxInclusive {
+		s.TotalAmount = s.TotalAmount.Sub(s.TaxAmount.Sub(s.SurchargeTaxAmount))
+	}
 }
 
 // ValidatePaymentMethod validates payment method
@@ -331,9 +912,19 @@ func ValidatePaymentMethod(method PaymentMethod) error {
 // ValidateSaleStatus validates sale status
 func ValidateSaleStatus(status SaleStatus) error {
 	switch status {
-	case SaleStatusPending, SaleStatusCompleted, SaleStatusCancelled, SaleStatusRefunded:
+	case SaleStatusPending, SaleStatusCompleted, SaleStatusCancelled, SaleStatusRefunded, SaleStatusHeld:
+		return nil
+	default:
+		return errors.NewValidationError("invalid sale status", "status must be one of: pending, completed, cancelled, refunded, held")
+	}
+}
+
+// ValidateSaleChannel validates sale channel
+func ValidateSaleChannel(channel SaleChannel) error {
+	switch channel {
+	case SaleChannelInStore, SaleChannelOnline, SaleChannelMarketplace, SaleChannelPhoneOrder:
 		return nil
 	default:
-		return errors.NewValidationError("invalid sale status", "status must be one of: pending, completed, cancelled, refunded")
+		return errors.NewValidationError("invalid sale channel", "channel must be one of: in_store, online, marketplace, phone_order")
 	}
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MaxSalePaymentLines caps how many payment methods one sale can be settled with
+const MaxSalePaymentLines = 5
+
+// PaymentLine is one part of a split payment as entered at the till
+type PaymentLine struct {
+	PaymentMethod PaymentMethod   `json:"payment_method"`
+	Amount        decimal.Decimal `json:"amount"` // Amount tendered with this method, before any surcharge
+}
+
+// SalePayment records how much of a sale was settled with one payment method. The amounts
+// of a sale's payments add up to the sale total.
+type SalePayment struct {
+	ID              uuid.UUID       `json:"id"`
+	SaleID          uuid.UUID       `json:"sale_id"`
+	PaymentMethod   PaymentMethod   `json:"payment_method"`
+	Amount          decimal.Decimal `json:"amount"`           // Applied to the sale, including this method's surcharge
+	TenderedAmount  decimal.Decimal `json:"tendered_amount"`  // Handed over by the customer, before change and surcharge
+	SurchargeAmount decimal.Decimal `json:"surcharge_amount"` // Surcharge charged for this method, excluding tax
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// newSalePayment creates a payment line for a sale
+func newSalePayment(saleID uuid.UUID, method PaymentMethod, amount, tendered, surcharge decimal.Decimal) SalePayment {
+	return SalePayment{
+		ID:              uuid.New(),
+		SaleID:          saleID,
+		PaymentMethod:   method,
+		Amount:          amount,
+		TenderedAmount:  tendered,
+		SurchargeAmount: surcharge,
+		CreatedAt:       time.Now(),
+	}
+}
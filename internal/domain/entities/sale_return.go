@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// receiptTokenPrefix makes receipt tokens recognisable when scanned from a receipt QR code
+const receiptTokenPrefix = "rct_"
+
+// newReceiptToken generates the token printed on a completed sale's receipt
+func newReceiptToken() (string, error) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.NewInternalError("failed to generate receipt token", err)
+	}
+	return receiptTokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// VerifyReceiptToken checks a token scanned from a receipt belongs to the sale
+func (s *Sale) VerifyReceiptToken(token string) error {
+	if s.ReceiptToken == "" {
+		return errors.NewValidationError("receipt cannot be verified", "sale was completed before receipts carried a token")
+	}
+	if subtle.ConstantTimeCompare([]byte(s.ReceiptToken), []byte(token)) != 1 {
+		return errors.NewValidationError("invalid receipt", "receipt token does not match the sale")
+	}
+	return nil
+}
+
+// ReturnableQuantity returns the quantity of the item that can still be returned
+func (i *SaleItem) ReturnableQuantity() int {
+	if remaining := i.Quantity - i.ReturnedQuantity; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// ReturnedQuantities returns the quantity of each sale item returned so far
+func (s *Sale) ReturnedQuantities() map[uuid.UUID]int {
+	returned := make(map[uuid.UUID]int, len(s.Items))
+	for _, item := range s.Items {
+		returned[item.ID] = item.ReturnedQuantity
+	}
+	return returned
+}
+
+// RecordReturn adds the items of a refund to the quantities returned of the sale's items
+func (s *Sale) RecordReturn(refund *Refund) error {
+	for _, refunded := range refund.Items {
+		item := s.findItem(refunded.SaleItemID)
+		if item == nil {
+			return errors.NewNotFoundError("sale item")
+		}
+		if refunded.Quantity > item.ReturnableQuantity() {
+			return errors.NewValidationError("refund quantity too large", "cannot refund more than was sold and not yet refunded")
+		}
+		item.ReturnedQuantity += refunded.Quantity
+	}
+
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// ReturnDeadline returns when the return window of a completed sale closes, or nil when
+// returns are not time limited
+func (s *Sale) ReturnDeadline(windowDays int) *time.Time {
+	if windowDays <= 0 || s.CompletedAt == nil {
+		return nil
+	}
+
+	deadline := s.CompletedAt.AddDate(0, 0, windowDays)
+	return &deadline
+}
+
+// IsWithinReturnWindow checks if the sale can still be returned without a manager override
+func (s *Sale) IsWithinReturnWindow(windowDays int, now time.Time) bool {
+	deadline := s.ReturnDeadline(windowDays)
+	return deadline == nil || now.Before(*deadline)
+}
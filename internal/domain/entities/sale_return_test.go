@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSale_ReceiptToken(t *testing.T) {
+	t.Run("completed sale has a receipt token", func(t *testing.T) {
+		sale := createCompletedSale(t)
+
+		assert.Contains(t, sale.ReceiptToken, receiptTokenPrefix)
+		assert.NoError(t, sale.VerifyReceiptToken(sale.ReceiptToken))
+	})
+
+	t.Run("token of another sale", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		other := createCompletedSale(t)
+
+		err := sale.VerifyReceiptToken(other.ReceiptToken)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid receipt")
+	})
+
+	t.Run("sale without token", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		sale.ReceiptToken = ""
+
+		err := sale.VerifyReceiptToken("rct_anything")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "receipt cannot be verified")
+	})
+}
+
+func TestSale_RecordReturn(t *testing.T) {
+	t.Run("tracks returned quantity per item", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		laptop := sale.Items[0]
+
+		refund, err := NewRefund(sale, "RFD-001", []RefundLine{{SaleItemID: laptop.ID, Quantity: 1}}, sale.ReturnedQuantities(), "", "customer return", laptop.SaleID)
+		require.NoError(t, err)
+		require.NoError(t, sale.RecordReturn(refund))
+
+		assert.Equal(t, 1, sale.Items[0].ReturnedQuantity)
+		assert.Equal(t, laptop.Quantity-1, sale.Items[0].ReturnableQuantity())
+		assert.False(t, sale.IsFullyRefunded(sale.ReturnedQuantities()))
+	})
+
+	t.Run("cannot return beyond the purchased quantity", func(t *testing.T) {
+		sale := createCompletedSale(t)
+		laptop := sale.Items[0]
+		sale.Items[0].ReturnedQuantity = laptop.Quantity
+
+		refund, err := NewRefund(sale, "RFD-002", []RefundLine{{SaleItemID: laptop.ID, Quantity: 1}}, sale.ReturnedQuantities(), "", "customer return", laptop.SaleID)
+
+		assert.Error(t, err)
+		assert.Nil(t, refund)
+		assert.Equal(t, 0, sale.Items[0].ReturnableQuantity())
+	})
+
+	t.Run("everything returned", func(t *testing.T) {
+		sale := createCompletedSale(t)
+
+		refund, err := NewRefund(sale, "RFD-003", nil, sale.ReturnedQuantities(), "", "customer return", sale.CreatedBy)
+		require.NoError(t, err)
+		require.NoError(t, sale.RecordReturn(refund))
+
+		assert.True(t, sale.IsFullyRefunded(sale.ReturnedQuantities()))
+	})
+}
+
+func TestSale_ReturnWindow(t *testing.T) {
+	sale := createCompletedSale(t)
+	completedAt := time.Date(2026, 10, 1, 15, 0, 0, 0, time.UTC)
+	sale.CompletedAt = &completedAt
+
+	t.Run("no window", func(t *testing.T) {
+		assert.Nil(t, sale.ReturnDeadline(0))
+		assert.True(t, sale.IsWithinReturnWindow(0, completedAt.AddDate(1, 0, 0)))
+	})
+
+	t.Run("within window", func(t *testing.T) {
+		deadline := sale.ReturnDeadline(14)
+
+		require.NotNil(t, deadline)
+		assert.Equal(t, completedAt.AddDate(0, 0, 14), *deadline)
+		assert.True(t, sale.IsWithinReturnWindow(14, completedAt.AddDate(0, 0, 13)))
+	})
+
+	t.Run("window passed", func(t *testing.T) {
+		assert.False(t, sale.IsWithinReturnWindow(14, completedAt.AddDate(0, 0, 14)))
+	})
+}
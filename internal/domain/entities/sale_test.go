@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -365,8 +366,8 @@ func TestSale_ApplyTax(t *testing.T) {
 		err := sale.ApplyTax(taxPercentage)
 
 		require.NoError(t, err)
-		expectedTax := sale.Subtotal.Sub(sale.DiscountAmount).Mul(taxPercentage).Div(decimal.NewFromInt(100))
-		assert.True(t, expectedTax.Equal(sale.TaxAmount))
+		// Tax is rounded to cents per item: 199.998 and 7.999
+		assert.True(t, decimal.NewFromFloat(208.00).Equal(sale.TaxAmount), "got %s", sale.TaxAmount)
 		assert.True(t, sale.UpdatedAt.After(originalUpdatedAt))
 	})
 
@@ -398,7 +399,7 @@ func TestSale_ProcessPayment(t *testing.T) {
 
 		time.Sleep(time.Millisecond)
 
-		err := sale.ProcessPayment(paidAmount, paymentMethod)
+		err := sale.ProcessPayment(paidAmount, paymentMethod, nil)
 
 		require.NoError(t, err)
 		assert.True(t, paidAmount.Equal(sale.PaidAmount))
@@ -412,7 +413,7 @@ func TestSale_ProcessPayment(t *testing.T) {
 		sale := createSaleWithItems(t)
 		paidAmount := sale.TotalAmount
 
-		err := sale.ProcessPayment(paidAmount, PaymentMethodCard)
+		err := sale.ProcessPayment(paidAmount, PaymentMethodCard, nil)
 
 		require.NoError(t, err)
 		assert.True(t, decimal.Zero.Equal(sale.ChangeAmount))
@@ -422,7 +423,7 @@ func TestSale_ProcessPayment(t *testing.T) {
 		sale := createSaleWithItems(t)
 		paidAmount := sale.TotalAmount.Sub(decimal.NewFromFloat(10.0))
 
-		err := sale.ProcessPayment(paidAmount, PaymentMethodCash)
+		err := sale.ProcessPayment(paidAmount, PaymentMethodCash, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "insufficient payment")
@@ -431,7 +432,79 @@ func TestSale_ProcessPayment(t *testing.T) {
 	t.Run("invalid payment method", func(t *testing.T) {
 		sale := createSaleWithItems(t)
 
-		err := sale.ProcessPayment(sale.TotalAmount, "invalid_method")
+		err := sale.ProcessPayment(sale.TotalAmount, "invalid_method", nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payment method")
+	})
+
+	t.Run("apply taxable surcharge", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.ApplyTax(decimal.NewFromInt(10)))
+		totalBeforeSurcharge := sale.TotalAmount
+		goodsTax := sale.TaxAmount
+		rule := createSurchargeRule(t, PaymentMethodCard, true)
+
+		err := sale.ProcessPayment(totalBeforeSurcharge.Mul(decimal.NewFromInt(2)), PaymentMethodCard, rule)
+
+		require.NoError(t, err)
+		expectedSurcharge := totalBeforeSurcharge.Mul(decimal.NewFromInt(3)).Div(decimal.NewFromInt(100)).Round(2)
+		expectedSurchargeTax := expectedSurcharge.Mul(decimal.NewFromInt(10)).Div(decimal.NewFromInt(100)).Round(2)
+		assert.True(t, expectedSurcharge.Equal(sale.SurchargeAmount))
+		assert.True(t, expectedSurchargeTax.Equal(sale.SurchargeTaxAmount))
+		assert.True(t, goodsTax.Add(expectedSurchargeTax).Equal(sale.TaxAmount))
+		assert.True(t, totalBeforeSurcharge.Add(expectedSurcharge).Add(expectedSurchargeTax).Equal(sale.TotalAmount))
+		assert.Equal(t, "Card fee", sale.SurchargeLabel)
+	})
+
+	t.Run("surcharge cleared when paying without one", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		totalBeforeSurcharge := sale.TotalAmount
+		rule := createSurchargeRule(t, PaymentMethodCard, false)
+
+		require.NoError(t, sale.ProcessPayment(totalBeforeSurcharge.Mul(decimal.NewFromInt(2)), PaymentMethodCard, rule))
+		require.True(t, sale.SurchargeAmount.IsPositive())
+		assert.True(t, sale.SurchargeTaxAmount.IsZero())
+
+		err := sale.ProcessPayment(totalBeforeSurcharge, PaymentMethodCash, nil)
+
+		require.NoError(t, err)
+		assert.True(t, sale.SurchargeAmount.IsZero())
+		assert.True(t, totalBeforeSurcharge.Equal(sale.TotalAmount))
+	})
+
+	t.Run("surcharge rule for another payment method", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		rule := createSurchargeRule(t, PaymentMethodCard, false)
+
+		err := sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash, rule)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid surcharge")
+	})
+}
+
+func TestSale_PriceForPayment(t *testing.T) {
+	t.Run("adds the surcharge without taking a payment", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.ApplyTax(decimal.NewFromInt(10)))
+		totalBeforeSurcharge := sale.TotalAmount
+		rule := createSurchargeRule(t, PaymentMethodCard, true)
+
+		err := sale.PriceForPayment(PaymentMethodCard, rule)
+
+		require.NoError(t, err)
+		expectedSurcharge := totalBeforeSurcharge.Mul(decimal.NewFromInt(3)).Div(decimal.NewFromInt(100)).Round(2)
+		expectedSurchargeTax := expectedSurcharge.Mul(decimal.NewFromInt(10)).Div(decimal.NewFromInt(100)).Round(2)
+		assert.True(t, totalBeforeSurcharge.Add(expectedSurcharge).Add(expectedSurchargeTax).Equal(sale.TotalAmount))
+		assert.True(t, sale.PaidAmount.IsZero())
+		assert.Empty(t, sale.Payments)
+	})
+
+	t.Run("invalid payment method", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.PriceForPayment("invalid_method", nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid payment method")
@@ -441,7 +514,7 @@ func TestSale_ProcessPayment(t *testing.T) {
 func TestSale_CompleteSale(t *testing.T) {
 	t.Run("complete valid sale", func(t *testing.T) {
 		sale := createSaleWithItems(t)
-		err := sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash)
+		err := sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash, nil)
 		require.NoError(t, err)
 
 		originalUpdatedAt := sale.UpdatedAt
@@ -666,6 +739,490 @@ func TestValidateSaleStatus(t *testing.T) {
 
 // Helper functions for creating test data
 
+func TestSale_SetChannel(t *testing.T) {
+	t.Run("new sale defaults to in-store", func(t *testing.T) {
+		sale := createValidSale(t)
+
+		assert.Equal(t, SaleChannelInStore, sale.Channel)
+	})
+
+	t.Run("set valid channel", func(t *testing.T) {
+		sale := createValidSale(t)
+
+		err := sale.SetChannel(SaleChannelOnline)
+
+		require.NoError(t, err)
+		assert.Equal(t, SaleChannelOnline, sale.Channel)
+	})
+
+	t.Run("set invalid channel", func(t *testing.T) {
+		sale := createValidSale(t)
+
+		err := sale.SetChannel("carrier_pigeon")
+
+		assert.Error(t, err)
+		assert.Equal(t, SaleChannelInStore, sale.Channel)
+	})
+
+	t.Run("cannot change channel of completed sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash, nil))
+		require.NoError(t, sale.CompleteSale())
+
+		err := sale.SetChannel(SaleChannelMarketplace)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid sale status")
+	})
+}
+
+func TestSale_SetCustomer(t *testing.T) {
+	t.Run("pending sale", func(t *testing.T) {
+		sale := createValidSale(t)
+
+		err := sale.SetCustomer(" Jane Roe ", "jane@example.com", " +1987654321 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Roe", sale.CustomerName)
+		assert.Equal(t, "jane@example.com", sale.CustomerEmail)
+		assert.Equal(t, "+1987654321", sale.CustomerPhone)
+	})
+
+	t.Run("completed sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.ProcessPayment(sale.TotalAmount, PaymentMethodCash, nil))
+		require.NoError(t, sale.CompleteSale())
+
+		err := sale.SetCustomer("Jane Roe", "", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, "John Doe", sale.CustomerName)
+	})
+
+	t.Run("details too long", func(t *testing.T) {
+		sale := createValidSale(t)
+
+		err := sale.SetCustomer(strings.Repeat("a", 256), "", "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "customer details too long")
+	})
+}
+
+func TestValidateSaleChannel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		channel       SaleChannel
+		expectedError bool
+	}{
+		{"valid in-store channel", SaleChannelInStore, false},
+		{"valid online channel", SaleChannelOnline, false},
+		{"valid marketplace channel", SaleChannelMarketplace, false},
+		{"valid phone order channel", SaleChannelPhoneOrder, false},
+		{"invalid channel", "invalid", true},
+		{"empty channel", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSaleChannel(tc.channel)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid sale channel")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSaleItem_ApplyPromotion(t *testing.T) {
+	t.Run("apply valid promotion", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+
+		err := item.ApplyPromotion(decimal.NewFromFloat(899.99))
+
+		require.NoError(t, err)
+		assert.Equal(t, SaleItemPriceSourcePromotion, item.PriceSource)
+		assert.True(t, decimal.NewFromFloat(899.99).Equal(item.UnitPrice))
+		assert.True(t, decimal.NewFromFloat(999.99).Equal(item.ListPrice))
+		assert.True(t, decimal.NewFromFloat(1799.98).Equal(item.TotalPrice))
+		assert.True(t, decimal.NewFromInt(200).Equal(item.PriceDiscount()))
+	})
+
+	t.Run("promotion not below list price", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+
+		err := item.ApplyPromotion(decimal.NewFromFloat(999.99))
+
+		assert.Error(t, err)
+		assert.Equal(t, SaleItemPriceSourceList, item.PriceSource)
+	})
+
+	t.Run("invalid promotion price", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+
+		err := item.ApplyPromotion(decimal.Zero)
+
+		assert.Error(t, err)
+		appErr, ok := errors.IsAppError(err)
+		assert.True(t, ok)
+		assert.Equal(t, errors.ErrorTypeInvalidPrice, appErr.Type)
+	})
+}
+
+func TestSaleItem_RefreshPromotion(t *testing.T) {
+	t.Run("promotion started", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+		promoPrice := decimal.NewFromFloat(899.99)
+
+		changed := item.RefreshPromotion(&promoPrice)
+
+		assert.True(t, changed)
+		assert.Equal(t, SaleItemPriceSourcePromotion, item.PriceSource)
+		assert.True(t, promoPrice.Equal(item.UnitPrice))
+		assert.True(t, decimal.NewFromFloat(1799.98).Equal(item.TotalPrice))
+		assert.False(t, item.RefreshPromotion(&promoPrice))
+	})
+
+	t.Run("promotion ended", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+		require.NoError(t, item.ApplyPromotion(decimal.NewFromFloat(899.99)))
+
+		changed := item.RefreshPromotion(nil)
+
+		assert.True(t, changed)
+		assert.Equal(t, SaleItemPriceSourceList, item.PriceSource)
+		assert.True(t, item.ListPrice.Equal(item.UnitPrice))
+		assert.True(t, decimal.NewFromFloat(1999.98).Equal(item.TotalPrice))
+	})
+
+	t.Run("overridden price left alone", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+		require.NoError(t, item.OverridePrice(decimal.NewFromFloat(950), "Damaged box"))
+		promoPrice := decimal.NewFromFloat(899.99)
+
+		changed := item.RefreshPromotion(&promoPrice)
+
+		assert.False(t, changed)
+		assert.Equal(t, SaleItemPriceSourceOverride, item.PriceSource)
+		assert.True(t, decimal.NewFromFloat(950).Equal(item.UnitPrice))
+	})
+}
+
+func TestSaleItem_OverridePrice(t *testing.T) {
+	t.Run("override with reason", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+
+		err := item.OverridePrice(decimal.NewFromFloat(949.99), "  damaged box ")
+
+		require.NoError(t, err)
+		assert.Equal(t, SaleItemPriceSourceOverride, item.PriceSource)
+		assert.Equal(t, "damaged box", item.OverrideReason)
+		assert.True(t, decimal.NewFromInt(100).Equal(item.PriceDiscount()))
+	})
+
+	t.Run("override without reason", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+
+		err := item.OverridePrice(decimal.NewFromFloat(949.99), " ")
+
+		assert.Error(t, err)
+		assert.Equal(t, SaleItemPriceSourceList, item.PriceSource)
+	})
+
+	t.Run("invalid override price", func(t *testing.T) {
+		item := createValidSaleItem(t, uuid.New())
+
+		err := item.OverridePrice(decimal.NewFromFloat(-1), "manager approval")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSaleItem_SetUnitCost(t *testing.T) {
+	item := createValidSaleItem(t, uuid.New())
+
+	require.NoError(t, item.SetUnitCost(decimal.NewFromFloat(700)))
+	assert.True(t, decimal.NewFromFloat(700).Equal(item.UnitCost))
+
+	assert.Error(t, item.SetUnitCost(decimal.NewFromFloat(-1)))
+}
+
+func TestSale_OverrideItemPrice(t *testing.T) {
+	t.Run("override existing item", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		productID := sale.Items[0].ProductID
+
+		err := sale.OverrideItemPrice(productID, decimal.NewFromFloat(900), "price match")
+
+		require.NoError(t, err)
+		assert.Equal(t, SaleItemPriceSourceOverride, sale.Items[0].PriceSource)
+		expectedSubtotal := decimal.NewFromFloat(1800).Add(decimal.NewFromFloat(79.99))
+		assert.True(t, expectedSubtotal.Equal(sale.Subtotal))
+	})
+
+	t.Run("override non-existing item", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.OverrideItemPrice(uuid.New(), decimal.NewFromFloat(900), "price match")
+
+		assert.Error(t, err)
+		appErr, ok := errors.IsAppError(err)
+		assert.True(t, ok)
+		assert.Equal(t, errors.ErrorTypeNotFound, appErr.Type)
+	})
+}
+
+func TestSale_SetItemSerialNumbers(t *testing.T) {
+	serializedProduct := func(sale *Sale) *Product {
+		return &Product{ID: sale.Items[0].ProductID, TenantID: sale.TenantID, SKU: "LAPTOP001", IsSerialized: true}
+	}
+
+	t.Run("captures normalized serial numbers", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.SetItemSerialNumbers(serializedProduct(sale), []string{" sn-001 ", "SN-002"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SN-001", "SN-002"}, sale.Items[0].SerialNumbers)
+		assert.True(t, sale.Items[0].HasSerialNumber("SN-002"))
+	})
+
+	t.Run("quantity cannot drop below serial numbers captured", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.SetItemSerialNumbers(serializedProduct(sale), []string{"SN-001", "SN-002"}))
+
+		err := sale.UpdateItemQuantity(sale.Items[0].ProductID, 1)
+
+		assert.Error(t, err)
+		assert.Equal(t, 2, sale.Items[0].Quantity)
+	})
+
+	tests := []struct {
+		name    string
+		prepare func(sale *Sale, product *Product)
+		serials []string
+		errMsg  string
+	}{
+		{"more serial numbers than quantity", nil, []string{"SN-001", "SN-002", "SN-003"}, "too many serial numbers"},
+		{"duplicate serial numbers", nil, []string{"SN-001", "sn-001"}, "duplicate serial number"},
+		{"empty serial number", nil, []string{" "}, "serial number is required"},
+		{"product not serialized", func(_ *Sale, product *Product) { product.SetSerialized(false) }, []string{"SN-001"}, "product is not serialized"},
+		{"product not in sale", func(_ *Sale, product *Product) { product.ID = uuid.New() }, []string{"SN-001"}, "not found"},
+		{"sale not pending", func(sale *Sale, _ *Product) { sale.Status = SaleStatusCompleted }, []string{"SN-001"}, "invalid sale status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sale := createSaleWithItems(t)
+			product := serializedProduct(sale)
+			if tt.prepare != nil {
+				tt.prepare(sale, product)
+			}
+
+			err := sale.SetItemSerialNumbers(product, tt.serials)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestSale_ProcessSplitPayment(t *testing.T) {
+	t.Run("part cash part card", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		total := sale.TotalAmount
+
+		err := sale.ProcessSplitPayment([]PaymentLine{
+			{PaymentMethod: PaymentMethodCash, Amount: decimal.NewFromInt(100)},
+			{PaymentMethod: PaymentMethodCard, Amount: total.Sub(decimal.NewFromInt(100))},
+		}, nil)
+
+		require.NoError(t, err)
+		require.Len(t, sale.Payments, 2)
+		assert.True(t, decimal.NewFromInt(100).Equal(sale.Payments[0].Amount))
+		assert.True(t, sale.Payments[0].Amount.Add(sale.Payments[1].Amount).Equal(sale.TotalAmount))
+		assert.Equal(t, PaymentMethodCard, sale.PaymentMethod)
+		assert.True(t, decimal.Zero.Equal(sale.ChangeAmount))
+	})
+
+	t.Run("change given from cash", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		cardAmount := sale.TotalAmount.Sub(decimal.NewFromInt(50))
+
+		err := sale.ProcessSplitPayment([]PaymentLine{
+			{PaymentMethod: PaymentMethodCard, Amount: cardAmount},
+			{PaymentMethod: PaymentMethodCash, Amount: decimal.NewFromInt(60)},
+		}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(10).Equal(sale.ChangeAmount))
+		assert.True(t, decimal.NewFromInt(50).Equal(sale.Payments[1].Amount))
+		assert.True(t, decimal.NewFromInt(60).Equal(sale.Payments[1].TenderedAmount))
+	})
+
+	t.Run("change without cash", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.ProcessSplitPayment([]PaymentLine{
+			{PaymentMethod: PaymentMethodCard, Amount: sale.TotalAmount},
+			{PaymentMethod: PaymentMethodDigitalWallet, Amount: decimal.NewFromInt(10)},
+		}, nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("surcharge only on card part", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		cardAmount := decimal.NewFromInt(1000)
+		cashAmount := sale.TotalAmount.Sub(cardAmount)
+		rule := createSurchargeRule(t, PaymentMethodCard, false)
+
+		err := sale.ProcessSplitPayment([]PaymentLine{
+			{PaymentMethod: PaymentMethodCash, Amount: cashAmount},
+			{PaymentMethod: PaymentMethodCard, Amount: cardAmount},
+		}, map[PaymentMethod]*PaymentSurchargeRule{PaymentMethodCard: rule})
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(30).Equal(sale.SurchargeAmount))
+		assert.True(t, decimal.NewFromInt(1030).Equal(sale.Payments[1].Amount))
+		assert.True(t, decimal.NewFromInt(30).Equal(sale.Payments[1].SurchargeAmount))
+		assert.True(t, sale.Payments[0].Amount.Add(sale.Payments[1].Amount).Equal(sale.TotalAmount))
+		assert.Equal(t, "Card fee", sale.SurchargeLabel)
+	})
+
+	t.Run("insufficient payment", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.ProcessSplitPayment([]PaymentLine{
+			{PaymentMethod: PaymentMethodCash, Amount: decimal.NewFromInt(10)},
+			{PaymentMethod: PaymentMethodCard, Amount: decimal.NewFromInt(10)},
+		}, nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate payment method", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.ProcessSplitPayment([]PaymentLine{
+			{PaymentMethod: PaymentMethodCard, Amount: sale.TotalAmount},
+			{PaymentMethod: PaymentMethodCard, Amount: decimal.NewFromInt(10)},
+		}, nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSale_Hold(t *testing.T) {
+	t.Run("hold pending sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		userID := uuid.New()
+
+		err := sale.Hold(userID, "  Blue jacket  ", time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, SaleStatusHeld, sale.Status)
+		assert.True(t, sale.IsHeld())
+		assert.Equal(t, "Blue jacket", sale.HoldLabel)
+		require.NotNil(t, sale.HeldBy)
+		assert.Equal(t, userID, *sale.HeldBy)
+		require.NotNil(t, sale.HoldExpiresAt)
+		assert.Equal(t, sale.HeldAt.Add(time.Hour), *sale.HoldExpiresAt)
+	})
+
+	t.Run("hold empty sale", func(t *testing.T) {
+		sale := createValidSale(t)
+
+		err := sale.Hold(uuid.New(), "", time.Hour)
+
+		assert.Error(t, err)
+		assert.Equal(t, SaleStatusPending, sale.Status)
+	})
+
+	t.Run("hold completed sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		sale.Status = SaleStatusCompleted
+
+		err := sale.Hold(uuid.New(), "", time.Hour)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("hold with long label", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.Hold(uuid.New(), strings.Repeat("a", MaxSaleHoldLabelLength+1), time.Hour)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSale_Resume(t *testing.T) {
+	t.Run("resume held sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.Hold(uuid.New(), "Table 4", time.Hour))
+
+		err := sale.Resume(time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, SaleStatusPending, sale.Status)
+		assert.Nil(t, sale.HeldAt)
+		assert.Nil(t, sale.HeldBy)
+		assert.Nil(t, sale.HoldExpiresAt)
+		assert.Empty(t, sale.HoldLabel)
+	})
+
+	t.Run("resume expired hold", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+		require.NoError(t, sale.Hold(uuid.New(), "", time.Hour))
+
+		err := sale.Resume(time.Now().Add(2 * time.Hour))
+
+		assert.Error(t, err)
+		assert.Equal(t, SaleStatusHeld, sale.Status)
+	})
+
+	t.Run("resume pending sale", func(t *testing.T) {
+		sale := createSaleWithItems(t)
+
+		err := sale.Resume(time.Now())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSale_ExpireHold(t *testing.T) {
+	sale := createSaleWithItems(t)
+	require.NoError(t, sale.Hold(uuid.New(), "", time.Hour))
+
+	assert.False(t, sale.IsHoldExpired(time.Now()))
+	assert.Error(t, sale.ExpireHold(time.Now()))
+
+	later := time.Now().Add(time.Hour + time.Minute)
+	assert.True(t, sale.IsHoldExpired(later))
+	require.NoError(t, sale.ExpireHold(later))
+	assert.Equal(t, SaleStatusCancelled, sale.Status)
+	assert.False(t, sale.IsHoldExpired(later))
+}
+
+func TestSale_LockExchangeRate(t *testing.T) {
+	sale := createValidSale(t)
+	rate, err := NewExchangeRate("IDR", "IDR", decimal.NewFromInt(1), sale.CreatedAt)
+	require.NoError(t, err)
+
+	assert.Error(t, sale.LockExchangeRate(nil))
+	require.NoError(t, sale.LockExchangeRate(rate))
+	assert.Equal(t, rate, sale.ExchangeRate)
+	assert.Equal(t, "IDR", sale.Currency)
+
+	err = sale.LockExchangeRate(rate)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exchange rate already locked")
+}
+
 func createValidSale(t *testing.T) *Sale {
 	createdBy := uuid.New()
 
@@ -715,3 +1272,19 @@ func createSaleWithItems(t *testing.T) *Sale {
 
 	return sale
 }
+
+func createSurchargeRule(t *testing.T, paymentMethod PaymentMethod, isTaxable bool) *PaymentSurchargeRule {
+	rule, err := NewPaymentSurchargeRule(
+		uuid.New(),
+		paymentMethod,
+		SurchargeTypePercentage,
+		decimal.NewFromInt(3),
+		decimal.Zero,
+		decimal.Zero,
+		"Card fee",
+		isTaxable,
+		uuid.New(),
+	)
+	require.NoError(t, err)
+	return rule
+}
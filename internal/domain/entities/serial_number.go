@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// SerialNumberStatus represents where a serialized unit is in its life
+type SerialNumberStatus string
+
+const (
+	SerialNumberStatusInStock  SerialNumberStatus = "in_stock" // Registered, not sold yet
+	SerialNumberStatusSold     SerialNumberStatus = "sold"
+	SerialNumberStatusReturned SerialNumberStatus = "returned" // Refunded, can be sold again
+)
+
+// MaxSerialNumberLength is the longest serial number accepted
+const MaxSerialNumberLength = 100
+
+// SerialNumber represents a single unit of a serialized product. It remembers the sale the
+// unit was last sold in, so warranty claims can be checked against it.
+type SerialNumber struct {
+	ID         uuid.UUID          `json:"id"`
+	TenantID   uuid.UUID          `json:"tenant_id"`
+	ProductID  uuid.UUID          `json:"product_id"`
+	Serial     string             `json:"serial"`
+	Status     SerialNumberStatus `json:"status"`
+	SaleID     *uuid.UUID         `json:"sale_id,omitempty"`
+	SaleItemID *uuid.UUID         `json:"sale_item_id,omitempty"`
+	SoldAt     *time.Time         `json:"sold_at,omitempty"`
+	ReturnedAt *time.Time         `json:"returned_at,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+// NewSerialNumber registers a unit of a product as in stock
+func NewSerialNumber(tenantID, productID uuid.UUID, serial string) (*SerialNumber, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if productID == uuid.Nil {
+		return nil, errors.NewValidationError("product ID is required", "product ID cannot be empty")
+	}
+
+	serial, err := NormalizeSerialNumber(serial)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &SerialNumber{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		ProductID: productID,
+		Serial:    serial,
+		Status:    SerialNumberStatusInStock,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Sell records the unit as sold in a sale. A unit already sold cannot be sold again until
+// it is returned.
+func (s *SerialNumber) Sell(saleID, saleItemID uuid.UUID) error {
+	if s.Status == SerialNumberStatusSold {
+		return errors.NewConflictError(fmt.Sprintf("serial number '%s' has already been sold", s.Serial))
+	}
+
+	now := time.Now()
+	s.Status = SerialNumberStatusSold
+	s.SaleID = &saleID
+	s.SaleItemID = &saleItemID
+	s.SoldAt = &now
+	s.ReturnedAt = nil
+	s.UpdatedAt = now
+	return nil
+}
+
+// Return records the unit as returned by the customer it was sold to
+func (s *SerialNumber) Return() error {
+	if s.Status != SerialNumberStatusSold {
+		return errors.NewValidationError("invalid serial number status", fmt.Sprintf("serial number '%s' has not been sold", s.Serial))
+	}
+
+	now := time.Now()
+	s.Status = SerialNumberStatusReturned
+	s.ReturnedAt = &now
+	s.UpdatedAt = now
+	return nil
+}
+
+// IsSoldIn reports whether the unit is currently sold through the given sale item
+func (s *SerialNumber) IsSoldIn(saleItemID uuid.UUID) bool {
+	return s.Status == SerialNumberStatusSold && s.SaleItemID != nil && *s.SaleItemID == saleItemID
+}
+
+// NormalizeSerialNumber trims and upper-cases a serial number, so it is matched the same way
+// however it was typed or scanned
+func NormalizeSerialNumber(serial string) (string, error) {
+	serial = strings.ToUpper(strings.TrimSpace(serial))
+	if serial == "" {
+		return "", errors.NewValidationError("serial number is required", "serial number cannot be empty")
+	}
+	if len(serial) > MaxSerialNumberLength {
+		return "", errors.NewValidationError("serial number too long", fmt.Sprintf("serial number cannot exceed %d characters", MaxSerialNumberLength))
+	}
+	return serial, nil
+}
+
+// NormalizeSerialNumbers normalizes a list of serial numbers, rejecting duplicates
+func NormalizeSerialNumbers(serials []string) ([]string, error) {
+	normalized := make([]string, 0, len(serials))
+	seen := make(map[string]bool, len(serials))
+	for _, serial := range serials {
+		serial, err := NormalizeSerialNumber(serial)
+		if err != nil {
+			return nil, err
+		}
+		if seen[serial] {
+			return nil, errors.NewValidationError("duplicate serial number", fmt.Sprintf("serial number '%s' is listed more than once", serial))
+		}
+		seen[serial] = true
+		normalized = append(normalized, serial)
+	}
+	return normalized, nil
+}
+
+// ValidateSerialNumberStatus validates a serial number status
+func ValidateSerialNumberStatus(status SerialNumberStatus) error {
+	switch status {
+	case SerialNumberStatusInStock, SerialNumberStatusSold, SerialNumberStatusReturned:
+		return nil
+	default:
+		return errors.NewValidationError("invalid serial number status", "status must be one of: in_stock, sold, returned")
+	}
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+func TestNewSerialNumber(t *testing.T) {
+	tenantID, productID := uuid.New(), uuid.New()
+
+	serial, err := NewSerialNumber(tenantID, productID, " abc-123 ")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ABC-123", serial.Serial)
+	assert.Equal(t, SerialNumberStatusInStock, serial.Status)
+	assert.Equal(t, productID, serial.ProductID)
+	assert.Nil(t, serial.SaleID)
+
+	tests := []struct {
+		name      string
+		tenantID  uuid.UUID
+		productID uuid.UUID
+		serial    string
+	}{
+		{"missing tenant", uuid.Nil, productID, "ABC-123"},
+		{"missing product", tenantID, uuid.Nil, "ABC-123"},
+		{"empty serial", tenantID, productID, "  "},
+		{"serial too long", tenantID, productID, string(make([]byte, MaxSerialNumberLength+1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serial, err := NewSerialNumber(tt.tenantID, tt.productID, tt.serial)
+
+			assert.Error(t, err)
+			assert.Nil(t, serial)
+		})
+	}
+}
+
+func TestSerialNumber_Sell(t *testing.T) {
+	serial, err := NewSerialNumber(uuid.New(), uuid.New(), "ABC-123")
+	require.NoError(t, err)
+	saleID, saleItemID := uuid.New(), uuid.New()
+
+	require.NoError(t, serial.Sell(saleID, saleItemID))
+
+	assert.Equal(t, SerialNumberStatusSold, serial.Status)
+	assert.Equal(t, saleID, *serial.SaleID)
+	assert.NotNil(t, serial.SoldAt)
+	assert.True(t, serial.IsSoldIn(saleItemID))
+	assert.False(t, serial.IsSoldIn(uuid.New()))
+
+	t.Run("cannot be sold twice", func(t *testing.T) {
+		err := serial.Sell(uuid.New(), uuid.New())
+
+		assert.Error(t, err)
+		appErr, ok := errors.IsAppError(err)
+		require.True(t, ok)
+		assert.Equal(t, errors.ErrorTypeConflict, appErr.Type)
+		assert.Equal(t, saleID, *serial.SaleID)
+	})
+}
+
+func TestSerialNumber_Return(t *testing.T) {
+	serial, err := NewSerialNumber(uuid.New(), uuid.New(), "ABC-123")
+	require.NoError(t, err)
+
+	assert.Error(t, serial.Return(), "unsold unit cannot be returned")
+
+	saleItemID := uuid.New()
+	require.NoError(t, serial.Sell(uuid.New(), saleItemID))
+	require.NoError(t, serial.Return())
+
+	assert.Equal(t, SerialNumberStatusReturned, serial.Status)
+	assert.NotNil(t, serial.ReturnedAt)
+	assert.False(t, serial.IsSoldIn(saleItemID))
+	assert.Error(t, serial.Return())
+
+	// A returned unit can be sold again
+	require.NoError(t, serial.Sell(uuid.New(), uuid.New()))
+	assert.Nil(t, serial.ReturnedAt)
+}
+
+func TestNormalizeSerialNumbers(t *testing.T) {
+	serials, err := NormalizeSerialNumbers([]string{" a1 ", "B2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B2"}, serials)
+
+	_, err = NormalizeSerialNumbers([]string{"a1", "A1 "})
+	assert.Error(t, err)
+
+	serials, err = NormalizeSerialNumbers(nil)
+	require.NoError(t, err)
+	assert.Empty(t, serials)
+}
+
+func TestValidateSerialNumberStatus(t *testing.T) {
+	assert.NoError(t, ValidateSerialNumberStatus(SerialNumberStatusInStock))
+	assert.NoError(t, ValidateSerialNumberStatus(SerialNumberStatusSold))
+	assert.NoError(t, ValidateSerialNumberStatus(SerialNumberStatusReturned))
+	assert.Error(t, ValidateSerialNumberStatus("lost"))
+}
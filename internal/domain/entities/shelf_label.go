@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxShelfLabelsPerBatch caps the number of labels rendered in one print job
+const MaxShelfLabelsPerBatch = 1000
+
+// LabelLayout describes a sheet of equally sized labels arranged in a grid. All
+// dimensions are in millimeters.
+type LabelLayout struct {
+	Name          string  `json:"name"`
+	Description   string  `json:"description,omitempty"`
+	PageWidthMM   float64 `json:"page_width_mm"`
+	PageHeightMM  float64 `json:"page_height_mm"`
+	Columns       int     `json:"columns"`
+	Rows          int     `json:"rows"`
+	LabelWidthMM  float64 `json:"label_width_mm"`
+	LabelHeightMM float64 `json:"label_height_mm"`
+	MarginTopMM   float64 `json:"margin_top_mm"`
+	MarginLeftMM  float64 `json:"margin_left_mm"`
+	GapXMM        float64 `json:"gap_x_mm"` // Horizontal space between columns
+	GapYMM        float64 `json:"gap_y_mm"` // Vertical space between rows
+}
+
+// labelLayouts are the preset layouts matching common label sheets
+var labelLayouts = map[string]LabelLayout{
+	"a4_3x7": {
+		Name: "a4_3x7", Description: "A4, 21 labels (63.5 x 38.1 mm)",
+		PageWidthMM: 210, PageHeightMM: 297, Columns: 3, Rows: 7,
+		LabelWidthMM: 63.5, LabelHeightMM: 38.1, MarginTopMM: 15.15, MarginLeftMM: 7.25, GapXMM: 2.5,
+	},
+	"a4_3x8": {
+		Name: "a4_3x8", Description: "A4, 24 labels (63.5 x 33.9 mm)",
+		PageWidthMM: 210, PageHeightMM: 297, Columns: 3, Rows: 8,
+		LabelWidthMM: 63.5, LabelHeightMM: 33.9, MarginTopMM: 12.9, MarginLeftMM: 7.25, GapXMM: 2.5,
+	},
+	"a4_2x7": {
+		Name: "a4_2x7", Description: "A4, 14 labels (99.1 x 38.1 mm)",
+		PageWidthMM: 210, PageHeightMM: 297, Columns: 2, Rows: 7,
+		LabelWidthMM: 99.1, LabelHeightMM: 38.1, MarginTopMM: 15.15, MarginLeftMM: 4.65, GapXMM: 2.5,
+	},
+	"letter_3x10": {
+		Name: "letter_3x10", Description: "US Letter, 30 labels (66.7 x 25.4 mm)",
+		PageWidthMM: 215.9, PageHeightMM: 279.4, Columns: 3, Rows: 10,
+		LabelWidthMM: 66.7, LabelHeightMM: 25.4, MarginTopMM: 12.7, MarginLeftMM: 4.8, GapXMM: 3.1,
+	},
+	"letter_2x10": {
+		Name: "letter_2x10", Description: "US Letter, 20 labels (101.6 x 25.4 mm)",
+		PageWidthMM: 215.9, PageHeightMM: 279.4, Columns: 2, Rows: 10,
+		LabelWidthMM: 101.6, LabelHeightMM: 25.4, MarginTopMM: 12.7, MarginLeftMM: 4.0, GapXMM: 4.7,
+	},
+}
+
+// DefaultLabelLayout is used when a print job does not name a layout
+const DefaultLabelLayout = "a4_3x8"
+
+// GetLabelLayout returns a preset layout by name
+func GetLabelLayout(name string) (LabelLayout, error) {
+	layout, ok := labelLayouts[name]
+	if !ok {
+		return LabelLayout{}, errors.NewValidationError("invalid label layout", fmt.Sprintf("unknown label layout: %s", name))
+	}
+	return layout, nil
+}
+
+// LabelLayouts returns all preset layouts ordered by name
+func LabelLayouts() []LabelLayout {
+	layouts := make([]LabelLayout, 0, len(labelLayouts))
+	for _, layout := range labelLayouts {
+		layouts = append(layouts, layout)
+	}
+	sort.Slice(layouts, func(i, j int) bool {
+		return layouts[i].Name < layouts[j].Name
+	})
+	return layouts
+}
+
+// Validate checks that the labels are large enough to print on and fit on the page
+func (l LabelLayout) Validate() error {
+	if l.PageWidthMM <= 0 || l.PageHeightMM <= 0 {
+		return errors.NewValidationError("invalid page size", "page width and height must be positive")
+	}
+	if l.Columns < 1 || l.Rows < 1 {
+		return errors.NewValidationError("invalid label grid", "columns and rows must be at least 1")
+	}
+	if l.LabelWidthMM < 30 || l.LabelHeightMM < 20 {
+		return errors.NewValidationError("label too small", "labels must be at least 30 x 20 mm")
+	}
+	if l.MarginTopMM < 0 || l.MarginLeftMM < 0 || l.GapXMM < 0 || l.GapYMM < 0 {
+		return errors.NewValidationError("invalid label spacing", "margins and gaps cannot be negative")
+	}
+
+	width := l.MarginLeftMM + float64(l.Columns)*l.LabelWidthMM + float64(l.Columns-1)*l.GapXMM
+	height := l.MarginTopMM + float64(l.Rows)*l.LabelHeightMM + float64(l.Rows-1)*l.GapYMM
+	if width > l.PageWidthMM || height > l.PageHeightMM {
+		return errors.NewValidationError("labels do not fit on page", fmt.Sprintf("label grid needs %.1f x %.1f mm", width, height))
+	}
+
+	return nil
+}
+
+// LabelsPerPage returns how many labels fit on one sheet
+func (l LabelLayout) LabelsPerPage() int {
+	return l.Columns * l.Rows
+}
+
+// LabelPosition returns the top-left corner of the label at the given index on its page.
+// Labels fill each row left to right before moving to the next row.
+func (l LabelLayout) LabelPosition(index int) (x, y float64) {
+	slot := index % l.LabelsPerPage()
+	column := slot % l.Columns
+	row := slot / l.Columns
+	x = l.MarginLeftMM + float64(column)*(l.LabelWidthMM+l.GapXMM)
+	y = l.MarginTopMM + float64(row)*(l.LabelHeightMM+l.GapYMM)
+	return x, y
+}
+
+// ShelfLabel is the content printed on one shelf label
+type ShelfLabel struct {
+	Name        string           `json:"name"`
+	SKU         string           `json:"sku"`
+	Unit        string           `json:"unit"`
+	Price       decimal.Decimal  `json:"price"`
+	PromoPrice  *decimal.Decimal `json:"promo_price,omitempty"`
+	PromoEndsAt *time.Time       `json:"promo_ends_at,omitempty"`
+	BarcodeData string           `json:"barcode_data"` // Barcode, or SKU if the product has none
+	Currency    CurrencyFormat   `json:"currency"`
+}
+
+// NewShelfLabel creates the label for a product as of the given time. The promotional
+// price is shown only while the promotion is running.
+func NewShelfLabel(product *Product, currency CurrencyFormat, now time.Time) ShelfLabel {
+	label := ShelfLabel{
+		Name:        product.Name,
+		SKU:         product.SKU,
+		Unit:        product.Unit,
+		Price:       product.Price,
+		BarcodeData: product.Barcode,
+		Currency:    currency,
+	}
+	if label.BarcodeData == "" {
+		label.BarcodeData = product.SKU
+	}
+	if promo := product.ActivePromoPrice(now); promo != nil {
+		label.PromoPrice = promo
+		label.PromoEndsAt = product.PromoEndsAt
+	}
+	return label
+}
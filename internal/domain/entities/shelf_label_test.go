@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelLayouts(t *testing.T) {
+	layouts := LabelLayouts()
+
+	require.NotEmpty(t, layouts)
+	for _, layout := range layouts {
+		assert.NoError(t, layout.Validate(), layout.Name)
+	}
+
+	_, err := GetLabelLayout(DefaultLabelLayout)
+	assert.NoError(t, err)
+
+	_, err = GetLabelLayout("a3_1x1")
+	assert.Error(t, err)
+}
+
+func TestLabelLayout_Validate(t *testing.T) {
+	layout, err := GetLabelLayout("a4_3x8")
+	require.NoError(t, err)
+
+	t.Run("grid wider than page", func(t *testing.T) {
+		wide := layout
+		wide.Columns = 4
+
+		err := wide.Validate()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "labels do not fit on page")
+	})
+
+	t.Run("label too small", func(t *testing.T) {
+		small := layout
+		small.LabelHeightMM = 10
+
+		assert.Error(t, small.Validate())
+	})
+}
+
+func TestLabelLayout_LabelPosition(t *testing.T) {
+	layout, err := GetLabelLayout("a4_3x8")
+	require.NoError(t, err)
+
+	x, y := layout.LabelPosition(0)
+	assert.Equal(t, layout.MarginLeftMM, x)
+	assert.Equal(t, layout.MarginTopMM, y)
+
+	x, y = layout.LabelPosition(4)
+	assert.InDelta(t, layout.MarginLeftMM+layout.LabelWidthMM+layout.GapXMM, x, 0.001)
+	assert.InDelta(t, layout.MarginTopMM+layout.LabelHeightMM, y, 0.001)
+
+	// The first label on the second page is back at the top left
+	x, y = layout.LabelPosition(layout.LabelsPerPage())
+	assert.Equal(t, layout.MarginLeftMM, x)
+	assert.Equal(t, layout.MarginTopMM, y)
+}
+
+func TestNewShelfLabel(t *testing.T) {
+	product, err := NewProduct(uuid.New(), "MLK-001", "Fresh Milk", "", "Dairy", "ltr", decimal.NewFromInt(18000), decimal.NewFromInt(15000), 5, uuid.New())
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("falls back to SKU without barcode", func(t *testing.T) {
+		label := NewShelfLabel(product, DefaultCurrencyFormat("IDR"), now)
+
+		assert.Equal(t, "Fresh Milk", label.Name)
+		assert.Equal(t, "MLK-001", label.BarcodeData)
+		assert.Equal(t, "Rp", label.Currency.Symbol)
+		assert.Nil(t, label.PromoPrice)
+	})
+
+	t.Run("shows running promotion", func(t *testing.T) {
+		endsAt := now.Add(72 * time.Hour)
+		require.NoError(t, product.SetPromotion(decimal.NewFromInt(15000), nil, &endsAt))
+		require.NoError(t, product.SetBarcode("8991234567890"))
+
+		label := NewShelfLabel(product, DefaultCurrencyFormat("IDR"), now)
+
+		require.NotNil(t, label.PromoPrice)
+		assert.True(t, decimal.NewFromInt(15000).Equal(*label.PromoPrice))
+		assert.Equal(t, &endsAt, label.PromoEndsAt)
+		assert.Equal(t, "8991234567890", label.BarcodeData)
+	})
+
+	t.Run("hides expired promotion", func(t *testing.T) {
+		label := NewShelfLabel(product, DefaultCurrencyFormat("IDR"), now.Add(96*time.Hour))
+
+		assert.Nil(t, label.PromoPrice)
+		assert.Nil(t, label.PromoEndsAt)
+	})
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,6 +31,8 @@ const (
 	ReasonAdjustment  StockMovementReason = "adjustment"
 	ReasonReservation StockMovementReason = "reservation"
 	ReasonRelease     StockMovementReason = "release"
+	ReasonTransfer    StockMovementReason = "transfer"  // Moved between locations
+	ReasonStocktake   StockMovementReason = "stocktake" // Variance found by a stock count
 )
 
 // Stock represents current stock levels for a product
@@ -47,15 +50,17 @@ type Stock struct {
 
 // StockMovement represents a stock movement record
 type StockMovement struct {
-	ID        uuid.UUID           `json:"id"`
-	ProductID uuid.UUID           `json:"product_id"`
-	Type      StockMovementType   `json:"type"`
-	Reason    StockMovementReason `json:"reason"`
-	Quantity  int                 `json:"quantity"`
-	Reference string              `json:"reference,omitempty"` // Order ID, Invoice ID, etc.
-	Notes     string              `json:"notes,omitempty"`
-	CreatedAt time.Time           `json:"created_at"`
-	CreatedBy uuid.UUID           `json:"created_by"`
+	ID         uuid.UUID           `json:"id"`
+	ProductID  uuid.UUID           `json:"product_id"`
+	Type       StockMovementType   `json:"type"`
+	Reason     StockMovementReason `json:"reason"`
+	Quantity   int                 `json:"quantity"`
+	Reference  string              `json:"reference,omitempty"` // Order ID, Invoice ID, etc.
+	Notes      string              `json:"notes,omitempty"`
+	LocationID *uuid.UUID          `json:"location_id,omitempty"` // Set on movements between locations
+	ReversalOf *uuid.UUID          `json:"reversal_of,omitempty"` // Set on movements undoing a mistaken one
+	CreatedAt  time.Time           `json:"created_at"`
+	CreatedBy  uuid.UUID           `json:"created_by"`
 }
 
 // NewStock creates a new stock record for a product
@@ -113,6 +118,54 @@ func NewStockMovement(productID uuid.UUID, movementType StockMovementType, reaso
 	return movement, nil
 }
 
+// IsReversible reports whether the movement can be undone by a reversal. Only stock taken in
+// or out directly can be; sales, returns, transfers and reservations are undone through their
+// own operations, and a reversal is not reversed again.
+func (m *StockMovement) IsReversible() bool {
+	if m.ReversalOf != nil || m.LocationID != nil {
+		return false
+	}
+	if m.Type != StockMovementTypeIn && m.Type != StockMovementTypeOut {
+		return false
+	}
+	switch m.Reason {
+	case ReasonSale, ReasonReturn, ReasonTransfer, ReasonReservation, ReasonRelease:
+		return false
+	default:
+		return true
+	}
+}
+
+// NewReversal creates the movement that undoes this one: the same quantity in the opposite
+// direction, with the same reason and reference so reports net the two out. The notes saying
+// why the movement is reversed are required.
+func (m *StockMovement) NewReversal(notes string, createdBy uuid.UUID) (*StockMovement, error) {
+	if !m.IsReversible() {
+		return nil, errors.NewValidationError("stock movement cannot be reversed", "only stock adjustments can be reversed; sales, returns, transfers and reservations are undone through their own operations, and reversals cannot be reversed")
+	}
+
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return nil, errors.NewValidationError("notes are required", "explain why the stock movement is reversed")
+	}
+	if len(notes) > 500 {
+		return nil, errors.NewValidationError("notes too long", "notes cannot exceed 500 characters")
+	}
+
+	movementType := StockMovementTypeIn
+	if m.Type == StockMovementTypeIn {
+		movementType = StockMovementTypeOut
+	}
+
+	reversal, err := NewStockMovement(m.ProductID, movementType, m.Reason, m.Quantity, m.Reference, notes, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	reversal.ReversalOf = &m.ID
+
+	return reversal, nil
+}
+
 // AddStock increases available stock
 func (s *Stock) AddStock(quantity int, reason StockMovementReason) error {
 	if quantity <= 0 {
@@ -250,9 +303,9 @@ func ValidateStockMovementType(movementType StockMovementType) error {
 // ValidateStockMovementReason validates stock movement reason
 func ValidateStockMovementReason(reason StockMovementReason) error {
 	switch reason {
-	case ReasonPurchase, ReasonSale, ReasonReturn, ReasonDamage, ReasonExpiry, ReasonAdjustment, ReasonReservation, ReasonRelease:
+	case ReasonPurchase, ReasonSale, ReasonReturn, ReasonDamage, ReasonExpiry, ReasonAdjustment, ReasonReservation, ReasonRelease, ReasonTransfer, ReasonStocktake:
 		return nil
 	default:
-		return errors.NewValidationError("invalid stock movement reason", "reason must be one of: purchase, sale, return, damage, expiry, adjustment, reservation, release")
+		return errors.NewValidationError("invalid stock movement reason", "reason must be one of: purchase, sale, return, damage, expiry, adjustment, reservation, release, transfer, stocktake")
 	}
 }
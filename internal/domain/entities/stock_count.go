@@ -0,0 +1,338 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxStockCountBatchSize caps the scans a handheld scanner submits in one batch
+const MaxStockCountBatchSize = 500
+
+// MaxStockCountImportRows caps the rows of a counted quantities CSV file
+const MaxStockCountImportRows = 10000
+
+// StockCountStatus represents the status of a stock count
+type StockCountStatus string
+
+const (
+	StockCountStatusOpen      StockCountStatus = "open"      // Being counted
+	StockCountStatusCompleted StockCountStatus = "completed" // Variances posted to stock
+	StockCountStatusCancelled StockCountStatus = "cancelled"
+)
+
+// StockCountScanStatus represents what became of a scan submitted to a stock count
+type StockCountScanStatus string
+
+const (
+	StockCountScanStatusRecorded       StockCountScanStatus = "recorded"        // First count of the product
+	StockCountScanStatusUpdated        StockCountScanStatus = "updated"         // A rescan replaced the product's count
+	StockCountScanStatusDuplicate      StockCountScanStatus = "duplicate"       // The same scan was already recorded, e.g. a retried upload
+	StockCountScanStatusStale          StockCountScanStatus = "stale"           // Scanned before the product's recorded count, so ignored
+	StockCountScanStatusUnknownBarcode StockCountScanStatus = "unknown_barcode" // No product has the barcode
+	StockCountScanStatusInvalid        StockCountScanStatus = "invalid"
+)
+
+// StockCount represents a stocktake: products are scanned and counted on the shelves, and
+// the counts replace the stock on record once the count is completed. A count may cover a
+// single location, a category, or both; it covers every product otherwise.
+type StockCount struct {
+	ID          uuid.UUID        `json:"id"`
+	TenantID    uuid.UUID        `json:"tenant_id"`
+	Name        string           `json:"name"` // e.g. "Aisle 3, October"
+	Status      StockCountStatus `json:"status"`
+	Notes       string           `json:"notes,omitempty"`
+	LocationID  *uuid.UUID       `json:"location_id,omitempty"` // Counted at, expected quantities are the location's
+	CategoryID  *uuid.UUID       `json:"category_id,omitempty"` // Counted category, subcategories included
+	Category    string           `json:"category,omitempty"`    // Name of the category at CategoryID
+	Lines       []StockCountLine `json:"lines"`
+	StartedBy   uuid.UUID        `json:"started_by"`
+	StartedAt   time.Time        `json:"started_at"`
+	CompletedBy *uuid.UUID       `json:"completed_by,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"` // Also set when cancelled
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// StockCountLine represents the counted quantity of a product. A product has one line per
+// count; rescanning it replaces the counted quantity.
+type StockCountLine struct {
+	ID          uuid.UUID `json:"id"`
+	CountID     uuid.UUID `json:"count_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductSKU  string    `json:"product_sku"`
+	ProductName string    `json:"product_name"`
+	Barcode     string    `json:"barcode"`
+	CountedQty  int       `json:"counted_qty"`
+	ExpectedQty int       `json:"expected_qty"` // Stock on record when last scanned
+	Variance    int       `json:"variance"`     // Counted less expected
+	CountedAt   time.Time `json:"counted_at"`   // When the scanner scanned it
+	CountedBy   uuid.UUID `json:"counted_by"`
+	ScanCount   int       `json:"scan_count"` // Scans recorded, rescans included
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// StockCountScan represents a product counted on a handheld scanner
+type StockCountScan struct {
+	Barcode   string    `json:"barcode" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"min=0"`
+	ScannedAt time.Time `json:"scanned_at" validate:"required"` // Scanner's clock
+}
+
+// StockCountVariances summarizes the differences a count found between the counted and the
+// expected quantities
+type StockCountVariances struct {
+	CountID       uuid.UUID        `json:"count_id"`
+	CountedLines  int              `json:"counted_lines"`
+	VarianceLines int              `json:"variance_lines"` // Lines whose count differs from the stock on record
+	UnitsOver     int              `json:"units_over"`     // Units counted beyond the stock on record
+	UnitsShort    int              `json:"units_short"`    // Units on record but not counted
+	NetVariance   int              `json:"net_variance"`
+	Lines         []StockCountLine `json:"lines"` // Lines with a variance
+}
+
+// StockCountScanResult represents the outcome of a scan, returned to the scanner straight
+// away so a counter can recount a line whose variance looks wrong
+type StockCountScanResult struct {
+	Barcode     string               `json:"barcode"`
+	Status      StockCountScanStatus `json:"status"`
+	Message     string               `json:"message,omitempty"`
+	ProductID   *uuid.UUID           `json:"product_id,omitempty"`
+	ProductSKU  string               `json:"product_sku,omitempty"`
+	ProductName string               `json:"product_name,omitempty"`
+	CountedQty  *int                 `json:"counted_qty,omitempty"`
+	ExpectedQty *int                 `json:"expected_qty,omitempty"`
+	Variance    *int                 `json:"variance,omitempty"`
+}
+
+// NewStockCount starts a stock count
+func NewStockCount(tenantID uuid.UUID, name, notes string, startedBy uuid.UUID, now time.Time) (*StockCount, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if startedBy == uuid.Nil {
+		return nil, errors.NewValidationError("user ID is required", "user ID cannot be empty")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, errors.NewValidationError("name too long", "name cannot exceed 100 characters")
+	}
+
+	return &StockCount{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		Status:    StockCountStatusOpen,
+		Notes:     strings.TrimSpace(notes),
+		Lines:     []StockCountLine{},
+		StartedBy: startedBy,
+		StartedAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ScopeTo limits the count to the products held at a location and to a category and its
+// subcategories. Either may be nil.
+func (c *StockCount) ScopeTo(location *Location, category *Category) error {
+	if !c.IsOpen() {
+		return errors.NewValidationError("stock count not open", "only open stock counts can be scoped")
+	}
+	if len(c.Lines) > 0 {
+		return errors.NewValidationError("stock count started", "a stock count cannot be scoped once products are counted")
+	}
+
+	c.LocationID, c.CategoryID, c.Category = nil, nil, ""
+	if location != nil {
+		if location.TenantID != c.TenantID {
+			return errors.NewValidationError("unknown location", "location does not exist")
+		}
+		if !location.IsActive {
+			return errors.NewValidationError("location not active", location.Name+" is not active")
+		}
+		id := location.ID
+		c.LocationID = &id
+	}
+	if category != nil {
+		if category.TenantID != c.TenantID {
+			return errors.NewValidationError("unknown category", "category does not exist")
+		}
+		id := category.ID
+		c.CategoryID = &id
+		c.Category = category.Name
+	}
+
+	return nil
+}
+
+// Covers checks if the count covers a product. categoryIDs holds the count's category and
+// its subcategories; it is ignored when the count is not limited to a category.
+func (c *StockCount) Covers(product *Product, categoryIDs map[uuid.UUID]bool) bool {
+	if c.CategoryID == nil {
+		return true
+	}
+	return product.CategoryID != nil && categoryIDs[*product.CategoryID]
+}
+
+// IsOpen checks if the count is still being counted
+func (c *StockCount) IsOpen() bool {
+	return c.Status == StockCountStatusOpen
+}
+
+// RecordScan records a product counted by a scanner, with the stock on record to compare
+// it with. Scans are deduplicated on the scanner's timestamp: a rescan replaces the count
+// when scanned after the recorded one, the same scan submitted again is a duplicate, and a
+// scan from before the recorded count arrived late and is ignored. It returns the product's
+// line, and whether it changed.
+func (c *StockCount) RecordScan(product *Product, expectedQty int, scan StockCountScan, userID uuid.UUID, now time.Time) (*StockCountLine, StockCountScanStatus, error) {
+	if !c.IsOpen() {
+		return nil, "", errors.NewValidationError("stock count not open", "scans can only be recorded on open stock counts")
+	}
+	if product == nil {
+		return nil, StockCountScanStatusUnknownBarcode, errors.NewValidationError("unknown barcode", "no product has the barcode")
+	}
+	if scan.Quantity < 0 {
+		return nil, StockCountScanStatusInvalid, errors.NewValidationError("invalid quantity", "counted quantity cannot be negative")
+	}
+	if scan.ScannedAt.IsZero() {
+		return nil, StockCountScanStatusInvalid, errors.NewValidationError("scan time is required", "scanned_at cannot be empty")
+	}
+
+	for i := range c.Lines {
+		line := &c.Lines[i]
+		if line.ProductID != product.ID {
+			continue
+		}
+
+		switch {
+		case scan.ScannedAt.Equal(line.CountedAt) && scan.Quantity == line.CountedQty:
+			return line, StockCountScanStatusDuplicate, nil
+		case !scan.ScannedAt.After(line.CountedAt):
+			return line, StockCountScanStatusStale, nil
+		}
+
+		line.Barcode = strings.TrimSpace(scan.Barcode)
+		line.CountedQty = scan.Quantity
+		line.ExpectedQty = expectedQty
+		line.Variance = scan.Quantity - expectedQty
+		line.CountedAt = scan.ScannedAt
+		line.CountedBy = userID
+		line.ScanCount++
+		line.UpdatedAt = now
+		c.UpdatedAt = now
+		return line, StockCountScanStatusUpdated, nil
+	}
+
+	c.Lines = append(c.Lines, StockCountLine{
+		ID:          uuid.New(),
+		CountID:     c.ID,
+		ProductID:   product.ID,
+		ProductSKU:  product.SKU,
+		ProductName: product.Name,
+		Barcode:     strings.TrimSpace(scan.Barcode),
+		CountedQty:  scan.Quantity,
+		ExpectedQty: expectedQty,
+		Variance:    scan.Quantity - expectedQty,
+		CountedAt:   scan.ScannedAt,
+		CountedBy:   userID,
+		ScanCount:   1,
+		UpdatedAt:   now,
+	})
+	c.UpdatedAt = now
+	return &c.Lines[len(c.Lines)-1], StockCountScanStatusRecorded, nil
+}
+
+// Variances summarizes the lines whose counted quantity differs from the stock on record
+func (c *StockCount) Variances() *StockCountVariances {
+	variances := &StockCountVariances{
+		CountID:      c.ID,
+		CountedLines: len(c.Lines),
+		Lines:        []StockCountLine{},
+	}
+	for _, line := range c.Lines {
+		if line.Variance == 0 {
+			continue
+		}
+		variances.VarianceLines++
+		variances.NetVariance += line.Variance
+		if line.Variance > 0 {
+			variances.UnitsOver += line.Variance
+		} else {
+			variances.UnitsShort -= line.Variance
+		}
+		variances.Lines = append(variances.Lines, line)
+	}
+	return variances
+}
+
+// Complete closes the count, after which its counted quantities are posted to stock
+func (c *StockCount) Complete(userID uuid.UUID, now time.Time) error {
+	if !c.IsOpen() {
+		return errors.NewValidationError("stock count not open", "only open stock counts can be completed")
+	}
+	if len(c.Lines) == 0 {
+		return errors.NewValidationError("stock count empty", "a stock count needs at least one counted product to be completed")
+	}
+
+	c.Status = StockCountStatusCompleted
+	c.CompletedBy = &userID
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// Cancel abandons the count without changing stock
+func (c *StockCount) Cancel(userID uuid.UUID, now time.Time) error {
+	if !c.IsOpen() {
+		return errors.NewValidationError("stock count not open", "only open stock counts can be cancelled")
+	}
+
+	c.Status = StockCountStatusCancelled
+	c.CompletedBy = &userID
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// NewStockCountScanResult reports the outcome of a scan with the product's line as it stands
+func NewStockCountScanResult(scan StockCountScan, status StockCountScanStatus, line *StockCountLine, err error) StockCountScanResult {
+	result := StockCountScanResult{
+		Barcode: scan.Barcode,
+		Status:  status,
+	}
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok {
+			result.Message = appErr.Details
+		} else {
+			result.Message = err.Error()
+		}
+	}
+	if line != nil {
+		productID := line.ProductID
+		counted, expected, variance := line.CountedQty, line.ExpectedQty, line.Variance
+		result.ProductID = &productID
+		result.ProductSKU = line.ProductSKU
+		result.ProductName = line.ProductName
+		result.CountedQty = &counted
+		result.ExpectedQty = &expected
+		result.Variance = &variance
+	}
+	return result
+}
+
+// ValidateStockCountStatus validates stock count status
+func ValidateStockCountStatus(status StockCountStatus) error {
+	switch status {
+	case StockCountStatusOpen, StockCountStatusCompleted, StockCountStatusCancelled:
+		return nil
+	default:
+		return errors.NewValidationError("invalid stock count status", "status must be one of: open, completed, cancelled")
+	}
+}
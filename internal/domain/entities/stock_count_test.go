@@ -0,0 +1,229 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStockCount(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+
+	t.Run("valid stock count", func(t *testing.T) {
+		tenantID, userID := uuid.New(), uuid.New()
+
+		count, err := NewStockCount(tenantID, "  Aisle 3 ", "before opening", userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, count.TenantID)
+		assert.Equal(t, "Aisle 3", count.Name)
+		assert.Equal(t, StockCountStatusOpen, count.Status)
+		assert.Empty(t, count.Lines)
+		assert.Equal(t, userID, count.StartedBy)
+		assert.Equal(t, now, count.StartedAt)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := NewStockCount(uuid.New(), " ", "", uuid.New(), now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "name is required")
+	})
+}
+
+func TestStockCount_RecordScan(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	product := &Product{ID: uuid.New(), SKU: "SKU-1", Name: "Widget"}
+	userID := uuid.New()
+
+	newCount := func(t *testing.T) *StockCount {
+		count, err := NewStockCount(uuid.New(), "Aisle 3", "", userID, now)
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("first scan records the product's count", func(t *testing.T) {
+		count := newCount(t)
+
+		line, status, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: 8, ScannedAt: now}, userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockCountScanStatusRecorded, status)
+		assert.Equal(t, 8, line.CountedQty)
+		assert.Equal(t, 10, line.ExpectedQty)
+		assert.Equal(t, -2, line.Variance)
+		assert.Equal(t, 1, line.ScanCount)
+		assert.Len(t, count.Lines, 1)
+	})
+
+	t.Run("later rescan replaces the count", func(t *testing.T) {
+		count := newCount(t)
+		_, _, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: 8, ScannedAt: now}, userID, now)
+		require.NoError(t, err)
+
+		line, status, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: 10, ScannedAt: now.Add(time.Minute)}, userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockCountScanStatusUpdated, status)
+		assert.Equal(t, 10, line.CountedQty)
+		assert.Zero(t, line.Variance)
+		assert.Equal(t, 2, line.ScanCount)
+		assert.Len(t, count.Lines, 1)
+	})
+
+	t.Run("resubmitted scan is a duplicate", func(t *testing.T) {
+		count := newCount(t)
+		scan := StockCountScan{Barcode: "123", Quantity: 8, ScannedAt: now}
+		_, _, err := count.RecordScan(product, 10, scan, userID, now)
+		require.NoError(t, err)
+
+		line, status, err := count.RecordScan(product, 10, scan, userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockCountScanStatusDuplicate, status)
+		assert.Equal(t, 1, line.ScanCount)
+	})
+
+	t.Run("earlier scan arriving late is stale", func(t *testing.T) {
+		count := newCount(t)
+		_, _, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: 10, ScannedAt: now}, userID, now)
+		require.NoError(t, err)
+
+		line, status, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: 7, ScannedAt: now.Add(-time.Minute)}, userID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockCountScanStatusStale, status)
+		assert.Equal(t, 10, line.CountedQty)
+	})
+
+	t.Run("negative quantity", func(t *testing.T) {
+		count := newCount(t)
+
+		line, status, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: -1, ScannedAt: now}, userID, now)
+
+		assert.Error(t, err)
+		assert.Nil(t, line)
+		assert.Equal(t, StockCountScanStatusInvalid, status)
+		assert.Empty(t, count.Lines)
+	})
+
+	t.Run("closed count", func(t *testing.T) {
+		count := newCount(t)
+		require.NoError(t, count.Cancel(userID, now))
+
+		_, _, err := count.RecordScan(product, 10, StockCountScan{Barcode: "123", Quantity: 1, ScannedAt: now}, userID, now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "stock count not open")
+	})
+}
+
+func TestStockCount_Complete(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	count, err := NewStockCount(uuid.New(), "Aisle 3", "", userID, now)
+	require.NoError(t, err)
+
+	err = count.Complete(userID, now)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "stock count empty")
+
+	_, _, err = count.RecordScan(&Product{ID: uuid.New()}, 0, StockCountScan{Barcode: "123", Quantity: 1, ScannedAt: now}, userID, now)
+	require.NoError(t, err)
+
+	require.NoError(t, count.Complete(userID, now.Add(time.Hour)))
+	assert.Equal(t, StockCountStatusCompleted, count.Status)
+	require.NotNil(t, count.CompletedAt)
+	assert.Error(t, count.Cancel(userID, now.Add(time.Hour)))
+}
+
+func TestStockCount_ScopeTo(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tenantID, userID := uuid.New(), uuid.New()
+	location, err := NewLocation(tenantID, "WH", "Warehouse", "")
+	require.NoError(t, err)
+	drinks, err := NewCategory(tenantID, "Drinks", "", 0, uuid.New())
+	require.NoError(t, err)
+
+	t.Run("location and category", func(t *testing.T) {
+		count, err := NewStockCount(tenantID, "Drinks in the warehouse", "", userID, now)
+		require.NoError(t, err)
+
+		require.NoError(t, count.ScopeTo(location, drinks))
+		assert.Equal(t, location.ID, *count.LocationID)
+		assert.Equal(t, drinks.ID, *count.CategoryID)
+		assert.Equal(t, "Drinks", count.Category)
+
+		soft := uuid.New()
+		categoryIDs := map[uuid.UUID]bool{drinks.ID: true, soft: true}
+		assert.True(t, count.Covers(&Product{CategoryID: &soft}, categoryIDs))
+		assert.False(t, count.Covers(&Product{}, categoryIDs))
+	})
+
+	t.Run("whole store covers every product", func(t *testing.T) {
+		count, err := NewStockCount(tenantID, "Year end", "", userID, now)
+		require.NoError(t, err)
+
+		require.NoError(t, count.ScopeTo(nil, nil))
+		assert.True(t, count.Covers(&Product{}, nil))
+	})
+
+	t.Run("another tenant's location", func(t *testing.T) {
+		count, err := NewStockCount(uuid.New(), "Aisle 3", "", userID, now)
+		require.NoError(t, err)
+
+		assert.Error(t, count.ScopeTo(location, nil))
+	})
+
+	t.Run("after counting started", func(t *testing.T) {
+		count, err := NewStockCount(tenantID, "Aisle 3", "", userID, now)
+		require.NoError(t, err)
+		_, _, err = count.RecordScan(&Product{ID: uuid.New()}, 0, StockCountScan{Barcode: "123", Quantity: 1, ScannedAt: now}, userID, now)
+		require.NoError(t, err)
+
+		assert.Error(t, count.ScopeTo(location, nil))
+	})
+}
+
+func TestStockCount_Variances(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	count, err := NewStockCount(uuid.New(), "Aisle 3", "", userID, now)
+	require.NoError(t, err)
+
+	record := func(counted, expected int) {
+		_, _, err := count.RecordScan(&Product{ID: uuid.New()}, expected, StockCountScan{Barcode: "123", Quantity: counted, ScannedAt: now}, userID, now)
+		require.NoError(t, err)
+	}
+	record(12, 10)
+	record(5, 5)
+	record(1, 4)
+
+	variances := count.Variances()
+
+	assert.Equal(t, 3, variances.CountedLines)
+	assert.Equal(t, 2, variances.VarianceLines)
+	assert.Equal(t, 2, variances.UnitsOver)
+	assert.Equal(t, 3, variances.UnitsShort)
+	assert.Equal(t, -1, variances.NetVariance)
+	assert.Len(t, variances.Lines, 2)
+}
+
+func TestNewStockCountScanResult(t *testing.T) {
+	scan := StockCountScan{Barcode: "123", Quantity: 8}
+	line := &StockCountLine{ProductID: uuid.New(), ProductSKU: "SKU-1", CountedQty: 8, ExpectedQty: 10, Variance: -2}
+
+	result := NewStockCountScanResult(scan, StockCountScanStatusRecorded, line, nil)
+
+	assert.Equal(t, "123", result.Barcode)
+	require.NotNil(t, result.Variance)
+	assert.Equal(t, -2, *result.Variance)
+	assert.Empty(t, result.Message)
+
+	unknown := NewStockCountScanResult(scan, StockCountScanStatusUnknownBarcode, nil, nil)
+	assert.Nil(t, unknown.ProductID)
+	assert.Nil(t, unknown.Variance)
+}
@@ -0,0 +1,242 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// StockReservationStatus represents the lifecycle status of a stock reservation
+type StockReservationStatus string
+
+const (
+	StockReservationStatusActive             StockReservationStatus = "active"
+	StockReservationStatusPartiallyConfirmed StockReservationStatus = "partially_confirmed"
+	StockReservationStatusConfirmed          StockReservationStatus = "confirmed"
+	StockReservationStatusReleased           StockReservationStatus = "released"
+	StockReservationStatusExpired            StockReservationStatus = "expired"
+)
+
+const (
+	// DefaultStockReservationTTL is how long stock is held when the caller does not specify an expiry
+	DefaultStockReservationTTL = 15 * time.Minute
+	// MaxStockReservationTTL is the longest stock may be held by a single reservation
+	MaxStockReservationTTL = 7 * 24 * time.Hour
+)
+
+// StockReservation represents stock held for an external order (e.g. an online checkout)
+type StockReservation struct {
+	ID        uuid.UUID              `json:"id"`
+	TenantID  uuid.UUID              `json:"tenant_id"`
+	Reference string                 `json:"reference"` // External order reference
+	Source    string                 `json:"source"`    // Order source, e.g. the e-commerce connector name
+	Status    StockReservationStatus `json:"status"`
+	Items     []StockReservationItem `json:"items"`
+	Notes     string                 `json:"notes,omitempty"`
+	ExpiresAt time.Time              `json:"expires_at"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	CreatedBy uuid.UUID              `json:"created_by"`
+}
+
+// StockReservationItem represents a product line held by a stock reservation
+type StockReservationItem struct {
+	ID            uuid.UUID `json:"id"`
+	ReservationID uuid.UUID `json:"reservation_id"`
+	ProductID     uuid.UUID `json:"product_id"`
+	Quantity      int       `json:"quantity"`
+	ConfirmedQty  int       `json:"confirmed_qty"`
+	ReleasedQty   int       `json:"released_qty"`
+}
+
+// NewStockReservation creates a new stock reservation
+func NewStockReservation(tenantID uuid.UUID, reference, source string, ttl time.Duration, createdBy uuid.UUID) (*StockReservation, error) {
+	if strings.TrimSpace(reference) == "" {
+		return nil, errors.NewValidationError("reference is required", "reference cannot be empty")
+	}
+	if len(reference) > 100 {
+		return nil, errors.NewValidationError("reference too long", "reference cannot exceed 100 characters")
+	}
+	if ttl == 0 {
+		ttl = DefaultStockReservationTTL
+	}
+	if ttl < 0 || ttl > MaxStockReservationTTL {
+		return nil, errors.NewValidationError("invalid reservation expiry", "expiry must be between 1 second and 7 days")
+	}
+
+	now := time.Now()
+	reservation := &StockReservation{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Reference: strings.TrimSpace(reference),
+		Source:    strings.TrimSpace(source),
+		Status:    StockReservationStatusActive,
+		Items:     []StockReservationItem{},
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+	}
+
+	return reservation, nil
+}
+
+// AddItem adds a product line to the reservation
+func (r *StockReservation) AddItem(productID uuid.UUID, quantity int) error {
+	if r.Status != StockReservationStatusActive {
+		return errors.NewValidationError("invalid reservation status", "items can only be added to active reservations")
+	}
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+
+	for i := range r.Items {
+		if r.Items[i].ProductID == productID {
+			r.Items[i].Quantity += quantity
+			r.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	r.Items = append(r.Items, StockReservationItem{
+		ID:            uuid.New(),
+		ReservationID: r.ID,
+		ProductID:     productID,
+		Quantity:      quantity,
+	})
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Confirm converts part or all of a product's outstanding reservation into a sale
+func (r *StockReservation) Confirm(productID uuid.UUID, quantity int) error {
+	if !r.IsOpen() {
+		return errors.NewValidationError("invalid reservation status", "only active reservations can be confirmed")
+	}
+	if r.IsExpired(time.Now()) {
+		return errors.NewValidationError("reservation expired", "reservation expired at "+r.ExpiresAt.Format(time.RFC3339))
+	}
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+
+	item := r.findItem(productID)
+	if item == nil {
+		return errors.NewNotFoundError("reservation item")
+	}
+	if quantity > item.OutstandingQty() {
+		return errors.NewValidationError("insufficient reserved stock", "cannot confirm more than the outstanding reserved quantity")
+	}
+
+	item.ConfirmedQty += quantity
+	r.refreshStatus()
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Release returns all outstanding reserved quantities to available stock
+func (r *StockReservation) Release() error {
+	if !r.IsOpen() {
+		return errors.NewValidationError("invalid reservation status", "only active reservations can be released")
+	}
+
+	r.settleOutstanding()
+	r.Status = StockReservationStatusReleased
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Expire marks an open reservation as expired and releases its outstanding quantities
+func (r *StockReservation) Expire() error {
+	if !r.IsOpen() {
+		return errors.NewValidationError("invalid reservation status", "only active reservations can expire")
+	}
+
+	r.settleOutstanding()
+	r.Status = StockReservationStatusExpired
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// ExtendExpiry pushes the reservation expiry out from now
+func (r *StockReservation) ExtendExpiry(ttl time.Duration) error {
+	if !r.IsOpen() {
+		return errors.NewValidationError("invalid reservation status", "only active reservations can be extended")
+	}
+	if ttl <= 0 || ttl > MaxStockReservationTTL {
+		return errors.NewValidationError("invalid reservation expiry", "expiry must be between 1 second and 7 days")
+	}
+
+	r.ExpiresAt = time.Now().Add(ttl)
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// OutstandingItems returns the items that still hold reserved stock, with Quantity set to the outstanding amount
+func (r *StockReservation) OutstandingItems() []StockReservationItem {
+	var items []StockReservationItem
+	for _, item := range r.Items {
+		if outstanding := item.OutstandingQty(); outstanding > 0 {
+			item.Quantity = outstanding
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// IsOpen checks if the reservation still holds stock
+func (r *StockReservation) IsOpen() bool {
+	return r.Status == StockReservationStatusActive || r.Status == StockReservationStatusPartiallyConfirmed
+}
+
+// IsExpired checks if the reservation has passed its expiry time
+func (r *StockReservation) IsExpired(now time.Time) bool {
+	return now.After(r.ExpiresAt)
+}
+
+// OutstandingQty returns the quantity that is still reserved for the item
+func (i *StockReservationItem) OutstandingQty() int {
+	return i.Quantity - i.ConfirmedQty - i.ReleasedQty
+}
+
+// ValidateStockReservationStatus validates stock reservation status
+func ValidateStockReservationStatus(status StockReservationStatus) error {
+	switch status {
+	case StockReservationStatusActive, StockReservationStatusPartiallyConfirmed, StockReservationStatusConfirmed,
+		StockReservationStatusReleased, StockReservationStatusExpired:
+		return nil
+	default:
+		return errors.NewValidationError("invalid reservation status", "status must be one of: active, partially_confirmed, confirmed, released, expired")
+	}
+}
+
+// findItem finds the reservation item for a product
+func (r *StockReservation) findItem(productID uuid.UUID) *StockReservationItem {
+	for i := range r.Items {
+		if r.Items[i].ProductID == productID {
+			return &r.Items[i]
+		}
+	}
+	return nil
+}
+
+// settleOutstanding marks all outstanding quantities as released
+func (r *StockReservation) settleOutstanding() {
+	for i := range r.Items {
+		r.Items[i].ReleasedQty += r.Items[i].OutstandingQty()
+	}
+}
+
+// refreshStatus updates the status after a confirmation
+func (r *StockReservation) refreshStatus() {
+	for _, item := range r.Items {
+		if item.OutstandingQty() > 0 {
+			r.Status = StockReservationStatusPartiallyConfirmed
+			return
+		}
+	}
+	r.Status = StockReservationStatusConfirmed
+}
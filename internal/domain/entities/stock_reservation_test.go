@@ -0,0 +1,235 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStockReservation(t *testing.T) {
+	t.Run("valid reservation creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		reservation, err := NewStockReservation(tenantID, "WEB-1001", "shopify", 30*time.Minute, createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, reservation.ID)
+		assert.Equal(t, tenantID, reservation.TenantID)
+		assert.Equal(t, "WEB-1001", reservation.Reference)
+		assert.Equal(t, "shopify", reservation.Source)
+		assert.Equal(t, StockReservationStatusActive, reservation.Status)
+		assert.Empty(t, reservation.Items)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), reservation.ExpiresAt, time.Second)
+		assert.Equal(t, createdBy, reservation.CreatedBy)
+	})
+
+	t.Run("default expiry", func(t *testing.T) {
+		reservation, err := NewStockReservation(uuid.New(), "WEB-1001", "", 0, uuid.New())
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultStockReservationTTL), reservation.ExpiresAt, time.Second)
+	})
+
+	t.Run("invalid reference - empty", func(t *testing.T) {
+		reservation, err := NewStockReservation(uuid.New(), " ", "", 0, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, reservation)
+		assert.Contains(t, err.Error(), "reference is required")
+	})
+
+	t.Run("invalid expiry - too long", func(t *testing.T) {
+		reservation, err := NewStockReservation(uuid.New(), "WEB-1001", "", MaxStockReservationTTL+time.Hour, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, reservation)
+		assert.Contains(t, err.Error(), "invalid reservation expiry")
+	})
+}
+
+func TestStockReservation_AddItem(t *testing.T) {
+	reservation := createValidStockReservation(t)
+	productID := uuid.New()
+
+	t.Run("add new item", func(t *testing.T) {
+		err := reservation.AddItem(productID, 2)
+
+		require.NoError(t, err)
+		require.Len(t, reservation.Items, 1)
+		assert.Equal(t, productID, reservation.Items[0].ProductID)
+		assert.Equal(t, reservation.ID, reservation.Items[0].ReservationID)
+		assert.Equal(t, 2, reservation.Items[0].Quantity)
+	})
+
+	t.Run("add same product merges quantity", func(t *testing.T) {
+		err := reservation.AddItem(productID, 3)
+
+		require.NoError(t, err)
+		require.Len(t, reservation.Items, 1)
+		assert.Equal(t, 5, reservation.Items[0].Quantity)
+	})
+
+	t.Run("invalid quantity", func(t *testing.T) {
+		err := reservation.AddItem(uuid.New(), 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestStockReservation_Confirm(t *testing.T) {
+	t.Run("partial confirmation", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		productID := uuid.New()
+		require.NoError(t, reservation.AddItem(productID, 5))
+
+		err := reservation.Confirm(productID, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockReservationStatusPartiallyConfirmed, reservation.Status)
+		assert.Equal(t, 2, reservation.Items[0].ConfirmedQty)
+		assert.Equal(t, 3, reservation.Items[0].OutstandingQty())
+	})
+
+	t.Run("full confirmation", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		productID := uuid.New()
+		require.NoError(t, reservation.AddItem(productID, 5))
+		require.NoError(t, reservation.Confirm(productID, 2))
+
+		err := reservation.Confirm(productID, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockReservationStatusConfirmed, reservation.Status)
+		assert.Empty(t, reservation.OutstandingItems())
+	})
+
+	t.Run("cannot confirm more than outstanding", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		productID := uuid.New()
+		require.NoError(t, reservation.AddItem(productID, 2))
+
+		err := reservation.Confirm(productID, 3)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient reserved stock")
+	})
+
+	t.Run("cannot confirm unknown product", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		require.NoError(t, reservation.AddItem(uuid.New(), 2))
+
+		err := reservation.Confirm(uuid.New(), 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("cannot confirm expired reservation", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		productID := uuid.New()
+		require.NoError(t, reservation.AddItem(productID, 2))
+		reservation.ExpiresAt = time.Now().Add(-time.Minute)
+
+		err := reservation.Confirm(productID, 1)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "reservation expired")
+	})
+}
+
+func TestStockReservation_Release(t *testing.T) {
+	t.Run("release after partial confirmation", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		productID := uuid.New()
+		otherProductID := uuid.New()
+		require.NoError(t, reservation.AddItem(productID, 5))
+		require.NoError(t, reservation.AddItem(otherProductID, 1))
+		require.NoError(t, reservation.Confirm(productID, 2))
+
+		outstanding := reservation.OutstandingItems()
+		require.Len(t, outstanding, 2)
+		assert.Equal(t, 3, outstanding[0].Quantity)
+		assert.Equal(t, 1, outstanding[1].Quantity)
+
+		err := reservation.Release()
+
+		require.NoError(t, err)
+		assert.Equal(t, StockReservationStatusReleased, reservation.Status)
+		assert.Equal(t, 3, reservation.Items[0].ReleasedQty)
+		assert.Equal(t, 1, reservation.Items[1].ReleasedQty)
+		assert.Empty(t, reservation.OutstandingItems())
+	})
+
+	t.Run("cannot release closed reservation", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		require.NoError(t, reservation.Release())
+
+		err := reservation.Release()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("expire releases outstanding", func(t *testing.T) {
+		reservation := createValidStockReservation(t)
+		require.NoError(t, reservation.AddItem(uuid.New(), 4))
+
+		err := reservation.Expire()
+
+		require.NoError(t, err)
+		assert.Equal(t, StockReservationStatusExpired, reservation.Status)
+		assert.Equal(t, 4, reservation.Items[0].ReleasedQty)
+		assert.False(t, reservation.IsOpen())
+	})
+}
+
+func TestStockReservation_ExtendExpiry(t *testing.T) {
+	reservation := createValidStockReservation(t)
+
+	err := reservation.ExtendExpiry(time.Hour)
+
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), reservation.ExpiresAt, time.Second)
+
+	assert.Error(t, reservation.ExtendExpiry(0))
+}
+
+func TestValidateStockReservationStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		status        StockReservationStatus
+		expectedError bool
+	}{
+		{"valid active status", StockReservationStatusActive, false},
+		{"valid partially confirmed status", StockReservationStatusPartiallyConfirmed, false},
+		{"valid confirmed status", StockReservationStatusConfirmed, false},
+		{"valid released status", StockReservationStatusReleased, false},
+		{"valid expired status", StockReservationStatusExpired, false},
+		{"invalid status", "invalid", true},
+		{"empty status", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateStockReservationStatus(tc.status)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid reservation status")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func createValidStockReservation(t *testing.T) *StockReservation {
+	reservation, err := NewStockReservation(uuid.New(), "WEB-1001", "shopify", time.Hour, uuid.New())
+
+	require.NoError(t, err)
+	require.NotNil(t, reservation)
+
+	return reservation
+}
@@ -614,6 +614,52 @@ func TestValidateStockMovementReason(t *testing.T) {
 	}
 }
 
+func TestStockMovement_NewReversal(t *testing.T) {
+	t.Run("reverses an adjustment", func(t *testing.T) {
+		original, err := NewStockMovement(uuid.New(), StockMovementTypeIn, ReasonAdjustment, 12, "ADJ-7", "Received count", uuid.New())
+		require.NoError(t, err)
+
+		userID := uuid.New()
+		reversal, err := original.NewReversal("  Entered 12 instead of 2  ", userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, StockMovementTypeOut, reversal.Type)
+		assert.Equal(t, ReasonAdjustment, reversal.Reason)
+		assert.Equal(t, 12, reversal.Quantity)
+		assert.Equal(t, "ADJ-7", reversal.Reference)
+		assert.Equal(t, "Entered 12 instead of 2", reversal.Notes)
+		assert.Equal(t, userID, reversal.CreatedBy)
+		require.NotNil(t, reversal.ReversalOf)
+		assert.Equal(t, original.ID, *reversal.ReversalOf)
+
+		// A reversal cannot itself be reversed
+		_, err = reversal.NewReversal("Undo the undo", userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("notes required", func(t *testing.T) {
+		original, err := NewStockMovement(uuid.New(), StockMovementTypeOut, ReasonDamage, 3, "", "", uuid.New())
+		require.NoError(t, err)
+
+		_, err = original.NewReversal(" ", uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("movements of other operations", func(t *testing.T) {
+		for _, reason := range []StockMovementReason{ReasonSale, ReasonReturn, ReasonTransfer} {
+			original, err := NewStockMovement(uuid.New(), StockMovementTypeOut, reason, 1, "", "", uuid.New())
+			require.NoError(t, err)
+
+			assert.False(t, original.IsReversible(), reason)
+		}
+
+		reserved, err := NewStockMovement(uuid.New(), StockMovementTypeReserved, ReasonAdjustment, 1, "", "", uuid.New())
+		require.NoError(t, err)
+		assert.False(t, reserved.IsReversible())
+	})
+}
+
 // Helper function to create a valid stock for testing
 func createValidStock(t *testing.T) *Stock {
 	productID := uuid.New()
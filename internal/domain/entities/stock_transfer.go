@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// StockTransferStatus represents the status of a stock transfer
+type StockTransferStatus string
+
+const (
+	StockTransferStatusRequested StockTransferStatus = "requested"  // Waiting to be shipped
+	StockTransferStatusInTransit StockTransferStatus = "in_transit" // Shipped, left the source location
+	StockTransferStatusReceived  StockTransferStatus = "received"   // Arrived at the destination location
+	StockTransferStatusCancelled StockTransferStatus = "cancelled"
+)
+
+// StockTransfer represents moving stock from one location to another. Shipping takes the
+// quantities out of the source location and receiving puts them into the destination, each
+// recorded as a stock movement at its location.
+type StockTransfer struct {
+	ID             uuid.UUID           `json:"id"`
+	TenantID       uuid.UUID           `json:"tenant_id"`
+	TransferNumber string              `json:"transfer_number"`
+	FromLocationID uuid.UUID           `json:"from_location_id"`
+	ToLocationID   uuid.UUID           `json:"to_location_id"`
+	Status         StockTransferStatus `json:"status"`
+	Items          []StockTransferItem `json:"items"`
+	Notes          string              `json:"notes,omitempty"`
+	RequestedBy    uuid.UUID           `json:"requested_by"`
+	ShippedBy      *uuid.UUID          `json:"shipped_by,omitempty"`
+	ShippedAt      *time.Time          `json:"shipped_at,omitempty"`
+	ReceivedBy     *uuid.UUID          `json:"received_by,omitempty"`
+	ReceivedAt     *time.Time          `json:"received_at,omitempty"`
+	CancelledAt    *time.Time          `json:"cancelled_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// StockTransferItem represents a product line in a stock transfer
+type StockTransferItem struct {
+	ID          uuid.UUID `json:"id"`
+	TransferID  uuid.UUID `json:"transfer_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductSKU  string    `json:"product_sku"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+}
+
+// NewStockTransfer creates a new requested stock transfer between two locations
+func NewStockTransfer(tenantID uuid.UUID, transferNumber string, from, to *Location, notes string, requestedBy uuid.UUID, now time.Time) (*StockTransfer, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if transferNumber == "" {
+		return nil, errors.NewValidationError("transfer number is required", "transfer number cannot be empty")
+	}
+	if from == nil || to == nil {
+		return nil, errors.NewValidationError("locations are required", "a transfer needs a source and a destination location")
+	}
+	if from.ID == to.ID {
+		return nil, errors.NewValidationError("invalid locations", "a transfer must move stock to another location")
+	}
+	if !from.IsActive || !to.IsActive {
+		return nil, errors.NewValidationError("location not active", "transfers can only be made between active locations")
+	}
+
+	return &StockTransfer{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		TransferNumber: transferNumber,
+		FromLocationID: from.ID,
+		ToLocationID:   to.ID,
+		Status:         StockTransferStatusRequested,
+		Items:          []StockTransferItem{},
+		Notes:          strings.TrimSpace(notes),
+		RequestedBy:    requestedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// AddItem adds a product to a requested transfer. Adding a product already on the transfer
+// increases its quantity.
+func (t *StockTransfer) AddItem(product *Product, quantity int) error {
+	if t.Status != StockTransferStatusRequested {
+		return errors.NewValidationError("invalid transfer status", "only requested transfers can be modified")
+	}
+	if product == nil {
+		return errors.NewValidationError("product is required", "product cannot be nil")
+	}
+	if quantity <= 0 {
+		return errors.NewInvalidQuantityError(quantity)
+	}
+
+	for i := range t.Items {
+		if t.Items[i].ProductID == product.ID {
+			t.Items[i].Quantity += quantity
+			t.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	t.Items = append(t.Items, StockTransferItem{
+		ID:          uuid.New(),
+		TransferID:  t.ID,
+		ProductID:   product.ID,
+		ProductSKU:  product.SKU,
+		ProductName: product.Name,
+		Quantity:    quantity,
+	})
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Ship marks a requested transfer as having left the source location
+func (t *StockTransfer) Ship(userID uuid.UUID, now time.Time) error {
+	if t.Status != StockTransferStatusRequested {
+		return errors.NewValidationError("invalid transfer status", "only requested transfers can be shipped")
+	}
+	if len(t.Items) == 0 {
+		return errors.NewValidationError("transfer has no items", "add at least one item before shipping the transfer")
+	}
+
+	t.Status = StockTransferStatusInTransit
+	t.ShippedBy = &userID
+	t.ShippedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// Receive marks an in-transit transfer as arrived at the destination location
+func (t *StockTransfer) Receive(userID uuid.UUID, now time.Time) error {
+	if t.Status != StockTransferStatusInTransit {
+		return errors.NewValidationError("invalid transfer status", "only in-transit transfers can be received")
+	}
+
+	t.Status = StockTransferStatusReceived
+	t.ReceivedBy = &userID
+	t.ReceivedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// Cancel cancels a transfer that has not been shipped yet
+func (t *StockTransfer) Cancel(now time.Time) error {
+	if t.Status != StockTransferStatusRequested {
+		return errors.NewValidationError("invalid transfer status", "only transfers that have not been shipped can be cancelled")
+	}
+
+	t.Status = StockTransferStatusCancelled
+	t.CancelledAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// StockMovement returns the movement recording an item of the transfer leaving the source
+// location when shipped, or entering the destination location when received
+func (t *StockTransfer) StockMovement(item StockTransferItem, userID uuid.UUID) (*StockMovement, error) {
+	movementType, locationID, notes := StockMovementTypeOut, t.FromLocationID, "Shipped on transfer "+t.TransferNumber
+	if t.Status == StockTransferStatusReceived {
+		movementType, locationID, notes = StockMovementTypeIn, t.ToLocationID, "Received on transfer "+t.TransferNumber
+	}
+
+	movement, err := NewStockMovement(item.ProductID, movementType, ReasonTransfer, item.Quantity, t.TransferNumber, notes, userID)
+	if err != nil {
+		return nil, err
+	}
+	movement.LocationID = &locationID
+	return movement, nil
+}
+
+// ValidateStockTransferStatus validates stock transfer status
+func ValidateStockTransferStatus(status StockTransferStatus) error {
+	switch status {
+	case StockTransferStatusRequested, StockTransferStatusInTransit, StockTransferStatusReceived, StockTransferStatusCancelled:
+		return nil
+	default:
+		return errors.NewValidationError("invalid stock transfer status", "status must be one of: requested, in_transit, received, cancelled")
+	}
+}
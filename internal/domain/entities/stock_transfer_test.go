@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRequestedTransfer returns a transfer of five desks from a warehouse to a store
+func newRequestedTransfer(t *testing.T, now time.Time) (*StockTransfer, *Product) {
+	tenantID := uuid.New()
+	from, err := NewLocation(tenantID, "WH", "Warehouse", "")
+	require.NoError(t, err)
+	to, err := NewLocation(tenantID, "STORE", "Store", "")
+	require.NoError(t, err)
+
+	transfer, err := NewStockTransfer(tenantID, "TR-001", from, to, " Weekend restock ", uuid.New(), now)
+	require.NoError(t, err)
+
+	product, err := NewProduct(tenantID, "DESK-01", "Desk", "", "Furniture", "pcs", decimal.NewFromInt(500), decimal.NewFromInt(300), 0, uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, transfer.AddItem(product, 5))
+	return transfer, product
+}
+
+func TestNewStockTransfer(t *testing.T) {
+	transfer, _ := newRequestedTransfer(t, time.Now())
+
+	assert.Equal(t, StockTransferStatusRequested, transfer.Status)
+	assert.Equal(t, "Weekend restock", transfer.Notes)
+
+	tenantID := uuid.New()
+	from, err := NewLocation(tenantID, "WH", "Warehouse", "")
+	require.NoError(t, err)
+	inactive, err := NewLocation(tenantID, "OLD", "Old Store", "")
+	require.NoError(t, err)
+	inactive.SetActive(false)
+
+	tests := []struct {
+		name string
+		from *Location
+		to   *Location
+	}{
+		{"missing destination", from, nil},
+		{"same location", from, from},
+		{"inactive location", from, inactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer, err := NewStockTransfer(tenantID, "TR-001", tt.from, tt.to, "", uuid.New(), time.Now())
+
+			assert.Error(t, err)
+			assert.Nil(t, transfer)
+		})
+	}
+}
+
+func TestStockTransfer_AddItem(t *testing.T) {
+	transfer, product := newRequestedTransfer(t, time.Now())
+
+	require.NoError(t, transfer.AddItem(product, 3))
+	require.Len(t, transfer.Items, 1)
+	assert.Equal(t, 8, transfer.Items[0].Quantity)
+	assert.Equal(t, "DESK-01", transfer.Items[0].ProductSKU)
+
+	assert.Error(t, transfer.AddItem(product, 0))
+}
+
+func TestStockTransfer_Lifecycle(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+
+	t.Run("ship then receive", func(t *testing.T) {
+		transfer, product := newRequestedTransfer(t, now)
+
+		assert.Error(t, transfer.Receive(userID, now))
+
+		require.NoError(t, transfer.Ship(userID, now))
+		assert.Equal(t, StockTransferStatusInTransit, transfer.Status)
+		assert.Equal(t, userID, *transfer.ShippedBy)
+
+		out, err := transfer.StockMovement(transfer.Items[0], userID)
+		require.NoError(t, err)
+		assert.Equal(t, StockMovementTypeOut, out.Type)
+		assert.Equal(t, ReasonTransfer, out.Reason)
+		assert.Equal(t, transfer.FromLocationID, *out.LocationID)
+		assert.Equal(t, product.ID, out.ProductID)
+		assert.Equal(t, 5, out.Quantity)
+		assert.Equal(t, "TR-001", out.Reference)
+
+		assert.Error(t, transfer.Cancel(now))
+		assert.Error(t, transfer.AddItem(product, 1))
+
+		require.NoError(t, transfer.Receive(userID, now))
+		assert.Equal(t, StockTransferStatusReceived, transfer.Status)
+
+		in, err := transfer.StockMovement(transfer.Items[0], userID)
+		require.NoError(t, err)
+		assert.Equal(t, StockMovementTypeIn, in.Type)
+		assert.Equal(t, transfer.ToLocationID, *in.LocationID)
+	})
+
+	t.Run("cancel before shipping", func(t *testing.T) {
+		transfer, _ := newRequestedTransfer(t, now)
+
+		require.NoError(t, transfer.Cancel(now))
+		assert.Equal(t, StockTransferStatusCancelled, transfer.Status)
+		assert.Error(t, transfer.Ship(userID, now))
+	})
+
+	t.Run("ship without items", func(t *testing.T) {
+		transfer, _ := newRequestedTransfer(t, now)
+		transfer.Items = nil
+
+		assert.Error(t, transfer.Ship(userID, now))
+	})
+}
+
+func TestValidateStockTransferStatus(t *testing.T) {
+	assert.NoError(t, ValidateStockTransferStatus(StockTransferStatusInTransit))
+	assert.Error(t, ValidateStockTransferStatus(StockTransferStatus("lost")))
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// MaxSupplierPaymentTermsDays caps the payment terms of a supplier
+	MaxSupplierPaymentTermsDays = 365
+	// MaxSupplierLeadTimeDays caps the delivery lead time of a supplier
+	MaxSupplierLeadTimeDays = 365
+)
+
+// Supplier represents a vendor the tenant buys stock from
+type Supplier struct {
+	ID               uuid.UUID `json:"id"`
+	TenantID         uuid.UUID `json:"tenant_id"`
+	Name             string    `json:"name"`
+	ContactName      string    `json:"contact_name,omitempty"`
+	Email            string    `json:"email,omitempty"`
+	Phone            string    `json:"phone,omitempty"`
+	Address          string    `json:"address,omitempty"`
+	PaymentTermsDays int       `json:"payment_terms_days"` // Days after delivery the supplier expects payment, 0 for cash on delivery
+	LeadTimeDays     int       `json:"lead_time_days"`     // Days between ordering and delivery
+	Notes            string    `json:"notes,omitempty"`
+	IsActive         bool      `json:"is_active"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	CreatedBy        uuid.UUID `json:"created_by"`
+}
+
+// SupplierDetails holds the editable details of a supplier
+type SupplierDetails struct {
+	Name             string
+	ContactName      string
+	Email            string
+	Phone            string
+	Address          string
+	PaymentTermsDays int
+	LeadTimeDays     int
+	Notes            string
+}
+
+// NewSupplier creates a new active supplier
+func NewSupplier(tenantID uuid.UUID, details SupplierDetails, createdBy uuid.UUID) (*Supplier, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if err := validateSupplierDetails(details); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	supplier := &Supplier{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+	}
+	supplier.applyDetails(details)
+
+	return supplier, nil
+}
+
+// Update replaces the supplier's details
+func (s *Supplier) Update(details SupplierDetails) error {
+	if err := validateSupplierDetails(details); err != nil {
+		return err
+	}
+
+	s.applyDetails(details)
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Activate makes the supplier available for new purchase orders and reorder suggestions
+func (s *Supplier) Activate() {
+	s.IsActive = true
+	s.UpdatedAt = time.Now()
+}
+
+// Deactivate hides the supplier from reorder suggestions without unlinking its products
+func (s *Supplier) Deactivate() {
+	s.IsActive = false
+	s.UpdatedAt = time.Now()
+}
+
+// ExpectedDelivery returns when an order placed at the given time should arrive
+func (s *Supplier) ExpectedDelivery(orderedAt time.Time) time.Time {
+	return orderedAt.AddDate(0, 0, s.LeadTimeDays)
+}
+
+// applyDetails copies trimmed details onto the supplier
+func (s *Supplier) applyDetails(details SupplierDetails) {
+	s.Name = strings.TrimSpace(details.Name)
+	s.ContactName = strings.TrimSpace(details.ContactName)
+	s.Email = strings.ToLower(strings.TrimSpace(details.Email))
+	s.Phone = strings.TrimSpace(details.Phone)
+	s.Address = strings.TrimSpace(details.Address)
+	s.PaymentTermsDays = details.PaymentTermsDays
+	s.LeadTimeDays = details.LeadTimeDays
+	s.Notes = strings.TrimSpace(details.Notes)
+}
+
+// validateSupplierDetails validates supplier details
+func validateSupplierDetails(details SupplierDetails) error {
+	if err := validateSupplierInput(details.Name, details.Email); err != nil {
+		return err
+	}
+	if details.PaymentTermsDays < 0 || details.PaymentTermsDays > MaxSupplierPaymentTermsDays {
+		return errors.NewValidationError("invalid payment terms", "payment terms must be between 0 and 365 days")
+	}
+	if details.LeadTimeDays < 0 || details.LeadTimeDays > MaxSupplierLeadTimeDays {
+		return errors.NewValidationError("invalid lead time", "lead time must be between 0 and 365 days")
+	}
+	return nil
+}
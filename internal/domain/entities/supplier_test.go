@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSupplierDetails() SupplierDetails {
+	return SupplierDetails{
+		Name:             " Acme Supplies ",
+		ContactName:      "Budi",
+		Email:            " Orders@Acme.test ",
+		Phone:            "0812",
+		PaymentTermsDays: 30,
+		LeadTimeDays:     5,
+	}
+}
+
+func TestNewSupplier(t *testing.T) {
+	t.Run("valid supplier", func(t *testing.T) {
+		tenantID := uuid.New()
+		createdBy := uuid.New()
+
+		supplier, err := NewSupplier(tenantID, validSupplierDetails(), createdBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, supplier.ID)
+		assert.Equal(t, tenantID, supplier.TenantID)
+		assert.Equal(t, "Acme Supplies", supplier.Name)
+		assert.Equal(t, "orders@acme.test", supplier.Email)
+		assert.Equal(t, 30, supplier.PaymentTermsDays)
+		assert.Equal(t, 5, supplier.LeadTimeDays)
+		assert.True(t, supplier.IsActive)
+		assert.Equal(t, createdBy, supplier.CreatedBy)
+	})
+
+	t.Run("tenant required", func(t *testing.T) {
+		supplier, err := NewSupplier(uuid.Nil, validSupplierDetails(), uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, supplier)
+	})
+
+	t.Run("name required", func(t *testing.T) {
+		details := validSupplierDetails()
+		details.Name = " "
+
+		supplier, err := NewSupplier(uuid.New(), details, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, supplier)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		details := validSupplierDetails()
+		details.Email = "acme"
+
+		_, err := NewSupplier(uuid.New(), details, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("payment terms out of range", func(t *testing.T) {
+		details := validSupplierDetails()
+		details.PaymentTermsDays = -1
+
+		_, err := NewSupplier(uuid.New(), details, uuid.New())
+		assert.Error(t, err)
+
+		details.PaymentTermsDays = MaxSupplierPaymentTermsDays + 1
+		_, err = NewSupplier(uuid.New(), details, uuid.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("lead time out of range", func(t *testing.T) {
+		details := validSupplierDetails()
+		details.LeadTimeDays = MaxSupplierLeadTimeDays + 1
+
+		_, err := NewSupplier(uuid.New(), details, uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSupplier_Update(t *testing.T) {
+	supplier, err := NewSupplier(uuid.New(), validSupplierDetails(), uuid.New())
+	require.NoError(t, err)
+
+	details := validSupplierDetails()
+	details.Name = "Acme Wholesale"
+	details.LeadTimeDays = 10
+	require.NoError(t, supplier.Update(details))
+	assert.Equal(t, "Acme Wholesale", supplier.Name)
+	assert.Equal(t, 10, supplier.LeadTimeDays)
+
+	details.LeadTimeDays = -1
+	assert.Error(t, supplier.Update(details))
+	assert.Equal(t, 10, supplier.LeadTimeDays)
+}
+
+func TestSupplier_ActivateDeactivate(t *testing.T) {
+	supplier, err := NewSupplier(uuid.New(), validSupplierDetails(), uuid.New())
+	require.NoError(t, err)
+
+	supplier.Deactivate()
+	assert.False(t, supplier.IsActive)
+
+	supplier.Activate()
+	assert.True(t, supplier.IsActive)
+}
+
+func TestSupplier_ExpectedDelivery(t *testing.T) {
+	supplier, err := NewSupplier(uuid.New(), validSupplierDetails(), uuid.New())
+	require.NoError(t, err)
+
+	orderedAt := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC), supplier.ExpectedDelivery(orderedAt))
+}
+
+func TestProduct_SetPreferredSupplier(t *testing.T) {
+	product := &Product{}
+	supplierID := uuid.New()
+
+	product.SetPreferredSupplier(&supplierID)
+	assert.Equal(t, &supplierID, product.SupplierID)
+
+	product.SetPreferredSupplier(nil)
+	assert.Nil(t, product.SupplierID)
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// maxTaxInvoiceSerialNumber is the largest sequence number of an 8 digit tax invoice serial
+const maxTaxInvoiceSerialNumber = 99999999
+
+// TaxInvoiceSerialRange represents a block of tax invoice serial numbers allocated to a tenant by
+// the tax authority, such as the Indonesian NSFP (Nomor Seri Faktur Pajak) issued through
+// e-Nofa. Serials are "<branch code>-<year>.<sequence number>", e.g. 000-26.00000001, and are
+// handed out in order, never twice.
+type TaxInvoiceSerialRange struct {
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	BranchCode  string    `json:"branch_code"` // Three digits
+	Year        int       `json:"year"`        // Tax year the serials may be used in
+	FirstNumber int64     `json:"first_number"`
+	LastNumber  int64     `json:"last_number"`
+	NextNumber  int64     `json:"next_number"` // Past LastNumber once every serial is used
+	CreatedBy   uuid.UUID `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewTaxInvoiceSerialRange creates a range of serials from first to last inclusive
+func NewTaxInvoiceSerialRange(tenantID uuid.UUID, branchCode string, year int, first, last int64, createdBy uuid.UUID) (*TaxInvoiceSerialRange, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if branchCode == "" {
+		branchCode = "000"
+	}
+	if len(branchCode) != 3 || !isDigits(branchCode) {
+		return nil, errors.NewValidationError("invalid branch code", "branch_code must be 3 digits")
+	}
+	if year < 2000 || year > 2099 {
+		return nil, errors.NewValidationError("invalid year", "year must be between 2000 and 2099")
+	}
+	if first < 1 || last > maxTaxInvoiceSerialNumber {
+		return nil, errors.NewValidationError("invalid serial range", "serial numbers must be between 1 and 99999999")
+	}
+	if last < first {
+		return nil, errors.NewValidationError("invalid serial range", "last_number cannot be before first_number")
+	}
+
+	now := time.Now()
+	return &TaxInvoiceSerialRange{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		BranchCode:  branchCode,
+		Year:        year,
+		FirstNumber: first,
+		LastNumber:  last,
+		NextNumber:  first,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Remaining returns how many serials of the range are unused
+func (r *TaxInvoiceSerialRange) Remaining() int64 {
+	if r.NextNumber > r.LastNumber {
+		return 0
+	}
+	return r.LastNumber - r.NextNumber + 1
+}
+
+// Overlaps reports whether the range shares serials with other
+func (r *TaxInvoiceSerialRange) Overlaps(other *TaxInvoiceSerialRange) bool {
+	return r.BranchCode == other.BranchCode && r.Year == other.Year &&
+		r.FirstNumber <= other.LastNumber && other.FirstNumber <= r.LastNumber
+}
+
+// Serial formats the serial of a sequence number of the range
+func (r *TaxInvoiceSerialRange) Serial(number int64) string {
+	return fmt.Sprintf("%s-%02d.%08d", r.BranchCode, r.Year%100, number)
+}
+
+// TaxInvoiceSerial represents the serial of a tax invoice issued for an invoice
+type TaxInvoiceSerial struct {
+	ID         uuid.UUID `json:"id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	InvoiceID  uuid.UUID `json:"invoice_id"`
+	RangeID    uuid.UUID `json:"range_id"`
+	Serial     string    `json:"serial"`
+	AssignedBy uuid.UUID `json:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// isDigits reports whether s consists of ASCII digits only
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
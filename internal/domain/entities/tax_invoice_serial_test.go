@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaxInvoiceSerialRange(t *testing.T) {
+	t.Run("valid range", func(t *testing.T) {
+		serialRange, err := NewTaxInvoiceSerialRange(uuid.New(), "", 2026, 12345670, 12345679, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, "000", serialRange.BranchCode)
+		assert.Equal(t, int64(12345670), serialRange.NextNumber)
+		assert.Equal(t, int64(10), serialRange.Remaining())
+		assert.Equal(t, "000-26.12345670", serialRange.Serial(serialRange.NextNumber))
+	})
+
+	t.Run("invalid branch code", func(t *testing.T) {
+		_, err := NewTaxInvoiceSerialRange(uuid.New(), "01A", 2026, 1, 10, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid year", func(t *testing.T) {
+		_, err := NewTaxInvoiceSerialRange(uuid.New(), "000", 1999, 1, 10, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("last before first", func(t *testing.T) {
+		_, err := NewTaxInvoiceSerialRange(uuid.New(), "000", 2026, 10, 1, uuid.New())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("number too large", func(t *testing.T) {
+		_, err := NewTaxInvoiceSerialRange(uuid.New(), "000", 2026, 1, 100000000, uuid.New())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTaxInvoiceSerialRange_Remaining(t *testing.T) {
+	serialRange, err := NewTaxInvoiceSerialRange(uuid.New(), "000", 2026, 1, 2, uuid.New())
+	require.NoError(t, err)
+
+	serialRange.NextNumber = 3
+
+	assert.Equal(t, int64(0), serialRange.Remaining())
+}
+
+func TestTaxInvoiceSerialRange_Overlaps(t *testing.T) {
+	tenantID := uuid.New()
+	first, err := NewTaxInvoiceSerialRange(tenantID, "000", 2026, 1, 100, uuid.New())
+	require.NoError(t, err)
+
+	overlapping, err := NewTaxInvoiceSerialRange(tenantID, "000", 2026, 100, 200, uuid.New())
+	require.NoError(t, err)
+	assert.True(t, first.Overlaps(overlapping))
+
+	next, err := NewTaxInvoiceSerialRange(tenantID, "000", 2026, 101, 200, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, first.Overlaps(next))
+
+	otherYear, err := NewTaxInvoiceSerialRange(tenantID, "000", 2027, 1, 100, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, first.Overlaps(otherYear))
+}
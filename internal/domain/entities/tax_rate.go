@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// TaxPricingMode represents whether a tenant's prices include tax
+type TaxPricingMode string
+
+const (
+	TaxPricingModeExclusive TaxPricingMode = "tax_exclusive" // Tax is added on top of prices
+	TaxPricingModeInclusive TaxPricingMode = "tax_inclusive" // Prices already include tax
+)
+
+// TaxRate represents a named tax rate a tenant assigns to categories and products, such as a
+// reduced rate for food. Items without a rate of their own are taxed at the sale's rate.
+type TaxRate struct {
+	ID        uuid.UUID       `json:"id"`
+	TenantID  uuid.UUID       `json:"tenant_id"`
+	Name      string          `json:"name"` // Unique per tenant, e.g. "Reduced"
+	Rate      decimal.Decimal `json:"rate"` // Percentage, e.g. 7.5
+	IsActive  bool            `json:"is_active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	UpdatedBy uuid.UUID       `json:"updated_by"`
+}
+
+// TaxRateSummary represents the tax charged at one rate on a sale or invoice
+type TaxRateSummary struct {
+	TaxRate       decimal.Decimal `json:"tax_rate"`
+	TaxableAmount decimal.Decimal `json:"taxable_amount"` // After the discount, excluding tax
+	TaxAmount     decimal.Decimal `json:"tax_amount"`
+}
+
+// NewTaxRate creates a new active tax rate
+func NewTaxRate(tenantID uuid.UUID, name string, rate decimal.Decimal, createdBy uuid.UUID) (*TaxRate, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant is required", "tenant_id cannot be empty")
+	}
+
+	now := time.Now()
+	taxRate := &TaxRate{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		IsActive:  true,
+		CreatedAt: now,
+	}
+	if err := taxRate.Update(name, rate, createdBy); err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+// Update renames the tax rate and changes its percentage. Sales keep the rate their items
+// were taxed at.
+func (t *TaxRate) Update(name string, rate decimal.Decimal, updatedBy uuid.UUID) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("tax rate name is required", "name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.NewValidationError("tax rate name too long", "name cannot exceed 100 characters")
+	}
+	if rate.LessThan(decimal.Zero) || rate.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.NewValidationError("invalid tax rate", "rate must be between 0 and 100")
+	}
+
+	t.Name = name
+	t.Rate = rate
+	t.UpdatedAt = time.Now()
+	t.UpdatedBy = updatedBy
+	return nil
+}
+
+// Activate enables the tax rate
+func (t *TaxRate) Activate(updatedBy uuid.UUID) {
+	t.IsActive = true
+	t.UpdatedAt = time.Now()
+	t.UpdatedBy = updatedBy
+}
+
+// Deactivate disables the tax rate. Categories and products keep it assigned, but new sale
+// items are taxed at the sale's rate instead.
+func (t *TaxRate) Deactivate(updatedBy uuid.UUID) {
+	t.IsActive = false
+	t.UpdatedAt = time.Now()
+	t.UpdatedBy = updatedBy
+}
+
+// checkAssignable checks that the tax rate can be assigned to a category or product of the tenant
+func (t *TaxRate) checkAssignable(tenantID uuid.UUID) error {
+	if t.TenantID != tenantID {
+		return errors.NewValidationError("unknown tax rate", "tax rate does not belong to the tenant")
+	}
+	if !t.IsActive {
+		return errors.NewValidationError("tax rate is not active", "only active tax rates can be assigned")
+	}
+	return nil
+}
+
+// ResolveTaxRateID returns the tax rate a product is sold at: its own, otherwise the rate of
+// its category or the nearest parent category with one. categories are all the tenant's
+// categories. It returns nil when the product is taxed at the sale's rate.
+func ResolveTaxRateID(product *Product, categories []*Category) *uuid.UUID {
+	if product.TaxRateID != nil {
+		return product.TaxRateID
+	}
+	if product.CategoryID == nil {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	category := byID[*product.CategoryID]
+	for depth := 0; category != nil && depth < MaxCategoryDepth; depth++ {
+		if category.TaxRateID != nil {
+			return category.TaxRateID
+		}
+		if category.ParentID == nil {
+			break
+		}
+		category = byID[*category.ParentID]
+	}
+
+	return nil
+}
+
+// ValidateTaxPricingMode validates tax pricing mode
+func ValidateTaxPricingMode(mode TaxPricingMode) error {
+	switch mode {
+	case TaxPricingModeExclusive, TaxPricingModeInclusive:
+		return nil
+	default:
+		return errors.NewValidationError("invalid tax pricing mode", "tax pricing mode must be one of: tax_exclusive, tax_inclusive")
+	}
+}
+
+// taxLine is a sale or invoice line as tax is computed on it
+type taxLine struct {
+	amount decimal.Decimal // Line total before the document discount
+	rate   decimal.Decimal
+}
+
+// computeLineTaxes spreads the document discount over the lines in proportion to their amounts
+// and taxes what is left of each line at its rate, rounding each line's tax to cents. With
+// tax-inclusive pricing the tax is the part of the discounted amount that is tax. It returns
+// each line's taxable amount, excluding tax, and its tax.
+func computeLineTaxes(lines []taxLine, discount decimal.Decimal, inclusive bool) (taxable, taxes []decimal.Decimal) {
+	total := decimal.Zero
+	for _, line := range lines {
+		total = total.Add(line.amount)
+	}
+
+	hundred := decimal.NewFromInt(100)
+	taxable = make([]decimal.Decimal, len(lines))
+	taxes = make([]decimal.Decimal, len(lines))
+	allocated := decimal.Zero
+	for i, line := range lines {
+		share := decimal.Zero
+		if total.IsPositive() {
+			if i == len(lines)-1 {
+				share = discount.Sub(allocated)
+			} else {
+				share = line.amount.Mul(discount).Div(total).Round(2)
+			}
+		}
+		allocated = allocated.Add(share)
+
+		net := line.amount.Sub(share)
+		if inclusive {
+			taxes[i] = net.Mul(line.rate).Div(hundred.Add(line.rate)).Round(2)
+			taxable[i] = net.Sub(taxes[i])
+		} else {
+			taxes[i] = net.Mul(line.rate).Div(hundred).Round(2)
+			taxable[i] = net
+		}
+	}
+
+	return taxable, taxes
+}
+
+// summarizeTax groups line taxes by rate, lowest rate first. A taxed surcharge is added at
+// the document's rate.
+func summarizeTax(lines []taxLine, taxable, taxes []decimal.Decimal, surcharge, surchargeTax, surchargeRate decimal.Decimal) []TaxRateSummary {
+	summary := []TaxRateSummary{}
+	add := func(rate, taxableAmount, taxAmount decimal.Decimal) {
+		for i := range summary {
+			if summary[i].TaxRate.Equal(rate) {
+				summary[i].TaxableAmount = summary[i].TaxableAmount.Add(taxableAmount)
+				summary[i].TaxAmount = summary[i].TaxAmount.Add(taxAmount)
+				return
+			}
+		}
+		summary = append(summary, TaxRateSummary{TaxRate: rate, TaxableAmount: taxableAmount, TaxAmount: taxAmount})
+	}
+
+	for i, line := range lines {
+		add(line.rate, taxable[i], taxes[i])
+	}
+	if surchargeTax.IsPositive() {
+		add(surchargeRate, surcharge, surchargeTax)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].TaxRate.LessThan(summary[j].TaxRate)
+	})
+	return summary
+}
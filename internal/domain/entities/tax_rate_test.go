@@ -0,0 +1,229 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaxRate(t *testing.T) {
+	t.Run("valid tax rate", func(t *testing.T) {
+		tenantID := uuid.New()
+		taxRate, err := NewTaxRate(tenantID, " Reduced ", decimal.NewFromFloat(7.5), uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, taxRate.TenantID)
+		assert.Equal(t, "Reduced", taxRate.Name)
+		assert.True(t, decimal.NewFromFloat(7.5).Equal(taxRate.Rate))
+		assert.True(t, taxRate.IsActive)
+	})
+
+	tests := []struct {
+		name        string
+		tenantID    uuid.UUID
+		taxName     string
+		rate        decimal.Decimal
+		errContains string
+	}{
+		{"missing tenant", uuid.Nil, "Standard", decimal.NewFromInt(10), "tenant is required"},
+		{"missing name", uuid.New(), "  ", decimal.NewFromInt(10), "tax rate name is required"},
+		{"negative rate", uuid.New(), "Standard", decimal.NewFromInt(-1), "invalid tax rate"},
+		{"rate over 100", uuid.New(), "Standard", decimal.NewFromInt(101), "invalid tax rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxRate, err := NewTaxRate(tt.tenantID, tt.taxName, tt.rate, uuid.New())
+
+			assert.Error(t, err)
+			assert.Nil(t, taxRate)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestProduct_SetTaxRate(t *testing.T) {
+	tenantID := uuid.New()
+	product, err := NewProduct(tenantID, "TEA-01", "Green Tea", "", "Drinks", "pcs", decimal.NewFromInt(4), decimal.NewFromInt(2), 0, uuid.New())
+	require.NoError(t, err)
+
+	t.Run("assigns an active rate", func(t *testing.T) {
+		taxRate, err := NewTaxRate(tenantID, "Reduced", decimal.NewFromInt(5), uuid.New())
+		require.NoError(t, err)
+
+		require.NoError(t, product.SetTaxRate(taxRate))
+		assert.Equal(t, taxRate.ID, *product.TaxRateID)
+
+		require.NoError(t, product.SetTaxRate(nil))
+		assert.Nil(t, product.TaxRateID)
+	})
+
+	t.Run("refuses an inactive rate", func(t *testing.T) {
+		taxRate, err := NewTaxRate(tenantID, "Old", decimal.NewFromInt(5), uuid.New())
+		require.NoError(t, err)
+		taxRate.Deactivate(uuid.New())
+
+		err = product.SetTaxRate(taxRate)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not active")
+	})
+
+	t.Run("refuses another tenant's rate", func(t *testing.T) {
+		taxRate, err := NewTaxRate(uuid.New(), "Other", decimal.NewFromInt(5), uuid.New())
+		require.NoError(t, err)
+
+		err = product.SetTaxRate(taxRate)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown tax rate")
+	})
+}
+
+func TestResolveTaxRateID(t *testing.T) {
+	tenantID := uuid.New()
+	userID := uuid.New()
+	reduced, err := NewTaxRate(tenantID, "Reduced", decimal.NewFromInt(5), userID)
+	require.NoError(t, err)
+	zero, err := NewTaxRate(tenantID, "Zero", decimal.Zero, userID)
+	require.NoError(t, err)
+
+	food, err := NewCategory(tenantID, "Food", "", 0, userID)
+	require.NoError(t, err)
+	require.NoError(t, food.SetTaxRate(reduced, userID))
+	bakery, err := NewCategory(tenantID, "Bakery", "", 0, userID)
+	require.NoError(t, err)
+	require.NoError(t, bakery.MoveTo(&food.ID, []*Category{food}, userID))
+	categories := []*Category{food, bakery}
+
+	newProduct := func(category *Category) *Product {
+		product, err := NewProduct(tenantID, "BREAD-01", "Bread", "", "Bakery", "pcs", decimal.NewFromInt(3), decimal.NewFromInt(1), 0, userID)
+		require.NoError(t, err)
+		if category != nil {
+			require.NoError(t, product.SetCategory(category))
+		}
+		return product
+	}
+
+	t.Run("inherits from the parent category", func(t *testing.T) {
+		assert.Equal(t, reduced.ID, *ResolveTaxRateID(newProduct(bakery), categories))
+	})
+
+	t.Run("product rate overrides the category's", func(t *testing.T) {
+		product := newProduct(bakery)
+		require.NoError(t, product.SetTaxRate(zero))
+
+		assert.Equal(t, zero.ID, *ResolveTaxRateID(product, categories))
+	})
+
+	t.Run("no rate without a category", func(t *testing.T) {
+		assert.Nil(t, ResolveTaxRateID(newProduct(nil), categories))
+	})
+}
+
+func TestSale_PerLineTax(t *testing.T) {
+	tenantID := uuid.New()
+	reduced, err := NewTaxRate(tenantID, "Reduced", decimal.NewFromInt(5), uuid.New())
+	require.NoError(t, err)
+
+	newSale := func(t *testing.T) *Sale {
+		sale, err := NewSale(tenantID, "S-001", "", "", "", uuid.New())
+		require.NoError(t, err)
+
+		bread, err := NewSaleItem(sale.ID, uuid.New(), "BREAD-01", "Bread", 2, decimal.NewFromInt(30))
+		require.NoError(t, err)
+		bread.AssignTaxRate(reduced)
+		require.NoError(t, sale.AddItem(bread))
+
+		soap, err := NewSaleItem(sale.ID, uuid.New(), "SOAP-01", "Soap", 1, decimal.NewFromInt(40))
+		require.NoError(t, err)
+		require.NoError(t, sale.AddItem(soap))
+
+		require.NoError(t, sale.ApplyDiscount(decimal.NewFromInt(10)))
+		return sale
+	}
+
+	t.Run("tax exclusive", func(t *testing.T) {
+		sale := newSale(t)
+
+		require.NoError(t, sale.ApplyTax(decimal.NewFromInt(10)))
+
+		// The discount is split 6 and 4, leaving 54 at 5% and 36 at 10%
+		assert.True(t, decimal.NewFromFloat(2.7).Equal(sale.Items[0].TaxAmount), "got %s", sale.Items[0].TaxAmount)
+		assert.True(t, decimal.NewFromFloat(3.6).Equal(sale.Items[1].TaxAmount), "got %s", sale.Items[1].TaxAmount)
+		assert.True(t, decimal.NewFromFloat(6.3).Equal(sale.TaxAmount), "got %s", sale.TaxAmount)
+		assert.True(t, decimal.NewFromFloat(96.3).Equal(sale.TotalAmount), "got %s", sale.TotalAmount)
+
+		summary := sale.TaxSummary()
+		require.Len(t, summary, 2)
+		assert.True(t, decimal.NewFromInt(5).Equal(summary[0].TaxRate))
+		assert.True(t, decimal.NewFromInt(54).Equal(summary[0].TaxableAmount))
+		assert.True(t, decimal.NewFromInt(10).Equal(summary[1].TaxRate))
+		assert.True(t, decimal.NewFromInt(36).Equal(summary[1].TaxableAmount))
+	})
+
+	t.Run("tax inclusive", func(t *testing.T) {
+		sale := newSale(t)
+		require.NoError(t, sale.SetTaxPricingMode(TaxPricingModeInclusive))
+
+		require.NoError(t, sale.ApplyTax(decimal.NewFromInt(10)))
+
+		// 54 includes 2.57 at 5% and 36 includes 3.27 at 10%
+		assert.True(t, decimal.NewFromFloat(2.57).Equal(sale.Items[0].TaxAmount), "got %s", sale.Items[0].TaxAmount)
+		assert.True(t, decimal.NewFromFloat(3.27).Equal(sale.Items[1].TaxAmount), "got %s", sale.Items[1].TaxAmount)
+		assert.True(t, decimal.NewFromFloat(5.84).Equal(sale.TaxAmount), "got %s", sale.TaxAmount)
+		assert.True(t, decimal.NewFromInt(90).Equal(sale.TotalAmount), "got %s", sale.TotalAmount)
+
+		summary := sale.TaxSummary()
+		require.Len(t, summary, 2)
+		assert.True(t, decimal.NewFromFloat(51.43).Equal(summary[0].TaxableAmount), "got %s", summary[0].TaxableAmount)
+	})
+
+	t.Run("inactive rate falls back to the sale's", func(t *testing.T) {
+		item, err := NewSaleItem(uuid.New(), uuid.New(), "SOAP-01", "Soap", 1, decimal.NewFromInt(40))
+		require.NoError(t, err)
+		inactive := *reduced
+		inactive.Deactivate(uuid.New())
+
+		item.AssignTaxRate(&inactive)
+
+		assert.Nil(t, item.TaxRateID)
+	})
+}
+
+func TestInvoice_TaxSummary(t *testing.T) {
+	tenantID := uuid.New()
+	reduced, err := NewTaxRate(tenantID, "Reduced", decimal.NewFromInt(5), uuid.New())
+	require.NoError(t, err)
+
+	sale, err := NewSale(tenantID, "S-001", "", "", "", uuid.New())
+	require.NoError(t, err)
+	bread, err := NewSaleItem(sale.ID, uuid.New(), "BREAD-01", "Bread", 2, decimal.NewFromInt(30))
+	require.NoError(t, err)
+	bread.AssignTaxRate(reduced)
+	require.NoError(t, sale.AddItem(bread))
+	soap, err := NewSaleItem(sale.ID, uuid.New(), "SOAP-01", "Soap", 1, decimal.NewFromInt(40))
+	require.NoError(t, err)
+	require.NoError(t, sale.AddItem(soap))
+	require.NoError(t, sale.ApplyTax(decimal.NewFromInt(10)))
+	sale.Status = SaleStatusCompleted
+
+	invoice, err := NewInvoice(tenantID, "INV-001", sale, uuid.New())
+	require.NoError(t, err)
+
+	require.Len(t, invoice.TaxSummary, 2)
+	assert.True(t, decimal.NewFromInt(3).Equal(invoice.TaxSummary[0].TaxAmount), "got %s", invoice.TaxSummary[0].TaxAmount)
+	assert.True(t, decimal.NewFromInt(4).Equal(invoice.TaxSummary[1].TaxAmount), "got %s", invoice.TaxSummary[1].TaxAmount)
+	assert.Equal(t, reduced.ID, *invoice.Items[0].TaxRateID)
+
+	t.Run("recalculation keeps each item's rate", func(t *testing.T) {
+		invoice.RecalculateTotals(decimal.NewFromInt(20))
+
+		assert.True(t, decimal.NewFromInt(3).Equal(invoice.Items[0].TaxAmount), "got %s", invoice.Items[0].TaxAmount)
+		assert.True(t, decimal.NewFromInt(8).Equal(invoice.Items[1].TaxAmount), "got %s", invoice.Items[1].TaxAmount)
+		assert.True(t, decimal.NewFromInt(11).Equal(invoice.TaxAmount), "got %s", invoice.TaxAmount)
+	})
+}
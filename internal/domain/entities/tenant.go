@@ -26,6 +26,9 @@ type TenantConfiguration struct {
 	POSSettings  POSSettings             `json:"pos_settings"`
 	FeatureFlags map[string]bool         `json:"feature_flags"`
 	CustomFields map[string]interface{}  `json:"custom_fields,omitempty"`
+
+	// CurrencyFormat overrides the conventional display of the tenant's currency
+	CurrencyFormat *CurrencyFormat `json:"currency_format,omitempty"`
 }
 
 // BusinessInfo represents tenant's business information
@@ -45,6 +48,9 @@ type POSSettings struct {
 	TaxRate           float64 `json:"tax_rate"`
 	ReceiptTemplate   string `json:"receipt_template"`
 	AutoPrintReceipts bool   `json:"auto_print_receipts"`
+	ReturnWindowDays  int    `json:"return_window_days,omitempty"` // Days after a sale it can be refunded without a manager override, 0 for no limit
+	TaxPricingMode    TaxPricingMode `json:"tax_pricing_mode,omitempty"` // Whether prices include tax, tax_exclusive when empty
+	CostMethod        CostMethod     `json:"cost_method,omitempty"`      // How sold units are costed, moving_average when empty
 }
 
 // Tenant represents a tenant in the multi-tenant system
@@ -263,6 +269,56 @@ func (t *Tenant) GetCurrency() string {
 	return "USD"
 }
 
+// GetCurrencyFormat returns how the tenant's amounts are displayed, falling back to the
+// conventional display of the tenant's currency
+func (t *Tenant) GetCurrencyFormat() CurrencyFormat {
+	if t.Configuration.CurrencyFormat != nil {
+		return *t.Configuration.CurrencyFormat
+	}
+	return DefaultCurrencyFormat(t.GetCurrency())
+}
+
+// SetCurrencyFormat overrides how the tenant's amounts are displayed. A nil format restores
+// the conventional display of the tenant's currency.
+func (t *Tenant) SetCurrencyFormat(format *CurrencyFormat) error {
+	if format == nil {
+		t.Configuration.CurrencyFormat = nil
+		t.UpdatedAt = time.Now()
+		return nil
+	}
+
+	if err := format.Validate(); err != nil {
+		return err
+	}
+
+	override := *format
+	t.Configuration.CurrencyFormat = &override
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetReturnWindowDays returns how many days after a sale it can be refunded without a manager
+// override, 0 for no limit
+func (t *Tenant) GetReturnWindowDays() int {
+	return t.Configuration.POSSettings.ReturnWindowDays
+}
+
+// GetTaxPricingMode returns whether the tenant's prices include tax
+func (t *Tenant) GetTaxPricingMode() TaxPricingMode {
+	if t.Configuration.POSSettings.TaxPricingMode == "" {
+		return TaxPricingModeExclusive
+	}
+	return t.Configuration.POSSettings.TaxPricingMode
+}
+
+// GetCostMethod returns how the tenant's sold units are costed
+func (t *Tenant) GetCostMethod() CostMethod {
+	if t.Configuration.POSSettings.CostMethod == "" {
+		return CostMethodMovingAverage
+	}
+	return t.Configuration.POSSettings.CostMethod
+}
+
 // GetTaxRate returns the tenant's tax rate
 func (t *Tenant) GetTaxRate() decimal.Decimal {
 	return decimal.NewFromFloat(t.Configuration.BusinessInfo.TaxRate)
@@ -312,6 +368,28 @@ func validateTenantConfiguration(config TenantConfiguration) error {
 	if config.BusinessInfo.Currency == "" {
 		config.BusinessInfo.Currency = "USD"
 	}
+
+	if config.CurrencyFormat != nil {
+		if err := config.CurrencyFormat.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if config.POSSettings.ReturnWindowDays < 0 {
+		return errors.NewValidationError("invalid return window", "pos_settings.return_window_days cannot be negative")
+	}
+
+	if config.POSSettings.TaxPricingMode != "" {
+		if err := ValidateTaxPricingMode(config.POSSettings.TaxPricingMode); err != nil {
+			return err
+		}
+	}
+
+	if config.POSSettings.CostMethod != "" {
+		if err := ValidateCostMethod(config.POSSettings.CostMethod); err != nil {
+			return err
+		}
+	}
 	
 	return nil
 }
@@ -130,6 +130,14 @@ func (tc *TenantContext) GetCurrency() string {
 	return "USD"
 }
 
+// GetCurrencyFormat returns how the tenant's amounts are displayed
+func (tc *TenantContext) GetCurrencyFormat() CurrencyFormat {
+	if tc.Configuration.CurrencyFormat != nil {
+		return *tc.Configuration.CurrencyFormat
+	}
+	return DefaultCurrencyFormat(tc.GetCurrency())
+}
+
 // GetTaxRate returns the tenant's tax rate
 func (tc *TenantContext) GetTaxRate() float64 {
 	return tc.Configuration.BusinessInfo.TaxRate
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// TenantExportStatus represents the status of a tenant data export job
+type TenantExportStatus string
+
+const (
+	TenantExportStatusPending    TenantExportStatus = "pending"
+	TenantExportStatusProcessing TenantExportStatus = "processing"
+	TenantExportStatusCompleted  TenantExportStatus = "completed"
+	TenantExportStatusFailed     TenantExportStatus = "failed"
+)
+
+// TenantExportRetention is how long a completed export archive stays downloadable
+const TenantExportRetention = 7 * 24 * time.Hour
+
+// TenantExport represents a full-tenant data export job used for offboarding and data portability
+type TenantExport struct {
+	ID           uuid.UUID          `json:"id"`
+	TenantID     uuid.UUID          `json:"tenant_id"`
+	Status       TenantExportStatus `json:"status"`
+	FilePath     string             `json:"-"` // Storage path of the archive, only exposed through signed links
+	FileSize     int64              `json:"file_size"`
+	RecordCounts map[string]int     `json:"record_counts,omitempty"` // Number of records exported per entity
+	ErrorMessage string             `json:"error_message,omitempty"`
+	RequestedBy  uuid.UUID          `json:"requested_by"`
+	StartedAt    *time.Time         `json:"started_at,omitempty"`
+	CompletedAt  *time.Time         `json:"completed_at,omitempty"`
+	ExpiresAt    *time.Time         `json:"expires_at,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// NewTenantExport creates a new pending tenant export job
+func NewTenantExport(tenantID, requestedBy uuid.UUID) (*TenantExport, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if requestedBy == uuid.Nil {
+		return nil, errors.NewValidationError("requester is required", "requested by cannot be empty")
+	}
+
+	now := time.Now()
+	export := &TenantExport{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		Status:       TenantExportStatusPending,
+		RecordCounts: map[string]int{},
+		RequestedBy:  requestedBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	return export, nil
+}
+
+// Start marks the export as being processed
+func (e *TenantExport) Start() error {
+	if e.Status != TenantExportStatusPending {
+		return errors.NewValidationError("invalid export status", "only pending exports can be started")
+	}
+
+	now := time.Now()
+	e.Status = TenantExportStatusProcessing
+	e.StartedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+// Complete marks the export as completed with the stored archive details
+func (e *TenantExport) Complete(filePath string, fileSize int64, recordCounts map[string]int) error {
+	if e.Status != TenantExportStatusProcessing {
+		return errors.NewValidationError("invalid export status", "only processing exports can be completed")
+	}
+	if filePath == "" {
+		return errors.NewValidationError("file path is required", "completed exports must reference an archive")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(TenantExportRetention)
+	e.Status = TenantExportStatusCompleted
+	e.FilePath = filePath
+	e.FileSize = fileSize
+	e.RecordCounts = recordCounts
+	e.CompletedAt = &now
+	e.ExpiresAt = &expiresAt
+	e.UpdatedAt = now
+	return nil
+}
+
+// Fail marks the export as failed
+func (e *TenantExport) Fail(reason string) {
+	now := time.Now()
+	e.Status = TenantExportStatusFailed
+	e.ErrorMessage = reason
+	e.CompletedAt = &now
+	e.UpdatedAt = now
+}
+
+// IsInProgress checks if the export is still pending or processing
+func (e *TenantExport) IsInProgress() bool {
+	return e.Status == TenantExportStatusPending || e.Status == TenantExportStatusProcessing
+}
+
+// IsDownloadable checks if the export archive can still be downloaded
+func (e *TenantExport) IsDownloadable(now time.Time) bool {
+	return e.Status == TenantExportStatusCompleted && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTenantExport(t *testing.T) {
+	t.Run("valid export creation", func(t *testing.T) {
+		tenantID := uuid.New()
+		requestedBy := uuid.New()
+
+		export, err := NewTenantExport(tenantID, requestedBy)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, export.ID)
+		assert.Equal(t, tenantID, export.TenantID)
+		assert.Equal(t, requestedBy, export.RequestedBy)
+		assert.Equal(t, TenantExportStatusPending, export.Status)
+		assert.True(t, export.IsInProgress())
+		assert.False(t, export.IsDownloadable(time.Now()))
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		export, err := NewTenantExport(uuid.Nil, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, export)
+	})
+
+	t.Run("invalid requester", func(t *testing.T) {
+		export, err := NewTenantExport(uuid.New(), uuid.Nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, export)
+	})
+}
+
+func TestTenantExport_Lifecycle(t *testing.T) {
+	t.Run("complete export", func(t *testing.T) {
+		export := createValidTenantExport(t)
+
+		require.NoError(t, export.Start())
+		assert.Equal(t, TenantExportStatusProcessing, export.Status)
+		assert.NotNil(t, export.StartedAt)
+
+		err := export.Complete("exports/tenant/export.zip", 2048, map[string]int{"products": 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, TenantExportStatusCompleted, export.Status)
+		assert.Equal(t, "exports/tenant/export.zip", export.FilePath)
+		assert.Equal(t, int64(2048), export.FileSize)
+		assert.Equal(t, 3, export.RecordCounts["products"])
+		assert.False(t, export.IsInProgress())
+		assert.True(t, export.IsDownloadable(time.Now()))
+		assert.False(t, export.IsDownloadable(time.Now().Add(TenantExportRetention+time.Minute)))
+	})
+
+	t.Run("cannot start twice", func(t *testing.T) {
+		export := createValidTenantExport(t)
+		require.NoError(t, export.Start())
+
+		assert.Error(t, export.Start())
+	})
+
+	t.Run("cannot complete pending export", func(t *testing.T) {
+		export := createValidTenantExport(t)
+
+		assert.Error(t, export.Complete("exports/tenant/export.zip", 1, nil))
+	})
+
+	t.Run("cannot complete without file", func(t *testing.T) {
+		export := createValidTenantExport(t)
+		require.NoError(t, export.Start())
+
+		assert.Error(t, export.Complete("", 0, nil))
+	})
+
+	t.Run("fail export", func(t *testing.T) {
+		export := createValidTenantExport(t)
+		require.NoError(t, export.Start())
+
+		export.Fail("storage unavailable")
+
+		assert.Equal(t, TenantExportStatusFailed, export.Status)
+		assert.Equal(t, "storage unavailable", export.ErrorMessage)
+		assert.False(t, export.IsInProgress())
+		assert.False(t, export.IsDownloadable(time.Now()))
+	})
+}
+
+func createValidTenantExport(t *testing.T) *TenantExport {
+	export, err := NewTenantExport(uuid.New(), uuid.New())
+
+	require.NoError(t, err)
+	require.NotNil(t, export)
+
+	return export
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// tenantScopeKey is the context key carrying the tenant that data access is restricted to
+type tenantScopeKey struct{}
+
+// WithTenantScope returns a copy of ctx whose data access is restricted to a single tenant.
+// Repositories only return and modify rows belonging to that tenant.
+func WithTenantScope(ctx context.Context, tenantID uuid.UUID) context.Context {
+	if tenantID == uuid.Nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantScopeKey{}, tenantID)
+}
+
+// TenantScopeFromContext returns the tenant data access is restricted to. Contexts without a
+// scope must be marked with WithAllTenants to access tenant data.
+func TenantScopeFromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenantID, ok := ctx.Value(tenantScopeKey{}).(uuid.UUID)
+	return tenantID, ok
+}
+
+// allTenantsKey is the context key marking data access that deliberately spans every tenant
+type allTenantsKey struct{}
+
+// WithAllTenants returns a copy of ctx whose data access spans every tenant. It is reserved for
+// background jobs, sign-in and public lookups that resolve the tenant from the data itself;
+// repositories refuse tenant data to contexts that are neither scoped nor marked this way.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsKey{}, true)
+}
+
+// SpansAllTenants reports whether ctx was deliberately marked to access every tenant's data
+func SpansAllTenants(ctx context.Context) bool {
+	all, _ := ctx.Value(allTenantsKey{}).(bool)
+	return all
+}
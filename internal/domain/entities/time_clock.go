@@ -0,0 +1,289 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// MaxTimesheetPeriod is the longest pay period a single timesheet may cover
+const MaxTimesheetPeriod = 62 * 24 * time.Hour
+
+// TimeClockEntry represents a single shift worked by a staff member, from clock-in to clock-out
+type TimeClockEntry struct {
+	ID         uuid.UUID        `json:"id"`
+	TenantID   uuid.UUID        `json:"tenant_id"`
+	UserID     uuid.UUID        `json:"user_id"`
+	DeviceID   *uuid.UUID       `json:"device_id,omitempty"` // Terminal the staff member clocked in on
+	ClockInAt  time.Time        `json:"clock_in_at"`
+	ClockOutAt *time.Time       `json:"clock_out_at,omitempty"`
+	Breaks     []TimeClockBreak `json:"breaks"`
+	Notes      string           `json:"notes,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// TimeClockBreak represents an unpaid break taken during a shift
+type TimeClockBreak struct {
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// NewTimeClockEntry clocks a staff member in
+func NewTimeClockEntry(tenantID, userID uuid.UUID, deviceID *uuid.UUID, now time.Time) (*TimeClockEntry, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if userID == uuid.Nil {
+		return nil, errors.NewValidationError("user ID is required", "user ID cannot be empty")
+	}
+
+	return &TimeClockEntry{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		DeviceID:  deviceID,
+		ClockInAt: now,
+		Breaks:    []TimeClockBreak{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsOpen checks if the staff member is still clocked in
+func (e *TimeClockEntry) IsOpen() bool {
+	return e.ClockOutAt == nil
+}
+
+// OnBreak checks if the staff member is currently on a break
+func (e *TimeClockEntry) OnBreak() bool {
+	return len(e.Breaks) > 0 && e.Breaks[len(e.Breaks)-1].EndedAt == nil
+}
+
+// StartBreak starts a break
+func (e *TimeClockEntry) StartBreak(now time.Time) error {
+	if !e.IsOpen() {
+		return errors.NewValidationError("shift already ended", "cannot start a break after clocking out")
+	}
+	if e.OnBreak() {
+		return errors.NewValidationError("already on break", "end the current break before starting another")
+	}
+	if now.Before(e.lastActivity()) {
+		return errors.NewValidationError("invalid break start", "break cannot start before the last clock event")
+	}
+
+	e.Breaks = append(e.Breaks, TimeClockBreak{StartedAt: now})
+	e.UpdatedAt = now
+	return nil
+}
+
+// EndBreak ends the current break
+func (e *TimeClockEntry) EndBreak(now time.Time) error {
+	if !e.OnBreak() {
+		return errors.NewValidationError("not on break", "there is no break in progress")
+	}
+	current := &e.Breaks[len(e.Breaks)-1]
+	if now.Before(current.StartedAt) {
+		return errors.NewValidationError("invalid break end", "break cannot end before it started")
+	}
+
+	current.EndedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+// ClockOut ends the shift, ending any break still in progress
+func (e *TimeClockEntry) ClockOut(now time.Time, notes string) error {
+	if !e.IsOpen() {
+		return errors.NewValidationError("already clocked out", "shift has already ended")
+	}
+	if now.Before(e.lastActivity()) {
+		return errors.NewValidationError("invalid clock out", "clock out cannot be before the last clock event")
+	}
+	if len(notes) > 255 {
+		return errors.NewValidationError("notes too long", "notes cannot exceed 255 characters")
+	}
+
+	if e.OnBreak() {
+		e.Breaks[len(e.Breaks)-1].EndedAt = &now
+	}
+	e.ClockOutAt = &now
+	e.Notes = notes
+	e.UpdatedAt = now
+	return nil
+}
+
+// BreakDuration returns the total break time, counting a break in progress up to now
+func (e *TimeClockEntry) BreakDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, b := range e.Breaks {
+		total += intervalEnd(b.EndedAt, now).Sub(b.StartedAt)
+	}
+	return total
+}
+
+// WorkedDuration returns the time worked excluding breaks, counting an open shift up to now
+func (e *TimeClockEntry) WorkedDuration(now time.Time) time.Duration {
+	return intervalEnd(e.ClockOutAt, now).Sub(e.ClockInAt) - e.BreakDuration(now)
+}
+
+// workedIntervals returns the periods actually worked, i.e. the shift with its breaks cut out
+func (e *TimeClockEntry) workedIntervals(now time.Time) [][2]time.Time {
+	var intervals [][2]time.Time
+	start := e.ClockInAt
+	for _, b := range e.Breaks {
+		intervals = append(intervals, [2]time.Time{start, b.StartedAt})
+		start = intervalEnd(b.EndedAt, now)
+	}
+	return append(intervals, [2]time.Time{start, intervalEnd(e.ClockOutAt, now)})
+}
+
+// lastActivity returns the time of the most recent clock event
+func (e *TimeClockEntry) lastActivity() time.Time {
+	if len(e.Breaks) == 0 {
+		return e.ClockInAt
+	}
+	last := e.Breaks[len(e.Breaks)-1]
+	if last.EndedAt != nil {
+		return *last.EndedAt
+	}
+	return last.StartedAt
+}
+
+// intervalEnd returns the end of an interval, or now while the interval is still running
+func intervalEnd(end *time.Time, now time.Time) time.Time {
+	if end != nil {
+		return *end
+	}
+	return now
+}
+
+// Timesheet summarizes the hours worked by each staff member over a pay period
+type Timesheet struct {
+	PeriodStart time.Time       `json:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end"`
+	Timezone    string          `json:"timezone"`
+	Staff       []TimesheetLine `json:"staff"`
+	TotalHours  float64         `json:"total_hours"`
+	// StaffHoursByHour holds staff-hours worked per local weekday (Sunday first) and hour of
+	// day, in the same shape as the hourly sales heatmap so staffing can be compared with it
+	StaffHoursByHour [7][24]float64 `json:"staff_hours_by_hour"`
+}
+
+// TimesheetLine represents one staff member's hours in a timesheet
+type TimesheetLine struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Shifts      int       `json:"shifts"`
+	OpenShifts  int       `json:"open_shifts"` // Shifts not clocked out yet, counted up to now
+	WorkedHours float64   `json:"worked_hours"`
+	BreakHours  float64   `json:"break_hours"`
+}
+
+// NewTimesheet builds a timesheet from the shifts overlapping [start, end). Only the part of
+// each shift inside the period is counted; open shifts are counted up to now.
+func NewTimesheet(entries []*TimeClockEntry, start, end time.Time, loc *time.Location, now time.Time) (*Timesheet, error) {
+	if err := ValidateTimesheetPeriod(start, end); err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	sheet := &Timesheet{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Timezone:    loc.String(),
+		Staff:       []TimesheetLine{},
+	}
+
+	lines := make(map[uuid.UUID]*TimesheetLine)
+	var staffHours [7][24]time.Duration
+	for _, entry := range entries {
+		line, ok := lines[entry.UserID]
+		if !ok {
+			line = &TimesheetLine{UserID: entry.UserID}
+			lines[entry.UserID] = line
+		}
+		line.Shifts++
+		if entry.IsOpen() {
+			line.OpenShifts++
+		}
+
+		var worked time.Duration
+		for _, interval := range entry.workedIntervals(now) {
+			from, to := clampInterval(interval[0], interval[1], start, end)
+			worked += to.Sub(from)
+			addStaffHours(&staffHours, from, to, loc)
+		}
+		shiftFrom, shiftTo := clampInterval(entry.ClockInAt, intervalEnd(entry.ClockOutAt, now), start, end)
+
+		line.WorkedHours += worked.Hours()
+		line.BreakHours += (shiftTo.Sub(shiftFrom) - worked).Hours()
+	}
+
+	for _, line := range lines {
+		line.WorkedHours = roundHours(line.WorkedHours)
+		line.BreakHours = roundHours(line.BreakHours)
+		sheet.TotalHours += line.WorkedHours
+		sheet.Staff = append(sheet.Staff, *line)
+	}
+	sort.Slice(sheet.Staff, func(i, j int) bool {
+		return sheet.Staff[i].UserID.String() < sheet.Staff[j].UserID.String()
+	})
+	sheet.TotalHours = roundHours(sheet.TotalHours)
+
+	for day := range staffHours {
+		for hour := range staffHours[day] {
+			sheet.StaffHoursByHour[day][hour] = roundHours(staffHours[day][hour].Hours())
+		}
+	}
+
+	return sheet, nil
+}
+
+// ValidateTimesheetPeriod checks that a pay period is non-empty and not too long
+func ValidateTimesheetPeriod(start, end time.Time) error {
+	if !end.After(start) {
+		return errors.NewValidationError("invalid pay period", "period end must be after period start")
+	}
+	if end.Sub(start) > MaxTimesheetPeriod {
+		return errors.NewValidationError("pay period too long", "timesheet cannot cover more than 62 days")
+	}
+	return nil
+}
+
+// clampInterval restricts [from, to) to [start, end), returning an empty interval when they do not overlap
+func clampInterval(from, to, start, end time.Time) (time.Time, time.Time) {
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if to.Before(from) {
+		to = from
+	}
+	return from, to
+}
+
+// addStaffHours spreads [from, to) over the local weekday and hour buckets it covers
+func addStaffHours(buckets *[7][24]time.Duration, from, to time.Time, loc *time.Location) {
+	for from.Before(to) {
+		local := from.In(loc)
+		next := time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+1, 0, 0, 0, loc)
+		if next.After(to) {
+			next = to
+		}
+		buckets[local.Weekday()][local.Hour()] += next.Sub(from)
+		from = next
+	}
+}
+
+// roundHours rounds hours to two decimal places
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
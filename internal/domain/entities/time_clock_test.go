@@ -0,0 +1,197 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimeClockEntry(t *testing.T) {
+	t.Run("valid clock in", func(t *testing.T) {
+		tenantID := uuid.New()
+		userID := uuid.New()
+		deviceID := uuid.New()
+		now := time.Now()
+
+		entry, err := NewTimeClockEntry(tenantID, userID, &deviceID, now)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, entry.ID)
+		assert.Equal(t, tenantID, entry.TenantID)
+		assert.Equal(t, userID, entry.UserID)
+		assert.Equal(t, &deviceID, entry.DeviceID)
+		assert.Equal(t, now, entry.ClockInAt)
+		assert.True(t, entry.IsOpen())
+		assert.False(t, entry.OnBreak())
+		assert.Empty(t, entry.Breaks)
+	})
+
+	t.Run("invalid user - empty", func(t *testing.T) {
+		entry, err := NewTimeClockEntry(uuid.New(), uuid.Nil, nil, time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, entry)
+		assert.Contains(t, err.Error(), "user ID is required")
+	})
+}
+
+func TestTimeClockEntry_Breaks(t *testing.T) {
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("start and end break", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+
+		require.NoError(t, entry.StartBreak(start.Add(3*time.Hour)))
+		assert.True(t, entry.OnBreak())
+
+		require.NoError(t, entry.EndBreak(start.Add(3*time.Hour+30*time.Minute)))
+		assert.False(t, entry.OnBreak())
+		assert.Equal(t, 30*time.Minute, entry.BreakDuration(start.Add(5*time.Hour)))
+	})
+
+	t.Run("cannot start break twice", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+		require.NoError(t, entry.StartBreak(start.Add(time.Hour)))
+
+		err := entry.StartBreak(start.Add(2 * time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already on break")
+	})
+
+	t.Run("cannot end break when not on break", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+
+		err := entry.EndBreak(start.Add(time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not on break")
+	})
+
+	t.Run("cannot start break before last clock event", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+
+		err := entry.StartBreak(start.Add(-time.Minute))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid break start")
+	})
+}
+
+func TestTimeClockEntry_ClockOut(t *testing.T) {
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("clock out", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+		require.NoError(t, entry.StartBreak(start.Add(4*time.Hour)))
+		require.NoError(t, entry.EndBreak(start.Add(5*time.Hour)))
+
+		err := entry.ClockOut(start.Add(8*time.Hour), "closed the store")
+
+		require.NoError(t, err)
+		assert.False(t, entry.IsOpen())
+		assert.Equal(t, "closed the store", entry.Notes)
+		assert.Equal(t, 7*time.Hour, entry.WorkedDuration(start.Add(24*time.Hour)))
+	})
+
+	t.Run("clock out ends break in progress", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+		require.NoError(t, entry.StartBreak(start.Add(4*time.Hour)))
+
+		err := entry.ClockOut(start.Add(5*time.Hour), "")
+
+		require.NoError(t, err)
+		assert.False(t, entry.OnBreak())
+		assert.Equal(t, time.Hour, entry.BreakDuration(start.Add(24*time.Hour)))
+		assert.Equal(t, 4*time.Hour, entry.WorkedDuration(start.Add(24*time.Hour)))
+	})
+
+	t.Run("cannot clock out twice", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+		require.NoError(t, entry.ClockOut(start.Add(time.Hour), ""))
+
+		err := entry.ClockOut(start.Add(2*time.Hour), "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already clocked out")
+	})
+
+	t.Run("open shift counts up to now", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, start)
+
+		assert.Equal(t, 2*time.Hour, entry.WorkedDuration(start.Add(2*time.Hour)))
+	})
+}
+
+func TestNewTimesheet(t *testing.T) {
+	periodStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("summarizes hours per staff member", func(t *testing.T) {
+		// Monday 12 October, 09:00-17:00 with a one hour break
+		shiftStart := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+		first := createValidTimeClockEntry(t, shiftStart)
+		require.NoError(t, first.StartBreak(shiftStart.Add(3*time.Hour)))
+		require.NoError(t, first.EndBreak(shiftStart.Add(4*time.Hour)))
+		require.NoError(t, first.ClockOut(shiftStart.Add(8*time.Hour), ""))
+
+		second, err := NewTimeClockEntry(first.TenantID, first.UserID, nil, shiftStart.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, second.ClockOut(shiftStart.Add(24*time.Hour+90*time.Minute), ""))
+
+		sheet, err := NewTimesheet([]*TimeClockEntry{first, second}, periodStart, periodEnd, time.UTC, now)
+
+		require.NoError(t, err)
+		require.Len(t, sheet.Staff, 1)
+		assert.Equal(t, first.UserID, sheet.Staff[0].UserID)
+		assert.Equal(t, 2, sheet.Staff[0].Shifts)
+		assert.Equal(t, 8.5, sheet.Staff[0].WorkedHours)
+		assert.Equal(t, 1.0, sheet.Staff[0].BreakHours)
+		assert.Equal(t, 8.5, sheet.TotalHours)
+		assert.Equal(t, 1.0, sheet.StaffHoursByHour[time.Monday][9])
+		assert.Equal(t, 0.0, sheet.StaffHoursByHour[time.Monday][12])
+		assert.Equal(t, 0.5, sheet.StaffHoursByHour[time.Tuesday][10])
+	})
+
+	t.Run("only counts the part of a shift inside the period", func(t *testing.T) {
+		entry := createValidTimeClockEntry(t, periodEnd.Add(-2*time.Hour))
+
+		sheet, err := NewTimesheet([]*TimeClockEntry{entry}, periodStart, periodEnd, time.UTC, now)
+
+		require.NoError(t, err)
+		require.Len(t, sheet.Staff, 1)
+		assert.Equal(t, 1, sheet.Staff[0].OpenShifts)
+		assert.Equal(t, 2.0, sheet.Staff[0].WorkedHours)
+	})
+
+	t.Run("buckets hours in the requested time zone", func(t *testing.T) {
+		jakarta := time.FixedZone("WIB", 7*60*60)
+		entry := createValidTimeClockEntry(t, time.Date(2026, 10, 12, 2, 0, 0, 0, time.UTC))
+		require.NoError(t, entry.ClockOut(time.Date(2026, 10, 12, 3, 0, 0, 0, time.UTC), ""))
+
+		sheet, err := NewTimesheet([]*TimeClockEntry{entry}, periodStart, periodEnd, jakarta, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "WIB", sheet.Timezone)
+		assert.Equal(t, 1.0, sheet.StaffHoursByHour[time.Monday][9])
+	})
+
+	t.Run("invalid period - too long", func(t *testing.T) {
+		sheet, err := NewTimesheet(nil, periodStart, periodStart.Add(MaxTimesheetPeriod+time.Hour), time.UTC, now)
+
+		assert.Error(t, err)
+		assert.Nil(t, sheet)
+		assert.Contains(t, err.Error(), "pay period too long")
+	})
+}
+
+// Helper function to create a valid time clock entry for testing
+func createValidTimeClockEntry(t *testing.T, clockIn time.Time) *TimeClockEntry {
+	entry, err := NewTimeClockEntry(uuid.New(), uuid.New(), nil, clockIn)
+	require.NoError(t, err)
+	return entry
+}
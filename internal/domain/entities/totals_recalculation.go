@@ -0,0 +1,206 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// TotalsDocumentType represents the kind of document whose stored totals are recalculated
+type TotalsDocumentType string
+
+const (
+	TotalsDocumentTypeSale    TotalsDocumentType = "sale"
+	TotalsDocumentTypeInvoice TotalsDocumentType = "invoice"
+)
+
+// TotalsRecalculationStatus represents the status of a totals recalculation
+type TotalsRecalculationStatus string
+
+const (
+	TotalsRecalculationStatusPreviewed TotalsRecalculationStatus = "previewed" // Dry run; nothing was changed yet
+	TotalsRecalculationStatusApplied   TotalsRecalculationStatus = "applied"
+)
+
+// TotalsCorrectionOutcome represents the outcome of correcting a single document
+type TotalsCorrectionOutcome string
+
+const (
+	TotalsCorrectionOutcomePending TotalsCorrectionOutcome = "pending"
+	TotalsCorrectionOutcomeApplied TotalsCorrectionOutcome = "applied"
+	TotalsCorrectionOutcomeSkipped TotalsCorrectionOutcome = "skipped"
+	TotalsCorrectionOutcomeFailed  TotalsCorrectionOutcome = "failed"
+)
+
+// Reasons a correction is skipped instead of applied
+const (
+	TotalsCorrectionSkipDocumentChanged = "document_changed_since_preview"
+	TotalsCorrectionSkipMissingDocument = "document_not_found"
+)
+
+// MaxTotalsRecalculationSize caps the number of documents scanned by a single recalculation
+const MaxTotalsRecalculationSize = 500
+
+// MaxTotalsRecalculationReasonLength caps the reason recorded with a recalculation
+const MaxTotalsRecalculationReasonLength = 500
+
+// DocumentTotals represents the stored totals of a sale or invoice
+type DocumentTotals struct {
+	Subtotal    decimal.Decimal `json:"subtotal"`
+	TaxAmount   decimal.Decimal `json:"tax_amount"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+}
+
+// Equal reports whether both totals hold the same amounts
+func (t DocumentTotals) Equal(other DocumentTotals) bool {
+	return t.Subtotal.Equal(other.Subtotal) &&
+		t.TaxAmount.Equal(other.TaxAmount) &&
+		t.TotalAmount.Equal(other.TotalAmount)
+}
+
+// TotalsRecalculation represents a maintenance run that recomputes the stored totals of a set
+// of documents. It is created as a dry run listing the documents whose totals would change and
+// only changes them once applied.
+type TotalsRecalculation struct {
+	ID           uuid.UUID                 `json:"id"`
+	TenantID     uuid.UUID                 `json:"tenant_id"`
+	DocumentType TotalsDocumentType        `json:"document_type"`
+	Reason       string                    `json:"reason"`
+	Status       TotalsRecalculationStatus `json:"status"`
+	Corrections  []TotalsCorrection        `json:"corrections,omitempty"`
+	ScannedCount int                       `json:"scanned_count"`
+	ChangedCount int                       `json:"changed_count"`
+	AppliedCount int                       `json:"applied_count"`
+	SkippedCount int                       `json:"skipped_count"`
+	FailedCount  int                       `json:"failed_count"`
+	RequestedBy  uuid.UUID                 `json:"requested_by"`
+	AppliedBy    *uuid.UUID                `json:"applied_by,omitempty"`
+	AppliedAt    *time.Time                `json:"applied_at,omitempty"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+}
+
+// TotalsCorrection represents the difference between the stored and recalculated totals of
+// one document
+type TotalsCorrection struct {
+	ID              uuid.UUID               `json:"id"`
+	RecalculationID uuid.UUID               `json:"recalculation_id"`
+	DocumentID      uuid.UUID               `json:"document_id"`
+	DocumentNumber  string                  `json:"document_number"`
+	Before          DocumentTotals          `json:"before"`
+	After           DocumentTotals          `json:"after"`
+	Outcome         TotalsCorrectionOutcome `json:"outcome"`
+	Reason          string                  `json:"reason,omitempty"` // Skip reason or error
+	ProcessedAt     *time.Time              `json:"processed_at,omitempty"`
+}
+
+// NewTotalsRecalculation creates a dry run over scanned documents. Only documents whose totals
+// change are listed as corrections.
+func NewTotalsRecalculation(tenantID, requestedBy uuid.UUID, documentType TotalsDocumentType, reason string, scanned int, corrections []TotalsCorrection) (*TotalsRecalculation, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if err := ValidateTotalsDocumentType(documentType); err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		return nil, errors.NewValidationError("reason is required", "explain why the totals are recalculated")
+	}
+	if len(reason) > MaxTotalsRecalculationReasonLength {
+		return nil, errors.NewValidationError("reason too long", "reason cannot exceed 500 characters")
+	}
+	if scanned > MaxTotalsRecalculationSize {
+		return nil, errors.NewValidationError("too many documents selected", "a recalculation cannot exceed 500 documents")
+	}
+
+	now := time.Now()
+	recalculation := &TotalsRecalculation{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		DocumentType: documentType,
+		Reason:       reason,
+		Status:       TotalsRecalculationStatusPreviewed,
+		Corrections:  make([]TotalsCorrection, 0, len(corrections)),
+		ScannedCount: scanned,
+		RequestedBy:  requestedBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	for _, correction := range corrections {
+		correction.ID = uuid.New()
+		correction.RecalculationID = recalculation.ID
+		correction.Outcome = TotalsCorrectionOutcomePending
+		recalculation.Corrections = append(recalculation.Corrections, correction)
+	}
+
+	recalculation.recount()
+	return recalculation, nil
+}
+
+// NewTotalsCorrection creates the correction of a document's totals
+func NewTotalsCorrection(documentID uuid.UUID, documentNumber string, before, after DocumentTotals) TotalsCorrection {
+	return TotalsCorrection{
+		DocumentID:     documentID,
+		DocumentNumber: documentNumber,
+		Before:         before,
+		After:          after,
+		Outcome:        TotalsCorrectionOutcomePending,
+	}
+}
+
+// StartApply checks that the recalculation can still be applied
+func (r *TotalsRecalculation) StartApply() error {
+	if r.Status != TotalsRecalculationStatusPreviewed {
+		return errors.NewConflictError("recalculation has already been applied")
+	}
+	return nil
+}
+
+// RecordOutcome records the outcome of correcting the document at index
+func (r *TotalsRecalculation) RecordOutcome(index int, outcome TotalsCorrectionOutcome, reason string) {
+	now := time.Now()
+	r.Corrections[index].Outcome = outcome
+	r.Corrections[index].Reason = reason
+	r.Corrections[index].ProcessedAt = &now
+	r.UpdatedAt = now
+	r.recount()
+}
+
+// MarkApplied marks the recalculation as applied once every correction has an outcome
+func (r *TotalsRecalculation) MarkApplied(userID uuid.UUID) {
+	now := time.Now()
+	r.Status = TotalsRecalculationStatusApplied
+	r.AppliedBy = &userID
+	r.AppliedAt = &now
+	r.UpdatedAt = now
+}
+
+// recount recomputes the outcome counters from the corrections
+func (r *TotalsRecalculation) recount() {
+	r.ChangedCount = len(r.Corrections)
+	r.AppliedCount, r.SkippedCount, r.FailedCount = 0, 0, 0
+	for _, correction := range r.Corrections {
+		switch correction.Outcome {
+		case TotalsCorrectionOutcomeApplied:
+			r.AppliedCount++
+		case TotalsCorrectionOutcomeSkipped:
+			r.SkippedCount++
+		case TotalsCorrectionOutcomeFailed:
+			r.FailedCount++
+		}
+	}
+}
+
+// ValidateTotalsDocumentType validates totals document type
+func ValidateTotalsDocumentType(documentType TotalsDocumentType) error {
+	switch documentType {
+	case TotalsDocumentTypeSale, TotalsDocumentTypeInvoice:
+		return nil
+	default:
+		return errors.NewValidationError("invalid document type", "document type must be one of: sale, invoice")
+	}
+}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTotalsRecalculation(t *testing.T) {
+	before := DocumentTotals{Subtotal: decimal.NewFromInt(100), TaxAmount: decimal.NewFromInt(10), TotalAmount: decimal.NewFromInt(110)}
+	after := DocumentTotals{Subtotal: decimal.NewFromInt(100), TaxAmount: decimal.NewFromInt(11), TotalAmount: decimal.NewFromInt(111)}
+
+	t.Run("valid dry run", func(t *testing.T) {
+		corrections := []TotalsCorrection{NewTotalsCorrection(uuid.New(), "SALE-1", before, after)}
+
+		recalculation, err := NewTotalsRecalculation(uuid.New(), uuid.New(), TotalsDocumentTypeSale, "tax rounding fix", 3, corrections)
+
+		require.NoError(t, err)
+		assert.Equal(t, TotalsRecalculationStatusPreviewed, recalculation.Status)
+		assert.Equal(t, 3, recalculation.ScannedCount)
+		assert.Equal(t, 1, recalculation.ChangedCount)
+		assert.Equal(t, recalculation.ID, recalculation.Corrections[0].RecalculationID)
+		assert.NotEqual(t, uuid.Nil, recalculation.Corrections[0].ID)
+		assert.Equal(t, TotalsCorrectionOutcomePending, recalculation.Corrections[0].Outcome)
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		recalculation, err := NewTotalsRecalculation(uuid.New(), uuid.New(), TotalsDocumentTypeSale, "", 0, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, recalculation)
+	})
+
+	t.Run("invalid document type", func(t *testing.T) {
+		recalculation, err := NewTotalsRecalculation(uuid.New(), uuid.New(), TotalsDocumentType("refund"), "fix", 0, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, recalculation)
+	})
+
+	t.Run("too many documents", func(t *testing.T) {
+		recalculation, err := NewTotalsRecalculation(uuid.New(), uuid.New(), TotalsDocumentTypeInvoice, "fix", MaxTotalsRecalculationSize+1, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, recalculation)
+	})
+}
+
+func TestTotalsRecalculation_Apply(t *testing.T) {
+	totals := DocumentTotals{Subtotal: decimal.NewFromInt(10), TaxAmount: decimal.Zero, TotalAmount: decimal.NewFromInt(10)}
+	corrections := []TotalsCorrection{
+		NewTotalsCorrection(uuid.New(), "INV-1", totals, totals),
+		NewTotalsCorrection(uuid.New(), "INV-2", totals, totals),
+	}
+	recalculation, err := NewTotalsRecalculation(uuid.New(), uuid.New(), TotalsDocumentTypeInvoice, "fix", 2, corrections)
+	require.NoError(t, err)
+
+	require.NoError(t, recalculation.StartApply())
+	recalculation.RecordOutcome(0, TotalsCorrectionOutcomeApplied, "")
+	recalculation.RecordOutcome(1, TotalsCorrectionOutcomeSkipped, TotalsCorrectionSkipDocumentChanged)
+	userID := uuid.New()
+	recalculation.MarkApplied(userID)
+
+	assert.Equal(t, TotalsRecalculationStatusApplied, recalculation.Status)
+	assert.Equal(t, 1, recalculation.AppliedCount)
+	assert.Equal(t, 1, recalculation.SkippedCount)
+	assert.Equal(t, &userID, recalculation.AppliedBy)
+	assert.NotNil(t, recalculation.Corrections[1].ProcessedAt)
+	assert.Error(t, recalculation.StartApply())
+}
+
+func TestSale_RecalculateTotals(t *testing.T) {
+	sale := &Sale{
+		Items: []SaleItem{
+			{UnitPrice: decimal.NewFromFloat(2.50), Quantity: 4, TotalPrice: decimal.NewFromFloat(9.99)},
+		},
+		Subtotal:           decimal.NewFromFloat(9.99),
+		TaxRate:            decimal.NewFromInt(10),
+		TaxAmount:          decimal.NewFromFloat(1.50),
+		DiscountAmount:     decimal.NewFromInt(1),
+		SurchargeAmount:    decimal.NewFromInt(2),
+		SurchargeTaxAmount: decimal.NewFromFloat(0.20),
+		TotalAmount:        decimal.NewFromFloat(12.49),
+	}
+	before := sale.Totals()
+
+	sale.RecalculateTotals()
+
+	assert.True(t, decimal.NewFromInt(10).Equal(sale.Items[0].TotalPrice))
+	assert.True(t, decimal.NewFromInt(10).Equal(sale.Subtotal))
+	assert.True(t, decimal.NewFromFloat(1.10).Equal(sale.TaxAmount))
+	assert.True(t, decimal.NewFromFloat(12.10).Equal(sale.TotalAmount))
+	assert.False(t, before.Equal(sale.Totals()))
+}
+
+func TestInvoice_RecalculateTotals(t *testing.T) {
+	invoice := &Invoice{
+		Items: []InvoiceItem{
+			{UnitPrice: decimal.NewFromInt(5), Quantity: 2, TotalPrice: decimal.NewFromInt(10)},
+		},
+		Subtotal:    decimal.NewFromInt(10),
+		TaxAmount:   decimal.NewFromInt(1),
+		TotalAmount: decimal.NewFromInt(11),
+	}
+	before := invoice.Totals()
+
+	invoice.RecalculateTotals(decimal.NewFromInt(10))
+	assert.True(t, before.Equal(invoice.Totals()))
+
+	invoice.RecalculateTotals(decimal.NewFromInt(11))
+	assert.True(t, decimal.NewFromFloat(1.1).Equal(invoice.TaxAmount))
+	assert.True(t, decimal.NewFromFloat(11.1).Equal(invoice.TotalAmount))
+}
@@ -26,6 +26,7 @@ const (
 	UserStatusActive   UserStatus = "active"
 	UserStatusInactive UserStatus = "inactive"
 	UserStatusSuspended UserStatus = "suspended"
+	UserStatusInvited  UserStatus = "invited" // Has not accepted their invitation and set a password yet
 )
 
 // User represents a user in the system
@@ -39,6 +40,8 @@ type User struct {
 	Role        UserRole   `json:"role"`
 	Status      UserStatus `json:"status"`
 	PasswordHash string    `json:"-"` // Never expose password hash in JSON
+	PasswordChangedAt  time.Time `json:"password_changed_at"`
+	MustChangePassword bool      `json:"must_change_password"` // Set by admins to force a change on next login
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
@@ -46,9 +49,12 @@ type User struct {
 
 // NewUser creates a new user
 func NewUser(tenantID uuid.UUID, username, email, firstName, lastName, password string, role UserRole) (*User, error) {
-	if err := validateUserInput(username, email, firstName, lastName, password); err != nil {
+	if err := validateUserInput(username, email, firstName, lastName); err != nil {
 		return nil, err
 	}
+	if len(password) < 8 {
+		return nil, errors.NewValidationError("password too short", "password must be at least 8 characters long")
+	}
 
 	if err := ValidateUserRole(role); err != nil {
 		return nil, err
@@ -61,22 +67,66 @@ func NewUser(tenantID uuid.UUID, username, email, firstName, lastName, password
 
 	now := time.Now()
 	user := &User{
-		ID:           uuid.New(),
-		TenantID:     tenantID,
-		Username:     username,
-		Email:        email,
-		FirstName:    firstName,
-		LastName:     lastName,
-		Role:         role,
-		Status:       UserStatusActive,
-		PasswordHash: passwordHash,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		Username:          username,
+		Email:             email,
+		FirstName:         firstName,
+		LastName:          lastName,
+		Role:              role,
+		Status:            UserStatusActive,
+		PasswordHash:      passwordHash,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		PasswordChangedAt: now,
 	}
 
 	return user, nil
 }
 
+// NewInvitedUser creates a user who sets their own password by accepting an invitation. Until
+// then the user has no password and cannot sign in.
+func NewInvitedUser(tenantID uuid.UUID, username, email, firstName, lastName string, role UserRole) (*User, error) {
+	if err := validateUserInput(username, email, firstName, lastName); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateUserRole(role); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &User{
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		Username:          username,
+		Email:             email,
+		FirstName:         firstName,
+		LastName:          lastName,
+		Role:              role,
+		Status:            UserStatusInvited,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		PasswordChangedAt: now,
+	}
+
+	return user, nil
+}
+
+// AcceptInvitation sets the password of an invited user and activates the account
+func (u *User) AcceptInvitation(password string) error {
+	if u.Status != UserStatusInvited {
+		return errors.NewValidationError("user is not invited", "only invited users can accept an invitation")
+	}
+
+	if err := u.UpdatePassword(password); err != nil {
+		return err
+	}
+
+	u.Status = UserStatusActive
+	return nil
+}
+
 // ValidatePassword checks if the provided password matches the user's password
 func (u *User) ValidatePassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
@@ -94,11 +144,26 @@ func (u *User) UpdatePassword(newPassword string) error {
 		return errors.NewInternalError("failed to hash password", err)
 	}
 
+	now := time.Now()
 	u.PasswordHash = passwordHash
-	u.UpdatedAt = time.Now()
+	u.PasswordChangedAt = now
+	u.MustChangePassword = false
+	u.UpdatedAt = now
 	return nil
 }
 
+// RequirePasswordChange makes the user change their password before signing in again
+func (u *User) RequirePasswordChange() {
+	u.MustChangePassword = true
+	u.UpdatedAt = time.Now()
+}
+
+// PasswordChangeRequired checks if the user has to change their password before signing in,
+// because an admin asked for it or the password expired under the policy
+func (u *User) PasswordChangeRequired(policy PasswordPolicy, at time.Time) bool {
+	return u.MustChangePassword || policy.IsExpired(u.PasswordChangedAt, at)
+}
+
 // UpdateProfile updates user profile information
 func (u *User) UpdateProfile(firstName, lastName, email string) error {
 	if firstName == "" {
@@ -123,6 +188,9 @@ func (u *User) ChangeStatus(status UserStatus) error {
 	if err := ValidateUserStatus(status); err != nil {
 		return err
 	}
+	if status != u.Status && (status == UserStatusInvited || u.Status == UserStatusInvited) {
+		return errors.NewValidationError("invalid status change", "invited users become active by accepting their invitation")
+	}
 
 	u.Status = status
 	u.UpdatedAt = time.Now()
@@ -157,6 +225,11 @@ func (u *User) CanManageUsers() bool {
 	return u.Role == RoleAdmin || u.Role == RoleManager
 }
 
+// CanManageUser checks if the user can manage the given user, who must belong to their tenant
+func (u *User) CanManageUser(other *User) bool {
+	return u.CanManageUsers() && u.TenantID == other.TenantID
+}
+
 // CanManageProducts checks if the user can manage products
 func (u *User) CanManageProducts() bool {
 	return u.Role == RoleAdmin || u.Role == RoleManager
@@ -185,16 +258,16 @@ func ValidateUserRole(role UserRole) error {
 // ValidateUserStatus validates if the status is valid
 func ValidateUserStatus(status UserStatus) error {
 	switch status {
-	case UserStatusActive, UserStatusInactive, UserStatusSuspended:
+	case UserStatusActive, UserStatusInactive, UserStatusSuspended, UserStatusInvited:
 		return nil
 	default:
-		return errors.NewValidationError("invalid user status", "status must be one of: active, inactive, suspended")
+		return errors.NewValidationError("invalid user status", "status must be one of: active, inactive, suspended, invited")
 	}
 }
 
 // Helper functions
 
-func validateUserInput(username, email, firstName, lastName, password string) error {
+func validateUserInput(username, email, firstName, lastName string) error {
 	if username == "" {
 		return errors.NewValidationError("username is required", "username cannot be empty")
 	}
@@ -213,9 +286,6 @@ func validateUserInput(username, email, firstName, lastName, password string) er
 	if lastName == "" {
 		return errors.NewValidationError("last name is required", "last_name cannot be empty")
 	}
-	if len(password) < 8 {
-		return errors.NewValidationError("password too short", "password must be at least 8 characters long")
-	}
 	return nil
 }
 
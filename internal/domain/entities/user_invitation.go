@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// UserInvitationStatus represents the state of a user invitation
+type UserInvitationStatus string
+
+const (
+	UserInvitationPending  UserInvitationStatus = "pending"
+	UserInvitationAccepted UserInvitationStatus = "accepted"
+	UserInvitationRevoked  UserInvitationStatus = "revoked"
+	UserInvitationExpired  UserInvitationStatus = "expired"
+)
+
+// UserInvitation invites a user created by an admin to set their password and activate their
+// account through an emailed activation link. Resending it extends the expiry, which also
+// invalidates the links sent before.
+type UserInvitation struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Email      string     `json:"email"`
+	InvitedBy  uuid.UUID  `json:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	SentCount  int        `json:"sent_count"`
+	LastSentAt time.Time  `json:"last_sent_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy  *uuid.UUID `json:"revoked_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewUserInvitation creates an invitation for an invited user, valid for the given duration
+func NewUserInvitation(user *User, invitedBy uuid.UUID, validFor time.Duration) (*UserInvitation, error) {
+	if user.Status != UserStatusInvited {
+		return nil, errors.NewValidationError("user is not invited", "invitations can only be sent to invited users")
+	}
+	if validFor <= 0 {
+		return nil, errors.NewValidationError("invalid invitation expiry", "invitations must be valid for a positive duration")
+	}
+
+	now := time.Now()
+	return &UserInvitation{
+		ID:         uuid.New(),
+		TenantID:   user.TenantID,
+		UserID:     user.ID,
+		Email:      user.Email,
+		InvitedBy:  invitedBy,
+		ExpiresAt:  time.Unix(now.Add(validFor).Unix(), 0),
+		SentCount:  1,
+		LastSentAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// Status returns the state of the invitation at the given time
+func (i *UserInvitation) Status(at time.Time) UserInvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return UserInvitationAccepted
+	case i.RevokedAt != nil:
+		return UserInvitationRevoked
+	case !at.Before(i.ExpiresAt):
+		return UserInvitationExpired
+	default:
+		return UserInvitationPending
+	}
+}
+
+// Resend renews the invitation for the given duration. Only links sent from now on are valid.
+func (i *UserInvitation) Resend(validFor time.Duration, at time.Time) error {
+	switch i.Status(at) {
+	case UserInvitationAccepted:
+		return errors.NewConflictError("invitation has already been accepted")
+	case UserInvitationRevoked:
+		return errors.NewConflictError("invitation has been revoked")
+	}
+	if validFor <= 0 {
+		return errors.NewValidationError("invalid invitation expiry", "invitations must be valid for a positive duration")
+	}
+
+	expiresAt := time.Unix(at.Add(validFor).Unix(), 0)
+	// Links carry the expiry, so it has to change for the earlier links to stop working
+	if !expiresAt.After(i.ExpiresAt) {
+		expiresAt = i.ExpiresAt.Add(time.Second)
+	}
+
+	i.ExpiresAt = expiresAt
+	i.SentCount++
+	i.LastSentAt = at
+	i.UpdatedAt = at
+	return nil
+}
+
+// Accept marks the invitation as accepted
+func (i *UserInvitation) Accept(at time.Time) error {
+	if err := i.checkPending(at); err != nil {
+		return err
+	}
+
+	i.AcceptedAt = &at
+	i.UpdatedAt = at
+	return nil
+}
+
+// Revoke withdraws the invitation, so its links stop working
+func (i *UserInvitation) Revoke(revokedBy uuid.UUID, at time.Time) error {
+	switch i.Status(at) {
+	case UserInvitationAccepted:
+		return errors.NewConflictError("invitation has already been accepted")
+	case UserInvitationRevoked:
+		return errors.NewConflictError("invitation has already been revoked")
+	}
+
+	i.RevokedAt = &at
+	i.RevokedBy = &revokedBy
+	i.UpdatedAt = at
+	return nil
+}
+
+// CheckLink checks a link was signed for the invitation as last sent, and that the invitation
+// can still be accepted
+func (i *UserInvitation) CheckLink(link *UserInvitationLink, secret string, at time.Time) error {
+	if link.InvitationID != i.ID || !link.ExpiresAt.Equal(i.ExpiresAt) {
+		return errors.NewUnauthorizedError("invalid invitation link")
+	}
+	if err := link.Verify(secret, at); err != nil {
+		return err
+	}
+	return i.checkPending(at)
+}
+
+// checkPending refuses invitations that can no longer be accepted
+func (i *UserInvitation) checkPending(at time.Time) error {
+	switch i.Status(at) {
+	case UserInvitationAccepted:
+		return errors.NewConflictError("invitation has already been accepted")
+	case UserInvitationRevoked:
+		return errors.NewUnauthorizedError("invitation has been revoked")
+	case UserInvitationExpired:
+		return errors.NewUnauthorizedError("invitation has expired")
+	}
+	return nil
+}
+
+// UserInvitationLink is the signed activation link of an invitation
+type UserInvitationLink struct {
+	InvitationID uuid.UUID
+	ExpiresAt    time.Time
+	Signature    string
+}
+
+// NewUserInvitationLink signs an activation link for an invitation
+func NewUserInvitationLink(secret string, invitation *UserInvitation) *UserInvitationLink {
+	link := &UserInvitationLink{
+		InvitationID: invitation.ID,
+		ExpiresAt:    time.Unix(invitation.ExpiresAt.Unix(), 0),
+	}
+	link.Signature = hex.EncodeToString(link.mac(secret))
+	return link
+}
+
+// Verify checks the link was signed with secret and has not expired at now
+func (l *UserInvitationLink) Verify(secret string, now time.Time) error {
+	signature, err := hex.DecodeString(l.Signature)
+	if err != nil || !hmac.Equal(signature, l.mac(secret)) {
+		return errors.NewUnauthorizedError("invalid invitation link")
+	}
+	if !now.Before(l.ExpiresAt) {
+		return errors.NewUnauthorizedError("invitation has expired")
+	}
+	return nil
+}
+
+// mac returns the HMAC-SHA256 of the link's invitation and expiry
+func (l *UserInvitationLink) mac(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "invitation.%s.%d", l.InvitationID, l.ExpiresAt.Unix())
+	return mac.Sum(nil)
+}
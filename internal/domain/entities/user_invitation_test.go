@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInvitationSecret = "invitation-test-secret"
+
+func testUserInvitation(t *testing.T) (*User, *UserInvitation) {
+	user, err := NewInvitedUser(uuid.New(), "jdoe", "jdoe@example.com", "John", "Doe", RoleCashier)
+	require.NoError(t, err)
+
+	invitation, err := NewUserInvitation(user, uuid.New(), 72*time.Hour)
+	require.NoError(t, err)
+	return user, invitation
+}
+
+func TestNewInvitedUser(t *testing.T) {
+	user, _ := testUserInvitation(t)
+
+	assert.Equal(t, UserStatusInvited, user.Status)
+	assert.Empty(t, user.PasswordHash)
+	assert.False(t, user.ValidatePassword(""))
+
+	t.Run("cannot be activated without accepting", func(t *testing.T) {
+		assert.Error(t, user.ChangeStatus(UserStatusActive))
+	})
+
+	t.Run("accepting sets the password and activates", func(t *testing.T) {
+		require.NoError(t, user.AcceptInvitation("Correct1Horse"))
+
+		assert.Equal(t, UserStatusActive, user.Status)
+		assert.True(t, user.ValidatePassword("Correct1Horse"))
+		assert.Error(t, user.AcceptInvitation("Another2Horse"))
+	})
+}
+
+func TestNewUserInvitation(t *testing.T) {
+	user, invitation := testUserInvitation(t)
+
+	assert.Equal(t, user.ID, invitation.UserID)
+	assert.Equal(t, user.Email, invitation.Email)
+	assert.Equal(t, 1, invitation.SentCount)
+	assert.Equal(t, UserInvitationPending, invitation.Status(time.Now()))
+	assert.Equal(t, UserInvitationExpired, invitation.Status(invitation.ExpiresAt))
+
+	t.Run("requires an invited user", func(t *testing.T) {
+		active, err := NewUser(uuid.New(), "jane", "jane@example.com", "Jane", "Doe", "Correct1Horse", RoleCashier)
+		require.NoError(t, err)
+
+		_, err = NewUserInvitation(active, uuid.New(), time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestUserInvitation_CheckLink(t *testing.T) {
+	_, invitation := testUserInvitation(t)
+	now := time.Now()
+	link := NewUserInvitationLink(testInvitationSecret, invitation)
+
+	assert.NoError(t, invitation.CheckLink(link, testInvitationSecret, now))
+	assert.Error(t, invitation.CheckLink(link, "other-secret", now))
+	assert.Error(t, invitation.CheckLink(link, testInvitationSecret, invitation.ExpiresAt))
+
+	t.Run("rejects a tampered expiry", func(t *testing.T) {
+		tampered := *link
+		tampered.ExpiresAt = tampered.ExpiresAt.Add(24 * time.Hour)
+
+		assert.Error(t, tampered.Verify(testInvitationSecret, now))
+	})
+
+	t.Run("resending invalidates earlier links", func(t *testing.T) {
+		require.NoError(t, invitation.Resend(72*time.Hour, now))
+
+		assert.Equal(t, 2, invitation.SentCount)
+		assert.Error(t, invitation.CheckLink(link, testInvitationSecret, now))
+		assert.NoError(t, invitation.CheckLink(NewUserInvitationLink(testInvitationSecret, invitation), testInvitationSecret, now))
+	})
+
+	t.Run("revoking invalidates the link", func(t *testing.T) {
+		require.NoError(t, invitation.Revoke(uuid.New(), now))
+
+		current := NewUserInvitationLink(testInvitationSecret, invitation)
+		assert.Error(t, invitation.CheckLink(current, testInvitationSecret, now))
+		assert.Error(t, invitation.Resend(time.Hour, now))
+		assert.Equal(t, UserInvitationRevoked, invitation.Status(now))
+	})
+}
+
+func TestUserInvitation_Accept(t *testing.T) {
+	_, invitation := testUserInvitation(t)
+	now := time.Now()
+
+	require.NoError(t, invitation.Accept(now))
+	assert.Equal(t, UserInvitationAccepted, invitation.Status(now))
+	assert.Error(t, invitation.Accept(now))
+	assert.Error(t, invitation.Revoke(uuid.New(), now))
+
+	t.Run("expired invitations cannot be accepted", func(t *testing.T) {
+		_, expired := testUserInvitation(t)
+
+		assert.Error(t, expired.Accept(expired.ExpiresAt.Add(time.Minute)))
+	})
+}
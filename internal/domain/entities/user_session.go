@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// SessionRevokeReason records why a session was revoked
+type SessionRevokeReason string
+
+const (
+	SessionRevokedLogout        SessionRevokeReason = "logout"
+	SessionRevokedByUser        SessionRevokeReason = "revoked"
+	SessionRevokedByAdmin       SessionRevokeReason = "admin_revoked"
+	SessionRevokedTokenReuse    SessionRevokeReason = "refresh_token_reuse"
+	SessionRevokedPasswordReset SessionRevokeReason = "password_reset"
+)
+
+// UserSession represents a signed-in device of a user. Each session holds one refresh token at
+// a time; refreshing rotates it, and presenting a rotated token again revokes the session since
+// the token must have been stolen.
+type UserSession struct {
+	ID               uuid.UUID           `json:"id"`
+	UserID           uuid.UUID           `json:"user_id"`
+	TenantID         uuid.UUID           `json:"tenant_id"`
+	DeviceName       string              `json:"device_name,omitempty"`
+	UserAgent        string              `json:"user_agent,omitempty"`
+	IPAddress        string              `json:"ip_address,omitempty"`
+	RefreshTokenHash string              `json:"-"` // SHA-256 of the current refresh token
+	LastRefreshedAt  time.Time           `json:"last_refreshed_at"`
+	ExpiresAt        time.Time           `json:"expires_at"`
+	RevokedAt        *time.Time          `json:"revoked_at,omitempty"`
+	RevokedBy        *uuid.UUID          `json:"revoked_by,omitempty"`
+	RevokeReason     SessionRevokeReason `json:"revoke_reason,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
+
+// NewUserSession creates a new session for a user signing in from a device. The session holds no
+// refresh token until one is issued.
+func NewUserSession(userID, tenantID uuid.UUID, deviceName, userAgent, ipAddress string) (*UserSession, error) {
+	if userID == uuid.Nil {
+		return nil, errors.NewValidationError("user ID is required", "user ID cannot be empty")
+	}
+
+	deviceName = strings.TrimSpace(deviceName)
+	if len(deviceName) > 100 {
+		return nil, errors.NewValidationError("device name too long", "device name cannot exceed 100 characters")
+	}
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+
+	now := time.Now()
+	return &UserSession{
+		ID:              uuid.New(),
+		UserID:          userID,
+		TenantID:        tenantID,
+		DeviceName:      deviceName,
+		UserAgent:       userAgent,
+		IPAddress:       ipAddress,
+		LastRefreshedAt: now,
+		ExpiresAt:       now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// IssueRefreshToken makes refreshToken the only refresh token of the session and extends the
+// session until the token expires
+func (s *UserSession) IssueRefreshToken(refreshToken string, expiresAt time.Time, ipAddress string) error {
+	if s.RevokedAt != nil {
+		return errors.NewUnauthorizedError("session has been revoked")
+	}
+	if refreshToken == "" {
+		return errors.NewValidationError("refresh token is required", "refresh token cannot be empty")
+	}
+
+	now := time.Now()
+	s.RefreshTokenHash = HashRefreshToken(refreshToken)
+	s.LastRefreshedAt = now
+	s.ExpiresAt = expiresAt
+	if ipAddress != "" {
+		s.IPAddress = ipAddress
+	}
+	s.UpdatedAt = now
+	return nil
+}
+
+// MatchesRefreshToken checks if refreshToken is the current refresh token of the session
+func (s *UserSession) MatchesRefreshToken(refreshToken string) bool {
+	hash := HashRefreshToken(refreshToken)
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(s.RefreshTokenHash)) == 1
+}
+
+// IsActive checks if the session can still be used at the given time
+func (s *UserSession) IsActive(at time.Time) bool {
+	return s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// Revoke ends the session. revokedBy is nil when the system revokes it.
+func (s *UserSession) Revoke(revokedBy *uuid.UUID, reason SessionRevokeReason) error {
+	if s.RevokedAt != nil {
+		return errors.NewValidationError("session already revoked", "session is no longer active")
+	}
+
+	now := time.Now()
+	s.RevokedAt = &now
+	s.RevokedBy = revokedBy
+	s.RevokeReason = reason
+	s.UpdatedAt = now
+	return nil
+}
+
+// HashRefreshToken returns the stored representation of a refresh token
+func HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserSession(t *testing.T) {
+	t.Run("valid session", func(t *testing.T) {
+		userID := uuid.New()
+		tenantID := uuid.New()
+
+		session, err := NewUserSession(userID, tenantID, " Front counter iPad ", "Mozilla/5.0", "203.0.113.7")
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, session.ID)
+		assert.Equal(t, userID, session.UserID)
+		assert.Equal(t, tenantID, session.TenantID)
+		assert.Equal(t, "Front counter iPad", session.DeviceName)
+		assert.Empty(t, session.RefreshTokenHash)
+		assert.False(t, session.IsActive(time.Now()))
+	})
+
+	t.Run("user agent is truncated", func(t *testing.T) {
+		session, err := NewUserSession(uuid.New(), uuid.New(), "", strings.Repeat("a", 600), "")
+
+		require.NoError(t, err)
+		assert.Len(t, session.UserAgent, 500)
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		session, err := NewUserSession(uuid.Nil, uuid.New(), "", "", "")
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+
+	t.Run("device name too long", func(t *testing.T) {
+		session, err := NewUserSession(uuid.New(), uuid.New(), strings.Repeat("a", 101), "", "")
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+}
+
+func TestUserSession_IssueRefreshToken(t *testing.T) {
+	session, err := NewUserSession(uuid.New(), uuid.New(), "", "", "203.0.113.7")
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	require.NoError(t, session.IssueRefreshToken("first", expiresAt, ""))
+
+	assert.True(t, session.IsActive(time.Now()))
+	assert.Equal(t, expiresAt, session.ExpiresAt)
+	assert.Equal(t, "203.0.113.7", session.IPAddress)
+	assert.True(t, session.MatchesRefreshToken("first"))
+	assert.NotContains(t, session.RefreshTokenHash, "first")
+
+	t.Run("rotation replaces the previous token", func(t *testing.T) {
+		require.NoError(t, session.IssueRefreshToken("second", expiresAt.Add(time.Hour), "198.51.100.4"))
+
+		assert.True(t, session.MatchesRefreshToken("second"))
+		assert.False(t, session.MatchesRefreshToken("first"))
+		assert.Equal(t, "198.51.100.4", session.IPAddress)
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		assert.Error(t, session.IssueRefreshToken("", expiresAt, ""))
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		require.NoError(t, session.Revoke(nil, SessionRevokedLogout))
+
+		assert.Error(t, session.IssueRefreshToken("third", expiresAt, ""))
+	})
+}
+
+func TestUserSession_IsActive(t *testing.T) {
+	session, err := NewUserSession(uuid.New(), uuid.New(), "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, session.IssueRefreshToken("token", time.Now().Add(time.Hour), ""))
+
+	assert.True(t, session.IsActive(time.Now()))
+	assert.False(t, session.IsActive(time.Now().Add(2*time.Hour)))
+}
+
+func TestUserSession_Revoke(t *testing.T) {
+	session, err := NewUserSession(uuid.New(), uuid.New(), "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, session.IssueRefreshToken("token", time.Now().Add(time.Hour), ""))
+
+	adminID := uuid.New()
+	require.NoError(t, session.Revoke(&adminID, SessionRevokedByAdmin))
+
+	assert.False(t, session.IsActive(time.Now()))
+	require.NotNil(t, session.RevokedAt)
+	assert.Equal(t, &adminID, session.RevokedBy)
+	assert.Equal(t, SessionRevokedByAdmin, session.RevokeReason)
+
+	assert.Error(t, session.Revoke(&adminID, SessionRevokedByAdmin))
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/nicklaros/adol/pkg/errors"
 )
 
@@ -259,6 +261,30 @@ func TestUserPermissions(t *testing.T) {
 	}
 }
 
+func TestUserCanManageUser(t *testing.T) {
+	manager, err := NewUser("manager", "manager@example.com", "Jane", "Doe", "password123", RoleManager)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	cashier, err := NewUser("cashier", "cashier@example.com", "John", "Doe", "password123", RoleCashier)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	cashier.TenantID = manager.TenantID
+
+	if !manager.CanManageUser(cashier) {
+		t.Errorf("Expected manager to manage a user of their tenant")
+	}
+	if cashier.CanManageUser(manager) {
+		t.Errorf("Expected cashier not to manage other users")
+	}
+
+	cashier.TenantID = uuid.New()
+	if manager.CanManageUser(cashier) {
+		t.Errorf("Expected manager not to manage a user of another tenant")
+	}
+}
+
 func TestUserStatusChanges(t *testing.T) {
 	user, err := NewUser("testuser", "test@example.com", "John", "Doe", "password123", RoleEmployee)
 	if err != nil {
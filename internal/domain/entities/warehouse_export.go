@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// WarehouseDataset identifies a table exported to the tenant's data warehouse
+type WarehouseDataset string
+
+const (
+	WarehouseDatasetSales           WarehouseDataset = "sales"
+	WarehouseDatasetSaleItems       WarehouseDataset = "sale_items"
+	WarehouseDatasetPayments        WarehouseDataset = "payments"
+	WarehouseDatasetStockMovements  WarehouseDataset = "stock_movements"
+	WarehouseDatasetCostAdjustments WarehouseDataset = "inventory_cost_adjustments"
+)
+
+const (
+	// WarehouseExportSettleDelay keeps the export window behind the current time so rows
+	// still being written by in-flight transactions are picked up by the next window
+	WarehouseExportSettleDelay = 5 * time.Minute
+	// WarehouseExportMaxWindow is the longest span exported in one file; windows never
+	// cross a UTC day so every file belongs to exactly one date partition
+	WarehouseExportMaxWindow = 24 * time.Hour
+)
+
+// WarehouseDatasets returns the exported datasets in export order
+func WarehouseDatasets() []WarehouseDataset {
+	return []WarehouseDataset{
+		WarehouseDatasetSales,
+		WarehouseDatasetSaleItems,
+		WarehouseDatasetPayments,
+		WarehouseDatasetStockMovements,
+		WarehouseDatasetCostAdjustments,
+	}
+}
+
+// ValidateWarehouseDataset validates a warehouse dataset name
+func ValidateWarehouseDataset(dataset WarehouseDataset) error {
+	for _, valid := range WarehouseDatasets() {
+		if dataset == valid {
+			return nil
+		}
+	}
+	return errors.NewValidationError("invalid dataset", "dataset must be one of: sales, sale_items, payments, stock_movements, inventory_cost_adjustments")
+}
+
+// WarehouseExportSetting represents a tenant's opt-in to the scheduled Parquet export of its
+// sales and stock data. ExportedThrough is the watermark: every change before it has been exported.
+type WarehouseExportSetting struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	IsEnabled       bool       `json:"is_enabled"`
+	ExportedThrough *time.Time `json:"exported_through,omitempty"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	UpdatedBy       uuid.UUID  `json:"updated_by"`
+}
+
+// NewWarehouseExportSetting creates a disabled warehouse export setting
+func NewWarehouseExportSetting(tenantID, updatedBy uuid.UUID) (*WarehouseExportSetting, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	setting := &WarehouseExportSetting{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		IsEnabled: false,
+		CreatedAt: now,
+		UpdatedAt: now,
+		UpdatedBy: updatedBy,
+	}
+
+	return setting, nil
+}
+
+// Configure opts the tenant in or out of the export
+func (s *WarehouseExportSetting) Configure(enabled bool, updatedBy uuid.UUID) {
+	s.IsEnabled = enabled
+	s.UpdatedBy = updatedBy
+	s.UpdatedAt = time.Now()
+}
+
+// NextWindow returns the next span of changes to export at the given instant. The window
+// starts at the watermark, or at origin for the first export, and ends at the next UTC
+// midnight or the settle cut-off, whichever comes first.
+func (s *WarehouseExportSetting) NextWindow(now, origin time.Time) (time.Time, time.Time, bool) {
+	if !s.IsEnabled {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start := origin.UTC().Truncate(WarehouseExportMaxWindow)
+	if s.ExportedThrough != nil {
+		start = s.ExportedThrough.UTC()
+	}
+
+	// Whole seconds keep the watermark exact after a round trip through the database
+	end := start.Truncate(WarehouseExportMaxWindow).Add(WarehouseExportMaxWindow)
+	if cutoff := now.UTC().Add(-WarehouseExportSettleDelay).Truncate(time.Second); cutoff.Before(end) {
+		end = cutoff
+	}
+
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, end, true
+}
+
+// WarehouseExportFile represents a Parquet file written to the tenant's warehouse prefix
+type WarehouseExportFile struct {
+	ID            uuid.UUID        `json:"id"`
+	TenantID      uuid.UUID        `json:"tenant_id"`
+	Dataset       WarehouseDataset `json:"dataset"`
+	PartitionDate time.Time        `json:"partition_date"`
+	ObjectKey     string           `json:"object_key"`
+	RowCount      int              `json:"row_count"`
+	SizeBytes     int64            `json:"size_bytes"`
+	WindowStart   time.Time        `json:"window_start"`
+	WindowEnd     time.Time        `json:"window_end"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// NewWarehouseExportFile creates the record of an exported file. The object key is laid out
+// as Hive-style partitions so Athena and BigQuery can prune by tenant, dataset and date.
+func NewWarehouseExportFile(tenantID uuid.UUID, dataset WarehouseDataset, windowStart, windowEnd time.Time, rowCount int, sizeBytes int64) (*WarehouseExportFile, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+	if err := ValidateWarehouseDataset(dataset); err != nil {
+		return nil, err
+	}
+	if !windowEnd.After(windowStart) {
+		return nil, errors.NewValidationError("invalid export window", "window end must be after window start")
+	}
+
+	windowStart = windowStart.UTC()
+	windowEnd = windowEnd.UTC()
+	partitionDate := windowStart.Truncate(WarehouseExportMaxWindow)
+
+	file := &WarehouseExportFile{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		Dataset:       dataset,
+		PartitionDate: partitionDate,
+		ObjectKey:     WarehouseObjectKey(tenantID, dataset, partitionDate, windowStart, windowEnd),
+		RowCount:      rowCount,
+		SizeBytes:     sizeBytes,
+		WindowStart:   windowStart,
+		WindowEnd:     windowEnd,
+		CreatedAt:     time.Now(),
+	}
+
+	return file, nil
+}
+
+// WarehousePrefix returns the storage prefix holding all of a tenant's warehouse files
+func WarehousePrefix(tenantID uuid.UUID) string {
+	return fmt.Sprintf("warehouse/tenant_id=%s", tenantID)
+}
+
+// WarehouseObjectKey returns the storage key of the file for a dataset and window. Keys are
+// deterministic so re-exporting a window overwrites the previous file instead of duplicating it.
+func WarehouseObjectKey(tenantID uuid.UUID, dataset WarehouseDataset, partitionDate, windowStart, windowEnd time.Time) string {
+	return fmt.Sprintf("%s/%s/dt=%s/part-%s-%s.parquet",
+		WarehousePrefix(tenantID), dataset, partitionDate.Format("2006-01-02"),
+		windowStart.Format("20060102T150405Z"), windowEnd.Format("20060102T150405Z"))
+}
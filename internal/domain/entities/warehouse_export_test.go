@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWarehouseExportSetting(t *testing.T) {
+	t.Run("valid setting creation", func(t *testing.T) {
+		tenantID := uuid.New()
+
+		setting, err := NewWarehouseExportSetting(tenantID, uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, setting.TenantID)
+		assert.False(t, setting.IsEnabled)
+		assert.Nil(t, setting.ExportedThrough)
+	})
+
+	t.Run("tenant required", func(t *testing.T) {
+		setting, err := NewWarehouseExportSetting(uuid.Nil, uuid.New())
+
+		assert.Error(t, err)
+		assert.Nil(t, setting)
+	})
+}
+
+func TestWarehouseExportSetting_NextWindow(t *testing.T) {
+	origin := time.Date(2026, 10, 13, 9, 45, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("disabled setting has no window", func(t *testing.T) {
+		setting := createValidWarehouseExportSetting(t)
+		setting.IsEnabled = false
+
+		_, _, ok := setting.NextWindow(now, origin)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("first window starts at the origin date", func(t *testing.T) {
+		setting := createValidWarehouseExportSetting(t)
+
+		start, end, ok := setting.NextWindow(now, origin)
+
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), end)
+	})
+
+	t.Run("window ends at the next UTC midnight", func(t *testing.T) {
+		setting := createValidWarehouseExportSetting(t)
+		watermark := time.Date(2026, 10, 14, 18, 30, 0, 0, time.UTC)
+		setting.ExportedThrough = &watermark
+
+		start, end, ok := setting.NextWindow(now, origin)
+
+		require.True(t, ok)
+		assert.Equal(t, watermark, start)
+		assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), end)
+	})
+
+	t.Run("window stops at the settle cut-off", func(t *testing.T) {
+		setting := createValidWarehouseExportSetting(t)
+		watermark := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+		setting.ExportedThrough = &watermark
+
+		start, end, ok := setting.NextWindow(now, origin)
+
+		require.True(t, ok)
+		assert.Equal(t, watermark, start)
+		assert.Equal(t, now.Add(-WarehouseExportSettleDelay), end)
+	})
+
+	t.Run("caught up", func(t *testing.T) {
+		setting := createValidWarehouseExportSetting(t)
+		watermark := now.Add(-time.Minute)
+		setting.ExportedThrough = &watermark
+
+		_, _, ok := setting.NextWindow(now, origin)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestNewWarehouseExportFile(t *testing.T) {
+	tenantID := uuid.New()
+	start := time.Date(2026, 10, 14, 18, 30, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("valid file", func(t *testing.T) {
+		file, err := NewWarehouseExportFile(tenantID, WarehouseDatasetSaleItems, start, end, 12, 2048)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), file.PartitionDate)
+		assert.Equal(t,
+			"warehouse/tenant_id="+tenantID.String()+"/sale_items/dt=2026-10-14/part-20261014T183000Z-20261015T000000Z.parquet",
+			file.ObjectKey)
+		assert.Equal(t, 12, file.RowCount)
+	})
+
+	t.Run("invalid dataset", func(t *testing.T) {
+		_, err := NewWarehouseExportFile(tenantID, "customers", start, end, 1, 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		_, err := NewWarehouseExportFile(tenantID, WarehouseDatasetSales, end, start, 1, 1)
+
+		assert.Error(t, err)
+	})
+}
+
+// Helper function to create an enabled warehouse export setting for testing
+func createValidWarehouseExportSetting(t *testing.T) *WarehouseExportSetting {
+	setting, err := NewWarehouseExportSetting(uuid.New(), uuid.New())
+	require.NoError(t, err)
+	setting.Configure(true, uuid.New())
+	return setting
+}
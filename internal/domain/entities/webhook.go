@@ -0,0 +1,446 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// MaxWebhookAttempts caps how many times a delivery is attempted before it is marked failed,
+	// unless the tenant's retry policy says otherwise
+	MaxWebhookAttempts = 8
+
+	// WebhookSignatureHeader carries the timestamped HMAC-SHA256 signature of a delivery body
+	WebhookSignatureHeader = "X-Adol-Signature"
+
+	// WebhookDeliveryHeader carries the delivery's idempotency key so consumers can discard
+	// duplicates. Replays of a delivery carry the key of the original delivery.
+	WebhookDeliveryHeader = "X-Adol-Delivery"
+
+	// WebhookReplayHeader is set on deliveries that replay an earlier delivery
+	WebhookReplayHeader = "X-Adol-Replay"
+
+	// WebhookEventHeader carries the event type of a delivery
+	WebhookEventHeader = "X-Adol-Event"
+
+	webhookSecretPrefix = "whsec_"
+	webhookBaseBackoff  = 30 * time.Second
+	webhookMaxBackoff   = 6 * time.Hour
+)
+
+// WebhookEventType represents an event tenants can subscribe webhook endpoints to
+type WebhookEventType string
+
+const (
+	WebhookEventSaleCompleted  WebhookEventType = "sale.completed"
+	WebhookEventInvoicePaid    WebhookEventType = "invoice.paid"
+	WebhookEventStockLow       WebhookEventType = "stock.low"
+	WebhookEventProductUpdated WebhookEventType = "product.updated"
+	// WebhookEventCatalogItemUpserted carries the product, price and stock changes of a
+	// catalog upsert from an external system of record as one event
+	WebhookEventCatalogItemUpserted WebhookEventType = "catalog_item.upserted"
+)
+
+// WebhookEventTypes lists every event a webhook endpoint can subscribe to
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventSaleCompleted,
+	WebhookEventInvoicePaid,
+	WebhookEventStockLow,
+	WebhookEventProductUpdated,
+	WebhookEventCatalogItemUpserted,
+}
+
+// IsValid reports whether the event type is known
+func (t WebhookEventType) IsValid() bool {
+	for _, eventType := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"   // Waiting for its next attempt
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered" // Acknowledged with a 2xx response
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"    // Gave up after the last attempt
+)
+
+// WebhookEndpoint represents a URL a tenant receives signed event notifications on
+type WebhookEndpoint struct {
+	ID                  uuid.UUID          `json:"id"`
+	TenantID            uuid.UUID          `json:"tenant_id"`
+	URL                 string             `json:"url"`
+	Secret              string             `json:"-"` // Shared secret the deliveries are signed with
+	Events              []WebhookEventType `json:"events"`
+	Description         string             `json:"description,omitempty"`
+	IsActive            bool               `json:"is_active"`
+	ConsecutiveFailures int                `json:"consecutive_failures"` // Failed attempts since the last successful one
+	PausedAt            *time.Time         `json:"paused_at,omitempty"`  // Set when the endpoint was paused for failing repeatedly
+	PauseReason         string             `json:"pause_reason,omitempty"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at"`
+	CreatedBy           uuid.UUID          `json:"created_by"`
+}
+
+// WebhookDelivery represents one event sent to one endpoint, kept for retries and replay
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	TenantID       uuid.UUID             `json:"tenant_id"`
+	EndpointID     uuid.UUID             `json:"endpoint_id"`
+	EventID        uuid.UUID             `json:"event_id"` // Shared by the deliveries of one event to several endpoints
+	EventType      WebhookEventType      `json:"event_type"`
+	ReplayOf       *uuid.UUID            `json:"replay_of,omitempty"` // Original delivery this one replays
+	Payload        json.RawMessage       `json:"payload"`             // Exact body that is sent and signed
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// WebhookEnvelope is the JSON body of every webhook delivery
+type WebhookEnvelope struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	TenantID  uuid.UUID        `json:"tenant_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// NewWebhookEndpoint creates an active webhook endpoint with a freshly generated secret
+func NewWebhookEndpoint(tenantID uuid.UUID, endpointURL string, events []WebhookEventType, description string, createdBy uuid.UUID) (*WebhookEndpoint, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required", "tenant ID cannot be empty")
+	}
+
+	now := time.Now()
+	endpoint := &WebhookEndpoint{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+	}
+	if err := endpoint.Update(endpointURL, events, description, true); err != nil {
+		return nil, err
+	}
+	if err := endpoint.RotateSecret(); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// Update changes the endpoint's URL, subscribed events, description and active flag.
+// Activating a paused endpoint resumes it with a clean failure count.
+func (e *WebhookEndpoint) Update(endpointURL string, events []WebhookEventType, description string, isActive bool) error {
+	endpointURL = strings.TrimSpace(endpointURL)
+	if err := validateWebhookURL(endpointURL); err != nil {
+		return err
+	}
+
+	subscribed, err := normalizeWebhookEvents(events)
+	if err != nil {
+		return err
+	}
+
+	description = strings.TrimSpace(description)
+	if len(description) > 255 {
+		return errors.NewValidationError("description too long", "description cannot exceed 255 characters")
+	}
+
+	e.URL = endpointURL
+	e.Events = subscribed
+	e.Description = description
+	if isActive && !e.IsActive {
+		e.ConsecutiveFailures = 0
+		e.PausedAt = nil
+		e.PauseReason = ""
+	}
+	e.IsActive = isActive
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// Pause deactivates an endpoint that keeps failing. Its pending deliveries are failed and
+// can be replayed once the endpoint is activated again.
+func (e *WebhookEndpoint) Pause(reason string) {
+	now := time.Now()
+	e.IsActive = false
+	e.PausedAt = &now
+	e.PauseReason = reason
+	e.UpdatedAt = now
+}
+
+// IsPaused reports whether the endpoint was deactivated automatically
+func (e *WebhookEndpoint) IsPaused() bool {
+	return !e.IsActive && e.PausedAt != nil
+}
+
+// RotateSecret replaces the signing secret. Consumers must switch to the new secret.
+func (e *WebhookEndpoint) RotateSecret() error {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return err
+	}
+
+	e.Secret = secret
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// newWebhookSecret generates a random secret to sign webhook payloads with
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.NewInternalError("failed to generate webhook secret", err)
+	}
+
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+// Subscribes reports whether the endpoint is active and receives the event type
+func (e *WebhookEndpoint) Subscribes(eventType WebhookEventType) bool {
+	if !e.IsActive {
+		return false
+	}
+	for _, event := range e.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWebhookPayload serializes an event into the envelope sent to every subscribed endpoint
+func NewWebhookPayload(eventID uuid.UUID, eventType WebhookEventType, tenantID uuid.UUID, occurredAt time.Time, data interface{}) ([]byte, error) {
+	if !eventType.IsValid() {
+		return nil, errors.NewValidationError("invalid webhook event", fmt.Sprintf("unknown event type %q", eventType))
+	}
+
+	payload, err := json.Marshal(WebhookEnvelope{
+		ID:        eventID,
+		Type:      eventType,
+		TenantID:  tenantID,
+		CreatedAt: occurredAt.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to serialize webhook payload", err)
+	}
+
+	return payload, nil
+}
+
+// NewWebhookDelivery creates a pending delivery of a serialized event to an endpoint, due immediately
+func NewWebhookDelivery(endpoint *WebhookEndpoint, eventID uuid.UUID, eventType WebhookEventType, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		TenantID:      endpoint.TenantID,
+		EndpointID:    endpoint.ID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// NewWebhookReplay creates a pending delivery that sends an earlier delivery's payload to the
+// same endpoint again. Replays of a replay point at the original delivery, so every copy
+// shares its idempotency key.
+func NewWebhookReplay(original *WebhookDelivery) (*WebhookDelivery, error) {
+	if original.Status == WebhookDeliveryStatusPending {
+		return nil, errors.NewConflictError("delivery is still pending and will be retried automatically")
+	}
+
+	now := time.Now()
+	replayOf := original.IdempotencyKey()
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		TenantID:      original.TenantID,
+		EndpointID:    original.EndpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		ReplayOf:      &replayOf,
+		Payload:       original.Payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// IdempotencyKey returns the ID consumers deduplicate the delivery by: the original delivery's
+// ID for replays, its own ID otherwise
+func (d *WebhookDelivery) IdempotencyKey() uuid.UUID {
+	if d.ReplayOf != nil {
+		return *d.ReplayOf
+	}
+	return d.ID
+}
+
+// IsReplay reports whether the delivery replays an earlier delivery
+func (d *WebhookDelivery) IsReplay() bool {
+	return d.ReplayOf != nil
+}
+
+// RecordAttempt records the outcome of sending the delivery. A 2xx response marks it delivered;
+// anything else schedules a retry with the policy's backoff until its attempts run out.
+func (d *WebhookDelivery) RecordAttempt(at time.Time, responseStatus int, sendErr error, policy RetryPolicy) {
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.ResponseStatus = responseStatus
+	d.UpdatedAt = time.Now()
+
+	if sendErr == nil && responseStatus >= 200 && responseStatus < 300 {
+		d.Status = WebhookDeliveryStatusDelivered
+		d.DeliveredAt = &at
+		d.NextAttemptAt = nil
+		d.LastError = ""
+		return
+	}
+
+	if sendErr != nil {
+		d.LastError = sendErr.Error()
+	} else {
+		d.LastError = fmt.Sprintf("endpoint responded with status %d", responseStatus)
+	}
+
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = WebhookDeliveryStatusFailed
+		d.NextAttemptAt = nil
+		return
+	}
+
+	next := at.Add(policy.Backoff(d.Attempts))
+	d.Status = WebhookDeliveryStatusPending
+	d.NextAttemptAt = &next
+}
+
+// Fail marks the delivery failed without attempting it, e.g. when its endpoint was disabled
+func (d *WebhookDelivery) Fail(reason string) {
+	d.Status = WebhookDeliveryStatusFailed
+	d.NextAttemptAt = nil
+	d.LastError = reason
+	d.UpdatedAt = time.Now()
+}
+
+// WebhookBackoff returns how long to wait before retrying after the given number of attempts
+// under the default policy: 30 seconds doubling on each attempt, capped at 6 hours
+func WebhookBackoff(attempts int) time.Duration {
+	return DefaultWebhookRetryPolicy().Backoff(attempts)
+}
+
+// SignWebhookPayload signs a delivery body sent at the given time. Consumers recompute the
+// HMAC-SHA256 of "<timestamp>.<body>" with their secret and compare it to v1.
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	unix := timestamp.Unix()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", unix)
+	mac.Write(payload)
+
+	return fmt.Sprintf("t=%d,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// validateWebhookURL checks that a webhook URL is an absolute http(s) URL
+func validateWebhookURL(endpointURL string) error {
+	if endpointURL == "" {
+		return errors.NewValidationError("webhook URL is required", "url cannot be empty")
+	}
+	if len(endpointURL) > 2048 {
+		return errors.NewValidationError("webhook URL too long", "url cannot exceed 2048 characters")
+	}
+
+	parsed, err := url.Parse(endpointURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return errors.NewValidationError("invalid webhook URL", "url must be an absolute http or https URL")
+	}
+	if parsed.User != nil {
+		return errors.NewValidationError("invalid webhook URL", "url cannot contain credentials")
+	}
+
+	// Hosts resolving to internal addresses are refused again when deliveries are sent
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.NewValidationError("invalid webhook URL", "url cannot point to a local address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicWebhookAddress(addr) {
+		return errors.NewValidationError("invalid webhook URL", "url cannot point to a loopback, private or link-local address")
+	}
+
+	return nil
+}
+
+// nonPublicWebhookPrefixes are special-purpose IPv4 ranges not covered by the netip checks
+var nonPublicWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // This network
+	netip.MustParsePrefix("100.64.0.0/10"), // Shared address space of carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, including broadcast
+}
+
+// IsPublicWebhookAddress reports whether webhooks may be delivered to an IP address. Loopback,
+// private, link-local and other special-purpose addresses, such as the cloud metadata service
+// at 169.254.169.254, reach the server's own network rather than a tenant's endpoint.
+func IsPublicWebhookAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicWebhookPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeWebhookEvents validates and de-duplicates subscribed event types
+func normalizeWebhookEvents(events []WebhookEventType) ([]WebhookEventType, error) {
+	if len(events) == 0 {
+		return nil, errors.NewValidationError("events are required", "subscribe to at least one event")
+	}
+
+	seen := make(map[WebhookEventType]bool, len(events))
+	subscribed := make([]WebhookEventType, 0, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			return nil, errors.NewValidationError("invalid webhook event", fmt.Sprintf("unknown event type %q", event))
+		}
+		if seen[event] {
+			continue
+		}
+		seen[event] = true
+		subscribed = append(subscribed, event)
+	}
+
+	return subscribed, nil
+}
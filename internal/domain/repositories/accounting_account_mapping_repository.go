@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// AccountingAccountMappingRepository defines the interface for accounting account mapping data access
+type AccountingAccountMappingRepository interface {
+	// GetByTenant retrieves the account mapping of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.AccountingAccountMapping, error)
+
+	// Save creates or updates the account mapping of a tenant
+	Save(ctx context.Context, mapping *entities.AccountingAccountMapping) error
+}
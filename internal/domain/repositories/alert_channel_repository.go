@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// AlertChannelRepository defines the interface for alert channel data access
+type AlertChannelRepository interface {
+	// Create creates a new alert channel
+	Create(ctx context.Context, channel *entities.AlertChannel) error
+
+	// GetByID retrieves an alert channel by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.AlertChannel, error)
+
+	// GetByTenant retrieves all alert channels of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.AlertChannel, error)
+
+	// GetActiveByTenant retrieves the active alert channels of a tenant
+	GetActiveByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.AlertChannel, error)
+
+	// Update updates an alert channel's settings
+	Update(ctx context.Context, channel *entities.AlertChannel) error
+
+	// RecordNotification saves the outcome of the latest notification sent to a channel
+	RecordNotification(ctx context.Context, channel *entities.AlertChannel) error
+
+	// Delete deletes an alert channel
+	Delete(ctx context.Context, id uuid.UUID) error
+}
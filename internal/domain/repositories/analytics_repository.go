@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// AnalyticsRepository defines the interface for running analytics queries against the reporting tables
+type AnalyticsRepository interface {
+	// Query runs a normalized analytics query scoped to a tenant
+	Query(ctx context.Context, tenantID uuid.UUID, query entities.AnalyticsQuery) (*AnalyticsResult, error)
+}
+
+// AnalyticsColumn describes a column of an analytics result
+type AnalyticsColumn struct {
+	Name string                      `json:"name"`
+	Type entities.AnalyticsFieldType `json:"type"`
+	Role string                      `json:"role"` // dimension or measure
+}
+
+// AnalyticsResult represents the rows of an analytics query. Each row holds one value per
+// column, in column order.
+type AnalyticsResult struct {
+	Columns   []AnalyticsColumn `json:"columns"`
+	Rows      [][]interface{}   `json:"rows"`
+	Truncated bool              `json:"truncated"` // More rows matched than the query limit
+}
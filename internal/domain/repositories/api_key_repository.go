@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	// Create creates a new API key
+	Create(ctx context.Context, apiKey *entities.APIKey) error
+
+	// GetByID retrieves an API key by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+
+	// GetByKeyHash retrieves an API key by the hash of its key
+	GetByKeyHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+
+	// Update updates an existing API key
+	Update(ctx context.Context, apiKey *entities.APIKey) error
+
+	// GetByTenant retrieves all API keys for a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.APIKey, error)
+
+	// RecordUse records when and from where an API key was last used
+	RecordUse(ctx context.Context, id uuid.UUID, at time.Time, ip string) error
+}
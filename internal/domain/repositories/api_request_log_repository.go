@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// APIRequestLogRepository defines the interface for API request log data access
+type APIRequestLogRepository interface {
+	// Create stores a captured API request
+	Create(ctx context.Context, log *entities.APIRequestLog) error
+
+	// GetByRequestID retrieves a captured API request by its request ID
+	GetByRequestID(ctx context.Context, requestID string) (*entities.APIRequestLog, error)
+
+	// List retrieves a tenant's captured API requests matching the filter, newest first
+	List(ctx context.Context, filter APIRequestLogFilter) ([]*entities.APIRequestLog, error)
+
+	// DeleteExpired permanently deletes logs that expired before the given time and returns
+	// how many were deleted
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// APIRequestLogFilter represents API request log filter
+type APIRequestLogFilter struct {
+	TenantID      uuid.UUID
+	FromDate      time.Time
+	ToDate        time.Time
+	MinStatusCode int   // Only requests answered with at least this status code, 0 for any
+	MinDurationMs int64 // Only requests that took at least this long, 0 for any
+	Limit         int
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// AttachmentRepository defines the interface for attachment data access
+type AttachmentRepository interface {
+	// Create creates a new attachment
+	Create(ctx context.Context, attachment *entities.Attachment) error
+
+	// GetByID retrieves an attachment by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Attachment, error)
+
+	// ListByOwner retrieves the attachments of an invoice or sale, oldest first
+	ListByOwner(ctx context.Context, ownerType entities.AttachmentOwnerType, ownerID uuid.UUID) ([]*entities.Attachment, error)
+
+	// Delete deletes an attachment
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// CategoryRepository defines the interface for product category data access
+type CategoryRepository interface {
+	// GetByTenant retrieves all the tenant's categories
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.Category, error)
+
+	// GetByID retrieves a category by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+
+	// GetByName retrieves the tenant's category with the given name, ignoring case
+	GetByName(ctx context.Context, tenantID uuid.UUID, name string) (*entities.Category, error)
+
+	// Create creates a new category
+	Create(ctx context.Context, category *entities.Category) error
+
+	// Update updates a category. Renaming it renames the category of its products and of
+	// its margin floor along with it.
+	Update(ctx context.Context, category *entities.Category, oldName string) error
+
+	// Delete deletes a category
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CountProducts counts the products filed directly under the category
+	CountProducts(ctx context.Context, id uuid.UUID) (int, error)
+}
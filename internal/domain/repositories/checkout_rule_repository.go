@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// CheckoutRuleRepository defines the interface for checkout rule data access
+type CheckoutRuleRepository interface {
+	// Create creates a new checkout rule
+	Create(ctx context.Context, rule *entities.CheckoutRule) error
+
+	// GetByID retrieves a checkout rule by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CheckoutRule, error)
+
+	// Update updates an existing checkout rule
+	Update(ctx context.Context, rule *entities.CheckoutRule) error
+
+	// Delete deletes a checkout rule
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByTenant retrieves all checkout rules for a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.CheckoutRule, error)
+
+	// GetActiveByTenant retrieves active checkout rules for a tenant
+	GetActiveByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.CheckoutRule, error)
+}
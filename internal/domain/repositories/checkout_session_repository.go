@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// CheckoutSessionRepository defines the interface for checkout session data access
+type CheckoutSessionRepository interface {
+	// Create creates a new checkout session
+	Create(ctx context.Context, session *entities.CheckoutSession) error
+
+	// GetByID retrieves a checkout session by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CheckoutSession, error)
+
+	// Update updates a checkout session
+	Update(ctx context.Context, session *entities.CheckoutSession) error
+
+	// GetIdle retrieves active checkout sessions across all tenants that expired before the
+	// given time, oldest first
+	GetIdle(ctx context.Context, before time.Time, limit int) ([]*entities.CheckoutSession, error)
+}
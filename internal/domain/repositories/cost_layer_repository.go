@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// CostLayerRepository defines the interface for cost layer data access
+type CostLayerRepository interface {
+	// Create records a cost layer
+	Create(ctx context.Context, layer *entities.CostLayer) error
+
+	// GetOpenByProductForUpdate retrieves the layers of a product with units remaining, oldest
+	// first, and locks them until the transaction ends
+	GetOpenByProductForUpdate(ctx context.Context, productID uuid.UUID) ([]*entities.CostLayer, error)
+
+	// UpdateRemaining updates the units remaining in a cost layer
+	UpdateRemaining(ctx context.Context, layer *entities.CostLayer) error
+}
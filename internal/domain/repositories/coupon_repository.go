@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// CouponRepository defines the interface for coupon data access
+type CouponRepository interface {
+	// Create creates a new coupon
+	Create(ctx context.Context, coupon *entities.Coupon) error
+
+	// GetByID retrieves a coupon by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Coupon, error)
+
+	// GetByCode retrieves the tenant's coupon with the given normalized code
+	GetByCode(ctx context.Context, tenantID uuid.UUID, code string) (*entities.Coupon, error)
+
+	// GetByCodeForUpdate retrieves the tenant's coupon with the given normalized code and locks
+	// it until the transaction ends, so concurrent sales cannot redeem it past its limit. It
+	// must be called on a transaction's repository.
+	GetByCodeForUpdate(ctx context.Context, tenantID uuid.UUID, code string) (*entities.Coupon, error)
+
+	// GetByTenant retrieves all the tenant's coupons, newest first
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.Coupon, error)
+
+	// Update updates a coupon. Its redemption count is only changed by CreateRedemption.
+	Update(ctx context.Context, coupon *entities.Coupon) error
+
+	// CreateRedemption records a coupon applied to a sale and counts it against the coupon's
+	// redemption limit. It must be called on a transaction's repository.
+	CreateRedemption(ctx context.Context, redemption *entities.CouponRedemption) error
+
+	// GetRedemptionsByCoupon retrieves the redemptions of a coupon, newest first
+	GetRedemptionsByCoupon(ctx context.Context, couponID uuid.UUID, limit int) ([]*entities.CouponRedemption, error)
+
+	// GetPerformanceLines retrieves the redemptions of each of the tenant's coupons between the
+	// dates, excluding refunded sales. Amounts are in the tenant's base currency.
+	GetPerformanceLines(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*CouponPerformanceLine, error)
+}
+
+// CouponPerformanceLine represents how a coupon performed within a date range
+type CouponPerformanceLine struct {
+	CouponID       uuid.UUID
+	Code           string
+	Redemptions    int
+	DiscountAmount decimal.Decimal
+	SalesTotal     decimal.Decimal
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// CustomerDocument is a kind of document linked to the customer it was made out to
+type CustomerDocument string
+
+const (
+	CustomerDocumentSale    CustomerDocument = "sale"
+	CustomerDocumentInvoice CustomerDocument = "invoice"
+)
+
+// CustomerContact is the customer snapshot of a sale or invoice not yet linked to a customer
+type CustomerContact struct {
+	Document   CustomerDocument
+	DocumentID uuid.UUID
+	TenantID   uuid.UUID
+	Name       string
+	Email      string
+	Phone      string
+}
+
+// CustomerMergeCounts counts the records re-linked from a merged customer to its survivor
+type CustomerMergeCounts struct {
+	Sales    int `json:"sales"`
+	Invoices int `json:"invoices"`
+}
+
+// CustomerRepository defines the interface for customer and duplicate customer data access
+type CustomerRepository interface {
+	// Create creates a new customer
+	Create(ctx context.Context, customer *entities.Customer) error
+
+	// GetByID retrieves a customer by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Customer, error)
+
+	// GetByIDForUpdate retrieves a customer by ID and locks it until the transaction ends. It
+	// must be called on a transaction's repository.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Customer, error)
+
+	// GetByContact retrieves the customer of a tenant with the normalized email or, when no
+	// email is given, the normalized phone number. Customers that were not merged away are
+	// preferred.
+	GetByContact(ctx context.Context, tenantID uuid.UUID, emailKey, phoneKey string) (*entities.Customer, error)
+
+	// Update updates a customer's details and merge state
+	Update(ctx context.Context, customer *entities.Customer) error
+
+	// ListActive retrieves the customers of a tenant that were not merged away
+	ListActive(ctx context.Context, tenantID uuid.UUID) ([]*entities.Customer, error)
+
+	// ListTenantIDs retrieves the tenants that have customers
+	ListTenantIDs(ctx context.Context) ([]uuid.UUID, error)
+
+	// ListUnlinkedContacts retrieves, in ID order after afterID, the contacts of settled
+	// documents with an email or phone number that are not linked to a customer yet
+	ListUnlinkedContacts(ctx context.Context, document CustomerDocument, afterID uuid.UUID, limit int) ([]*CustomerContact, error)
+
+	// LinkDocuments links documents to a customer
+	LinkDocuments(ctx context.Context, document CustomerDocument, customerID uuid.UUID, documentIDs []uuid.UUID) error
+
+	// Relink moves the sales, invoices and merged customers of a merged customer to its survivor
+	Relink(ctx context.Context, fromID, toID uuid.UUID) (*CustomerMergeCounts, error)
+
+	// SaveDuplicates records duplicate suggestions. A pair already suggested, in either order,
+	// is left as it is, so dismissed suggestions are not raised again.
+	SaveDuplicates(ctx context.Context, duplicates []*entities.CustomerDuplicate) error
+
+	// GetDuplicateByID retrieves a duplicate suggestion by ID
+	GetDuplicateByID(ctx context.Context, id uuid.UUID) (*entities.CustomerDuplicate, error)
+
+	// UpdateDuplicate updates a duplicate suggestion's status
+	UpdateDuplicate(ctx context.Context, duplicate *entities.CustomerDuplicate) error
+
+	// ListPendingDuplicates retrieves the pending duplicate suggestions of a tenant, best match first
+	ListPendingDuplicates(ctx context.Context, tenantID uuid.UUID, limit int) ([]*entities.CustomerDuplicate, error)
+
+	// ResolveMergedDuplicates marks the suggestion of a merged pair as merged and drops the other
+	// pending suggestions involving the merged customer; detection suggests them again against
+	// the survivor
+	ResolveMergedDuplicates(ctx context.Context, survivorID, mergedID, userID uuid.UUID, resolvedAt time.Time) error
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// DailyDigestSettingRepository defines the interface for daily digest setting data access
+type DailyDigestSettingRepository interface {
+	// GetByTenant retrieves the digest setting of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.DailyDigestSetting, error)
+
+	// Save creates or updates the digest setting of a tenant
+	Save(ctx context.Context, setting *entities.DailyDigestSetting) error
+
+	// ListEnabled retrieves all enabled digest settings across tenants
+	ListEnabled(ctx context.Context) ([]*entities.DailyDigestSetting, error)
+
+	// ClaimDigest atomically marks the digest for a business date as being sent.
+	// It returns false when the digest for that date was already claimed.
+	ClaimDigest(ctx context.Context, id uuid.UUID, date time.Time) (bool, error)
+
+	// ReleaseDigest restores the previous digest date after a failed send so it is retried
+	ReleaseDigest(ctx context.Context, id uuid.UUID, date time.Time, previous *time.Time) error
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// DeliveryPolicyRepository defines the interface for delivery policy data access and the
+// failure counts of email recipients the policies act on
+type DeliveryPolicyRepository interface {
+	// GetByTenant retrieves the delivery policy of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.DeliveryPolicy, error)
+
+	// Save creates or updates the delivery policy of a tenant
+	Save(ctx context.Context, policy *entities.DeliveryPolicy) error
+
+	// RecordEmailFailure counts a failed email to a normalized address and returns how many
+	// emails to it failed in a row
+	RecordEmailFailure(ctx context.Context, tenantID uuid.UUID, email, reason string, at time.Time) (int, error)
+
+	// ResetEmailFailures clears the failure count of an address after a successful email
+	ResetEmailFailures(ctx context.Context, tenantID uuid.UUID, email string) error
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// DocumentSignatureRepository defines the interface for document signature data access
+type DocumentSignatureRepository interface {
+	// Create stores a new document signature
+	Create(ctx context.Context, signature *entities.DocumentSignature) error
+
+	// GetByDocument retrieves the signature of a document
+	GetByDocument(ctx context.Context, documentType entities.SignableDocumentType, documentID uuid.UUID) (*entities.DocumentSignature, error)
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// EcommerceRepository defines the interface for e-commerce integration, order and product
+// link data access
+type EcommerceRepository interface {
+	// CreateIntegration creates a new e-commerce integration
+	CreateIntegration(ctx context.Context, integration *entities.EcommerceIntegration) error
+
+	// GetIntegrationByID retrieves an e-commerce integration by ID, with its credentials
+	GetIntegrationByID(ctx context.Context, id uuid.UUID) (*entities.EcommerceIntegration, error)
+
+	// GetIntegrationsByTenant retrieves all e-commerce integrations of a tenant
+	GetIntegrationsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.EcommerceIntegration, error)
+
+	// GetStockPushIntegrations retrieves the active integrations of every tenant that push
+	// stock levels to their store
+	GetStockPushIntegrations(ctx context.Context) ([]*entities.EcommerceIntegration, error)
+
+	// UpdateIntegration updates an e-commerce integration's settings and credentials
+	UpdateIntegration(ctx context.Context, integration *entities.EcommerceIntegration) error
+
+	// RecordOrderReceived sets when the integration last received an order
+	RecordOrderReceived(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// RecordStockPush saves the outcome of the latest stock push of an integration
+	RecordStockPush(ctx context.Context, integration *entities.EcommerceIntegration) error
+
+	// DeleteIntegration deletes an e-commerce integration with its orders and product links
+	DeleteIntegration(ctx context.Context, id uuid.UUID) error
+
+	// CreateOrder records an order received by an integration. It returns a conflict error
+	// when the order was already recorded.
+	CreateOrder(ctx context.Context, order *entities.EcommerceOrder) error
+
+	// GetOrderByExternalID retrieves an order of an integration by its ID on the store
+	GetOrderByExternalID(ctx context.Context, integrationID uuid.UUID, externalID string) (*entities.EcommerceOrder, error)
+
+	// UpdateOrder updates an order's status, sale, reservation and error
+	UpdateOrder(ctx context.Context, order *entities.EcommerceOrder) error
+
+	// ListOrders retrieves orders with pagination and filtering, newest first
+	ListOrders(ctx context.Context, filter EcommerceOrderFilter, pagination utils.PaginationInfo) ([]*entities.EcommerceOrder, utils.PaginationInfo, error)
+
+	// CreateProductLink links a product to a store product. It returns a conflict error when
+	// the product is already linked on the integration.
+	CreateProductLink(ctx context.Context, link *entities.EcommerceProductLink) error
+
+	// GetProductLinkByID retrieves a product link by ID
+	GetProductLinkByID(ctx context.Context, id uuid.UUID) (*entities.EcommerceProductLink, error)
+
+	// GetProductLinks retrieves the product links of an integration
+	GetProductLinks(ctx context.Context, integrationID uuid.UUID) ([]*entities.EcommerceProductLink, error)
+
+	// UpdateProductLink saves a product link's resolved inventory item and last pushed level
+	UpdateProductLink(ctx context.Context, link *entities.EcommerceProductLink) error
+
+	// DeleteProductLink deletes a product link
+	DeleteProductLink(ctx context.Context, id uuid.UUID) error
+}
+
+// EcommerceOrderFilter represents filters for e-commerce order queries
+type EcommerceOrderFilter struct {
+	IntegrationID uuid.UUID                      `json:"integration_id"`
+	Status        *entities.EcommerceOrderStatus `json:"status,omitempty"`
+}
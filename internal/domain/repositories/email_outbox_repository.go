@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// EmailOutboxRepository defines the interface for the queue of emails sent by the background worker
+type EmailOutboxRepository interface {
+	// Create queues an email
+	Create(ctx context.Context, email *entities.OutboxEmail) error
+
+	// GetByInvoice retrieves the emails queued for an invoice, most recent first
+	GetByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.OutboxEmail, error)
+
+	// ClaimDue atomically leases up to limit pending emails due at now by pushing their
+	// next attempt to leaseUntil, so concurrent workers never send the same email
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.OutboxEmail, error)
+
+	// Update records the outcome of a send attempt
+	Update(ctx context.Context, email *entities.OutboxEmail) error
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// EmailTemplateRepository defines the interface for tenant email template override data access
+type EmailTemplateRepository interface {
+	// GetByTenant retrieves all email template overrides of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.EmailTemplate, error)
+
+	// GetByKind retrieves the tenant's override of the template of a kind
+	GetByKind(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind) (*entities.EmailTemplate, error)
+
+	// Save creates or updates the tenant's override of the template's kind
+	Save(ctx context.Context, template *entities.EmailTemplate) error
+
+	// Delete deletes the tenant's override of the template of a kind
+	Delete(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind) error
+}
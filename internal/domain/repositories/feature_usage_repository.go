@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// FeatureUsageRepository defines the interface for feature usage data access. Usage is
+// platform-level data read across tenants by the admin API.
+type FeatureUsageRepository interface {
+	// Record adds the usage to the tenant's counter of the feature for the day
+	Record(ctx context.Context, usage *entities.FeatureUsage) error
+
+	// GetUsageLines retrieves how many tenants used each feature, and how often, per period
+	// between the dates
+	GetUsageLines(ctx context.Context, fromDate, toDate time.Time, period ReportPeriod) ([]*FeatureUsageLine, error)
+
+	// GetAdoptionLines retrieves, per feature, how many active or trial tenants had used it
+	// before toDate and how many of them used it for the first time on or after fromDate
+	GetAdoptionLines(ctx context.Context, fromDate, toDate time.Time) ([]*FeatureAdoptionLine, error)
+
+	// CountActiveTenants counts the tenants that are active or on trial
+	CountActiveTenants(ctx context.Context) (int, error)
+}
+
+// FeatureUsageLine represents the usage of a feature within one period
+type FeatureUsageLine struct {
+	PeriodStart   time.Time
+	Feature       entities.Feature
+	ActiveTenants int
+	Events        int
+}
+
+// FeatureAdoptionLine represents how many tenants have adopted a feature
+type FeatureAdoptionLine struct {
+	Feature         entities.Feature
+	AdoptingTenants int
+	NewAdopters     int
+}
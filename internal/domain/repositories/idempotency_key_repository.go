@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key data access
+type IdempotencyKeyRepository interface {
+	// Claim stores a new in-progress key. It takes over an existing key with the same tenant
+	// and key only when that one has expired or its in-progress lock was taken before
+	// lockedBefore, and reports whether the key was claimed.
+	Claim(ctx context.Context, key *entities.IdempotencyKey, lockedBefore time.Time) (bool, error)
+
+	// GetByKey retrieves a tenant's idempotency key
+	GetByKey(ctx context.Context, tenantID uuid.UUID, key string) (*entities.IdempotencyKey, error)
+
+	// Complete stores the response of a claimed key
+	Complete(ctx context.Context, key *entities.IdempotencyKey) error
+
+	// Delete releases a claimed key so the request can be retried
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteExpired permanently deletes keys that expired before the given time and returns
+	// how many were deleted
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
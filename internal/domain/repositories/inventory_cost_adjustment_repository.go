@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// InventoryCostAdjustmentRepository defines the interface for inventory cost adjustment journal data access
+type InventoryCostAdjustmentRepository interface {
+	// Create records a cost adjustment
+	Create(ctx context.Context, adjustment *entities.InventoryCostAdjustment) error
+
+	// GetByID retrieves a cost adjustment by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.InventoryCostAdjustment, error)
+
+	// List retrieves cost adjustments with pagination and filtering, newest first
+	List(ctx context.Context, filter InventoryCostAdjustmentFilter, pagination utils.PaginationInfo) ([]*entities.InventoryCostAdjustment, utils.PaginationInfo, error)
+
+	// GetTotals sums the cost adjustments of a tenant recorded in [from, to) per product and reason
+	GetTotals(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*CostAdjustmentTotal, error)
+}
+
+// InventoryCostAdjustmentFilter represents filters for cost adjustment queries
+type InventoryCostAdjustmentFilter struct {
+	ProductID *uuid.UUID                     `json:"product_id,omitempty"`
+	Reason    *entities.CostAdjustmentReason `json:"reason,omitempty"`
+	FromDate  *time.Time                     `json:"from_date,omitempty"`
+	ToDate    *time.Time                     `json:"to_date,omitempty"`
+}
+
+// CostAdjustmentTotal represents the cost adjustments of one product for one reason
+type CostAdjustmentTotal struct {
+	ProductID   uuid.UUID                     `json:"product_id"`
+	ProductSKU  string                        `json:"product_sku"`
+	ProductName string                        `json:"product_name"`
+	Reason      entities.CostAdjustmentReason `json:"reason"`
+	Count       int                           `json:"count"`
+	Quantity    int                           `json:"quantity"` // Units written down
+	Amount      decimal.Decimal               `json:"amount"`   // Change in inventory value; negative for write-downs
+}
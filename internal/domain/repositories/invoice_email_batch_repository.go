@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// InvoiceEmailBatchRepository defines the interface for bulk invoice email batch data access
+type InvoiceEmailBatchRepository interface {
+	// Create creates a new batch with its items
+	Create(ctx context.Context, batch *entities.InvoiceEmailBatch) error
+
+	// GetByID retrieves a batch with its items by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceEmailBatch, error)
+
+	// Update updates the status and counters of a batch
+	Update(ctx context.Context, batch *entities.InvoiceEmailBatch) error
+
+	// UpdateItem updates the outcome of a single batch item
+	UpdateItem(ctx context.Context, item *entities.InvoiceEmailBatchItem) error
+
+	// GetByTenant retrieves the most recent batches of a tenant without their items
+	GetByTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]*entities.InvoiceEmailBatch, error)
+}
+
+// EmailSuppressionRepository defines the interface for email suppression data access
+type EmailSuppressionRepository interface {
+	// Create creates a new email suppression, doing nothing if the address is already suppressed
+	Create(ctx context.Context, suppression *entities.EmailSuppression) error
+
+	// Delete removes the suppression of an address
+	Delete(ctx context.Context, tenantID uuid.UUID, email string) error
+
+	// GetByTenant retrieves all suppressed addresses of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.EmailSuppression, error)
+
+	// GetSuppressed returns which of the given normalized addresses are suppressed for a tenant
+	GetSuppressed(ctx context.Context, tenantID uuid.UUID, emails []string) (map[string]bool, error)
+}
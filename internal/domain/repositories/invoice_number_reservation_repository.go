@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// InvoiceNumberReservationRepository defines the interface for invoice number reservation data access
+type InvoiceNumberReservationRepository interface {
+	// Reserve takes the next number of the tenant's invoice number sequence for the reservation
+	// and creates it, atomically so that no number is skipped
+	Reserve(ctx context.Context, reservation *entities.InvoiceNumberReservation) error
+
+	// GetByID retrieves an invoice number reservation by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceNumberReservation, error)
+
+	// List retrieves invoice number reservations with pagination and filtering
+	List(ctx context.Context, filter InvoiceNumberReservationFilter, pagination utils.PaginationInfo) ([]*entities.InvoiceNumberReservation, utils.PaginationInfo, error)
+
+	// Bind marks the reservation bound and gives its number to the draft invoice it is bound to,
+	// atomically
+	Bind(ctx context.Context, reservation *entities.InvoiceNumberReservation) error
+
+	// Void marks the reservation voided
+	Void(ctx context.Context, reservation *entities.InvoiceNumberReservation) error
+}
+
+// InvoiceNumberReservationFilter represents filters for invoice number reservation queries
+type InvoiceNumberReservationFilter struct {
+	TenantID          uuid.UUID                                `json:"tenant_id"`
+	Status            *entities.InvoiceNumberReservationStatus `json:"status,omitempty"`
+	ExternalReference string                                   `json:"external_reference,omitempty"`
+}
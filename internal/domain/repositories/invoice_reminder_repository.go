@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// InvoiceReminderRepository defines the interface for the log of sent invoice payment reminders
+type InvoiceReminderRepository interface {
+	// Claim records a reminder before it is sent. It returns false if the invoice already
+	// got that reminder for its due date, so it is never emailed twice.
+	Claim(ctx context.Context, reminder *entities.InvoiceReminder) (bool, error)
+
+	// Release removes the record of a reminder that could not be sent, so it is retried
+	Release(ctx context.Context, id uuid.UUID) error
+
+	// GetByInvoice retrieves the reminders sent for an invoice, most recent first
+	GetByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoiceReminder, error)
+}
@@ -19,12 +19,19 @@ type InvoiceRepository interface {
 	// GetByID retrieves an invoice by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Invoice, error)
 
+	// GetByIDForUpdate retrieves an invoice by ID and locks it until the transaction ends, so
+	// concurrent payments cannot overpay it. It must be called on a transaction's repository.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Invoice, error)
+
 	// GetByInvoiceNumber retrieves an invoice by invoice number
 	GetByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error)
 
 	// GetBySaleID retrieves an invoice by sale ID
 	GetBySaleID(ctx context.Context, saleID uuid.UUID) (*entities.Invoice, error)
 
+	// GetBySaleIDs retrieves the invoices of the given sales, keyed by sale ID
+	GetBySaleIDs(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID]*entities.Invoice, error)
+
 	// Update updates an existing invoice
 	Update(ctx context.Context, invoice *entities.Invoice) error
 
@@ -37,6 +44,13 @@ type InvoiceRepository interface {
 	// GetOverdueInvoices retrieves overdue invoices
 	GetOverdueInvoices(ctx context.Context, pagination utils.PaginationInfo) ([]*entities.Invoice, utils.PaginationInfo, error)
 
+	// GetCustomerOverdueSummary summarizes a tenant customer's overdue invoices matched by email or phone
+	GetCustomerOverdueSummary(ctx context.Context, tenantID uuid.UUID, customerEmail, customerPhone string) (*CustomerOverdueSummary, error)
+
+	// GetOverdueSummary summarizes a tenant's overdue invoices as of a point in time,
+	// listing up to limit of the longest overdue
+	GetOverdueSummary(ctx context.Context, tenantID uuid.UUID, asOf time.Time, limit int) (*OverdueInvoiceSummary, error)
+
 	// GetInvoiceReport generates invoice report for a date range
 	GetInvoiceReport(ctx context.Context, fromDate, toDate time.Time) (*InvoiceReport, error)
 
@@ -45,6 +59,13 @@ type InvoiceRepository interface {
 
 	// GetInvoicesByStatus retrieves invoices by status
 	GetInvoicesByStatus(ctx context.Context, status entities.InvoiceStatus, pagination utils.PaginationInfo) ([]*entities.Invoice, utils.PaginationInfo, error)
+
+	// CreatePayment records a payment against an invoice. The invoice's paid amount and status
+	// are saved with Update.
+	CreatePayment(ctx context.Context, payment *entities.InvoicePayment) error
+
+	// GetPayments retrieves the payments recorded against an invoice, oldest first
+	GetPayments(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoicePayment, error)
 }
 
 // InvoiceItemRepository defines the interface for invoice item data access
@@ -86,6 +107,8 @@ type InvoiceFilter struct {
 	ToDate        *time.Time              `json:"to_date,omitempty"`
 	DueFromDate   *time.Time              `json:"due_from_date,omitempty"`
 	DueToDate     *time.Time              `json:"due_to_date,omitempty"`
+	PaidFromDate  *time.Time              `json:"paid_from_date,omitempty"`
+	PaidToDate    *time.Time              `json:"paid_to_date,omitempty"` // Exclusive
 	MinAmount     *decimal.Decimal        `json:"min_amount,omitempty"`
 	MaxAmount     *decimal.Decimal        `json:"max_amount,omitempty"`
 	Overdue       *bool                   `json:"overdue,omitempty"`
@@ -96,21 +119,46 @@ type InvoiceFilter struct {
 
 // InvoiceReport represents an invoice report for a date range
 type InvoiceReport struct {
-	FromDate           time.Time            `json:"from_date"`
-	ToDate             time.Time            `json:"to_date"`
-	TotalInvoices      int                  `json:"total_invoices"`
-	TotalAmount        decimal.Decimal      `json:"total_amount"`
-	PaidAmount         decimal.Decimal      `json:"paid_amount"`
-	OutstandingAmount  decimal.Decimal      `json:"outstanding_amount"`
-	DraftInvoices      int                  `json:"draft_invoices"`
-	GeneratedInvoices  int                  `json:"generated_invoices"`
-	SentInvoices       int                  `json:"sent_invoices"`
-	PaidInvoices       int                  `json:"paid_invoices"`
-	CancelledInvoices  int                  `json:"cancelled_invoices"`
-	OverdueInvoices    int                  `json:"overdue_invoices"`
-	AveragePaymentTime decimal.Decimal      `json:"average_payment_time"` // in days
-	PaymentMethodStats []PaymentMethodStat  `json:"payment_method_stats"`
-	MonthlyInvoices    []MonthlyInvoiceData `json:"monthly_invoices"`
+	FromDate              time.Time            `json:"from_date"`
+	ToDate                time.Time            `json:"to_date"`
+	TotalInvoices         int                  `json:"total_invoices"`
+	TotalAmount           decimal.Decimal      `json:"total_amount"`
+	PaidAmount            decimal.Decimal      `json:"paid_amount"`
+	OutstandingAmount     decimal.Decimal      `json:"outstanding_amount"`
+	UnpaidBalance         decimal.Decimal      `json:"unpaid_balance"`         // Left to pay on open invoices without payments
+	PartiallyPaidBalance  decimal.Decimal      `json:"partially_paid_balance"` // Left to pay on partially paid invoices
+	DraftInvoices         int                  `json:"draft_invoices"`
+	GeneratedInvoices     int                  `json:"generated_invoices"`
+	SentInvoices          int                  `json:"sent_invoices"`
+	PartiallyPaidInvoices int                  `json:"partially_paid_invoices"`
+	PaidInvoices          int                  `json:"paid_invoices"`
+	CancelledInvoices     int                  `json:"cancelled_invoices"`
+	OverdueInvoices       int                  `json:"overdue_invoices"`
+	AveragePaymentTime    decimal.Decimal      `json:"average_payment_time"` // in days
+	PaymentMethodStats    []PaymentMethodStat  `json:"payment_method_stats"`
+	MonthlyInvoices       []MonthlyInvoiceData `json:"monthly_invoices"`
+}
+
+// CustomerOverdueSummary represents a customer's outstanding overdue invoices
+type CustomerOverdueSummary struct {
+	InvoiceCount      int             `json:"invoice_count"`
+	OutstandingAmount decimal.Decimal `json:"outstanding_amount"`
+}
+
+// OverdueInvoiceSummary represents a tenant's outstanding overdue invoices
+type OverdueInvoiceSummary struct {
+	InvoiceCount      int                   `json:"invoice_count"`
+	OutstandingAmount decimal.Decimal       `json:"outstanding_amount"`
+	Invoices          []*OverdueInvoiceLine `json:"invoices"`
+}
+
+// OverdueInvoiceLine represents a single overdue invoice
+type OverdueInvoiceLine struct {
+	InvoiceID         uuid.UUID       `json:"invoice_id"`
+	InvoiceNumber     string          `json:"invoice_number"`
+	CustomerName      string          `json:"customer_name"`
+	DueDate           time.Time       `json:"due_date"`
+	OutstandingAmount decimal.Decimal `json:"outstanding_amount"`
 }
 
 // MonthlyInvoiceData represents monthly invoice data point
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// InvoiceShareLinkRepository defines the interface for invoice share link data access
+type InvoiceShareLinkRepository interface {
+	// Create creates a new share link
+	Create(ctx context.Context, link *entities.InvoiceShareLink) error
+
+	// GetByID retrieves a share link by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceShareLink, error)
+
+	// GetByTokenHash retrieves a share link by the hash of its token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.InvoiceShareLink, error)
+
+	// ListByInvoice retrieves the share links of an invoice, newest first
+	ListByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoiceShareLink, error)
+
+	// Update updates the expiry and revocation of a share link
+	Update(ctx context.Context, link *entities.InvoiceShareLink) error
+
+	// RecordView counts a view of a share link
+	RecordView(ctx context.Context, id uuid.UUID, viewedAt time.Time) error
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// InvoiceTemplateRepository defines the interface for custom invoice template data access
+type InvoiceTemplateRepository interface {
+	// Create creates a new custom invoice template
+	Create(ctx context.Context, template *entities.CustomInvoiceTemplate) error
+
+	// GetByID retrieves a custom invoice template by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CustomInvoiceTemplate, error)
+
+	// GetDefault retrieves the tenant's default invoice template
+	GetDefault(ctx context.Context, tenantID uuid.UUID) (*entities.CustomInvoiceTemplate, error)
+
+	// ListByTenant retrieves a tenant's custom invoice templates by name
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.CustomInvoiceTemplate, error)
+
+	// Update updates a custom invoice template, including its logo
+	Update(ctx context.Context, template *entities.CustomInvoiceTemplate) error
+
+	// SetDefault makes a template the tenant's default, replacing the previous default
+	SetDefault(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// Delete deletes a custom invoice template
+	Delete(ctx context.Context, id uuid.UUID) error
+}
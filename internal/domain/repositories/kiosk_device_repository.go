@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// KioskDeviceRepository defines the interface for kiosk device data access
+type KioskDeviceRepository interface {
+	// Create creates a new kiosk device
+	Create(ctx context.Context, device *entities.KioskDevice) error
+
+	// GetByID retrieves a kiosk device by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error)
+
+	// GetByTokenHash retrieves a kiosk device by the hash of its device token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.KioskDevice, error)
+
+	// Update updates an existing kiosk device
+	Update(ctx context.Context, device *entities.KioskDevice) error
+
+	// GetByTenant retrieves all kiosk devices for a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.KioskDevice, error)
+}
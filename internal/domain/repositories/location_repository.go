@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// LocationRepository defines the interface for location and per-location stock data access
+type LocationRepository interface {
+	// Create creates a new location
+	Create(ctx context.Context, location *entities.Location) error
+
+	// GetByID retrieves a location by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Location, error)
+
+	// Update updates a location
+	Update(ctx context.Context, location *entities.Location) error
+
+	// List retrieves the tenant's locations by code, the inactive ones only when asked for
+	List(ctx context.Context, includeInactive bool) ([]*entities.Location, error)
+
+	// GetStock retrieves the stock of a product at a location. It returns a not found error
+	// when the location never held the product.
+	GetStock(ctx context.Context, locationID, productID uuid.UUID) (*entities.LocationStock, error)
+
+	// GetStockForUpdate retrieves the stock of a product at a location and locks it until the
+	// transaction ends. It returns a not found error when the location never held the product.
+	GetStockForUpdate(ctx context.Context, locationID, productID uuid.UUID) (*entities.LocationStock, error)
+
+	// SaveStock creates or updates the stock of a product at a location
+	SaveStock(ctx context.Context, stock *entities.LocationStock) error
+
+	// ListStock retrieves the stock held at a location with pagination
+	ListStock(ctx context.Context, locationID uuid.UUID, pagination utils.PaginationInfo) ([]*entities.LocationStock, utils.PaginationInfo, error)
+
+	// GetProductStock retrieves the stock of a product at each location holding it
+	GetProductStock(ctx context.Context, productID uuid.UUID) ([]*entities.LocationStock, error)
+}
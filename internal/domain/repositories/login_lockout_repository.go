@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// LoginLockoutRepository defines the interface for login lockout data access
+type LoginLockoutRepository interface {
+	// GetByUser retrieves the login lockout state of a user
+	GetByUser(ctx context.Context, userID uuid.UUID) (*entities.LoginLockout, error)
+
+	// RecordFailure atomically counts a failed login of a user, starting the count over when the
+	// previous failure is older than the window, and returns the updated state
+	RecordFailure(ctx context.Context, userID, tenantID uuid.UUID, ip string, at time.Time, window time.Duration) (*entities.LoginLockout, error)
+
+	// Save saves the login lockout state of a user
+	Save(ctx context.Context, lockout *entities.LoginLockout) error
+
+	// Reset clears the failed logins and lockouts of a user
+	Reset(ctx context.Context, userID uuid.UUID) error
+}
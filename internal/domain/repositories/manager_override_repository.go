@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// ManagerOverrideRepository defines the interface for manager override data access
+type ManagerOverrideRepository interface {
+	// Create creates a new manager override
+	Create(ctx context.Context, override *entities.ManagerOverride) error
+
+	// GetByTokenHash retrieves a manager override by the hash of its token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.ManagerOverride, error)
+
+	// MarkConsumed records that an override was used. It fails with a conflict when the
+	// override was already consumed, so concurrent requests cannot both use it.
+	MarkConsumed(ctx context.Context, id uuid.UUID, consumedAt time.Time) error
+}
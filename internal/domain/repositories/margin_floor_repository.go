@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// MarginFloorRepository defines the interface for margin floor, violation and alert setting data access
+type MarginFloorRepository interface {
+	// Save creates or updates the margin floor of a category
+	Save(ctx context.Context, floor *entities.MarginFloor) error
+
+	// GetByTenantAndCategory retrieves the margin floor of a category, matched case-insensitively
+	GetByTenantAndCategory(ctx context.Context, tenantID uuid.UUID, category string) (*entities.MarginFloor, error)
+
+	// GetByTenant retrieves all margin floors of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.MarginFloor, error)
+
+	// Delete deletes a margin floor
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateViolations records margin floor violations
+	CreateViolations(ctx context.Context, violations []*entities.MarginViolation) error
+
+	// GetViolations retrieves the violations of a tenant recorded in [from, to), newest first
+	GetViolations(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*entities.MarginViolation, error)
+
+	// GetAlertSetting retrieves the margin alert setting of a tenant
+	GetAlertSetting(ctx context.Context, tenantID uuid.UUID) (*entities.MarginAlertSetting, error)
+
+	// SaveAlertSetting creates or updates the margin alert setting of a tenant
+	SaveAlertSetting(ctx context.Context, setting *entities.MarginAlertSetting) error
+
+	// ListWeeklyReportSettings retrieves the alert settings of tenants that receive the weekly report
+	ListWeeklyReportSettings(ctx context.Context) ([]*entities.MarginAlertSetting, error)
+
+	// ClaimWeeklyReport atomically marks the report for a week as being sent.
+	// It returns false when the report for that week was already claimed.
+	ClaimWeeklyReport(ctx context.Context, tenantID uuid.UUID, week time.Time) (bool, error)
+
+	// ReleaseWeeklyReport restores the previous report week after a failed send so it is retried
+	ReleaseWeeklyReport(ctx context.Context, tenantID uuid.UUID, week time.Time, previous *time.Time) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// PasswordHistoryRepository defines the interface for password history data access
+type PasswordHistoryRepository interface {
+	// Create records a previous password of a user
+	Create(ctx context.Context, entry *entities.PasswordHistoryEntry) error
+
+	// GetRecentHashesByUser retrieves the hashes of a user's most recent passwords, newest first
+	GetRecentHashesByUser(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
+
+	// PruneByUser deletes all but the given number of a user's most recent passwords
+	PruneByUser(ctx context.Context, userID uuid.UUID, keep int) error
+}
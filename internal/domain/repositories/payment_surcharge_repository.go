@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// PaymentSurchargeRuleRepository defines the interface for payment surcharge rule data access
+type PaymentSurchargeRuleRepository interface {
+	// Create creates a new payment surcharge rule
+	Create(ctx context.Context, rule *entities.PaymentSurchargeRule) error
+
+	// GetByID retrieves a payment surcharge rule by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentSurchargeRule, error)
+
+	// Update updates an existing payment surcharge rule
+	Update(ctx context.Context, rule *entities.PaymentSurchargeRule) error
+
+	// Delete deletes a payment surcharge rule
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByTenant retrieves all payment surcharge rules for a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.PaymentSurchargeRule, error)
+
+	// GetActiveByPaymentMethod retrieves a tenant's active rule for a payment method
+	GetActiveByPaymentMethod(ctx context.Context, tenantID uuid.UUID, paymentMethod entities.PaymentMethod) (*entities.PaymentSurchargeRule, error)
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PaymentTransactionRepository defines the interface for payment gateway transaction data access
+type PaymentTransactionRepository interface {
+	// Create creates a new payment transaction
+	Create(ctx context.Context, transaction *entities.PaymentTransaction) error
+
+	// GetByID retrieves a payment transaction by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentTransaction, error)
+
+	// GetByExternalID retrieves a payment transaction by its reference on the gateway
+	GetByExternalID(ctx context.Context, provider entities.PaymentGatewayProvider, externalID string) (*entities.PaymentTransaction, error)
+
+	// Update updates a payment transaction's gateway reference, status and error
+	Update(ctx context.Context, transaction *entities.PaymentTransaction) error
+
+	// List retrieves payment transactions with pagination and filtering, newest first
+	List(ctx context.Context, filter PaymentTransactionFilter, pagination utils.PaginationInfo) ([]*entities.PaymentTransaction, utils.PaginationInfo, error)
+}
+
+// PaymentTransactionFilter represents filters for payment transaction queries
+type PaymentTransactionFilter struct {
+	SaleID    *uuid.UUID                         `json:"sale_id,omitempty"`
+	InvoiceID *uuid.UUID                         `json:"invoice_id,omitempty"`
+	Provider  *entities.PaymentGatewayProvider   `json:"provider,omitempty"`
+	Status    *entities.PaymentTransactionStatus `json:"status,omitempty"`
+}
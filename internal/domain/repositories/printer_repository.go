@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// PrinterRepository defines the interface for registered printer data access
+type PrinterRepository interface {
+	// Create registers a new printer. A default printer replaces the terminal's previous
+	// default of the same kind.
+	Create(ctx context.Context, printer *entities.Printer) error
+
+	// GetByID retrieves a printer by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Printer, error)
+
+	// GetByTenant retrieves the printers of a tenant, limited to those serving a terminal
+	// when terminal is not empty
+	GetByTenant(ctx context.Context, tenantID uuid.UUID, terminal string) ([]*entities.Printer, error)
+
+	// FindForTerminal retrieves the printer of a kind a terminal prints to: its own default,
+	// then any of its own, then the tenant's printers shared by all terminals
+	FindForTerminal(ctx context.Context, tenantID uuid.UUID, terminal string, isThermal bool) (*entities.Printer, error)
+
+	// UpdateStatus updates the test print status of a printer
+	UpdateStatus(ctx context.Context, printer *entities.Printer) error
+
+	// Delete deletes a printer
+	Delete(ctx context.Context, id uuid.UUID) error
+}
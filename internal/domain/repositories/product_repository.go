@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -17,9 +18,18 @@ type ProductRepository interface {
 	// GetByID retrieves a product by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error)
 
+	// GetByIDs retrieves the products with the given IDs, keyed by product ID
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Product, error)
+
 	// GetBySKU retrieves a product by SKU
 	GetBySKU(ctx context.Context, sku string) (*entities.Product, error)
 
+	// GetByTenantAndSKU retrieves a product by tenant ID and SKU
+	GetByTenantAndSKU(ctx context.Context, tenantID uuid.UUID, sku string) (*entities.Product, error)
+
+	// GetByTenantAndBarcode retrieves a product by tenant ID and barcode
+	GetByTenantAndBarcode(ctx context.Context, tenantID uuid.UUID, barcode string) (*entities.Product, error)
+
 	// Update updates an existing product
 	Update(ctx context.Context, product *entities.Product) error
 
@@ -29,6 +39,9 @@ type ProductRepository interface {
 	// List retrieves products with pagination and filtering
 	List(ctx context.Context, filter ProductFilter, pagination utils.PaginationInfo) ([]*entities.Product, utils.PaginationInfo, error)
 
+	// ListWithStock retrieves products with pagination and filtering, along with their stock
+	ListWithStock(ctx context.Context, filter ProductFilter, pagination utils.PaginationInfo) ([]*ProductWithStock, utils.PaginationInfo, error)
+
 	// GetByCategory retrieves products by category
 	GetByCategory(ctx context.Context, category string, pagination utils.PaginationInfo) ([]*entities.Product, utils.PaginationInfo, error)
 
@@ -40,15 +53,42 @@ type ProductRepository interface {
 
 	// GetLowStockProducts retrieves products with low stock
 	GetLowStockProducts(ctx context.Context, pagination utils.PaginationInfo) ([]*entities.Product, utils.PaginationInfo, error)
+
+	// GetDueForPublishing retrieves scheduled products whose publish time has passed
+	GetDueForPublishing(ctx context.Context, now time.Time) ([]*entities.Product, error)
+
+	// GetWithAvailabilityWindow retrieves active and inactive products that are only sold during
+	// a limited period
+	GetWithAvailabilityWindow(ctx context.Context) ([]*entities.Product, error)
 }
 
 // ProductFilter represents filters for product queries
 type ProductFilter struct {
-	Category string                  `json:"category,omitempty"`
-	Status   *entities.ProductStatus `json:"status,omitempty"`
-	Search   string                  `json:"search,omitempty"` // Search in name, description, SKU
-	MinPrice *float64                `json:"min_price,omitempty"`
-	MaxPrice *float64                `json:"max_price,omitempty"`
-	OrderBy  string                  `json:"order_by,omitempty"`
-	OrderDir string                  `json:"order_dir,omitempty"` // ASC or DESC
+	Category     string                        `json:"category,omitempty"`
+	CategoryID   *uuid.UUID                    `json:"category_id,omitempty"` // Matches the category and all its subcategories
+	Status       *entities.ProductStatus       `json:"status,omitempty"`
+	PublishState *entities.ProductPublishState `json:"publish_state,omitempty"`
+	Search       string                        `json:"search,omitempty"` // Search in name, description, SKU
+	SupplierID   *uuid.UUID                    `json:"supplier_id,omitempty"`
+	MinPrice     *float64                      `json:"min_price,omitempty"`
+	MaxPrice     *float64                      `json:"max_price,omitempty"`
+	OrderBy      string                        `json:"order_by,omitempty"`  // A product column, ProductOrderByStockStatus or ProductOrderByAvailableQty
+	OrderDir     string                        `json:"order_dir,omitempty"` // ASC or DESC
+}
+
+// Computed orderings of product listings, based on each product's stock
+const (
+	// ProductOrderByStockStatus orders out of stock products first, then low stock, then in
+	// stock, and by available quantity within each status
+	ProductOrderByStockStatus = "stock_status"
+
+	// ProductOrderByAvailableQty orders products by available quantity, counting products
+	// without a stock record as having none
+	ProductOrderByAvailableQty = "available_qty"
+)
+
+// ProductWithStock is a listed product along with its stock
+type ProductWithStock struct {
+	Product *entities.Product
+	Stock   *entities.Stock // Nil if the product has no stock record
 }
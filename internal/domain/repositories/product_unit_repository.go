@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// ProductUnitRepository defines the interface for tenant product unit data access
+type ProductUnitRepository interface {
+	// GetByTenant retrieves the tenant's own units
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.ProductUnit, error)
+
+	// GetByCode retrieves the tenant's own unit with the given code
+	GetByCode(ctx context.Context, tenantID uuid.UUID, code string) (*entities.ProductUnit, error)
+
+	// Create creates a new unit
+	Create(ctx context.Context, unit *entities.ProductUnit) error
+
+	// Update updates a unit's names and allowed decimals
+	Update(ctx context.Context, unit *entities.ProductUnit) error
+
+	// Delete deletes a unit
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CountProducts counts the tenant's products measured in the unit with the given code
+	CountProducts(ctx context.Context, tenantID uuid.UUID, code string) (int, error)
+}
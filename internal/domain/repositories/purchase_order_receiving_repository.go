@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// PurchaseOrderReceivingRepository defines the interface for purchase order receiving session
+// data access
+type PurchaseOrderReceivingRepository interface {
+	// Create creates a new receiving session. It returns a conflict error when the purchase
+	// order already has an open session.
+	Create(ctx context.Context, receiving *entities.PurchaseOrderReceiving) error
+
+	// GetByID retrieves a receiving session by ID with its lines
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrderReceiving, error)
+
+	// GetByIDForUpdate retrieves a receiving session by ID with its lines and locks it until
+	// the transaction ends, so concurrent scans cannot lose each other's units. It must be
+	// called on a transaction's repository.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrderReceiving, error)
+
+	// Update updates a receiving session's status
+	Update(ctx context.Context, receiving *entities.PurchaseOrderReceiving) error
+
+	// SaveLines inserts or replaces scanned lines of a receiving session
+	SaveLines(ctx context.Context, lines []*entities.PurchaseOrderReceivingLine) error
+
+	// ListByPurchaseOrder retrieves the receiving sessions of a purchase order with their lines,
+	// most recently started first
+	ListByPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) ([]*entities.PurchaseOrderReceiving, error)
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PurchaseOrderRepository defines the interface for purchase order data access
+type PurchaseOrderRepository interface {
+	// Create creates a new purchase order with its items
+	Create(ctx context.Context, order *entities.PurchaseOrder) error
+
+	// GetByID retrieves a purchase order by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error)
+
+	// GetByIDForUpdate retrieves a purchase order by ID and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error)
+
+	// Update updates a purchase order and replaces its items
+	Update(ctx context.Context, order *entities.PurchaseOrder) error
+
+	// List retrieves purchase orders with pagination and filtering
+	List(ctx context.Context, filter PurchaseOrderFilter, pagination utils.PaginationInfo) ([]*entities.PurchaseOrder, utils.PaginationInfo, error)
+
+	// GetOnOrderQuantities retrieves the units of each product still to be delivered on a
+	// tenant's draft and ordered purchase orders, keyed by product ID
+	GetOnOrderQuantities(ctx context.Context, tenantID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+// PurchaseOrderFilter represents filters for purchase order queries
+type PurchaseOrderFilter struct {
+	Status    *entities.PurchaseOrderStatus `json:"status,omitempty"`
+	Supplier  string                        `json:"supplier,omitempty"` // Search in supplier name
+	ProductID *uuid.UUID                    `json:"product_id,omitempty"`
+	OrderBy   string                        `json:"order_by,omitempty"`
+	OrderDir  string                        `json:"order_dir,omitempty"` // ASC or DESC
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// QuoteRepository defines the interface for quote data access
+type QuoteRepository interface {
+	// Create creates a new quote with its items
+	Create(ctx context.Context, quote *entities.Quote) error
+
+	// GetByID retrieves a quote by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Quote, error)
+
+	// GetByIDForUpdate retrieves a quote by ID and locks it until the transaction ends, so it
+	// is converted to a sale only once
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Quote, error)
+
+	// Update updates a quote and replaces its items
+	Update(ctx context.Context, quote *entities.Quote) error
+
+	// List retrieves quotes with pagination and filtering
+	List(ctx context.Context, filter QuoteFilter, pagination utils.PaginationInfo) ([]*entities.Quote, utils.PaginationInfo, error)
+
+	// GetExpired retrieves draft and sent quotes across all tenants that expired before the given time
+	GetExpired(ctx context.Context, before time.Time, limit int) ([]*entities.Quote, error)
+}
+
+// QuoteFilter represents filters for quote queries
+type QuoteFilter struct {
+	Status    *entities.QuoteStatus `json:"status,omitempty"`
+	Customer  string                `json:"customer,omitempty"` // Search in customer name and email
+	ProductID *uuid.UUID            `json:"product_id,omitempty"`
+	OrderBy   string                `json:"order_by,omitempty"`
+	OrderDir  string                `json:"order_dir,omitempty"` // ASC or DESC
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// RefundRepository defines the interface for sale refund data access
+type RefundRepository interface {
+	// Create creates a new refund with its items
+	Create(ctx context.Context, refund *entities.Refund) error
+
+	// GetByID retrieves a refund by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Refund, error)
+
+	// GetBySaleID retrieves all refunds of a sale, oldest first
+	GetBySaleID(ctx context.Context, saleID uuid.UUID) ([]*entities.Refund, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// RegisterReportRepository defines the interface for Z-report data access. X-reports are
+// snapshots and are not stored.
+type RegisterReportRepository interface {
+	// CreateZReport stores a Z-report, failing with a conflict when its session already has one
+	// or its number is taken
+	CreateZReport(ctx context.Context, report *entities.RegisterReport) error
+
+	// GetZReportBySession retrieves the Z-report of a register session
+	GetZReportBySession(ctx context.Context, sessionID uuid.UUID) (*entities.RegisterReport, error)
+
+	// GetLatestZReport retrieves the highest numbered Z-report of a register
+	GetLatestZReport(ctx context.Context, tenantID uuid.UUID, register string) (*entities.RegisterReport, error)
+}
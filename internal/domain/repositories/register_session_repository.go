@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// RegisterSessionRepository defines the interface for register session data access
+type RegisterSessionRepository interface {
+	// Create creates a new register session
+	Create(ctx context.Context, session *entities.RegisterSession) error
+
+	// GetByID retrieves a register session by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.RegisterSession, error)
+
+	// GetOpenByRegister retrieves the session currently open on a register
+	GetOpenByRegister(ctx context.Context, tenantID uuid.UUID, register string) (*entities.RegisterSession, error)
+
+	// Update updates a register session and its cash movements
+	Update(ctx context.Context, session *entities.RegisterSession) error
+
+	// List retrieves register sessions with pagination and filtering, most recently opened first
+	List(ctx context.Context, filter RegisterSessionFilter, pagination utils.PaginationInfo) ([]*entities.RegisterSession, utils.PaginationInfo, error)
+
+	// GetOpenedBetween retrieves the sessions opened in [start, end)
+	GetOpenedBetween(ctx context.Context, tenantID uuid.UUID, start, end time.Time) ([]*entities.RegisterSession, error)
+
+	// GetCashTotals sums the cash taken by a cashier's completed sales and given back by their
+	// cash refunds in [start, end)
+	GetCashTotals(ctx context.Context, tenantID, cashierID uuid.UUID, start, end time.Time) (*entities.RegisterCashTotals, error)
+
+	// GetSalesTotals sums a cashier's completed sales by payment method and their refunds by
+	// refund method in [start, end)
+	GetSalesTotals(ctx context.Context, tenantID, cashierID uuid.UUID, start, end time.Time) (*entities.RegisterSalesTotals, error)
+}
+
+// RegisterSessionFilter represents filters for register session queries
+type RegisterSessionFilter struct {
+	TenantID uuid.UUID                       `json:"tenant_id"`
+	Register string                          `json:"register,omitempty"`
+	Status   *entities.RegisterSessionStatus `json:"status,omitempty"`
+}
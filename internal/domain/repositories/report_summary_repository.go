@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportSummaryRepository defines the interface for the precomputed daily sales and invoice
+// summaries the reports read from
+type ReportSummaryRepository interface {
+	// GetStaleDays lists the days whose sales, refunds or invoices changed after since and
+	// after the day was last summarized, most recent first
+	GetStaleDays(ctx context.Context, since time.Time, limit int) ([]*SummaryDay, error)
+
+	// GetMissingSalesDays lists the days from fromDate up to toDate (exclusive) that have no
+	// sales summary yet
+	GetMissingSalesDays(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]time.Time, error)
+
+	// GetMissingInvoiceDays lists the days from fromDate up to toDate (exclusive) that have no
+	// invoice summary yet
+	GetMissingInvoiceDays(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]time.Time, error)
+
+	// RefreshSalesSummaries recomputes the sales summary of every day from fromDate up to
+	// toDate (exclusive)
+	RefreshSalesSummaries(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) error
+
+	// RefreshInvoiceSummaries recomputes the invoice summary of every day from fromDate up to
+	// toDate (exclusive)
+	RefreshInvoiceSummaries(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) error
+
+	// GetSalesReport builds the sales report of a date range from the daily summaries
+	GetSalesReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*SalesReport, error)
+
+	// GetInvoiceReport builds the invoice report of a date range from the daily summaries
+	GetInvoiceReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*InvoiceReport, error)
+}
+
+// SummaryDay identifies a tenant's day to summarize
+type SummaryDay struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Date     time.Time `json:"date"`
+}
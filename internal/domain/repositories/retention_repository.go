@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// RetentionPolicyRepository defines the interface for retention policy data access and enforcement.
+// Purge queries never touch records under an active retention hold.
+type RetentionPolicyRepository interface {
+	// GetByTenant retrieves all retention policies of a tenant
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.RetentionPolicy, error)
+
+	// Save creates or updates the retention policy of a tenant for the policy's entity
+	Save(ctx context.Context, policy *entities.RetentionPolicy) error
+
+	// ListEnabled retrieves all enabled retention policies across tenants
+	ListEnabled(ctx context.Context) ([]*entities.RetentionPolicy, error)
+
+	// CountExpired counts the tenant's records of an entity older than cutoff that are not on hold
+	CountExpired(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, cutoff time.Time) (int64, error)
+
+	// PurgeExpired permanently deletes up to limit of the tenant's records of an entity older than
+	// cutoff that are not on hold, and returns how many were deleted
+	PurgeExpired(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, cutoff time.Time, limit int) (int64, error)
+}
+
+// RetentionHoldRepository defines the interface for retention hold data access
+type RetentionHoldRepository interface {
+	// Create creates a new retention hold
+	Create(ctx context.Context, hold *entities.RetentionHold) error
+
+	// GetByID retrieves a retention hold by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.RetentionHold, error)
+
+	// Update updates an existing retention hold
+	Update(ctx context.Context, hold *entities.RetentionHold) error
+
+	// GetByTenant retrieves the retention holds of a tenant, newest first
+	GetByTenant(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]*entities.RetentionHold, error)
+
+	// GetActiveByRecord retrieves the active hold on a record, if any
+	GetActiveByRecord(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, recordID uuid.UUID) (*entities.RetentionHold, error)
+}
+
+// DeletedRecordRepository defines the interface for listing and restoring soft-deleted sales and
+// invoices, the records of the restorable retention entities
+type DeletedRecordRepository interface {
+	// List retrieves a tenant's soft-deleted records of an entity, most recently deleted first
+	List(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, pagination utils.PaginationInfo) ([]*entities.DeletedRecord, utils.PaginationInfo, error)
+
+	// Restore clears the deletion of a tenant's soft-deleted record
+	Restore(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, id uuid.UUID) error
+}
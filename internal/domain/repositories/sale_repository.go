@@ -19,6 +19,12 @@ type SaleRepository interface {
 	// GetByID retrieves a sale by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Sale, error)
 
+	// GetByIDForUpdate retrieves a sale by ID and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Sale, error)
+
+	// GetByIDs retrieves the sales with the given IDs, keyed by sale ID
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Sale, error)
+
 	// GetBySaleNumber retrieves a sale by sale number
 	GetBySaleNumber(ctx context.Context, saleNumber string) (*entities.Sale, error)
 
@@ -37,11 +43,35 @@ type SaleRepository interface {
 	// GetDailySales retrieves daily sales summary
 	GetDailySales(ctx context.Context, date time.Time) (*DailySalesReport, error)
 
+	// GetDiscountLines retrieves discount and margin totals of completed sales grouped by period, cashier and category
+	GetDiscountLines(ctx context.Context, filter DiscountReportFilter) ([]*DiscountReportLine, error)
+
+	// GetTaxSummaryLines retrieves the taxable base and tax of a tenant's sales and credit notes
+	// for a date range, grouped by month and tax rate
+	GetTaxSummaryLines(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*TaxSummaryLine, error)
+
+	// GetAccountingLines retrieves the amounts a tenant's sales and refunds post to the general
+	// ledger for a date range, grouped by day
+	GetAccountingLines(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*AccountingLine, error)
+
+	// GetSalesSummary retrieves sales totals of a tenant for a date range
+	GetSalesSummary(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*SalesSummary, error)
+
+	// GetTopProducts retrieves a tenant's best selling products by revenue for a date range
+	GetTopProducts(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, limit int) ([]*ProductSalesStats, error)
+
+	// GetProductProfitability retrieves the gross profit of a tenant's products sold on completed
+	// sales for a date range, most profitable first
+	GetProductProfitability(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, limit int) ([]*ProductSalesStats, error)
+
 	// GetTotalSalesByUser retrieves total sales amount by user
 	GetTotalSalesByUser(ctx context.Context, userID uuid.UUID, fromDate, toDate time.Time) (decimal.Decimal, error)
 
 	// ExistsBySaleNumber checks if a sale exists by sale number
 	ExistsBySaleNumber(ctx context.Context, saleNumber string) (bool, error)
+
+	// GetExpiredHolds retrieves held sales across all tenants whose hold expired before the given time
+	GetExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*entities.Sale, error)
 }
 
 // SaleItemRepository defines the interface for sale item data access
@@ -70,14 +100,23 @@ type SaleItemRepository interface {
 	// DeleteBySaleID deletes all items for a sale
 	DeleteBySaleID(ctx context.Context, saleID uuid.UUID) error
 
+	// AddReturnedQuantity records a quantity of a sale item as returned, failing with a conflict
+	// when it would exceed the quantity sold
+	AddReturnedQuantity(ctx context.Context, id uuid.UUID, quantity int) error
+
 	// GetTopSellingProducts retrieves top selling products by quantity or revenue
 	GetTopSellingProducts(ctx context.Context, fromDate, toDate time.Time, limit int, byRevenue bool) ([]*ProductSalesStats, error)
+
+	// GetQuantitiesSold retrieves the units of each product sold net of returns on a tenant's
+	// completed sales between two dates, keyed by product ID
+	GetQuantitiesSold(ctx context.Context, tenantID uuid.UUID, productIDs []uuid.UUID, fromDate, toDate time.Time) (map[uuid.UUID]int, error)
 }
 
 // SaleFilter represents filters for sale queries
 type SaleFilter struct {
 	Status        *entities.SaleStatus    `json:"status,omitempty"`
 	PaymentMethod *entities.PaymentMethod `json:"payment_method,omitempty"`
+	Channel       *entities.SaleChannel   `json:"channel,omitempty"`
 	CreatedBy     *uuid.UUID              `json:"created_by,omitempty"`
 	CustomerName  string                  `json:"customer_name,omitempty"`
 	CustomerEmail string                  `json:"customer_email,omitempty"`
@@ -96,36 +135,71 @@ type SalesReport struct {
 	ToDate             time.Time           `json:"to_date"`
 	TotalSales         int                 `json:"total_sales"`
 	TotalRevenue       decimal.Decimal     `json:"total_revenue"`
-	TotalProfit        decimal.Decimal     `json:"total_profit"`
+	CostOfGoods        decimal.Decimal     `json:"cost_of_goods"` // Of completed sales, at the unit costs when completed
+	TotalProfit        decimal.Decimal     `json:"total_profit"`  // Item revenue of completed sales less cost of goods
 	CompletedSales     int                 `json:"completed_sales"`
 	CancelledSales     int                 `json:"cancelled_sales"`
 	RefundedSales      int                 `json:"refunded_sales"`
 	AverageOrderValue  decimal.Decimal     `json:"average_order_value"`
+	SurchargeRevenue   decimal.Decimal     `json:"surcharge_revenue"` // Payment surcharges included in total revenue
+	RefundCount        int                 `json:"refund_count"`
+	RefundedAmount     decimal.Decimal     `json:"refunded_amount"` // Refunds issued in the period
+	NetRevenue         decimal.Decimal     `json:"net_revenue"`     // Total revenue less refunded amount
 	TotalItemsSold     int                 `json:"total_items_sold"`
 	UniqueCustomers    int                 `json:"unique_customers"`
 	PaymentMethodStats []PaymentMethodStat `json:"payment_method_stats"`
+	ChannelStats       []SalesChannelStat  `json:"channel_stats"`
 	DailySales         []DailySalesData    `json:"daily_sales"`
 }
 
+// SalesSummary represents sales totals of a tenant for a date range
+type SalesSummary struct {
+	FromDate          time.Time       `json:"from_date"`
+	ToDate            time.Time       `json:"to_date"`
+	TotalSales        int             `json:"total_sales"`
+	CompletedSales    int             `json:"completed_sales"`
+	CancelledSales    int             `json:"cancelled_sales"`
+	TotalRevenue      decimal.Decimal `json:"total_revenue"`
+	DiscountAmount    decimal.Decimal `json:"discount_amount"`
+	SurchargeAmount   decimal.Decimal `json:"surcharge_amount"`
+	AverageOrderValue decimal.Decimal `json:"average_order_value"`
+	ItemsSold         int             `json:"items_sold"`
+}
+
 // DailySalesReport represents daily sales summary
 type DailySalesReport struct {
 	Date               time.Time           `json:"date"`
 	TotalSales         int                 `json:"total_sales"`
 	TotalRevenue       decimal.Decimal     `json:"total_revenue"`
+	CostOfGoods        decimal.Decimal     `json:"cost_of_goods"` // Of completed sales, at the unit costs when completed
+	TotalProfit        decimal.Decimal     `json:"total_profit"`  // Item revenue of completed sales less cost of goods
 	CompletedSales     int                 `json:"completed_sales"`
 	CancelledSales     int                 `json:"cancelled_sales"`
 	RefundedSales      int                 `json:"refunded_sales"`
 	AverageOrderValue  decimal.Decimal     `json:"average_order_value"`
+	RefundCount        int                 `json:"refund_count"`
+	RefundedAmount     decimal.Decimal     `json:"refunded_amount"` // Refunds issued during the day
+	NetRevenue         decimal.Decimal     `json:"net_revenue"`     // Total revenue less refunded amount
 	TotalItemsSold     int                 `json:"total_items_sold"`
 	TopSellingProducts []ProductSalesStats `json:"top_selling_products"`
 }
 
 // PaymentMethodStat represents payment method statistics
 type PaymentMethodStat struct {
-	PaymentMethod entities.PaymentMethod `json:"payment_method"`
-	Count         int                    `json:"count"`
-	TotalAmount   decimal.Decimal        `json:"total_amount"`
-	Percentage    decimal.Decimal        `json:"percentage"`
+	PaymentMethod   entities.PaymentMethod `json:"payment_method"`
+	Count           int                    `json:"count"`
+	TotalAmount     decimal.Decimal        `json:"total_amount"`
+	SurchargeAmount decimal.Decimal        `json:"surcharge_amount"`
+	Percentage      decimal.Decimal        `json:"percentage"`
+}
+
+// SalesChannelStat represents sales channel statistics
+type SalesChannelStat struct {
+	Channel           entities.SaleChannel `json:"channel"`
+	Count             int                  `json:"count"`
+	TotalAmount       decimal.Decimal      `json:"total_amount"`
+	AverageOrderValue decimal.Decimal      `json:"average_order_value"`
+	Percentage        decimal.Decimal      `json:"percentage"`
 }
 
 // DailySalesData represents daily sales data point
@@ -144,4 +218,80 @@ type ProductSalesStats struct {
 	TotalRevenue decimal.Decimal `json:"total_revenue"`
 	AveragePrice decimal.Decimal `json:"average_price"`
 	SalesCount   int             `json:"sales_count"`
+	CostOfGoods  decimal.Decimal `json:"cost_of_goods"`
+	TotalProfit  decimal.Decimal `json:"total_profit"`
+}
+
+// ReportPeriod represents the time bucket used to group report data
+type ReportPeriod string
+
+const (
+	ReportPeriodDay   ReportPeriod = "day"
+	ReportPeriodWeek  ReportPeriod = "week"
+	ReportPeriodMonth ReportPeriod = "month"
+)
+
+// DiscountReportFilter represents filters for the discount report
+type DiscountReportFilter struct {
+	TenantID  uuid.UUID    `json:"tenant_id"`
+	FromDate  time.Time    `json:"from_date"`
+	ToDate    time.Time    `json:"to_date"` // Exclusive
+	Period    ReportPeriod `json:"period"`
+	CashierID *uuid.UUID   `json:"cashier_id,omitempty"`
+	Category  string       `json:"category,omitempty"`
+}
+
+// DiscountReportLine represents discount and margin totals for one period, cashier and category
+type DiscountReportLine struct {
+	PeriodStart           time.Time       `json:"period_start"`
+	CashierID             uuid.UUID       `json:"cashier_id"`
+	CashierName           string          `json:"cashier_name"`
+	Category              string          `json:"category"`
+	ItemsSold             int             `json:"items_sold"`
+	ListRevenue           decimal.Decimal `json:"list_revenue"`            // Revenue had every item sold at list price
+	ItemRevenue           decimal.Decimal `json:"item_revenue"`            // Revenue at charged item prices, before sale-level discounts
+	CostOfGoods           decimal.Decimal `json:"cost_of_goods"`           // Product cost recorded at the time of sale
+	ManualDiscount        decimal.Decimal `json:"manual_discount"`         // Sale-level discounts allocated to items by value
+	PromotionDiscount     decimal.Decimal `json:"promotion_discount"`      // Below-list pricing from product promotions
+	PriceOverrideDiscount decimal.Decimal `json:"price_override_discount"` // Below-list pricing from manual price overrides
+}
+
+// TaxDocumentType distinguishes the documents a tax summary line totals
+type TaxDocumentType string
+
+const (
+	TaxDocumentSale       TaxDocumentType = "sale"
+	TaxDocumentCreditNote TaxDocumentType = "credit_note" // Refund of a completed sale
+)
+
+// TaxSummaryLine represents the tax totals of one document type for one month and tax rate
+type TaxSummaryLine struct {
+	Month            time.Time       `json:"month"`
+	TaxRate          decimal.Decimal `json:"tax_rate"`
+	DocumentType     TaxDocumentType `json:"document_type"`
+	DocumentCount    int             `json:"document_count"`
+	TaxableBase      decimal.Decimal `json:"taxable_base"`       // Amount the tax was charged on, after discounts
+	NonTaxableAmount decimal.Decimal `json:"non_taxable_amount"` // Charges exempt from tax, such as untaxed surcharges
+	TaxAmount        decimal.Decimal `json:"tax_amount"`
+}
+
+// AccountingLineType is the kind of amount an accounting line carries
+type AccountingLineType string
+
+const (
+	AccountingLinePayment      AccountingLineType = "payment"       // Received by sales, per payment method
+	AccountingLineTax          AccountingLineType = "tax"           // Charged on sales
+	AccountingLineCost         AccountingLineType = "cost"          // Cost of goods sold
+	AccountingLineRefund       AccountingLineType = "refund"        // Paid back by refunds, per refund method
+	AccountingLineRefundTax    AccountingLineType = "refund_tax"    // Given back by refunds
+	AccountingLineReturnedCost AccountingLineType = "returned_cost" // Cost of goods returned to stock by refunds
+)
+
+// AccountingLine represents a day's total of one kind of amount posted to the general ledger,
+// in the base currency
+type AccountingLine struct {
+	Date          time.Time              `json:"date"`
+	Type          AccountingLineType     `json:"type"`
+	PaymentMethod entities.PaymentMethod `json:"payment_method,omitempty"`
+	Amount        decimal.Decimal        `json:"amount"`
 }
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// SerialNumberRepository defines the interface for serial number registry data access
+type SerialNumberRepository interface {
+	// Create registers a serial number. It returns a conflict error when the product
+	// already has the serial number.
+	Create(ctx context.Context, serial *entities.SerialNumber) error
+
+	// GetByProductAndSerialForUpdate retrieves a serial number of a product and locks it until
+	// the transaction ends, so the same unit is never sold twice
+	GetByProductAndSerialForUpdate(ctx context.Context, productID uuid.UUID, serial string) (*entities.SerialNumber, error)
+
+	// Update updates the status and sale of a serial number
+	Update(ctx context.Context, serial *entities.SerialNumber) error
+
+	// FindBySerial retrieves the serial numbers matching a serial across all products, as
+	// different manufacturers may use the same serial
+	FindBySerial(ctx context.Context, serial string) ([]*entities.SerialNumber, error)
+
+	// List retrieves serial numbers with pagination and filtering, latest first
+	List(ctx context.Context, filter SerialNumberFilter, pagination utils.PaginationInfo) ([]*entities.SerialNumber, utils.PaginationInfo, error)
+}
+
+// SerialNumberFilter represents filters for serial number queries
+type SerialNumberFilter struct {
+	ProductID *uuid.UUID                   `json:"product_id,omitempty"`
+	SaleID    *uuid.UUID                   `json:"sale_id,omitempty"`
+	Status    *entities.SerialNumberStatus `json:"status,omitempty"`
+}
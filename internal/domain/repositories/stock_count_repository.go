@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// StockCountRepository defines the interface for stock count data access
+type StockCountRepository interface {
+	// Create creates a new stock count
+	Create(ctx context.Context, count *entities.StockCount) error
+
+	// GetByID retrieves a stock count by ID with its lines
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.StockCount, error)
+
+	// GetByIDForUpdate retrieves a stock count by ID with its lines and locks it until the
+	// transaction ends, so a count cannot be completed twice. It must be called on a
+	// transaction's repository.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StockCount, error)
+
+	// Update updates a stock count's status
+	Update(ctx context.Context, count *entities.StockCount) error
+
+	// SaveLines inserts or replaces counted lines of a stock count
+	SaveLines(ctx context.Context, lines []*entities.StockCountLine) error
+
+	// List retrieves stock counts without their lines, with pagination and filtering, most
+	// recently started first
+	List(ctx context.Context, filter StockCountFilter, pagination utils.PaginationInfo) ([]*entities.StockCount, utils.PaginationInfo, error)
+}
+
+// StockCountFilter represents filters for stock count queries
+type StockCountFilter struct {
+	TenantID   uuid.UUID                  `json:"tenant_id"`
+	Status     *entities.StockCountStatus `json:"status,omitempty"`
+	LocationID *uuid.UUID                 `json:"location_id,omitempty"`
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/pkg/utils"
@@ -21,6 +22,14 @@ type StockRepository interface {
 	// GetByProductID retrieves stock by product ID
 	GetByProductID(ctx context.Context, productID uuid.UUID) (*entities.Stock, error)
 
+	// GetByProductIDs retrieves the stock of the given products, keyed by product ID
+	GetByProductIDs(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]*entities.Stock, error)
+
+	// GetByProductIDForUpdate retrieves stock by product ID and locks the row until the
+	// transaction ends, so concurrent read-modify-write updates of the same stock are
+	// serialized. It must be called on a transaction's repository.
+	GetByProductIDForUpdate(ctx context.Context, productID uuid.UUID) (*entities.Stock, error)
+
 	// Update updates stock information
 	Update(ctx context.Context, stock *entities.Stock) error
 
@@ -36,6 +45,23 @@ type StockRepository interface {
 	// GetOutOfStockItems retrieves items that are out of stock
 	GetOutOfStockItems(ctx context.Context, pagination utils.PaginationInfo) ([]*entities.Stock, utils.PaginationInfo, error)
 
+	// GetLowStockByTenant retrieves a tenant's active products at or below their reorder level, emptiest first
+	GetLowStockByTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]*LowStockItem, error)
+
+	// GetInventorySummary aggregates stock counts and value across a tenant's active products
+	GetInventorySummary(ctx context.Context, tenantID uuid.UUID) (*InventorySummary, error)
+
+	// GetStockAlertCounts counts the low and out of stock active products of every tenant
+	GetStockAlertCounts(ctx context.Context) ([]*TenantStockAlertCount, error)
+
+	// GetInventoryValuation retrieves the units on hand and their value at cost of a tenant's
+	// active products with stock, by category and name
+	GetInventoryValuation(ctx context.Context, tenantID uuid.UUID, method entities.ValuationMethod) ([]*InventoryValuationItem, error)
+
+	// GetInventoryValuationByLocation retrieves the units of a tenant's active products held at
+	// each location and their value at cost, by location, category and name
+	GetInventoryValuationByLocation(ctx context.Context, tenantID uuid.UUID, method entities.ValuationMethod) ([]*LocationValuationItem, error)
+
 	// BulkUpdateStock updates multiple stock records in a transaction
 	BulkUpdateStock(ctx context.Context, stocks []*entities.Stock) error
 
@@ -63,6 +89,9 @@ type StockMovementRepository interface {
 	// GetByID retrieves a stock movement by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.StockMovement, error)
 
+	// GetReversal retrieves the movement that reverses a stock movement
+	GetReversal(ctx context.Context, movementID uuid.UUID) (*entities.StockMovement, error)
+
 	// List retrieves stock movements with pagination and filtering
 	List(ctx context.Context, filter StockMovementFilter, pagination utils.PaginationInfo) ([]*entities.StockMovement, utils.PaginationInfo, error)
 
@@ -77,6 +106,9 @@ type StockMovementRepository interface {
 
 	// BulkCreate creates multiple stock movement records in a transaction
 	BulkCreate(ctx context.Context, movements []*entities.StockMovement) error
+
+	// GetLedger retrieves a product's running-balance stock ledger over [fromDate, toDate)
+	GetLedger(ctx context.Context, productID uuid.UUID, fromDate, toDate time.Time) (*StockLedger, error)
 }
 
 // StockFilter represents filters for stock queries
@@ -89,17 +121,101 @@ type StockFilter struct {
 	OrderDir   string     `json:"order_dir,omitempty"` // ASC or DESC
 }
 
+// LowStockItem represents a product whose available stock is at or below its reorder level.
+// The supplier fields are set when the product has an active preferred supplier.
+type LowStockItem struct {
+	ProductID     uuid.UUID  `json:"product_id"`
+	ProductSKU    string     `json:"product_sku"`
+	ProductName   string     `json:"product_name"`
+	AvailableQty  int        `json:"available_qty"`
+	ReorderLevel  int        `json:"reorder_level"`
+	SupplierID    *uuid.UUID `json:"supplier_id,omitempty"`
+	SupplierName  string     `json:"supplier_name,omitempty"`
+	SupplierEmail string     `json:"supplier_email,omitempty"`
+	SupplierPhone string     `json:"supplier_phone,omitempty"`
+	LeadTimeDays  int        `json:"lead_time_days,omitempty"`
+}
+
+// InventorySummary represents aggregate stock figures for a tenant's active products.
+// Low and out of stock follow Stock.GetStockStatus, so a product is counted in at most one.
+type InventorySummary struct {
+	TotalSKUs       int             `json:"total_skus"`
+	TotalUnits      int             `json:"total_units"`
+	ReservedUnits   int             `json:"reserved_units"`
+	StockValue      decimal.Decimal `json:"stock_value"`        // Units on hand valued at cost
+	RetailValue     decimal.Decimal `json:"retail_value"`       // Units on hand valued at selling price
+	LowStockCount   int             `json:"low_stock_count"`    // Available but at or below reorder level
+	OutOfStockCount int             `json:"out_of_stock_count"` // Nothing available, including products without a stock record
+}
+
+// TenantStockAlertCount represents how many of a tenant's active products are low or out of
+// stock, counted as in InventorySummary
+type TenantStockAlertCount struct {
+	TenantID        uuid.UUID `json:"tenant_id"`
+	LowStockCount   int       `json:"low_stock_count"`
+	OutOfStockCount int       `json:"out_of_stock_count"`
+}
+
+// InventoryValuationItem represents the value at cost of one product's units on hand
+type InventoryValuationItem struct {
+	ProductID   uuid.UUID       `json:"product_id"`
+	ProductSKU  string          `json:"product_sku"`
+	ProductName string          `json:"product_name"`
+	Category    string          `json:"category"`
+	OnHandQty   int             `json:"on_hand_qty"`
+	UnitCost    decimal.Decimal `json:"unit_cost"`
+	Value       decimal.Decimal `json:"value"`
+}
+
+// LocationValuationItem represents the units of a product held at a location and their value at cost
+type LocationValuationItem struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	LocationName string    `json:"location_name"`
+	InventoryValuationItem
+}
+
 // StockMovementFilter represents filters for stock movement queries
 type StockMovementFilter struct {
-	ProductID *uuid.UUID                    `json:"product_id,omitempty"`
-	Type      *entities.StockMovementType   `json:"type,omitempty"`
-	Reason    *entities.StockMovementReason `json:"reason,omitempty"`
-	Reference string                        `json:"reference,omitempty"`
-	CreatedBy *uuid.UUID                    `json:"created_by,omitempty"`
-	FromDate  *time.Time                    `json:"from_date,omitempty"`
-	ToDate    *time.Time                    `json:"to_date,omitempty"`
-	OrderBy   string                        `json:"order_by,omitempty"`
-	OrderDir  string                        `json:"order_dir,omitempty"` // ASC or DESC
+	ProductID  *uuid.UUID                    `json:"product_id,omitempty"`
+	Type       *entities.StockMovementType   `json:"type,omitempty"`
+	Reason     *entities.StockMovementReason `json:"reason,omitempty"`
+	Reference  string                        `json:"reference,omitempty"`
+	LocationID *uuid.UUID                    `json:"location_id,omitempty"`
+	CreatedBy  *uuid.UUID                    `json:"created_by,omitempty"`
+	FromDate   *time.Time                    `json:"from_date,omitempty"`
+	ToDate     *time.Time                    `json:"to_date,omitempty"`
+	OrderBy    string                        `json:"order_by,omitempty"`
+	OrderDir   string                        `json:"order_dir,omitempty"` // ASC or DESC
+}
+
+// StockLedger represents a product's stock on hand over a period: the balance at the start, each
+// movement with the balance it left, and the balance at the end. Reservations and transfers
+// between locations do not change the stock on hand and are left out.
+type StockLedger struct {
+	ProductID      uuid.UUID          `json:"product_id"`
+	FromDate       time.Time          `json:"from_date"`
+	ToDate         time.Time          `json:"to_date"`
+	OpeningBalance int                `json:"opening_balance"`
+	TotalIn        int                `json:"total_in"`
+	TotalOut       int                `json:"total_out"`
+	ClosingBalance int                `json:"closing_balance"`
+	Entries        []StockLedgerEntry `json:"entries"`
+}
+
+// StockLedgerEntry represents a stock movement in a ledger. Change is signed: positive for
+// stock received and negative for stock taken out.
+type StockLedgerEntry struct {
+	MovementID uuid.UUID                    `json:"movement_id"`
+	Type       entities.StockMovementType   `json:"type"`
+	Reason     entities.StockMovementReason `json:"reason"`
+	Change     int                          `json:"change"`
+	Balance    int                          `json:"balance"`
+	Reference  string                       `json:"reference,omitempty"`
+	Notes      string                       `json:"notes,omitempty"`
+	ReversalOf *uuid.UUID                   `json:"reversal_of,omitempty"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	CreatedBy  uuid.UUID                    `json:"created_by"`
 }
 
 // StockAdjustment represents a stock adjustment operation
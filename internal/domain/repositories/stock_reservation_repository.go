@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// StockReservationRepository defines the interface for stock reservation data access
+type StockReservationRepository interface {
+	// Create creates a new stock reservation with its items
+	Create(ctx context.Context, reservation *entities.StockReservation) error
+
+	// GetByID retrieves a stock reservation by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.StockReservation, error)
+
+	// GetByIDForUpdate retrieves a stock reservation by ID and locks it until the transaction ends
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StockReservation, error)
+
+	// Update updates a stock reservation and its item quantities
+	Update(ctx context.Context, reservation *entities.StockReservation) error
+
+	// List retrieves stock reservations with pagination and filtering
+	List(ctx context.Context, filter StockReservationFilter, pagination utils.PaginationInfo) ([]*entities.StockReservation, utils.PaginationInfo, error)
+
+	// GetByReference retrieves all stock reservations for an external order reference
+	GetByReference(ctx context.Context, reference string) ([]*entities.StockReservation, error)
+
+	// GetExpired retrieves open stock reservations whose expiry is before the given time
+	GetExpired(ctx context.Context, before time.Time, limit int) ([]*entities.StockReservation, error)
+}
+
+// StockReservationFilter represents filters for stock reservation queries
+type StockReservationFilter struct {
+	TenantID  uuid.UUID                        `json:"tenant_id"`
+	Reference string                           `json:"reference,omitempty"`
+	Source    string                           `json:"source,omitempty"`
+	Status    *entities.StockReservationStatus `json:"status,omitempty"`
+	ProductID *uuid.UUID                       `json:"product_id,omitempty"`
+	OrderBy   string                           `json:"order_by,omitempty"`
+	OrderDir  string                           `json:"order_dir,omitempty"` // ASC or DESC
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// StockTransferRepository defines the interface for stock transfer data access
+type StockTransferRepository interface {
+	// Create creates a new stock transfer with its items
+	Create(ctx context.Context, transfer *entities.StockTransfer) error
+
+	// GetByID retrieves a stock transfer by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.StockTransfer, error)
+
+	// GetByIDForUpdate retrieves a stock transfer by ID and locks it until the transaction
+	// ends, so it is shipped and received only once
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StockTransfer, error)
+
+	// Update updates the status of a stock transfer
+	Update(ctx context.Context, transfer *entities.StockTransfer) error
+
+	// List retrieves stock transfers with pagination and filtering, latest first
+	List(ctx context.Context, filter StockTransferFilter, pagination utils.PaginationInfo) ([]*entities.StockTransfer, utils.PaginationInfo, error)
+}
+
+// StockTransferFilter represents filters for stock transfer queries
+type StockTransferFilter struct {
+	Status     *entities.StockTransferStatus `json:"status,omitempty"`
+	LocationID *uuid.UUID                    `json:"location_id,omitempty"` // Transfers from or to the location
+	ProductID  *uuid.UUID                    `json:"product_id,omitempty"`
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// SupplierRepository defines the interface for supplier data access
+type SupplierRepository interface {
+	// Create creates a new supplier
+	Create(ctx context.Context, supplier *entities.Supplier) error
+
+	// GetByID retrieves a supplier by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Supplier, error)
+
+	// Update updates a supplier
+	Update(ctx context.Context, supplier *entities.Supplier) error
+
+	// Delete deletes a supplier. Products linked to it lose their preferred supplier.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves suppliers with pagination and filtering
+	List(ctx context.Context, filter SupplierFilter, pagination utils.PaginationInfo) ([]*entities.Supplier, utils.PaginationInfo, error)
+}
+
+// SupplierFilter represents filters for supplier queries
+type SupplierFilter struct {
+	Search   string `json:"search,omitempty"` // Search in name, contact name and email
+	IsActive *bool  `json:"is_active,omitempty"`
+	OrderBy  string `json:"order_by,omitempty"`
+	OrderDir string `json:"order_dir,omitempty"` // ASC or DESC
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// TaxInvoiceSerialRepository defines the interface for tax invoice serial data access
+type TaxInvoiceSerialRepository interface {
+	// CreateRange creates a new range of serials
+	CreateRange(ctx context.Context, serialRange *entities.TaxInvoiceSerialRange) error
+
+	// ListRanges retrieves a tenant's serial ranges, newest year first
+	ListRanges(ctx context.Context, tenantID uuid.UUID) ([]*entities.TaxInvoiceSerialRange, error)
+
+	// Assign gives an invoice the next unused serial of the tenant's oldest range for a year with
+	// serials left. An invoice that already has a serial keeps it. It returns a not found error
+	// when every range of the year is used up.
+	Assign(ctx context.Context, tenantID, invoiceID uuid.UUID, year int, assignedBy uuid.UUID) (*entities.TaxInvoiceSerial, error)
+
+	// GetByInvoiceID retrieves the serial of an invoice
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) (*entities.TaxInvoiceSerial, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// TaxRateRepository defines the interface for tax rate data access
+type TaxRateRepository interface {
+	// Create creates a new tax rate
+	Create(ctx context.Context, taxRate *entities.TaxRate) error
+
+	// GetByID retrieves a tax rate by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TaxRate, error)
+
+	// GetByTenant retrieves all the tenant's tax rates, ordered by rate
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.TaxRate, error)
+
+	// Update updates a tax rate
+	Update(ctx context.Context, taxRate *entities.TaxRate) error
+}
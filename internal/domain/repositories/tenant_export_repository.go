@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// TenantExportRepository defines the interface for tenant export job data access
+type TenantExportRepository interface {
+	// Create creates a new tenant export job
+	Create(ctx context.Context, export *entities.TenantExport) error
+
+	// GetByID retrieves a tenant export job by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TenantExport, error)
+
+	// Update updates an existing tenant export job
+	Update(ctx context.Context, export *entities.TenantExport) error
+
+	// GetByTenant retrieves all export jobs for a tenant, newest first
+	GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.TenantExport, error)
+
+	// ClaimDue atomically marks up to limit export jobs as processing, oldest first. Pending jobs
+	// are claimed, as are processing jobs started before staleBefore whose worker stopped.
+	ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]*entities.TenantExport, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// TimeClockRepository defines the interface for staff time clock data access
+type TimeClockRepository interface {
+	// Create creates a new time clock entry
+	Create(ctx context.Context, entry *entities.TimeClockEntry) error
+
+	// GetByID retrieves a time clock entry by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TimeClockEntry, error)
+
+	// GetOpenByUser retrieves the shift a staff member is currently clocked in to
+	GetOpenByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entities.TimeClockEntry, error)
+
+	// Update updates a time clock entry and its breaks
+	Update(ctx context.Context, entry *entities.TimeClockEntry) error
+
+	// GetByPeriod retrieves the shifts overlapping [start, end), optionally for a single staff member
+	GetByPeriod(ctx context.Context, tenantID uuid.UUID, start, end time.Time, userID *uuid.UUID) ([]*entities.TimeClockEntry, error)
+}
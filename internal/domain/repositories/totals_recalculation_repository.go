@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// TotalsRecalculationRepository defines the interface for totals recalculation data access
+type TotalsRecalculationRepository interface {
+	// Create creates a new recalculation with its corrections
+	Create(ctx context.Context, recalculation *entities.TotalsRecalculation) error
+
+	// GetByID retrieves a recalculation with its corrections by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TotalsRecalculation, error)
+
+	// Update updates the status and counters of a recalculation
+	Update(ctx context.Context, recalculation *entities.TotalsRecalculation) error
+
+	// UpdateCorrection updates the outcome of a single correction
+	UpdateCorrection(ctx context.Context, correction *entities.TotalsCorrection) error
+
+	// GetByTenant retrieves the most recent recalculations of a tenant without their corrections
+	GetByTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]*entities.TotalsRecalculation, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// UserInvitationRepository defines the interface for user invitation data access
+type UserInvitationRepository interface {
+	// Create creates a new invitation
+	Create(ctx context.Context, invitation *entities.UserInvitation) error
+
+	// GetByID retrieves an invitation by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.UserInvitation, error)
+
+	// GetLatestByUser retrieves the most recent invitation of a user of a tenant
+	GetLatestByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entities.UserInvitation, error)
+
+	// Update updates an existing invitation
+	Update(ctx context.Context, invitation *entities.UserInvitation) error
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -37,6 +38,14 @@ type UserRepository interface {
 	
 	// ExistsByEmail checks if a user exists by email
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// GetActiveEmailsByRole retrieves the email addresses of a tenant's active users with a role
+	GetActiveEmailsByRole(ctx context.Context, tenantID uuid.UUID, role entities.UserRole) ([]string, error)
+
+	// RequirePasswordChange makes a tenant's users change their password on next login, either the
+	// given users or, when none are given, every user with the role, or every user when role is nil.
+	// It returns the IDs of the affected users.
+	RequirePasswordChange(ctx context.Context, tenantID uuid.UUID, userIDs []uuid.UUID, role *entities.UserRole, at time.Time) ([]uuid.UUID, error)
 }
 
 // UserFilter represents filters for user queries
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// UserSessionRepository defines the interface for user session data access
+type UserSessionRepository interface {
+	// Create creates a new session
+	Create(ctx context.Context, session *entities.UserSession) error
+
+	// GetByID retrieves a session by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.UserSession, error)
+
+	// Update updates an existing session if its refresh token hash is still previousRefreshTokenHash.
+	// It returns a conflict error when the refresh token was rotated since the session was read.
+	Update(ctx context.Context, session *entities.UserSession, previousRefreshTokenHash string) error
+
+	// GetActiveByUser retrieves the sessions of a user that are active at the given time
+	GetActiveByUser(ctx context.Context, userID uuid.UUID, at time.Time) ([]*entities.UserSession, error)
+
+	// GetIPAddressesByUser retrieves the distinct IP addresses of a user's sessions created since the given time
+	GetIPAddressesByUser(ctx context.Context, userID uuid.UUID, since time.Time) ([]string, error)
+
+	// RevokeAllByUser revokes every active session of a user and returns the IDs of the revoked sessions
+	RevokeAllByUser(ctx context.Context, userID uuid.UUID, revokedBy *uuid.UUID, reason entities.SessionRevokeReason, at time.Time) ([]uuid.UUID, error)
+
+	// DeleteExpired deletes sessions that expired or were revoked before the given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
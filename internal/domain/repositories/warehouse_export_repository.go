@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// WarehouseExportRepository defines the interface for warehouse export setting and file data access
+type WarehouseExportRepository interface {
+	// GetSettingByTenant retrieves the warehouse export setting of a tenant
+	GetSettingByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.WarehouseExportSetting, error)
+
+	// SaveSetting creates or updates the warehouse export setting of a tenant
+	SaveSetting(ctx context.Context, setting *entities.WarehouseExportSetting) error
+
+	// ListEnabledSettings retrieves all enabled warehouse export settings across tenants
+	ListEnabledSettings(ctx context.Context) ([]*entities.WarehouseExportSetting, error)
+
+	// ClaimWindow atomically advances the watermark from previous to through. It returns
+	// false when another run already moved the watermark.
+	ClaimWindow(ctx context.Context, id uuid.UUID, previous *time.Time, through time.Time) (bool, error)
+
+	// ReleaseWindow restores the previous watermark after a failed export so the window is retried
+	ReleaseWindow(ctx context.Context, id uuid.UUID, through time.Time, previous *time.Time) error
+
+	// RecordRun records when the export last ran and the error it failed with, if any
+	RecordRun(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error
+
+	// SaveFile records an exported file, replacing the record of a previous export of the same key
+	SaveFile(ctx context.Context, file *entities.WarehouseExportFile) error
+
+	// ListFiles retrieves the exported files of a tenant in partition order
+	ListFiles(ctx context.Context, tenantID uuid.UUID, filter WarehouseExportFileFilter) ([]*entities.WarehouseExportFile, error)
+}
+
+// WarehouseExportFileFilter represents filters for listing exported files
+type WarehouseExportFileFilter struct {
+	Dataset  *entities.WarehouseDataset
+	DateFrom *time.Time
+	DateTo   *time.Time
+}
+
+// WarehouseSourceRepository defines the interface for reading the rows exported to the warehouse.
+// Each method returns the rows of a tenant that changed in the half-open window [from, to).
+type WarehouseSourceRepository interface {
+	// GetSales retrieves sales created, updated or deleted in the window
+	GetSales(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]WarehouseSaleRow, error)
+
+	// GetSaleItems retrieves the current items of sales updated in the window
+	GetSaleItems(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]WarehouseSaleItemRow, error)
+
+	// GetPayments retrieves sale payments completed and refunds paid out in the window
+	GetPayments(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]WarehousePaymentRow, error)
+
+	// GetStockMovements retrieves stock movements recorded in the window
+	GetStockMovements(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]WarehouseStockMovementRow, error)
+
+	// GetCostAdjustments retrieves inventory cost adjustments recorded in the window
+	GetCostAdjustments(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]WarehouseCostAdjustmentRow, error)
+}
+
+// WarehouseSaleRow represents a sale as exported to the warehouse
+type WarehouseSaleRow struct {
+	ID              uuid.UUID
+	SaleNumber      string
+	Status          string
+	Channel         string
+	CustomerName    string
+	PaymentMethod   string
+	Subtotal        decimal.Decimal
+	DiscountAmount  decimal.Decimal
+	TaxAmount       decimal.Decimal
+	SurchargeAmount decimal.Decimal
+	TotalAmount     decimal.Decimal
+	Currency        string          // Empty on sales without a locked exchange rate
+	ExchangeRate    decimal.Decimal // Locked when the sale was created
+	CreatedBy       uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	CompletedAt     *time.Time
+	DeletedAt       *time.Time
+}
+
+// WarehouseSaleItemRow represents a sale item as exported to the warehouse
+type WarehouseSaleItemRow struct {
+	ID            uuid.UUID
+	SaleID        uuid.UUID
+	SaleNumber    string
+	ProductID     uuid.UUID
+	ProductSKU    string
+	ProductName   string
+	Quantity      int
+	ListPrice     decimal.Decimal
+	UnitPrice     decimal.Decimal
+	UnitCost      decimal.Decimal
+	TotalPrice    decimal.Decimal
+	PriceSource   string
+	SaleUpdatedAt time.Time
+}
+
+// Warehouse payment row kinds
+const (
+	WarehousePaymentKindPayment = "payment"
+	WarehousePaymentKindRefund  = "refund"
+)
+
+// WarehousePaymentRow represents money taken for a sale or paid back by a refund. Refund
+// amounts are negative so summing the amount column gives net takings.
+type WarehousePaymentRow struct {
+	ID         uuid.UUID // Sale ID for payments, refund ID for refunds
+	Kind       string
+	SaleID     uuid.UUID
+	SaleNumber string
+	Reference  string // Sale number for payments, refund number for refunds
+	Method     string
+	Amount     decimal.Decimal
+	Tendered   decimal.Decimal
+	Change     decimal.Decimal
+	Surcharge  decimal.Decimal
+	OccurredAt time.Time
+}
+
+// WarehouseStockMovementRow represents a stock movement as exported to the warehouse
+type WarehouseStockMovementRow struct {
+	ID          uuid.UUID
+	ProductID   uuid.UUID
+	ProductSKU  string
+	ProductName string
+	Type        string
+	Reason      string
+	Quantity    int
+	Reference   string
+	Notes       string
+	CreatedBy   uuid.UUID
+	CreatedAt   time.Time
+}
+
+// WarehouseCostAdjustmentRow represents an inventory cost adjustment as exported to the warehouse
+type WarehouseCostAdjustmentRow struct {
+	ID                uuid.UUID
+	ProductID         uuid.UUID
+	ProductSKU        string
+	ProductName       string
+	Reason            string
+	Quantity          int
+	OnHandQty         int
+	PreviousUnitCost  decimal.Decimal
+	AdjustedUnitCost  decimal.Decimal
+	ResultingUnitCost decimal.Decimal
+	Amount            decimal.Decimal
+	Notes             string
+	CreatedBy         uuid.UUID
+	CreatedAt         time.Time
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// WebhookRepository defines the interface for webhook endpoint and delivery data access
+type WebhookRepository interface {
+	// CreateEndpoint creates a new webhook endpoint
+	CreateEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+
+	// GetEndpointByID retrieves a webhook endpoint by ID
+	GetEndpointByID(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error)
+
+	// GetEndpointsByTenant retrieves all webhook endpoints of a tenant
+	GetEndpointsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.WebhookEndpoint, error)
+
+	// GetActiveEndpointsForEvent retrieves the active endpoints of a tenant subscribed to an event
+	GetActiveEndpointsForEvent(ctx context.Context, tenantID uuid.UUID, eventType entities.WebhookEventType) ([]*entities.WebhookEndpoint, error)
+
+	// UpdateEndpoint updates a webhook endpoint, including its secret
+	UpdateEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+
+	// DeleteEndpoint deletes a webhook endpoint and its deliveries
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	// RecordEndpointFailure counts a failed attempt to an endpoint and returns how many attempts
+	// failed in a row
+	RecordEndpointFailure(ctx context.Context, id uuid.UUID) (int, error)
+
+	// ResetEndpointFailures clears the failure count of an endpoint after a successful attempt
+	ResetEndpointFailures(ctx context.Context, id uuid.UUID) error
+
+	// PauseEndpoint deactivates an endpoint that keeps failing. It returns false when the
+	// endpoint was already inactive, so a pause is only reported once.
+	PauseEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) (bool, error)
+
+	// CreateDeliveries records deliveries of an event
+	CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+
+	// CreateReplay records a replay delivery. It returns false when a replay of the same
+	// original delivery is still pending.
+	CreateReplay(ctx context.Context, replay *entities.WebhookDelivery) (bool, error)
+
+	// GetReplays retrieves the replays of a delivery, oldest first
+	GetReplays(ctx context.Context, deliveryID uuid.UUID) ([]*entities.WebhookDelivery, error)
+
+	// GetReplayCandidates retrieves up to limit original deliveries of an endpoint created in
+	// [from, to) that failed, or were delivered when includeDelivered is set, skipping those
+	// with a pending replay or, unless includeDelivered is set, a delivered one
+	GetReplayCandidates(ctx context.Context, endpointID uuid.UUID, from, to time.Time, includeDelivered bool, limit int) ([]*entities.WebhookDelivery, error)
+
+	// GetDeliveryByID retrieves a webhook delivery by ID
+	GetDeliveryByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+
+	// ListDeliveries retrieves webhook deliveries with pagination and filtering, newest first
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter, pagination utils.PaginationInfo) ([]*entities.WebhookDelivery, utils.PaginationInfo, error)
+
+	// ClaimDueDeliveries atomically leases up to limit pending deliveries due at now by moving
+	// their next attempt to leaseUntil, so a delivery is only sent by one server at a time
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error)
+
+	// UpdateDelivery records the outcome of a delivery attempt
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
+
+// WebhookDeliveryFilter represents filters for webhook delivery queries
+type WebhookDeliveryFilter struct {
+	EndpointID *uuid.UUID                      `json:"endpoint_id,omitempty"`
+	EventType  *entities.WebhookEventType      `json:"event_type,omitempty"`
+	Status     *entities.WebhookDeliveryStatus `json:"status,omitempty"`
+	FromDate   *time.Time                      `json:"from_date,omitempty"`
+	ToDate     *time.Time                      `json:"to_date,omitempty"`
+}
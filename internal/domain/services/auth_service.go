@@ -44,8 +44,8 @@ type AuthService interface {
 
 // JWTService defines the interface for JWT token operations
 type JWTService interface {
-	// GenerateTokenPair generates access and refresh tokens
-	GenerateTokenPair(user *entities.User) (*TokenPair, error)
+	// GenerateTokenPair generates access and refresh tokens bound to a session
+	GenerateTokenPair(user *entities.User, sessionID uuid.UUID) (*TokenPair, error)
 	
 	// ValidateAccessToken validates an access token and returns claims
 	ValidateAccessToken(tokenString string) (*JWTClaims, error)
@@ -86,6 +86,7 @@ type TokenPair struct {
 // JWTClaims represents JWT token claims
 type JWTClaims struct {
 	UserID    uuid.UUID         `json:"user_id"`
+	SessionID uuid.UUID         `json:"session_id"` // The session the token was issued for
 	Username  string            `json:"username"`
 	Email     string            `json:"email"`
 	Role      entities.UserRole `json:"role"`
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 )
@@ -26,6 +27,25 @@ type InvoicePDFService interface {
 
 	// PreviewInvoice generates a preview image of the invoice
 	PreviewInvoice(ctx context.Context, invoice *entities.Invoice, template *entities.InvoiceTemplate) ([]byte, error)
+
+	// GenerateRegisterReportPDF generates an X- or Z-report on A4 sheets
+	GenerateRegisterReportPDF(ctx context.Context, report *entities.RegisterReport) ([]byte, error)
+
+	// GenerateRegisterReportReceiptPDF generates an X- or Z-report as a thermal receipt (80mm width)
+	GenerateRegisterReportReceiptPDF(ctx context.Context, report *entities.RegisterReport) ([]byte, error)
+
+	// GenerateQuotePDF generates a quote with the company details of an invoice template
+	GenerateQuotePDF(ctx context.Context, quote *entities.Quote, template *entities.InvoiceTemplate) ([]byte, error)
+
+	// GenerateSaleReceiptPDF generates the receipt of a completed sale without an invoice, as a
+	// thermal receipt (80mm width) unless the template is for A4 or A5 sheets
+	GenerateSaleReceiptPDF(ctx context.Context, sale *entities.Sale, template *entities.InvoiceTemplate) ([]byte, error)
+}
+
+// ShelfLabelPDFService defines the interface for shelf label PDF generation
+type ShelfLabelPDFService interface {
+	// GenerateShelfLabelsPDF renders labels onto sheets of the given layout, one label per slot
+	GenerateShelfLabelsPDF(ctx context.Context, labels []entities.ShelfLabel, layout entities.LabelLayout) ([]byte, error)
 }
 
 // EmailService defines the interface for email operations
@@ -33,6 +53,10 @@ type EmailService interface {
 	// SendInvoiceEmail sends an invoice via email
 	SendInvoiceEmail(ctx context.Context, invoice *entities.Invoice, recipient string, pdfData []byte) error
 
+	// SendInvoiceLinkEmail sends an invoice via email as a download link valid until expiresAt,
+	// for a PDF too large to attach
+	SendInvoiceLinkEmail(ctx context.Context, invoice *entities.Invoice, recipient, downloadURL string, expiresAt time.Time) error
+
 	// SendReceiptEmail sends a receipt via email
 	SendReceiptEmail(ctx context.Context, invoice *entities.Invoice, recipient string, pdfData []byte) error
 
@@ -45,6 +69,16 @@ type EmailService interface {
 	// SendOverdueNotice sends overdue payment notice
 	SendOverdueNotice(ctx context.Context, invoice *entities.Invoice, recipient string) error
 
+	// SendQuoteEmail sends a quote via email
+	SendQuoteEmail(ctx context.Context, quote *entities.Quote, recipient string, pdfData []byte) error
+
+	// SendSaleReceiptEmail sends the receipt of a completed sale via email
+	SendSaleReceiptEmail(ctx context.Context, sale *entities.Sale, recipient string, pdfData []byte) error
+
+	// SendUserInvitationEmail sends an invited user the link to activate their account, valid
+	// until the invitation expires
+	SendUserInvitationEmail(ctx context.Context, invitation *entities.UserInvitation, user *entities.User, activationURL string) error
+
 	// ValidateEmailAddress validates an email address
 	ValidateEmailAddress(email string) bool
 }
@@ -57,6 +91,9 @@ type PrintService interface {
 	// PrintReceipt prints a receipt to a thermal printer
 	PrintReceipt(ctx context.Context, invoice *entities.Invoice, template *entities.InvoiceTemplate, printerName string) error
 
+	// PrintSaleReceipt prints the receipt of a completed sale to a printer
+	PrintSaleReceipt(ctx context.Context, sale *entities.Sale, template *entities.InvoiceTemplate, printerName string) error
+
 	// GetAvailablePrinters returns list of available printers
 	GetAvailablePrinters() ([]PrinterInfo, error)
 
@@ -65,6 +102,12 @@ type PrintService interface {
 
 	// SetDefaultPrinter sets the default printer
 	SetDefaultPrinter(printerName string) error
+
+	// PrintTestPage prints a test page to a printer
+	PrintTestPage(ctx context.Context, printerName string) error
+
+	// PrintRegisterReport prints an X- or Z-report to a printer, as a receipt on thermal printers
+	PrintRegisterReport(ctx context.Context, report *entities.RegisterReport, printerName string, thermal bool) error
 }
 
 // PrinterInfo represents printer information
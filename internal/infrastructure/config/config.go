@@ -17,14 +17,27 @@ type Config struct {
 	Tenant    TenantConfig
 	Security  SecurityConfig
 	Features  FeatureConfig
+	RequestLog RequestLogConfig
+	Storage   StorageConfig
+	Sales     SalesConfig
+	GRPC      GRPCConfig
+	SIEM      SIEMConfig
+	InvoiceReminders InvoiceReminderConfig
+	Email     EmailConfig
+	ExchangeRates ExchangeRateConfig
+	PaymentGateways PaymentGatewayConfig
+	Metrics   MetricsConfig
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port         string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
+	Port          string
+	ReadTimeout   time.Duration
+	WriteTimeout  time.Duration
+	IdleTimeout   time.Duration
+	DashboardURL  string        // Base URL of the web dashboard, used for links in emails
+	PublicURL     string        // Base URL the API is reachable at by customers, used for invoice portal links in emails
+	WarmUpTimeout time.Duration // Bound on the startup warm-up run before /health/ready passes, 0 to skip it
 }
 
 // DatabaseConfig holds database configuration
@@ -39,6 +52,14 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	MigrationsPath  string
+
+	// ReplicaDSN is the connection string of a read-only replica that list, lookup and report
+	// reads are sent to. Reads stay on the primary when it is empty or the replica is unavailable.
+	ReplicaDSN string
+	// ReplicaMaxLag is how far behind the primary the replica may be before reads leave it
+	ReplicaMaxLag time.Duration
+	// ReplicaCheckInterval is how often the replica's availability and lag are checked
+	ReplicaCheckInterval time.Duration
 }
 
 // JWTConfig holds JWT configuration
@@ -75,11 +96,125 @@ type SecurityConfig struct {
 	PasswordRequireLower  bool
 	PasswordRequireDigit  bool
 	PasswordRequireSymbol bool
+	PasswordHistorySize   int           // Previous passwords a user may not reuse
+	PasswordMaxAge        time.Duration // Passwords older than this must be changed on next login, 0 disables expiry
+	InvitationExpiry      time.Duration // How long the activation link emailed to an invited user stays valid
 	MaxLoginAttempts      int
-	LockoutDuration       time.Duration
+	LoginAttemptWindow    time.Duration // Failed logins older than this no longer count towards a lockout
+	LockoutDuration       time.Duration // Length of the first lockout, doubled with each lockout in a row
+	MaxLockoutDuration    time.Duration // Cap on the lockout length
 	SessionTimeout        time.Duration
 }
 
+// RequestLogConfig holds API request/response body logging configuration. Captured bodies
+// are redacted and kept only for the retention period.
+type RequestLogConfig struct {
+	Enabled      bool
+	SampleRate   float64 // Fraction of requests captured, 0 to 1
+	MaxBodyBytes int     // Bodies larger than this are not stored
+	Retention    time.Duration
+	RedactFields []string // Extra JSON fields to mask on top of the built-in rules
+}
+
+// StorageConfig holds S3-compatible object storage configuration. The bucket holds tenant
+// exports and the warehouse Parquet files; tenants never see these credentials.
+type StorageConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool
+}
+
+// SalesConfig holds point of sale configuration
+type SalesConfig struct {
+	HeldSaleTTL       time.Duration // How long a parked sale keeps its stock reserved before it is cancelled
+	IdempotencyKeyTTL time.Duration // How long a retried request with the same Idempotency-Key gets the stored response
+}
+
+// InvoiceReminderConfig holds the schedule of the payment reminders emailed for unpaid invoices
+type InvoiceReminderConfig struct {
+	Enabled           bool
+	SendHour          int // Hour of the day, in UTC, from which the day's reminders are sent
+	DaysBeforeDue     int // Reminder sent this many days before the due date, 0 to skip it
+	OverdueEveryDays  int // Days between overdue notices
+	MaxOverdueNotices int // Overdue notices sent per invoice, 0 to send none
+}
+
+// EmailConfig holds the provider outgoing email is sent through. Only the settings of the
+// selected provider are used.
+type EmailConfig struct {
+	Provider           string // smtp, sendgrid, ses or mailgun
+	FromAddress        string
+	FromName           string
+	Timeout            time.Duration // Per message
+	SMTPHost           string
+	SMTPPort           string
+	SMTPUsername       string
+	SMTPPassword       string
+	SendGridAPIKey     string
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+	MailgunDomain      string
+	MailgunAPIKey      string
+	MailgunBaseURL     string        // https://api.eu.mailgun.net for EU domains
+	MaxAttachmentSize  int           // Largest invoice PDF attached, in bytes; larger ones are sent as a signed portal link. 0 to always attach
+	DownloadLinkExpiry time.Duration // How long a signed portal link stays valid
+}
+
+// GRPCConfig holds gRPC server configuration
+type GRPCConfig struct {
+	Enabled        bool
+	Port           string
+	MaxRecvMsgSize int // Largest request message accepted, in bytes
+}
+
+// SIEMConfig holds shipping of audit events and security logs to an external SIEM
+type SIEMConfig struct {
+	Enabled       bool
+	Sink          string        // syslog, http or opensearch
+	Endpoint      string        // host:port for syslog, URL for http and opensearch
+	Network       string        // tcp or udp, syslog only
+	Index         string        // Index, alias or data stream, opensearch only
+	Username      string        // Basic auth, opensearch only
+	Password      string        // Basic auth, opensearch only
+	Token         string        // Bearer token, http only
+	LogLevel      string        // Lowest log level shipped, audit events are always shipped
+	Timeout       time.Duration // Per batch
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+	MaxRetries    int
+	RetryBackoff  time.Duration
+	BlockTimeout  time.Duration // How long logging waits for room in a full buffer before dropping the event
+}
+
+// MetricsConfig holds the export of metrics for Prometheus to scrape
+type MetricsConfig struct {
+	PrometheusEnabled  bool          // Serve /metrics in the Prometheus text format instead of JSON
+	Token              string        // Bearer token scrapers must send, empty to leave /metrics open
+	StockAlertInterval time.Duration // How often the low and out of stock gauges are recounted
+}
+
+// ExchangeRateConfig holds the provider sales in foreign currencies take their rates from
+type ExchangeRateConfig struct {
+	Endpoint string        // Base URL of a Frankfurter-compatible rates API
+	Timeout  time.Duration // Per request
+	CacheTTL time.Duration // How long fetched rates are used before they are fetched again
+}
+
+// PaymentGatewayConfig holds the gateways card and digital wallet payments are collected
+// through. A gateway without a key is not offered.
+type PaymentGatewayConfig struct {
+	Timeout             time.Duration // Per request
+	StripeSecretKey     string
+	StripeWebhookSecret string // Signing secret of the Stripe webhook endpoint
+	MidtransServerKey   string
+	MidtransProduction  bool // Charge through the production environment instead of the sandbox
+}
+
 // FeatureConfig holds feature flag configuration
 type FeatureConfig struct {
 	EnableMultiTenancy     bool
@@ -95,10 +230,13 @@ type FeatureConfig struct {
 func Load() (*Config, error) {
 	cfg := &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "8080"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 10*time.Second),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 10*time.Second),
-			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			Port:          getEnv("SERVER_PORT", "8080"),
+			ReadTimeout:   getDurationEnv("SERVER_READ_TIMEOUT", 10*time.Second),
+			WriteTimeout:  getDurationEnv("SERVER_WRITE_TIMEOUT", 10*time.Second),
+			IdleTimeout:   getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			DashboardURL:  getEnv("DASHBOARD_URL", "http://localhost:3000"),
+			PublicURL:     getEnv("SERVER_PUBLIC_URL", "http://localhost:8080"),
+			WarmUpTimeout: getDurationEnv("SERVER_WARMUP_TIMEOUT", 30*time.Second),
 		},
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -111,6 +249,10 @@ func Load() (*Config, error) {
 			MaxIdleConns:    getIntEnv("DB_MAX_IDLE_CONNS", 25),
 			ConnMaxLifetime: getDurationEnv("DB_CONN_MAX_LIFETIME", 5*time.Minute),
 			MigrationsPath:  getEnv("DB_MIGRATIONS_PATH", "migrations"),
+
+			ReplicaDSN:           getEnv("DB_REPLICA_DSN", ""),
+			ReplicaMaxLag:        getDurationEnv("DB_REPLICA_MAX_LAG", 5*time.Second),
+			ReplicaCheckInterval: getDurationEnv("DB_REPLICA_CHECK_INTERVAL", 10*time.Second),
 		},
 		JWT: JWTConfig{
 			SecretKey:           getEnv("JWT_SECRET_KEY", "your-256-bit-secret"),
@@ -139,8 +281,13 @@ func Load() (*Config, error) {
 			PasswordRequireLower:  getBoolEnv("SECURITY_PASSWORD_REQUIRE_LOWER", true),
 			PasswordRequireDigit:  getBoolEnv("SECURITY_PASSWORD_REQUIRE_DIGIT", true),
 			PasswordRequireSymbol: getBoolEnv("SECURITY_PASSWORD_REQUIRE_SYMBOL", false),
+			PasswordHistorySize:   getIntEnv("SECURITY_PASSWORD_HISTORY_SIZE", 5),
+			PasswordMaxAge:        getDurationEnv("SECURITY_PASSWORD_MAX_AGE", 0),
+			InvitationExpiry:      getDurationEnv("SECURITY_INVITATION_EXPIRY", 72*time.Hour),
 			MaxLoginAttempts:      getIntEnv("SECURITY_MAX_LOGIN_ATTEMPTS", 5),
+			LoginAttemptWindow:    getDurationEnv("SECURITY_LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
 			LockoutDuration:       getDurationEnv("SECURITY_LOCKOUT_DURATION", 15*time.Minute),
+			MaxLockoutDuration:    getDurationEnv("SECURITY_MAX_LOCKOUT_DURATION", 24*time.Hour),
 			SessionTimeout:        getDurationEnv("SECURITY_SESSION_TIMEOUT", 8*time.Hour),
 		},
 		Features: FeatureConfig{
@@ -152,6 +299,91 @@ func Load() (*Config, error) {
 			EnableCustomDomains: getBoolEnv("FEATURE_ENABLE_CUSTOM_DOMAINS", false),
 			EnableFeatureGating: getBoolEnv("FEATURE_ENABLE_FEATURE_GATING", true),
 		},
+		RequestLog: RequestLogConfig{
+			Enabled:      getBoolEnv("REQUEST_LOG_ENABLED", false),
+			SampleRate:   getFloatEnv("REQUEST_LOG_SAMPLE_RATE", 0.01),
+			MaxBodyBytes: getIntEnv("REQUEST_LOG_MAX_BODY_BYTES", 16*1024),
+			Retention:    getDurationEnv("REQUEST_LOG_RETENTION", 72*time.Hour),
+			RedactFields: getListEnv("REQUEST_LOG_REDACT_FIELDS", nil),
+		},
+		Storage: StorageConfig{
+			Endpoint:        getEnv("STORAGE_S3_ENDPOINT", ""),
+			Region:          getEnv("STORAGE_S3_REGION", "us-east-1"),
+			Bucket:          getEnv("STORAGE_S3_BUCKET", ""),
+			AccessKeyID:     getEnv("STORAGE_S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv("STORAGE_S3_SECRET_ACCESS_KEY", ""),
+			PathStyle:       getBoolEnv("STORAGE_S3_PATH_STYLE", false),
+		},
+		Sales: SalesConfig{
+			HeldSaleTTL:       getDurationEnv("SALES_HELD_SALE_TTL", 4*time.Hour),
+			IdempotencyKeyTTL: getDurationEnv("SALES_IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		},
+		InvoiceReminders: InvoiceReminderConfig{
+			Enabled:           getBoolEnv("INVOICE_REMINDERS_ENABLED", true),
+			SendHour:          getIntEnv("INVOICE_REMINDERS_SEND_HOUR", 9),
+			DaysBeforeDue:     getIntEnv("INVOICE_REMINDERS_DAYS_BEFORE_DUE", 3),
+			OverdueEveryDays:  getIntEnv("INVOICE_REMINDERS_OVERDUE_EVERY_DAYS", 7),
+			MaxOverdueNotices: getIntEnv("INVOICE_REMINDERS_MAX_OVERDUE_NOTICES", 3),
+		},
+		Email: EmailConfig{
+			Provider:           getEnv("EMAIL_PROVIDER", "smtp"),
+			FromAddress:        getEnv("EMAIL_FROM_ADDRESS", ""),
+			FromName:           getEnv("EMAIL_FROM_NAME", "ADOL POS"),
+			Timeout:            getDurationEnv("EMAIL_TIMEOUT", 30*time.Second),
+			SMTPHost:           getEnv("SMTP_HOST", ""),
+			SMTPPort:           getEnv("SMTP_PORT", "587"),
+			SMTPUsername:       getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:       getEnv("SMTP_PASSWORD", ""),
+			SendGridAPIKey:     getEnv("SENDGRID_API_KEY", ""),
+			SESRegion:          getEnv("SES_REGION", "us-east-1"),
+			SESAccessKeyID:     getEnv("SES_ACCESS_KEY_ID", ""),
+			SESSecretAccessKey: getEnv("SES_SECRET_ACCESS_KEY", ""),
+			MailgunDomain:      getEnv("MAILGUN_DOMAIN", ""),
+			MailgunAPIKey:      getEnv("MAILGUN_API_KEY", ""),
+			MailgunBaseURL:     getEnv("MAILGUN_BASE_URL", "https://api.mailgun.net"),
+			MaxAttachmentSize:  getIntEnv("EMAIL_MAX_ATTACHMENT_SIZE", 10<<20),
+			DownloadLinkExpiry: getDurationEnv("EMAIL_DOWNLOAD_LINK_EXPIRY", 7*24*time.Hour),
+		},
+		GRPC: GRPCConfig{
+			Enabled:        getBoolEnv("GRPC_ENABLED", true),
+			Port:           getEnv("GRPC_PORT", "9090"),
+			MaxRecvMsgSize: getIntEnv("GRPC_MAX_RECV_MSG_SIZE", 4<<20),
+		},
+		SIEM: SIEMConfig{
+			Enabled:       getBoolEnv("SIEM_ENABLED", false),
+			Sink:          getEnv("SIEM_SINK", "syslog"),
+			Endpoint:      getEnv("SIEM_ENDPOINT", ""),
+			Network:       getEnv("SIEM_NETWORK", "tcp"),
+			Index:         getEnv("SIEM_INDEX", "adol-security"),
+			Username:      getEnv("SIEM_USERNAME", ""),
+			Password:      getEnv("SIEM_PASSWORD", ""),
+			Token:         getEnv("SIEM_TOKEN", ""),
+			LogLevel:      getEnv("SIEM_LOG_LEVEL", "warn"),
+			Timeout:       getDurationEnv("SIEM_TIMEOUT", 10*time.Second),
+			BufferSize:    getIntEnv("SIEM_BUFFER_SIZE", 10000),
+			BatchSize:     getIntEnv("SIEM_BATCH_SIZE", 500),
+			FlushInterval: getDurationEnv("SIEM_FLUSH_INTERVAL", 5*time.Second),
+			MaxRetries:    getIntEnv("SIEM_MAX_RETRIES", 5),
+			RetryBackoff:  getDurationEnv("SIEM_RETRY_BACKOFF", time.Second),
+			BlockTimeout:  getDurationEnv("SIEM_BLOCK_TIMEOUT", 100*time.Millisecond),
+		},
+		ExchangeRates: ExchangeRateConfig{
+			Endpoint: getEnv("EXCHANGE_RATE_ENDPOINT", "https://api.frankfurter.app"),
+			Timeout:  getDurationEnv("EXCHANGE_RATE_TIMEOUT", 10*time.Second),
+			CacheTTL: getDurationEnv("EXCHANGE_RATE_CACHE_TTL", time.Hour),
+		},
+		PaymentGateways: PaymentGatewayConfig{
+			Timeout:             getDurationEnv("PAYMENT_GATEWAY_TIMEOUT", 30*time.Second),
+			StripeSecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
+			StripeWebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			MidtransServerKey:   getEnv("MIDTRANS_SERVER_KEY", ""),
+			MidtransProduction:  getBoolEnv("MIDTRANS_PRODUCTION", false),
+		},
+		Metrics: MetricsConfig{
+			PrometheusEnabled:  getBoolEnv("METRICS_PROMETHEUS_ENABLED", false),
+			Token:              getEnv("METRICS_TOKEN", ""),
+			StockAlertInterval: getDurationEnv("METRICS_STOCK_ALERT_INTERVAL", time.Minute),
+		},
 	}
 
 	return cfg, nil
@@ -175,6 +407,32 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getFloatEnv gets an environment variable as float or returns a default value
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getListEnv gets a comma-separated environment variable as a list or returns a default value
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // getDurationEnv gets an environment variable as duration or returns a default value
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
@@ -248,10 +506,77 @@ func (c *Config) Validate() error {
 	if c.Security.PasswordMinLength < 8 {
 		return fmt.Errorf("password minimum length must be at least 8")
 	}
+
+	if c.Security.PasswordHistorySize < 0 || c.Security.PasswordMaxAge < 0 {
+		return fmt.Errorf("password history size and maximum age cannot be negative")
+	}
+
+	if c.Security.InvitationExpiry <= 0 {
+		return fmt.Errorf("invitation expiry must be positive")
+	}
 	
 	if c.Security.MaxLoginAttempts < 1 {
 		return fmt.Errorf("max login attempts must be at least 1")
 	}
+
+	if c.Security.LoginAttemptWindow <= 0 || c.Security.LockoutDuration <= 0 {
+		return fmt.Errorf("login attempt window and lockout duration must be positive")
+	}
+
+	if c.Security.MaxLockoutDuration < c.Security.LockoutDuration {
+		return fmt.Errorf("max lockout duration cannot be shorter than the lockout duration")
+	}
+	
+	if c.RequestLog.SampleRate < 0 || c.RequestLog.SampleRate > 1 {
+		return fmt.Errorf("request log sample rate must be between 0 and 1")
+	}
+	
+	if c.Sales.IdempotencyKeyTTL <= 0 {
+		return fmt.Errorf("idempotency key TTL must be positive")
+	}
+	
+	if c.SIEM.Enabled {
+		validSinks := []string{"syslog", "http", "opensearch"}
+		if !contains(validSinks, c.SIEM.Sink) {
+			return fmt.Errorf("invalid SIEM sink: %s, must be one of: %s", c.SIEM.Sink, strings.Join(validSinks, ", "))
+		}
+		if c.SIEM.Endpoint == "" {
+			return fmt.Errorf("SIEM endpoint must be set when SIEM shipping is enabled")
+		}
+	}
+	
+	if c.InvoiceReminders.Enabled {
+		if c.InvoiceReminders.SendHour < 0 || c.InvoiceReminders.SendHour > 23 {
+			return fmt.Errorf("invoice reminder send hour must be between 0 and 23")
+		}
+		if c.InvoiceReminders.DaysBeforeDue < 0 || c.InvoiceReminders.MaxOverdueNotices < 0 {
+			return fmt.Errorf("invoice reminder days before due and max overdue notices cannot be negative")
+		}
+		if c.InvoiceReminders.OverdueEveryDays < 1 {
+			return fmt.Errorf("invoice reminder overdue interval must be at least 1 day")
+		}
+	}
+	
+	validEmailProviders := []string{"smtp", "sendgrid", "ses", "mailgun"}
+	if !contains(validEmailProviders, c.Email.Provider) {
+		return fmt.Errorf("invalid email provider: %s, must be one of: %s", c.Email.Provider, strings.Join(validEmailProviders, ", "))
+	}
+	
+	if c.Email.MaxAttachmentSize < 0 {
+		return fmt.Errorf("email max attachment size cannot be negative")
+	}
+	
+	if c.Email.MaxAttachmentSize > 0 && c.Email.DownloadLinkExpiry <= 0 {
+		return fmt.Errorf("email download link expiry must be positive")
+	}
+	
+	if c.PaymentGateways.StripeSecretKey != "" && c.PaymentGateways.StripeWebhookSecret == "" {
+		return fmt.Errorf("Stripe webhook secret must be set when Stripe is enabled")
+	}
+
+	if c.Server.WarmUpTimeout < 0 {
+		return fmt.Errorf("server warm-up timeout cannot be negative")
+	}
 	
 	validLogLevels := []string{"trace", "debug", "info", "warn", "error", "fatal", "panic"}
 	if !contains(validLogLevels, strings.ToLower(c.Logger.Level)) {
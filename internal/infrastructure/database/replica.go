@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicklaros/adol/internal/infrastructure/config"
+)
+
+// replicaLagQuery returns how many seconds the replica's replayed data is behind the primary.
+// A replica that has replayed everything it received is not behind, however long ago the
+// last write was, and a server that is not a standby reports no lag.
+const replicaLagQuery = `
+	SELECT COALESCE(CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	END, 0)`
+
+type readerKey struct{}
+
+// ReplicaRouter implements the read routing of ports.DatabasePort. Contexts marked read-only
+// carry the read replica while it is reachable and no further behind the primary than the
+// configured maximum lag; otherwise they carry nothing and reads stay on the primary.
+// Writes and transactions always use the primary.
+type ReplicaRouter struct {
+	primary *sql.DB
+	replica *sql.DB
+	maxLag  time.Duration
+	healthy atomic.Bool
+}
+
+// NewReplicaRouter opens the read replica configured by cfg.ReplicaDSN. Without a replica
+// DSN every read goes to the primary. The replica is not used until CheckReplica has found
+// it healthy, so a replica that is down at startup does not stop the server from starting.
+func NewReplicaRouter(primary *sql.DB, cfg config.DatabaseConfig) (*ReplicaRouter, error) {
+	router := &ReplicaRouter{primary: primary, maxLag: cfg.ReplicaMaxLag}
+	if cfg.ReplicaDSN == "" {
+		return router, nil
+	}
+
+	replica, err := sql.Open("postgres", cfg.ReplicaDSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replica connection: %w", err)
+	}
+
+	replica.SetMaxOpenConns(cfg.MaxOpenConns)
+	replica.SetMaxIdleConns(cfg.MaxIdleConns)
+	replica.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	router.replica = replica
+	return router, nil
+}
+
+// HasReplica reports whether a read replica is configured
+func (r *ReplicaRouter) HasReplica() bool {
+	return r.replica != nil
+}
+
+// ReadOnly marks a context whose reads may be served by the read replica, which can lag
+// slightly behind the primary. It is left unchanged while the replica is unavailable.
+func (r *ReplicaRouter) ReadOnly(ctx context.Context) context.Context {
+	if r.replica == nil || !r.healthy.Load() {
+		return ctx
+	}
+	return context.WithValue(ctx, readerKey{}, r.replica)
+}
+
+// Health checks that the primary database is reachable
+func (r *ReplicaRouter) Health(ctx context.Context) error {
+	if err := r.primary.PingContext(ctx); err != nil {
+		return fmt.Errorf("database health check failed: %w", err)
+	}
+	return nil
+}
+
+// CheckReplica checks that the read replica is reachable and caught up, and routes reads to
+// it only while it is. It is run at startup and periodically.
+func (r *ReplicaRouter) CheckReplica(ctx context.Context) error {
+	if r.replica == nil {
+		return nil
+	}
+
+	var lagSeconds float64
+	if err := r.replica.QueryRowContext(ctx, replicaLagQuery).Scan(&lagSeconds); err != nil {
+		r.healthy.Store(false)
+		return fmt.Errorf("read replica is unavailable: %w", err)
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	if r.maxLag > 0 && lag > r.maxLag {
+		r.healthy.Store(false)
+		return fmt.Errorf("read replica is %s behind the primary, more than the allowed %s", lag.Round(time.Millisecond), r.maxLag)
+	}
+
+	r.healthy.Store(true)
+	return nil
+}
+
+// Close closes the read replica's connections. The primary is owned by the caller.
+func (r *ReplicaRouter) Close() error {
+	if r.replica == nil {
+		return nil
+	}
+	return r.replica.Close()
+}
+
+// Reader returns the database a repository read runs on: the replica a read-only context
+// carries, or else primary
+func Reader(ctx context.Context, primary *sql.DB) *sql.DB {
+	if replica, ok := ctx.Value(readerKey{}).(*sql.DB); ok {
+		return replica
+	}
+	return primary
+}
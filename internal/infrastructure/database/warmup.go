@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// WarmUp readies the connection pool before the first requests arrive. It opens up to conns
+// connections at once, so each of them is established rather than the same one being reused,
+// and prepares the given statements on every one of them, which loads the catalog entries
+// their tables and indexes need into that backend. The queries are then run once and their
+// rows read, pulling the reference data they touch into PostgreSQL's buffer cache.
+//
+// A statement or query that fails does not stop the others; all failures are returned
+// together. The connections are handed back to the pool, so keeping them warm relies on the
+// pool allowing at least conns idle connections.
+func WarmUp(ctx context.Context, db *sql.DB, conns int, statements, queries []string) error {
+	if conns < 1 {
+		conns = 1
+	}
+
+	var errs []error
+	opened := make([]*sql.Conn, 0, conns)
+	for i := 0; i < conns; i++ {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open connection: %w", err))
+			break
+		}
+		opened = append(opened, conn)
+	}
+
+	for _, conn := range opened {
+		for _, statement := range statements {
+			stmt, err := conn.PrepareContext(ctx, statement)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to prepare statement: %w", err))
+				continue
+			}
+			stmt.Close()
+		}
+	}
+
+	// Hand the connections back before running the queries, which may need one of them
+	for _, conn := range opened {
+		conn.Close()
+	}
+
+	for _, query := range queries {
+		if err := drainQuery(ctx, db, query); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// drainQuery runs a query and reads all of its rows
+func drainQuery(ctx context.Context, db *sql.DB, query string) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to run warm-up query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read warm-up query: %w", err)
+	}
+
+	return nil
+}
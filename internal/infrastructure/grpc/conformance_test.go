@@ -0,0 +1,242 @@
+package grpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// TestMethodPermissions checks every method of the registered services requires a permission
+// and every permission belongs to a registered method
+func TestMethodPermissions(t *testing.T) {
+	rpc := grpc.NewServer()
+	adolv1.RegisterProductServiceServer(rpc, &productService{})
+	adolv1.RegisterStockServiceServer(rpc, &stockService{})
+	adolv1.RegisterSaleServiceServer(rpc, &saleService{})
+	adolv1.RegisterInvoiceServiceServer(rpc, &invoiceService{})
+
+	registered := make(map[string]bool)
+	for service, info := range rpc.GetServiceInfo() {
+		for _, method := range info.Methods {
+			fullMethod := "/" + service + "/" + method.Name
+			registered[fullMethod] = true
+			assert.Contains(t, methodPermissions, fullMethod, "method has no permission")
+		}
+	}
+	for fullMethod := range methodPermissions {
+		assert.True(t, registered[fullMethod], "permission for %s, which is not registered", fullMethod)
+	}
+}
+
+// TestResponsesConvertEveryField converts use case responses with every field set and checks
+// each field of the message carries the value of the Go field of the same name
+func TestResponsesConvertEveryField(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func(value reflect.Value) proto.Message
+		typ     reflect.Type
+	}{
+		{"Product", func(v reflect.Value) proto.Message { return newProduct(v.Interface().(*usecases.ProductResponse)) }, reflect.TypeOf(usecases.ProductResponse{})},
+		{"Stock", func(v reflect.Value) proto.Message { return newStock(v.Interface().(*usecases.StockResponse)) }, reflect.TypeOf(usecases.StockResponse{})},
+		{"Sale", func(v reflect.Value) proto.Message { return newSale(v.Interface().(*usecases.SaleResponse)) }, reflect.TypeOf(usecases.SaleResponse{})},
+		{"Invoice", func(v reflect.Value) proto.Message { return newInvoice(v.Interface().(*usecases.InvoiceResponse)) }, reflect.TypeOf(usecases.InvoiceResponse{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := reflect.New(tt.typ)
+			n := 0
+			populateGo(resp.Elem(), 0, &n)
+
+			message := tt.convert(resp).ProtoReflect()
+			compareMessage(t, tt.name, message, resp)
+		})
+	}
+}
+
+// compareMessage checks every field of message against the Go field of the same name
+func compareMessage(t *testing.T, path string, message protoreflect.Message, value reflect.Value) {
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := path + "." + string(field.Name())
+		goField, ok := findGoField(value, string(field.Name()))
+		if !ok {
+			t.Errorf("%s: no Go field", fieldPath)
+			continue
+		}
+		if !message.Has(field) {
+			t.Errorf("%s: not encoded or decoded", fieldPath)
+			continue
+		}
+
+		if field.IsList() {
+			list := message.Get(field).List()
+			goField = reflect.Indirect(goField)
+			if goField.Kind() != reflect.Slice || goField.Len() != list.Len() {
+				t.Errorf("%s: got %d elements, Go field has %v", fieldPath, list.Len(), goField)
+				continue
+			}
+			for j := 0; j < list.Len(); j++ {
+				compareValue(t, fmt.Sprintf("%s[%d]", fieldPath, j), field, list.Get(j), goField.Index(j))
+			}
+			continue
+		}
+		compareValue(t, fieldPath, field, message.Get(field), goField)
+	}
+}
+
+// compareValue checks a singular protobuf value against its Go field
+func compareValue(t *testing.T, path string, field protoreflect.FieldDescriptor, value protoreflect.Value, goValue reflect.Value) {
+	for goValue.Kind() == reflect.Ptr {
+		if goValue.IsNil() {
+			t.Errorf("%s: Go field is nil", path)
+			return
+		}
+		goValue = goValue.Elem()
+	}
+
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		if field.Message().FullName() == "google.protobuf.Timestamp" {
+			fields := field.Message().Fields()
+			got := time.Unix(value.Message().Get(fields.ByName("seconds")).Int(), value.Message().Get(fields.ByName("nanos")).Int())
+			want, ok := goValue.Interface().(time.Time)
+			if !ok || !got.Equal(want) {
+				t.Errorf("%s: got %v, Go field has %v", path, got, goValue.Interface())
+			}
+			return
+		}
+		compareMessage(t, path, value.Message(), goValue)
+	case protoreflect.StringKind:
+		var want string
+		switch v := goValue.Interface().(type) {
+		case uuid.UUID:
+			want = v.String()
+		case decimal.Decimal:
+			want = v.String()
+		default:
+			if goValue.Kind() != reflect.String {
+				t.Errorf("%s: string field has Go type %s", path, goValue.Type())
+				return
+			}
+			want = goValue.String()
+		}
+		if value.String() != want {
+			t.Errorf("%s: got %q, Go field has %q", path, value.String(), want)
+		}
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		if goValue.Kind() < reflect.Int || goValue.Kind() > reflect.Int64 || value.Int() != goValue.Int() {
+			t.Errorf("%s: got %d, Go field has %v", path, value.Int(), goValue.Interface())
+		}
+	case protoreflect.BoolKind:
+		if goValue.Kind() != reflect.Bool || value.Bool() != goValue.Bool() {
+			t.Errorf("%s: got %t, Go field has %v", path, value.Bool(), goValue.Interface())
+		}
+	default:
+		t.Errorf("%s: unsupported field kind %s", path, field.Kind())
+	}
+}
+
+// findGoField finds the field of a Go message for a protobuf field, looking into embedded
+// structs. Fields match by JSON name or by name ignoring case and underscores.
+func findGoField(value reflect.Value, name string) (reflect.Value, bool) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	normalized := strings.ReplaceAll(name, "_", "")
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == name || strings.EqualFold(field.Name, normalized) {
+			return value.Field(i), true
+		}
+	}
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).Anonymous {
+			if found, ok := findGoField(value.Field(i), name); ok {
+				return found, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// populateGo sets every exported field of a Go value to a distinct non-zero value. Nesting is
+// bounded since responses may refer back to their own type.
+func populateGo(value reflect.Value, depth int, n *int) {
+	*n++
+	switch value.Interface().(type) {
+	case time.Time:
+		value.Set(reflect.ValueOf(sampleTime(*n)))
+		return
+	case decimal.Decimal:
+		value.Set(reflect.ValueOf(decimal.NewFromInt(int64(*n))))
+		return
+	case uuid.UUID:
+		value.Set(reflect.ValueOf(uuid.New()))
+		return
+	case json.RawMessage:
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString("value-" + strconv.Itoa(*n))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(*n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(uint64(*n))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(*n))
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Ptr:
+		if depth > 4 {
+			return
+		}
+		value.Set(reflect.New(value.Type().Elem()))
+		populateGo(value.Elem(), depth+1, n)
+	case reflect.Slice:
+		if depth > 4 {
+			return
+		}
+		value.Set(reflect.MakeSlice(value.Type(), 1, 1))
+		populateGo(value.Index(0), depth+1, n)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				populateGo(value.Field(i), depth, n)
+			}
+		}
+	}
+}
+
+// sampleTime returns a distinct time with whole microseconds for the nth value
+func sampleTime(n int) time.Time {
+	return time.Unix(1700000000+int64(n), int64(n)*1000).UTC()
+}
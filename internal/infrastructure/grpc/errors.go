@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// errorCodes maps application error types to gRPC status codes
+var errorCodes = map[errors.ErrorType]codes.Code{
+	errors.ErrorTypeValidation:        codes.InvalidArgument,
+	errors.ErrorTypeBadRequest:        codes.InvalidArgument,
+	errors.ErrorTypeInvalidPrice:      codes.InvalidArgument,
+	errors.ErrorTypeInvalidQuantity:   codes.InvalidArgument,
+	errors.ErrorTypeNotFound:          codes.NotFound,
+	errors.ErrorTypeConflict:          codes.AlreadyExists,
+	errors.ErrorTypeUnauthorized:      codes.Unauthenticated,
+	errors.ErrorTypeForbidden:         codes.PermissionDenied,
+	errors.ErrorTypeTimeout:           codes.DeadlineExceeded,
+	errors.ErrorTypeRateLimit:         codes.ResourceExhausted,
+	errors.ErrorTypeInsufficientStock: codes.FailedPrecondition,
+	errors.ErrorTypeProductNotActive:  codes.FailedPrecondition,
+	errors.ErrorTypeUserNotActive:     codes.FailedPrecondition,
+	errors.ErrorTypeRuleViolation:     codes.FailedPrecondition,
+	errors.ErrorTypeInternal:          codes.Internal,
+}
+
+// toStatus converts a use case error to a gRPC status. Errors that are not application
+// errors are reported as internal errors without their details, as for HTTP.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+
+	if appErr, ok := errors.IsAppError(err); ok {
+		code, known := errorCodes[appErr.Type]
+		if !known {
+			code = codes.Unknown
+		}
+		message := appErr.Message
+		if appErr.Details != "" {
+			message += ": " + appErr.Details
+		}
+		return status.Error(code, message)
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
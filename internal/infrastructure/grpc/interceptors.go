@@ -0,0 +1,180 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+)
+
+// permission is the resource and action a method requires, as checked for HTTP routes
+type permission struct {
+	resource string
+	action   string
+}
+
+// methodPermissions maps every exposed method to the permission it requires. Methods
+// missing from the map are refused.
+var methodPermissions = map[string]permission{
+	"/adol.v1.ProductService/GetProduct":      {"products", "read"},
+	"/adol.v1.ProductService/GetProductBySKU": {"products", "read"},
+	"/adol.v1.ProductService/ListProducts":    {"products", "read"},
+	"/adol.v1.StockService/GetStock":          {"stock", "read"},
+	"/adol.v1.StockService/AdjustStock":       {"stock", "update"},
+	"/adol.v1.SaleService/CreateSale":         {"sales", "create"},
+	"/adol.v1.SaleService/GetSale":            {"sales", "read"},
+	"/adol.v1.SaleService/AddSaleItem":        {"sales", "update"},
+	"/adol.v1.SaleService/CompleteSale":       {"sales", "update"},
+	"/adol.v1.InvoiceService/CreateInvoice":   {"invoices", "create"},
+	"/adol.v1.InvoiceService/GetInvoice":      {"invoices", "read"},
+}
+
+// userKey is the context key carrying the authenticated user of a call
+type userKey struct{}
+
+// currentUser returns the user the call was authenticated as
+func currentUser(ctx context.Context) (*entities.User, error) {
+	user, ok := ctx.Value(userKey{}).(*entities.User)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	return user, nil
+}
+
+// recoveryInterceptor turns a panicking handler into an Internal error
+func (s *Server) recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"method": info.FullMethod,
+				"panic":  fmt.Sprint(r),
+				"stack":  string(debug.Stack()),
+			}).Error("gRPC handler panicked")
+			resp, err = nil, status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// loggingInterceptor logs every call with its status and duration
+func (s *Server) loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	callStatus := status.Convert(err)
+
+	entry := s.logger.WithFields(map[string]interface{}{
+		"method":      info.FullMethod,
+		"code":        callStatus.Code().String(),
+		"duration_ms": time.Since(start).Milliseconds(),
+		"peer":        peerAddr(ctx),
+	})
+	switch callStatus.Code() {
+	case codes.OK:
+		entry.Info("gRPC call completed")
+	case codes.Internal, codes.Unknown:
+		entry.WithField("error", callStatus.Message()).Error("gRPC call failed")
+	default:
+		entry.WithField("error", callStatus.Message()).Warn("gRPC call failed")
+	}
+
+	return resp, err
+}
+
+// authInterceptor authenticates the bearer token in the authorization metadata and checks
+// the method's permission, then scopes data access to the user's tenant
+func (s *Server) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	required, ok := methodPermissions[info.FullMethod]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "method %s is not exposed", info.FullMethod)
+	}
+
+	authorization := incomingMetadata(ctx, "authorization")
+	if authorization == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	if token == authorization || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+
+	user, err := s.authUseCase.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	allowed, err := s.authUseCase.CheckPermission(ctx, user.ID, required.resource, required.action)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	ctx = context.WithValue(ctx, userKey{}, user)
+	ctx = entities.WithTenantScope(ctx, user.TenantID)
+
+	return handler(ctx, req)
+}
+
+// auditInterceptor records every call that changes data with its outcome, alongside the
+// audit events the use cases record themselves
+func (s *Server) auditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	required := methodPermissions[info.FullMethod]
+	if required.action == "read" {
+		return handler(ctx, req)
+	}
+
+	resp, err := handler(ctx, req)
+	callStatus := status.Convert(err)
+
+	auditEvent := ports.AuditEvent{
+		ID:       uuid.New(),
+		Action:   "grpc_call",
+		Resource: required.resource,
+		NewValue: map[string]interface{}{
+			"method": info.FullMethod,
+			"code":   callStatus.Code().String(),
+		},
+		IPAddress: peerAddr(ctx),
+		UserAgent: incomingMetadata(ctx, "user-agent"),
+		Timestamp: time.Now(),
+		Success:   err == nil,
+	}
+	if user, userErr := currentUser(ctx); userErr == nil {
+		auditEvent.UserID = user.ID
+	}
+	if err != nil {
+		auditEvent.ErrorMessage = callStatus.Message()
+	}
+	s.audit.Log(ctx, auditEvent)
+
+	return resp, err
+}
+
+// incomingMetadata returns the first value of a request metadata key, or "" when it was not sent
+func incomingMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// peerAddr returns the network address of the client making the call
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
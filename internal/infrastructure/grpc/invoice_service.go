@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// invoiceService implements adol.v1.InvoiceService
+type invoiceService struct {
+	adolv1.UnimplementedInvoiceServiceServer
+	*Server
+}
+
+// CreateInvoice handles InvoiceService.CreateInvoice
+func (s *invoiceService) CreateInvoice(ctx context.Context, req *adolv1.CreateInvoiceRequest) (*adolv1.Invoice, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	saleID, err := parseID("sale_id", req.GetSaleId())
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := usecases.CreateInvoiceRequest{
+		SaleID:          saleID,
+		CustomerAddress: req.GetCustomerAddress(),
+		Notes:           req.GetNotes(),
+	}
+	if req.GetDueDate() != nil {
+		dueDate := req.GetDueDate().AsTime()
+		createReq.DueDate = &dueDate
+	}
+
+	invoice, err := s.invoiceUseCase.CreateInvoice(ctx, user.ID, createReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newInvoice(invoice), nil
+}
+
+// GetInvoice handles InvoiceService.GetInvoice
+func (s *invoiceService) GetInvoice(ctx context.Context, req *adolv1.GetInvoiceRequest) (*adolv1.Invoice, error) {
+	invoiceID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.invoiceUseCase.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newInvoice(invoice), nil
+}
+
+// newInvoice converts an invoice response to its message
+func newInvoice(invoice *usecases.InvoiceResponse) *adolv1.Invoice {
+	items := make([]*adolv1.InvoiceItem, 0, len(invoice.Items))
+	for _, item := range invoice.Items {
+		items = append(items, newInvoiceItem(item))
+	}
+
+	return &adolv1.Invoice{
+		Id:              invoice.ID.String(),
+		InvoiceNumber:   invoice.InvoiceNumber,
+		SaleId:          invoice.SaleID.String(),
+		CustomerName:    invoice.CustomerName,
+		CustomerEmail:   invoice.CustomerEmail,
+		CustomerPhone:   invoice.CustomerPhone,
+		CustomerAddress: invoice.CustomerAddress,
+		Items:           items,
+		Subtotal:        invoice.Subtotal.String(),
+		TaxAmount:       invoice.TaxAmount.String(),
+		DiscountAmount:  invoice.DiscountAmount.String(),
+		TotalAmount:     invoice.TotalAmount.String(),
+		PaidAmount:      invoice.PaidAmount.String(),
+		PaymentMethod:   string(invoice.PaymentMethod),
+		Status:          string(invoice.Status),
+		Notes:           invoice.Notes,
+		DueDate:         optionalTimestamp(invoice.DueDate),
+		PaidAt:          optionalTimestamp(invoice.PaidAt),
+		CreatedAt:       timestamppb.New(invoice.CreatedAt),
+	}
+}
+
+// newInvoiceItem converts an invoice item response to its message
+func newInvoiceItem(item *usecases.InvoiceItemResponse) *adolv1.InvoiceItem {
+	return &adolv1.InvoiceItem{
+		Id:          item.ID.String(),
+		ProductId:   item.ProductID.String(),
+		ProductSku:  item.ProductSKU,
+		ProductName: item.ProductName,
+		Quantity:    int32(item.Quantity),
+		UnitPrice:   item.UnitPrice.String(),
+		TotalPrice:  item.TotalPrice.String(),
+	}
+}
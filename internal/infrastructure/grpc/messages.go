@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nicklaros/adol/pkg/utils"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// Messages are generated from proto/adol/v1 with buf generate; this file holds the helpers
+// converting between them and the use case requests and responses.
+
+// pagination returns the requested page with the same defaults as the HTTP API
+func pagination(page *adolv1.PageRequest) utils.PaginationInfo {
+	pagination := utils.PaginationInfo{Page: 1, Limit: 10}
+	if page.GetPage() > 0 {
+		pagination.Page = int(page.GetPage())
+	}
+	if page.GetLimit() > 0 {
+		pagination.Limit = int(page.GetLimit())
+	}
+	return pagination
+}
+
+// newPagination converts the page of a list that was returned to its message
+func newPagination(pagination utils.PaginationInfo) *adolv1.Pagination {
+	return &adolv1.Pagination{
+		Page:       int32(pagination.Page),
+		Limit:      int32(pagination.Limit),
+		TotalCount: int32(pagination.TotalCount),
+		TotalPages: int32(pagination.TotalPages),
+		HasNext:    pagination.HasNext,
+		HasPrev:    pagination.HasPrev,
+	}
+}
+
+// parseID parses a required UUID field of a request
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", field, err)
+	}
+	return id, nil
+}
+
+// parseDecimal parses an optional decimal field of a request; empty is zero
+func parseDecimal(field, value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, status.Errorf(codes.InvalidArgument, "invalid %s: %v", field, err)
+	}
+	return d, nil
+}
+
+// optionalTimestamp converts an optional time to a timestamp, leaving it unset when t is nil
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// productService implements adol.v1.ProductService
+type productService struct {
+	adolv1.UnimplementedProductServiceServer
+	*Server
+}
+
+// GetProduct handles ProductService.GetProduct
+func (s *productService) GetProduct(ctx context.Context, req *adolv1.GetProductRequest) (*adolv1.Product, error) {
+	productID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productUseCase.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newProduct(product), nil
+}
+
+// GetProductBySKU handles ProductService.GetProductBySKU
+func (s *productService) GetProductBySKU(ctx context.Context, req *adolv1.GetProductBySKURequest) (*adolv1.Product, error) {
+	if req.GetSku() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+
+	product, err := s.productUseCase.GetProductBySKU(ctx, req.GetSku())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newProduct(product), nil
+}
+
+// ListProducts handles ProductService.ListProducts
+func (s *productService) ListProducts(ctx context.Context, req *adolv1.ListProductsRequest) (*adolv1.ListProductsResponse, error) {
+	filter := repositories.ProductFilter{
+		Category: req.GetCategory(),
+		Search:   req.GetSearch(),
+	}
+	if req.GetStatus() != "" {
+		productStatus := entities.ProductStatus(req.GetStatus())
+		if err := entities.ValidateProductStatus(productStatus); err != nil {
+			return nil, toStatus(err)
+		}
+		filter.Status = &productStatus
+	}
+
+	response, err := s.productUseCase.ListProducts(ctx, filter, pagination(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	products := make([]*adolv1.Product, 0, len(response.Products))
+	for _, product := range response.Products {
+		products = append(products, newProduct(product))
+	}
+
+	return &adolv1.ListProductsResponse{
+		Products:   products,
+		Pagination: newPagination(response.Pagination),
+	}, nil
+}
+
+// newProduct converts a product response to its message
+func newProduct(product *usecases.ProductResponse) *adolv1.Product {
+	return &adolv1.Product{
+		Id:             product.ID.String(),
+		Sku:            product.SKU,
+		Barcode:        product.Barcode,
+		Name:           product.Name,
+		Description:    product.Description,
+		Category:       product.Category,
+		Price:          product.Price.String(),
+		Cost:           product.Cost.String(),
+		Status:         string(product.Status),
+		Unit:           product.Unit,
+		MinStock:       int32(product.MinStock),
+		AvailableStock: int32(product.AvailableStock),
+		ReservedStock:  int32(product.ReservedStock),
+		TotalStock:     int32(product.TotalStock),
+		StockStatus:    product.StockStatus,
+		PublishState:   string(product.PublishState),
+		CreatedAt:      timestamppb.New(product.CreatedAt),
+		UpdatedAt:      timestamppb.New(product.UpdatedAt),
+	}
+}
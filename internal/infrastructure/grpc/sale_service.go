@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// saleService implements adol.v1.SaleService
+type saleService struct {
+	adolv1.UnimplementedSaleServiceServer
+	*Server
+}
+
+// CreateSale handles SaleService.CreateSale
+func (s *saleService) CreateSale(ctx context.Context, req *adolv1.CreateSaleRequest) (*adolv1.Sale, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := s.saleUseCase.CreateSale(ctx, user.TenantID, user.ID, usecases.CreateSaleRequest{
+		CustomerName:  req.GetCustomerName(),
+		CustomerEmail: req.GetCustomerEmail(),
+		CustomerPhone: req.GetCustomerPhone(),
+		Channel:       entities.SaleChannel(req.GetChannel()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newSale(sale), nil
+}
+
+// GetSale handles SaleService.GetSale
+func (s *saleService) GetSale(ctx context.Context, req *adolv1.GetSaleRequest) (*adolv1.Sale, error) {
+	saleID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := s.saleUseCase.GetSale(ctx, saleID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newSale(sale), nil
+}
+
+// AddSaleItem handles SaleService.AddSaleItem
+func (s *saleService) AddSaleItem(ctx context.Context, req *adolv1.AddSaleItemRequest) (*adolv1.Sale, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	saleID, err := parseID("sale_id", req.GetSaleId())
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := s.saleUseCase.AddSaleItem(ctx, user.ID, saleID, usecases.AddSaleItemRequest{
+		ProductID: productID,
+		Quantity:  int(req.GetQuantity()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newSale(sale), nil
+}
+
+// CompleteSale handles SaleService.CompleteSale
+func (s *saleService) CompleteSale(ctx context.Context, req *adolv1.CompleteSaleRequest) (*adolv1.Sale, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	saleID, err := parseID("sale_id", req.GetSaleId())
+	if err != nil {
+		return nil, err
+	}
+
+	completeReq := usecases.CompleteSaleRequest{
+		PaymentMethod: entities.PaymentMethod(req.GetPaymentMethod()),
+		Notes:         req.GetNotes(),
+	}
+	if completeReq.PaidAmount, err = parseDecimal("paid_amount", req.GetPaidAmount()); err != nil {
+		return nil, err
+	}
+	if completeReq.DiscountAmount, err = parseDecimal("discount_amount", req.GetDiscountAmount()); err != nil {
+		return nil, err
+	}
+	if completeReq.TaxPercentage, err = parseDecimal("tax_percentage", req.GetTaxPercentage()); err != nil {
+		return nil, err
+	}
+
+	sale, err := s.saleUseCase.CompleteSale(ctx, user.ID, saleID, completeReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newSale(sale), nil
+}
+
+// newSale converts a sale response to its message
+func newSale(sale *usecases.SaleResponse) *adolv1.Sale {
+	items := make([]*adolv1.SaleItem, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		items = append(items, newSaleItem(item))
+	}
+
+	return &adolv1.Sale{
+		Id:              sale.ID.String(),
+		SaleNumber:      sale.SaleNumber,
+		CustomerName:    sale.CustomerName,
+		CustomerEmail:   sale.CustomerEmail,
+		CustomerPhone:   sale.CustomerPhone,
+		Items:           items,
+		Subtotal:        sale.Subtotal.String(),
+		TaxAmount:       sale.TaxAmount.String(),
+		DiscountAmount:  sale.DiscountAmount.String(),
+		SurchargeAmount: sale.SurchargeAmount.String(),
+		TotalAmount:     sale.TotalAmount.String(),
+		PaidAmount:      sale.PaidAmount.String(),
+		ChangeAmount:    sale.ChangeAmount.String(),
+		PaymentMethod:   string(sale.PaymentMethod),
+		Channel:         string(sale.Channel),
+		Status:          string(sale.Status),
+		Notes:           sale.Notes,
+		CreatedAt:       timestamppb.New(sale.CreatedAt),
+		CompletedAt:     optionalTimestamp(sale.CompletedAt),
+	}
+}
+
+// newSaleItem converts a sale item response to its message
+func newSaleItem(item *usecases.SaleItemResponse) *adolv1.SaleItem {
+	return &adolv1.SaleItem{
+		Id:          item.ID.String(),
+		ProductId:   item.ProductID.String(),
+		ProductSku:  item.ProductSKU,
+		ProductName: item.ProductName,
+		Quantity:    int32(item.Quantity),
+		UnitPrice:   item.UnitPrice.String(),
+		TotalPrice:  item.TotalPrice.String(),
+		ListPrice:   item.ListPrice.String(),
+	}
+}
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/infrastructure/config"
+	"github.com/nicklaros/adol/pkg/logger"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// Server exposes the core use cases over gRPC for internal services. It runs alongside the
+// HTTP server on its own port and shares its authentication and audit logic.
+type Server struct {
+	config *config.Config
+	db     *sql.DB
+	logger logger.Logger
+	rpc    *grpc.Server
+
+	// Use cases
+	authUseCase    *usecases.AuthUseCase
+	productUseCase *usecases.ProductUseCase
+	stockUseCase   *usecases.StockUseCase
+	saleUseCase    *usecases.SaleUseCase
+	invoiceUseCase *usecases.InvoiceUseCase
+
+	audit ports.AuditPort
+}
+
+// NewServer creates a new gRPC server
+func NewServer(cfg *config.Config, db *sql.DB, logger logger.Logger) *Server {
+	server := &Server{
+		config: cfg,
+		db:     db,
+		logger: logger,
+	}
+
+	// Interceptors run in order: recovery and logging see every call, authentication
+	// rejects unauthenticated calls before they are audited or handled
+	server.rpc = grpc.NewServer(
+		grpc.MaxRecvMsgSize(cfg.GRPC.MaxRecvMsgSize),
+		grpc.ChainUnaryInterceptor(
+			server.recoveryInterceptor,
+			server.loggingInterceptor,
+			server.authInterceptor,
+			server.auditInterceptor,
+		),
+	)
+
+	// Register services
+	adolv1.RegisterProductServiceServer(server.rpc, &productService{Server: server})
+	adolv1.RegisterStockServiceServer(server.rpc, &stockService{Server: server})
+	adolv1.RegisterSaleServiceServer(server.rpc, &saleService{Server: server})
+	adolv1.RegisterInvoiceServiceServer(server.rpc, &invoiceService{Server: server})
+
+	return server
+}
+
+// Start starts the gRPC server
+func (s *Server) Start() error {
+	s.logger.Info("Starting gRPC server on port " + s.config.GRPC.Port)
+	listener, err := net.Listen("tcp", ":"+s.config.GRPC.Port)
+	if err != nil {
+		return err
+	}
+	return s.rpc.Serve(listener)
+}
+
+// Shutdown gracefully shuts down the gRPC server, waiting for in-flight calls until ctx is
+// done and then closing the connections that are left
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("Shutting down gRPC server...")
+
+	stopped := make(chan struct{})
+	go func() {
+		s.rpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.rpc.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	adolv1 "github.com/nicklaros/adol/proto/adol/v1"
+)
+
+// stockService implements adol.v1.StockService
+type stockService struct {
+	adolv1.UnimplementedStockServiceServer
+	*Server
+}
+
+// GetStock handles StockService.GetStock
+func (s *stockService) GetStock(ctx context.Context, req *adolv1.GetStockRequest) (*adolv1.Stock, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := s.stockUseCase.GetStock(ctx, productID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newStock(stock), nil
+}
+
+// AdjustStock handles StockService.AdjustStock
+func (s *stockService) AdjustStock(ctx context.Context, req *adolv1.AdjustStockRequest) (*adolv1.Stock, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := s.stockUseCase.AdjustStock(ctx, user.ID, usecases.StockAdjustmentRequest{
+		ProductID: productID,
+		Type:      entities.StockMovementType(req.GetType()),
+		Reason:    entities.StockMovementReason(req.GetReason()),
+		Quantity:  int(req.GetQuantity()),
+		Reference: req.GetReference(),
+		Notes:     req.GetNotes(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newStock(stock), nil
+}
+
+// newStock converts a stock response to its message
+func newStock(stock *usecases.StockResponse) *adolv1.Stock {
+	return &adolv1.Stock{
+		Id:             stock.ID.String(),
+		ProductId:      stock.ProductID.String(),
+		ProductSku:     stock.ProductSKU,
+		ProductName:    stock.ProductName,
+		AvailableQty:   int32(stock.AvailableQty),
+		ReservedQty:    int32(stock.ReservedQty),
+		TotalQty:       int32(stock.TotalQty),
+		ReorderLevel:   int32(stock.ReorderLevel),
+		StockStatus:    stock.StockStatus,
+		LastMovementAt: optionalTimestamp(stock.LastMovementAt),
+		UpdatedAt:      timestamppb.New(stock.UpdatedAt),
+	}
+}
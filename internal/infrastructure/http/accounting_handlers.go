@@ -0,0 +1,125 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getAccountingAccountMapping handles retrieving the accounts journal entries are posted to
+func (s *Server) getAccountingAccountMapping(c *gin.Context) {
+	if err := s.checkPermission(c, "accounting", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	mapping, err := s.accountingExportUseCase.GetAccountMapping(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": mapping,
+	})
+}
+
+// updateAccountingAccountMapping handles replacing the accounts journal entries are posted to
+func (s *Server) updateAccountingAccountMapping(c *gin.Context) {
+	if err := s.checkPermission(c, "accounting", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req entities.AccountingAccounts
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	mapping, err := s.accountingExportUseCase.UpdateAccountMapping(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Accounting account mapping updated successfully",
+		"data":    mapping,
+	})
+}
+
+// getAccountingJournal handles the general ledger journal entries of sales and refunds. The from
+// and to query parameters are inclusive YYYY-MM-DD dates and group_by is day or period; format
+// csv, iif, xero or accurate downloads the entries for import into accounting software.
+func (s *Server) getAccountingJournal(c *gin.Context) {
+	if err := s.checkPermission(c, "accounting", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	now := time.Now()
+	fromDate, err := time.ParseInLocation(reportDateLayout, c.Query("from"), now.Location())
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid from", "from must be in YYYY-MM-DD format"))
+		return
+	}
+	toDate, err := time.ParseInLocation(reportDateLayout, c.Query("to"), now.Location())
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid to", "to must be in YYYY-MM-DD format"))
+		return
+	}
+
+	req := usecases.AccountingJournalRequest{
+		FromDate: fromDate,
+		ToDate:   toDate.AddDate(0, 0, 1),
+		GroupBy:  entities.JournalGrouping(c.DefaultQuery("group_by", string(entities.JournalGroupingDay))),
+	}
+
+	if c.DefaultQuery("format", "json") == "json" {
+		journal, err := s.accountingExportUseCase.GetJournal(c.Request.Context(), GetTenantID(c), req)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": journal,
+		})
+		return
+	}
+
+	format, err := usecases.ParseJournalFormat(c.Query("format"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	data, err := s.accountingExportUseCase.ExportJournal(c.Request.Context(), GetTenantID(c), userID, req, format)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("journal-%s-%s-%s.%s", format, fromDate.Format(reportDateLayout), toDate.Format(reportDateLayout), format.Extension())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, format.ContentType(), data)
+}
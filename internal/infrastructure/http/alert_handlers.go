@@ -0,0 +1,267 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	tenantmonitoring "github.com/nicklaros/adol/internal/infrastructure/monitoring"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// alertNotifyTimeout bounds sending one alert to all of its tenant's channels
+	alertNotifyTimeout = time.Minute
+
+	// defaultAlertListPeriod is how far back alerts are listed when no since is given
+	defaultAlertListPeriod = 7 * 24 * time.Hour
+)
+
+// notifyAlert sends an alert raised by the tenant monitor to the tenant's alert channels.
+// Sending happens outside the request that raised the alert so it is never slowed down.
+func (s *Server) notifyAlert(_ context.Context, alert *tenantmonitoring.Alert) {
+	if s.alertNotificationUseCase == nil {
+		return
+	}
+
+	notice := usecases.AlertNotice{
+		ID:          alert.ID,
+		TenantID:    alert.TenantID,
+		Type:        string(alert.Type),
+		Severity:    entities.AlertSeverity(alert.Severity),
+		Title:       alert.Title,
+		Description: alert.Description,
+		Metadata:    alert.Metadata,
+		CreatedAt:   alert.CreatedAt,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), alertNotifyTimeout)
+		defer cancel()
+		s.alertNotificationUseCase.Notify(ctx, notice)
+	}()
+}
+
+// listAlerts handles listing the tenant's monitoring alerts raised since a time, optionally
+// only those still open
+func (s *Server) listAlerts(c *gin.Context) {
+	if err := s.checkPermission(c, "alerts", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	since := time.Now().Add(-defaultAlertListPeriod)
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid since", "since must be an RFC 3339 timestamp"))
+			return
+		}
+		since = parsed
+	}
+
+	alerts, err := s.tenantMonitor.ListAlerts(c.Request.Context(), GetTenantID(c), since)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if c.Query("open") == "true" {
+		open := make([]*tenantmonitoring.Alert, 0, len(alerts))
+		for _, alert := range alerts {
+			if alert.IsOpen() {
+				open = append(open, alert)
+			}
+		}
+		alerts = open
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": alerts,
+	})
+}
+
+// acknowledgeAlert handles marking an alert as being looked into
+func (s *Server) acknowledgeAlert(c *gin.Context) {
+	s.changeAlertStatus(c, s.tenantMonitor.AcknowledgeAlert, "Alert acknowledged")
+}
+
+// resolveAlert handles closing an alert
+func (s *Server) resolveAlert(c *gin.Context) {
+	s.changeAlertStatus(c, s.tenantMonitor.ResolveAlert, "Alert resolved")
+}
+
+func (s *Server) changeAlertStatus(c *gin.Context, change func(ctx context.Context, tenantID, alertID, userID uuid.UUID) (*tenantmonitoring.Alert, error), message string) {
+	if err := s.checkPermission(c, "alerts", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid alert ID", err.Error()))
+		return
+	}
+
+	alert, err := change(c.Request.Context(), GetTenantID(c), alertID, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    alert,
+	})
+}
+
+// listAlertChannels handles listing the tenant's alert channels
+func (s *Server) listAlertChannels(c *gin.Context) {
+	if err := s.checkPermission(c, "alerts", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	channels, err := s.alertNotificationUseCase.ListChannels(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": channels,
+	})
+}
+
+// getAlertChannel handles retrieving an alert channel
+func (s *Server) getAlertChannel(c *gin.Context) {
+	if err := s.checkPermission(c, "alerts", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	channelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid alert channel ID", err.Error()))
+		return
+	}
+
+	channel, err := s.alertNotificationUseCase.GetChannel(c.Request.Context(), GetTenantID(c), channelID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": channel,
+	})
+}
+
+// createAlertChannel handles creating an alert channel. The signing secret of a webhook
+// channel is only shown in this response.
+func (s *Server) createAlertChannel(c *gin.Context) {
+	if err := s.checkPermission(c, "alerts", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateAlertChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	channel, err := s.alertNotificationUseCase.CreateChannel(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Alert channel created successfully",
+		"data":    channel,
+	})
+}
+
+// updateAlertChannel handles updating an alert channel
+func (s *Server) updateAlertChannel(c *gin.Context) {
+	if err := s.checkPermission(c, "alerts", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	channelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid alert channel ID", err.Error()))
+		return
+	}
+
+	var req usecases.UpdateAlertChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	channel, err := s.alertNotificationUseCase.UpdateChannel(c.Request.Context(), GetTenantID(c), userID, channelID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert channel updated successfully",
+		"data":    channel,
+	})
+}
+
+// deleteAlertChannel handles deleting an alert channel
+func (s *Server) deleteAlertChannel(c *gin.Context) {
+	if err := s.checkPermission(c, "alerts", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	channelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid alert channel ID", err.Error()))
+		return
+	}
+
+	if err := s.alertNotificationUseCase.DeleteChannel(c.Request.Context(), GetTenantID(c), userID, channelID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert channel deleted successfully",
+	})
+}
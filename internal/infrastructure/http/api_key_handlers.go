@@ -0,0 +1,98 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// apiKeyHeader carries the API key sent by external systems
+	apiKeyHeader = "X-API-Key"
+	// apiKeyPrincipalKey is the gin context key holding the authenticated API key
+	apiKeyPrincipalKey = "api_key"
+)
+
+// listAPIKeys handles listing the tenant's API keys
+func (s *Server) listAPIKeys(c *gin.Context) {
+	if err := s.checkPermission(c, "api_keys", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	apiKeys, err := s.apiKeyUseCase.ListAPIKeys(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": apiKeys,
+	})
+}
+
+// createAPIKey handles creating a new API key
+func (s *Server) createAPIKey(c *gin.Context) {
+	if err := s.checkPermission(c, "api_keys", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	result, err := s.apiKeyUseCase.CreateAPIKey(c.Request.Context(), GetTenantID(c), req, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "API key created successfully",
+		"data":    result,
+	})
+}
+
+// revokeAPIKey handles revoking an API key
+func (s *Server) revokeAPIKey(c *gin.Context) {
+	if err := s.checkPermission(c, "api_keys", "delete"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	apiKeyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid API key ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	apiKey, err := s.apiKeyUseCase.RevokeAPIKey(c.Request.Context(), GetTenantID(c), apiKeyID, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked successfully",
+		"data":    apiKey,
+	})
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getAPIRequestLog handles retrieving a captured API request by its request ID
+func (s *Server) getAPIRequestLog(c *gin.Context) {
+	if err := s.checkPermission(c, "request_logs", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	log, err := s.apiRequestLogUseCase.GetByRequestID(c.Request.Context(), c.Param("request_id"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": log,
+	})
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// attachmentUploadOverhead allows for the multipart form around an uploaded file on top of the
+// attachment size limit
+const attachmentUploadOverhead = 1 << 16
+
+// uploadAttachment handles attaching a file to an invoice or sale. The file is sent as a
+// multipart "file" field, or as the raw request body named by the file_name query parameter.
+func (s *Server) uploadAttachment(ownerType entities.AttachmentOwnerType, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.checkPermission(c, permission, "update"); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		ownerID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid ID", err.Error()))
+			return
+		}
+
+		userID, err := s.getCurrentUser(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		// Cap the whole body, so an oversized multipart upload is refused before it is buffered
+		// in memory or spooled to disk
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, entities.MaxAttachmentSize+attachmentUploadOverhead)
+
+		var file io.Reader = c.Request.Body
+		fileName := c.Query("file_name")
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			header, err := c.FormFile("file")
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if stderrors.As(err, &tooLarge) {
+					s.respondWithError(c, errors.NewValidationError("file too large", "attachments cannot exceed 10 MB"))
+					return
+				}
+				s.respondWithError(c, errors.NewValidationError("file is required", err.Error()))
+				return
+			}
+			upload, err := header.Open()
+			if err != nil {
+				s.respondWithError(c, errors.NewValidationError("invalid file", err.Error()))
+				return
+			}
+			defer upload.Close()
+			file = upload
+			fileName = header.Filename
+		}
+
+		// Read one byte past the limit so an oversized file is rejected rather than truncated
+		data, err := io.ReadAll(io.LimitReader(file, entities.MaxAttachmentSize+1))
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid file", err.Error()))
+			return
+		}
+
+		attachment, err := s.attachmentUseCase.UploadAttachment(c.Request.Context(), GetTenantID(c), userID, ownerType, ownerID, fileName, data)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Attachment uploaded successfully",
+			"data":    attachment,
+		})
+	}
+}
+
+// listAttachments handles listing the files attached to an invoice or sale
+func (s *Server) listAttachments(ownerType entities.AttachmentOwnerType, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.checkPermission(c, permission, "read"); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		ownerID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid ID", err.Error()))
+			return
+		}
+
+		attachments, err := s.attachmentUseCase.ListAttachments(c.Request.Context(), GetTenantID(c), ownerType, ownerID)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Attachments retrieved successfully",
+			"data":    attachments,
+		})
+	}
+}
+
+// downloadAttachment handles downloading a file attached to an invoice or sale
+func (s *Server) downloadAttachment(ownerType entities.AttachmentOwnerType, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.checkPermission(c, permission, "read"); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		ownerID, attachmentID, err := parseAttachmentParams(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		attachment, data, err := s.attachmentUseCase.DownloadAttachment(c.Request.Context(), GetTenantID(c), ownerType, ownerID, attachmentID)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+		// Browsers must not sniff an uploaded file into HTML or script served from the API origin
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Data(http.StatusOK, attachment.ContentType, data)
+	}
+}
+
+// deleteAttachment handles removing a file from an invoice or sale
+func (s *Server) deleteAttachment(ownerType entities.AttachmentOwnerType, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.checkPermission(c, permission, "update"); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		ownerID, attachmentID, err := parseAttachmentParams(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		userID, err := s.getCurrentUser(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		if err := s.attachmentUseCase.DeleteAttachment(c.Request.Context(), GetTenantID(c), userID, ownerType, ownerID, attachmentID); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Attachment deleted successfully",
+		})
+	}
+}
+
+// parseAttachmentParams parses the owner and attachment IDs of an attachment route
+func parseAttachmentParams(c *gin.Context) (uuid.UUID, uuid.UUID, error) {
+	ownerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.NewValidationError("invalid ID", err.Error())
+	}
+	attachmentID, err := uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.NewValidationError("invalid attachment ID", err.Error())
+	}
+	return ownerID, attachmentID, nil
+}
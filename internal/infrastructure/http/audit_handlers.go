@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// getResourceHistory returns a handler that renders the audit history of a single entity.
+// The permission checked is read access on the resource group the route belongs to.
+func (s *Server) getResourceHistory(resource, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.checkPermission(c, permission, "read"); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		resourceID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid ID", err.Error()))
+			return
+		}
+
+		// Parse pagination parameters
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+		pagination := utils.PaginationInfo{
+			Page:  page,
+			Limit: limit,
+		}
+
+		history, err := s.auditUseCase.GetResourceHistory(c.Request.Context(), GetTenantID(c), resource, resourceID.String(), pagination)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": history,
+		})
+	}
+}
+
+// verifyAuditTrail handles checking the tenant's audit trail for tampering
+func (s *Server) verifyAuditTrail(c *gin.Context) {
+	if err := s.checkPermission(c, "audit", "verify"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var from *time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid from", "from must be an RFC 3339 timestamp"))
+			return
+		}
+		from = &parsed
+	}
+
+	verification, err := s.auditUseCase.VerifyAuditTrail(c.Request.Context(), GetTenantID(c), from)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": verification,
+	})
+}
@@ -2,13 +2,11 @@ package http
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"github.com/nicklaros/adol/internal/application/usecases"
-	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/pkg/errors"
 )
 
@@ -31,36 +29,24 @@ func (s *Server) login(c *gin.Context) {
 	req.IPAddress = c.ClientIP()
 	req.UserAgent = c.GetHeader("User-Agent")
 
-	// TODO: Use actual auth use case
-	// For now, return mock response
-	response := &usecases.LoginResponse{
-		AccessToken:  "mock-access-token",
-		RefreshToken: "mock-refresh-token",
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(24 * time.Hour),
-		User: &entities.User{
-			ID:        uuid.New(),
-			Username:  req.Username,
-			Email:     "user@example.com",
-			FirstName: "John",
-			LastName:  "Doe",
-			Role:      entities.RoleAdmin,
-			Status:    entities.UserStatusActive,
-		},
-	}
-
 	s.logger.WithFields(map[string]interface{}{
 		"username":   req.Username,
 		"ip_address": req.IPAddress,
 	}).Info("User login attempt")
 
+	response, err := s.authUseCase.Login(c.Request.Context(), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    response,
 	})
 }
 
-// refreshToken handles token refresh
+// refreshToken handles token refresh. Each refresh token can only be used once.
 func (s *Server) refreshToken(c *gin.Context) {
 	var req usecases.RefreshTokenRequest
 
@@ -69,22 +55,12 @@ func (s *Server) refreshToken(c *gin.Context) {
 		return
 	}
 
-	// TODO: Use actual auth use case
-	// For now, return mock response
-	response := &usecases.LoginResponse{
-		AccessToken:  "new-mock-access-token",
-		RefreshToken: "new-mock-refresh-token",
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(24 * time.Hour),
-		User: &entities.User{
-			ID:        uuid.New(),
-			Username:  "user",
-			Email:     "user@example.com",
-			FirstName: "John",
-			LastName:  "Doe",
-			Role:      entities.RoleAdmin,
-			Status:    entities.UserStatusActive,
-		},
+	req.IPAddress = c.ClientIP()
+
+	response, err := s.authUseCase.RefreshToken(c.Request.Context(), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -93,7 +69,7 @@ func (s *Server) refreshToken(c *gin.Context) {
 	})
 }
 
-// logout handles user logout
+// logout handles user logout, ending the current session
 func (s *Server) logout(c *gin.Context) {
 	userID, err := s.getCurrentUser(c)
 	if err != nil {
@@ -101,30 +77,170 @@ func (s *Server) logout(c *gin.Context) {
 		return
 	}
 
-	token, exists := c.Get("token")
-	if !exists {
-		s.respondWithError(c, errors.NewUnauthorizedError("token not found"))
+	tokenStr, err := s.getCurrentToken(c)
+	if err != nil {
+		s.respondWithError(c, err)
 		return
 	}
 
-	tokenStr, ok := token.(string)
-	if !ok {
-		s.respondWithError(c, errors.NewInternalError("invalid token format", nil))
+	if err := s.authUseCase.Logout(c.Request.Context(), userID, tokenStr); err != nil {
+		s.respondWithError(c, err)
 		return
 	}
 
-	// TODO: Use actual auth use case to logout
-	_ = userID
-	_ = tokenStr
-
-	s.logger.WithField("user_id", userID).Info("User logged out")
-
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Logged out successfully",
 	})
 }
 
+// listSessions handles listing the current user's active sessions
+func (s *Server) listSessions(c *gin.Context) {
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	tokenStr, err := s.getCurrentToken(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessions, err := s.authUseCase.ListSessions(c.Request.Context(), userID, tokenStr)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": sessions,
+	})
+}
+
+// revokeSession handles signing the current user out of one of their sessions
+func (s *Server) revokeSession(c *gin.Context) {
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	if err := s.authUseCase.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked successfully",
+	})
+}
+
+// listUserSessions handles listing another user's active sessions (admin only)
+func (s *Server) listUserSessions(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid user ID", "user ID must be a valid UUID"))
+		return
+	}
+
+	sessions, err := s.authUseCase.ListUserSessions(c.Request.Context(), adminID, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": sessions,
+	})
+}
+
+// revokeUserSession handles signing another user out of one session (admin only)
+func (s *Server) revokeUserSession(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid user ID", "user ID must be a valid UUID"))
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("session_id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	if err := s.authUseCase.RevokeUserSession(c.Request.Context(), adminID, userID, sessionID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked successfully",
+	})
+}
+
+// revokeAllUserSessions handles signing another user out of every session (admin only)
+func (s *Server) revokeAllUserSessions(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid user ID", "user ID must be a valid UUID"))
+		return
+	}
+
+	revoked, err := s.authUseCase.RevokeAllUserSessions(c.Request.Context(), adminID, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sessions revoked successfully",
+		"data": gin.H{
+			"revoked_sessions": revoked,
+		},
+	})
+}
+
 // changePassword handles password change
 func (s *Server) changePassword(c *gin.Context) {
 	userID, err := s.getCurrentUser(c)
@@ -139,10 +255,10 @@ func (s *Server) changePassword(c *gin.Context) {
 		return
 	}
 
-	// TODO: Use actual auth use case
-	// For now, return success
-	_ = userID
-	_ = req
+	if err := s.authUseCase.ChangePassword(c.Request.Context(), userID, req); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -150,6 +266,28 @@ func (s *Server) changePassword(c *gin.Context) {
 	})
 }
 
+// changeExpiredPassword handles the password change of a user who has to change it before signing in
+func (s *Server) changeExpiredPassword(c *gin.Context) {
+	var req usecases.ChangeExpiredPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	if err := s.authUseCase.ChangeExpiredPassword(c.Request.Context(), req); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Password changed successfully, sign in with the new password",
+	})
+}
+
 // resetPassword handles password reset (admin only)
 func (s *Server) resetPassword(c *gin.Context) {
 	adminID, err := s.getCurrentUser(c)
@@ -179,12 +317,44 @@ func (s *Server) resetPassword(c *gin.Context) {
 
 	req.UserID = userID
 
-	// TODO: Use actual auth use case
-	_ = adminID
-	_ = req
+	if err := s.authUseCase.ResetPassword(c.Request.Context(), adminID, req); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Password reset successfully",
 	})
-}
\ No newline at end of file
+}
+
+// requirePasswordChange handles making users change their password on next login (admin only)
+func (s *Server) requirePasswordChange(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.RequirePasswordChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.userUseCase.RequirePasswordChange(c.Request.Context(), adminID, GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    response,
+		"message": "Password change required successfully",
+	})
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// upsertCatalogItem handles creating or updating a product with its price and stock level
+// in one idempotent call, for external systems of record such as an ERP
+func (s *Server) upsertCatalogItem(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpsertCatalogItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	item, err := s.productUseCase.UpsertCatalogItem(c.Request.Context(), GetTenantID(c), userID, c.Param("sku"), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	message := "Catalog item updated successfully"
+	if item.Created {
+		status = http.StatusCreated
+		message = "Catalog item created successfully"
+	} else if !item.Changed {
+		message = "Catalog item is up to date"
+	}
+
+	c.JSON(status, gin.H{
+		"message": message,
+		"data":    item,
+	})
+}
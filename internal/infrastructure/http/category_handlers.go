@@ -0,0 +1,168 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listCategories handles listing the tenant's categories as a flat list
+func (s *Server) listCategories(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	categories, err := s.categoryUseCase.ListCategories(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": categories,
+	})
+}
+
+// getCategoryTree handles retrieving the tenant's categories as a tree
+func (s *Server) getCategoryTree(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	tree, err := s.categoryUseCase.GetCategoryTree(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tree,
+	})
+}
+
+// getCategory handles retrieving a category
+func (s *Server) getCategory(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid category ID", err.Error()))
+		return
+	}
+
+	category, err := s.categoryUseCase.GetCategory(c.Request.Context(), GetTenantID(c), categoryID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": category,
+	})
+}
+
+// createCategory handles adding a category to the tenant's tree
+func (s *Server) createCategory(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "manage_categories"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	category, err := s.categoryUseCase.CreateCategory(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Category created successfully",
+		"data":    category,
+	})
+}
+
+// updateCategory handles renaming, moving or reordering a category
+func (s *Server) updateCategory(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "manage_categories"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid category ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	category, err := s.categoryUseCase.UpdateCategory(c.Request.Context(), GetTenantID(c), userID, categoryID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Category updated successfully",
+		"data":    category,
+	})
+}
+
+// deleteCategory handles removing a category without subcategories or products
+func (s *Server) deleteCategory(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "manage_categories"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid category ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.categoryUseCase.DeleteCategory(c.Request.Context(), GetTenantID(c), userID, categoryID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Category deleted successfully",
+	})
+}
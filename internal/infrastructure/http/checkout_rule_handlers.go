@@ -0,0 +1,150 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listCheckoutRules handles listing the tenant's checkout rules
+func (s *Server) listCheckoutRules(c *gin.Context) {
+	if err := s.checkPermission(c, "checkout_rules", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	rules, err := s.checkoutRuleUseCase.ListCheckoutRules(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rules,
+	})
+}
+
+// createCheckoutRule handles creating a checkout rule
+func (s *Server) createCheckoutRule(c *gin.Context) {
+	if err := s.checkPermission(c, "checkout_rules", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateCheckoutRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	rule, err := s.checkoutRuleUseCase.CreateCheckoutRule(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Checkout rule created successfully",
+		"data":    rule,
+	})
+}
+
+// getCheckoutRule handles retrieving a checkout rule
+func (s *Server) getCheckoutRule(c *gin.Context) {
+	if err := s.checkPermission(c, "checkout_rules", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout rule ID", err.Error()))
+		return
+	}
+
+	rule, err := s.checkoutRuleUseCase.GetCheckoutRule(c.Request.Context(), GetTenantID(c), ruleID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rule,
+	})
+}
+
+// updateCheckoutRule handles updating a checkout rule
+func (s *Server) updateCheckoutRule(c *gin.Context) {
+	if err := s.checkPermission(c, "checkout_rules", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout rule ID", err.Error()))
+		return
+	}
+
+	var req usecases.UpdateCheckoutRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	rule, err := s.checkoutRuleUseCase.UpdateCheckoutRule(c.Request.Context(), GetTenantID(c), userID, ruleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Checkout rule updated successfully",
+		"data":    rule,
+	})
+}
+
+// deleteCheckoutRule handles deleting a checkout rule
+func (s *Server) deleteCheckoutRule(c *gin.Context) {
+	if err := s.checkPermission(c, "checkout_rules", "delete"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout rule ID", err.Error()))
+		return
+	}
+
+	if err := s.checkoutRuleUseCase.DeleteCheckoutRule(c.Request.Context(), GetTenantID(c), userID, ruleID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Checkout rule deleted successfully",
+	})
+}
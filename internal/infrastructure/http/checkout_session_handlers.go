@@ -0,0 +1,270 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// startCheckoutSession handles starting a checkout session with a new pending sale
+func (s *Server) startCheckoutSession(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.StartCheckoutSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.StartSession(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Checkout session started successfully",
+		"data":    response,
+	})
+}
+
+// getCheckoutSession handles retrieving a checkout session to resume it
+func (s *Server) getCheckoutSession(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.GetSession(c.Request.Context(), GetTenantID(c), sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// setCheckoutCart handles replacing the cart of a checkout session
+func (s *Server) setCheckoutCart(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SetCheckoutCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.SetCart(c.Request.Context(), GetTenantID(c), userID, sessionID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Cart updated successfully",
+		"data":    response,
+	})
+}
+
+// setCheckoutCustomer handles attaching the customer to a checkout session
+func (s *Server) setCheckoutCustomer(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateSaleCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.SetCustomer(c.Request.Context(), GetTenantID(c), userID, sessionID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Customer updated successfully",
+		"data":    response,
+	})
+}
+
+// evaluateCheckoutSession handles applying promotions and checkout rules to a checkout session
+func (s *Server) evaluateCheckoutSession(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.Evaluate(c.Request.Context(), GetTenantID(c), userID, sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Checkout evaluated successfully",
+		"data":    response,
+	})
+}
+
+// createCheckoutPaymentIntent handles recording how the customer of a checkout session pays
+func (s *Server) createCheckoutPaymentIntent(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CompleteSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.CreatePaymentIntent(c.Request.Context(), GetTenantID(c), userID, sessionID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment intent created successfully",
+		"data":    response,
+	})
+}
+
+// completeCheckoutSession handles completing the sale of a checkout session
+func (s *Server) completeCheckoutSession(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.Complete(c.Request.Context(), GetTenantID(c), userID, sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Checkout completed successfully",
+		"data":    response,
+	})
+}
+
+// cancelCheckoutSession handles abandoning a checkout session
+func (s *Server) cancelCheckoutSession(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid checkout session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.checkoutSessionUseCase.Cancel(c.Request.Context(), GetTenantID(c), userID, sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Checkout session cancelled successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listCoupons handles listing the tenant's coupons
+func (s *Server) listCoupons(c *gin.Context) {
+	if err := s.checkPermission(c, "coupons", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	coupons, err := s.couponUseCase.ListCoupons(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": coupons,
+	})
+}
+
+// createCoupon handles creating a coupon code
+func (s *Server) createCoupon(c *gin.Context) {
+	if err := s.checkPermission(c, "coupons", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	coupon, err := s.couponUseCase.CreateCoupon(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Coupon created successfully",
+		"data":    coupon,
+	})
+}
+
+// getCoupon handles retrieving a coupon
+func (s *Server) getCoupon(c *gin.Context) {
+	if err := s.checkPermission(c, "coupons", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid coupon ID", err.Error()))
+		return
+	}
+
+	coupon, err := s.couponUseCase.GetCoupon(c.Request.Context(), GetTenantID(c), couponID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": coupon,
+	})
+}
+
+// updateCoupon handles updating a coupon's discount, limits or status
+func (s *Server) updateCoupon(c *gin.Context) {
+	if err := s.checkPermission(c, "coupons", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid coupon ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	coupon, err := s.couponUseCase.UpdateCoupon(c.Request.Context(), GetTenantID(c), userID, couponID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Coupon updated successfully",
+		"data":    coupon,
+	})
+}
+
+// validateCoupon handles checking a code a customer presents at checkout
+func (s *Server) validateCoupon(c *gin.Context) {
+	if err := s.checkPermission(c, "coupons", "validate"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ValidateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	result, err := s.couponUseCase.ValidateCoupon(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// getCouponRedemptions handles listing the latest redemptions of a coupon
+func (s *Server) getCouponRedemptions(c *gin.Context) {
+	if err := s.checkPermission(c, "coupons", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid coupon ID", err.Error()))
+		return
+	}
+
+	redemptions, err := s.couponUseCase.GetCouponRedemptions(c.Request.Context(), GetTenantID(c), couponID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": redemptions,
+	})
+}
+
+// getCouponPerformanceReport handles reporting on coupon redemptions. The from_date and
+// to_date query parameters are inclusive YYYY-MM-DD dates.
+func (s *Server) getCouponPerformanceReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	report, err := s.couponUseCase.GetPerformanceReport(c.Request.Context(), GetTenantID(c), fromDate, toDate)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getCurrencyFormat handles retrieving how the tenant's amounts are displayed
+func (s *Server) getCurrencyFormat(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	format, err := s.tenantUseCase.GetCurrencyFormat(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": format,
+	})
+}
+
+// updateCurrencyFormat handles changing the tenant's currency symbol, separators and decimal places
+func (s *Server) updateCurrencyFormat(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateCurrencyFormatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	format, err := s.tenantUseCase.UpdateCurrencyFormat(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Currency format updated successfully",
+		"data":    format,
+	})
+}
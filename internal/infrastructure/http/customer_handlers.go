@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getCustomer handles retrieving a customer
+func (s *Server) getCustomer(c *gin.Context) {
+	if err := s.checkPermission(c, "customers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid customer ID", err.Error()))
+		return
+	}
+
+	customer, err := s.customerUseCase.GetCustomer(c.Request.Context(), GetTenantID(c), customerID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": customer,
+	})
+}
+
+// listCustomerDuplicates handles listing the pending duplicate customer suggestions
+func (s *Server) listCustomerDuplicates(c *gin.Context) {
+	if err := s.checkPermission(c, "customers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.customerUseCase.ListDuplicates(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// dismissCustomerDuplicate handles dismissing a duplicate customer suggestion
+func (s *Server) dismissCustomerDuplicate(c *gin.Context) {
+	if err := s.checkPermission(c, "customers", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	duplicateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid duplicate ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	duplicate, err := s.customerUseCase.DismissDuplicate(c.Request.Context(), GetTenantID(c), userID, duplicateID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Duplicate dismissed successfully",
+		"data":    duplicate,
+	})
+}
+
+// mergeCustomers handles merging a duplicate customer into a surviving one
+func (s *Server) mergeCustomers(c *gin.Context) {
+	if err := s.checkPermission(c, "customers", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.MergeCustomersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.customerUseCase.MergeCustomers(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Customers merged successfully",
+		"data":    response,
+	})
+}
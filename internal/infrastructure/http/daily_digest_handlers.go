@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getDailyDigestSettings handles retrieving the tenant's daily digest settings
+func (s *Server) getDailyDigestSettings(c *gin.Context) {
+	if err := s.checkPermission(c, "daily_digest", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	setting, err := s.dailyDigestUseCase.GetSettings(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": setting,
+	})
+}
+
+// updateDailyDigestSettings handles opting in or out of the daily digest and changing its recipients and schedule
+func (s *Server) updateDailyDigestSettings(c *gin.Context) {
+	if err := s.checkPermission(c, "daily_digest", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateDailyDigestSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	setting, err := s.dailyDigestUseCase.UpdateSettings(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Daily digest settings updated successfully",
+		"data":    setting,
+	})
+}
+
+// previewDailyDigest handles rendering the daily digest email for a date without sending it
+func (s *Server) previewDailyDigest(c *gin.Context) {
+	if err := s.checkPermission(c, "daily_digest", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var date time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse(reportDateLayout, dateStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid date", "date must be in YYYY-MM-DD format"))
+			return
+		}
+		date = parsed
+	}
+
+	body, err := s.dailyDigestUseCase.PreviewDigest(c.Request.Context(), GetTenantID(c), date)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(body))
+}
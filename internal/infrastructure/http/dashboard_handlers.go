@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	tenantmonitoring "github.com/nicklaros/adol/internal/infrastructure/monitoring"
+)
+
+// businessDashboardResponse adds the tenant's active monitoring alerts to the cached dashboard
+// figures. Alerts are held in memory by the tenant monitor, so they are always read fresh.
+type businessDashboardResponse struct {
+	*usecases.BusinessDashboardResponse
+	Alerts []*tenantmonitoring.Alert `json:"alerts"`
+}
+
+// getBusinessDashboard handles retrieving everything shown on the main dashboard in one round trip
+func (s *Server) getBusinessDashboard(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	tenantID := GetTenantID(c)
+	dashboard, err := s.dashboardUseCase.GetBusinessDashboard(c.Request.Context(), tenantID, time.Now())
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	alerts, err := s.tenantMonitor.CheckAlerts(c.Request.Context(), tenantID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": businessDashboardResponse{
+			BusinessDashboardResponse: dashboard,
+			Alerts:                    alerts,
+		},
+	})
+}
+
+// getInventoryDashboard handles retrieving the aggregate figures for the inventory landing page
+func (s *Server) getInventoryDashboard(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	dashboard, err := s.dashboardUseCase.GetInventoryDashboard(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dashboard,
+	})
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getDeliveryPolicy handles getting the tenant's webhook and email retry policies
+func (s *Server) getDeliveryPolicy(c *gin.Context) {
+	if err := s.checkPermission(c, "delivery_policy", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	policy, err := s.deliveryPolicyUseCase.GetPolicy(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": policy,
+	})
+}
+
+// updateDeliveryPolicy handles changing the tenant's webhook and email retry policies and
+// who is alerted when a destination is paused
+func (s *Server) updateDeliveryPolicy(c *gin.Context) {
+	if err := s.checkPermission(c, "delivery_policy", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateDeliveryPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	policy, err := s.deliveryPolicyUseCase.UpdatePolicy(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Delivery policy updated successfully",
+		"data":    policy,
+	})
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// captureInvoiceSignature handles recording a customer's drawn signature against an invoice
+func (s *Server) captureInvoiceSignature(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "sign"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req usecases.CaptureSignatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	client := usecases.SignatureClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	signature, err := s.documentSignatureUseCase.CaptureInvoiceSignature(c.Request.Context(), GetTenantID(c), userID, invoiceID, req, client)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Signature captured successfully",
+		"data":    signature,
+	})
+}
+
+// getInvoiceSignature handles retrieving the signer details of an invoice signature
+func (s *Server) getInvoiceSignature(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	signature, err := s.documentSignatureUseCase.GetInvoiceSignature(c.Request.Context(), GetTenantID(c), invoiceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": signature,
+	})
+}
+
+// getInvoiceSignatureImage handles downloading the drawn signature image of an invoice
+func (s *Server) getInvoiceSignatureImage(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	signature, err := s.documentSignatureUseCase.GetInvoiceSignature(c.Request.Context(), GetTenantID(c), invoiceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, signature.ContentType, signature.ImageData)
+}
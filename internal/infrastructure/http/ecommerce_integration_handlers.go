@@ -0,0 +1,340 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// ecommerceWebhookBodyLimit caps the size of an order webhook body
+const ecommerceWebhookBodyLimit = 1 << 20
+
+// receiveEcommerceWebhook handles order webhooks sent by Shopify and WooCommerce stores. The
+// store authenticates by signing the body with the integration's webhook secret. Orders that
+// cannot become sales are acknowledged and recorded as failed; other errors make the store
+// retry the webhook.
+func (s *Server) receiveEcommerceWebhook(c *gin.Context) {
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	topic := c.GetHeader("X-Shopify-Topic")
+	signature := c.GetHeader("X-Shopify-Hmac-Sha256")
+	if topic == "" {
+		topic = c.GetHeader("X-WC-Webhook-Topic")
+		signature = c.GetHeader("X-WC-Webhook-Signature")
+	}
+
+	// WooCommerce pings a new webhook's URL without a topic before delivering to it
+	if topic == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Webhook acknowledged",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, ecommerceWebhookBodyLimit))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	order, err := s.ecommerceIntegrationUseCase.HandleWebhook(c.Request.Context(), integrationID, topic, signature, body)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook processed successfully",
+		"data":    order,
+	})
+}
+
+// listEcommerceIntegrations handles listing the tenant's e-commerce integrations
+func (s *Server) listEcommerceIntegrations(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrations, err := s.ecommerceIntegrationUseCase.ListIntegrations(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": integrations,
+	})
+}
+
+// getEcommerceIntegration handles retrieving an e-commerce integration
+func (s *Server) getEcommerceIntegration(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	integration, err := s.ecommerceIntegrationUseCase.GetIntegration(c.Request.Context(), GetTenantID(c), integrationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": integration,
+	})
+}
+
+// createEcommerceIntegration handles connecting the tenant to a Shopify or WooCommerce store
+func (s *Server) createEcommerceIntegration(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateEcommerceIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	integration, err := s.ecommerceIntegrationUseCase.CreateIntegration(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "E-commerce integration created successfully",
+		"data":    integration,
+	})
+}
+
+// updateEcommerceIntegration handles updating an e-commerce integration
+func (s *Server) updateEcommerceIntegration(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	var req usecases.UpdateEcommerceIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	integration, err := s.ecommerceIntegrationUseCase.UpdateIntegration(c.Request.Context(), GetTenantID(c), userID, integrationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "E-commerce integration updated successfully",
+		"data":    integration,
+	})
+}
+
+// deleteEcommerceIntegration handles disconnecting the tenant from a store
+func (s *Server) deleteEcommerceIntegration(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	if err := s.ecommerceIntegrationUseCase.DeleteIntegration(c.Request.Context(), GetTenantID(c), userID, integrationID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "E-commerce integration deleted successfully",
+	})
+}
+
+// listEcommerceOrders handles listing the orders an integration received
+func (s *Server) listEcommerceOrders(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	var status *entities.EcommerceOrderStatus
+	if value := c.Query("status"); value != "" {
+		orderStatus := entities.EcommerceOrderStatus(value)
+		if !orderStatus.IsValid() {
+			s.respondWithError(c, errors.NewValidationError("invalid order status", "status must be one of: open, fulfilled, cancelled, failed"))
+			return
+		}
+		status = &orderStatus
+	}
+
+	response, err := s.ecommerceIntegrationUseCase.ListOrders(c.Request.Context(), GetTenantID(c), integrationID, status, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// listEcommerceProductLinks handles listing the product links of an integration
+func (s *Server) listEcommerceProductLinks(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	links, err := s.ecommerceIntegrationUseCase.ListProductLinks(c.Request.Context(), GetTenantID(c), integrationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": links,
+	})
+}
+
+// createEcommerceProductLink handles linking a product to its product on the store
+func (s *Server) createEcommerceProductLink(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	var req usecases.CreateEcommerceProductLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	link, err := s.ecommerceIntegrationUseCase.CreateProductLink(c.Request.Context(), GetTenantID(c), userID, integrationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Product linked successfully",
+		"data":    link,
+	})
+}
+
+// deleteEcommerceProductLink handles unlinking a product from the store
+func (s *Server) deleteEcommerceProductLink(c *gin.Context) {
+	if err := s.checkPermission(c, "ecommerce_integrations", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	integrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid integration ID", err.Error()))
+		return
+	}
+
+	linkID, err := uuid.Parse(c.Param("link_id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid product link ID", err.Error()))
+		return
+	}
+
+	if err := s.ecommerceIntegrationUseCase.DeleteProductLink(c.Request.Context(), GetTenantID(c), userID, integrationID, linkID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product unlinked successfully",
+	})
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getInvoiceEmails handles listing the emails queued for an invoice and their delivery status
+func (s *Server) getInvoiceEmails(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	emails, err := s.emailOutboxUseCase.GetInvoiceEmails(c.Request.Context(), GetTenantID(c), invoiceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": emails,
+	})
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listEmailTemplates handles listing the template in use for every kind of customer email
+func (s *Server) listEmailTemplates(c *gin.Context) {
+	if err := s.checkPermission(c, "email_templates", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	templates, err := s.emailTemplateUseCase.ListTemplates(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": templates,
+	})
+}
+
+// getEmailTemplate handles retrieving the template in use for a kind of customer email
+func (s *Server) getEmailTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "email_templates", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	kind := entities.EmailTemplateKind(c.Param("kind"))
+	template, err := s.emailTemplateUseCase.GetTemplate(c.Request.Context(), GetTenantID(c), kind)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": template,
+	})
+}
+
+// updateEmailTemplate handles overriding the template of a kind of customer email
+func (s *Server) updateEmailTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "email_templates", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateEmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	kind := entities.EmailTemplateKind(c.Param("kind"))
+	template, err := s.emailTemplateUseCase.UpdateTemplate(c.Request.Context(), GetTenantID(c), userID, kind, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email template updated successfully",
+		"data":    template,
+	})
+}
+
+// resetEmailTemplate handles removing the tenant's override of an email template
+func (s *Server) resetEmailTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "email_templates", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	kind := entities.EmailTemplateKind(c.Param("kind"))
+	template, err := s.emailTemplateUseCase.ResetTemplate(c.Request.Context(), GetTenantID(c), userID, kind)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email template reset to default",
+		"data":    template,
+	})
+}
+
+// previewEmailTemplate handles rendering an email template without saving or sending it
+func (s *Server) previewEmailTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "email_templates", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.PreviewEmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	kind := entities.EmailTemplateKind(c.Param("kind"))
+	rendered, err := s.emailTemplateUseCase.PreviewTemplate(c.Request.Context(), GetTenantID(c), kind, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rendered,
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/nicklaros/adol/pkg/audit"
 	"github.com/nicklaros/adol/pkg/errors"
 )
 
@@ -46,6 +47,12 @@ func (s *Server) RequestTrackingMiddleware() gin.HandlerFunc {
 		c.Set("request_id", requestID)
 		c.Header("X-Request-ID", requestID)
 
+		// Record where the request came from on the audit events it causes
+		c.Request = c.Request.WithContext(audit.WithOrigin(c.Request.Context(), audit.Origin{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}))
+
 		start := time.Now()
 
 		// Process request
@@ -74,6 +81,16 @@ func (s *Server) RequestTrackingMiddleware() gin.HandlerFunc {
 
 		logMessage := fmt.Sprintf("%s %s - %d (%dms)", c.Request.Method, c.Request.URL.String(), statusCode, duration.Milliseconds())
 
+		if s.config.Metrics.PrometheusEnabled {
+			s.observeRequestLatency(c, duration.Seconds())
+		}
+
+		// Feed per-tenant error rate and latency into the tenant health score
+		if tenantID := GetTenantID(c); tenantID != uuid.Nil {
+			operation := c.Request.Method + " " + c.FullPath()
+			s.tenantMonitor.TrackResponse(c.Request.Context(), tenantID, operation, duration, statusCode < 500)
+		}
+
 		switch logLevel {
 		case "error":
 			s.logger.WithFields(logFields).Error(logMessage)
@@ -0,0 +1,322 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// exportResponseWriter streams an export to the client. The download headers are only sent
+// with the first bytes of the file, so an export failing before it produced any output can
+// still be answered with a JSON error, and every write is flushed to the client right away.
+type exportResponseWriter struct {
+	c        *gin.Context
+	format   usecases.ExportFormat
+	filename string
+	started  bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.format.ContentType())
+		w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.c.Status(http.StatusOK)
+	}
+
+	n, err := w.c.Writer.Write(p)
+	w.c.Writer.Flush()
+	return n, err
+}
+
+// streamExport runs an export into the response. Errors after the file started streaming
+// cannot be reported to the client any more; the use case has logged them and the client
+// receives a truncated file.
+func (s *Server) streamExport(c *gin.Context, name string, export func(usecases.ExportFormat, *exportResponseWriter) error) {
+	format, err := usecases.ParseExportFormat(c.Query("format"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	w := &exportResponseWriter{
+		c:        c,
+		format:   format,
+		filename: fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format),
+	}
+
+	if err := export(format, w); err != nil && !w.started {
+		s.respondWithError(c, err)
+	}
+}
+
+// exportProducts handles streaming the products matching the list filters as CSV or XLSX
+func (s *Server) exportProducts(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filter, err := productFilterFromQuery(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	s.streamExport(c, "products", func(format usecases.ExportFormat, w *exportResponseWriter) error {
+		return s.exportUseCase.ExportProducts(c.Request.Context(), GetTenantID(c), userID, filter, format, w)
+	})
+}
+
+// exportSales handles streaming the sales matching the list filters as CSV or XLSX
+func (s *Server) exportSales(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filter := repositories.SaleFilter{
+		CustomerName:  c.Query("customer_name"),
+		CustomerEmail: c.Query("customer_email"),
+		Search:        c.Query("search"),
+		OrderBy:       c.Query("order_by"),
+		OrderDir:      c.Query("order_dir"),
+	}
+	if status := c.Query("status"); status != "" {
+		value := entities.SaleStatus(status)
+		filter.Status = &value
+	}
+	if paymentMethod := c.Query("payment_method"); paymentMethod != "" {
+		value := entities.PaymentMethod(paymentMethod)
+		filter.PaymentMethod = &value
+	}
+	if channel := c.Query("channel"); channel != "" {
+		value := entities.SaleChannel(channel)
+		filter.Channel = &value
+	}
+	if filter.CreatedBy, err = queryUUID(c, "created_by"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.FromDate, err = queryTime(c, "from_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.ToDate, err = queryTime(c, "to_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.MinAmount, err = queryDecimal(c, "min_amount"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.MaxAmount, err = queryDecimal(c, "max_amount"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	s.streamExport(c, "sales", func(format usecases.ExportFormat, w *exportResponseWriter) error {
+		return s.exportUseCase.ExportSales(c.Request.Context(), GetTenantID(c), userID, filter, format, w)
+	})
+}
+
+// exportInvoices handles streaming the invoices matching the list filters as CSV or XLSX
+func (s *Server) exportInvoices(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filter := repositories.InvoiceFilter{
+		CustomerName:  c.Query("customer_name"),
+		CustomerEmail: c.Query("customer_email"),
+		Search:        c.Query("search"),
+		OrderBy:       c.Query("order_by"),
+		OrderDir:      c.Query("order_dir"),
+	}
+	if status := c.Query("status"); status != "" {
+		value := entities.InvoiceStatus(status)
+		filter.Status = &value
+	}
+	if paymentMethod := c.Query("payment_method"); paymentMethod != "" {
+		value := entities.PaymentMethod(paymentMethod)
+		filter.PaymentMethod = &value
+	}
+	if overdue := c.Query("overdue"); overdue != "" {
+		value, err := strconv.ParseBool(overdue)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid overdue", "overdue must be true or false"))
+			return
+		}
+		filter.Overdue = &value
+	}
+	if filter.CreatedBy, err = queryUUID(c, "created_by"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.SaleID, err = queryUUID(c, "sale_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.FromDate, err = queryTime(c, "from_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.ToDate, err = queryTime(c, "to_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.DueFromDate, err = queryTime(c, "due_from_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.DueToDate, err = queryTime(c, "due_to_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.MinAmount, err = queryDecimal(c, "min_amount"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.MaxAmount, err = queryDecimal(c, "max_amount"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	s.streamExport(c, "invoices", func(format usecases.ExportFormat, w *exportResponseWriter) error {
+		return s.exportUseCase.ExportInvoices(c.Request.Context(), GetTenantID(c), userID, filter, format, w)
+	})
+}
+
+// exportStockMovements handles streaming the stock movements matching the list filters as
+// CSV or XLSX
+func (s *Server) exportStockMovements(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filter := repositories.StockMovementFilter{
+		Reference: c.Query("reference"),
+		OrderBy:   c.Query("order_by"),
+		OrderDir:  c.Query("order_dir"),
+	}
+	if movementType := c.Query("type"); movementType != "" {
+		value := entities.StockMovementType(movementType)
+		filter.Type = &value
+	}
+	if reason := c.Query("reason"); reason != "" {
+		value := entities.StockMovementReason(reason)
+		filter.Reason = &value
+	}
+	if filter.ProductID, err = queryUUID(c, "product_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.LocationID, err = queryUUID(c, "location_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.CreatedBy, err = queryUUID(c, "created_by"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.FromDate, err = queryTime(c, "from_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.ToDate, err = queryTime(c, "to_date"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	s.streamExport(c, "stock-movements", func(format usecases.ExportFormat, w *exportResponseWriter) error {
+		return s.exportUseCase.ExportStockMovements(c.Request.Context(), GetTenantID(c), userID, filter, format, w)
+	})
+}
+
+// queryUUID parses an optional UUID query parameter
+func queryUUID(c *gin.Context, name string) (*uuid.UUID, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid "+name, name+" must be a UUID")
+	}
+	return &value, nil
+}
+
+// queryTime parses an optional RFC 3339 timestamp query parameter
+func queryTime(c *gin.Context, name string) (*time.Time, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid "+name, name+" must be an RFC 3339 timestamp")
+	}
+	return &value, nil
+}
+
+// queryDecimal parses an optional decimal query parameter
+func queryDecimal(c *gin.Context, name string) (*decimal.Decimal, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := decimal.NewFromString(raw)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid "+name, name+" must be a number")
+	}
+	return &value, nil
+}
+
+// queryFloat parses an optional number query parameter
+func queryFloat(c *gin.Context, name string) (*float64, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid "+name, name+" must be a number")
+	}
+	return &value, nil
+}
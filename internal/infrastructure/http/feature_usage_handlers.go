@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+)
+
+// getFeatureAdoptionReport handles reporting how widely the major features are adopted across
+// tenants. The from_date and to_date query parameters are inclusive YYYY-MM-DD dates.
+func (s *Server) getFeatureAdoptionReport(c *gin.Context) {
+	if err := s.checkPermission(c, "feature_adoption", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	report, err := s.featureUsageUseCase.GetAdoptionReport(c.Request.Context(), usecases.FeatureAdoptionReportRequest{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Period:   repositories.ReportPeriod(c.DefaultQuery("period", string(repositories.ReportPeriodWeek))),
+	})
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
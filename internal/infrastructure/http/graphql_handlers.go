@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// GraphQLRequest represents a GraphQL request
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphql handles GraphQL queries of products, stock, sales and invoices. Requests fail as a
+// whole only when they cannot be executed; errors resolving a field are returned along with
+// the rest of the data, with status 200 as GraphQL clients expect.
+func (s *Server) graphql(c *gin.Context) {
+	var req GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+	if req.Query == "" {
+		s.respondWithError(c, errors.NewValidationError("query is required", "query cannot be empty"))
+		return
+	}
+
+	c.JSON(http.StatusOK, s.executeGraphQL(c, req))
+}
+
+// executeGraphQL parses, validates and executes a GraphQL request. Queries that are not valid
+// or exceed the limits are answered with errors and no data.
+func (s *Server) executeGraphQL(c *gin.Context, req GraphQLRequest) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if validation := graphql.ValidateDocument(s.graphqlSchema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := checkGraphQLLimits(doc); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        *s.graphqlSchema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(c.Request.Context(), graphqlContextKey{}, s.newGraphQLRequest(c)),
+	})
+	for i, err := range result.Errors {
+		result.Errors[i] = s.formatGraphQLError(err)
+	}
+	return result
+}
+
+// checkGraphQLLimits checks that no operation of a validated query nests its fields deeper
+// than graphqlMaxDepth or selects more than graphqlMaxFields fields, counting the fields of a
+// fragment every time it is spread
+func checkGraphQLLimits(doc *ast.Document) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		limits := &graphqlLimits{fragments: fragments}
+		limits.walk(op.SelectionSet, 1)
+		if limits.tooDeep != nil {
+			return gqlerrors.NewError(fmt.Sprintf("Query exceeds the maximum depth of %d.", graphqlMaxDepth), []ast.Node{limits.tooDeep}, "", nil, nil, nil)
+		}
+		if limits.fields > graphqlMaxFields {
+			return gqlerrors.NewError(fmt.Sprintf("Query exceeds the maximum of %d fields.", graphqlMaxFields), []ast.Node{op}, "", nil, nil, nil)
+		}
+	}
+	return nil
+}
+
+// graphqlLimits walks the selections of an operation, counting its fields
+type graphqlLimits struct {
+	fragments map[string]*ast.FragmentDefinition
+	fields    int
+	tooDeep   *ast.Field // First field nested too deeply
+}
+
+// walk counts the fields of a selection set at a depth. It stops once a limit is exceeded, so
+// fragments spread many times over do not make it expand the whole query.
+func (l *graphqlLimits) walk(set *ast.SelectionSet, depth int) {
+	if set == nil {
+		return
+	}
+
+	for _, selection := range set.Selections {
+		if l.tooDeep != nil || l.fields > graphqlMaxFields {
+			return
+		}
+
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if depth > graphqlMaxDepth {
+				l.tooDeep = sel
+				return
+			}
+			l.fields++
+			l.walk(sel.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			l.walk(sel.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			// Validation rejects unknown fragments and cycles between them
+			if fragment, ok := l.fragments[sel.Name.Value]; ok {
+				l.walk(fragment.SelectionSet, depth)
+			}
+		}
+	}
+}
@@ -0,0 +1,558 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const (
+	graphqlMaxDepth     = 10  // How deeply fields may be nested in a query
+	graphqlMaxFields    = 500 // How many fields a query may select, fragments expanded
+	graphqlDefaultLimit = 10  // Page size of list fields when no limit is given
+	graphqlMaxLimit     = 100 // Largest page size of list fields
+)
+
+// graphqlContextKey is the context key of the state of a GraphQL request
+type graphqlContextKey struct{}
+
+// graphqlRequest is the state of one GraphQL request, shared by its resolvers. Nested fields
+// load what they reference through the loaders, so e.g. the products of every item of a page
+// of sales are read in one query.
+type graphqlRequest struct {
+	gin         *gin.Context
+	permissions map[string]error // Result of the read permission check, keyed by resource
+
+	products *dataloader.Loader[uuid.UUID, *usecases.ProductResponse]
+	stock    *dataloader.Loader[uuid.UUID, *usecases.StockResponse] // Keyed by product ID
+	sales    *dataloader.Loader[uuid.UUID, *usecases.SaleResponse]
+	invoices *dataloader.Loader[uuid.UUID, *usecases.InvoiceResponse] // Keyed by sale ID
+}
+
+// newGraphQLRequest creates the state of a GraphQL request
+func (s *Server) newGraphQLRequest(c *gin.Context) *graphqlRequest {
+	return &graphqlRequest{
+		gin:         c,
+		permissions: make(map[string]error),
+		products:    newGraphQLLoader(s.productUseCase.GetProductsByIDs),
+		stock:       newGraphQLLoader(s.stockUseCase.GetStockByProductIDs),
+		sales:       newGraphQLLoader(s.saleUseCase.GetSalesByIDs),
+		invoices:    newGraphQLLoader(s.invoiceUseCase.GetInvoicesBySaleIDs),
+	}
+}
+
+// newGraphQLLoader creates a loader batching the keys loaded while resolving one level of a
+// query into one call of batch. Keys missing from the result load as null. Loaders cache what
+// they load, so they must not outlive the request they are created for.
+func newGraphQLLoader[K comparable, V any](batch func(ctx context.Context, keys []K) (map[K]V, error)) *dataloader.Loader[K, V] {
+	return dataloader.NewBatchedLoader(func(ctx context.Context, keys []K) []*dataloader.Result[V] {
+		values, err := batch(ctx, keys)
+		results := make([]*dataloader.Result[V], len(keys))
+		for i, key := range keys {
+			results[i] = &dataloader.Result[V]{Data: values[key], Error: err}
+		}
+		return results
+	})
+}
+
+// graphqlLoad queues a key on a loader and returns the thunk the executor resolves once every
+// field of the current level has queued its keys
+func graphqlLoad[K comparable, V any](ctx context.Context, loader *dataloader.Loader[K, V], key K) func() (interface{}, error) {
+	thunk := loader.Load(ctx, key)
+	return func() (interface{}, error) {
+		return thunk()
+	}
+}
+
+// graphqlResolver resolves a field reading a resource, after checking that the current user
+// may read it. The check is made once per resource and request, like REST handlers check it
+// once per call.
+func (s *Server) graphqlResolver(resource string, resolve func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		req := p.Context.Value(graphqlContextKey{}).(*graphqlRequest)
+
+		err, checked := req.permissions[resource]
+		if !checked {
+			err = s.checkPermission(req.gin, resource, "read")
+			req.permissions[resource] = err
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return resolve(p.Context, req, p)
+	}
+}
+
+// formatGraphQLError presents an error like respondWithError does: application errors keep
+// their message and type, other resolver errors are logged and hidden from the client. Errors
+// in the query itself are returned as they are.
+func (s *Server) formatGraphQLError(err gqlerrors.FormattedError) gqlerrors.FormattedError {
+	original := graphqlOriginalError(err)
+	if original == nil {
+		return err
+	}
+
+	if appErr, ok := errors.IsAppError(original); ok {
+		err.Message = appErr.Message
+		err.Extensions = map[string]interface{}{"type": appErr.Type}
+		if appErr.Details != "" {
+			err.Extensions["details"] = appErr.Details
+		}
+		return err
+	}
+
+	s.logger.WithField("error", original.Error()).Error("GraphQL resolver failed")
+	err.Message = "Internal server error"
+	err.Extensions = map[string]interface{}{"type": "INTERNAL_ERROR"}
+	return err
+}
+
+// graphqlOriginalError returns the error a resolver failed with, unwrapping the located and
+// formatted errors of the executor, or nil when the error is not a resolver's
+func graphqlOriginalError(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
+
+// newGraphQLSchema builds the schema of the GraphQL endpoint. The schema is static, so an
+// error building it is a programming error.
+func (s *Server) newGraphQLSchema() *graphql.Schema {
+	pagination := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Pagination",
+		Description: "A page of a list.",
+		Fields: graphql.Fields{
+			"page":       {Type: graphql.NewNonNull(graphql.Int)},
+			"limit":      {Type: graphql.NewNonNull(graphql.Int)},
+			"totalCount": {Type: graphql.NewNonNull(graphql.Int)},
+			"totalPages": {Type: graphql.NewNonNull(graphql.Int)},
+			"hasNext":    {Type: graphql.NewNonNull(graphql.Boolean)},
+			"hasPrev":    {Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	loadProduct := s.graphqlResolver("products", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+		switch source := p.Source.(type) {
+		case *usecases.StockResponse:
+			return graphqlLoad(ctx, req.products, source.ProductID), nil
+		case *usecases.SaleItemResponse:
+			return graphqlLoad(ctx, req.products, source.ProductID), nil
+		case *usecases.InvoiceItemResponse:
+			return graphqlLoad(ctx, req.products, source.ProductID), nil
+		}
+		return nil, nil
+	})
+	loadStock := s.graphqlResolver("stock", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+		return graphqlLoad(ctx, req.stock, p.Source.(*usecases.ProductResponse).ID), nil
+	})
+
+	// Objects referring to each other declare their fields in thunks, which the schema calls
+	// once every object exists
+	var product, stock, sale, invoice *graphql.Object
+
+	product = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Product",
+		Description: "A product of the catalog.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":            {Type: graphql.NewNonNull(graphql.ID)},
+				"sku":           {Type: graphql.NewNonNull(graphql.String)},
+				"barcode":       {Type: graphql.String},
+				"name":          {Type: graphql.NewNonNull(graphql.String)},
+				"description":   {Type: graphql.String},
+				"category":      {Type: graphql.String},
+				"categoryId":    {Type: graphql.ID},
+				"price":         {Type: graphql.NewNonNull(graphql.String), Description: "Decimal amount, in the product's currency."},
+				"cost":          {Type: graphql.NewNonNull(graphql.String), Description: "Decimal amount, in the product's currency."},
+				"currency":      {Type: graphql.String, Description: "Empty when the tenant's currency."},
+				"status":        {Type: graphql.NewNonNull(graphql.String)},
+				"unit":          {Type: graphql.String},
+				"minStock":      {Type: graphql.NewNonNull(graphql.Int)},
+				"profitMargin":  {Type: graphql.NewNonNull(graphql.String)},
+				"profitAmount":  {Type: graphql.NewNonNull(graphql.String)},
+				"promoPrice":    {Type: graphql.String},
+				"promoStartsAt": {Type: graphql.DateTime},
+				"promoEndsAt":   {Type: graphql.DateTime},
+				"publishState":  {Type: graphql.NewNonNull(graphql.String)},
+				"supplierId":    {Type: graphql.ID},
+				"isSeasonal":    {Type: graphql.NewNonNull(graphql.Boolean)},
+				"isSerialized":  {Type: graphql.NewNonNull(graphql.Boolean)},
+				"createdAt":     {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":     {Type: graphql.NewNonNull(graphql.DateTime)},
+				"stock":         {Type: stock, Description: "Null when the product has no stock record.", Resolve: loadStock},
+			}
+		}),
+	})
+
+	stock = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Stock",
+		Description: "The stock of a product.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             {Type: graphql.NewNonNull(graphql.ID)},
+				"productId":      {Type: graphql.NewNonNull(graphql.ID)},
+				"availableQty":   {Type: graphql.NewNonNull(graphql.Int)},
+				"reservedQty":    {Type: graphql.NewNonNull(graphql.Int)},
+				"totalQty":       {Type: graphql.NewNonNull(graphql.Int)},
+				"reorderLevel":   {Type: graphql.NewNonNull(graphql.Int)},
+				"stockStatus":    {Type: graphql.NewNonNull(graphql.String)},
+				"lastMovementAt": {Type: graphql.DateTime},
+				"createdAt":      {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":      {Type: graphql.NewNonNull(graphql.DateTime)},
+				"product":        {Type: product, Resolve: loadProduct},
+			}
+		}),
+	})
+
+	saleItem := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "SaleItem",
+		Description: "A line of a sale.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":               {Type: graphql.NewNonNull(graphql.ID)},
+				"productId":        {Type: graphql.NewNonNull(graphql.ID)},
+				"productSku":       {Type: graphql.NewNonNull(graphql.String), Description: "SKU of the product when it was sold."},
+				"productName":      {Type: graphql.NewNonNull(graphql.String), Description: "Name of the product when it was sold."},
+				"quantity":         {Type: graphql.NewNonNull(graphql.Int)},
+				"unitPrice":        {Type: graphql.NewNonNull(graphql.String)},
+				"totalPrice":       {Type: graphql.NewNonNull(graphql.String)},
+				"listPrice":        {Type: graphql.NewNonNull(graphql.String)},
+				"priceSource":      {Type: graphql.NewNonNull(graphql.String)},
+				"taxRate":          {Type: graphql.NewNonNull(graphql.String)},
+				"taxAmount":        {Type: graphql.NewNonNull(graphql.String)},
+				"returnedQuantity": {Type: graphql.NewNonNull(graphql.Int)},
+				"serialNumbers":    {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"createdAt":        {Type: graphql.NewNonNull(graphql.DateTime)},
+				"product":          {Type: product, Description: "Null when the product was deleted.", Resolve: loadProduct},
+			}
+		}),
+	})
+
+	sale = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Sale",
+		Description: "A sale and its items.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              {Type: graphql.NewNonNull(graphql.ID)},
+				"saleNumber":      {Type: graphql.NewNonNull(graphql.String)},
+				"customerName":    {Type: graphql.String},
+				"customerEmail":   {Type: graphql.String},
+				"customerPhone":   {Type: graphql.String},
+				"items":           {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(saleItem)))},
+				"subtotal":        {Type: graphql.NewNonNull(graphql.String)},
+				"taxAmount":       {Type: graphql.NewNonNull(graphql.String)},
+				"discountAmount":  {Type: graphql.NewNonNull(graphql.String)},
+				"surchargeAmount": {Type: graphql.NewNonNull(graphql.String)},
+				"totalAmount":     {Type: graphql.NewNonNull(graphql.String)},
+				"paidAmount":      {Type: graphql.NewNonNull(graphql.String)},
+				"changeAmount":    {Type: graphql.NewNonNull(graphql.String)},
+				"paymentMethod":   {Type: graphql.String},
+				"channel":         {Type: graphql.NewNonNull(graphql.String)},
+				"status":          {Type: graphql.NewNonNull(graphql.String)},
+				"currency":        {Type: graphql.String, Description: "Currency of the amounts, empty when the tenant's currency."},
+				"notes":           {Type: graphql.String},
+				"createdAt":       {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":       {Type: graphql.NewNonNull(graphql.DateTime)},
+				"createdBy":       {Type: graphql.NewNonNull(graphql.ID)},
+				"completedAt":     {Type: graphql.DateTime},
+				"invoice": {Type: invoice, Description: "Null when no invoice was issued for the sale.", Resolve: s.graphqlResolver("invoices", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					return graphqlLoad(ctx, req.invoices, p.Source.(*usecases.SaleResponse).ID), nil
+				})},
+			}
+		}),
+	})
+
+	invoiceItem := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "InvoiceItem",
+		Description: "A line of an invoice.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: graphql.NewNonNull(graphql.ID)},
+				"productId":   {Type: graphql.NewNonNull(graphql.ID)},
+				"productSku":  {Type: graphql.NewNonNull(graphql.String)},
+				"productName": {Type: graphql.NewNonNull(graphql.String)},
+				"description": {Type: graphql.String},
+				"quantity":    {Type: graphql.NewNonNull(graphql.Int)},
+				"unitPrice":   {Type: graphql.NewNonNull(graphql.String)},
+				"totalPrice":  {Type: graphql.NewNonNull(graphql.String)},
+				"product":     {Type: product, Description: "Null when the product was deleted.", Resolve: loadProduct},
+			}
+		}),
+	})
+
+	invoice = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Invoice",
+		Description: "An invoice and its items.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                {Type: graphql.NewNonNull(graphql.ID)},
+				"invoiceNumber":     {Type: graphql.NewNonNull(graphql.String)},
+				"saleId":            {Type: graphql.NewNonNull(graphql.ID)},
+				"customerName":      {Type: graphql.String},
+				"customerEmail":     {Type: graphql.String},
+				"customerPhone":     {Type: graphql.String},
+				"customerAddress":   {Type: graphql.String},
+				"items":             {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(invoiceItem)))},
+				"subtotal":          {Type: graphql.NewNonNull(graphql.String)},
+				"taxAmount":         {Type: graphql.NewNonNull(graphql.String)},
+				"discountAmount":    {Type: graphql.NewNonNull(graphql.String)},
+				"surchargeAmount":   {Type: graphql.NewNonNull(graphql.String)},
+				"totalAmount":       {Type: graphql.NewNonNull(graphql.String)},
+				"paidAmount":        {Type: graphql.NewNonNull(graphql.String)},
+				"outstandingAmount": {Type: graphql.NewNonNull(graphql.String)},
+				"paymentMethod":     {Type: graphql.String},
+				"status":            {Type: graphql.NewNonNull(graphql.String)},
+				"currency":          {Type: graphql.String, Description: "Currency of the amounts, empty when the tenant's currency."},
+				"notes":             {Type: graphql.String},
+				"dueDate":           {Type: graphql.DateTime},
+				"paidAt":            {Type: graphql.DateTime},
+				"createdAt":         {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":         {Type: graphql.NewNonNull(graphql.DateTime)},
+				"createdBy":         {Type: graphql.NewNonNull(graphql.ID)},
+				"sale": {Type: sale, Resolve: s.graphqlResolver("sales", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					return graphqlLoad(ctx, req.sales, p.Source.(*usecases.InvoiceResponse).SaleID), nil
+				})},
+			}
+		}),
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"product": {
+				Type: product,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: s.graphqlResolver("products", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					id, err := graphqlIDArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					return graphqlLoad(ctx, req.products, *id), nil
+				}),
+			},
+			"products": {
+				Type: graphqlPage("ProductPage", product, pagination),
+				Args: graphqlPageArgs(graphql.FieldConfigArgument{
+					"search":   {Type: graphql.String, Description: "Search in name, description and SKU."},
+					"category": {Type: graphql.String},
+					"status":   {Type: graphql.String},
+				}),
+				Resolve: s.graphqlResolver("products", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					filter := repositories.ProductFilter{
+						Search:   graphqlStringArg(p, "search"),
+						Category: graphqlStringArg(p, "category"),
+					}
+					if status := graphqlStringArg(p, "status"); status != "" {
+						value := entities.ProductStatus(status)
+						filter.Status = &value
+					}
+
+					response, err := s.productUseCase.ListProducts(ctx, filter, graphqlPagination(p))
+					if err != nil {
+						return nil, err
+					}
+					return map[string]interface{}{"items": response.Products, "pagination": response.Pagination}, nil
+				}),
+			},
+			"stock": {
+				Type: stock,
+				Args: graphql.FieldConfigArgument{"productId": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: s.graphqlResolver("stock", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					productID, err := graphqlIDArg(p, "productId")
+					if err != nil {
+						return nil, err
+					}
+					return graphqlLoad(ctx, req.stock, *productID), nil
+				}),
+			},
+			"stocks": {
+				Type: graphqlPage("StockPage", stock, pagination),
+				Args: graphqlPageArgs(graphql.FieldConfigArgument{
+					"search":     {Type: graphql.String, Description: "Search in product name and SKU."},
+					"lowStock":   {Type: graphql.Boolean},
+					"outOfStock": {Type: graphql.Boolean},
+				}),
+				Resolve: s.graphqlResolver("stock", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					filter := repositories.StockFilter{
+						Search:     graphqlStringArg(p, "search"),
+						LowStock:   graphqlBoolArg(p, "lowStock"),
+						OutOfStock: graphqlBoolArg(p, "outOfStock"),
+					}
+
+					response, err := s.stockUseCase.ListStock(ctx, filter, graphqlPagination(p))
+					if err != nil {
+						return nil, err
+					}
+					return map[string]interface{}{"items": response.Stocks, "pagination": response.Pagination}, nil
+				}),
+			},
+			"sale": {
+				Type: sale,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: s.graphqlResolver("sales", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					id, err := graphqlIDArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					return graphqlLoad(ctx, req.sales, *id), nil
+				}),
+			},
+			"sales": {
+				Type: graphqlPage("SalePage", sale, pagination),
+				Args: graphqlPageArgs(graphql.FieldConfigArgument{
+					"search": {Type: graphql.String, Description: "Search in sale number, customer name and email."},
+					"status": {Type: graphql.String},
+					"from":   {Type: graphql.DateTime},
+					"to":     {Type: graphql.DateTime},
+				}),
+				Resolve: s.graphqlResolver("sales", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					filter := repositories.SaleFilter{
+						Search:   graphqlStringArg(p, "search"),
+						FromDate: graphqlTimeArg(p, "from"),
+						ToDate:   graphqlTimeArg(p, "to"),
+					}
+					if status := graphqlStringArg(p, "status"); status != "" {
+						value := entities.SaleStatus(status)
+						filter.Status = &value
+					}
+
+					response, err := s.saleUseCase.ListSales(ctx, filter, graphqlPagination(p))
+					if err != nil {
+						return nil, err
+					}
+					return map[string]interface{}{"items": response.Sales, "pagination": response.Pagination}, nil
+				}),
+			},
+			"invoice": {
+				Type: invoice,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: s.graphqlResolver("invoices", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					id, err := graphqlIDArg(p, "id")
+					if err != nil {
+						return nil, err
+					}
+					invoice, err := s.invoiceUseCase.GetInvoice(ctx, *id)
+					if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeNotFound {
+						return nil, nil
+					}
+					return invoice, err
+				}),
+			},
+			"invoices": {
+				Type: graphqlPage("InvoicePage", invoice, pagination),
+				Args: graphqlPageArgs(graphql.FieldConfigArgument{
+					"search":  {Type: graphql.String, Description: "Search in invoice number, customer name and email."},
+					"status":  {Type: graphql.String},
+					"overdue": {Type: graphql.Boolean},
+				}),
+				Resolve: s.graphqlResolver("invoices", func(ctx context.Context, req *graphqlRequest, p graphql.ResolveParams) (interface{}, error) {
+					filter := repositories.InvoiceFilter{
+						Search:  graphqlStringArg(p, "search"),
+						Overdue: graphqlBoolArg(p, "overdue"),
+					}
+					if status := graphqlStringArg(p, "status"); status != "" {
+						value := entities.InvoiceStatus(status)
+						filter.Status = &value
+					}
+
+					response, err := s.invoiceUseCase.ListInvoices(ctx, filter, graphqlPagination(p))
+					if err != nil {
+						return nil, err
+					}
+					return map[string]interface{}{"items": response.Invoices, "pagination": response.Pagination}, nil
+				}),
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		panic("invalid GraphQL schema: " + err.Error())
+	}
+	return &schema
+}
+
+// graphqlPage creates the object of a page of a list field
+func graphqlPage(name string, item, pagination *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items":      {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"pagination": {Type: graphql.NewNonNull(pagination)},
+		},
+	})
+}
+
+// graphqlPageArgs adds the pagination arguments of a list field to its filters
+func graphqlPageArgs(filters graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	filters["page"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1}
+	filters["limit"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: graphqlDefaultLimit, Description: "At most 100."}
+	return filters
+}
+
+// graphqlPagination reads the pagination arguments of a list field, capping the page size
+func graphqlPagination(p graphql.ResolveParams) utils.PaginationInfo {
+	page, _ := p.Args["page"].(int)
+	limit, _ := p.Args["limit"].(int)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = graphqlDefaultLimit
+	}
+	if limit > graphqlMaxLimit {
+		limit = graphqlMaxLimit
+	}
+	return utils.PaginationInfo{Page: page, Limit: limit}
+}
+
+// graphqlIDArg parses an ID argument as a UUID
+func graphqlIDArg(p graphql.ResolveParams, name string) (*uuid.UUID, error) {
+	raw, _ := p.Args[name].(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid "+name, name+" must be a UUID")
+	}
+	return &id, nil
+}
+
+// graphqlStringArg reads an optional string argument, empty when not given
+func graphqlStringArg(p graphql.ResolveParams, name string) string {
+	value, _ := p.Args[name].(string)
+	return value
+}
+
+// graphqlBoolArg reads an optional boolean argument, nil when not given
+func graphqlBoolArg(p graphql.ResolveParams, name string) *bool {
+	if value, ok := p.Args[name].(bool); ok {
+		return &value
+	}
+	return nil
+}
+
+// graphqlTimeArg reads an optional DateTime argument, nil when not given
+func graphqlTimeArg(p graphql.ResolveParams, name string) *time.Time {
+	if value, ok := p.Args[name].(time.Time); ok {
+		return &value
+	}
+	return nil
+}
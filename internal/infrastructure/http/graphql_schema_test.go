@@ -0,0 +1,210 @@
+package http
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// TestGraphQLFieldsResolve checks that every field without a resolver of its own finds its
+// value in the use case response it is resolved from, since the default resolver silently
+// returns null for a field it cannot match
+func TestGraphQLFieldsResolve(t *testing.T) {
+	schema := (&Server{}).newGraphQLSchema()
+
+	sources := map[string]interface{}{
+		"Pagination":  &utils.PaginationInfo{},
+		"Product":     &usecases.ProductResponse{},
+		"Stock":       &usecases.StockResponse{},
+		"Sale":        &usecases.SaleResponse{},
+		"SaleItem":    &usecases.SaleItemResponse{},
+		"Invoice":     &usecases.InvoiceResponse{},
+		"InvoiceItem": &usecases.InvoiceItemResponse{},
+	}
+
+	for name, source := range sources {
+		n := 0
+		populate(reflect.ValueOf(source).Elem(), &n)
+
+		object, ok := schema.Type(name).(*graphql.Object)
+		if !ok {
+			t.Fatalf("schema has no object %s", name)
+		}
+		for fieldName, field := range object.Fields() {
+			if field.Resolve != nil {
+				continue
+			}
+
+			value, err := graphql.DefaultResolveFn(graphql.ResolveParams{
+				Source: source,
+				Info:   graphql.ResolveInfo{FieldName: fieldName},
+			})
+			if err != nil {
+				t.Errorf("%s.%s: %v", name, fieldName, err)
+				continue
+			}
+			if !resolvesValue(field.Type, value) {
+				t.Errorf("%s.%s resolved to %#v", name, fieldName, value)
+			}
+		}
+	}
+}
+
+// resolvesValue reports whether a resolved value is set and serializes as the field's type
+func resolvesValue(t graphql.Type, value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return false
+	}
+
+	switch typ := t.(type) {
+	case *graphql.NonNull:
+		return resolvesValue(typ.OfType, value)
+	case *graphql.List:
+		return rv.Kind() == reflect.Slice && rv.Len() > 0 && resolvesValue(typ.OfType, rv.Index(0).Interface())
+	case *graphql.Scalar:
+		return typ.Serialize(value) != nil
+	}
+	return true
+}
+
+// populate sets every exported field of a value to a non-zero value
+func populate(value reflect.Value, n *int) {
+	*n++
+	switch value.Interface().(type) {
+	case time.Time:
+		value.Set(reflect.ValueOf(time.Unix(1700000000+int64(*n), 0).UTC()))
+		return
+	case decimal.Decimal:
+		value.Set(reflect.ValueOf(decimal.NewFromInt(int64(*n))))
+		return
+	case uuid.UUID:
+		value.Set(reflect.ValueOf(uuid.New()))
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString("value-" + strconv.Itoa(*n))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(*n))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(*n))
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Ptr:
+		value.Set(reflect.New(value.Type().Elem()))
+		populate(value.Elem(), n)
+	case reflect.Slice:
+		value.Set(reflect.MakeSlice(value.Type(), 1, 1))
+		populate(value.Index(0), n)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				populate(value.Field(i), n)
+			}
+		}
+	}
+}
+
+func TestCheckGraphQLLimits(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat("{ sale(id: 1) ", depth) + strings.Repeat("}", depth)
+	}
+	spread := func(times int) string {
+		return "{ " + strings.Repeat("...Fields ", times) + "} fragment Fields on Query { " + strings.Repeat("a ", 50) + "}"
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "at max depth", query: nested(graphqlMaxDepth)},
+		{name: "too deep", query: nested(graphqlMaxDepth + 1), wantErr: "maximum depth"},
+		{name: "at max fields", query: spread(graphqlMaxFields / 50)},
+		{name: "too many fields through fragments", query: spread(graphqlMaxFields/50 + 1), wantErr: "maximum of 500 fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			err = checkGraphQLLimits(doc)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestGraphQLLoaderBatchesLevel checks that the keys loaded by the fields of one level of a
+// query are read in one batch
+func TestGraphQLLoaderBatchesLevel(t *testing.T) {
+	var batches int32
+	loader := newGraphQLLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		atomic.AddInt32(&batches, 1)
+		values := make(map[int]string, len(keys))
+		for _, key := range keys {
+			values[key] = "item-" + strconv.Itoa(key)
+		}
+		return values, nil
+	})
+
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"name": {
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return graphqlLoad(p.Context, loader, p.Source.(int)), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"items": {
+				Type: graphql.NewList(item),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return []int{1, 2, 3, 4, 5}, nil
+				},
+			},
+		},
+	})})
+	if err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ items { name } }", Context: context.Background()})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if batches != 1 {
+		t.Errorf("expected 1 batch, got %d", batches)
+	}
+
+	items := result.Data.(map[string]interface{})["items"].([]interface{})
+	if len(items) != 5 || items[4].(map[string]interface{})["name"] != "item-5" {
+		t.Errorf("unexpected items: %v", items)
+	}
+}
@@ -7,10 +7,6 @@ import (
 )
 
 // Product handlers
-func (s *Server) listProducts(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "List products - TODO: implement"})
-}
-
 func (s *Server) createProduct(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Create product - TODO: implement"})
 }
@@ -27,10 +23,6 @@ func (s *Server) deleteProduct(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Delete product - TODO: implement"})
 }
 
-func (s *Server) getCategories(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get categories - TODO: implement"})
-}
-
 func (s *Server) getLowStockProducts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Get low stock products - TODO: implement"})
 }
@@ -89,10 +81,6 @@ func (s *Server) cancelSale(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Cancel sale - TODO: implement"})
 }
 
-func (s *Server) addSaleItem(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Add sale item - TODO: implement"})
-}
-
 func (s *Server) updateSaleItem(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Update sale item - TODO: implement"})
 }
@@ -151,18 +139,6 @@ func (s *Server) getOverdueInvoices(c *gin.Context) {
 }
 
 // Report handlers
-func (s *Server) getSalesReport(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get sales report - TODO: implement"})
-}
-
-func (s *Server) getDailySalesReport(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get daily sales report - TODO: implement"})
-}
-
-func (s *Server) getInvoiceReport(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get invoice report - TODO: implement"})
-}
-
 func (s *Server) getTopSellingProducts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Get top selling products - TODO: implement"})
 }
@@ -172,17 +148,12 @@ func (s *Server) getInvoicePreview(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Get invoice preview - TODO: implement"})
 }
 
-// getInvoiceTemplates handles getting available invoice templates
-func (s *Server) getInvoiceTemplates(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get invoice templates - TODO: implement"})
-}
-
 // getPaperSizes handles getting available paper sizes
 func (s *Server) getPaperSizes(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Get paper sizes - TODO: implement"})
 }
 
-// getAvailablePrinters handles getting available printers
+// getAvailablePrinters handles getting the registered printers invoices can be printed to
 func (s *Server) getAvailablePrinters(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get available printers - TODO: implement"})
+	s.listPrinters(c)
 }
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+const (
+	// idempotencyMaxBodyBytes caps the request and response bodies of idempotent requests.
+	// Larger responses are not stored, so their retries are handled afresh.
+	idempotencyMaxBodyBytes = 1 << 20
+	// idempotencyWriteTimeout bounds how long storing a response may take
+	idempotencyWriteTimeout = 5 * time.Second
+)
+
+// IdempotencyMiddleware makes a route safe to retry. A request carrying an Idempotency-Key
+// header claims the key, and its response is stored; a retry with the same key gets the
+// stored response without running the handler again. Requests without the header are
+// handled as usual. It must run after authentication, as keys are scoped to the tenant and
+// bound to the user who made the request.
+func (s *Server) IdempotencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(entities.IdempotencyKeyHeader)
+		if key == "" || s.idempotencyUseCase == nil {
+			c.Next()
+			return
+		}
+
+		userID, err := s.getCurrentUser(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			c.Abort()
+			return
+		}
+
+		// Keys belong to the tenant authentication put the request in
+		tenantID := GetTenantID(c)
+		if tenantID == uuid.Nil {
+			s.respondWithError(c, errors.NewForbiddenError("request is not scoped to a tenant"))
+			c.Abort()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, idempotencyMaxBodyBytes+1))
+			if err != nil {
+				s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+				c.Abort()
+				return
+			}
+			if len(body) > idempotencyMaxBodyBytes {
+				s.respondWithError(c, errors.NewValidationError("request body too large", "idempotent requests are limited to 1 MB"))
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		claimed, replay, err := s.idempotencyUseCase.Begin(c.Request.Context(), tenantID, userID, key, c.Request.Method, c.Request.URL.Path, body)
+		if err != nil {
+			s.respondWithError(c, err)
+			c.Abort()
+			return
+		}
+		if replay != nil {
+			c.Header(entities.IdempotencyReplayedHeader, "true")
+			c.Data(replay.ResponseStatus, "application/json; charset=utf-8", replay.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}, limit: idempotencyMaxBodyBytes}
+		c.Writer = writer
+
+		c.Next()
+
+		// The client may have gone away, which is exactly when it will retry, so the response
+		// is stored even when the request context is cancelled
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), idempotencyWriteTimeout)
+		defer cancel()
+
+		if writer.truncated {
+			s.idempotencyUseCase.Release(ctx, claimed)
+			return
+		}
+		s.idempotencyUseCase.Complete(ctx, claimed, writer.Status(), writer.body.Bytes())
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const testAccessToken = "test-access-token"
+
+type stubJWTService struct {
+	services.JWTService
+	claims *services.JWTClaims
+}
+
+func (s *stubJWTService) IsTokenRevoked(string) bool { return false }
+
+func (s *stubJWTService) ValidateAccessToken(token string) (*services.JWTClaims, error) {
+	if token != testAccessToken {
+		return nil, errors.NewUnauthorizedError("invalid token")
+	}
+	return s.claims, nil
+}
+
+type stubUserRepository struct {
+	repositories.UserRepository
+	user *entities.User
+}
+
+func (r *stubUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	if id != r.user.ID {
+		return nil, errors.NewNotFoundError("user")
+	}
+	return r.user, nil
+}
+
+type stubUserSessionRepository struct {
+	repositories.UserSessionRepository
+	session *entities.UserSession
+}
+
+func (r *stubUserSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.UserSession, error) {
+	if id != r.session.ID {
+		return nil, errors.NewNotFoundError("user session")
+	}
+	return r.session, nil
+}
+
+type stubCache struct {
+	ports.CachePort
+}
+
+func (c *stubCache) Get(ctx context.Context, key string, dest interface{}) error {
+	return errors.NewNotFoundError("cache entry")
+}
+
+func (c *stubCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return nil
+}
+
+type recordingIdempotencyKeyRepository struct {
+	repositories.IdempotencyKeyRepository
+	claimed []*entities.IdempotencyKey
+}
+
+func (r *recordingIdempotencyKeyRepository) Claim(ctx context.Context, key *entities.IdempotencyKey, lockedBefore time.Time) (bool, error) {
+	r.claimed = append(r.claimed, key)
+	return true, nil
+}
+
+func (r *recordingIdempotencyKeyRepository) Complete(ctx context.Context, key *entities.IdempotencyKey) error {
+	return nil
+}
+
+func TestIdempotencyMiddlewareUsesTenantOfJWTUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tenantID := uuid.New()
+	user := &entities.User{ID: uuid.New(), TenantID: tenantID, Status: entities.UserStatusActive}
+	session := &entities.UserSession{ID: uuid.New(), UserID: user.ID, TenantID: tenantID, ExpiresAt: time.Now().Add(time.Hour)}
+	keyRepo := &recordingIdempotencyKeyRepository{}
+
+	log := logger.NewLogger()
+	s := &Server{
+		authUseCase: usecases.NewAuthUseCase(
+			&stubUserRepository{user: user}, nil, &stubUserSessionRepository{session: session}, nil, nil,
+			&stubJWTService{claims: &services.JWTClaims{UserID: user.ID, SessionID: session.ID}},
+			&stubCache{}, nil, nil, entities.LoginLockoutPolicy{}, log,
+		),
+		idempotencyUseCase: usecases.NewIdempotencyUseCase(keyRepo, time.Hour, log),
+	}
+
+	var handlerTenant uuid.UUID
+	var handlerScope uuid.UUID
+	router := gin.New()
+	router.POST("/sales", s.authMiddleware(), s.IdempotencyMiddleware(), func(c *gin.Context) {
+		handlerTenant = GetTenantID(c)
+		handlerScope, _ = entities.TenantScopeFromContext(c.Request.Context())
+		c.JSON(http.StatusCreated, gin.H{"data": "created"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/sales", strings.NewReader(`{"notes":"test"}`))
+	req.Header.Set("Authorization", "Bearer "+testAccessToken)
+	req.Header.Set(entities.IdempotencyKeyHeader, "retry-safe-key")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	if len(keyRepo.claimed) != 1 {
+		t.Fatalf("expected 1 claimed key, got %d", len(keyRepo.claimed))
+	}
+	if got := keyRepo.claimed[0].TenantID; got != tenantID {
+		t.Errorf("expected key of tenant %s, got %s", tenantID, got)
+	}
+	if got := keyRepo.claimed[0].UserID; got != user.ID {
+		t.Errorf("expected key of user %s, got %s", user.ID, got)
+	}
+	if handlerTenant != tenantID {
+		t.Errorf("expected handler tenant %s, got %s", tenantID, handlerTenant)
+	}
+	if handlerScope != tenantID {
+		t.Errorf("expected data access scoped to %s, got %s", tenantID, handlerScope)
+	}
+}
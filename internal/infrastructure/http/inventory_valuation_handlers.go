@@ -0,0 +1,175 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// writeDownStock handles revaluing units on hand to a lower cost
+func (s *Server) writeDownStock(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "revalue"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.WriteDownInventoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	adjustment, err := s.inventoryValuationUseCase.WriteDown(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Inventory written down successfully",
+		"data":    adjustment,
+	})
+}
+
+// listStockWriteDowns handles listing the inventory cost adjustment journal. The optional
+// inclusive from_date and to_date query parameters are YYYY-MM-DD dates.
+func (s *Server) listStockWriteDowns(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.InventoryCostAdjustmentFilter{}
+
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+			return
+		}
+		filter.ProductID = &id
+	}
+
+	if reason := c.Query("reason"); reason != "" {
+		adjustmentReason := entities.CostAdjustmentReason(reason)
+		if !adjustmentReason.IsValid() {
+			s.respondWithError(c, errors.NewValidationError("invalid reason", "reason must be damaged, obsolete, expired, market_value or other"))
+			return
+		}
+		filter.Reason = &adjustmentReason
+	}
+
+	if c.Query("from_date") != "" || c.Query("to_date") != "" {
+		fromDate, toDate, err := parseReportDateRange(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.FromDate = &fromDate
+		filter.ToDate = &toDate
+	}
+
+	response, err := s.inventoryValuationUseCase.ListCostAdjustments(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// getStockWriteDown handles retrieving an inventory cost adjustment journal entry
+func (s *Server) getStockWriteDown(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adjustmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid write-down ID", err.Error()))
+		return
+	}
+
+	adjustment, err := s.inventoryValuationUseCase.GetCostAdjustment(c.Request.Context(), GetTenantID(c), adjustmentID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": adjustment,
+	})
+}
+
+// getInventoryValuationReport handles the report of inventory value at cost by product,
+// category and location and the write-downs recorded in a period. method picks average_cost
+// or latest_cost; format=csv downloads the valuation as CSV for accounting.
+func (s *Server) getInventoryValuationReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		s.respondWithError(c, errors.NewValidationError("invalid format", "format must be json or csv"))
+		return
+	}
+
+	method := entities.ValuationMethod(c.DefaultQuery("method", string(entities.ValuationMethodAverageCost)))
+	report, err := s.inventoryValuationUseCase.GetValuationReport(c.Request.Context(), GetTenantID(c), fromDate, toDate, method)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if format == "csv" {
+		data, err := usecases.InventoryValuationCSV(report)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filename := fmt.Sprintf("inventory-valuation-%s-%s.csv", method, report.GeneratedAt.Format(reportDateLayout))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// bulkEmailInvoices handles queueing emails for a selected list of invoices
+func (s *Server) bulkEmailInvoices(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "bulk_email"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.BulkEmailInvoicesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	batch, err := s.invoiceEmailBatchUseCase.BulkEmailInvoices(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Invoice emails queued",
+		"data":    batch,
+	})
+}
+
+// listInvoiceEmailBatches handles listing the tenant's recent bulk email batches
+func (s *Server) listInvoiceEmailBatches(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	batches, err := s.invoiceEmailBatchUseCase.ListBatches(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": batches,
+	})
+}
+
+// getInvoiceEmailBatch handles retrieving the progress and per-invoice outcomes of a batch
+func (s *Server) getInvoiceEmailBatch(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	batchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid batch ID", err.Error()))
+		return
+	}
+
+	batch, err := s.invoiceEmailBatchUseCase.GetBatch(c.Request.Context(), GetTenantID(c), batchID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": batch,
+	})
+}
+
+// listEmailSuppressions handles listing addresses that opted out of emails
+func (s *Server) listEmailSuppressions(c *gin.Context) {
+	if err := s.checkPermission(c, "email_suppressions", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	suppressions, err := s.invoiceEmailBatchUseCase.ListSuppressions(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": suppressions,
+	})
+}
+
+// createEmailSuppression handles recording that an address opted out of emails
+func (s *Server) createEmailSuppression(c *gin.Context) {
+	if err := s.checkPermission(c, "email_suppressions", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateEmailSuppressionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	suppression, err := s.invoiceEmailBatchUseCase.AddSuppression(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Email suppression created successfully",
+		"data":    suppression,
+	})
+}
+
+// deleteEmailSuppression handles removing an address from the opt-out list
+func (s *Server) deleteEmailSuppression(c *gin.Context) {
+	if err := s.checkPermission(c, "email_suppressions", "delete"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.invoiceEmailBatchUseCase.RemoveSuppression(c.Request.Context(), GetTenantID(c), userID, c.Param("email")); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email suppression deleted successfully",
+	})
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listInvoiceNumberReservations handles listing invoice number reservations with pagination and filtering
+func (s *Server) listInvoiceNumberReservations(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.InvoiceNumberReservationFilter{
+		TenantID:          GetTenantID(c),
+		ExternalReference: c.Query("external_reference"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		reservationStatus := entities.InvoiceNumberReservationStatus(status)
+		if err := entities.ValidateInvoiceNumberReservationStatus(reservationStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &reservationStatus
+	}
+
+	response, err := s.invoiceNumberReservationUseCase.ListReservations(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// reserveInvoiceNumber handles reserving the next invoice number for an external system
+func (s *Server) reserveInvoiceNumber(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "reserve_number"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ReserveInvoiceNumberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reservation, err := s.invoiceNumberReservationUseCase.ReserveNumber(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invoice number reserved successfully",
+		"data":    reservation,
+	})
+}
+
+// getInvoiceNumberReservation handles retrieving an invoice number reservation
+func (s *Server) getInvoiceNumberReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	reservation, err := s.invoiceNumberReservationUseCase.GetReservation(c.Request.Context(), GetTenantID(c), reservationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reservation,
+	})
+}
+
+// bindInvoiceNumberReservation handles giving a reserved invoice number to a draft invoice
+func (s *Server) bindInvoiceNumberReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "reserve_number"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	var req usecases.BindInvoiceNumberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reservation, err := s.invoiceNumberReservationUseCase.BindReservation(c.Request.Context(), GetTenantID(c), userID, reservationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice number bound successfully",
+		"data":    reservation,
+	})
+}
+
+// voidInvoiceNumberReservation handles voiding a reserved invoice number
+func (s *Server) voidInvoiceNumberReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "reserve_number"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	var req usecases.VoidInvoiceNumberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reservation, err := s.invoiceNumberReservationUseCase.VoidReservation(c.Request.Context(), GetTenantID(c), userID, reservationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice number voided successfully",
+		"data":    reservation,
+	})
+}
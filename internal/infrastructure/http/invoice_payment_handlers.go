@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// recordInvoicePayment handles recording a full or partial payment against an invoice
+func (s *Server) recordInvoicePayment(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req usecases.RecordInvoicePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	invoice, err := s.invoiceUseCase.RecordInvoicePayment(c.Request.Context(), userID, invoiceID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invoice payment recorded successfully",
+		"data":    invoice,
+	})
+}
+
+// listInvoicePayments handles listing the payments recorded against an invoice
+func (s *Server) listInvoicePayments(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	payments, err := s.invoiceUseCase.ListInvoicePayments(c.Request.Context(), invoiceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": payments,
+	})
+}
+
+// setInvoicePaymentSchedule handles splitting an invoice's total into installments
+func (s *Server) setInvoicePaymentSchedule(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req usecases.SetInvoicePaymentScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	invoice, err := s.invoiceUseCase.SetInvoicePaymentSchedule(c.Request.Context(), userID, invoiceID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice payment schedule updated successfully",
+		"data":    invoice,
+	})
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// downloadPortalInvoicePDF handles invoice downloads from the signed links emailed to
+// customers. The link's signature authenticates the request rather than a user session.
+func (s *Server) downloadPortalInvoicePDF(c *gin.Context) {
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req usecases.InvoiceDownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid query parameters", err.Error()))
+		return
+	}
+
+	invoice, data, err := s.invoicePortalUseCase.DownloadInvoicePDF(c.Request.Context(), invoiceID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("invoice_%s.pdf", invoice.InvoiceNumber)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
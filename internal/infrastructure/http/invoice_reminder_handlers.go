@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getInvoiceReminders handles listing the payment reminders emailed for an invoice
+func (s *Server) getInvoiceReminders(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	reminders, err := s.invoiceReminderUseCase.GetReminders(c.Request.Context(), GetTenantID(c), invoiceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reminders,
+	})
+}
@@ -0,0 +1,216 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// createInvoiceShareLink handles creating a public link to an invoice. The link's URL is only
+// returned in this response.
+func (s *Server) createInvoiceShareLink(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req usecases.CreateInvoiceShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	link, err := s.invoiceShareUseCase.CreateShareLink(c.Request.Context(), GetTenantID(c), userID, invoiceID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invoice share link created successfully",
+		"data":    link,
+	})
+}
+
+// listInvoiceShareLinks handles listing the share links of an invoice
+func (s *Server) listInvoiceShareLinks(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	links, err := s.invoiceShareUseCase.ListShareLinks(c.Request.Context(), GetTenantID(c), invoiceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": links,
+	})
+}
+
+// updateInvoiceShareLink handles extending or shortening a share link's expiry
+func (s *Server) updateInvoiceShareLink(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, linkID, err := parseInvoiceShareLinkIDs(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateInvoiceShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	link, err := s.invoiceShareUseCase.UpdateShareLink(c.Request.Context(), GetTenantID(c), userID, invoiceID, linkID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice share link updated successfully",
+		"data":    link,
+	})
+}
+
+// revokeInvoiceShareLink handles permanently disabling a share link
+func (s *Server) revokeInvoiceShareLink(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, linkID, err := parseInvoiceShareLinkIDs(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.invoiceShareUseCase.RevokeShareLink(c.Request.Context(), GetTenantID(c), userID, invoiceID, linkID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice share link revoked successfully",
+	})
+}
+
+// viewPublicInvoice handles the HTML page an invoice share link opens. The link's token
+// authenticates the request rather than a user session.
+func (s *Server) viewPublicInvoice(c *gin.Context) {
+	page, err := s.invoiceShareUseCase.ViewInvoice(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	setPublicInvoiceHeaders(c)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// downloadPublicInvoicePDF handles downloading the PDF of the invoice a share link opens
+func (s *Server) downloadPublicInvoicePDF(c *gin.Context) {
+	invoice, data, err := s.invoiceShareUseCase.DownloadInvoicePDF(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("invoice_%s.pdf", invoice.InvoiceNumber)
+	setPublicInvoiceHeaders(c)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// payPublicInvoice handles paying the invoice a share link opens. The pay now form of the
+// invoice page is redirected to the gateway's payment page; API clients get the transaction,
+// with the client secret to confirm Stripe payments with.
+func (s *Server) payPublicInvoice(c *gin.Context) {
+	var req usecases.PayInvoiceShareLinkRequest
+	if err := c.ShouldBind(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	transaction, err := s.invoiceShareUseCase.PayInvoice(c.Request.Context(), c.Param("token"), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	setPublicInvoiceHeaders(c)
+	if c.ContentType() == "application/x-www-form-urlencoded" && transaction.RedirectURL != "" {
+		c.Redirect(http.StatusSeeOther, transaction.RedirectURL)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Payment intent created successfully",
+		"data":    transaction,
+	})
+}
+
+// setPublicInvoiceHeaders keeps share link pages out of caches, and their tokens out of the
+// referrer sent to other sites
+func setPublicInvoiceHeaders(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+}
+
+// parseInvoiceShareLinkIDs parses the invoice and share link IDs of the request path
+func parseInvoiceShareLinkIDs(c *gin.Context) (uuid.UUID, uuid.UUID, error) {
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.NewValidationError("invalid invoice ID", err.Error())
+	}
+	linkID, err := uuid.Parse(c.Param("link_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.NewValidationError("invalid share link ID", err.Error())
+	}
+	return invoiceID, linkID, nil
+}
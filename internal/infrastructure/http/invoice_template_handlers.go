@@ -0,0 +1,342 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getInvoiceTemplates handles listing the tenant's invoice templates
+func (s *Server) getInvoiceTemplates(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	paperSize := entities.PaperSize(c.Query("paper_size"))
+	templates, err := s.invoiceTemplateUseCase.ListTemplates(c.Request.Context(), GetTenantID(c), paperSize)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice templates retrieved successfully",
+		"data":    templates,
+	})
+}
+
+// createInvoiceTemplate handles creating an invoice template
+func (s *Server) createInvoiceTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SaveInvoiceTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	template, err := s.invoiceTemplateUseCase.CreateTemplate(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invoice template created successfully",
+		"data":    template,
+	})
+}
+
+// getInvoiceTemplate handles getting an invoice template
+func (s *Server) getInvoiceTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	template, err := s.invoiceTemplateUseCase.GetTemplate(c.Request.Context(), GetTenantID(c), id)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice template retrieved successfully",
+		"data":    template,
+	})
+}
+
+// updateInvoiceTemplate handles replacing an invoice template's name and design
+func (s *Server) updateInvoiceTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SaveInvoiceTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	template, err := s.invoiceTemplateUseCase.UpdateTemplate(c.Request.Context(), GetTenantID(c), userID, id, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice template updated successfully",
+		"data":    template,
+	})
+}
+
+// deleteInvoiceTemplate handles deleting an invoice template
+func (s *Server) deleteInvoiceTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.invoiceTemplateUseCase.DeleteTemplate(c.Request.Context(), GetTenantID(c), userID, id); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice template deleted successfully",
+	})
+}
+
+// setDefaultInvoiceTemplate handles making an invoice template the tenant's default
+func (s *Server) setDefaultInvoiceTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	template, err := s.invoiceTemplateUseCase.SetDefaultTemplate(c.Request.Context(), GetTenantID(c), userID, id)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Default invoice template set successfully",
+		"data":    template,
+	})
+}
+
+// uploadInvoiceTemplateLogo handles replacing an invoice template's logo. The image is sent as
+// a multipart "file" field or as the raw request body.
+func (s *Server) uploadInvoiceTemplateLogo(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var file io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("file is required", err.Error()))
+			return
+		}
+		upload, err := header.Open()
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid file", err.Error()))
+			return
+		}
+		defer upload.Close()
+		file = upload
+	}
+
+	// Read one byte past the limit so an oversized logo is rejected rather than truncated
+	data, err := io.ReadAll(io.LimitReader(file, entities.MaxInvoiceLogoSize+1))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid file", err.Error()))
+		return
+	}
+
+	template, err := s.invoiceTemplateUseCase.UploadLogo(c.Request.Context(), GetTenantID(c), userID, id, data)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice template logo uploaded successfully",
+		"data":    template,
+	})
+}
+
+// getInvoiceTemplateLogo handles downloading an invoice template's logo
+func (s *Server) getInvoiceTemplateLogo(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	logo, contentType, err := s.invoiceTemplateUseCase.GetLogo(c.Request.Context(), GetTenantID(c), id)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, logo)
+}
+
+// deleteInvoiceTemplateLogo handles removing an invoice template's logo
+func (s *Server) deleteInvoiceTemplateLogo(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	template, err := s.invoiceTemplateUseCase.DeleteLogo(c.Request.Context(), GetTenantID(c), userID, id)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invoice template logo deleted successfully",
+		"data":    template,
+	})
+}
+
+// previewInvoiceTemplate handles rendering a sample invoice PDF with a stored template
+func (s *Server) previewInvoiceTemplate(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid template ID", err.Error()))
+		return
+	}
+
+	pdfData, err := s.invoiceTemplateUseCase.PreviewTemplate(c.Request.Context(), GetTenantID(c), id)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="invoice_template_preview.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdfData)
+}
+
+// previewInvoiceTemplateDesign handles rendering a sample invoice PDF with a design that has
+// not been saved
+func (s *Server) previewInvoiceTemplateDesign(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var design entities.InvoiceTemplate
+	if err := c.ShouldBindJSON(&design); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	pdfData, err := s.invoiceTemplateUseCase.PreviewDesign(c.Request.Context(), GetTenantID(c), design)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="invoice_template_preview.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdfData)
+}
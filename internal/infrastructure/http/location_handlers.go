@@ -0,0 +1,219 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listLocations handles listing the tenant's locations
+func (s *Server) listLocations(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	includeInactive := c.Query("include_inactive") == "true"
+
+	locations, err := s.locationUseCase.ListLocations(c.Request.Context(), includeInactive)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": locations,
+	})
+}
+
+// createLocation handles creating a location
+func (s *Server) createLocation(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	location, err := s.locationUseCase.CreateLocation(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Location created successfully",
+		"data":    location,
+	})
+}
+
+// getLocation handles retrieving a location
+func (s *Server) getLocation(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	locationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid location ID", err.Error()))
+		return
+	}
+
+	location, err := s.locationUseCase.GetLocation(c.Request.Context(), GetTenantID(c), locationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": location,
+	})
+}
+
+// updateLocation handles updating a location
+func (s *Server) updateLocation(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	locationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid location ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	location, err := s.locationUseCase.UpdateLocation(c.Request.Context(), GetTenantID(c), userID, locationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Location updated successfully",
+		"data":    location,
+	})
+}
+
+// listLocationStock handles listing the products held at a location
+func (s *Server) listLocationStock(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	locationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid location ID", err.Error()))
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	response, err := s.locationUseCase.ListLocationStock(c.Request.Context(), GetTenantID(c), locationID, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// setLocationStock handles recording how much of a product a location holds
+func (s *Server) setLocationStock(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	locationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid location ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SetLocationStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	stock, err := s.locationUseCase.SetLocationStock(c.Request.Context(), GetTenantID(c), userID, locationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Location stock updated successfully",
+		"data":    stock,
+	})
+}
+
+// getProductLocationStock handles retrieving how much of a product each location holds
+func (s *Server) getProductLocationStock(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+		return
+	}
+
+	stock, err := s.locationUseCase.GetProductLocationStock(c.Request.Context(), GetTenantID(c), productID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": stock,
+	})
+}
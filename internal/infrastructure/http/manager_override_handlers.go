@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// managerOverrideHeader carries the override token on the request performing a restricted action
+const managerOverrideHeader = "X-Manager-Override"
+
+// issueManagerOverride handles a manager entering their credentials on a cashier's terminal
+// to approve a single restricted action
+func (s *Server) issueManagerOverride(c *gin.Context) {
+	if err := s.checkPermission(c, "overrides", "request"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.IssueOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	override, err := s.managerOverrideUseCase.IssueOverride(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Manager override approved",
+		"data":    override,
+	})
+}
+
+// requireManagerOverride consumes the override token sent with a restricted action. Managers
+// and admins act on their own authority and need no token. It returns the approving manager,
+// if any, and responds with an error when the action is not authorized.
+func (s *Server) requireManagerOverride(c *gin.Context, action entities.OverrideAction, resourceID uuid.UUID) (*uuid.UUID, bool) {
+	role, err := s.getCurrentUserRole(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return nil, false
+	}
+	if role == entities.RoleAdmin || role == entities.RoleManager {
+		return nil, true
+	}
+
+	token := c.GetHeader(managerOverrideHeader)
+	if token == "" {
+		s.respondWithError(c, errors.NewForbiddenError("manager override required"))
+		return nil, false
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return nil, false
+	}
+
+	override, err := s.managerOverrideUseCase.ConsumeOverride(c.Request.Context(), GetTenantID(c), userID, token, action, resourceID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return nil, false
+	}
+
+	return &override.ApprovedBy, true
+}
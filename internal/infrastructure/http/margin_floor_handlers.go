@@ -0,0 +1,155 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listMarginFloors handles listing the tenant's per-category margin floors
+func (s *Server) listMarginFloors(c *gin.Context) {
+	if err := s.checkPermission(c, "margin_floors", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	floors, err := s.marginFloorUseCase.ListFloors(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": floors,
+	})
+}
+
+// setMarginFloor handles creating or updating the margin floor of a category
+func (s *Server) setMarginFloor(c *gin.Context) {
+	if err := s.checkPermission(c, "margin_floors", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SetMarginFloorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	floor, err := s.marginFloorUseCase.SetFloor(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Margin floor saved successfully",
+		"data":    floor,
+	})
+}
+
+// deleteMarginFloor handles removing the margin floor of a category
+func (s *Server) deleteMarginFloor(c *gin.Context) {
+	if err := s.checkPermission(c, "margin_floors", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.marginFloorUseCase.DeleteFloor(c.Request.Context(), GetTenantID(c), userID, c.Param("category")); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Margin floor deleted successfully",
+	})
+}
+
+// getMarginAlertSettings handles retrieving who is alerted about margin floor violations
+func (s *Server) getMarginAlertSettings(c *gin.Context) {
+	if err := s.checkPermission(c, "margin_floors", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	setting, err := s.marginFloorUseCase.GetAlertSettings(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": setting,
+	})
+}
+
+// updateMarginAlertSettings handles changing margin alert recipients and the weekly report opt-in
+func (s *Server) updateMarginAlertSettings(c *gin.Context) {
+	if err := s.checkPermission(c, "margin_floors", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateMarginAlertSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	setting, err := s.marginFloorUseCase.UpdateAlertSettings(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Margin alert settings updated successfully",
+		"data":    setting,
+	})
+}
+
+// getMarginViolationReport handles the report of sales and prices below their margin floor
+func (s *Server) getMarginViolationReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	report, err := s.marginFloorUseCase.GetViolationReport(c.Request.Context(), GetTenantID(c), fromDate, toDate)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
@@ -1,12 +1,14 @@
 package http
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/nicklaros/adol/internal/application/usecases"
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/pkg/errors"
 )
@@ -14,6 +16,12 @@ import (
 // AuthMiddleware provides authentication middleware
 func (s *Server) authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// External systems authenticate with an API key instead of a user session
+		if apiKey := c.GetHeader(apiKeyHeader); apiKey != "" {
+			s.authenticateAPIKey(c, apiKey)
+			return
+		}
+
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -32,9 +40,7 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 
 		token := bearerToken[1]
 
-		// TODO: Validate token using auth service
-		// For now, we'll create a mock validation
-		userID, err := s.validateToken(token)
+		user, err := s.validateToken(c.Request.Context(), token)
 		if err != nil {
 			s.respondWithError(c, err)
 			c.Abort()
@@ -42,13 +48,33 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 		}
 
 		// Set user ID in context
-		c.Set("user_id", userID)
+		c.Set("user_id", user.ID)
 		c.Set("token", token)
 
+		// Data access is restricted to the tenant the user belongs to
+		setTenantScope(c, user.TenantID)
+
 		c.Next()
 	}
 }
 
+// authenticateAPIKey authenticates a request made with an API key. The request acts as the user
+// who created the key, in the key's tenant, limited to the key's scopes.
+func (s *Server) authenticateAPIKey(c *gin.Context, apiKey string) {
+	principal, err := s.apiKeyUseCase.Authenticate(c.Request.Context(), apiKey, c.ClientIP())
+	if err != nil {
+		s.respondWithError(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", principal.CreatedBy)
+	c.Set(apiKeyPrincipalKey, principal)
+	setTenantScope(c, principal.TenantID)
+
+	c.Next()
+}
+
 // PermissionMiddleware checks if user has required permission
 func (s *Server) permissionMiddleware(resource, action string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -77,6 +103,13 @@ func (s *Server) adminOnlyMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// API keys never carry admin rights
+		if getAPIKeyPrincipal(c) != nil {
+			s.respondWithError(c, errors.NewForbiddenError("API keys cannot access admin endpoints"))
+			c.Abort()
+			return
+		}
+
 		// TODO: Check if user is admin using auth service
 		// For now, we'll allow all authenticated users
 		_ = userID
@@ -85,14 +118,20 @@ func (s *Server) adminOnlyMiddleware() gin.HandlerFunc {
 	}
 }
 
-// validateToken validates JWT token and returns user ID
-// TODO: This is a mock implementation, replace with actual JWT validation
-func (s *Server) validateToken(token string) (uuid.UUID, error) {
-	// Mock implementation - in real scenario, validate JWT token
-	if token == "mock-token" {
-		return uuid.New(), nil
-	}
-	return uuid.Nil, errors.NewUnauthorizedError("invalid token")
+// validateToken validates JWT token and returns its user. Tokens of revoked sessions are rejected.
+func (s *Server) validateToken(ctx context.Context, token string) (*entities.User, error) {
+	return s.authUseCase.ValidateToken(ctx, token)
+}
+
+// setTenantScope sets the tenant an authenticated request acts in, restricting the data access
+// of the request to that tenant
+func setTenantScope(c *gin.Context, tenantID uuid.UUID) {
+	tenantContext := &entities.TenantContext{TenantID: tenantID}
+	c.Set(string(TenantContextKeyValue), tenantContext)
+
+	ctx := context.WithValue(c.Request.Context(), TenantContextKeyValue, tenantContext)
+	ctx = entities.WithTenantScope(ctx, tenantID)
+	c.Request = c.Request.WithContext(ctx)
 }
 
 // getCurrentUser gets current user from context
@@ -110,6 +149,31 @@ func (s *Server) getCurrentUser(c *gin.Context) (uuid.UUID, error) {
 	return userID, nil
 }
 
+// getCurrentToken gets the access token the request was authenticated with
+func (s *Server) getCurrentToken(c *gin.Context) (string, error) {
+	token, exists := c.Get("token")
+	if !exists {
+		return "", errors.NewUnauthorizedError("token not found")
+	}
+
+	tokenStr, ok := token.(string)
+	if !ok {
+		return "", errors.NewInternalError("invalid token format", nil)
+	}
+
+	return tokenStr, nil
+}
+
+// getAPIKeyPrincipal gets the API key the request was authenticated with, if any
+func getAPIKeyPrincipal(c *gin.Context) *usecases.APIKeyPrincipal {
+	if principal, exists := c.Get(apiKeyPrincipalKey); exists {
+		if p, ok := principal.(*usecases.APIKeyPrincipal); ok {
+			return p
+		}
+	}
+	return nil
+}
+
 // getCurrentUserRole gets current user role from context
 // TODO: This is a mock implementation
 func (s *Server) getCurrentUserRole(c *gin.Context) (entities.UserRole, error) {
@@ -119,6 +183,14 @@ func (s *Server) getCurrentUserRole(c *gin.Context) (entities.UserRole, error) {
 
 // checkPermission checks if current user has required permission
 func (s *Server) checkPermission(c *gin.Context, resource, action string) error {
+	// API keys are limited to their scopes, whatever the role of the user who created them
+	if principal := getAPIKeyPrincipal(c); principal != nil {
+		if principal.Allows(resource, action) {
+			return nil
+		}
+		return errors.NewForbiddenError("API key is not scoped for " + resource + ":" + action)
+	}
+
 	userRole, err := s.getCurrentUserRole(c)
 	if err != nil {
 		return err
@@ -130,19 +202,28 @@ func (s *Server) checkPermission(c *gin.Context, resource, action string) error
 		return nil
 	}
 
-	// Cashier can only process sales and view products
+	// Every staff member clocks themselves in and out
+	if resource == "time_clock" && action == "clock" {
+		return nil
+	}
+
+	// Cashier can only process sales, validate coupons, run their register, view products, count
+	// stock, capture invoice signatures and request manager overrides
 	if userRole == entities.RoleCashier {
 		if (resource == "sales" && (action == "create" || action == "read" || action == "update")) ||
+			(resource == "coupons" && action == "validate") ||
+			(resource == "registers" && (action == "operate" || action == "read")) ||
+			(resource == "overrides" && action == "request") ||
 			(resource == "products" && action == "read") ||
-			(resource == "stock" && action == "read") ||
-			(resource == "invoices" && (action == "create" || action == "read")) {
+			(resource == "stock" && (action == "read" || action == "count")) ||
+			(resource == "invoices" && (action == "create" || action == "read" || action == "sign")) {
 			return nil
 		}
 	}
 
-	// Employee has read-only access
+	// Employee has read-only access, and counts stock in stocktakes
 	if userRole == entities.RoleEmployee {
-		if action == "read" {
+		if action == "read" || (resource == "stock" && action == "count") {
 			return nil
 		}
 	}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// paymentWebhookBodyLimit caps the size of a payment gateway notification body
+const paymentWebhookBodyLimit = 1 << 20
+
+// receivePaymentWebhook handles payment notifications sent by Stripe and Midtrans. Stripe signs
+// the body in the Stripe-Signature header; Midtrans signs the notification in its body. A
+// confirmed payment completes its sale or pays its invoice; other errors make the gateway retry
+// the notification.
+func (s *Server) receivePaymentWebhook(c *gin.Context) {
+	provider := entities.PaymentGatewayProvider(c.Param("provider"))
+	if !provider.IsValid() {
+		s.respondWithError(c, errors.NewNotFoundError("payment gateway"))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, paymentWebhookBodyLimit))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	transaction, err := s.paymentGatewayUseCase.HandleWebhook(c.Request.Context(), provider, c.GetHeader("Stripe-Signature"), body)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook processed successfully",
+		"data":    transaction,
+	})
+}
+
+// createSalePaymentIntent handles starting a card or digital wallet payment of a pending sale
+func (s *Server) createSalePaymentIntent(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.CreateSalePaymentIntentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	transaction, err := s.paymentGatewayUseCase.CreateSalePaymentIntent(c.Request.Context(), GetTenantID(c), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Payment intent created successfully",
+		"data":    transaction,
+	})
+}
+
+// createInvoicePaymentIntent handles starting a card or digital wallet payment of an invoice
+func (s *Server) createInvoicePaymentIntent(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req usecases.CreateInvoicePaymentIntentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	transaction, err := s.paymentGatewayUseCase.CreateInvoicePaymentIntent(c.Request.Context(), GetTenantID(c), userID, invoiceID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Payment intent created successfully",
+		"data":    transaction,
+	})
+}
+
+// listPaymentTransactions handles listing payment gateway transactions
+func (s *Server) listPaymentTransactions(c *gin.Context) {
+	if err := s.checkPermission(c, "payment_transactions", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	var filter repositories.PaymentTransactionFilter
+	if value := c.Query("sale_id"); value != "" {
+		saleID, err := uuid.Parse(value)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+			return
+		}
+		filter.SaleID = &saleID
+	}
+	if value := c.Query("invoice_id"); value != "" {
+		invoiceID, err := uuid.Parse(value)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+			return
+		}
+		filter.InvoiceID = &invoiceID
+	}
+	if value := c.Query("provider"); value != "" {
+		provider := entities.PaymentGatewayProvider(value)
+		if !provider.IsValid() {
+			s.respondWithError(c, errors.NewValidationError("invalid payment gateway", "provider must be one of: stripe, midtrans"))
+			return
+		}
+		filter.Provider = &provider
+	}
+	if value := c.Query("status"); value != "" {
+		status := entities.PaymentTransactionStatus(value)
+		if !status.IsValid() {
+			s.respondWithError(c, errors.NewValidationError("invalid payment status", "status must be one of: pending, succeeded, failed"))
+			return
+		}
+		filter.Status = &status
+	}
+
+	response, err := s.paymentGatewayUseCase.ListTransactions(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// getPaymentTransaction handles retrieving a payment gateway transaction
+func (s *Server) getPaymentTransaction(c *gin.Context) {
+	if err := s.checkPermission(c, "payment_transactions", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid payment transaction ID", err.Error()))
+		return
+	}
+
+	transaction, err := s.paymentGatewayUseCase.GetTransaction(c.Request.Context(), GetTenantID(c), transactionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": transaction,
+	})
+}
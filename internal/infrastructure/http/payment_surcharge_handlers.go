@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listPaymentSurcharges handles listing the tenant's payment surcharge rules
+func (s *Server) listPaymentSurcharges(c *gin.Context) {
+	if err := s.checkPermission(c, "payment_surcharges", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	rules, err := s.paymentSurchargeUseCase.ListSurchargeRules(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rules,
+	})
+}
+
+// createPaymentSurcharge handles creating a payment surcharge rule
+func (s *Server) createPaymentSurcharge(c *gin.Context) {
+	if err := s.checkPermission(c, "payment_surcharges", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreatePaymentSurchargeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	rule, err := s.paymentSurchargeUseCase.CreateSurchargeRule(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Payment surcharge created successfully",
+		"data":    rule,
+	})
+}
+
+// updatePaymentSurcharge handles updating a payment surcharge rule
+func (s *Server) updatePaymentSurcharge(c *gin.Context) {
+	if err := s.checkPermission(c, "payment_surcharges", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid payment surcharge ID", err.Error()))
+		return
+	}
+
+	var req usecases.UpdatePaymentSurchargeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	rule, err := s.paymentSurchargeUseCase.UpdateSurchargeRule(c.Request.Context(), GetTenantID(c), userID, ruleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment surcharge updated successfully",
+		"data":    rule,
+	})
+}
+
+// deletePaymentSurcharge handles deleting a payment surcharge rule
+func (s *Server) deletePaymentSurcharge(c *gin.Context) {
+	if err := s.checkPermission(c, "payment_surcharges", "delete"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid payment surcharge ID", err.Error()))
+		return
+	}
+
+	if err := s.paymentSurchargeUseCase.DeleteSurchargeRule(c.Request.Context(), GetTenantID(c), userID, ruleID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment surcharge deleted successfully",
+	})
+}
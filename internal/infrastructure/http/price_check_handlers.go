@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// kioskDeviceTokenHeader carries the device token sent by price-checker kiosks
+const kioskDeviceTokenHeader = "X-Device-Token"
+
+// priceCheck handles barcode price lookups from customer-facing price-checker kiosks.
+// Kiosks authenticate with a device token rather than a user session.
+func (s *Server) priceCheck(c *gin.Context) {
+	tenantID, err := s.priceCheckUseCase.AuthenticateDevice(c.Request.Context(), c.GetHeader(kioskDeviceTokenHeader))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	result, err := s.priceCheckUseCase.CheckPrice(c.Request.Context(), tenantID, c.Query("barcode"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// listKioskDevices handles listing the tenant's registered kiosk devices
+func (s *Server) listKioskDevices(c *gin.Context) {
+	if err := s.checkPermission(c, "kiosk_devices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	devices, err := s.priceCheckUseCase.ListDevices(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": devices,
+	})
+}
+
+// registerKioskDevice handles registering a new kiosk device
+func (s *Server) registerKioskDevice(c *gin.Context) {
+	if err := s.checkPermission(c, "kiosk_devices", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.RegisterKioskDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	result, err := s.priceCheckUseCase.RegisterDevice(c.Request.Context(), GetTenantID(c), req, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Kiosk device registered successfully",
+		"data":    result,
+	})
+}
+
+// revokeKioskDevice handles revoking a kiosk device
+func (s *Server) revokeKioskDevice(c *gin.Context) {
+	if err := s.checkPermission(c, "kiosk_devices", "delete"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid device ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	device, err := s.priceCheckUseCase.RevokeDevice(c.Request.Context(), GetTenantID(c), deviceID, userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Kiosk device revoked successfully",
+		"data":    device,
+	})
+}
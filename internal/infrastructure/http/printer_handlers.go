@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listPrinters handles listing the tenant's registered printers, optionally those a terminal
+// can print to
+func (s *Server) listPrinters(c *gin.Context) {
+	if err := s.checkPermission(c, "printers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	printers, err := s.printerUseCase.ListPrinters(c.Request.Context(), GetTenantID(c), c.Query("terminal"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": printers,
+	})
+}
+
+// registerPrinter handles registering a printer
+func (s *Server) registerPrinter(c *gin.Context) {
+	if err := s.checkPermission(c, "printers", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.RegisterPrinterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	printer, err := s.printerUseCase.RegisterPrinter(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Printer registered successfully",
+		"data":    printer,
+	})
+}
+
+// getPrinter handles retrieving a registered printer
+func (s *Server) getPrinter(c *gin.Context) {
+	if err := s.checkPermission(c, "printers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	printerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid printer ID", err.Error()))
+		return
+	}
+
+	printer, err := s.printerUseCase.GetPrinter(c.Request.Context(), GetTenantID(c), printerID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": printer,
+	})
+}
+
+// deletePrinter handles removing a registered printer
+func (s *Server) deletePrinter(c *gin.Context) {
+	if err := s.checkPermission(c, "printers", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	printerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid printer ID", err.Error()))
+		return
+	}
+
+	if err := s.printerUseCase.DeletePrinter(c.Request.Context(), GetTenantID(c), userID, printerID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Printer deleted successfully",
+	})
+}
+
+// testPrinter handles printing a test page to a registered printer
+func (s *Server) testPrinter(c *gin.Context) {
+	if err := s.checkPermission(c, "printers", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	printerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid printer ID", err.Error()))
+		return
+	}
+
+	printer, err := s.printerUseCase.TestPrint(c.Request.Context(), GetTenantID(c), userID, printerID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Test page sent",
+		"data":    printer,
+	})
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listProducts handles listing products with their stock. Passing order_by=stock_status
+// lists out of stock products first.
+func (s *Server) listProducts(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filter, err := productFilterFromQuery(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	response, err := s.productUseCase.ListProducts(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// productFilterFromQuery parses the product list filters from the query string
+func productFilterFromQuery(c *gin.Context) (repositories.ProductFilter, error) {
+	filter := repositories.ProductFilter{
+		Category: c.Query("category"),
+		Search:   c.Query("search"),
+		OrderBy:  c.Query("order_by"),
+		OrderDir: c.Query("order_dir"),
+	}
+	if status := c.Query("status"); status != "" {
+		value := entities.ProductStatus(status)
+		filter.Status = &value
+	}
+	if publishState := c.Query("publish_state"); publishState != "" {
+		value := entities.ProductPublishState(publishState)
+		filter.PublishState = &value
+	}
+
+	var err error
+	if filter.SupplierID, err = queryUUID(c, "supplier_id"); err != nil {
+		return filter, err
+	}
+	if filter.CategoryID, err = queryUUID(c, "category_id"); err != nil {
+		return filter, err
+	}
+	if filter.MinPrice, err = queryFloat(c, "min_price"); err != nil {
+		return filter, err
+	}
+	if filter.MaxPrice, err = queryFloat(c, "max_price"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
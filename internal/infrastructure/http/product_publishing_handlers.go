@@ -0,0 +1,168 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listProductReviewQueue handles listing products waiting for review
+func (s *Server) listProductReviewQueue(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	response, err := s.productUseCase.ListReviewQueue(c.Request.Context(), pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// submitProductForReview handles submitting a draft product for review
+func (s *Server) submitProductForReview(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, productID, ok := s.productPublishingParams(c)
+	if !ok {
+		return
+	}
+
+	product, err := s.productUseCase.SubmitProductForReview(c.Request.Context(), GetTenantID(c), userID, productID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product submitted for review",
+		"data":    product,
+	})
+}
+
+// approveProduct handles approving a product under review
+func (s *Server) approveProduct(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "approve"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, productID, ok := s.productPublishingParams(c)
+	if !ok {
+		return
+	}
+
+	var req usecases.ApproveProductRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	product, err := s.productUseCase.ApproveProduct(c.Request.Context(), GetTenantID(c), userID, productID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product approved",
+		"data":    product,
+	})
+}
+
+// rejectProduct handles sending a product under review back to draft
+func (s *Server) rejectProduct(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "approve"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, productID, ok := s.productPublishingParams(c)
+	if !ok {
+		return
+	}
+
+	var req usecases.RejectProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	product, err := s.productUseCase.RejectProduct(c.Request.Context(), GetTenantID(c), userID, productID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product rejected",
+		"data":    product,
+	})
+}
+
+// unpublishProduct handles moving a published product back to draft
+func (s *Server) unpublishProduct(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, productID, ok := s.productPublishingParams(c)
+	if !ok {
+		return
+	}
+
+	product, err := s.productUseCase.UnpublishProduct(c.Request.Context(), GetTenantID(c), userID, productID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product unpublished",
+		"data":    product,
+	})
+}
+
+// productPublishingParams reads the current user and product ID, responding with an error
+// when either is missing or invalid
+func (s *Server) productPublishingParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, productID, true
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listProductUnits handles listing the unit catalog, named in the requested language
+func (s *Server) listProductUnits(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	units, err := s.productUnitUseCase.ListUnits(c.Request.Context(), GetTenantID(c), requestLanguage(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": units,
+	})
+}
+
+// getProductUnit handles retrieving a unit of the catalog
+func (s *Server) getProductUnit(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	unit, err := s.productUnitUseCase.GetUnit(c.Request.Context(), GetTenantID(c), c.Param("code"), requestLanguage(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": unit,
+	})
+}
+
+// createProductUnit handles adding a unit to the tenant's catalog
+func (s *Server) createProductUnit(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "manage_units"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateProductUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	unit, err := s.productUnitUseCase.CreateUnit(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Unit created successfully",
+		"data":    unit,
+	})
+}
+
+// updateProductUnit handles updating one of the tenant's units
+func (s *Server) updateProductUnit(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "manage_units"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateProductUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	unit, err := s.productUnitUseCase.UpdateUnit(c.Request.Context(), GetTenantID(c), userID, c.Param("code"), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Unit updated successfully",
+		"data":    unit,
+	})
+}
+
+// deleteProductUnit handles removing one of the tenant's units
+func (s *Server) deleteProductUnit(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "manage_units"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.productUnitUseCase.DeleteUnit(c.Request.Context(), GetTenantID(c), userID, c.Param("code")); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Unit deleted successfully",
+	})
+}
+
+// requestLanguage returns the language requested by the lang query parameter or else the
+// first language of the Accept-Language header, e.g. "id-ID"
+func requestLanguage(c *gin.Context) string {
+	if language := c.Query("lang"); language != "" {
+		return language
+	}
+
+	language, _, _ := strings.Cut(c.GetHeader("Accept-Language"), ",")
+	language, _, _ = strings.Cut(language, ";")
+	return strings.TrimSpace(language)
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/infrastructure/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/monitoring"
+)
+
+// prometheusMetrics serves the collected metrics, per-tenant counters from the tenant monitor
+// and database pool stats in the Prometheus text exposition format
+func (s *Server) prometheusMetrics(c *gin.Context) {
+	if token := s.config.Metrics.Token; token != "" {
+		sent := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			s.respondWithError(c, errors.NewUnauthorizedError("invalid metrics token"))
+			return
+		}
+	}
+
+	families := s.tenantMetricFamilies(c.Request.Context())
+	families = append(families, s.databasePoolMetricFamilies()...)
+
+	var buf bytes.Buffer
+	if err := s.metrics.WritePrometheus(&buf, families...); err != nil {
+		s.respondWithError(c, errors.NewInternalError("failed to write metrics", err))
+		return
+	}
+
+	c.Data(http.StatusOK, monitoring.PrometheusContentType, buf.Bytes())
+}
+
+// observeRequestLatency records a request's latency by method, route and status. Routes are
+// the registered path patterns, so IDs in URLs do not each make a series of their own.
+func (s *Server) observeRequestLatency(c *gin.Context, seconds float64) {
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+
+	s.metrics.Histogram("http_request_duration_seconds", map[string]string{
+		"method": c.Request.Method,
+		"route":  route,
+		"status": strconv.Itoa(c.Writer.Status()),
+	}, monitoring.DefaultLatencyBuckets).Observe(seconds)
+}
+
+// recordStockAlertMetrics recounts the low and out of stock products of every tenant into
+// gauges. It is run periodically while Prometheus metrics are enabled.
+func (s *Server) recordStockAlertMetrics(ctx context.Context) error {
+	counts, err := repositories.NewPostgreSQLStockRepository(s.db).GetStockAlertCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		labels := map[string]string{"tenant_id": count.TenantID.String()}
+		s.metrics.Gauge("stock_low_products", labels).Set(float64(count.LowStockCount))
+		s.metrics.Gauge("stock_out_of_stock_products", labels).Set(float64(count.OutOfStockCount))
+	}
+
+	return nil
+}
+
+// tenantMetricFamilies exports the request, usage and alert counters the tenant monitor holds
+func (s *Server) tenantMetricFamilies(ctx context.Context) []monitoring.Family {
+	requests := monitoring.Family{Name: "tenant_requests_total", Help: "Requests made by the tenant", Type: monitoring.CounterMetric}
+	requestErrors := monitoring.Family{Name: "tenant_request_errors_total", Help: "Requests of the tenant that failed with a server error", Type: monitoring.CounterMetric}
+	usage := monitoring.Family{Name: "tenant_resource_usage", Help: "Current usage of a resource by the tenant", Type: monitoring.GaugeMetric}
+	alerts := monitoring.Family{Name: "tenant_active_alerts", Help: "Active monitoring alerts of the tenant by severity", Type: monitoring.GaugeMetric}
+
+	for _, tenant := range s.tenantMonitor.ListTenantMetrics(ctx) {
+		tenantID := tenant.TenantID.String()
+		labels := map[string]string{"tenant_id": tenantID}
+		requests.Samples = append(requests.Samples, monitoring.Sample{Labels: labels, Value: float64(tenant.RequestCount)})
+		requestErrors.Samples = append(requestErrors.Samples, monitoring.Sample{Labels: labels, Value: float64(tenant.ErrorCount)})
+
+		for resource, amount := range tenant.Usage {
+			usage.Samples = append(usage.Samples, monitoring.Sample{
+				Labels: map[string]string{"tenant_id": tenantID, "resource": resource},
+				Value:  float64(amount),
+			})
+		}
+		for severity, count := range tenant.ActiveAlerts {
+			alerts.Samples = append(alerts.Samples, monitoring.Sample{
+				Labels: map[string]string{"tenant_id": tenantID, "severity": string(severity)},
+				Value:  float64(count),
+			})
+		}
+	}
+
+	return []monitoring.Family{requests, requestErrors, usage, alerts}
+}
+
+// databasePoolMetricFamilies exports the connection pool stats of the database handle
+func (s *Server) databasePoolMetricFamilies() []monitoring.Family {
+	stats := s.db.Stats()
+	gauge := func(name, help string, value float64) monitoring.Family {
+		return monitoring.Family{Name: name, Help: help, Type: monitoring.GaugeMetric, Samples: []monitoring.Sample{{Value: value}}}
+	}
+	counter := func(name, help string, value float64) monitoring.Family {
+		return monitoring.Family{Name: name, Help: help, Type: monitoring.CounterMetric, Samples: []monitoring.Sample{{Value: value}}}
+	}
+
+	return []monitoring.Family{
+		gauge("db_pool_max_open_connections", "Maximum number of open connections to the database", float64(stats.MaxOpenConnections)),
+		gauge("db_pool_open_connections", "Established connections, in use and idle", float64(stats.OpenConnections)),
+		gauge("db_pool_in_use_connections", "Connections currently in use", float64(stats.InUse)),
+		gauge("db_pool_idle_connections", "Idle connections", float64(stats.Idle)),
+		counter("db_pool_wait_count_total", "Connections waited for", float64(stats.WaitCount)),
+		counter("db_pool_wait_duration_seconds_total", "Time spent waiting for a connection", stats.WaitDuration.Seconds()),
+		counter("db_pool_max_idle_closed_total", "Connections closed because of the idle connection limit", float64(stats.MaxIdleClosed)),
+		counter("db_pool_max_lifetime_closed_total", "Connections closed because of their maximum lifetime", float64(stats.MaxLifetimeClosed)),
+	}
+}
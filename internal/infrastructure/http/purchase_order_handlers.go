@@ -0,0 +1,567 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listPurchaseOrders handles listing purchase orders with pagination and filtering
+func (s *Server) listPurchaseOrders(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.PurchaseOrderFilter{
+		Supplier: c.Query("supplier"),
+		OrderBy:  c.DefaultQuery("order_by", "created_at"),
+		OrderDir: c.DefaultQuery("order_dir", "DESC"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		orderStatus := entities.PurchaseOrderStatus(status)
+		if err := entities.ValidatePurchaseOrderStatus(orderStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &orderStatus
+	}
+
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+			return
+		}
+		filter.ProductID = &id
+	}
+
+	response, err := s.purchaseOrderUseCase.ListPurchaseOrders(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// createPurchaseOrder handles creating a draft purchase order
+func (s *Server) createPurchaseOrder(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	order, err := s.purchaseOrderUseCase.CreatePurchaseOrder(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Purchase order created successfully",
+		"data":    order,
+	})
+}
+
+// getPurchaseOrder handles retrieving a purchase order
+func (s *Server) getPurchaseOrder(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	order, err := s.purchaseOrderUseCase.GetPurchaseOrder(c.Request.Context(), GetTenantID(c), orderID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": order,
+	})
+}
+
+// updatePurchaseOrder handles updating a draft purchase order
+func (s *Server) updatePurchaseOrder(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	order, err := s.purchaseOrderUseCase.UpdatePurchaseOrder(c.Request.Context(), GetTenantID(c), userID, orderID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Purchase order updated successfully",
+		"data":    order,
+	})
+}
+
+// orderPurchaseOrder handles marking a draft purchase order as sent to the supplier
+func (s *Server) orderPurchaseOrder(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	order, err := s.purchaseOrderUseCase.OrderPurchaseOrder(c.Request.Context(), GetTenantID(c), userID, orderID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Purchase order ordered successfully",
+		"data":    order,
+	})
+}
+
+// receivePurchaseOrder handles receiving a delivery against a purchase order
+func (s *Server) receivePurchaseOrder(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "receive"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ReceivePurchaseOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	order, err := s.purchaseOrderUseCase.ReceivePurchaseOrder(c.Request.Context(), GetTenantID(c), userID, orderID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Purchase order received successfully",
+		"data":    order,
+	})
+}
+
+// cancelPurchaseOrder handles cancelling a purchase order
+func (s *Server) cancelPurchaseOrder(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	order, err := s.purchaseOrderUseCase.CancelPurchaseOrder(c.Request.Context(), GetTenantID(c), userID, orderID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Purchase order cancelled successfully",
+		"data":    order,
+	})
+}
+
+// getReorderSuggestions handles suggesting order quantities for low stock products
+func (s *Server) getReorderSuggestions(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	req, err := reorderSuggestionRequestFromQuery(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.purchaseOrderUseCase.GetReorderSuggestions(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// createReorderDrafts handles creating draft purchase orders from reorder suggestions
+func (s *Server) createReorderDrafts(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateReorderDraftsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.purchaseOrderUseCase.CreateReorderDrafts(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Reorder draft purchase orders created successfully",
+		"data":    response,
+	})
+}
+
+// listPurchaseOrderReceivings handles listing the receiving sessions of a purchase order
+func (s *Server) listPurchaseOrderReceivings(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	receivings, err := s.purchaseOrderUseCase.ListReceivings(c.Request.Context(), GetTenantID(c), orderID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": receivings,
+	})
+}
+
+// startPurchaseOrderReceiving handles starting a session receiving a purchase order by scanning
+func (s *Server) startPurchaseOrderReceiving(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "receive"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid purchase order ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.StartPurchaseOrderReceivingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	receiving, err := s.purchaseOrderUseCase.StartReceiving(c.Request.Context(), GetTenantID(c), userID, orderID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Receiving session started successfully",
+		"data":    receiving,
+	})
+}
+
+// getPurchaseOrderReceiving handles retrieving a receiving session with its scanned lines
+func (s *Server) getPurchaseOrderReceiving(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, receivingID, err := receivingParams(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	receiving, err := s.purchaseOrderUseCase.GetReceiving(c.Request.Context(), GetTenantID(c), orderID, receivingID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": receiving,
+	})
+}
+
+// submitPurchaseOrderScans handles a batch of barcodes scanned at a receiving station. Bad
+// scans are reported in their line's result rather than failing the batch.
+func (s *Server) submitPurchaseOrderScans(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "receive"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, receivingID, err := receivingParams(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SubmitPurchaseOrderScansRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.purchaseOrderUseCase.SubmitReceivingScans(c.Request.Context(), GetTenantID(c), userID, orderID, receivingID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// getPurchaseOrderReceivingDiscrepancies handles retrieving the items a receiving session
+// under- or over-received
+func (s *Server) getPurchaseOrderReceivingDiscrepancies(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, receivingID, err := receivingParams(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	discrepancies, err := s.purchaseOrderUseCase.GetReceivingDiscrepancies(c.Request.Context(), GetTenantID(c), orderID, receivingID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": discrepancies,
+	})
+}
+
+// completePurchaseOrderReceiving handles receiving the units scanned in a session into stock
+func (s *Server) completePurchaseOrderReceiving(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "receive"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, receivingID, err := receivingParams(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.purchaseOrderUseCase.CompleteReceiving(c.Request.Context(), GetTenantID(c), userID, orderID, receivingID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Receiving session completed successfully",
+		"data":    response,
+	})
+}
+
+// cancelPurchaseOrderReceiving handles abandoning a receiving session
+func (s *Server) cancelPurchaseOrderReceiving(c *gin.Context) {
+	if err := s.checkPermission(c, "purchase_orders", "receive"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	orderID, receivingID, err := receivingParams(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	receiving, err := s.purchaseOrderUseCase.CancelReceiving(c.Request.Context(), GetTenantID(c), userID, orderID, receivingID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Receiving session cancelled successfully",
+		"data":    receiving,
+	})
+}
+
+// receivingParams parses the purchase order and receiving session IDs of a receiving route
+func receivingParams(c *gin.Context) (uuid.UUID, uuid.UUID, error) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.NewValidationError("invalid purchase order ID", err.Error())
+	}
+	receivingID, err := uuid.Parse(c.Param("receiving_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.NewValidationError("invalid receiving session ID", err.Error())
+	}
+	return orderID, receivingID, nil
+}
+
+// reorderSuggestionRequestFromQuery parses the parameters of reorder suggestions
+func reorderSuggestionRequestFromQuery(c *gin.Context) (usecases.ReorderSuggestionRequest, error) {
+	var req usecases.ReorderSuggestionRequest
+
+	if salesDays := c.Query("sales_days"); salesDays != "" {
+		days, err := strconv.Atoi(salesDays)
+		if err != nil {
+			return req, errors.NewValidationError("invalid sales_days", "sales_days must be a number")
+		}
+		req.SalesDays = days
+	}
+	if coverDays := c.Query("cover_days"); coverDays != "" {
+		days, err := strconv.Atoi(coverDays)
+		if err != nil {
+			return req, errors.NewValidationError("invalid cover_days", "cover_days must be a number")
+		}
+		req.CoverDays = days
+	}
+
+	supplierID, err := queryUUID(c, "supplier_id")
+	if err != nil {
+		return req, err
+	}
+	req.SupplierID = supplierID
+
+	return req, nil
+}
@@ -0,0 +1,321 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listQuotes handles listing quotes with pagination and filtering
+func (s *Server) listQuotes(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.QuoteFilter{
+		Customer: c.Query("customer"),
+		OrderBy:  c.DefaultQuery("order_by", "created_at"),
+		OrderDir: c.DefaultQuery("order_dir", "DESC"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		quoteStatus := entities.QuoteStatus(status)
+		if err := entities.ValidateQuoteStatus(quoteStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &quoteStatus
+	}
+
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+			return
+		}
+		filter.ProductID = &id
+	}
+
+	response, err := s.quoteUseCase.ListQuotes(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// createQuote handles creating a draft quote
+func (s *Server) createQuote(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	quote, err := s.quoteUseCase.CreateQuote(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Quote created successfully",
+		"data":    quote,
+	})
+}
+
+// getQuote handles retrieving a quote
+func (s *Server) getQuote(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	quote, err := s.quoteUseCase.GetQuote(c.Request.Context(), GetTenantID(c), quoteID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": quote,
+	})
+}
+
+// updateQuote handles updating a draft quote
+func (s *Server) updateQuote(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	quote, err := s.quoteUseCase.UpdateQuote(c.Request.Context(), GetTenantID(c), userID, quoteID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quote updated successfully",
+		"data":    quote,
+	})
+}
+
+// downloadQuotePDF handles downloading a quote as a PDF
+func (s *Server) downloadQuotePDF(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	req := usecases.GenerateQuotePDFRequest{
+		QuoteID:   quoteID,
+		PaperSize: entities.PaperSize(c.Query("paper_size")),
+	}
+
+	quote, data, err := s.quoteUseCase.GenerateQuotePDF(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("quote_%s.pdf", quote.QuoteNumber)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// sendQuote handles emailing a quote to the customer
+func (s *Server) sendQuote(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SendQuoteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	quote, err := s.quoteUseCase.SendQuote(c.Request.Context(), GetTenantID(c), userID, quoteID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quote sent successfully",
+		"data":    quote,
+	})
+}
+
+// acceptQuote handles recording a customer's acceptance of a quote
+func (s *Server) acceptQuote(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quote, err := s.quoteUseCase.AcceptQuote(c.Request.Context(), GetTenantID(c), userID, quoteID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quote accepted successfully",
+		"data":    quote,
+	})
+}
+
+// rejectQuote handles recording that a customer turned a quote down
+func (s *Server) rejectQuote(c *gin.Context) {
+	if err := s.checkPermission(c, "quotes", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quote, err := s.quoteUseCase.RejectQuote(c.Request.Context(), GetTenantID(c), userID, quoteID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quote rejected successfully",
+		"data":    quote,
+	})
+}
+
+// convertQuoteToSale handles creating a pending sale from an accepted quote
+func (s *Server) convertQuoteToSale(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid quote ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sale, err := s.quoteUseCase.ConvertQuoteToSale(c.Request.Context(), GetTenantID(c), userID, quoteID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Quote converted to sale successfully",
+		"data":    sale,
+	})
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getRegisterXReport handles taking a mid-shift X-report of an open register session
+func (s *Server) getRegisterXReport(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.RegisterReportFormatRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid query parameters", err.Error()))
+		return
+	}
+
+	report, err := s.registerReportUseCase.GetXReport(c.Request.Context(), GetTenantID(c), userID, sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	s.respondWithRegisterReport(c, report, req.Format, "")
+}
+
+// generateRegisterZReport handles taking the closing Z-report of a closed register session,
+// or reprinting it once taken
+func (s *Server) generateRegisterZReport(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "operate"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.RegisterReportFormatRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid query parameters", err.Error()))
+		return
+	}
+
+	report, err := s.registerReportUseCase.GenerateZReport(c.Request.Context(), GetTenantID(c), userID, sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	s.respondWithRegisterReport(c, report, req.Format, "Z-report taken successfully")
+}
+
+// printRegisterReport handles printing the X- or Z-report of a register session to a
+// registered printer
+func (s *Server) printRegisterReport(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "operate"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.PrintRegisterReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	report, err := s.registerReportUseCase.PrintReport(c.Request.Context(), GetTenantID(c), userID, sessionID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Register report sent to printer",
+		"data":    report,
+	})
+}
+
+// respondWithRegisterReport writes a register report as JSON or, for the pdf and receipt
+// formats, as a PDF download
+func (s *Server) respondWithRegisterReport(c *gin.Context, report *entities.RegisterReport, format, message string) {
+	if format == "" || format == usecases.RegisterReportFormatJSON {
+		response := gin.H{"data": report}
+		if message != "" {
+			response["message"] = message
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	data, err := s.registerReportUseCase.RenderReport(c.Request.Context(), report, format)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-report-%s-%s.pdf", report.Type, report.Register, report.GeneratedAt.Format("20060102-150405"))
+	if report.Type == entities.RegisterReportTypeZ {
+		filename = fmt.Sprintf("z-report-%s-%d.pdf", report.Register, report.Number)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
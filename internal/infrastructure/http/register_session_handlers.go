@@ -0,0 +1,275 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listRegisterSessions handles listing register sessions
+func (s *Server) listRegisterSessions(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.RegisterSessionFilter{
+		TenantID: GetTenantID(c),
+		Register: c.Query("register"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		sessionStatus := entities.RegisterSessionStatus(status)
+		if err := entities.ValidateRegisterSessionStatus(sessionStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &sessionStatus
+	}
+
+	response, err := s.registerSessionUseCase.ListSessions(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// openRegisterSession handles opening a register with an opening float
+func (s *Server) openRegisterSession(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "operate"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.OpenRegisterSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	session, err := s.registerSessionUseCase.OpenSession(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Register opened successfully",
+		"data":    session,
+	})
+}
+
+// getCurrentRegisterSession handles getting the session open on a register
+func (s *Server) getCurrentRegisterSession(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	register := c.Query("register")
+	if register == "" {
+		s.respondWithError(c, errors.NewValidationError("register is required", "register query parameter cannot be empty"))
+		return
+	}
+
+	session, err := s.registerSessionUseCase.GetOpenSession(c.Request.Context(), GetTenantID(c), register)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": session,
+	})
+}
+
+// getRegisterSession handles retrieving a register session
+func (s *Server) getRegisterSession(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	session, err := s.registerSessionUseCase.GetSession(c.Request.Context(), GetTenantID(c), sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": session,
+	})
+}
+
+// recordRegisterCashMovement handles cash put into or taken out of a register's drawer
+func (s *Server) recordRegisterCashMovement(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "operate"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	var req usecases.CashMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	session, err := s.registerSessionUseCase.RecordCashMovement(c.Request.Context(), GetTenantID(c), userID, sessionID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Cash movement recorded successfully",
+		"data":    session,
+	})
+}
+
+// closeRegisterSession handles closing a register with the counted cash
+func (s *Server) closeRegisterSession(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "operate"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	var req usecases.CloseRegisterSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reconciliation, err := s.registerSessionUseCase.CloseSession(c.Request.Context(), GetTenantID(c), userID, sessionID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Register closed successfully",
+		"data":    reconciliation,
+	})
+}
+
+// getRegisterSessionReconciliation handles the reconciliation of a register session
+func (s *Server) getRegisterSessionReconciliation(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid session ID", err.Error()))
+		return
+	}
+
+	reconciliation, err := s.registerSessionUseCase.GetReconciliation(c.Request.Context(), GetTenantID(c), sessionID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reconciliation,
+	})
+}
+
+// getDailyRegisterReconciliation handles the end-of-day reconciliation of the register
+// sessions opened on a date, interpreted in the requested time zone
+func (s *Server) getDailyRegisterReconciliation(c *gin.Context) {
+	if err := s.checkPermission(c, "registers", "reconcile"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	loc := time.UTC
+	if tz := c.Query("timezone"); tz != "" {
+		parsed, err := time.LoadLocation(tz)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid timezone", "timezone must be an IANA time zone name"))
+			return
+		}
+		loc = parsed
+	}
+
+	date, err := time.ParseInLocation(reportDateLayout, c.Query("date"), loc)
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid date", "date must be in YYYY-MM-DD format"))
+		return
+	}
+
+	req := usecases.DailyRegisterReconciliationRequest{
+		Date:     date,
+		Location: loc,
+	}
+
+	reconciliation, err := s.registerSessionUseCase.GetDailyReconciliation(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reconciliation,
+	})
+}
@@ -0,0 +1,295 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// reportDateLayout is the date format accepted by report query parameters
+const reportDateLayout = "2006-01-02"
+
+// getSalesReport handles the sales report with cost of goods sold and gross profit. The
+// from_date and to_date query parameters are inclusive YYYY-MM-DD dates; refresh=true
+// recomputes the daily summaries of the range before reporting.
+func (s *Server) getSalesReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	report, err := s.reportUseCase.GetSalesReport(c.Request.Context(), GetTenantID(c), fromDate, toDate, c.Query("refresh") == "true")
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getInvoiceReport handles the invoice report of invoices created between the inclusive
+// from_date and to_date; refresh=true recomputes the daily summaries of the range first
+func (s *Server) getInvoiceReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	report, err := s.reportUseCase.GetInvoiceReport(c.Request.Context(), GetTenantID(c), fromDate, toDate, c.Query("refresh") == "true")
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getDailySalesReport handles the sales report of a single day, today unless a YYYY-MM-DD date
+// is given
+func (s *Server) getDailySalesReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.ParseInLocation(reportDateLayout, dateStr, now.Location())
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid date", "date must be in YYYY-MM-DD format"))
+			return
+		}
+		date = parsed
+	}
+
+	report, err := s.reportUseCase.GetDailySalesReport(c.Request.Context(), date)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getProductProfitabilityReport handles the report of products by gross profit
+func (s *Server) getProductProfitabilityReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	report, err := s.reportUseCase.GetProductProfitability(c.Request.Context(), GetTenantID(c), fromDate, toDate, limit)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getDiscountReport handles the discounts and markdowns report
+func (s *Server) getDiscountReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	req := usecases.DiscountReportRequest{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Period:   repositories.ReportPeriod(c.DefaultQuery("period", string(repositories.ReportPeriodDay))),
+		Category: c.Query("category"),
+	}
+
+	if cashierIDStr := c.Query("cashier_id"); cashierIDStr != "" {
+		cashierID, err := uuid.Parse(cashierIDStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid cashier ID", err.Error()))
+			return
+		}
+		req.CashierID = &cashierID
+	}
+
+	report, err := s.reportUseCase.GetDiscountReport(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getTaxSummaryReport handles the sales tax summary for the periodic tax return. The from and
+// to query parameters are inclusive YYYY-MM-DD dates; format=csv downloads the report as CSV.
+func (s *Server) getTaxSummaryReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	now := time.Now()
+	fromDate, err := time.ParseInLocation(reportDateLayout, c.Query("from"), now.Location())
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid from", "from must be in YYYY-MM-DD format"))
+		return
+	}
+	toDate, err := time.ParseInLocation(reportDateLayout, c.Query("to"), now.Location())
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid to", "to must be in YYYY-MM-DD format"))
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		s.respondWithError(c, errors.NewValidationError("invalid format", "format must be json or csv"))
+		return
+	}
+
+	report, err := s.reportUseCase.GetTaxSummary(c.Request.Context(), GetTenantID(c), fromDate, toDate.AddDate(0, 0, 1))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if format == "csv" {
+		data, err := usecases.TaxSummaryCSV(report)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filename := fmt.Sprintf("tax-summary-%s-%s.csv", fromDate.Format(reportDateLayout), toDate.Format(reportDateLayout))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getLowStockReport handles the low stock report grouped by the supplier to reorder from
+func (s *Server) getLowStockReport(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	report, err := s.reportUseCase.GetLowStockReport(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+// getAnalyticsCatalog handles listing the dimensions and measures analytics queries can use
+func (s *Server) getAnalyticsCatalog(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": s.analyticsUseCase.GetCatalog(),
+	})
+}
+
+// runAnalyticsQuery handles running a declarative analytics query for a dashboard chart
+func (s *Server) runAnalyticsQuery(c *gin.Context) {
+	if err := s.checkPermission(c, "reports", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.AnalyticsQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	result, err := s.analyticsUseCase.RunQuery(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// parseReportDateRange parses the inclusive from_date and to_date query parameters into
+// a half-open range. It defaults to the last 30 days.
+func parseReportDateRange(c *gin.Context) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	toDate := today
+	if toDateStr := c.Query("to_date"); toDateStr != "" {
+		parsed, err := time.ParseInLocation(reportDateLayout, toDateStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.NewValidationError("invalid to_date", "to_date must be in YYYY-MM-DD format")
+		}
+		toDate = parsed
+	}
+
+	fromDate := toDate.AddDate(0, 0, -29)
+	if fromDateStr := c.Query("from_date"); fromDateStr != "" {
+		parsed, err := time.ParseInLocation(reportDateLayout, fromDateStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.NewValidationError("invalid from_date", "from_date must be in YYYY-MM-DD format")
+		}
+		fromDate = parsed
+	}
+
+	return fromDate, toDate.AddDate(0, 0, 1), nil
+}
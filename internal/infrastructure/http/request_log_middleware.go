@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/redact"
+)
+
+// requestLogWriteTimeout bounds how long storing a captured request may take
+const requestLogWriteTimeout = 5 * time.Second
+
+// bodyCaptureWriter tees the response body into a buffer, up to a limit
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body      *bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write writes the response and captures it while under the limit
+func (w *bodyCaptureWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the response and captures it while under the limit
+func (w *bodyCaptureWriter) WriteString(data string) (int, error) {
+	w.capture([]byte(data))
+	return w.ResponseWriter.WriteString(data)
+}
+
+func (w *bodyCaptureWriter) capture(data []byte) {
+	if w.truncated {
+		return
+	}
+	if w.body.Len()+len(data) > w.limit {
+		w.truncated = true
+		return
+	}
+	w.body.Write(data)
+}
+
+// RequestLogMiddleware captures a sample of request and response bodies for debugging
+// integrations. Bodies are redacted before they are stored, and payloads carrying cardholder
+// data are never stored. It must run after RequestTrackingMiddleware, which assigns the
+// request ID the captures are queried by.
+func (s *Server) RequestLogMiddleware() gin.HandlerFunc {
+	cfg := s.config.RequestLog
+	rules := redact.NewRules(cfg.RedactFields...)
+
+	return func(c *gin.Context) {
+		if !cfg.Enabled || s.apiRequestLogUseCase == nil || rand.Float64() >= cfg.SampleRate {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		// Read up to one byte past the limit to detect oversized bodies, then restore the body
+		var requestBody []byte
+		if c.Request.Body != nil {
+			original := c.Request.Body
+			requestBody, _ = io.ReadAll(io.LimitReader(original, int64(cfg.MaxBodyBytes)+1))
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(requestBody), original), original}
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}, limit: cfg.MaxBodyBytes}
+		c.Writer = writer
+
+		c.Next()
+
+		log := entities.NewAPIRequestLog(
+			c.GetString("request_id"),
+			c.Request.Method,
+			c.Request.URL.Path,
+			c.Request.URL.RawQuery,
+			writer.Status(),
+			time.Since(start),
+			cfg.Retention,
+		)
+		log.RequestHeaders = rules.Headers(c.Request.Header)
+		if tenantID := GetTenantID(c); tenantID != uuid.Nil {
+			log.TenantID = &tenantID
+		}
+		if userID, err := s.getCurrentUser(c); err == nil {
+			log.UserID = &userID
+		}
+
+		log.RequestBody, log.RequestOmitted = redactBody(rules, requestBody, len(requestBody) > cfg.MaxBodyBytes)
+		log.ResponseBody, log.ResponseOmitted = redactBody(rules, writer.body.Bytes(), writer.truncated)
+
+		// A PCI payload on either side means neither body is kept
+		if log.RequestOmitted == entities.APIRequestBodyOmittedPCI || log.ResponseOmitted == entities.APIRequestBodyOmittedPCI {
+			log.RequestBody, log.RequestOmitted = "", entities.APIRequestBodyOmittedPCI
+			log.ResponseBody, log.ResponseOmitted = "", entities.APIRequestBodyOmittedPCI
+		}
+
+		// Store outside the request so logging never slows down or fails the response
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), requestLogWriteTimeout)
+			defer cancel()
+			s.apiRequestLogUseCase.Record(ctx, log)
+		}()
+	}
+}
+
+// redactBody applies the redaction rules to a captured body and explains why it was omitted, if it was
+func redactBody(rules *redact.Rules, body []byte, truncated bool) (string, entities.APIRequestBodyOmission) {
+	if truncated {
+		return "", entities.APIRequestBodyOmittedTruncated
+	}
+	if len(body) == 0 {
+		return "", ""
+	}
+
+	redacted, pci := rules.JSON(body)
+	if pci {
+		return "", entities.APIRequestBodyOmittedPCI
+	}
+	if redacted == nil {
+		return "", entities.APIRequestBodyOmittedNotJSON
+	}
+	return string(redacted), ""
+}
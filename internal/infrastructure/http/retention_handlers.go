@@ -0,0 +1,221 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// getRetentionPolicies handles listing the tenant's data retention policies
+func (s *Server) getRetentionPolicies(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	policies, err := s.retentionUseCase.GetPolicies(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": policies,
+	})
+}
+
+// updateRetentionPolicy handles configuring the retention policy of an entity
+func (s *Server) updateRetentionPolicy(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateRetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	entity := entities.RetentionEntity(c.Param("entity"))
+	policy, err := s.retentionUseCase.UpdatePolicy(c.Request.Context(), GetTenantID(c), userID, entity, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Retention policy updated successfully",
+		"data":    policy,
+	})
+}
+
+// previewRetentionPurge handles a dry run of the purge job showing how many records would be deleted
+func (s *Server) previewRetentionPurge(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	preview, err := s.retentionUseCase.PreviewPurge(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": preview,
+	})
+}
+
+// listRetentionHolds handles listing the tenant's retention holds
+func (s *Server) listRetentionHolds(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	activeOnly := c.DefaultQuery("active", "true") == "true"
+
+	holds, err := s.retentionUseCase.ListHolds(c.Request.Context(), GetTenantID(c), activeOnly)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": holds,
+	})
+}
+
+// createRetentionHold handles placing a retention hold on a record under dispute or audit
+func (s *Server) createRetentionHold(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateRetentionHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	hold, err := s.retentionUseCase.PlaceHold(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Retention hold placed successfully",
+		"data":    hold,
+	})
+}
+
+// releaseRetentionHold handles lifting a retention hold
+func (s *Server) releaseRetentionHold(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	holdID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid hold ID", err.Error()))
+		return
+	}
+
+	hold, err := s.retentionUseCase.ReleaseHold(c.Request.Context(), GetTenantID(c), userID, holdID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Retention hold released successfully",
+		"data":    hold,
+	})
+}
+
+// listDeletedRecords handles listing the tenant's soft-deleted sales or invoices
+func (s *Server) listDeletedRecords(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	entity := entities.RetentionEntity(c.Param("entity"))
+	response, err := s.retentionUseCase.ListDeletedRecords(c.Request.Context(), GetTenantID(c), entity, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// restoreDeletedRecord handles undoing the soft deletion of a sale or invoice
+func (s *Server) restoreDeletedRecord(c *gin.Context) {
+	if err := s.checkPermission(c, "retention", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	recordID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid record ID", err.Error()))
+		return
+	}
+
+	entity := entities.RetentionEntity(c.Param("entity"))
+	if err := s.retentionUseCase.RestoreDeletedRecord(c.Request.Context(), GetTenantID(c), userID, entity, recordID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Record restored successfully",
+	})
+}
@@ -0,0 +1,375 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// addSaleItem handles adding a product to a pending sale. Selling a product outside its
+// availability window needs a manager override.
+func (s *Server) addSaleItem(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.AddSaleItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if req.SellOutOfSeason {
+		approvedBy, ok := s.requireManagerOverride(c, entities.OverrideActionSellUnavailable, saleID)
+		if !ok {
+			return
+		}
+		req.ApprovedBy = approvedBy
+	}
+
+	sale, err := s.saleUseCase.AddSaleItem(c.Request.Context(), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale item added successfully",
+		"data":    sale,
+	})
+}
+
+// addSaleItems handles adding several products to a pending sale in one request. Nothing is
+// added unless every line is valid; otherwise the response lists the error of each failed line.
+// Selling any product outside its availability window needs a manager override.
+func (s *Server) addSaleItems(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.AddSaleItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// One override covers every line of the batch sold out of season
+	sellOutOfSeason := false
+	for _, item := range req.Items {
+		sellOutOfSeason = sellOutOfSeason || item.SellOutOfSeason
+	}
+	if sellOutOfSeason {
+		approvedBy, ok := s.requireManagerOverride(c, entities.OverrideActionSellUnavailable, saleID)
+		if !ok {
+			return
+		}
+		for i := range req.Items {
+			if req.Items[i].SellOutOfSeason {
+				req.Items[i].ApprovedBy = approvedBy
+			}
+		}
+	}
+
+	result, err := s.saleUseCase.AddSaleItems(c.Request.Context(), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if result.Failed > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"message": "Sale items not added, some lines are invalid",
+			"data":    result,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale items added successfully",
+		"data":    result,
+	})
+}
+
+// overrideSaleItemPrice handles manually overriding the charged price of a sale item
+func (s *Server) overrideSaleItemPrice(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.OverrideSaleItemPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	approvedBy, ok := s.requireManagerOverride(c, entities.OverrideActionPriceOverride, saleID)
+	if !ok {
+		return
+	}
+	req.ApprovedBy = approvedBy
+
+	sale, err := s.saleUseCase.OverrideSaleItemPrice(c.Request.Context(), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale item price overridden successfully",
+		"data":    sale,
+	})
+}
+
+// setSaleItemSerialNumbers handles capturing the serial numbers of a serialized product in a sale
+func (s *Server) setSaleItemSerialNumbers(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.SetSaleItemSerialNumbersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sale, err := s.saleUseCase.SetSaleItemSerialNumbers(c.Request.Context(), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale item serial numbers updated successfully",
+		"data":    sale,
+	})
+}
+
+// refundSale handles refunding some or all items of a completed sale. Cashiers need a
+// manager override to refund.
+func (s *Server) refundSale(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.RefundSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Returns past the return window need the stronger late return override
+	action := entities.OverrideActionRefund
+	if req.ReturnOutsideWindow {
+		action = entities.OverrideActionLateReturn
+	}
+	approvedBy, ok := s.requireManagerOverride(c, action, saleID)
+	if !ok {
+		return
+	}
+	req.ApprovedBy = approvedBy
+
+	refund, err := s.saleUseCase.RefundSale(c.Request.Context(), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Sale refunded successfully",
+		"data":    refund,
+	})
+}
+
+// lookupSaleForReturn handles finding a sale brought back for a return by its sale number and
+// receipt token
+func (s *Server) lookupSaleForReturn(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ReturnLookupRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid query parameters", err.Error()))
+		return
+	}
+	if req.SaleNumber == "" {
+		s.respondWithError(c, errors.NewValidationError("sale number is required", "sale_number query parameter is required"))
+		return
+	}
+
+	lookup, err := s.saleUseCase.LookupSaleForReturn(c.Request.Context(), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale retrieved successfully",
+		"data":    lookup,
+	})
+}
+
+// getSaleRefunds handles listing the refunds of a sale
+func (s *Server) getSaleRefunds(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	refunds, err := s.saleUseCase.GetSaleRefunds(c.Request.Context(), saleID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": refunds,
+	})
+}
+
+// holdSale handles parking a pending sale so it can be resumed later
+func (s *Server) holdSale(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	var req usecases.HoldSaleRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sale, err := s.saleUseCase.HoldSale(c.Request.Context(), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale held successfully",
+		"data":    sale,
+	})
+}
+
+// resumeSale handles resuming a held sale, possibly on another terminal
+func (s *Server) resumeSale(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	sale, err := s.saleUseCase.ResumeSale(c.Request.Context(), userID, saleID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sale resumed successfully",
+		"data":    sale,
+	})
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// issueSaleReceipt handles rendering the receipt of a completed sale. The receipt is emailed
+// and/or printed when asked for, otherwise it is downloaded as a PDF.
+func (s *Server) issueSaleReceipt(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid sale ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SaleReceiptRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	result, err := s.saleReceiptUseCase.IssueReceipt(c.Request.Context(), GetTenantID(c), userID, saleID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if !req.Email && !req.Print {
+		filename := fmt.Sprintf("receipt_%s.pdf", result.Sale.SaleNumber)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, "application/pdf", result.PDF)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Receipt issued successfully",
+		"data":    result,
+	})
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listSerialNumbers handles listing serial numbers with pagination and filtering
+func (s *Server) listSerialNumbers(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	var filter repositories.SerialNumberFilter
+
+	if status := c.Query("status"); status != "" {
+		serialStatus := entities.SerialNumberStatus(status)
+		if err := entities.ValidateSerialNumberStatus(serialStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &serialStatus
+	}
+
+	var err error
+	if filter.ProductID, err = queryUUID(c, "product_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.SaleID, err = queryUUID(c, "sale_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.serialNumberUseCase.ListSerialNumbers(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// registerSerialNumbers handles registering serial numbers of a serialized product received into stock
+func (s *Server) registerSerialNumbers(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.RegisterSerialNumbersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	serialNumbers, err := s.serialNumberUseCase.RegisterSerialNumbers(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Serial numbers registered successfully",
+		"data":    serialNumbers,
+	})
+}
+
+// lookupSerialNumber handles looking up a serial number for a warranty query
+func (s *Server) lookupSerialNumber(c *gin.Context) {
+	if err := s.checkPermission(c, "sales", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	serialNumbers, err := s.serialNumberUseCase.LookupSerialNumber(c.Request.Context(), GetTenantID(c), c.Param("serial"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": serialNumbers,
+	})
+}
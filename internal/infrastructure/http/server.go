@@ -6,17 +6,30 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
 
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/infrastructure/config"
+	"github.com/nicklaros/adol/internal/infrastructure/database"
+	tenantmonitoring "github.com/nicklaros/adol/internal/infrastructure/monitoring"
+	"github.com/nicklaros/adol/internal/infrastructure/repositories"
+	"github.com/nicklaros/adol/internal/infrastructure/scheduler"
+	"github.com/nicklaros/adol/internal/infrastructure/services"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/logger"
 	"github.com/nicklaros/adol/pkg/monitoring"
+	"github.com/nicklaros/adol/pkg/siem"
 )
 
+// replicaCheckTimeout bounds one read replica lag check
+const replicaCheckTimeout = 5 * time.Second
+
 // Server represents the HTTP server
 type Server struct {
 	config  *config.Config
@@ -26,6 +39,90 @@ type Server struct {
 	server  *http.Server
 	metrics *monitoring.MetricsCollector
 	health  *monitoring.HealthChecker
+
+	// tenantMonitor tracks per-tenant request, delivery and usage metrics for health scoring
+	tenantMonitor tenantmonitoring.TenantMonitor
+
+	// siem ships audit events and security logs to an external SIEM when enabled
+	siem *siem.Shipper
+
+	// scheduler runs background jobs such as the daily digest
+	scheduler *scheduler.Scheduler
+
+	// replicas routes the reads of read-only contexts to the read replica while it is caught up
+	replicas *database.ReplicaRouter
+
+	// ready is set once the startup warm-up has finished, which /health/ready waits for
+	ready atomic.Bool
+
+	productUseCase                  *usecases.ProductUseCase
+	saleUseCase                     *usecases.SaleUseCase
+	stockUseCase                    *usecases.StockUseCase
+	checkoutRuleUseCase             *usecases.CheckoutRuleUseCase
+	stockReservationUseCase         *usecases.StockReservationUseCase
+	auditUseCase                    *usecases.AuditUseCase
+	tenantExportUseCase             *usecases.TenantExportUseCase
+	priceCheckUseCase               *usecases.PriceCheckUseCase
+	apiKeyUseCase                   *usecases.APIKeyUseCase
+	authUseCase                     *usecases.AuthUseCase
+	userUseCase                     *usecases.UserUseCase
+	userInvitationUseCase           *usecases.UserInvitationUseCase
+	reportUseCase                   *usecases.ReportUseCase
+	dailyDigestUseCase              *usecases.DailyDigestUseCase
+	retentionUseCase                *usecases.RetentionUseCase
+	dashboardUseCase                *usecases.DashboardUseCase
+	invoiceEmailBatchUseCase        *usecases.InvoiceEmailBatchUseCase
+	paymentSurchargeUseCase         *usecases.PaymentSurchargeUseCase
+	apiRequestLogUseCase            *usecases.APIRequestLogUseCase
+	documentSignatureUseCase        *usecases.DocumentSignatureUseCase
+	managerOverrideUseCase          *usecases.ManagerOverrideUseCase
+	timeClockUseCase                *usecases.TimeClockUseCase
+	shelfLabelUseCase               *usecases.ShelfLabelUseCase
+	purchaseOrderUseCase            *usecases.PurchaseOrderUseCase
+	warehouseExportUseCase          *usecases.WarehouseExportUseCase
+	supplierUseCase                 *usecases.SupplierUseCase
+	customerUseCase                 *usecases.CustomerUseCase
+	analyticsUseCase                *usecases.AnalyticsUseCase
+	supportBundleUseCase            *usecases.SupportBundleUseCase
+	totalsRecalculationUseCase      *usecases.TotalsRecalculationUseCase
+	marginFloorUseCase              *usecases.MarginFloorUseCase
+	webhookUseCase                  *usecases.WebhookUseCase
+	inventoryValuationUseCase       *usecases.InventoryValuationUseCase
+	exportUseCase                   *usecases.ExportUseCase
+	idempotencyUseCase              *usecases.IdempotencyUseCase
+	deliveryPolicyUseCase           *usecases.DeliveryPolicyUseCase
+	invoiceReminderUseCase          *usecases.InvoiceReminderUseCase
+	emailOutboxUseCase              *usecases.EmailOutboxUseCase
+	invoicePortalUseCase            *usecases.InvoicePortalUseCase
+	emailTemplateUseCase            *usecases.EmailTemplateUseCase
+	invoiceNumberReservationUseCase *usecases.InvoiceNumberReservationUseCase
+	printerUseCase                  *usecases.PrinterUseCase
+	productUnitUseCase              *usecases.ProductUnitUseCase
+	registerSessionUseCase          *usecases.RegisterSessionUseCase
+	checkoutSessionUseCase          *usecases.CheckoutSessionUseCase
+	registerReportUseCase           *usecases.RegisterReportUseCase
+	stockCountUseCase               *usecases.StockCountUseCase
+	categoryUseCase                 *usecases.CategoryUseCase
+	featureUsageUseCase             *usecases.FeatureUsageUseCase
+	couponUseCase                   *usecases.CouponUseCase
+	taxRateUseCase                  *usecases.TaxRateUseCase
+	invoiceUseCase                  *usecases.InvoiceUseCase
+	quoteUseCase                    *usecases.QuoteUseCase
+	locationUseCase                 *usecases.LocationUseCase
+	stockTransferUseCase            *usecases.StockTransferUseCase
+	serialNumberUseCase             *usecases.SerialNumberUseCase
+	alertNotificationUseCase        *usecases.AlertNotificationUseCase
+	ecommerceIntegrationUseCase     *usecases.EcommerceIntegrationUseCase
+	paymentGatewayUseCase           *usecases.PaymentGatewayUseCase
+	accountingExportUseCase         *usecases.AccountingExportUseCase
+	taxInvoiceExportUseCase         *usecases.TaxInvoiceExportUseCase
+	invoiceShareUseCase             *usecases.InvoiceShareUseCase
+	saleReceiptUseCase              *usecases.SaleReceiptUseCase
+	invoiceTemplateUseCase          *usecases.InvoiceTemplateUseCase
+	attachmentUseCase               *usecases.AttachmentUseCase
+
+	// GraphQL schema of the dashboard API
+	graphqlSchema *graphql.Schema
 }
 
 // NewServer creates a new HTTP server
@@ -50,28 +147,48 @@ func NewServer(cfg *config.Config, db *sql.DB, baseLogger logger.Logger) *Server
 	healthChecker := monitoring.NewHealthChecker(enhancedLogger)
 
 	server := &Server{
-		config:  cfg,
-		db:      db,
-		logger:  enhancedLogger,
-		router:  router,
-		metrics: metricsCollector,
-		health:  healthChecker,
+		config:        cfg,
+		db:            db,
+		logger:        enhancedLogger,
+		router:        router,
+		metrics:       metricsCollector,
+		health:        healthChecker,
+		tenantMonitor: tenantmonitoring.NewTenantMonitor(enhancedLogger),
+		scheduler:     scheduler.New(enhancedLogger),
+	}
+
+	// Ship security logs to the SIEM before anything else logs
+	if cfg.SIEM.Enabled {
+		server.setupSIEM(baseLogger)
 	}
 
 	// Add enhanced middleware
 	router.Use(gin.Recovery())
 	router.Use(server.ErrorHandlingMiddleware())
 	router.Use(server.RequestTrackingMiddleware())
+	router.Use(server.RequestLogMiddleware())
 	router.Use(server.SecurityHeadersMiddleware())
 	router.Use(corsMiddleware())
 	router.Use(server.RateLimitingMiddleware())
 
+	// Serve read-only queries from the read replica when one is configured
+	server.setupReplicas()
+
+	// Send monitoring alerts to the tenants' alert channels
+	server.tenantMonitor.SetAlertNotifier(server.notifyAlert)
+
 	// Register health checks
 	server.registerHealthChecks()
 
+	// Build the GraphQL schema before its route is set up
+	server.graphqlSchema = server.newGraphQLSchema()
+
 	// Setup routes
 	server.setupRoutes()
 
+	// Register background jobs
+	server.registerScheduledJobs()
+
 	// Create HTTP server
 	server.server = &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -90,19 +207,206 @@ func NewServer(cfg *config.Config, db *sql.DB, baseLogger logger.Logger) *Server
 // Start starts the HTTP server
 func (s *Server) Start() error {
 	s.logger.Info("Starting HTTP server on port " + s.config.Server.Port)
+	s.scheduler.Start()
+	go s.checkReplica()
+	go s.warmUp()
 	return s.server.ListenAndServe()
 }
 
+// warmUp opens the pooled database connections, prepares the statements of the checkout path
+// on them and reads the reference data most requests need, so POS terminals do not see a
+// latency spike on their first requests after a deploy. The server reports ready once it
+// ends; failures only make those first requests slower, so they are logged and not fatal.
+func (s *Server) warmUp() {
+	defer s.ready.Store(true)
+
+	timeout := s.config.Server.WarmUpTimeout
+	if timeout <= 0 {
+		return
+	}
+
+	conns := s.config.Database.MaxIdleConns
+	if maxOpen := s.config.Database.MaxOpenConns; maxOpen > 0 && conns > maxOpen {
+		conns = maxOpen
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := database.WarmUp(ctx, s.db, conns, repositories.WarmUpStatements, repositories.WarmUpQueries)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"duration": time.Since(start).String(),
+			"error":    err.Error(),
+		}).Warn("Startup warm-up did not complete")
+		return
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"connections": conns,
+		"statements":  len(repositories.WarmUpStatements),
+		"duration":    time.Since(start).String(),
+	}).Info("Startup warm-up completed")
+}
+
+// setupReplicas opens the read replica. Reads stay on the primary when it cannot be opened.
+func (s *Server) setupReplicas() {
+	replicas, err := database.NewReplicaRouter(s.db, s.config.Database)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).Error("Failed to open read replica, reads will use the primary")
+		return
+	}
+	s.replicas = replicas
+}
+
+// checkReplica runs the first replica check at startup so reads move to the replica without
+// waiting for the first scheduled check
+func (s *Server) checkReplica() {
+	if s.replicas == nil || !s.replicas.HasReplica() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), replicaCheckTimeout)
+	defer cancel()
+
+	if err := s.replicas.CheckReplica(ctx); err != nil {
+		s.logger.WithField("error", err.Error()).Warn("Read replica is not in use, reads will use the primary")
+		return
+	}
+	s.logger.Info("Read replica is in use")
+}
+
 // Shutdown gracefully shuts down the HTTP server
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down HTTP server...")
-	return s.server.Shutdown(ctx)
+	if err := s.scheduler.Stop(ctx); err != nil {
+		s.logger.WithField("error", err.Error()).Error("Failed to stop scheduler")
+	}
+	err := s.server.Shutdown(ctx)
+	if s.replicas != nil {
+		if closeErr := s.replicas.Close(); closeErr != nil {
+			s.logger.WithField("error", closeErr.Error()).Error("Failed to close read replica connections")
+		}
+	}
+	if s.siem != nil {
+		if closeErr := s.siem.Close(ctx); closeErr != nil {
+			s.logger.WithFields(map[string]interface{}{
+				services.SIEMComponentField: services.SIEMComponent,
+				"error":                     closeErr.Error(),
+			}).Error("Failed to flush SIEM events")
+		}
+	}
+	return err
+}
+
+// setupSIEM starts shipping logs at the configured level from both the server's and the base
+// logger to the SIEM. Audit ports are wrapped with services.NewSIEMAuditService(port, s.siem).
+func (s *Server) setupSIEM(baseLogger logger.Logger) {
+	shipper, err := services.NewSIEMShipper(s.config.SIEM, func(err error) {
+		s.logger.WithFields(map[string]interface{}{
+			services.SIEMComponentField: services.SIEMComponent,
+			"error":                     err.Error(),
+		}).Error("Failed to ship events to SIEM")
+	})
+	if err != nil {
+		s.logger.WithField("error", err.Error()).Error("Failed to set up SIEM shipping")
+		return
+	}
+
+	hook, err := services.NewSIEMLogHook(shipper, s.config.SIEM.LogLevel)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).Error("Invalid SIEM log level")
+		shipper.Close(context.Background())
+		return
+	}
+	logger.AddHook(s.logger, hook)
+	logger.AddHook(baseLogger, hook)
+
+	s.siem = shipper
+	s.logger.WithFields(map[string]interface{}{
+		"sink":     s.config.SIEM.Sink,
+		"endpoint": s.config.SIEM.Endpoint,
+	}).Info("Shipping audit events and security logs to SIEM")
+}
+
+// registerScheduledJobs registers the background jobs run by the scheduler
+func (s *Server) registerScheduledJobs() {
+	if s.dailyDigestUseCase != nil {
+		// Digests go out at each tenant's local send hour, so check every 15 minutes
+		s.scheduler.Every("daily_digest", 15*time.Minute, 10*time.Minute, s.dailyDigestUseCase.SendDueDigests)
+	}
+	if s.retentionUseCase != nil {
+		s.scheduler.Every("retention_purge", time.Hour, 30*time.Minute, s.retentionUseCase.PurgeExpired)
+	}
+	if s.productUseCase != nil {
+		s.scheduler.Every("product_publishing", 5*time.Minute, time.Minute, s.productUseCase.PublishDueProducts)
+		s.scheduler.Every("product_availability", 15*time.Minute, 2*time.Minute, s.productUseCase.ApplyProductAvailability)
+	}
+	if s.apiRequestLogUseCase != nil {
+		s.scheduler.Every("request_log_purge", time.Hour, 15*time.Minute, s.apiRequestLogUseCase.PurgeExpired)
+	}
+	if s.tenantExportUseCase != nil {
+		// Exports are resumed after a restart once their lease lapses, so the timeout stays below it
+		s.scheduler.Every("tenant_export", time.Minute, 50*time.Minute, s.tenantExportUseCase.ProcessDue)
+	}
+	if s.warehouseExportUseCase != nil {
+		s.scheduler.Every("warehouse_export", time.Hour, 45*time.Minute, s.warehouseExportUseCase.ExportDue)
+	}
+	if s.marginFloorUseCase != nil {
+		s.scheduler.Every("margin_weekly_report", time.Hour, 20*time.Minute, s.marginFloorUseCase.SendWeeklyReports)
+	}
+	if s.webhookUseCase != nil {
+		s.scheduler.Every("webhook_delivery", 30*time.Second, 4*time.Minute, s.webhookUseCase.DeliverDue)
+	}
+	if s.ecommerceIntegrationUseCase != nil {
+		s.scheduler.Every("ecommerce_stock_push", 5*time.Minute, 4*time.Minute, s.ecommerceIntegrationUseCase.PushStockLevels)
+	}
+	if s.emailOutboxUseCase != nil {
+		s.scheduler.Every("email_outbox", 15*time.Second, 4*time.Minute, s.emailOutboxUseCase.SendDue)
+	}
+	if s.saleUseCase != nil {
+		s.scheduler.Every("held_sale_expiry", 5*time.Minute, time.Minute, s.saleUseCase.ExpireHeldSales)
+	}
+	if s.stockReservationUseCase != nil {
+		s.scheduler.Every("stock_reservation_expiry", time.Minute, time.Minute, s.stockReservationUseCase.ExpireReservations)
+	}
+	if s.quoteUseCase != nil {
+		s.scheduler.Every("quote_expiry", time.Hour, 5*time.Minute, s.quoteUseCase.ExpireQuotes)
+	}
+	if s.checkoutSessionUseCase != nil {
+		s.scheduler.Every("checkout_session_expiry", 5*time.Minute, time.Minute, s.checkoutSessionUseCase.ExpireIdleSessions)
+	}
+	if s.idempotencyUseCase != nil {
+		s.scheduler.Every("idempotency_key_purge", time.Hour, 15*time.Minute, s.idempotencyUseCase.PurgeExpired)
+	}
+	if s.authUseCase != nil {
+		s.scheduler.Every("user_session_purge", time.Hour, 15*time.Minute, s.authUseCase.PurgeExpiredSessions)
+	}
+	if s.customerUseCase != nil {
+		s.scheduler.Every("customer_duplicates", 24*time.Hour, time.Hour, s.customerUseCase.DetectDuplicates)
+	}
+	if s.reportUseCase != nil {
+		// Keeps the daily report summaries in step with changed sales and invoices
+		s.scheduler.Every("report_summaries", 5*time.Minute, 4*time.Minute, s.reportUseCase.RefreshStaleSummaries)
+	}
+	if s.config.Metrics.PrometheusEnabled && s.config.Metrics.StockAlertInterval > 0 {
+		s.scheduler.Every("stock_alert_metrics", s.config.Metrics.StockAlertInterval, 30*time.Second, s.recordStockAlertMetrics)
+	}
+	if s.invoiceReminderUseCase != nil && s.config.InvoiceReminders.Enabled {
+		// Reminders go out from the configured send hour, so check hourly
+		s.scheduler.Every("invoice_reminders", time.Hour, 30*time.Minute, s.invoiceReminderUseCase.SendDueReminders)
+	}
+	if s.replicas != nil && s.replicas.HasReplica() {
+		s.scheduler.Every("replica_health", s.config.Database.ReplicaCheckInterval, replicaCheckTimeout, s.replicas.CheckReplica)
+	}
 }
 
 // setupRoutes sets up all the routes
 func (s *Server) setupRoutes() {
 	// Health check endpoint
 	s.router.GET("/health", s.healthCheck)
+	s.router.GET("/health/ready", s.readinessCheck)
 	s.router.GET("/health/detailed", s.detailedHealthCheck)
 	s.router.GET("/metrics", s.metricsEndpoint)
 
@@ -121,13 +425,43 @@ func (s *Server) setupRoutes() {
 		{
 			auth.POST("/login", s.login)
 			auth.POST("/refresh", s.refreshToken)
-			auth.POST("/logout", s.logout)
+			auth.POST("/password/expired", s.changeExpiredPassword)
+			auth.GET("/invitations/:id", s.getInvitationDetails)
+			auth.POST("/invitations/:id/accept", s.acceptInvitation)
+		}
+
+		// Price checker kiosk routes (authenticated by device token)
+		v1.GET("/price-check", s.priceCheck)
+
+		// Invoice portal routes (authenticated by signed download link)
+		v1.GET("/portal/invoices/:id/pdf", s.downloadPortalInvoicePDF)
+
+		// Public invoice routes (authenticated by share link token)
+		publicInvoices := v1.Group("/public/invoices")
+		{
+			publicInvoices.GET("/:token", s.viewPublicInvoice)
+			publicInvoices.GET("/:token/pdf", s.downloadPublicInvoicePDF)
+			publicInvoices.POST("/:token/pay", s.payPublicInvoice)
 		}
 
+		// E-commerce order webhooks (authenticated by the store's signature)
+		v1.POST("/integrations/ecommerce/:id/webhooks", s.receiveEcommerceWebhook)
+
+		// Payment gateway notifications (authenticated by the gateway's signature)
+		v1.POST("/payments/webhooks/:provider", s.receivePaymentWebhook)
+
 		// Protected routes (require authentication)
 		protected := v1.Group("/")
 		protected.Use(s.authMiddleware())
 		{
+			// Session routes for the signed-in user
+			sessions := protected.Group("/auth")
+			{
+				sessions.POST("/logout", s.logout)
+				sessions.GET("/sessions", s.listSessions)
+				sessions.DELETE("/sessions/:id", s.revokeSession)
+			}
+
 			// User management routes
 			users := protected.Group("/users")
 			{
@@ -141,19 +475,61 @@ func (s *Server) setupRoutes() {
 				users.PUT("/:id/suspend", s.suspendUser)
 				users.PUT("/change-password", s.changePassword)
 				users.PUT("/:id/reset-password", s.resetPassword)
+				users.POST("/require-password-change", s.requirePasswordChange)
+				users.POST("/invitations", s.inviteUser)
+				users.GET("/:id/invitation", s.getUserInvitation)
+				users.POST("/:id/invitation/resend", s.resendUserInvitation)
+				users.DELETE("/:id/invitation", s.revokeUserInvitation)
+				users.GET("/:id/sessions", s.listUserSessions)
+				users.DELETE("/:id/sessions", s.revokeAllUserSessions)
+				users.DELETE("/:id/sessions/:session_id", s.revokeUserSession)
+				users.GET("/:id/history", s.getResourceHistory("user", "users"))
 			}
 
 			// Product management routes
 			products := protected.Group("/products")
 			{
 				products.GET("", s.listProducts)
+				products.GET("/export", s.exportProducts)
 				products.POST("", s.createProduct)
 				products.GET("/:id", s.getProduct)
 				products.PUT("/:id", s.updateProduct)
 				products.DELETE("/:id", s.deleteProduct)
-				products.GET("/categories", s.getCategories)
+				products.GET("/categories", s.listCategories)
+				products.GET("/categories/tree", s.getCategoryTree)
+				products.POST("/categories", s.createCategory)
+				products.GET("/categories/:id", s.getCategory)
+				products.PUT("/categories/:id", s.updateCategory)
+				products.DELETE("/categories/:id", s.deleteCategory)
 				products.GET("/low-stock", s.getLowStockProducts)
 				products.GET("/sku/:sku", s.getProductBySKU)
+				products.GET("/review-queue", s.listProductReviewQueue)
+				products.POST("/:id/submit", s.submitProductForReview)
+				products.POST("/:id/approve", s.approveProduct)
+				products.POST("/:id/reject", s.rejectProduct)
+				products.POST("/:id/unpublish", s.unpublishProduct)
+				products.PUT("/:id/supplier", s.assignProductSupplier)
+				products.GET("/:id/locations", s.getProductLocationStock)
+				products.GET("/:id/stock-ledger", s.getProductStockLedger)
+				products.GET("/:id/history", s.getResourceHistory("product", "products"))
+				products.GET("/units", s.listProductUnits)
+				products.POST("/units", s.createProductUnit)
+				products.GET("/units/:code", s.getProductUnit)
+				products.PUT("/units/:code", s.updateProductUnit)
+				products.DELETE("/units/:code", s.deleteProductUnit)
+			}
+
+			// Catalog upsert routes for external systems of record
+			catalog := protected.Group("/catalog")
+			{
+				catalog.PUT("/items/:sku", s.upsertCatalogItem)
+			}
+
+			// Shelf label printing routes
+			shelfLabels := protected.Group("/shelf-labels")
+			{
+				shelfLabels.POST("", s.printShelfLabels)
+				shelfLabels.GET("/layouts", s.listLabelLayouts)
 			}
 
 			// Stock management routes
@@ -166,40 +542,367 @@ func (s *Server) setupRoutes() {
 				stock.POST("/release", s.releaseReservedStock)
 				stock.GET("/low-stock", s.getLowStockItems)
 				stock.GET("/movements", s.getStockMovements)
+				stock.GET("/movements/export", s.exportStockMovements)
 				stock.GET("/movements/:productId", s.getProductStockMovements)
+				stock.POST("/movements/:id/reverse", s.reverseStockMovement)
+				stock.POST("/write-downs", s.writeDownStock)
+				stock.GET("/write-downs", s.listStockWriteDowns)
+				stock.GET("/write-downs/:id", s.getStockWriteDown)
+				stock.GET("/counts", s.listStockCounts)
+				stock.POST("/counts", s.startStockCount)
+				stock.GET("/counts/:id", s.getStockCount)
+				stock.POST("/counts/:id/scans", s.submitStockCountScans)
+				stock.POST("/counts/:id/import", s.importStockCounts)
+				stock.GET("/counts/:id/variances", s.getStockCountVariances)
+				stock.POST("/counts/:id/complete", s.completeStockCount)
+				stock.POST("/counts/:id/cancel", s.cancelStockCount)
+			}
+
+			// Stock reservation routes for external order sources
+			stockReservations := protected.Group("/stock-reservations")
+			{
+				stockReservations.GET("", s.listStockReservations)
+				stockReservations.POST("", s.createStockReservation)
+				stockReservations.GET("/:id", s.getStockReservation)
+				stockReservations.POST("/:id/confirm", s.confirmStockReservation)
+				stockReservations.POST("/:id/release", s.releaseStockReservation)
+				stockReservations.PUT("/:id/expiry", s.extendStockReservation)
+				stockReservations.GET("/reference/:reference", s.getStockReservationsByReference)
+				stockReservations.GET("/:id/history", s.getResourceHistory("stock_reservation", "stock"))
+			}
+
+			// E-commerce integration routes for Shopify and WooCommerce stores
+			ecommerce := protected.Group("/ecommerce-integrations")
+			{
+				ecommerce.GET("", s.listEcommerceIntegrations)
+				ecommerce.POST("", s.createEcommerceIntegration)
+				ecommerce.GET("/:id", s.getEcommerceIntegration)
+				ecommerce.PUT("/:id", s.updateEcommerceIntegration)
+				ecommerce.DELETE("/:id", s.deleteEcommerceIntegration)
+				ecommerce.GET("/:id/orders", s.listEcommerceOrders)
+				ecommerce.GET("/:id/product-links", s.listEcommerceProductLinks)
+				ecommerce.POST("/:id/product-links", s.createEcommerceProductLink)
+				ecommerce.DELETE("/:id/product-links/:link_id", s.deleteEcommerceProductLink)
+			}
+
+			// Payment gateway transaction routes
+			paymentTransactions := protected.Group("/payment-transactions")
+			{
+				paymentTransactions.GET("", s.listPaymentTransactions)
+				paymentTransactions.GET("/:id", s.getPaymentTransaction)
+			}
+
+			// Purchase order routes for replenishing stock from suppliers
+			purchaseOrders := protected.Group("/purchase-orders")
+			{
+				purchaseOrders.GET("", s.listPurchaseOrders)
+				purchaseOrders.POST("", s.createPurchaseOrder)
+				purchaseOrders.GET("/reorder-suggestions", s.getReorderSuggestions)
+				purchaseOrders.POST("/reorder-drafts", s.createReorderDrafts)
+				purchaseOrders.GET("/:id", s.getPurchaseOrder)
+				purchaseOrders.PUT("/:id", s.updatePurchaseOrder)
+				purchaseOrders.POST("/:id/order", s.orderPurchaseOrder)
+				purchaseOrders.POST("/:id/receive", s.receivePurchaseOrder)
+				purchaseOrders.GET("/:id/receivings", s.listPurchaseOrderReceivings)
+				purchaseOrders.POST("/:id/receivings", s.startPurchaseOrderReceiving)
+				purchaseOrders.GET("/:id/receivings/:receiving_id", s.getPurchaseOrderReceiving)
+				purchaseOrders.POST("/:id/receivings/:receiving_id/scans", s.submitPurchaseOrderScans)
+				purchaseOrders.GET("/:id/receivings/:receiving_id/discrepancies", s.getPurchaseOrderReceivingDiscrepancies)
+				purchaseOrders.POST("/:id/receivings/:receiving_id/complete", s.completePurchaseOrderReceiving)
+				purchaseOrders.POST("/:id/receivings/:receiving_id/cancel", s.cancelPurchaseOrderReceiving)
+				purchaseOrders.POST("/:id/cancel", s.cancelPurchaseOrder)
+				purchaseOrders.GET("/:id/history", s.getResourceHistory("purchase_order", "purchase_orders"))
+			}
+
+			// Quote routes for pricing sales ahead and converting accepted quotes into sales
+			quotes := protected.Group("/quotes")
+			{
+				quotes.GET("", s.listQuotes)
+				quotes.POST("", s.createQuote)
+				quotes.GET("/:id", s.getQuote)
+				quotes.PUT("/:id", s.updateQuote)
+				quotes.GET("/:id/pdf", s.downloadQuotePDF)
+				quotes.POST("/:id/send", s.sendQuote)
+				quotes.POST("/:id/accept", s.acceptQuote)
+				quotes.POST("/:id/reject", s.rejectQuote)
+				quotes.POST("/:id/convert", s.convertQuoteToSale)
+				quotes.GET("/:id/history", s.getResourceHistory("quote", "quotes"))
+			}
+
+			// Location routes for the stores and warehouses stock is held at
+			locations := protected.Group("/locations")
+			{
+				locations.GET("", s.listLocations)
+				locations.POST("", s.createLocation)
+				locations.GET("/:id", s.getLocation)
+				locations.PUT("/:id", s.updateLocation)
+				locations.GET("/:id/stock", s.listLocationStock)
+				locations.PUT("/:id/stock", s.setLocationStock)
+				locations.GET("/:id/history", s.getResourceHistory("location", "locations"))
+			}
+
+			// Stock transfer routes for moving stock between locations
+			stockTransfers := protected.Group("/stock-transfers")
+			{
+				stockTransfers.GET("", s.listStockTransfers)
+				stockTransfers.POST("", s.createStockTransfer)
+				stockTransfers.GET("/in-transit", s.listInTransitStockTransfers)
+				stockTransfers.GET("/:id", s.getStockTransfer)
+				stockTransfers.POST("/:id/ship", s.shipStockTransfer)
+				stockTransfers.POST("/:id/receive", s.receiveStockTransfer)
+				stockTransfers.POST("/:id/cancel", s.cancelStockTransfer)
+				stockTransfers.GET("/:id/history", s.getResourceHistory("stock_transfer", "locations"))
+			}
+
+			// Serial number registry routes
+			serials := protected.Group("/serials")
+			{
+				serials.GET("", s.listSerialNumbers)
+				serials.POST("", s.registerSerialNumbers)
+				serials.GET("/lookup/:serial", s.lookupSerialNumber)
+			}
+
+			// Supplier directory routes
+			suppliers := protected.Group("/suppliers")
+			{
+				suppliers.GET("", s.listSuppliers)
+				suppliers.POST("", s.createSupplier)
+				suppliers.GET("/:id", s.getSupplier)
+				suppliers.PUT("/:id", s.updateSupplier)
+				suppliers.DELETE("/:id", s.deleteSupplier)
+				suppliers.GET("/:id/products", s.listSupplierProducts)
+				suppliers.GET("/:id/history", s.getResourceHistory("supplier", "suppliers"))
+			}
+
+			// Customer routes for merging customers recorded more than once
+			customers := protected.Group("/customers")
+			{
+				customers.GET("/duplicates", s.listCustomerDuplicates)
+				customers.POST("/duplicates/:id/dismiss", s.dismissCustomerDuplicate)
+				customers.POST("/merge", s.mergeCustomers)
+				customers.GET("/:id", s.getCustomer)
+				customers.GET("/:id/history", s.getResourceHistory("customer", "customers"))
+			}
+
+			// Kiosk device management routes
+			kioskDevices := protected.Group("/kiosk-devices")
+			{
+				kioskDevices.GET("", s.listKioskDevices)
+				kioskDevices.POST("", s.registerKioskDevice)
+				kioskDevices.DELETE("/:id", s.revokeKioskDevice)
+			}
+
+			// API key management routes
+			apiKeys := protected.Group("/api-keys")
+			{
+				apiKeys.GET("", s.listAPIKeys)
+				apiKeys.POST("", s.createAPIKey)
+				apiKeys.DELETE("/:id", s.revokeAPIKey)
+			}
+
+			// Staff time clock routes
+			timeClock := protected.Group("/time-clock")
+			{
+				timeClock.GET("/current", s.getCurrentShift)
+				timeClock.POST("/clock-in", s.clockIn)
+				timeClock.POST("/clock-out", s.clockOut)
+				timeClock.POST("/breaks/start", s.startBreak)
+				timeClock.POST("/breaks/end", s.endBreak)
+				timeClock.GET("/timesheet", s.getTimesheet)
 			}
 
 			// Sales management routes
 			sales := protected.Group("/sales")
 			{
 				sales.GET("", s.listSales)
-				sales.POST("", s.createSale)
+				sales.GET("/export", s.exportSales)
+				sales.GET("/returns/lookup", s.lookupSaleForReturn)
+				sales.POST("", s.IdempotencyMiddleware(), s.createSale)
 				sales.GET("/:id", s.getSale)
 				sales.PUT("/:id/cancel", s.cancelSale)
 				sales.POST("/:id/items", s.addSaleItem)
+				sales.POST("/:id/items/batch", s.addSaleItems)
 				sales.PUT("/:id/items", s.updateSaleItem)
+				sales.PUT("/:id/items/price", s.overrideSaleItemPrice)
+				sales.PUT("/:id/items/serials", s.setSaleItemSerialNumbers)
 				sales.DELETE("/:id/items/:productId", s.removeSaleItem)
-				sales.POST("/:id/complete", s.completeSale)
+				sales.POST("/:id/complete", s.IdempotencyMiddleware(), s.completeSale)
+				sales.POST("/:id/payment-intents", s.createSalePaymentIntent)
+				sales.POST("/:id/receipt", s.issueSaleReceipt)
+				sales.POST("/:id/hold", s.holdSale)
+				sales.POST("/:id/resume", s.resumeSale)
+				sales.POST("/:id/refunds", s.refundSale)
+				sales.GET("/:id/refunds", s.getSaleRefunds)
 				sales.GET("/number/:saleNumber", s.getSaleBySaleNumber)
+				sales.GET("/:id/history", s.getResourceHistory("sale", "sales"))
+				sales.POST("/:id/attachments", s.uploadAttachment(entities.AttachmentOwnerSale, "sales"))
+				sales.GET("/:id/attachments", s.listAttachments(entities.AttachmentOwnerSale, "sales"))
+				sales.GET("/:id/attachments/:attachment_id", s.downloadAttachment(entities.AttachmentOwnerSale, "sales"))
+				sales.DELETE("/:id/attachments/:attachment_id", s.deleteAttachment(entities.AttachmentOwnerSale, "sales"))
+			}
+
+			// Manager override routes
+			overrides := protected.Group("/manager-overrides")
+			{
+				overrides.POST("", s.issueManagerOverride)
+			}
+
+			// Checkout rule routes
+			checkoutRules := protected.Group("/checkout-rules")
+			{
+				checkoutRules.GET("", s.listCheckoutRules)
+				checkoutRules.POST("", s.createCheckoutRule)
+				checkoutRules.GET("/:id", s.getCheckoutRule)
+				checkoutRules.PUT("/:id", s.updateCheckoutRule)
+				checkoutRules.DELETE("/:id", s.deleteCheckoutRule)
+				checkoutRules.GET("/:id/history", s.getResourceHistory("checkout_rule", "checkout_rules"))
+			}
+
+			// Payment surcharge routes
+			paymentSurcharges := protected.Group("/payment-surcharges")
+			{
+				paymentSurcharges.GET("", s.listPaymentSurcharges)
+				paymentSurcharges.POST("", s.createPaymentSurcharge)
+				paymentSurcharges.PUT("/:id", s.updatePaymentSurcharge)
+				paymentSurcharges.DELETE("/:id", s.deletePaymentSurcharge)
+				paymentSurcharges.GET("/:id/history", s.getResourceHistory("payment_surcharge", "payment_surcharges"))
+			}
+
+			// Coupon routes
+			coupons := protected.Group("/coupons")
+			{
+				coupons.GET("", s.listCoupons)
+				coupons.POST("", s.createCoupon)
+				coupons.POST("/validate", s.validateCoupon)
+				coupons.GET("/performance", s.getCouponPerformanceReport)
+				coupons.GET("/:id", s.getCoupon)
+				coupons.PUT("/:id", s.updateCoupon)
+				coupons.GET("/:id/redemptions", s.getCouponRedemptions)
+				coupons.GET("/:id/history", s.getResourceHistory("coupon", "coupons"))
+			}
+
+			// Tax rate routes
+			taxRates := protected.Group("/tax-rates")
+			{
+				taxRates.GET("", s.listTaxRates)
+				taxRates.POST("", s.createTaxRate)
+				taxRates.GET("/:id", s.getTaxRate)
+				taxRates.PUT("/:id", s.updateTaxRate)
+				taxRates.GET("/:id/history", s.getResourceHistory("tax_rate", "tax_rates"))
 			}
 
+			// GraphQL queries of products, stock, sales and invoices for dashboards
+			protected.POST("/graphql", s.graphql)
+
 			// Invoice management routes
 			invoices := protected.Group("/invoices")
 			{
 				invoices.GET("", s.listInvoices)
+				invoices.GET("/export", s.exportInvoices)
 				invoices.POST("", s.createInvoice)
 				invoices.GET("/:id", s.getInvoice)
 				invoices.PUT("/:id/paid", s.markInvoiceAsPaid)
+				invoices.POST("/:id/payments", s.recordInvoicePayment)
+				invoices.GET("/:id/payments", s.listInvoicePayments)
+				invoices.POST("/:id/payment-intents", s.createInvoicePaymentIntent)
+				invoices.PUT("/:id/payment-schedule", s.setInvoicePaymentSchedule)
+				invoices.PUT("/:id/customer-tax-id", s.setInvoiceCustomerTaxID)
+				invoices.POST("/:id/share-links", s.createInvoiceShareLink)
+				invoices.GET("/:id/share-links", s.listInvoiceShareLinks)
+				invoices.PUT("/:id/share-links/:link_id", s.updateInvoiceShareLink)
+				invoices.DELETE("/:id/share-links/:link_id", s.revokeInvoiceShareLink)
 				invoices.PUT("/:id/cancel", s.cancelInvoice)
 				invoices.GET("/:id/pdf", s.generateInvoicePDF)
 				invoices.GET("/:id/preview", s.getInvoicePreview)
 				invoices.POST("/:id/email", s.sendInvoiceEmail)
+				invoices.GET("/:id/emails", s.getInvoiceEmails)
+				invoices.GET("/:id/reminders", s.getInvoiceReminders)
 				invoices.POST("/:id/print", s.printInvoice)
 				invoices.GET("/number/:invoiceNumber", s.getInvoiceByNumber)
 				invoices.GET("/overdue", s.getOverdueInvoices)
 				invoices.GET("/templates", s.getInvoiceTemplates)
+				invoices.POST("/templates", s.createInvoiceTemplate)
+				invoices.POST("/templates/preview", s.previewInvoiceTemplateDesign)
+				invoices.GET("/templates/:id", s.getInvoiceTemplate)
+				invoices.PUT("/templates/:id", s.updateInvoiceTemplate)
+				invoices.DELETE("/templates/:id", s.deleteInvoiceTemplate)
+				invoices.PUT("/templates/:id/default", s.setDefaultInvoiceTemplate)
+				invoices.GET("/templates/:id/preview", s.previewInvoiceTemplate)
+				invoices.PUT("/templates/:id/logo", s.uploadInvoiceTemplateLogo)
+				invoices.GET("/templates/:id/logo", s.getInvoiceTemplateLogo)
+				invoices.DELETE("/templates/:id/logo", s.deleteInvoiceTemplateLogo)
 				invoices.GET("/paper-sizes", s.getPaperSizes)
 				invoices.GET("/printers", s.getAvailablePrinters)
+				invoices.GET("/:id/history", s.getResourceHistory("invoice", "invoices"))
+				invoices.POST("/:id/attachments", s.uploadAttachment(entities.AttachmentOwnerInvoice, "invoices"))
+				invoices.GET("/:id/attachments", s.listAttachments(entities.AttachmentOwnerInvoice, "invoices"))
+				invoices.GET("/:id/attachments/:attachment_id", s.downloadAttachment(entities.AttachmentOwnerInvoice, "invoices"))
+				invoices.DELETE("/:id/attachments/:attachment_id", s.deleteAttachment(entities.AttachmentOwnerInvoice, "invoices"))
+				invoices.POST("/bulk-email", s.bulkEmailInvoices)
+				invoices.GET("/bulk-email", s.listInvoiceEmailBatches)
+				invoices.GET("/bulk-email/:id", s.getInvoiceEmailBatch)
+				invoices.POST("/:id/signature", s.captureInvoiceSignature)
+				invoices.GET("/:id/signature", s.getInvoiceSignature)
+				invoices.GET("/:id/signature/image", s.getInvoiceSignatureImage)
+				invoices.POST("/number-reservations", s.reserveInvoiceNumber)
+				invoices.GET("/number-reservations", s.listInvoiceNumberReservations)
+				invoices.GET("/number-reservations/:id", s.getInvoiceNumberReservation)
+				invoices.POST("/number-reservations/:id/bind", s.bindInvoiceNumberReservation)
+				invoices.POST("/number-reservations/:id/void", s.voidInvoiceNumberReservation)
+			}
+
+			// Printer routes
+			printers := protected.Group("/printers")
+			{
+				printers.GET("", s.listPrinters)
+				printers.POST("", s.registerPrinter)
+				printers.GET("/:id", s.getPrinter)
+				printers.DELETE("/:id", s.deletePrinter)
+				printers.POST("/:id/test", s.testPrinter)
+			}
+
+			// Register session routes
+			registers := protected.Group("/registers")
+			{
+				registers.GET("/current", s.getCurrentRegisterSession)
+				registers.GET("/reconciliation", s.getDailyRegisterReconciliation)
+				registers.GET("/sessions", s.listRegisterSessions)
+				registers.POST("/sessions", s.openRegisterSession)
+				registers.GET("/sessions/:id", s.getRegisterSession)
+				registers.POST("/sessions/:id/movements", s.recordRegisterCashMovement)
+				registers.POST("/sessions/:id/close", s.closeRegisterSession)
+				registers.GET("/sessions/:id/reconciliation", s.getRegisterSessionReconciliation)
+				registers.GET("/sessions/:id/x-report", s.getRegisterXReport)
+				registers.POST("/sessions/:id/z-report", s.generateRegisterZReport)
+				registers.POST("/sessions/:id/reports/print", s.printRegisterReport)
+			}
+
+			// Checkout session routes
+			checkout := protected.Group("/checkout/sessions")
+			{
+				checkout.POST("", s.IdempotencyMiddleware(), s.startCheckoutSession)
+				checkout.GET("/:id", s.getCheckoutSession)
+				checkout.PUT("/:id/cart", s.setCheckoutCart)
+				checkout.PUT("/:id/customer", s.setCheckoutCustomer)
+				checkout.POST("/:id/evaluate", s.evaluateCheckoutSession)
+				checkout.POST("/:id/payment-intent", s.createCheckoutPaymentIntent)
+				checkout.POST("/:id/complete", s.completeCheckoutSession)
+				checkout.POST("/:id/cancel", s.cancelCheckoutSession)
+			}
+
+			// Email opt-out routes
+			emailSuppressions := protected.Group("/email-suppressions")
+			{
+				emailSuppressions.GET("", s.listEmailSuppressions)
+				emailSuppressions.POST("", s.createEmailSuppression)
+				emailSuppressions.DELETE("/:email", s.deleteEmailSuppression)
+			}
+
+			// Dashboard routes
+			dashboard := protected.Group("/dashboard")
+			{
+				dashboard.GET("", s.getBusinessDashboard)
+				dashboard.GET("/inventory", s.getInventoryDashboard)
 			}
 
 			// Reports routes
@@ -209,6 +912,30 @@ func (s *Server) setupRoutes() {
 				reports.GET("/sales/daily", s.getDailySalesReport)
 				reports.GET("/invoices", s.getInvoiceReport)
 				reports.GET("/products/top-selling", s.getTopSellingProducts)
+				reports.GET("/products/profitability", s.getProductProfitabilityReport)
+				reports.GET("/discounts", s.getDiscountReport)
+				reports.GET("/tax-summary", s.getTaxSummaryReport)
+				reports.GET("/margin-violations", s.getMarginViolationReport)
+				reports.GET("/low-stock", s.getLowStockReport)
+				reports.GET("/inventory-valuation", s.getInventoryValuationReport)
+				reports.GET("/analytics/catalog", s.getAnalyticsCatalog)
+				reports.POST("/analytics/query", s.runAnalyticsQuery)
+			}
+
+			// Accounting export routes
+			accounting := protected.Group("/accounting")
+			{
+				accounting.GET("/account-mapping", s.getAccountingAccountMapping)
+				accounting.PUT("/account-mapping", s.updateAccountingAccountMapping)
+				accounting.GET("/journal", s.getAccountingJournal)
+			}
+
+			// Tax invoice routes
+			taxInvoices := protected.Group("/tax-invoices")
+			{
+				taxInvoices.POST("/serial-ranges", s.createTaxInvoiceSerialRange)
+				taxInvoices.GET("/serial-ranges", s.listTaxInvoiceSerialRanges)
+				taxInvoices.GET("/export", s.exportTaxInvoices)
 			}
 
 			// Tenant management routes (require tenant context)
@@ -219,7 +946,61 @@ func (s *Server) setupRoutes() {
 				tenant.PUT("/info", s.updateTenant)
 				tenant.GET("/settings", s.getTenantSettings)
 				tenant.PUT("/settings", s.updateTenantSettings)
+				tenant.GET("/currency-format", s.getCurrencyFormat)
+				tenant.PUT("/currency-format", s.updateCurrencyFormat)
 				tenant.POST("/switch", s.switchTenant)
+				tenant.POST("/exports", s.requestTenantExport)
+				tenant.GET("/exports", s.listTenantExports)
+				tenant.GET("/exports/:id", s.getTenantExport)
+				tenant.GET("/exports/:id/download", s.getTenantExportDownload)
+				tenant.GET("/digest", s.getDailyDigestSettings)
+				tenant.PUT("/digest", s.updateDailyDigestSettings)
+				tenant.GET("/digest/preview", s.previewDailyDigest)
+				tenant.GET("/warehouse", s.getWarehouseExportSettings)
+				tenant.PUT("/warehouse", s.updateWarehouseExportSettings)
+				tenant.GET("/warehouse/manifest", s.getWarehouseManifest)
+				tenant.GET("/retention/policies", s.getRetentionPolicies)
+				tenant.PUT("/retention/policies/:entity", s.updateRetentionPolicy)
+				tenant.GET("/retention/preview", s.previewRetentionPurge)
+				tenant.GET("/retention/holds", s.listRetentionHolds)
+				tenant.POST("/retention/holds", s.createRetentionHold)
+				tenant.DELETE("/retention/holds/:id", s.releaseRetentionHold)
+				tenant.GET("/retention/deleted/:entity", s.listDeletedRecords)
+				tenant.POST("/retention/deleted/:entity/:id/restore", s.restoreDeletedRecord)
+				tenant.GET("/audit/verify", s.verifyAuditTrail)
+				tenant.POST("/maintenance/recalculations", s.previewTotalsRecalculation)
+				tenant.GET("/maintenance/recalculations", s.listTotalsRecalculations)
+				tenant.GET("/maintenance/recalculations/:id", s.getTotalsRecalculation)
+				tenant.POST("/maintenance/recalculations/:id/apply", s.applyTotalsRecalculation)
+				tenant.GET("/margin-floors", s.listMarginFloors)
+				tenant.PUT("/margin-floors", s.setMarginFloor)
+				tenant.DELETE("/margin-floors/:category", s.deleteMarginFloor)
+				tenant.GET("/margin-alerts", s.getMarginAlertSettings)
+				tenant.PUT("/margin-alerts", s.updateMarginAlertSettings)
+				tenant.GET("/delivery-policy", s.getDeliveryPolicy)
+				tenant.PUT("/delivery-policy", s.updateDeliveryPolicy)
+				tenant.GET("/email-templates", s.listEmailTemplates)
+				tenant.GET("/email-templates/:kind", s.getEmailTemplate)
+				tenant.PUT("/email-templates/:kind", s.updateEmailTemplate)
+				tenant.DELETE("/email-templates/:kind", s.resetEmailTemplate)
+				tenant.POST("/email-templates/:kind/preview", s.previewEmailTemplate)
+				tenant.GET("/webhooks", s.listWebhookEndpoints)
+				tenant.POST("/webhooks", s.createWebhookEndpoint)
+				tenant.PUT("/webhooks/:id", s.updateWebhookEndpoint)
+				tenant.DELETE("/webhooks/:id", s.deleteWebhookEndpoint)
+				tenant.POST("/webhooks/:id/rotate-secret", s.rotateWebhookSecret)
+				tenant.POST("/webhooks/:id/replay", s.replayWebhookEvents)
+				tenant.GET("/webhook-deliveries", s.listWebhookDeliveries)
+				tenant.GET("/webhook-deliveries/:id", s.getWebhookDelivery)
+				tenant.POST("/webhook-deliveries/:id/redeliver", s.redeliverWebhookDelivery)
+				tenant.GET("/alerts", s.listAlerts)
+				tenant.POST("/alerts/:id/acknowledge", s.acknowledgeAlert)
+				tenant.POST("/alerts/:id/resolve", s.resolveAlert)
+				tenant.GET("/alert-channels", s.listAlertChannels)
+				tenant.POST("/alert-channels", s.createAlertChannel)
+				tenant.GET("/alert-channels/:id", s.getAlertChannel)
+				tenant.PUT("/alert-channels/:id", s.updateAlertChannel)
+				tenant.DELETE("/alert-channels/:id", s.deleteAlertChannel)
 			}
 
 			// Subscription management routes
@@ -238,6 +1019,11 @@ func (s *Server) setupRoutes() {
 				sysadmin.GET("/tenants", s.listTenants)
 				sysadmin.PUT("/tenants/:tenant_id/activate", s.activateTenant)
 				sysadmin.PUT("/tenants/:tenant_id/suspend", s.suspendTenant)
+				sysadmin.GET("/tenants/health-scores", s.listTenantHealthScores)
+				sysadmin.GET("/tenants/:tenant_id/health-score", s.getTenantHealthScore)
+				sysadmin.GET("/request-logs/:request_id", s.getAPIRequestLog)
+				sysadmin.GET("/tenants/:tenant_id/support-bundle", s.generateSupportBundle)
+				sysadmin.GET("/feature-adoption", s.getFeatureAdoptionReport)
 			}
 		}
 	}
@@ -259,6 +1045,31 @@ func (s *Server) healthCheck(c *gin.Context) {
 	}, "Service is healthy")
 }
 
+// readinessCheck reports whether the server is ready for traffic: the startup warm-up has
+// finished and the database is reachable
+func (s *Server) readinessCheck(c *gin.Context) {
+	if !s.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":    "warming_up",
+			"timestamp": time.Now().UTC(),
+			"service":   "adol-pos-api",
+		})
+		return
+	}
+
+	if err := s.db.Ping(); err != nil {
+		s.RespondWithError(c, errors.NewInternalError("Database connection failed", err))
+		return
+	}
+
+	s.RespondWithSuccess(c, gin.H{
+		"status":    "ready",
+		"timestamp": time.Now().UTC(),
+		"service":   "adol-pos-api",
+		"version":   "1.0.0",
+	}, "Service is ready")
+}
+
 // detailedHealthCheck provides detailed health information
 func (s *Server) detailedHealthCheck(c *gin.Context) {
 	health := s.health.RunChecks()
@@ -283,8 +1094,13 @@ func (s *Server) detailedHealthCheck(c *gin.Context) {
 	c.JSON(statusCode, response)
 }
 
-// metricsEndpoint provides application metrics
+// metricsEndpoint provides application metrics, in the Prometheus text format when enabled
 func (s *Server) metricsEndpoint(c *gin.Context) {
+	if s.config.Metrics.PrometheusEnabled {
+		s.prometheusMetrics(c)
+		return
+	}
+
 	metrics := s.metrics.GetAllMetrics()
 	s.RespondWithSuccess(c, gin.H{
 		"metrics":   metrics,
@@ -311,6 +1127,56 @@ func (s *Server) registerHealthChecks() {
 		}
 	})
 
+	// Read replica health check. Reads fall back to the primary while the replica is down or
+	// lagging, so this only degrades the service.
+	if s.replicas != nil && s.replicas.HasReplica() {
+		s.health.RegisterCheck("database_replica", func() monitoring.HealthCheck {
+			ctx, cancel := context.WithTimeout(context.Background(), replicaCheckTimeout)
+			defer cancel()
+
+			if err := s.replicas.CheckReplica(ctx); err != nil {
+				return monitoring.HealthCheck{
+					Name:    "database_replica",
+					Status:  monitoring.HealthStatusDegraded,
+					Message: "Reads are served by the primary: " + err.Error(),
+				}
+			}
+			return monitoring.HealthCheck{
+				Name:    "database_replica",
+				Status:  monitoring.HealthStatusHealthy,
+				Message: "Read replica is caught up",
+			}
+		})
+	}
+
+	// SIEM shipping health check
+	if s.siem != nil {
+		s.health.RegisterCheck("siem", func() monitoring.HealthCheck {
+			stats := s.siem.Stats()
+			details := map[string]interface{}{
+				"queued":  stats.Queued,
+				"shipped": stats.Shipped,
+				"dropped": stats.Dropped,
+				"failed":  stats.Failed,
+			}
+			// A buffer filling up means the SIEM cannot keep up and events will soon be dropped
+			if stats.Queued > s.config.SIEM.BufferSize/2 {
+				return monitoring.HealthCheck{
+					Name:    "siem",
+					Status:  monitoring.HealthStatusDegraded,
+					Message: fmt.Sprintf("SIEM shipping is falling behind: %d events queued", stats.Queued),
+					Details: details,
+				}
+			}
+			return monitoring.HealthCheck{
+				Name:    "siem",
+				Status:  monitoring.HealthStatusHealthy,
+				Message: "SIEM shipping is keeping up",
+				Details: details,
+			}
+		})
+	}
+
 	// Memory health check
 	s.health.RegisterCheck("memory", func() monitoring.HealthCheck {
 		var m runtime.MemStats
@@ -356,8 +1222,8 @@ func (s *Server) startMetricsCollection() {
 func corsMiddleware() gin.HandlerFunc {
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
-	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID"}
+	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID", "X-Manager-Override", "Idempotency-Key"}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"}
-	config.ExposeHeaders = []string{"X-Request-ID"}
+	config.ExposeHeaders = []string{"X-Request-ID", "Idempotent-Replayed"}
 	return cors.New(config)
 }
\ No newline at end of file
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listStockCounts handles listing stock counts
+func (s *Server) listStockCounts(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.StockCountFilter{
+		TenantID: GetTenantID(c),
+	}
+
+	if status := c.Query("status"); status != "" {
+		countStatus := entities.StockCountStatus(status)
+		if err := entities.ValidateStockCountStatus(countStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &countStatus
+	}
+
+	var err error
+	if filter.LocationID, err = queryUUID(c, "location_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.stockCountUseCase.ListCounts(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// startStockCount handles starting a stock count
+func (s *Server) startStockCount(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "count"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.StartStockCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	count, err := s.stockCountUseCase.StartCount(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Stock count started successfully",
+		"data":    count,
+	})
+}
+
+// getStockCount handles retrieving a stock count with its counted lines
+func (s *Server) getStockCount(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	countID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock count ID", err.Error()))
+		return
+	}
+
+	count, err := s.stockCountUseCase.GetCount(c.Request.Context(), GetTenantID(c), countID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": count,
+	})
+}
+
+// submitStockCountScans handles a batch of scans uploaded by a handheld scanner. Bad scans
+// are reported in their line's result rather than failing the batch.
+func (s *Server) submitStockCountScans(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "count"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	countID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock count ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SubmitStockCountScansRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.stockCountUseCase.SubmitScans(c.Request.Context(), GetTenantID(c), userID, countID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// importStockCounts handles counted quantities uploaded as a CSV file, either as the
+// "file" field of a multipart form or as the request body
+func (s *Server) importStockCounts(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "count"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	countID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock count ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var file io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("file is required", err.Error()))
+			return
+		}
+		upload, err := header.Open()
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid file", err.Error()))
+			return
+		}
+		defer upload.Close()
+		file = upload
+	}
+
+	response, err := s.stockCountUseCase.ImportCounts(c.Request.Context(), GetTenantID(c), userID, countID, file)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Counts imported successfully",
+		"data":    response,
+	})
+}
+
+// getStockCountVariances handles summarizing the variances a stock count found
+func (s *Server) getStockCountVariances(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	countID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock count ID", err.Error()))
+		return
+	}
+
+	variances, err := s.stockCountUseCase.GetVariances(c.Request.Context(), GetTenantID(c), countID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": variances,
+	})
+}
+
+// completeStockCount handles approving a stock count and posting its variances to stock
+func (s *Server) completeStockCount(c *gin.Context) {
+	s.finishStockCount(c, s.stockCountUseCase.CompleteCount, "Stock count completed successfully")
+}
+
+// cancelStockCount handles abandoning a stock count without changing stock
+func (s *Server) cancelStockCount(c *gin.Context) {
+	s.finishStockCount(c, s.stockCountUseCase.CancelCount, "Stock count cancelled successfully")
+}
+
+// finishStockCount closes a stock count with the given use case action
+func (s *Server) finishStockCount(c *gin.Context, finish func(ctx context.Context, tenantID, userID, countID uuid.UUID) (*entities.StockCount, error), message string) {
+	if err := s.checkPermission(c, "stock", "adjust"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	countID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock count ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	count, err := finish(c.Request.Context(), GetTenantID(c), userID, countID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    count,
+	})
+}
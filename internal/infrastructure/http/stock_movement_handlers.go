@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// reverseStockMovement handles undoing a mistaken stock adjustment with the opposite movement
+func (s *Server) reverseStockMovement(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "adjust"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	movementID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock movement ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ReverseStockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reversal, err := s.stockUseCase.ReverseStockMovement(c.Request.Context(), GetTenantID(c), userID, movementID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Stock movement reversed successfully",
+		"data":    reversal,
+	})
+}
+
+// getProductStockLedger handles retrieving a product's running-balance stock ledger. The
+// inclusive from_date and to_date query parameters are YYYY-MM-DD dates and default to the
+// last 30 days.
+func (s *Server) getProductStockLedger(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+		return
+	}
+
+	fromDate, toDate, err := parseReportDateRange(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ledger, err := s.stockUseCase.GetProductStockLedger(c.Request.Context(), productID, fromDate, toDate)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": ledger,
+	})
+}
@@ -0,0 +1,258 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listStockReservations handles listing stock reservations with pagination and filtering
+func (s *Server) listStockReservations(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.StockReservationFilter{
+		TenantID:  GetTenantID(c),
+		Reference: c.Query("reference"),
+		Source:    c.Query("source"),
+		OrderBy:   c.DefaultQuery("order_by", "created_at"),
+		OrderDir:  c.DefaultQuery("order_dir", "DESC"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		reservationStatus := entities.StockReservationStatus(status)
+		if err := entities.ValidateStockReservationStatus(reservationStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &reservationStatus
+	}
+
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+			return
+		}
+		filter.ProductID = &id
+	}
+
+	response, err := s.stockReservationUseCase.ListReservations(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// createStockReservation handles holding stock for an external order
+func (s *Server) createStockReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "reserve"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateStockReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reservation, err := s.stockReservationUseCase.CreateReservation(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Stock reserved successfully",
+		"data":    reservation,
+	})
+}
+
+// getStockReservation handles retrieving a stock reservation
+func (s *Server) getStockReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	reservation, err := s.stockReservationUseCase.GetReservation(c.Request.Context(), GetTenantID(c), reservationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reservation,
+	})
+}
+
+// getStockReservationsByReference handles retrieving stock reservations for an external order reference
+func (s *Server) getStockReservationsByReference(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservations, err := s.stockReservationUseCase.GetReservationsByReference(c.Request.Context(), GetTenantID(c), c.Param("reference"))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reservations,
+	})
+}
+
+// confirmStockReservation handles confirming part or all of a stock reservation
+func (s *Server) confirmStockReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "reserve"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	// An empty body confirms all outstanding quantities
+	var req usecases.ConfirmStockReservationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	reservation, err := s.stockReservationUseCase.ConfirmReservation(c.Request.Context(), GetTenantID(c), userID, reservationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock reservation confirmed successfully",
+		"data":    reservation,
+	})
+}
+
+// releaseStockReservation handles releasing the outstanding stock of a reservation
+func (s *Server) releaseStockReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "reserve"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	var req usecases.ReleaseStockReservationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+			return
+		}
+	}
+
+	reservation, err := s.stockReservationUseCase.ReleaseReservation(c.Request.Context(), GetTenantID(c), userID, reservationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock reservation released successfully",
+		"data":    reservation,
+	})
+}
+
+// extendStockReservation handles extending the expiry of a stock reservation
+func (s *Server) extendStockReservation(c *gin.Context) {
+	if err := s.checkPermission(c, "stock", "reserve"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid reservation ID", err.Error()))
+		return
+	}
+
+	var req usecases.ExtendStockReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	reservation, err := s.stockReservationUseCase.ExtendReservation(c.Request.Context(), GetTenantID(c), userID, reservationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock reservation extended successfully",
+		"data":    reservation,
+	})
+}
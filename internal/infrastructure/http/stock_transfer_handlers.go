@@ -0,0 +1,245 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listStockTransfers handles listing stock transfers with pagination and filtering
+func (s *Server) listStockTransfers(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	var filter repositories.StockTransferFilter
+
+	if status := c.Query("status"); status != "" {
+		transferStatus := entities.StockTransferStatus(status)
+		if err := entities.ValidateStockTransferStatus(transferStatus); err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		filter.Status = &transferStatus
+	}
+
+	var err error
+	if filter.LocationID, err = queryUUID(c, "location_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if filter.ProductID, err = queryUUID(c, "product_id"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.stockTransferUseCase.ListTransfers(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// listInTransitStockTransfers handles listing the transfers shipped but not received yet
+func (s *Server) listInTransitStockTransfers(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	locationID, err := queryUUID(c, "location_id")
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	response, err := s.stockTransferUseCase.ListInTransitTransfers(c.Request.Context(), locationID, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// createStockTransfer handles requesting a stock transfer between locations
+func (s *Server) createStockTransfer(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	transfer, err := s.stockTransferUseCase.CreateTransfer(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Stock transfer requested successfully",
+		"data":    transfer,
+	})
+}
+
+// getStockTransfer handles retrieving a stock transfer
+func (s *Server) getStockTransfer(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock transfer ID", err.Error()))
+		return
+	}
+
+	transfer, err := s.stockTransferUseCase.GetTransfer(c.Request.Context(), GetTenantID(c), transferID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": transfer,
+	})
+}
+
+// shipStockTransfer handles shipping a stock transfer out of its source location
+func (s *Server) shipStockTransfer(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock transfer ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transfer, err := s.stockTransferUseCase.ShipTransfer(c.Request.Context(), GetTenantID(c), userID, transferID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock transfer shipped successfully",
+		"data":    transfer,
+	})
+}
+
+// receiveStockTransfer handles receiving a stock transfer at its destination location
+func (s *Server) receiveStockTransfer(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock transfer ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transfer, err := s.stockTransferUseCase.ReceiveTransfer(c.Request.Context(), GetTenantID(c), userID, transferID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock transfer received successfully",
+		"data":    transfer,
+	})
+}
+
+// cancelStockTransfer handles cancelling a stock transfer that has not been shipped
+func (s *Server) cancelStockTransfer(c *gin.Context) {
+	if err := s.checkPermission(c, "locations", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid stock transfer ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	transfer, err := s.stockTransferUseCase.CancelTransfer(c.Request.Context(), GetTenantID(c), userID, transferID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock transfer cancelled successfully",
+		"data":    transfer,
+	})
+}
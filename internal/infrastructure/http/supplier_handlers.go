@@ -0,0 +1,248 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listSuppliers handles listing suppliers with pagination and filtering
+func (s *Server) listSuppliers(c *gin.Context) {
+	if err := s.checkPermission(c, "suppliers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	filter := repositories.SupplierFilter{
+		Search:   c.Query("search"),
+		OrderBy:  c.DefaultQuery("order_by", "name"),
+		OrderDir: c.DefaultQuery("order_dir", "ASC"),
+	}
+
+	if isActive := c.Query("is_active"); isActive != "" {
+		active, err := strconv.ParseBool(isActive)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid is_active", "is_active must be true or false"))
+			return
+		}
+		filter.IsActive = &active
+	}
+
+	response, err := s.supplierUseCase.ListSuppliers(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// createSupplier handles creating a supplier
+func (s *Server) createSupplier(c *gin.Context) {
+	if err := s.checkPermission(c, "suppliers", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	supplier, err := s.supplierUseCase.CreateSupplier(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Supplier created successfully",
+		"data":    supplier,
+	})
+}
+
+// getSupplier handles retrieving a supplier
+func (s *Server) getSupplier(c *gin.Context) {
+	if err := s.checkPermission(c, "suppliers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid supplier ID", err.Error()))
+		return
+	}
+
+	supplier, err := s.supplierUseCase.GetSupplier(c.Request.Context(), GetTenantID(c), supplierID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": supplier,
+	})
+}
+
+// updateSupplier handles updating a supplier
+func (s *Server) updateSupplier(c *gin.Context) {
+	if err := s.checkPermission(c, "suppliers", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid supplier ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	supplier, err := s.supplierUseCase.UpdateSupplier(c.Request.Context(), GetTenantID(c), userID, supplierID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Supplier updated successfully",
+		"data":    supplier,
+	})
+}
+
+// deleteSupplier handles deleting a supplier
+func (s *Server) deleteSupplier(c *gin.Context) {
+	if err := s.checkPermission(c, "suppliers", "delete"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid supplier ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	if err := s.supplierUseCase.DeleteSupplier(c.Request.Context(), GetTenantID(c), userID, supplierID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Supplier deleted successfully",
+	})
+}
+
+// listSupplierProducts handles listing the products that prefer a supplier
+func (s *Server) listSupplierProducts(c *gin.Context) {
+	if err := s.checkPermission(c, "suppliers", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid supplier ID", err.Error()))
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	response, err := s.supplierUseCase.ListSupplierProducts(c.Request.Context(), GetTenantID(c), supplierID, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// assignProductSupplier handles setting or clearing a product's preferred supplier
+func (s *Server) assignProductSupplier(c *gin.Context) {
+	if err := s.checkPermission(c, "products", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid product ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.AssignProductSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	product, err := s.supplierUseCase.AssignProductSupplier(c.Request.Context(), GetTenantID(c), userID, productID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Product supplier updated successfully",
+		"data":    product,
+	})
+}
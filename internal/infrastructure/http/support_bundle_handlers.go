@@ -0,0 +1,70 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// generateSupportBundle handles downloading a support bundle for a tenant. The optional
+// from_date and to_date query parameters are RFC 3339 timestamps.
+func (s *Server) generateSupportBundle(c *gin.Context) {
+	if err := s.checkPermission(c, "support_bundles", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	tenantID, err := uuid.Parse(c.Param("tenant_id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid tenant ID", err.Error()))
+		return
+	}
+
+	var req usecases.SupportBundleRequest
+	if fromDateStr := c.Query("from_date"); fromDateStr != "" {
+		fromDate, err := time.Parse(time.RFC3339, fromDateStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid from_date", "from_date must be an RFC 3339 timestamp"))
+			return
+		}
+		req.FromDate = &fromDate
+	}
+	if toDateStr := c.Query("to_date"); toDateStr != "" {
+		toDate, err := time.Parse(time.RFC3339, toDateStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid to_date", "to_date must be an RFC 3339 timestamp"))
+			return
+		}
+		req.ToDate = &toDate
+	}
+	if thresholdStr := c.Query("slow_threshold_ms"); thresholdStr != "" {
+		threshold, err := strconv.Atoi(thresholdStr)
+		if err != nil || threshold <= 0 {
+			s.respondWithError(c, errors.NewValidationError("invalid slow_threshold_ms", "slow_threshold_ms must be a positive number"))
+			return
+		}
+		req.SlowThreshold = time.Duration(threshold) * time.Millisecond
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	bundle, err := s.supportBundleUseCase.GenerateBundle(c.Request.Context(), tenantID, userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bundle.FileName))
+	c.Data(http.StatusOK, "application/zip", bundle.Content)
+}
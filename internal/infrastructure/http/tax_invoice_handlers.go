@@ -0,0 +1,149 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// SetCustomerTaxIDRequest represents a request to set the tax ID of an invoice's customer
+type SetCustomerTaxIDRequest struct {
+	CustomerTaxID string `json:"customer_tax_id"`
+}
+
+// setInvoiceCustomerTaxID handles setting the tax ID of an invoice's customer, printed on its
+// tax invoice
+func (s *Server) setInvoiceCustomerTaxID(c *gin.Context) {
+	if err := s.checkPermission(c, "invoices", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req SetCustomerTaxIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	invoice, err := s.taxInvoiceExportUseCase.SetCustomerTaxID(c.Request.Context(), GetTenantID(c), userID, invoiceID, req.CustomerTaxID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Customer tax ID updated successfully",
+		"data":    invoice,
+	})
+}
+
+// createTaxInvoiceSerialRange handles registering a range of tax invoice serials allocated by the
+// tax authority
+func (s *Server) createTaxInvoiceSerialRange(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_invoices", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateTaxInvoiceSerialRangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	serialRange, err := s.taxInvoiceExportUseCase.CreateSerialRange(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tax invoice serial range created successfully",
+		"data":    serialRange,
+	})
+}
+
+// listTaxInvoiceSerialRanges handles listing the tenant's tax invoice serial ranges
+func (s *Server) listTaxInvoiceSerialRanges(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_invoices", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	ranges, err := s.taxInvoiceExportUseCase.ListSerialRanges(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": ranges,
+	})
+}
+
+// exportTaxInvoices handles downloading the tax invoices of the invoices paid between the
+// inclusive YYYY-MM-DD from and to dates, in the format of the format query parameter
+func (s *Server) exportTaxInvoices(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_invoices", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	now := time.Now()
+	fromDate, err := time.ParseInLocation(reportDateLayout, c.Query("from"), now.Location())
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid from", "from must be in YYYY-MM-DD format"))
+		return
+	}
+	toDate, err := time.ParseInLocation(reportDateLayout, c.Query("to"), now.Location())
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid to", "to must be in YYYY-MM-DD format"))
+		return
+	}
+
+	req := usecases.ExportTaxInvoicesRequest{
+		FromDate: fromDate,
+		ToDate:   toDate.AddDate(0, 0, 1),
+		Format:   c.DefaultQuery("format", "efaktur"),
+	}
+
+	file, err := s.taxInvoiceExportUseCase.ExportPaidInvoices(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("tax-invoices-%s-%s-%s.%s", req.Format, fromDate.Format(reportDateLayout), toDate.Format(reportDateLayout), file.FileExtension)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listTaxRates handles listing the tenant's tax rates
+func (s *Server) listTaxRates(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_rates", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	taxRates, err := s.taxRateUseCase.ListTaxRates(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": taxRates,
+	})
+}
+
+// createTaxRate handles creating a tax rate
+func (s *Server) createTaxRate(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_rates", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateTaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	taxRate, err := s.taxRateUseCase.CreateTaxRate(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tax rate created successfully",
+		"data":    taxRate,
+	})
+}
+
+// getTaxRate handles retrieving a tax rate
+func (s *Server) getTaxRate(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_rates", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	taxRateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid tax rate ID", err.Error()))
+		return
+	}
+
+	taxRate, err := s.taxRateUseCase.GetTaxRate(c.Request.Context(), GetTenantID(c), taxRateID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": taxRate,
+	})
+}
+
+// updateTaxRate handles renaming, changing or deactivating a tax rate
+func (s *Server) updateTaxRate(c *gin.Context) {
+	if err := s.checkPermission(c, "tax_rates", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	taxRateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid tax rate ID", err.Error()))
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateTaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	taxRate, err := s.taxRateUseCase.UpdateTaxRate(c.Request.Context(), GetTenantID(c), userID, taxRateID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tax rate updated successfully",
+		"data":    taxRate,
+	})
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// requestTenantExport handles requesting a full export of the tenant's data
+func (s *Server) requestTenantExport(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant", "export"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	export, err := s.tenantExportUseCase.RequestExport(c.Request.Context(), GetTenantID(c), userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Tenant export queued",
+		"data":    export,
+	})
+}
+
+// listTenantExports handles listing the tenant's export jobs
+func (s *Server) listTenantExports(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant", "export"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	exports, err := s.tenantExportUseCase.ListExports(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": exports,
+	})
+}
+
+// getTenantExport handles retrieving the status of an export job
+func (s *Server) getTenantExport(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant", "export"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid export ID", err.Error()))
+		return
+	}
+
+	export, err := s.tenantExportUseCase.GetExport(c.Request.Context(), GetTenantID(c), exportID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": export,
+	})
+}
+
+// getTenantExportDownload handles issuing a signed download link for a completed export
+func (s *Server) getTenantExportDownload(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant", "export"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid export ID", err.Error()))
+		return
+	}
+
+	link, err := s.tenantExportUseCase.GetDownloadLink(c.Request.Context(), GetTenantID(c), userID, exportID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": link,
+	})
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// listTenantHealthScores handles listing tenant health scores, worst first, for support triage
+func (s *Server) listTenantHealthScores(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant_health", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	scores, err := s.tenantMonitor.ListHealthScores(c.Request.Context())
+	if err != nil {
+		s.respondWithError(c, errors.NewInternalError("failed to compute tenant health scores", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": scores,
+	})
+}
+
+// getTenantHealthScore handles retrieving the health score breakdown of a single tenant
+func (s *Server) getTenantHealthScore(c *gin.Context) {
+	if err := s.checkPermission(c, "tenant_health", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	tenantID, err := uuid.Parse(c.Param("tenant_id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid tenant ID", err.Error()))
+		return
+	}
+
+	score, err := s.tenantMonitor.GetHealthScore(c.Request.Context(), tenantID)
+	if err != nil {
+		s.respondWithError(c, errors.NewInternalError("failed to compute tenant health score", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": score,
+	})
+}
@@ -73,8 +73,9 @@ func (tm *TenantMiddleware) TenantResolverMiddleware() gin.HandlerFunc {
 		// Store tenant context in gin context
 		c.Set(string(TenantContextKeyValue), tenantContext)
 
-		// Store tenant context in request context for database operations
+		// Store tenant context in request context and restrict repositories to the tenant's rows
 		ctx := context.WithValue(c.Request.Context(), TenantContextKeyValue, tenantContext)
+		ctx = entities.WithTenantScope(ctx, tenantContext.TenantID)
 		c.Request = c.Request.WithContext(ctx)
 
 		// Set database session variable for Row Level Security
@@ -0,0 +1,219 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// clockIn handles a staff member clocking in, optionally on a registered device
+func (s *Server) clockIn(c *gin.Context) {
+	if err := s.checkPermission(c, "time_clock", "clock"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ClockInRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	entry, err := s.timeClockUseCase.ClockIn(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Clocked in successfully",
+		"data":    entry,
+	})
+}
+
+// clockOut handles a staff member clocking out
+func (s *Server) clockOut(c *gin.Context) {
+	if err := s.checkPermission(c, "time_clock", "clock"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.ClockOutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	entry, err := s.timeClockUseCase.ClockOut(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Clocked out successfully",
+		"data":    entry,
+	})
+}
+
+// startBreak handles a staff member starting a break
+func (s *Server) startBreak(c *gin.Context) {
+	if err := s.checkPermission(c, "time_clock", "clock"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	entry, err := s.timeClockUseCase.StartBreak(c.Request.Context(), GetTenantID(c), userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Break started",
+		"data":    entry,
+	})
+}
+
+// endBreak handles a staff member ending a break
+func (s *Server) endBreak(c *gin.Context) {
+	if err := s.checkPermission(c, "time_clock", "clock"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	entry, err := s.timeClockUseCase.EndBreak(c.Request.Context(), GetTenantID(c), userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Break ended",
+		"data":    entry,
+	})
+}
+
+// getCurrentShift handles getting the shift the staff member is clocked in to
+func (s *Server) getCurrentShift(c *gin.Context) {
+	if err := s.checkPermission(c, "time_clock", "clock"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	entry, err := s.timeClockUseCase.GetCurrentShift(c.Request.Context(), GetTenantID(c), userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entry,
+	})
+}
+
+// getTimesheet handles the timesheet report for a pay period. The inclusive from_date and
+// to_date are interpreted in the requested time zone. Staff other than managers and admins
+// only see their own hours.
+func (s *Server) getTimesheet(c *gin.Context) {
+	if err := s.checkPermission(c, "timesheets", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	loc := time.UTC
+	if tz := c.Query("timezone"); tz != "" {
+		parsed, err := time.LoadLocation(tz)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid timezone", "timezone must be an IANA time zone name"))
+			return
+		}
+		loc = parsed
+	}
+
+	fromDate, err := time.ParseInLocation(reportDateLayout, c.Query("from_date"), loc)
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid from_date", "from_date must be in YYYY-MM-DD format"))
+		return
+	}
+	toDate, err := time.ParseInLocation(reportDateLayout, c.Query("to_date"), loc)
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid to_date", "to_date must be in YYYY-MM-DD format"))
+		return
+	}
+
+	req := usecases.TimesheetRequest{
+		FromDate: fromDate,
+		ToDate:   toDate.AddDate(0, 0, 1),
+		Location: loc,
+	}
+
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid user ID", err.Error()))
+			return
+		}
+		req.UserID = &userID
+	}
+
+	role, err := s.getCurrentUserRole(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if role != entities.RoleAdmin && role != entities.RoleManager {
+		userID, err := s.getCurrentUser(c)
+		if err != nil {
+			s.respondWithError(c, err)
+			return
+		}
+		req.UserID = &userID
+	}
+
+	timesheet, err := s.timeClockUseCase.GetTimesheet(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": timesheet,
+	})
+}
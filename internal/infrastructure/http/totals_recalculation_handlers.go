@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// previewTotalsRecalculation handles a dry run recalculating the stored totals of selected documents
+func (s *Server) previewTotalsRecalculation(c *gin.Context) {
+	if err := s.checkPermission(c, "maintenance", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.PreviewTotalsRecalculationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	recalculation, err := s.totalsRecalculationUseCase.PreviewRecalculation(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Totals recalculation previewed",
+		"data":    recalculation,
+	})
+}
+
+// applyTotalsRecalculation handles applying the corrections of a previewed recalculation
+func (s *Server) applyTotalsRecalculation(c *gin.Context) {
+	if err := s.checkPermission(c, "maintenance", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	recalculationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid recalculation ID", err.Error()))
+		return
+	}
+
+	recalculation, err := s.totalsRecalculationUseCase.ApplyRecalculation(c.Request.Context(), GetTenantID(c), userID, recalculationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Totals recalculation applied",
+		"data":    recalculation,
+	})
+}
+
+// listTotalsRecalculations handles listing the tenant's recent totals recalculations
+func (s *Server) listTotalsRecalculations(c *gin.Context) {
+	if err := s.checkPermission(c, "maintenance", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	recalculations, err := s.totalsRecalculationUseCase.ListRecalculations(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": recalculations,
+	})
+}
+
+// getTotalsRecalculation handles retrieving a recalculation with the diff of each document
+func (s *Server) getTotalsRecalculation(c *gin.Context) {
+	if err := s.checkPermission(c, "maintenance", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	recalculationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid recalculation ID", err.Error()))
+		return
+	}
+
+	recalculation, err := s.totalsRecalculationUseCase.GetRecalculation(c.Request.Context(), GetTenantID(c), recalculationID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": recalculation,
+	})
+}
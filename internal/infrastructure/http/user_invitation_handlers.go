@@ -0,0 +1,189 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// inviteUser handles creating a user who sets their own password through an emailed
+// activation link
+func (s *Server) inviteUser(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "create"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	response, err := s.userInvitationUseCase.InviteUser(c.Request.Context(), adminID, GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	message := "User invited successfully"
+	if !response.Invitation.EmailSent {
+		message = "User invited, but the invitation email could not be sent. Resend it later."
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    response,
+		"message": message,
+	})
+}
+
+// getUserInvitation handles retrieving the latest invitation of a user
+func (s *Server) getUserInvitation(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid user ID", "user ID must be a valid UUID"))
+		return
+	}
+
+	invitation, err := s.userInvitationUseCase.GetUserInvitation(c.Request.Context(), GetTenantID(c), userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": invitation,
+	})
+}
+
+// resendUserInvitation handles emailing an invited user a new activation link
+func (s *Server) resendUserInvitation(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid user ID", "user ID must be a valid UUID"))
+		return
+	}
+
+	invitation, err := s.userInvitationUseCase.ResendInvitation(c.Request.Context(), adminID, GetTenantID(c), userID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+	if !invitation.EmailSent {
+		s.respondWithError(c, errors.NewInternalError("failed to send invitation email", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    invitation,
+		"message": "Invitation resent successfully",
+	})
+}
+
+// revokeUserInvitation handles withdrawing the invitation of a user
+func (s *Server) revokeUserInvitation(c *gin.Context) {
+	if err := s.checkPermission(c, "users", "update"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	adminID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid user ID", "user ID must be a valid UUID"))
+		return
+	}
+
+	if err := s.userInvitationUseCase.RevokeInvitation(c.Request.Context(), adminID, GetTenantID(c), userID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation revoked successfully",
+	})
+}
+
+// getInvitationDetails handles showing an invited user who an activation link is for. The
+// link's signature is the only access control.
+func (s *Server) getInvitationDetails(c *gin.Context) {
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewUnauthorizedError("invalid invitation link"))
+		return
+	}
+
+	var req usecases.InvitationLinkRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		s.respondWithError(c, errors.NewUnauthorizedError("invalid invitation link"))
+		return
+	}
+
+	details, err := s.userInvitationUseCase.GetInvitationDetails(c.Request.Context(), invitationID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": details,
+	})
+}
+
+// acceptInvitation handles an invited user choosing their password through an activation link
+func (s *Server) acceptInvitation(c *gin.Context) {
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewUnauthorizedError("invalid invitation link"))
+		return
+	}
+
+	var req usecases.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	if err := s.userInvitationUseCase.AcceptInvitation(c.Request.Context(), invitationID, req); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account activated successfully, sign in with the new password",
+	})
+}
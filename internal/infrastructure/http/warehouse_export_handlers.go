@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// getWarehouseExportSettings handles retrieving the tenant's warehouse export settings
+func (s *Server) getWarehouseExportSettings(c *gin.Context) {
+	if err := s.checkPermission(c, "warehouse_export", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	setting, err := s.warehouseExportUseCase.GetSettings(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": setting,
+	})
+}
+
+// updateWarehouseExportSettings handles opting in or out of the scheduled warehouse export
+func (s *Server) updateWarehouseExportSettings(c *gin.Context) {
+	if err := s.checkPermission(c, "warehouse_export", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.UpdateWarehouseExportSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	setting, err := s.warehouseExportUseCase.UpdateSettings(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Warehouse export settings updated successfully",
+		"data":    setting,
+	})
+}
+
+// getWarehouseManifest handles listing the tenant's exported Parquet files and their schemas
+func (s *Server) getWarehouseManifest(c *gin.Context) {
+	if err := s.checkPermission(c, "warehouse_export", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.WarehouseManifestRequest
+
+	if dataset := c.Query("dataset"); dataset != "" {
+		name := entities.WarehouseDataset(dataset)
+		req.Dataset = &name
+	}
+
+	if fromDate := c.Query("from_date"); fromDate != "" {
+		date, err := time.Parse(reportDateLayout, fromDate)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid from date", "from_date must be in YYYY-MM-DD format"))
+			return
+		}
+		req.DateFrom = &date
+	}
+
+	if toDate := c.Query("to_date"); toDate != "" {
+		date, err := time.Parse(reportDateLayout, toDate)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid to date", "to_date must be in YYYY-MM-DD format"))
+			return
+		}
+		req.DateTo = &date
+	}
+
+	manifest, err := s.warehouseExportUseCase.GetManifest(c.Request.Context(), GetTenantID(c), req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": manifest,
+	})
+}
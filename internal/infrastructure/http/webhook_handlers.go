@@ -0,0 +1,315 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/usecases"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// listWebhookEndpoints handles listing the tenant's webhook endpoints
+func (s *Server) listWebhookEndpoints(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	endpoints, err := s.webhookUseCase.ListEndpoints(c.Request.Context(), GetTenantID(c))
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"endpoints": endpoints,
+			"events":    entities.WebhookEventTypes,
+		},
+	})
+}
+
+// createWebhookEndpoint handles registering a webhook endpoint
+func (s *Server) createWebhookEndpoint(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	var req usecases.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	endpoint, err := s.webhookUseCase.CreateEndpoint(c.Request.Context(), GetTenantID(c), userID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook endpoint created successfully. Store the secret now; it will not be shown again.",
+		"data":    endpoint,
+	})
+}
+
+// updateWebhookEndpoint handles changing a webhook endpoint
+func (s *Server) updateWebhookEndpoint(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid webhook endpoint ID", err.Error()))
+		return
+	}
+
+	var req usecases.UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	endpoint, err := s.webhookUseCase.UpdateEndpoint(c.Request.Context(), GetTenantID(c), userID, endpointID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook endpoint updated successfully",
+		"data":    endpoint,
+	})
+}
+
+// rotateWebhookSecret handles replacing a webhook endpoint's signing secret
+func (s *Server) rotateWebhookSecret(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid webhook endpoint ID", err.Error()))
+		return
+	}
+
+	endpoint, err := s.webhookUseCase.RotateEndpointSecret(c.Request.Context(), GetTenantID(c), userID, endpointID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook secret rotated successfully. Store the secret now; it will not be shown again.",
+		"data":    endpoint,
+	})
+}
+
+// deleteWebhookEndpoint handles removing a webhook endpoint
+func (s *Server) deleteWebhookEndpoint(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid webhook endpoint ID", err.Error()))
+		return
+	}
+
+	if err := s.webhookUseCase.DeleteEndpoint(c.Request.Context(), GetTenantID(c), userID, endpointID); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook endpoint deleted successfully",
+	})
+}
+
+// listWebhookDeliveries handles listing the tenant's webhook deliveries and their status
+func (s *Server) listWebhookDeliveries(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	pagination := utils.PaginationInfo{
+		Page:  page,
+		Limit: limit,
+	}
+
+	// Parse filter parameters
+	var filter repositories.WebhookDeliveryFilter
+
+	if endpointID := c.Query("endpoint_id"); endpointID != "" {
+		id, err := uuid.Parse(endpointID)
+		if err != nil {
+			s.respondWithError(c, errors.NewValidationError("invalid webhook endpoint ID", err.Error()))
+			return
+		}
+		filter.EndpointID = &id
+	}
+
+	if event := c.Query("event_type"); event != "" {
+		eventType := entities.WebhookEventType(event)
+		if !eventType.IsValid() {
+			s.respondWithError(c, errors.NewValidationError("invalid webhook event", "unknown event type"))
+			return
+		}
+		filter.EventType = &eventType
+	}
+
+	if status := c.Query("status"); status != "" {
+		deliveryStatus := entities.WebhookDeliveryStatus(status)
+		switch deliveryStatus {
+		case entities.WebhookDeliveryStatusPending, entities.WebhookDeliveryStatusDelivered, entities.WebhookDeliveryStatusFailed:
+			filter.Status = &deliveryStatus
+		default:
+			s.respondWithError(c, errors.NewValidationError("invalid delivery status", "status must be pending, delivered or failed"))
+			return
+		}
+	}
+
+	response, err := s.webhookUseCase.ListDeliveries(c.Request.Context(), filter, pagination)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// getWebhookDelivery handles inspecting a webhook delivery, including the exact payload sent
+func (s *Server) getWebhookDelivery(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "read"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid webhook delivery ID", err.Error()))
+		return
+	}
+
+	delivery, err := s.webhookUseCase.GetDelivery(c.Request.Context(), GetTenantID(c), deliveryID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": delivery,
+	})
+}
+
+// redeliverWebhookDelivery handles re-sending a delivered or failed event to its endpoint
+func (s *Server) redeliverWebhookDelivery(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid webhook delivery ID", err.Error()))
+		return
+	}
+
+	replay, err := s.webhookUseCase.RedeliverDelivery(c.Request.Context(), GetTenantID(c), userID, deliveryID)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Webhook redelivery queued successfully",
+		"data":    replay,
+	})
+}
+
+// replayWebhookEvents handles replaying an endpoint's events of a period after a consumer outage
+func (s *Server) replayWebhookEvents(c *gin.Context) {
+	if err := s.checkPermission(c, "webhooks", "manage"); err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	userID, err := s.getCurrentUser(c)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid webhook endpoint ID", err.Error()))
+		return
+	}
+
+	var req usecases.ReplayWebhookEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithError(c, errors.NewValidationError("invalid request body", err.Error()))
+		return
+	}
+
+	result, err := s.webhookUseCase.ReplayEndpoint(c.Request.Context(), GetTenantID(c), userID, endpointID, req)
+	if err != nil {
+		s.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Webhook replay queued successfully",
+		"data":    result,
+	})
+}
@@ -3,12 +3,15 @@ package monitoring
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	
 	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/logger"
 )
 
@@ -29,12 +32,32 @@ type TenantMonitor interface {
 	// Health monitoring
 	RecordHealthCheck(ctx context.Context, tenantID uuid.UUID, service string, status bool, responseTime time.Duration)
 	GetTenantHealth(ctx context.Context, tenantID uuid.UUID) (*TenantHealth, error)
+
+	// Delivery monitoring for outbound emails and webhooks
+	TrackDelivery(ctx context.Context, tenantID uuid.UUID, channel DeliveryChannel, success bool)
+
+	// Health scoring for support triage
+	GetHealthScore(ctx context.Context, tenantID uuid.UUID) (*TenantHealthScore, error)
+	ListHealthScores(ctx context.Context) ([]*TenantHealthScore, error)
+
+	// Export to an external metrics system
+	ListTenantMetrics(ctx context.Context) []*TenantMetrics
 	
 	// Alert management
 	CheckAlerts(ctx context.Context, tenantID uuid.UUID) ([]*Alert, error)
+	ListAlerts(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]*Alert, error)
 	CreateAlert(ctx context.Context, alert *Alert) error
+	AcknowledgeAlert(ctx context.Context, tenantID, alertID, userID uuid.UUID) (*Alert, error)
+	ResolveAlert(ctx context.Context, tenantID, alertID, userID uuid.UUID) (*Alert, error)
+
+	// SetAlertNotifier registers the function told about every newly raised alert
+	SetAlertNotifier(notifier AlertNotifier)
 }
 
+// AlertNotifier is told about each alert when it is raised, outside the monitor's lock. An
+// alert repeating one that is still open is not raised again.
+type AlertNotifier func(ctx context.Context, alert *Alert)
+
 // UsageMetrics represents usage statistics for a tenant
 type UsageMetrics struct {
 	TenantID     uuid.UUID         `json:"tenant_id"`
@@ -123,6 +146,51 @@ const (
 	HealthStatusUnknown   HealthStatus = "unknown"
 )
 
+// DeliveryChannel represents an outbound delivery channel tracked per tenant
+type DeliveryChannel string
+
+const (
+	DeliveryChannelEmail   DeliveryChannel = "email"
+	DeliveryChannelWebhook DeliveryChannel = "webhook"
+)
+
+// DeliveryMetrics represents outbound delivery outcomes for a tenant channel
+type DeliveryMetrics struct {
+	Channel     DeliveryChannel `json:"channel"`
+	SentCount   int64           `json:"sent_count"`
+	FailedCount int64           `json:"failed_count"`
+	LastFailure *time.Time      `json:"last_failure,omitempty"`
+}
+
+// TenantHealthScore represents a composite 0-100 health score for a tenant, where lower
+// scores need attention first
+type TenantHealthScore struct {
+	TenantID   uuid.UUID            `json:"tenant_id"`
+	Score      float64              `json:"score"`
+	Status     HealthStatus         `json:"status"`
+	Factors    []*HealthScoreFactor `json:"factors"`
+	ComputedAt time.Time            `json:"computed_at"`
+}
+
+// HealthScoreFactor represents one weighted input to a tenant health score
+type HealthScoreFactor struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // Share of the total score, factors add up to 100
+	Score  float64 `json:"score"`  // Points earned out of Weight
+	Value  float64 `json:"value"`  // Raw measurement, e.g. error rate percentage
+	Detail string  `json:"detail"`
+}
+
+// TenantMetrics is a point-in-time copy of a tenant's counters, for export to an external
+// metrics system
+type TenantMetrics struct {
+	TenantID     uuid.UUID
+	RequestCount int64
+	ErrorCount   int64
+	Usage        map[string]int64      // Current usage by resource
+	ActiveAlerts map[AlertSeverity]int // Active alerts by severity
+}
+
 // Alert represents a monitoring alert
 type Alert struct {
 	ID          uuid.UUID    `json:"id"`
@@ -134,9 +202,24 @@ type Alert struct {
 	Metadata    map[string]interface{} `json:"metadata"`
 	CreatedAt   time.Time    `json:"created_at"`
 	ResolvedAt  *time.Time   `json:"resolved_at,omitempty"`
+	ResolvedBy  *uuid.UUID   `json:"resolved_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by,omitempty"`
 	Status      AlertStatus  `json:"status"`
 }
 
+// IsOpen reports whether the alert has not been resolved or suppressed yet
+func (a *Alert) IsOpen() bool {
+	return a.Status == AlertStatusActive || a.Status == AlertStatusAcknowledged
+}
+
+// repeats reports whether the alert raises the same condition as another, such as the same
+// resource crossing the same usage threshold again
+func (a *Alert) repeats(other *Alert) bool {
+	return a.Type == other.Type && a.Severity == other.Severity && a.Title == other.Title &&
+		fmt.Sprint(a.Metadata["resource"]) == fmt.Sprint(other.Metadata["resource"])
+}
+
 // AlertType represents different types of alerts
 type AlertType string
 
@@ -163,6 +246,7 @@ type AlertStatus string
 
 const (
 	AlertStatusActive    AlertStatus = "active"
+	AlertStatusAcknowledged AlertStatus = "acknowledged" // Someone is looking into it; still open
 	AlertStatusResolved  AlertStatus = "resolved"
 	AlertStatusSuppressed AlertStatus = "suppressed"
 )
@@ -174,6 +258,8 @@ type tenantMonitor struct {
 	performanceStore map[uuid.UUID]*PerformanceMetrics
 	healthStore     map[uuid.UUID]*TenantHealth
 	alertStore      map[uuid.UUID][]*Alert
+	deliveryStore   map[uuid.UUID]map[DeliveryChannel]*DeliveryMetrics
+	notifier        AlertNotifier
 	mu              sync.RWMutex
 }
 
@@ -185,13 +271,13 @@ func NewTenantMonitor(logger logger.EnhancedLogger) TenantMonitor {
 		performanceStore: make(map[uuid.UUID]*PerformanceMetrics),
 		healthStore:     make(map[uuid.UUID]*TenantHealth),
 		alertStore:      make(map[uuid.UUID][]*Alert),
+		deliveryStore:   make(map[uuid.UUID]map[DeliveryChannel]*DeliveryMetrics),
 	}
 }
 
 // TrackUsage tracks resource usage for a tenant
 func (tm *tenantMonitor) TrackUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int64) error {
 	tm.mu.Lock()
-	defer tm.mu.Unlock()
 
 	// Initialize tenant usage map if not exists
 	if tm.usageStore[tenantID] == nil {
@@ -227,8 +313,13 @@ func (tm *tenantMonitor) TrackUsage(ctx context.Context, tenantID uuid.UUID, res
 	// Log usage tracking
 	tm.logger.LogTenantUsage(tenantID.String(), resource, usage.CurrentUsage, usage.Limit)
 
-	// Check for alerts
-	tm.checkUsageAlerts(ctx, tenantID, usage)
+	// Check for alerts, raising them once the usage is recorded
+	alerts := tm.checkUsageAlerts(tenantID, usage)
+	tm.mu.Unlock()
+
+	for _, alert := range alerts {
+		tm.CreateAlert(ctx, alert)
+	}
 
 	return nil
 }
@@ -277,7 +368,9 @@ func getNextResetDate() time.Time {
 	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
 }
 
-func (tm *tenantMonitor) checkUsageAlerts(ctx context.Context, tenantID uuid.UUID, usage *UsageMetrics) {
+// checkUsageAlerts returns the alerts a resource's usage calls for
+func (tm *tenantMonitor) checkUsageAlerts(tenantID uuid.UUID, usage *UsageMetrics) []*Alert {
+	var alerts []*Alert
 	percentage := float64(usage.CurrentUsage) / float64(usage.Limit) * 100
 
 	// Create warning alert at 80%
@@ -298,7 +391,7 @@ func (tm *tenantMonitor) checkUsageAlerts(ctx context.Context, tenantID uuid.UUI
 			CreatedAt: time.Now(),
 			Status:    AlertStatusActive,
 		}
-		tm.CreateAlert(ctx, alert)
+		alerts = append(alerts, alert)
 	}
 
 	// Create critical alert at 100%
@@ -319,8 +412,10 @@ func (tm *tenantMonitor) checkUsageAlerts(ctx context.Context, tenantID uuid.UUI
 			CreatedAt: time.Now(),
 			Status:    AlertStatusActive,
 		}
-		tm.CreateAlert(ctx, alert)
+		alerts = append(alerts, alert)
 	}
+
+	return alerts
 }
 
 // Additional implementation methods would continue here...
@@ -348,6 +443,7 @@ func (tm *tenantMonitor) TrackResponse(ctx context.Context, tenantID uuid.UUID,
 		metrics.ErrorCount++
 	}
 	metrics.SuccessRate = float64(metrics.RequestCount-metrics.ErrorCount) / float64(metrics.RequestCount) * 100
+	metrics.AverageResponse += (duration - metrics.AverageResponse) / time.Duration(metrics.RequestCount)
 	metrics.LastUpdated = time.Now()
 
 	// Update operation-specific metrics
@@ -364,6 +460,7 @@ func (tm *tenantMonitor) TrackResponse(ctx context.Context, tenantID uuid.UUID,
 		opMetrics.ErrorCount++
 	}
 	opMetrics.SuccessRate = float64(opMetrics.RequestCount-opMetrics.ErrorCount) / float64(opMetrics.RequestCount) * 100
+	opMetrics.AverageResponse += (duration - opMetrics.AverageResponse) / time.Duration(opMetrics.RequestCount)
 
 	// Log performance tracking
 	tm.logger.WithFields(map[string]interface{}{
@@ -524,18 +621,41 @@ func (tm *tenantMonitor) CheckAlerts(ctx context.Context, tenantID uuid.UUID) ([
 	return make([]*Alert, 0), nil
 }
 
-// CreateAlert creates a new alert for a tenant
+// ListAlerts retrieves every alert raised for a tenant since the given time, resolved or not
+func (tm *tenantMonitor) ListAlerts(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]*Alert, error) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	alerts := make([]*Alert, 0)
+	for _, alert := range tm.alertStore[tenantID] {
+		if !alert.CreatedAt.Before(since) {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+// CreateAlert creates a new alert for a tenant and tells the alert notifier about it. An
+// alert repeating one that is still open is dropped.
 func (tm *tenantMonitor) CreateAlert(ctx context.Context, alert *Alert) error {
 	tm.mu.Lock()
-	defer tm.mu.Unlock()
 
 	// Initialize tenant alerts if not exists
 	if tm.alertStore[alert.TenantID] == nil {
 		tm.alertStore[alert.TenantID] = make([]*Alert, 0)
 	}
 
+	for _, existing := range tm.alertStore[alert.TenantID] {
+		if existing.IsOpen() && existing.repeats(alert) {
+			tm.mu.Unlock()
+			return nil
+		}
+	}
+
 	// Add alert
 	tm.alertStore[alert.TenantID] = append(tm.alertStore[alert.TenantID], alert)
+	notifier := tm.notifier
+	tm.mu.Unlock()
 
 	// Log alert creation
 	tm.logger.WithFields(map[string]interface{}{
@@ -546,6 +666,74 @@ func (tm *tenantMonitor) CreateAlert(ctx context.Context, alert *Alert) error {
 		"title":     alert.Title,
 	}).Warn("Alert created")
 
+	if notifier != nil {
+		notifier(ctx, alert)
+	}
+
+	return nil
+}
+
+// AcknowledgeAlert marks an active alert as being looked into. It stays open until resolved.
+func (tm *tenantMonitor) AcknowledgeAlert(ctx context.Context, tenantID, alertID, userID uuid.UUID) (*Alert, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	alert := tm.findAlert(tenantID, alertID)
+	if alert == nil {
+		return nil, errors.NewNotFoundError("alert")
+	}
+	if !alert.IsOpen() {
+		return nil, errors.NewConflictError("alert is no longer open")
+	}
+
+	if alert.Status == AlertStatusActive {
+		now := time.Now()
+		alert.Status = AlertStatusAcknowledged
+		alert.AcknowledgedAt = &now
+		alert.AcknowledgedBy = &userID
+	}
+
+	copied := *alert
+	return &copied, nil
+}
+
+// ResolveAlert closes an open alert. The same condition raises a new alert if it happens again.
+func (tm *tenantMonitor) ResolveAlert(ctx context.Context, tenantID, alertID, userID uuid.UUID) (*Alert, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	alert := tm.findAlert(tenantID, alertID)
+	if alert == nil {
+		return nil, errors.NewNotFoundError("alert")
+	}
+	if !alert.IsOpen() {
+		return nil, errors.NewConflictError("alert is no longer open")
+	}
+
+	now := time.Now()
+	alert.Status = AlertStatusResolved
+	alert.ResolvedAt = &now
+	alert.ResolvedBy = &userID
+
+	copied := *alert
+	return &copied, nil
+}
+
+// SetAlertNotifier registers the function told about every newly raised alert
+func (tm *tenantMonitor) SetAlertNotifier(notifier AlertNotifier) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.notifier = notifier
+}
+
+// findAlert returns a tenant's alert by ID. The caller must hold the lock.
+func (tm *tenantMonitor) findAlert(tenantID, alertID uuid.UUID) *Alert {
+	for _, alert := range tm.alertStore[tenantID] {
+		if alert.ID == alertID {
+			return alert
+		}
+	}
 	return nil
 }
 
@@ -581,4 +769,245 @@ func (tm *tenantMonitor) updateOverallHealth(health *TenantHealth) {
 	} else {
 		health.OverallStatus = HealthStatusHealthy
 	}
-}
\ No newline at end of file
+}
+
+// Health score weights, adding up to 100
+const (
+	healthScoreWeightErrors   = 35.0
+	healthScoreWeightLatency  = 25.0
+	healthScoreWeightDelivery = 20.0
+	healthScoreWeightLimits   = 20.0
+)
+
+// Health score thresholds at which a factor earns no points
+const (
+	healthScoreMaxErrorRate    = 10.0 // Percent of failed requests
+	healthScoreMaxDeliveryFail = 20.0 // Percent of failed emails and webhooks
+	healthScoreGoodLatency     = 500 * time.Millisecond
+	healthScoreBadLatency      = 5 * time.Second
+	healthScoreLimitPressure   = 80.0 // Usage percentage where limit pressure starts to count
+)
+
+// TrackDelivery records the outcome of an outbound email or webhook delivery for a tenant
+func (tm *tenantMonitor) TrackDelivery(ctx context.Context, tenantID uuid.UUID, channel DeliveryChannel, success bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if tm.deliveryStore[tenantID] == nil {
+		tm.deliveryStore[tenantID] = make(map[DeliveryChannel]*DeliveryMetrics)
+	}
+
+	metrics, exists := tm.deliveryStore[tenantID][channel]
+	if !exists {
+		metrics = &DeliveryMetrics{Channel: channel}
+		tm.deliveryStore[tenantID][channel] = metrics
+	}
+
+	if success {
+		metrics.SentCount++
+		return
+	}
+
+	now := time.Now()
+	metrics.FailedCount++
+	metrics.LastFailure = &now
+
+	tm.logger.WithFields(map[string]interface{}{
+		"tenant_id": tenantID.String(),
+		"channel":   channel,
+	}).Debug("Delivery failure tracked")
+}
+
+// GetHealthScore computes the composite health score for a tenant
+func (tm *tenantMonitor) GetHealthScore(ctx context.Context, tenantID uuid.UUID) (*TenantHealthScore, error) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	return tm.computeHealthScore(tenantID, time.Now()), nil
+}
+
+// ListHealthScores computes health scores for every tenant with recorded activity, worst first
+func (tm *tenantMonitor) ListHealthScores(ctx context.Context) ([]*TenantHealthScore, error) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	tenantIDs := make(map[uuid.UUID]bool)
+	for tenantID := range tm.performanceStore {
+		tenantIDs[tenantID] = true
+	}
+	for tenantID := range tm.usageStore {
+		tenantIDs[tenantID] = true
+	}
+	for tenantID := range tm.deliveryStore {
+		tenantIDs[tenantID] = true
+	}
+
+	now := time.Now()
+	scores := make([]*TenantHealthScore, 0, len(tenantIDs))
+	for tenantID := range tenantIDs {
+		scores = append(scores, tm.computeHealthScore(tenantID, now))
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].TenantID.String() < scores[j].TenantID.String()
+	})
+
+	return scores, nil
+}
+
+// ListTenantMetrics copies the request, usage and alert counters of every tenant seen so far
+func (tm *tenantMonitor) ListTenantMetrics(ctx context.Context) []*TenantMetrics {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	byTenant := make(map[uuid.UUID]*TenantMetrics)
+	get := func(tenantID uuid.UUID) *TenantMetrics {
+		metrics, exists := byTenant[tenantID]
+		if !exists {
+			metrics = &TenantMetrics{
+				TenantID:     tenantID,
+				Usage:        make(map[string]int64),
+				ActiveAlerts: make(map[AlertSeverity]int),
+			}
+			byTenant[tenantID] = metrics
+		}
+		return metrics
+	}
+
+	for tenantID, performance := range tm.performanceStore {
+		metrics := get(tenantID)
+		metrics.RequestCount = performance.RequestCount
+		metrics.ErrorCount = performance.ErrorCount
+	}
+	for tenantID, usage := range tm.usageStore {
+		metrics := get(tenantID)
+		for resource, resourceUsage := range usage {
+			metrics.Usage[resource] = resourceUsage.CurrentUsage
+		}
+	}
+	for tenantID, alerts := range tm.alertStore {
+		metrics := get(tenantID)
+		for _, alert := range alerts {
+			if alert.Status == AlertStatusActive {
+				metrics.ActiveAlerts[alert.Severity]++
+			}
+		}
+	}
+
+	list := make([]*TenantMetrics, 0, len(byTenant))
+	for _, metrics := range byTenant {
+		list = append(list, metrics)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].TenantID.String() < list[j].TenantID.String()
+	})
+	return list
+}
+
+// computeHealthScore combines error rate, latency, delivery failures and limit pressure into
+// a single score. Factors without data earn full points. Callers must hold the read lock.
+func (tm *tenantMonitor) computeHealthScore(tenantID uuid.UUID, now time.Time) *TenantHealthScore {
+	factors := make([]*HealthScoreFactor, 0, 4)
+
+	// Error rate
+	var errorRate float64
+	var averageResponse time.Duration
+	if metrics, exists := tm.performanceStore[tenantID]; exists && metrics.RequestCount > 0 {
+		errorRate = float64(metrics.ErrorCount) / float64(metrics.RequestCount) * 100
+		averageResponse = metrics.AverageResponse
+	}
+	factors = append(factors, &HealthScoreFactor{
+		Name:   "error_rate",
+		Weight: healthScoreWeightErrors,
+		Score:  healthScoreWeightErrors * (1 - clampRatio(errorRate/healthScoreMaxErrorRate)),
+		Value:  errorRate,
+		Detail: fmt.Sprintf("%.1f%% of requests failed", errorRate),
+	})
+
+	// Latency
+	latencyRatio := float64(averageResponse-healthScoreGoodLatency) / float64(healthScoreBadLatency-healthScoreGoodLatency)
+	factors = append(factors, &HealthScoreFactor{
+		Name:   "latency",
+		Weight: healthScoreWeightLatency,
+		Score:  healthScoreWeightLatency * (1 - clampRatio(latencyRatio)),
+		Value:  float64(averageResponse.Milliseconds()),
+		Detail: fmt.Sprintf("average response %dms", averageResponse.Milliseconds()),
+	})
+
+	// Failed emails and webhooks
+	var sent, failed int64
+	for _, metrics := range tm.deliveryStore[tenantID] {
+		sent += metrics.SentCount
+		failed += metrics.FailedCount
+	}
+	var failureRate float64
+	if sent+failed > 0 {
+		failureRate = float64(failed) / float64(sent+failed) * 100
+	}
+	factors = append(factors, &HealthScoreFactor{
+		Name:   "delivery_failures",
+		Weight: healthScoreWeightDelivery,
+		Score:  healthScoreWeightDelivery * (1 - clampRatio(failureRate/healthScoreMaxDeliveryFail)),
+		Value:  failureRate,
+		Detail: fmt.Sprintf("%d of %d emails and webhooks failed", failed, sent+failed),
+	})
+
+	// Limit pressure
+	var maxUsage float64
+	pressuredResource := ""
+	for resource, usage := range tm.usageStore[tenantID] {
+		if usage.Limit <= 0 {
+			continue
+		}
+		percentage := float64(usage.CurrentUsage) / float64(usage.Limit) * 100
+		if percentage > maxUsage {
+			maxUsage = percentage
+			pressuredResource = resource
+		}
+	}
+	limitDetail := "no resource near its limit"
+	if pressuredResource != "" {
+		limitDetail = fmt.Sprintf("%s usage at %.1f%%", pressuredResource, maxUsage)
+	}
+	factors = append(factors, &HealthScoreFactor{
+		Name:   "limit_pressure",
+		Weight: healthScoreWeightLimits,
+		Score:  healthScoreWeightLimits * (1 - clampRatio((maxUsage-healthScoreLimitPressure)/(100-healthScoreLimitPressure))),
+		Value:  maxUsage,
+		Detail: limitDetail,
+	})
+
+	var total float64
+	for _, factor := range factors {
+		factor.Score = math.Round(factor.Score*10) / 10
+		total += factor.Score
+	}
+
+	return &TenantHealthScore{
+		TenantID:   tenantID,
+		Score:      math.Round(total*10) / 10,
+		Status:     healthStatusForScore(total),
+		Factors:    factors,
+		ComputedAt: now,
+	}
+}
+
+// healthStatusForScore maps a composite health score to a health status
+func healthStatusForScore(score float64) HealthStatus {
+	switch {
+	case score >= 80:
+		return HealthStatusHealthy
+	case score >= 50:
+		return HealthStatusWarning
+	default:
+		return HealthStatusCritical
+	}
+}
+
+// clampRatio limits a ratio to the range [0, 1]
+func clampRatio(ratio float64) float64 {
+	return math.Max(0, math.Min(1, ratio))
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresAccountingAccountMappingRepository implements the AccountingAccountMappingRepository interface
+type PostgresAccountingAccountMappingRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAccountingAccountMappingRepository creates a new PostgreSQL accounting account mapping repository
+func NewPostgresAccountingAccountMappingRepository(db *sql.DB) repositories.AccountingAccountMappingRepository {
+	return &PostgresAccountingAccountMappingRepository{db: db}
+}
+
+// GetByTenant retrieves the account mapping of a tenant
+func (r *PostgresAccountingAccountMappingRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.AccountingAccountMapping, error) {
+	query := `
+		SELECT id, tenant_id, sales_revenue_account, sales_returns_account, tax_payable_account,
+			cost_of_goods_sold_account, inventory_account, cash_account, card_account,
+			digital_wallet_account, bank_transfer_account, zero_tax_rate, created_at, updated_at, updated_by
+		FROM accounting_account_mappings
+		WHERE tenant_id = $1`
+
+	var mapping entities.AccountingAccountMapping
+	accounts := &mapping.Accounts
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&mapping.ID, &mapping.TenantID, &accounts.SalesRevenue, &accounts.SalesReturns, &accounts.TaxPayable,
+		&accounts.CostOfGoodsSold, &accounts.Inventory, &accounts.Cash, &accounts.Card,
+		&accounts.DigitalWallet, &accounts.BankTransfer, &accounts.ZeroTaxRate,
+		&mapping.CreatedAt, &mapping.UpdatedAt, &mapping.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("accounting account mapping")
+		}
+		return nil, fmt.Errorf("failed to get accounting account mapping: %w", err)
+	}
+
+	return &mapping, nil
+}
+
+// Save creates or updates the account mapping of a tenant
+func (r *PostgresAccountingAccountMappingRepository) Save(ctx context.Context, mapping *entities.AccountingAccountMapping) error {
+	query := `
+		INSERT INTO accounting_account_mappings (id, tenant_id, sales_revenue_account, sales_returns_account,
+			tax_payable_account, cost_of_goods_sold_account, inventory_account, cash_account, card_account,
+			digital_wallet_account, bank_transfer_account, zero_tax_rate, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			sales_revenue_account = EXCLUDED.sales_revenue_account,
+			sales_returns_account = EXCLUDED.sales_returns_account,
+			tax_payable_account = EXCLUDED.tax_payable_account,
+			cost_of_goods_sold_account = EXCLUDED.cost_of_goods_sold_account,
+			inventory_account = EXCLUDED.inventory_account,
+			cash_account = EXCLUDED.cash_account,
+			card_account = EXCLUDED.card_account,
+			digital_wallet_account = EXCLUDED.digital_wallet_account,
+			bank_transfer_account = EXCLUDED.bank_transfer_account,
+			zero_tax_rate = EXCLUDED.zero_tax_rate,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	accounts := mapping.Accounts
+	_, err := r.db.ExecContext(ctx, query,
+		mapping.ID, mapping.TenantID, accounts.SalesRevenue, accounts.SalesReturns, accounts.TaxPayable,
+		accounts.CostOfGoodsSold, accounts.Inventory, accounts.Cash, accounts.Card, accounts.DigitalWallet,
+		accounts.BankTransfer, accounts.ZeroTaxRate, mapping.CreatedAt, mapping.UpdatedAt, mapping.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save accounting account mapping: %w", err)
+	}
+
+	return nil
+}
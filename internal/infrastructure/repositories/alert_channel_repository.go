@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresAlertChannelRepository implements the AlertChannelRepository interface
+type PostgresAlertChannelRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAlertChannelRepository creates a new PostgreSQL alert channel repository
+func NewPostgresAlertChannelRepository(db *sql.DB) repositories.AlertChannelRepository {
+	return &PostgresAlertChannelRepository{db: db}
+}
+
+const alertChannelColumns = `id, tenant_id, name, type, min_severity, recipients, COALESCE(url, ''), COALESCE(secret, ''),
+			is_active, last_notified_at, COALESCE(last_error, ''), created_by, created_at, updated_at`
+
+// Create creates a new alert channel
+func (r *PostgresAlertChannelRepository) Create(ctx context.Context, channel *entities.AlertChannel) error {
+	query := `
+		INSERT INTO alert_channels (id, tenant_id, name, type, min_severity, recipients, url, secret,
+			is_active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10, $11, $12)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		channel.ID, channel.TenantID, channel.Name, channel.Type, channel.MinSeverity, pq.Array(channel.Recipients),
+		channel.URL, channel.Secret, channel.IsActive, channel.CreatedBy, channel.CreatedAt, channel.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("alert channel '%s' already exists", channel.Name))
+		}
+		return fmt.Errorf("failed to insert alert channel: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an alert channel by ID
+func (r *PostgresAlertChannelRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.AlertChannel, error) {
+	query := `
+		SELECT ` + alertChannelColumns + `
+		FROM alert_channels
+		WHERE id = $1`
+
+	channel, err := r.scanAlertChannel(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("alert channel")
+		}
+		return nil, fmt.Errorf("failed to get alert channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// GetByTenant retrieves all alert channels of a tenant
+func (r *PostgresAlertChannelRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.AlertChannel, error) {
+	query := `
+		SELECT ` + alertChannelColumns + `
+		FROM alert_channels
+		WHERE tenant_id = $1
+		ORDER BY name`
+
+	return r.queryAlertChannels(ctx, query, tenantID)
+}
+
+// GetActiveByTenant retrieves the active alert channels of a tenant
+func (r *PostgresAlertChannelRepository) GetActiveByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.AlertChannel, error) {
+	query := `
+		SELECT ` + alertChannelColumns + `
+		FROM alert_channels
+		WHERE tenant_id = $1 AND is_active
+		ORDER BY name`
+
+	return r.queryAlertChannels(ctx, query, tenantID)
+}
+
+// Update updates an alert channel's settings
+func (r *PostgresAlertChannelRepository) Update(ctx context.Context, channel *entities.AlertChannel) error {
+	query := `
+		UPDATE alert_channels
+		SET name = $2, min_severity = $3, recipients = $4, url = NULLIF($5, ''), is_active = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		channel.ID, channel.Name, channel.MinSeverity, pq.Array(channel.Recipients), channel.URL, channel.IsActive,
+		channel.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("alert channel '%s' already exists", channel.Name))
+		}
+		return fmt.Errorf("failed to update alert channel: %w", err)
+	}
+
+	return checkAlertChannelAffected(result)
+}
+
+// RecordNotification saves the outcome of the latest notification sent to a channel
+func (r *PostgresAlertChannelRepository) RecordNotification(ctx context.Context, channel *entities.AlertChannel) error {
+	query := `
+		UPDATE alert_channels SET last_notified_at = $2, last_error = NULLIF($3, ''), updated_at = $4
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, channel.ID, channel.LastNotifiedAt, channel.LastError, channel.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record alert channel notification: %w", err)
+	}
+
+	return checkAlertChannelAffected(result)
+}
+
+// Delete deletes an alert channel
+func (r *PostgresAlertChannelRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM alert_channels WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert channel: %w", err)
+	}
+
+	return checkAlertChannelAffected(result)
+}
+
+func (r *PostgresAlertChannelRepository) queryAlertChannels(ctx context.Context, query string, args ...interface{}) ([]*entities.AlertChannel, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert channels: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []*entities.AlertChannel{}
+	for rows.Next() {
+		channel, err := r.scanAlertChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert channel: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate alert channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+// scanAlertChannel scans an alert channel from a row
+func (r *PostgresAlertChannelRepository) scanAlertChannel(row interface{ Scan(...interface{}) error }) (*entities.AlertChannel, error) {
+	var channel entities.AlertChannel
+	var recipients pq.StringArray
+
+	err := row.Scan(&channel.ID, &channel.TenantID, &channel.Name, &channel.Type, &channel.MinSeverity, &recipients,
+		&channel.URL, &channel.Secret, &channel.IsActive, &channel.LastNotifiedAt, &channel.LastError,
+		&channel.CreatedBy, &channel.CreatedAt, &channel.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Recipients = []string(recipients)
+	return &channel, nil
+}
+
+func checkAlertChannelAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("alert channel")
+	}
+
+	return nil
+}
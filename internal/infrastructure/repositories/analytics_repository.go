@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// analyticsStatementTimeout bounds how long a single analytics query may run
+const analyticsStatementTimeout = "5s"
+
+// analyticsSourceTables holds the FROM clause of each analytics source. Both sources expose
+// the sale as s so the sale dimensions and the tenant and date conditions are shared.
+var analyticsSourceTables = map[entities.AnalyticsSource]string{
+	entities.AnalyticsSourceSales: `sales s
+		LEFT JOIN users u ON u.id = s.created_by`,
+	entities.AnalyticsSourceSaleItems: `sale_items si
+		JOIN sales s ON s.id = si.sale_id
+		LEFT JOIN products p ON p.id = si.product_id
+		LEFT JOIN users u ON u.id = s.created_by`,
+}
+
+// analyticsDimensionColumns maps catalog dimensions to SQL expressions
+var analyticsDimensionColumns = map[string]string{
+	"channel":        "s.channel",
+	"payment_method": "COALESCE(s.payment_method, '')",
+	"cashier_id":     "s.created_by",
+	"cashier_name":   "COALESCE(u.username, '')",
+	"product_id":     "si.product_id",
+	"product_sku":    "si.product_sku",
+	"product_name":   "si.product_name",
+	"category":       "COALESCE(p.category, '')",
+	"price_source":   "si.price_source",
+}
+
+// analyticsMeasureColumns maps catalog measures of each source to SQL aggregates
+var analyticsMeasureColumns = map[entities.AnalyticsSource]map[string]string{
+	entities.AnalyticsSourceSales: {
+		"sale_count":          "COUNT(*)",
+		"revenue":             "COALESCE(SUM(s.total_amount * s.exchange_rate), 0)",
+		"discount_amount":     "COALESCE(SUM(s.discount_amount * s.exchange_rate), 0)",
+		"tax_amount":          "COALESCE(SUM(s.tax_amount * s.exchange_rate), 0)",
+		"average_order_value": "COALESCE(ROUND(AVG(s.total_amount * s.exchange_rate), 2), 0)",
+	},
+	entities.AnalyticsSourceSaleItems: {
+		"sale_count":    "COUNT(DISTINCT s.id)",
+		"quantity_sold": "COALESCE(SUM(si.quantity), 0)",
+		"item_revenue":  "COALESCE(SUM(si.total_price * s.exchange_rate), 0)",
+		"cost_of_goods": "COALESCE(SUM(si.unit_cost * si.quantity * s.exchange_rate), 0)",
+		"gross_profit":  "COALESCE(SUM((si.total_price - si.unit_cost * si.quantity) * s.exchange_rate), 0)",
+	},
+}
+
+// PostgresAnalyticsRepository implements the AnalyticsRepository interface
+type PostgresAnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAnalyticsRepository creates a new PostgreSQL analytics repository
+func NewPostgresAnalyticsRepository(db *sql.DB) repositories.AnalyticsRepository {
+	return &PostgresAnalyticsRepository{db: db}
+}
+
+// Query runs a normalized analytics query scoped to a tenant. The query runs in a read-only
+// transaction with a statement timeout so an expensive spec cannot hold up the database.
+func (r *PostgresAnalyticsRepository) Query(ctx context.Context, tenantID uuid.UUID, query entities.AnalyticsQuery) (*repositories.AnalyticsResult, error) {
+	statement, args, columns, err := compileAnalyticsQuery(tenantID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = '%s'", analyticsStatementTimeout)); err != nil {
+		return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "57014" {
+			return nil, errors.NewValidationError("query took too long", "narrow the date range, add filters or select fewer dimensions")
+		}
+		return nil, fmt.Errorf("failed to run analytics query: %w", err)
+	}
+	defer rows.Close()
+
+	result := &repositories.AnalyticsResult{
+		Columns: columns,
+		Rows:    [][]interface{}{},
+	}
+
+	for rows.Next() {
+		if len(result.Rows) == query.Limit {
+			result.Truncated = true
+			break
+		}
+
+		targets := make([]interface{}, len(columns))
+		for i, column := range columns {
+			targets[i] = analyticsScanTarget(column.Type)
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return nil, fmt.Errorf("failed to scan analytics row: %w", err)
+		}
+
+		row := make([]interface{}, len(columns))
+		for i, target := range targets {
+			row[i] = analyticsValue(target)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "57014" {
+			return nil, errors.NewValidationError("query took too long", "narrow the date range, add filters or select fewer dimensions")
+		}
+		return nil, fmt.Errorf("failed to iterate analytics rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// Helper functions
+
+// compileAnalyticsQuery compiles a normalized analytics query to SQL. Only expressions from the
+// column maps are interpolated; every value from the spec is passed as an argument.
+func compileAnalyticsQuery(tenantID uuid.UUID, query entities.AnalyticsQuery) (string, []interface{}, []repositories.AnalyticsColumn, error) {
+	from, ok := analyticsSourceTables[query.Source]
+	if !ok {
+		return "", nil, nil, errors.NewValidationError("invalid source", "source must be one of: sales, sale_items")
+	}
+	measures := analyticsMeasureColumns[query.Source]
+
+	conditions := []string{
+		"s.tenant_id = $1",
+		"s.status = 'completed'",
+		"s.deleted_at IS NULL",
+		"s.completed_at >= $2",
+		"s.completed_at < $3",
+	}
+	args := []interface{}{tenantID, query.DateFrom, query.DateTo}
+
+	var selects []string
+	var columns []repositories.AnalyticsColumn
+
+	if query.DateGrain != "" {
+		args = append(args, string(query.DateGrain))
+		selects = append(selects, fmt.Sprintf("date_trunc($%d, s.completed_at) AS %s", len(args), entities.AnalyticsPeriodColumn))
+		columns = append(columns, repositories.AnalyticsColumn{Name: entities.AnalyticsPeriodColumn, Type: entities.AnalyticsFieldTypeTime, Role: "dimension"})
+	}
+
+	for _, name := range query.Dimensions {
+		expr, ok := analyticsDimensionColumns[name]
+		field, known := query.Field(name)
+		if !ok || !known {
+			return "", nil, nil, errors.NewValidationError("unknown dimension", fmt.Sprintf("%s is not a dimension of %s", name, query.Source))
+		}
+		selects = append(selects, fmt.Sprintf("%s AS %s", expr, name))
+		columns = append(columns, repositories.AnalyticsColumn{Name: name, Type: field.Type, Role: "dimension"})
+	}
+	groupCount := len(selects)
+
+	for _, name := range query.Measures {
+		expr, ok := measures[name]
+		field, known := query.Field(name)
+		if !ok || !known {
+			return "", nil, nil, errors.NewValidationError("unknown measure", fmt.Sprintf("%s is not a measure of %s", name, query.Source))
+		}
+		selects = append(selects, fmt.Sprintf("%s AS %s", expr, name))
+		columns = append(columns, repositories.AnalyticsColumn{Name: name, Type: field.Type, Role: "measure"})
+	}
+
+	for _, filter := range query.Filters {
+		expr, ok := analyticsDimensionColumns[filter.Dimension]
+		field, known := query.Field(filter.Dimension)
+		if !ok || !known {
+			return "", nil, nil, errors.NewValidationError("unknown filter dimension", fmt.Sprintf("%s is not a dimension of %s", filter.Dimension, query.Source))
+		}
+
+		arrayType := "text[]"
+		if field.Type == entities.AnalyticsFieldTypeUUID {
+			arrayType = "uuid[]"
+		}
+
+		switch filter.Operator {
+		case entities.AnalyticsFilterEquals:
+			args = append(args, filter.Values[0])
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", expr, len(args)))
+		case entities.AnalyticsFilterNotEquals:
+			args = append(args, filter.Values[0])
+			conditions = append(conditions, fmt.Sprintf("%s <> $%d", expr, len(args)))
+		case entities.AnalyticsFilterIn:
+			args = append(args, pq.Array(filter.Values))
+			conditions = append(conditions, fmt.Sprintf("%s = ANY($%d::%s)", expr, len(args), arrayType))
+		case entities.AnalyticsFilterNotIn:
+			args = append(args, pq.Array(filter.Values))
+			conditions = append(conditions, fmt.Sprintf("NOT (%s = ANY($%d::%s))", expr, len(args), arrayType))
+		default:
+			return "", nil, nil, errors.NewValidationError("invalid filter operator", "operator must be one of: eq, neq, in, not_in")
+		}
+	}
+
+	statement := fmt.Sprintf("SELECT %s\n\t\tFROM %s\n\t\tWHERE %s", strings.Join(selects, ", "), from, strings.Join(conditions, " AND "))
+
+	if groupCount > 0 {
+		positions := make([]string, groupCount)
+		for i := range positions {
+			positions[i] = fmt.Sprintf("%d", i+1)
+		}
+		statement += "\n\t\tGROUP BY " + strings.Join(positions, ", ")
+	}
+
+	// Time series read oldest first, everything else largest first
+	orderBy := query.OrderBy
+	orderDir := query.OrderDir
+	if orderBy == "" {
+		if query.DateGrain != "" {
+			orderBy, orderDir = entities.AnalyticsPeriodColumn, "ASC"
+		} else {
+			orderBy, orderDir = query.Measures[0], "DESC"
+		}
+	}
+	statement += fmt.Sprintf("\n\t\tORDER BY %s %s", orderBy, orderDir)
+
+	// Fetch one extra row to tell whether the result was truncated
+	args = append(args, query.Limit+1)
+	statement += fmt.Sprintf("\n\t\tLIMIT $%d", len(args))
+
+	return statement, args, columns, nil
+}
+
+// analyticsScanTarget returns a scan destination for a column type
+func analyticsScanTarget(fieldType entities.AnalyticsFieldType) interface{} {
+	switch fieldType {
+	case entities.AnalyticsFieldTypeTime:
+		return new(sql.NullTime)
+	case entities.AnalyticsFieldTypeInteger:
+		return new(sql.NullInt64)
+	case entities.AnalyticsFieldTypeDecimal:
+		return new(decimal.NullDecimal)
+	default:
+		return new(sql.NullString)
+	}
+}
+
+// analyticsValue unwraps a scanned value, returning nil for NULL
+func analyticsValue(target interface{}) interface{} {
+	switch v := target.(type) {
+	case *sql.NullTime:
+		if v.Valid {
+			return v.Time
+		}
+	case *sql.NullInt64:
+		if v.Valid {
+			return v.Int64
+		}
+	case *decimal.NullDecimal:
+		if v.Valid {
+			return v.Decimal
+		}
+	case *sql.NullString:
+		if v.Valid {
+			return v.String
+		}
+	}
+	return nil
+}
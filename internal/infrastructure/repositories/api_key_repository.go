@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// apiKeyColumns lists the API key columns in the order scanAPIKey reads them
+const apiKeyColumns = `id, tenant_id, name, key_prefix, key_hash, scopes, is_active, expires_at,
+			last_used_at, last_used_ip, revoked_at, revoked_by, created_at, updated_at, created_by`
+
+// PostgresAPIKeyRepository implements the APIKeyRepository interface
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIKeyRepository creates a new PostgreSQL API key repository
+func NewPostgresAPIKeyRepository(db *sql.DB) repositories.APIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// Create creates a new API key
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, apiKey *entities.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, tenant_id, name, key_prefix, key_hash, scopes, is_active, expires_at,
+			created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		apiKey.ID, apiKey.TenantID, apiKey.Name, apiKey.KeyPrefix, apiKey.KeyHash, pq.Array(apiKey.Scopes),
+		apiKey.IsActive, apiKey.ExpiresAt, apiKey.CreatedAt, apiKey.UpdatedAt, apiKey.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an API key by ID
+func (r *PostgresAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE id = $1`
+
+	apiKey, err := r.scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("API key")
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// GetByKeyHash retrieves an API key by the hash of its key
+func (r *PostgresAPIKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE key_hash = $1`
+
+	apiKey, err := r.scanAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("API key")
+		}
+		return nil, fmt.Errorf("failed to get API key by hash: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// Update updates an existing API key
+func (r *PostgresAPIKeyRepository) Update(ctx context.Context, apiKey *entities.APIKey) error {
+	query := `
+		UPDATE api_keys SET
+			name = $2, is_active = $3, revoked_at = $4, revoked_by = $5, updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		apiKey.ID, apiKey.Name, apiKey.IsActive, apiKey.RevokedAt, apiKey.RevokedBy, apiKey.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+
+	return checkAPIKeyAffected(result)
+}
+
+// GetByTenant retrieves all API keys for a tenant
+func (r *PostgresAPIKeyRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE tenant_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []*entities.APIKey
+	for rows.Next() {
+		apiKey, err := r.scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RecordUse records when and from where an API key was last used
+func (r *PostgresAPIKeyRepository) RecordUse(ctx context.Context, id uuid.UUID, at time.Time, ip string) error {
+	query := `
+		UPDATE api_keys SET last_used_at = $2, last_used_ip = $3
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, at, ip)
+	if err != nil {
+		return fmt.Errorf("failed to record API key use: %w", err)
+	}
+
+	return checkAPIKeyAffected(result)
+}
+
+// Helper functions
+
+// scanAPIKey scans an API key from a row
+func (r *PostgresAPIKeyRepository) scanAPIKey(row interface{ Scan(...interface{}) error }) (*entities.APIKey, error) {
+	var apiKey entities.APIKey
+	var scopes pq.StringArray
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	var revokedBy uuid.NullUUID
+
+	err := row.Scan(
+		&apiKey.ID, &apiKey.TenantID, &apiKey.Name, &apiKey.KeyPrefix, &apiKey.KeyHash, &scopes,
+		&apiKey.IsActive, &expiresAt, &lastUsedAt, &apiKey.LastUsedIP, &revokedAt, &revokedBy,
+		&apiKey.CreatedAt, &apiKey.UpdatedAt, &apiKey.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey.Scopes = []string(scopes)
+	if expiresAt.Valid {
+		apiKey.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		apiKey.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		apiKey.RevokedAt = &revokedAt.Time
+	}
+	if revokedBy.Valid {
+		apiKey.RevokedBy = &revokedBy.UUID
+	}
+
+	return &apiKey, nil
+}
+
+func checkAPIKeyAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("API key")
+	}
+
+	return nil
+}
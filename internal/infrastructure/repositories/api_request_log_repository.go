@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresAPIRequestLogRepository implements the APIRequestLogRepository interface
+type PostgresAPIRequestLogRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIRequestLogRepository creates a new PostgreSQL API request log repository
+func NewPostgresAPIRequestLogRepository(db *sql.DB) repositories.APIRequestLogRepository {
+	return &PostgresAPIRequestLogRepository{db: db}
+}
+
+// Create stores a captured API request
+func (r *PostgresAPIRequestLogRepository) Create(ctx context.Context, log *entities.APIRequestLog) error {
+	headersJSON, err := json.Marshal(log.RequestHeaders)
+	if err != nil {
+		return fmt.Errorf("failed to marshal API request log headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_request_logs (id, request_id, tenant_id, user_id, method, path, query, status_code,
+			duration_ms, request_headers, request_body, request_body_omitted, response_body,
+			response_body_omitted, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		log.ID, log.RequestID, log.TenantID, log.UserID, log.Method, log.Path, log.Query, log.StatusCode,
+		log.DurationMs, headersJSON, log.RequestBody, log.RequestOmitted, log.ResponseBody,
+		log.ResponseOmitted, log.CreatedAt, log.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert API request log: %w", err)
+	}
+
+	return nil
+}
+
+// GetByRequestID retrieves a captured API request by its request ID
+func (r *PostgresAPIRequestLogRepository) GetByRequestID(ctx context.Context, requestID string) (*entities.APIRequestLog, error) {
+	query := `
+		SELECT id, request_id, tenant_id, user_id, method, path, query, status_code,
+			duration_ms, request_headers, request_body, request_body_omitted, response_body,
+			response_body_omitted, created_at, expires_at
+		FROM api_request_logs
+		WHERE request_id = $1 AND expires_at > NOW()`
+
+	log, err := r.scanAPIRequestLog(r.db.QueryRowContext(ctx, query, requestID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("API request log")
+		}
+		return nil, fmt.Errorf("failed to get API request log: %w", err)
+	}
+
+	return log, nil
+}
+
+// List retrieves a tenant's captured API requests matching the filter, newest first
+func (r *PostgresAPIRequestLogRepository) List(ctx context.Context, filter repositories.APIRequestLogFilter) ([]*entities.APIRequestLog, error) {
+	query := `
+		SELECT id, request_id, tenant_id, user_id, method, path, query, status_code,
+			duration_ms, request_headers, request_body, request_body_omitted, response_body,
+			response_body_omitted, created_at, expires_at
+		FROM api_request_logs
+		WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3
+			AND status_code >= $4 AND duration_ms >= $5 AND expires_at > NOW()
+		ORDER BY created_at DESC
+		LIMIT $6`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		filter.TenantID, filter.FromDate, filter.ToDate, filter.MinStatusCode, filter.MinDurationMs, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API request logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []*entities.APIRequestLog
+	for rows.Next() {
+		log, err := r.scanAPIRequestLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API request log: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API request logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// DeleteExpired permanently deletes logs that expired before the given time
+func (r *PostgresAPIRequestLogRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM api_request_logs WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired API request logs: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// Helper functions
+
+// scanAPIRequestLog scans an API request log from a row
+func (r *PostgresAPIRequestLogRepository) scanAPIRequestLog(row interface{ Scan(...interface{}) error }) (*entities.APIRequestLog, error) {
+	var log entities.APIRequestLog
+	var tenantID, userID uuid.NullUUID
+	var headersJSON []byte
+
+	err := row.Scan(
+		&log.ID, &log.RequestID, &tenantID, &userID, &log.Method, &log.Path, &log.Query, &log.StatusCode,
+		&log.DurationMs, &headersJSON, &log.RequestBody, &log.RequestOmitted, &log.ResponseBody,
+		&log.ResponseOmitted, &log.CreatedAt, &log.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID.Valid {
+		log.TenantID = &tenantID.UUID
+	}
+	if userID.Valid {
+		log.UserID = &userID.UUID
+	}
+	if len(headersJSON) > 0 {
+		if err := json.Unmarshal(headersJSON, &log.RequestHeaders); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal API request log headers: %w", err)
+		}
+	}
+
+	return &log, nil
+}
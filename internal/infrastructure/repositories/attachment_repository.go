@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresAttachmentRepository implements the AttachmentRepository interface
+type PostgresAttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAttachmentRepository creates a new PostgreSQL attachment repository
+func NewPostgresAttachmentRepository(db *sql.DB) repositories.AttachmentRepository {
+	return &PostgresAttachmentRepository{db: db}
+}
+
+const attachmentColumns = `id, tenant_id, owner_type, owner_id, file_name, content_type, size,
+			storage_path, uploaded_by, created_at`
+
+// Create creates a new attachment
+func (r *PostgresAttachmentRepository) Create(ctx context.Context, attachment *entities.Attachment) error {
+	query := `
+		INSERT INTO attachments (` + attachmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		attachment.ID, attachment.TenantID, attachment.OwnerType, attachment.OwnerID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.StoragePath, attachment.UploadedBy, attachment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an attachment by ID
+func (r *PostgresAttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE id = $1`
+
+	attachment, err := r.scanAttachment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("attachment")
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListByOwner retrieves the attachments of an invoice or sale, oldest first
+func (r *PostgresAttachmentRepository) ListByOwner(ctx context.Context, ownerType entities.AttachmentOwnerType, ownerID uuid.UUID) ([]*entities.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE owner_type = $1 AND owner_id = $2
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, ownerType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*entities.Attachment{}
+	for rows.Next() {
+		attachment, err := r.scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Delete deletes an attachment
+func (r *PostgresAttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM attachments WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("attachment")
+	}
+
+	return nil
+}
+
+// scanAttachment scans an attachment from a row
+func (r *PostgresAttachmentRepository) scanAttachment(row interface{ Scan(...interface{}) error }) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+
+	err := row.Scan(&attachment.ID, &attachment.TenantID, &attachment.OwnerType, &attachment.OwnerID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.StoragePath, &attachment.UploadedBy, &attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// categorySubtreeQuery selects the IDs of a category, given as the placeholder in %s, and of
+// all its subcategories
+const categorySubtreeQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE id = %s
+		UNION ALL
+		SELECT c.id FROM categories c JOIN subtree st ON c.parent_id = st.id
+	)
+	SELECT id FROM subtree`
+
+// PostgresCategoryRepository implements the CategoryRepository interface
+type PostgresCategoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCategoryRepository creates a new PostgreSQL category repository
+func NewPostgresCategoryRepository(db *sql.DB) repositories.CategoryRepository {
+	return &PostgresCategoryRepository{db: db}
+}
+
+// GetByTenant retrieves all the tenant's categories
+func (r *PostgresCategoryRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.Category, error) {
+	query := `
+		SELECT id, tenant_id, parent_id, name, slug, sort_order, tax_rate_id, created_at, updated_at, updated_by
+		FROM categories
+		WHERE tenant_id = $1
+		ORDER BY sort_order, LOWER(name)`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*entities.Category{}
+	for rows.Next() {
+		category, err := r.scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// GetByID retrieves a category by ID
+func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	query := `
+		SELECT id, tenant_id, parent_id, name, slug, sort_order, tax_rate_id, created_at, updated_at, updated_by
+		FROM categories
+		WHERE id = $1`
+
+	category, err := r.scanCategory(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("category")
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetByName retrieves the tenant's category with the given name, ignoring case
+func (r *PostgresCategoryRepository) GetByName(ctx context.Context, tenantID uuid.UUID, name string) (*entities.Category, error) {
+	query := `
+		SELECT id, tenant_id, parent_id, name, slug, sort_order, tax_rate_id, created_at, updated_at, updated_by
+		FROM categories
+		WHERE tenant_id = $1 AND LOWER(name) = LOWER($2)`
+
+	category, err := r.scanCategory(r.db.QueryRowContext(ctx, query, tenantID, strings.TrimSpace(name)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("category")
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+// Create creates a new category
+func (r *PostgresCategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	query := `
+		INSERT INTO categories (id, tenant_id, parent_id, name, slug, sort_order, tax_rate_id, created_at, updated_at,
+			updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		category.ID, category.TenantID, category.ParentID, category.Name, category.Slug, category.SortOrder,
+		category.TaxRateID, category.CreatedAt, category.UpdatedAt, category.UpdatedBy)
+	if err != nil {
+		if conflict := categoryConflict(err, category); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to insert category: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a category. Renaming it renames the category of its products and of its
+// margin floor in the same transaction, so they keep grouping under it.
+func (r *PostgresCategoryRepository) Update(ctx context.Context, category *entities.Category, oldName string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE categories SET parent_id = $2, name = $3, slug = $4, sort_order = $5, tax_rate_id = $6, updated_at = $7,
+			updated_by = $8
+		WHERE id = $1`
+
+	result, err := tx.ExecContext(ctx, query,
+		category.ID, category.ParentID, category.Name, category.Slug, category.SortOrder, category.TaxRateID,
+		category.UpdatedAt, category.UpdatedBy)
+	if err != nil {
+		if conflict := categoryConflict(err, category); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("category")
+	}
+
+	if category.Name != oldName {
+		_, err = tx.ExecContext(ctx, `UPDATE products SET category = $2 WHERE category_id = $1`, category.ID, category.Name)
+		if err != nil {
+			return fmt.Errorf("failed to rename category of products: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE margin_floors SET category = $3
+			WHERE tenant_id = $1 AND LOWER(category) = LOWER($2)
+				AND NOT EXISTS (SELECT 1 FROM margin_floors f WHERE f.tenant_id = $1 AND LOWER(f.category) = LOWER($3))`,
+			category.TenantID, oldName, category.Name)
+		if err != nil {
+			return fmt.Errorf("failed to rename category of margin floor: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete deletes a category
+func (r *PostgresCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM categories WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return errors.NewConflictError("category has subcategories")
+		}
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("category")
+	}
+
+	return nil
+}
+
+// CountProducts counts the products filed directly under the category
+func (r *PostgresCategoryRepository) CountProducts(ctx context.Context, id uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM products WHERE category_id = $1 AND deleted_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count products by category: %w", err)
+	}
+
+	return count, nil
+}
+
+// categoryConflict translates a unique violation on the category's name or slug
+func categoryConflict(err error, category *entities.Category) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok || pqErr.Code != "23505" {
+		return nil
+	}
+	if strings.Contains(pqErr.Constraint, "slug") {
+		return errors.NewConflictError(fmt.Sprintf("category slug '%s' already exists", category.Slug))
+	}
+	return errors.NewConflictError(fmt.Sprintf("category '%s' already exists", category.Name))
+}
+
+// scanCategory scans a category from a row
+func (r *PostgresCategoryRepository) scanCategory(row interface{ Scan(...interface{}) error }) (*entities.Category, error) {
+	var category entities.Category
+	var parentID, taxRateID uuid.NullUUID
+
+	err := row.Scan(&category.ID, &category.TenantID, &parentID, &category.Name, &category.Slug,
+		&category.SortOrder, &taxRateID, &category.CreatedAt, &category.UpdatedAt, &category.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		category.ParentID = &parentID.UUID
+	}
+	if taxRateID.Valid {
+		category.TaxRateID = &taxRateID.UUID
+	}
+
+	return &category, nil
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresCheckoutRuleRepository implements the CheckoutRuleRepository interface
+type PostgresCheckoutRuleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCheckoutRuleRepository creates a new PostgreSQL checkout rule repository
+func NewPostgresCheckoutRuleRepository(db *sql.DB) repositories.CheckoutRuleRepository {
+	return &PostgresCheckoutRuleRepository{db: db}
+}
+
+// Create creates a new checkout rule
+func (r *PostgresCheckoutRuleRepository) Create(ctx context.Context, rule *entities.CheckoutRule) error {
+	conditionsJSON, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkout rule conditions: %w", err)
+	}
+
+	query := `
+		INSERT INTO checkout_rules (id, tenant_id, name, description, conditions, message,
+			is_active, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		rule.ID, rule.TenantID, rule.Name, rule.Description, conditionsJSON, rule.Message,
+		rule.IsActive, rule.CreatedAt, rule.UpdatedAt, rule.CreatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("checkout rule with name '%s' already exists", rule.Name))
+		}
+		return fmt.Errorf("failed to insert checkout rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a checkout rule by ID
+func (r *PostgresCheckoutRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.CheckoutRule, error) {
+	query := `
+		SELECT id, tenant_id, name, description, conditions, message,
+			is_active, created_at, updated_at, created_by
+		FROM checkout_rules 
+		WHERE id = $1`
+
+	rule, err := r.scanCheckoutRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("checkout rule")
+		}
+		return nil, fmt.Errorf("failed to get checkout rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Update updates an existing checkout rule
+func (r *PostgresCheckoutRuleRepository) Update(ctx context.Context, rule *entities.CheckoutRule) error {
+	conditionsJSON, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkout rule conditions: %w", err)
+	}
+
+	query := `
+		UPDATE checkout_rules SET 
+			name = $2, description = $3, conditions = $4, message = $5,
+			is_active = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID, rule.Name, rule.Description, conditionsJSON, rule.Message,
+		rule.IsActive, rule.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("checkout rule with name '%s' already exists", rule.Name))
+		}
+		return fmt.Errorf("failed to update checkout rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("checkout rule")
+	}
+
+	return nil
+}
+
+// Delete deletes a checkout rule
+func (r *PostgresCheckoutRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM checkout_rules WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete checkout rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("checkout rule")
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves all checkout rules for a tenant
+func (r *PostgresCheckoutRuleRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.CheckoutRule, error) {
+	query := `
+		SELECT id, tenant_id, name, description, conditions, message,
+			is_active, created_at, updated_at, created_by
+		FROM checkout_rules 
+		WHERE tenant_id = $1
+		ORDER BY created_at`
+
+	return r.queryCheckoutRules(ctx, query, tenantID)
+}
+
+// GetActiveByTenant retrieves active checkout rules for a tenant
+func (r *PostgresCheckoutRuleRepository) GetActiveByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.CheckoutRule, error) {
+	query := `
+		SELECT id, tenant_id, name, description, conditions, message,
+			is_active, created_at, updated_at, created_by
+		FROM checkout_rules 
+		WHERE tenant_id = $1 AND is_active = true
+		ORDER BY created_at`
+
+	return r.queryCheckoutRules(ctx, query, tenantID)
+}
+
+// Helper functions
+
+// queryCheckoutRules runs a checkout rule query and scans all rows
+func (r *PostgresCheckoutRuleRepository) queryCheckoutRules(ctx context.Context, query string, args ...interface{}) ([]*entities.CheckoutRule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checkout rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.CheckoutRule
+	for rows.Next() {
+		rule, err := r.scanCheckoutRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan checkout rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate checkout rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// scanCheckoutRule scans a checkout rule from a row
+func (r *PostgresCheckoutRuleRepository) scanCheckoutRule(row interface{ Scan(...interface{}) error }) (*entities.CheckoutRule, error) {
+	var rule entities.CheckoutRule
+	var description sql.NullString
+	var conditionsJSON []byte
+
+	err := row.Scan(
+		&rule.ID, &rule.TenantID, &rule.Name, &description, &conditionsJSON, &rule.Message,
+		&rule.IsActive, &rule.CreatedAt, &rule.UpdatedAt, &rule.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Description = description.String
+	if err := json.Unmarshal(conditionsJSON, &rule.Conditions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkout rule conditions: %w", err)
+	}
+
+	return &rule, nil
+}
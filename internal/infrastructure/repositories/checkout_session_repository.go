@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresCheckoutSessionRepository implements the CheckoutSessionRepository interface
+type PostgresCheckoutSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCheckoutSessionRepository creates a new PostgreSQL checkout session repository
+func NewPostgresCheckoutSessionRepository(db *sql.DB) repositories.CheckoutSessionRepository {
+	return &PostgresCheckoutSessionRepository{db: db}
+}
+
+const checkoutSessionColumns = `id, tenant_id, sale_id, status, step, evaluation, payment_intent, expires_at,
+			completed_at, created_by, created_at, updated_at`
+
+// Create creates a new checkout session
+func (r *PostgresCheckoutSessionRepository) Create(ctx context.Context, session *entities.CheckoutSession) error {
+	evaluationJSON, intentJSON, err := marshalCheckoutState(session)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO checkout_sessions (id, tenant_id, sale_id, status, step, evaluation, payment_intent,
+			expires_at, completed_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		session.ID, session.TenantID, session.SaleID, session.Status, session.Step, evaluationJSON, intentJSON,
+		session.ExpiresAt, session.CompletedAt, session.CreatedBy, session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("sale already has a checkout session")
+		}
+		return fmt.Errorf("failed to insert checkout session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a checkout session by ID
+func (r *PostgresCheckoutSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.CheckoutSession, error) {
+	query := fmt.Sprintf(`SELECT %s FROM checkout_sessions WHERE id = $1`, checkoutSessionColumns)
+
+	session, err := r.scanCheckoutSession(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("checkout session")
+		}
+		return nil, fmt.Errorf("failed to get checkout session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Update updates a checkout session
+func (r *PostgresCheckoutSessionRepository) Update(ctx context.Context, session *entities.CheckoutSession) error {
+	evaluationJSON, intentJSON, err := marshalCheckoutState(session)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE checkout_sessions SET
+			status = $2, step = $3, evaluation = $4, payment_intent = $5, expires_at = $6,
+			completed_at = $7, updated_at = $8
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID, session.Status, session.Step, evaluationJSON, intentJSON, session.ExpiresAt,
+		session.CompletedAt, session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update checkout session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("checkout session")
+	}
+
+	return nil
+}
+
+// GetIdle retrieves active checkout sessions across all tenants that expired before the given
+// time, oldest first
+func (r *PostgresCheckoutSessionRepository) GetIdle(ctx context.Context, before time.Time, limit int) ([]*entities.CheckoutSession, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM checkout_sessions
+		WHERE status = 'active' AND expires_at < $1
+		ORDER BY expires_at
+		LIMIT $2`, checkoutSessionColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idle checkout sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*entities.CheckoutSession{}
+	for rows.Next() {
+		session, err := r.scanCheckoutSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan checkout session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate checkout sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Helper functions
+
+// marshalCheckoutState marshals the evaluation and payment intent of a session, leaving them
+// NULL when not set
+func marshalCheckoutState(session *entities.CheckoutSession) ([]byte, []byte, error) {
+	var evaluationJSON, intentJSON []byte
+	var err error
+
+	if session.Evaluation != nil {
+		evaluationJSON, err = json.Marshal(session.Evaluation)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal checkout evaluation: %w", err)
+		}
+	}
+	if session.PaymentIntent != nil {
+		intentJSON, err = json.Marshal(session.PaymentIntent)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal payment intent: %w", err)
+		}
+	}
+
+	return evaluationJSON, intentJSON, nil
+}
+
+// scanCheckoutSession scans a checkout session from a row
+func (r *PostgresCheckoutSessionRepository) scanCheckoutSession(row interface{ Scan(...interface{}) error }) (*entities.CheckoutSession, error) {
+	var session entities.CheckoutSession
+	var evaluationJSON, intentJSON []byte
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID, &session.TenantID, &session.SaleID, &session.Status, &session.Step, &evaluationJSON, &intentJSON,
+		&session.ExpiresAt, &completedAt, &session.CreatedBy, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(evaluationJSON) > 0 {
+		if err := json.Unmarshal(evaluationJSON, &session.Evaluation); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal checkout evaluation: %w", err)
+		}
+	}
+	if len(intentJSON) > 0 {
+		if err := json.Unmarshal(intentJSON, &session.PaymentIntent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payment intent: %w", err)
+		}
+	}
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+
+	return &session, nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresCostLayerRepository implements the CostLayerRepository interface
+type PostgresCostLayerRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCostLayerRepository creates a new PostgreSQL cost layer repository
+func NewPostgresCostLayerRepository(db *sql.DB) repositories.CostLayerRepository {
+	return &PostgresCostLayerRepository{db: db}
+}
+
+// Create records a cost layer
+func (r *PostgresCostLayerRepository) Create(ctx context.Context, layer *entities.CostLayer) error {
+	query := `
+		INSERT INTO cost_layers (id, tenant_id, product_id, reference, quantity, remaining_qty, unit_cost,
+			received_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		layer.ID, layer.TenantID, layer.ProductID, layer.Reference, layer.Quantity, layer.RemainingQty,
+		layer.UnitCost, layer.ReceivedAt, layer.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create cost layer: %w", err)
+	}
+
+	return nil
+}
+
+// GetOpenByProductForUpdate retrieves the layers of a product with units remaining, oldest
+// first, and locks them until the transaction ends
+func (r *PostgresCostLayerRepository) GetOpenByProductForUpdate(ctx context.Context, productID uuid.UUID) ([]*entities.CostLayer, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{productID})
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT id, tenant_id, product_id, reference, quantity, remaining_qty, unit_cost, received_at, updated_at
+		FROM cost_layers
+		WHERE product_id = $1 AND remaining_qty > 0` + scope + `
+		ORDER BY received_at, id
+		FOR UPDATE`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cost layers: %w", err)
+	}
+	defer rows.Close()
+
+	var layers []*entities.CostLayer
+	for rows.Next() {
+		var layer entities.CostLayer
+		err := rows.Scan(&layer.ID, &layer.TenantID, &layer.ProductID, &layer.Reference, &layer.Quantity,
+			&layer.RemainingQty, &layer.UnitCost, &layer.ReceivedAt, &layer.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cost layer: %w", err)
+		}
+		layers = append(layers, &layer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate cost layers: %w", err)
+	}
+
+	return layers, nil
+}
+
+// UpdateRemaining updates the units remaining in a cost layer
+func (r *PostgresCostLayerRepository) UpdateRemaining(ctx context.Context, layer *entities.CostLayer) error {
+	query := `UPDATE cost_layers SET remaining_qty = $2, updated_at = $3 WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{layer.ID, layer.RemainingQty, layer.UpdatedAt})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update cost layer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("cost layer")
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresCouponRepository implements the CouponRepository interface
+type PostgresCouponRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCouponRepository creates a new PostgreSQL coupon repository
+func NewPostgresCouponRepository(db *sql.DB) repositories.CouponRepository {
+	return &PostgresCouponRepository{db: db}
+}
+
+const couponColumns = `id, tenant_id, code, type, value, max_redemptions, redemption_count, expires_at, is_active,
+			created_at, updated_at, updated_by`
+
+const couponRedemptionColumns = `id, tenant_id, coupon_id, sale_id, code, discount_amount, sale_total, redeemed_by,
+			redeemed_at`
+
+// Create creates a new coupon
+func (r *PostgresCouponRepository) Create(ctx context.Context, coupon *entities.Coupon) error {
+	query := fmt.Sprintf(`
+		INSERT INTO coupons (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`, couponColumns)
+
+	_, err := r.db.ExecContext(ctx, query,
+		coupon.ID, coupon.TenantID, coupon.Code, coupon.Type, coupon.Value, coupon.MaxRedemptions,
+		coupon.RedemptionCount, coupon.ExpiresAt, coupon.IsActive, coupon.CreatedAt, coupon.UpdatedAt,
+		coupon.UpdatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("coupon '%s' already exists", coupon.Code))
+		}
+		return fmt.Errorf("failed to insert coupon: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a coupon by ID
+func (r *PostgresCouponRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Coupon, error) {
+	query := fmt.Sprintf(`SELECT %s FROM coupons WHERE id = $1`, couponColumns)
+
+	return r.getCoupon(ctx, query, id)
+}
+
+// GetByCode retrieves the tenant's coupon with the given normalized code
+func (r *PostgresCouponRepository) GetByCode(ctx context.Context, tenantID uuid.UUID, code string) (*entities.Coupon, error) {
+	query := fmt.Sprintf(`SELECT %s FROM coupons WHERE tenant_id = $1 AND code = $2`, couponColumns)
+
+	return r.getCoupon(ctx, query, tenantID, code)
+}
+
+// GetByCodeForUpdate retrieves the tenant's coupon with the given normalized code and locks it
+// until the transaction ends
+func (r *PostgresCouponRepository) GetByCodeForUpdate(ctx context.Context, tenantID uuid.UUID, code string) (*entities.Coupon, error) {
+	query := fmt.Sprintf(`SELECT %s FROM coupons WHERE tenant_id = $1 AND code = $2 FOR UPDATE`, couponColumns)
+
+	return r.getCoupon(ctx, query, tenantID, code)
+}
+
+// getCoupon retrieves a single coupon with the given query
+func (r *PostgresCouponRepository) getCoupon(ctx context.Context, query string, args ...interface{}) (*entities.Coupon, error) {
+	coupon, err := r.scanCoupon(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("coupon")
+		}
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	return coupon, nil
+}
+
+// GetByTenant retrieves all the tenant's coupons, newest first
+func (r *PostgresCouponRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.Coupon, error) {
+	query := fmt.Sprintf(`SELECT %s FROM coupons WHERE tenant_id = $1 ORDER BY created_at DESC`, couponColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coupons: %w", err)
+	}
+	defer rows.Close()
+
+	coupons := []*entities.Coupon{}
+	for rows.Next() {
+		coupon, err := r.scanCoupon(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan coupon: %w", err)
+		}
+		coupons = append(coupons, coupon)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate coupons: %w", err)
+	}
+
+	return coupons, nil
+}
+
+// Update updates a coupon. Its redemption count is only changed by CreateRedemption.
+func (r *PostgresCouponRepository) Update(ctx context.Context, coupon *entities.Coupon) error {
+	query := `
+		UPDATE coupons SET
+			type = $2, value = $3, max_redemptions = $4, expires_at = $5, is_active = $6, updated_at = $7,
+			updated_by = $8
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		coupon.ID, coupon.Type, coupon.Value, coupon.MaxRedemptions, coupon.ExpiresAt, coupon.IsActive,
+		coupon.UpdatedAt, coupon.UpdatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23514" {
+			return errors.NewConflictError("coupon has been redeemed more often than the new limit allows")
+		}
+		return fmt.Errorf("failed to update coupon: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("coupon")
+	}
+
+	return nil
+}
+
+// CreateRedemption records a coupon applied to a sale and counts it against the coupon's
+// redemption limit. It must be called on a transaction's repository.
+func (r *PostgresCouponRepository) CreateRedemption(ctx context.Context, redemption *entities.CouponRedemption) error {
+	query := fmt.Sprintf(`
+		INSERT INTO coupon_redemptions (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, couponRedemptionColumns)
+
+	_, err := r.db.ExecContext(ctx, query,
+		redemption.ID, redemption.TenantID, redemption.CouponID, redemption.SaleID, redemption.Code,
+		redemption.DiscountAmount, redemption.SaleTotal, redemption.RedeemedBy, redemption.RedeemedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("a coupon has already been applied to this sale")
+		}
+		return fmt.Errorf("failed to insert coupon redemption: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE coupons SET redemption_count = redemption_count + 1
+		WHERE id = $1 AND (max_redemptions = 0 OR redemption_count < max_redemptions)`,
+		redemption.CouponID)
+	if err != nil {
+		return fmt.Errorf("failed to count coupon redemption: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewConflictError(fmt.Sprintf("coupon '%s' has reached its redemption limit", redemption.Code))
+	}
+
+	return nil
+}
+
+// GetRedemptionsByCoupon retrieves the redemptions of a coupon, newest first
+func (r *PostgresCouponRepository) GetRedemptionsByCoupon(ctx context.Context, couponID uuid.UUID, limit int) ([]*entities.CouponRedemption, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM coupon_redemptions
+		WHERE coupon_id = $1
+		ORDER BY redeemed_at DESC
+		LIMIT $2`, couponRedemptionColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, couponID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coupon redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	redemptions := []*entities.CouponRedemption{}
+	for rows.Next() {
+		redemption, err := r.scanRedemption(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan coupon redemption: %w", err)
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate coupon redemptions: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+// GetPerformanceLines retrieves the redemptions of each of the tenant's coupons between the
+// dates, excluding refunded sales. Amounts are converted to the tenant's base currency.
+func (r *PostgresCouponRepository) GetPerformanceLines(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*repositories.CouponPerformanceLine, error) {
+	query := `
+		SELECT
+			c.id,
+			c.code,
+			COUNT(cr.id) as redemptions,
+			COALESCE(SUM(cr.discount_amount * s.exchange_rate), 0) as discount_amount,
+			COALESCE(SUM(cr.sale_total * s.exchange_rate), 0) as sales_total
+		FROM coupons c
+		LEFT JOIN (coupon_redemptions cr JOIN sales s ON s.id = cr.sale_id AND s.status = 'completed')
+			ON cr.coupon_id = c.id AND cr.redeemed_at >= $2 AND cr.redeemed_at < $3
+		WHERE c.tenant_id = $1
+		GROUP BY c.id, c.code
+		ORDER BY redemptions DESC, c.code`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coupon performance lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*repositories.CouponPerformanceLine
+	for rows.Next() {
+		var line repositories.CouponPerformanceLine
+		if err := rows.Scan(&line.CouponID, &line.Code, &line.Redemptions, &line.DiscountAmount, &line.SalesTotal); err != nil {
+			return nil, fmt.Errorf("failed to scan coupon performance line: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate coupon performance lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanCoupon scans a coupon from a row
+func (r *PostgresCouponRepository) scanCoupon(row interface{ Scan(...interface{}) error }) (*entities.Coupon, error) {
+	var coupon entities.Coupon
+	var expiresAt sql.NullTime
+
+	err := row.Scan(&coupon.ID, &coupon.TenantID, &coupon.Code, &coupon.Type, &coupon.Value,
+		&coupon.MaxRedemptions, &coupon.RedemptionCount, &expiresAt, &coupon.IsActive, &coupon.CreatedAt,
+		&coupon.UpdatedAt, &coupon.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		coupon.ExpiresAt = &expiresAt.Time
+	}
+
+	return &coupon, nil
+}
+
+// scanRedemption scans a coupon redemption from a row
+func (r *PostgresCouponRepository) scanRedemption(row interface{ Scan(...interface{}) error }) (*entities.CouponRedemption, error) {
+	var redemption entities.CouponRedemption
+
+	err := row.Scan(&redemption.ID, &redemption.TenantID, &redemption.CouponID, &redemption.SaleID,
+		&redemption.Code, &redemption.DiscountAmount, &redemption.SaleTotal, &redemption.RedeemedBy,
+		&redemption.RedeemedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redemption, nil
+}
@@ -0,0 +1,523 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresCustomerRepository implements the CustomerRepository interface
+type PostgresCustomerRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCustomerRepository creates a new PostgreSQL customer repository
+func NewPostgresCustomerRepository(db *sql.DB) repositories.CustomerRepository {
+	return &PostgresCustomerRepository{db: db}
+}
+
+const customerColumns = `id, tenant_id, name, email, phone, email_key, phone_key, merged_into, merged_at,
+			created_at, updated_at`
+
+const customerDuplicateColumns = `id, tenant_id, customer_id, duplicate_id, score, reasons, status, resolved_by,
+			resolved_at, created_at, updated_at`
+
+// customerDocumentQueries holds the table of each linked document kind and the condition a
+// document must meet to be linked: settled, so its customer details no longer change
+var customerDocumentQueries = map[repositories.CustomerDocument]struct {
+	table   string
+	settled string
+}{
+	repositories.CustomerDocumentSale:    {table: "sales", settled: "status IN ('completed', 'refunded')"},
+	repositories.CustomerDocumentInvoice: {table: "invoices", settled: "status NOT IN ('draft', 'cancelled')"},
+}
+
+// Create creates a new customer
+func (r *PostgresCustomerRepository) Create(ctx context.Context, customer *entities.Customer) error {
+	query := `
+		INSERT INTO customers (id, tenant_id, name, email, phone, email_key, phone_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		customer.ID, customer.TenantID, customer.Name, customer.Email, customer.Phone, customer.EmailKey,
+		customer.PhoneKey, customer.CreatedAt, customer.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("customer with this contact already exists")
+		}
+		return fmt.Errorf("failed to insert customer: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a customer by ID
+func (r *PostgresCustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Customer, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a customer by ID and locks it until the transaction ends
+func (r *PostgresCustomerRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Customer, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a customer by ID, with an optional locking clause
+func (r *PostgresCustomerRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.Customer, error) {
+	query := fmt.Sprintf(`SELECT %s FROM customers WHERE id = $1`, customerColumns)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	customer, err := r.scanCustomer(r.db.QueryRowContext(ctx, query+scope+lock, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("customer")
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// GetByContact retrieves the customer of a tenant with the normalized email or, when no email is
+// given, the normalized phone number
+func (r *PostgresCustomerRepository) GetByContact(ctx context.Context, tenantID uuid.UUID, emailKey, phoneKey string) (*entities.Customer, error) {
+	column, key := "email_key", emailKey
+	if emailKey == "" {
+		column, key = "phone_key", phoneKey
+	}
+	if key == "" {
+		return nil, errors.NewNotFoundError("customer")
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM customers WHERE tenant_id = $1 AND %s = $2`, customerColumns, column)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{tenantID, key})
+	if err != nil {
+		return nil, err
+	}
+	order := " ORDER BY merged_into IS NULL DESC, email_key = '' DESC, created_at LIMIT 1"
+	customer, err := r.scanCustomer(r.db.QueryRowContext(ctx, query+scope+order, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("customer")
+		}
+		return nil, fmt.Errorf("failed to get customer by contact: %w", err)
+	}
+
+	return customer, nil
+}
+
+// Update updates a customer's details and merge state
+func (r *PostgresCustomerRepository) Update(ctx context.Context, customer *entities.Customer) error {
+	query := `
+		UPDATE customers SET
+			name = $2, email = $3, phone = $4, email_key = $5, phone_key = $6, merged_into = $7,
+			merged_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		customer.ID, customer.Name, customer.Email, customer.Phone, customer.EmailKey, customer.PhoneKey,
+		customer.MergedInto, customer.MergedAt, customer.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("customer with this contact already exists")
+		}
+		return fmt.Errorf("failed to update customer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("customer")
+	}
+
+	return nil
+}
+
+// ListActive retrieves the customers of a tenant that were not merged away
+func (r *PostgresCustomerRepository) ListActive(ctx context.Context, tenantID uuid.UUID) ([]*entities.Customer, error) {
+	query := fmt.Sprintf(`SELECT %s FROM customers WHERE tenant_id = $1 AND merged_into IS NULL`, customerColumns)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{tenantID})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, query+scope+" ORDER BY created_at, id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query customers: %w", err)
+	}
+	defer rows.Close()
+
+	customers := []*entities.Customer{}
+	for rows.Next() {
+		customer, err := r.scanCustomer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		customers = append(customers, customer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate customers: %w", err)
+	}
+
+	return customers, nil
+}
+
+// ListTenantIDs retrieves the tenants that have customers
+func (r *PostgresCustomerRepository) ListTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query := `SELECT DISTINCT tenant_id FROM customers WHERE merged_into IS NULL`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", nil)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, query+scope, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query customer tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenantIDs := []uuid.UUID{}
+	for rows.Next() {
+		var tenantID uuid.UUID
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, fmt.Errorf("failed to scan customer tenant: %w", err)
+		}
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate customer tenants: %w", err)
+	}
+
+	return tenantIDs, nil
+}
+
+// ListUnlinkedContacts retrieves, in ID order after afterID, the contacts of settled documents
+// with an email or phone number that are not linked to a customer yet
+func (r *PostgresCustomerRepository) ListUnlinkedContacts(ctx context.Context, document repositories.CustomerDocument, afterID uuid.UUID, limit int) ([]*repositories.CustomerContact, error) {
+	queries, ok := customerDocumentQueries[document]
+	if !ok {
+		return nil, fmt.Errorf("unknown customer document %q", document)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, tenant_id, COALESCE(customer_name, ''), COALESCE(customer_email, ''), COALESCE(customer_phone, '')
+		FROM %s
+		WHERE id > $1 AND customer_id IS NULL AND deleted_at IS NULL AND %s
+			AND (COALESCE(customer_email, '') <> '' OR COALESCE(customer_phone, '') <> '')`,
+		queries.table, queries.settled)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{afterID})
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, limit)
+	rows, err := r.db.QueryContext(ctx, query+scope+fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unlinked %s contacts: %w", document, err)
+	}
+	defer rows.Close()
+
+	contacts := []*repositories.CustomerContact{}
+	for rows.Next() {
+		contact := repositories.CustomerContact{Document: document}
+		if err := rows.Scan(&contact.DocumentID, &contact.TenantID, &contact.Name, &contact.Email, &contact.Phone); err != nil {
+			return nil, fmt.Errorf("failed to scan unlinked %s contact: %w", document, err)
+		}
+		contacts = append(contacts, &contact)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate unlinked %s contacts: %w", document, err)
+	}
+
+	return contacts, nil
+}
+
+// LinkDocuments links documents to a customer
+func (r *PostgresCustomerRepository) LinkDocuments(ctx context.Context, document repositories.CustomerDocument, customerID uuid.UUID, documentIDs []uuid.UUID) error {
+	queries, ok := customerDocumentQueries[document]
+	if !ok {
+		return fmt.Errorf("unknown customer document %q", document)
+	}
+	if len(documentIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(documentIDs))
+	for i, id := range documentIDs {
+		ids[i] = id.String()
+	}
+
+	// Only documents of the customer's own tenant are linked
+	query := fmt.Sprintf(`
+		UPDATE %s SET customer_id = $1
+		WHERE id = ANY($2::uuid[]) AND customer_id IS NULL
+			AND tenant_id = (SELECT tenant_id FROM customers WHERE id = $1)`, queries.table)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{customerID, pq.Array(ids)})
+	if err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, query+scope, args...); err != nil {
+		return fmt.Errorf("failed to link %s documents to customer: %w", document, err)
+	}
+
+	return nil
+}
+
+// Relink moves the sales, invoices and merged customers of a merged customer to its survivor
+func (r *PostgresCustomerRepository) Relink(ctx context.Context, fromID, toID uuid.UUID) (*repositories.CustomerMergeCounts, error) {
+	counts := &repositories.CustomerMergeCounts{}
+
+	relink := func(query string) (int, error) {
+		scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromID, toID})
+		if err != nil {
+			return 0, err
+		}
+		result, err := r.db.ExecContext(ctx, query+scope, args...)
+		if err != nil {
+			return 0, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		return int(rowsAffected), err
+	}
+
+	var err error
+	if counts.Sales, err = relink(`UPDATE sales SET customer_id = $2 WHERE customer_id = $1`); err != nil {
+		return nil, fmt.Errorf("failed to relink customer sales: %w", err)
+	}
+	if counts.Invoices, err = relink(`UPDATE invoices SET customer_id = $2 WHERE customer_id = $1`); err != nil {
+		return nil, fmt.Errorf("failed to relink customer invoices: %w", err)
+	}
+	// Customers merged earlier into the merged customer now point straight at the survivor
+	if _, err = relink(`UPDATE customers SET merged_into = $2 WHERE merged_into = $1`); err != nil {
+		return nil, fmt.Errorf("failed to relink merged customers: %w", err)
+	}
+
+	return counts, nil
+}
+
+// SaveDuplicates records duplicate suggestions, leaving pairs already suggested as they are
+func (r *PostgresCustomerRepository) SaveDuplicates(ctx context.Context, duplicates []*entities.CustomerDuplicate) error {
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO customer_duplicates (id, tenant_id, customer_id, duplicate_id, score, reasons, status,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT DO NOTHING`
+
+	for _, duplicate := range duplicates {
+		_, err := tx.ExecContext(ctx, query,
+			duplicate.ID, duplicate.TenantID, duplicate.CustomerID, duplicate.DuplicateID, duplicate.Score,
+			pq.Array(customerMatchReasonStrings(duplicate.Reasons)), duplicate.Status, duplicate.CreatedAt,
+			duplicate.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save customer duplicate: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetDuplicateByID retrieves a duplicate suggestion by ID
+func (r *PostgresCustomerRepository) GetDuplicateByID(ctx context.Context, id uuid.UUID) (*entities.CustomerDuplicate, error) {
+	query := fmt.Sprintf(`SELECT %s FROM customer_duplicates WHERE id = $1`, customerDuplicateColumns)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := r.scanDuplicate(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("customer duplicate")
+		}
+		return nil, fmt.Errorf("failed to get customer duplicate: %w", err)
+	}
+
+	return duplicate, nil
+}
+
+// UpdateDuplicate updates a duplicate suggestion's status
+func (r *PostgresCustomerRepository) UpdateDuplicate(ctx context.Context, duplicate *entities.CustomerDuplicate) error {
+	query := `
+		UPDATE customer_duplicates SET
+			status = $2, resolved_by = $3, resolved_at = $4, updated_at = $5
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		duplicate.ID, duplicate.Status, duplicate.ResolvedBy, duplicate.ResolvedAt, duplicate.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update customer duplicate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("customer duplicate")
+	}
+
+	return nil
+}
+
+// ListPendingDuplicates retrieves the pending duplicate suggestions of a tenant, best match first
+func (r *PostgresCustomerRepository) ListPendingDuplicates(ctx context.Context, tenantID uuid.UUID, limit int) ([]*entities.CustomerDuplicate, error) {
+	query := fmt.Sprintf(`SELECT %s FROM customer_duplicates WHERE tenant_id = $1 AND status = 'pending'`, customerDuplicateColumns)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{tenantID})
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, limit)
+	rows, err := r.db.QueryContext(ctx, query+scope+fmt.Sprintf(" ORDER BY score DESC, created_at LIMIT $%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query customer duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	duplicates := []*entities.CustomerDuplicate{}
+	for rows.Next() {
+		duplicate, err := r.scanDuplicate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan customer duplicate: %w", err)
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate customer duplicates: %w", err)
+	}
+
+	return duplicates, nil
+}
+
+// ResolveMergedDuplicates marks the suggestion of a merged pair as merged and drops the other
+// pending suggestions involving the merged customer
+func (r *PostgresCustomerRepository) ResolveMergedDuplicates(ctx context.Context, survivorID, mergedID, userID uuid.UUID, resolvedAt time.Time) error {
+	query := `
+		UPDATE customer_duplicates SET status = 'merged', resolved_by = $3, resolved_at = $4, updated_at = $4
+		WHERE LEAST(customer_id, duplicate_id) = LEAST($1::uuid, $2::uuid)
+			AND GREATEST(customer_id, duplicate_id) = GREATEST($1::uuid, $2::uuid)`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{survivorID, mergedID, userID, resolvedAt})
+	if err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, query+scope, args...); err != nil {
+		return fmt.Errorf("failed to resolve merged customer duplicate: %w", err)
+	}
+
+	query = `
+		DELETE FROM customer_duplicates
+		WHERE status = 'pending' AND (customer_id = $1 OR duplicate_id = $1)`
+
+	scope, args, err = tenantScope(ctx, "tenant_id", []interface{}{mergedID})
+	if err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, query+scope, args...); err != nil {
+		return fmt.Errorf("failed to drop merged customer duplicates: %w", err)
+	}
+
+	return nil
+}
+
+// Helper methods
+
+// scanCustomer scans a customer from a row
+func (r *PostgresCustomerRepository) scanCustomer(row interface{ Scan(...interface{}) error }) (*entities.Customer, error) {
+	var customer entities.Customer
+	var mergedInto uuid.NullUUID
+	var mergedAt sql.NullTime
+
+	err := row.Scan(
+		&customer.ID, &customer.TenantID, &customer.Name, &customer.Email, &customer.Phone,
+		&customer.EmailKey, &customer.PhoneKey, &mergedInto, &mergedAt, &customer.CreatedAt,
+		&customer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if mergedInto.Valid {
+		customer.MergedInto = &mergedInto.UUID
+	}
+	if mergedAt.Valid {
+		customer.MergedAt = &mergedAt.Time
+	}
+
+	return &customer, nil
+}
+
+// scanDuplicate scans a duplicate suggestion from a row
+func (r *PostgresCustomerRepository) scanDuplicate(row interface{ Scan(...interface{}) error }) (*entities.CustomerDuplicate, error) {
+	var duplicate entities.CustomerDuplicate
+	var reasons []string
+	var resolvedBy uuid.NullUUID
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&duplicate.ID, &duplicate.TenantID, &duplicate.CustomerID, &duplicate.DuplicateID, &duplicate.Score,
+		pq.Array(&reasons), &duplicate.Status, &resolvedBy, &resolvedAt, &duplicate.CreatedAt,
+		&duplicate.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicate.Reasons = make([]entities.CustomerMatchReason, len(reasons))
+	for i, reason := range reasons {
+		duplicate.Reasons[i] = entities.CustomerMatchReason(reason)
+	}
+	if resolvedBy.Valid {
+		duplicate.ResolvedBy = &resolvedBy.UUID
+	}
+	if resolvedAt.Valid {
+		duplicate.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &duplicate, nil
+}
+
+// customerMatchReasonStrings converts match reasons for storage in a text array
+func customerMatchReasonStrings(reasons []entities.CustomerMatchReason) []string {
+	strs := make([]string, len(reasons))
+	for i, reason := range reasons {
+		strs[i] = string(reason)
+	}
+	return strs
+}
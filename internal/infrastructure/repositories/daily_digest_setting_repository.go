@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresDailyDigestSettingRepository implements the DailyDigestSettingRepository interface
+type PostgresDailyDigestSettingRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDailyDigestSettingRepository creates a new PostgreSQL daily digest setting repository
+func NewPostgresDailyDigestSettingRepository(db *sql.DB) repositories.DailyDigestSettingRepository {
+	return &PostgresDailyDigestSettingRepository{db: db}
+}
+
+// GetByTenant retrieves the digest setting of a tenant
+func (r *PostgresDailyDigestSettingRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.DailyDigestSetting, error) {
+	query := `
+		SELECT id, tenant_id, is_enabled, recipients, send_hour, timezone, last_digest_date,
+			created_at, updated_at, updated_by
+		FROM daily_digest_settings
+		WHERE tenant_id = $1`
+
+	setting, err := r.scanDailyDigestSetting(r.db.QueryRowContext(ctx, query, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("daily digest setting")
+		}
+		return nil, fmt.Errorf("failed to get daily digest setting: %w", err)
+	}
+
+	return setting, nil
+}
+
+// Save creates or updates the digest setting of a tenant
+func (r *PostgresDailyDigestSettingRepository) Save(ctx context.Context, setting *entities.DailyDigestSetting) error {
+	query := `
+		INSERT INTO daily_digest_settings (id, tenant_id, is_enabled, recipients, send_hour, timezone,
+			last_digest_date, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			is_enabled = EXCLUDED.is_enabled,
+			recipients = EXCLUDED.recipients,
+			send_hour = EXCLUDED.send_hour,
+			timezone = EXCLUDED.timezone,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		setting.ID, setting.TenantID, setting.IsEnabled, pq.Array(setting.Recipients), setting.SendHour,
+		setting.Timezone, setting.LastDigestDate, setting.CreatedAt, setting.UpdatedAt, setting.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save daily digest setting: %w", err)
+	}
+
+	return nil
+}
+
+// ListEnabled retrieves all enabled digest settings across tenants
+func (r *PostgresDailyDigestSettingRepository) ListEnabled(ctx context.Context) ([]*entities.DailyDigestSetting, error) {
+	query := `
+		SELECT id, tenant_id, is_enabled, recipients, send_hour, timezone, last_digest_date,
+			created_at, updated_at, updated_by
+		FROM daily_digest_settings
+		WHERE is_enabled = true
+		ORDER BY tenant_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily digest settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []*entities.DailyDigestSetting
+	for rows.Next() {
+		setting, err := r.scanDailyDigestSetting(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily digest setting: %w", err)
+		}
+		settings = append(settings, setting)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate daily digest settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// ClaimDigest atomically marks the digest for a business date as being sent
+func (r *PostgresDailyDigestSettingRepository) ClaimDigest(ctx context.Context, id uuid.UUID, date time.Time) (bool, error) {
+	query := `
+		UPDATE daily_digest_settings
+		SET last_digest_date = $2
+		WHERE id = $1 AND is_enabled = true AND (last_digest_date IS NULL OR last_digest_date < $2)`
+
+	result, err := r.db.ExecContext(ctx, query, id, date.Format("2006-01-02"))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim daily digest: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// ReleaseDigest restores the previous digest date after a failed send so it is retried
+func (r *PostgresDailyDigestSettingRepository) ReleaseDigest(ctx context.Context, id uuid.UUID, date time.Time, previous *time.Time) error {
+	var previousDate interface{}
+	if previous != nil {
+		previousDate = previous.Format("2006-01-02")
+	}
+
+	query := `
+		UPDATE daily_digest_settings
+		SET last_digest_date = $3
+		WHERE id = $1 AND last_digest_date = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, id, date.Format("2006-01-02"), previousDate); err != nil {
+		return fmt.Errorf("failed to release daily digest: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// scanDailyDigestSetting scans a daily digest setting from a row
+func (r *PostgresDailyDigestSettingRepository) scanDailyDigestSetting(row interface{ Scan(...interface{}) error }) (*entities.DailyDigestSetting, error) {
+	var setting entities.DailyDigestSetting
+	var recipients pq.StringArray
+	var lastDigestDate sql.NullTime
+
+	err := row.Scan(
+		&setting.ID, &setting.TenantID, &setting.IsEnabled, &recipients, &setting.SendHour, &setting.Timezone,
+		&lastDigestDate, &setting.CreatedAt, &setting.UpdatedAt, &setting.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	setting.Recipients = []string(recipients)
+	if setting.Recipients == nil {
+		setting.Recipients = []string{}
+	}
+	if lastDigestDate.Valid {
+		// DATE columns carry no zone; interpret them in the tenant's digest time zone
+		date := lastDigestDate.Time
+		localDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, setting.Location())
+		setting.LastDigestDate = &localDate
+	}
+
+	return &setting, nil
+}
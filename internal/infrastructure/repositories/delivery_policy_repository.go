@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresDeliveryPolicyRepository implements the DeliveryPolicyRepository interface
+type PostgresDeliveryPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDeliveryPolicyRepository creates a new PostgreSQL delivery policy repository
+func NewPostgresDeliveryPolicyRepository(db *sql.DB) repositories.DeliveryPolicyRepository {
+	return &PostgresDeliveryPolicyRepository{db: db}
+}
+
+// GetByTenant retrieves the delivery policy of a tenant
+func (r *PostgresDeliveryPolicyRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.DeliveryPolicy, error) {
+	query := `
+		SELECT id, tenant_id,
+			webhook_max_attempts, webhook_initial_backoff_seconds, webhook_max_backoff_seconds,
+			webhook_timeout_seconds, webhook_pause_after_failures,
+			email_max_attempts, email_initial_backoff_seconds, email_max_backoff_seconds,
+			email_timeout_seconds, email_pause_after_failures,
+			alert_recipients, created_at, updated_at, updated_by
+		FROM delivery_policies
+		WHERE tenant_id = $1`
+
+	var policy entities.DeliveryPolicy
+	var recipients pq.StringArray
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&policy.ID, &policy.TenantID,
+		&policy.Webhook.MaxAttempts, &policy.Webhook.InitialBackoffSeconds, &policy.Webhook.MaxBackoffSeconds,
+		&policy.Webhook.TimeoutSeconds, &policy.Webhook.PauseAfterFailures,
+		&policy.Email.MaxAttempts, &policy.Email.InitialBackoffSeconds, &policy.Email.MaxBackoffSeconds,
+		&policy.Email.TimeoutSeconds, &policy.Email.PauseAfterFailures,
+		&recipients, &policy.CreatedAt, &policy.UpdatedAt, &policy.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("delivery policy")
+		}
+		return nil, fmt.Errorf("failed to get delivery policy: %w", err)
+	}
+
+	policy.AlertRecipients = []string(recipients)
+	if policy.AlertRecipients == nil {
+		policy.AlertRecipients = []string{}
+	}
+
+	return &policy, nil
+}
+
+// Save creates or updates the delivery policy of a tenant
+func (r *PostgresDeliveryPolicyRepository) Save(ctx context.Context, policy *entities.DeliveryPolicy) error {
+	query := `
+		INSERT INTO delivery_policies (id, tenant_id,
+			webhook_max_attempts, webhook_initial_backoff_seconds, webhook_max_backoff_seconds,
+			webhook_timeout_seconds, webhook_pause_after_failures,
+			email_max_attempts, email_initial_backoff_seconds, email_max_backoff_seconds,
+			email_timeout_seconds, email_pause_after_failures,
+			alert_recipients, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			webhook_max_attempts = EXCLUDED.webhook_max_attempts,
+			webhook_initial_backoff_seconds = EXCLUDED.webhook_initial_backoff_seconds,
+			webhook_max_backoff_seconds = EXCLUDED.webhook_max_backoff_seconds,
+			webhook_timeout_seconds = EXCLUDED.webhook_timeout_seconds,
+			webhook_pause_after_failures = EXCLUDED.webhook_pause_after_failures,
+			email_max_attempts = EXCLUDED.email_max_attempts,
+			email_initial_backoff_seconds = EXCLUDED.email_initial_backoff_seconds,
+			email_max_backoff_seconds = EXCLUDED.email_max_backoff_seconds,
+			email_timeout_seconds = EXCLUDED.email_timeout_seconds,
+			email_pause_after_failures = EXCLUDED.email_pause_after_failures,
+			alert_recipients = EXCLUDED.alert_recipients,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		policy.ID, policy.TenantID,
+		policy.Webhook.MaxAttempts, policy.Webhook.InitialBackoffSeconds, policy.Webhook.MaxBackoffSeconds,
+		policy.Webhook.TimeoutSeconds, policy.Webhook.PauseAfterFailures,
+		policy.Email.MaxAttempts, policy.Email.InitialBackoffSeconds, policy.Email.MaxBackoffSeconds,
+		policy.Email.TimeoutSeconds, policy.Email.PauseAfterFailures,
+		pq.Array(policy.AlertRecipients), policy.CreatedAt, policy.UpdatedAt, policy.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save delivery policy: %w", err)
+	}
+
+	return nil
+}
+
+// RecordEmailFailure counts a failed email to a normalized address and returns how many
+// emails to it failed in a row
+func (r *PostgresDeliveryPolicyRepository) RecordEmailFailure(ctx context.Context, tenantID uuid.UUID, email, reason string, at time.Time) (int, error) {
+	query := `
+		INSERT INTO email_delivery_failures (tenant_id, email, consecutive_failures, last_error, last_failed_at)
+		VALUES ($1, $2, 1, $3, $4)
+		ON CONFLICT (tenant_id, email) DO UPDATE SET
+			consecutive_failures = email_delivery_failures.consecutive_failures + 1,
+			last_error = EXCLUDED.last_error,
+			last_failed_at = EXCLUDED.last_failed_at
+		RETURNING consecutive_failures`
+
+	var failures int
+	if err := r.db.QueryRowContext(ctx, query, tenantID, email, reason, at).Scan(&failures); err != nil {
+		return 0, fmt.Errorf("failed to record email failure: %w", err)
+	}
+
+	return failures, nil
+}
+
+// ResetEmailFailures clears the failure count of an address after a successful email
+func (r *PostgresDeliveryPolicyRepository) ResetEmailFailures(ctx context.Context, tenantID uuid.UUID, email string) error {
+	query := `DELETE FROM email_delivery_failures WHERE tenant_id = $1 AND email = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, tenantID, email); err != nil {
+		return fmt.Errorf("failed to reset email failures: %w", err)
+	}
+
+	return nil
+}
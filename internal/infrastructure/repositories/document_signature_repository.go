@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresDocumentSignatureRepository implements the DocumentSignatureRepository interface
+type PostgresDocumentSignatureRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDocumentSignatureRepository creates a new PostgreSQL document signature repository
+func NewPostgresDocumentSignatureRepository(db *sql.DB) repositories.DocumentSignatureRepository {
+	return &PostgresDocumentSignatureRepository{db: db}
+}
+
+// Create stores a new document signature
+func (r *PostgresDocumentSignatureRepository) Create(ctx context.Context, signature *entities.DocumentSignature) error {
+	query := `
+		INSERT INTO document_signatures (id, tenant_id, document_type, document_id, signer_name, signer_title,
+			image_data, content_type, ip_address, user_agent, signed_at, captured_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		signature.ID, signature.TenantID, signature.DocumentType, signature.DocumentID, signature.SignerName,
+		signature.SignerTitle, signature.ImageData, signature.ContentType, signature.IPAddress,
+		signature.UserAgent, signature.SignedAt, signature.CapturedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("document is already signed")
+		}
+		return fmt.Errorf("failed to insert document signature: %w", err)
+	}
+
+	return nil
+}
+
+// GetByDocument retrieves the signature of a document
+func (r *PostgresDocumentSignatureRepository) GetByDocument(ctx context.Context, documentType entities.SignableDocumentType, documentID uuid.UUID) (*entities.DocumentSignature, error) {
+	query := `
+		SELECT id, tenant_id, document_type, document_id, signer_name, signer_title,
+			image_data, content_type, ip_address, user_agent, signed_at, captured_by
+		FROM document_signatures
+		WHERE document_type = $1 AND document_id = $2`
+
+	var signature entities.DocumentSignature
+	err := r.db.QueryRowContext(ctx, query, documentType, documentID).Scan(
+		&signature.ID, &signature.TenantID, &signature.DocumentType, &signature.DocumentID, &signature.SignerName,
+		&signature.SignerTitle, &signature.ImageData, &signature.ContentType, &signature.IPAddress,
+		&signature.UserAgent, &signature.SignedAt, &signature.CapturedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("document signature")
+		}
+		return nil, fmt.Errorf("failed to get document signature: %w", err)
+	}
+
+	return &signature, nil
+}
@@ -0,0 +1,467 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PostgresEcommerceRepository implements the EcommerceRepository interface
+type PostgresEcommerceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEcommerceRepository creates a new PostgreSQL e-commerce repository
+func NewPostgresEcommerceRepository(db *sql.DB) repositories.EcommerceRepository {
+	return &PostgresEcommerceRepository{db: db}
+}
+
+const ecommerceIntegrationColumns = `id, tenant_id, platform, name, store_url, webhook_secret, COALESCE(access_token, ''),
+			COALESCE(consumer_key, ''), COALESCE(consumer_secret, ''), COALESCE(location_id, ''), push_stock, is_active,
+			last_order_at, last_stock_push_at, COALESCE(last_error, ''), created_by, created_at, updated_at`
+
+const ecommerceOrderColumns = `id, tenant_id, integration_id, external_id, order_number, status, sale_id, reservation_id,
+			COALESCE(error, ''), created_at, updated_at`
+
+const ecommerceProductLinkColumns = `id, tenant_id, integration_id, product_id, external_product_id,
+			COALESCE(external_variant_id, ''), COALESCE(inventory_item_id, ''), last_pushed_qty, last_pushed_at,
+			created_at, updated_at`
+
+// CreateIntegration creates a new e-commerce integration
+func (r *PostgresEcommerceRepository) CreateIntegration(ctx context.Context, integration *entities.EcommerceIntegration) error {
+	query := `
+		INSERT INTO ecommerce_integrations (id, tenant_id, platform, name, store_url, webhook_secret, access_token,
+			consumer_key, consumer_secret, location_id, push_stock, is_active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), $11, $12,
+			$13, $14, $15)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		integration.ID, integration.TenantID, integration.Platform, integration.Name, integration.StoreURL,
+		integration.WebhookSecret, integration.AccessToken, integration.ConsumerKey, integration.ConsumerSecret,
+		integration.LocationID, integration.PushStock, integration.IsActive, integration.CreatedBy,
+		integration.CreatedAt, integration.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("e-commerce integration '%s' already exists", integration.Name))
+		}
+		return fmt.Errorf("failed to insert e-commerce integration: %w", err)
+	}
+
+	return nil
+}
+
+// GetIntegrationByID retrieves an e-commerce integration by ID, with its credentials
+func (r *PostgresEcommerceRepository) GetIntegrationByID(ctx context.Context, id uuid.UUID) (*entities.EcommerceIntegration, error) {
+	query := `
+		SELECT ` + ecommerceIntegrationColumns + `
+		FROM ecommerce_integrations
+		WHERE id = $1`
+
+	integration, err := r.scanIntegration(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("e-commerce integration")
+		}
+		return nil, fmt.Errorf("failed to get e-commerce integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// GetIntegrationsByTenant retrieves all e-commerce integrations of a tenant
+func (r *PostgresEcommerceRepository) GetIntegrationsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.EcommerceIntegration, error) {
+	query := `
+		SELECT ` + ecommerceIntegrationColumns + `
+		FROM ecommerce_integrations
+		WHERE tenant_id = $1
+		ORDER BY name`
+
+	return r.queryIntegrations(ctx, query, tenantID)
+}
+
+// GetStockPushIntegrations retrieves the active integrations of every tenant that push stock
+// levels to their store
+func (r *PostgresEcommerceRepository) GetStockPushIntegrations(ctx context.Context) ([]*entities.EcommerceIntegration, error) {
+	query := `
+		SELECT ` + ecommerceIntegrationColumns + `
+		FROM ecommerce_integrations
+		WHERE is_active AND push_stock
+		ORDER BY tenant_id, name`
+
+	return r.queryIntegrations(ctx, query)
+}
+
+// UpdateIntegration updates an e-commerce integration's settings and credentials
+func (r *PostgresEcommerceRepository) UpdateIntegration(ctx context.Context, integration *entities.EcommerceIntegration) error {
+	query := `
+		UPDATE ecommerce_integrations
+		SET name = $2, store_url = $3, webhook_secret = $4, access_token = NULLIF($5, ''), consumer_key = NULLIF($6, ''),
+			consumer_secret = NULLIF($7, ''), location_id = NULLIF($8, ''), push_stock = $9, is_active = $10,
+			updated_at = $11
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		integration.ID, integration.Name, integration.StoreURL, integration.WebhookSecret, integration.AccessToken,
+		integration.ConsumerKey, integration.ConsumerSecret, integration.LocationID, integration.PushStock,
+		integration.IsActive, integration.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("e-commerce integration '%s' already exists", integration.Name))
+		}
+		return fmt.Errorf("failed to update e-commerce integration: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "e-commerce integration")
+}
+
+// RecordOrderReceived sets when the integration last received an order
+func (r *PostgresEcommerceRepository) RecordOrderReceived(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE ecommerce_integrations SET last_order_at = $2 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to record e-commerce order received: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "e-commerce integration")
+}
+
+// RecordStockPush saves the outcome of the latest stock push of an integration
+func (r *PostgresEcommerceRepository) RecordStockPush(ctx context.Context, integration *entities.EcommerceIntegration) error {
+	query := `
+		UPDATE ecommerce_integrations SET last_stock_push_at = $2, last_error = NULLIF($3, ''), updated_at = $4
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		integration.ID, integration.LastStockPushAt, integration.LastError, integration.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record e-commerce stock push: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "e-commerce integration")
+}
+
+// DeleteIntegration deletes an e-commerce integration with its orders and product links
+func (r *PostgresEcommerceRepository) DeleteIntegration(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM ecommerce_integrations WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete e-commerce integration: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "e-commerce integration")
+}
+
+// CreateOrder records an order received by an integration
+func (r *PostgresEcommerceRepository) CreateOrder(ctx context.Context, order *entities.EcommerceOrder) error {
+	query := `
+		INSERT INTO ecommerce_orders (id, tenant_id, integration_id, external_id, order_number, status, sale_id,
+			reservation_id, error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		order.ID, order.TenantID, order.IntegrationID, order.ExternalID, order.OrderNumber, order.Status,
+		order.SaleID, order.ReservationID, order.Error, order.CreatedAt, order.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("order '%s' is already being processed", order.OrderNumber))
+		}
+		return fmt.Errorf("failed to insert e-commerce order: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrderByExternalID retrieves an order of an integration by its ID on the store
+func (r *PostgresEcommerceRepository) GetOrderByExternalID(ctx context.Context, integrationID uuid.UUID, externalID string) (*entities.EcommerceOrder, error) {
+	query := `
+		SELECT ` + ecommerceOrderColumns + `
+		FROM ecommerce_orders
+		WHERE integration_id = $1 AND external_id = $2`
+
+	order, err := r.scanOrder(r.db.QueryRowContext(ctx, query, integrationID, externalID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("e-commerce order")
+		}
+		return nil, fmt.Errorf("failed to get e-commerce order: %w", err)
+	}
+
+	return order, nil
+}
+
+// UpdateOrder updates an order's status, sale, reservation and error
+func (r *PostgresEcommerceRepository) UpdateOrder(ctx context.Context, order *entities.EcommerceOrder) error {
+	query := `
+		UPDATE ecommerce_orders
+		SET status = $2, sale_id = $3, reservation_id = $4, error = NULLIF($5, ''), updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		order.ID, order.Status, order.SaleID, order.ReservationID, order.Error, order.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update e-commerce order: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "e-commerce order")
+}
+
+// ListOrders retrieves orders with pagination and filtering, newest first
+func (r *PostgresEcommerceRepository) ListOrders(ctx context.Context, filter repositories.EcommerceOrderFilter, pagination utils.PaginationInfo) ([]*entities.EcommerceOrder, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"integration_id = $1"}
+	args := []interface{}{filter.IntegrationID}
+	argCount := 1
+
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	}
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM ecommerce_orders %s", whereClause)
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, pagination, fmt.Errorf("failed to count e-commerce orders: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM ecommerce_orders
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		ecommerceOrderColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query e-commerce orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*entities.EcommerceOrder{}
+	for rows.Next() {
+		order, err := r.scanOrder(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan e-commerce order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate e-commerce orders: %w", err)
+	}
+
+	return orders, paginationResult, nil
+}
+
+// CreateProductLink links a product to a store product
+func (r *PostgresEcommerceRepository) CreateProductLink(ctx context.Context, link *entities.EcommerceProductLink) error {
+	query := `
+		INSERT INTO ecommerce_product_links (id, tenant_id, integration_id, product_id, external_product_id,
+			external_variant_id, inventory_item_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.ID, link.TenantID, link.IntegrationID, link.ProductID, link.ExternalProductID, link.ExternalVariantID,
+		link.InventoryItemID, link.CreatedAt, link.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("product is already linked on this integration")
+		}
+		return fmt.Errorf("failed to insert e-commerce product link: %w", err)
+	}
+
+	return nil
+}
+
+// GetProductLinkByID retrieves a product link by ID
+func (r *PostgresEcommerceRepository) GetProductLinkByID(ctx context.Context, id uuid.UUID) (*entities.EcommerceProductLink, error) {
+	query := `
+		SELECT ` + ecommerceProductLinkColumns + `
+		FROM ecommerce_product_links
+		WHERE id = $1`
+
+	link, err := r.scanProductLink(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("product link")
+		}
+		return nil, fmt.Errorf("failed to get e-commerce product link: %w", err)
+	}
+
+	return link, nil
+}
+
+// GetProductLinks retrieves the product links of an integration
+func (r *PostgresEcommerceRepository) GetProductLinks(ctx context.Context, integrationID uuid.UUID) ([]*entities.EcommerceProductLink, error) {
+	query := `
+		SELECT ` + ecommerceProductLinkColumns + `
+		FROM ecommerce_product_links
+		WHERE integration_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, integrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query e-commerce product links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*entities.EcommerceProductLink{}
+	for rows.Next() {
+		link, err := r.scanProductLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan e-commerce product link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate e-commerce product links: %w", err)
+	}
+
+	return links, nil
+}
+
+// UpdateProductLink saves a product link's resolved inventory item and last pushed level
+func (r *PostgresEcommerceRepository) UpdateProductLink(ctx context.Context, link *entities.EcommerceProductLink) error {
+	query := `
+		UPDATE ecommerce_product_links
+		SET inventory_item_id = NULLIF($2, ''), last_pushed_qty = $3, last_pushed_at = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		link.ID, link.InventoryItemID, link.LastPushedQty, link.LastPushedAt, link.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update e-commerce product link: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "product link")
+}
+
+// DeleteProductLink deletes a product link
+func (r *PostgresEcommerceRepository) DeleteProductLink(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM ecommerce_product_links WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete e-commerce product link: %w", err)
+	}
+
+	return checkEcommerceAffected(result, "product link")
+}
+
+func (r *PostgresEcommerceRepository) queryIntegrations(ctx context.Context, query string, args ...interface{}) ([]*entities.EcommerceIntegration, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query e-commerce integrations: %w", err)
+	}
+	defer rows.Close()
+
+	integrations := []*entities.EcommerceIntegration{}
+	for rows.Next() {
+		integration, err := r.scanIntegration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan e-commerce integration: %w", err)
+		}
+		integrations = append(integrations, integration)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate e-commerce integrations: %w", err)
+	}
+
+	return integrations, nil
+}
+
+// scanIntegration scans an e-commerce integration from a row
+func (r *PostgresEcommerceRepository) scanIntegration(row interface{ Scan(...interface{}) error }) (*entities.EcommerceIntegration, error) {
+	var integration entities.EcommerceIntegration
+
+	err := row.Scan(&integration.ID, &integration.TenantID, &integration.Platform, &integration.Name,
+		&integration.StoreURL, &integration.WebhookSecret, &integration.AccessToken, &integration.ConsumerKey,
+		&integration.ConsumerSecret, &integration.LocationID, &integration.PushStock, &integration.IsActive,
+		&integration.LastOrderAt, &integration.LastStockPushAt, &integration.LastError, &integration.CreatedBy,
+		&integration.CreatedAt, &integration.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &integration, nil
+}
+
+// scanOrder scans an e-commerce order from a row
+func (r *PostgresEcommerceRepository) scanOrder(row interface{ Scan(...interface{}) error }) (*entities.EcommerceOrder, error) {
+	var order entities.EcommerceOrder
+
+	err := row.Scan(&order.ID, &order.TenantID, &order.IntegrationID, &order.ExternalID, &order.OrderNumber,
+		&order.Status, &order.SaleID, &order.ReservationID, &order.Error, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// scanProductLink scans an e-commerce product link from a row
+func (r *PostgresEcommerceRepository) scanProductLink(row interface{ Scan(...interface{}) error }) (*entities.EcommerceProductLink, error) {
+	var link entities.EcommerceProductLink
+	var lastPushedQty sql.NullInt64
+
+	err := row.Scan(&link.ID, &link.TenantID, &link.IntegrationID, &link.ProductID, &link.ExternalProductID,
+		&link.ExternalVariantID, &link.InventoryItemID, &lastPushedQty, &link.LastPushedAt, &link.CreatedAt,
+		&link.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastPushedQty.Valid {
+		qty := int(lastPushedQty.Int64)
+		link.LastPushedQty = &qty
+	}
+	return &link, nil
+}
+
+func checkEcommerceAffected(result sql.Result, resource string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError(resource)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// emailOutboxColumns lists the email_outbox columns in the order scanOutboxEmail reads them
+const emailOutboxColumns = `id, tenant_id, invoice_id, recipient, template, paper_size, status, attempts,
+			next_attempt_at, last_attempt_at, last_error, delivery, sent_at, created_at, updated_at, created_by`
+
+// PostgresEmailOutboxRepository implements the EmailOutboxRepository interface
+type PostgresEmailOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailOutboxRepository creates a new PostgreSQL email outbox repository
+func NewPostgresEmailOutboxRepository(db *sql.DB) repositories.EmailOutboxRepository {
+	return &PostgresEmailOutboxRepository{db: db}
+}
+
+// Create queues an email
+func (r *PostgresEmailOutboxRepository) Create(ctx context.Context, email *entities.OutboxEmail) error {
+	var template []byte
+	if email.Template != nil {
+		var err error
+		template, err = json.Marshal(email.Template)
+		if err != nil {
+			return fmt.Errorf("failed to marshal invoice template: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO email_outbox (` + emailOutboxColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15, $16)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		email.ID, email.TenantID, email.InvoiceID, email.Recipient, template, email.PaperSize, email.Status,
+		email.Attempts, email.NextAttemptAt, email.LastAttemptAt, email.LastError, email.Delivery,
+		email.SentAt, email.CreatedAt, email.UpdatedAt, email.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+
+	return nil
+}
+
+// GetByInvoice retrieves the emails queued for an invoice, most recent first
+func (r *PostgresEmailOutboxRepository) GetByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.OutboxEmail, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceID})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + emailOutboxColumns + ` FROM email_outbox WHERE invoice_id = $1` + scope + ` ORDER BY created_at DESC`
+
+	return r.queryOutboxEmails(ctx, query, args...)
+}
+
+// ClaimDue atomically leases up to limit pending emails due at now
+func (r *PostgresEmailOutboxRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.OutboxEmail, error) {
+	query := `
+		UPDATE email_outbox
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM email_outbox
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + emailOutboxColumns
+
+	emails, err := r.queryOutboxEmails(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim queued emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+// Update records the outcome of a send attempt
+func (r *PostgresEmailOutboxRepository) Update(ctx context.Context, email *entities.OutboxEmail) error {
+	query := `
+		UPDATE email_outbox
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5, last_error = $6,
+			delivery = NULLIF($7, ''), sent_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		email.ID, email.Status, email.Attempts, email.NextAttemptAt, email.LastAttemptAt, email.LastError,
+		email.Delivery, email.SentAt, email.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update queued email: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("queued email")
+	}
+
+	return nil
+}
+
+// queryOutboxEmails runs a query returning emailOutboxColumns rows
+func (r *PostgresEmailOutboxRepository) queryOutboxEmails(ctx context.Context, query string, args ...interface{}) ([]*entities.OutboxEmail, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queued emails: %w", err)
+	}
+	defer rows.Close()
+
+	emails := []*entities.OutboxEmail{}
+	for rows.Next() {
+		email, err := r.scanOutboxEmail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queued email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate queued emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+// scanOutboxEmail scans a queued email from a row
+func (r *PostgresEmailOutboxRepository) scanOutboxEmail(row interface{ Scan(...interface{}) error }) (*entities.OutboxEmail, error) {
+	var email entities.OutboxEmail
+	var template []byte
+	var nextAttemptAt, lastAttemptAt, sentAt sql.NullTime
+	var lastError, delivery sql.NullString
+
+	err := row.Scan(&email.ID, &email.TenantID, &email.InvoiceID, &email.Recipient, &template, &email.PaperSize,
+		&email.Status, &email.Attempts, &nextAttemptAt, &lastAttemptAt, &lastError, &delivery, &sentAt,
+		&email.CreatedAt, &email.UpdatedAt, &email.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(template) > 0 {
+		email.Template = &entities.InvoiceTemplate{}
+		if err := json.Unmarshal(template, email.Template); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal invoice template: %w", err)
+		}
+	}
+	if nextAttemptAt.Valid {
+		email.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if lastAttemptAt.Valid {
+		email.LastAttemptAt = &lastAttemptAt.Time
+	}
+	if sentAt.Valid {
+		email.SentAt = &sentAt.Time
+	}
+	email.LastError = lastError.String
+	email.Delivery = entities.OutboxEmailDelivery(delivery.String)
+
+	return &email, nil
+}
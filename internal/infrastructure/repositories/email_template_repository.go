@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresEmailTemplateRepository implements the EmailTemplateRepository interface
+type PostgresEmailTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailTemplateRepository creates a new PostgreSQL email template repository
+func NewPostgresEmailTemplateRepository(db *sql.DB) repositories.EmailTemplateRepository {
+	return &PostgresEmailTemplateRepository{db: db}
+}
+
+// GetByTenant retrieves all email template overrides of a tenant
+func (r *PostgresEmailTemplateRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.EmailTemplate, error) {
+	query := `
+		SELECT id, tenant_id, kind, subject, html_body, text_body, created_at, updated_at, updated_by
+		FROM email_templates
+		WHERE tenant_id = $1
+		ORDER BY kind`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query email templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*entities.EmailTemplate{}
+	for rows.Next() {
+		template, err := r.scanEmailTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate email templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetByKind retrieves the tenant's override of the template of a kind
+func (r *PostgresEmailTemplateRepository) GetByKind(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind) (*entities.EmailTemplate, error) {
+	query := `
+		SELECT id, tenant_id, kind, subject, html_body, text_body, created_at, updated_at, updated_by
+		FROM email_templates
+		WHERE tenant_id = $1 AND kind = $2`
+
+	template, err := r.scanEmailTemplate(r.db.QueryRowContext(ctx, query, tenantID, kind))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("email template")
+		}
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+
+	return template, nil
+}
+
+// Save creates or updates the tenant's override of the template's kind
+func (r *PostgresEmailTemplateRepository) Save(ctx context.Context, template *entities.EmailTemplate) error {
+	query := `
+		INSERT INTO email_templates (id, tenant_id, kind, subject, html_body, text_body, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (tenant_id, kind) DO UPDATE SET
+			subject = EXCLUDED.subject,
+			html_body = EXCLUDED.html_body,
+			text_body = EXCLUDED.text_body,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		template.ID, template.TenantID, template.Kind, template.Subject, template.HTMLBody, template.TextBody,
+		template.CreatedAt, template.UpdatedAt, template.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save email template: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the tenant's override of the template of a kind
+func (r *PostgresEmailTemplateRepository) Delete(ctx context.Context, tenantID uuid.UUID, kind entities.EmailTemplateKind) error {
+	query := `DELETE FROM email_templates WHERE tenant_id = $1 AND kind = $2`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, kind)
+	if err != nil {
+		return fmt.Errorf("failed to delete email template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("email template")
+	}
+
+	return nil
+}
+
+// scanEmailTemplate scans an email template from a row
+func (r *PostgresEmailTemplateRepository) scanEmailTemplate(row interface{ Scan(...interface{}) error }) (*entities.EmailTemplate, error) {
+	var template entities.EmailTemplate
+
+	err := row.Scan(&template.ID, &template.TenantID, &template.Kind, &template.Subject, &template.HTMLBody,
+		&template.TextBody, &template.CreatedAt, &template.UpdatedAt, &template.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+)
+
+// PostgresFeatureUsageRepository implements the FeatureUsageRepository interface
+type PostgresFeatureUsageRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresFeatureUsageRepository creates a new PostgreSQL feature usage repository
+func NewPostgresFeatureUsageRepository(db *sql.DB) repositories.FeatureUsageRepository {
+	return &PostgresFeatureUsageRepository{db: db}
+}
+
+// Record adds the usage to the tenant's counter of the feature for the day
+func (r *PostgresFeatureUsageRepository) Record(ctx context.Context, usage *entities.FeatureUsage) error {
+	query := `
+		INSERT INTO feature_usage (tenant_id, feature, day, events, last_used_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, feature, day) DO UPDATE SET
+			events = feature_usage.events + EXCLUDED.events,
+			last_used_at = GREATEST(feature_usage.last_used_at, EXCLUDED.last_used_at)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		usage.TenantID, usage.Feature, usage.Day, usage.Events, usage.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record feature usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetUsageLines retrieves how many tenants used each feature, and how often, per period
+// between the dates
+func (r *PostgresFeatureUsageRepository) GetUsageLines(ctx context.Context, fromDate, toDate time.Time, period repositories.ReportPeriod) ([]*repositories.FeatureUsageLine, error) {
+	query := `
+		SELECT
+			date_trunc($3, day::timestamp) as period_start,
+			feature,
+			COUNT(DISTINCT tenant_id) as active_tenants,
+			COALESCE(SUM(events), 0) as events
+		FROM feature_usage
+		WHERE day >= $1 AND day < $2
+		GROUP BY 1, 2
+		ORDER BY 1, 2`
+
+	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate, string(period))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature usage lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*repositories.FeatureUsageLine
+	for rows.Next() {
+		var line repositories.FeatureUsageLine
+		if err := rows.Scan(&line.PeriodStart, &line.Feature, &line.ActiveTenants, &line.Events); err != nil {
+			return nil, fmt.Errorf("failed to scan feature usage line: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature usage lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetAdoptionLines retrieves, per feature, how many active or trial tenants had used it
+// before toDate and how many of them used it for the first time on or after fromDate
+func (r *PostgresFeatureUsageRepository) GetAdoptionLines(ctx context.Context, fromDate, toDate time.Time) ([]*repositories.FeatureAdoptionLine, error) {
+	query := `
+		WITH first_use AS (
+			SELECT fu.tenant_id, fu.feature, MIN(fu.day) as first_day
+			FROM feature_usage fu
+			JOIN tenants t ON fu.tenant_id = t.id
+			WHERE fu.day < $2 AND t.status IN ('active', 'trial')
+			GROUP BY fu.tenant_id, fu.feature
+		)
+		SELECT
+			feature,
+			COUNT(*) as adopting_tenants,
+			COUNT(*) FILTER (WHERE first_day >= $1) as new_adopters
+		FROM first_use
+		GROUP BY feature
+		ORDER BY feature`
+
+	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature adoption lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*repositories.FeatureAdoptionLine
+	for rows.Next() {
+		var line repositories.FeatureAdoptionLine
+		if err := rows.Scan(&line.Feature, &line.AdoptingTenants, &line.NewAdopters); err != nil {
+			return nil, fmt.Errorf("failed to scan feature adoption line: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature adoption lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// CountActiveTenants counts the tenants that are active or on trial
+func (r *PostgresFeatureUsageRepository) CountActiveTenants(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM tenants WHERE status IN ('active', 'trial')`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count active tenants: %w", err)
+	}
+
+	return count, nil
+}
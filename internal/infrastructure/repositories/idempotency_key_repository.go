@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresIdempotencyKeyRepository implements the IdempotencyKeyRepository interface
+type PostgresIdempotencyKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIdempotencyKeyRepository creates a new PostgreSQL idempotency key repository
+func NewPostgresIdempotencyKeyRepository(db *sql.DB) repositories.IdempotencyKeyRepository {
+	return &PostgresIdempotencyKeyRepository{db: db}
+}
+
+// Claim stores a new in-progress key, taking over an expired or abandoned one. The unique
+// index on (tenant_id, idempotency_key) makes concurrent claims of the same key race safely:
+// exactly one of them inserts or updates the row.
+func (r *PostgresIdempotencyKeyRepository) Claim(ctx context.Context, key *entities.IdempotencyKey, lockedBefore time.Time) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (id, tenant_id, idempotency_key, user_id, method, path, request_hash,
+			status, response_status, response_body, created_at, completed_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0, NULL, $9, NULL, $10)
+		ON CONFLICT (tenant_id, idempotency_key) DO UPDATE SET
+			id = EXCLUDED.id,
+			user_id = EXCLUDED.user_id,
+			method = EXCLUDED.method,
+			path = EXCLUDED.path,
+			request_hash = EXCLUDED.request_hash,
+			status = EXCLUDED.status,
+			response_status = 0,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.status = $11 AND idempotency_keys.created_at < $12)`
+
+	result, err := r.db.ExecContext(ctx, query,
+		key.ID, key.TenantID, key.Key, key.UserID, key.Method, key.Path, key.RequestHash,
+		key.Status, key.CreatedAt, key.ExpiresAt,
+		entities.IdempotencyKeyStatusInProgress, lockedBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+// GetByKey retrieves a tenant's idempotency key
+func (r *PostgresIdempotencyKeyRepository) GetByKey(ctx context.Context, tenantID uuid.UUID, key string) (*entities.IdempotencyKey, error) {
+	query := `
+		SELECT id, tenant_id, idempotency_key, user_id, method, path, request_hash, status,
+			response_status, response_body, created_at, completed_at, expires_at
+		FROM idempotency_keys
+		WHERE tenant_id = $1 AND idempotency_key = $2`
+
+	idempotencyKey := &entities.IdempotencyKey{}
+	var completedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tenantID, key).Scan(
+		&idempotencyKey.ID,
+		&idempotencyKey.TenantID,
+		&idempotencyKey.Key,
+		&idempotencyKey.UserID,
+		&idempotencyKey.Method,
+		&idempotencyKey.Path,
+		&idempotencyKey.RequestHash,
+		&idempotencyKey.Status,
+		&idempotencyKey.ResponseStatus,
+		&idempotencyKey.ResponseBody,
+		&idempotencyKey.CreatedAt,
+		&completedAt,
+		&idempotencyKey.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("idempotency key")
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if completedAt.Valid {
+		idempotencyKey.CompletedAt = &completedAt.Time
+	}
+
+	return idempotencyKey, nil
+}
+
+// Complete stores the response of a claimed key. The ID check keeps a request whose lock was
+// taken over from overwriting the response of the request that took it.
+func (r *PostgresIdempotencyKeyRepository) Complete(ctx context.Context, key *entities.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = $2, response_status = $3, response_body = $4, completed_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, key.ID, key.Status, key.ResponseStatus, key.ResponseBody, key.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError("idempotency key")
+	}
+
+	return nil
+}
+
+// Delete releases a claimed key so the request can be retried
+func (r *PostgresIdempotencyKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired permanently deletes keys that expired before the given time
+func (r *PostgresIdempotencyKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// inventoryCostAdjustmentColumns lists the inventory_cost_adjustments columns in scan order
+const inventoryCostAdjustmentColumns = `id, tenant_id, product_id, reason, quantity, on_hand_qty, previous_unit_cost,
+	adjusted_unit_cost, resulting_unit_cost, amount, COALESCE(notes, ''), created_at, created_by`
+
+// PostgresInventoryCostAdjustmentRepository implements the InventoryCostAdjustmentRepository interface
+type PostgresInventoryCostAdjustmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInventoryCostAdjustmentRepository creates a new PostgreSQL inventory cost adjustment repository
+func NewPostgresInventoryCostAdjustmentRepository(db *sql.DB) repositories.InventoryCostAdjustmentRepository {
+	return &PostgresInventoryCostAdjustmentRepository{db: db}
+}
+
+// Create records a cost adjustment
+func (r *PostgresInventoryCostAdjustmentRepository) Create(ctx context.Context, adjustment *entities.InventoryCostAdjustment) error {
+	query := `
+		INSERT INTO inventory_cost_adjustments (id, tenant_id, product_id, reason, quantity, on_hand_qty,
+			previous_unit_cost, adjusted_unit_cost, resulting_unit_cost, amount, notes, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		adjustment.ID, adjustment.TenantID, adjustment.ProductID, adjustment.Reason, adjustment.Quantity,
+		adjustment.OnHandQty, adjustment.PreviousUnitCost, adjustment.AdjustedUnitCost, adjustment.ResultingUnitCost,
+		adjustment.Amount, adjustment.Notes, adjustment.CreatedAt, adjustment.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create inventory cost adjustment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a cost adjustment by ID
+func (r *PostgresInventoryCostAdjustmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.InventoryCostAdjustment, error) {
+	query := `SELECT ` + inventoryCostAdjustmentColumns + ` FROM inventory_cost_adjustments WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	adjustment, err := r.scanInventoryCostAdjustment(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("inventory cost adjustment")
+		}
+		return nil, fmt.Errorf("failed to get inventory cost adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// List retrieves cost adjustments with pagination and filtering, newest first
+func (r *PostgresInventoryCostAdjustmentRepository) List(ctx context.Context, filter repositories.InventoryCostAdjustmentFilter, pagination utils.PaginationInfo) ([]*entities.InventoryCostAdjustment, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argCount := 0
+
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	}
+
+	if filter.ProductID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", argCount))
+		args = append(args, *filter.ProductID)
+	}
+
+	if filter.Reason != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("reason = $%d", argCount))
+		args = append(args, *filter.Reason)
+	}
+
+	if filter.FromDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argCount))
+		args = append(args, *filter.FromDate)
+	}
+
+	if filter.ToDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argCount))
+		args = append(args, *filter.ToDate)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM inventory_cost_adjustments %s", whereClause)
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, pagination, fmt.Errorf("failed to count inventory cost adjustments: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM inventory_cost_adjustments
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		inventoryCostAdjustmentColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query inventory cost adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	var adjustments []*entities.InventoryCostAdjustment
+	for rows.Next() {
+		adjustment, err := r.scanInventoryCostAdjustment(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan inventory cost adjustment: %w", err)
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate inventory cost adjustments: %w", err)
+	}
+
+	return adjustments, paginationResult, nil
+}
+
+// GetTotals sums the cost adjustments of a tenant recorded in [from, to) per product and reason
+func (r *PostgresInventoryCostAdjustmentRepository) GetTotals(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*repositories.CostAdjustmentTotal, error) {
+	query := `
+		SELECT a.product_id, p.sku, p.name, a.reason, COUNT(*), SUM(a.quantity), SUM(a.amount)
+		FROM inventory_cost_adjustments a
+		JOIN products p ON p.id = a.product_id
+		WHERE a.tenant_id = $1 AND a.created_at >= $2 AND a.created_at < $3
+		GROUP BY a.product_id, p.sku, p.name, a.reason
+		ORDER BY SUM(a.amount), p.name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inventory cost adjustment totals: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []*repositories.CostAdjustmentTotal{}
+	for rows.Next() {
+		var total repositories.CostAdjustmentTotal
+		err := rows.Scan(&total.ProductID, &total.ProductSKU, &total.ProductName, &total.Reason,
+			&total.Count, &total.Quantity, &total.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory cost adjustment total: %w", err)
+		}
+		totals = append(totals, &total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate inventory cost adjustment totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// scanInventoryCostAdjustment scans a cost adjustment from a row
+func (r *PostgresInventoryCostAdjustmentRepository) scanInventoryCostAdjustment(row interface{ Scan(...interface{}) error }) (*entities.InventoryCostAdjustment, error) {
+	var adjustment entities.InventoryCostAdjustment
+
+	err := row.Scan(
+		&adjustment.ID, &adjustment.TenantID, &adjustment.ProductID, &adjustment.Reason, &adjustment.Quantity,
+		&adjustment.OnHandQty, &adjustment.PreviousUnitCost, &adjustment.AdjustedUnitCost, &adjustment.ResultingUnitCost,
+		&adjustment.Amount, &adjustment.Notes, &adjustment.CreatedAt, &adjustment.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adjustment, nil
+}
@@ -0,0 +1,327 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresInvoiceEmailBatchRepository implements the InvoiceEmailBatchRepository interface
+type PostgresInvoiceEmailBatchRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInvoiceEmailBatchRepository creates a new PostgreSQL invoice email batch repository
+func NewPostgresInvoiceEmailBatchRepository(db *sql.DB) repositories.InvoiceEmailBatchRepository {
+	return &PostgresInvoiceEmailBatchRepository{db: db}
+}
+
+// Create creates a new batch with its items
+func (r *PostgresInvoiceEmailBatchRepository) Create(ctx context.Context, batch *entities.InvoiceEmailBatch) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO invoice_email_batches (id, tenant_id, status, total_count, sent_count, failed_count,
+			skipped_count, requested_by, started_at, completed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err = tx.ExecContext(ctx, query,
+		batch.ID, batch.TenantID, batch.Status, batch.TotalCount, batch.SentCount, batch.FailedCount,
+		batch.SkippedCount, batch.RequestedBy, batch.StartedAt, batch.CompletedAt, batch.CreatedAt, batch.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert invoice email batch: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO invoice_email_batch_items (id, batch_id, position, invoice_id, invoice_number,
+			recipient, outcome, reason, processed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	for i, item := range batch.Items {
+		_, err := tx.ExecContext(ctx, itemQuery,
+			item.ID, batch.ID, i, item.InvoiceID, item.InvoiceNumber,
+			item.Recipient, item.Outcome, item.Reason, item.ProcessedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert invoice email batch item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a batch with its items by ID
+func (r *PostgresInvoiceEmailBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceEmailBatch, error) {
+	query := `
+		SELECT id, tenant_id, status, total_count, sent_count, failed_count, skipped_count,
+			requested_by, started_at, completed_at, created_at, updated_at
+		FROM invoice_email_batches
+		WHERE id = $1`
+
+	batch, err := r.scanInvoiceEmailBatch(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invoice email batch")
+		}
+		return nil, fmt.Errorf("failed to get invoice email batch: %w", err)
+	}
+
+	if err := r.loadItems(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// Update updates the status and counters of a batch
+func (r *PostgresInvoiceEmailBatchRepository) Update(ctx context.Context, batch *entities.InvoiceEmailBatch) error {
+	query := `
+		UPDATE invoice_email_batches SET
+			status = $2, total_count = $3, sent_count = $4, failed_count = $5, skipped_count = $6,
+			started_at = $7, completed_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		batch.ID, batch.Status, batch.TotalCount, batch.SentCount, batch.FailedCount, batch.SkippedCount,
+		batch.StartedAt, batch.CompletedAt, batch.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update invoice email batch: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("invoice email batch")
+	}
+
+	return nil
+}
+
+// UpdateItem updates the outcome of a single batch item
+func (r *PostgresInvoiceEmailBatchRepository) UpdateItem(ctx context.Context, item *entities.InvoiceEmailBatchItem) error {
+	query := `
+		UPDATE invoice_email_batch_items SET
+			outcome = $2, reason = $3, processed_at = $4
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, item.ID, item.Outcome, item.Reason, item.ProcessedAt); err != nil {
+		return fmt.Errorf("failed to update invoice email batch item: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves the most recent batches of a tenant without their items
+func (r *PostgresInvoiceEmailBatchRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]*entities.InvoiceEmailBatch, error) {
+	query := `
+		SELECT id, tenant_id, status, total_count, sent_count, failed_count, skipped_count,
+			requested_by, started_at, completed_at, created_at, updated_at
+		FROM invoice_email_batches
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoice email batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*entities.InvoiceEmailBatch
+	for rows.Next() {
+		batch, err := r.scanInvoiceEmailBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice email batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invoice email batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Helper functions
+
+// scanInvoiceEmailBatch scans an invoice email batch from a row
+func (r *PostgresInvoiceEmailBatchRepository) scanInvoiceEmailBatch(row interface{ Scan(...interface{}) error }) (*entities.InvoiceEmailBatch, error) {
+	var batch entities.InvoiceEmailBatch
+	var startedAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&batch.ID, &batch.TenantID, &batch.Status, &batch.TotalCount, &batch.SentCount, &batch.FailedCount,
+		&batch.SkippedCount, &batch.RequestedBy, &startedAt, &completedAt, &batch.CreatedAt, &batch.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if startedAt.Valid {
+		batch.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+
+	return &batch, nil
+}
+
+// loadItems loads the items of a batch in their original order
+func (r *PostgresInvoiceEmailBatchRepository) loadItems(ctx context.Context, batch *entities.InvoiceEmailBatch) error {
+	query := `
+		SELECT id, batch_id, invoice_id, invoice_number, recipient, outcome, reason, processed_at
+		FROM invoice_email_batch_items
+		WHERE batch_id = $1
+		ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, batch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query invoice email batch items: %w", err)
+	}
+	defer rows.Close()
+
+	batch.Items = []entities.InvoiceEmailBatchItem{}
+	for rows.Next() {
+		var item entities.InvoiceEmailBatchItem
+		var processedAt sql.NullTime
+		err := rows.Scan(&item.ID, &item.BatchID, &item.InvoiceID, &item.InvoiceNumber,
+			&item.Recipient, &item.Outcome, &item.Reason, &processedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan invoice email batch item: %w", err)
+		}
+		if processedAt.Valid {
+			item.ProcessedAt = &processedAt.Time
+		}
+		batch.Items = append(batch.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate invoice email batch items: %w", err)
+	}
+
+	return nil
+}
+
+// PostgresEmailSuppressionRepository implements the EmailSuppressionRepository interface
+type PostgresEmailSuppressionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailSuppressionRepository creates a new PostgreSQL email suppression repository
+func NewPostgresEmailSuppressionRepository(db *sql.DB) repositories.EmailSuppressionRepository {
+	return &PostgresEmailSuppressionRepository{db: db}
+}
+
+// Create creates a new email suppression, doing nothing if the address is already suppressed
+func (r *PostgresEmailSuppressionRepository) Create(ctx context.Context, suppression *entities.EmailSuppression) error {
+	query := `
+		INSERT INTO email_suppressions (id, tenant_id, email, reason, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, email) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		suppression.ID, suppression.TenantID, suppression.Email, suppression.Reason,
+		suppression.CreatedAt, suppression.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert email suppression: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the suppression of an address
+func (r *PostgresEmailSuppressionRepository) Delete(ctx context.Context, tenantID uuid.UUID, email string) error {
+	query := `DELETE FROM email_suppressions WHERE tenant_id = $1 AND email = $2`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, email)
+	if err != nil {
+		return fmt.Errorf("failed to delete email suppression: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("email suppression")
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves all suppressed addresses of a tenant
+func (r *PostgresEmailSuppressionRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.EmailSuppression, error) {
+	query := `
+		SELECT id, tenant_id, email, reason, created_at, created_by
+		FROM email_suppressions
+		WHERE tenant_id = $1
+		ORDER BY email`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	var suppressions []*entities.EmailSuppression
+	for rows.Next() {
+		var suppression entities.EmailSuppression
+		err := rows.Scan(&suppression.ID, &suppression.TenantID, &suppression.Email, &suppression.Reason,
+			&suppression.CreatedAt, &suppression.CreatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		suppressions = append(suppressions, &suppression)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate email suppressions: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// GetSuppressed returns which of the given normalized addresses are suppressed for a tenant
+func (r *PostgresEmailSuppressionRepository) GetSuppressed(ctx context.Context, tenantID uuid.UUID, emails []string) (map[string]bool, error) {
+	suppressed := make(map[string]bool)
+	if len(emails) == 0 {
+		return suppressed, nil
+	}
+
+	query := `SELECT email FROM email_suppressions WHERE tenant_id = $1 AND email = ANY($2)`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, pq.Array(emails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		suppressed[email] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate email suppressions: %w", err)
+	}
+
+	return suppressed, nil
+}
@@ -26,12 +26,12 @@ func NewPostgresInvoiceItemRepository(db *sql.DB) repositories.InvoiceItemReposi
 func (r *PostgresInvoiceItemRepository) Create(ctx context.Context, item *entities.InvoiceItem) error {
 	query := `
 		INSERT INTO invoice_items (id, invoice_id, product_id, product_sku, product_name, 
-			description, quantity, unit_price, total_price)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			description, quantity, unit_price, total_price, tax_rate_id, tax_rate, tax_amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		item.ID, item.InvoiceID, item.ProductID, item.ProductSKU, item.ProductName,
-		item.Description, item.Quantity, item.UnitPrice, item.TotalPrice)
+		item.Description, item.Quantity, item.UnitPrice, item.TotalPrice, item.TaxRateID, item.TaxRate, item.TaxAmount)
 	if err != nil {
 		return fmt.Errorf("failed to create invoice item: %w", err)
 	}
@@ -43,15 +43,21 @@ func (r *PostgresInvoiceItemRepository) Create(ctx context.Context, item *entiti
 func (r *PostgresInvoiceItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceItem, error) {
 	query := `
 		SELECT id, invoice_id, product_id, product_sku, product_name, 
-			description, quantity, unit_price, total_price
+			description, quantity, unit_price, total_price, tax_rate_id, tax_rate, tax_amount
 		FROM invoice_items 
 		WHERE id = $1`
 
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
 	var item entities.InvoiceItem
 	var description sql.NullString
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var taxRateID uuid.NullUUID
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(
 		&item.ID, &item.InvoiceID, &item.ProductID, &item.ProductSKU, &item.ProductName,
-		&description, &item.Quantity, &item.UnitPrice, &item.TotalPrice)
+		&description, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &taxRateID, &item.TaxRate,
+		&item.TaxAmount)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("invoice item")
@@ -60,6 +66,9 @@ func (r *PostgresInvoiceItemRepository) GetByID(ctx context.Context, id uuid.UUI
 	}
 
 	item.Description = description.String
+	if taxRateID.Valid {
+		item.TaxRateID = &taxRateID.UUID
+	}
 	return &item, nil
 }
 
@@ -67,12 +76,16 @@ func (r *PostgresInvoiceItemRepository) GetByID(ctx context.Context, id uuid.UUI
 func (r *PostgresInvoiceItemRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoiceItem, error) {
 	query := `
 		SELECT id, invoice_id, product_id, product_sku, product_name, 
-			description, quantity, unit_price, total_price
+			description, quantity, unit_price, total_price, tax_rate_id, tax_rate, tax_amount
 		FROM invoice_items 
-		WHERE invoice_id = $1 
+		WHERE invoice_id = $1%s
 		ORDER BY product_name`
 
-	rows, err := r.db.QueryContext(ctx, query, invoiceID)
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceID})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query invoice items: %w", err)
 	}
@@ -82,12 +95,17 @@ func (r *PostgresInvoiceItemRepository) GetByInvoiceID(ctx context.Context, invo
 	for rows.Next() {
 		var item entities.InvoiceItem
 		var description sql.NullString
+		var taxRateID uuid.NullUUID
 		err := rows.Scan(&item.ID, &item.InvoiceID, &item.ProductID, &item.ProductSKU,
-			&item.ProductName, &description, &item.Quantity, &item.UnitPrice, &item.TotalPrice)
+			&item.ProductName, &description, &item.Quantity, &item.UnitPrice, &item.TotalPrice,
+			&taxRateID, &item.TaxRate, &item.TaxAmount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan invoice item: %w", err)
 		}
 		item.Description = description.String
+		if taxRateID.Valid {
+			item.TaxRateID = &taxRateID.UUID
+		}
 		items = append(items, &item)
 	}
 
@@ -103,12 +121,16 @@ func (r *PostgresInvoiceItemRepository) Update(ctx context.Context, item *entiti
 	query := `
 		UPDATE invoice_items SET 
 			product_id = $2, product_sku = $3, product_name = $4, description = $5,
-			quantity = $6, unit_price = $7, total_price = $8
+			quantity = $6, unit_price = $7, total_price = $8, tax_rate_id = $9, tax_rate = $10, tax_amount = $11
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query,
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 		item.ID, item.ProductID, item.ProductSKU, item.ProductName, item.Description,
-		item.Quantity, item.UnitPrice, item.TotalPrice)
+		item.Quantity, item.UnitPrice, item.TotalPrice, item.TaxRateID, item.TaxRate, item.TaxAmount})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update invoice item: %w", err)
 	}
@@ -126,9 +148,13 @@ func (r *PostgresInvoiceItemRepository) Update(ctx context.Context, item *entiti
 
 // Delete deletes an invoice item
 func (r *PostgresInvoiceItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM invoice_items WHERE id = $1`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM invoice_items WHERE id = $1` + scope
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete invoice item: %w", err)
 	}
@@ -158,13 +184,13 @@ func (r *PostgresInvoiceItemRepository) BulkCreate(ctx context.Context, items []
 
 	query := `
 		INSERT INTO invoice_items (id, invoice_id, product_id, product_sku, product_name, 
-			description, quantity, unit_price, total_price)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			description, quantity, unit_price, total_price, tax_rate_id, tax_rate, tax_amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	for _, item := range items {
 		_, err := tx.ExecContext(ctx, query,
 			item.ID, item.InvoiceID, item.ProductID, item.ProductSKU, item.ProductName,
-			item.Description, item.Quantity, item.UnitPrice, item.TotalPrice)
+			item.Description, item.Quantity, item.UnitPrice, item.TotalPrice, item.TaxRateID, item.TaxRate, item.TaxAmount)
 		if err != nil {
 			return fmt.Errorf("failed to create invoice item: %w", err)
 		}
@@ -188,13 +214,17 @@ func (r *PostgresInvoiceItemRepository) BulkUpdate(ctx context.Context, items []
 	query := `
 		UPDATE invoice_items SET 
 			product_id = $2, product_sku = $3, product_name = $4, description = $5,
-			quantity = $6, unit_price = $7, total_price = $8
+			quantity = $6, unit_price = $7, total_price = $8, tax_rate_id = $9, tax_rate = $10, tax_amount = $11
 		WHERE id = $1`
 
 	for _, item := range items {
-		result, err := tx.ExecContext(ctx, query,
+		scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 			item.ID, item.ProductID, item.ProductSKU, item.ProductName, item.Description,
-			item.Quantity, item.UnitPrice, item.TotalPrice)
+			item.Quantity, item.UnitPrice, item.TotalPrice, item.TaxRateID, item.TaxRate, item.TaxAmount})
+		if err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, query+scope, args...)
 		if err != nil {
 			return fmt.Errorf("failed to update invoice item: %w", err)
 		}
@@ -213,9 +243,13 @@ func (r *PostgresInvoiceItemRepository) BulkUpdate(ctx context.Context, items []
 
 // DeleteByInvoiceID deletes all items for an invoice
 func (r *PostgresInvoiceItemRepository) DeleteByInvoiceID(ctx context.Context, invoiceID uuid.UUID) error {
-	query := `DELETE FROM invoice_items WHERE invoice_id = $1`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceID})
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM invoice_items WHERE invoice_id = $1` + scope
 
-	_, err := r.db.ExecContext(ctx, query, invoiceID)
+	_, err = r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete invoice items: %w", err)
 	}
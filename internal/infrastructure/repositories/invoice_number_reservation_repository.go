@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PostgresInvoiceNumberReservationRepository implements the InvoiceNumberReservationRepository interface
+type PostgresInvoiceNumberReservationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInvoiceNumberReservationRepository creates a new PostgreSQL invoice number reservation repository
+func NewPostgresInvoiceNumberReservationRepository(db *sql.DB) repositories.InvoiceNumberReservationRepository {
+	return &PostgresInvoiceNumberReservationRepository{db: db}
+}
+
+const invoiceNumberReservationColumns = `id, tenant_id, sequence_number, invoice_number, status, reason,
+			COALESCE(external_reference, ''), invoice_id, COALESCE(void_reason, ''), reserved_by, bound_by,
+			voided_by, bound_at, voided_at, created_at, updated_at`
+
+// Reserve takes the next number of the tenant's invoice number sequence for the reservation
+// and creates it. The sequence row stays locked until the reservation is committed, so
+// concurrent reservations are serialized and a failed insert gives the number back.
+func (r *PostgresInvoiceNumberReservationRepository) Reserve(ctx context.Context, reservation *entities.InvoiceNumberReservation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sequenceQuery := `
+		INSERT INTO invoice_number_sequences (tenant_id, last_number)
+		VALUES ($1, 1)
+		ON CONFLICT (tenant_id) DO UPDATE SET last_number = invoice_number_sequences.last_number + 1
+		RETURNING last_number`
+
+	var sequenceNumber int64
+	if err := tx.QueryRowContext(ctx, sequenceQuery, reservation.TenantID).Scan(&sequenceNumber); err != nil {
+		return fmt.Errorf("failed to advance invoice number sequence: %w", err)
+	}
+	reservation.AssignSequenceNumber(sequenceNumber)
+
+	query := `
+		INSERT INTO invoice_number_reservations (id, tenant_id, sequence_number, invoice_number, status,
+			reason, external_reference, reserved_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10)`
+
+	_, err = tx.ExecContext(ctx, query,
+		reservation.ID, reservation.TenantID, reservation.SequenceNumber, reservation.InvoiceNumber,
+		reservation.Status, reservation.Reason, reservation.ExternalReference, reservation.ReservedBy,
+		reservation.CreatedAt, reservation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert invoice number reservation: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves an invoice number reservation by ID
+func (r *PostgresInvoiceNumberReservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceNumberReservation, error) {
+	query := `
+		SELECT ` + invoiceNumberReservationColumns + `
+		FROM invoice_number_reservations
+		WHERE id = $1`
+
+	reservation, err := r.scanInvoiceNumberReservation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invoice number reservation")
+		}
+		return nil, fmt.Errorf("failed to get invoice number reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// List retrieves invoice number reservations with pagination and filtering, latest number first
+func (r *PostgresInvoiceNumberReservationRepository) List(ctx context.Context, filter repositories.InvoiceNumberReservationFilter, pagination utils.PaginationInfo) ([]*entities.InvoiceNumberReservation, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+	argCount := 1
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.ExternalReference != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("external_reference = $%d", argCount))
+		args = append(args, filter.ExternalReference)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM invoice_number_reservations %s", whereClause)
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count invoice number reservations: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM invoice_number_reservations
+		%s
+		ORDER BY sequence_number DESC
+		LIMIT $%d OFFSET $%d`,
+		invoiceNumberReservationColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query invoice number reservations: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []*entities.InvoiceNumberReservation{}
+	for rows.Next() {
+		reservation, err := r.scanInvoiceNumberReservation(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan invoice number reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate invoice number reservations: %w", err)
+	}
+
+	return reservations, paginationResult, nil
+}
+
+// Bind marks the reservation bound and gives its number to the draft invoice it is bound to
+func (r *PostgresInvoiceNumberReservationRepository) Bind(ctx context.Context, reservation *entities.InvoiceNumberReservation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.transition(ctx, tx, reservation); err != nil {
+		return err
+	}
+
+	invoiceQuery := `
+		UPDATE invoices SET invoice_number = $3, updated_at = $4
+		WHERE id = $1 AND tenant_id = $2 AND status = $5 AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, invoiceQuery,
+		reservation.InvoiceID, reservation.TenantID, reservation.InvoiceNumber, reservation.UpdatedAt,
+		entities.InvoiceStatusDraft)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("invoice with number '%s' already exists", reservation.InvoiceNumber))
+		}
+		return fmt.Errorf("failed to renumber invoice: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewConflictError("invoice is no longer a draft")
+	}
+
+	return tx.Commit()
+}
+
+// Void marks the reservation voided
+func (r *PostgresInvoiceNumberReservationRepository) Void(ctx context.Context, reservation *entities.InvoiceNumberReservation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.transition(ctx, tx, reservation); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// transition stores a reservation leaving the reserved status, failing if another request
+// already bound or voided it
+func (r *PostgresInvoiceNumberReservationRepository) transition(ctx context.Context, tx *sql.Tx, reservation *entities.InvoiceNumberReservation) error {
+	query := `
+		UPDATE invoice_number_reservations SET
+			status = $2, invoice_id = $3, void_reason = NULLIF($4, ''), bound_by = $5, voided_by = $6,
+			bound_at = $7, voided_at = $8, updated_at = $9
+		WHERE id = $1 AND status = $10`
+
+	result, err := tx.ExecContext(ctx, query,
+		reservation.ID, reservation.Status, reservation.InvoiceID, reservation.VoidReason, reservation.BoundBy,
+		reservation.VoidedBy, reservation.BoundAt, reservation.VoidedAt, reservation.UpdatedAt,
+		entities.InvoiceNumberReservationStatusReserved)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("invoice already has a reserved number")
+		}
+		return fmt.Errorf("failed to update invoice number reservation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewConflictError("invoice number is no longer reserved")
+	}
+
+	return nil
+}
+
+// scanInvoiceNumberReservation scans an invoice number reservation from a row
+func (r *PostgresInvoiceNumberReservationRepository) scanInvoiceNumberReservation(row interface{ Scan(...interface{}) error }) (*entities.InvoiceNumberReservation, error) {
+	var reservation entities.InvoiceNumberReservation
+
+	err := row.Scan(&reservation.ID, &reservation.TenantID, &reservation.SequenceNumber, &reservation.InvoiceNumber,
+		&reservation.Status, &reservation.Reason, &reservation.ExternalReference, &reservation.InvoiceID,
+		&reservation.VoidReason, &reservation.ReservedBy, &reservation.BoundBy, &reservation.VoidedBy,
+		&reservation.BoundAt, &reservation.VoidedAt, &reservation.CreatedAt, &reservation.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
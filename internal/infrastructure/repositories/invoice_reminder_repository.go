@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+)
+
+// PostgresInvoiceReminderRepository implements the InvoiceReminderRepository interface
+type PostgresInvoiceReminderRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInvoiceReminderRepository creates a new PostgreSQL invoice reminder repository
+func NewPostgresInvoiceReminderRepository(db *sql.DB) repositories.InvoiceReminderRepository {
+	return &PostgresInvoiceReminderRepository{db: db}
+}
+
+// Claim records a reminder unless the invoice already got it for its due date
+func (r *PostgresInvoiceReminderRepository) Claim(ctx context.Context, reminder *entities.InvoiceReminder) (bool, error) {
+	query := `
+		INSERT INTO invoice_reminders (id, tenant_id, invoice_id, kind, sequence, due_date, recipient, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (invoice_id, kind, sequence, due_date) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query,
+		reminder.ID, reminder.TenantID, reminder.InvoiceID, reminder.Kind, reminder.Sequence,
+		reminder.DueDate, reminder.Recipient, reminder.SentAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim invoice reminder: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim invoice reminder: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+// Release removes the record of a reminder that could not be sent
+func (r *PostgresInvoiceReminderRepository) Release(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM invoice_reminders WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to release invoice reminder: %w", err)
+	}
+
+	return nil
+}
+
+// GetByInvoice retrieves the reminders sent for an invoice, most recent first
+func (r *PostgresInvoiceReminderRepository) GetByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoiceReminder, error) {
+	query := `
+		SELECT id, tenant_id, invoice_id, kind, sequence, due_date, recipient, sent_at
+		FROM invoice_reminders
+		WHERE invoice_id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceID})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, query+scope+" ORDER BY sent_at DESC", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoice reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := []*entities.InvoiceReminder{}
+	for rows.Next() {
+		var reminder entities.InvoiceReminder
+		err := rows.Scan(&reminder.ID, &reminder.TenantID, &reminder.InvoiceID, &reminder.Kind,
+			&reminder.Sequence, &reminder.DueDate, &reminder.Recipient, &reminder.SentAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice reminder: %w", err)
+		}
+		reminders = append(reminders, &reminder)
+	}
+
+	return reminders, rows.Err()
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/infrastructure/database"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/utils"
 )
@@ -38,17 +40,32 @@ func (r *PostgresInvoiceRepository) Create(ctx context.Context, invoice *entitie
 	// Insert invoice
 	query := `
 		INSERT INTO invoices (id, invoice_number, sale_id, customer_name, customer_email, 
-			customer_phone, customer_address, subtotal, tax_amount, discount_amount, 
+			customer_phone, customer_address, customer_tax_id, subtotal, tax_amount, discount_amount, 
 			total_amount, paid_amount, payment_method, status, notes, due_date, paid_at,
-			created_at, updated_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
+			created_at, updated_at, created_by, surcharge_amount, surcharge_tax_amount, surcharge_label, tenant_id,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive, tax_summary,
+			payment_schedule)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+			$21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32)`
 
+	taxSummary, err := json.Marshal(invoiceTaxSummary(invoice))
+	if err != nil {
+		return fmt.Errorf("failed to marshal tax summary: %w", err)
+	}
+	paymentSchedule, err := json.Marshal(invoicePaymentSchedule(invoice))
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment schedule: %w", err)
+	}
+
+	currency, baseCurrency, exchangeRate, rateLockedAt := exchangeRateColumns(invoice.ExchangeRate)
 	_, err = tx.ExecContext(ctx, query,
 		invoice.ID, invoice.InvoiceNumber, invoice.SaleID, invoice.CustomerName,
-		invoice.CustomerEmail, invoice.CustomerPhone, invoice.CustomerAddress,
+		invoice.CustomerEmail, invoice.CustomerPhone, invoice.CustomerAddress, invoice.CustomerTaxID,
 		invoice.Subtotal, invoice.TaxAmount, invoice.DiscountAmount, invoice.TotalAmount,
 		invoice.PaidAmount, invoice.PaymentMethod, invoice.Status, invoice.Notes,
-		invoice.DueDate, invoice.PaidAt, invoice.CreatedAt, invoice.UpdatedAt, invoice.CreatedBy)
+		invoice.DueDate, invoice.PaidAt, invoice.CreatedAt, invoice.UpdatedAt, invoice.CreatedBy,
+		invoice.SurchargeAmount, invoice.SurchargeTaxAmount, invoice.SurchargeLabel, invoice.TenantID,
+		currency, baseCurrency, exchangeRate, rateLockedAt, invoice.TaxInclusive, taxSummary, paymentSchedule)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
 			return errors.NewConflictError(fmt.Sprintf("invoice with invoice_number '%s' already exists", invoice.InvoiceNumber))
@@ -68,25 +85,52 @@ func (r *PostgresInvoiceRepository) Create(ctx context.Context, invoice *entitie
 
 // GetByID retrieves an invoice by ID
 func (r *PostgresInvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Invoice, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves an invoice by ID and locks it until the transaction ends
+func (r *PostgresInvoiceRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Invoice, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves an invoice by ID, appending the locking clause to the query
+func (r *PostgresInvoiceRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.Invoice, error) {
+	db := r.db
+	if lock == "" {
+		db = database.Reader(ctx, r.db)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, invoice_number, sale_id, customer_name, customer_email, 
-			customer_phone, customer_address, subtotal, tax_amount, discount_amount, 
+		SELECT id, tenant_id, invoice_number, sale_id, customer_name, customer_email, 
+			customer_phone, customer_address, customer_tax_id, subtotal, tax_amount, discount_amount, 
 			total_amount, paid_amount, payment_method, status, notes, due_date, paid_at,
-			created_at, updated_at, created_by
+			created_at, updated_at, created_by, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive, tax_summary,
+			payment_schedule
 		FROM invoices 
-		WHERE id = $1 AND deleted_at IS NULL`
+		WHERE id = $1 AND deleted_at IS NULL` + scope + lock
 
 	var invoice entities.Invoice
 	var customerEmail, customerPhone, customerAddress, notes sql.NullString
 	var paymentMethod sql.NullString
 	var dueDate, paidAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&invoice.ID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
-		&customerEmail, &customerPhone, &customerAddress, &invoice.Subtotal,
+	var currency, baseCurrency sql.NullString
+	var exchangeRate decimal.Decimal
+	var rateLockedAt sql.NullTime
+	var taxSummary, paymentSchedule []byte
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(
+		&invoice.ID, &invoice.TenantID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
+		&customerEmail, &customerPhone, &customerAddress, &invoice.CustomerTaxID, &invoice.Subtotal,
 		&invoice.TaxAmount, &invoice.DiscountAmount, &invoice.TotalAmount,
 		&invoice.PaidAmount, &paymentMethod, &invoice.Status, &notes, &dueDate, &paidAt,
-		&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy)
+		&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy,
+		&invoice.SurchargeAmount, &invoice.SurchargeTaxAmount, &invoice.SurchargeLabel,
+		&currency, &baseCurrency, &exchangeRate, &rateLockedAt, &invoice.TaxInclusive, &taxSummary, &paymentSchedule)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("invoice")
@@ -108,6 +152,14 @@ func (r *PostgresInvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	if paidAt.Valid {
 		invoice.PaidAt = &paidAt.Time
 	}
+	invoice.Currency = currency.String
+	invoice.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
+	if invoice.TaxSummary, err = scanTaxSummary(taxSummary); err != nil {
+		return nil, err
+	}
+	if invoice.PaymentSchedule, err = scanPaymentSchedule(paymentSchedule); err != nil {
+		return nil, err
+	}
 
 	// Load invoice items
 	items, err := r.getInvoiceItems(ctx, invoice.ID)
@@ -121,25 +173,39 @@ func (r *PostgresInvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (
 
 // GetByInvoiceNumber retrieves an invoice by invoice number
 func (r *PostgresInvoiceRepository) GetByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error) {
+	db := database.Reader(ctx, r.db)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceNumber})
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, invoice_number, sale_id, customer_name, customer_email, 
-			customer_phone, customer_address, subtotal, tax_amount, discount_amount, 
+		SELECT id, tenant_id, invoice_number, sale_id, customer_name, customer_email, 
+			customer_phone, customer_address, customer_tax_id, subtotal, tax_amount, discount_amount, 
 			total_amount, paid_amount, payment_method, status, notes, due_date, paid_at,
-			created_at, updated_at, created_by
+			created_at, updated_at, created_by, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive, tax_summary,
+			payment_schedule
 		FROM invoices 
-		WHERE invoice_number = $1 AND deleted_at IS NULL`
+		WHERE invoice_number = $1 AND deleted_at IS NULL` + scope
 
 	var invoice entities.Invoice
 	var customerEmail, customerPhone, customerAddress, notes sql.NullString
 	var paymentMethod sql.NullString
 	var dueDate, paidAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, invoiceNumber).Scan(
-		&invoice.ID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
-		&customerEmail, &customerPhone, &customerAddress, &invoice.Subtotal,
+	var currency, baseCurrency sql.NullString
+	var exchangeRate decimal.Decimal
+	var rateLockedAt sql.NullTime
+	var taxSummary, paymentSchedule []byte
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(
+		&invoice.ID, &invoice.TenantID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
+		&customerEmail, &customerPhone, &customerAddress, &invoice.CustomerTaxID, &invoice.Subtotal,
 		&invoice.TaxAmount, &invoice.DiscountAmount, &invoice.TotalAmount,
 		&invoice.PaidAmount, &paymentMethod, &invoice.Status, &notes, &dueDate, &paidAt,
-		&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy)
+		&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy,
+		&invoice.SurchargeAmount, &invoice.SurchargeTaxAmount, &invoice.SurchargeLabel,
+		&currency, &baseCurrency, &exchangeRate, &rateLockedAt, &invoice.TaxInclusive, &taxSummary, &paymentSchedule)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("invoice")
@@ -161,6 +227,14 @@ func (r *PostgresInvoiceRepository) GetByInvoiceNumber(ctx context.Context, invo
 	if paidAt.Valid {
 		invoice.PaidAt = &paidAt.Time
 	}
+	invoice.Currency = currency.String
+	invoice.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
+	if invoice.TaxSummary, err = scanTaxSummary(taxSummary); err != nil {
+		return nil, err
+	}
+	if invoice.PaymentSchedule, err = scanPaymentSchedule(paymentSchedule); err != nil {
+		return nil, err
+	}
 
 	// Load invoice items
 	items, err := r.getInvoiceItems(ctx, invoice.ID)
@@ -174,25 +248,37 @@ func (r *PostgresInvoiceRepository) GetByInvoiceNumber(ctx context.Context, invo
 
 // GetBySaleID retrieves an invoice by sale ID
 func (r *PostgresInvoiceRepository) GetBySaleID(ctx context.Context, saleID uuid.UUID) (*entities.Invoice, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{saleID})
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, invoice_number, sale_id, customer_name, customer_email, 
-			customer_phone, customer_address, subtotal, tax_amount, discount_amount, 
+		SELECT id, tenant_id, invoice_number, sale_id, customer_name, customer_email, 
+			customer_phone, customer_address, customer_tax_id, subtotal, tax_amount, discount_amount, 
 			total_amount, paid_amount, payment_method, status, notes, due_date, paid_at,
-			created_at, updated_at, created_by
+			created_at, updated_at, created_by, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive, tax_summary,
+			payment_schedule
 		FROM invoices 
-		WHERE sale_id = $1 AND deleted_at IS NULL`
+		WHERE sale_id = $1 AND deleted_at IS NULL` + scope
 
 	var invoice entities.Invoice
 	var customerEmail, customerPhone, customerAddress, notes sql.NullString
 	var paymentMethod sql.NullString
 	var dueDate, paidAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, saleID).Scan(
-		&invoice.ID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
-		&customerEmail, &customerPhone, &customerAddress, &invoice.Subtotal,
+	var currency, baseCurrency sql.NullString
+	var exchangeRate decimal.Decimal
+	var rateLockedAt sql.NullTime
+	var taxSummary, paymentSchedule []byte
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(
+		&invoice.ID, &invoice.TenantID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
+		&customerEmail, &customerPhone, &customerAddress, &invoice.CustomerTaxID, &invoice.Subtotal,
 		&invoice.TaxAmount, &invoice.DiscountAmount, &invoice.TotalAmount,
 		&invoice.PaidAmount, &paymentMethod, &invoice.Status, &notes, &dueDate, &paidAt,
-		&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy)
+		&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy,
+		&invoice.SurchargeAmount, &invoice.SurchargeTaxAmount, &invoice.SurchargeLabel,
+		&currency, &baseCurrency, &exchangeRate, &rateLockedAt, &invoice.TaxInclusive, &taxSummary, &paymentSchedule)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("invoice")
@@ -214,6 +300,14 @@ func (r *PostgresInvoiceRepository) GetBySaleID(ctx context.Context, saleID uuid
 	if paidAt.Valid {
 		invoice.PaidAt = &paidAt.Time
 	}
+	invoice.Currency = currency.String
+	invoice.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
+	if invoice.TaxSummary, err = scanTaxSummary(taxSummary); err != nil {
+		return nil, err
+	}
+	if invoice.PaymentSchedule, err = scanPaymentSchedule(paymentSchedule); err != nil {
+		return nil, err
+	}
 
 	// Load invoice items
 	items, err := r.getInvoiceItems(ctx, invoice.ID)
@@ -225,6 +319,109 @@ func (r *PostgresInvoiceRepository) GetBySaleID(ctx context.Context, saleID uuid
 	return &invoice, nil
 }
 
+// GetBySaleIDs retrieves the invoices of the given sales in one query, keyed by sale ID, with
+// their items. Sales without an invoice are left out.
+func (r *PostgresInvoiceRepository) GetBySaleIDs(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID]*entities.Invoice, error) {
+	invoices := make(map[uuid.UUID]*entities.Invoice, len(saleIDs))
+	if len(saleIDs) == 0 {
+		return invoices, nil
+	}
+
+	ids := make([]string, len(saleIDs))
+	for i, id := range saleIDs {
+		ids[i] = id.String()
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{pq.Array(ids)})
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT id, tenant_id, invoice_number, sale_id, customer_name, customer_email,
+			customer_phone, customer_address, customer_tax_id, subtotal, tax_amount, discount_amount,
+			total_amount, paid_amount, payment_method, status, notes, due_date, paid_at,
+			created_at, updated_at, created_by, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive, tax_summary,
+			payment_schedule
+		FROM invoices
+		WHERE sale_id = ANY($1::uuid[]) AND deleted_at IS NULL` + scope
+
+	rows, err := database.Reader(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var found []*entities.Invoice
+	for rows.Next() {
+		var invoice entities.Invoice
+		var customerEmail, customerPhone, customerAddress, notes sql.NullString
+		var paymentMethod sql.NullString
+		var dueDate, paidAt sql.NullTime
+		var currency, baseCurrency sql.NullString
+		var exchangeRate decimal.Decimal
+		var rateLockedAt sql.NullTime
+		var taxSummary, paymentSchedule []byte
+
+		err := rows.Scan(
+			&invoice.ID, &invoice.TenantID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
+			&customerEmail, &customerPhone, &customerAddress, &invoice.CustomerTaxID, &invoice.Subtotal,
+			&invoice.TaxAmount, &invoice.DiscountAmount, &invoice.TotalAmount,
+			&invoice.PaidAmount, &paymentMethod, &invoice.Status, &notes, &dueDate, &paidAt,
+			&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy,
+			&invoice.SurchargeAmount, &invoice.SurchargeTaxAmount, &invoice.SurchargeLabel,
+			&currency, &baseCurrency, &exchangeRate, &rateLockedAt, &invoice.TaxInclusive, &taxSummary, &paymentSchedule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice: %w", err)
+		}
+
+		// Handle nullable fields
+		invoice.CustomerEmail = customerEmail.String
+		invoice.CustomerPhone = customerPhone.String
+		invoice.CustomerAddress = customerAddress.String
+		invoice.Notes = notes.String
+		if paymentMethod.Valid {
+			invoice.PaymentMethod = entities.PaymentMethod(paymentMethod.String)
+		}
+		if dueDate.Valid {
+			invoice.DueDate = &dueDate.Time
+		}
+		if paidAt.Valid {
+			invoice.PaidAt = &paidAt.Time
+		}
+		invoice.Currency = currency.String
+		invoice.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
+		if invoice.TaxSummary, err = scanTaxSummary(taxSummary); err != nil {
+			return nil, err
+		}
+		if invoice.PaymentSchedule, err = scanPaymentSchedule(paymentSchedule); err != nil {
+			return nil, err
+		}
+
+		found = append(found, &invoice)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invoices: %w", err)
+	}
+
+	invoiceIDs := make([]uuid.UUID, len(found))
+	for i, invoice := range found {
+		invoiceIDs[i] = invoice.ID
+	}
+
+	items, err := r.getItemsByInvoiceIDs(ctx, invoiceIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, invoice := range found {
+		invoice.Items = items[invoice.ID]
+		invoices[invoice.SaleID] = invoice
+	}
+
+	return invoices, nil
+}
+
 // Update updates an existing invoice
 func (r *PostgresInvoiceRepository) Update(ctx context.Context, invoice *entities.Invoice) error {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -239,14 +436,29 @@ func (r *PostgresInvoiceRepository) Update(ctx context.Context, invoice *entitie
 			customer_name = $2, customer_email = $3, customer_phone = $4, customer_address = $5,
 			subtotal = $6, tax_amount = $7, discount_amount = $8, total_amount = $9,
 			paid_amount = $10, payment_method = $11, status = $12, notes = $13,
-			due_date = $14, paid_at = $15, updated_at = $16
+			due_date = $14, paid_at = $15, updated_at = $16, tax_summary = $17, payment_schedule = $18,
+			customer_tax_id = $19
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := tx.ExecContext(ctx, query,
+	taxSummary, err := json.Marshal(invoiceTaxSummary(invoice))
+	if err != nil {
+		return fmt.Errorf("failed to marshal tax summary: %w", err)
+	}
+	paymentSchedule, err := json.Marshal(invoicePaymentSchedule(invoice))
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment schedule: %w", err)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 		invoice.ID, invoice.CustomerName, invoice.CustomerEmail, invoice.CustomerPhone,
 		invoice.CustomerAddress, invoice.Subtotal, invoice.TaxAmount, invoice.DiscountAmount,
 		invoice.TotalAmount, invoice.PaidAmount, invoice.PaymentMethod, invoice.Status,
-		invoice.Notes, invoice.DueDate, invoice.PaidAt, invoice.UpdatedAt)
+		invoice.Notes, invoice.DueDate, invoice.PaidAt, invoice.UpdatedAt, taxSummary, paymentSchedule,
+		invoice.CustomerTaxID})
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, query+scope, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update invoice: %w", err)
 	}
@@ -276,9 +488,13 @@ func (r *PostgresInvoiceRepository) Update(ctx context.Context, invoice *entitie
 
 // Delete deletes an invoice (soft delete)
 func (r *PostgresInvoiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE invoices SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	query := `UPDATE invoices SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL` + scope
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete invoice: %w", err)
 	}
@@ -296,10 +512,14 @@ func (r *PostgresInvoiceRepository) Delete(ctx context.Context, id uuid.UUID) er
 
 // ExistsByInvoiceNumber checks if an invoice exists by invoice number
 func (r *PostgresInvoiceRepository) ExistsByInvoiceNumber(ctx context.Context, invoiceNumber string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM invoices WHERE invoice_number = $1 AND deleted_at IS NULL)`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceNumber})
+	if err != nil {
+		return false, err
+	}
+	query := `SELECT EXISTS(SELECT 1 FROM invoices WHERE invoice_number = $1 AND deleted_at IS NULL` + scope + `)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, invoiceNumber).Scan(&exists)
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check invoice existence: %w", err)
 	}
@@ -309,11 +529,23 @@ func (r *PostgresInvoiceRepository) ExistsByInvoiceNumber(ctx context.Context, i
 
 // List retrieves invoices with pagination and filtering
 func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositories.InvoiceFilter, pagination utils.PaginationInfo) ([]*entities.Invoice, utils.PaginationInfo, error) {
+	db := database.Reader(ctx, r.db)
+
 	// Build WHERE clause
 	conditions := []string{"deleted_at IS NULL"}
 	args := []interface{}{}
 	argCount := 0
 
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	}
+
 	if filter.Status != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
@@ -374,6 +606,18 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 		args = append(args, *filter.DueToDate)
 	}
 
+	if filter.PaidFromDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("paid_at >= $%d", argCount))
+		args = append(args, *filter.PaidFromDate)
+	}
+
+	if filter.PaidToDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("paid_at < $%d", argCount))
+		args = append(args, *filter.PaidToDate)
+	}
+
 	if filter.MinAmount != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("total_amount >= $%d", argCount))
@@ -411,7 +655,7 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 	// Count total records
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM invoices %s", whereClause)
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to count invoices: %w", err)
 	}
@@ -422,10 +666,12 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 
 	// Query with pagination
 	query := fmt.Sprintf(`
-		SELECT id, invoice_number, sale_id, customer_name, customer_email, 
-			customer_phone, customer_address, subtotal, tax_amount, discount_amount, 
+		SELECT id, tenant_id, invoice_number, sale_id, customer_name, customer_email, 
+			customer_phone, customer_address, customer_tax_id, subtotal, tax_amount, discount_amount, 
 			total_amount, paid_amount, payment_method, status, notes, due_date, paid_at,
-			created_at, updated_at, created_by
+			created_at, updated_at, created_by, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive, tax_summary,
+			payment_schedule
 		FROM invoices 
 		%s 
 		ORDER BY %s 
@@ -434,7 +680,7 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 
 	args = append(args, pagination.Limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, paginationResult, fmt.Errorf("failed to query invoices: %w", err)
 	}
@@ -446,13 +692,19 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 		var customerEmail, customerPhone, customerAddress, notes sql.NullString
 		var paymentMethod sql.NullString
 		var dueDate, paidAt sql.NullTime
+		var currency, baseCurrency sql.NullString
+		var exchangeRate decimal.Decimal
+		var rateLockedAt sql.NullTime
+		var taxSummary, paymentSchedule []byte
 
 		err := rows.Scan(
-			&invoice.ID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
-			&customerEmail, &customerPhone, &customerAddress, &invoice.Subtotal,
+			&invoice.ID, &invoice.TenantID, &invoice.InvoiceNumber, &invoice.SaleID, &invoice.CustomerName,
+			&customerEmail, &customerPhone, &customerAddress, &invoice.CustomerTaxID, &invoice.Subtotal,
 			&invoice.TaxAmount, &invoice.DiscountAmount, &invoice.TotalAmount,
 			&invoice.PaidAmount, &paymentMethod, &invoice.Status, &notes, &dueDate, &paidAt,
-			&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy)
+			&invoice.CreatedAt, &invoice.UpdatedAt, &invoice.CreatedBy,
+			&invoice.SurchargeAmount, &invoice.SurchargeTaxAmount, &invoice.SurchargeLabel,
+			&currency, &baseCurrency, &exchangeRate, &rateLockedAt, &invoice.TaxInclusive, &taxSummary, &paymentSchedule)
 		if err != nil {
 			return nil, paginationResult, fmt.Errorf("failed to scan invoice: %w", err)
 		}
@@ -471,13 +723,14 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 		if paidAt.Valid {
 			invoice.PaidAt = &paidAt.Time
 		}
-
-		// Load invoice items for each invoice
-		items, err := r.getInvoiceItems(ctx, invoice.ID)
-		if err != nil {
+		invoice.Currency = currency.String
+		invoice.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
+		if invoice.TaxSummary, err = scanTaxSummary(taxSummary); err != nil {
+			return nil, paginationResult, err
+		}
+		if invoice.PaymentSchedule, err = scanPaymentSchedule(paymentSchedule); err != nil {
 			return nil, paginationResult, err
 		}
-		invoice.Items = items
 
 		invoices = append(invoices, &invoice)
 	}
@@ -486,6 +739,20 @@ func (r *PostgresInvoiceRepository) List(ctx context.Context, filter repositorie
 		return nil, paginationResult, fmt.Errorf("failed to iterate invoices: %w", err)
 	}
 
+	// Load the items of every listed invoice at once
+	invoiceIDs := make([]uuid.UUID, len(invoices))
+	for i, invoice := range invoices {
+		invoiceIDs[i] = invoice.ID
+	}
+
+	items, err := r.getItemsByInvoiceIDs(ctx, invoiceIDs)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+	for _, invoice := range invoices {
+		invoice.Items = items[invoice.ID]
+	}
+
 	return invoices, paginationResult, nil
 }
 
@@ -499,6 +766,88 @@ func (r *PostgresInvoiceRepository) GetOverdueInvoices(ctx context.Context, pagi
 	return r.List(ctx, filter, pagination)
 }
 
+// GetOverdueSummary summarizes a tenant's overdue invoices as of a point in time
+func (r *PostgresInvoiceRepository) GetOverdueSummary(ctx context.Context, tenantID uuid.UUID, asOf time.Time, limit int) (*repositories.OverdueInvoiceSummary, error) {
+	db := database.Reader(ctx, r.db)
+
+	summary := repositories.OverdueInvoiceSummary{
+		Invoices: []*repositories.OverdueInvoiceLine{},
+	}
+
+	query := `
+		SELECT 
+			COUNT(*) as invoice_count,
+			COALESCE(SUM((total_amount - paid_amount) * exchange_rate), 0) as outstanding_amount
+		FROM invoices 
+		WHERE tenant_id = $1 AND due_date < $2 AND status NOT IN ('paid', 'cancelled') AND deleted_at IS NULL`
+
+	err := db.QueryRowContext(ctx, query, tenantID, asOf).Scan(&summary.InvoiceCount, &summary.OutstandingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue summary: %w", err)
+	}
+
+	if summary.InvoiceCount == 0 || limit <= 0 {
+		return &summary, nil
+	}
+
+	linesQuery := `
+		SELECT id, invoice_number, customer_name, due_date, total_amount - paid_amount
+		FROM invoices 
+		WHERE tenant_id = $1 AND due_date < $2 AND status NOT IN ('paid', 'cancelled') AND deleted_at IS NULL
+		ORDER BY due_date ASC
+		LIMIT $3`
+
+	rows, err := db.QueryContext(ctx, linesQuery, tenantID, asOf, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overdue invoices: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line repositories.OverdueInvoiceLine
+		err := rows.Scan(&line.InvoiceID, &line.InvoiceNumber, &line.CustomerName, &line.DueDate, &line.OutstandingAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan overdue invoice: %w", err)
+		}
+		summary.Invoices = append(summary.Invoices, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate overdue invoices: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// GetCustomerOverdueSummary summarizes a tenant customer's overdue invoices matched by email or phone
+func (r *PostgresInvoiceRepository) GetCustomerOverdueSummary(ctx context.Context, tenantID uuid.UUID, customerEmail, customerPhone string) (*repositories.CustomerOverdueSummary, error) {
+	var summary repositories.CustomerOverdueSummary
+	if customerEmail == "" && customerPhone == "" {
+		summary.OutstandingAmount = decimal.Zero
+		return &summary, nil
+	}
+
+	query := `
+		SELECT 
+			COUNT(*) as invoice_count,
+			COALESCE(SUM((total_amount - paid_amount) * exchange_rate), 0) as outstanding_amount
+		FROM invoices 
+		WHERE tenant_id = $1 AND due_date < NOW() AND status NOT IN ('paid', 'cancelled') AND deleted_at IS NULL
+			AND ((customer_email = $2 AND $2 != '') OR (customer_phone = $3 AND $3 != ''))`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{tenantID, customerEmail, customerPhone})
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(
+		&summary.InvoiceCount, &summary.OutstandingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer overdue summary: %w", err)
+	}
+
+	return &summary, nil
+}
+
 // GetInvoicesByStatus retrieves invoices by status
 func (r *PostgresInvoiceRepository) GetInvoicesByStatus(ctx context.Context, status entities.InvoiceStatus, pagination utils.PaginationInfo) ([]*entities.Invoice, utils.PaginationInfo, error) {
 	// Filter by status
@@ -515,13 +864,18 @@ func (r *PostgresInvoiceRepository) GetInvoiceReport(ctx context.Context, fromDa
 	query := `
 		SELECT 
 			COUNT(*) as total_invoices,
-			COALESCE(SUM(total_amount), 0) as total_amount,
-			COALESCE(SUM(paid_amount), 0) as paid_amount,
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_amount,
+			COALESCE(SUM(paid_amount * exchange_rate), 0) as paid_amount,
 			COALESCE(SUM(CASE WHEN status = 'draft' THEN 1 ELSE 0 END), 0) as draft_invoices,
 			COALESCE(SUM(CASE WHEN status = 'generated' THEN 1 ELSE 0 END), 0) as generated_invoices,
 			COALESCE(SUM(CASE WHEN status = 'sent' THEN 1 ELSE 0 END), 0) as sent_invoices,
 			COALESCE(SUM(CASE WHEN status = 'paid' THEN 1 ELSE 0 END), 0) as paid_invoices,
-			COALESCE(SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END), 0) as cancelled_invoices
+			COALESCE(SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END), 0) as cancelled_invoices,
+			COALESCE(SUM(CASE WHEN status = 'partially_paid' THEN 1 ELSE 0 END), 0) as partially_paid_invoices,
+			COALESCE(SUM(CASE WHEN status IN ('draft', 'generated', 'sent')
+				THEN GREATEST(total_amount - paid_amount, 0) * exchange_rate ELSE 0 END), 0) as unpaid_balance,
+			COALESCE(SUM(CASE WHEN status = 'partially_paid'
+				THEN (total_amount - paid_amount) * exchange_rate ELSE 0 END), 0) as partially_paid_balance
 		FROM invoices 
 		WHERE created_at >= $1 AND created_at <= $2 AND deleted_at IS NULL`
 
@@ -529,10 +883,15 @@ func (r *PostgresInvoiceRepository) GetInvoiceReport(ctx context.Context, fromDa
 	report.FromDate = fromDate
 	report.ToDate = toDate
 
-	err := r.db.QueryRowContext(ctx, query, fromDate, toDate).Scan(
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(
 		&report.TotalInvoices, &report.TotalAmount, &report.PaidAmount,
 		&report.DraftInvoices, &report.GeneratedInvoices, &report.SentInvoices,
-		&report.PaidInvoices, &report.CancelledInvoices)
+		&report.PaidInvoices, &report.CancelledInvoices, &report.PartiallyPaidInvoices,
+		&report.UnpaidBalance, &report.PartiallyPaidBalance)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invoice statistics: %w", err)
 	}
@@ -541,15 +900,9 @@ func (r *PostgresInvoiceRepository) GetInvoiceReport(ctx context.Context, fromDa
 	report.OutstandingAmount = report.TotalAmount.Sub(report.PaidAmount)
 
 	// Get overdue invoices count
-	overdueQuery := `
-		SELECT COUNT(*)
-		FROM invoices 
-		WHERE created_at >= $1 AND created_at <= $2 AND due_date < NOW() 
-			AND status NOT IN ('paid', 'cancelled') AND deleted_at IS NULL`
-
-	err = r.db.QueryRowContext(ctx, overdueQuery, fromDate, toDate).Scan(&report.OverdueInvoices)
+	report.OverdueInvoices, err = r.getOverdueInvoiceCount(ctx, fromDate, toDate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get overdue invoices count: %w", err)
+		return nil, err
 	}
 
 	// Get average payment time
@@ -560,7 +913,7 @@ func (r *PostgresInvoiceRepository) GetInvoiceReport(ctx context.Context, fromDa
 			AND paid_at IS NOT NULL AND deleted_at IS NULL`
 
 	var avgPaymentDays float64
-	err = r.db.QueryRowContext(ctx, avgPaymentQuery, fromDate, toDate).Scan(&avgPaymentDays)
+	err = r.db.QueryRowContext(ctx, avgPaymentQuery+scope, args...).Scan(&avgPaymentDays)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get average payment time: %w", err)
 	}
@@ -583,19 +936,75 @@ func (r *PostgresInvoiceRepository) GetInvoiceReport(ctx context.Context, fromDa
 	return &report, nil
 }
 
+// CreatePayment records a payment against an invoice
+func (r *PostgresInvoiceRepository) CreatePayment(ctx context.Context, payment *entities.InvoicePayment) error {
+	query := `
+		INSERT INTO invoice_payments (id, tenant_id, invoice_id, amount, payment_method, reference,
+			paid_at, recorded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		payment.ID, payment.TenantID, payment.InvoiceID, payment.Amount, payment.PaymentMethod,
+		payment.Reference, payment.PaidAt, payment.RecordedBy, payment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert invoice payment: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayments retrieves the payments recorded against an invoice, oldest first
+func (r *PostgresInvoiceRepository) GetPayments(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoicePayment, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceID})
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT id, tenant_id, invoice_id, amount, payment_method, reference, paid_at, recorded_by,
+			created_at
+		FROM invoice_payments
+		WHERE invoice_id = $1` + scope + `
+		ORDER BY paid_at, created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoice payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []*entities.InvoicePayment{}
+	for rows.Next() {
+		var payment entities.InvoicePayment
+		err := rows.Scan(&payment.ID, &payment.TenantID, &payment.InvoiceID, &payment.Amount,
+			&payment.PaymentMethod, &payment.Reference, &payment.PaidAt, &payment.RecordedBy,
+			&payment.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice payment: %w", err)
+		}
+		payments = append(payments, &payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invoice payments: %w", err)
+	}
+
+	return payments, nil
+}
+
 // Helper functions
 
 // insertInvoiceItems inserts invoice items in a transaction
 func (r *PostgresInvoiceRepository) insertInvoiceItems(ctx context.Context, tx *sql.Tx, invoiceID uuid.UUID, items []entities.InvoiceItem) error {
 	query := `
 		INSERT INTO invoice_items (id, invoice_id, product_id, product_sku, product_name, 
-			description, quantity, unit_price, total_price)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			description, quantity, unit_price, total_price, tax_rate_id, tax_rate, tax_amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	for _, item := range items {
 		_, err := tx.ExecContext(ctx, query,
 			item.ID, invoiceID, item.ProductID, item.ProductSKU, item.ProductName,
-			item.Description, item.Quantity, item.UnitPrice, item.TotalPrice)
+			item.Description, item.Quantity, item.UnitPrice, item.TotalPrice, item.TaxRateID, item.TaxRate,
+			item.TaxAmount)
 		if err != nil {
 			return fmt.Errorf("failed to insert invoice item: %w", err)
 		}
@@ -604,6 +1013,49 @@ func (r *PostgresInvoiceRepository) insertInvoiceItems(ctx context.Context, tx *
 	return nil
 }
 
+// invoiceTaxSummary returns the invoice's tax summary as stored, never null
+func invoiceTaxSummary(invoice *entities.Invoice) []entities.TaxRateSummary {
+	if invoice.TaxSummary == nil {
+		return []entities.TaxRateSummary{}
+	}
+	return invoice.TaxSummary
+}
+
+// scanTaxSummary decodes an invoice's stored tax summary
+func scanTaxSummary(data []byte) ([]entities.TaxRateSummary, error) {
+	summary := []entities.TaxRateSummary{}
+	if len(data) == 0 {
+		return summary, nil
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tax summary: %w", err)
+	}
+	return summary, nil
+}
+
+// invoicePaymentSchedule returns the invoice's payment schedule as stored, never null
+func invoicePaymentSchedule(invoice *entities.Invoice) []entities.InvoiceInstallment {
+	if invoice.PaymentSchedule == nil {
+		return []entities.InvoiceInstallment{}
+	}
+	return invoice.PaymentSchedule
+}
+
+// scanPaymentSchedule decodes an invoice's stored payment schedule, nil when it has none
+func scanPaymentSchedule(data []byte) ([]entities.InvoiceInstallment, error) {
+	var schedule []entities.InvoiceInstallment
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment schedule: %w", err)
+	}
+	if len(schedule) == 0 {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
 // deleteInvoiceItems deletes all invoice items for an invoice
 func (r *PostgresInvoiceRepository) deleteInvoiceItems(ctx context.Context, tx *sql.Tx, invoiceID uuid.UUID) error {
 	query := `DELETE FROM invoice_items WHERE invoice_id = $1`
@@ -618,30 +1070,55 @@ func (r *PostgresInvoiceRepository) deleteInvoiceItems(ctx context.Context, tx *
 
 // getInvoiceItems retrieves all items for an invoice
 func (r *PostgresInvoiceRepository) getInvoiceItems(ctx context.Context, invoiceID uuid.UUID) ([]entities.InvoiceItem, error) {
+	items, err := r.getItemsByInvoiceIDs(ctx, []uuid.UUID{invoiceID})
+	if err != nil {
+		return nil, err
+	}
+	return items[invoiceID], nil
+}
+
+// getItemsByInvoiceIDs retrieves the items of several invoices in one query, keyed by invoice ID
+func (r *PostgresInvoiceRepository) getItemsByInvoiceIDs(ctx context.Context, invoiceIDs []uuid.UUID) (map[uuid.UUID][]entities.InvoiceItem, error) {
+	db := database.Reader(ctx, r.db)
+
+	items := make(map[uuid.UUID][]entities.InvoiceItem, len(invoiceIDs))
+	if len(invoiceIDs) == 0 {
+		return items, nil
+	}
+
+	ids := make([]string, len(invoiceIDs))
+	for i, id := range invoiceIDs {
+		ids[i] = id.String()
+	}
+
 	query := `
 		SELECT id, invoice_id, product_id, product_sku, product_name, 
-			description, quantity, unit_price, total_price
+			description, quantity, unit_price, total_price, tax_rate_id, tax_rate, tax_amount
 		FROM invoice_items 
-		WHERE invoice_id = $1 
+		WHERE invoice_id = ANY($1::uuid[]) 
 		ORDER BY product_name`
 
-	rows, err := r.db.QueryContext(ctx, query, invoiceID)
+	rows, err := db.QueryContext(ctx, query, pq.Array(ids))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query invoice items: %w", err)
 	}
 	defer rows.Close()
 
-	var items []entities.InvoiceItem
 	for rows.Next() {
 		var item entities.InvoiceItem
 		var description sql.NullString
+		var taxRateID uuid.NullUUID
 		err := rows.Scan(&item.ID, &item.InvoiceID, &item.ProductID, &item.ProductSKU,
-			&item.ProductName, &description, &item.Quantity, &item.UnitPrice, &item.TotalPrice)
+			&item.ProductName, &description, &item.Quantity, &item.UnitPrice, &item.TotalPrice,
+			&taxRateID, &item.TaxRate, &item.TaxAmount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan invoice item: %w", err)
 		}
 		item.Description = description.String
-		items = append(items, item)
+		if taxRateID.Valid {
+			item.TaxRateID = &taxRateID.UUID
+		}
+		items[item.InvoiceID] = append(items[item.InvoiceID], item)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -651,20 +1128,50 @@ func (r *PostgresInvoiceRepository) getInvoiceItems(ctx context.Context, invoice
 	return items, nil
 }
 
+// getOverdueInvoiceCount counts the invoices created in a date range that are past due and
+// not yet paid
+func (r *PostgresInvoiceRepository) getOverdueInvoiceCount(ctx context.Context, fromDate, toDate time.Time) (int, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT COUNT(*)
+		FROM invoices 
+		WHERE created_at >= $1 AND created_at <= $2 AND due_date < NOW() 
+			AND status NOT IN ('paid', 'cancelled') AND deleted_at IS NULL`
+
+	var count int
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return 0, err
+	}
+	if err := db.QueryRowContext(ctx, query+scope, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to get overdue invoices count: %w", err)
+	}
+
+	return count, nil
+}
+
 // getInvoicePaymentMethodStats gets payment method statistics for invoices
 func (r *PostgresInvoiceRepository) getInvoicePaymentMethodStats(ctx context.Context, fromDate, toDate time.Time) ([]repositories.PaymentMethodStat, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
 		SELECT 
 			payment_method,
 			COUNT(*) as count,
-			COALESCE(SUM(total_amount), 0) as total_amount
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_amount,
+			COALESCE(SUM(surcharge_amount * exchange_rate), 0) as surcharge_amount
 		FROM invoices 
 		WHERE created_at >= $1 AND created_at <= $2 AND status = 'paid' 
-			AND deleted_at IS NULL AND payment_method IS NOT NULL
+			AND deleted_at IS NULL AND payment_method IS NOT NULL%s
 		GROUP BY payment_method
 		ORDER BY total_amount DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate)
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query invoice payment method stats: %w", err)
 	}
@@ -676,7 +1183,7 @@ func (r *PostgresInvoiceRepository) getInvoicePaymentMethodStats(ctx context.Con
 	// First pass: collect data and calculate total
 	for rows.Next() {
 		var stat repositories.PaymentMethodStat
-		err := rows.Scan(&stat.PaymentMethod, &stat.Count, &stat.TotalAmount)
+		err := rows.Scan(&stat.PaymentMethod, &stat.Count, &stat.TotalAmount, &stat.SurchargeAmount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan invoice payment method stat: %w", err)
 		}
@@ -702,18 +1209,24 @@ func (r *PostgresInvoiceRepository) getInvoicePaymentMethodStats(ctx context.Con
 
 // getMonthlyInvoiceData gets monthly invoice data for a date range
 func (r *PostgresInvoiceRepository) getMonthlyInvoiceData(ctx context.Context, fromDate, toDate time.Time) ([]repositories.MonthlyInvoiceData, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
 		SELECT 
 			DATE_TRUNC('month', created_at) as month,
 			COUNT(*) as total_invoices,
-			COALESCE(SUM(total_amount), 0) as total_amount,
-			COALESCE(SUM(paid_amount), 0) as paid_amount
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_amount,
+			COALESCE(SUM(paid_amount * exchange_rate), 0) as paid_amount
 		FROM invoices 
-		WHERE created_at >= $1 AND created_at <= $2 AND deleted_at IS NULL
+		WHERE created_at >= $1 AND created_at <= $2 AND deleted_at IS NULL%s
 		GROUP BY DATE_TRUNC('month', created_at)
 		ORDER BY month`
 
-	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate)
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query monthly invoice data: %w", err)
 	}
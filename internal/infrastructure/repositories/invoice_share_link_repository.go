@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// invoiceShareLinkColumns lists the columns scanned by scanInvoiceShareLink
+const invoiceShareLinkColumns = `id, tenant_id, invoice_id, token_hash, payment_provider, expires_at, revoked_at,
+	view_count, last_viewed_at, created_by, created_at, updated_at`
+
+// PostgresInvoiceShareLinkRepository implements the InvoiceShareLinkRepository interface
+type PostgresInvoiceShareLinkRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInvoiceShareLinkRepository creates a new PostgreSQL invoice share link repository
+func NewPostgresInvoiceShareLinkRepository(db *sql.DB) repositories.InvoiceShareLinkRepository {
+	return &PostgresInvoiceShareLinkRepository{db: db}
+}
+
+// Create creates a new share link
+func (r *PostgresInvoiceShareLinkRepository) Create(ctx context.Context, link *entities.InvoiceShareLink) error {
+	query := `
+		INSERT INTO invoice_share_links (id, tenant_id, invoice_id, token_hash, payment_provider, expires_at,
+			revoked_at, view_count, last_viewed_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.ID, link.TenantID, link.InvoiceID, link.TokenHash, link.PaymentProvider, link.ExpiresAt,
+		link.RevokedAt, link.ViewCount, link.LastViewedAt, link.CreatedBy, link.CreatedAt, link.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert invoice share link: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a share link by ID
+func (r *PostgresInvoiceShareLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceShareLink, error) {
+	query := `SELECT ` + invoiceShareLinkColumns + ` FROM invoice_share_links WHERE id = $1`
+
+	link, err := r.scanInvoiceShareLink(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invoice share link")
+		}
+		return nil, fmt.Errorf("failed to get invoice share link: %w", err)
+	}
+
+	return link, nil
+}
+
+// GetByTokenHash retrieves a share link by the hash of its token
+func (r *PostgresInvoiceShareLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.InvoiceShareLink, error) {
+	query := `SELECT ` + invoiceShareLinkColumns + ` FROM invoice_share_links WHERE token_hash = $1`
+
+	link, err := r.scanInvoiceShareLink(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invoice share link")
+		}
+		return nil, fmt.Errorf("failed to get invoice share link by token: %w", err)
+	}
+
+	return link, nil
+}
+
+// ListByInvoice retrieves the share links of an invoice, newest first
+func (r *PostgresInvoiceShareLinkRepository) ListByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.InvoiceShareLink, error) {
+	query := `SELECT ` + invoiceShareLinkColumns + ` FROM invoice_share_links WHERE invoice_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoice share links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*entities.InvoiceShareLink{}
+	for rows.Next() {
+		link, err := r.scanInvoiceShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice share link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invoice share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Update updates the expiry and revocation of a share link
+func (r *PostgresInvoiceShareLinkRepository) Update(ctx context.Context, link *entities.InvoiceShareLink) error {
+	query := `
+		UPDATE invoice_share_links SET
+			expires_at = $2, revoked_at = $3, updated_at = $4
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, link.ID, link.ExpiresAt, link.RevokedAt, link.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update invoice share link: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("invoice share link")
+	}
+
+	return nil
+}
+
+// RecordView counts a view of a share link. The count is incremented in place so concurrent
+// views are not lost.
+func (r *PostgresInvoiceShareLinkRepository) RecordView(ctx context.Context, id uuid.UUID, viewedAt time.Time) error {
+	query := `
+		UPDATE invoice_share_links SET
+			view_count = view_count + 1, last_viewed_at = $2
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, viewedAt); err != nil {
+		return fmt.Errorf("failed to record invoice share link view: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// scanInvoiceShareLink scans a share link from a row
+func (r *PostgresInvoiceShareLinkRepository) scanInvoiceShareLink(row interface{ Scan(...interface{}) error }) (*entities.InvoiceShareLink, error) {
+	var link entities.InvoiceShareLink
+	var revokedAt, lastViewedAt sql.NullTime
+
+	err := row.Scan(
+		&link.ID, &link.TenantID, &link.InvoiceID, &link.TokenHash, &link.PaymentProvider, &link.ExpiresAt, &revokedAt,
+		&link.ViewCount, &lastViewedAt, &link.CreatedBy, &link.CreatedAt, &link.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		link.RevokedAt = &revokedAt.Time
+	}
+	if lastViewedAt.Valid {
+		link.LastViewedAt = &lastViewedAt.Time
+	}
+
+	return &link, nil
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresInvoiceTemplateRepository implements the InvoiceTemplateRepository interface
+type PostgresInvoiceTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInvoiceTemplateRepository creates a new PostgreSQL invoice template repository
+func NewPostgresInvoiceTemplateRepository(db *sql.DB) repositories.InvoiceTemplateRepository {
+	return &PostgresInvoiceTemplateRepository{db: db}
+}
+
+const invoiceTemplateColumns = `id, tenant_id, name, design, logo, logo_content_type, is_default,
+			created_by, updated_by, created_at, updated_at`
+
+// Create creates a new custom invoice template
+func (r *PostgresInvoiceTemplateRepository) Create(ctx context.Context, template *entities.CustomInvoiceTemplate) error {
+	design, err := json.Marshal(template.Template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invoice template design: %w", err)
+	}
+
+	query := `
+		INSERT INTO invoice_templates (id, tenant_id, name, design, logo, logo_content_type, is_default,
+			created_by, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		template.ID, template.TenantID, template.Name, design, template.Logo, template.LogoContentType, template.IsDefault,
+		template.CreatedBy, template.UpdatedBy, template.CreatedAt, template.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("invoice template '%s' already exists", template.Name))
+		}
+		return fmt.Errorf("failed to insert invoice template: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a custom invoice template by ID
+func (r *PostgresInvoiceTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.CustomInvoiceTemplate, error) {
+	query := `
+		SELECT ` + invoiceTemplateColumns + `
+		FROM invoice_templates
+		WHERE id = $1`
+
+	template, err := r.scanInvoiceTemplate(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invoice template")
+		}
+		return nil, fmt.Errorf("failed to get invoice template: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetDefault retrieves the tenant's default invoice template
+func (r *PostgresInvoiceTemplateRepository) GetDefault(ctx context.Context, tenantID uuid.UUID) (*entities.CustomInvoiceTemplate, error) {
+	query := `
+		SELECT ` + invoiceTemplateColumns + `
+		FROM invoice_templates
+		WHERE tenant_id = $1 AND is_default`
+
+	template, err := r.scanInvoiceTemplate(r.db.QueryRowContext(ctx, query, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invoice template")
+		}
+		return nil, fmt.Errorf("failed to get default invoice template: %w", err)
+	}
+
+	return template, nil
+}
+
+// ListByTenant retrieves a tenant's custom invoice templates by name
+func (r *PostgresInvoiceTemplateRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.CustomInvoiceTemplate, error) {
+	query := `
+		SELECT ` + invoiceTemplateColumns + `
+		FROM invoice_templates
+		WHERE tenant_id = $1
+		ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invoice templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*entities.CustomInvoiceTemplate{}
+	for rows.Next() {
+		template, err := r.scanInvoiceTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invoice templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update updates a custom invoice template, including its logo
+func (r *PostgresInvoiceTemplateRepository) Update(ctx context.Context, template *entities.CustomInvoiceTemplate) error {
+	design, err := json.Marshal(template.Template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invoice template design: %w", err)
+	}
+
+	query := `
+		UPDATE invoice_templates
+		SET name = $2, design = $3, logo = $4, logo_content_type = $5, updated_by = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		template.ID, template.Name, design, template.Logo, template.LogoContentType, template.UpdatedBy, template.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("invoice template '%s' already exists", template.Name))
+		}
+		return fmt.Errorf("failed to update invoice template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("invoice template")
+	}
+
+	return nil
+}
+
+// SetDefault makes a template the tenant's default, replacing the previous default in the
+// same transaction
+func (r *PostgresInvoiceTemplateRepository) SetDefault(ctx context.Context, tenantID, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `UPDATE invoice_templates SET is_default = FALSE WHERE tenant_id = $1 AND is_default AND id <> $2`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to clear default invoice template: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE invoice_templates SET is_default = TRUE WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to set default invoice template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("invoice template")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a custom invoice template
+func (r *PostgresInvoiceTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM invoice_templates WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete invoice template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("invoice template")
+	}
+
+	return nil
+}
+
+// scanInvoiceTemplate scans a custom invoice template from a row
+func (r *PostgresInvoiceTemplateRepository) scanInvoiceTemplate(row interface{ Scan(...interface{}) error }) (*entities.CustomInvoiceTemplate, error) {
+	var template entities.CustomInvoiceTemplate
+	var design []byte
+
+	err := row.Scan(&template.ID, &template.TenantID, &template.Name, &design, &template.Logo, &template.LogoContentType,
+		&template.IsDefault, &template.CreatedBy, &template.UpdatedBy, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(design, &template.Template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal invoice template design: %w", err)
+	}
+
+	return &template, nil
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresKioskDeviceRepository implements the KioskDeviceRepository interface
+type PostgresKioskDeviceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresKioskDeviceRepository creates a new PostgreSQL kiosk device repository
+func NewPostgresKioskDeviceRepository(db *sql.DB) repositories.KioskDeviceRepository {
+	return &PostgresKioskDeviceRepository{db: db}
+}
+
+// Create creates a new kiosk device
+func (r *PostgresKioskDeviceRepository) Create(ctx context.Context, device *entities.KioskDevice) error {
+	query := `
+		INSERT INTO kiosk_devices (id, tenant_id, name, token_hash, is_active, revoked_at,
+			created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		device.ID, device.TenantID, device.Name, device.TokenHash, device.IsActive, device.RevokedAt,
+		device.CreatedAt, device.UpdatedAt, device.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert kiosk device: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a kiosk device by ID
+func (r *PostgresKioskDeviceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error) {
+	query := `
+		SELECT id, tenant_id, name, token_hash, is_active, revoked_at, created_at, updated_at, created_by
+		FROM kiosk_devices
+		WHERE id = $1`
+
+	device, err := r.scanKioskDevice(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("kiosk device")
+		}
+		return nil, fmt.Errorf("failed to get kiosk device: %w", err)
+	}
+
+	return device, nil
+}
+
+// GetByTokenHash retrieves a kiosk device by the hash of its device token
+func (r *PostgresKioskDeviceRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.KioskDevice, error) {
+	query := `
+		SELECT id, tenant_id, name, token_hash, is_active, revoked_at, created_at, updated_at, created_by
+		FROM kiosk_devices
+		WHERE token_hash = $1`
+
+	device, err := r.scanKioskDevice(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("kiosk device")
+		}
+		return nil, fmt.Errorf("failed to get kiosk device by token: %w", err)
+	}
+
+	return device, nil
+}
+
+// Update updates an existing kiosk device
+func (r *PostgresKioskDeviceRepository) Update(ctx context.Context, device *entities.KioskDevice) error {
+	query := `
+		UPDATE kiosk_devices SET
+			name = $2, is_active = $3, revoked_at = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		device.ID, device.Name, device.IsActive, device.RevokedAt, device.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update kiosk device: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("kiosk device")
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves all kiosk devices for a tenant
+func (r *PostgresKioskDeviceRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.KioskDevice, error) {
+	query := `
+		SELECT id, tenant_id, name, token_hash, is_active, revoked_at, created_at, updated_at, created_by
+		FROM kiosk_devices
+		WHERE tenant_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kiosk devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*entities.KioskDevice
+	for rows.Next() {
+		device, err := r.scanKioskDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan kiosk device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate kiosk devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// Helper functions
+
+// scanKioskDevice scans a kiosk device from a row
+func (r *PostgresKioskDeviceRepository) scanKioskDevice(row interface{ Scan(...interface{}) error }) (*entities.KioskDevice, error) {
+	var device entities.KioskDevice
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&device.ID, &device.TenantID, &device.Name, &device.TokenHash, &device.IsActive, &revokedAt,
+		&device.CreatedAt, &device.UpdatedAt, &device.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		device.RevokedAt = &revokedAt.Time
+	}
+
+	return &device, nil
+}
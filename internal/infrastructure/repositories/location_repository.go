@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// locationColumns lists the columns of a location in the order scanLocation reads them
+const locationColumns = `id, tenant_id, code, name, address, is_active, created_at, updated_at`
+
+// locationStockColumns lists the columns of a location stock record in the order scanStock reads them
+const locationStockColumns = `id, tenant_id, location_id, product_id, quantity, updated_at`
+
+// PostgresLocationRepository implements the LocationRepository interface
+type PostgresLocationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLocationRepository creates a new PostgreSQL location repository
+func NewPostgresLocationRepository(db *sql.DB) repositories.LocationRepository {
+	return &PostgresLocationRepository{db: db}
+}
+
+// Create creates a new location
+func (r *PostgresLocationRepository) Create(ctx context.Context, location *entities.Location) error {
+	query := `
+		INSERT INTO locations (id, tenant_id, code, name, address, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		location.ID, location.TenantID, location.Code, location.Name, location.Address,
+		location.IsActive, location.CreatedAt, location.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("location with code '%s' already exists", location.Code))
+		}
+		return fmt.Errorf("failed to create location: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a location by ID
+func (r *PostgresLocationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Location, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + locationColumns + ` FROM locations WHERE id = $1` + scope
+
+	location, err := r.scanLocation(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("location")
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+
+	return location, nil
+}
+
+// Update updates a location
+func (r *PostgresLocationRepository) Update(ctx context.Context, location *entities.Location) error {
+	query := `
+		UPDATE locations SET name = $2, address = $3, is_active = $4, updated_at = $5
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		location.ID, location.Name, location.Address, location.IsActive, location.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("location")
+	}
+
+	return nil
+}
+
+// List retrieves the tenant's locations by code, the inactive ones only when asked for
+func (r *PostgresLocationRepository) List(ctx context.Context, includeInactive bool) ([]*entities.Location, error) {
+	condition := "is_active = TRUE"
+	if includeInactive {
+		condition = "1=1"
+	}
+	scope, args, err := tenantScope(ctx, "tenant_id", nil)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + locationColumns + ` FROM locations WHERE ` + condition + scope + ` ORDER BY code`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []*entities.Location
+	for rows.Next() {
+		location, err := r.scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// GetStock retrieves the stock of a product at a location
+func (r *PostgresLocationRepository) GetStock(ctx context.Context, locationID, productID uuid.UUID) (*entities.LocationStock, error) {
+	return r.getStock(ctx, locationID, productID, "")
+}
+
+// GetStockForUpdate retrieves the stock of a product at a location and locks it until the
+// transaction ends
+func (r *PostgresLocationRepository) GetStockForUpdate(ctx context.Context, locationID, productID uuid.UUID) (*entities.LocationStock, error) {
+	return r.getStock(ctx, locationID, productID, " FOR UPDATE")
+}
+
+// getStock retrieves the stock of a product at a location, appending the locking clause to
+// the query
+func (r *PostgresLocationRepository) getStock(ctx context.Context, locationID, productID uuid.UUID, lock string) (*entities.LocationStock, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{locationID, productID})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + locationStockColumns + ` FROM location_stock WHERE location_id = $1 AND product_id = $2` + scope + lock
+
+	stock, err := r.scanStock(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("location stock")
+		}
+		return nil, fmt.Errorf("failed to get location stock: %w", err)
+	}
+
+	return stock, nil
+}
+
+// SaveStock creates or updates the stock of a product at a location
+func (r *PostgresLocationRepository) SaveStock(ctx context.Context, stock *entities.LocationStock) error {
+	query := `
+		INSERT INTO location_stock (id, tenant_id, location_id, product_id, quantity, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (location_id, product_id) DO UPDATE SET
+			quantity = EXCLUDED.quantity,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		stock.ID, stock.TenantID, stock.LocationID, stock.ProductID, stock.Quantity, stock.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save location stock: %w", err)
+	}
+
+	return nil
+}
+
+// ListStock retrieves the stock held at a location with pagination, by product
+func (r *PostgresLocationRepository) ListStock(ctx context.Context, locationID uuid.UUID, pagination utils.PaginationInfo) ([]*entities.LocationStock, utils.PaginationInfo, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{locationID})
+	if err != nil {
+		return nil, utils.PaginationInfo{}, err
+	}
+	whereClause := "WHERE location_id = $1 AND quantity > 0" + scope
+
+	// Count total records
+	var total int
+	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM location_stock "+whereClause, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count location stock: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM location_stock
+		%s
+		ORDER BY product_id
+		LIMIT $%d OFFSET $%d`,
+		locationStockColumns, whereClause, len(args)+1, len(args)+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	stocks, err := r.queryStock(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+
+	return stocks, paginationResult, nil
+}
+
+// GetProductStock retrieves the stock of a product at each location holding it
+func (r *PostgresLocationRepository) GetProductStock(ctx context.Context, productID uuid.UUID) ([]*entities.LocationStock, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{productID})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + locationStockColumns + ` FROM location_stock WHERE product_id = $1 AND quantity > 0` + scope
+
+	return r.queryStock(ctx, query, args...)
+}
+
+// Helper functions
+
+// queryStock runs a query selecting locationStockColumns
+func (r *PostgresLocationRepository) queryStock(ctx context.Context, query string, args ...interface{}) ([]*entities.LocationStock, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query location stock: %w", err)
+	}
+	defer rows.Close()
+
+	var stocks []*entities.LocationStock
+	for rows.Next() {
+		stock, err := r.scanStock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location stock: %w", err)
+		}
+		stocks = append(stocks, stock)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate location stock: %w", err)
+	}
+
+	return stocks, nil
+}
+
+// scanLocation scans a location from a row
+func (r *PostgresLocationRepository) scanLocation(row interface{ Scan(...interface{}) error }) (*entities.Location, error) {
+	var location entities.Location
+	var address sql.NullString
+
+	err := row.Scan(&location.ID, &location.TenantID, &location.Code, &location.Name, &address,
+		&location.IsActive, &location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	location.Address = address.String
+	return &location, nil
+}
+
+// scanStock scans a location stock record from a row
+func (r *PostgresLocationRepository) scanStock(row interface{ Scan(...interface{}) error }) (*entities.LocationStock, error) {
+	var stock entities.LocationStock
+	err := row.Scan(&stock.ID, &stock.TenantID, &stock.LocationID, &stock.ProductID, &stock.Quantity, &stock.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stock, nil
+}
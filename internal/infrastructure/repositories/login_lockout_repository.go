@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// loginLockoutColumns lists the login lockout columns in the order scanLoginLockout reads them
+const loginLockoutColumns = `user_id, tenant_id, failed_attempts, lockouts, locked_until, last_failed_at,
+			last_failed_ip, updated_at`
+
+// PostgresLoginLockoutRepository implements the LoginLockoutRepository interface
+type PostgresLoginLockoutRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLoginLockoutRepository creates a new PostgreSQL login lockout repository
+func NewPostgresLoginLockoutRepository(db *sql.DB) repositories.LoginLockoutRepository {
+	return &PostgresLoginLockoutRepository{db: db}
+}
+
+// GetByUser retrieves the login lockout state of a user
+func (r *PostgresLoginLockoutRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*entities.LoginLockout, error) {
+	query := `
+		SELECT ` + loginLockoutColumns + `
+		FROM login_lockouts
+		WHERE user_id = $1`
+
+	lockout, err := r.scanLoginLockout(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("login lockout")
+		}
+		return nil, fmt.Errorf("failed to get login lockout: %w", err)
+	}
+
+	return lockout, nil
+}
+
+// RecordFailure atomically counts a failed login of a user, starting the count over when the
+// previous failure is older than the window, and returns the updated state
+func (r *PostgresLoginLockoutRepository) RecordFailure(ctx context.Context, userID, tenantID uuid.UUID, ip string, at time.Time, window time.Duration) (*entities.LoginLockout, error) {
+	query := `
+		INSERT INTO login_lockouts (user_id, tenant_id, failed_attempts, lockouts, last_failed_at,
+			last_failed_ip, updated_at)
+		VALUES ($1, $2, 1, 0, $3, $4, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			failed_attempts = CASE
+				WHEN login_lockouts.last_failed_at > $3 - $5 * INTERVAL '1 second'
+				THEN login_lockouts.failed_attempts + 1
+				ELSE 1
+			END,
+			last_failed_at = $3,
+			last_failed_ip = $4,
+			updated_at = $3
+		RETURNING ` + loginLockoutColumns
+
+	lockout, err := r.scanLoginLockout(r.db.QueryRowContext(ctx, query, userID, tenantID, at, ip, window.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	return lockout, nil
+}
+
+// Save saves the login lockout state of a user
+func (r *PostgresLoginLockoutRepository) Save(ctx context.Context, lockout *entities.LoginLockout) error {
+	query := `
+		INSERT INTO login_lockouts (user_id, tenant_id, failed_attempts, lockouts, locked_until,
+			last_failed_at, last_failed_ip, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			failed_attempts = EXCLUDED.failed_attempts,
+			lockouts = EXCLUDED.lockouts,
+			locked_until = EXCLUDED.locked_until,
+			last_failed_at = EXCLUDED.last_failed_at,
+			last_failed_ip = EXCLUDED.last_failed_ip,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		lockout.UserID, lockout.TenantID, lockout.FailedAttempts, lockout.Lockouts, lockout.LockedUntil,
+		lockout.LastFailedAt, lockout.LastFailedIP, lockout.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save login lockout: %w", err)
+	}
+
+	return nil
+}
+
+// Reset clears the failed logins and lockouts of a user
+func (r *PostgresLoginLockoutRepository) Reset(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM login_lockouts WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to reset login lockout: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// scanLoginLockout scans a login lockout from a row
+func (r *PostgresLoginLockoutRepository) scanLoginLockout(row interface{ Scan(...interface{}) error }) (*entities.LoginLockout, error) {
+	var lockout entities.LoginLockout
+	var lockedUntil sql.NullTime
+
+	err := row.Scan(
+		&lockout.UserID, &lockout.TenantID, &lockout.FailedAttempts, &lockout.Lockouts, &lockedUntil,
+		&lockout.LastFailedAt, &lockout.LastFailedIP, &lockout.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if lockedUntil.Valid {
+		lockout.LockedUntil = &lockedUntil.Time
+	}
+
+	return &lockout, nil
+}
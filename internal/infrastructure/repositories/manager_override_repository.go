@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresManagerOverrideRepository implements the ManagerOverrideRepository interface
+type PostgresManagerOverrideRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresManagerOverrideRepository creates a new PostgreSQL manager override repository
+func NewPostgresManagerOverrideRepository(db *sql.DB) repositories.ManagerOverrideRepository {
+	return &PostgresManagerOverrideRepository{db: db}
+}
+
+// Create creates a new manager override
+func (r *PostgresManagerOverrideRepository) Create(ctx context.Context, override *entities.ManagerOverride) error {
+	query := `
+		INSERT INTO manager_overrides (id, tenant_id, action, resource_id, token_hash, approved_by,
+			requested_by, reason, expires_at, consumed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		override.ID, override.TenantID, override.Action, override.ResourceID, override.TokenHash, override.ApprovedBy,
+		override.RequestedBy, override.Reason, override.ExpiresAt, override.ConsumedAt, override.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert manager override: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash retrieves a manager override by the hash of its token
+func (r *PostgresManagerOverrideRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.ManagerOverride, error) {
+	query := `
+		SELECT id, tenant_id, action, resource_id, token_hash, approved_by, requested_by, reason,
+			expires_at, consumed_at, created_at
+		FROM manager_overrides
+		WHERE token_hash = $1`
+
+	override, err := r.scanManagerOverride(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("manager override")
+		}
+		return nil, fmt.Errorf("failed to get manager override by token: %w", err)
+	}
+
+	return override, nil
+}
+
+// MarkConsumed records that an override was used
+func (r *PostgresManagerOverrideRepository) MarkConsumed(ctx context.Context, id uuid.UUID, consumedAt time.Time) error {
+	query := `
+		UPDATE manager_overrides SET consumed_at = $2
+		WHERE id = $1 AND consumed_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, consumedAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark manager override consumed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewConflictError("manager override has already been used")
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// scanManagerOverride scans a manager override from a row
+func (r *PostgresManagerOverrideRepository) scanManagerOverride(row interface{ Scan(...interface{}) error }) (*entities.ManagerOverride, error) {
+	var override entities.ManagerOverride
+	var resourceID uuid.NullUUID
+	var consumedAt sql.NullTime
+
+	err := row.Scan(
+		&override.ID, &override.TenantID, &override.Action, &resourceID, &override.TokenHash, &override.ApprovedBy,
+		&override.RequestedBy, &override.Reason, &override.ExpiresAt, &consumedAt, &override.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if resourceID.Valid {
+		override.ResourceID = &resourceID.UUID
+	}
+	if consumedAt.Valid {
+		override.ConsumedAt = &consumedAt.Time
+	}
+
+	return &override, nil
+}
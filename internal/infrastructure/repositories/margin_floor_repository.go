@@ -0,0 +1,338 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresMarginFloorRepository implements the MarginFloorRepository interface
+type PostgresMarginFloorRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMarginFloorRepository creates a new PostgreSQL margin floor repository
+func NewPostgresMarginFloorRepository(db *sql.DB) repositories.MarginFloorRepository {
+	return &PostgresMarginFloorRepository{db: db}
+}
+
+// Save creates or updates the margin floor of a category
+func (r *PostgresMarginFloorRepository) Save(ctx context.Context, floor *entities.MarginFloor) error {
+	query := `
+		INSERT INTO margin_floors (id, tenant_id, category, min_margin_percent, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, LOWER(category)) DO UPDATE SET
+			category = EXCLUDED.category,
+			min_margin_percent = EXCLUDED.min_margin_percent,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		floor.ID, floor.TenantID, floor.Category, floor.MinMarginPercent, floor.CreatedAt, floor.UpdatedAt, floor.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save margin floor: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTenantAndCategory retrieves the margin floor of a category, matched case-insensitively
+func (r *PostgresMarginFloorRepository) GetByTenantAndCategory(ctx context.Context, tenantID uuid.UUID, category string) (*entities.MarginFloor, error) {
+	query := `
+		SELECT id, tenant_id, category, min_margin_percent, created_at, updated_at, updated_by
+		FROM margin_floors
+		WHERE tenant_id = $1 AND LOWER(category) = LOWER($2)`
+
+	floor, err := r.scanMarginFloor(r.db.QueryRowContext(ctx, query, tenantID, category))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("margin floor")
+		}
+		return nil, fmt.Errorf("failed to get margin floor: %w", err)
+	}
+
+	return floor, nil
+}
+
+// GetByTenant retrieves all margin floors of a tenant
+func (r *PostgresMarginFloorRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.MarginFloor, error) {
+	query := `
+		SELECT id, tenant_id, category, min_margin_percent, created_at, updated_at, updated_by
+		FROM margin_floors
+		WHERE tenant_id = $1
+		ORDER BY category`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query margin floors: %w", err)
+	}
+	defer rows.Close()
+
+	var floors []*entities.MarginFloor
+	for rows.Next() {
+		floor, err := r.scanMarginFloor(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan margin floor: %w", err)
+		}
+		floors = append(floors, floor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate margin floors: %w", err)
+	}
+
+	return floors, nil
+}
+
+// Delete deletes a margin floor
+func (r *PostgresMarginFloorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM margin_floors WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete margin floor: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("margin floor")
+	}
+
+	return nil
+}
+
+// CreateViolations records margin floor violations
+func (r *PostgresMarginFloorRepository) CreateViolations(ctx context.Context, violations []*entities.MarginViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO margin_violations (id, tenant_id, source, product_id, product_sku, product_name, category,
+			sale_id, unit_price, unit_cost, margin_percent, floor_percent, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	for _, violation := range violations {
+		_, err := tx.ExecContext(ctx, query,
+			violation.ID, violation.TenantID, violation.Source, violation.ProductID, violation.ProductSKU,
+			violation.ProductName, violation.Category, violation.SaleID, violation.UnitPrice, violation.UnitCost,
+			violation.MarginPercent, violation.FloorPercent, violation.UserID, violation.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert margin violation: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetViolations retrieves the violations of a tenant recorded in [from, to), newest first
+func (r *PostgresMarginFloorRepository) GetViolations(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*entities.MarginViolation, error) {
+	query := `
+		SELECT id, tenant_id, source, product_id, product_sku, product_name, category, sale_id,
+			unit_price, unit_cost, margin_percent, floor_percent, user_id, created_at
+		FROM margin_violations
+		WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query margin violations: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []*entities.MarginViolation
+	for rows.Next() {
+		var violation entities.MarginViolation
+		var saleID uuid.NullUUID
+		err := rows.Scan(
+			&violation.ID, &violation.TenantID, &violation.Source, &violation.ProductID, &violation.ProductSKU,
+			&violation.ProductName, &violation.Category, &saleID, &violation.UnitPrice, &violation.UnitCost,
+			&violation.MarginPercent, &violation.FloorPercent, &violation.UserID, &violation.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan margin violation: %w", err)
+		}
+		if saleID.Valid {
+			violation.SaleID = &saleID.UUID
+		}
+		violations = append(violations, &violation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate margin violations: %w", err)
+	}
+
+	return violations, nil
+}
+
+// GetAlertSetting retrieves the margin alert setting of a tenant
+func (r *PostgresMarginFloorRepository) GetAlertSetting(ctx context.Context, tenantID uuid.UUID) (*entities.MarginAlertSetting, error) {
+	query := `
+		SELECT tenant_id, recipients, alert_on_violation, weekly_report, last_report_week, updated_at, updated_by
+		FROM margin_alert_settings
+		WHERE tenant_id = $1`
+
+	setting, err := r.scanMarginAlertSetting(r.db.QueryRowContext(ctx, query, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("margin alert setting")
+		}
+		return nil, fmt.Errorf("failed to get margin alert setting: %w", err)
+	}
+
+	return setting, nil
+}
+
+// SaveAlertSetting creates or updates the margin alert setting of a tenant
+func (r *PostgresMarginFloorRepository) SaveAlertSetting(ctx context.Context, setting *entities.MarginAlertSetting) error {
+	query := `
+		INSERT INTO margin_alert_settings (tenant_id, recipients, alert_on_violation, weekly_report,
+			last_report_week, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			recipients = EXCLUDED.recipients,
+			alert_on_violation = EXCLUDED.alert_on_violation,
+			weekly_report = EXCLUDED.weekly_report,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		setting.TenantID, pq.Array(setting.Recipients), setting.AlertOnViolation, setting.WeeklyReport,
+		setting.LastReportWeek, setting.UpdatedAt, setting.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save margin alert setting: %w", err)
+	}
+
+	return nil
+}
+
+// ListWeeklyReportSettings retrieves the alert settings of tenants that receive the weekly report
+func (r *PostgresMarginFloorRepository) ListWeeklyReportSettings(ctx context.Context) ([]*entities.MarginAlertSetting, error) {
+	query := `
+		SELECT tenant_id, recipients, alert_on_violation, weekly_report, last_report_week, updated_at, updated_by
+		FROM margin_alert_settings
+		WHERE weekly_report = true
+		ORDER BY tenant_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query margin alert settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []*entities.MarginAlertSetting
+	for rows.Next() {
+		setting, err := r.scanMarginAlertSetting(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan margin alert setting: %w", err)
+		}
+		settings = append(settings, setting)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate margin alert settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// ClaimWeeklyReport atomically marks the report for a week as being sent
+func (r *PostgresMarginFloorRepository) ClaimWeeklyReport(ctx context.Context, tenantID uuid.UUID, week time.Time) (bool, error) {
+	query := `
+		UPDATE margin_alert_settings
+		SET last_report_week = $2
+		WHERE tenant_id = $1 AND weekly_report = true AND (last_report_week IS NULL OR last_report_week < $2)`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, week.Format("2006-01-02"))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim weekly margin report: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// ReleaseWeeklyReport restores the previous report week after a failed send so it is retried
+func (r *PostgresMarginFloorRepository) ReleaseWeeklyReport(ctx context.Context, tenantID uuid.UUID, week time.Time, previous *time.Time) error {
+	var previousWeek interface{}
+	if previous != nil {
+		previousWeek = previous.Format("2006-01-02")
+	}
+
+	query := `
+		UPDATE margin_alert_settings
+		SET last_report_week = $3
+		WHERE tenant_id = $1 AND last_report_week = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, tenantID, week.Format("2006-01-02"), previousWeek); err != nil {
+		return fmt.Errorf("failed to release weekly margin report: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// scanMarginFloor scans a margin floor from a row
+func (r *PostgresMarginFloorRepository) scanMarginFloor(row interface{ Scan(...interface{}) error }) (*entities.MarginFloor, error) {
+	var floor entities.MarginFloor
+
+	err := row.Scan(&floor.ID, &floor.TenantID, &floor.Category, &floor.MinMarginPercent,
+		&floor.CreatedAt, &floor.UpdatedAt, &floor.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &floor, nil
+}
+
+// scanMarginAlertSetting scans a margin alert setting from a row
+func (r *PostgresMarginFloorRepository) scanMarginAlertSetting(row interface{ Scan(...interface{}) error }) (*entities.MarginAlertSetting, error) {
+	var setting entities.MarginAlertSetting
+	var recipients pq.StringArray
+	var lastReportWeek sql.NullTime
+
+	err := row.Scan(&setting.TenantID, &recipients, &setting.AlertOnViolation, &setting.WeeklyReport,
+		&lastReportWeek, &setting.UpdatedAt, &setting.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	setting.Recipients = []string(recipients)
+	if setting.Recipients == nil {
+		setting.Recipients = []string{}
+	}
+	if lastReportWeek.Valid {
+		// Report weeks start at midnight UTC
+		date := lastReportWeek.Time
+		week := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		setting.LastReportWeek = &week
+	}
+
+	return &setting, nil
+}
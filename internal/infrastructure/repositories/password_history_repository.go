@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+)
+
+// PostgresPasswordHistoryRepository implements the PasswordHistoryRepository interface
+type PostgresPasswordHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPasswordHistoryRepository creates a new PostgreSQL password history repository
+func NewPostgresPasswordHistoryRepository(db *sql.DB) repositories.PasswordHistoryRepository {
+	return &PostgresPasswordHistoryRepository{db: db}
+}
+
+// Create records a previous password of a user
+func (r *PostgresPasswordHistoryRepository) Create(ctx context.Context, entry *entities.PasswordHistoryEntry) error {
+	query := `
+		INSERT INTO password_history (id, tenant_id, user_id, password_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.ExecContext(ctx, query, entry.ID, entry.TenantID, entry.UserID, entry.PasswordHash, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert password history: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecentHashesByUser retrieves the hashes of a user's most recent passwords, newest first
+func (r *PostgresPasswordHistoryRepository) GetRecentHashesByUser(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query password history: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan password history: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate password history: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// PruneByUser deletes all but the given number of a user's most recent passwords
+func (r *PostgresPasswordHistoryRepository) PruneByUser(ctx context.Context, userID uuid.UUID, keep int) error {
+	query := `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC
+			LIMIT $2
+		)`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, keep); err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresPaymentSurchargeRuleRepository implements the PaymentSurchargeRuleRepository interface
+type PostgresPaymentSurchargeRuleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPaymentSurchargeRuleRepository creates a new PostgreSQL payment surcharge rule repository
+func NewPostgresPaymentSurchargeRuleRepository(db *sql.DB) repositories.PaymentSurchargeRuleRepository {
+	return &PostgresPaymentSurchargeRuleRepository{db: db}
+}
+
+// Create creates a new payment surcharge rule
+func (r *PostgresPaymentSurchargeRuleRepository) Create(ctx context.Context, rule *entities.PaymentSurchargeRule) error {
+	query := `
+		INSERT INTO payment_surcharge_rules (id, tenant_id, payment_method, type, value, min_amount,
+			max_amount, label, is_taxable, is_active, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		rule.ID, rule.TenantID, rule.PaymentMethod, rule.Type, rule.Value, rule.MinAmount,
+		rule.MaxAmount, rule.Label, rule.IsTaxable, rule.IsActive, rule.CreatedAt, rule.UpdatedAt, rule.CreatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("surcharge rule for payment method '%s' already exists", rule.PaymentMethod))
+		}
+		return fmt.Errorf("failed to insert payment surcharge rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a payment surcharge rule by ID
+func (r *PostgresPaymentSurchargeRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentSurchargeRule, error) {
+	query := `
+		SELECT id, tenant_id, payment_method, type, value, min_amount,
+			max_amount, label, is_taxable, is_active, created_at, updated_at, created_by
+		FROM payment_surcharge_rules 
+		WHERE id = $1`
+
+	rule, err := r.scanPaymentSurchargeRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("payment surcharge rule")
+		}
+		return nil, fmt.Errorf("failed to get payment surcharge rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Update updates an existing payment surcharge rule
+func (r *PostgresPaymentSurchargeRuleRepository) Update(ctx context.Context, rule *entities.PaymentSurchargeRule) error {
+	query := `
+		UPDATE payment_surcharge_rules SET 
+			type = $2, value = $3, min_amount = $4, max_amount = $5, label = $6,
+			is_taxable = $7, is_active = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID, rule.Type, rule.Value, rule.MinAmount, rule.MaxAmount, rule.Label,
+		rule.IsTaxable, rule.IsActive, rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update payment surcharge rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("payment surcharge rule")
+	}
+
+	return nil
+}
+
+// Delete deletes a payment surcharge rule
+func (r *PostgresPaymentSurchargeRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM payment_surcharge_rules WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete payment surcharge rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("payment surcharge rule")
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves all payment surcharge rules for a tenant
+func (r *PostgresPaymentSurchargeRuleRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.PaymentSurchargeRule, error) {
+	query := `
+		SELECT id, tenant_id, payment_method, type, value, min_amount,
+			max_amount, label, is_taxable, is_active, created_at, updated_at, created_by
+		FROM payment_surcharge_rules 
+		WHERE tenant_id = $1
+		ORDER BY payment_method`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment surcharge rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.PaymentSurchargeRule
+	for rows.Next() {
+		rule, err := r.scanPaymentSurchargeRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment surcharge rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payment surcharge rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// GetActiveByPaymentMethod retrieves a tenant's active rule for a payment method
+func (r *PostgresPaymentSurchargeRuleRepository) GetActiveByPaymentMethod(ctx context.Context, tenantID uuid.UUID, paymentMethod entities.PaymentMethod) (*entities.PaymentSurchargeRule, error) {
+	query := `
+		SELECT id, tenant_id, payment_method, type, value, min_amount,
+			max_amount, label, is_taxable, is_active, created_at, updated_at, created_by
+		FROM payment_surcharge_rules 
+		WHERE tenant_id = $1 AND payment_method = $2 AND is_active = true`
+
+	rule, err := r.scanPaymentSurchargeRule(r.db.QueryRowContext(ctx, query, tenantID, paymentMethod))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("payment surcharge rule")
+		}
+		return nil, fmt.Errorf("failed to get payment surcharge rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Helper functions
+
+// scanPaymentSurchargeRule scans a payment surcharge rule from a row
+func (r *PostgresPaymentSurchargeRuleRepository) scanPaymentSurchargeRule(row interface{ Scan(...interface{}) error }) (*entities.PaymentSurchargeRule, error) {
+	var rule entities.PaymentSurchargeRule
+
+	err := row.Scan(
+		&rule.ID, &rule.TenantID, &rule.PaymentMethod, &rule.Type, &rule.Value, &rule.MinAmount,
+		&rule.MaxAmount, &rule.Label, &rule.IsTaxable, &rule.IsActive, &rule.CreatedAt, &rule.UpdatedAt, &rule.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PostgresPaymentTransactionRepository implements the PaymentTransactionRepository interface
+type PostgresPaymentTransactionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPaymentTransactionRepository creates a new PostgreSQL payment transaction repository
+func NewPostgresPaymentTransactionRepository(db *sql.DB) repositories.PaymentTransactionRepository {
+	return &PostgresPaymentTransactionRepository{db: db}
+}
+
+const paymentTransactionColumns = `id, tenant_id, provider, sale_id, invoice_id, payment_method, amount, currency,
+			discount_amount, COALESCE(coupon_code, ''), tax_percentage, COALESCE(external_id, ''),
+			COALESCE(client_secret, ''), COALESCE(redirect_url, ''), status, COALESCE(gateway_status, ''),
+			COALESCE(error, ''), succeeded_at, created_by, created_at, updated_at`
+
+// Create creates a new payment transaction
+func (r *PostgresPaymentTransactionRepository) Create(ctx context.Context, transaction *entities.PaymentTransaction) error {
+	query := `
+		INSERT INTO payment_transactions (id, tenant_id, provider, sale_id, invoice_id, payment_method, amount, currency,
+			discount_amount, coupon_code, tax_percentage, external_id, client_secret, redirect_url, status,
+			gateway_status, error, succeeded_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, NULLIF($12, ''), NULLIF($13, ''),
+			NULLIF($14, ''), $15, NULLIF($16, ''), NULLIF($17, ''), $18, $19, $20, $21)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		transaction.ID, transaction.TenantID, transaction.Provider, transaction.SaleID, transaction.InvoiceID,
+		transaction.PaymentMethod, transaction.Amount, transaction.Currency, transaction.DiscountAmount,
+		transaction.CouponCode, transaction.TaxPercentage, transaction.ExternalID, transaction.ClientSecret,
+		transaction.RedirectURL, transaction.Status, transaction.GatewayStatus, transaction.Error,
+		transaction.SucceededAt, transaction.CreatedBy, transaction.CreatedAt, transaction.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("payment transaction already exists")
+		}
+		return fmt.Errorf("failed to insert payment transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a payment transaction by ID
+func (r *PostgresPaymentTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentTransaction, error) {
+	query := `
+		SELECT ` + paymentTransactionColumns + `
+		FROM payment_transactions
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := r.scanTransaction(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("payment transaction")
+		}
+		return nil, fmt.Errorf("failed to get payment transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// GetByExternalID retrieves a payment transaction by its reference on the gateway
+func (r *PostgresPaymentTransactionRepository) GetByExternalID(ctx context.Context, provider entities.PaymentGatewayProvider, externalID string) (*entities.PaymentTransaction, error) {
+	query := `
+		SELECT ` + paymentTransactionColumns + `
+		FROM payment_transactions
+		WHERE provider = $1 AND external_id = $2`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{provider, externalID})
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := r.scanTransaction(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("payment transaction")
+		}
+		return nil, fmt.Errorf("failed to get payment transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// Update updates a payment transaction's gateway reference, status and error
+func (r *PostgresPaymentTransactionRepository) Update(ctx context.Context, transaction *entities.PaymentTransaction) error {
+	query := `
+		UPDATE payment_transactions
+		SET external_id = NULLIF($2, ''), client_secret = NULLIF($3, ''), redirect_url = NULLIF($4, ''),
+			status = $5, gateway_status = NULLIF($6, ''), error = NULLIF($7, ''), succeeded_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		transaction.ID, transaction.ExternalID, transaction.ClientSecret, transaction.RedirectURL, transaction.Status,
+		transaction.GatewayStatus, transaction.Error, transaction.SucceededAt, transaction.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("payment transaction already exists")
+		}
+		return fmt.Errorf("failed to update payment transaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("payment transaction")
+	}
+
+	return nil
+}
+
+// List retrieves payment transactions with pagination and filtering, newest first
+func (r *PostgresPaymentTransactionRepository) List(ctx context.Context, filter repositories.PaymentTransactionFilter, pagination utils.PaginationInfo) ([]*entities.PaymentTransaction, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	var conditions []string
+	var args []interface{}
+	argCount := 0
+
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	}
+
+	if filter.SaleID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("sale_id = $%d", argCount))
+		args = append(args, *filter.SaleID)
+	}
+
+	if filter.InvoiceID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("invoice_id = $%d", argCount))
+		args = append(args, *filter.InvoiceID)
+	}
+
+	if filter.Provider != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("provider = $%d", argCount))
+		args = append(args, *filter.Provider)
+	}
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM payment_transactions %s", whereClause)
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, pagination, fmt.Errorf("failed to count payment transactions: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM payment_transactions
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		paymentTransactionColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query payment transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []*entities.PaymentTransaction{}
+	for rows.Next() {
+		transaction, err := r.scanTransaction(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan payment transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate payment transactions: %w", err)
+	}
+
+	return transactions, paginationResult, nil
+}
+
+// scanTransaction scans a payment transaction row
+func (r *PostgresPaymentTransactionRepository) scanTransaction(row interface{ Scan(...interface{}) error }) (*entities.PaymentTransaction, error) {
+	var transaction entities.PaymentTransaction
+	err := row.Scan(
+		&transaction.ID, &transaction.TenantID, &transaction.Provider, &transaction.SaleID, &transaction.InvoiceID,
+		&transaction.PaymentMethod, &transaction.Amount, &transaction.Currency, &transaction.DiscountAmount,
+		&transaction.CouponCode, &transaction.TaxPercentage, &transaction.ExternalID, &transaction.ClientSecret,
+		&transaction.RedirectURL, &transaction.Status, &transaction.GatewayStatus, &transaction.Error,
+		&transaction.SucceededAt, &transaction.CreatedBy, &transaction.CreatedAt, &transaction.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
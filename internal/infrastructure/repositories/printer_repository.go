@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresPrinterRepository implements the PrinterRepository interface
+type PostgresPrinterRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPrinterRepository creates a new PostgreSQL printer repository
+func NewPostgresPrinterRepository(db *sql.DB) repositories.PrinterRepository {
+	return &PostgresPrinterRepository{db: db}
+}
+
+const printerColumns = `id, tenant_id, name, COALESCE(description, ''), COALESCE(terminal, ''), is_thermal, is_default,
+			status, last_tested_at, COALESCE(last_test_error, ''), created_by, created_at, updated_at`
+
+// Create registers a new printer
+func (r *PostgresPrinterRepository) Create(ctx context.Context, printer *entities.Printer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if printer.IsDefault {
+		clearQuery := `
+			UPDATE printers SET is_default = false
+			WHERE tenant_id = $1 AND COALESCE(terminal, '') = $2 AND is_thermal = $3 AND is_default`
+
+		if _, err := tx.ExecContext(ctx, clearQuery, printer.TenantID, printer.Terminal, printer.IsThermal); err != nil {
+			return fmt.Errorf("failed to clear default printer: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO printers (id, tenant_id, name, description, terminal, is_thermal, is_default,
+			status, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10, $11)`
+
+	_, err = tx.ExecContext(ctx, query,
+		printer.ID, printer.TenantID, printer.Name, printer.Description, printer.Terminal, printer.IsThermal,
+		printer.IsDefault, printer.Status, printer.CreatedBy, printer.CreatedAt, printer.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("printer '%s' is already registered", printer.Name))
+		}
+		return fmt.Errorf("failed to insert printer: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a printer by ID
+func (r *PostgresPrinterRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Printer, error) {
+	query := `
+		SELECT ` + printerColumns + `
+		FROM printers
+		WHERE id = $1`
+
+	printer, err := r.scanPrinter(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("printer")
+		}
+		return nil, fmt.Errorf("failed to get printer: %w", err)
+	}
+
+	return printer, nil
+}
+
+// GetByTenant retrieves the printers of a tenant, optionally limited to a terminal
+func (r *PostgresPrinterRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID, terminal string) ([]*entities.Printer, error) {
+	query := `
+		SELECT ` + printerColumns + `
+		FROM printers
+		WHERE tenant_id = $1 AND ($2 = '' OR terminal = $2 OR terminal IS NULL)
+		ORDER BY terminal NULLS FIRST, is_thermal DESC, is_default DESC, name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, terminal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query printers: %w", err)
+	}
+	defer rows.Close()
+
+	printers := []*entities.Printer{}
+	for rows.Next() {
+		printer, err := r.scanPrinter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan printer: %w", err)
+		}
+		printers = append(printers, printer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate printers: %w", err)
+	}
+
+	return printers, nil
+}
+
+// FindForTerminal retrieves the printer of a kind a terminal prints to
+func (r *PostgresPrinterRepository) FindForTerminal(ctx context.Context, tenantID uuid.UUID, terminal string, isThermal bool) (*entities.Printer, error) {
+	query := `
+		SELECT ` + printerColumns + `
+		FROM printers
+		WHERE tenant_id = $1 AND is_thermal = $3 AND (terminal = NULLIF($2, '') OR terminal IS NULL)
+		ORDER BY terminal NULLS LAST, is_default DESC, name
+		LIMIT 1`
+
+	printer, err := r.scanPrinter(r.db.QueryRowContext(ctx, query, tenantID, terminal, isThermal))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("printer")
+		}
+		return nil, fmt.Errorf("failed to find printer: %w", err)
+	}
+
+	return printer, nil
+}
+
+// UpdateStatus updates the test print status of a printer
+func (r *PostgresPrinterRepository) UpdateStatus(ctx context.Context, printer *entities.Printer) error {
+	query := `
+		UPDATE printers SET status = $2, last_tested_at = $3, last_test_error = NULLIF($4, ''), updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		printer.ID, printer.Status, printer.LastTestedAt, printer.LastTestError, printer.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update printer status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("printer")
+	}
+
+	return nil
+}
+
+// Delete deletes a printer
+func (r *PostgresPrinterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM printers WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete printer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("printer")
+	}
+
+	return nil
+}
+
+// scanPrinter scans a printer from a row
+func (r *PostgresPrinterRepository) scanPrinter(row interface{ Scan(...interface{}) error }) (*entities.Printer, error) {
+	var printer entities.Printer
+
+	err := row.Scan(&printer.ID, &printer.TenantID, &printer.Name, &printer.Description, &printer.Terminal,
+		&printer.IsThermal, &printer.IsDefault, &printer.Status, &printer.LastTestedAt, &printer.LastTestError,
+		&printer.CreatedBy, &printer.CreatedAt, &printer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &printer, nil
+}
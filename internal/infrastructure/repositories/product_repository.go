@@ -13,6 +13,7 @@ import (
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/infrastructure/database"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/utils"
 )
@@ -32,8 +33,11 @@ func NewPostgreSQLProductRepository(db *sql.DB) repositories.ProductRepository {
 // Create creates a new product
 func (r *PostgreSQLProductRepository) Create(ctx context.Context, product *entities.Product) error {
 	query := `
-		INSERT INTO products (id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, created_at, updated_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+		INSERT INTO products (id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock,
+			barcode, promo_price, promo_starts_at, promo_ends_at, publish_state, publish_at, published_at, submitted_by,
+			reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25,
+			$26, $27, $28, $29, $30, $31, $32)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		product.ID,
@@ -47,6 +51,24 @@ func (r *PostgreSQLProductRepository) Create(ctx context.Context, product *entit
 		product.Status,
 		product.Unit,
 		product.MinStock,
+		product.Barcode,
+		product.PromoPrice,
+		product.PromoStartsAt,
+		product.PromoEndsAt,
+		product.PublishState,
+		product.PublishAt,
+		product.PublishedAt,
+		product.SubmittedBy,
+		product.ReviewedBy,
+		product.ReviewNotes,
+		product.SupplierID,
+		product.CategoryID,
+		product.TaxRateID,
+		product.Currency,
+		product.AvailableFrom,
+		product.AvailableUntil,
+		product.IsSeasonal,
+		product.IsSerialized,
 		product.CreatedAt,
 		product.UpdatedAt,
 		product.CreatedBy,
@@ -59,6 +81,9 @@ func (r *PostgreSQLProductRepository) Create(ctx context.Context, product *entit
 				if strings.Contains(pqErr.Detail, "sku") {
 					return errors.NewConflictError("SKU already exists")
 				}
+				if strings.Contains(pqErr.Detail, "barcode") {
+					return errors.NewConflictError("barcode already exists")
+				}
 				return errors.NewConflictError("product already exists")
 			}
 		}
@@ -70,15 +95,23 @@ func (r *PostgreSQLProductRepository) Create(ctx context.Context, product *entit
 
 // GetByID retrieves a product by ID
 func (r *PostgreSQLProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
-		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, created_at, updated_at, created_by
+		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
 		FROM products 
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	product := &entities.Product{}
 	var priceStr, costStr string
+	var promoPrice sql.NullString
+	var promoStartsAt, promoEndsAt sql.NullTime
+	var publishAt, publishedAt sql.NullTime
+	var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+	var availableFrom, availableUntil sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&product.ID,
 		&product.TenantID,
 		&product.SKU,
@@ -90,6 +123,24 @@ func (r *PostgreSQLProductRepository) GetByID(ctx context.Context, id uuid.UUID)
 		&product.Status,
 		&product.Unit,
 		&product.MinStock,
+		&product.Barcode,
+		&promoPrice,
+		&promoStartsAt,
+		&promoEndsAt,
+		&product.PublishState,
+		&publishAt,
+		&publishedAt,
+		&submittedBy,
+		&reviewedBy,
+		&product.ReviewNotes,
+		&supplierID,
+		&categoryID,
+		&taxRateID,
+		&product.Currency,
+		&availableFrom,
+		&availableUntil,
+		&product.IsSeasonal,
+		&product.IsSerialized,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 		&product.CreatedBy,
@@ -109,21 +160,146 @@ func (r *PostgreSQLProductRepository) GetByID(ctx context.Context, id uuid.UUID)
 	if product.Cost, err = decimal.NewFromString(costStr); err != nil {
 		return nil, fmt.Errorf("failed to parse cost: %w", err)
 	}
+	if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+		return nil, err
+	}
+	setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+	setProductAvailability(product, availableFrom, availableUntil)
+	if supplierID.Valid {
+		product.SupplierID = &supplierID.UUID
+	}
+	if categoryID.Valid {
+		product.CategoryID = &categoryID.UUID
+	}
+	if taxRateID.Valid {
+		product.TaxRateID = &taxRateID.UUID
+	}
 
 	return product, nil
 }
 
+// GetByIDs retrieves the products with the given IDs in one query, keyed by product ID.
+// Products that do not exist are left out.
+func (r *PostgreSQLProductRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Product, error) {
+	products := make(map[uuid.UUID]*entities.Product, len(ids))
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	productIDs := make([]string, len(ids))
+	for i, id := range ids {
+		productIDs[i] = id.String()
+	}
+
+	query := `
+		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
+		FROM products
+		WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product := &entities.Product{}
+		var priceStr, costStr string
+		var promoPrice sql.NullString
+		var promoStartsAt, promoEndsAt sql.NullTime
+		var publishAt, publishedAt sql.NullTime
+		var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+		var availableFrom, availableUntil sql.NullTime
+
+		err := rows.Scan(
+			&product.ID,
+			&product.TenantID,
+			&product.SKU,
+			&product.Name,
+			&product.Description,
+			&product.Category,
+			&priceStr,
+			&costStr,
+			&product.Status,
+			&product.Unit,
+			&product.MinStock,
+			&product.Barcode,
+			&promoPrice,
+			&promoStartsAt,
+			&promoEndsAt,
+			&product.PublishState,
+			&publishAt,
+			&publishedAt,
+			&submittedBy,
+			&reviewedBy,
+			&product.ReviewNotes,
+			&supplierID,
+			&categoryID,
+			&taxRateID,
+			&product.Currency,
+			&availableFrom,
+			&availableUntil,
+			&product.IsSeasonal,
+			&product.IsSerialized,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+
+		if product.Price, err = decimal.NewFromString(priceStr); err != nil {
+			return nil, fmt.Errorf("failed to parse price: %w", err)
+		}
+		if product.Cost, err = decimal.NewFromString(costStr); err != nil {
+			return nil, fmt.Errorf("failed to parse cost: %w", err)
+		}
+		if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+			return nil, err
+		}
+		setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+		setProductAvailability(product, availableFrom, availableUntil)
+		if supplierID.Valid {
+			product.SupplierID = &supplierID.UUID
+		}
+		if categoryID.Valid {
+			product.CategoryID = &categoryID.UUID
+		}
+		if taxRateID.Valid {
+			product.TaxRateID = &taxRateID.UUID
+		}
+
+		products[product.ID] = product
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	return products, nil
+}
+
 // GetBySKU retrieves a product by SKU
 func (r *PostgreSQLProductRepository) GetBySKU(ctx context.Context, sku string) (*entities.Product, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
-		SELECT id, sku, name, description, category, price, cost, status, unit, min_stock, created_at, updated_at, created_by
+		SELECT id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
 		FROM products 
 		WHERE sku = $1 AND deleted_at IS NULL`
 
 	product := &entities.Product{}
 	var priceStr, costStr string
+	var promoPrice sql.NullString
+	var promoStartsAt, promoEndsAt sql.NullTime
+	var publishAt, publishedAt sql.NullTime
+	var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+	var availableFrom, availableUntil sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, sku).Scan(
+	err := db.QueryRowContext(ctx, query, sku).Scan(
 		&product.ID,
 		&product.SKU,
 		&product.Name,
@@ -134,6 +310,24 @@ func (r *PostgreSQLProductRepository) GetBySKU(ctx context.Context, sku string)
 		&product.Status,
 		&product.Unit,
 		&product.MinStock,
+		&product.Barcode,
+		&promoPrice,
+		&promoStartsAt,
+		&promoEndsAt,
+		&product.PublishState,
+		&publishAt,
+		&publishedAt,
+		&submittedBy,
+		&reviewedBy,
+		&product.ReviewNotes,
+		&supplierID,
+		&categoryID,
+		&taxRateID,
+		&product.Currency,
+		&availableFrom,
+		&availableUntil,
+		&product.IsSeasonal,
+		&product.IsSerialized,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 		&product.CreatedBy,
@@ -153,6 +347,20 @@ func (r *PostgreSQLProductRepository) GetBySKU(ctx context.Context, sku string)
 	if product.Cost, err = decimal.NewFromString(costStr); err != nil {
 		return nil, fmt.Errorf("failed to parse cost: %w", err)
 	}
+	if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+		return nil, err
+	}
+	setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+	setProductAvailability(product, availableFrom, availableUntil)
+	if supplierID.Valid {
+		product.SupplierID = &supplierID.UUID
+	}
+	if categoryID.Valid {
+		product.CategoryID = &categoryID.UUID
+	}
+	if taxRateID.Valid {
+		product.TaxRateID = &taxRateID.UUID
+	}
 
 	return product, nil
 }
@@ -162,7 +370,11 @@ func (r *PostgreSQLProductRepository) Update(ctx context.Context, product *entit
 	query := `
 		UPDATE products 
 		SET sku = $2, name = $3, description = $4, category = $5, price = $6, cost = $7, 
-		    status = $8, unit = $9, min_stock = $10, updated_at = $11
+		    status = $8, unit = $9, min_stock = $10, barcode = $11, promo_price = $12,
+		    promo_starts_at = $13, promo_ends_at = $14, publish_state = $15, publish_at = $16,
+		    published_at = $17, submitted_by = $18, reviewed_by = $19, review_notes = $20, updated_at = $21,
+		    supplier_id = $22, available_from = $23, available_until = $24, is_seasonal = $25, category_id = $26,
+		    tax_rate_id = $27, currency = $28, is_serialized = $29
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -176,7 +388,25 @@ func (r *PostgreSQLProductRepository) Update(ctx context.Context, product *entit
 		product.Status,
 		product.Unit,
 		product.MinStock,
+		product.Barcode,
+		product.PromoPrice,
+		product.PromoStartsAt,
+		product.PromoEndsAt,
+		product.PublishState,
+		product.PublishAt,
+		product.PublishedAt,
+		product.SubmittedBy,
+		product.ReviewedBy,
+		product.ReviewNotes,
 		product.UpdatedAt,
+		product.SupplierID,
+		product.AvailableFrom,
+		product.AvailableUntil,
+		product.IsSeasonal,
+		product.CategoryID,
+		product.TaxRateID,
+		product.Currency,
+		product.IsSerialized,
 	)
 
 	if err != nil {
@@ -186,6 +416,9 @@ func (r *PostgreSQLProductRepository) Update(ctx context.Context, product *entit
 				if strings.Contains(pqErr.Detail, "sku") {
 					return errors.NewConflictError("SKU already exists")
 				}
+				if strings.Contains(pqErr.Detail, "barcode") {
+					return errors.NewConflictError("barcode already exists")
+				}
 				return errors.NewConflictError("product already exists")
 			}
 		}
@@ -230,40 +463,88 @@ func (r *PostgreSQLProductRepository) Delete(ctx context.Context, id uuid.UUID)
 
 // List retrieves products with pagination and filtering
 func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositories.ProductFilter, pagination utils.PaginationInfo) ([]*entities.Product, utils.PaginationInfo, error) {
+	items, resultPagination, err := r.ListWithStock(ctx, filter, pagination)
+	if err != nil {
+		return nil, pagination, err
+	}
+
+	var products []*entities.Product
+	for _, item := range items {
+		products = append(products, item.Product)
+	}
+
+	return products, resultPagination, nil
+}
+
+// ListWithStock retrieves products with pagination and filtering, along with their stock.
+// Stock is joined in the query so listings can be ordered by stock status or available
+// quantity without looking up each product's stock.
+func (r *PostgreSQLProductRepository) ListWithStock(ctx context.Context, filter repositories.ProductFilter, pagination utils.PaginationInfo) ([]*repositories.ProductWithStock, utils.PaginationInfo, error) {
+	db := database.Reader(ctx, r.db)
+
 	// Build WHERE clause
 	var whereConditions []string
 	var args []interface{}
 	argIndex := 1
 
-	whereConditions = append(whereConditions, "deleted_at IS NULL")
+	whereConditions = append(whereConditions, "p.deleted_at IS NULL")
+
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.tenant_id = $%d", argIndex))
+		args = append(args, tenantID)
+		argIndex++
+	}
 
 	if filter.Category != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("category = $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("p.category = $%d", argIndex))
 		args = append(args, filter.Category)
 		argIndex++
 	}
 
+	if filter.CategoryID != nil {
+		subtree := fmt.Sprintf(categorySubtreeQuery, fmt.Sprintf("$%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("p.category_id IN (%s)", subtree))
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+
 	if filter.Status != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("p.status = $%d", argIndex))
 		args = append(args, *filter.Status)
 		argIndex++
 	}
 
+	if filter.PublishState != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.publish_state = $%d", argIndex))
+		args = append(args, *filter.PublishState)
+		argIndex++
+	}
+
+	if filter.SupplierID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.supplier_id = $%d", argIndex))
+		args = append(args, *filter.SupplierID)
+		argIndex++
+	}
+
 	if filter.Search != "" {
-		searchCondition := fmt.Sprintf("(name ILIKE $%d OR description ILIKE $%d OR sku ILIKE $%d)", argIndex, argIndex, argIndex)
+		searchCondition := fmt.Sprintf("(p.name ILIKE $%d OR p.description ILIKE $%d OR p.sku ILIKE $%d)", argIndex, argIndex, argIndex)
 		whereConditions = append(whereConditions, searchCondition)
 		args = append(args, "%"+filter.Search+"%")
 		argIndex++
 	}
 
 	if filter.MinPrice != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("price >= $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("p.price >= $%d", argIndex))
 		args = append(args, *filter.MinPrice)
 		argIndex++
 	}
 
 	if filter.MaxPrice != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("price <= $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("p.price <= $%d", argIndex))
 		args = append(args, *filter.MaxPrice)
 		argIndex++
 	}
@@ -271,19 +552,31 @@ func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositor
 	whereClause := strings.Join(whereConditions, " AND ")
 
 	// Build ORDER BY clause
-	orderBy := "created_at DESC"
+	orderBy := "p.created_at DESC"
 	if filter.OrderBy != "" {
 		direction := "ASC"
 		if filter.OrderDir == "DESC" {
 			direction = "DESC"
 		}
-		orderBy = fmt.Sprintf("%s %s", filter.OrderBy, direction)
+
+		switch filter.OrderBy {
+		case repositories.ProductOrderByStockStatus:
+			orderBy = fmt.Sprintf(`CASE
+				WHEN COALESCE(s.available_qty, 0) = 0 THEN 0
+				WHEN s.available_qty <= s.reorder_level THEN 1
+				ELSE 2
+			END %s, COALESCE(s.available_qty, 0) %s, p.name ASC`, direction, direction)
+		case repositories.ProductOrderByAvailableQty:
+			orderBy = fmt.Sprintf("COALESCE(s.available_qty, 0) %s, p.name ASC", direction)
+		default:
+			orderBy = fmt.Sprintf("p.%s %s", filter.OrderBy, direction)
+		}
 	}
 
 	// Count total records
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM products WHERE %s", whereClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM products p WHERE %s", whereClause)
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to count products: %w", err)
 	}
@@ -294,8 +587,12 @@ func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositor
 
 	// Build main query
 	query := fmt.Sprintf(`
-		SELECT id, sku, name, description, category, price, cost, status, unit, min_stock, created_at, updated_at, created_by
-		FROM products 
+		SELECT p.id, p.tenant_id, p.sku, p.name, p.description, p.category, p.price, p.cost, p.status, p.unit, p.min_stock, p.barcode,
+		       p.promo_price, p.promo_starts_at, p.promo_ends_at, p.publish_state, p.publish_at, p.published_at, p.submitted_by, p.reviewed_by,
+		       p.review_notes, p.supplier_id, p.category_id, p.tax_rate_id, p.currency, p.available_from, p.available_until, p.is_seasonal, p.is_serialized, p.created_at, p.updated_at, p.created_by,
+		       s.id, s.available_qty, s.reserved_qty, s.total_qty, s.reorder_level, s.last_movement_at, s.created_at, s.updated_at
+		FROM products p
+		LEFT JOIN stock s ON s.product_id = p.id
 		WHERE %s
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d`,
@@ -303,19 +600,28 @@ func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositor
 
 	args = append(args, pagination.Limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to query products: %w", err)
 	}
 	defer rows.Close()
 
-	var products []*entities.Product
+	var items []*repositories.ProductWithStock
 	for rows.Next() {
 		product := &entities.Product{}
 		var priceStr, costStr string
+		var promoPrice sql.NullString
+		var promoStartsAt, promoEndsAt sql.NullTime
+		var publishAt, publishedAt sql.NullTime
+		var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+		var availableFrom, availableUntil sql.NullTime
+		var stockID uuid.NullUUID
+		var availableQty, reservedQty, totalQty, reorderLevel sql.NullInt64
+		var lastMovementAt, stockCreatedAt, stockUpdatedAt sql.NullTime
 
 		err := rows.Scan(
 			&product.ID,
+			&product.TenantID,
 			&product.SKU,
 			&product.Name,
 			&product.Description,
@@ -325,9 +631,35 @@ func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositor
 			&product.Status,
 			&product.Unit,
 			&product.MinStock,
+			&product.Barcode,
+			&promoPrice,
+			&promoStartsAt,
+			&promoEndsAt,
+			&product.PublishState,
+			&publishAt,
+			&publishedAt,
+			&submittedBy,
+			&reviewedBy,
+			&product.ReviewNotes,
+			&supplierID,
+			&categoryID,
+			&taxRateID,
+			&product.Currency,
+			&availableFrom,
+			&availableUntil,
+			&product.IsSeasonal,
+			&product.IsSerialized,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 			&product.CreatedBy,
+			&stockID,
+			&availableQty,
+			&reservedQty,
+			&totalQty,
+			&reorderLevel,
+			&lastMovementAt,
+			&stockCreatedAt,
+			&stockUpdatedAt,
 		)
 		if err != nil {
 			return nil, pagination, fmt.Errorf("failed to scan product: %w", err)
@@ -340,8 +672,39 @@ func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositor
 		if product.Cost, err = decimal.NewFromString(costStr); err != nil {
 			return nil, pagination, fmt.Errorf("failed to parse cost: %w", err)
 		}
+		if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+			return nil, pagination, err
+		}
+		setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+		setProductAvailability(product, availableFrom, availableUntil)
+		if supplierID.Valid {
+			product.SupplierID = &supplierID.UUID
+		}
+		if categoryID.Valid {
+			product.CategoryID = &categoryID.UUID
+		}
+		if taxRateID.Valid {
+			product.TaxRateID = &taxRateID.UUID
+		}
 
-		products = append(products, product)
+		item := &repositories.ProductWithStock{Product: product}
+		if stockID.Valid {
+			item.Stock = &entities.Stock{
+				ID:           stockID.UUID,
+				ProductID:    product.ID,
+				AvailableQty: int(availableQty.Int64),
+				ReservedQty:  int(reservedQty.Int64),
+				TotalQty:     int(totalQty.Int64),
+				ReorderLevel: int(reorderLevel.Int64),
+				CreatedAt:    stockCreatedAt.Time,
+				UpdatedAt:    stockUpdatedAt.Time,
+			}
+			if lastMovementAt.Valid {
+				item.Stock.LastMovementAt = &lastMovementAt.Time
+			}
+		}
+
+		items = append(items, item)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -358,7 +721,7 @@ func (r *PostgreSQLProductRepository) List(ctx context.Context, filter repositor
 		HasPrev:    pagination.Page > 1,
 	}
 
-	return products, resultPagination, nil
+	return items, resultPagination, nil
 }
 
 // GetByCategory retrieves products by category
@@ -435,7 +798,8 @@ func (r *PostgreSQLProductRepository) GetLowStockProducts(ctx context.Context, p
 	// Main query with JOIN
 	query := `
 		SELECT p.id, p.sku, p.name, p.description, p.category, p.price, p.cost, p.status, 
-		       p.unit, p.min_stock, p.created_at, p.updated_at, p.created_by
+		       p.unit, p.min_stock, p.barcode, p.promo_price, p.promo_starts_at, p.promo_ends_at,
+		       p.publish_state, p.publish_at, p.published_at, p.submitted_by, p.reviewed_by, p.review_notes, p.supplier_id, p.category_id, p.tax_rate_id, p.currency, p.available_from, p.available_until, p.is_seasonal, p.is_serialized, p.created_at, p.updated_at, p.created_by
 		FROM products p
 		JOIN stock s ON p.id = s.product_id
 		WHERE p.deleted_at IS NULL AND s.available_qty <= p.min_stock
@@ -452,6 +816,11 @@ func (r *PostgreSQLProductRepository) GetLowStockProducts(ctx context.Context, p
 	for rows.Next() {
 		product := &entities.Product{}
 		var priceStr, costStr string
+		var promoPrice sql.NullString
+		var promoStartsAt, promoEndsAt sql.NullTime
+		var publishAt, publishedAt sql.NullTime
+		var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+		var availableFrom, availableUntil sql.NullTime
 
 		err := rows.Scan(
 			&product.ID,
@@ -464,6 +833,24 @@ func (r *PostgreSQLProductRepository) GetLowStockProducts(ctx context.Context, p
 			&product.Status,
 			&product.Unit,
 			&product.MinStock,
+			&product.Barcode,
+			&promoPrice,
+			&promoStartsAt,
+			&promoEndsAt,
+			&product.PublishState,
+			&publishAt,
+			&publishedAt,
+			&submittedBy,
+			&reviewedBy,
+			&product.ReviewNotes,
+			&supplierID,
+			&categoryID,
+			&taxRateID,
+			&product.Currency,
+			&availableFrom,
+			&availableUntil,
+			&product.IsSeasonal,
+			&product.IsSerialized,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 			&product.CreatedBy,
@@ -479,6 +866,20 @@ func (r *PostgreSQLProductRepository) GetLowStockProducts(ctx context.Context, p
 		if product.Cost, err = decimal.NewFromString(costStr); err != nil {
 			return nil, pagination, fmt.Errorf("failed to parse cost: %w", err)
 		}
+		if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+			return nil, pagination, err
+		}
+		setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+		setProductAvailability(product, availableFrom, availableUntil)
+		if supplierID.Valid {
+			product.SupplierID = &supplierID.UUID
+		}
+		if categoryID.Valid {
+			product.CategoryID = &categoryID.UUID
+		}
+		if taxRateID.Valid {
+			product.TaxRateID = &taxRateID.UUID
+		}
 
 		products = append(products, product)
 	}
@@ -503,12 +904,18 @@ func (r *PostgreSQLProductRepository) GetLowStockProducts(ctx context.Context, p
 // GetByTenantAndSKU retrieves a product by tenant ID and SKU
 func (r *PostgreSQLProductRepository) GetByTenantAndSKU(ctx context.Context, tenantID uuid.UUID, sku string) (*entities.Product, error) {
 	query := `
-		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, created_at, updated_at, created_by
+		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
 		FROM products 
 		WHERE tenant_id = $1 AND sku = $2 AND deleted_at IS NULL`
 
 	product := &entities.Product{}
 	var priceStr, costStr string
+	var promoPrice sql.NullString
+	var promoStartsAt, promoEndsAt sql.NullTime
+	var publishAt, publishedAt sql.NullTime
+	var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+	var availableFrom, availableUntil sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, tenantID, sku).Scan(
 		&product.ID,
@@ -522,6 +929,24 @@ func (r *PostgreSQLProductRepository) GetByTenantAndSKU(ctx context.Context, ten
 		&product.Status,
 		&product.Unit,
 		&product.MinStock,
+		&product.Barcode,
+		&promoPrice,
+		&promoStartsAt,
+		&promoEndsAt,
+		&product.PublishState,
+		&publishAt,
+		&publishedAt,
+		&submittedBy,
+		&reviewedBy,
+		&product.ReviewNotes,
+		&supplierID,
+		&categoryID,
+		&taxRateID,
+		&product.Currency,
+		&availableFrom,
+		&availableUntil,
+		&product.IsSeasonal,
+		&product.IsSerialized,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 		&product.CreatedBy,
@@ -541,6 +966,337 @@ func (r *PostgreSQLProductRepository) GetByTenantAndSKU(ctx context.Context, ten
 	if product.Cost, err = decimal.NewFromString(costStr); err != nil {
 		return nil, fmt.Errorf("failed to parse cost: %w", err)
 	}
+	if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+		return nil, err
+	}
+	setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+	setProductAvailability(product, availableFrom, availableUntil)
+	if supplierID.Valid {
+		product.SupplierID = &supplierID.UUID
+	}
+	if categoryID.Valid {
+		product.CategoryID = &categoryID.UUID
+	}
+	if taxRateID.Valid {
+		product.TaxRateID = &taxRateID.UUID
+	}
 
 	return product, nil
 }
+
+// GetByTenantAndBarcode retrieves a product by tenant ID and barcode
+func (r *PostgreSQLProductRepository) GetByTenantAndBarcode(ctx context.Context, tenantID uuid.UUID, barcode string) (*entities.Product, error) {
+	query := `
+		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
+		FROM products 
+		WHERE tenant_id = $1 AND barcode = $2 AND deleted_at IS NULL`
+
+	product := &entities.Product{}
+	var priceStr, costStr string
+	var promoPrice sql.NullString
+	var promoStartsAt, promoEndsAt sql.NullTime
+	var publishAt, publishedAt sql.NullTime
+	var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+	var availableFrom, availableUntil sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, tenantID, barcode).Scan(
+		&product.ID,
+		&product.TenantID,
+		&product.SKU,
+		&product.Name,
+		&product.Description,
+		&product.Category,
+		&priceStr,
+		&costStr,
+		&product.Status,
+		&product.Unit,
+		&product.MinStock,
+		&product.Barcode,
+		&promoPrice,
+		&promoStartsAt,
+		&promoEndsAt,
+		&product.PublishState,
+		&publishAt,
+		&publishedAt,
+		&submittedBy,
+		&reviewedBy,
+		&product.ReviewNotes,
+		&supplierID,
+		&categoryID,
+		&taxRateID,
+		&product.Currency,
+		&availableFrom,
+		&availableUntil,
+		&product.IsSeasonal,
+		&product.IsSerialized,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.CreatedBy,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("product")
+		}
+		return nil, fmt.Errorf("failed to get product by tenant and barcode: %w", err)
+	}
+
+	// Parse decimal values
+	if product.Price, err = decimal.NewFromString(priceStr); err != nil {
+		return nil, fmt.Errorf("failed to parse price: %w", err)
+	}
+	if product.Cost, err = decimal.NewFromString(costStr); err != nil {
+		return nil, fmt.Errorf("failed to parse cost: %w", err)
+	}
+	if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+		return nil, err
+	}
+	setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+	setProductAvailability(product, availableFrom, availableUntil)
+	if supplierID.Valid {
+		product.SupplierID = &supplierID.UUID
+	}
+	if categoryID.Valid {
+		product.CategoryID = &categoryID.UUID
+	}
+	if taxRateID.Valid {
+		product.TaxRateID = &taxRateID.UUID
+	}
+
+	return product, nil
+}
+
+// GetDueForPublishing retrieves scheduled products whose publish time has passed
+func (r *PostgreSQLProductRepository) GetDueForPublishing(ctx context.Context, now time.Time) ([]*entities.Product, error) {
+	query := `
+		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
+		FROM products
+		WHERE publish_state = $1 AND publish_at <= $2 AND deleted_at IS NULL
+		ORDER BY publish_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, entities.ProductPublishStateScheduled, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products due for publishing: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*entities.Product
+	for rows.Next() {
+		product := &entities.Product{}
+		var priceStr, costStr string
+		var promoPrice sql.NullString
+		var promoStartsAt, promoEndsAt sql.NullTime
+		var publishAt, publishedAt sql.NullTime
+		var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+		var availableFrom, availableUntil sql.NullTime
+
+		err := rows.Scan(
+			&product.ID,
+			&product.TenantID,
+			&product.SKU,
+			&product.Name,
+			&product.Description,
+			&product.Category,
+			&priceStr,
+			&costStr,
+			&product.Status,
+			&product.Unit,
+			&product.MinStock,
+			&product.Barcode,
+			&promoPrice,
+			&promoStartsAt,
+			&promoEndsAt,
+			&product.PublishState,
+			&publishAt,
+			&publishedAt,
+			&submittedBy,
+			&reviewedBy,
+			&product.ReviewNotes,
+			&supplierID,
+			&categoryID,
+			&taxRateID,
+			&product.Currency,
+			&availableFrom,
+			&availableUntil,
+			&product.IsSeasonal,
+			&product.IsSerialized,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+
+		if product.Price, err = decimal.NewFromString(priceStr); err != nil {
+			return nil, fmt.Errorf("failed to parse price: %w", err)
+		}
+		if product.Cost, err = decimal.NewFromString(costStr); err != nil {
+			return nil, fmt.Errorf("failed to parse cost: %w", err)
+		}
+		if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+			return nil, err
+		}
+		setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+		setProductAvailability(product, availableFrom, availableUntil)
+		if supplierID.Valid {
+			product.SupplierID = &supplierID.UUID
+		}
+		if categoryID.Valid {
+			product.CategoryID = &categoryID.UUID
+		}
+		if taxRateID.Valid {
+			product.TaxRateID = &taxRateID.UUID
+		}
+
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	return products, nil
+}
+
+// GetWithAvailabilityWindow retrieves active and inactive products that are only sold during
+// a limited period
+func (r *PostgreSQLProductRepository) GetWithAvailabilityWindow(ctx context.Context) ([]*entities.Product, error) {
+	query := `
+		SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+		       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, category_id, tax_rate_id, currency, available_from, available_until, is_seasonal, is_serialized, created_at, updated_at, created_by
+		FROM products
+		WHERE (available_from IS NOT NULL OR available_until IS NOT NULL)
+		  AND status IN ($1, $2) AND deleted_at IS NULL
+		ORDER BY tenant_id, id`
+
+	rows, err := r.db.QueryContext(ctx, query, entities.ProductStatusActive, entities.ProductStatusInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products with availability window: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*entities.Product
+	for rows.Next() {
+		product := &entities.Product{}
+		var priceStr, costStr string
+		var promoPrice sql.NullString
+		var promoStartsAt, promoEndsAt sql.NullTime
+		var publishAt, publishedAt sql.NullTime
+		var submittedBy, reviewedBy, supplierID, categoryID, taxRateID uuid.NullUUID
+		var availableFrom, availableUntil sql.NullTime
+
+		err := rows.Scan(
+			&product.ID,
+			&product.TenantID,
+			&product.SKU,
+			&product.Name,
+			&product.Description,
+			&product.Category,
+			&priceStr,
+			&costStr,
+			&product.Status,
+			&product.Unit,
+			&product.MinStock,
+			&product.Barcode,
+			&promoPrice,
+			&promoStartsAt,
+			&promoEndsAt,
+			&product.PublishState,
+			&publishAt,
+			&publishedAt,
+			&submittedBy,
+			&reviewedBy,
+			&product.ReviewNotes,
+			&supplierID,
+			&categoryID,
+			&taxRateID,
+			&product.Currency,
+			&availableFrom,
+			&availableUntil,
+			&product.IsSeasonal,
+			&product.IsSerialized,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+
+		if product.Price, err = decimal.NewFromString(priceStr); err != nil {
+			return nil, fmt.Errorf("failed to parse price: %w", err)
+		}
+		if product.Cost, err = decimal.NewFromString(costStr); err != nil {
+			return nil, fmt.Errorf("failed to parse cost: %w", err)
+		}
+		if err = setProductPromotion(product, promoPrice, promoStartsAt, promoEndsAt); err != nil {
+			return nil, err
+		}
+		setProductPublishing(product, publishAt, publishedAt, submittedBy, reviewedBy)
+		setProductAvailability(product, availableFrom, availableUntil)
+		if supplierID.Valid {
+			product.SupplierID = &supplierID.UUID
+		}
+		if categoryID.Valid {
+			product.CategoryID = &categoryID.UUID
+		}
+		if taxRateID.Valid {
+			product.TaxRateID = &taxRateID.UUID
+		}
+
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	return products, nil
+}
+
+// setProductAvailability applies the nullable availability columns to a scanned product
+func setProductAvailability(product *entities.Product, availableFrom, availableUntil sql.NullTime) {
+	if availableFrom.Valid {
+		product.AvailableFrom = &availableFrom.Time
+	}
+	if availableUntil.Valid {
+		product.AvailableUntil = &availableUntil.Time
+	}
+}
+
+// setProductPromotion applies the nullable promotion columns to a scanned product
+func setProductPromotion(product *entities.Product, promoPrice sql.NullString, promoStartsAt, promoEndsAt sql.NullTime) error {
+	if promoPrice.Valid {
+		price, err := decimal.NewFromString(promoPrice.String)
+		if err != nil {
+			return fmt.Errorf("failed to parse promo price: %w", err)
+		}
+		product.PromoPrice = &price
+	}
+	if promoStartsAt.Valid {
+		product.PromoStartsAt = &promoStartsAt.Time
+	}
+	if promoEndsAt.Valid {
+		product.PromoEndsAt = &promoEndsAt.Time
+	}
+	return nil
+}
+
+// setProductPublishing applies the nullable publishing columns to a scanned product
+func setProductPublishing(product *entities.Product, publishAt, publishedAt sql.NullTime, submittedBy, reviewedBy uuid.NullUUID) {
+	if publishAt.Valid {
+		product.PublishAt = &publishAt.Time
+	}
+	if publishedAt.Valid {
+		product.PublishedAt = &publishedAt.Time
+	}
+	if submittedBy.Valid {
+		product.SubmittedBy = &submittedBy.UUID
+	}
+	if reviewedBy.Valid {
+		product.ReviewedBy = &reviewedBy.UUID
+	}
+}
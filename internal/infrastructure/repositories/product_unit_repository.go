@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresProductUnitRepository implements the ProductUnitRepository interface
+type PostgresProductUnitRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresProductUnitRepository creates a new PostgreSQL product unit repository
+func NewPostgresProductUnitRepository(db *sql.DB) repositories.ProductUnitRepository {
+	return &PostgresProductUnitRepository{db: db}
+}
+
+// GetByTenant retrieves the tenant's own units
+func (r *PostgresProductUnitRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.ProductUnit, error) {
+	query := `
+		SELECT id, tenant_id, code, names, decimal_places, created_at, updated_at, updated_by
+		FROM product_units
+		WHERE tenant_id = $1
+		ORDER BY code`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product units: %w", err)
+	}
+	defer rows.Close()
+
+	units := []*entities.ProductUnit{}
+	for rows.Next() {
+		unit, err := r.scanProductUnit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product unit: %w", err)
+		}
+		units = append(units, unit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product units: %w", err)
+	}
+
+	return units, nil
+}
+
+// GetByCode retrieves the tenant's own unit with the given code
+func (r *PostgresProductUnitRepository) GetByCode(ctx context.Context, tenantID uuid.UUID, code string) (*entities.ProductUnit, error) {
+	query := `
+		SELECT id, tenant_id, code, names, decimal_places, created_at, updated_at, updated_by
+		FROM product_units
+		WHERE tenant_id = $1 AND code = $2`
+
+	unit, err := r.scanProductUnit(r.db.QueryRowContext(ctx, query, tenantID, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("product unit")
+		}
+		return nil, fmt.Errorf("failed to get product unit: %w", err)
+	}
+
+	return unit, nil
+}
+
+// Create creates a new unit
+func (r *PostgresProductUnitRepository) Create(ctx context.Context, unit *entities.ProductUnit) error {
+	namesJSON, err := json.Marshal(unit.Names)
+	if err != nil {
+		return fmt.Errorf("failed to marshal product unit names: %w", err)
+	}
+
+	query := `
+		INSERT INTO product_units (id, tenant_id, code, names, decimal_places, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		unit.ID, unit.TenantID, unit.Code, namesJSON, unit.DecimalPlaces, unit.CreatedAt, unit.UpdatedAt, unit.UpdatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("unit '%s' already exists", unit.Code))
+		}
+		return fmt.Errorf("failed to insert product unit: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a unit's names and allowed decimals
+func (r *PostgresProductUnitRepository) Update(ctx context.Context, unit *entities.ProductUnit) error {
+	namesJSON, err := json.Marshal(unit.Names)
+	if err != nil {
+		return fmt.Errorf("failed to marshal product unit names: %w", err)
+	}
+
+	query := `
+		UPDATE product_units SET names = $2, decimal_places = $3, updated_at = $4, updated_by = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, unit.ID, namesJSON, unit.DecimalPlaces, unit.UpdatedAt, unit.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to update product unit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("product unit")
+	}
+
+	return nil
+}
+
+// Delete deletes a unit
+func (r *PostgresProductUnitRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM product_units WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete product unit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("product unit")
+	}
+
+	return nil
+}
+
+// CountProducts counts the tenant's products measured in the unit with the given code
+func (r *PostgresProductUnitRepository) CountProducts(ctx context.Context, tenantID uuid.UUID, code string) (int, error) {
+	query := `SELECT COUNT(*) FROM products WHERE tenant_id = $1 AND unit = $2 AND deleted_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, tenantID, code).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count products by unit: %w", err)
+	}
+
+	return count, nil
+}
+
+// scanProductUnit scans a product unit from a row
+func (r *PostgresProductUnitRepository) scanProductUnit(row interface{ Scan(...interface{}) error }) (*entities.ProductUnit, error) {
+	var unit entities.ProductUnit
+	var namesJSON []byte
+
+	err := row.Scan(&unit.ID, &unit.TenantID, &unit.Code, &namesJSON, &unit.DecimalPlaces,
+		&unit.CreatedAt, &unit.UpdatedAt, &unit.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(namesJSON, &unit.Names); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product unit names: %w", err)
+	}
+
+	return &unit, nil
+}
@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresPurchaseOrderReceivingRepository implements the PurchaseOrderReceivingRepository interface
+type PostgresPurchaseOrderReceivingRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPurchaseOrderReceivingRepository creates a new PostgreSQL purchase order receiving repository
+func NewPostgresPurchaseOrderReceivingRepository(db *sql.DB) repositories.PurchaseOrderReceivingRepository {
+	return &PostgresPurchaseOrderReceivingRepository{db: db}
+}
+
+const purchaseOrderReceivingColumns = `id, tenant_id, purchase_order_id, status, notes, started_by, started_at,
+			completed_by, completed_at, created_at, updated_at`
+
+// Create creates a new receiving session
+func (r *PostgresPurchaseOrderReceivingRepository) Create(ctx context.Context, receiving *entities.PurchaseOrderReceiving) error {
+	query := `
+		INSERT INTO purchase_order_receivings (id, tenant_id, purchase_order_id, status, notes, started_by,
+			started_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		receiving.ID, receiving.TenantID, receiving.PurchaseOrderID, receiving.Status, receiving.Notes,
+		receiving.StartedBy, receiving.StartedAt, receiving.CreatedAt, receiving.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("purchase order already has an open receiving session")
+		}
+		return fmt.Errorf("failed to insert purchase order receiving: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a receiving session by ID with its lines
+func (r *PostgresPurchaseOrderReceivingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrderReceiving, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a receiving session by ID with its lines and locks it until the
+// transaction ends
+func (r *PostgresPurchaseOrderReceivingRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrderReceiving, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a receiving session by ID with its lines, with an optional locking clause
+func (r *PostgresPurchaseOrderReceivingRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.PurchaseOrderReceiving, error) {
+	query := fmt.Sprintf(`SELECT %s FROM purchase_order_receivings WHERE id = $1`, purchaseOrderReceivingColumns)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	receiving, err := r.scanReceiving(r.db.QueryRowContext(ctx, query+scope+lock, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("receiving session")
+		}
+		return nil, fmt.Errorf("failed to get purchase order receiving: %w", err)
+	}
+
+	if err := r.loadLines(ctx, []*entities.PurchaseOrderReceiving{receiving}); err != nil {
+		return nil, err
+	}
+
+	return receiving, nil
+}
+
+// Update updates a receiving session's status
+func (r *PostgresPurchaseOrderReceivingRepository) Update(ctx context.Context, receiving *entities.PurchaseOrderReceiving) error {
+	query := `
+		UPDATE purchase_order_receivings SET
+			status = $2, notes = $3, completed_by = $4, completed_at = $5, updated_at = $6
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		receiving.ID, receiving.Status, receiving.Notes, receiving.CompletedBy, receiving.CompletedAt, receiving.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order receiving: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("receiving session")
+	}
+
+	return nil
+}
+
+// SaveLines inserts or replaces scanned lines of a receiving session
+func (r *PostgresPurchaseOrderReceivingRepository) SaveLines(ctx context.Context, lines []*entities.PurchaseOrderReceivingLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO purchase_order_receiving_lines (id, receiving_id, item_id, product_id, product_sku,
+			product_name, barcode, scanned_qty, scan_count, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (receiving_id, item_id) DO UPDATE SET
+			barcode = EXCLUDED.barcode, scanned_qty = EXCLUDED.scanned_qty, scan_count = EXCLUDED.scan_count,
+			updated_at = EXCLUDED.updated_at`
+
+	for _, line := range lines {
+		_, err := tx.ExecContext(ctx, query,
+			line.ID, line.ReceivingID, line.ItemID, line.ProductID, line.ProductSKU, line.ProductName,
+			line.Barcode, line.ScannedQty, line.ScanCount, line.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save purchase order receiving line: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListByPurchaseOrder retrieves the receiving sessions of a purchase order with their lines,
+// most recently started first
+func (r *PostgresPurchaseOrderReceivingRepository) ListByPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) ([]*entities.PurchaseOrderReceiving, error) {
+	query := fmt.Sprintf(`SELECT %s FROM purchase_order_receivings WHERE purchase_order_id = $1`, purchaseOrderReceivingColumns)
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{purchaseOrderID})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, query+scope+" ORDER BY started_at DESC", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query purchase order receivings: %w", err)
+	}
+	defer rows.Close()
+
+	receivings := []*entities.PurchaseOrderReceiving{}
+	for rows.Next() {
+		receiving, err := r.scanReceiving(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order receiving: %w", err)
+		}
+		receivings = append(receivings, receiving)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate purchase order receivings: %w", err)
+	}
+
+	if err := r.loadLines(ctx, receivings); err != nil {
+		return nil, err
+	}
+
+	return receivings, nil
+}
+
+// Helper methods
+
+// loadLines loads the lines for a set of receiving sessions in a single query
+func (r *PostgresPurchaseOrderReceivingRepository) loadLines(ctx context.Context, receivings []*entities.PurchaseOrderReceiving) error {
+	if len(receivings) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(receivings))
+	byID := make(map[uuid.UUID]*entities.PurchaseOrderReceiving, len(receivings))
+	for i, receiving := range receivings {
+		ids[i] = receiving.ID.String()
+		byID[receiving.ID] = receiving
+	}
+
+	query := `
+		SELECT id, receiving_id, item_id, product_id, product_sku, product_name, barcode, scanned_qty,
+			scan_count, updated_at
+		FROM purchase_order_receiving_lines
+		WHERE receiving_id = ANY($1::uuid[])
+		ORDER BY product_name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query purchase order receiving lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.PurchaseOrderReceivingLine
+		err := rows.Scan(
+			&line.ID, &line.ReceivingID, &line.ItemID, &line.ProductID, &line.ProductSKU, &line.ProductName,
+			&line.Barcode, &line.ScannedQty, &line.ScanCount, &line.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan purchase order receiving line: %w", err)
+		}
+		if receiving, ok := byID[line.ReceivingID]; ok {
+			receiving.Lines = append(receiving.Lines, line)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate purchase order receiving lines: %w", err)
+	}
+
+	return nil
+}
+
+// scanReceiving scans a receiving session from a row
+func (r *PostgresPurchaseOrderReceivingRepository) scanReceiving(row interface{ Scan(...interface{}) error }) (*entities.PurchaseOrderReceiving, error) {
+	var receiving entities.PurchaseOrderReceiving
+	var completedBy uuid.NullUUID
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&receiving.ID, &receiving.TenantID, &receiving.PurchaseOrderID, &receiving.Status, &receiving.Notes,
+		&receiving.StartedBy, &receiving.StartedAt, &completedBy, &completedAt, &receiving.CreatedAt,
+		&receiving.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedBy.Valid {
+		receiving.CompletedBy = &completedBy.UUID
+	}
+	if completedAt.Valid {
+		receiving.CompletedAt = &completedAt.Time
+	}
+	receiving.Lines = []entities.PurchaseOrderReceivingLine{}
+
+	return &receiving, nil
+}
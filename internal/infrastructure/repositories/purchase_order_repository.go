@@ -0,0 +1,390 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// purchaseOrderOrderColumns lists the columns purchase orders can be sorted by
+var purchaseOrderOrderColumns = map[string]bool{
+	"po_number":     true,
+	"supplier_name": true,
+	"status":        true,
+	"total_cost":    true,
+	"expected_at":   true,
+	"ordered_at":    true,
+	"received_at":   true,
+	"created_at":    true,
+	"updated_at":    true,
+}
+
+// PostgresPurchaseOrderRepository implements the PurchaseOrderRepository interface
+type PostgresPurchaseOrderRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPurchaseOrderRepository creates a new PostgreSQL purchase order repository
+func NewPostgresPurchaseOrderRepository(db *sql.DB) repositories.PurchaseOrderRepository {
+	return &PostgresPurchaseOrderRepository{db: db}
+}
+
+// Create creates a new purchase order with its items
+func (r *PostgresPurchaseOrderRepository) Create(ctx context.Context, order *entities.PurchaseOrder) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO purchase_orders (id, tenant_id, po_number, supplier_name, supplier_email, supplier_phone,
+			status, total_cost, notes, expected_at, ordered_at, received_at, cancelled_at,
+			created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err = tx.ExecContext(ctx, query,
+		order.ID, order.TenantID, order.PONumber, order.SupplierName, order.SupplierEmail, order.SupplierPhone,
+		order.Status, order.TotalCost, order.Notes, order.ExpectedAt, order.OrderedAt, order.ReceivedAt,
+		order.CancelledAt, order.CreatedAt, order.UpdatedAt, order.CreatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("purchase order with number '%s' already exists", order.PONumber))
+		}
+		return fmt.Errorf("failed to insert purchase order: %w", err)
+	}
+
+	if err := r.insertItems(ctx, tx, order); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a purchase order by ID
+func (r *PostgresPurchaseOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a purchase order by ID and locks it until the transaction ends
+func (r *PostgresPurchaseOrderRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a purchase order by ID, with an optional locking clause
+func (r *PostgresPurchaseOrderRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.PurchaseOrder, error) {
+	query := `
+		SELECT id, tenant_id, po_number, supplier_name, supplier_email, supplier_phone,
+			status, total_cost, notes, expected_at, ordered_at, received_at, cancelled_at,
+			created_at, updated_at, created_by
+		FROM purchase_orders
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	order, err := r.scanPurchaseOrder(r.db.QueryRowContext(ctx, query+scope+lock, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("purchase order")
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*entities.PurchaseOrder{order}); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Update updates a purchase order and replaces its items
+func (r *PostgresPurchaseOrderRepository) Update(ctx context.Context, order *entities.PurchaseOrder) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE purchase_orders SET
+			supplier_name = $2, supplier_email = $3, supplier_phone = $4, status = $5, total_cost = $6,
+			notes = $7, expected_at = $8, ordered_at = $9, received_at = $10, cancelled_at = $11, updated_at = $12
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		order.ID, order.SupplierName, order.SupplierEmail, order.SupplierPhone, order.Status, order.TotalCost,
+		order.Notes, order.ExpectedAt, order.OrderedAt, order.ReceivedAt, order.CancelledAt, order.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("purchase order")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM purchase_order_items WHERE purchase_order_id = $1`, order.ID); err != nil {
+		return fmt.Errorf("failed to delete purchase order items: %w", err)
+	}
+
+	if err := r.insertItems(ctx, tx, order); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// List retrieves purchase orders with pagination and filtering
+func (r *PostgresPurchaseOrderRepository) List(ctx context.Context, filter repositories.PurchaseOrderFilter, pagination utils.PaginationInfo) ([]*entities.PurchaseOrder, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 0
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.Supplier != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("supplier_name ILIKE $%d", argCount))
+		args = append(args, "%"+filter.Supplier+"%")
+	}
+
+	if filter.ProductID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT purchase_order_id FROM purchase_order_items WHERE product_id = $%d)", argCount))
+		args = append(args, *filter.ProductID)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, utils.PaginationInfo{}, err
+	}
+	argCount = len(args)
+	whereClause := "WHERE " + strings.Join(conditions, " AND ") + scope
+
+	// Build ORDER BY clause
+	orderBy := "created_at DESC"
+	if purchaseOrderOrderColumns[filter.OrderBy] {
+		direction := "ASC"
+		if filter.OrderDir == "DESC" {
+			direction = "DESC"
+		}
+		orderBy = fmt.Sprintf("%s %s", filter.OrderBy, direction)
+	}
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM purchase_orders %s", whereClause)
+	var total int
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count purchase orders: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT id, tenant_id, po_number, supplier_name, supplier_email, supplier_phone,
+			status, total_cost, notes, expected_at, ordered_at, received_at, cancelled_at,
+			created_at, updated_at, created_by
+		FROM purchase_orders
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		whereClause, orderBy, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query purchase orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*entities.PurchaseOrder
+	for rows.Next() {
+		order, err := r.scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan purchase order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate purchase orders: %w", err)
+	}
+
+	if err := r.loadItems(ctx, orders); err != nil {
+		return nil, paginationResult, err
+	}
+
+	return orders, paginationResult, nil
+}
+
+// GetOnOrderQuantities retrieves the units of each product still to be delivered on a
+// tenant's draft and ordered purchase orders, keyed by product ID
+func (r *PostgresPurchaseOrderRepository) GetOnOrderQuantities(ctx context.Context, tenantID uuid.UUID, productIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	quantities := make(map[uuid.UUID]int, len(productIDs))
+	if len(productIDs) == 0 {
+		return quantities, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT poi.product_id, COALESCE(SUM(poi.quantity - poi.received_qty), 0)
+		FROM purchase_order_items poi
+		JOIN purchase_orders po ON poi.purchase_order_id = po.id
+		WHERE po.tenant_id = $1 AND poi.product_id = ANY($2::uuid[])
+			AND po.status IN ('draft', 'ordered')
+		GROUP BY poi.product_id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quantities on order: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID uuid.UUID
+		var quantity int
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan quantity on order: %w", err)
+		}
+		quantities[productID] = quantity
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate quantities on order: %w", err)
+	}
+
+	return quantities, nil
+}
+
+// Helper functions
+
+// insertItems inserts the items of a purchase order
+func (r *PostgresPurchaseOrderRepository) insertItems(ctx context.Context, tx *sql.Tx, order *entities.PurchaseOrder) error {
+	query := `
+		INSERT INTO purchase_order_items (id, purchase_order_id, product_id, product_sku, product_name,
+			quantity, received_qty, unit_cost, total_cost)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	for _, item := range order.Items {
+		_, err := tx.ExecContext(ctx, query,
+			item.ID, order.ID, item.ProductID, item.ProductSKU, item.ProductName,
+			item.Quantity, item.ReceivedQty, item.UnitCost, item.TotalCost)
+		if err != nil {
+			return fmt.Errorf("failed to insert purchase order item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// scanPurchaseOrder scans a purchase order from a row
+func (r *PostgresPurchaseOrderRepository) scanPurchaseOrder(row interface{ Scan(...interface{}) error }) (*entities.PurchaseOrder, error) {
+	var order entities.PurchaseOrder
+	var supplierEmail, supplierPhone, notes sql.NullString
+	var expectedAt, orderedAt, receivedAt, cancelledAt sql.NullTime
+
+	err := row.Scan(
+		&order.ID, &order.TenantID, &order.PONumber, &order.SupplierName, &supplierEmail, &supplierPhone,
+		&order.Status, &order.TotalCost, &notes, &expectedAt, &orderedAt, &receivedAt, &cancelledAt,
+		&order.CreatedAt, &order.UpdatedAt, &order.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	order.SupplierEmail = supplierEmail.String
+	order.SupplierPhone = supplierPhone.String
+	order.Notes = notes.String
+	if expectedAt.Valid {
+		order.ExpectedAt = &expectedAt.Time
+	}
+	if orderedAt.Valid {
+		order.OrderedAt = &orderedAt.Time
+	}
+	if receivedAt.Valid {
+		order.ReceivedAt = &receivedAt.Time
+	}
+	if cancelledAt.Valid {
+		order.CancelledAt = &cancelledAt.Time
+	}
+
+	return &order, nil
+}
+
+// loadItems loads the items for a set of purchase orders in a single query
+func (r *PostgresPurchaseOrderRepository) loadItems(ctx context.Context, orders []*entities.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(orders))
+	byID := make(map[uuid.UUID]*entities.PurchaseOrder, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID.String()
+		order.Items = []entities.PurchaseOrderItem{}
+		byID[order.ID] = order
+	}
+
+	query := `
+		SELECT id, purchase_order_id, product_id, product_sku, product_name,
+			quantity, received_qty, unit_cost, total_cost
+		FROM purchase_order_items
+		WHERE purchase_order_id = ANY($1::uuid[])
+		ORDER BY product_name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query purchase order items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.PurchaseOrderItem
+		err := rows.Scan(&item.ID, &item.PurchaseOrderID, &item.ProductID, &item.ProductSKU, &item.ProductName,
+			&item.Quantity, &item.ReceivedQty, &item.UnitCost, &item.TotalCost)
+		if err != nil {
+			return fmt.Errorf("failed to scan purchase order item: %w", err)
+		}
+		if order, ok := byID[item.PurchaseOrderID]; ok {
+			order.Items = append(order.Items, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate purchase order items: %w", err)
+	}
+
+	return nil
+}
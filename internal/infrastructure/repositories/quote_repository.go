@@ -0,0 +1,367 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// quoteColumns lists the columns of a quote in the order scanQuote reads them
+const quoteColumns = `id, tenant_id, quote_number, customer_name, customer_email, customer_phone,
+	status, total_amount, currency, notes, expires_at, sent_at, accepted_at, rejected_at,
+	expired_at, converted_at, sale_id, created_at, updated_at, created_by`
+
+// PostgresQuoteRepository implements the QuoteRepository interface
+type PostgresQuoteRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresQuoteRepository creates a new PostgreSQL quote repository
+func NewPostgresQuoteRepository(db *sql.DB) repositories.QuoteRepository {
+	return &PostgresQuoteRepository{db: db}
+}
+
+// Create creates a new quote with its items
+func (r *PostgresQuoteRepository) Create(ctx context.Context, quote *entities.Quote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO quotes (id, tenant_id, quote_number, customer_name, customer_email, customer_phone,
+			status, total_amount, currency, notes, expires_at, sent_at, accepted_at, rejected_at,
+			expired_at, converted_at, sale_id, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
+
+	_, err = tx.ExecContext(ctx, query,
+		quote.ID, quote.TenantID, quote.QuoteNumber, quote.CustomerName, quote.CustomerEmail, quote.CustomerPhone,
+		quote.Status, quote.TotalAmount, quote.Currency, quote.Notes, quote.ExpiresAt, quote.SentAt,
+		quote.AcceptedAt, quote.RejectedAt, quote.ExpiredAt, quote.ConvertedAt, quote.SaleID,
+		quote.CreatedAt, quote.UpdatedAt, quote.CreatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("quote with number '%s' already exists", quote.QuoteNumber))
+		}
+		return fmt.Errorf("failed to insert quote: %w", err)
+	}
+
+	if err := r.insertItems(ctx, tx, quote); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a quote by ID
+func (r *PostgresQuoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Quote, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a quote by ID and locks it until the transaction ends
+func (r *PostgresQuoteRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Quote, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a quote by ID, appending the locking clause to the query
+func (r *PostgresQuoteRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.Quote, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE id = $1` + scope + lock
+
+	quote, err := r.scanQuote(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("quote")
+		}
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*entities.Quote{quote}); err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// Update updates a quote and replaces its items
+func (r *PostgresQuoteRepository) Update(ctx context.Context, quote *entities.Quote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE quotes SET
+			customer_name = $2, customer_email = $3, customer_phone = $4, status = $5, total_amount = $6,
+			notes = $7, expires_at = $8, sent_at = $9, accepted_at = $10, rejected_at = $11,
+			expired_at = $12, converted_at = $13, sale_id = $14, updated_at = $15
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		quote.ID, quote.CustomerName, quote.CustomerEmail, quote.CustomerPhone, quote.Status, quote.TotalAmount,
+		quote.Notes, quote.ExpiresAt, quote.SentAt, quote.AcceptedAt, quote.RejectedAt,
+		quote.ExpiredAt, quote.ConvertedAt, quote.SaleID, quote.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update quote: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("quote")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quote_items WHERE quote_id = $1`, quote.ID); err != nil {
+		return fmt.Errorf("failed to delete quote items: %w", err)
+	}
+
+	if err := r.insertItems(ctx, tx, quote); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// List retrieves quotes with pagination and filtering
+func (r *PostgresQuoteRepository) List(ctx context.Context, filter repositories.QuoteFilter, pagination utils.PaginationInfo) ([]*entities.Quote, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 0
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.Customer != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("(customer_name ILIKE $%d OR customer_email ILIKE $%d)", argCount, argCount))
+		args = append(args, "%"+filter.Customer+"%")
+	}
+
+	if filter.ProductID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT quote_id FROM quote_items WHERE product_id = $%d)", argCount))
+		args = append(args, *filter.ProductID)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, utils.PaginationInfo{}, err
+	}
+	argCount = len(args)
+	whereClause := "WHERE " + strings.Join(conditions, " AND ") + scope
+
+	// Build ORDER BY clause
+	orderBy := "created_at DESC"
+	switch filter.OrderBy {
+	case "created_at", "expires_at", "quote_number", "customer_name", "total_amount":
+		direction := "ASC"
+		if filter.OrderDir == "DESC" {
+			direction = "DESC"
+		}
+		orderBy = fmt.Sprintf("%s %s", filter.OrderBy, direction)
+	}
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM quotes %s", whereClause)
+	var total int
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count quotes: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM quotes
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		quoteColumns, whereClause, orderBy, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	quotes, err := r.queryQuotes(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+
+	return quotes, paginationResult, nil
+}
+
+// GetExpired retrieves draft and sent quotes across all tenants that expired before the given time
+func (r *PostgresQuoteRepository) GetExpired(ctx context.Context, before time.Time, limit int) ([]*entities.Quote, error) {
+	query := `
+		SELECT ` + quoteColumns + `
+		FROM quotes
+		WHERE status IN ('draft', 'sent') AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2`
+
+	return r.queryQuotes(ctx, query, before, limit)
+}
+
+// Helper functions
+
+// queryQuotes runs a query selecting quoteColumns and loads the items of the quotes found
+func (r *PostgresQuoteRepository) queryQuotes(ctx context.Context, query string, args ...interface{}) ([]*entities.Quote, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quotes: %w", err)
+	}
+	defer rows.Close()
+
+	var quotes []*entities.Quote
+	for rows.Next() {
+		quote, err := r.scanQuote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quote: %w", err)
+		}
+		quotes = append(quotes, quote)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate quotes: %w", err)
+	}
+	rows.Close()
+
+	if err := r.loadItems(ctx, quotes); err != nil {
+		return nil, err
+	}
+
+	return quotes, nil
+}
+
+// insertItems inserts the items of a quote
+func (r *PostgresQuoteRepository) insertItems(ctx context.Context, tx *sql.Tx, quote *entities.Quote) error {
+	query := `
+		INSERT INTO quote_items (id, quote_id, product_id, product_sku, product_name,
+			quantity, unit_price, total_price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	for _, item := range quote.Items {
+		_, err := tx.ExecContext(ctx, query,
+			item.ID, quote.ID, item.ProductID, item.ProductSKU, item.ProductName,
+			item.Quantity, item.UnitPrice, item.TotalPrice)
+		if err != nil {
+			return fmt.Errorf("failed to insert quote item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// scanQuote scans a quote from a row
+func (r *PostgresQuoteRepository) scanQuote(row interface{ Scan(...interface{}) error }) (*entities.Quote, error) {
+	var quote entities.Quote
+	var customerEmail, customerPhone, notes sql.NullString
+	var sentAt, acceptedAt, rejectedAt, expiredAt, convertedAt sql.NullTime
+	var saleID uuid.NullUUID
+
+	err := row.Scan(
+		&quote.ID, &quote.TenantID, &quote.QuoteNumber, &quote.CustomerName, &customerEmail, &customerPhone,
+		&quote.Status, &quote.TotalAmount, &quote.Currency, &notes, &quote.ExpiresAt, &sentAt, &acceptedAt,
+		&rejectedAt, &expiredAt, &convertedAt, &saleID, &quote.CreatedAt, &quote.UpdatedAt, &quote.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	quote.CustomerEmail = customerEmail.String
+	quote.CustomerPhone = customerPhone.String
+	quote.Notes = notes.String
+	if sentAt.Valid {
+		quote.SentAt = &sentAt.Time
+	}
+	if acceptedAt.Valid {
+		quote.AcceptedAt = &acceptedAt.Time
+	}
+	if rejectedAt.Valid {
+		quote.RejectedAt = &rejectedAt.Time
+	}
+	if expiredAt.Valid {
+		quote.ExpiredAt = &expiredAt.Time
+	}
+	if convertedAt.Valid {
+		quote.ConvertedAt = &convertedAt.Time
+	}
+	if saleID.Valid {
+		quote.SaleID = &saleID.UUID
+	}
+
+	return &quote, nil
+}
+
+// loadItems loads the items for a set of quotes in a single query
+func (r *PostgresQuoteRepository) loadItems(ctx context.Context, quotes []*entities.Quote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(quotes))
+	byID := make(map[uuid.UUID]*entities.Quote, len(quotes))
+	for i, quote := range quotes {
+		ids[i] = quote.ID.String()
+		quote.Items = []entities.QuoteItem{}
+		byID[quote.ID] = quote
+	}
+
+	query := `
+		SELECT id, quote_id, product_id, product_sku, product_name,
+			quantity, unit_price, total_price
+		FROM quote_items
+		WHERE quote_id = ANY($1::uuid[])
+		ORDER BY product_name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query quote items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.QuoteItem
+		err := rows.Scan(&item.ID, &item.QuoteID, &item.ProductID, &item.ProductSKU, &item.ProductName,
+			&item.Quantity, &item.UnitPrice, &item.TotalPrice)
+		if err != nil {
+			return fmt.Errorf("failed to scan quote item: %w", err)
+		}
+		if quote, ok := byID[item.QuoteID]; ok {
+			quote.Items = append(quote.Items, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate quote items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresRefundRepository implements the RefundRepository interface
+type PostgresRefundRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRefundRepository creates a new PostgreSQL refund repository
+func NewPostgresRefundRepository(db *sql.DB) repositories.RefundRepository {
+	return &PostgresRefundRepository{db: db}
+}
+
+// Create creates a new refund with its items
+func (r *PostgresRefundRepository) Create(ctx context.Context, refund *entities.Refund) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO refunds (id, tenant_id, sale_id, refund_number, subtotal, discount_amount,
+			tax_amount, total_amount, refund_method, reason, approved_by, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err = tx.ExecContext(ctx, query,
+		refund.ID, refund.TenantID, refund.SaleID, refund.RefundNumber, refund.Subtotal, refund.DiscountAmount,
+		refund.TaxAmount, refund.TotalAmount, refund.RefundMethod, refund.Reason, refund.ApprovedBy,
+		refund.CreatedAt, refund.CreatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("refund with number '%s' already exists", refund.RefundNumber))
+		}
+		return fmt.Errorf("failed to insert refund: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO refund_items (id, refund_id, sale_item_id, product_id, product_sku, product_name,
+			quantity, unit_price, total_price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	for _, item := range refund.Items {
+		_, err := tx.ExecContext(ctx, itemQuery,
+			item.ID, refund.ID, item.SaleItemID, item.ProductID, item.ProductSKU, item.ProductName,
+			item.Quantity, item.UnitPrice, item.TotalPrice)
+		if err != nil {
+			return fmt.Errorf("failed to insert refund item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a refund by ID
+func (r *PostgresRefundRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Refund, error) {
+	query := `
+		SELECT id, tenant_id, sale_id, refund_number, subtotal, discount_amount, tax_amount,
+			total_amount, refund_method, reason, approved_by, created_at, created_by
+		FROM refunds
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	refund, err := r.scanRefund(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("refund")
+		}
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*entities.Refund{refund}); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// GetBySaleID retrieves all refunds of a sale, oldest first
+func (r *PostgresRefundRepository) GetBySaleID(ctx context.Context, saleID uuid.UUID) ([]*entities.Refund, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{saleID})
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT id, tenant_id, sale_id, refund_number, subtotal, discount_amount, tax_amount,
+			total_amount, refund_method, reason, approved_by, created_at, created_by
+		FROM refunds
+		WHERE sale_id = $1` + scope + `
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []*entities.Refund
+	for rows.Next() {
+		refund, err := r.scanRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate refunds: %w", err)
+	}
+
+	if err := r.loadItems(ctx, refunds); err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+// Helper functions
+
+// scanRefund scans a refund from a row
+func (r *PostgresRefundRepository) scanRefund(row interface{ Scan(...interface{}) error }) (*entities.Refund, error) {
+	var refund entities.Refund
+	var approvedBy uuid.NullUUID
+
+	err := row.Scan(
+		&refund.ID, &refund.TenantID, &refund.SaleID, &refund.RefundNumber, &refund.Subtotal,
+		&refund.DiscountAmount, &refund.TaxAmount, &refund.TotalAmount, &refund.RefundMethod,
+		&refund.Reason, &approvedBy, &refund.CreatedAt, &refund.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if approvedBy.Valid {
+		refund.ApprovedBy = &approvedBy.UUID
+	}
+
+	return &refund, nil
+}
+
+// loadItems loads the items of the given refunds
+func (r *PostgresRefundRepository) loadItems(ctx context.Context, refunds []*entities.Refund) error {
+	if len(refunds) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(refunds))
+	byID := make(map[uuid.UUID]*entities.Refund, len(refunds))
+	for i, refund := range refunds {
+		ids[i] = refund.ID.String()
+		refund.Items = []entities.RefundItem{}
+		byID[refund.ID] = refund
+	}
+
+	query := `
+		SELECT id, refund_id, sale_item_id, product_id, product_sku, product_name,
+			quantity, unit_price, total_price
+		FROM refund_items
+		WHERE refund_id = ANY($1::uuid[])
+		ORDER BY product_name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query refund items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.RefundItem
+		err := rows.Scan(&item.ID, &item.RefundID, &item.SaleItemID, &item.ProductID, &item.ProductSKU,
+			&item.ProductName, &item.Quantity, &item.UnitPrice, &item.TotalPrice)
+		if err != nil {
+			return fmt.Errorf("failed to scan refund item: %w", err)
+		}
+		if refund, ok := byID[item.RefundID]; ok {
+			refund.Items = append(refund.Items, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate refund items: %w", err)
+	}
+
+	return nil
+}
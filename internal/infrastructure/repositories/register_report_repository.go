@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresRegisterReportRepository implements the RegisterReportRepository interface
+type PostgresRegisterReportRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRegisterReportRepository creates a new PostgreSQL register report repository
+func NewPostgresRegisterReportRepository(db *sql.DB) repositories.RegisterReportRepository {
+	return &PostgresRegisterReportRepository{db: db}
+}
+
+const registerZReportColumns = `id, tenant_id, session_id, register, number, grand_total, sales, drawer,
+			generated_by, generated_at`
+
+// CreateZReport stores a Z-report
+func (r *PostgresRegisterReportRepository) CreateZReport(ctx context.Context, report *entities.RegisterReport) error {
+	salesJSON, err := json.Marshal(report.Sales)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sales totals: %w", err)
+	}
+	drawerJSON, err := json.Marshal(report.Drawer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal drawer reconciliation: %w", err)
+	}
+
+	query := `
+		INSERT INTO register_z_reports (id, tenant_id, session_id, register, number, grand_total, sales, drawer,
+			generated_by, generated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		report.ID, report.TenantID, report.SessionID, report.Register, report.Number, report.GrandTotal,
+		salesJSON, drawerJSON, report.GeneratedBy, report.GeneratedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("Z-report already taken")
+		}
+		return fmt.Errorf("failed to insert Z-report: %w", err)
+	}
+
+	return nil
+}
+
+// GetZReportBySession retrieves the Z-report of a register session
+func (r *PostgresRegisterReportRepository) GetZReportBySession(ctx context.Context, sessionID uuid.UUID) (*entities.RegisterReport, error) {
+	query := fmt.Sprintf(`SELECT %s FROM register_z_reports WHERE session_id = $1`, registerZReportColumns)
+
+	report, err := r.scanZReport(r.db.QueryRowContext(ctx, query, sessionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("Z-report")
+		}
+		return nil, fmt.Errorf("failed to get Z-report: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetLatestZReport retrieves the highest numbered Z-report of a register
+func (r *PostgresRegisterReportRepository) GetLatestZReport(ctx context.Context, tenantID uuid.UUID, register string) (*entities.RegisterReport, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM register_z_reports
+		WHERE tenant_id = $1 AND register = $2
+		ORDER BY number DESC
+		LIMIT 1`, registerZReportColumns)
+
+	report, err := r.scanZReport(r.db.QueryRowContext(ctx, query, tenantID, register))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("Z-report")
+		}
+		return nil, fmt.Errorf("failed to get latest Z-report: %w", err)
+	}
+
+	return report, nil
+}
+
+// Helper functions
+
+// scanZReport scans a Z-report from a row
+func (r *PostgresRegisterReportRepository) scanZReport(row interface{ Scan(...interface{}) error }) (*entities.RegisterReport, error) {
+	report := entities.RegisterReport{Type: entities.RegisterReportTypeZ}
+	var salesJSON, drawerJSON []byte
+
+	err := row.Scan(
+		&report.ID, &report.TenantID, &report.SessionID, &report.Register, &report.Number, &report.GrandTotal,
+		&salesJSON, &drawerJSON, &report.GeneratedBy, &report.GeneratedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(salesJSON, &report.Sales); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sales totals: %w", err)
+	}
+	if err := json.Unmarshal(drawerJSON, &report.Drawer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal drawer reconciliation: %w", err)
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,377 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PostgresRegisterSessionRepository implements the RegisterSessionRepository interface
+type PostgresRegisterSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRegisterSessionRepository creates a new PostgreSQL register session repository
+func NewPostgresRegisterSessionRepository(db *sql.DB) repositories.RegisterSessionRepository {
+	return &PostgresRegisterSessionRepository{db: db}
+}
+
+const registerSessionColumns = `id, tenant_id, register, status, opening_float, movements, cash_sales,
+			cash_refunds, expected_cash, counted_cash, notes, opened_by, opened_at, closed_by, closed_at,
+			created_at, updated_at`
+
+// Create creates a new register session
+func (r *PostgresRegisterSessionRepository) Create(ctx context.Context, session *entities.RegisterSession) error {
+	movementsJSON, err := json.Marshal(session.Movements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cash movements: %w", err)
+	}
+
+	query := `
+		INSERT INTO register_sessions (id, tenant_id, register, status, opening_float, movements,
+			notes, opened_by, opened_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		session.ID, session.TenantID, session.Register, session.Status, session.OpeningFloat, movementsJSON,
+		session.Notes, session.OpenedBy, session.OpenedAt, session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("register already has an open session")
+		}
+		return fmt.Errorf("failed to insert register session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a register session by ID
+func (r *PostgresRegisterSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.RegisterSession, error) {
+	query := fmt.Sprintf(`SELECT %s FROM register_sessions WHERE id = $1`, registerSessionColumns)
+
+	session, err := r.scanRegisterSession(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("register session")
+		}
+		return nil, fmt.Errorf("failed to get register session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetOpenByRegister retrieves the session currently open on a register
+func (r *PostgresRegisterSessionRepository) GetOpenByRegister(ctx context.Context, tenantID uuid.UUID, register string) (*entities.RegisterSession, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM register_sessions
+		WHERE tenant_id = $1 AND register = $2 AND status = 'open'`, registerSessionColumns)
+
+	session, err := r.scanRegisterSession(r.db.QueryRowContext(ctx, query, tenantID, register))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("open register session")
+		}
+		return nil, fmt.Errorf("failed to get open register session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Update updates a register session and its cash movements
+func (r *PostgresRegisterSessionRepository) Update(ctx context.Context, session *entities.RegisterSession) error {
+	movementsJSON, err := json.Marshal(session.Movements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cash movements: %w", err)
+	}
+
+	query := `
+		UPDATE register_sessions SET
+			status = $2, movements = $3, cash_sales = $4, cash_refunds = $5, expected_cash = $6,
+			counted_cash = $7, notes = $8, closed_by = $9, closed_at = $10, updated_at = $11
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID, session.Status, movementsJSON, session.CashSales, session.CashRefunds, session.ExpectedCash,
+		session.CountedCash, session.Notes, session.ClosedBy, session.ClosedAt, session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update register session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("register session")
+	}
+
+	return nil
+}
+
+// List retrieves register sessions with pagination and filtering, most recently opened first
+func (r *PostgresRegisterSessionRepository) List(ctx context.Context, filter repositories.RegisterSessionFilter, pagination utils.PaginationInfo) ([]*entities.RegisterSession, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+	argCount := 1
+
+	if filter.Register != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("register = $%d", argCount))
+		args = append(args, filter.Register)
+	}
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM register_sessions %s", whereClause)
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count register sessions: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM register_sessions
+		%s
+		ORDER BY opened_at DESC
+		LIMIT $%d OFFSET $%d`,
+		registerSessionColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	sessions, err := r.querySessions(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+
+	return sessions, paginationResult, nil
+}
+
+// GetOpenedBetween retrieves the sessions opened in [start, end)
+func (r *PostgresRegisterSessionRepository) GetOpenedBetween(ctx context.Context, tenantID uuid.UUID, start, end time.Time) ([]*entities.RegisterSession, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM register_sessions
+		WHERE tenant_id = $1 AND opened_at >= $2 AND opened_at < $3
+		ORDER BY register, opened_at`, registerSessionColumns)
+
+	return r.querySessions(ctx, query, tenantID, start, end)
+}
+
+// GetCashTotals sums the cash taken by a cashier's completed sales and given back by their
+// cash refunds in [start, end). Refunded sales count too: their cash came into the drawer
+// before the refund took it out.
+func (r *PostgresRegisterSessionRepository) GetCashTotals(ctx context.Context, tenantID, cashierID uuid.UUID, start, end time.Time) (*entities.RegisterCashTotals, error) {
+	var totals entities.RegisterCashTotals
+
+	salesQuery := `
+		SELECT COALESCE(SUM(sp.amount), 0), COUNT(DISTINCT s.id)
+		FROM sale_payments sp
+		JOIN sales s ON s.id = sp.sale_id
+		WHERE s.tenant_id = $1 AND s.created_by = $2 AND s.status IN ('completed', 'refunded')
+			AND s.deleted_at IS NULL AND s.completed_at >= $3 AND s.completed_at < $4
+			AND sp.payment_method = 'cash'`
+
+	err := r.db.QueryRowContext(ctx, salesQuery, tenantID, cashierID, start, end).Scan(&totals.CashSales, &totals.SaleCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum cash sales: %w", err)
+	}
+
+	refundsQuery := `
+		SELECT COALESCE(SUM(total_amount), 0), COUNT(*)
+		FROM refunds
+		WHERE tenant_id = $1 AND created_by = $2 AND refund_method = 'cash'
+			AND created_at >= $3 AND created_at < $4`
+
+	err = r.db.QueryRowContext(ctx, refundsQuery, tenantID, cashierID, start, end).Scan(&totals.CashRefunds, &totals.RefundCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum cash refunds: %w", err)
+	}
+
+	return &totals, nil
+}
+
+// GetSalesTotals sums a cashier's completed sales by payment method and their refunds by
+// refund method in [start, end). As with cash totals, refunded sales count as sales. Amounts
+// are converted to the tenant's base currency at each sale's locked rate.
+func (r *PostgresRegisterSessionRepository) GetSalesTotals(ctx context.Context, tenantID, cashierID uuid.UUID, start, end time.Time) (*entities.RegisterSalesTotals, error) {
+	totals := entities.RegisterSalesTotals{
+		Payments: []entities.RegisterPaymentTotal{},
+		Refunds:  []entities.RegisterPaymentTotal{},
+	}
+
+	salesQuery := `
+		SELECT COUNT(*), COALESCE(SUM(subtotal * exchange_rate), 0), COALESCE(SUM(discount_amount * exchange_rate), 0),
+			COALESCE(SUM(tax_amount * exchange_rate), 0), COALESCE(SUM(surcharge_amount * exchange_rate), 0),
+			COALESCE(SUM(total_amount * exchange_rate), 0)
+		FROM sales
+		WHERE tenant_id = $1 AND created_by = $2 AND status IN ('completed', 'refunded')
+			AND deleted_at IS NULL AND completed_at >= $3 AND completed_at < $4`
+
+	err := r.db.QueryRowContext(ctx, salesQuery, tenantID, cashierID, start, end).Scan(
+		&totals.SaleCount, &totals.GrossSales, &totals.DiscountAmount,
+		&totals.TaxAmount, &totals.SurchargeAmount, &totals.NetSales)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum sales: %w", err)
+	}
+
+	itemsQuery := `
+		SELECT COALESCE(SUM(si.quantity), 0)
+		FROM sale_items si
+		JOIN sales s ON s.id = si.sale_id
+		WHERE s.tenant_id = $1 AND s.created_by = $2 AND s.status IN ('completed', 'refunded')
+			AND s.deleted_at IS NULL AND s.completed_at >= $3 AND s.completed_at < $4`
+
+	err = r.db.QueryRowContext(ctx, itemsQuery, tenantID, cashierID, start, end).Scan(&totals.ItemsSold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum items sold: %w", err)
+	}
+
+	paymentsQuery := `
+		SELECT sp.payment_method, COUNT(DISTINCT s.id), COALESCE(SUM(sp.amount * s.exchange_rate), 0)
+		FROM sale_payments sp
+		JOIN sales s ON s.id = sp.sale_id
+		WHERE s.tenant_id = $1 AND s.created_by = $2 AND s.status IN ('completed', 'refunded')
+			AND s.deleted_at IS NULL AND s.completed_at >= $3 AND s.completed_at < $4
+		GROUP BY sp.payment_method
+		ORDER BY sp.payment_method`
+
+	totals.Payments, err = r.queryPaymentTotals(ctx, paymentsQuery, tenantID, cashierID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum sale payments: %w", err)
+	}
+
+	refundsQuery := `
+		SELECT r.refund_method, COUNT(*), COALESCE(SUM(r.total_amount * s.exchange_rate), 0)
+		FROM refunds r
+		JOIN sales s ON s.id = r.sale_id
+		WHERE r.tenant_id = $1 AND r.created_by = $2 AND r.created_at >= $3 AND r.created_at < $4
+		GROUP BY r.refund_method
+		ORDER BY r.refund_method`
+
+	totals.Refunds, err = r.queryPaymentTotals(ctx, refundsQuery, tenantID, cashierID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum refunds: %w", err)
+	}
+
+	totals.RefundAmount = decimal.Zero
+	for _, refund := range totals.Refunds {
+		totals.RefundCount += refund.Count
+		totals.RefundAmount = totals.RefundAmount.Add(refund.Amount)
+	}
+
+	return &totals, nil
+}
+
+// Helper functions
+
+// queryPaymentTotals runs a query returning payment method, count and amount rows
+func (r *PostgresRegisterSessionRepository) queryPaymentTotals(ctx context.Context, query string, args ...interface{}) ([]entities.RegisterPaymentTotal, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []entities.RegisterPaymentTotal{}
+	for rows.Next() {
+		var total entities.RegisterPaymentTotal
+		if err := rows.Scan(&total.PaymentMethod, &total.Count, &total.Amount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// querySessions runs a query returning register session rows
+func (r *PostgresRegisterSessionRepository) querySessions(ctx context.Context, query string, args ...interface{}) ([]*entities.RegisterSession, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query register sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*entities.RegisterSession{}
+	for rows.Next() {
+		session, err := r.scanRegisterSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan register session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate register sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// scanRegisterSession scans a register session from a row
+func (r *PostgresRegisterSessionRepository) scanRegisterSession(row interface{ Scan(...interface{}) error }) (*entities.RegisterSession, error) {
+	var session entities.RegisterSession
+	var movementsJSON []byte
+	var cashSales, cashRefunds, expectedCash, countedCash decimal.NullDecimal
+	var closedBy uuid.NullUUID
+	var closedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID, &session.TenantID, &session.Register, &session.Status, &session.OpeningFloat, &movementsJSON,
+		&cashSales, &cashRefunds, &expectedCash, &countedCash, &session.Notes, &session.OpenedBy, &session.OpenedAt,
+		&closedBy, &closedAt, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(movementsJSON, &session.Movements); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cash movements: %w", err)
+	}
+	if cashSales.Valid {
+		session.CashSales = &cashSales.Decimal
+	}
+	if cashRefunds.Valid {
+		session.CashRefunds = &cashRefunds.Decimal
+	}
+	if expectedCash.Valid {
+		session.ExpectedCash = &expectedCash.Decimal
+	}
+	if countedCash.Valid {
+		session.CountedCash = &countedCash.Decimal
+	}
+	if closedBy.Valid {
+		session.ClosedBy = &closedBy.UUID
+	}
+	if closedAt.Valid {
+		session.ClosedAt = &closedAt.Time
+	}
+
+	return &session, nil
+}
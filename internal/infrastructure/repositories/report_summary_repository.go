@@ -0,0 +1,412 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/infrastructure/database"
+)
+
+// summaryRefreshGrace is how long before a day was last summarized a change still marks it
+// stale. A change committed while the day was being summarized can carry an earlier timestamp
+// than the refresh without having been seen by it.
+const summaryRefreshGrace = "5 minutes"
+
+// salesSummaryRefreshSQL recomputes the sales summaries of the days from $2 up to $3
+// (exclusive) for tenant $1, including days without sales
+const salesSummaryRefreshSQL = `
+	WITH days AS (
+		SELECT d::date AS day FROM generate_series($2::date, $3::date - 1, INTERVAL '1 day') AS d
+	),
+	sale_totals AS (
+		SELECT DATE(created_at) AS day,
+			COUNT(*) AS total_sales,
+			SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) AS completed_sales,
+			SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END) AS cancelled_sales,
+			SUM(CASE WHEN status = 'refunded' THEN 1 ELSE 0 END) AS refunded_sales,
+			SUM(total_amount * exchange_rate) AS total_revenue,
+			SUM(CASE WHEN status = 'completed' THEN total_amount * exchange_rate ELSE 0 END) AS completed_revenue,
+			SUM(CASE WHEN status = 'completed' THEN surcharge_amount * exchange_rate ELSE 0 END) AS surcharge_revenue
+		FROM sales
+		WHERE tenant_id = $1 AND created_at >= $2::date AND created_at < $3::date AND deleted_at IS NULL
+		GROUP BY DATE(created_at)
+	),
+	item_totals AS (
+		SELECT DATE(s.created_at) AS day,
+			SUM(si.quantity) AS items_sold,
+			SUM(CASE WHEN s.status = 'completed' THEN si.unit_cost * si.quantity * s.exchange_rate ELSE 0 END) AS cost_of_goods,
+			SUM(CASE WHEN s.status = 'completed'
+				THEN (si.total_price - si.unit_cost * si.quantity) * s.exchange_rate ELSE 0 END) AS total_profit
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.tenant_id = $1 AND s.created_at >= $2::date AND s.created_at < $3::date AND s.deleted_at IS NULL
+		GROUP BY DATE(s.created_at)
+	),
+	refund_totals AS (
+		SELECT DATE(r.created_at) AS day,
+			COUNT(*) AS refund_count,
+			SUM(r.total_amount * s.exchange_rate) AS refunded_amount
+		FROM refunds r
+		JOIN sales s ON s.id = r.sale_id
+		WHERE r.tenant_id = $1 AND r.created_at >= $2::date AND r.created_at < $3::date
+		GROUP BY DATE(r.created_at)
+	)
+	INSERT INTO sales_daily_summaries (tenant_id, summary_date, total_sales, completed_sales, cancelled_sales,
+		refunded_sales, total_revenue, completed_revenue, surcharge_revenue, items_sold, cost_of_goods,
+		total_profit, refund_count, refunded_amount, refreshed_at)
+	SELECT $1, days.day,
+		COALESCE(st.total_sales, 0), COALESCE(st.completed_sales, 0), COALESCE(st.cancelled_sales, 0),
+		COALESCE(st.refunded_sales, 0), COALESCE(st.total_revenue, 0), COALESCE(st.completed_revenue, 0),
+		COALESCE(st.surcharge_revenue, 0), COALESCE(it.items_sold, 0), COALESCE(it.cost_of_goods, 0),
+		COALESCE(it.total_profit, 0), COALESCE(rt.refund_count, 0), COALESCE(rt.refunded_amount, 0), NOW()
+	FROM days
+	LEFT JOIN sale_totals st ON st.day = days.day
+	LEFT JOIN item_totals it ON it.day = days.day
+	LEFT JOIN refund_totals rt ON rt.day = days.day
+	ON CONFLICT (tenant_id, summary_date) DO UPDATE SET
+		total_sales = EXCLUDED.total_sales,
+		completed_sales = EXCLUDED.completed_sales,
+		cancelled_sales = EXCLUDED.cancelled_sales,
+		refunded_sales = EXCLUDED.refunded_sales,
+		total_revenue = EXCLUDED.total_revenue,
+		completed_revenue = EXCLUDED.completed_revenue,
+		surcharge_revenue = EXCLUDED.surcharge_revenue,
+		items_sold = EXCLUDED.items_sold,
+		cost_of_goods = EXCLUDED.cost_of_goods,
+		total_profit = EXCLUDED.total_profit,
+		refund_count = EXCLUDED.refund_count,
+		refunded_amount = EXCLUDED.refunded_amount,
+		refreshed_at = EXCLUDED.refreshed_at`
+
+// invoiceSummaryRefreshSQL recomputes the invoice summaries of the days from $2 up to $3
+// (exclusive) for tenant $1, including days without invoices
+const invoiceSummaryRefreshSQL = `
+	WITH days AS (
+		SELECT d::date AS day FROM generate_series($2::date, $3::date - 1, INTERVAL '1 day') AS d
+	),
+	invoice_totals AS (
+		SELECT DATE(created_at) AS day,
+			COUNT(*) AS total_invoices,
+			SUM(total_amount * exchange_rate) AS total_amount,
+			SUM(paid_amount * exchange_rate) AS paid_amount,
+			SUM(CASE WHEN status IN ('draft', 'generated', 'sent')
+				THEN GREATEST(total_amount - paid_amount, 0) * exchange_rate ELSE 0 END) AS unpaid_balance,
+			SUM(CASE WHEN status = 'partially_paid'
+				THEN (total_amount - paid_amount) * exchange_rate ELSE 0 END) AS partially_paid_balance,
+			SUM(CASE WHEN status = 'draft' THEN 1 ELSE 0 END) AS draft_invoices,
+			SUM(CASE WHEN status = 'generated' THEN 1 ELSE 0 END) AS generated_invoices,
+			SUM(CASE WHEN status = 'sent' THEN 1 ELSE 0 END) AS sent_invoices,
+			SUM(CASE WHEN status = 'partially_paid' THEN 1 ELSE 0 END) AS partially_paid_invoices,
+			SUM(CASE WHEN status = 'paid' THEN 1 ELSE 0 END) AS paid_invoices,
+			SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END) AS cancelled_invoices,
+			SUM(CASE WHEN status = 'paid' AND paid_at IS NOT NULL THEN 1 ELSE 0 END) AS timed_paid_invoices,
+			SUM(CASE WHEN status = 'paid' AND paid_at IS NOT NULL
+				THEN EXTRACT(DAY FROM (paid_at - created_at)) ELSE 0 END) AS payment_days
+		FROM invoices
+		WHERE tenant_id = $1 AND created_at >= $2::date AND created_at < $3::date AND deleted_at IS NULL
+		GROUP BY DATE(created_at)
+	)
+	INSERT INTO invoice_daily_summaries (tenant_id, summary_date, total_invoices, total_amount, paid_amount,
+		unpaid_balance, partially_paid_balance, draft_invoices, generated_invoices, sent_invoices,
+		partially_paid_invoices, paid_invoices, cancelled_invoices, timed_paid_invoices, payment_days, refreshed_at)
+	SELECT $1, days.day,
+		COALESCE(it.total_invoices, 0), COALESCE(it.total_amount, 0), COALESCE(it.paid_amount, 0),
+		COALESCE(it.unpaid_balance, 0), COALESCE(it.partially_paid_balance, 0), COALESCE(it.draft_invoices, 0),
+		COALESCE(it.generated_invoices, 0), COALESCE(it.sent_invoices, 0), COALESCE(it.partially_paid_invoices, 0),
+		COALESCE(it.paid_invoices, 0), COALESCE(it.cancelled_invoices, 0), COALESCE(it.timed_paid_invoices, 0),
+		COALESCE(it.payment_days, 0), NOW()
+	FROM days
+	LEFT JOIN invoice_totals it ON it.day = days.day
+	ON CONFLICT (tenant_id, summary_date) DO UPDATE SET
+		total_invoices = EXCLUDED.total_invoices,
+		total_amount = EXCLUDED.total_amount,
+		paid_amount = EXCLUDED.paid_amount,
+		unpaid_balance = EXCLUDED.unpaid_balance,
+		partially_paid_balance = EXCLUDED.partially_paid_balance,
+		draft_invoices = EXCLUDED.draft_invoices,
+		generated_invoices = EXCLUDED.generated_invoices,
+		sent_invoices = EXCLUDED.sent_invoices,
+		partially_paid_invoices = EXCLUDED.partially_paid_invoices,
+		paid_invoices = EXCLUDED.paid_invoices,
+		cancelled_invoices = EXCLUDED.cancelled_invoices,
+		timed_paid_invoices = EXCLUDED.timed_paid_invoices,
+		payment_days = EXCLUDED.payment_days,
+		refreshed_at = EXCLUDED.refreshed_at`
+
+// PostgresReportSummaryRepository implements the ReportSummaryRepository interface
+type PostgresReportSummaryRepository struct {
+	db *sql.DB
+
+	// The breakdowns that cannot be added up across days are still read from the sales and
+	// invoices themselves
+	sales    *PostgresSaleRepository
+	invoices *PostgresInvoiceRepository
+}
+
+// NewPostgresReportSummaryRepository creates a new PostgreSQL report summary repository
+func NewPostgresReportSummaryRepository(db *sql.DB) repositories.ReportSummaryRepository {
+	return &PostgresReportSummaryRepository{
+		db:       db,
+		sales:    &PostgresSaleRepository{db: db},
+		invoices: &PostgresInvoiceRepository{db: db},
+	}
+}
+
+// GetStaleDays lists the days whose sales, refunds or invoices changed after since and after
+// the day was last summarized, most recent first
+func (r *PostgresReportSummaryRepository) GetStaleDays(ctx context.Context, since time.Time, limit int) ([]*repositories.SummaryDay, error) {
+	query := `
+		SELECT tenant_id, day FROM (
+			SELECT s.tenant_id, DATE(s.created_at) AS day
+			FROM sales s
+			LEFT JOIN sales_daily_summaries d ON d.tenant_id = s.tenant_id AND d.summary_date = DATE(s.created_at)
+			WHERE s.updated_at > $1 AND s.tenant_id IS NOT NULL
+				AND (d.refreshed_at IS NULL OR s.updated_at > d.refreshed_at - INTERVAL '` + summaryRefreshGrace + `')
+			UNION
+			SELECT rf.tenant_id, DATE(rf.created_at) AS day
+			FROM refunds rf
+			LEFT JOIN sales_daily_summaries d ON d.tenant_id = rf.tenant_id AND d.summary_date = DATE(rf.created_at)
+			WHERE rf.created_at > $1
+				AND (d.refreshed_at IS NULL OR rf.created_at > d.refreshed_at - INTERVAL '` + summaryRefreshGrace + `')
+			UNION
+			SELECT i.tenant_id, DATE(i.created_at) AS day
+			FROM invoices i
+			LEFT JOIN invoice_daily_summaries d ON d.tenant_id = i.tenant_id AND d.summary_date = DATE(i.created_at)
+			WHERE i.updated_at > $1 AND i.tenant_id IS NOT NULL
+				AND (d.refreshed_at IS NULL OR i.updated_at > d.refreshed_at - INTERVAL '` + summaryRefreshGrace + `')
+		) stale
+		ORDER BY day DESC, tenant_id
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale summary days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*repositories.SummaryDay
+	for rows.Next() {
+		var day repositories.SummaryDay
+		if err := rows.Scan(&day.TenantID, &day.Date); err != nil {
+			return nil, fmt.Errorf("failed to scan stale summary day: %w", err)
+		}
+		days = append(days, &day)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stale summary days: %w", err)
+	}
+
+	return days, nil
+}
+
+// GetMissingSalesDays lists the days from fromDate up to toDate (exclusive) that have no sales
+// summary yet
+func (r *PostgresReportSummaryRepository) GetMissingSalesDays(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]time.Time, error) {
+	return r.getMissingDays(ctx, "sales_daily_summaries", tenantID, fromDate, toDate)
+}
+
+// GetMissingInvoiceDays lists the days from fromDate up to toDate (exclusive) that have no
+// invoice summary yet
+func (r *PostgresReportSummaryRepository) GetMissingInvoiceDays(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]time.Time, error) {
+	return r.getMissingDays(ctx, "invoice_daily_summaries", tenantID, fromDate, toDate)
+}
+
+// RefreshSalesSummaries recomputes the sales summary of every day from fromDate up to toDate
+// (exclusive)
+func (r *PostgresReportSummaryRepository) RefreshSalesSummaries(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) error {
+	if _, err := r.db.ExecContext(ctx, salesSummaryRefreshSQL, tenantID, fromDate, toDate); err != nil {
+		return fmt.Errorf("failed to refresh sales summaries: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshInvoiceSummaries recomputes the invoice summary of every day from fromDate up to
+// toDate (exclusive)
+func (r *PostgresReportSummaryRepository) RefreshInvoiceSummaries(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) error {
+	if _, err := r.db.ExecContext(ctx, invoiceSummaryRefreshSQL, tenantID, fromDate, toDate); err != nil {
+		return fmt.Errorf("failed to refresh invoice summaries: %w", err)
+	}
+
+	return nil
+}
+
+// GetSalesReport builds the sales report of a date range from the daily summaries. Unique
+// customers and the payment method and channel breakdowns are read from the sales.
+func (r *PostgresReportSummaryRepository) GetSalesReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*repositories.SalesReport, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT
+			COALESCE(SUM(total_sales), 0), COALESCE(SUM(completed_sales), 0),
+			COALESCE(SUM(cancelled_sales), 0), COALESCE(SUM(refunded_sales), 0),
+			COALESCE(SUM(total_revenue), 0), COALESCE(SUM(completed_revenue), 0),
+			COALESCE(SUM(surcharge_revenue), 0), COALESCE(SUM(items_sold), 0),
+			COALESCE(SUM(cost_of_goods), 0), COALESCE(SUM(total_profit), 0),
+			COALESCE(SUM(refund_count), 0), COALESCE(SUM(refunded_amount), 0)
+		FROM sales_daily_summaries
+		WHERE tenant_id = $1 AND summary_date >= $2::date AND summary_date < $3::date`
+
+	report := repositories.SalesReport{FromDate: fromDate, ToDate: toDate}
+	var completedRevenue decimal.Decimal
+	err := db.QueryRowContext(ctx, query, tenantID, fromDate, toDate).Scan(
+		&report.TotalSales, &report.CompletedSales, &report.CancelledSales, &report.RefundedSales,
+		&report.TotalRevenue, &completedRevenue, &report.SurchargeRevenue, &report.TotalItemsSold,
+		&report.CostOfGoods, &report.TotalProfit, &report.RefundCount, &report.RefundedAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales summary totals: %w", err)
+	}
+
+	if report.CompletedSales > 0 {
+		report.AverageOrderValue = completedRevenue.Div(decimal.NewFromInt(int64(report.CompletedSales))).Round(2)
+	}
+	report.NetRevenue = report.TotalRevenue.Sub(report.RefundedAmount)
+
+	dailyQuery := `
+		SELECT summary_date, completed_sales, completed_revenue
+		FROM sales_daily_summaries
+		WHERE tenant_id = $1 AND summary_date >= $2::date AND summary_date < $3::date AND completed_sales > 0
+		ORDER BY summary_date`
+
+	rows, err := db.QueryContext(ctx, dailyQuery, tenantID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily sales summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data repositories.DailySalesData
+		if err := rows.Scan(&data.Date, &data.TotalSales, &data.TotalRevenue); err != nil {
+			return nil, fmt.Errorf("failed to scan daily sales summary: %w", err)
+		}
+		report.DailySales = append(report.DailySales, data)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate daily sales summaries: %w", err)
+	}
+
+	if report.UniqueCustomers, err = r.sales.getUniqueCustomers(ctx, fromDate, toDate); err != nil {
+		return nil, err
+	}
+	if report.PaymentMethodStats, err = r.sales.getPaymentMethodStats(ctx, fromDate, toDate); err != nil {
+		return nil, err
+	}
+	if report.ChannelStats, err = r.sales.getSalesChannelStats(ctx, fromDate, toDate); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// GetInvoiceReport builds the invoice report of a date range from the daily summaries. The
+// overdue count, which changes as due dates pass, and the payment method breakdown are read
+// from the invoices.
+func (r *PostgresReportSummaryRepository) GetInvoiceReport(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*repositories.InvoiceReport, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT
+			COALESCE(SUM(total_invoices), 0), COALESCE(SUM(total_amount), 0), COALESCE(SUM(paid_amount), 0),
+			COALESCE(SUM(unpaid_balance), 0), COALESCE(SUM(partially_paid_balance), 0),
+			COALESCE(SUM(draft_invoices), 0), COALESCE(SUM(generated_invoices), 0),
+			COALESCE(SUM(sent_invoices), 0), COALESCE(SUM(partially_paid_invoices), 0),
+			COALESCE(SUM(paid_invoices), 0), COALESCE(SUM(cancelled_invoices), 0),
+			COALESCE(SUM(timed_paid_invoices), 0), COALESCE(SUM(payment_days), 0)
+		FROM invoice_daily_summaries
+		WHERE tenant_id = $1 AND summary_date >= $2::date AND summary_date < $3::date`
+
+	report := repositories.InvoiceReport{FromDate: fromDate, ToDate: toDate}
+	var timedPaidInvoices int
+	var paymentDays decimal.Decimal
+	err := db.QueryRowContext(ctx, query, tenantID, fromDate, toDate).Scan(
+		&report.TotalInvoices, &report.TotalAmount, &report.PaidAmount, &report.UnpaidBalance,
+		&report.PartiallyPaidBalance, &report.DraftInvoices, &report.GeneratedInvoices, &report.SentInvoices,
+		&report.PartiallyPaidInvoices, &report.PaidInvoices, &report.CancelledInvoices,
+		&timedPaidInvoices, &paymentDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice summary totals: %w", err)
+	}
+
+	report.OutstandingAmount = report.TotalAmount.Sub(report.PaidAmount)
+	if timedPaidInvoices > 0 {
+		report.AveragePaymentTime = paymentDays.Div(decimal.NewFromInt(int64(timedPaidInvoices)))
+	}
+
+	monthlyQuery := `
+		SELECT DATE_TRUNC('month', summary_date), SUM(total_invoices), SUM(total_amount), SUM(paid_amount)
+		FROM invoice_daily_summaries
+		WHERE tenant_id = $1 AND summary_date >= $2::date AND summary_date < $3::date
+		GROUP BY DATE_TRUNC('month', summary_date)
+		HAVING SUM(total_invoices) > 0
+		ORDER BY DATE_TRUNC('month', summary_date)`
+
+	rows, err := db.QueryContext(ctx, monthlyQuery, tenantID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query monthly invoice summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data repositories.MonthlyInvoiceData
+		if err := rows.Scan(&data.Month, &data.TotalInvoices, &data.TotalAmount, &data.PaidAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly invoice summary: %w", err)
+		}
+		report.MonthlyInvoices = append(report.MonthlyInvoices, data)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate monthly invoice summaries: %w", err)
+	}
+
+	if report.OverdueInvoices, err = r.invoices.getOverdueInvoiceCount(ctx, fromDate, toDate); err != nil {
+		return nil, err
+	}
+	if report.PaymentMethodStats, err = r.invoices.getInvoicePaymentMethodStats(ctx, fromDate, toDate); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// getMissingDays lists the days from fromDate up to toDate (exclusive) without a row in a
+// daily summary table
+func (r *PostgresReportSummaryRepository) getMissingDays(ctx context.Context, table string, tenantID uuid.UUID, fromDate, toDate time.Time) ([]time.Time, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT d::date
+		FROM generate_series($2::date, $3::date - 1, INTERVAL '1 day') AS d
+		WHERE NOT EXISTS (
+			SELECT 1 FROM ` + table + ` s WHERE s.tenant_id = $1 AND s.summary_date = d::date
+		)
+		ORDER BY d`
+
+	rows, err := db.QueryContext(ctx, query, tenantID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query missing summary days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("failed to scan missing summary day: %w", err)
+		}
+		days = append(days, day)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate missing summary days: %w", err)
+	}
+
+	return days, nil
+}
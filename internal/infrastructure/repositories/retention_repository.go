@@ -0,0 +1,487 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// retentionTarget describes where an entity's records live and which of them are purge candidates.
+// Candidate queries take the tenant ID as $1 and the cutoff as $2, and select record IDs.
+type retentionTarget struct {
+	table      string
+	candidates string
+}
+
+// retentionTargets maps each retention entity to its purge target. Audit logs have no tenant
+// column and are scoped through the acting user; they are also kept while any hold references
+// the audited record. Archived sales are soft-deleted sales, kept while they are invoiced or
+// refunded; deleted invoices are kept while a reserved invoice number is bound to them.
+var retentionTargets = map[entities.RetentionEntity]retentionTarget{
+	entities.RetentionEntityAuditLogs: {
+		table: "audit_logs",
+		candidates: `
+			SELECT a.id FROM audit_logs a
+			JOIN users u ON u.id = a.user_id
+			WHERE u.tenant_id = $1 AND a.timestamp < $2
+				AND NOT EXISTS (
+					SELECT 1 FROM retention_holds h
+					WHERE h.tenant_id = $1 AND h.released_at IS NULL
+						AND (h.record_id = a.id OR h.record_id::text = a.resource_id)
+				)`,
+	},
+	entities.RetentionEntityStockMovements: {
+		table: "stock_movements",
+		candidates: `
+			SELECT m.id FROM stock_movements m
+			JOIN products p ON p.id = m.product_id
+			WHERE p.tenant_id = $1 AND m.created_at < $2
+				AND NOT EXISTS (
+					SELECT 1 FROM retention_holds h
+					WHERE h.tenant_id = $1 AND h.released_at IS NULL
+						AND h.entity = 'stock_movements' AND h.record_id = m.id
+				)`,
+	},
+	entities.RetentionEntityArchivedSales: {
+		table: "sales",
+		candidates: `
+			SELECT s.id FROM sales s
+			WHERE s.tenant_id = $1 AND s.deleted_at IS NOT NULL AND s.deleted_at < $2
+				AND NOT EXISTS (SELECT 1 FROM invoices i WHERE i.sale_id = s.id)
+				AND NOT EXISTS (SELECT 1 FROM refunds f WHERE f.sale_id = s.id)
+				AND NOT EXISTS (
+					SELECT 1 FROM retention_holds h
+					WHERE h.tenant_id = $1 AND h.released_at IS NULL
+						AND h.entity = 'archived_sales' AND h.record_id = s.id
+				)`,
+	},
+	entities.RetentionEntityDeletedInvoices: {
+		table: "invoices",
+		candidates: `
+			SELECT i.id FROM invoices i
+			WHERE i.tenant_id = $1 AND i.deleted_at IS NOT NULL AND i.deleted_at < $2
+				AND NOT EXISTS (SELECT 1 FROM invoice_number_reservations n WHERE n.invoice_id = i.id)
+				AND NOT EXISTS (
+					SELECT 1 FROM retention_holds h
+					WHERE h.tenant_id = $1 AND h.released_at IS NULL
+						AND h.entity = 'deleted_invoices' AND h.record_id = i.id
+				)`,
+	},
+}
+
+// deletedRecordTarget describes where the soft-deleted records of a restorable entity live.
+// restoreBlocker selects why a record of the table aliased t cannot be restored, or nothing.
+type deletedRecordTarget struct {
+	record         string
+	table          string
+	numberColumn   string
+	restoreBlocker string
+}
+
+// deletedRecordTargets maps each restorable retention entity to its table. An invoice cannot be
+// restored while its sale is deleted, as it would reference a sale that no longer shows anywhere.
+var deletedRecordTargets = map[entities.RetentionEntity]deletedRecordTarget{
+	entities.RetentionEntityArchivedSales: {
+		record:       "sale",
+		table:        "sales",
+		numberColumn: "sale_number",
+	},
+	entities.RetentionEntityDeletedInvoices: {
+		record:       "invoice",
+		table:        "invoices",
+		numberColumn: "invoice_number",
+		restoreBlocker: `
+			SELECT 'the sale of this invoice is deleted; restore the sale first'
+			FROM sales s WHERE s.id = t.sale_id AND s.deleted_at IS NOT NULL`,
+	},
+}
+
+// PostgresRetentionPolicyRepository implements the RetentionPolicyRepository interface
+type PostgresRetentionPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRetentionPolicyRepository creates a new PostgreSQL retention policy repository
+func NewPostgresRetentionPolicyRepository(db *sql.DB) repositories.RetentionPolicyRepository {
+	return &PostgresRetentionPolicyRepository{db: db}
+}
+
+// GetByTenant retrieves all retention policies of a tenant
+func (r *PostgresRetentionPolicyRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.RetentionPolicy, error) {
+	query := `
+		SELECT id, tenant_id, entity, retention_days, is_enabled, last_purged_at, created_at, updated_at, updated_by
+		FROM retention_policies
+		WHERE tenant_id = $1
+		ORDER BY entity`
+
+	return r.queryRetentionPolicies(ctx, query, tenantID)
+}
+
+// Save creates or updates the retention policy of a tenant for the policy's entity
+func (r *PostgresRetentionPolicyRepository) Save(ctx context.Context, policy *entities.RetentionPolicy) error {
+	query := `
+		INSERT INTO retention_policies (id, tenant_id, entity, retention_days, is_enabled, last_purged_at,
+			created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (tenant_id, entity) DO UPDATE SET
+			retention_days = EXCLUDED.retention_days,
+			is_enabled = EXCLUDED.is_enabled,
+			last_purged_at = EXCLUDED.last_purged_at,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		policy.ID, policy.TenantID, policy.Entity, policy.RetentionDays, policy.IsEnabled, policy.LastPurgedAt,
+		policy.CreatedAt, policy.UpdatedAt, policy.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save retention policy: %w", err)
+	}
+
+	return nil
+}
+
+// ListEnabled retrieves all enabled retention policies across tenants
+func (r *PostgresRetentionPolicyRepository) ListEnabled(ctx context.Context) ([]*entities.RetentionPolicy, error) {
+	query := `
+		SELECT id, tenant_id, entity, retention_days, is_enabled, last_purged_at, created_at, updated_at, updated_by
+		FROM retention_policies
+		WHERE is_enabled = true
+		ORDER BY tenant_id, entity`
+
+	return r.queryRetentionPolicies(ctx, query)
+}
+
+// CountExpired counts the tenant's records of an entity older than cutoff that are not on hold
+func (r *PostgresRetentionPolicyRepository) CountExpired(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, cutoff time.Time) (int64, error) {
+	target, ok := retentionTargets[entity]
+	if !ok {
+		return 0, errors.NewValidationError("invalid retention entity", "unsupported entity: "+string(entity))
+	}
+
+	query := `SELECT COUNT(*) FROM (` + target.candidates + `) candidates`
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, tenantID, cutoff).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count expired %s: %w", entity, err)
+	}
+
+	return count, nil
+}
+
+// PurgeExpired permanently deletes up to limit of the tenant's expired records of an entity
+func (r *PostgresRetentionPolicyRepository) PurgeExpired(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, cutoff time.Time, limit int) (int64, error) {
+	target, ok := retentionTargets[entity]
+	if !ok {
+		return 0, errors.NewValidationError("invalid retention entity", "unsupported entity: "+string(entity))
+	}
+
+	query := `DELETE FROM ` + target.table + ` WHERE id IN (` + target.candidates + ` LIMIT $3)`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, cutoff, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired %s: %w", entity, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// Helper functions
+
+// queryRetentionPolicies runs a retention policy query and scans the results
+func (r *PostgresRetentionPolicyRepository) queryRetentionPolicies(ctx context.Context, query string, args ...interface{}) ([]*entities.RetentionPolicy, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retention policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*entities.RetentionPolicy
+	for rows.Next() {
+		var policy entities.RetentionPolicy
+		var lastPurgedAt sql.NullTime
+		err := rows.Scan(
+			&policy.ID, &policy.TenantID, &policy.Entity, &policy.RetentionDays, &policy.IsEnabled,
+			&lastPurgedAt, &policy.CreatedAt, &policy.UpdatedAt, &policy.UpdatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan retention policy: %w", err)
+		}
+		if lastPurgedAt.Valid {
+			policy.LastPurgedAt = &lastPurgedAt.Time
+		}
+		policies = append(policies, &policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate retention policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// PostgresRetentionHoldRepository implements the RetentionHoldRepository interface
+type PostgresRetentionHoldRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRetentionHoldRepository creates a new PostgreSQL retention hold repository
+func NewPostgresRetentionHoldRepository(db *sql.DB) repositories.RetentionHoldRepository {
+	return &PostgresRetentionHoldRepository{db: db}
+}
+
+// Create creates a new retention hold
+func (r *PostgresRetentionHoldRepository) Create(ctx context.Context, hold *entities.RetentionHold) error {
+	query := `
+		INSERT INTO retention_holds (id, tenant_id, entity, record_id, reason, created_at, created_by,
+			released_at, released_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		hold.ID, hold.TenantID, hold.Entity, hold.RecordID, hold.Reason, hold.CreatedAt, hold.CreatedBy,
+		hold.ReleasedAt, hold.ReleasedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert retention hold: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a retention hold by ID
+func (r *PostgresRetentionHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.RetentionHold, error) {
+	query := `
+		SELECT id, tenant_id, entity, record_id, reason, created_at, created_by, released_at, released_by
+		FROM retention_holds
+		WHERE id = $1`
+
+	hold, err := r.scanRetentionHold(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("retention hold")
+		}
+		return nil, fmt.Errorf("failed to get retention hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+// Update updates an existing retention hold
+func (r *PostgresRetentionHoldRepository) Update(ctx context.Context, hold *entities.RetentionHold) error {
+	query := `
+		UPDATE retention_holds SET
+			reason = $2, released_at = $3, released_by = $4
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, hold.ID, hold.Reason, hold.ReleasedAt, hold.ReleasedBy)
+	if err != nil {
+		return fmt.Errorf("failed to update retention hold: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("retention hold")
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves the retention holds of a tenant, newest first
+func (r *PostgresRetentionHoldRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]*entities.RetentionHold, error) {
+	query := `
+		SELECT id, tenant_id, entity, record_id, reason, created_at, created_by, released_at, released_by
+		FROM retention_holds
+		WHERE tenant_id = $1 AND (NOT $2 OR released_at IS NULL)
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retention holds: %w", err)
+	}
+	defer rows.Close()
+
+	var holds []*entities.RetentionHold
+	for rows.Next() {
+		hold, err := r.scanRetentionHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan retention hold: %w", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate retention holds: %w", err)
+	}
+
+	return holds, nil
+}
+
+// GetActiveByRecord retrieves the active hold on a record, if any
+func (r *PostgresRetentionHoldRepository) GetActiveByRecord(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, recordID uuid.UUID) (*entities.RetentionHold, error) {
+	query := `
+		SELECT id, tenant_id, entity, record_id, reason, created_at, created_by, released_at, released_by
+		FROM retention_holds
+		WHERE tenant_id = $1 AND entity = $2 AND record_id = $3 AND released_at IS NULL`
+
+	hold, err := r.scanRetentionHold(r.db.QueryRowContext(ctx, query, tenantID, entity, recordID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("retention hold")
+		}
+		return nil, fmt.Errorf("failed to get retention hold: %w", err)
+	}
+
+	return hold, nil
+}
+
+// scanRetentionHold scans a retention hold from a row
+func (r *PostgresRetentionHoldRepository) scanRetentionHold(row interface{ Scan(...interface{}) error }) (*entities.RetentionHold, error) {
+	var hold entities.RetentionHold
+	var releasedAt sql.NullTime
+	var releasedBy uuid.NullUUID
+
+	err := row.Scan(
+		&hold.ID, &hold.TenantID, &hold.Entity, &hold.RecordID, &hold.Reason, &hold.CreatedAt, &hold.CreatedBy,
+		&releasedAt, &releasedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if releasedAt.Valid {
+		hold.ReleasedAt = &releasedAt.Time
+	}
+	if releasedBy.Valid {
+		hold.ReleasedBy = &releasedBy.UUID
+	}
+
+	return &hold, nil
+}
+
+// PostgresDeletedRecordRepository implements the DeletedRecordRepository interface
+type PostgresDeletedRecordRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDeletedRecordRepository creates a new PostgreSQL deleted record repository
+func NewPostgresDeletedRecordRepository(db *sql.DB) repositories.DeletedRecordRepository {
+	return &PostgresDeletedRecordRepository{db: db}
+}
+
+// List retrieves a tenant's soft-deleted records of an entity, most recently deleted first
+func (r *PostgresDeletedRecordRepository) List(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, pagination utils.PaginationInfo) ([]*entities.DeletedRecord, utils.PaginationInfo, error) {
+	target, ok := deletedRecordTargets[entity]
+	if !ok {
+		return nil, pagination, errors.NewValidationError("invalid retention entity", "records of "+string(entity)+" cannot be restored")
+	}
+
+	countQuery := `SELECT COUNT(*) FROM ` + target.table + ` WHERE tenant_id = $1 AND deleted_at IS NOT NULL`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, tenantID).Scan(&total); err != nil {
+		return nil, pagination, fmt.Errorf("failed to count deleted %s: %w", entity, err)
+	}
+
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(paginationResult.Page, paginationResult.Limit)
+
+	query := `
+		SELECT t.id, t.` + target.numberColumn + `, COALESCE(t.customer_name, ''), t.total_amount, t.status,
+			t.created_at, t.deleted_at,
+			EXISTS (
+				SELECT 1 FROM retention_holds h
+				WHERE h.tenant_id = t.tenant_id AND h.released_at IS NULL AND h.entity = $2 AND h.record_id = t.id
+			)
+		FROM ` + target.table + ` t
+		WHERE t.tenant_id = $1 AND t.deleted_at IS NOT NULL
+		ORDER BY t.deleted_at DESC, t.id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, entity, paginationResult.Limit, offset)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to query deleted %s: %w", entity, err)
+	}
+	defer rows.Close()
+
+	records := []*entities.DeletedRecord{}
+	for rows.Next() {
+		record := &entities.DeletedRecord{Entity: entity}
+		err := rows.Scan(&record.ID, &record.Number, &record.CustomerName, &record.TotalAmount, &record.Status,
+			&record.CreatedAt, &record.DeletedAt, &record.IsOnHold)
+		if err != nil {
+			return nil, pagination, fmt.Errorf("failed to scan deleted %s: %w", entity, err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, pagination, fmt.Errorf("failed to iterate deleted %s: %w", entity, err)
+	}
+
+	return records, paginationResult, nil
+}
+
+// Restore clears the deletion of a tenant's soft-deleted record. Restoring a record whose
+// number has since been given to another record is a conflict.
+func (r *PostgresDeletedRecordRepository) Restore(ctx context.Context, tenantID uuid.UUID, entity entities.RetentionEntity, id uuid.UUID) error {
+	target, ok := deletedRecordTargets[entity]
+	if !ok {
+		return errors.NewValidationError("invalid retention entity", "records of "+string(entity)+" cannot be restored")
+	}
+
+	if target.restoreBlocker != "" {
+		query := `
+			SELECT (` + target.restoreBlocker + `)
+			FROM ` + target.table + ` t
+			WHERE t.id = $1 AND t.tenant_id = $2 AND t.deleted_at IS NOT NULL`
+
+		var blocker sql.NullString
+		err := r.db.QueryRowContext(ctx, query, id, tenantID).Scan(&blocker)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.NewNotFoundError("deleted record")
+			}
+			return fmt.Errorf("failed to check deleted %s: %w", entity, err)
+		}
+		if blocker.Valid {
+			return errors.NewConflictError(blocker.String)
+		}
+	}
+
+	query := `
+		UPDATE ` + target.table + ` SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, tenantID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("the number of this %s has since been given to another %s", target.record, target.record))
+		}
+		return fmt.Errorf("failed to restore deleted %s: %w", entity, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("deleted record")
+	}
+
+	return nil
+}
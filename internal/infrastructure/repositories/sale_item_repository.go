@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
@@ -27,12 +28,14 @@ func NewPostgresSaleItemRepository(db *sql.DB) repositories.SaleItemRepository {
 func (r *PostgresSaleItemRepository) Create(ctx context.Context, item *entities.SaleItem) error {
 	query := `
 		INSERT INTO sale_items (id, sale_id, product_id, product_sku, product_name, 
-			quantity, unit_price, total_price, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, tax_rate_id,
+			tax_rate, tax_amount, created_at, serial_numbers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		item.ID, item.SaleID, item.ProductID, item.ProductSKU, item.ProductName,
-		item.Quantity, item.UnitPrice, item.TotalPrice, item.CreatedAt)
+		item.Quantity, item.UnitPrice, item.TotalPrice, item.ListPrice, item.UnitCost, item.PriceSource,
+		item.OverrideReason, item.TaxRateID, item.TaxRate, item.TaxAmount, item.CreatedAt, pq.Array(item.SerialNumbers))
 	if err != nil {
 		return fmt.Errorf("failed to create sale item: %w", err)
 	}
@@ -44,20 +47,33 @@ func (r *PostgresSaleItemRepository) Create(ctx context.Context, item *entities.
 func (r *PostgresSaleItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.SaleItem, error) {
 	query := `
 		SELECT id, sale_id, product_id, product_sku, product_name, 
-			quantity, unit_price, total_price, created_at
+			quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, tax_rate_id,
+			tax_rate, tax_amount, created_at, returned_quantity, serial_numbers
 		FROM sale_items 
 		WHERE id = $1`
 
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
 	var item entities.SaleItem
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var taxRateID uuid.NullUUID
+	var serialNumbers pq.StringArray
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(
 		&item.ID, &item.SaleID, &item.ProductID, &item.ProductSKU, &item.ProductName,
-		&item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.CreatedAt)
+		&item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.ListPrice, &item.UnitCost, &item.PriceSource,
+		&item.OverrideReason, &taxRateID, &item.TaxRate, &item.TaxAmount, &item.CreatedAt, &item.ReturnedQuantity,
+		&serialNumbers)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("sale item")
 		}
 		return nil, fmt.Errorf("failed to get sale item: %w", err)
 	}
+	if taxRateID.Valid {
+		item.TaxRateID = &taxRateID.UUID
+	}
+	item.SerialNumbers = []string(serialNumbers)
 
 	return &item, nil
 }
@@ -66,12 +82,17 @@ func (r *PostgresSaleItemRepository) GetByID(ctx context.Context, id uuid.UUID)
 func (r *PostgresSaleItemRepository) GetBySaleID(ctx context.Context, saleID uuid.UUID) ([]*entities.SaleItem, error) {
 	query := `
 		SELECT id, sale_id, product_id, product_sku, product_name, 
-			quantity, unit_price, total_price, created_at
+			quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, tax_rate_id,
+			tax_rate, tax_amount, created_at, returned_quantity, serial_numbers
 		FROM sale_items 
-		WHERE sale_id = $1 
+		WHERE sale_id = $1%s
 		ORDER BY created_at`
 
-	rows, err := r.db.QueryContext(ctx, query, saleID)
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{saleID})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sale items: %w", err)
 	}
@@ -80,11 +101,19 @@ func (r *PostgresSaleItemRepository) GetBySaleID(ctx context.Context, saleID uui
 	var items []*entities.SaleItem
 	for rows.Next() {
 		var item entities.SaleItem
+		var taxRateID uuid.NullUUID
+		var serialNumbers pq.StringArray
 		err := rows.Scan(&item.ID, &item.SaleID, &item.ProductID, &item.ProductSKU,
-			&item.ProductName, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.CreatedAt)
+			&item.ProductName, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.ListPrice, &item.UnitCost, &item.PriceSource,
+			&item.OverrideReason, &taxRateID, &item.TaxRate, &item.TaxAmount, &item.CreatedAt, &item.ReturnedQuantity,
+			&serialNumbers)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan sale item: %w", err)
 		}
+		if taxRateID.Valid {
+			item.TaxRateID = &taxRateID.UUID
+		}
+		item.SerialNumbers = []string(serialNumbers)
 		items = append(items, &item)
 	}
 
@@ -100,12 +129,19 @@ func (r *PostgresSaleItemRepository) Update(ctx context.Context, item *entities.
 	query := `
 		UPDATE sale_items SET 
 			product_id = $2, product_sku = $3, product_name = $4,
-			quantity = $5, unit_price = $6, total_price = $7
+			quantity = $5, unit_price = $6, total_price = $7, list_price = $8,
+			unit_cost = $9, price_source = $10, override_reason = $11, tax_rate_id = $12, tax_rate = $13,
+			tax_amount = $14, serial_numbers = $15
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query,
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 		item.ID, item.ProductID, item.ProductSKU, item.ProductName,
-		item.Quantity, item.UnitPrice, item.TotalPrice)
+		item.Quantity, item.UnitPrice, item.TotalPrice, item.ListPrice, item.UnitCost, item.PriceSource,
+		item.OverrideReason, item.TaxRateID, item.TaxRate, item.TaxAmount, pq.Array(item.SerialNumbers)})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update sale item: %w", err)
 	}
@@ -123,9 +159,13 @@ func (r *PostgresSaleItemRepository) Update(ctx context.Context, item *entities.
 
 // Delete deletes a sale item
 func (r *PostgresSaleItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM sale_items WHERE id = $1`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM sale_items WHERE id = $1` + scope
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete sale item: %w", err)
 	}
@@ -155,13 +195,15 @@ func (r *PostgresSaleItemRepository) BulkCreate(ctx context.Context, items []*en
 
 	query := `
 		INSERT INTO sale_items (id, sale_id, product_id, product_sku, product_name, 
-			quantity, unit_price, total_price, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, tax_rate_id,
+			tax_rate, tax_amount, created_at, serial_numbers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 	for _, item := range items {
 		_, err := tx.ExecContext(ctx, query,
 			item.ID, item.SaleID, item.ProductID, item.ProductSKU, item.ProductName,
-			item.Quantity, item.UnitPrice, item.TotalPrice, item.CreatedAt)
+			item.Quantity, item.UnitPrice, item.TotalPrice, item.ListPrice, item.UnitCost, item.PriceSource,
+			item.OverrideReason, item.TaxRateID, item.TaxRate, item.TaxAmount, item.CreatedAt, pq.Array(item.SerialNumbers))
 		if err != nil {
 			return fmt.Errorf("failed to create sale item: %w", err)
 		}
@@ -185,13 +227,20 @@ func (r *PostgresSaleItemRepository) BulkUpdate(ctx context.Context, items []*en
 	query := `
 		UPDATE sale_items SET 
 			product_id = $2, product_sku = $3, product_name = $4,
-			quantity = $5, unit_price = $6, total_price = $7
+			quantity = $5, unit_price = $6, total_price = $7, list_price = $8,
+			unit_cost = $9, price_source = $10, override_reason = $11, tax_rate_id = $12, tax_rate = $13,
+			tax_amount = $14, serial_numbers = $15
 		WHERE id = $1`
 
 	for _, item := range items {
-		result, err := tx.ExecContext(ctx, query,
+		scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 			item.ID, item.ProductID, item.ProductSKU, item.ProductName,
-			item.Quantity, item.UnitPrice, item.TotalPrice)
+			item.Quantity, item.UnitPrice, item.TotalPrice, item.ListPrice, item.UnitCost, item.PriceSource,
+			item.OverrideReason, item.TaxRateID, item.TaxRate, item.TaxAmount, pq.Array(item.SerialNumbers)})
+		if err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, query+scope, args...)
 		if err != nil {
 			return fmt.Errorf("failed to update sale item: %w", err)
 		}
@@ -210,9 +259,13 @@ func (r *PostgresSaleItemRepository) BulkUpdate(ctx context.Context, items []*en
 
 // DeleteBySaleID deletes all items for a sale
 func (r *PostgresSaleItemRepository) DeleteBySaleID(ctx context.Context, saleID uuid.UUID) error {
-	query := `DELETE FROM sale_items WHERE sale_id = $1`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{saleID})
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM sale_items WHERE sale_id = $1` + scope
 
-	_, err := r.db.ExecContext(ctx, query, saleID)
+	_, err = r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete sale items: %w", err)
 	}
@@ -220,6 +273,33 @@ func (r *PostgresSaleItemRepository) DeleteBySaleID(ctx context.Context, saleID
 	return nil
 }
 
+// AddReturnedQuantity records a quantity of a sale item as returned. The quantity is only
+// added while it stays within the quantity sold, so concurrent returns cannot exceed it.
+func (r *PostgresSaleItemRepository) AddReturnedQuantity(ctx context.Context, id uuid.UUID, quantity int) error {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id, quantity})
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE sale_items SET returned_quantity = returned_quantity + $2
+		WHERE id = $1 AND returned_quantity + $2 <= quantity` + scope
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update returned quantity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewConflictError("sale item has already been returned")
+	}
+
+	return nil
+}
+
 // GetTopSellingProducts retrieves top selling products by quantity or revenue
 func (r *PostgresSaleItemRepository) GetTopSellingProducts(ctx context.Context, fromDate, toDate time.Time, limit int, byRevenue bool) ([]*repositories.ProductSalesStats, error) {
 	orderBy := "quantity_sold DESC"
@@ -227,24 +307,30 @@ func (r *PostgresSaleItemRepository) GetTopSellingProducts(ctx context.Context,
 		orderBy = "total_revenue DESC"
 	}
 
+	scope, args, err := tenantScope(ctx, "s.tenant_id", []interface{}{fromDate, toDate, limit})
+	if err != nil {
+		return nil, err
+	}
 	query := fmt.Sprintf(`
 		SELECT 
 			si.product_id,
 			si.product_sku,
 			si.product_name,
 			SUM(si.quantity) as quantity_sold,
-			SUM(si.total_price) as total_revenue,
-			AVG(si.unit_price) as average_price,
-			COUNT(DISTINCT s.id) as sales_count
+			SUM(si.total_price * s.exchange_rate) as total_revenue,
+			AVG(si.unit_price * s.exchange_rate) as average_price,
+			COUNT(DISTINCT s.id) as sales_count,
+			`+saleItemCostOfGoodsSQL+` as cost_of_goods,
+			`+saleItemGrossProfitSQL+` as total_profit
 		FROM sale_items si
 		JOIN sales s ON si.sale_id = s.id
 		WHERE s.created_at >= $1 AND s.created_at <= $2 
-			AND s.status = 'completed' AND s.deleted_at IS NULL
+			AND s.status = 'completed' AND s.deleted_at IS NULL%s
 		GROUP BY si.product_id, si.product_sku, si.product_name
 		ORDER BY %s
-		LIMIT $3`, orderBy)
+		LIMIT $3`, scope, orderBy)
 
-	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate, limit)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query top selling products: %w", err)
 	}
@@ -254,7 +340,8 @@ func (r *PostgresSaleItemRepository) GetTopSellingProducts(ctx context.Context,
 	for rows.Next() {
 		var product repositories.ProductSalesStats
 		err := rows.Scan(&product.ProductID, &product.ProductSKU, &product.ProductName,
-			&product.QuantitySold, &product.TotalRevenue, &product.AveragePrice, &product.SalesCount)
+			&product.QuantitySold, &product.TotalRevenue, &product.AveragePrice, &product.SalesCount,
+			&product.CostOfGoods, &product.TotalProfit)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan product sales stats: %w", err)
 		}
@@ -267,3 +354,47 @@ func (r *PostgresSaleItemRepository) GetTopSellingProducts(ctx context.Context,
 
 	return products, nil
 }
+
+// GetQuantitiesSold retrieves the units of each product sold net of returns on a tenant's
+// completed sales between two dates, keyed by product ID
+func (r *PostgresSaleItemRepository) GetQuantitiesSold(ctx context.Context, tenantID uuid.UUID, productIDs []uuid.UUID, fromDate, toDate time.Time) (map[uuid.UUID]int, error) {
+	quantities := make(map[uuid.UUID]int, len(productIDs))
+	if len(productIDs) == 0 {
+		return quantities, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT si.product_id, COALESCE(SUM(si.quantity - si.returned_quantity), 0)
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.tenant_id = $1 AND si.product_id = ANY($2::uuid[])
+			AND s.created_at >= $3 AND s.created_at <= $4
+			AND s.status = 'completed' AND s.deleted_at IS NULL
+		GROUP BY si.product_id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, pq.Array(ids), fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quantities sold: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID uuid.UUID
+		var quantity int
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan quantity sold: %w", err)
+		}
+		quantities[productID] = quantity
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate quantities sold: %w", err)
+	}
+
+	return quantities, nil
+}
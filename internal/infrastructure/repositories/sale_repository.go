@@ -13,10 +13,18 @@ import (
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/infrastructure/database"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/utils"
 )
 
+// Cost of goods sold and gross profit of sale items in the base currency, at the unit costs
+// snapshotted when their sales were completed
+const (
+	saleItemCostOfGoodsSQL = `COALESCE(SUM(si.unit_cost * si.quantity * s.exchange_rate), 0)`
+	saleItemGrossProfitSQL = `COALESCE(SUM((si.total_price - si.unit_cost * si.quantity) * s.exchange_rate), 0)`
+)
+
 // PostgresSaleRepository implements the SaleRepository interface
 type PostgresSaleRepository struct {
 	db *sql.DB
@@ -39,16 +47,28 @@ func (r *PostgresSaleRepository) Create(ctx context.Context, sale *entities.Sale
 	query := `
 		INSERT INTO sales (id, sale_number, customer_name, customer_email, customer_phone,
 			subtotal, tax_amount, discount_amount, total_amount, paid_amount, change_amount,
-			payment_method, status, notes, created_at, updated_at, completed_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+			payment_method, channel, status, notes, created_at, updated_at, completed_at, created_by,
+			tax_rate, surcharge_amount, surcharge_tax_amount, surcharge_label, tenant_id,
+			held_at, held_by, hold_expires_at, hold_label, receipt_token, payment_reference,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at, tax_inclusive)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+			$20, $21, $22, $23, $24, $25, $26, $27, $28, NULLIF($29, ''), NULLIF($30, ''), $31, $32, $33, $34, $35)`
+
+	currency, baseCurrency, exchangeRate, rateLockedAt := exchangeRateColumns(sale.ExchangeRate)
 
 	_, err = tx.ExecContext(ctx, query,
 		sale.ID, sale.SaleNumber, sale.CustomerName, sale.CustomerEmail, sale.CustomerPhone,
 		sale.Subtotal, sale.TaxAmount, sale.DiscountAmount, sale.TotalAmount,
-		sale.PaidAmount, sale.ChangeAmount, sale.PaymentMethod, sale.Status, sale.Notes,
-		sale.CreatedAt, sale.UpdatedAt, sale.CompletedAt, sale.CreatedBy)
+		sale.PaidAmount, sale.ChangeAmount, sale.PaymentMethod, sale.Channel, sale.Status, sale.Notes,
+		sale.CreatedAt, sale.UpdatedAt, sale.CompletedAt, sale.CreatedBy,
+		sale.TaxRate, sale.SurchargeAmount, sale.SurchargeTaxAmount, sale.SurchargeLabel, sale.TenantID,
+		sale.HeldAt, sale.HeldBy, sale.HoldExpiresAt, sale.HoldLabel, sale.ReceiptToken, sale.PaymentReference,
+		currency, baseCurrency, exchangeRate, rateLockedAt, sale.TaxInclusive)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			if pqErr.Constraint == "idx_sales_payment_reference" {
+				return errors.NewConflictError(fmt.Sprintf("payment '%s' already paid another sale", sale.PaymentReference))
+			}
 			return errors.NewConflictError(fmt.Sprintf("sale with sale_number '%s' already exists", sale.SaleNumber))
 		}
 		return fmt.Errorf("failed to insert sale: %w", err)
@@ -61,28 +81,59 @@ func (r *PostgresSaleRepository) Create(ctx context.Context, sale *entities.Sale
 		}
 	}
 
+	// Insert payment lines
+	if err := r.insertSalePayments(ctx, tx, sale.ID, sale.Payments); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
 // GetByID retrieves a sale by ID
 func (r *PostgresSaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Sale, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a sale by ID and locks its row until the transaction ends. Other
+// transactions locking or updating the same sale wait for it.
+func (r *PostgresSaleRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Sale, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a sale by ID, with an optional locking clause
+func (r *PostgresSaleRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.Sale, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, sale_number, customer_name, customer_email, customer_phone,
+		SELECT id, tenant_id, sale_number, customer_name, customer_email, customer_phone,
 			subtotal, tax_amount, discount_amount, total_amount, paid_amount, change_amount,
-			payment_method, status, notes, created_at, updated_at, completed_at, created_by
+			payment_method, channel, status, notes, created_at, updated_at, completed_at, created_by,
+			tax_rate, tax_inclusive, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			held_at, held_by, hold_expires_at, hold_label, receipt_token, payment_reference,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at
 		FROM sales 
-		WHERE id = $1 AND deleted_at IS NULL`
+		WHERE id = $1 AND deleted_at IS NULL` + scope + lock
 
 	var sale entities.Sale
 	var customerName, customerEmail, customerPhone, notes sql.NullString
 	var paymentMethod sql.NullString
-	var completedAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&sale.ID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
+	var completedAt, heldAt, holdExpiresAt sql.NullTime
+	var heldBy uuid.NullUUID
+	var holdLabel, receiptToken, paymentReference sql.NullString
+	var currency, baseCurrency sql.NullString
+	var exchangeRate decimal.Decimal
+	var rateLockedAt sql.NullTime
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(
+		&sale.ID, &sale.TenantID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
 		&sale.Subtotal, &sale.TaxAmount, &sale.DiscountAmount, &sale.TotalAmount,
-		&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Status, &notes,
-		&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy)
+		&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Channel, &sale.Status, &notes,
+		&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy,
+		&sale.TaxRate, &sale.TaxInclusive, &sale.SurchargeAmount, &sale.SurchargeTaxAmount, &sale.SurchargeLabel,
+		&heldAt, &heldBy, &holdExpiresAt, &holdLabel, &receiptToken, &paymentReference,
+		&currency, &baseCurrency, &exchangeRate, &rateLockedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("sale")
@@ -101,6 +152,11 @@ func (r *PostgresSaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 	if completedAt.Valid {
 		sale.CompletedAt = &completedAt.Time
 	}
+	applySaleHold(&sale, heldAt, heldBy, holdExpiresAt, holdLabel)
+	sale.ReceiptToken = receiptToken.String
+	sale.PaymentReference = paymentReference.String
+	sale.Currency = currency.String
+	sale.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
 
 	// Load sale items
 	items, err := r.getSaleItems(ctx, sale.ID)
@@ -109,28 +165,148 @@ func (r *PostgresSaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 	}
 	sale.Items = items
 
+	// Load payment lines
+	payments, err := r.getSalePayments(ctx, sale.ID)
+	if err != nil {
+		return nil, err
+	}
+	sale.Payments = payments
+
 	return &sale, nil
 }
 
+// GetByIDs retrieves the sales with the given IDs in one query, keyed by sale ID, with their
+// items and payment lines. Sales that do not exist are left out.
+func (r *PostgresSaleRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Sale, error) {
+	sales := make(map[uuid.UUID]*entities.Sale, len(ids))
+	if len(ids) == 0 {
+		return sales, nil
+	}
+
+	saleIDs := make([]string, len(ids))
+	for i, id := range ids {
+		saleIDs[i] = id.String()
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{pq.Array(saleIDs)})
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT id, tenant_id, sale_number, customer_name, customer_email, customer_phone,
+			subtotal, tax_amount, discount_amount, total_amount, paid_amount, change_amount,
+			payment_method, channel, status, notes, created_at, updated_at, completed_at, created_by,
+			tax_rate, tax_inclusive, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			held_at, held_by, hold_expires_at, hold_label, receipt_token, payment_reference,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at
+		FROM sales
+		WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL` + scope
+
+	rows, err := database.Reader(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sales: %w", err)
+	}
+	defer rows.Close()
+
+	found := make([]uuid.UUID, 0, len(ids))
+	for rows.Next() {
+		var sale entities.Sale
+		var customerName, customerEmail, customerPhone, notes sql.NullString
+		var paymentMethod sql.NullString
+		var completedAt, heldAt, holdExpiresAt sql.NullTime
+		var heldBy uuid.NullUUID
+		var holdLabel, receiptToken, paymentReference sql.NullString
+		var currency, baseCurrency sql.NullString
+		var exchangeRate decimal.Decimal
+		var rateLockedAt sql.NullTime
+
+		err := rows.Scan(
+			&sale.ID, &sale.TenantID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
+			&sale.Subtotal, &sale.TaxAmount, &sale.DiscountAmount, &sale.TotalAmount,
+			&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Channel, &sale.Status, &notes,
+			&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy,
+			&sale.TaxRate, &sale.TaxInclusive, &sale.SurchargeAmount, &sale.SurchargeTaxAmount, &sale.SurchargeLabel,
+			&heldAt, &heldBy, &holdExpiresAt, &holdLabel, &receiptToken, &paymentReference,
+			&currency, &baseCurrency, &exchangeRate, &rateLockedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sale: %w", err)
+		}
+
+		// Handle nullable fields
+		sale.CustomerName = customerName.String
+		sale.CustomerEmail = customerEmail.String
+		sale.CustomerPhone = customerPhone.String
+		sale.Notes = notes.String
+		if paymentMethod.Valid {
+			sale.PaymentMethod = entities.PaymentMethod(paymentMethod.String)
+		}
+		if completedAt.Valid {
+			sale.CompletedAt = &completedAt.Time
+		}
+		applySaleHold(&sale, heldAt, heldBy, holdExpiresAt, holdLabel)
+		sale.ReceiptToken = receiptToken.String
+		sale.PaymentReference = paymentReference.String
+		sale.Currency = currency.String
+		sale.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
+
+		sales[sale.ID] = &sale
+		found = append(found, sale.ID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sales: %w", err)
+	}
+
+	items, err := r.getItemsBySaleIDs(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := r.getPaymentsBySaleIDs(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	for id, sale := range sales {
+		sale.Items = items[id]
+		sale.Payments = payments[id]
+	}
+
+	return sales, nil
+}
+
 // GetBySaleNumber retrieves a sale by sale number
 func (r *PostgresSaleRepository) GetBySaleNumber(ctx context.Context, saleNumber string) (*entities.Sale, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{saleNumber})
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, sale_number, customer_name, customer_email, customer_phone,
+		SELECT id, tenant_id, sale_number, customer_name, customer_email, customer_phone,
 			subtotal, tax_amount, discount_amount, total_amount, paid_amount, change_amount,
-			payment_method, status, notes, created_at, updated_at, completed_at, created_by
+			payment_method, channel, status, notes, created_at, updated_at, completed_at, created_by,
+			tax_rate, tax_inclusive, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			held_at, held_by, hold_expires_at, hold_label, receipt_token, payment_reference,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at
 		FROM sales 
-		WHERE sale_number = $1 AND deleted_at IS NULL`
+		WHERE sale_number = $1 AND deleted_at IS NULL` + scope
 
 	var sale entities.Sale
 	var customerName, customerEmail, customerPhone, notes sql.NullString
 	var paymentMethod sql.NullString
-	var completedAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, saleNumber).Scan(
-		&sale.ID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
+	var completedAt, heldAt, holdExpiresAt sql.NullTime
+	var heldBy uuid.NullUUID
+	var holdLabel, receiptToken, paymentReference sql.NullString
+	var currency, baseCurrency sql.NullString
+	var exchangeRate decimal.Decimal
+	var rateLockedAt sql.NullTime
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(
+		&sale.ID, &sale.TenantID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
 		&sale.Subtotal, &sale.TaxAmount, &sale.DiscountAmount, &sale.TotalAmount,
-		&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Status, &notes,
-		&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy)
+		&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Channel, &sale.Status, &notes,
+		&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy,
+		&sale.TaxRate, &sale.TaxInclusive, &sale.SurchargeAmount, &sale.SurchargeTaxAmount, &sale.SurchargeLabel,
+		&heldAt, &heldBy, &holdExpiresAt, &holdLabel, &receiptToken, &paymentReference,
+		&currency, &baseCurrency, &exchangeRate, &rateLockedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("sale")
@@ -149,6 +325,11 @@ func (r *PostgresSaleRepository) GetBySaleNumber(ctx context.Context, saleNumber
 	if completedAt.Valid {
 		sale.CompletedAt = &completedAt.Time
 	}
+	applySaleHold(&sale, heldAt, heldBy, holdExpiresAt, holdLabel)
+	sale.ReceiptToken = receiptToken.String
+	sale.PaymentReference = paymentReference.String
+	sale.Currency = currency.String
+	sale.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
 
 	// Load sale items
 	items, err := r.getSaleItems(ctx, sale.ID)
@@ -157,6 +338,13 @@ func (r *PostgresSaleRepository) GetBySaleNumber(ctx context.Context, saleNumber
 	}
 	sale.Items = items
 
+	// Load payment lines
+	payments, err := r.getSalePayments(ctx, sale.ID)
+	if err != nil {
+		return nil, err
+	}
+	sale.Payments = payments
+
 	return &sale, nil
 }
 
@@ -174,15 +362,28 @@ func (r *PostgresSaleRepository) Update(ctx context.Context, sale *entities.Sale
 			customer_name = $2, customer_email = $3, customer_phone = $4,
 			subtotal = $5, tax_amount = $6, discount_amount = $7, total_amount = $8,
 			paid_amount = $9, change_amount = $10, payment_method = $11, status = $12,
-			notes = $13, updated_at = $14, completed_at = $15
+			notes = $13, updated_at = $14, completed_at = $15, channel = $16,
+			tax_rate = $17, surcharge_amount = $18, surcharge_tax_amount = $19, surcharge_label = $20,
+			held_at = $21, held_by = $22, hold_expires_at = $23, hold_label = $24,
+			receipt_token = NULLIF($25, ''), tax_inclusive = $26, payment_reference = NULLIF($27, '')
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := tx.ExecContext(ctx, query,
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 		sale.ID, sale.CustomerName, sale.CustomerEmail, sale.CustomerPhone,
 		sale.Subtotal, sale.TaxAmount, sale.DiscountAmount, sale.TotalAmount,
 		sale.PaidAmount, sale.ChangeAmount, sale.PaymentMethod, sale.Status,
-		sale.Notes, sale.UpdatedAt, sale.CompletedAt)
+		sale.Notes, sale.UpdatedAt, sale.CompletedAt, sale.Channel,
+		sale.TaxRate, sale.SurchargeAmount, sale.SurchargeTaxAmount, sale.SurchargeLabel,
+		sale.HeldAt, sale.HeldBy, sale.HoldExpiresAt, sale.HoldLabel, sale.ReceiptToken, sale.TaxInclusive,
+		sale.PaymentReference})
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, query+scope, args...)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_sales_payment_reference" {
+			return errors.NewConflictError(fmt.Sprintf("payment '%s' already paid another sale", sale.PaymentReference))
+		}
 		return fmt.Errorf("failed to update sale: %w", err)
 	}
 
@@ -206,14 +407,26 @@ func (r *PostgresSaleRepository) Update(ctx context.Context, sale *entities.Sale
 		}
 	}
 
+	// Replace payment lines
+	if err := r.deleteSalePayments(ctx, tx, sale.ID); err != nil {
+		return err
+	}
+	if err := r.insertSalePayments(ctx, tx, sale.ID, sale.Payments); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
 // Delete deletes a sale (soft delete)
 func (r *PostgresSaleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE sales SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	query := `UPDATE sales SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL` + scope
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete sale: %w", err)
 	}
@@ -231,11 +444,23 @@ func (r *PostgresSaleRepository) Delete(ctx context.Context, id uuid.UUID) error
 
 // List retrieves sales with pagination and filtering
 func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.SaleFilter, pagination utils.PaginationInfo) ([]*entities.Sale, utils.PaginationInfo, error) {
+	db := database.Reader(ctx, r.db)
+
 	// Build WHERE clause
 	conditions := []string{"deleted_at IS NULL"}
 	args := []interface{}{}
 	argCount := 0
 
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	}
+
 	if filter.Status != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
@@ -248,6 +473,12 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 		args = append(args, *filter.PaymentMethod)
 	}
 
+	if filter.Channel != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("channel = $%d", argCount))
+		args = append(args, *filter.Channel)
+	}
+
 	if filter.CreatedBy != nil {
 		argCount++
 		conditions = append(conditions, fmt.Sprintf("created_by = $%d", argCount))
@@ -311,7 +542,7 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 	// Count total records
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM sales %s", whereClause)
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to count sales: %w", err)
 	}
@@ -322,9 +553,12 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 
 	// Query with pagination
 	query := fmt.Sprintf(`
-		SELECT id, sale_number, customer_name, customer_email, customer_phone,
+		SELECT id, tenant_id, sale_number, customer_name, customer_email, customer_phone,
 			subtotal, tax_amount, discount_amount, total_amount, paid_amount, change_amount,
-			payment_method, status, notes, created_at, updated_at, completed_at, created_by
+			payment_method, channel, status, notes, created_at, updated_at, completed_at, created_by,
+			tax_rate, tax_inclusive, surcharge_amount, surcharge_tax_amount, surcharge_label,
+			held_at, held_by, hold_expires_at, hold_label, receipt_token, payment_reference,
+			currency, base_currency, exchange_rate, exchange_rate_locked_at
 		FROM sales 
 		%s 
 		ORDER BY %s 
@@ -333,7 +567,7 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 
 	args = append(args, pagination.Limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, paginationResult, fmt.Errorf("failed to query sales: %w", err)
 	}
@@ -344,13 +578,21 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 		var sale entities.Sale
 		var customerName, customerEmail, customerPhone, notes sql.NullString
 		var paymentMethod sql.NullString
-		var completedAt sql.NullTime
+		var completedAt, heldAt, holdExpiresAt sql.NullTime
+		var heldBy uuid.NullUUID
+		var holdLabel, receiptToken, paymentReference sql.NullString
+		var currency, baseCurrency sql.NullString
+		var exchangeRate decimal.Decimal
+		var rateLockedAt sql.NullTime
 
 		err := rows.Scan(
-			&sale.ID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
+			&sale.ID, &sale.TenantID, &sale.SaleNumber, &customerName, &customerEmail, &customerPhone,
 			&sale.Subtotal, &sale.TaxAmount, &sale.DiscountAmount, &sale.TotalAmount,
-			&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Status, &notes,
-			&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy)
+			&sale.PaidAmount, &sale.ChangeAmount, &paymentMethod, &sale.Channel, &sale.Status, &notes,
+			&sale.CreatedAt, &sale.UpdatedAt, &completedAt, &sale.CreatedBy,
+			&sale.TaxRate, &sale.TaxInclusive, &sale.SurchargeAmount, &sale.SurchargeTaxAmount, &sale.SurchargeLabel,
+			&heldAt, &heldBy, &holdExpiresAt, &holdLabel, &receiptToken, &paymentReference,
+			&currency, &baseCurrency, &exchangeRate, &rateLockedAt)
 		if err != nil {
 			return nil, paginationResult, fmt.Errorf("failed to scan sale: %w", err)
 		}
@@ -366,13 +608,11 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 		if completedAt.Valid {
 			sale.CompletedAt = &completedAt.Time
 		}
-
-		// Load sale items for each sale
-		items, err := r.getSaleItems(ctx, sale.ID)
-		if err != nil {
-			return nil, paginationResult, err
-		}
-		sale.Items = items
+		applySaleHold(&sale, heldAt, heldBy, holdExpiresAt, holdLabel)
+		sale.ReceiptToken = receiptToken.String
+		sale.PaymentReference = paymentReference.String
+		sale.Currency = currency.String
+		sale.ExchangeRate = scanExchangeRate(currency, baseCurrency, exchangeRate, rateLockedAt)
 
 		sales = append(sales, &sale)
 	}
@@ -381,15 +621,79 @@ func (r *PostgresSaleRepository) List(ctx context.Context, filter repositories.S
 		return nil, paginationResult, fmt.Errorf("failed to iterate sales: %w", err)
 	}
 
+	// Load the items and payment lines of every listed sale at once
+	saleIDs := make([]uuid.UUID, len(sales))
+	for i, sale := range sales {
+		saleIDs[i] = sale.ID
+	}
+
+	items, err := r.getItemsBySaleIDs(ctx, saleIDs)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+	payments, err := r.getPaymentsBySaleIDs(ctx, saleIDs)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+	for _, sale := range sales {
+		sale.Items = items[sale.ID]
+		sale.Payments = payments[sale.ID]
+	}
+
 	return sales, paginationResult, nil
 }
 
+// GetExpiredHolds retrieves held sales across all tenants whose hold expired before the given time
+func (r *PostgresSaleRepository) GetExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*entities.Sale, error) {
+	query := `
+		SELECT id
+		FROM sales
+		WHERE status = 'held' AND hold_expires_at < $1 AND deleted_at IS NULL
+		ORDER BY hold_expires_at
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired held sales: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan held sale: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate held sales: %w", err)
+	}
+	rows.Close()
+
+	sales := make([]*entities.Sale, 0, len(ids))
+	for _, id := range ids {
+		sale, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		sales = append(sales, sale)
+	}
+
+	return sales, nil
+}
+
 // ExistsBySaleNumber checks if a sale exists by sale number
 func (r *PostgresSaleRepository) ExistsBySaleNumber(ctx context.Context, saleNumber string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM sales WHERE sale_number = $1 AND deleted_at IS NULL)`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{saleNumber})
+	if err != nil {
+		return false, err
+	}
+	query := `SELECT EXISTS(SELECT 1 FROM sales WHERE sale_number = $1 AND deleted_at IS NULL` + scope + `)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, saleNumber).Scan(&exists)
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check sale existence: %w", err)
 	}
@@ -403,11 +707,12 @@ func (r *PostgresSaleRepository) GetSalesReport(ctx context.Context, fromDate, t
 	query := `
 		SELECT 
 			COUNT(*) as total_sales,
-			COALESCE(SUM(total_amount), 0) as total_revenue,
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_revenue,
 			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0) as completed_sales,
 			COALESCE(SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END), 0) as cancelled_sales,
 			COALESCE(SUM(CASE WHEN status = 'refunded' THEN 1 ELSE 0 END), 0) as refunded_sales,
-			COALESCE(AVG(CASE WHEN status = 'completed' THEN total_amount END), 0) as average_order_value
+			COALESCE(AVG(CASE WHEN status = 'completed' THEN total_amount * exchange_rate END), 0) as average_order_value,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN surcharge_amount * exchange_rate ELSE 0 END), 0) as surcharge_revenue
 		FROM sales 
 		WHERE created_at >= $1 AND created_at <= $2 AND deleted_at IS NULL`
 
@@ -415,9 +720,14 @@ func (r *PostgresSaleRepository) GetSalesReport(ctx context.Context, fromDate, t
 	report.FromDate = fromDate
 	report.ToDate = toDate
 
-	err := r.db.QueryRowContext(ctx, query, fromDate, toDate).Scan(
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(
 		&report.TotalSales, &report.TotalRevenue, &report.CompletedSales,
-		&report.CancelledSales, &report.RefundedSales, &report.AverageOrderValue)
+		&report.CancelledSales, &report.RefundedSales, &report.AverageOrderValue,
+		&report.SurchargeRevenue)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sales statistics: %w", err)
 	}
@@ -429,21 +739,32 @@ func (r *PostgresSaleRepository) GetSalesReport(ctx context.Context, fromDate, t
 		JOIN sales s ON si.sale_id = s.id
 		WHERE s.created_at >= $1 AND s.created_at <= $2 AND s.deleted_at IS NULL`
 
-	err = r.db.QueryRowContext(ctx, itemsQuery, fromDate, toDate).Scan(&report.TotalItemsSold)
+	itemsScope, itemsArgs, err := tenantScope(ctx, "s.tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.QueryRowContext(ctx, itemsQuery+itemsScope, itemsArgs...).Scan(&report.TotalItemsSold)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total items sold: %w", err)
 	}
 
 	// Get unique customers count
-	customersQuery := `
-		SELECT COUNT(DISTINCT customer_email)
-		FROM sales 
-		WHERE created_at >= $1 AND created_at <= $2 AND deleted_at IS NULL AND customer_email IS NOT NULL AND customer_email != ''`
+	report.UniqueCustomers, err = r.getUniqueCustomers(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
 
-	err = r.db.QueryRowContext(ctx, customersQuery, fromDate, toDate).Scan(&report.UniqueCustomers)
+	// Get refunds issued in the period
+	refundsQuery := `
+		SELECT COUNT(*), COALESCE(SUM(total_amount * (SELECT exchange_rate FROM sales WHERE sales.id = refunds.sale_id)), 0)
+		FROM refunds
+		WHERE created_at >= $1 AND created_at <= $2`
+
+	err = r.db.QueryRowContext(ctx, refundsQuery+scope, args...).Scan(&report.RefundCount, &report.RefundedAmount)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get unique customers: %w", err)
+		return nil, fmt.Errorf("failed to get refunds: %w", err)
 	}
+	report.NetRevenue = report.TotalRevenue.Sub(report.RefundedAmount)
 
 	// Get payment method statistics
 	paymentStats, err := r.getPaymentMethodStats(ctx, fromDate, toDate)
@@ -452,6 +773,13 @@ func (r *PostgresSaleRepository) GetSalesReport(ctx context.Context, fromDate, t
 	}
 	report.PaymentMethodStats = paymentStats
 
+	// Get sales channel statistics
+	channelStats, err := r.getSalesChannelStats(ctx, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	report.ChannelStats = channelStats
+
 	// Get daily sales data
 	dailySales, err := r.getDailySalesData(ctx, fromDate, toDate)
 	if err != nil {
@@ -459,32 +787,47 @@ func (r *PostgresSaleRepository) GetSalesReport(ctx context.Context, fromDate, t
 	}
 	report.DailySales = dailySales
 
-	// Calculate profit (requires product cost data)
-	report.TotalProfit = decimal.Zero // TODO: Implement profit calculation when product cost tracking is added
+	// Get cost of goods sold and gross profit of completed sales
+	profitQuery := `
+		SELECT ` + saleItemCostOfGoodsSQL + `, ` + saleItemGrossProfitSQL + `
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.created_at >= $1 AND s.created_at <= $2 AND s.status = 'completed' AND s.deleted_at IS NULL`
+
+	err = r.db.QueryRowContext(ctx, profitQuery+itemsScope, itemsArgs...).Scan(&report.CostOfGoods, &report.TotalProfit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profit: %w", err)
+	}
 
 	return &report, nil
 }
 
 // GetDailySales retrieves daily sales summary
 func (r *PostgresSaleRepository) GetDailySales(ctx context.Context, date time.Time) (*repositories.DailySalesReport, error) {
+	db := database.Reader(ctx, r.db)
+
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
 		SELECT 
 			COUNT(*) as total_sales,
-			COALESCE(SUM(total_amount), 0) as total_revenue,
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_revenue,
 			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0) as completed_sales,
 			COALESCE(SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END), 0) as cancelled_sales,
 			COALESCE(SUM(CASE WHEN status = 'refunded' THEN 1 ELSE 0 END), 0) as refunded_sales,
-			COALESCE(AVG(CASE WHEN status = 'completed' THEN total_amount END), 0) as average_order_value
+			COALESCE(AVG(CASE WHEN status = 'completed' THEN total_amount * exchange_rate END), 0) as average_order_value
 		FROM sales 
 		WHERE created_at >= $1 AND created_at < $2 AND deleted_at IS NULL`
 
 	var report repositories.DailySalesReport
 	report.Date = date
 
-	err := r.db.QueryRowContext(ctx, query, startOfDay, endOfDay).Scan(
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{startOfDay, endOfDay})
+	if err != nil {
+		return nil, err
+	}
+	err = db.QueryRowContext(ctx, query+scope, args...).Scan(
 		&report.TotalSales, &report.TotalRevenue, &report.CompletedSales,
 		&report.CancelledSales, &report.RefundedSales, &report.AverageOrderValue)
 	if err != nil {
@@ -498,11 +841,39 @@ func (r *PostgresSaleRepository) GetDailySales(ctx context.Context, date time.Ti
 		JOIN sales s ON si.sale_id = s.id
 		WHERE s.created_at >= $1 AND s.created_at < $2 AND s.deleted_at IS NULL`
 
-	err = r.db.QueryRowContext(ctx, itemsQuery, startOfDay, endOfDay).Scan(&report.TotalItemsSold)
+	itemsScope, itemsArgs, err := tenantScope(ctx, "s.tenant_id", []interface{}{startOfDay, endOfDay})
+	if err != nil {
+		return nil, err
+	}
+	err = db.QueryRowContext(ctx, itemsQuery+itemsScope, itemsArgs...).Scan(&report.TotalItemsSold)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total items sold: %w", err)
 	}
 
+	// Get cost of goods sold and gross profit of the day's completed sales
+	profitQuery := `
+		SELECT ` + saleItemCostOfGoodsSQL + `, ` + saleItemGrossProfitSQL + `
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.created_at >= $1 AND s.created_at < $2 AND s.status = 'completed' AND s.deleted_at IS NULL`
+
+	err = db.QueryRowContext(ctx, profitQuery+itemsScope, itemsArgs...).Scan(&report.CostOfGoods, &report.TotalProfit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profit: %w", err)
+	}
+
+	// Get refunds issued during the day
+	refundsQuery := `
+		SELECT COUNT(*), COALESCE(SUM(total_amount * (SELECT exchange_rate FROM sales WHERE sales.id = refunds.sale_id)), 0)
+		FROM refunds
+		WHERE created_at >= $1 AND created_at < $2`
+
+	err = db.QueryRowContext(ctx, refundsQuery+scope, args...).Scan(&report.RefundCount, &report.RefundedAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunds: %w", err)
+	}
+	report.NetRevenue = report.TotalRevenue.Sub(report.RefundedAmount)
+
 	// Get top selling products for the day
 	topProducts, err := r.getTopSellingProductsForDay(ctx, startOfDay, endOfDay)
 	if err != nil {
@@ -513,16 +884,161 @@ func (r *PostgresSaleRepository) GetDailySales(ctx context.Context, date time.Ti
 	return &report, nil
 }
 
+// GetSalesSummary retrieves sales totals of a tenant for a date range
+func (r *PostgresSaleRepository) GetSalesSummary(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) (*repositories.SalesSummary, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT 
+			COUNT(*) as total_sales,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0) as completed_sales,
+			COALESCE(SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END), 0) as cancelled_sales,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN total_amount * exchange_rate ELSE 0 END), 0) as total_revenue,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN discount_amount * exchange_rate ELSE 0 END), 0) as discount_amount,
+			COALESCE(AVG(CASE WHEN status = 'completed' THEN total_amount * exchange_rate END), 0) as average_order_value,
+			COALESCE(SUM(CASE WHEN status = 'completed' THEN surcharge_amount * exchange_rate ELSE 0 END), 0) as surcharge_amount
+		FROM sales 
+		WHERE tenant_id = $1 AND created_at >= $2 AND created_at < $3 AND deleted_at IS NULL`
+
+	summary := repositories.SalesSummary{
+		FromDate: fromDate,
+		ToDate:   toDate,
+	}
+
+	err := db.QueryRowContext(ctx, query, tenantID, fromDate, toDate).Scan(
+		&summary.TotalSales, &summary.CompletedSales, &summary.CancelledSales,
+		&summary.TotalRevenue, &summary.DiscountAmount, &summary.AverageOrderValue,
+		&summary.SurchargeAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales summary: %w", err)
+	}
+
+	itemsQuery := `
+		SELECT COALESCE(SUM(si.quantity), 0)
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.tenant_id = $1 AND s.created_at >= $2 AND s.created_at < $3
+			AND s.status = 'completed' AND s.deleted_at IS NULL`
+
+	err = db.QueryRowContext(ctx, itemsQuery, tenantID, fromDate, toDate).Scan(&summary.ItemsSold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items sold: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// GetTopProducts retrieves a tenant's best selling products by revenue for a date range
+func (r *PostgresSaleRepository) GetTopProducts(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, limit int) ([]*repositories.ProductSalesStats, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT 
+			si.product_id,
+			si.product_sku,
+			si.product_name,
+			SUM(si.quantity) as quantity_sold,
+			SUM(si.total_price * s.exchange_rate) as total_revenue,
+			AVG(si.unit_price * s.exchange_rate) as average_price,
+			COUNT(DISTINCT s.id) as sales_count,
+			` + saleItemCostOfGoodsSQL + ` as cost_of_goods,
+			` + saleItemGrossProfitSQL + ` as total_profit
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.tenant_id = $1 AND s.created_at >= $2 AND s.created_at < $3
+			AND s.status = 'completed' AND s.deleted_at IS NULL
+		GROUP BY si.product_id, si.product_sku, si.product_name
+		ORDER BY total_revenue DESC
+		LIMIT $4`
+
+	rows, err := db.QueryContext(ctx, query, tenantID, fromDate, toDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top products: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*repositories.ProductSalesStats
+	for rows.Next() {
+		var product repositories.ProductSalesStats
+		err := rows.Scan(&product.ProductID, &product.ProductSKU, &product.ProductName,
+			&product.QuantitySold, &product.TotalRevenue, &product.AveragePrice, &product.SalesCount,
+			&product.CostOfGoods, &product.TotalProfit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product sales stats: %w", err)
+		}
+		products = append(products, &product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product sales stats: %w", err)
+	}
+
+	return products, nil
+}
+
+// GetProductProfitability retrieves the gross profit of a tenant's products sold on completed
+// sales for a date range, most profitable first
+func (r *PostgresSaleRepository) GetProductProfitability(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time, limit int) ([]*repositories.ProductSalesStats, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT 
+			si.product_id,
+			si.product_sku,
+			si.product_name,
+			SUM(si.quantity) as quantity_sold,
+			SUM(si.total_price * s.exchange_rate) as total_revenue,
+			AVG(si.unit_price * s.exchange_rate) as average_price,
+			COUNT(DISTINCT s.id) as sales_count,
+			` + saleItemCostOfGoodsSQL + ` as cost_of_goods,
+			` + saleItemGrossProfitSQL + ` as total_profit
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.tenant_id = $1 AND s.created_at >= $2 AND s.created_at < $3
+			AND s.status = 'completed' AND s.deleted_at IS NULL
+		GROUP BY si.product_id, si.product_sku, si.product_name
+		ORDER BY total_profit DESC, si.product_name
+		LIMIT $4`
+
+	rows, err := db.QueryContext(ctx, query, tenantID, fromDate, toDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product profitability: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*repositories.ProductSalesStats
+	for rows.Next() {
+		var product repositories.ProductSalesStats
+		err := rows.Scan(&product.ProductID, &product.ProductSKU, &product.ProductName,
+			&product.QuantitySold, &product.TotalRevenue, &product.AveragePrice, &product.SalesCount,
+			&product.CostOfGoods, &product.TotalProfit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product sales stats: %w", err)
+		}
+		products = append(products, &product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product sales stats: %w", err)
+	}
+
+	return products, nil
+}
+
 // GetTotalSalesByUser retrieves total sales amount by user
 func (r *PostgresSaleRepository) GetTotalSalesByUser(ctx context.Context, userID uuid.UUID, fromDate, toDate time.Time) (decimal.Decimal, error) {
 	query := `
-		SELECT COALESCE(SUM(total_amount), 0)
+		SELECT COALESCE(SUM(total_amount * exchange_rate), 0)
 		FROM sales 
 		WHERE created_by = $1 AND created_at >= $2 AND created_at <= $3 
 			AND status = 'completed' AND deleted_at IS NULL`
 
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{userID, fromDate, toDate})
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
 	var total decimal.Decimal
-	err := r.db.QueryRowContext(ctx, query, userID, fromDate, toDate).Scan(&total)
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(&total)
 	if err != nil {
 		return decimal.Zero, fmt.Errorf("failed to get total sales by user: %w", err)
 	}
@@ -530,19 +1046,288 @@ func (r *PostgresSaleRepository) GetTotalSalesByUser(ctx context.Context, userID
 	return total, nil
 }
 
+// GetDiscountLines retrieves discount and margin totals of completed sales grouped by period, cashier and category
+func (r *PostgresSaleRepository) GetDiscountLines(ctx context.Context, filter repositories.DiscountReportFilter) ([]*repositories.DiscountReportLine, error) {
+	db := database.Reader(ctx, r.db)
+
+	conditions := []string{
+		"s.tenant_id = $1",
+		"s.status = 'completed'",
+		"s.deleted_at IS NULL",
+		"s.completed_at >= $2",
+		"s.completed_at < $3",
+	}
+	args := []interface{}{filter.TenantID, filter.FromDate, filter.ToDate, string(filter.Period)}
+	argCount := 4
+
+	if filter.CashierID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("s.created_by = $%d", argCount))
+		args = append(args, *filter.CashierID)
+	}
+
+	if filter.Category != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("p.category = $%d", argCount))
+		args = append(args, filter.Category)
+	}
+
+	// Sale-level discounts are allocated to items in proportion to their share of the subtotal
+	query := fmt.Sprintf(`
+		SELECT 
+			date_trunc($4, s.completed_at) as period_start,
+			s.created_by,
+			COALESCE(u.username, '') as cashier_name,
+			COALESCE(p.category, '') as category,
+			COALESCE(SUM(si.quantity), 0) as items_sold,
+			COALESCE(SUM(si.list_price * si.quantity * s.exchange_rate), 0) as list_revenue,
+			COALESCE(SUM(si.total_price * s.exchange_rate), 0) as item_revenue,
+			COALESCE(SUM(si.unit_cost * si.quantity * s.exchange_rate), 0) as cost_of_goods,
+			COALESCE(SUM(CASE WHEN s.subtotal > 0 THEN s.discount_amount * si.total_price / s.subtotal * s.exchange_rate ELSE 0 END), 0) as manual_discount,
+			COALESCE(SUM(CASE WHEN si.price_source = 'promotion' THEN (si.list_price - si.unit_price) * si.quantity * s.exchange_rate ELSE 0 END), 0) as promotion_discount,
+			COALESCE(SUM(CASE WHEN si.price_source = 'override' THEN (si.list_price - si.unit_price) * si.quantity * s.exchange_rate ELSE 0 END), 0) as price_override_discount
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		LEFT JOIN products p ON si.product_id = p.id
+		LEFT JOIN users u ON s.created_by = u.id
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 3, 4`, strings.Join(conditions, " AND "))
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query discount lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*repositories.DiscountReportLine
+	for rows.Next() {
+		var line repositories.DiscountReportLine
+		err := rows.Scan(&line.PeriodStart, &line.CashierID, &line.CashierName, &line.Category,
+			&line.ItemsSold, &line.ListRevenue, &line.ItemRevenue, &line.CostOfGoods,
+			&line.ManualDiscount, &line.PromotionDiscount, &line.PriceOverrideDiscount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan discount line: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate discount lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetTaxSummaryLines retrieves the taxable base and tax of a tenant's sales and credit notes
+// grouped by month and tax rate. Sales count in the month they were completed, including
+// sales refunded later; refunds count as credit notes in the month they were made, at the
+// tax rate of their sale. Surcharges are taxable when tax was charged on them.
+func (r *PostgresSaleRepository) GetTaxSummaryLines(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*repositories.TaxSummaryLine, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT 
+			date_trunc('month', s.completed_at) as month,
+			s.tax_rate,
+			'sale' as document_type,
+			COUNT(*) as document_count,
+			COALESCE(SUM((s.subtotal - s.discount_amount + CASE WHEN s.surcharge_tax_amount > 0 THEN s.surcharge_amount ELSE 0 END) * s.exchange_rate), 0) as taxable_base,
+			COALESCE(SUM(CASE WHEN s.surcharge_tax_amount > 0 THEN 0 ELSE s.surcharge_amount * s.exchange_rate END), 0) as non_taxable_amount,
+			COALESCE(SUM(s.tax_amount * s.exchange_rate), 0) as tax_amount
+		FROM sales s
+		WHERE s.tenant_id = $1 AND s.status IN ('completed', 'refunded') AND s.deleted_at IS NULL
+			AND s.completed_at >= $2 AND s.completed_at < $3
+		GROUP BY 1, 2
+		UNION ALL
+		SELECT 
+			date_trunc('month', rf.created_at) as month,
+			s.tax_rate,
+			'credit_note' as document_type,
+			COUNT(*) as document_count,
+			COALESCE(SUM((rf.subtotal - rf.discount_amount) * s.exchange_rate), 0) as taxable_base,
+			0 as non_taxable_amount,
+			COALESCE(SUM(rf.tax_amount * s.exchange_rate), 0) as tax_amount
+		FROM refunds rf
+		JOIN sales s ON rf.sale_id = s.id
+		WHERE rf.tenant_id = $1 AND rf.created_at >= $2 AND rf.created_at < $3
+		GROUP BY 1, 2
+		ORDER BY 1, 2, 3`
+
+	rows, err := db.QueryContext(ctx, query, tenantID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax summary lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*repositories.TaxSummaryLine
+	for rows.Next() {
+		var line repositories.TaxSummaryLine
+		err := rows.Scan(&line.Month, &line.TaxRate, &line.DocumentType, &line.DocumentCount,
+			&line.TaxableBase, &line.NonTaxableAmount, &line.TaxAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tax summary line: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tax summary lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetAccountingLines retrieves the amounts a tenant's sales and refunds post to the general
+// ledger grouped by day. Sales post on the day they were completed, including sales refunded
+// later, and refunds on the day they were made. Amounts are converted to the base currency at
+// the exchange rate locked on the sale.
+func (r *PostgresSaleRepository) GetAccountingLines(ctx context.Context, tenantID uuid.UUID, fromDate, toDate time.Time) ([]*repositories.AccountingLine, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT 
+			date_trunc('day', s.completed_at) as day,
+			'payment' as line_type,
+			sp.payment_method,
+			COALESCE(SUM(sp.amount * s.exchange_rate), 0) as amount
+		FROM sale_payments sp
+		JOIN sales s ON sp.sale_id = s.id
+		WHERE s.tenant_id = $1 AND s.status IN ('completed', 'refunded') AND s.deleted_at IS NULL
+			AND s.completed_at >= $2 AND s.completed_at < $3
+		GROUP BY 1, 3
+		UNION ALL
+		SELECT 
+			date_trunc('day', s.completed_at) as day,
+			'tax' as line_type,
+			'' as payment_method,
+			COALESCE(SUM(s.tax_amount * s.exchange_rate), 0) as amount
+		FROM sales s
+		WHERE s.tenant_id = $1 AND s.status IN ('completed', 'refunded') AND s.deleted_at IS NULL
+			AND s.completed_at >= $2 AND s.completed_at < $3
+		GROUP BY 1
+		UNION ALL
+		SELECT 
+			date_trunc('day', s.completed_at) as day,
+			'cost' as line_type,
+			'' as payment_method,
+			COALESCE(SUM(si.unit_cost * si.quantity * s.exchange_rate), 0) as amount
+		FROM sale_items si
+		JOIN sales s ON si.sale_id = s.id
+		WHERE s.tenant_id = $1 AND s.status IN ('completed', 'refunded') AND s.deleted_at IS NULL
+			AND s.completed_at >= $2 AND s.completed_at < $3
+		GROUP BY 1
+		UNION ALL
+		SELECT 
+			date_trunc('day', rf.created_at) as day,
+			'refund' as line_type,
+			rf.refund_method as payment_method,
+			COALESCE(SUM(rf.total_amount * s.exchange_rate), 0) as amount
+		FROM refunds rf
+		JOIN sales s ON rf.sale_id = s.id
+		WHERE rf.tenant_id = $1 AND rf.created_at >= $2 AND rf.created_at < $3
+		GROUP BY 1, 3
+		UNION ALL
+		SELECT 
+			date_trunc('day', rf.created_at) as day,
+			'refund_tax' as line_type,
+			'' as payment_method,
+			COALESCE(SUM(rf.tax_amount * s.exchange_rate), 0) as amount
+		FROM refunds rf
+		JOIN sales s ON rf.sale_id = s.id
+		WHERE rf.tenant_id = $1 AND rf.created_at >= $2 AND rf.created_at < $3
+		GROUP BY 1
+		UNION ALL
+		SELECT 
+			date_trunc('day', rf.created_at) as day,
+			'returned_cost' as line_type,
+			'' as payment_method,
+			COALESCE(SUM(si.unit_cost * ri.quantity * s.exchange_rate), 0) as amount
+		FROM refund_items ri
+		JOIN refunds rf ON ri.refund_id = rf.id
+		JOIN sale_items si ON ri.sale_item_id = si.id
+		JOIN sales s ON rf.sale_id = s.id
+		WHERE rf.tenant_id = $1 AND rf.created_at >= $2 AND rf.created_at < $3
+		GROUP BY 1
+		ORDER BY 1, 2, 3`
+
+	rows, err := db.QueryContext(ctx, query, tenantID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounting lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*repositories.AccountingLine
+	for rows.Next() {
+		var line repositories.AccountingLine
+		if err := rows.Scan(&line.Date, &line.Type, &line.PaymentMethod, &line.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan accounting line: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate accounting lines: %w", err)
+	}
+
+	return lines, nil
+}
+
 // Helper functions
 
+// applySaleHold copies the nullable hold columns onto a scanned sale
+func applySaleHold(sale *entities.Sale, heldAt sql.NullTime, heldBy uuid.NullUUID, holdExpiresAt sql.NullTime, holdLabel sql.NullString) {
+	if heldAt.Valid {
+		sale.HeldAt = &heldAt.Time
+	}
+	if heldBy.Valid {
+		sale.HeldBy = &heldBy.UUID
+	}
+	if holdExpiresAt.Valid {
+		sale.HoldExpiresAt = &holdExpiresAt.Time
+	}
+	sale.HoldLabel = holdLabel.String
+}
+
+// exchangeRateColumns splits a document's locked exchange rate into its nullable columns.
+// Documents without a locked rate are stored at a rate of 1.
+func exchangeRateColumns(rate *entities.ExchangeRate) (sql.NullString, sql.NullString, decimal.Decimal, sql.NullTime) {
+	if rate == nil {
+		return sql.NullString{}, sql.NullString{}, decimal.NewFromInt(1), sql.NullTime{}
+	}
+	return sql.NullString{String: rate.Currency, Valid: true},
+		sql.NullString{String: rate.BaseCurrency, Valid: true},
+		rate.Rate,
+		sql.NullTime{Time: rate.LockedAt, Valid: true}
+}
+
+// scanExchangeRate rebuilds a document's locked exchange rate from its nullable columns
+func scanExchangeRate(currency, baseCurrency sql.NullString, rate decimal.Decimal, lockedAt sql.NullTime) *entities.ExchangeRate {
+	if !currency.Valid || !baseCurrency.Valid {
+		return nil
+	}
+	return &entities.ExchangeRate{
+		Currency:     currency.String,
+		BaseCurrency: baseCurrency.String,
+		Rate:         rate,
+		LockedAt:     lockedAt.Time,
+	}
+}
+
 // insertSaleItems inserts sale items in a transaction
 func (r *PostgresSaleRepository) insertSaleItems(ctx context.Context, tx *sql.Tx, saleID uuid.UUID, items []entities.SaleItem) error {
 	query := `
 		INSERT INTO sale_items (id, sale_id, product_id, product_sku, product_name, 
-			quantity, unit_price, total_price, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, tax_rate_id,
+			tax_rate, tax_amount, created_at, returned_quantity, serial_numbers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
 
 	for _, item := range items {
 		_, err := tx.ExecContext(ctx, query,
 			item.ID, saleID, item.ProductID, item.ProductSKU, item.ProductName,
-			item.Quantity, item.UnitPrice, item.TotalPrice, item.CreatedAt)
+			item.Quantity, item.UnitPrice, item.TotalPrice, item.ListPrice, item.UnitCost, item.PriceSource,
+			item.OverrideReason, item.TaxRateID, item.TaxRate, item.TaxAmount, item.CreatedAt, item.ReturnedQuantity,
+			pq.Array(item.SerialNumbers))
 		if err != nil {
 			return fmt.Errorf("failed to insert sale item: %w", err)
 		}
@@ -565,28 +1350,57 @@ func (r *PostgresSaleRepository) deleteSaleItems(ctx context.Context, tx *sql.Tx
 
 // getSaleItems retrieves all items for a sale
 func (r *PostgresSaleRepository) getSaleItems(ctx context.Context, saleID uuid.UUID) ([]entities.SaleItem, error) {
+	items, err := r.getItemsBySaleIDs(ctx, []uuid.UUID{saleID})
+	if err != nil {
+		return nil, err
+	}
+	return items[saleID], nil
+}
+
+// getItemsBySaleIDs retrieves the items of several sales in one query, keyed by sale ID
+func (r *PostgresSaleRepository) getItemsBySaleIDs(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID][]entities.SaleItem, error) {
+	db := database.Reader(ctx, r.db)
+
+	items := make(map[uuid.UUID][]entities.SaleItem, len(saleIDs))
+	if len(saleIDs) == 0 {
+		return items, nil
+	}
+
+	ids := make([]string, len(saleIDs))
+	for i, id := range saleIDs {
+		ids[i] = id.String()
+	}
+
 	query := `
 		SELECT id, sale_id, product_id, product_sku, product_name, 
-			quantity, unit_price, total_price, created_at
+			quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, tax_rate_id,
+			tax_rate, tax_amount, created_at, returned_quantity, serial_numbers
 		FROM sale_items 
-		WHERE sale_id = $1 
+		WHERE sale_id = ANY($1::uuid[]) 
 		ORDER BY created_at`
 
-	rows, err := r.db.QueryContext(ctx, query, saleID)
+	rows, err := db.QueryContext(ctx, query, pq.Array(ids))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sale items: %w", err)
 	}
 	defer rows.Close()
 
-	var items []entities.SaleItem
 	for rows.Next() {
 		var item entities.SaleItem
+		var taxRateID uuid.NullUUID
+		var serialNumbers pq.StringArray
 		err := rows.Scan(&item.ID, &item.SaleID, &item.ProductID, &item.ProductSKU,
-			&item.ProductName, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.CreatedAt)
+			&item.ProductName, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.ListPrice, &item.UnitCost, &item.PriceSource,
+			&item.OverrideReason, &taxRateID, &item.TaxRate, &item.TaxAmount, &item.CreatedAt, &item.ReturnedQuantity,
+			&serialNumbers)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan sale item: %w", err)
 		}
-		items = append(items, item)
+		if taxRateID.Valid {
+			item.TaxRateID = &taxRateID.UUID
+		}
+		item.SerialNumbers = []string(serialNumbers)
+		items[item.SaleID] = append(items[item.SaleID], item)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -596,20 +1410,132 @@ func (r *PostgresSaleRepository) getSaleItems(ctx context.Context, saleID uuid.U
 	return items, nil
 }
 
-// getPaymentMethodStats gets payment method statistics for a date range
+// insertSalePayments inserts sale payment lines in a transaction
+func (r *PostgresSaleRepository) insertSalePayments(ctx context.Context, tx *sql.Tx, saleID uuid.UUID, payments []entities.SalePayment) error {
+	query := `
+		INSERT INTO sale_payments (id, sale_id, payment_method, amount, tendered_amount, surcharge_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	for _, payment := range payments {
+		_, err := tx.ExecContext(ctx, query,
+			payment.ID, saleID, payment.PaymentMethod, payment.Amount, payment.TenderedAmount,
+			payment.SurchargeAmount, payment.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert sale payment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteSalePayments deletes all payment lines for a sale
+func (r *PostgresSaleRepository) deleteSalePayments(ctx context.Context, tx *sql.Tx, saleID uuid.UUID) error {
+	query := `DELETE FROM sale_payments WHERE sale_id = $1`
+
+	_, err := tx.ExecContext(ctx, query, saleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete sale payments: %w", err)
+	}
+
+	return nil
+}
+
+// getSalePayments retrieves all payment lines for a sale
+func (r *PostgresSaleRepository) getSalePayments(ctx context.Context, saleID uuid.UUID) ([]entities.SalePayment, error) {
+	payments, err := r.getPaymentsBySaleIDs(ctx, []uuid.UUID{saleID})
+	if err != nil {
+		return nil, err
+	}
+	return payments[saleID], nil
+}
+
+// getPaymentsBySaleIDs retrieves the payment lines of several sales in one query, keyed by sale ID
+func (r *PostgresSaleRepository) getPaymentsBySaleIDs(ctx context.Context, saleIDs []uuid.UUID) (map[uuid.UUID][]entities.SalePayment, error) {
+	db := database.Reader(ctx, r.db)
+
+	payments := make(map[uuid.UUID][]entities.SalePayment, len(saleIDs))
+	if len(saleIDs) == 0 {
+		return payments, nil
+	}
+
+	ids := make([]string, len(saleIDs))
+	for i, id := range saleIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT id, sale_id, payment_method, amount, tendered_amount, surcharge_amount, created_at
+		FROM sale_payments
+		WHERE sale_id = ANY($1::uuid[])
+		ORDER BY created_at, id`
+
+	rows, err := db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sale payments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payment entities.SalePayment
+		err := rows.Scan(&payment.ID, &payment.SaleID, &payment.PaymentMethod, &payment.Amount,
+			&payment.TenderedAmount, &payment.SurchargeAmount, &payment.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sale payment: %w", err)
+		}
+		payments[payment.SaleID] = append(payments[payment.SaleID], payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sale payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// getUniqueCustomers counts the distinct customer emails on sales in a date range
+func (r *PostgresSaleRepository) getUniqueCustomers(ctx context.Context, fromDate, toDate time.Time) (int, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT COUNT(DISTINCT customer_email)
+		FROM sales 
+		WHERE created_at >= $1 AND created_at <= $2 AND deleted_at IS NULL AND customer_email IS NOT NULL AND customer_email != ''`
+
+	var count int
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return 0, err
+	}
+	if err := db.QueryRowContext(ctx, query+scope, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to get unique customers: %w", err)
+	}
+
+	return count, nil
+}
+
+// getPaymentMethodStats gets payment method statistics for a date range. Revenue is broken
+// down per payment line, so a split payment counts towards each method it used.
 func (r *PostgresSaleRepository) getPaymentMethodStats(ctx context.Context, fromDate, toDate time.Time) ([]repositories.PaymentMethodStat, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
 		SELECT 
-			payment_method,
+			sp.payment_method,
 			COUNT(*) as count,
-			COALESCE(SUM(total_amount), 0) as total_amount
-		FROM sales 
-		WHERE created_at >= $1 AND created_at <= $2 AND status = 'completed' 
-			AND deleted_at IS NULL AND payment_method IS NOT NULL
-		GROUP BY payment_method
+			COALESCE(SUM(sp.amount * s.exchange_rate), 0) as total_amount,
+			COALESCE(SUM(sp.surcharge_amount * s.exchange_rate), 0) as surcharge_amount
+		FROM sale_payments sp
+		JOIN sales s ON s.id = sp.sale_id
+		WHERE s.created_at >= $1 AND s.created_at <= $2 AND s.status = 'completed' 
+			AND s.deleted_at IS NULL%s
+		GROUP BY sp.payment_method
 		ORDER BY total_amount DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate)
+	scope, args, err := tenantScope(ctx, "s.tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query payment method stats: %w", err)
 	}
@@ -621,7 +1547,7 @@ func (r *PostgresSaleRepository) getPaymentMethodStats(ctx context.Context, from
 	// First pass: collect data and calculate total
 	for rows.Next() {
 		var stat repositories.PaymentMethodStat
-		err := rows.Scan(&stat.PaymentMethod, &stat.Count, &stat.TotalAmount)
+		err := rows.Scan(&stat.PaymentMethod, &stat.Count, &stat.TotalAmount, &stat.SurchargeAmount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan payment method stat: %w", err)
 		}
@@ -645,19 +1571,80 @@ func (r *PostgresSaleRepository) getPaymentMethodStats(ctx context.Context, from
 	return stats, nil
 }
 
+// getSalesChannelStats gets sales channel statistics for a date range
+func (r *PostgresSaleRepository) getSalesChannelStats(ctx context.Context, fromDate, toDate time.Time) ([]repositories.SalesChannelStat, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT 
+			channel,
+			COUNT(*) as count,
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_amount,
+			COALESCE(AVG(total_amount * exchange_rate), 0) as average_order_value
+		FROM sales 
+		WHERE created_at >= $1 AND created_at <= $2 AND status = 'completed' AND deleted_at IS NULL%s
+		GROUP BY channel
+		ORDER BY total_amount DESC`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sales channel stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []repositories.SalesChannelStat
+	var totalRevenue decimal.Decimal
+
+	// First pass: collect data and calculate total
+	for rows.Next() {
+		var stat repositories.SalesChannelStat
+		err := rows.Scan(&stat.Channel, &stat.Count, &stat.TotalAmount, &stat.AverageOrderValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sales channel stat: %w", err)
+		}
+		stats = append(stats, stat)
+		totalRevenue = totalRevenue.Add(stat.TotalAmount)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sales channel stats: %w", err)
+	}
+
+	// Second pass: calculate percentages
+	for i := range stats {
+		if totalRevenue.GreaterThan(decimal.Zero) {
+			stats[i].Percentage = stats[i].TotalAmount.Div(totalRevenue).Mul(decimal.NewFromInt(100))
+		} else {
+			stats[i].Percentage = decimal.Zero
+		}
+	}
+
+	return stats, nil
+}
+
 // getDailySalesData gets daily sales data for a date range
 func (r *PostgresSaleRepository) getDailySalesData(ctx context.Context, fromDate, toDate time.Time) ([]repositories.DailySalesData, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
 		SELECT 
 			DATE(created_at) as date,
 			COUNT(*) as total_sales,
-			COALESCE(SUM(total_amount), 0) as total_revenue
+			COALESCE(SUM(total_amount * exchange_rate), 0) as total_revenue
 		FROM sales 
-		WHERE created_at >= $1 AND created_at <= $2 AND status = 'completed' AND deleted_at IS NULL
+		WHERE created_at >= $1 AND created_at <= $2 AND status = 'completed' AND deleted_at IS NULL%s
 		GROUP BY DATE(created_at)
 		ORDER BY date`
 
-	rows, err := r.db.QueryContext(ctx, query, fromDate, toDate)
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{fromDate, toDate})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query daily sales data: %w", err)
 	}
@@ -682,23 +1669,31 @@ func (r *PostgresSaleRepository) getDailySalesData(ctx context.Context, fromDate
 
 // getTopSellingProductsForDay gets top selling products for a specific day
 func (r *PostgresSaleRepository) getTopSellingProductsForDay(ctx context.Context, startOfDay, endOfDay time.Time) ([]repositories.ProductSalesStats, error) {
+	db := database.Reader(ctx, r.db)
+
 	query := `
 		SELECT 
 			si.product_id,
 			si.product_sku,
 			si.product_name,
 			SUM(si.quantity) as quantity_sold,
-			SUM(si.total_price) as total_revenue,
-			AVG(si.unit_price) as average_price,
-			COUNT(DISTINCT s.id) as sales_count
+			SUM(si.total_price * s.exchange_rate) as total_revenue,
+			AVG(si.unit_price * s.exchange_rate) as average_price,
+			COUNT(DISTINCT s.id) as sales_count,
+			` + saleItemCostOfGoodsSQL + ` as cost_of_goods,
+			` + saleItemGrossProfitSQL + ` as total_profit
 		FROM sale_items si
 		JOIN sales s ON si.sale_id = s.id
-		WHERE s.created_at >= $1 AND s.created_at < $2 AND s.status = 'completed' AND s.deleted_at IS NULL
+		WHERE s.created_at >= $1 AND s.created_at < $2 AND s.status = 'completed' AND s.deleted_at IS NULL%s
 		GROUP BY si.product_id, si.product_sku, si.product_name
 		ORDER BY quantity_sold DESC
 		LIMIT 10`
 
-	rows, err := r.db.QueryContext(ctx, query, startOfDay, endOfDay)
+	scope, args, err := tenantScope(ctx, "s.tenant_id", []interface{}{startOfDay, endOfDay})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query top selling products: %w", err)
 	}
@@ -708,7 +1703,8 @@ func (r *PostgresSaleRepository) getTopSellingProductsForDay(ctx context.Context
 	for rows.Next() {
 		var product repositories.ProductSalesStats
 		err := rows.Scan(&product.ProductID, &product.ProductSKU, &product.ProductName,
-			&product.QuantitySold, &product.TotalRevenue, &product.AveragePrice, &product.SalesCount)
+			&product.QuantitySold, &product.TotalRevenue, &product.AveragePrice, &product.SalesCount,
+			&product.CostOfGoods, &product.TotalProfit)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan product sales stats: %w", err)
 		}
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// serialNumberColumns lists the columns of a serial number in the order scanSerialNumber reads them
+const serialNumberColumns = `id, tenant_id, product_id, serial, status, sale_id, sale_item_id,
+	sold_at, returned_at, created_at, updated_at`
+
+// PostgresSerialNumberRepository implements the SerialNumberRepository interface
+type PostgresSerialNumberRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSerialNumberRepository creates a new PostgreSQL serial number repository
+func NewPostgresSerialNumberRepository(db *sql.DB) repositories.SerialNumberRepository {
+	return &PostgresSerialNumberRepository{db: db}
+}
+
+// Create registers a serial number
+func (r *PostgresSerialNumberRepository) Create(ctx context.Context, serial *entities.SerialNumber) error {
+	query := `
+		INSERT INTO serial_numbers (id, tenant_id, product_id, serial, status, sale_id, sale_item_id,
+			sold_at, returned_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		serial.ID, serial.TenantID, serial.ProductID, serial.Serial, serial.Status, serial.SaleID,
+		serial.SaleItemID, serial.SoldAt, serial.ReturnedAt, serial.CreatedAt, serial.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("serial number '%s' is already registered for the product", serial.Serial))
+		}
+		return fmt.Errorf("failed to create serial number: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProductAndSerialForUpdate retrieves a serial number of a product and locks it until
+// the transaction ends
+func (r *PostgresSerialNumberRepository) GetByProductAndSerialForUpdate(ctx context.Context, productID uuid.UUID, serial string) (*entities.SerialNumber, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{productID, serial})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + serialNumberColumns + ` FROM serial_numbers WHERE product_id = $1 AND serial = $2` + scope + ` FOR UPDATE`
+
+	serialNumber, err := r.scanSerialNumber(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("serial number")
+		}
+		return nil, fmt.Errorf("failed to get serial number: %w", err)
+	}
+
+	return serialNumber, nil
+}
+
+// Update updates the status and sale of a serial number
+func (r *PostgresSerialNumberRepository) Update(ctx context.Context, serial *entities.SerialNumber) error {
+	query := `
+		UPDATE serial_numbers SET
+			status = $2, sale_id = $3, sale_item_id = $4, sold_at = $5, returned_at = $6, updated_at = $7
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		serial.ID, serial.Status, serial.SaleID, serial.SaleItemID, serial.SoldAt, serial.ReturnedAt, serial.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update serial number: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("serial number")
+	}
+
+	return nil
+}
+
+// FindBySerial retrieves the serial numbers matching a serial across all products
+func (r *PostgresSerialNumberRepository) FindBySerial(ctx context.Context, serial string) ([]*entities.SerialNumber, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{serial})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + serialNumberColumns + ` FROM serial_numbers WHERE serial = $1` + scope + ` ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query serial numbers: %w", err)
+	}
+	defer rows.Close()
+
+	var serials []*entities.SerialNumber
+	for rows.Next() {
+		serialNumber, err := r.scanSerialNumber(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan serial number: %w", err)
+		}
+		serials = append(serials, serialNumber)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate serial numbers: %w", err)
+	}
+
+	return serials, nil
+}
+
+// List retrieves serial numbers with pagination and filtering, latest first
+func (r *PostgresSerialNumberRepository) List(ctx context.Context, filter repositories.SerialNumberFilter, pagination utils.PaginationInfo) ([]*entities.SerialNumber, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 0
+
+	if filter.ProductID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", argCount))
+		args = append(args, *filter.ProductID)
+	}
+
+	if filter.SaleID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("sale_id = $%d", argCount))
+		args = append(args, *filter.SaleID)
+	}
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, utils.PaginationInfo{}, err
+	}
+	argCount = len(args)
+	whereClause := "WHERE " + strings.Join(conditions, " AND ") + scope
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM serial_numbers %s", whereClause)
+	var total int
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count serial numbers: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM serial_numbers
+		%s
+		ORDER BY updated_at DESC
+		LIMIT $%d OFFSET $%d`,
+		serialNumberColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query serial numbers: %w", err)
+	}
+	defer rows.Close()
+
+	var serials []*entities.SerialNumber
+	for rows.Next() {
+		serialNumber, err := r.scanSerialNumber(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan serial number: %w", err)
+		}
+		serials = append(serials, serialNumber)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate serial numbers: %w", err)
+	}
+
+	return serials, paginationResult, nil
+}
+
+// Helper functions
+
+// scanSerialNumber scans a serial number from a row
+func (r *PostgresSerialNumberRepository) scanSerialNumber(row interface{ Scan(...interface{}) error }) (*entities.SerialNumber, error) {
+	var serial entities.SerialNumber
+	var saleID, saleItemID uuid.NullUUID
+	var soldAt, returnedAt sql.NullTime
+
+	err := row.Scan(
+		&serial.ID, &serial.TenantID, &serial.ProductID, &serial.Serial, &serial.Status, &saleID, &saleItemID,
+		&soldAt, &returnedAt, &serial.CreatedAt, &serial.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if saleID.Valid {
+		serial.SaleID = &saleID.UUID
+	}
+	if saleItemID.Valid {
+		serial.SaleItemID = &saleItemID.UUID
+	}
+	if soldAt.Valid {
+		serial.SoldAt = &soldAt.Time
+	}
+	if returnedAt.Valid {
+		serial.ReturnedAt = &returnedAt.Time
+	}
+
+	return &serial, nil
+}
@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// PostgresStockCountRepository implements the StockCountRepository interface
+type PostgresStockCountRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresStockCountRepository creates a new PostgreSQL stock count repository
+func NewPostgresStockCountRepository(db *sql.DB) repositories.StockCountRepository {
+	return &PostgresStockCountRepository{db: db}
+}
+
+const stockCountColumns = `id, tenant_id, name, status, notes, location_id, category_id, category, started_by,
+			started_at, completed_by, completed_at, created_at, updated_at`
+
+// Create creates a new stock count
+func (r *PostgresStockCountRepository) Create(ctx context.Context, count *entities.StockCount) error {
+	query := `
+		INSERT INTO stock_counts (id, tenant_id, name, status, notes, location_id, category_id, category,
+			started_by, started_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		count.ID, count.TenantID, count.Name, count.Status, count.Notes, count.LocationID, count.CategoryID,
+		count.Category, count.StartedBy, count.StartedAt, count.CreatedAt, count.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert stock count: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a stock count by ID with its lines
+func (r *PostgresStockCountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.StockCount, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a stock count by ID with its lines and locks it until the
+// transaction ends
+func (r *PostgresStockCountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StockCount, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a stock count by ID with its lines, with an optional locking clause
+func (r *PostgresStockCountRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.StockCount, error) {
+	query := fmt.Sprintf(`SELECT %s FROM stock_counts WHERE id = $1`, stockCountColumns)
+
+	count, err := r.scanStockCount(r.db.QueryRowContext(ctx, query+lock, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("stock count")
+		}
+		return nil, fmt.Errorf("failed to get stock count: %w", err)
+	}
+
+	lines, err := r.getLines(ctx, count.ID)
+	if err != nil {
+		return nil, err
+	}
+	count.Lines = lines
+
+	return count, nil
+}
+
+// Update updates a stock count's status
+func (r *PostgresStockCountRepository) Update(ctx context.Context, count *entities.StockCount) error {
+	query := `
+		UPDATE stock_counts SET
+			status = $2, notes = $3, completed_by = $4, completed_at = $5, updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		count.ID, count.Status, count.Notes, count.CompletedBy, count.CompletedAt, count.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update stock count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("stock count")
+	}
+
+	return nil
+}
+
+// SaveLines inserts or replaces counted lines of a stock count. A line only replaces the
+// stored one when it was scanned later, so a batch racing another scanner's cannot bring
+// back an older count.
+func (r *PostgresStockCountRepository) SaveLines(ctx context.Context, lines []*entities.StockCountLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO stock_count_lines (id, count_id, product_id, product_sku, product_name, barcode,
+			counted_qty, expected_qty, variance, counted_at, counted_by, scan_count, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (count_id, product_id) DO UPDATE SET
+			barcode = EXCLUDED.barcode, counted_qty = EXCLUDED.counted_qty, expected_qty = EXCLUDED.expected_qty,
+			variance = EXCLUDED.variance, counted_at = EXCLUDED.counted_at, counted_by = EXCLUDED.counted_by,
+			scan_count = stock_count_lines.scan_count + 1, updated_at = EXCLUDED.updated_at
+		WHERE stock_count_lines.counted_at < EXCLUDED.counted_at`
+
+	for _, line := range lines {
+		_, err := tx.ExecContext(ctx, query,
+			line.ID, line.CountID, line.ProductID, line.ProductSKU, line.ProductName, line.Barcode,
+			line.CountedQty, line.ExpectedQty, line.Variance, line.CountedAt, line.CountedBy, line.ScanCount,
+			line.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save stock count line: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// List retrieves stock counts without their lines, with pagination and filtering, most
+// recently started first
+func (r *PostgresStockCountRepository) List(ctx context.Context, filter repositories.StockCountFilter, pagination utils.PaginationInfo) ([]*entities.StockCount, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+	argCount := 1
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.LocationID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("location_id = $%d", argCount))
+		args = append(args, *filter.LocationID)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM stock_counts %s", whereClause)
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count stock counts: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM stock_counts
+		%s
+		ORDER BY started_at DESC
+		LIMIT $%d OFFSET $%d`,
+		stockCountColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query stock counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []*entities.StockCount{}
+	for rows.Next() {
+		count, err := r.scanStockCount(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan stock count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate stock counts: %w", err)
+	}
+
+	return counts, paginationResult, nil
+}
+
+// Helper methods
+
+// getLines retrieves the lines of a stock count, largest variance first
+func (r *PostgresStockCountRepository) getLines(ctx context.Context, countID uuid.UUID) ([]entities.StockCountLine, error) {
+	query := `
+		SELECT id, count_id, product_id, product_sku, product_name, barcode, counted_qty, expected_qty,
+			variance, counted_at, counted_by, scan_count, updated_at
+		FROM stock_count_lines
+		WHERE count_id = $1
+		ORDER BY ABS(variance) DESC, product_name`
+
+	rows, err := r.db.QueryContext(ctx, query, countID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock count lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []entities.StockCountLine{}
+	for rows.Next() {
+		var line entities.StockCountLine
+		err := rows.Scan(
+			&line.ID, &line.CountID, &line.ProductID, &line.ProductSKU, &line.ProductName, &line.Barcode,
+			&line.CountedQty, &line.ExpectedQty, &line.Variance, &line.CountedAt, &line.CountedBy,
+			&line.ScanCount, &line.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock count line: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock count lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanStockCount scans a stock count from a row
+func (r *PostgresStockCountRepository) scanStockCount(row interface{ Scan(...interface{}) error }) (*entities.StockCount, error) {
+	var count entities.StockCount
+	var locationID, categoryID, completedBy uuid.NullUUID
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&count.ID, &count.TenantID, &count.Name, &count.Status, &count.Notes, &locationID, &categoryID,
+		&count.Category, &count.StartedBy, &count.StartedAt, &completedBy, &completedAt, &count.CreatedAt,
+		&count.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if locationID.Valid {
+		count.LocationID = &locationID.UUID
+	}
+	if categoryID.Valid {
+		count.CategoryID = &categoryID.UUID
+	}
+	if completedBy.Valid {
+		count.CompletedBy = &completedBy.UUID
+	}
+	if completedAt.Valid {
+		count.CompletedAt = &completedAt.Time
+	}
+	count.Lines = []entities.StockCountLine{}
+
+	return &count, nil
+}
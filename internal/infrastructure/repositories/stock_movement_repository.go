@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
@@ -29,8 +31,8 @@ func NewPostgreSQLStockMovementRepository(db *sql.DB) repositories.StockMovement
 // Create creates a new stock movement record
 func (r *PostgreSQLStockMovementRepository) Create(ctx context.Context, movement *entities.StockMovement) error {
 	query := `
-		INSERT INTO stock_movements (id, product_id, type, reason, quantity, reference, notes, created_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		INSERT INTO stock_movements (id, product_id, type, reason, quantity, reference, notes, location_id, reversal_of, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		movement.ID,
@@ -40,11 +42,16 @@ func (r *PostgreSQLStockMovementRepository) Create(ctx context.Context, movement
 		movement.Quantity,
 		movement.Reference,
 		movement.Notes,
+		movement.LocationID,
+		movement.ReversalOf,
 		movement.CreatedAt,
 		movement.CreatedBy,
 	)
 
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("stock movement has already been reversed")
+		}
 		return fmt.Errorf("failed to create stock movement: %w", err)
 	}
 
@@ -54,23 +61,15 @@ func (r *PostgreSQLStockMovementRepository) Create(ctx context.Context, movement
 // GetByID retrieves a stock movement by ID
 func (r *PostgreSQLStockMovementRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.StockMovement, error) {
 	query := `
-		SELECT id, product_id, type, reason, quantity, reference, notes, created_at, created_by
+		SELECT id, product_id, type, reason, quantity, reference, notes, location_id, reversal_of, created_at, created_by
 		FROM stock_movements 
 		WHERE id = $1`
 
-	movement := &entities.StockMovement{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&movement.ID,
-		&movement.ProductID,
-		&movement.Type,
-		&movement.Reason,
-		&movement.Quantity,
-		&movement.Reference,
-		&movement.Notes,
-		&movement.CreatedAt,
-		&movement.CreatedBy,
-	)
-
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	movement, err := r.scanStockMovement(r.db.QueryRowContext(ctx, query+scope, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.NewNotFoundError("stock movement")
@@ -81,6 +80,28 @@ func (r *PostgreSQLStockMovementRepository) GetByID(ctx context.Context, id uuid
 	return movement, nil
 }
 
+// GetReversal retrieves the movement that reverses a stock movement
+func (r *PostgreSQLStockMovementRepository) GetReversal(ctx context.Context, movementID uuid.UUID) (*entities.StockMovement, error) {
+	query := `
+		SELECT id, product_id, type, reason, quantity, reference, notes, location_id, reversal_of, created_at, created_by
+		FROM stock_movements
+		WHERE reversal_of = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{movementID})
+	if err != nil {
+		return nil, err
+	}
+	movement, err := r.scanStockMovement(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("stock movement reversal")
+		}
+		return nil, fmt.Errorf("failed to get stock movement reversal: %w", err)
+	}
+
+	return movement, nil
+}
+
 // List retrieves stock movements with pagination and filtering
 func (r *PostgreSQLStockMovementRepository) List(ctx context.Context, filter repositories.StockMovementFilter, pagination utils.PaginationInfo) ([]*entities.StockMovement, utils.PaginationInfo, error) {
 	// Build WHERE clause
@@ -88,6 +109,16 @@ func (r *PostgreSQLStockMovementRepository) List(ctx context.Context, filter rep
 	var args []interface{}
 	argIndex := 1
 
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		whereConditions = append(whereConditions, fmt.Sprintf("tenant_id = $%d", argIndex))
+		args = append(args, tenantID)
+		argIndex++
+	}
+
 	if filter.ProductID != nil {
 		whereConditions = append(whereConditions, fmt.Sprintf("product_id = $%d", argIndex))
 		args = append(args, *filter.ProductID)
@@ -112,6 +143,12 @@ func (r *PostgreSQLStockMovementRepository) List(ctx context.Context, filter rep
 		argIndex++
 	}
 
+	if filter.LocationID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("location_id = $%d", argIndex))
+		args = append(args, *filter.LocationID)
+		argIndex++
+	}
+
 	if filter.CreatedBy != nil {
 		whereConditions = append(whereConditions, fmt.Sprintf("created_by = $%d", argIndex))
 		args = append(args, *filter.CreatedBy)
@@ -148,7 +185,7 @@ func (r *PostgreSQLStockMovementRepository) List(ctx context.Context, filter rep
 	// Count total records
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM stock_movements %s", whereClause)
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to count stock movements: %w", err)
 	}
@@ -159,7 +196,7 @@ func (r *PostgreSQLStockMovementRepository) List(ctx context.Context, filter rep
 
 	// Build main query
 	query := fmt.Sprintf(`
-		SELECT id, product_id, type, reason, quantity, reference, notes, created_at, created_by
+		SELECT id, product_id, type, reason, quantity, reference, notes, location_id, reversal_of, created_at, created_by
 		FROM stock_movements 
 		%s
 		ORDER BY %s
@@ -176,18 +213,7 @@ func (r *PostgreSQLStockMovementRepository) List(ctx context.Context, filter rep
 
 	var movements []*entities.StockMovement
 	for rows.Next() {
-		movement := &entities.StockMovement{}
-		err := rows.Scan(
-			&movement.ID,
-			&movement.ProductID,
-			&movement.Type,
-			&movement.Reason,
-			&movement.Quantity,
-			&movement.Reference,
-			&movement.Notes,
-			&movement.CreatedAt,
-			&movement.CreatedBy,
-		)
+		movement, err := r.scanStockMovement(rows)
 		if err != nil {
 			return nil, pagination, fmt.Errorf("failed to scan stock movement: %w", err)
 		}
@@ -221,13 +247,17 @@ func (r *PostgreSQLStockMovementRepository) GetByProductID(ctx context.Context,
 
 // GetByReference retrieves stock movements by reference
 func (r *PostgreSQLStockMovementRepository) GetByReference(ctx context.Context, reference string) ([]*entities.StockMovement, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{reference})
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, product_id, type, reason, quantity, reference, notes, created_at, created_by
+		SELECT id, product_id, type, reason, quantity, reference, notes, location_id, reversal_of, created_at, created_by
 		FROM stock_movements 
-		WHERE reference = $1
+		WHERE reference = $1` + scope + `
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, reference)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query stock movements by reference: %w", err)
 	}
@@ -235,18 +265,7 @@ func (r *PostgreSQLStockMovementRepository) GetByReference(ctx context.Context,
 
 	var movements []*entities.StockMovement
 	for rows.Next() {
-		movement := &entities.StockMovement{}
-		err := rows.Scan(
-			&movement.ID,
-			&movement.ProductID,
-			&movement.Type,
-			&movement.Reason,
-			&movement.Quantity,
-			&movement.Reference,
-			&movement.Notes,
-			&movement.CreatedAt,
-			&movement.CreatedBy,
-		)
+		movement, err := r.scanStockMovement(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stock movement: %w", err)
 		}
@@ -262,9 +281,13 @@ func (r *PostgreSQLStockMovementRepository) GetByReference(ctx context.Context,
 
 // Delete deletes a stock movement record
 func (r *PostgreSQLStockMovementRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM stock_movements WHERE id = $1`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM stock_movements WHERE id = $1` + scope
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete stock movement: %w", err)
 	}
@@ -294,8 +317,8 @@ func (r *PostgreSQLStockMovementRepository) BulkCreate(ctx context.Context, move
 	defer tx.Rollback()
 
 	query := `
-		INSERT INTO stock_movements (id, product_id, type, reason, quantity, reference, notes, created_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		INSERT INTO stock_movements (id, product_id, type, reason, quantity, reference, notes, location_id, reversal_of, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	stmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
@@ -312,6 +335,8 @@ func (r *PostgreSQLStockMovementRepository) BulkCreate(ctx context.Context, move
 			movement.Quantity,
 			movement.Reference,
 			movement.Notes,
+			movement.LocationID,
+			movement.ReversalOf,
 			movement.CreatedAt,
 			movement.CreatedBy,
 		)
@@ -326,3 +351,140 @@ func (r *PostgreSQLStockMovementRepository) BulkCreate(ctx context.Context, move
 
 	return nil
 }
+
+// GetLedger retrieves a product's running-balance stock ledger over [fromDate, toDate).
+// Balances are worked back from the stock currently on hand, so the ledger agrees with it even
+// when stock was recorded without a movement, such as a product's initial stock.
+func (r *PostgreSQLStockMovementRepository) GetLedger(ctx context.Context, productID uuid.UUID, fromDate, toDate time.Time) (*repositories.StockLedger, error) {
+	args := []interface{}{productID, fromDate, toDate}
+	movementScope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, err
+	}
+	stockScope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only movements since the start of the period are needed: the balance after a movement is
+	// the stock on hand less everything recorded after it
+	ledgerCTE := `
+		WITH on_hand AS (
+			SELECT COALESCE(SUM(total_qty), 0) AS qty
+			FROM stock
+			WHERE product_id = $1` + stockScope + `
+		),
+		movements AS (
+			SELECT id, type, reason, reference, notes, reversal_of, created_at, created_by,
+			       CASE WHEN type = 'in' THEN quantity ELSE -quantity END AS change
+			FROM stock_movements
+			WHERE product_id = $1
+			  AND type IN ('in', 'out')
+			  AND reason <> 'transfer'
+			  AND created_at >= $2` + movementScope + `
+		)`
+
+	summaryQuery := ledgerCTE + `
+		SELECT on_hand.qty - COALESCE(SUM(m.change), 0),
+		       on_hand.qty - COALESCE(SUM(m.change) FILTER (WHERE m.created_at >= $3), 0),
+		       COALESCE(SUM(m.change) FILTER (WHERE m.change > 0 AND m.created_at < $3), 0),
+		       COALESCE(-SUM(m.change) FILTER (WHERE m.change < 0 AND m.created_at < $3), 0)
+		FROM on_hand
+		LEFT JOIN movements m ON true
+		GROUP BY on_hand.qty`
+
+	ledger := &repositories.StockLedger{
+		ProductID: productID,
+		FromDate:  fromDate,
+		ToDate:    toDate,
+		Entries:   []repositories.StockLedgerEntry{},
+	}
+	err = r.db.QueryRowContext(ctx, summaryQuery, args...).Scan(
+		&ledger.OpeningBalance,
+		&ledger.ClosingBalance,
+		&ledger.TotalIn,
+		&ledger.TotalOut,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock ledger balances: %w", err)
+	}
+
+	entriesQuery := ledgerCTE + `,
+		ledger AS (
+			SELECT m.*,
+			       on_hand.qty - COALESCE(SUM(m.change) OVER (
+			           ORDER BY m.created_at DESC, m.id DESC
+			           ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+			       ), 0) AS balance
+			FROM movements m
+			CROSS JOIN on_hand
+		)
+		SELECT id, type, reason, change, balance, reference, notes, reversal_of, created_at, created_by
+		FROM ledger
+		WHERE created_at < $3
+		ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, entriesQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry repositories.StockLedgerEntry
+		var reversalOf uuid.NullUUID
+		if err := rows.Scan(
+			&entry.MovementID,
+			&entry.Type,
+			&entry.Reason,
+			&entry.Change,
+			&entry.Balance,
+			&entry.Reference,
+			&entry.Notes,
+			&reversalOf,
+			&entry.CreatedAt,
+			&entry.CreatedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan stock ledger entry: %w", err)
+		}
+		if reversalOf.Valid {
+			entry.ReversalOf = &reversalOf.UUID
+		}
+		ledger.Entries = append(ledger.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock ledger entries: %w", err)
+	}
+
+	return ledger, nil
+}
+
+// scanStockMovement scans a stock movement row
+func (r *PostgreSQLStockMovementRepository) scanStockMovement(row interface{ Scan(...interface{}) error }) (*entities.StockMovement, error) {
+	movement := &entities.StockMovement{}
+	var locationID, reversalOf uuid.NullUUID
+	err := row.Scan(
+		&movement.ID,
+		&movement.ProductID,
+		&movement.Type,
+		&movement.Reason,
+		&movement.Quantity,
+		&movement.Reference,
+		&movement.Notes,
+		&locationID,
+		&reversalOf,
+		&movement.CreatedAt,
+		&movement.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if locationID.Valid {
+		movement.LocationID = &locationID.UUID
+	}
+	if reversalOf.Valid {
+		movement.ReversalOf = &reversalOf.UUID
+	}
+	return movement, nil
+}
@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/internal/infrastructure/database"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/utils"
 )
@@ -61,8 +63,12 @@ func (r *PostgreSQLStockRepository) GetByID(ctx context.Context, id uuid.UUID) (
 		FROM stock 
 		WHERE id = $1`
 
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
 	stock := &entities.Stock{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err = r.db.QueryRowContext(ctx, query+scope, args...).Scan(
 		&stock.ID,
 		&stock.ProductID,
 		&stock.AvailableQty,
@@ -86,14 +92,34 @@ func (r *PostgreSQLStockRepository) GetByID(ctx context.Context, id uuid.UUID) (
 
 // GetByProductID retrieves stock by product ID
 func (r *PostgreSQLStockRepository) GetByProductID(ctx context.Context, productID uuid.UUID) (*entities.Stock, error) {
+	return r.getByProductID(ctx, database.Reader(ctx, r.db), productID, "")
+}
+
+// GetByProductIDForUpdate retrieves stock by product ID and locks the row until the
+// transaction ends. Other transactions locking or updating the same stock wait for it.
+func (r *PostgreSQLStockRepository) GetByProductIDForUpdate(ctx context.Context, productID uuid.UUID) (*entities.Stock, error) {
+	return r.getByProductID(ctx, r.db, productID, " FOR UPDATE")
+}
+
+// rowQuerier is implemented by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getByProductID retrieves stock by product ID through db, with an optional locking clause
+func (r *PostgreSQLStockRepository) getByProductID(ctx context.Context, db rowQuerier, productID uuid.UUID, lock string) (*entities.Stock, error) {
 	query := `
 		SELECT id, product_id, available_qty, reserved_qty, total_qty, reorder_level, 
 		       last_movement_at, created_at, updated_at
 		FROM stock 
 		WHERE product_id = $1`
 
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{productID})
+	if err != nil {
+		return nil, err
+	}
 	stock := &entities.Stock{}
-	err := r.db.QueryRowContext(ctx, query, productID).Scan(
+	err = db.QueryRowContext(ctx, query+scope+lock, args...).Scan(
 		&stock.ID,
 		&stock.ProductID,
 		&stock.AvailableQty,
@@ -115,6 +141,61 @@ func (r *PostgreSQLStockRepository) GetByProductID(ctx context.Context, productI
 	return stock, nil
 }
 
+// GetByProductIDs retrieves the stock of the given products in one query, keyed by product ID.
+// Products without stock are left out.
+func (r *PostgreSQLStockRepository) GetByProductIDs(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]*entities.Stock, error) {
+	stocks := make(map[uuid.UUID]*entities.Stock, len(productIDs))
+	if len(productIDs) == 0 {
+		return stocks, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT id, product_id, available_qty, reserved_qty, total_qty, reorder_level,
+		       last_movement_at, created_at, updated_at
+		FROM stock
+		WHERE product_id = ANY($1::uuid[])`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{pq.Array(ids)})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := database.Reader(ctx, r.db).QueryContext(ctx, query+scope, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stock := &entities.Stock{}
+		err := rows.Scan(
+			&stock.ID,
+			&stock.ProductID,
+			&stock.AvailableQty,
+			&stock.ReservedQty,
+			&stock.TotalQty,
+			&stock.ReorderLevel,
+			&stock.LastMovementAt,
+			&stock.CreatedAt,
+			&stock.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock: %w", err)
+		}
+		stocks[stock.ProductID] = stock
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock: %w", err)
+	}
+
+	return stocks, nil
+}
+
 // Update updates stock information
 func (r *PostgreSQLStockRepository) Update(ctx context.Context, stock *entities.Stock) error {
 	query := `
@@ -123,7 +204,7 @@ func (r *PostgreSQLStockRepository) Update(ctx context.Context, stock *entities.
 		    last_movement_at = $6, updated_at = $7
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query,
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
 		stock.ID,
 		stock.AvailableQty,
 		stock.ReservedQty,
@@ -131,7 +212,11 @@ func (r *PostgreSQLStockRepository) Update(ctx context.Context, stock *entities.
 		stock.ReorderLevel,
 		stock.LastMovementAt,
 		stock.UpdatedAt,
-	)
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
 
 	if err != nil {
 		return fmt.Errorf("failed to update stock: %w", err)
@@ -151,9 +236,13 @@ func (r *PostgreSQLStockRepository) Update(ctx context.Context, stock *entities.
 
 // Delete deletes a stock record
 func (r *PostgreSQLStockRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM stock WHERE id = $1`
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM stock WHERE id = $1` + scope
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete stock: %w", err)
 	}
@@ -177,6 +266,16 @@ func (r *PostgreSQLStockRepository) List(ctx context.Context, filter repositorie
 	var args []interface{}
 	argIndex := 1
 
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		whereConditions = append(whereConditions, fmt.Sprintf("s.tenant_id = $%d", argIndex))
+		args = append(args, tenantID)
+		argIndex++
+	}
+
 	if filter.ProductID != nil {
 		whereConditions = append(whereConditions, fmt.Sprintf("s.product_id = $%d", argIndex))
 		args = append(args, *filter.ProductID)
@@ -220,7 +319,7 @@ func (r *PostgreSQLStockRepository) List(ctx context.Context, filter repositorie
 		%s`, whereClause)
 
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to count stock: %w", err)
 	}
@@ -301,6 +400,186 @@ func (r *PostgreSQLStockRepository) GetOutOfStockItems(ctx context.Context, pagi
 	return r.List(ctx, filter, pagination)
 }
 
+// GetLowStockByTenant retrieves a tenant's active products at or below their reorder level, emptiest first
+func (r *PostgreSQLStockRepository) GetLowStockByTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]*repositories.LowStockItem, error) {
+	db := database.Reader(ctx, r.db)
+
+	query := `
+		SELECT p.id, p.sku, p.name, s.available_qty, s.reorder_level,
+			sup.id, COALESCE(sup.name, ''), COALESCE(sup.email, ''), COALESCE(sup.phone, ''), COALESCE(sup.lead_time_days, 0)
+		FROM stock s
+		JOIN products p ON s.product_id = p.id
+		LEFT JOIN suppliers sup ON sup.id = p.supplier_id AND sup.is_active = true
+		WHERE p.tenant_id = $1 AND p.status = 'active' AND p.deleted_at IS NULL
+			AND s.available_qty <= s.reorder_level
+		ORDER BY s.available_qty ASC, p.name ASC
+		LIMIT $2`
+
+	rows, err := db.QueryContext(ctx, query, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query low stock items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*repositories.LowStockItem
+	for rows.Next() {
+		var item repositories.LowStockItem
+		var supplierID uuid.NullUUID
+		err := rows.Scan(&item.ProductID, &item.ProductSKU, &item.ProductName, &item.AvailableQty, &item.ReorderLevel,
+			&supplierID, &item.SupplierName, &item.SupplierEmail, &item.SupplierPhone, &item.LeadTimeDays)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan low stock item: %w", err)
+		}
+		if supplierID.Valid {
+			item.SupplierID = &supplierID.UUID
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate low stock items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetInventorySummary aggregates stock counts and value across a tenant's active products
+func (r *PostgreSQLStockRepository) GetInventorySummary(ctx context.Context, tenantID uuid.UUID) (*repositories.InventorySummary, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(s.total_qty), 0),
+			COALESCE(SUM(s.reserved_qty), 0),
+			COALESCE(SUM(s.total_qty * p.cost), 0),
+			COALESCE(SUM(s.total_qty * p.price), 0),
+			COUNT(*) FILTER (WHERE s.available_qty > 0 AND s.available_qty <= s.reorder_level),
+			COUNT(*) FILTER (WHERE COALESCE(s.available_qty, 0) = 0)
+		FROM products p
+		LEFT JOIN stock s ON s.product_id = p.id
+		WHERE p.tenant_id = $1 AND p.status = 'active' AND p.deleted_at IS NULL`
+
+	var summary repositories.InventorySummary
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&summary.TotalSKUs, &summary.TotalUnits, &summary.ReservedUnits, &summary.StockValue,
+		&summary.RetailValue, &summary.LowStockCount, &summary.OutOfStockCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory summary: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// GetStockAlertCounts counts the low and out of stock active products of every tenant
+func (r *PostgreSQLStockRepository) GetStockAlertCounts(ctx context.Context) ([]*repositories.TenantStockAlertCount, error) {
+	query := `
+		SELECT
+			p.tenant_id,
+			COUNT(*) FILTER (WHERE s.available_qty > 0 AND s.available_qty <= s.reorder_level),
+			COUNT(*) FILTER (WHERE COALESCE(s.available_qty, 0) = 0)
+		FROM products p
+		LEFT JOIN stock s ON s.product_id = p.id
+		WHERE p.tenant_id IS NOT NULL AND p.status = 'active' AND p.deleted_at IS NULL
+		GROUP BY p.tenant_id
+		ORDER BY p.tenant_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock alert counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*repositories.TenantStockAlertCount
+	for rows.Next() {
+		var count repositories.TenantStockAlertCount
+		if err := rows.Scan(&count.TenantID, &count.LowStockCount, &count.OutOfStockCount); err != nil {
+			return nil, fmt.Errorf("failed to scan stock alert count: %w", err)
+		}
+		counts = append(counts, &count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock alert counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetInventoryValuation retrieves the units on hand and their value at cost of a tenant's
+// active products with stock, by category and name
+func (r *PostgreSQLStockRepository) GetInventoryValuation(ctx context.Context, tenantID uuid.UUID, method entities.ValuationMethod) ([]*repositories.InventoryValuationItem, error) {
+	query := `
+		SELECT id, sku, name, category, qty, unit_cost, qty * unit_cost
+		FROM (
+			SELECT p.id, p.sku, p.name, p.category, s.total_qty AS qty, ` + valuationUnitCostSQL(method) + ` AS unit_cost
+			FROM products p
+			JOIN stock s ON s.product_id = p.id
+			WHERE p.tenant_id = $1 AND p.status = 'active' AND p.deleted_at IS NULL AND s.total_qty > 0
+		) v
+		ORDER BY category, name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inventory valuation: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*repositories.InventoryValuationItem{}
+	for rows.Next() {
+		var item repositories.InventoryValuationItem
+		err := rows.Scan(&item.ProductID, &item.ProductSKU, &item.ProductName, &item.Category,
+			&item.OnHandQty, &item.UnitCost, &item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory valuation item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate inventory valuation: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetInventoryValuationByLocation retrieves the units of a tenant's active products held at
+// each location and their value at cost, by location, category and name
+func (r *PostgreSQLStockRepository) GetInventoryValuationByLocation(ctx context.Context, tenantID uuid.UUID, method entities.ValuationMethod) ([]*repositories.LocationValuationItem, error) {
+	query := `
+		SELECT location_id, location_code, location_name, id, sku, name, category, qty, unit_cost, qty * unit_cost
+		FROM (
+			SELECT l.id AS location_id, l.code AS location_code, l.name AS location_name,
+				p.id, p.sku, p.name, p.category, ls.quantity AS qty, ` + valuationUnitCostSQL(method) + ` AS unit_cost
+			FROM location_stock ls
+			JOIN locations l ON l.id = ls.location_id
+			JOIN products p ON p.id = ls.product_id
+			WHERE l.tenant_id = $1 AND p.status = 'active' AND p.deleted_at IS NULL AND ls.quantity > 0
+		) v
+		ORDER BY location_name, category, name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inventory valuation by location: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*repositories.LocationValuationItem{}
+	for rows.Next() {
+		var item repositories.LocationValuationItem
+		err := rows.Scan(&item.LocationID, &item.LocationCode, &item.LocationName, &item.ProductID, &item.ProductSKU,
+			&item.ProductName, &item.Category, &item.OnHandQty, &item.UnitCost, &item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location valuation item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate inventory valuation by location: %w", err)
+	}
+
+	return items, nil
+}
+
 // BulkUpdateStock updates multiple stock records in a transaction
 func (r *PostgreSQLStockRepository) BulkUpdateStock(ctx context.Context, stocks []*entities.Stock) error {
 	if len(stocks) == 0 {
@@ -319,14 +598,18 @@ func (r *PostgreSQLStockRepository) BulkUpdateStock(ctx context.Context, stocks
 		    last_movement_at = $6, updated_at = $7
 		WHERE id = $1`
 
-	stmt, err := tx.PrepareContext(ctx, query)
+	scope, scopeArgs, err := tenantScope(ctx, "tenant_id", make([]interface{}, 7))
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, query+scope)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
 	for _, stock := range stocks {
-		_, err := stmt.ExecContext(ctx,
+		args := append([]interface{}{
 			stock.ID,
 			stock.AvailableQty,
 			stock.ReservedQty,
@@ -334,7 +617,8 @@ func (r *PostgreSQLStockRepository) BulkUpdateStock(ctx context.Context, stocks
 			stock.ReorderLevel,
 			stock.LastMovementAt,
 			stock.UpdatedAt,
-		)
+		}, scopeArgs[7:]...)
+		_, err := stmt.ExecContext(ctx, args...)
 		if err != nil {
 			return fmt.Errorf("failed to update stock %s: %w", stock.ID, err)
 		}
@@ -355,8 +639,8 @@ func (r *PostgreSQLStockRepository) AdjustStock(ctx context.Context, adjustment
 	}
 	defer tx.Rollback()
 
-	// Get current stock
-	stock, err := r.GetByProductID(ctx, adjustment.ProductID)
+	// Lock the current stock so concurrent adjustments apply one after the other
+	stock, err := r.getByProductID(ctx, tx, adjustment.ProductID, " FOR UPDATE")
 	if err != nil {
 		return fmt.Errorf("failed to get stock: %w", err)
 	}
@@ -375,10 +659,10 @@ func (r *PostgreSQLStockRepository) AdjustStock(ctx context.Context, adjustment
 	updateQuery := `
 		UPDATE stock 
 		SET available_qty = $2, total_qty = $3, last_movement_at = $4, updated_at = $5
-		WHERE product_id = $1`
+		WHERE id = $1`
 
 	_, err = tx.ExecContext(ctx, updateQuery,
-		adjustment.ProductID,
+		stock.ID,
 		stock.AvailableQty,
 		stock.TotalQty,
 		stock.LastMovementAt,
@@ -428,8 +712,8 @@ func (r *PostgreSQLStockRepository) ReserveStock(ctx context.Context, reservatio
 	}
 	defer tx.Rollback()
 
-	// Get current stock
-	stock, err := r.GetByProductID(ctx, reservation.ProductID)
+	// Lock the current stock so concurrent adjustments apply one after the other
+	stock, err := r.getByProductID(ctx, tx, reservation.ProductID, " FOR UPDATE")
 	if err != nil {
 		return fmt.Errorf("failed to get stock: %w", err)
 	}
@@ -444,10 +728,10 @@ func (r *PostgreSQLStockRepository) ReserveStock(ctx context.Context, reservatio
 	updateQuery := `
 		UPDATE stock 
 		SET available_qty = $2, reserved_qty = $3, last_movement_at = $4, updated_at = $5
-		WHERE product_id = $1`
+		WHERE id = $1`
 
 	_, err = tx.ExecContext(ctx, updateQuery,
-		reservation.ProductID,
+		stock.ID,
 		stock.AvailableQty,
 		stock.ReservedQty,
 		stock.LastMovementAt,
@@ -492,8 +776,8 @@ func (r *PostgreSQLStockRepository) ReleaseReservedStock(ctx context.Context, re
 	}
 	defer tx.Rollback()
 
-	// Get current stock
-	stock, err := r.GetByProductID(ctx, release.ProductID)
+	// Lock the current stock so concurrent adjustments apply one after the other
+	stock, err := r.getByProductID(ctx, tx, release.ProductID, " FOR UPDATE")
 	if err != nil {
 		return fmt.Errorf("failed to get stock: %w", err)
 	}
@@ -508,10 +792,10 @@ func (r *PostgreSQLStockRepository) ReleaseReservedStock(ctx context.Context, re
 	updateQuery := `
 		UPDATE stock 
 		SET available_qty = $2, reserved_qty = $3, last_movement_at = $4, updated_at = $5
-		WHERE product_id = $1`
+		WHERE id = $1`
 
 	_, err = tx.ExecContext(ctx, updateQuery,
-		release.ProductID,
+		stock.ID,
 		stock.AvailableQty,
 		stock.ReservedQty,
 		stock.LastMovementAt,
@@ -561,8 +845,8 @@ func (r *PostgreSQLStockRepository) BulkReserveStock(ctx context.Context, reserv
 	defer tx.Rollback()
 
 	for _, reservation := range reservations {
-		// Get current stock
-		stock, err := r.GetByProductID(ctx, reservation.ProductID)
+		// Lock the current stock so concurrent adjustments apply one after the other
+		stock, err := r.getByProductID(ctx, tx, reservation.ProductID, " FOR UPDATE")
 		if err != nil {
 			return fmt.Errorf("failed to get stock for product %s: %w", reservation.ProductID, err)
 		}
@@ -577,10 +861,10 @@ func (r *PostgreSQLStockRepository) BulkReserveStock(ctx context.Context, reserv
 		updateQuery := `
 			UPDATE stock 
 			SET available_qty = $2, reserved_qty = $3, last_movement_at = $4, updated_at = $5
-			WHERE product_id = $1`
+			WHERE id = $1`
 
 		_, err = tx.ExecContext(ctx, updateQuery,
-			reservation.ProductID,
+			stock.ID,
 			stock.AvailableQty,
 			stock.ReservedQty,
 			stock.LastMovementAt,
@@ -631,8 +915,8 @@ func (r *PostgreSQLStockRepository) BulkReleaseStock(ctx context.Context, releas
 	defer tx.Rollback()
 
 	for _, release := range releases {
-		// Get current stock
-		stock, err := r.GetByProductID(ctx, release.ProductID)
+		// Lock the current stock so concurrent adjustments apply one after the other
+		stock, err := r.getByProductID(ctx, tx, release.ProductID, " FOR UPDATE")
 		if err != nil {
 			return fmt.Errorf("failed to get stock for product %s: %w", release.ProductID, err)
 		}
@@ -647,10 +931,10 @@ func (r *PostgreSQLStockRepository) BulkReleaseStock(ctx context.Context, releas
 		updateQuery := `
 			UPDATE stock 
 			SET available_qty = $2, reserved_qty = $3, last_movement_at = $4, updated_at = $5
-			WHERE product_id = $1`
+			WHERE id = $1`
 
 		_, err = tx.ExecContext(ctx, updateQuery,
-			release.ProductID,
+			stock.ID,
 			stock.AvailableQty,
 			stock.ReservedQty,
 			stock.LastMovementAt,
@@ -686,4 +970,15 @@ func (r *PostgreSQLStockRepository) BulkReleaseStock(ctx context.Context, releas
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// valuationUnitCostSQL returns the SQL expression of the unit cost a product p is valued at.
+// The latest cost is the unit cost of the product's most recent receipt, or its average cost
+// when it has never been received.
+func valuationUnitCostSQL(method entities.ValuationMethod) string {
+	if method == entities.ValuationMethodLatestCost {
+		return `COALESCE((SELECT cl.unit_cost FROM cost_layers cl WHERE cl.product_id = p.id
+			ORDER BY cl.received_at DESC, cl.id DESC LIMIT 1), p.cost)`
+	}
+	return `p.cost`
+}
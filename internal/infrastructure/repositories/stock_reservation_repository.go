@@ -0,0 +1,340 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// stockReservationOrderColumns lists the columns stock reservations can be sorted by
+var stockReservationOrderColumns = map[string]bool{
+	"reference":  true,
+	"source":     true,
+	"status":     true,
+	"expires_at": true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// PostgresStockReservationRepository implements the StockReservationRepository interface
+type PostgresStockReservationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresStockReservationRepository creates a new PostgreSQL stock reservation repository
+func NewPostgresStockReservationRepository(db *sql.DB) repositories.StockReservationRepository {
+	return &PostgresStockReservationRepository{db: db}
+}
+
+// Create creates a new stock reservation with its items
+func (r *PostgresStockReservationRepository) Create(ctx context.Context, reservation *entities.StockReservation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO stock_reservations (id, tenant_id, reference, source, status, notes,
+			expires_at, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = tx.ExecContext(ctx, query,
+		reservation.ID, reservation.TenantID, reservation.Reference, reservation.Source,
+		reservation.Status, reservation.Notes, reservation.ExpiresAt, reservation.CreatedAt,
+		reservation.UpdatedAt, reservation.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert stock reservation: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO stock_reservation_items (id, reservation_id, product_id, quantity, confirmed_qty, released_qty)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, item := range reservation.Items {
+		_, err := tx.ExecContext(ctx, itemQuery,
+			item.ID, reservation.ID, item.ProductID, item.Quantity, item.ConfirmedQty, item.ReleasedQty)
+		if err != nil {
+			return fmt.Errorf("failed to insert stock reservation item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a stock reservation by ID
+func (r *PostgresStockReservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.StockReservation, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a stock reservation by ID and locks it until the transaction ends
+func (r *PostgresStockReservationRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StockReservation, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a stock reservation by ID, with an optional locking clause
+func (r *PostgresStockReservationRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.StockReservation, error) {
+	query := `
+		SELECT id, tenant_id, reference, source, status, notes,
+			expires_at, created_at, updated_at, created_by
+		FROM stock_reservations
+		WHERE id = $1` + lock
+
+	var reservation entities.StockReservation
+	var source, notes sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&reservation.ID, &reservation.TenantID, &reservation.Reference, &source,
+		&reservation.Status, &notes, &reservation.ExpiresAt, &reservation.CreatedAt,
+		&reservation.UpdatedAt, &reservation.CreatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("stock reservation")
+		}
+		return nil, fmt.Errorf("failed to get stock reservation: %w", err)
+	}
+
+	reservation.Source = source.String
+	reservation.Notes = notes.String
+
+	if err := r.loadItems(ctx, []*entities.StockReservation{&reservation}); err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// Update updates a stock reservation and its item quantities
+func (r *PostgresStockReservationRepository) Update(ctx context.Context, reservation *entities.StockReservation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE stock_reservations SET
+			status = $2, notes = $3, expires_at = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := tx.ExecContext(ctx, query,
+		reservation.ID, reservation.Status, reservation.Notes, reservation.ExpiresAt, reservation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update stock reservation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("stock reservation")
+	}
+
+	itemQuery := `
+		UPDATE stock_reservation_items SET
+			quantity = $2, confirmed_qty = $3, released_qty = $4
+		WHERE id = $1`
+
+	for _, item := range reservation.Items {
+		if _, err := tx.ExecContext(ctx, itemQuery, item.ID, item.Quantity, item.ConfirmedQty, item.ReleasedQty); err != nil {
+			return fmt.Errorf("failed to update stock reservation item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// List retrieves stock reservations with pagination and filtering
+func (r *PostgresStockReservationRepository) List(ctx context.Context, filter repositories.StockReservationFilter, pagination utils.PaginationInfo) ([]*entities.StockReservation, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{filter.TenantID}
+	argCount := 1
+
+	if filter.Reference != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("reference = $%d", argCount))
+		args = append(args, filter.Reference)
+	}
+
+	if filter.Source != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("source = $%d", argCount))
+		args = append(args, filter.Source)
+	}
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.ProductID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT reservation_id FROM stock_reservation_items WHERE product_id = $%d)", argCount))
+		args = append(args, *filter.ProductID)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Build ORDER BY clause
+	orderBy := "created_at DESC"
+	if stockReservationOrderColumns[filter.OrderBy] {
+		direction := "ASC"
+		if filter.OrderDir == "DESC" {
+			direction = "DESC"
+		}
+		orderBy = fmt.Sprintf("%s %s", filter.OrderBy, direction)
+	}
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM stock_reservations %s", whereClause)
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count stock reservations: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT id, tenant_id, reference, source, status, notes,
+			expires_at, created_at, updated_at, created_by
+		FROM stock_reservations
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		whereClause, orderBy, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	reservations, err := r.queryReservations(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, err
+	}
+
+	return reservations, paginationResult, nil
+}
+
+// GetByReference retrieves all stock reservations for an external order reference
+func (r *PostgresStockReservationRepository) GetByReference(ctx context.Context, reference string) ([]*entities.StockReservation, error) {
+	query := `
+		SELECT id, tenant_id, reference, source, status, notes,
+			expires_at, created_at, updated_at, created_by
+		FROM stock_reservations
+		WHERE reference = $1
+		ORDER BY created_at DESC`
+
+	return r.queryReservations(ctx, query, reference)
+}
+
+// GetExpired retrieves open stock reservations whose expiry is before the given time
+func (r *PostgresStockReservationRepository) GetExpired(ctx context.Context, before time.Time, limit int) ([]*entities.StockReservation, error) {
+	query := `
+		SELECT id, tenant_id, reference, source, status, notes,
+			expires_at, created_at, updated_at, created_by
+		FROM stock_reservations
+		WHERE status IN ('active', 'partially_confirmed') AND expires_at < $1
+		ORDER BY expires_at
+		LIMIT $2`
+
+	return r.queryReservations(ctx, query, before, limit)
+}
+
+// Helper functions
+
+// queryReservations runs a reservation query and loads the items for all returned rows
+func (r *PostgresStockReservationRepository) queryReservations(ctx context.Context, query string, args ...interface{}) ([]*entities.StockReservation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []*entities.StockReservation
+	for rows.Next() {
+		var reservation entities.StockReservation
+		var source, notes sql.NullString
+
+		err := rows.Scan(
+			&reservation.ID, &reservation.TenantID, &reservation.Reference, &source,
+			&reservation.Status, &notes, &reservation.ExpiresAt, &reservation.CreatedAt,
+			&reservation.UpdatedAt, &reservation.CreatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock reservation: %w", err)
+		}
+
+		reservation.Source = source.String
+		reservation.Notes = notes.String
+		reservations = append(reservations, &reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock reservations: %w", err)
+	}
+
+	if err := r.loadItems(ctx, reservations); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// loadItems loads the items for a set of reservations in a single query
+func (r *PostgresStockReservationRepository) loadItems(ctx context.Context, reservations []*entities.StockReservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(reservations))
+	byID := make(map[uuid.UUID]*entities.StockReservation, len(reservations))
+	for i, reservation := range reservations {
+		ids[i] = reservation.ID.String()
+		reservation.Items = []entities.StockReservationItem{}
+		byID[reservation.ID] = reservation
+	}
+
+	query := `
+		SELECT id, reservation_id, product_id, quantity, confirmed_qty, released_qty
+		FROM stock_reservation_items
+		WHERE reservation_id = ANY($1::uuid[])
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query stock reservation items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.StockReservationItem
+		err := rows.Scan(&item.ID, &item.ReservationID, &item.ProductID,
+			&item.Quantity, &item.ConfirmedQty, &item.ReleasedQty)
+		if err != nil {
+			return fmt.Errorf("failed to scan stock reservation item: %w", err)
+		}
+		if reservation, ok := byID[item.ReservationID]; ok {
+			reservation.Items = append(reservation.Items, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate stock reservation items: %w", err)
+	}
+
+	return nil
+}
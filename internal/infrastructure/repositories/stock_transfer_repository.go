@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// stockTransferColumns lists the columns of a stock transfer in the order scanTransfer reads them
+const stockTransferColumns = `id, tenant_id, transfer_number, from_location_id, to_location_id, status,
+	notes, requested_by, shipped_by, shipped_at, received_by, received_at, cancelled_at,
+	created_at, updated_at`
+
+// PostgresStockTransferRepository implements the StockTransferRepository interface
+type PostgresStockTransferRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresStockTransferRepository creates a new PostgreSQL stock transfer repository
+func NewPostgresStockTransferRepository(db *sql.DB) repositories.StockTransferRepository {
+	return &PostgresStockTransferRepository{db: db}
+}
+
+// Create creates a new stock transfer with its items
+func (r *PostgresStockTransferRepository) Create(ctx context.Context, transfer *entities.StockTransfer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO stock_transfers (id, tenant_id, transfer_number, from_location_id, to_location_id, status,
+			notes, requested_by, shipped_by, shipped_at, received_by, received_at, cancelled_at,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = tx.ExecContext(ctx, query,
+		transfer.ID, transfer.TenantID, transfer.TransferNumber, transfer.FromLocationID, transfer.ToLocationID,
+		transfer.Status, transfer.Notes, transfer.RequestedBy, transfer.ShippedBy, transfer.ShippedAt,
+		transfer.ReceivedBy, transfer.ReceivedAt, transfer.CancelledAt, transfer.CreatedAt, transfer.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("stock transfer with number '%s' already exists", transfer.TransferNumber))
+		}
+		return fmt.Errorf("failed to insert stock transfer: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO stock_transfer_items (id, transfer_id, product_id, product_sku, product_name, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, item := range transfer.Items {
+		_, err := tx.ExecContext(ctx, itemQuery,
+			item.ID, transfer.ID, item.ProductID, item.ProductSKU, item.ProductName, item.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to insert stock transfer item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a stock transfer by ID
+func (r *PostgresStockTransferRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.StockTransfer, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a stock transfer by ID and locks it until the transaction ends
+func (r *PostgresStockTransferRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StockTransfer, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+// getByID retrieves a stock transfer by ID, appending the locking clause to the query
+func (r *PostgresStockTransferRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entities.StockTransfer, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + stockTransferColumns + ` FROM stock_transfers WHERE id = $1` + scope + lock
+
+	transfer, err := r.scanTransfer(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("stock transfer")
+		}
+		return nil, fmt.Errorf("failed to get stock transfer: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*entities.StockTransfer{transfer}); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// Update updates the status of a stock transfer
+func (r *PostgresStockTransferRepository) Update(ctx context.Context, transfer *entities.StockTransfer) error {
+	query := `
+		UPDATE stock_transfers SET
+			status = $2, shipped_by = $3, shipped_at = $4, received_by = $5, received_at = $6,
+			cancelled_at = $7, updated_at = $8
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		transfer.ID, transfer.Status, transfer.ShippedBy, transfer.ShippedAt, transfer.ReceivedBy,
+		transfer.ReceivedAt, transfer.CancelledAt, transfer.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update stock transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("stock transfer")
+	}
+
+	return nil
+}
+
+// List retrieves stock transfers with pagination and filtering, latest first
+func (r *PostgresStockTransferRepository) List(ctx context.Context, filter repositories.StockTransferFilter, pagination utils.PaginationInfo) ([]*entities.StockTransfer, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 0
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.LocationID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("(from_location_id = $%d OR to_location_id = $%d)", argCount, argCount))
+		args = append(args, *filter.LocationID)
+	}
+
+	if filter.ProductID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT transfer_id FROM stock_transfer_items WHERE product_id = $%d)", argCount))
+		args = append(args, *filter.ProductID)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, utils.PaginationInfo{}, err
+	}
+	argCount = len(args)
+	whereClause := "WHERE " + strings.Join(conditions, " AND ") + scope
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM stock_transfers %s", whereClause)
+	var total int
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count stock transfers: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM stock_transfers
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		stockTransferColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query stock transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*entities.StockTransfer
+	for rows.Next() {
+		transfer, err := r.scanTransfer(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan stock transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate stock transfers: %w", err)
+	}
+	rows.Close()
+
+	if err := r.loadItems(ctx, transfers); err != nil {
+		return nil, paginationResult, err
+	}
+
+	return transfers, paginationResult, nil
+}
+
+// Helper functions
+
+// scanTransfer scans a stock transfer from a row
+func (r *PostgresStockTransferRepository) scanTransfer(row interface{ Scan(...interface{}) error }) (*entities.StockTransfer, error) {
+	var transfer entities.StockTransfer
+	var notes sql.NullString
+	var shippedBy, receivedBy uuid.NullUUID
+	var shippedAt, receivedAt, cancelledAt sql.NullTime
+
+	err := row.Scan(
+		&transfer.ID, &transfer.TenantID, &transfer.TransferNumber, &transfer.FromLocationID, &transfer.ToLocationID,
+		&transfer.Status, &notes, &transfer.RequestedBy, &shippedBy, &shippedAt, &receivedBy, &receivedAt,
+		&cancelledAt, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.Notes = notes.String
+	if shippedBy.Valid {
+		transfer.ShippedBy = &shippedBy.UUID
+	}
+	if shippedAt.Valid {
+		transfer.ShippedAt = &shippedAt.Time
+	}
+	if receivedBy.Valid {
+		transfer.ReceivedBy = &receivedBy.UUID
+	}
+	if receivedAt.Valid {
+		transfer.ReceivedAt = &receivedAt.Time
+	}
+	if cancelledAt.Valid {
+		transfer.CancelledAt = &cancelledAt.Time
+	}
+
+	return &transfer, nil
+}
+
+// loadItems loads the items for a set of stock transfers in a single query
+func (r *PostgresStockTransferRepository) loadItems(ctx context.Context, transfers []*entities.StockTransfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(transfers))
+	byID := make(map[uuid.UUID]*entities.StockTransfer, len(transfers))
+	for i, transfer := range transfers {
+		ids[i] = transfer.ID.String()
+		transfer.Items = []entities.StockTransferItem{}
+		byID[transfer.ID] = transfer
+	}
+
+	query := `
+		SELECT id, transfer_id, product_id, product_sku, product_name, quantity
+		FROM stock_transfer_items
+		WHERE transfer_id = ANY($1::uuid[])
+		ORDER BY product_name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query stock transfer items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.StockTransferItem
+		err := rows.Scan(&item.ID, &item.TransferID, &item.ProductID, &item.ProductSKU, &item.ProductName, &item.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to scan stock transfer item: %w", err)
+		}
+		if transfer, ok := byID[item.TransferID]; ok {
+			transfer.Items = append(transfer.Items, item)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate stock transfer items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// supplierOrderColumns lists the columns suppliers can be sorted by
+var supplierOrderColumns = map[string]bool{
+	"name":           true,
+	"lead_time_days": true,
+	"created_at":     true,
+	"updated_at":     true,
+}
+
+// PostgresSupplierRepository implements the SupplierRepository interface
+type PostgresSupplierRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSupplierRepository creates a new PostgreSQL supplier repository
+func NewPostgresSupplierRepository(db *sql.DB) repositories.SupplierRepository {
+	return &PostgresSupplierRepository{db: db}
+}
+
+// Create creates a new supplier
+func (r *PostgresSupplierRepository) Create(ctx context.Context, supplier *entities.Supplier) error {
+	query := `
+		INSERT INTO suppliers (id, tenant_id, name, contact_name, email, phone, address,
+			payment_terms_days, lead_time_days, notes, is_active, created_at, updated_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		supplier.ID, supplier.TenantID, supplier.Name, supplier.ContactName, supplier.Email, supplier.Phone,
+		supplier.Address, supplier.PaymentTermsDays, supplier.LeadTimeDays, supplier.Notes, supplier.IsActive,
+		supplier.CreatedAt, supplier.UpdatedAt, supplier.CreatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("supplier with name '%s' already exists", supplier.Name))
+		}
+		return fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a supplier by ID
+func (r *PostgresSupplierRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Supplier, error) {
+	query := `
+		SELECT id, tenant_id, name, contact_name, email, phone, address,
+			payment_terms_days, lead_time_days, notes, is_active, created_at, updated_at, created_by
+		FROM suppliers
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := r.scanSupplier(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("supplier")
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+// Update updates a supplier
+func (r *PostgresSupplierRepository) Update(ctx context.Context, supplier *entities.Supplier) error {
+	query := `
+		UPDATE suppliers SET
+			name = $2, contact_name = $3, email = $4, phone = $5, address = $6,
+			payment_terms_days = $7, lead_time_days = $8, notes = $9, is_active = $10, updated_at = $11
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		supplier.ID, supplier.Name, supplier.ContactName, supplier.Email, supplier.Phone, supplier.Address,
+		supplier.PaymentTermsDays, supplier.LeadTimeDays, supplier.Notes, supplier.IsActive, supplier.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("supplier with name '%s' already exists", supplier.Name))
+		}
+		return fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("supplier")
+	}
+
+	return nil
+}
+
+// Delete deletes a supplier. Products linked to it lose their preferred supplier.
+func (r *PostgresSupplierRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, `DELETE FROM suppliers WHERE id = $1`+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete supplier: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("supplier")
+	}
+
+	return nil
+}
+
+// List retrieves suppliers with pagination and filtering
+func (r *PostgresSupplierRepository) List(ctx context.Context, filter repositories.SupplierFilter, pagination utils.PaginationInfo) ([]*entities.Supplier, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 0
+
+	if filter.Search != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("(name ILIKE $%d OR contact_name ILIKE $%d OR email ILIKE $%d)", argCount, argCount, argCount))
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	if filter.IsActive != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", argCount))
+		args = append(args, *filter.IsActive)
+	}
+
+	scope, args, err := tenantScope(ctx, "tenant_id", args)
+	if err != nil {
+		return nil, utils.PaginationInfo{}, err
+	}
+	argCount = len(args)
+	whereClause := "WHERE " + strings.Join(conditions, " AND ") + scope
+
+	// Build ORDER BY clause
+	orderBy := "name ASC"
+	if supplierOrderColumns[filter.OrderBy] {
+		direction := "ASC"
+		if filter.OrderDir == "DESC" {
+			direction = "DESC"
+		}
+		orderBy = fmt.Sprintf("%s %s", filter.OrderBy, direction)
+	}
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM suppliers %s", whereClause)
+	var total int
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count suppliers: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT id, tenant_id, name, contact_name, email, phone, address,
+			payment_terms_days, lead_time_days, notes, is_active, created_at, updated_at, created_by
+		FROM suppliers
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		whereClause, orderBy, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []*entities.Supplier
+	for rows.Next() {
+		supplier, err := r.scanSupplier(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan supplier: %w", err)
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate suppliers: %w", err)
+	}
+
+	return suppliers, paginationResult, nil
+}
+
+// Helper functions
+
+// scanSupplier scans a supplier from a row
+func (r *PostgresSupplierRepository) scanSupplier(row interface{ Scan(...interface{}) error }) (*entities.Supplier, error) {
+	var supplier entities.Supplier
+	var contactName, email, phone, address, notes sql.NullString
+
+	err := row.Scan(
+		&supplier.ID, &supplier.TenantID, &supplier.Name, &contactName, &email, &phone, &address,
+		&supplier.PaymentTermsDays, &supplier.LeadTimeDays, &notes, &supplier.IsActive,
+		&supplier.CreatedAt, &supplier.UpdatedAt, &supplier.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier.ContactName = contactName.String
+	supplier.Email = email.String
+	supplier.Phone = phone.String
+	supplier.Address = address.String
+	supplier.Notes = notes.String
+
+	return &supplier, nil
+}
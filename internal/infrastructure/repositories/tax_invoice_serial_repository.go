@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresTaxInvoiceSerialRepository implements the TaxInvoiceSerialRepository interface
+type PostgresTaxInvoiceSerialRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTaxInvoiceSerialRepository creates a new PostgreSQL tax invoice serial repository
+func NewPostgresTaxInvoiceSerialRepository(db *sql.DB) repositories.TaxInvoiceSerialRepository {
+	return &PostgresTaxInvoiceSerialRepository{db: db}
+}
+
+const taxInvoiceSerialColumns = `id, tenant_id, invoice_id, range_id, serial, assigned_by, assigned_at`
+
+// CreateRange creates a new range of serials
+func (r *PostgresTaxInvoiceSerialRepository) CreateRange(ctx context.Context, serialRange *entities.TaxInvoiceSerialRange) error {
+	query := `
+		INSERT INTO tax_invoice_serial_ranges (id, tenant_id, branch_code, year, first_number, last_number,
+			next_number, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		serialRange.ID, serialRange.TenantID, serialRange.BranchCode, serialRange.Year, serialRange.FirstNumber,
+		serialRange.LastNumber, serialRange.NextNumber, serialRange.CreatedBy, serialRange.CreatedAt,
+		serialRange.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert tax invoice serial range: %w", err)
+	}
+
+	return nil
+}
+
+// ListRanges retrieves a tenant's serial ranges, newest year first
+func (r *PostgresTaxInvoiceSerialRepository) ListRanges(ctx context.Context, tenantID uuid.UUID) ([]*entities.TaxInvoiceSerialRange, error) {
+	query := `
+		SELECT id, tenant_id, branch_code, year, first_number, last_number, next_number, created_by,
+			created_at, updated_at
+		FROM tax_invoice_serial_ranges
+		WHERE tenant_id = $1
+		ORDER BY year DESC, created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax invoice serial ranges: %w", err)
+	}
+	defer rows.Close()
+
+	ranges := []*entities.TaxInvoiceSerialRange{}
+	for rows.Next() {
+		var serialRange entities.TaxInvoiceSerialRange
+		err := rows.Scan(&serialRange.ID, &serialRange.TenantID, &serialRange.BranchCode, &serialRange.Year,
+			&serialRange.FirstNumber, &serialRange.LastNumber, &serialRange.NextNumber, &serialRange.CreatedBy,
+			&serialRange.CreatedAt, &serialRange.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tax invoice serial range: %w", err)
+		}
+		ranges = append(ranges, &serialRange)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tax invoice serial ranges: %w", err)
+	}
+
+	return ranges, nil
+}
+
+// Assign gives an invoice the next unused serial of the tenant's oldest range for a year with
+// serials left. The range row stays locked until the serial is committed, so concurrent
+// assignments are serialized and a failed insert gives the serial back.
+func (r *PostgresTaxInvoiceSerialRepository) Assign(ctx context.Context, tenantID, invoiceID uuid.UUID, year int, assignedBy uuid.UUID) (*entities.TaxInvoiceSerial, error) {
+	if serial, err := r.GetByInvoiceID(ctx, invoiceID); err == nil {
+		return serial, nil
+	} else if appErr, ok := errors.IsAppError(err); !ok || appErr.Type != errors.ErrorTypeNotFound {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rangeQuery := `
+		SELECT id, branch_code, year, next_number
+		FROM tax_invoice_serial_ranges
+		WHERE tenant_id = $1 AND year = $2 AND next_number <= last_number
+		ORDER BY created_at, first_number
+		LIMIT 1
+		FOR UPDATE`
+
+	var serialRange entities.TaxInvoiceSerialRange
+	err = tx.QueryRowContext(ctx, rangeQuery, tenantID, year).Scan(&serialRange.ID, &serialRange.BranchCode, &serialRange.Year, &serialRange.NextNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("tax invoice serial range")
+		}
+		return nil, fmt.Errorf("failed to lock tax invoice serial range: %w", err)
+	}
+
+	// Another request may have assigned a serial while this one waited for the lock
+	existingQuery := `SELECT ` + taxInvoiceSerialColumns + ` FROM tax_invoice_serials WHERE invoice_id = $1`
+	if serial, err := r.scanSerial(tx.QueryRowContext(ctx, existingQuery, invoiceID)); err == nil {
+		return serial, nil
+	} else if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get tax invoice serial: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tax_invoice_serial_ranges SET next_number = next_number + 1, updated_at = NOW() WHERE id = $1`, serialRange.ID); err != nil {
+		return nil, fmt.Errorf("failed to advance tax invoice serial range: %w", err)
+	}
+
+	serial := &entities.TaxInvoiceSerial{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		InvoiceID:  invoiceID,
+		RangeID:    serialRange.ID,
+		Serial:     serialRange.Serial(serialRange.NextNumber),
+		AssignedBy: assignedBy,
+		AssignedAt: time.Now(),
+	}
+
+	query := `
+		INSERT INTO tax_invoice_serials (` + taxInvoiceSerialColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.ExecContext(ctx, query,
+		serial.ID, serial.TenantID, serial.InvoiceID, serial.RangeID, serial.Serial, serial.AssignedBy, serial.AssignedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, errors.NewConflictError(fmt.Sprintf("tax invoice serial '%s' is already used", serial.Serial))
+		}
+		return nil, fmt.Errorf("failed to insert tax invoice serial: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tax invoice serial: %w", err)
+	}
+
+	return serial, nil
+}
+
+// GetByInvoiceID retrieves the serial of an invoice
+func (r *PostgresTaxInvoiceSerialRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) (*entities.TaxInvoiceSerial, error) {
+	query := `
+		SELECT ` + taxInvoiceSerialColumns + `
+		FROM tax_invoice_serials
+		WHERE invoice_id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{invoiceID})
+	if err != nil {
+		return nil, err
+	}
+	serial, err := r.scanSerial(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("tax invoice serial")
+		}
+		return nil, fmt.Errorf("failed to get tax invoice serial: %w", err)
+	}
+
+	return serial, nil
+}
+
+// scanSerial scans a tax invoice serial row
+func (r *PostgresTaxInvoiceSerialRepository) scanSerial(row interface{ Scan(...interface{}) error }) (*entities.TaxInvoiceSerial, error) {
+	var serial entities.TaxInvoiceSerial
+	err := row.Scan(&serial.ID, &serial.TenantID, &serial.InvoiceID, &serial.RangeID, &serial.Serial,
+		&serial.AssignedBy, &serial.AssignedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serial, nil
+}
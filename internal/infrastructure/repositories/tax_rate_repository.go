@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresTaxRateRepository implements the TaxRateRepository interface
+type PostgresTaxRateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTaxRateRepository creates a new PostgreSQL tax rate repository
+func NewPostgresTaxRateRepository(db *sql.DB) repositories.TaxRateRepository {
+	return &PostgresTaxRateRepository{db: db}
+}
+
+const taxRateColumns = `id, tenant_id, name, rate, is_active, created_at, updated_at, updated_by`
+
+// Create creates a new tax rate
+func (r *PostgresTaxRateRepository) Create(ctx context.Context, taxRate *entities.TaxRate) error {
+	query := fmt.Sprintf(`
+		INSERT INTO tax_rates (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, taxRateColumns)
+
+	_, err := r.db.ExecContext(ctx, query,
+		taxRate.ID, taxRate.TenantID, taxRate.Name, taxRate.Rate, taxRate.IsActive, taxRate.CreatedAt,
+		taxRate.UpdatedAt, taxRate.UpdatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("tax rate '%s' already exists", taxRate.Name))
+		}
+		return fmt.Errorf("failed to insert tax rate: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tax rate by ID
+func (r *PostgresTaxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TaxRate, error) {
+	query := fmt.Sprintf(`SELECT %s FROM tax_rates WHERE id = $1`, taxRateColumns)
+
+	taxRate, err := r.scanTaxRate(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("tax rate")
+		}
+		return nil, fmt.Errorf("failed to get tax rate: %w", err)
+	}
+
+	return taxRate, nil
+}
+
+// GetByTenant retrieves all the tenant's tax rates, ordered by rate
+func (r *PostgresTaxRateRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.TaxRate, error) {
+	query := fmt.Sprintf(`SELECT %s FROM tax_rates WHERE tenant_id = $1 ORDER BY rate, name`, taxRateColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax rates: %w", err)
+	}
+	defer rows.Close()
+
+	taxRates := []*entities.TaxRate{}
+	for rows.Next() {
+		taxRate, err := r.scanTaxRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tax rate: %w", err)
+		}
+		taxRates = append(taxRates, taxRate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tax rates: %w", err)
+	}
+
+	return taxRates, nil
+}
+
+// Update updates a tax rate
+func (r *PostgresTaxRateRepository) Update(ctx context.Context, taxRate *entities.TaxRate) error {
+	query := `
+		UPDATE tax_rates SET
+			name = $2, rate = $3, is_active = $4, updated_at = $5, updated_by = $6
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		taxRate.ID, taxRate.Name, taxRate.Rate, taxRate.IsActive, taxRate.UpdatedAt, taxRate.UpdatedBy)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError(fmt.Sprintf("tax rate '%s' already exists", taxRate.Name))
+		}
+		return fmt.Errorf("failed to update tax rate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("tax rate")
+	}
+
+	return nil
+}
+
+// scanTaxRate scans a tax rate from a row
+func (r *PostgresTaxRateRepository) scanTaxRate(row interface{ Scan(...interface{}) error }) (*entities.TaxRate, error) {
+	var taxRate entities.TaxRate
+
+	err := row.Scan(&taxRate.ID, &taxRate.TenantID, &taxRate.Name, &taxRate.Rate, &taxRate.IsActive,
+		&taxRate.CreatedAt, &taxRate.UpdatedAt, &taxRate.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &taxRate, nil
+}
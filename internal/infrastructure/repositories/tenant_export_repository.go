@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresTenantExportRepository implements the TenantExportRepository interface
+type PostgresTenantExportRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTenantExportRepository creates a new PostgreSQL tenant export repository
+func NewPostgresTenantExportRepository(db *sql.DB) repositories.TenantExportRepository {
+	return &PostgresTenantExportRepository{db: db}
+}
+
+// Create creates a new tenant export job
+func (r *PostgresTenantExportRepository) Create(ctx context.Context, export *entities.TenantExport) error {
+	recordCountsJSON, err := json.Marshal(export.RecordCounts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tenant export record counts: %w", err)
+	}
+
+	query := `
+		INSERT INTO tenant_exports (id, tenant_id, status, file_path, file_size, record_counts,
+			error_message, requested_by, started_at, completed_at, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		export.ID, export.TenantID, export.Status, export.FilePath, export.FileSize, recordCountsJSON,
+		export.ErrorMessage, export.RequestedBy, export.StartedAt, export.CompletedAt, export.ExpiresAt,
+		export.CreatedAt, export.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert tenant export: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tenant export job by ID
+func (r *PostgresTenantExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TenantExport, error) {
+	query := `
+		SELECT id, tenant_id, status, file_path, file_size, record_counts,
+			error_message, requested_by, started_at, completed_at, expires_at, created_at, updated_at
+		FROM tenant_exports
+		WHERE id = $1`
+
+	export, err := r.scanTenantExport(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("tenant export")
+		}
+		return nil, fmt.Errorf("failed to get tenant export: %w", err)
+	}
+
+	return export, nil
+}
+
+// Update updates an existing tenant export job
+func (r *PostgresTenantExportRepository) Update(ctx context.Context, export *entities.TenantExport) error {
+	recordCountsJSON, err := json.Marshal(export.RecordCounts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tenant export record counts: %w", err)
+	}
+
+	query := `
+		UPDATE tenant_exports SET
+			status = $2, file_path = $3, file_size = $4, record_counts = $5, error_message = $6,
+			started_at = $7, completed_at = $8, expires_at = $9, updated_at = $10
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		export.ID, export.Status, export.FilePath, export.FileSize, recordCountsJSON, export.ErrorMessage,
+		export.StartedAt, export.CompletedAt, export.ExpiresAt, export.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update tenant export: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("tenant export")
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves all export jobs for a tenant, newest first
+func (r *PostgresTenantExportRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.TenantExport, error) {
+	query := `
+		SELECT ` + tenantExportColumns + `
+		FROM tenant_exports
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC`
+
+	return r.queryTenantExports(ctx, query, tenantID)
+}
+
+// ClaimDue atomically marks up to limit export jobs as processing, oldest first. Pending jobs
+// are claimed, as are processing jobs started before staleBefore whose worker stopped.
+func (r *PostgresTenantExportRepository) ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]*entities.TenantExport, error) {
+	query := `
+		UPDATE tenant_exports
+		SET status = 'processing', started_at = $1, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM tenant_exports
+			WHERE status = 'pending' OR (status = 'processing' AND started_at < $2)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + tenantExportColumns
+
+	exports, err := r.queryTenantExports(ctx, query, now, staleBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim tenant exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// Helper functions
+
+// tenantExportColumns lists the columns scanned by scanTenantExport
+const tenantExportColumns = `id, tenant_id, status, file_path, file_size, record_counts,
+			error_message, requested_by, started_at, completed_at, expires_at, created_at, updated_at`
+
+// queryTenantExports runs a query returning tenantExportColumns rows
+func (r *PostgresTenantExportRepository) queryTenantExports(ctx context.Context, query string, args ...interface{}) ([]*entities.TenantExport, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenant exports: %w", err)
+	}
+	defer rows.Close()
+
+	var exports []*entities.TenantExport
+	for rows.Next() {
+		export, err := r.scanTenantExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tenant export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tenant exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// scanTenantExport scans a tenant export job from a row
+func (r *PostgresTenantExportRepository) scanTenantExport(row interface{ Scan(...interface{}) error }) (*entities.TenantExport, error) {
+	var export entities.TenantExport
+	var filePath, errorMessage sql.NullString
+	var startedAt, completedAt, expiresAt sql.NullTime
+	var recordCountsJSON []byte
+
+	err := row.Scan(
+		&export.ID, &export.TenantID, &export.Status, &filePath, &export.FileSize, &recordCountsJSON,
+		&errorMessage, &export.RequestedBy, &startedAt, &completedAt, &expiresAt,
+		&export.CreatedAt, &export.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	export.FilePath = filePath.String
+	export.ErrorMessage = errorMessage.String
+	if startedAt.Valid {
+		export.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+
+	export.RecordCounts = map[string]int{}
+	if len(recordCountsJSON) > 0 {
+		if err := json.Unmarshal(recordCountsJSON, &export.RecordCounts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tenant export record counts: %w", err)
+		}
+	}
+
+	return &export, nil
+}
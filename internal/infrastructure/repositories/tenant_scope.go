@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// tenantScope returns a condition restricting column to the tenant the context is scoped to,
+// numbered after the existing arguments, along with the arguments extended by the tenant ID.
+// The condition is empty for contexts spanning all tenants, such as background jobs. Any other
+// unscoped context is refused rather than silently reading or writing every tenant's rows.
+func tenantScope(ctx context.Context, column string, args []interface{}) (string, []interface{}, error) {
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil || !scoped {
+		return "", args, err
+	}
+
+	args = append(args, tenantID)
+	return fmt.Sprintf(" AND %s = $%d", column, len(args)), args, nil
+}
+
+// scopedTenant returns the tenant the context is scoped to and whether it is scoped at all. It
+// refuses unscoped contexts unless they are deliberately marked to span all tenants.
+func scopedTenant(ctx context.Context) (uuid.UUID, bool, error) {
+	if tenantID, ok := entities.TenantScopeFromContext(ctx); ok {
+		return tenantID, true, nil
+	}
+	if entities.SpansAllTenants(ctx) {
+		return uuid.Nil, false, nil
+	}
+	return uuid.Nil, false, errors.NewForbiddenError("data access is not scoped to a tenant")
+}
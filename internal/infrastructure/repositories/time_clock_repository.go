@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresTimeClockRepository implements the TimeClockRepository interface
+type PostgresTimeClockRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTimeClockRepository creates a new PostgreSQL time clock repository
+func NewPostgresTimeClockRepository(db *sql.DB) repositories.TimeClockRepository {
+	return &PostgresTimeClockRepository{db: db}
+}
+
+// Create creates a new time clock entry
+func (r *PostgresTimeClockRepository) Create(ctx context.Context, entry *entities.TimeClockEntry) error {
+	breaksJSON, err := json.Marshal(entry.Breaks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal time clock breaks: %w", err)
+	}
+
+	query := `
+		INSERT INTO time_clock_entries (id, tenant_id, user_id, device_id, clock_in_at, clock_out_at,
+			breaks, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		entry.ID, entry.TenantID, entry.UserID, entry.DeviceID, entry.ClockInAt, entry.ClockOutAt,
+		breaksJSON, entry.Notes, entry.CreatedAt, entry.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.NewConflictError("user is already clocked in")
+		}
+		return fmt.Errorf("failed to insert time clock entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a time clock entry by ID
+func (r *PostgresTimeClockRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TimeClockEntry, error) {
+	query := `
+		SELECT id, tenant_id, user_id, device_id, clock_in_at, clock_out_at, breaks, notes,
+			created_at, updated_at
+		FROM time_clock_entries
+		WHERE id = $1`
+
+	entry, err := r.scanTimeClockEntry(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("time clock entry")
+		}
+		return nil, fmt.Errorf("failed to get time clock entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetOpenByUser retrieves the shift a staff member is currently clocked in to
+func (r *PostgresTimeClockRepository) GetOpenByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entities.TimeClockEntry, error) {
+	query := `
+		SELECT id, tenant_id, user_id, device_id, clock_in_at, clock_out_at, breaks, notes,
+			created_at, updated_at
+		FROM time_clock_entries
+		WHERE tenant_id = $1 AND user_id = $2 AND clock_out_at IS NULL`
+
+	entry, err := r.scanTimeClockEntry(r.db.QueryRowContext(ctx, query, tenantID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("open shift")
+		}
+		return nil, fmt.Errorf("failed to get open time clock entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Update updates a time clock entry and its breaks
+func (r *PostgresTimeClockRepository) Update(ctx context.Context, entry *entities.TimeClockEntry) error {
+	breaksJSON, err := json.Marshal(entry.Breaks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal time clock breaks: %w", err)
+	}
+
+	query := `
+		UPDATE time_clock_entries SET
+			clock_out_at = $2, breaks = $3, notes = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		entry.ID, entry.ClockOutAt, breaksJSON, entry.Notes, entry.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update time clock entry: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("time clock entry")
+	}
+
+	return nil
+}
+
+// GetByPeriod retrieves the shifts overlapping [start, end), optionally for a single staff member
+func (r *PostgresTimeClockRepository) GetByPeriod(ctx context.Context, tenantID uuid.UUID, start, end time.Time, userID *uuid.UUID) ([]*entities.TimeClockEntry, error) {
+	query := `
+		SELECT id, tenant_id, user_id, device_id, clock_in_at, clock_out_at, breaks, notes,
+			created_at, updated_at
+		FROM time_clock_entries
+		WHERE tenant_id = $1 AND clock_in_at < $3 AND (clock_out_at IS NULL OR clock_out_at > $2)`
+	args := []interface{}{tenantID, start, end}
+
+	if userID != nil {
+		query += " AND user_id = $4"
+		args = append(args, *userID)
+	}
+	query += " ORDER BY clock_in_at"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time clock entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.TimeClockEntry
+	for rows.Next() {
+		entry, err := r.scanTimeClockEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan time clock entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate time clock entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Helper functions
+
+// scanTimeClockEntry scans a time clock entry from a row
+func (r *PostgresTimeClockRepository) scanTimeClockEntry(row interface{ Scan(...interface{}) error }) (*entities.TimeClockEntry, error) {
+	var entry entities.TimeClockEntry
+	var deviceID uuid.NullUUID
+	var clockOutAt sql.NullTime
+	var breaksJSON []byte
+
+	err := row.Scan(
+		&entry.ID, &entry.TenantID, &entry.UserID, &deviceID, &entry.ClockInAt, &clockOutAt, &breaksJSON,
+		&entry.Notes, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if deviceID.Valid {
+		entry.DeviceID = &deviceID.UUID
+	}
+	if clockOutAt.Valid {
+		entry.ClockOutAt = &clockOutAt.Time
+	}
+	if err := json.Unmarshal(breaksJSON, &entry.Breaks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal time clock breaks: %w", err)
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresTotalsRecalculationRepository implements the TotalsRecalculationRepository interface
+type PostgresTotalsRecalculationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTotalsRecalculationRepository creates a new PostgreSQL totals recalculation repository
+func NewPostgresTotalsRecalculationRepository(db *sql.DB) repositories.TotalsRecalculationRepository {
+	return &PostgresTotalsRecalculationRepository{db: db}
+}
+
+// Create creates a new recalculation with its corrections
+func (r *PostgresTotalsRecalculationRepository) Create(ctx context.Context, recalculation *entities.TotalsRecalculation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO totals_recalculations (id, tenant_id, document_type, reason, status, scanned_count,
+			changed_count, applied_count, skipped_count, failed_count, requested_by, applied_by,
+			applied_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = tx.ExecContext(ctx, query,
+		recalculation.ID, recalculation.TenantID, recalculation.DocumentType, recalculation.Reason,
+		recalculation.Status, recalculation.ScannedCount, recalculation.ChangedCount, recalculation.AppliedCount,
+		recalculation.SkippedCount, recalculation.FailedCount, recalculation.RequestedBy, recalculation.AppliedBy,
+		recalculation.AppliedAt, recalculation.CreatedAt, recalculation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert totals recalculation: %w", err)
+	}
+
+	correctionQuery := `
+		INSERT INTO totals_corrections (id, recalculation_id, position, document_id, document_number,
+			subtotal_before, tax_amount_before, total_amount_before,
+			subtotal_after, tax_amount_after, total_amount_after, outcome, reason, processed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	for i, correction := range recalculation.Corrections {
+		_, err := tx.ExecContext(ctx, correctionQuery,
+			correction.ID, recalculation.ID, i, correction.DocumentID, correction.DocumentNumber,
+			correction.Before.Subtotal, correction.Before.TaxAmount, correction.Before.TotalAmount,
+			correction.After.Subtotal, correction.After.TaxAmount, correction.After.TotalAmount,
+			correction.Outcome, correction.Reason, correction.ProcessedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert totals correction: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a recalculation with its corrections by ID
+func (r *PostgresTotalsRecalculationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TotalsRecalculation, error) {
+	query := `
+		SELECT id, tenant_id, document_type, reason, status, scanned_count, changed_count,
+			applied_count, skipped_count, failed_count, requested_by, applied_by, applied_at,
+			created_at, updated_at
+		FROM totals_recalculations
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	recalculation, err := r.scanTotalsRecalculation(r.db.QueryRowContext(ctx, query+scope, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("totals recalculation")
+		}
+		return nil, fmt.Errorf("failed to get totals recalculation: %w", err)
+	}
+
+	if err := r.loadCorrections(ctx, recalculation); err != nil {
+		return nil, err
+	}
+
+	return recalculation, nil
+}
+
+// Update updates the status and counters of a recalculation
+func (r *PostgresTotalsRecalculationRepository) Update(ctx context.Context, recalculation *entities.TotalsRecalculation) error {
+	query := `
+		UPDATE totals_recalculations SET
+			status = $2, changed_count = $3, applied_count = $4, skipped_count = $5, failed_count = $6,
+			applied_by = $7, applied_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		recalculation.ID, recalculation.Status, recalculation.ChangedCount, recalculation.AppliedCount,
+		recalculation.SkippedCount, recalculation.FailedCount, recalculation.AppliedBy, recalculation.AppliedAt,
+		recalculation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update totals recalculation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("totals recalculation")
+	}
+
+	return nil
+}
+
+// UpdateCorrection updates the outcome of a single correction
+func (r *PostgresTotalsRecalculationRepository) UpdateCorrection(ctx context.Context, correction *entities.TotalsCorrection) error {
+	query := `
+		UPDATE totals_corrections SET
+			outcome = $2, reason = $3, processed_at = $4
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, correction.ID, correction.Outcome, correction.Reason, correction.ProcessedAt); err != nil {
+		return fmt.Errorf("failed to update totals correction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTenant retrieves the most recent recalculations of a tenant without their corrections
+func (r *PostgresTotalsRecalculationRepository) GetByTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]*entities.TotalsRecalculation, error) {
+	query := `
+		SELECT id, tenant_id, document_type, reason, status, scanned_count, changed_count,
+			applied_count, skipped_count, failed_count, requested_by, applied_by, applied_at,
+			created_at, updated_at
+		FROM totals_recalculations
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query totals recalculations: %w", err)
+	}
+	defer rows.Close()
+
+	var recalculations []*entities.TotalsRecalculation
+	for rows.Next() {
+		recalculation, err := r.scanTotalsRecalculation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan totals recalculation: %w", err)
+		}
+		recalculations = append(recalculations, recalculation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate totals recalculations: %w", err)
+	}
+
+	return recalculations, nil
+}
+
+// Helper functions
+
+// scanTotalsRecalculation scans a totals recalculation from a row
+func (r *PostgresTotalsRecalculationRepository) scanTotalsRecalculation(row interface{ Scan(...interface{}) error }) (*entities.TotalsRecalculation, error) {
+	var recalculation entities.TotalsRecalculation
+	var appliedBy uuid.NullUUID
+	var appliedAt sql.NullTime
+
+	err := row.Scan(
+		&recalculation.ID, &recalculation.TenantID, &recalculation.DocumentType, &recalculation.Reason,
+		&recalculation.Status, &recalculation.ScannedCount, &recalculation.ChangedCount, &recalculation.AppliedCount,
+		&recalculation.SkippedCount, &recalculation.FailedCount, &recalculation.RequestedBy, &appliedBy,
+		&appliedAt, &recalculation.CreatedAt, &recalculation.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if appliedBy.Valid {
+		recalculation.AppliedBy = &appliedBy.UUID
+	}
+	if appliedAt.Valid {
+		recalculation.AppliedAt = &appliedAt.Time
+	}
+
+	return &recalculation, nil
+}
+
+// loadCorrections loads the corrections of a recalculation in their original order
+func (r *PostgresTotalsRecalculationRepository) loadCorrections(ctx context.Context, recalculation *entities.TotalsRecalculation) error {
+	query := `
+		SELECT id, recalculation_id, document_id, document_number,
+			subtotal_before, tax_amount_before, total_amount_before,
+			subtotal_after, tax_amount_after, total_amount_after, outcome, reason, processed_at
+		FROM totals_corrections
+		WHERE recalculation_id = $1
+		ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, recalculation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query totals corrections: %w", err)
+	}
+	defer rows.Close()
+
+	recalculation.Corrections = []entities.TotalsCorrection{}
+	for rows.Next() {
+		var correction entities.TotalsCorrection
+		var processedAt sql.NullTime
+		err := rows.Scan(&correction.ID, &correction.RecalculationID, &correction.DocumentID, &correction.DocumentNumber,
+			&correction.Before.Subtotal, &correction.Before.TaxAmount, &correction.Before.TotalAmount,
+			&correction.After.Subtotal, &correction.After.TaxAmount, &correction.After.TotalAmount,
+			&correction.Outcome, &correction.Reason, &processedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan totals correction: %w", err)
+		}
+		if processedAt.Valid {
+			correction.ProcessedAt = &processedAt.Time
+		}
+		recalculation.Corrections = append(recalculation.Corrections, correction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate totals corrections: %w", err)
+	}
+
+	return nil
+}
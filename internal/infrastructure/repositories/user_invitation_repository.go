@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// userInvitationColumns lists the invitation columns in the order scanUserInvitation reads them
+const userInvitationColumns = `id, tenant_id, user_id, email, invited_by, expires_at, sent_count, last_sent_at,
+			accepted_at, revoked_at, revoked_by, created_at, updated_at`
+
+// PostgresUserInvitationRepository implements the UserInvitationRepository interface
+type PostgresUserInvitationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserInvitationRepository creates a new PostgreSQL user invitation repository
+func NewPostgresUserInvitationRepository(db *sql.DB) repositories.UserInvitationRepository {
+	return &PostgresUserInvitationRepository{db: db}
+}
+
+// Create creates a new invitation
+func (r *PostgresUserInvitationRepository) Create(ctx context.Context, invitation *entities.UserInvitation) error {
+	query := `
+		INSERT INTO user_invitations (id, tenant_id, user_id, email, invited_by, expires_at, sent_count,
+			last_sent_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID, invitation.TenantID, invitation.UserID, invitation.Email, invitation.InvitedBy,
+		invitation.ExpiresAt, invitation.SentCount, invitation.LastSentAt, invitation.CreatedAt, invitation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert user invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an invitation by ID
+func (r *PostgresUserInvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.UserInvitation, error) {
+	query := `
+		SELECT ` + userInvitationColumns + `
+		FROM user_invitations
+		WHERE id = $1`
+
+	invitation, err := r.scanUserInvitation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invitation")
+		}
+		return nil, fmt.Errorf("failed to get user invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// GetLatestByUser retrieves the most recent invitation of a user of a tenant
+func (r *PostgresUserInvitationRepository) GetLatestByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entities.UserInvitation, error) {
+	query := `
+		SELECT ` + userInvitationColumns + `
+		FROM user_invitations
+		WHERE tenant_id = $1 AND user_id = $2
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	invitation, err := r.scanUserInvitation(r.db.QueryRowContext(ctx, query, tenantID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("invitation")
+		}
+		return nil, fmt.Errorf("failed to get user invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// Update updates an existing invitation
+func (r *PostgresUserInvitationRepository) Update(ctx context.Context, invitation *entities.UserInvitation) error {
+	query := `
+		UPDATE user_invitations SET
+			expires_at = $2, sent_count = $3, last_sent_at = $4, accepted_at = $5, revoked_at = $6,
+			revoked_by = $7, updated_at = $8
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		invitation.ID, invitation.ExpiresAt, invitation.SentCount, invitation.LastSentAt, invitation.AcceptedAt,
+		invitation.RevokedAt, invitation.RevokedBy, invitation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update user invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("invitation")
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// scanUserInvitation scans an invitation from a row
+func (r *PostgresUserInvitationRepository) scanUserInvitation(row interface{ Scan(...interface{}) error }) (*entities.UserInvitation, error) {
+	var invitation entities.UserInvitation
+	var acceptedAt, revokedAt sql.NullTime
+	var revokedBy uuid.NullUUID
+
+	err := row.Scan(
+		&invitation.ID, &invitation.TenantID, &invitation.UserID, &invitation.Email, &invitation.InvitedBy,
+		&invitation.ExpiresAt, &invitation.SentCount, &invitation.LastSentAt, &acceptedAt, &revokedAt, &revokedBy,
+		&invitation.CreatedAt, &invitation.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	if revokedAt.Valid {
+		invitation.RevokedAt = &revokedAt.Time
+	}
+	if revokedBy.Valid {
+		invitation.RevokedBy = &revokedBy.UUID
+	}
+
+	return &invitation, nil
+}
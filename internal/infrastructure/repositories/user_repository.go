@@ -31,8 +31,9 @@ func NewPostgreSQLUserRepository(db *sql.DB) repositories.UserRepository {
 // Create creates a new user
 func (r *PostgreSQLUserRepository) Create(ctx context.Context, user *entities.User) error {
 	query := `
-		INSERT INTO users (id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		INSERT INTO users (id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at,
+		                   password_changed_at, must_change_password)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
@@ -46,6 +47,8 @@ func (r *PostgreSQLUserRepository) Create(ctx context.Context, user *entities.Us
 		user.PasswordHash,
 		user.CreatedAt,
 		user.UpdatedAt,
+		user.PasswordChangedAt,
+		user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -70,7 +73,8 @@ func (r *PostgreSQLUserRepository) Create(ctx context.Context, user *entities.Us
 // GetByID retrieves a user by ID
 func (r *PostgreSQLUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	query := `
-		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE id = $1 AND deleted_at IS NULL`
 
@@ -90,6 +94,8 @@ func (r *PostgreSQLUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&lastLoginAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -109,7 +115,8 @@ func (r *PostgreSQLUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 // GetByUsername retrieves a user by username
 func (r *PostgreSQLUserRepository) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
 	query := `
-		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE username = $1 AND deleted_at IS NULL`
 
@@ -129,6 +136,8 @@ func (r *PostgreSQLUserRepository) GetByUsername(ctx context.Context, username s
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&lastLoginAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -148,7 +157,8 @@ func (r *PostgreSQLUserRepository) GetByUsername(ctx context.Context, username s
 // GetByEmail retrieves a user by email
 func (r *PostgreSQLUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	query := `
-		SELECT id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE email = $1`
 
@@ -167,6 +177,8 @@ func (r *PostgreSQLUserRepository) GetByEmail(ctx context.Context, email string)
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&lastLoginAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -188,7 +200,8 @@ func (r *PostgreSQLUserRepository) Update(ctx context.Context, user *entities.Us
 	query := `
 		UPDATE users 
 		SET username = $2, email = $3, first_name = $4, last_name = $5, 
-		    password_hash = $6, role = $7, status = $8, last_login_at = $9, updated_at = $10
+		    password_hash = $6, role = $7, status = $8, last_login_at = $9, updated_at = $10,
+		    password_changed_at = $11, must_change_password = $12
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -202,6 +215,8 @@ func (r *PostgreSQLUserRepository) Update(ctx context.Context, user *entities.Us
 		user.Status,
 		user.LastLoginAt,
 		user.UpdatedAt,
+		user.PasswordChangedAt,
+		user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -265,6 +280,16 @@ func (r *PostgreSQLUserRepository) List(ctx context.Context, filter repositories
 
 	whereConditions = append(whereConditions, "deleted_at IS NULL")
 
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		whereConditions = append(whereConditions, fmt.Sprintf("tenant_id = $%d", argIndex))
+		args = append(args, tenantID)
+		argIndex++
+	}
+
 	if filter.Role != nil {
 		whereConditions = append(whereConditions, fmt.Sprintf("role = $%d", argIndex))
 		args = append(args, *filter.Role)
@@ -299,7 +324,7 @@ func (r *PostgreSQLUserRepository) List(ctx context.Context, filter repositories
 	// Count total records
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users WHERE %s", whereClause)
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, pagination, fmt.Errorf("failed to count users: %w", err)
 	}
@@ -310,7 +335,8 @@ func (r *PostgreSQLUserRepository) List(ctx context.Context, filter repositories
 
 	// Build main query
 	query := fmt.Sprintf(`
-		SELECT id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE %s
 		ORDER BY %s
@@ -332,6 +358,7 @@ func (r *PostgreSQLUserRepository) List(ctx context.Context, filter repositories
 
 		err := rows.Scan(
 			&user.ID,
+			&user.TenantID,
 			&user.Username,
 			&user.Email,
 			&user.FirstName,
@@ -342,6 +369,8 @@ func (r *PostgreSQLUserRepository) List(ctx context.Context, filter repositories
 			&user.CreatedAt,
 			&user.UpdatedAt,
 			&lastLoginAt,
+			&user.PasswordChangedAt,
+			&user.MustChangePassword,
 		)
 		if err != nil {
 			return nil, pagination, fmt.Errorf("failed to scan user: %w", err)
@@ -400,7 +429,8 @@ func (r *PostgreSQLUserRepository) ExistsByEmail(ctx context.Context, email stri
 // GetByTenantAndEmail retrieves a user by tenant ID and email
 func (r *PostgreSQLUserRepository) GetByTenantAndEmail(ctx context.Context, tenantID uuid.UUID, email string) (*entities.User, error) {
 	query := `
-		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE tenant_id = $1 AND email = $2 AND deleted_at IS NULL`
 
@@ -420,6 +450,8 @@ func (r *PostgreSQLUserRepository) GetByTenantAndEmail(ctx context.Context, tena
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&lastLoginAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -439,7 +471,8 @@ func (r *PostgreSQLUserRepository) GetByTenantAndEmail(ctx context.Context, tena
 // GetByTenantAndUsername retrieves a user by tenant ID and username
 func (r *PostgreSQLUserRepository) GetByTenantAndUsername(ctx context.Context, tenantID uuid.UUID, username string) (*entities.User, error) {
 	query := `
-		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE tenant_id = $1 AND username = $2 AND deleted_at IS NULL`
 
@@ -459,6 +492,8 @@ func (r *PostgreSQLUserRepository) GetByTenantAndUsername(ctx context.Context, t
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&lastLoginAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
 	)
 
 	if err != nil {
@@ -491,7 +526,8 @@ func (r *PostgreSQLUserRepository) ListByTenant(ctx context.Context, tenantID uu
 
 	// Get users for this tenant
 	query := `
-		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at
+		SELECT id, tenant_id, username, email, first_name, last_name, role, status, password_hash, created_at, updated_at, last_login_at,
+		       password_changed_at, must_change_password
 		FROM users 
 		WHERE tenant_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC 
@@ -521,6 +557,8 @@ func (r *PostgreSQLUserRepository) ListByTenant(ctx context.Context, tenantID uu
 			&user.CreatedAt,
 			&user.UpdatedAt,
 			&lastLoginAt,
+			&user.PasswordChangedAt,
+			&user.MustChangePassword,
 		)
 		if err != nil {
 			return nil, pagination, fmt.Errorf("failed to scan user: %w", err)
@@ -550,6 +588,75 @@ func (r *PostgreSQLUserRepository) ListByTenant(ctx context.Context, tenantID uu
 	return users, resultPagination, nil
 }
 
+// GetActiveEmailsByRole retrieves the email addresses of a tenant's active users with a role
+func (r *PostgreSQLUserRepository) GetActiveEmailsByRole(ctx context.Context, tenantID uuid.UUID, role entities.UserRole) ([]string, error) {
+	query := `
+		SELECT email
+		FROM users
+		WHERE tenant_id = $1 AND role = $2 AND status = $3 AND deleted_at IS NULL
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, role, entities.UserStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user emails: %w", err)
+	}
+	defer rows.Close()
+
+	emails := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan user email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+// RequirePasswordChange makes a tenant's users change their password on next login, either the
+// given users or, when none are given, every user with the role, or every user when role is nil.
+// It returns the IDs of the affected users.
+func (r *PostgreSQLUserRepository) RequirePasswordChange(ctx context.Context, tenantID uuid.UUID, userIDs []uuid.UUID, role *entities.UserRole, at time.Time) ([]uuid.UUID, error) {
+	query := `
+		UPDATE users
+		SET must_change_password = TRUE, updated_at = $2
+		WHERE tenant_id = $1 AND deleted_at IS NULL
+		  AND (cardinality($3::uuid[]) = 0 OR id = ANY($3))
+		  AND ($4::text IS NULL OR role = $4)
+		RETURNING id`
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, at, pq.Array(ids), role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to require password change: %w", err)
+	}
+	defer rows.Close()
+
+	var affected []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		affected = append(affected, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user IDs: %w", err)
+	}
+
+	return affected, nil
+}
+
 // CountByTenant returns the count of users for a specific tenant
 func (r *PostgreSQLUserRepository) CountByTenant(ctx context.Context, tenantID uuid.UUID) (int, error) {
 	query := `SELECT COUNT(*) FROM users WHERE tenant_id = $1 AND deleted_at IS NULL`
@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// userSessionColumns lists the session columns in the order scanUserSession reads them
+const userSessionColumns = `id, user_id, tenant_id, device_name, user_agent, ip_address, refresh_token_hash,
+			last_refreshed_at, expires_at, revoked_at, revoked_by, revoke_reason, created_at, updated_at`
+
+// PostgresUserSessionRepository implements the UserSessionRepository interface
+type PostgresUserSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserSessionRepository creates a new PostgreSQL user session repository
+func NewPostgresUserSessionRepository(db *sql.DB) repositories.UserSessionRepository {
+	return &PostgresUserSessionRepository{db: db}
+}
+
+// Create creates a new session
+func (r *PostgresUserSessionRepository) Create(ctx context.Context, session *entities.UserSession) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id, tenant_id, device_name, user_agent, ip_address,
+			refresh_token_hash, last_refreshed_at, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID, session.UserID, session.TenantID, session.DeviceName, session.UserAgent, session.IPAddress,
+		session.RefreshTokenHash, session.LastRefreshedAt, session.ExpiresAt, session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert user session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *PostgresUserSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.UserSession, error) {
+	query := `
+		SELECT ` + userSessionColumns + `
+		FROM user_sessions
+		WHERE id = $1`
+
+	session, err := r.scanUserSession(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("session")
+		}
+		return nil, fmt.Errorf("failed to get user session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Update updates an existing session if its refresh token hash is still previousRefreshTokenHash.
+// Two refreshes racing with the same token both read the session, but only the first one's
+// compare-and-swap matches; the second gets a conflict error and is treated as token reuse.
+func (r *PostgresUserSessionRepository) Update(ctx context.Context, session *entities.UserSession, previousRefreshTokenHash string) error {
+	query := `
+		UPDATE user_sessions SET
+			ip_address = $2, refresh_token_hash = $3, last_refreshed_at = $4, expires_at = $5,
+			revoked_at = $6, revoked_by = $7, revoke_reason = $8, updated_at = $9
+		WHERE id = $1 AND refresh_token_hash = $10`
+
+	result, err := r.db.ExecContext(ctx, query,
+		session.ID, session.IPAddress, session.RefreshTokenHash, session.LastRefreshedAt, session.ExpiresAt,
+		session.RevokedAt, session.RevokedBy, session.RevokeReason, session.UpdatedAt, previousRefreshTokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to update user session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM user_sessions WHERE id = $1)`, session.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check user session: %w", err)
+		}
+		if !exists {
+			return errors.NewNotFoundError("session")
+		}
+		return errors.NewConflictError("session refresh token has already been rotated")
+	}
+
+	return nil
+}
+
+// GetActiveByUser retrieves the sessions of a user that are active at the given time
+func (r *PostgresUserSessionRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID, at time.Time) ([]*entities.UserSession, error) {
+	query := `
+		SELECT ` + userSessionColumns + `
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_refreshed_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*entities.UserSession
+	for rows.Next() {
+		session, err := r.scanUserSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetIPAddressesByUser retrieves the distinct IP addresses of a user's sessions created since the given time
+func (r *PostgresUserSessionRepository) GetIPAddressesByUser(ctx context.Context, userID uuid.UUID, since time.Time) ([]string, error) {
+	query := `
+		SELECT DISTINCT ip_address
+		FROM user_sessions
+		WHERE user_id = $1 AND created_at >= $2 AND ip_address <> ''`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session IP addresses: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("failed to scan session IP address: %w", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate session IP addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// RevokeAllByUser revokes every active session of a user and returns the IDs of the revoked sessions
+func (r *PostgresUserSessionRepository) RevokeAllByUser(ctx context.Context, userID uuid.UUID, revokedBy *uuid.UUID, reason entities.SessionRevokeReason, at time.Time) ([]uuid.UUID, error) {
+	query := `
+		UPDATE user_sessions SET
+			revoked_at = $2, revoked_by = $3, revoke_reason = $4, updated_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		RETURNING id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, at, revokedBy, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked session: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate revoked sessions: %w", err)
+	}
+
+	return ids, nil
+}
+
+// DeleteExpired deletes sessions that expired or were revoked before the given time
+func (r *PostgresUserSessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_sessions WHERE expires_at <= $1 OR revoked_at <= $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired user sessions: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted user sessions: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// Helper functions
+
+// scanUserSession scans a session from a row
+func (r *PostgresUserSessionRepository) scanUserSession(row interface{ Scan(...interface{}) error }) (*entities.UserSession, error) {
+	var session entities.UserSession
+	var revokedAt sql.NullTime
+	var revokedBy uuid.NullUUID
+
+	err := row.Scan(
+		&session.ID, &session.UserID, &session.TenantID, &session.DeviceName, &session.UserAgent, &session.IPAddress,
+		&session.RefreshTokenHash, &session.LastRefreshedAt, &session.ExpiresAt, &revokedAt, &revokedBy,
+		&session.RevokeReason, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	if revokedBy.Valid {
+		session.RevokedBy = &revokedBy.UUID
+	}
+
+	return &session, nil
+}
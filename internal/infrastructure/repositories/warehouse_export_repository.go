@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// PostgresWarehouseExportRepository implements the WarehouseExportRepository interface
+type PostgresWarehouseExportRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWarehouseExportRepository creates a new PostgreSQL warehouse export repository
+func NewPostgresWarehouseExportRepository(db *sql.DB) repositories.WarehouseExportRepository {
+	return &PostgresWarehouseExportRepository{db: db}
+}
+
+// GetSettingByTenant retrieves the warehouse export setting of a tenant
+func (r *PostgresWarehouseExportRepository) GetSettingByTenant(ctx context.Context, tenantID uuid.UUID) (*entities.WarehouseExportSetting, error) {
+	query := `
+		SELECT id, tenant_id, is_enabled, exported_through, last_run_at, last_error,
+			created_at, updated_at, updated_by
+		FROM warehouse_export_settings
+		WHERE tenant_id = $1`
+
+	setting, err := r.scanSetting(r.db.QueryRowContext(ctx, query, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("warehouse export setting")
+		}
+		return nil, fmt.Errorf("failed to get warehouse export setting: %w", err)
+	}
+
+	return setting, nil
+}
+
+// SaveSetting creates or updates the warehouse export setting of a tenant. The watermark is
+// owned by the export runs and is never overwritten here.
+func (r *PostgresWarehouseExportRepository) SaveSetting(ctx context.Context, setting *entities.WarehouseExportSetting) error {
+	query := `
+		INSERT INTO warehouse_export_settings (id, tenant_id, is_enabled, exported_through, last_run_at,
+			last_error, created_at, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			is_enabled = EXCLUDED.is_enabled,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by`
+
+	_, err := r.db.ExecContext(ctx, query,
+		setting.ID, setting.TenantID, setting.IsEnabled, setting.ExportedThrough, setting.LastRunAt,
+		setting.LastError, setting.CreatedAt, setting.UpdatedAt, setting.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save warehouse export setting: %w", err)
+	}
+
+	return nil
+}
+
+// ListEnabledSettings retrieves all enabled warehouse export settings across tenants
+func (r *PostgresWarehouseExportRepository) ListEnabledSettings(ctx context.Context) ([]*entities.WarehouseExportSetting, error) {
+	query := `
+		SELECT id, tenant_id, is_enabled, exported_through, last_run_at, last_error,
+			created_at, updated_at, updated_by
+		FROM warehouse_export_settings
+		WHERE is_enabled = true
+		ORDER BY tenant_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse export settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []*entities.WarehouseExportSetting
+	for rows.Next() {
+		setting, err := r.scanSetting(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse export setting: %w", err)
+		}
+		settings = append(settings, setting)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse export settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// ClaimWindow atomically advances the watermark from previous to through
+func (r *PostgresWarehouseExportRepository) ClaimWindow(ctx context.Context, id uuid.UUID, previous *time.Time, through time.Time) (bool, error) {
+	query := `
+		UPDATE warehouse_export_settings
+		SET exported_through = $3
+		WHERE id = $1 AND is_enabled = true AND exported_through IS NOT DISTINCT FROM $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, previous, through)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim warehouse export window: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// ReleaseWindow restores the previous watermark after a failed export so the window is retried
+func (r *PostgresWarehouseExportRepository) ReleaseWindow(ctx context.Context, id uuid.UUID, through time.Time, previous *time.Time) error {
+	query := `
+		UPDATE warehouse_export_settings
+		SET exported_through = $3
+		WHERE id = $1 AND exported_through = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, id, through, previous); err != nil {
+		return fmt.Errorf("failed to release warehouse export window: %w", err)
+	}
+
+	return nil
+}
+
+// RecordRun records when the export last ran and the error it failed with, if any
+func (r *PostgresWarehouseExportRepository) RecordRun(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error {
+	query := `
+		UPDATE warehouse_export_settings
+		SET last_run_at = $2, last_error = $3
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, runAt, lastError); err != nil {
+		return fmt.Errorf("failed to record warehouse export run: %w", err)
+	}
+
+	return nil
+}
+
+// SaveFile records an exported file, replacing the record of a previous export of the same key
+func (r *PostgresWarehouseExportRepository) SaveFile(ctx context.Context, file *entities.WarehouseExportFile) error {
+	query := `
+		INSERT INTO warehouse_export_files (id, tenant_id, dataset, partition_date, object_key, row_count,
+			size_bytes, window_start, window_end, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (tenant_id, object_key) DO UPDATE SET
+			row_count = EXCLUDED.row_count,
+			size_bytes = EXCLUDED.size_bytes,
+			created_at = EXCLUDED.created_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		file.ID, file.TenantID, file.Dataset, file.PartitionDate.Format("2006-01-02"), file.ObjectKey,
+		file.RowCount, file.SizeBytes, file.WindowStart, file.WindowEnd, file.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save warehouse export file: %w", err)
+	}
+
+	return nil
+}
+
+// ListFiles retrieves the exported files of a tenant in partition order
+func (r *PostgresWarehouseExportRepository) ListFiles(ctx context.Context, tenantID uuid.UUID, filter repositories.WarehouseExportFileFilter) ([]*entities.WarehouseExportFile, error) {
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+
+	if filter.Dataset != nil {
+		args = append(args, *filter.Dataset)
+		conditions = append(conditions, fmt.Sprintf("dataset = $%d", len(args)))
+	}
+
+	if filter.DateFrom != nil {
+		args = append(args, filter.DateFrom.Format("2006-01-02"))
+		conditions = append(conditions, fmt.Sprintf("partition_date >= $%d", len(args)))
+	}
+
+	if filter.DateTo != nil {
+		args = append(args, filter.DateTo.Format("2006-01-02"))
+		conditions = append(conditions, fmt.Sprintf("partition_date <= $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, tenant_id, dataset, partition_date, object_key, row_count, size_bytes,
+			window_start, window_end, created_at
+		FROM warehouse_export_files
+		WHERE %s
+		ORDER BY dataset, partition_date, window_start`, strings.Join(conditions, " AND "))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse export files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []*entities.WarehouseExportFile
+	for rows.Next() {
+		var file entities.WarehouseExportFile
+		err := rows.Scan(&file.ID, &file.TenantID, &file.Dataset, &file.PartitionDate, &file.ObjectKey,
+			&file.RowCount, &file.SizeBytes, &file.WindowStart, &file.WindowEnd, &file.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse export file: %w", err)
+		}
+		// DATE columns carry no zone; partitions are UTC dates
+		date := file.PartitionDate
+		file.PartitionDate = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		files = append(files, &file)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse export files: %w", err)
+	}
+
+	return files, nil
+}
+
+// Helper functions
+
+// scanSetting scans a warehouse export setting from a row
+func (r *PostgresWarehouseExportRepository) scanSetting(row interface{ Scan(...interface{}) error }) (*entities.WarehouseExportSetting, error) {
+	var setting entities.WarehouseExportSetting
+	var exportedThrough, lastRunAt sql.NullTime
+
+	err := row.Scan(
+		&setting.ID, &setting.TenantID, &setting.IsEnabled, &exportedThrough, &lastRunAt, &setting.LastError,
+		&setting.CreatedAt, &setting.UpdatedAt, &setting.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if exportedThrough.Valid {
+		setting.ExportedThrough = &exportedThrough.Time
+	}
+	if lastRunAt.Valid {
+		setting.LastRunAt = &lastRunAt.Time
+	}
+
+	return &setting, nil
+}
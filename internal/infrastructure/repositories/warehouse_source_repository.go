@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/domain/repositories"
+)
+
+// PostgresWarehouseSourceRepository implements the WarehouseSourceRepository interface
+type PostgresWarehouseSourceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWarehouseSourceRepository creates a new PostgreSQL warehouse source repository
+func NewPostgresWarehouseSourceRepository(db *sql.DB) repositories.WarehouseSourceRepository {
+	return &PostgresWarehouseSourceRepository{db: db}
+}
+
+// GetSales retrieves sales created, updated or deleted in the window. Soft-deleted sales are
+// included so the warehouse can drop them.
+func (r *PostgresWarehouseSourceRepository) GetSales(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]repositories.WarehouseSaleRow, error) {
+	query := `
+		SELECT id, sale_number, status, channel, COALESCE(customer_name, ''), COALESCE(payment_method, ''),
+			subtotal, discount_amount, tax_amount, surcharge_amount, total_amount,
+			COALESCE(currency, ''), exchange_rate, created_by, created_at, updated_at, completed_at, deleted_at
+		FROM sales
+		WHERE tenant_id = $1 AND updated_at >= $2 AND updated_at < $3
+		ORDER BY updated_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse sales: %w", err)
+	}
+	defer rows.Close()
+
+	sales := []repositories.WarehouseSaleRow{}
+	for rows.Next() {
+		var sale repositories.WarehouseSaleRow
+		var completedAt, deletedAt sql.NullTime
+		err := rows.Scan(&sale.ID, &sale.SaleNumber, &sale.Status, &sale.Channel, &sale.CustomerName, &sale.PaymentMethod,
+			&sale.Subtotal, &sale.DiscountAmount, &sale.TaxAmount, &sale.SurchargeAmount, &sale.TotalAmount,
+			&sale.Currency, &sale.ExchangeRate, &sale.CreatedBy, &sale.CreatedAt, &sale.UpdatedAt, &completedAt, &deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse sale: %w", err)
+		}
+		if completedAt.Valid {
+			sale.CompletedAt = &completedAt.Time
+		}
+		if deletedAt.Valid {
+			sale.DeletedAt = &deletedAt.Time
+		}
+		sales = append(sales, sale)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse sales: %w", err)
+	}
+
+	return sales, nil
+}
+
+// GetSaleItems retrieves the current items of sales updated in the window
+func (r *PostgresWarehouseSourceRepository) GetSaleItems(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]repositories.WarehouseSaleItemRow, error) {
+	query := `
+		SELECT si.id, si.sale_id, s.sale_number, si.product_id, si.product_sku, si.product_name,
+			si.quantity, si.list_price, si.unit_price, si.unit_cost, si.total_price, si.price_source, s.updated_at
+		FROM sale_items si
+		JOIN sales s ON s.id = si.sale_id
+		WHERE s.tenant_id = $1 AND s.updated_at >= $2 AND s.updated_at < $3
+		ORDER BY s.updated_at, si.sale_id, si.id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse sale items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []repositories.WarehouseSaleItemRow{}
+	for rows.Next() {
+		var item repositories.WarehouseSaleItemRow
+		err := rows.Scan(&item.ID, &item.SaleID, &item.SaleNumber, &item.ProductID, &item.ProductSKU, &item.ProductName,
+			&item.Quantity, &item.ListPrice, &item.UnitPrice, &item.UnitCost, &item.TotalPrice, &item.PriceSource,
+			&item.SaleUpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse sale item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse sale items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetPayments retrieves sale payments completed and refunds paid out in the window
+func (r *PostgresWarehouseSourceRepository) GetPayments(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]repositories.WarehousePaymentRow, error) {
+	query := `
+		SELECT id, 'payment', id, sale_number, sale_number, COALESCE(payment_method, ''),
+			total_amount, paid_amount, change_amount, surcharge_amount, completed_at
+		FROM sales
+		WHERE tenant_id = $1 AND completed_at >= $2 AND completed_at < $3
+			AND status IN ('completed', 'refunded')
+		UNION ALL
+		SELECT r.id, 'refund', r.sale_id, s.sale_number, r.refund_number, r.refund_method,
+			-r.total_amount, 0, 0, 0, r.created_at
+		FROM refunds r
+		JOIN sales s ON s.id = r.sale_id
+		WHERE r.tenant_id = $1 AND r.created_at >= $2 AND r.created_at < $3
+		ORDER BY 11, 1`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []repositories.WarehousePaymentRow{}
+	for rows.Next() {
+		var payment repositories.WarehousePaymentRow
+		err := rows.Scan(&payment.ID, &payment.Kind, &payment.SaleID, &payment.SaleNumber, &payment.Reference,
+			&payment.Method, &payment.Amount, &payment.Tendered, &payment.Change, &payment.Surcharge, &payment.OccurredAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// GetStockMovements retrieves stock movements recorded in the window
+func (r *PostgresWarehouseSourceRepository) GetStockMovements(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]repositories.WarehouseStockMovementRow, error) {
+	query := `
+		SELECT sm.id, sm.product_id, p.sku, p.name, sm.type, sm.reason, sm.quantity,
+			COALESCE(sm.reference, ''), COALESCE(sm.notes, ''), sm.created_by, sm.created_at
+		FROM stock_movements sm
+		JOIN products p ON p.id = sm.product_id
+		WHERE sm.tenant_id = $1 AND sm.created_at >= $2 AND sm.created_at < $3
+		ORDER BY sm.created_at, sm.id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []repositories.WarehouseStockMovementRow{}
+	for rows.Next() {
+		var movement repositories.WarehouseStockMovementRow
+		err := rows.Scan(&movement.ID, &movement.ProductID, &movement.ProductSKU, &movement.ProductName,
+			&movement.Type, &movement.Reason, &movement.Quantity, &movement.Reference, &movement.Notes,
+			&movement.CreatedBy, &movement.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse stock movement: %w", err)
+		}
+		movements = append(movements, movement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// GetCostAdjustments retrieves inventory cost adjustments recorded in the window
+func (r *PostgresWarehouseSourceRepository) GetCostAdjustments(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]repositories.WarehouseCostAdjustmentRow, error) {
+	query := `
+		SELECT a.id, a.product_id, p.sku, p.name, a.reason, a.quantity, a.on_hand_qty, a.previous_unit_cost,
+			a.adjusted_unit_cost, a.resulting_unit_cost, a.amount, COALESCE(a.notes, ''), a.created_by, a.created_at
+		FROM inventory_cost_adjustments a
+		JOIN products p ON p.id = a.product_id
+		WHERE a.tenant_id = $1 AND a.created_at >= $2 AND a.created_at < $3
+		ORDER BY a.created_at, a.id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query warehouse inventory cost adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	adjustments := []repositories.WarehouseCostAdjustmentRow{}
+	for rows.Next() {
+		var adjustment repositories.WarehouseCostAdjustmentRow
+		err := rows.Scan(&adjustment.ID, &adjustment.ProductID, &adjustment.ProductSKU, &adjustment.ProductName,
+			&adjustment.Reason, &adjustment.Quantity, &adjustment.OnHandQty, &adjustment.PreviousUnitCost,
+			&adjustment.AdjustedUnitCost, &adjustment.ResultingUnitCost, &adjustment.Amount, &adjustment.Notes,
+			&adjustment.CreatedBy, &adjustment.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan warehouse inventory cost adjustment: %w", err)
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warehouse inventory cost adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,48 @@
+package repositories
+
+// WarmUpStatements are the statements on the checkout path of POS terminals: scanning an
+// item, reading and locking its stock and recording the sale. They are prepared on each
+// pooled connection at startup so the first checkouts after a deploy do not pay for loading
+// the tables and indexes they use. Keep them in sync with the repositories' queries.
+var WarmUpStatements = []string{
+	`SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+	       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, available_from, available_until, is_seasonal, created_at, updated_at, created_by
+	FROM products
+	WHERE tenant_id = $1 AND barcode = $2 AND deleted_at IS NULL`,
+	`SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+	       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, available_from, available_until, is_seasonal, created_at, updated_at, created_by
+	FROM products
+	WHERE tenant_id = $1 AND sku = $2 AND deleted_at IS NULL`,
+	`SELECT id, tenant_id, sku, name, description, category, price, cost, status, unit, min_stock, barcode, promo_price, promo_starts_at, promo_ends_at,
+	       publish_state, publish_at, published_at, submitted_by, reviewed_by, review_notes, supplier_id, available_from, available_until, is_seasonal, created_at, updated_at, created_by
+	FROM products
+	WHERE id = $1 AND deleted_at IS NULL`,
+	`SELECT id, product_id, available_qty, reserved_qty, total_qty, reorder_level,
+	       last_movement_at, created_at, updated_at
+	FROM stock
+	WHERE product_id = $1 AND tenant_id = $2 FOR UPDATE`,
+	`INSERT INTO sales (id, sale_number, customer_name, customer_email, customer_phone,
+		subtotal, tax_amount, discount_amount, total_amount, paid_amount, change_amount,
+		payment_method, channel, status, notes, created_at, updated_at, completed_at, created_by,
+		tax_rate, surcharge_amount, surcharge_tax_amount, surcharge_label, tenant_id,
+		held_at, held_by, hold_expires_at, hold_label)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+		$20, $21, $22, $23, $24, $25, $26, $27, $28)`,
+	`INSERT INTO sale_items (id, sale_id, product_id, product_sku, product_name,
+		quantity, unit_price, total_price, list_price, unit_cost, price_source, override_reason, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+	`INSERT INTO stock_movements (id, product_id, type, reason, quantity, reference, notes, created_at, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+}
+
+// WarmUpQueries read the reference data nearly every request needs: product categories and
+// the tenants' configuration, which holds their tax rates and receipt templates. They are run
+// once at startup so that data is already in PostgreSQL's buffer cache.
+var WarmUpQueries = []string{
+	`SELECT DISTINCT category
+	FROM products
+	WHERE deleted_at IS NULL AND category IS NOT NULL AND category != ''`,
+	`SELECT id, configuration
+	FROM tenants
+	WHERE status IN ('active', 'trial')`,
+}
@@ -0,0 +1,577 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/repositories"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+const webhookEndpointColumns = `id, tenant_id, url, secret, events, description, is_active, consecutive_failures,
+			paused_at, pause_reason, created_at, updated_at, created_by`
+
+const webhookDeliveryColumns = `id, tenant_id, endpoint_id, event_id, event_type, replay_of, payload, status, attempts,
+			next_attempt_at, last_attempt_at, response_status, last_error, delivered_at, created_at, updated_at`
+
+// PostgresWebhookRepository implements the WebhookRepository interface
+type PostgresWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookRepository creates a new PostgreSQL webhook repository
+func NewPostgresWebhookRepository(db *sql.DB) repositories.WebhookRepository {
+	return &PostgresWebhookRepository{db: db}
+}
+
+// CreateEndpoint creates a new webhook endpoint
+func (r *PostgresWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	query := `
+		INSERT INTO webhook_endpoints (` + webhookEndpointColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		endpoint.ID, endpoint.TenantID, endpoint.URL, endpoint.Secret, pq.Array(webhookEventStrings(endpoint.Events)),
+		endpoint.Description, endpoint.IsActive, endpoint.ConsecutiveFailures, endpoint.PausedAt,
+		endpoint.PauseReason, endpoint.CreatedAt, endpoint.UpdatedAt, endpoint.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetEndpointByID retrieves a webhook endpoint by ID
+func (r *PostgresWebhookRepository) GetEndpointByID(ctx context.Context, id uuid.UUID) (*entities.WebhookEndpoint, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE id = $1` + scope
+
+	endpoint, err := r.scanWebhookEndpoint(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("webhook endpoint")
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// GetEndpointsByTenant retrieves all webhook endpoints of a tenant
+func (r *PostgresWebhookRepository) GetEndpointsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entities.WebhookEndpoint, error) {
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE tenant_id = $1 ORDER BY created_at`
+
+	return r.queryWebhookEndpoints(ctx, query, tenantID)
+}
+
+// GetActiveEndpointsForEvent retrieves the active endpoints of a tenant subscribed to an event
+func (r *PostgresWebhookRepository) GetActiveEndpointsForEvent(ctx context.Context, tenantID uuid.UUID, eventType entities.WebhookEventType) ([]*entities.WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE tenant_id = $1 AND is_active = true AND $2 = ANY(events)
+		ORDER BY created_at`
+
+	return r.queryWebhookEndpoints(ctx, query, tenantID, string(eventType))
+}
+
+// UpdateEndpoint updates a webhook endpoint, including its secret
+func (r *PostgresWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $2, secret = $3, events = $4, description = $5, is_active = $6, consecutive_failures = $7,
+			paused_at = $8, pause_reason = $9, updated_at = $10
+		WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{
+		endpoint.ID, endpoint.URL, endpoint.Secret, pq.Array(webhookEventStrings(endpoint.Events)),
+		endpoint.Description, endpoint.IsActive, endpoint.ConsecutiveFailures, endpoint.PausedAt,
+		endpoint.PauseReason, endpoint.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("webhook endpoint")
+	}
+
+	return nil
+}
+
+// DeleteEndpoint deletes a webhook endpoint and its deliveries
+func (r *PostgresWebhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM webhook_endpoints WHERE id = $1`
+
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query+scope, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("webhook endpoint")
+	}
+
+	return nil
+}
+
+// RecordEndpointFailure counts a failed attempt to an endpoint and returns how many attempts
+// failed in a row. The count is incremented in place so concurrent delivery runs add up.
+func (r *PostgresWebhookRepository) RecordEndpointFailure(ctx context.Context, id uuid.UUID) (int, error) {
+	query := `
+		UPDATE webhook_endpoints
+		SET consecutive_failures = consecutive_failures + 1
+		WHERE id = $1
+		RETURNING consecutive_failures`
+
+	var failures int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&failures); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.NewNotFoundError("webhook endpoint")
+		}
+		return 0, fmt.Errorf("failed to record webhook endpoint failure: %w", err)
+	}
+
+	return failures, nil
+}
+
+// ResetEndpointFailures clears the failure count of an endpoint after a successful attempt
+func (r *PostgresWebhookRepository) ResetEndpointFailures(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE webhook_endpoints SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures > 0`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to reset webhook endpoint failures: %w", err)
+	}
+
+	return nil
+}
+
+// PauseEndpoint deactivates an endpoint that keeps failing, unless it is already inactive
+func (r *PostgresWebhookRepository) PauseEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) (bool, error) {
+	query := `
+		UPDATE webhook_endpoints
+		SET is_active = false, paused_at = $2, pause_reason = $3, updated_at = $4
+		WHERE id = $1 AND is_active = true`
+
+	result, err := r.db.ExecContext(ctx, query, endpoint.ID, endpoint.PausedAt, endpoint.PauseReason, endpoint.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to pause webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// CreateDeliveries records deliveries of an event
+func (r *PostgresWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, delivery := range deliveries {
+		if _, err := tx.ExecContext(ctx, insertWebhookDeliveryQuery, webhookDeliveryArgs(delivery)...); err != nil {
+			return fmt.Errorf("failed to insert webhook delivery: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CreateReplay records a replay delivery unless a replay of the same original is still pending
+func (r *PostgresWebhookRepository) CreateReplay(ctx context.Context, replay *entities.WebhookDelivery) (bool, error) {
+	query := insertWebhookDeliveryQuery + `
+		ON CONFLICT (replay_of) WHERE status = 'pending' DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, webhookDeliveryArgs(replay)...)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert webhook replay: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// GetReplays retrieves the replays of a delivery, oldest first
+func (r *PostgresWebhookRepository) GetReplays(ctx context.Context, deliveryID uuid.UUID) ([]*entities.WebhookDelivery, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{deliveryID})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE replay_of = $1` + scope + ` ORDER BY created_at`
+
+	return r.queryWebhookDeliveries(ctx, query, args...)
+}
+
+// GetReplayCandidates retrieves an endpoint's original deliveries created in [from, to) that
+// still need to be replayed
+func (r *PostgresWebhookRepository) GetReplayCandidates(ctx context.Context, endpointID uuid.UUID, from, to time.Time, includeDelivered bool, limit int) ([]*entities.WebhookDelivery, error) {
+	statuses := []string{string(entities.WebhookDeliveryStatusFailed)}
+	if includeDelivered {
+		statuses = append(statuses, string(entities.WebhookDeliveryStatusDelivered))
+	}
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.endpoint_id = $1 AND d.replay_of IS NULL AND d.created_at >= $2 AND d.created_at < $3
+			AND d.status = ANY($4)
+			AND NOT EXISTS (
+				SELECT 1 FROM webhook_deliveries r
+				WHERE r.replay_of = d.id AND (r.status = 'pending' OR (r.status = 'delivered' AND NOT $5))
+			)`
+
+	scope, args, err := tenantScope(ctx, "d.tenant_id", []interface{}{endpointID, from, to, pq.Array(statuses), includeDelivered})
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, limit)
+	query += scope + fmt.Sprintf(" ORDER BY d.created_at LIMIT $%d", len(args))
+
+	return r.queryWebhookDeliveries(ctx, query, args...)
+}
+
+// GetDeliveryByID retrieves a webhook delivery by ID
+func (r *PostgresWebhookRepository) GetDeliveryByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	scope, args, err := tenantScope(ctx, "tenant_id", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1` + scope
+
+	delivery, err := r.scanWebhookDelivery(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("webhook delivery")
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ListDeliveries retrieves webhook deliveries with pagination and filtering, newest first
+func (r *PostgresWebhookRepository) ListDeliveries(ctx context.Context, filter repositories.WebhookDeliveryFilter, pagination utils.PaginationInfo) ([]*entities.WebhookDelivery, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argCount := 0
+
+	tenantID, scoped, err := scopedTenant(ctx)
+	if err != nil {
+		return nil, pagination, err
+	}
+	if scoped {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	}
+
+	if filter.EndpointID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("endpoint_id = $%d", argCount))
+		args = append(args, *filter.EndpointID)
+	}
+
+	if filter.EventType != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", argCount))
+		args = append(args, *filter.EventType)
+	}
+
+	if filter.Status != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, *filter.Status)
+	}
+
+	if filter.FromDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argCount))
+		args = append(args, *filter.FromDate)
+	}
+
+	if filter.ToDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argCount))
+		args = append(args, *filter.ToDate)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM webhook_deliveries %s", whereClause)
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, pagination, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM webhook_deliveries
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		webhookDeliveryColumns, whereClause, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		delivery, err := r.scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+
+	return deliveries, paginationResult, nil
+}
+
+// ClaimDueDeliveries atomically leases up to limit pending deliveries due at now
+func (r *PostgresWebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	deliveries, err := r.queryWebhookDeliveries(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (r *PostgresWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5, response_status = $6,
+			last_error = $7, delivered_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		webhookResponseStatus(delivery.ResponseStatus), delivery.LastError, delivery.DeliveredAt, delivery.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError("webhook delivery")
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// queryWebhookEndpoints runs an endpoint query and scans every row
+func (r *PostgresWebhookRepository) queryWebhookEndpoints(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookEndpoint, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var endpoints []*entities.WebhookEndpoint
+	for rows.Next() {
+		endpoint, err := r.scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// queryWebhookDeliveries runs a delivery query and scans every row
+func (r *PostgresWebhookRepository) queryWebhookDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		delivery, err := r.scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhookEndpoint scans a webhook endpoint from a row
+func (r *PostgresWebhookRepository) scanWebhookEndpoint(row interface{ Scan(...interface{}) error }) (*entities.WebhookEndpoint, error) {
+	var endpoint entities.WebhookEndpoint
+	var events pq.StringArray
+	var description, pauseReason sql.NullString
+	var pausedAt sql.NullTime
+
+	err := row.Scan(&endpoint.ID, &endpoint.TenantID, &endpoint.URL, &endpoint.Secret, &events, &description,
+		&endpoint.IsActive, &endpoint.ConsecutiveFailures, &pausedAt, &pauseReason,
+		&endpoint.CreatedAt, &endpoint.UpdatedAt, &endpoint.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.Events = make([]entities.WebhookEventType, len(events))
+	for i, event := range events {
+		endpoint.Events[i] = entities.WebhookEventType(event)
+	}
+	endpoint.Description = description.String
+	endpoint.PauseReason = pauseReason.String
+	if pausedAt.Valid {
+		endpoint.PausedAt = &pausedAt.Time
+	}
+
+	return &endpoint, nil
+}
+
+// scanWebhookDelivery scans a webhook delivery from a row
+func (r *PostgresWebhookRepository) scanWebhookDelivery(row interface{ Scan(...interface{}) error }) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	var replayOf uuid.NullUUID
+	var payload []byte
+	var nextAttemptAt, lastAttemptAt, deliveredAt sql.NullTime
+	var responseStatus sql.NullInt64
+	var lastError sql.NullString
+
+	err := row.Scan(&delivery.ID, &delivery.TenantID, &delivery.EndpointID, &delivery.EventID, &delivery.EventType,
+		&replayOf, &payload, &delivery.Status, &delivery.Attempts, &nextAttemptAt, &lastAttemptAt, &responseStatus,
+		&lastError, &deliveredAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if replayOf.Valid {
+		delivery.ReplayOf = &replayOf.UUID
+	}
+	delivery.Payload = payload
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if lastAttemptAt.Valid {
+		delivery.LastAttemptAt = &lastAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	delivery.ResponseStatus = int(responseStatus.Int64)
+	delivery.LastError = lastError.String
+
+	return &delivery, nil
+}
+
+// insertWebhookDeliveryQuery inserts a delivery with the arguments of webhookDeliveryArgs
+const insertWebhookDeliveryQuery = `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+// webhookDeliveryArgs returns the arguments of insertWebhookDeliveryQuery. The payload is
+// passed as text so it is stored byte for byte as it was signed.
+func webhookDeliveryArgs(delivery *entities.WebhookDelivery) []interface{} {
+	return []interface{}{
+		delivery.ID, delivery.TenantID, delivery.EndpointID, delivery.EventID, delivery.EventType, delivery.ReplayOf,
+		string(delivery.Payload), delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		webhookResponseStatus(delivery.ResponseStatus), delivery.LastError, delivery.DeliveredAt,
+		delivery.CreatedAt, delivery.UpdatedAt,
+	}
+}
+
+// webhookEventStrings converts event types for storage in a TEXT[] column
+func webhookEventStrings(events []entities.WebhookEventType) []string {
+	values := make([]string, len(events))
+	for i, event := range events {
+		values[i] = string(event)
+	}
+	return values
+}
+
+// webhookResponseStatus stores a missing response status, e.g. after a connection error, as NULL
+func webhookResponseStatus(status int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(status), Valid: status != 0}
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// maxRecordedFailures bounds how many failed runs are kept for diagnostics
+const maxRecordedFailures = 200
+
+// JobFunc is the work performed by a scheduled job on each run
+type JobFunc func(ctx context.Context) error
+
+// job represents a registered recurring job
+type job struct {
+	name     string
+	interval time.Duration
+	timeout  time.Duration
+	run      JobFunc
+}
+
+// Failure describes a failed or panicked job run
+type Failure struct {
+	Job        string    `json:"job"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error"`
+}
+
+// Scheduler runs registered jobs on fixed intervals in the background.
+// Each job runs in its own goroutine, so a slow job never delays the others,
+// and runs of the same job never overlap.
+type Scheduler struct {
+	jobs   []job
+	logger logger.Logger
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+
+	failures   []Failure
+	failuresMu sync.Mutex
+}
+
+// New creates a new scheduler
+func New(logger logger.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Every registers a job to run at the given interval. Each run is bounded by timeout;
+// a zero timeout defaults to the interval. Jobs must be registered before Start.
+func (s *Scheduler) Every(name string, interval, timeout time.Duration, run JobFunc) {
+	if timeout <= 0 {
+		timeout = interval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, job{name: name, interval: interval, timeout: timeout, run: run})
+}
+
+// Start starts running all registered jobs
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	// Jobs sweep the data of every tenant rather than acting for one
+	ctx, cancel := context.WithCancel(entities.WithAllTenants(context.Background()))
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+
+	s.logger.WithField("jobs", len(s.jobs)).Info("Scheduler started")
+}
+
+// Stop stops all jobs and waits for running jobs to finish or the context to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop runs a job on its interval until the scheduler is stopped
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, j)
+		}
+	}
+}
+
+// runOnce runs a single job execution, recovering from panics so one bad run
+// does not take the job or the process down
+func (s *Scheduler) runOnce(ctx context.Context, j job) {
+	runCtx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"job":   j.name,
+				"panic": r,
+			}).Error("Scheduled job panicked")
+			s.recordFailure(j.name, start, fmt.Sprintf("panic: %v", r))
+		}
+	}()
+
+	if err := j.run(runCtx); err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"job":   j.name,
+			"error": err.Error(),
+		}).Error("Scheduled job failed")
+		s.recordFailure(j.name, start, err.Error())
+		return
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job":         j.name,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}).Debug("Scheduled job completed")
+}
+
+// RecentFailures returns the failed job runs that started at or after since, oldest first.
+// Only the most recent failures are kept, and they do not survive a restart.
+func (s *Scheduler) RecentFailures(since time.Time) []Failure {
+	s.failuresMu.Lock()
+	defer s.failuresMu.Unlock()
+
+	var failures []Failure
+	for _, failure := range s.failures {
+		if !failure.StartedAt.Before(since) {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// recordFailure keeps a failed run for diagnostics, dropping the oldest once full
+func (s *Scheduler) recordFailure(name string, start time.Time, message string) {
+	s.failuresMu.Lock()
+	defer s.failuresMu.Unlock()
+
+	s.failures = append(s.failures, Failure{
+		Job:        name,
+		StartedAt:  start,
+		DurationMs: time.Since(start).Milliseconds(),
+		Error:      message,
+	})
+	if len(s.failures) > maxRecordedFailures {
+		s.failures = s.failures[len(s.failures)-maxRecordedFailures:]
+	}
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/audit"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/utils"
+)
+
+// maxAuditChainBreaks caps the breaks one verification reports; a chain broken that often
+// needs investigating anyway
+const maxAuditChainBreaks = 100
+
+const auditChainColumns = `id, tenant_id, user_id, action, resource, resource_id, old_value, new_value,
+			changes, ip_address, user_agent, timestamp, success, error_message, chain_sequence, previous_hash, hash`
+
+// AuditService implements the AuditPort interface on top of PostgreSQL
+type AuditService struct {
+	db *sql.DB
+}
+
+// NewAuditService creates a new PostgreSQL-backed audit service
+func NewAuditService(db *sql.DB) ports.AuditPort {
+	return &AuditService{db: db}
+}
+
+// Log stores an audit event together with its field-level changes, chained to the previous
+// event of its tenant. The tenant is the context's tenant scope, or else the acting user's
+// tenant. The request origin captured by the HTTP middleware fills in a missing IP address
+// and user agent.
+func (s *AuditService) Log(ctx context.Context, event ports.AuditEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	event.Timestamp = audit.ChainTimestamp(event.Timestamp)
+
+	if origin, ok := audit.OriginFromContext(ctx); ok {
+		if event.IPAddress == "" {
+			event.IPAddress = origin.IPAddress
+		}
+		if event.UserAgent == "" {
+			event.UserAgent = origin.UserAgent
+		}
+	}
+
+	oldValue, err := marshalNullableJSON(event.OldValue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit old value: %w", err)
+	}
+	newValue, err := marshalNullableJSON(event.NewValue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit new value: %w", err)
+	}
+	changes, err := marshalNullableJSON(event.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit changes: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tenantID, err := s.eventTenant(ctx, tx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Lock the head of the tenant's chain so its events are chained one at a time
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO audit_chain_heads (chain_key) VALUES ($1) ON CONFLICT (chain_key) DO NOTHING`, tenantID.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to create audit chain head: %w", err)
+	}
+
+	var sequence int64
+	err = tx.QueryRowContext(ctx,
+		`SELECT sequence, hash FROM audit_chain_heads WHERE chain_key = $1 FOR UPDATE`, tenantID.UUID).
+		Scan(&sequence, &event.PreviousHash)
+	if err != nil {
+		return fmt.Errorf("failed to lock audit chain head: %w", err)
+	}
+
+	event.Sequence = sequence + 1
+	event.Hash, err = audit.Hash(event.PreviousHash, chainRecord(event, tenantID, oldValue, newValue, changes))
+	if err != nil {
+		return fmt.Errorf("failed to hash audit event: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_logs (id, tenant_id, user_id, action, resource, resource_id, old_value, new_value,
+			changes, ip_address, user_agent, timestamp, success, error_message, chain_sequence, previous_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+
+	_, err = tx.ExecContext(ctx, query,
+		event.ID, tenantID, event.UserID, event.Action, event.Resource, event.ResourceID, oldValue, newValue,
+		changes, event.IPAddress, event.UserAgent, event.Timestamp, event.Success, event.ErrorMessage,
+		event.Sequence, event.PreviousHash, event.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE audit_chain_heads SET sequence = $2, hash = $3, logged_at = $4 WHERE chain_key = $1`,
+		tenantID.UUID, event.Sequence, event.Hash, event.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to advance audit chain head: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// VerifyChain recomputes the hash chain of a tenant's audit events logged from the given time
+// on. The first event checked is trusted to follow the events before it, so purged events do
+// not break the chain.
+func (s *AuditService) VerifyChain(ctx context.Context, tenantID uuid.UUID, from time.Time) (*ports.AuditChainVerification, error) {
+	verification := &ports.AuditChainVerification{
+		TenantID:   tenantID,
+		From:       from,
+		Breaks:     []ports.AuditChainBreak{},
+		VerifiedAt: time.Now(),
+	}
+	report := func(kind string, sequence int64, eventID uuid.UUID, detail string) {
+		if len(verification.Breaks) < maxAuditChainBreaks {
+			verification.Breaks = append(verification.Breaks, ports.AuditChainBreak{
+				Kind: kind, Sequence: sequence, EventID: eventID, Detail: detail,
+			})
+		}
+	}
+
+	var headSequence int64
+	var headHash string
+	var headLoggedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		`SELECT sequence, hash, logged_at FROM audit_chain_heads WHERE chain_key = $1`, tenantID).
+		Scan(&headSequence, &headHash, &headLoggedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get audit chain head: %w", err)
+	}
+
+	query := `
+		SELECT ` + auditChainColumns + `
+		FROM audit_logs
+		WHERE tenant_id = $1 AND chain_sequence >= (
+			SELECT MIN(chain_sequence) FROM audit_logs
+			WHERE tenant_id = $1 AND chain_sequence IS NOT NULL AND timestamp >= $2
+		)
+		ORDER BY chain_sequence`
+
+	rows, err := s.db.QueryContext(ctx, query, tenantID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit chain: %w", err)
+	}
+	defer rows.Close()
+
+	var lastSequence int64
+	var lastHash string
+	for rows.Next() {
+		event, record, err := scanChainedAuditEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+
+		hash, err := audit.Hash(event.PreviousHash, record)
+		if err != nil || hash != event.Hash {
+			report(ports.AuditChainAltered, event.Sequence, event.ID, "the event no longer matches its hash")
+		}
+
+		switch {
+		case verification.CheckedEvents == 0:
+			verification.FirstSequence = event.Sequence
+			if event.Sequence == 1 && event.PreviousHash != "" {
+				report(ports.AuditChainUnlinked, event.Sequence, event.ID, "the first event of the chain links to another event")
+			}
+		case event.Sequence != lastSequence+1:
+			report(ports.AuditChainUnlinked, event.Sequence, event.ID,
+				fmt.Sprintf("events %d to %d are missing", lastSequence+1, event.Sequence-1))
+		case event.PreviousHash != lastHash:
+			report(ports.AuditChainUnlinked, event.Sequence, event.ID,
+				fmt.Sprintf("the event does not follow event %d", lastSequence))
+		}
+
+		verification.CheckedEvents++
+		lastSequence = event.Sequence
+		lastHash = event.Hash
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate audit chain: %w", err)
+	}
+	verification.LastSequence = lastSequence
+
+	// The latest event must still be there when it was logged within the verified range
+	if headSequence > 0 && headLoggedAt.Valid && !headLoggedAt.Time.Before(from) {
+		switch {
+		case lastSequence < headSequence:
+			report(ports.AuditChainTruncated, headSequence, uuid.Nil,
+				fmt.Sprintf("events %d to %d were removed", lastSequence+1, headSequence))
+		case lastSequence > headSequence:
+			report(ports.AuditChainUnlinked, lastSequence, uuid.Nil,
+				fmt.Sprintf("events after %d were added outside the audit trail", headSequence))
+		case lastHash != headHash:
+			report(ports.AuditChainAltered, lastSequence, uuid.Nil, "the latest event does not match the chain head")
+		}
+	}
+
+	verification.IsIntact = len(verification.Breaks) == 0
+	return verification, nil
+}
+
+// eventTenant returns the tenant whose chain an event joins: the context's tenant scope, or
+// else the acting user's tenant. Events of neither join the chain of the nil tenant.
+func (s *AuditService) eventTenant(ctx context.Context, tx *sql.Tx, userID uuid.UUID) (uuid.NullUUID, error) {
+	if tenantID, ok := entities.TenantScopeFromContext(ctx); ok {
+		return uuid.NullUUID{UUID: tenantID, Valid: true}, nil
+	}
+
+	var tenantID uuid.NullUUID
+	err := tx.QueryRowContext(ctx, `SELECT tenant_id FROM users WHERE id = $1`, userID).Scan(&tenantID)
+	if err != nil && err != sql.ErrNoRows {
+		return tenantID, fmt.Errorf("failed to get audit event tenant: %w", err)
+	}
+
+	return tenantID, nil
+}
+
+// chainRecord returns the content of a stored event that its chain hash covers
+func chainRecord(event ports.AuditEvent, tenantID uuid.NullUUID, oldValue, newValue, changes interface{}) audit.Record {
+	record := audit.Record{
+		Sequence:     event.Sequence,
+		ID:           event.ID.String(),
+		UserID:       event.UserID.String(),
+		Action:       event.Action,
+		Resource:     event.Resource,
+		ResourceID:   event.ResourceID,
+		IPAddress:    event.IPAddress,
+		UserAgent:    event.UserAgent,
+		Timestamp:    event.Timestamp,
+		Success:      event.Success,
+		ErrorMessage: event.ErrorMessage,
+	}
+	if tenantID.Valid {
+		record.TenantID = tenantID.UUID.String()
+	}
+	record.OldValue, _ = oldValue.([]byte)
+	record.NewValue, _ = newValue.([]byte)
+	record.Changes, _ = changes.([]byte)
+	return record
+}
+
+// scanChainedAuditEvent scans a stored audit event along with the content its hash covers
+func scanChainedAuditEvent(row interface{ Scan(...interface{}) error }) (*ports.AuditEvent, audit.Record, error) {
+	var event ports.AuditEvent
+	var tenantID uuid.NullUUID
+	var resourceID, ipAddress, userAgent, errorMessage, previousHash, hash sql.NullString
+	var sequence sql.NullInt64
+	var oldValue, newValue, changes []byte
+
+	err := row.Scan(
+		&event.ID, &tenantID, &event.UserID, &event.Action, &event.Resource, &resourceID, &oldValue, &newValue,
+		&changes, &ipAddress, &userAgent, &event.Timestamp, &event.Success, &errorMessage,
+		&sequence, &previousHash, &hash)
+	if err != nil {
+		return nil, audit.Record{}, err
+	}
+
+	event.ResourceID = resourceID.String
+	event.IPAddress = ipAddress.String
+	event.UserAgent = userAgent.String
+	event.ErrorMessage = errorMessage.String
+	event.Sequence = sequence.Int64
+	event.PreviousHash = previousHash.String
+	event.Hash = hash.String
+
+	return &event, chainRecord(event, tenantID, oldValue, newValue, changes), nil
+}
+
+// Query retrieves audit events with pagination and filtering. A tenant-scoped context only
+// sees its tenant's events; any other context must be marked to span all tenants.
+func (s *AuditService) Query(ctx context.Context, filter ports.AuditFilter, pagination utils.PaginationInfo) ([]ports.AuditEvent, utils.PaginationInfo, error) {
+	// Build WHERE clause
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 0
+
+	if tenantID, ok := entities.TenantScopeFromContext(ctx); ok {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+	} else if !entities.SpansAllTenants(ctx) {
+		return nil, pagination, errors.NewForbiddenError("audit events are not scoped to a tenant")
+	}
+
+	if filter.TenantID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, *filter.TenantID)
+	}
+
+	if filter.UserID != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argCount))
+		args = append(args, *filter.UserID)
+	}
+
+	if filter.Action != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("action = $%d", argCount))
+		args = append(args, filter.Action)
+	}
+
+	if filter.Resource != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("resource = $%d", argCount))
+		args = append(args, filter.Resource)
+	}
+
+	if filter.ResourceID != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("resource_id = $%d", argCount))
+		args = append(args, filter.ResourceID)
+	}
+
+	if filter.FromDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("timestamp >= $%d", argCount))
+		args = append(args, *filter.FromDate)
+	}
+
+	if filter.ToDate != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("timestamp <= $%d", argCount))
+		args = append(args, *filter.ToDate)
+	}
+
+	if filter.Success != nil {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("success = $%d", argCount))
+		args = append(args, *filter.Success)
+	}
+
+	if filter.IPAddress != "" {
+		argCount++
+		conditions = append(conditions, fmt.Sprintf("ip_address = $%d", argCount))
+		args = append(args, filter.IPAddress)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Build ORDER BY clause
+	orderBy := "timestamp DESC"
+	if filter.OrderBy != "" {
+		direction := "ASC"
+		if filter.OrderDir == "DESC" {
+			direction = "DESC"
+		}
+		orderBy = fmt.Sprintf("%s %s", filter.OrderBy, direction)
+	}
+
+	// Count total records
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM audit_logs %s", whereClause)
+	var total int
+	err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, pagination, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	// Calculate pagination
+	paginationResult := utils.CalculatePagination(pagination.Page, pagination.Limit, total)
+	offset := utils.GetOffset(pagination.Page, pagination.Limit)
+
+	// Query with pagination
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_logs
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		auditChainColumns, whereClause, orderBy, argCount+1, argCount+2)
+
+	args = append(args, pagination.Limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []ports.AuditEvent
+	for rows.Next() {
+		event, record, err := scanChainedAuditEvent(rows)
+		if err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+
+		if err := unmarshalNullableJSON(record.OldValue, &event.OldValue); err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to unmarshal audit old value: %w", err)
+		}
+		if err := unmarshalNullableJSON(record.NewValue, &event.NewValue); err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to unmarshal audit new value: %w", err)
+		}
+		if err := unmarshalNullableJSON(record.Changes, &event.Changes); err != nil {
+			return nil, paginationResult, fmt.Errorf("failed to unmarshal audit changes: %w", err)
+		}
+
+		events = append(events, *event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, paginationResult, fmt.Errorf("failed to iterate audit events: %w", err)
+	}
+
+	return events, paginationResult, nil
+}
+
+// marshalNullableJSON marshals a value to JSON, storing empty values as NULL
+func marshalNullableJSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return nil, nil
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// unmarshalNullableJSON unmarshals JSON data, leaving the destination untouched for NULL
+func unmarshalNullableJSON(data []byte, dest interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, dest)
+}
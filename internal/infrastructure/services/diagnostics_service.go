@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/infrastructure/config"
+	"github.com/nicklaros/adol/internal/infrastructure/monitoring"
+	"github.com/nicklaros/adol/internal/infrastructure/scheduler"
+	"github.com/nicklaros/adol/pkg/redact"
+)
+
+// DiagnosticsService implements the DiagnosticsPort interface on top of the scheduler, the
+// tenant monitor and the loaded configuration
+type DiagnosticsService struct {
+	scheduler *scheduler.Scheduler
+	monitor   monitoring.TenantMonitor
+	config    *config.Config
+}
+
+// NewDiagnosticsService creates a new diagnostics service
+func NewDiagnosticsService(scheduler *scheduler.Scheduler, monitor monitoring.TenantMonitor, cfg *config.Config) ports.DiagnosticsPort {
+	return &DiagnosticsService{
+		scheduler: scheduler,
+		monitor:   monitor,
+		config:    cfg,
+	}
+}
+
+// tenantHealthSnapshot is the health section of a support bundle
+type tenantHealthSnapshot struct {
+	Score       *monitoring.TenantHealthScore  `json:"score"`
+	Health      *monitoring.TenantHealth       `json:"health"`
+	Performance *monitoring.PerformanceMetrics `json:"performance"`
+	Alerts      []*monitoring.Alert            `json:"alerts"`
+}
+
+// JobFailures returns background job runs that failed since the given time
+func (s *DiagnosticsService) JobFailures(since time.Time) []ports.JobFailure {
+	failures := s.scheduler.RecentFailures(since)
+
+	result := make([]ports.JobFailure, len(failures))
+	for i, failure := range failures {
+		result[i] = ports.JobFailure{
+			Job:        failure.Job,
+			StartedAt:  failure.StartedAt,
+			DurationMs: failure.DurationMs,
+			Error:      failure.Error,
+		}
+	}
+	return result
+}
+
+// TenantHealth returns a tenant's current health and the alerts raised for it since the given time
+func (s *DiagnosticsService) TenantHealth(ctx context.Context, tenantID uuid.UUID, since time.Time) (interface{}, error) {
+	score, err := s.monitor.GetHealthScore(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	health, err := s.monitor.GetTenantHealth(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	performance, err := s.monitor.GetPerformanceMetrics(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := s.monitor.ListAlerts(ctx, tenantID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenantHealthSnapshot{
+		Score:       score,
+		Health:      health,
+		Performance: performance,
+		Alerts:      alerts,
+	}, nil
+}
+
+// ConfigSnapshot returns the running configuration with credentials masked
+func (s *DiagnosticsService) ConfigSnapshot() map[string]interface{} {
+	cfg := s.config
+
+	return map[string]interface{}{
+		"server": map[string]interface{}{
+			"port":           cfg.Server.Port,
+			"read_timeout":   cfg.Server.ReadTimeout.String(),
+			"write_timeout":  cfg.Server.WriteTimeout.String(),
+			"idle_timeout":   cfg.Server.IdleTimeout.String(),
+			"dashboard_url":  cfg.Server.DashboardURL,
+			"public_url":     cfg.Server.PublicURL,
+			"warmup_timeout": cfg.Server.WarmUpTimeout.String(),
+		},
+		"database": map[string]interface{}{
+			"host":              cfg.Database.Host,
+			"port":              cfg.Database.Port,
+			"user":              cfg.Database.User,
+			"password":          maskSecret(cfg.Database.Password),
+			"name":              cfg.Database.DBName,
+			"ssl_mode":          cfg.Database.SSLMode,
+			"max_open_conns":    cfg.Database.MaxOpenConns,
+			"max_idle_conns":    cfg.Database.MaxIdleConns,
+			"conn_max_lifetime": cfg.Database.ConnMaxLifetime.String(),
+		},
+		"jwt": map[string]interface{}{
+			"secret_key":            maskSecret(cfg.JWT.SecretKey),
+			"access_token_expiry":   cfg.JWT.AccessTokenExpiry.String(),
+			"refresh_token_expiry":  cfg.JWT.RefreshTokenExpiry.String(),
+			"issuer":                cfg.JWT.Issuer,
+			"audience":              cfg.JWT.Audience,
+			"include_tenant_claims": cfg.JWT.IncludeTenantClaims,
+		},
+		"logger": map[string]interface{}{
+			"level":  cfg.Logger.Level,
+			"format": cfg.Logger.Format,
+		},
+		"security": map[string]interface{}{
+			"password_min_length":   cfg.Security.PasswordMinLength,
+			"password_history_size": cfg.Security.PasswordHistorySize,
+			"password_max_age":      cfg.Security.PasswordMaxAge.String(),
+			"invitation_expiry":     cfg.Security.InvitationExpiry.String(),
+			"max_login_attempts":    cfg.Security.MaxLoginAttempts,
+			"login_attempt_window":  cfg.Security.LoginAttemptWindow.String(),
+			"lockout_duration":      cfg.Security.LockoutDuration.String(),
+			"max_lockout_duration":  cfg.Security.MaxLockoutDuration.String(),
+			"session_timeout":       cfg.Security.SessionTimeout.String(),
+		},
+		"features": map[string]interface{}{
+			"multi_tenancy":  cfg.Features.EnableMultiTenancy,
+			"subscriptions":  cfg.Features.EnableSubscriptions,
+			"usage_limits":   cfg.Features.EnableUsageLimits,
+			"trial_periods":  cfg.Features.EnableTrialPeriods,
+			"subdomains":     cfg.Features.EnableSubdomains,
+			"custom_domains": cfg.Features.EnableCustomDomains,
+			"feature_gating": cfg.Features.EnableFeatureGating,
+		},
+		"request_log": map[string]interface{}{
+			"enabled":        cfg.RequestLog.Enabled,
+			"sample_rate":    cfg.RequestLog.SampleRate,
+			"max_body_bytes": cfg.RequestLog.MaxBodyBytes,
+			"retention":      cfg.RequestLog.Retention.String(),
+		},
+		"storage": map[string]interface{}{
+			"endpoint":          cfg.Storage.Endpoint,
+			"region":            cfg.Storage.Region,
+			"bucket":            cfg.Storage.Bucket,
+			"access_key_id":     maskSecret(cfg.Storage.AccessKeyID),
+			"secret_access_key": maskSecret(cfg.Storage.SecretAccessKey),
+			"path_style":        cfg.Storage.PathStyle,
+		},
+		"sales": map[string]interface{}{
+			"held_sale_ttl":       cfg.Sales.HeldSaleTTL.String(),
+			"idempotency_key_ttl": cfg.Sales.IdempotencyKeyTTL.String(),
+		},
+		"invoice_reminders": map[string]interface{}{
+			"enabled":             cfg.InvoiceReminders.Enabled,
+			"send_hour":           cfg.InvoiceReminders.SendHour,
+			"days_before_due":     cfg.InvoiceReminders.DaysBeforeDue,
+			"overdue_every_days":  cfg.InvoiceReminders.OverdueEveryDays,
+			"max_overdue_notices": cfg.InvoiceReminders.MaxOverdueNotices,
+		},
+		"email": map[string]interface{}{
+			"provider":              cfg.Email.Provider,
+			"from_address":          cfg.Email.FromAddress,
+			"from_name":             cfg.Email.FromName,
+			"timeout":               cfg.Email.Timeout.String(),
+			"smtp_host":             cfg.Email.SMTPHost,
+			"smtp_port":             cfg.Email.SMTPPort,
+			"smtp_username":         cfg.Email.SMTPUsername,
+			"smtp_password":         maskSecret(cfg.Email.SMTPPassword),
+			"sendgrid_api_key":      maskSecret(cfg.Email.SendGridAPIKey),
+			"ses_region":            cfg.Email.SESRegion,
+			"ses_access_key_id":     maskSecret(cfg.Email.SESAccessKeyID),
+			"ses_secret_access_key": maskSecret(cfg.Email.SESSecretAccessKey),
+			"mailgun_domain":        cfg.Email.MailgunDomain,
+			"mailgun_api_key":       maskSecret(cfg.Email.MailgunAPIKey),
+			"mailgun_base_url":      cfg.Email.MailgunBaseURL,
+			"max_attachment_size":   cfg.Email.MaxAttachmentSize,
+			"download_link_expiry":  cfg.Email.DownloadLinkExpiry.String(),
+		},
+		"exchange_rates": map[string]interface{}{
+			"endpoint":  cfg.ExchangeRates.Endpoint,
+			"timeout":   cfg.ExchangeRates.Timeout.String(),
+			"cache_ttl": cfg.ExchangeRates.CacheTTL.String(),
+		},
+	}
+}
+
+// maskSecret hides a configured secret while still showing whether it is set
+func maskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redact.Mask
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+)
+
+const (
+	// eFakturTransactionCode is KD_JENIS_TRANSAKSI for deliveries to buyers other than tax collectors
+	eFakturTransactionCode = "01"
+
+	// eFakturNoTaxID is written for buyers without a tax ID
+	eFakturNoTaxID = "000000000000000"
+)
+
+// eFakturHeader lists the three record layouts of an e-Faktur import file
+var eFakturHeader = [][]string{
+	{"FK", "KD_JENIS_TRANSAKSI", "FG_PENGGANTI", "NOMOR_FAKTUR", "MASA_PAJAK", "TAHUN_PAJAK", "TANGGAL_FAKTUR", "NPWP", "NAMA", "ALAMAT_LENGKAP", "JUMLAH_DPP", "JUMLAH_PPN", "JUMLAH_PPNBM", "ID_KETERANGAN_TAMBAHAN", "FG_UANG_MUKA", "UANG_MUKA_DPP", "UANG_MUKA_PPN", "UANG_MUKA_PPNBM", "REFERENSI", "KODE_DOKUMEN_PENDUKUNG"},
+	{"LT", "NPWP", "NAMA", "JALAN", "BLOK", "NOMOR", "RT", "RW", "KECAMATAN", "KELURAHAN", "KABUPATEN", "PROPINSI", "KODE_POS", "NOMOR_TELEPON"},
+	{"OF", "KODE_OBJEK", "NAMA", "HARGA_SATUAN", "JUMLAH_BARANG", "HARGA_TOTAL", "DISKON", "DPP", "PPN", "TARIF_PPNBM", "PPNBM"},
+}
+
+// EFakturExportService implements the TaxInvoiceExportPort interface for the CSV imported into
+// the Indonesian e-Faktur application: an FK record per tax invoice followed by an OF record
+// per item. Amounts must be in rupiah; DPP and PPN are rounded down to whole rupiah.
+type EFakturExportService struct{}
+
+// NewEFakturExportService creates a new e-Faktur export service
+func NewEFakturExportService() ports.TaxInvoiceExportPort {
+	return &EFakturExportService{}
+}
+
+// Format returns the name the format is requested by
+func (s *EFakturExportService) Format() string {
+	return "efaktur"
+}
+
+// ContentType returns the MIME type of exported files
+func (s *EFakturExportService) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// FileExtension returns the extension of exported files
+func (s *EFakturExportService) FileExtension() string {
+	return "csv"
+}
+
+// Export writes the tax invoices to w. The seller must have an NPWP; buyers without a valid
+// NPWP are written with the all-zero NPWP.
+func (s *EFakturExportService) Export(w io.Writer, seller ports.TaxInvoiceParty, invoices []ports.TaxInvoiceDocument) error {
+	if _, ok := eFakturTaxID(seller.TaxID); !ok {
+		return fmt.Errorf("seller NPWP must have 15 or 16 digits")
+	}
+
+	writer := csv.NewWriter(w)
+	for _, record := range eFakturHeader {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	for _, invoice := range invoices {
+		serial := digitsOnly(invoice.Serial)
+		if len(serial) != 13 {
+			return fmt.Errorf("tax invoice serial %q of invoice %s must have 13 digits", invoice.Serial, invoice.InvoiceNumber)
+		}
+
+		buyerTaxID, ok := eFakturTaxID(invoice.Buyer.TaxID)
+		if !ok {
+			buyerTaxID = eFakturNoTaxID
+		}
+
+		err := writer.Write([]string{
+			"FK",
+			eFakturTransactionCode,
+			"0", // Not a replacement
+			serial,
+			strconv.Itoa(int(invoice.Date.Month())),
+			strconv.Itoa(invoice.Date.Year()),
+			invoice.Date.Format("02/01/2006"),
+			buyerTaxID,
+			eFakturText(invoice.Buyer.Name),
+			eFakturText(invoice.Buyer.Address),
+			eFakturRupiah(invoice.TaxBase),
+			eFakturRupiah(invoice.TaxAmount),
+			"0",
+			"",
+			"0", "0", "0", "0", // Not a down payment
+			eFakturText(invoice.InvoiceNumber),
+			"",
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, line := range invoice.Lines {
+			err := writer.Write([]string{
+				"OF",
+				eFakturText(line.Code),
+				eFakturText(line.Name),
+				line.UnitPrice.StringFixed(2),
+				strconv.Itoa(line.Quantity),
+				line.TotalPrice.StringFixed(2),
+				line.Discount.StringFixed(2),
+				eFakturRupiah(line.TaxBase),
+				eFakturRupiah(line.TaxAmount),
+				"0",
+				"0",
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// eFakturTaxID returns the digits of an NPWP, which has 15 digits or, since 2024, 16
+func eFakturTaxID(taxID string) (string, bool) {
+	digits := digitsOnly(taxID)
+	return digits, len(digits) == 15 || len(digits) == 16
+}
+
+// eFakturRupiah formats an amount in whole rupiah, rounded down
+func eFakturRupiah(amount decimal.Decimal) string {
+	return amount.Floor().String()
+}
+
+// eFakturText removes line breaks, which the e-Faktur importer rejects
+func eFakturText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// digitsOnly returns the ASCII digits of s
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
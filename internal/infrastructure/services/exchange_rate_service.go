@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// exchangeRateResponseLimit caps how much of a provider response is read
+const exchangeRateResponseLimit = 1024 * 1024
+
+// ExchangeRateService implements the ExchangeRatePort interface over a Frankfurter-compatible
+// HTTP API. Rates are fetched per base currency and cached for cacheTTL. When the provider
+// cannot be reached, rates past their TTL are used rather than failing the sale.
+type ExchangeRateService struct {
+	endpoint string
+	client   *http.Client
+	cacheTTL time.Duration
+	logger   logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedRates
+}
+
+// cachedRates holds the rates of one base currency as fetched from the provider
+type cachedRates struct {
+	rates     map[string]decimal.Decimal
+	fetchedAt time.Time
+}
+
+// exchangeRateResponse is the provider's response to GET /latest
+type exchangeRateResponse struct {
+	Base  string                     `json:"base"`
+	Rates map[string]decimal.Decimal `json:"rates"`
+}
+
+// NewExchangeRateService creates a new exchange rate service
+func NewExchangeRateService(endpoint string, timeout, cacheTTL time.Duration, logger logger.Logger) ports.ExchangeRatePort {
+	return &ExchangeRateService{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		client:   &http.Client{Timeout: timeout},
+		cacheTTL: cacheTTL,
+		logger:   logger,
+		cache:    make(map[string]cachedRates),
+	}
+}
+
+// GetRate returns how many units of the to currency one unit of the from currency is worth
+func (s *ExchangeRateService) GetRate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rates, err := s.getRates(ctx, from)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rate, ok := rates[to]
+	if !ok || !rate.IsPositive() {
+		return decimal.Zero, fmt.Errorf("no exchange rate from %s to %s", from, to)
+	}
+
+	return rate, nil
+}
+
+// getRates returns the rates of the base currency, from the cache while they are fresh
+func (s *ExchangeRateService) getRates(ctx context.Context, base string) (map[string]decimal.Decimal, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[base]
+	s.mu.Unlock()
+
+	if ok && time.Since(cached.fetchedAt) < s.cacheTTL {
+		return cached.rates, nil
+	}
+
+	rates, err := s.fetchRates(ctx, base)
+	if err != nil {
+		if ok {
+			s.logger.WithFields(map[string]interface{}{
+				"base":       base,
+				"fetched_at": cached.fetchedAt,
+				"error":      err.Error(),
+			}).Warn("Failed to refresh exchange rates, using stale rates")
+			return cached.rates, nil
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[base] = cachedRates{rates: rates, fetchedAt: time.Now()}
+	s.mu.Unlock()
+
+	return rates, nil
+}
+
+// fetchRates fetches the latest rates of the base currency from the provider
+func (s *ExchangeRateService) fetchRates(ctx context.Context, base string) (map[string]decimal.Decimal, error) {
+	endpoint := fmt.Sprintf("%s/latest?from=%s", s.endpoint, url.QueryEscape(base))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exchange rate request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate provider returned status %d", resp.StatusCode)
+	}
+
+	var body exchangeRateResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, exchangeRateResponseLimit)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+	if body.Base != base {
+		return nil, fmt.Errorf("exchange rate provider returned rates for %s instead of %s", body.Base, base)
+	}
+
+	return body.Rates, nil
+}
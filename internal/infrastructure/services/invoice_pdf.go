@@ -0,0 +1,452 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/pdf"
+)
+
+// invoiceSheetLayout sizes an invoice on sheets of a paper size
+type invoiceSheetLayout struct {
+	widthMM    float64
+	heightMM   float64
+	marginMM   float64
+	fontSize   float64
+	lineHeight float64
+}
+
+var (
+	invoiceSheetA4     = invoiceSheetLayout{widthMM: 210, heightMM: 297, marginMM: 15, fontSize: 9, lineHeight: 5}
+	invoiceSheetA5     = invoiceSheetLayout{widthMM: 148, heightMM: 210, marginMM: 10, fontSize: 7.5, lineHeight: 4.2}
+	invoiceSheetLetter = invoiceSheetLayout{widthMM: 215.9, heightMM: 279.4, marginMM: 15, fontSize: 9, lineHeight: 5}
+	invoiceSheetLegal  = invoiceSheetLayout{widthMM: 215.9, heightMM: 355.6, marginMM: 15, fontSize: 9, lineHeight: 5}
+)
+
+// invoiceSheetLayoutFor returns the sheet layout of a paper size, A4 for unknown sizes
+func invoiceSheetLayoutFor(paperSize entities.PaperSize) invoiceSheetLayout {
+	switch paperSize {
+	case entities.PaperSizeA5:
+		return invoiceSheetA5
+	case entities.PaperSizeLetter:
+		return invoiceSheetLetter
+	case entities.PaperSizeLegal:
+		return invoiceSheetLegal
+	default:
+		return invoiceSheetA4
+	}
+}
+
+// invoiceColumn is a column of the item table
+type invoiceColumn struct {
+	title   string
+	widthMM float64 // 0 for the column taking the remaining width
+	value   func(item entities.InvoiceItem) string
+}
+
+// invoiceSheet draws an invoice from top to bottom, starting a new page when one is full
+type invoiceSheet struct {
+	doc      *pdf.Document
+	layout   invoiceSheetLayout
+	template *entities.InvoiceTemplate
+	logo     *pdf.Image
+	cursor   float64
+}
+
+// drawInvoice lays out an invoice with a template's design: an accent bar, the logo and
+// company details, the header, the customer and invoice details with the custom fields, the
+// item table with the template's columns, the totals, the notes and the footer
+func drawInvoice(invoice *entities.Invoice, template *entities.InvoiceTemplate, logo *pdf.Image) *pdf.Document {
+	layout := invoiceSheetLayoutFor(template.PaperSize)
+	sheet := &invoiceSheet{
+		doc:      pdf.New(layout.widthMM, layout.heightMM),
+		layout:   layout,
+		template: template,
+		logo:     logo,
+	}
+	sheet.doc.AddPage()
+
+	sheet.drawHeading()
+	sheet.drawDetails(invoice)
+	sheet.drawItems(invoice)
+	sheet.drawTotals(invoice)
+	sheet.drawClosing(invoice)
+
+	return sheet.doc
+}
+
+// drawHeading draws the accent bar, the logo, the company details and the header text
+func (s *invoiceSheet) drawHeading() {
+	s.accent()
+	s.doc.Rect(0, 0, s.layout.widthMM, 3)
+	s.black()
+
+	top := s.layout.marginMM
+	logoBottom := top
+	if s.logo != nil {
+		height := s.layout.lineHeight * 4
+		width := height * s.logo.AspectRatio()
+		if maxWidth := s.contentWidth() / 3; width > maxWidth {
+			width = maxWidth
+			height = width / s.logo.AspectRatio()
+		}
+		s.doc.Image(s.logo, s.left(), top, width, height)
+		logoBottom = top + height
+	}
+
+	company := s.template.CompanyInfo
+	lines := []string{company.Address, company.Phone, company.Email, company.Website}
+	if company.TaxID != "" {
+		lines = append(lines, "Tax ID: "+company.TaxID)
+	}
+	s.cursor = top + s.layout.lineHeight
+	s.rightText(pdf.FontBold, s.layout.fontSize*1.2, company.Name)
+	for _, line := range lines {
+		if line != "" {
+			s.nextLine()
+			s.rightText(pdf.FontRegular, s.layout.fontSize, line)
+		}
+	}
+	s.cursor += s.layout.lineHeight / 2
+	if s.cursor < logoBottom {
+		s.cursor = logoBottom
+	}
+
+	if s.template.Header != "" {
+		s.nextLine()
+		for _, line := range strings.Split(s.template.Header, "\n") {
+			s.nextLine()
+			s.text(s.left(), pdf.FontRegular, s.layout.fontSize, strings.TrimSpace(line))
+		}
+	}
+}
+
+// drawDetails draws the title, the customer billed on the left and the invoice details with
+// the custom fields on the right
+func (s *invoiceSheet) drawDetails(invoice *entities.Invoice) {
+	s.nextLine()
+	s.nextLine()
+	s.accent()
+	s.text(s.left(), pdf.FontBold, s.layout.fontSize*1.8, "INVOICE")
+	s.black()
+	s.nextLine()
+
+	billTo := []string{invoice.CustomerName, invoice.CustomerAddress, invoice.CustomerEmail, invoice.CustomerPhone}
+	if invoice.CustomerTaxID != "" {
+		billTo = append(billTo, "Tax ID: "+invoice.CustomerTaxID)
+	}
+
+	details := []entities.InvoiceTemplateField{
+		{Label: "Invoice Number", Value: invoice.InvoiceNumber},
+		{Label: "Invoice Date", Value: invoice.CreatedAt.Format("2006-01-02")},
+	}
+	if invoice.DueDate != nil {
+		details = append(details, entities.InvoiceTemplateField{Label: "Due Date", Value: invoice.DueDate.Format("2006-01-02")})
+	}
+	details = append(details, s.template.CustomFields...)
+
+	start := s.cursor
+	s.nextLine()
+	s.accent()
+	s.text(s.left(), pdf.FontBold, s.layout.fontSize, "BILL TO")
+	s.black()
+	for _, line := range billTo {
+		if line != "" {
+			s.nextLine()
+			s.text(s.left(), pdf.FontRegular, s.layout.fontSize, pdf.FitText(pdf.FontRegular, s.layout.fontSize, line, s.contentWidth()/2-4))
+		}
+	}
+	billToEnd := s.cursor
+
+	// The details are drawn level with the customer, from the middle of the page
+	s.cursor = start
+	labels := s.left() + s.contentWidth()/2
+	for _, field := range details {
+		s.nextLine()
+		s.text(labels, pdf.FontBold, s.layout.fontSize, pdf.FitText(pdf.FontBold, s.layout.fontSize, field.Label, s.contentWidth()/4-2))
+		value := pdf.FitText(pdf.FontRegular, s.layout.fontSize, field.Value, s.contentWidth()/4)
+		s.text(s.right()-pdf.TextWidth(pdf.FontRegular, s.layout.fontSize, value), pdf.FontRegular, s.layout.fontSize, value)
+	}
+	if s.cursor < billToEnd {
+		s.cursor = billToEnd
+	}
+}
+
+// drawItems draws the item table with the columns the template shows, repeating the column
+// titles on each page the table continues on
+func (s *invoiceSheet) drawItems(invoice *entities.Invoice) {
+	columns := s.columns(invoice)
+	widths := make([]float64, len(columns))
+	fixed := 0.0
+	for _, column := range columns {
+		fixed += column.widthMM
+	}
+	for i, column := range columns {
+		widths[i] = column.widthMM
+		if widths[i] == 0 {
+			widths[i] = s.contentWidth() - fixed
+		}
+	}
+
+	drawTitles := func() {
+		s.nextLine()
+		s.accent()
+		s.drawRow(columns, widths, pdf.FontBold, func(column invoiceColumn) string { return column.title })
+		s.doc.Rect(s.left(), s.cursor+1.2, s.contentWidth(), 0.4)
+		s.black()
+		s.cursor += 1.5
+	}
+
+	s.nextLine()
+	drawTitles()
+	description := s.template.ShowsColumn(entities.InvoiceColumnDescription)
+	for _, item := range invoice.Items {
+		lines := 1
+		if description && item.Description != "" {
+			lines = 2
+		}
+		if s.cursor+float64(lines)*s.layout.lineHeight > s.bottom() {
+			s.newPage()
+			drawTitles()
+		}
+
+		s.nextLine()
+		s.drawRow(columns, widths, pdf.FontRegular, func(column invoiceColumn) string { return column.value(item) })
+		if lines == 2 {
+			s.nextLine()
+			small := s.layout.fontSize * 0.85
+			s.text(s.left()+2, pdf.FontRegular, small, pdf.FitText(pdf.FontRegular, small, item.Description, widths[0]-4))
+		}
+	}
+
+	s.accent()
+	s.doc.Rect(s.left(), s.cursor+1.5, s.contentWidth(), 0.2)
+	s.black()
+	s.cursor += 1.5
+}
+
+// columns returns the item table columns the template shows. The first column is the item
+// and the last its total.
+func (s *invoiceSheet) columns(invoice *entities.Invoice) []invoiceColumn {
+	scale := s.layout.fontSize / invoiceSheetA4.fontSize
+	columns := []invoiceColumn{{
+		title: "Item",
+		value: func(item entities.InvoiceItem) string { return item.ProductName },
+	}}
+	if s.template.ShowsColumn(entities.InvoiceColumnSKU) {
+		columns = append(columns, invoiceColumn{
+			title:   "SKU",
+			widthMM: 28 * scale,
+			value:   func(item entities.InvoiceItem) string { return item.ProductSKU },
+		})
+	}
+	if s.template.ShowsColumn(entities.InvoiceColumnQuantity) {
+		columns = append(columns, invoiceColumn{
+			title:   "Qty",
+			widthMM: 14 * scale,
+			value:   func(item entities.InvoiceItem) string { return strconv.Itoa(item.Quantity) },
+		})
+	}
+	if s.template.ShowsColumn(entities.InvoiceColumnUnitPrice) {
+		columns = append(columns, invoiceColumn{
+			title:   "Unit Price",
+			widthMM: 30 * scale,
+			value:   func(item entities.InvoiceItem) string { return invoice.FormatAmount(item.UnitPrice) },
+		})
+	}
+	if s.template.ShowsColumn(entities.InvoiceColumnTaxRate) {
+		columns = append(columns, invoiceColumn{
+			title:   "Tax",
+			widthMM: 14 * scale,
+			value:   func(item entities.InvoiceItem) string { return item.TaxRate.String() + "%" },
+		})
+	}
+	return append(columns, invoiceColumn{
+		title:   "Amount",
+		widthMM: 32 * scale,
+		value:   func(item entities.InvoiceItem) string { return invoice.FormatAmount(item.TotalPrice) },
+	})
+}
+
+// drawRow draws a table row, the item column left-aligned and the others right-aligned
+func (s *invoiceSheet) drawRow(columns []invoiceColumn, widths []float64, font pdf.Font, cell func(invoiceColumn) string) {
+	x := s.left()
+	for i, column := range columns {
+		value := pdf.FitText(font, s.layout.fontSize, cell(column), widths[i]-2)
+		if i == 0 {
+			s.text(x, font, s.layout.fontSize, value)
+		} else {
+			s.text(x+widths[i]-pdf.TextWidth(font, s.layout.fontSize, value), font, s.layout.fontSize, value)
+		}
+		x += widths[i]
+	}
+}
+
+// drawTotals draws the totals right-aligned under the item table
+func (s *invoiceSheet) drawTotals(invoice *entities.Invoice) {
+	rows := []entities.InvoiceTemplateField{{Label: "Subtotal", Value: invoice.FormatAmount(invoice.Subtotal)}}
+	if invoice.DiscountAmount.IsPositive() {
+		rows = append(rows, entities.InvoiceTemplateField{Label: "Discount", Value: "-" + invoice.FormatAmount(invoice.DiscountAmount)})
+	}
+	if invoice.SurchargeAmount.IsPositive() {
+		rows = append(rows, entities.InvoiceTemplateField{Label: invoice.SurchargeLabel, Value: invoice.FormatAmount(invoice.SurchargeAmount)})
+	}
+	if s.template.IncludeTax && invoice.TaxAmount.IsPositive() {
+		label := "Tax"
+		if invoice.TaxInclusive {
+			label = "Tax (included)"
+		}
+		rows = append(rows, entities.InvoiceTemplateField{Label: label, Value: invoice.FormatAmount(invoice.TaxAmount)})
+	}
+
+	if s.cursor+float64(len(rows)+3)*s.layout.lineHeight > s.bottom() {
+		s.newPage()
+	}
+
+	labels := s.left() + s.contentWidth()/2
+	for _, row := range rows {
+		s.nextLine()
+		s.text(labels, pdf.FontRegular, s.layout.fontSize, row.Label)
+		s.rightText(pdf.FontRegular, s.layout.fontSize, row.Value)
+	}
+
+	s.nextLine()
+	s.accent()
+	s.text(labels, pdf.FontBold, s.layout.fontSize*1.2, "Total")
+	s.rightText(pdf.FontBold, s.layout.fontSize*1.2, invoice.FormatAmount(invoice.TotalAmount))
+	s.black()
+
+	if invoice.PaidAmount.IsPositive() {
+		s.nextLine()
+		s.text(labels, pdf.FontRegular, s.layout.fontSize, "Paid")
+		s.rightText(pdf.FontRegular, s.layout.fontSize, invoice.FormatAmount(invoice.PaidAmount))
+		if balance := invoice.TotalAmount.Sub(invoice.PaidAmount); balance.IsPositive() {
+			s.nextLine()
+			s.text(labels, pdf.FontBold, s.layout.fontSize, "Balance Due")
+			s.rightText(pdf.FontBold, s.layout.fontSize, invoice.FormatAmount(balance))
+		}
+	}
+}
+
+// drawClosing draws the notes, the customer's signature and the footer
+func (s *invoiceSheet) drawClosing(invoice *entities.Invoice) {
+	var lines []string
+	if invoice.Notes != "" {
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(invoice.Notes, "\n")...)
+	}
+	if invoice.Signature != nil {
+		lines = append(lines, "", strings.TrimSpace(string(signatureBlock(invoice.Signature))))
+	}
+	if s.template.Footer != "" {
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(s.template.Footer, "\n")...)
+	}
+
+	for _, line := range lines {
+		s.nextLine()
+		s.text(s.left(), pdf.FontRegular, s.layout.fontSize, pdf.FitText(pdf.FontRegular, s.layout.fontSize, strings.TrimSpace(line), s.contentWidth()))
+	}
+}
+
+// nextLine moves down a line, starting a new page when the current one is full
+func (s *invoiceSheet) nextLine() {
+	s.cursor += s.layout.lineHeight
+	if s.cursor > s.bottom() {
+		s.newPage()
+		s.cursor += s.layout.lineHeight
+	}
+}
+
+// newPage starts a new page with the accent bar
+func (s *invoiceSheet) newPage() {
+	s.doc.AddPage()
+	s.accent()
+	s.doc.Rect(0, 0, s.layout.widthMM, 3)
+	s.black()
+	s.cursor = s.layout.marginMM
+}
+
+func (s *invoiceSheet) text(x float64, font pdf.Font, size float64, text string) {
+	if text != "" {
+		s.doc.Text(x, s.cursor, font, size, text)
+	}
+}
+
+func (s *invoiceSheet) rightText(font pdf.Font, size float64, text string) {
+	s.text(s.right()-pdf.TextWidth(font, size, text), font, size, text)
+}
+
+func (s *invoiceSheet) accent() {
+	s.doc.FillColor(s.template.AccentRGB())
+}
+
+func (s *invoiceSheet) black() {
+	s.doc.FillColor(0, 0, 0)
+}
+
+func (s *invoiceSheet) left() float64         { return s.layout.marginMM }
+func (s *invoiceSheet) right() float64        { return s.layout.widthMM - s.layout.marginMM }
+func (s *invoiceSheet) bottom() float64       { return s.layout.heightMM - s.layout.marginMM }
+func (s *invoiceSheet) contentWidth() float64 { return s.right() - s.left() }
+
+// invoiceReceiptRows lays out an invoice as a receipt: the company, the template's header,
+// the invoice and its custom fields, the items, the totals and the footer
+func invoiceReceiptRows(invoice *entities.Invoice, template *entities.InvoiceTemplate) []registerReportRow {
+	company := template.CompanyInfo
+	rows := []registerReportRow{{label: company.Name, heading: true}}
+	for _, line := range []string{company.Address, company.Phone, company.TaxID} {
+		if line != "" {
+			rows = append(rows, registerReportRow{label: line})
+		}
+	}
+	if template.Header != "" {
+		for _, line := range strings.Split(template.Header, "\n") {
+			rows = append(rows, registerReportRow{label: strings.TrimSpace(line)})
+		}
+	}
+
+	rows = append(rows,
+		registerReportRow{},
+		registerReportRow{label: "INVOICE", value: invoice.InvoiceNumber, heading: true},
+		registerReportRow{label: "Date", value: formatReportTime(invoice.CreatedAt)},
+	)
+	if invoice.CustomerName != "" {
+		rows = append(rows, registerReportRow{label: "Customer", value: invoice.CustomerName})
+	}
+	for _, field := range template.CustomFields {
+		rows = append(rows, registerReportRow{label: field.Label, value: field.Value})
+	}
+
+	rows = append(rows, registerReportRow{})
+	for _, item := range invoice.Items {
+		rows = append(rows, registerReportRow{label: item.ProductName})
+		quantity := "  " + strconv.Itoa(item.Quantity)
+		if template.ShowsColumn(entities.InvoiceColumnUnitPrice) {
+			quantity += " x " + invoice.FormatAmount(item.UnitPrice)
+		}
+		rows = append(rows, registerReportRow{label: quantity, value: invoice.FormatAmount(item.TotalPrice)})
+	}
+
+	rows = append(rows, registerReportRow{}, registerReportRow{label: "Subtotal", value: invoice.FormatAmount(invoice.Subtotal)})
+	if invoice.DiscountAmount.IsPositive() {
+		rows = append(rows, registerReportRow{label: "Discount", value: "-" + invoice.FormatAmount(invoice.DiscountAmount)})
+	}
+	if invoice.SurchargeAmount.IsPositive() {
+		rows = append(rows, registerReportRow{label: invoice.SurchargeLabel, value: invoice.FormatAmount(invoice.SurchargeAmount)})
+	}
+	if template.IncludeTax && invoice.TaxAmount.IsPositive() {
+		rows = append(rows, registerReportRow{label: "Tax", value: invoice.FormatAmount(invoice.TaxAmount)})
+	}
+	rows = append(rows, registerReportRow{label: "Total", value: invoice.FormatAmount(invoice.TotalAmount), heading: true})
+
+	if template.Footer != "" {
+		rows = append(rows, registerReportRow{})
+		for _, line := range strings.Split(template.Footer, "\n") {
+			rows = append(rows, registerReportRow{label: strings.TrimSpace(line)})
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/domain/services"
+	"github.com/nicklaros/adol/pkg/barcode"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/pdf"
+)
+
+const (
+	// labelPaddingMM is the blank border kept inside each label
+	labelPaddingMM = 2.0
+	// maxBarcodeModuleMM is the widest bar module; narrower is used when the label is too small
+	maxBarcodeModuleMM = 0.33
+	// pointToMM converts font sizes to millimeters
+	pointToMM = 25.4 / 72
+)
+
+// LabelPDFService implements the ShelfLabelPDFService interface
+type LabelPDFService struct {
+	logger logger.Logger
+}
+
+// NewLabelPDFService creates a new shelf label PDF service
+func NewLabelPDFService(logger logger.Logger) services.ShelfLabelPDFService {
+	return &LabelPDFService{
+		logger: logger,
+	}
+}
+
+// GenerateShelfLabelsPDF renders labels onto sheets of the given layout, one label per slot
+func (s *LabelPDFService) GenerateShelfLabelsPDF(ctx context.Context, labels []entities.ShelfLabel, layout entities.LabelLayout) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, errors.NewValidationError("no labels to print", "at least one label is required")
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	doc := pdf.New(layout.PageWidthMM, layout.PageHeightMM)
+	for i, label := range labels {
+		if i%layout.LabelsPerPage() == 0 {
+			doc.AddPage()
+		}
+		x, y := layout.LabelPosition(i)
+		s.drawLabel(doc, label, x, y, layout.LabelWidthMM, layout.LabelHeightMM)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"labels": len(labels),
+		"pages":  doc.PageCount(),
+		"layout": layout.Name,
+	}).Info("Shelf labels generated successfully")
+
+	return doc.Bytes(), nil
+}
+
+// drawLabel draws one label with its top-left corner at x, y. From top to bottom a label
+// shows the product name, the price (or promo price with the regular price and validity)
+// and a Code 128 barcode with its human-readable text.
+func (s *LabelPDFService) drawLabel(doc *pdf.Document, label entities.ShelfLabel, x, y, width, height float64) {
+	innerX := x + labelPaddingMM
+	innerWidth := width - 2*labelPaddingMM
+	bottom := y + height - labelPaddingMM
+
+	// Larger labels get larger type
+	scale := math.Min(height/25.4, 1.5)
+	nameSize := 8 * scale
+	priceSize := 14 * scale
+	detailSize := 6 * scale
+	barcodeTextSize := 6.0
+
+	cursor := y + labelPaddingMM + nameSize*pointToMM
+	doc.Text(innerX, cursor, pdf.FontBold, nameSize, pdf.FitText(pdf.FontBold, nameSize, label.Name, innerWidth))
+
+	price := label.Price
+	if label.PromoPrice != nil {
+		price = *label.PromoPrice
+	}
+	cursor += 1 + priceSize*pointToMM
+	priceText := label.Currency.Format(price)
+	doc.Text(innerX, cursor, pdf.FontBold, priceSize, priceText)
+	if label.Unit != "" {
+		unitX := innerX + pdf.TextWidth(pdf.FontBold, priceSize, priceText) + 1
+		doc.Text(unitX, cursor, pdf.FontRegular, detailSize, "/ "+label.Unit)
+	}
+
+	if label.PromoPrice != nil {
+		detail := "Was " + label.Currency.Format(label.Price)
+		if label.PromoEndsAt != nil {
+			detail += " - until " + label.PromoEndsAt.Format("02 Jan 2006")
+		}
+		cursor += 0.5 + detailSize*pointToMM
+		doc.Text(innerX, cursor, pdf.FontRegular, detailSize, pdf.FitText(pdf.FontRegular, detailSize, detail, innerWidth))
+	}
+
+	// The barcode takes whatever height is left above its text line
+	textBaseline := bottom
+	barTop := cursor + 1.5
+	barBottom := textBaseline - barcodeTextSize*pointToMM - 0.5
+	if barBottom-barTop < 4 {
+		s.logger.WithField("sku", label.SKU).Warn("Shelf label too small for barcode")
+		return
+	}
+
+	widths, err := barcode.Code128(label.BarcodeData)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"sku":   label.SKU,
+			"error": err.Error(),
+		}).Warn("Failed to encode shelf label barcode")
+		doc.Text(innerX, textBaseline, pdf.FontRegular, barcodeTextSize, label.BarcodeData)
+		return
+	}
+
+	modules := 0
+	for _, w := range widths {
+		modules += w
+	}
+	module := math.Min(maxBarcodeModuleMM, innerWidth/float64(modules))
+
+	barX := innerX
+	for i, w := range widths {
+		if i%2 == 0 {
+			doc.Rect(barX, barTop, float64(w)*module, barBottom-barTop)
+		}
+		barX += float64(w) * module
+	}
+	doc.Text(innerX, textBaseline, pdf.FontRegular, barcodeTextSize, label.BarcodeData)
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// midtransSnapSandboxURL and midtransSnapProductionURL create Snap transactions
+	midtransSnapSandboxURL    = "https://app.sandbox.midtrans.com/snap/v1/transactions"
+	midtransSnapProductionURL = "https://app.midtrans.com/snap/v1/transactions"
+)
+
+// midtransEnabledPayments are the Snap payment channels offered for each payment method
+var midtransEnabledPayments = map[string][]string{
+	"card":           {"credit_card"},
+	"digital_wallet": {"gopay", "shopeepay", "other_qris"},
+}
+
+// MidtransGatewayService implements the PaymentGatewayPort interface over Midtrans Snap, which
+// hosts the payment page the customer is redirected to
+type MidtransGatewayService struct {
+	client    *http.Client
+	serverKey string
+	snapURL   string
+	logger    logger.Logger
+}
+
+// NewMidtransGatewayService creates a new Midtrans payment gateway service. Payments are charged
+// through the sandbox unless production is set.
+func NewMidtransGatewayService(serverKey string, production bool, timeout time.Duration, logger logger.Logger) ports.PaymentGatewayPort {
+	snapURL := midtransSnapSandboxURL
+	if production {
+		snapURL = midtransSnapProductionURL
+	}
+
+	return &MidtransGatewayService{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		serverKey: serverKey,
+		snapURL:   snapURL,
+		logger:    logger,
+	}
+}
+
+// CreatePaymentIntent creates a Snap transaction whose order ID is the request's reference. A
+// Snap transaction for an order ID that already exists is rejected rather than created twice.
+func (s *MidtransGatewayService) CreatePaymentIntent(ctx context.Context, request ports.PaymentIntentRequest) (*ports.PaymentIntent, error) {
+	if request.Currency != "IDR" {
+		return nil, fmt.Errorf("midtrans only accepts payments in IDR")
+	}
+	if !request.Amount.Equal(request.Amount.Truncate(0)) {
+		return nil, fmt.Errorf("midtrans payment amounts must be whole rupiah")
+	}
+
+	body := map[string]interface{}{
+		"transaction_details": map[string]interface{}{
+			"order_id":     request.Reference,
+			"gross_amount": request.Amount.IntPart(),
+		},
+	}
+	if payments, ok := midtransEnabledPayments[request.PaymentMethod]; ok {
+		body["enabled_payments"] = payments
+	}
+	if request.CustomerEmail != "" {
+		body["customer_details"] = map[string]interface{}{"email": request.CustomerEmail}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.snapURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	// The server key is the username, with an empty password
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(s.serverKey+":")))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(io.LimitReader(resp.Body, paymentGatewayResponseLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var transaction struct {
+		Token         string   `json:"token"`
+		RedirectURL   string   `json:"redirect_url"`
+		ErrorMessages []string `json:"error_messages"`
+	}
+	if err := json.Unmarshal(response, &transaction); err != nil && resp.StatusCode < 300 {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		s.logger.WithFields(map[string]interface{}{
+			"reference": request.Reference,
+			"status":    resp.StatusCode,
+			"error":     strings.Join(transaction.ErrorMessages, "; "),
+		}).Warn("Midtrans rejected transaction")
+		return nil, fmt.Errorf("midtrans responded with status %d: %s", resp.StatusCode, strings.Join(transaction.ErrorMessages, "; "))
+	}
+
+	return &ports.PaymentIntent{
+		ExternalID:    request.Reference,
+		RedirectURL:   transaction.RedirectURL,
+		GatewayStatus: "pending",
+	}, nil
+}
+
+// ParseWebhook verifies the signature key of an HTTP notification and returns the payment
+// update it carries. Midtrans signs the notification in its body, so the signature argument is
+// not used.
+func (s *MidtransGatewayService) ParseWebhook(signature string, body []byte) (*ports.PaymentGatewayEvent, error) {
+	var notification struct {
+		OrderID           string `json:"order_id"`
+		StatusCode        string `json:"status_code"`
+		GrossAmount       string `json:"gross_amount"`
+		Currency          string `json:"currency"`
+		SignatureKey      string `json:"signature_key"`
+		TransactionStatus string `json:"transaction_status"`
+		FraudStatus       string `json:"fraud_status"`
+	}
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, fmt.Errorf("failed to decode Midtrans notification: %w", err)
+	}
+
+	// The signature key is the hex SHA-512 of the order ID, status code, gross amount and server key
+	digest := sha512.Sum512([]byte(notification.OrderID + notification.StatusCode + notification.GrossAmount + s.serverKey))
+	expected := hex.EncodeToString(digest[:])
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(notification.SignatureKey))) != 1 {
+		return nil, fmt.Errorf("midtrans signature mismatch")
+	}
+
+	amount, err := decimal.NewFromString(notification.GrossAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Midtrans gross amount: %w", err)
+	}
+
+	var status string
+	switch notification.TransactionStatus {
+	case "settlement":
+		status = "succeeded"
+	case "capture":
+		// Card payments flagged by fraud detection wait for a manual review
+		switch notification.FraudStatus {
+		case "challenge":
+			status = "pending"
+		case "deny":
+			status = "failed"
+		default:
+			status = "succeeded"
+		}
+	case "deny", "cancel", "expire", "failure":
+		status = "failed"
+	case "pending", "authorize":
+		status = "pending"
+	default:
+		return nil, nil
+	}
+
+	currency := notification.Currency
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	return &ports.PaymentGatewayEvent{
+		ExternalID:    notification.OrderID,
+		Status:        status,
+		GatewayStatus: notification.TransactionStatus,
+		Amount:        amount,
+		Currency:      currency,
+	}, nil
+}
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strconv"
+	"time"
 
 	"github.com/nicklaros/adol/internal/domain/entities"
 	"github.com/nicklaros/adol/internal/domain/services"
 	"github.com/nicklaros/adol/pkg/errors"
 	"github.com/nicklaros/adol/pkg/logger"
+	"github.com/nicklaros/adol/pkg/pdf"
 )
 
 // PDFService implements the InvoicePDFService interface
@@ -42,10 +45,14 @@ func (s *PDFService) GenerateInvoicePDFToWriter(ctx context.Context, invoice *en
 		return errors.NewValidationError("template is required", "template cannot be nil")
 	}
 
-	// TODO: Implement actual PDF generation with gofpdf
-	// For now, return a placeholder to fix the build
-	placeholder := []byte("PDF content placeholder for invoice " + invoice.InvoiceNumber)
-	_, err := writer.Write(placeholder)
+	var doc *pdf.Document
+	if template.PaperSize == entities.PaperSizeReceipt {
+		doc = drawReportRows(invoiceReceiptRows(invoice, template), registerReportReceipt)
+	} else {
+		doc = drawInvoice(invoice, template, s.invoiceLogo(invoice, template))
+	}
+
+	_, err := writer.Write(doc.Bytes())
 	if err != nil {
 		s.logger.WithFields(map[string]interface{}{
 			"invoice_id": invoice.ID,
@@ -58,6 +65,7 @@ func (s *PDFService) GenerateInvoicePDFToWriter(ctx context.Context, invoice *en
 		"invoice_id":     invoice.ID,
 		"invoice_number": invoice.InvoiceNumber,
 		"paper_size":     template.PaperSize,
+		"pages":          doc.PageCount(),
 	}).Info("PDF generated successfully")
 
 	return nil
@@ -65,8 +73,6 @@ func (s *PDFService) GenerateInvoicePDFToWriter(ctx context.Context, invoice *en
 
 // GenerateReceiptPDF generates a thermal receipt PDF (80mm width)
 func (s *PDFService) GenerateReceiptPDF(ctx context.Context, invoice *entities.Invoice, template *entities.InvoiceTemplate) ([]byte, error) {
-	var buf bytes.Buffer
-	
 	if invoice == nil {
 		return nil, errors.NewValidationError("invoice is required", "invoice cannot be nil")
 	}
@@ -74,14 +80,8 @@ func (s *PDFService) GenerateReceiptPDF(ctx context.Context, invoice *entities.I
 		return nil, errors.NewValidationError("template is required", "template cannot be nil")
 	}
 
-	// TODO: Implement actual thermal receipt PDF generation
-	placeholder := []byte("Thermal receipt PDF placeholder for invoice " + invoice.InvoiceNumber)
-	_, err := buf.Write(placeholder)
-	if err != nil {
-		return nil, errors.NewInternalError("failed to generate receipt PDF", err)
-	}
-
-	return buf.Bytes(), nil
+	doc := drawReportRows(invoiceReceiptRows(invoice, template), registerReportReceipt)
+	return doc.Bytes(), nil
 }
 
 // ValidateTemplate validates an invoice template
@@ -99,9 +99,36 @@ func (s *PDFService) ValidateTemplate(template *entities.InvoiceTemplate) error
 		return errors.NewValidationError("currency is required", "currency cannot be empty")
 	}
 
+	if err := template.ValidateDesign(); err != nil {
+		return err
+	}
+	if len(template.Logo) > 0 {
+		if _, err := pdf.NewImage(template.Logo); err != nil {
+			return errors.NewValidationError("invalid logo", "logo image cannot be read")
+		}
+	}
+
 	return nil
 }
 
+// invoiceLogo prepares the template's logo for drawing, nil when the template shows none. A
+// logo that cannot be read is left out rather than failing the invoice.
+func (s *PDFService) invoiceLogo(invoice *entities.Invoice, template *entities.InvoiceTemplate) *pdf.Image {
+	if !template.ShowLogo || len(template.Logo) == 0 {
+		return nil
+	}
+
+	logo, err := pdf.NewImage(template.Logo)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"invoice_id": invoice.ID,
+			"error":      err.Error(),
+		}).Warn("Invoice logo skipped")
+		return nil
+	}
+	return logo
+}
+
 // GetDefaultTemplate returns the default invoice template for a paper size
 func (s *PDFService) GetDefaultTemplate(paperSize entities.PaperSize) *entities.InvoiceTemplate {
 	return &entities.InvoiceTemplate{
@@ -138,4 +165,18 @@ func (s *PDFService) PreviewInvoice(ctx context.Context, invoice *entities.Invoi
 	}).Info("Invoice preview generated")
 
 	return placeholder, nil
-}
\ No newline at end of file
+}
+
+// signatureBlock renders the signer details printed under the drawn signature image
+func signatureBlock(signature *entities.DocumentSignature) []byte {
+	if signature == nil {
+		return nil
+	}
+
+	signer := signature.SignerName
+	if signature.SignerTitle != "" {
+		signer += " (" + signature.SignerTitle + ")"
+	}
+	return []byte("\nSigned by " + signer + " on " + signature.SignedAt.Format(time.RFC3339) +
+		" [" + signature.ContentType + " signature image, " + strconv.Itoa(len(signature.ImageData)) + " bytes]")
+}
@@ -63,6 +63,63 @@ func (s *PrintService) PrintReceipt(ctx context.Context, invoice *entities.Invoi
 	return nil
 }
 
+// PrintSaleReceipt prints the receipt of a completed sale to a printer
+func (s *PrintService) PrintSaleReceipt(ctx context.Context, sale *entities.Sale, template *entities.InvoiceTemplate, printerName string) error {
+	if sale == nil {
+		return errors.NewValidationError("sale is required", "sale cannot be nil")
+	}
+	if printerName == "" {
+		return errors.NewValidationError("printer name is required", "printer name cannot be empty")
+	}
+
+	// Printing is not implemented yet, so the receipt is reported as not printed rather than
+	// telling the cashier it went to the printer
+	s.logger.WithFields(map[string]interface{}{
+		"sale_id":      sale.ID,
+		"sale_number":  sale.SaleNumber,
+		"printer_name": printerName,
+	}).Warn("Sale receipt print request received (not implemented)")
+
+	return errors.NewAppError(errors.ErrorTypeInternal, "receipt printing is not implemented", nil)
+}
+
+// PrintTestPage prints a test page to a printer
+func (s *PrintService) PrintTestPage(ctx context.Context, printerName string) error {
+	if printerName == "" {
+		return errors.NewValidationError("printer name is required", "printer name cannot be empty")
+	}
+
+	// Printing is not implemented yet, so the test page is reported as failed rather than
+	// leaving the printer marked ready without having printed anything
+	s.logger.WithFields(map[string]interface{}{
+		"printer_name": printerName,
+	}).Warn("Test page print request received (not implemented)")
+
+	return errors.NewAppError(errors.ErrorTypeInternal, "test page printing is not implemented", nil)
+}
+
+// PrintRegisterReport prints an X- or Z-report to a printer, as a receipt on thermal printers
+func (s *PrintService) PrintRegisterReport(ctx context.Context, report *entities.RegisterReport, printerName string, thermal bool) error {
+	if report == nil {
+		return errors.NewValidationError("report is required", "report cannot be nil")
+	}
+	if printerName == "" {
+		return errors.NewValidationError("printer name is required", "printer name cannot be empty")
+	}
+
+	// Printing is not implemented yet, so the report is reported as not printed rather than
+	// telling the cashier it went to the printer
+	s.logger.WithFields(map[string]interface{}{
+		"session_id":   report.SessionID,
+		"type":         report.Type,
+		"number":       report.Number,
+		"printer_name": printerName,
+		"thermal":      thermal,
+	}).Warn("Register report print request received (not implemented)")
+
+	return errors.NewAppError(errors.ErrorTypeInternal, "register report printing is not implemented", nil)
+}
+
 // GetAvailablePrinters returns list of available printers
 func (s *PrintService) GetAvailablePrinters() ([]services.PrinterInfo, error) {
 	// Return mock printer data for now
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// quoteSheetA5 sizes a quote on A5 sheets; other paper sizes are laid out on A4 sheets
+var quoteSheetA5 = registerReportLayout{widthMM: 148, heightMM: 210, marginMM: 12, fontSize: 8, lineHeight: 4.8}
+
+// GenerateQuotePDF generates a quote with the company details of an invoice template
+func (s *PDFService) GenerateQuotePDF(ctx context.Context, quote *entities.Quote, template *entities.InvoiceTemplate) ([]byte, error) {
+	if quote == nil {
+		return nil, errors.NewValidationError("quote is required", "quote cannot be nil")
+	}
+	if template == nil {
+		return nil, errors.NewValidationError("template is required", "template cannot be nil")
+	}
+
+	layout := registerReportSheet
+	if template.PaperSize == entities.PaperSizeA5 {
+		layout = quoteSheetA5
+	}
+	doc := drawReportRows(quoteRows(quote, template), layout)
+
+	s.logger.WithFields(map[string]interface{}{
+		"quote_id":     quote.ID,
+		"quote_number": quote.QuoteNumber,
+		"paper_size":   template.PaperSize,
+		"pages":        doc.PageCount(),
+	}).Info("Quote PDF generated successfully")
+
+	return doc.Bytes(), nil
+}
+
+// quoteRows lays out a quote from top to bottom: the company quoting, the quote and its
+// validity, the customer, the quoted items and the notes
+func quoteRows(quote *entities.Quote, template *entities.InvoiceTemplate) []registerReportRow {
+	company := template.CompanyInfo
+	rows := []registerReportRow{{label: company.Name, heading: true}}
+	for _, line := range []string{company.Address, company.Phone, company.Email, company.Website} {
+		if line != "" {
+			rows = append(rows, registerReportRow{label: line})
+		}
+	}
+
+	rows = append(rows,
+		registerReportRow{},
+		registerReportRow{label: "QUOTE", value: quote.QuoteNumber, heading: true},
+		registerReportRow{label: "Date", value: quote.CreatedAt.Format("2006-01-02")},
+		registerReportRow{label: "Valid until", value: quote.ExpiresAt.Format("2006-01-02")},
+		registerReportRow{},
+		registerReportRow{label: "CUSTOMER", heading: true},
+		registerReportRow{label: quote.CustomerName},
+	)
+	for _, line := range []string{quote.CustomerEmail, quote.CustomerPhone} {
+		if line != "" {
+			rows = append(rows, registerReportRow{label: line})
+		}
+	}
+
+	rows = append(rows,
+		registerReportRow{},
+		registerReportRow{label: "ITEMS", heading: true},
+	)
+	for _, item := range quote.Items {
+		rows = append(rows, registerReportRow{
+			label: fmt.Sprintf("%d x %s @ %s", item.Quantity, item.ProductName, quote.FormatAmount(item.UnitPrice)),
+			value: quote.FormatAmount(item.TotalPrice),
+		})
+	}
+	rows = append(rows, registerReportRow{label: "Total", value: quote.FormatAmount(quote.TotalAmount), heading: true})
+
+	if quote.Notes != "" {
+		rows = append(rows, registerReportRow{}, registerReportRow{label: "NOTES", heading: true})
+		for _, line := range strings.Split(quote.Notes, "\n") {
+			rows = append(rows, registerReportRow{label: strings.TrimSpace(line)})
+		}
+	}
+
+	rows = append(rows,
+		registerReportRow{},
+		registerReportRow{label: "Prices are valid until " + quote.ExpiresAt.Format("2006-01-02") + "."},
+	)
+	if template.Footer != "" {
+		rows = append(rows, registerReportRow{label: template.Footer})
+	}
+
+	return rows
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/pdf"
+)
+
+// registerReportLayout sizes a register report on its paper
+type registerReportLayout struct {
+	widthMM    float64
+	heightMM   float64 // 0 for a receipt roll cut to the report's length
+	marginMM   float64
+	fontSize   float64
+	lineHeight float64
+}
+
+var (
+	registerReportSheet   = registerReportLayout{widthMM: 210, heightMM: 297, marginMM: 20, fontSize: 10, lineHeight: 6}
+	registerReportReceipt = registerReportLayout{widthMM: 80, marginMM: 4, fontSize: 8, lineHeight: 4.2}
+)
+
+// registerReportRow is one line of a printed register report: a heading, a label with its
+// value aligned right, or a blank line when both are empty
+type registerReportRow struct {
+	label   string
+	value   string
+	heading bool
+}
+
+// GenerateRegisterReportPDF generates an X- or Z-report on A4 sheets
+func (s *PDFService) GenerateRegisterReportPDF(ctx context.Context, report *entities.RegisterReport) ([]byte, error) {
+	return s.generateRegisterReport(report, registerReportSheet)
+}
+
+// GenerateRegisterReportReceiptPDF generates an X- or Z-report as a thermal receipt (80mm width)
+func (s *PDFService) GenerateRegisterReportReceiptPDF(ctx context.Context, report *entities.RegisterReport) ([]byte, error) {
+	return s.generateRegisterReport(report, registerReportReceipt)
+}
+
+func (s *PDFService) generateRegisterReport(report *entities.RegisterReport, layout registerReportLayout) ([]byte, error) {
+	if report == nil {
+		return nil, errors.NewValidationError("report is required", "report cannot be nil")
+	}
+
+	doc := drawReportRows(registerReportRows(report), layout)
+
+	s.logger.WithFields(map[string]interface{}{
+		"session_id": report.SessionID,
+		"type":       report.Type,
+		"number":     report.Number,
+		"pages":      doc.PageCount(),
+	}).Info("Register report generated successfully")
+
+	return doc.Bytes(), nil
+}
+
+// drawReportRows draws rows from top to bottom, starting a new page when one is full. A
+// receipt roll is cut to the length of its rows.
+func drawReportRows(rows []registerReportRow, layout registerReportLayout) *pdf.Document {
+	height := layout.heightMM
+	if height == 0 {
+		height = 2*layout.marginMM + float64(len(rows)+1)*layout.lineHeight
+	}
+
+	doc := pdf.New(layout.widthMM, height)
+	doc.AddPage()
+	left := layout.marginMM
+	right := layout.widthMM - layout.marginMM
+	cursor := layout.marginMM
+	for _, row := range rows {
+		cursor += layout.lineHeight
+		if cursor > height-layout.marginMM {
+			doc.AddPage()
+			cursor = layout.marginMM + layout.lineHeight
+		}
+
+		font := pdf.FontRegular
+		if row.heading {
+			font = pdf.FontBold
+		}
+		valueWidth := pdf.TextWidth(font, layout.fontSize, row.value)
+		if row.label != "" {
+			label := pdf.FitText(font, layout.fontSize, row.label, right-left-valueWidth-2)
+			doc.Text(left, cursor, font, layout.fontSize, label)
+		}
+		if row.value != "" {
+			doc.Text(right-valueWidth, cursor, font, layout.fontSize, row.value)
+		}
+	}
+
+	return doc
+}
+
+// registerReportRows lays out a report from top to bottom: what it covers, the sales, the
+// payments taken, the refunds given and the cash drawer
+func registerReportRows(report *entities.RegisterReport) []registerReportRow {
+	title := "X-REPORT"
+	if report.Type == entities.RegisterReportTypeZ {
+		title = fmt.Sprintf("Z-REPORT #%d", report.Number)
+	}
+
+	drawer := report.Drawer
+	if drawer == nil {
+		drawer = &entities.RegisterReconciliation{}
+	}
+	sales := report.Sales
+
+	rows := []registerReportRow{
+		{label: title, heading: true},
+		{label: "Register", value: report.Register},
+		{label: "Opened", value: formatReportTime(drawer.OpenedAt)},
+	}
+	if drawer.ClosedAt != nil {
+		rows = append(rows, registerReportRow{label: "Closed", value: formatReportTime(*drawer.ClosedAt)})
+	}
+	rows = append(rows,
+		registerReportRow{label: "Printed", value: formatReportTime(report.GeneratedAt)},
+		registerReportRow{},
+		registerReportRow{label: "SALES", heading: true},
+		registerReportRow{label: "Sales", value: fmt.Sprintf("%d", sales.SaleCount)},
+		registerReportRow{label: "Items sold", value: fmt.Sprintf("%d", sales.ItemsSold)},
+		registerReportRow{label: "Gross sales", value: formatReportAmount(sales.GrossSales)},
+		registerReportRow{label: "Discounts", value: formatReportAmount(sales.DiscountAmount.Neg())},
+		registerReportRow{label: "Tax", value: formatReportAmount(sales.TaxAmount)},
+		registerReportRow{label: "Surcharges", value: formatReportAmount(sales.SurchargeAmount)},
+		registerReportRow{label: "Net sales", value: formatReportAmount(sales.NetSales), heading: true},
+		registerReportRow{},
+		registerReportRow{label: "PAYMENTS", heading: true},
+	)
+	rows = append(rows, paymentTotalRows(sales.Payments)...)
+	rows = append(rows,
+		registerReportRow{},
+		registerReportRow{label: "REFUNDS", heading: true},
+	)
+	rows = append(rows, paymentTotalRows(sales.Refunds)...)
+	rows = append(rows,
+		registerReportRow{label: fmt.Sprintf("Total refunds (%d)", sales.RefundCount), value: formatReportAmount(sales.RefundAmount), heading: true},
+		registerReportRow{},
+		registerReportRow{label: "DRAWER", heading: true},
+		registerReportRow{label: "Opening float", value: formatReportAmount(drawer.OpeningFloat)},
+		registerReportRow{label: "Cash sales", value: formatReportAmount(drawer.CashSales)},
+		registerReportRow{label: "Cash refunds", value: formatReportAmount(drawer.CashRefunds.Neg())},
+		registerReportRow{label: "Cash in", value: formatReportAmount(drawer.CashIn)},
+		registerReportRow{label: "Cash out", value: formatReportAmount(drawer.CashOut.Neg())},
+		registerReportRow{label: "Expected cash", value: formatReportAmount(drawer.ExpectedCash), heading: true},
+	)
+	if drawer.CountedCash != nil {
+		rows = append(rows, registerReportRow{label: "Counted cash", value: formatReportAmount(*drawer.CountedCash)})
+	}
+	if drawer.Difference != nil {
+		rows = append(rows, registerReportRow{label: "Difference", value: formatReportAmount(*drawer.Difference), heading: true})
+	}
+	if report.GrandTotal != nil {
+		rows = append(rows,
+			registerReportRow{},
+			registerReportRow{label: "Grand total", value: formatReportAmount(*report.GrandTotal), heading: true},
+		)
+	}
+
+	return rows
+}
+
+// paymentTotalRows lists the amount taken or given back with each payment method
+func paymentTotalRows(totals []entities.RegisterPaymentTotal) []registerReportRow {
+	if len(totals) == 0 {
+		return []registerReportRow{{label: "None"}}
+	}
+
+	rows := make([]registerReportRow, 0, len(totals))
+	for _, total := range totals {
+		label := strings.ReplaceAll(string(total.PaymentMethod), "_", " ")
+		if label != "" {
+			label = strings.ToUpper(label[:1]) + label[1:]
+		}
+		rows = append(rows, registerReportRow{
+			label: fmt.Sprintf("%s (%d)", label, total.Count),
+			value: formatReportAmount(total.Amount),
+		})
+	}
+	return rows
+}
+
+func formatReportAmount(amount decimal.Decimal) string {
+	return amount.StringFixed(2)
+}
+
+func formatReportTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04")
+}
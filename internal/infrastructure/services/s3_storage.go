@@ -0,0 +1,390 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/pkg/errors"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// S3Storage implements the FileStoragePort interface on any S3-compatible object store
+// (AWS S3, MinIO, Cloudflare R2, GCS interoperability) using Signature Version 4
+type S3Storage struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	pathStyle       bool
+	client          *http.Client
+	logger          logger.Logger
+}
+
+// S3StorageConfig holds S3-compatible storage configuration
+type S3StorageConfig struct {
+	Endpoint        string // e.g. https://s3.ap-southeast-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // Address the bucket in the path instead of the host name, as MinIO expects
+}
+
+// maxPresignExpiry is the longest expiry S3 accepts for a presigned URL
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// NewS3Storage creates a new S3-compatible file storage
+func NewS3Storage(config S3StorageConfig, logger logger.Logger) (ports.FileStoragePort, error) {
+	if config.Bucket == "" {
+		return nil, errors.NewValidationError("bucket is required", "S3 bucket cannot be empty")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.NewValidationError("credentials are required", "S3 access key ID and secret access key must be set")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + config.Region + ".amazonaws.com"
+	}
+	parsed, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, errors.NewValidationError("invalid endpoint", "S3 endpoint must be an absolute URL")
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &S3Storage{
+		endpoint:        parsed,
+		region:          region,
+		bucket:          config.Bucket,
+		accessKeyID:     config.AccessKeyID,
+		secretAccessKey: config.SecretAccessKey,
+		pathStyle:       config.PathStyle,
+		client:          &http.Client{Timeout: 5 * time.Minute},
+		logger:          logger,
+	}, nil
+}
+
+// Store stores a file and returns the file path
+func (s *S3Storage) Store(ctx context.Context, filename string, data []byte) (string, error) {
+	key := strings.TrimLeft(filename, "/")
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", s.responseError("store", key, resp)
+	}
+
+	return key, nil
+}
+
+// StoreFrom stores a file of the given size read from the start of body. The body is read once
+// to compute the payload hash SigV4 signs, then rewound and streamed to the object store.
+func (s *S3Storage) StoreFrom(ctx context.Context, filename string, body io.ReadSeeker, size int64) (string, error) {
+	key := strings.TrimLeft(filename, "/")
+
+	hash := sha256.New()
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind object %s: %w", key, err)
+	}
+	if _, err := io.Copy(hash, body); err != nil {
+		return "", fmt.Errorf("failed to hash object %s: %w", key, err)
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind object %s: %w", key, err)
+	}
+
+	resp, err := s.send(ctx, http.MethodPut, key, body, size, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", s.responseError("store", key, resp)
+	}
+
+	return key, nil
+}
+
+// Retrieve retrieves a file by path
+func (s *S3Storage) Retrieve(ctx context.Context, filepath string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, filepath, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NewNotFoundError("file")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.responseError("retrieve", filepath, resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", filepath, err)
+	}
+
+	return data, nil
+}
+
+// Delete deletes a file by path
+func (s *S3Storage) Delete(ctx context.Context, filepath string) error {
+	resp, err := s.do(ctx, http.MethodDelete, filepath, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s.responseError("delete", filepath, resp)
+	}
+
+	return nil
+}
+
+// Exists checks if a file exists
+func (s *S3Storage) Exists(ctx context.Context, filepath string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, filepath, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, s.responseError("check", filepath, resp)
+	}
+}
+
+// GetURL returns a public URL for a file. The URL only works for objects the bucket policy
+// makes publicly readable; use GetSignedURL for private objects.
+func (s *S3Storage) GetURL(ctx context.Context, filepath string) (string, error) {
+	return s.objectURL(filepath).String(), nil
+}
+
+// GetSignedURL returns a presigned GET URL for private file access
+func (s *S3Storage) GetSignedURL(ctx context.Context, filepath string, expiration time.Duration) (string, error) {
+	if expiration <= 0 || expiration > maxPresignExpiry {
+		return "", errors.NewValidationError("invalid expiration", "signed URL expiration must be between 1 second and 7 days")
+	}
+
+	now := time.Now().UTC()
+	target := s.objectURL(filepath)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.accessKeyID+"/"+s.credentialScope(now))
+	query.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	query.Set("X-Amz-Expires", fmt.Sprintf("%d", int(expiration.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalQuery := canonicalQueryString(query)
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		target.EscapedPath(),
+		canonicalQuery,
+		"host:" + target.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	signature := s.sign(now, canonicalRequest)
+	target.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+
+	return target.String(), nil
+}
+
+// Helper functions
+
+// do sends a signed request for an object
+func (s *S3Storage) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	return s.send(ctx, method, key, bytes.NewReader(body), int64(len(body)), sha256Hex(body))
+}
+
+// send sends a signed request for an object with a body of the given size and SHA-256 hash
+func (s *S3Storage) send(ctx context.Context, method, key string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	target := s.objectURL(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage request: %w", err)
+	}
+	req.ContentLength = size
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if method == http.MethodPut {
+		req.Header.Set("Content-Type", contentTypeFor(key))
+	}
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		method,
+		target.EscapedPath(),
+		"",
+		"host:" + target.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, s.credentialScope(now), signedHeaders, s.sign(now, canonicalRequest)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"method": method,
+			"key":    key,
+			"error":  err.Error(),
+		}).Error("Storage request failed")
+		return nil, fmt.Errorf("storage request failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// objectURL returns the URL of an object in the bucket
+func (s *S3Storage) objectURL(key string) *url.URL {
+	target := *s.endpoint
+	key = strings.TrimLeft(key, "/")
+
+	if s.pathStyle {
+		target.Path = target.Path + "/" + s.bucket + "/" + key
+	} else {
+		target.Host = s.bucket + "." + target.Host
+		target.Path = target.Path + "/" + key
+	}
+	target.RawPath = escapePath(target.Path)
+
+	return &target
+}
+
+// credentialScope returns the SigV4 credential scope for the given day
+func (s *S3Storage) credentialScope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// sign derives the SigV4 signing key and signs the canonical request
+func (s *S3Storage) sign(now time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		s.credentialScope(now),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// responseError converts a failed storage response into an error
+func (s *S3Storage) responseError(action, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	s.logger.WithFields(map[string]interface{}{
+		"action": action,
+		"key":    key,
+		"status": resp.StatusCode,
+		"body":   string(body),
+	}).Error("Storage request rejected")
+	return fmt.Errorf("failed to %s object %s: storage returned status %d", action, key, resp.StatusCode)
+}
+
+// contentTypeFor returns the content type stored with an object
+func contentTypeFor(key string) string {
+	switch {
+	case strings.HasSuffix(key, ".parquet"):
+		return "application/vnd.apache.parquet"
+	case strings.HasSuffix(key, ".zip"):
+		return "application/zip"
+	case strings.HasSuffix(key, ".json"):
+		return "application/json"
+	case strings.HasSuffix(key, ".pdf"):
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// escapePath URI-encodes every path segment as SigV4 requires, keeping the slashes
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQueryString sorts and URI-encodes query parameters as SigV4 requires
+func canonicalQueryString(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, uriEncode(key)+"="+uriEncode(values.Get(key)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything except the unreserved characters
+func uriEncode(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/errors"
+)
+
+// GenerateSaleReceiptPDF generates the receipt of a completed sale with the company details of
+// an invoice template. Receipt templates are laid out on an 80mm roll, A5 templates on A5
+// sheets and other paper sizes on A4 sheets.
+func (s *PDFService) GenerateSaleReceiptPDF(ctx context.Context, sale *entities.Sale, template *entities.InvoiceTemplate) ([]byte, error) {
+	if sale == nil {
+		return nil, errors.NewValidationError("sale is required", "sale cannot be nil")
+	}
+	if template == nil {
+		return nil, errors.NewValidationError("template is required", "template cannot be nil")
+	}
+
+	layout := registerReportSheet
+	switch template.PaperSize {
+	case entities.PaperSizeReceipt:
+		layout = registerReportReceipt
+	case entities.PaperSizeA5:
+		layout = quoteSheetA5
+	}
+	doc := drawReportRows(saleReceiptRows(sale, template), layout)
+
+	s.logger.WithFields(map[string]interface{}{
+		"sale_id":     sale.ID,
+		"sale_number": sale.SaleNumber,
+		"paper_size":  template.PaperSize,
+		"pages":       doc.PageCount(),
+	}).Info("Sale receipt PDF generated successfully")
+
+	return doc.Bytes(), nil
+}
+
+// saleReceiptRows lays out a receipt from top to bottom: the company, the sale, the items
+// with their quantity on a line of their own, the totals and how the sale was paid
+func saleReceiptRows(sale *entities.Sale, template *entities.InvoiceTemplate) []registerReportRow {
+	company := template.CompanyInfo
+	rows := []registerReportRow{{label: company.Name, heading: true}}
+	for _, line := range []string{company.Address, company.Phone, company.TaxID} {
+		if line != "" {
+			rows = append(rows, registerReportRow{label: line})
+		}
+	}
+
+	date := sale.CreatedAt
+	if sale.CompletedAt != nil {
+		date = *sale.CompletedAt
+	}
+	rows = append(rows,
+		registerReportRow{},
+		registerReportRow{label: "RECEIPT", value: sale.SaleNumber, heading: true},
+		registerReportRow{label: "Date", value: formatReportTime(date)},
+	)
+	if sale.CustomerName != "" {
+		rows = append(rows, registerReportRow{label: "Customer", value: sale.CustomerName})
+	}
+
+	rows = append(rows, registerReportRow{})
+	for _, item := range sale.Items {
+		rows = append(rows,
+			registerReportRow{label: item.ProductName},
+			registerReportRow{
+				label: fmt.Sprintf("  %d x %s", item.Quantity, sale.FormatAmount(item.UnitPrice)),
+				value: sale.FormatAmount(item.TotalPrice),
+			},
+		)
+	}
+
+	rows = append(rows, registerReportRow{}, registerReportRow{label: "Subtotal", value: sale.FormatAmount(sale.Subtotal)})
+	if sale.DiscountAmount.IsPositive() {
+		rows = append(rows, registerReportRow{label: "Discount", value: "-" + sale.FormatAmount(sale.DiscountAmount)})
+	}
+	if sale.SurchargeAmount.IsPositive() {
+		rows = append(rows, registerReportRow{label: sale.SurchargeLabel, value: sale.FormatAmount(sale.SurchargeAmount)})
+	}
+	if sale.TaxAmount.IsPositive() {
+		label := "Tax"
+		if sale.TaxInclusive {
+			label = "Tax (included)"
+		}
+		rows = append(rows, registerReportRow{label: label, value: sale.FormatAmount(sale.TaxAmount)})
+	}
+	rows = append(rows, registerReportRow{label: "Total", value: sale.FormatAmount(sale.TotalAmount), heading: true})
+
+	rows = append(rows, registerReportRow{})
+	if len(sale.Payments) > 0 {
+		for _, payment := range sale.Payments {
+			rows = append(rows, registerReportRow{label: string(payment.PaymentMethod), value: sale.FormatAmount(payment.TenderedAmount)})
+		}
+	} else {
+		rows = append(rows, registerReportRow{label: string(sale.PaymentMethod), value: sale.FormatAmount(sale.PaidAmount)})
+	}
+	if sale.ChangeAmount.IsPositive() {
+		rows = append(rows, registerReportRow{label: "Change", value: sale.FormatAmount(sale.ChangeAmount)})
+	}
+
+	rows = append(rows, registerReportRow{})
+	if template.Footer != "" {
+		rows = append(rows, registerReportRow{label: template.Footer})
+	}
+	rows = append(rows, registerReportRow{label: "Thank you for your purchase!"})
+
+	return rows
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/infrastructure/monitoring"
+)
+
+// SecurityAlertService implements the SecurityAlertPort interface on top of the tenant monitor
+type SecurityAlertService struct {
+	monitor monitoring.TenantMonitor
+}
+
+// NewSecurityAlertService creates a new security alert service
+func NewSecurityAlertService(monitor monitoring.TenantMonitor) ports.SecurityAlertPort {
+	return &SecurityAlertService{monitor: monitor}
+}
+
+// RaiseSecurityAlert raises a security alert
+func (s *SecurityAlertService) RaiseSecurityAlert(ctx context.Context, alert ports.SecurityAlert) error {
+	metadata := make(map[string]interface{}, len(alert.Metadata)+1)
+	for key, value := range alert.Metadata {
+		metadata[key] = value
+	}
+	// The monitor merges open alerts about the same resource
+	metadata["resource"] = alert.Subject
+
+	return s.monitor.CreateAlert(ctx, &monitoring.Alert{
+		ID:          uuid.New(),
+		TenantID:    alert.TenantID,
+		Type:        monitoring.AlertTypeSecurity,
+		Severity:    monitoring.AlertSeverity(alert.Severity),
+		Title:       alert.Title,
+		Description: alert.Description,
+		Metadata:    metadata,
+		CreatedAt:   time.Now(),
+		Status:      monitoring.AlertStatusActive,
+	})
+}
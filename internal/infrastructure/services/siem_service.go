@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/internal/infrastructure/config"
+	"github.com/nicklaros/adol/pkg/redact"
+	"github.com/nicklaros/adol/pkg/siem"
+)
+
+// Log entries whose SIEMComponentField is SIEMComponent are written by the SIEM pipeline
+// itself. They are never shipped, so a failing SIEM cannot feed its own errors back into the
+// buffer.
+const (
+	SIEMComponentField = "component"
+	SIEMComponent      = "siem"
+)
+
+// NewSIEMShipper creates a shipper for the sink configured for this installation
+func NewSIEMShipper(cfg config.SIEMConfig, onError func(error)) (*siem.Shipper, error) {
+	var (
+		sink siem.Sink
+		err  error
+	)
+	switch cfg.Sink {
+	case "syslog":
+		sink, err = siem.NewSyslogSink(cfg.Network, cfg.Endpoint, "adol-pos", cfg.Timeout)
+	case "http":
+		headers := map[string]string{}
+		if cfg.Token != "" {
+			headers["Authorization"] = "Bearer " + cfg.Token
+		}
+		sink, err = siem.NewHTTPSink(cfg.Endpoint, headers, cfg.Timeout)
+	case "opensearch":
+		sink, err = siem.NewOpenSearchSink(cfg.Endpoint, cfg.Index, cfg.Username, cfg.Password, cfg.Timeout)
+	default:
+		err = fmt.Errorf("unsupported SIEM sink %q", cfg.Sink)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+
+	return siem.NewShipper(sink, siem.Options{
+		Host:          hostname,
+		Service:       "adol-pos",
+		BufferSize:    cfg.BufferSize,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: cfg.FlushInterval,
+		MaxRetries:    cfg.MaxRetries,
+		RetryBackoff:  cfg.RetryBackoff,
+		BlockTimeout:  cfg.BlockTimeout,
+		OnError:       onError,
+	}), nil
+}
+
+// SIEMAuditService decorates an AuditPort and ships every logged event to the SIEM
+type SIEMAuditService struct {
+	ports.AuditPort
+	shipper *siem.Shipper
+	rules   *redact.Rules
+}
+
+// NewSIEMAuditService wraps audit so logged events are also shipped to the SIEM
+func NewSIEMAuditService(audit ports.AuditPort, shipper *siem.Shipper) ports.AuditPort {
+	return &SIEMAuditService{AuditPort: audit, shipper: shipper, rules: redact.NewRules()}
+}
+
+// Log stores the event and ships it. The event is shipped even when storing it failed, in
+// which case the SIEM holds the only copy.
+func (s *SIEMAuditService) Log(ctx context.Context, event ports.AuditEvent) error {
+	err := s.AuditPort.Log(ctx, event)
+
+	fields := map[string]interface{}{
+		"audit_id":    event.ID,
+		"user_id":     event.UserID,
+		"action":      event.Action,
+		"resource":    event.Resource,
+		"resource_id": event.ResourceID,
+		"ip_address":  event.IPAddress,
+		"user_agent":  event.UserAgent,
+		"success":     event.Success,
+		"old_value":   event.OldValue,
+		"new_value":   event.NewValue,
+		"changes":     event.Changes,
+	}
+	if event.ErrorMessage != "" {
+		fields["error_message"] = event.ErrorMessage
+	}
+	if tenantID, ok := entities.TenantScopeFromContext(ctx); ok {
+		fields["tenant_id"] = tenantID
+	}
+	if err != nil {
+		fields["store_error"] = err.Error()
+	}
+
+	severity := siem.SeverityNotice
+	if !event.Success {
+		severity = siem.SeverityWarning
+	}
+
+	s.shipper.Ship(siem.Event{
+		Timestamp: event.Timestamp,
+		Type:      siem.TypeAudit,
+		Severity:  severity,
+		Message:   fmt.Sprintf("%s %s", event.Action, event.Resource),
+		Fields:    siemFields(s.rules, fields),
+	})
+
+	return err
+}
+
+// SIEMLogHook is a logrus hook that ships log entries at or above a level to the SIEM. Warnings
+// and errors carry the security-relevant signals such as failed logins and denied access.
+type SIEMLogHook struct {
+	shipper *siem.Shipper
+	levels  []logrus.Level
+	rules   *redact.Rules
+}
+
+// NewSIEMLogHook creates a hook shipping entries at minLevel and above
+func NewSIEMLogHook(shipper *siem.Shipper, minLevel string) (*SIEMLogHook, error) {
+	level, err := logrus.ParseLevel(minLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels []logrus.Level
+	for _, l := range logrus.AllLevels {
+		if l <= level {
+			levels = append(levels, l)
+		}
+	}
+
+	return &SIEMLogHook{shipper: shipper, levels: levels, rules: redact.NewRules()}, nil
+}
+
+// Levels returns the levels the hook fires for
+func (h *SIEMLogHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire ships a log entry
+func (h *SIEMLogHook) Fire(entry *logrus.Entry) error {
+	if entry.Data[SIEMComponentField] == SIEMComponent {
+		return nil
+	}
+
+	severity := siem.SeverityInfo
+	switch entry.Level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		severity = siem.SeverityCritical
+	case logrus.ErrorLevel:
+		severity = siem.SeverityError
+	case logrus.WarnLevel:
+		severity = siem.SeverityWarning
+	}
+
+	h.shipper.Ship(siem.Event{
+		Timestamp: entry.Time,
+		Type:      siem.TypeLog,
+		Severity:  severity,
+		Message:   entry.Message,
+		Fields:    siemFields(h.rules, entry.Data),
+	})
+
+	return nil
+}
+
+// siemFields returns a JSON-safe copy of fields with credentials, secrets and customer PII
+// masked. Values that cannot be encoded are shipped as text so they never fail a batch, and
+// fields holding cardholder data are dropped altogether.
+func siemFields(rules *redact.Rules, fields map[string]interface{}) map[string]interface{} {
+	safe := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		if _, err := json.Marshal(value); err != nil {
+			value = fmt.Sprint(value)
+		}
+		safe[key] = value
+	}
+
+	body, err := json.Marshal(safe)
+	if err != nil {
+		return nil
+	}
+	redacted, pci := rules.JSON(body)
+	if pci {
+		return map[string]interface{}{"redacted": "payload contained cardholder data"}
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(redacted, &out); err != nil {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// storefrontResponseLimit caps how much of a store's response body is read
+	storefrontResponseLimit = 256 * 1024
+
+	// shopifyAPIVersion is the Shopify Admin REST API version requests are made against
+	shopifyAPIVersion = "2024-07"
+)
+
+// StorefrontService implements the StorefrontPort interface over the Shopify Admin REST API
+// and the WooCommerce REST API
+type StorefrontService struct {
+	client    *http.Client
+	userAgent string
+	logger    logger.Logger
+}
+
+// NewStorefrontService creates a new storefront service. Each request is bounded by timeout
+// and redirects are not followed, so credentials are only ever sent to the configured store.
+// Stores are only called at public addresses, and never through a proxy.
+func NewStorefrontService(timeout time.Duration, userAgent string, logger logger.Logger) ports.StorefrontPort {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialPublic(&net.Dialer{Timeout: timeout})
+
+	return &StorefrontService{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		userAgent: userAgent,
+		logger:    logger,
+	}
+}
+
+// SetStock sets the stock level of a product on a store
+func (s *StorefrontService) SetStock(ctx context.Context, request ports.StorefrontStockRequest) (string, error) {
+	switch request.Platform {
+	case "shopify":
+		return s.setShopifyStock(ctx, request)
+	case "woocommerce":
+		return "", s.setWooCommerceStock(ctx, request)
+	default:
+		return "", fmt.Errorf("unsupported storefront platform %q", request.Platform)
+	}
+}
+
+// setShopifyStock sets the available quantity of a variant's inventory item at the location
+func (s *StorefrontService) setShopifyStock(ctx context.Context, request ports.StorefrontStockRequest) (string, error) {
+	headers := map[string]string{"X-Shopify-Access-Token": request.AccessToken}
+
+	inventoryItemID := request.InventoryItemID
+	if inventoryItemID == "" {
+		var variant struct {
+			Variant struct {
+				InventoryItemID json.Number `json:"inventory_item_id"`
+			} `json:"variant"`
+		}
+		endpoint := fmt.Sprintf("%s/admin/api/%s/variants/%s.json", request.StoreURL, shopifyAPIVersion, url.PathEscape(request.VariantID))
+		if err := s.do(ctx, http.MethodGet, endpoint, headers, nil, &variant); err != nil {
+			return "", fmt.Errorf("failed to get Shopify variant %s: %w", request.VariantID, err)
+		}
+		inventoryItemID = variant.Variant.InventoryItemID.String()
+		if inventoryItemID == "" {
+			return "", fmt.Errorf("Shopify variant %s has no inventory item", request.VariantID)
+		}
+	}
+
+	body := map[string]interface{}{
+		"location_id":       json.Number(request.LocationID),
+		"inventory_item_id": json.Number(inventoryItemID),
+		"available":         request.Quantity,
+	}
+	endpoint := fmt.Sprintf("%s/admin/api/%s/inventory_levels/set.json", request.StoreURL, shopifyAPIVersion)
+	if err := s.do(ctx, http.MethodPost, endpoint, headers, body, nil); err != nil {
+		return inventoryItemID, fmt.Errorf("failed to set Shopify inventory level: %w", err)
+	}
+
+	return inventoryItemID, nil
+}
+
+// setWooCommerceStock sets the stock quantity of a product or variation, managing its stock
+// from then on
+func (s *StorefrontService) setWooCommerceStock(ctx context.Context, request ports.StorefrontStockRequest) error {
+	endpoint := fmt.Sprintf("%s/wp-json/wc/v3/products/%s", request.StoreURL, url.PathEscape(request.ProductID))
+	if request.VariantID != "" {
+		endpoint += "/variations/" + url.PathEscape(request.VariantID)
+	}
+
+	body := map[string]interface{}{
+		"manage_stock":   true,
+		"stock_quantity": request.Quantity,
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte(request.ConsumerKey + ":" + request.ConsumerSecret))
+	headers := map[string]string{"Authorization": "Basic " + credentials}
+	if err := s.do(ctx, http.MethodPut, endpoint, headers, body, nil); err != nil {
+		return fmt.Errorf("failed to set WooCommerce stock quantity: %w", err)
+	}
+
+	return nil
+}
+
+// do sends a JSON request and decodes the JSON response into out when it is not nil
+func (s *StorefrontService) do(ctx context.Context, method, endpoint string, headers map[string]string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, storefrontResponseLimit))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		s.logger.WithFields(map[string]interface{}{
+			"url":    endpoint,
+			"status": resp.StatusCode,
+		}).Warn("Store rejected request")
+		return fmt.Errorf("store responded with status %d", resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(payload, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
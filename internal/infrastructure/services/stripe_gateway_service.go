@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+const (
+	// stripeAPIURL is the base URL of the Stripe API
+	stripeAPIURL = "https://api.stripe.com/v1"
+
+	// stripeSignatureTolerance is how old a signed Stripe event may be, limiting replays
+	stripeSignatureTolerance = 5 * time.Minute
+
+	// paymentGatewayResponseLimit caps how much of a gateway's response body is read
+	paymentGatewayResponseLimit = 256 * 1024
+)
+
+// stripeZeroDecimalCurrencies are charged in whole units rather than cents
+var stripeZeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true, "KRW": true, "MGA": true,
+	"PYG": true, "RWF": true, "UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// StripeGatewayService implements the PaymentGatewayPort interface over the Stripe Payment
+// Intents API
+type StripeGatewayService struct {
+	client        *http.Client
+	secretKey     string
+	webhookSecret string
+	logger        logger.Logger
+}
+
+// NewStripeGatewayService creates a new Stripe payment gateway service. Webhook events are
+// verified with the signing secret of the webhook endpoint.
+func NewStripeGatewayService(secretKey, webhookSecret string, timeout time.Duration, logger logger.Logger) ports.PaymentGatewayPort {
+	return &StripeGatewayService{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		logger:        logger,
+	}
+}
+
+// stripePaymentIntent represents the parts of a Stripe payment intent used here
+type stripePaymentIntent struct {
+	ID             string `json:"id"`
+	ClientSecret   string `json:"client_secret"`
+	Status         string `json:"status"`
+	Amount         int64  `json:"amount"`
+	AmountReceived int64  `json:"amount_received"`
+	Currency       string `json:"currency"`
+}
+
+// CreatePaymentIntent creates a payment intent the client confirms with its client secret.
+// Digital wallets such as Apple Pay and Google Pay are card payments on Stripe.
+func (s *StripeGatewayService) CreatePaymentIntent(ctx context.Context, request ports.PaymentIntentRequest) (*ports.PaymentIntent, error) {
+	amount, err := stripeMinorUnits(request.Amount, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(amount, 10))
+	form.Set("currency", strings.ToLower(request.Currency))
+	form.Set("payment_method_types[]", "card")
+	form.Set("metadata[reference]", request.Reference)
+	if request.Description != "" {
+		form.Set("description", request.Description)
+	}
+	if request.CustomerEmail != "" {
+		form.Set("receipt_email", request.CustomerEmail)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, stripeAPIURL+"/payment_intents", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Retrying with the same reference returns the intent created the first time
+	req.Header.Set("Idempotency-Key", request.Reference)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, paymentGatewayResponseLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(payload, &failure)
+		s.logger.WithFields(map[string]interface{}{
+			"reference": request.Reference,
+			"status":    resp.StatusCode,
+			"error":     failure.Error.Message,
+		}).Warn("Stripe rejected payment intent")
+		return nil, fmt.Errorf("stripe responded with status %d: %s", resp.StatusCode, failure.Error.Message)
+	}
+
+	var intent stripePaymentIntent
+	if err := json.Unmarshal(payload, &intent); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &ports.PaymentIntent{
+		ExternalID:    intent.ID,
+		ClientSecret:  intent.ClientSecret,
+		GatewayStatus: intent.Status,
+	}, nil
+}
+
+// ParseWebhook verifies the Stripe-Signature header of an event and returns the payment intent
+// update it carries
+func (s *StripeGatewayService) ParseWebhook(signature string, body []byte) (*ports.PaymentGatewayEvent, error) {
+	if err := s.verifySignature(signature, body, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var event struct {
+		Type string `json:"type"`
+		Data struct {
+			Object stripePaymentIntent `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode Stripe event: %w", err)
+	}
+
+	intent := event.Data.Object
+	amount := intent.Amount
+	var status string
+	switch event.Type {
+	case "payment_intent.succeeded":
+		status = "succeeded"
+		amount = intent.AmountReceived
+	case "payment_intent.payment_failed", "payment_intent.canceled":
+		status = "failed"
+	case "payment_intent.processing", "payment_intent.requires_action":
+		status = "pending"
+	default:
+		return nil, nil
+	}
+
+	currency := strings.ToUpper(intent.Currency)
+	return &ports.PaymentGatewayEvent{
+		ExternalID:    intent.ID,
+		Status:        status,
+		GatewayStatus: intent.Status,
+		Amount:        stripeMajorUnits(amount, currency),
+		Currency:      currency,
+	}, nil
+}
+
+// verifySignature checks a Stripe-Signature header of the form "t=<unix time>,v1=<signature>".
+// The signature is the hex HMAC-SHA256 of "<unix time>.<body>" keyed with the webhook secret.
+func (s *StripeGatewayService) verifySignature(header string, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("malformed Stripe signature header")
+	}
+	signedAt := time.Unix(seconds, 0)
+	if now.Sub(signedAt) > stripeSignatureTolerance || signedAt.Sub(now) > stripeSignatureTolerance {
+		return fmt.Errorf("stripe signature timestamp outside the tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		decoded, err := hex.DecodeString(signature)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return fmt.Errorf("stripe signature mismatch")
+}
+
+// stripeMinorUnits converts an amount into the smallest unit of its currency, e.g. cents
+func stripeMinorUnits(amount decimal.Decimal, currency string) (int64, error) {
+	if stripeZeroDecimalCurrencies[strings.ToUpper(currency)] {
+		if !amount.Equal(amount.Truncate(0)) {
+			return 0, fmt.Errorf("%s amounts cannot have decimals", strings.ToUpper(currency))
+		}
+		return amount.IntPart(), nil
+	}
+	return amount.Shift(2).Round(0).IntPart(), nil
+}
+
+// stripeMajorUnits converts an amount in the smallest unit of its currency back
+func stripeMajorUnits(amount int64, currency string) decimal.Decimal {
+	if stripeZeroDecimalCurrencies[currency] {
+		return decimal.NewFromInt(amount)
+	}
+	return decimal.New(amount, -2)
+}
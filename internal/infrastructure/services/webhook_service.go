@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/nicklaros/adol/internal/application/ports"
+	"github.com/nicklaros/adol/internal/domain/entities"
+	"github.com/nicklaros/adol/pkg/logger"
+)
+
+// webhookResponseLimit caps how much of an endpoint's response body is read before it is discarded
+const webhookResponseLimit = 64 * 1024
+
+// WebhookService implements the WebhookPort interface over HTTP
+type WebhookService struct {
+	client    *http.Client
+	userAgent string
+	logger    logger.Logger
+}
+
+// NewWebhookService creates a new webhook service. Each request is bounded by timeout and
+// redirects are not followed, so an endpoint cannot bounce deliveries to another host.
+// Deliveries are only sent to public addresses, and never through a proxy.
+func NewWebhookService(timeout time.Duration, userAgent string, logger logger.Logger) ports.WebhookPort {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialPublic(&net.Dialer{Timeout: timeout})
+
+	return &WebhookService{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		userAgent: userAgent,
+		logger:    logger,
+	}
+}
+
+// Post sends a JSON body with the given headers and returns the response status code
+func (s *WebhookService) Post(ctx context.Context, request ports.WebhookRequest) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseLimit)); err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"url":    request.URL,
+			"status": resp.StatusCode,
+			"error":  err.Error(),
+		}).Warn("Failed to read webhook response")
+	}
+
+	return resp.StatusCode, nil
+}
+
+// dialPublic returns a dial function that resolves the host itself and refuses it when any of
+// its addresses is loopback, private or link-local. The checked addresses are dialed directly,
+// so the host cannot resolve to another address between the check and the connection.
+func dialPublic(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if !entities.IsPublicWebhookAddress(addr) {
+				return nil, fmt.Errorf("host %s resolves to non-public address %s", host, addr)
+			}
+		}
+
+		err = fmt.Errorf("host %s has no addresses", host)
+		for _, addr := range addrs {
+			var conn net.Conn
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
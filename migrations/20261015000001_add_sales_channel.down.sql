@@ -0,0 +1,6 @@
+-- Rollback sales channel attribution
+
+DROP INDEX IF EXISTS idx_sales_tenant_channel;
+DROP INDEX IF EXISTS idx_sales_channel;
+
+ALTER TABLE sales DROP COLUMN IF EXISTS channel;
@@ -0,0 +1,9 @@
+-- Sales channel attribution
+-- Records where a sale originated so reports can compare channel performance
+
+ALTER TABLE sales ADD COLUMN channel VARCHAR(50) NOT NULL DEFAULT 'in_store'
+    CHECK (channel IN ('in_store', 'online', 'marketplace', 'phone_order'));
+
+-- Create indexes for channel reporting
+CREATE INDEX idx_sales_channel ON sales(channel);
+CREATE INDEX idx_sales_tenant_channel ON sales(tenant_id, channel);
@@ -0,0 +1,9 @@
+-- Rollback checkout rules
+
+DROP TRIGGER IF EXISTS update_checkout_rules_updated_at ON checkout_rules;
+DROP POLICY IF EXISTS tenant_isolation_checkout_rules ON checkout_rules;
+
+DROP INDEX IF EXISTS idx_invoices_customer_phone;
+DROP INDEX IF EXISTS idx_invoices_customer_email;
+
+DROP TABLE IF EXISTS checkout_rules;
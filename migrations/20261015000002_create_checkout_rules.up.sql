@@ -0,0 +1,32 @@
+-- Checkout rules
+-- Tenant-defined validations evaluated when a sale is completed
+
+CREATE TABLE checkout_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    conditions JSONB NOT NULL DEFAULT '[]',
+    message TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+-- Create unique constraint and indexes for checkout rules
+ALTER TABLE checkout_rules ADD CONSTRAINT uk_checkout_rules_tenant_name UNIQUE (tenant_id, name);
+CREATE INDEX idx_checkout_rules_tenant_active ON checkout_rules(tenant_id, is_active);
+
+-- Indexes used when looking up a customer's overdue invoices at checkout
+CREATE INDEX idx_invoices_customer_email ON invoices(customer_email) WHERE customer_email IS NOT NULL;
+CREATE INDEX idx_invoices_customer_phone ON invoices(customer_phone) WHERE customer_phone IS NOT NULL;
+
+-- Enable Row Level Security
+ALTER TABLE checkout_rules ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_checkout_rules ON checkout_rules
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_checkout_rules_updated_at BEFORE UPDATE ON checkout_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
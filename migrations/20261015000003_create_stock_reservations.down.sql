@@ -0,0 +1,7 @@
+-- Rollback stock reservations
+
+DROP TRIGGER IF EXISTS update_stock_reservations_updated_at ON stock_reservations;
+DROP POLICY IF EXISTS tenant_isolation_stock_reservations ON stock_reservations;
+
+DROP TABLE IF EXISTS stock_reservation_items;
+DROP TABLE IF EXISTS stock_reservations;
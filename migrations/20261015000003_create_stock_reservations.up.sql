@@ -0,0 +1,42 @@
+-- Stock reservations
+-- Stock held for external order sources (e.g. online checkout) until confirmed, released or expired
+
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    reference VARCHAR(100) NOT NULL,
+    source VARCHAR(100),
+    status VARCHAR(50) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'partially_confirmed', 'confirmed', 'released', 'expired')),
+    notes TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE TABLE stock_reservation_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reservation_id UUID NOT NULL REFERENCES stock_reservations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    confirmed_qty INTEGER NOT NULL DEFAULT 0 CHECK (confirmed_qty >= 0),
+    released_qty INTEGER NOT NULL DEFAULT 0 CHECK (released_qty >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (confirmed_qty + released_qty <= quantity)
+);
+
+-- Create indexes for stock reservations
+CREATE INDEX idx_stock_reservations_tenant_id ON stock_reservations(tenant_id);
+CREATE INDEX idx_stock_reservations_reference ON stock_reservations(reference);
+CREATE INDEX idx_stock_reservations_status_expires_at ON stock_reservations(status, expires_at);
+CREATE INDEX idx_stock_reservation_items_reservation_id ON stock_reservation_items(reservation_id);
+CREATE INDEX idx_stock_reservation_items_product_id ON stock_reservation_items(product_id);
+
+-- Enable Row Level Security
+ALTER TABLE stock_reservations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_stock_reservations ON stock_reservations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_stock_reservations_updated_at BEFORE UPDATE ON stock_reservations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
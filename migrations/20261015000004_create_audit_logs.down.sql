@@ -0,0 +1,3 @@
+-- Rollback audit logs
+
+DROP TABLE IF EXISTS audit_logs;
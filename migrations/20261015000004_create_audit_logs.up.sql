@@ -0,0 +1,23 @@
+-- Audit logs
+-- Uniform audit trail with field-level changes computed from entity snapshots
+
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    resource VARCHAR(100) NOT NULL,
+    resource_id VARCHAR(255),
+    old_value JSONB,
+    new_value JSONB,
+    changes JSONB,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    success BOOLEAN NOT NULL DEFAULT true,
+    error_message TEXT
+);
+
+-- Create indexes for audit logs
+CREATE INDEX idx_audit_logs_resource ON audit_logs(resource, resource_id, timestamp DESC);
+CREATE INDEX idx_audit_logs_user_id ON audit_logs(user_id);
+CREATE INDEX idx_audit_logs_timestamp ON audit_logs(timestamp);
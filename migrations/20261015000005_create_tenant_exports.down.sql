@@ -0,0 +1,6 @@
+-- Rollback tenant exports
+
+DROP TRIGGER IF EXISTS update_tenant_exports_updated_at ON tenant_exports;
+DROP POLICY IF EXISTS tenant_isolation_tenant_exports ON tenant_exports;
+
+DROP TABLE IF EXISTS tenant_exports;
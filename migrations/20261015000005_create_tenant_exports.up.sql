@@ -0,0 +1,31 @@
+-- Tenant exports
+-- Full-tenant data export jobs used for offboarding and data portability
+
+CREATE TABLE tenant_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    file_path VARCHAR(500),
+    file_size BIGINT NOT NULL DEFAULT 0,
+    record_counts JSONB NOT NULL DEFAULT '{}',
+    error_message TEXT,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for tenant exports
+CREATE INDEX idx_tenant_exports_tenant_id ON tenant_exports(tenant_id, created_at DESC);
+CREATE INDEX idx_tenant_exports_status ON tenant_exports(status);
+
+-- Enable Row Level Security
+ALTER TABLE tenant_exports ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tenant_exports ON tenant_exports
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_tenant_exports_updated_at BEFORE UPDATE ON tenant_exports FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,14 @@
+-- Rollback product barcodes and kiosk devices
+
+DROP TRIGGER IF EXISTS update_kiosk_devices_updated_at ON kiosk_devices;
+DROP POLICY IF EXISTS tenant_isolation_kiosk_devices ON kiosk_devices;
+
+DROP TABLE IF EXISTS kiosk_devices;
+
+DROP INDEX IF EXISTS idx_products_tenant_barcode;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS promo_ends_at,
+    DROP COLUMN IF EXISTS promo_starts_at,
+    DROP COLUMN IF EXISTS promo_price,
+    DROP COLUMN IF EXISTS barcode;
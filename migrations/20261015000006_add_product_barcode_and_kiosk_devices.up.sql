@@ -0,0 +1,36 @@
+-- Product barcodes and promotions for price-checker kiosks
+-- Kiosk devices authenticate with a long-lived device token instead of a user session
+
+ALTER TABLE products
+    ADD COLUMN barcode VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN promo_price DECIMAL(15,2) CHECK (promo_price > 0),
+    ADD COLUMN promo_starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN promo_ends_at TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX idx_products_tenant_barcode ON products(tenant_id, barcode)
+    WHERE barcode <> '' AND deleted_at IS NULL;
+
+CREATE TABLE kiosk_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+-- Create indexes for kiosk devices
+CREATE UNIQUE INDEX idx_kiosk_devices_token_hash ON kiosk_devices(token_hash);
+CREATE INDEX idx_kiosk_devices_tenant_id ON kiosk_devices(tenant_id);
+
+-- Enable Row Level Security
+ALTER TABLE kiosk_devices ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_kiosk_devices ON kiosk_devices
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_kiosk_devices_updated_at BEFORE UPDATE ON kiosk_devices FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Rollback sale item pricing details
+
+DROP INDEX IF EXISTS idx_sales_tenant_completed_at;
+
+ALTER TABLE sale_items
+    DROP COLUMN IF EXISTS override_reason,
+    DROP COLUMN IF EXISTS price_source,
+    DROP COLUMN IF EXISTS unit_cost,
+    DROP COLUMN IF EXISTS list_price;
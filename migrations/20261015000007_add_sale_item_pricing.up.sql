@@ -0,0 +1,16 @@
+-- Sale item pricing details
+-- Records list price, cost and price source at the time of sale so discounts and margin impact can be reported
+
+ALTER TABLE sale_items
+    ADD COLUMN list_price DECIMAL(15,2),
+    ADD COLUMN unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    ADD COLUMN price_source VARCHAR(20) NOT NULL DEFAULT 'list' CHECK (price_source IN ('list', 'promotion', 'override')),
+    ADD COLUMN override_reason TEXT NOT NULL DEFAULT '';
+
+-- Existing items were sold at list price
+UPDATE sale_items SET list_price = unit_price WHERE list_price IS NULL;
+
+ALTER TABLE sale_items ALTER COLUMN list_price SET NOT NULL;
+
+-- Create indexes for discount reporting
+CREATE INDEX idx_sales_tenant_completed_at ON sales(tenant_id, completed_at) WHERE status = 'completed' AND deleted_at IS NULL;
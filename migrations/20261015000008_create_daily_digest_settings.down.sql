@@ -0,0 +1,6 @@
+-- Rollback daily digest settings
+
+DROP TRIGGER IF EXISTS update_daily_digest_settings_updated_at ON daily_digest_settings;
+DROP POLICY IF EXISTS tenant_isolation_daily_digest_settings ON daily_digest_settings;
+
+DROP TABLE IF EXISTS daily_digest_settings;
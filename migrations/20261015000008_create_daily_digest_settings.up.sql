@@ -0,0 +1,26 @@
+-- Opt-in end-of-day digest email sent to tenant owners by the scheduler
+
+CREATE TABLE daily_digest_settings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL UNIQUE REFERENCES tenants(id) ON DELETE CASCADE,
+    is_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    recipients TEXT[] NOT NULL DEFAULT '{}',
+    send_hour INTEGER NOT NULL DEFAULT 21 CHECK (send_hour >= 0 AND send_hour <= 23),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    last_digest_date DATE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+-- Create indexes for daily digest settings
+CREATE INDEX idx_daily_digest_settings_enabled ON daily_digest_settings(is_enabled) WHERE is_enabled = TRUE;
+
+-- Enable Row Level Security
+ALTER TABLE daily_digest_settings ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_daily_digest_settings ON daily_digest_settings
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_daily_digest_settings_updated_at BEFORE UPDATE ON daily_digest_settings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
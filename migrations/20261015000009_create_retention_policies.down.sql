@@ -0,0 +1,10 @@
+-- Rollback retention policies and holds
+
+DROP TRIGGER IF EXISTS update_retention_policies_updated_at ON retention_policies;
+DROP POLICY IF EXISTS tenant_isolation_retention_holds ON retention_holds;
+DROP POLICY IF EXISTS tenant_isolation_retention_policies ON retention_policies;
+
+DROP INDEX IF EXISTS idx_stock_movements_created_at;
+
+DROP TABLE IF EXISTS retention_holds;
+DROP TABLE IF EXISTS retention_policies;
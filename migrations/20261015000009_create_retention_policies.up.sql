@@ -0,0 +1,46 @@
+-- Per-tenant data retention policies enforced by a scheduled purge job
+-- Retention holds exempt individual records under dispute or audit from purging
+
+CREATE TABLE retention_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    entity VARCHAR(50) NOT NULL CHECK (entity IN ('audit_logs', 'stock_movements', 'archived_sales')),
+    retention_days INTEGER NOT NULL CHECK (retention_days > 0),
+    is_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_purged_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_retention_policies_tenant_entity UNIQUE (tenant_id, entity)
+);
+
+CREATE TABLE retention_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    entity VARCHAR(50) NOT NULL CHECK (entity IN ('audit_logs', 'stock_movements', 'archived_sales')),
+    record_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id),
+    released_at TIMESTAMP WITH TIME ZONE,
+    released_by UUID REFERENCES users(id)
+);
+
+-- Create indexes for retention tables
+CREATE INDEX idx_retention_policies_enabled ON retention_policies(is_enabled) WHERE is_enabled = TRUE;
+CREATE INDEX idx_retention_holds_tenant_id ON retention_holds(tenant_id, created_at DESC);
+CREATE UNIQUE INDEX idx_retention_holds_active_record ON retention_holds(tenant_id, entity, record_id) WHERE released_at IS NULL;
+CREATE INDEX idx_stock_movements_created_at ON stock_movements(created_at);
+
+-- Enable Row Level Security
+ALTER TABLE retention_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE retention_holds ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_retention_policies ON retention_policies
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_retention_holds ON retention_holds
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_retention_policies_updated_at BEFORE UPDATE ON retention_policies FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Rollback invoice email batches and email suppressions
+
+DROP TRIGGER IF EXISTS update_invoice_email_batches_updated_at ON invoice_email_batches;
+DROP POLICY IF EXISTS tenant_isolation_email_suppressions ON email_suppressions;
+DROP POLICY IF EXISTS tenant_isolation_invoice_email_batches ON invoice_email_batches;
+
+DROP TABLE IF EXISTS email_suppressions;
+DROP TABLE IF EXISTS invoice_email_batch_items;
+DROP TABLE IF EXISTS invoice_email_batches;
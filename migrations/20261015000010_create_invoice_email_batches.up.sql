@@ -0,0 +1,56 @@
+-- Bulk invoice email batches with per-invoice delivery outcomes
+-- Email suppressions record customer addresses that opted out of email
+
+CREATE TABLE invoice_email_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed')),
+    total_count INTEGER NOT NULL DEFAULT 0,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE invoice_email_batch_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    batch_id UUID NOT NULL REFERENCES invoice_email_batches(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    invoice_number VARCHAR(255) NOT NULL,
+    recipient VARCHAR(255) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (outcome IN ('pending', 'sent', 'failed', 'skipped')),
+    reason TEXT NOT NULL DEFAULT '',
+    processed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE email_suppressions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_email_suppressions_tenant_email UNIQUE (tenant_id, email)
+);
+
+-- Create indexes for invoice email tables
+CREATE INDEX idx_invoice_email_batches_tenant_id ON invoice_email_batches(tenant_id, created_at DESC);
+CREATE UNIQUE INDEX idx_invoice_email_batch_items_position ON invoice_email_batch_items(batch_id, position);
+
+-- Enable Row Level Security
+ALTER TABLE invoice_email_batches ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_suppressions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_invoice_email_batches ON invoice_email_batches
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_email_suppressions ON email_suppressions
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_invoice_email_batches_updated_at BEFORE UPDATE ON invoice_email_batches FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,15 @@
+-- Rollback payment surcharge rules
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS surcharge_label;
+ALTER TABLE invoices DROP COLUMN IF EXISTS surcharge_tax_amount;
+ALTER TABLE invoices DROP COLUMN IF EXISTS surcharge_amount;
+
+ALTER TABLE sales DROP COLUMN IF EXISTS surcharge_label;
+ALTER TABLE sales DROP COLUMN IF EXISTS surcharge_tax_amount;
+ALTER TABLE sales DROP COLUMN IF EXISTS surcharge_amount;
+ALTER TABLE sales DROP COLUMN IF EXISTS tax_rate;
+
+DROP TRIGGER IF EXISTS update_payment_surcharge_rules_updated_at ON payment_surcharge_rules;
+DROP POLICY IF EXISTS tenant_isolation_payment_surcharge_rules ON payment_surcharge_rules;
+
+DROP TABLE IF EXISTS payment_surcharge_rules;
@@ -0,0 +1,38 @@
+-- Per-payment-method surcharge rules passed on to customers at checkout
+-- Surcharges are itemized on sales and invoices so they can be reported separately
+
+CREATE TABLE payment_surcharge_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    payment_method VARCHAR(50) NOT NULL CHECK (payment_method IN ('cash', 'card', 'digital_wallet', 'bank_transfer')),
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percentage', 'fixed')),
+    value DECIMAL(15,2) NOT NULL CHECK (value > 0),
+    min_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    max_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (max_amount >= 0),
+    label VARCHAR(100) NOT NULL,
+    is_taxable BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_payment_surcharge_rules_tenant_method UNIQUE (tenant_id, payment_method)
+);
+
+-- Itemize surcharges on sales and invoices
+ALTER TABLE sales ADD COLUMN tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0;
+ALTER TABLE sales ADD COLUMN surcharge_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE sales ADD COLUMN surcharge_tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE sales ADD COLUMN surcharge_label VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE invoices ADD COLUMN surcharge_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE invoices ADD COLUMN surcharge_tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+ALTER TABLE invoices ADD COLUMN surcharge_label VARCHAR(100) NOT NULL DEFAULT '';
+
+-- Enable Row Level Security
+ALTER TABLE payment_surcharge_rules ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_payment_surcharge_rules ON payment_surcharge_rules
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_payment_surcharge_rules_updated_at BEFORE UPDATE ON payment_surcharge_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
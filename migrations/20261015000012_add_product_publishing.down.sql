@@ -0,0 +1,12 @@
+-- Rollback product publishing workflow
+
+DROP INDEX IF EXISTS idx_products_publish_due;
+DROP INDEX IF EXISTS idx_products_publish_state;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS review_notes,
+    DROP COLUMN IF EXISTS reviewed_by,
+    DROP COLUMN IF EXISTS submitted_by,
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS publish_state;
@@ -0,0 +1,18 @@
+-- Product publishing workflow
+-- Draft products are prepared in the back office and only reach POS terminals once approved.
+-- Existing products are treated as already published.
+
+ALTER TABLE products
+    ADD COLUMN publish_state VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (publish_state IN ('draft', 'in_review', 'scheduled', 'published')),
+    ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN published_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN submitted_by UUID REFERENCES users(id),
+    ADD COLUMN reviewed_by UUID REFERENCES users(id),
+    ADD COLUMN review_notes TEXT NOT NULL DEFAULT '';
+
+UPDATE products SET published_at = created_at WHERE published_at IS NULL;
+
+CREATE INDEX idx_products_publish_state ON products(tenant_id, publish_state) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_publish_due ON products(publish_at)
+    WHERE publish_state = 'scheduled' AND deleted_at IS NULL;
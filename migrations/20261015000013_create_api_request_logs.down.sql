@@ -0,0 +1,3 @@
+-- Rollback API request logs
+
+DROP TABLE IF EXISTS api_request_logs;
@@ -0,0 +1,26 @@
+-- Sampled API request/response captures for debugging integrations
+-- Bodies are redacted before they are stored and rows expire after a short retention period.
+-- The table is platform-level (queried by request ID from the admin API), so it has no RLS policy.
+
+CREATE TABLE api_request_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id VARCHAR(64) NOT NULL,
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    status_code INTEGER NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    request_headers JSONB NOT NULL DEFAULT '{}',
+    request_body TEXT NOT NULL DEFAULT '',
+    request_body_omitted VARCHAR(20) NOT NULL DEFAULT '',
+    response_body TEXT NOT NULL DEFAULT '',
+    response_body_omitted VARCHAR(20) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_api_request_logs_request_id ON api_request_logs(request_id);
+CREATE INDEX idx_api_request_logs_tenant_id ON api_request_logs(tenant_id, created_at DESC);
+CREATE INDEX idx_api_request_logs_expires_at ON api_request_logs(expires_at);
@@ -0,0 +1,5 @@
+-- Rollback document signatures
+
+DROP POLICY IF EXISTS tenant_isolation_document_signatures ON document_signatures;
+
+DROP TABLE IF EXISTS document_signatures;
@@ -0,0 +1,26 @@
+-- Customer signatures captured against documents, e.g. delivery confirmation on invoices
+-- document_id is not a foreign key so other document types can be signed later
+
+CREATE TABLE document_signatures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_type VARCHAR(30) NOT NULL CHECK (document_type IN ('invoice')),
+    document_id UUID NOT NULL,
+    signer_name VARCHAR(100) NOT NULL,
+    signer_title VARCHAR(100) NOT NULL DEFAULT '',
+    image_data BYTEA NOT NULL,
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('image/png', 'image/jpeg')),
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    signed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    captured_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_document_signatures_document UNIQUE (document_type, document_id)
+);
+
+CREATE INDEX idx_document_signatures_tenant_id ON document_signatures(tenant_id);
+
+-- Enable Row Level Security
+ALTER TABLE document_signatures ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_document_signatures ON document_signatures
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
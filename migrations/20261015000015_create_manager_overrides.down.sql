@@ -0,0 +1,5 @@
+-- Rollback manager overrides
+
+DROP POLICY IF EXISTS tenant_isolation_manager_overrides ON manager_overrides;
+
+DROP TABLE IF EXISTS manager_overrides;
@@ -0,0 +1,26 @@
+-- One-time manager approvals for restricted actions (price overrides, refunds) entered on a
+-- cashier's terminal. Only a hash of the override token is stored.
+
+CREATE TABLE manager_overrides (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    action VARCHAR(30) NOT NULL CHECK (action IN ('price_override', 'refund')),
+    resource_id UUID,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    approved_by UUID NOT NULL REFERENCES users(id),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    reason VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_manager_overrides_distinct_users CHECK (approved_by <> requested_by)
+);
+
+CREATE INDEX idx_manager_overrides_tenant_id ON manager_overrides(tenant_id);
+CREATE INDEX idx_manager_overrides_approved_by ON manager_overrides(approved_by);
+
+-- Enable Row Level Security
+ALTER TABLE manager_overrides ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_manager_overrides ON manager_overrides
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,21 @@
+DROP POLICY IF EXISTS tenant_isolation_invoice_items ON invoice_items;
+DROP POLICY IF EXISTS tenant_isolation_sale_items ON sale_items;
+DROP POLICY IF EXISTS tenant_isolation_stock_movements ON stock_movements;
+DROP POLICY IF EXISTS tenant_isolation_stock ON stock;
+
+ALTER TABLE invoice_items DISABLE ROW LEVEL SECURITY;
+ALTER TABLE sale_items DISABLE ROW LEVEL SECURITY;
+ALTER TABLE stock_movements DISABLE ROW LEVEL SECURITY;
+ALTER TABLE stock DISABLE ROW LEVEL SECURITY;
+
+DROP TRIGGER IF EXISTS inherit_invoice_items_tenant_id ON invoice_items;
+DROP TRIGGER IF EXISTS inherit_sale_items_tenant_id ON sale_items;
+DROP TRIGGER IF EXISTS inherit_stock_movements_tenant_id ON stock_movements;
+DROP TRIGGER IF EXISTS inherit_stock_tenant_id ON stock;
+
+DROP FUNCTION IF EXISTS inherit_tenant_id();
+
+ALTER TABLE invoice_items DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE sale_items DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE stock DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,58 @@
+-- Give stock, stock movements, sale items and invoice items their own tenant_id so they can be
+-- scoped and isolated directly. The column is copied from the parent row on insert, so callers
+-- never have to supply it.
+
+ALTER TABLE stock ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+ALTER TABLE stock_movements ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+ALTER TABLE sale_items ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+ALTER TABLE invoice_items ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+
+-- Backfill existing rows from their parents
+UPDATE stock s SET tenant_id = p.tenant_id FROM products p WHERE s.product_id = p.id;
+UPDATE stock_movements sm SET tenant_id = p.tenant_id FROM products p WHERE sm.product_id = p.id;
+UPDATE sale_items si SET tenant_id = s.tenant_id FROM sales s WHERE si.sale_id = s.id;
+UPDATE invoice_items ii SET tenant_id = i.tenant_id FROM invoices i WHERE ii.invoice_id = i.id;
+
+CREATE INDEX idx_stock_tenant_id ON stock(tenant_id);
+CREATE INDEX idx_stock_movements_tenant_id ON stock_movements(tenant_id);
+CREATE INDEX idx_sale_items_tenant_id ON sale_items(tenant_id);
+CREATE INDEX idx_invoice_items_tenant_id ON invoice_items(tenant_id);
+
+-- Copy tenant_id from the parent row. Arguments: parent table, foreign key column.
+CREATE OR REPLACE FUNCTION inherit_tenant_id()
+RETURNS TRIGGER AS $$
+DECLARE
+    parent_id UUID;
+BEGIN
+    IF NEW.tenant_id IS NULL THEN
+        EXECUTE format('SELECT ($1).%I', TG_ARGV[1]) INTO parent_id USING NEW;
+        EXECUTE format('SELECT tenant_id FROM %I WHERE id = $1', TG_ARGV[0])
+            INTO NEW.tenant_id USING parent_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER inherit_stock_tenant_id BEFORE INSERT ON stock
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('products', 'product_id');
+CREATE TRIGGER inherit_stock_movements_tenant_id BEFORE INSERT ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('products', 'product_id');
+CREATE TRIGGER inherit_sale_items_tenant_id BEFORE INSERT ON sale_items
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('sales', 'sale_id');
+CREATE TRIGGER inherit_invoice_items_tenant_id BEFORE INSERT ON invoice_items
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('invoices', 'invoice_id');
+
+-- Enable Row Level Security
+ALTER TABLE stock ENABLE ROW LEVEL SECURITY;
+ALTER TABLE stock_movements ENABLE ROW LEVEL SECURITY;
+ALTER TABLE sale_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invoice_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_stock ON stock
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_stock_movements ON stock_movements
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_sale_items ON sale_items
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_invoice_items ON invoice_items
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
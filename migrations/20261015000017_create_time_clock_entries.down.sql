@@ -0,0 +1,5 @@
+-- Rollback time clock entries
+
+DROP POLICY IF EXISTS tenant_isolation_time_clock_entries ON time_clock_entries;
+
+DROP TABLE IF EXISTS time_clock_entries;
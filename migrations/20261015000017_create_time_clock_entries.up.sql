@@ -0,0 +1,27 @@
+-- Staff attendance: one row per shift, clocked in and out from the POS. Breaks are stored
+-- inline since they are only ever read and written together with their shift.
+
+CREATE TABLE time_clock_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    device_id UUID REFERENCES kiosk_devices(id) ON DELETE SET NULL,
+    clock_in_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    clock_out_at TIMESTAMP WITH TIME ZONE,
+    breaks JSONB NOT NULL DEFAULT '[]',
+    notes VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_time_clock_entries_clock_out CHECK (clock_out_at IS NULL OR clock_out_at >= clock_in_at)
+);
+
+CREATE INDEX idx_time_clock_entries_tenant_clock_in ON time_clock_entries(tenant_id, clock_in_at);
+CREATE INDEX idx_time_clock_entries_user_id ON time_clock_entries(user_id);
+-- A staff member can only be clocked in to one shift at a time
+CREATE UNIQUE INDEX uk_time_clock_entries_open_shift ON time_clock_entries(tenant_id, user_id) WHERE clock_out_at IS NULL;
+
+-- Enable Row Level Security
+ALTER TABLE time_clock_entries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_time_clock_entries ON time_clock_entries
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,7 @@
+-- Rollback refunds
+
+DROP POLICY IF EXISTS tenant_isolation_refund_items ON refund_items;
+DROP POLICY IF EXISTS tenant_isolation_refunds ON refunds;
+
+DROP TABLE IF EXISTS refund_items;
+DROP TABLE IF EXISTS refunds;
@@ -0,0 +1,51 @@
+-- Refunds of completed sales. A sale can be refunded in several partial refunds; the sale
+-- is marked refunded once every item has been returned.
+
+CREATE TABLE refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    sale_id UUID NOT NULL REFERENCES sales(id),
+    refund_number VARCHAR(50) NOT NULL,
+    subtotal DECIMAL(15,2) NOT NULL CHECK (subtotal > 0),
+    discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+    total_amount DECIMAL(15,2) NOT NULL CHECK (total_amount >= 0),
+    refund_method VARCHAR(50) NOT NULL CHECK (refund_method IN ('cash', 'card', 'digital_wallet', 'bank_transfer')),
+    reason VARCHAR(255) NOT NULL,
+    approved_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE TABLE refund_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    refund_id UUID NOT NULL REFERENCES refunds(id) ON DELETE CASCADE,
+    sale_item_id UUID NOT NULL REFERENCES sale_items(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    product_sku VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(15,2) NOT NULL CHECK (unit_price > 0),
+    total_price DECIMAL(15,2) NOT NULL CHECK (total_price > 0),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- Create indexes for refunds
+CREATE UNIQUE INDEX uk_refunds_tenant_refund_number ON refunds(tenant_id, refund_number);
+CREATE INDEX idx_refunds_tenant_created_at ON refunds(tenant_id, created_at);
+CREATE INDEX idx_refunds_sale_id ON refunds(sale_id);
+CREATE INDEX idx_refund_items_refund_id ON refund_items(refund_id);
+CREATE INDEX idx_refund_items_sale_item_id ON refund_items(sale_item_id);
+CREATE INDEX idx_refund_items_tenant_id ON refund_items(tenant_id);
+
+CREATE TRIGGER inherit_refund_items_tenant_id BEFORE INSERT ON refund_items
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('refunds', 'refund_id');
+
+-- Enable Row Level Security
+ALTER TABLE refunds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE refund_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_refunds ON refunds
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_refund_items ON refund_items
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
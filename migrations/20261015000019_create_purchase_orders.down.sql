@@ -0,0 +1,8 @@
+-- Rollback purchase orders
+
+DROP TRIGGER IF EXISTS update_purchase_orders_updated_at ON purchase_orders;
+DROP POLICY IF EXISTS tenant_isolation_purchase_order_items ON purchase_order_items;
+DROP POLICY IF EXISTS tenant_isolation_purchase_orders ON purchase_orders;
+
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
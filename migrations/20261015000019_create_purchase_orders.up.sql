@@ -0,0 +1,56 @@
+-- Purchase orders for replenishing stock from suppliers. Receiving a purchase order adds the
+-- delivered quantities to stock with a 'purchase' stock movement referencing the PO number.
+
+CREATE TABLE purchase_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    po_number VARCHAR(50) NOT NULL,
+    supplier_name VARCHAR(255) NOT NULL,
+    supplier_email VARCHAR(255),
+    supplier_phone VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'ordered', 'received', 'cancelled')),
+    total_cost DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (total_cost >= 0),
+    notes TEXT,
+    expected_at TIMESTAMP WITH TIME ZONE,
+    ordered_at TIMESTAMP WITH TIME ZONE,
+    received_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE TABLE purchase_order_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    product_sku VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    received_qty INTEGER NOT NULL DEFAULT 0 CHECK (received_qty >= 0 AND received_qty <= quantity),
+    unit_cost DECIMAL(15,2) NOT NULL CHECK (unit_cost >= 0),
+    total_cost DECIMAL(15,2) NOT NULL CHECK (total_cost >= 0),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- Create indexes for purchase orders
+CREATE UNIQUE INDEX uk_purchase_orders_tenant_po_number ON purchase_orders(tenant_id, po_number);
+CREATE INDEX idx_purchase_orders_tenant_status ON purchase_orders(tenant_id, status);
+CREATE INDEX idx_purchase_orders_tenant_created_at ON purchase_orders(tenant_id, created_at);
+CREATE INDEX idx_purchase_order_items_purchase_order_id ON purchase_order_items(purchase_order_id);
+CREATE INDEX idx_purchase_order_items_product_id ON purchase_order_items(product_id);
+CREATE INDEX idx_purchase_order_items_tenant_id ON purchase_order_items(tenant_id);
+
+CREATE TRIGGER update_purchase_orders_updated_at BEFORE UPDATE ON purchase_orders FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER inherit_purchase_order_items_tenant_id BEFORE INSERT ON purchase_order_items
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('purchase_orders', 'purchase_order_id');
+
+-- Enable Row Level Security
+ALTER TABLE purchase_orders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE purchase_order_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_purchase_orders ON purchase_orders
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_purchase_order_items ON purchase_order_items
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,11 @@
+-- Rollback warehouse exports
+
+DROP TRIGGER IF EXISTS update_warehouse_export_settings_updated_at ON warehouse_export_settings;
+DROP POLICY IF EXISTS tenant_isolation_warehouse_export_files ON warehouse_export_files;
+DROP POLICY IF EXISTS tenant_isolation_warehouse_export_settings ON warehouse_export_settings;
+
+DROP INDEX IF EXISTS idx_stock_movements_tenant_created_at;
+DROP INDEX IF EXISTS idx_sales_tenant_updated_at;
+
+DROP TABLE IF EXISTS warehouse_export_files;
+DROP TABLE IF EXISTS warehouse_export_settings;
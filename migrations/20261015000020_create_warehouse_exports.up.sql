@@ -0,0 +1,48 @@
+-- Scheduled Parquet export of sales, sale items, payments and stock movements to the
+-- platform's S3-compatible bucket, partitioned per tenant so analysts can query it directly
+
+CREATE TABLE warehouse_export_settings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL UNIQUE REFERENCES tenants(id) ON DELETE CASCADE,
+    is_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    exported_through TIMESTAMP WITH TIME ZONE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE TABLE warehouse_export_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    dataset VARCHAR(50) NOT NULL CHECK (dataset IN ('sales', 'sale_items', 'payments', 'stock_movements')),
+    partition_date DATE NOT NULL,
+    object_key TEXT NOT NULL,
+    row_count INTEGER NOT NULL CHECK (row_count >= 0),
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for warehouse exports
+CREATE INDEX idx_warehouse_export_settings_enabled ON warehouse_export_settings(is_enabled) WHERE is_enabled = TRUE;
+CREATE UNIQUE INDEX uk_warehouse_export_files_tenant_object_key ON warehouse_export_files(tenant_id, object_key);
+CREATE INDEX idx_warehouse_export_files_tenant_dataset_date ON warehouse_export_files(tenant_id, dataset, partition_date);
+
+-- Incremental extraction reads changes by time range
+CREATE INDEX idx_sales_tenant_updated_at ON sales(tenant_id, updated_at);
+CREATE INDEX idx_stock_movements_tenant_created_at ON stock_movements(tenant_id, created_at);
+
+-- Enable Row Level Security
+ALTER TABLE warehouse_export_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE warehouse_export_files ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_warehouse_export_settings ON warehouse_export_settings
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_warehouse_export_files ON warehouse_export_files
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_warehouse_export_settings_updated_at BEFORE UPDATE ON warehouse_export_settings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Rollback suppliers
+
+DROP INDEX IF EXISTS idx_products_supplier_id;
+ALTER TABLE products DROP COLUMN IF EXISTS supplier_id;
+
+DROP TRIGGER IF EXISTS update_suppliers_updated_at ON suppliers;
+DROP POLICY IF EXISTS tenant_isolation_suppliers ON suppliers;
+
+DROP TABLE IF EXISTS suppliers;
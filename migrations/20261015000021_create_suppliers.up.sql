@@ -0,0 +1,34 @@
+-- Supplier directory. Products can name a preferred supplier so the low-stock report can
+-- suggest where to reorder from. Purchase orders keep their own copy of the supplier details.
+
+CREATE TABLE suppliers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    contact_name VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    address TEXT,
+    payment_terms_days INTEGER NOT NULL DEFAULT 0 CHECK (payment_terms_days >= 0 AND payment_terms_days <= 365),
+    lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0 AND lead_time_days <= 365),
+    notes TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+ALTER TABLE products ADD COLUMN supplier_id UUID REFERENCES suppliers(id) ON DELETE SET NULL;
+
+-- Create indexes for suppliers
+CREATE UNIQUE INDEX uk_suppliers_tenant_name ON suppliers(tenant_id, LOWER(name));
+CREATE INDEX idx_suppliers_tenant_active ON suppliers(tenant_id, is_active);
+CREATE INDEX idx_products_supplier_id ON products(supplier_id) WHERE supplier_id IS NOT NULL;
+
+CREATE TRIGGER update_suppliers_updated_at BEFORE UPDATE ON suppliers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Enable Row Level Security
+ALTER TABLE suppliers ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_suppliers ON suppliers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,15 @@
+-- Rollback held sales
+
+DROP INDEX IF EXISTS idx_sales_hold_expires_at;
+
+UPDATE sales SET status = 'cancelled' WHERE status = 'held';
+
+ALTER TABLE sales DROP CONSTRAINT IF EXISTS sales_status_check;
+ALTER TABLE sales ADD CONSTRAINT sales_status_check
+    CHECK (status IN ('pending', 'completed', 'cancelled', 'refunded'));
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS hold_label,
+    DROP COLUMN IF EXISTS hold_expires_at,
+    DROP COLUMN IF EXISTS held_by,
+    DROP COLUMN IF EXISTS held_at;
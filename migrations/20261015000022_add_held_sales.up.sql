@@ -0,0 +1,16 @@
+-- Held sales
+-- Cashiers can park a pending sale and resume it later on another terminal. Stock for a held
+-- sale is reserved until it is resumed, cancelled or its hold expires.
+
+ALTER TABLE sales DROP CONSTRAINT sales_status_check;
+ALTER TABLE sales ADD CONSTRAINT sales_status_check
+    CHECK (status IN ('pending', 'completed', 'cancelled', 'refunded', 'held'));
+
+ALTER TABLE sales
+    ADD COLUMN held_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN held_by UUID REFERENCES users(id),
+    ADD COLUMN hold_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN hold_label VARCHAR(100);
+
+-- Create indexes for the hold expiry job
+CREATE INDEX idx_sales_hold_expires_at ON sales(hold_expires_at) WHERE status = 'held';
@@ -0,0 +1,3 @@
+-- Rollback sale payment lines
+
+DROP TABLE IF EXISTS sale_payments;
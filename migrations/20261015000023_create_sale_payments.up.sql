@@ -0,0 +1,33 @@
+-- Sale payment lines. A sale can be settled with several payment methods, e.g. part cash and
+-- part card; the line amounts add up to the sale total. sales.payment_method keeps the method
+-- that paid the largest part.
+
+CREATE TABLE sale_payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sale_id UUID NOT NULL REFERENCES sales(id) ON DELETE CASCADE,
+    payment_method VARCHAR(50) NOT NULL CHECK (payment_method IN ('cash', 'card', 'digital_wallet', 'bank_transfer')),
+    amount DECIMAL(15,2) NOT NULL CHECK (amount >= 0),
+    tendered_amount DECIMAL(15,2) NOT NULL CHECK (tendered_amount >= 0),
+    surcharge_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (surcharge_amount >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- Backfill a single payment line for sales paid before split payments
+INSERT INTO sale_payments (sale_id, payment_method, amount, tendered_amount, surcharge_amount, created_at, tenant_id)
+SELECT id, payment_method, total_amount, paid_amount, surcharge_amount, COALESCE(completed_at, updated_at), tenant_id
+FROM sales
+WHERE payment_method IS NOT NULL AND status IN ('completed', 'refunded');
+
+-- Create indexes for sale payments
+CREATE INDEX idx_sale_payments_sale_id ON sale_payments(sale_id);
+CREATE INDEX idx_sale_payments_tenant_method ON sale_payments(tenant_id, payment_method);
+
+CREATE TRIGGER inherit_sale_payments_tenant_id BEFORE INSERT ON sale_payments
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('sales', 'sale_id');
+
+-- Enable Row Level Security
+ALTER TABLE sale_payments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_sale_payments ON sale_payments
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,9 @@
+-- Rollback totals recalculations
+
+DROP TRIGGER IF EXISTS update_totals_recalculations_updated_at ON totals_recalculations;
+DROP TRIGGER IF EXISTS inherit_totals_corrections_tenant_id ON totals_corrections;
+DROP POLICY IF EXISTS tenant_isolation_totals_corrections ON totals_corrections;
+DROP POLICY IF EXISTS tenant_isolation_totals_recalculations ON totals_recalculations;
+
+DROP TABLE IF EXISTS totals_corrections;
+DROP TABLE IF EXISTS totals_recalculations;
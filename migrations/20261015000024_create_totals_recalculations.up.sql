@@ -0,0 +1,60 @@
+-- Totals recalculations
+-- Maintenance runs that recompute stored sale and invoice totals, previewed as a dry run before
+-- any correction is applied
+
+CREATE TABLE totals_recalculations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_type VARCHAR(20) NOT NULL CHECK (document_type IN ('sale', 'invoice')),
+    reason VARCHAR(500) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'previewed' CHECK (status IN ('previewed', 'applied')),
+    scanned_count INTEGER NOT NULL DEFAULT 0,
+    changed_count INTEGER NOT NULL DEFAULT 0,
+    applied_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    applied_by UUID REFERENCES users(id),
+    applied_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE totals_corrections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recalculation_id UUID NOT NULL REFERENCES totals_recalculations(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    document_id UUID NOT NULL,
+    document_number VARCHAR(255) NOT NULL,
+    subtotal_before DECIMAL(15,2) NOT NULL,
+    tax_amount_before DECIMAL(15,2) NOT NULL,
+    total_amount_before DECIMAL(15,2) NOT NULL,
+    subtotal_after DECIMAL(15,2) NOT NULL,
+    tax_amount_after DECIMAL(15,2) NOT NULL,
+    total_amount_after DECIMAL(15,2) NOT NULL,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (outcome IN ('pending', 'applied', 'skipped', 'failed')),
+    reason TEXT NOT NULL DEFAULT '',
+    processed_at TIMESTAMP WITH TIME ZONE,
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- Create indexes for totals recalculations
+CREATE INDEX idx_totals_recalculations_tenant_id ON totals_recalculations(tenant_id, created_at DESC);
+CREATE UNIQUE INDEX idx_totals_corrections_position ON totals_corrections(recalculation_id, position);
+CREATE INDEX idx_totals_corrections_document_id ON totals_corrections(document_id);
+
+CREATE TRIGGER inherit_totals_corrections_tenant_id BEFORE INSERT ON totals_corrections
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('totals_recalculations', 'recalculation_id');
+
+-- Enable Row Level Security
+ALTER TABLE totals_recalculations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE totals_corrections ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_totals_recalculations ON totals_recalculations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_totals_corrections ON totals_corrections
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_totals_recalculations_updated_at BEFORE UPDATE ON totals_recalculations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,14 @@
+-- Rollback product availability windows
+
+DELETE FROM manager_overrides WHERE action = 'sell_unavailable';
+ALTER TABLE manager_overrides DROP CONSTRAINT IF EXISTS manager_overrides_action_check;
+ALTER TABLE manager_overrides ADD CONSTRAINT manager_overrides_action_check
+    CHECK (action IN ('price_override', 'refund'));
+
+DROP INDEX IF EXISTS idx_products_availability;
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS chk_products_availability_window,
+    DROP COLUMN IF EXISTS is_seasonal,
+    DROP COLUMN IF EXISTS available_until,
+    DROP COLUMN IF EXISTS available_from;
@@ -0,0 +1,18 @@
+-- Product availability windows
+-- Products can be limited to a sale period. Seasonal products repeat their window every year;
+-- the scheduler deactivates them once the window closes and reactivates them when it reopens.
+
+ALTER TABLE products
+    ADD COLUMN available_from TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN available_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN is_seasonal BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT chk_products_availability_window
+        CHECK (available_from IS NULL OR available_until IS NULL OR available_until > available_from);
+
+CREATE INDEX idx_products_availability ON products(tenant_id)
+    WHERE (available_from IS NOT NULL OR available_until IS NOT NULL) AND deleted_at IS NULL;
+
+-- Cashiers need a manager override to sell a product outside its availability window
+ALTER TABLE manager_overrides DROP CONSTRAINT IF EXISTS manager_overrides_action_check;
+ALTER TABLE manager_overrides ADD CONSTRAINT manager_overrides_action_check
+    CHECK (action IN ('price_override', 'refund', 'sell_unavailable'));
@@ -0,0 +1,10 @@
+-- Rollback margin floors
+
+DROP TRIGGER IF EXISTS update_margin_alert_settings_updated_at ON margin_alert_settings;
+DROP TRIGGER IF EXISTS update_margin_floors_updated_at ON margin_floors;
+DROP POLICY IF EXISTS tenant_isolation_margin_alert_settings ON margin_alert_settings;
+DROP POLICY IF EXISTS tenant_isolation_margin_violations ON margin_violations;
+DROP POLICY IF EXISTS tenant_isolation_margin_floors ON margin_floors;
+DROP TABLE IF EXISTS margin_alert_settings;
+DROP TABLE IF EXISTS margin_violations;
+DROP TABLE IF EXISTS margin_floors;
@@ -0,0 +1,64 @@
+-- Per-category margin floors
+-- Prices below a category's floor are still accepted; the cashier is warned, the violation is
+-- recorded for the weekly report and the owner can be alerted by email.
+
+CREATE TABLE margin_floors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    category VARCHAR(100) NOT NULL,
+    min_margin_percent DECIMAL(5,2) NOT NULL CHECK (min_margin_percent >= 0 AND min_margin_percent < 100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE UNIQUE INDEX idx_margin_floors_tenant_category ON margin_floors(tenant_id, LOWER(category));
+
+CREATE TABLE margin_violations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('sale_item', 'price_override', 'sale_discount', 'price_change')),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    product_sku VARCHAR(100) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    sale_id UUID REFERENCES sales(id) ON DELETE SET NULL,
+    unit_price DECIMAL(15,2) NOT NULL,
+    unit_cost DECIMAL(15,2) NOT NULL,
+    margin_percent DECIMAL(7,2) NOT NULL,
+    floor_percent DECIMAL(5,2) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_margin_violations_tenant_created ON margin_violations(tenant_id, created_at DESC);
+
+CREATE TABLE margin_alert_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    recipients TEXT[] NOT NULL DEFAULT '{}',
+    alert_on_violation BOOLEAN NOT NULL DEFAULT FALSE,
+    weekly_report BOOLEAN NOT NULL DEFAULT FALSE,
+    last_report_week DATE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE INDEX idx_margin_alert_settings_weekly ON margin_alert_settings(weekly_report) WHERE weekly_report = TRUE;
+
+-- Enable Row Level Security
+ALTER TABLE margin_floors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE margin_violations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE margin_alert_settings ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_margin_floors ON margin_floors
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_margin_violations ON margin_violations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_margin_alert_settings ON margin_alert_settings
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at triggers
+CREATE TRIGGER update_margin_floors_updated_at BEFORE UPDATE ON margin_floors FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_margin_alert_settings_updated_at BEFORE UPDATE ON margin_alert_settings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
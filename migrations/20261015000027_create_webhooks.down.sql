@@ -0,0 +1,8 @@
+-- Rollback outbound webhooks
+
+DROP TRIGGER IF EXISTS update_webhook_deliveries_updated_at ON webhook_deliveries;
+DROP TRIGGER IF EXISTS update_webhook_endpoints_updated_at ON webhook_endpoints;
+DROP POLICY IF EXISTS tenant_isolation_webhook_deliveries ON webhook_deliveries;
+DROP POLICY IF EXISTS tenant_isolation_webhook_endpoints ON webhook_endpoints;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
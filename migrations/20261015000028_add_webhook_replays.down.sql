@@ -0,0 +1,7 @@
+-- Rollback webhook replays
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_pending_replay;
+DROP INDEX IF EXISTS idx_webhook_deliveries_replay_of;
+DELETE FROM webhook_deliveries WHERE replay_of IS NOT NULL;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS replay_of;
+ALTER TABLE webhook_deliveries ALTER COLUMN payload TYPE JSONB USING payload::JSONB;
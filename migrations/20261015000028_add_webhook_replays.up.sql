@@ -0,0 +1,13 @@
+-- Webhook replays
+-- A replay is a new delivery that re-sends an earlier delivery's payload. It points at the
+-- original delivery, whose ID consumers use to discard duplicates. Payloads are stored as
+-- text so the exact bytes that were signed and sent can be inspected.
+
+ALTER TABLE webhook_deliveries ALTER COLUMN payload TYPE TEXT USING payload::TEXT;
+
+ALTER TABLE webhook_deliveries ADD COLUMN replay_of UUID REFERENCES webhook_deliveries(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_webhook_deliveries_replay_of ON webhook_deliveries(replay_of) WHERE replay_of IS NOT NULL;
+
+-- At most one replay of a delivery can be waiting to be sent
+CREATE UNIQUE INDEX idx_webhook_deliveries_pending_replay ON webhook_deliveries(replay_of) WHERE status = 'pending';
@@ -0,0 +1,10 @@
+-- Rollback inventory cost adjustments
+
+DELETE FROM warehouse_export_files WHERE dataset = 'inventory_cost_adjustments';
+
+ALTER TABLE warehouse_export_files DROP CONSTRAINT IF EXISTS warehouse_export_files_dataset_check;
+ALTER TABLE warehouse_export_files ADD CONSTRAINT warehouse_export_files_dataset_check
+    CHECK (dataset IN ('sales', 'sale_items', 'payments', 'stock_movements'));
+
+DROP POLICY IF EXISTS tenant_isolation_inventory_cost_adjustments ON inventory_cost_adjustments;
+DROP TABLE IF EXISTS inventory_cost_adjustments;
@@ -0,0 +1,34 @@
+-- Inventory cost adjustment journal
+-- Write-downs of damaged or obsolete stock lower the product's average cost without changing
+-- quantities. Each entry records the change in inventory value for the valuation report and
+-- the warehouse export; entries are never updated.
+
+CREATE TABLE inventory_cost_adjustments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damaged', 'obsolete', 'expired', 'market_value', 'other')),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    on_hand_qty INTEGER NOT NULL CHECK (on_hand_qty >= quantity),
+    previous_unit_cost DECIMAL(15,2) NOT NULL CHECK (previous_unit_cost >= 0),
+    adjusted_unit_cost DECIMAL(15,2) NOT NULL CHECK (adjusted_unit_cost >= 0),
+    resulting_unit_cost DECIMAL(15,2) NOT NULL CHECK (resulting_unit_cost >= 0),
+    amount DECIMAL(15,2) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE INDEX idx_inventory_cost_adjustments_tenant_created ON inventory_cost_adjustments(tenant_id, created_at DESC);
+CREATE INDEX idx_inventory_cost_adjustments_product ON inventory_cost_adjustments(product_id, created_at DESC);
+
+-- Enable Row Level Security
+ALTER TABLE inventory_cost_adjustments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_inventory_cost_adjustments ON inventory_cost_adjustments
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Write-downs are exported to the tenant's data warehouse
+ALTER TABLE warehouse_export_files DROP CONSTRAINT warehouse_export_files_dataset_check;
+ALTER TABLE warehouse_export_files ADD CONSTRAINT warehouse_export_files_dataset_check
+    CHECK (dataset IN ('sales', 'sale_items', 'payments', 'stock_movements', 'inventory_cost_adjustments'));
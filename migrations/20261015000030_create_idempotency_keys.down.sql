@@ -0,0 +1,4 @@
+-- Rollback idempotency keys
+
+DROP POLICY IF EXISTS tenant_isolation_idempotency_keys ON idempotency_keys;
+DROP TABLE IF EXISTS idempotency_keys;
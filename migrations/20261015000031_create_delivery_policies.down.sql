@@ -0,0 +1,11 @@
+-- Rollback delivery policies
+
+DROP TRIGGER IF EXISTS update_delivery_policies_updated_at ON delivery_policies;
+DROP POLICY IF EXISTS tenant_isolation_email_delivery_failures ON email_delivery_failures;
+DROP POLICY IF EXISTS tenant_isolation_delivery_policies ON delivery_policies;
+ALTER TABLE webhook_endpoints
+    DROP COLUMN IF EXISTS pause_reason,
+    DROP COLUMN IF EXISTS paused_at,
+    DROP COLUMN IF EXISTS consecutive_failures;
+DROP TABLE IF EXISTS email_delivery_failures;
+DROP TABLE IF EXISTS delivery_policies;
@@ -0,0 +1,50 @@
+-- Per-tenant retry policies for outbound webhooks and emails
+-- Destinations that fail too many times in a row are paused: webhook endpoints are
+-- deactivated and email addresses are added to the tenant's suppression list.
+
+CREATE TABLE delivery_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL UNIQUE REFERENCES tenants(id) ON DELETE CASCADE,
+    webhook_max_attempts INTEGER NOT NULL CHECK (webhook_max_attempts > 0),
+    webhook_initial_backoff_seconds INTEGER NOT NULL CHECK (webhook_initial_backoff_seconds > 0),
+    webhook_max_backoff_seconds INTEGER NOT NULL CHECK (webhook_max_backoff_seconds >= webhook_initial_backoff_seconds),
+    webhook_timeout_seconds INTEGER NOT NULL CHECK (webhook_timeout_seconds > 0),
+    webhook_pause_after_failures INTEGER NOT NULL CHECK (webhook_pause_after_failures >= 0),
+    email_max_attempts INTEGER NOT NULL CHECK (email_max_attempts > 0),
+    email_initial_backoff_seconds INTEGER NOT NULL CHECK (email_initial_backoff_seconds > 0),
+    email_max_backoff_seconds INTEGER NOT NULL CHECK (email_max_backoff_seconds >= email_initial_backoff_seconds),
+    email_timeout_seconds INTEGER NOT NULL CHECK (email_timeout_seconds > 0),
+    email_pause_after_failures INTEGER NOT NULL CHECK (email_pause_after_failures >= 0),
+    alert_recipients TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+-- Consecutive failed emails per recipient; the row is removed after a successful email
+CREATE TABLE email_delivery_failures (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant_id, email)
+);
+
+ALTER TABLE webhook_endpoints
+    ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN paused_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN pause_reason VARCHAR(255);
+
+-- Enable Row Level Security
+ALTER TABLE delivery_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_delivery_failures ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_delivery_policies ON delivery_policies
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_email_delivery_failures ON email_delivery_failures
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_delivery_policies_updated_at BEFORE UPDATE ON delivery_policies FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback invoice reminders
+
+DROP POLICY IF EXISTS tenant_isolation_invoice_reminders ON invoice_reminders;
+DROP TABLE IF EXISTS invoice_reminders;
@@ -0,0 +1,22 @@
+-- Log of the payment reminders emailed for unpaid invoices. The unique key keeps an invoice
+-- from getting the same reminder twice for a due date, even with several server instances.
+
+CREATE TABLE invoice_reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('due_soon', 'overdue')),
+    sequence INTEGER NOT NULL CHECK (sequence > 0),
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_invoice_reminders_invoice_kind UNIQUE (invoice_id, kind, sequence, due_date)
+);
+
+CREATE INDEX idx_invoice_reminders_tenant_sent_at ON invoice_reminders(tenant_id, sent_at);
+
+-- Enable Row Level Security
+ALTER TABLE invoice_reminders ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_invoice_reminders ON invoice_reminders
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,5 @@
+-- Rollback email outbox
+
+DROP TRIGGER IF EXISTS update_email_outbox_updated_at ON email_outbox;
+DROP POLICY IF EXISTS tenant_isolation_email_outbox ON email_outbox;
+DROP TABLE IF EXISTS email_outbox;
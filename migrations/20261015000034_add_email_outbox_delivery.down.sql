@@ -0,0 +1,3 @@
+-- Rollback email outbox delivery
+
+ALTER TABLE email_outbox DROP COLUMN IF EXISTS delivery;
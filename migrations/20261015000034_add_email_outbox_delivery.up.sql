@@ -0,0 +1,5 @@
+-- Email outbox delivery
+-- Records whether the invoice PDF was attached to an email or, being too large to attach,
+-- replaced by a signed download link to the invoice portal.
+
+ALTER TABLE email_outbox ADD COLUMN delivery VARCHAR(20) CHECK (delivery IN ('attachment', 'link'));
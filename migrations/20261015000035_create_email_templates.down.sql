@@ -0,0 +1,4 @@
+-- Rollback email templates
+
+DROP POLICY IF EXISTS tenant_isolation_email_templates ON email_templates;
+DROP TABLE IF EXISTS email_templates;
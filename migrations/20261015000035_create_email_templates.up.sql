@@ -0,0 +1,24 @@
+-- Tenant overrides of the built-in customer email templates. Subjects and text bodies are
+-- text/template templates, HTML bodies html/template templates.
+
+CREATE TABLE email_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice')),
+    subject VARCHAR(255) NOT NULL,
+    html_body TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_email_templates_tenant_kind UNIQUE (tenant_id, kind)
+);
+
+-- Enable Row Level Security
+ALTER TABLE email_templates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_email_templates ON email_templates
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_email_templates_updated_at BEFORE UPDATE ON email_templates FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
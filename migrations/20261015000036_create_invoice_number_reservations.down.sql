@@ -0,0 +1,6 @@
+-- Rollback invoice number reservations
+
+DROP POLICY IF EXISTS tenant_isolation_invoice_number_reservations ON invoice_number_reservations;
+DROP POLICY IF EXISTS tenant_isolation_invoice_number_sequences ON invoice_number_sequences;
+DROP TABLE IF EXISTS invoice_number_reservations;
+DROP TABLE IF EXISTS invoice_number_sequences;
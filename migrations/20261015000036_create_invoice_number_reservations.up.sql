@@ -0,0 +1,48 @@
+-- Invoice numbers reserved for invoices issued by external systems. Each tenant has a gapless
+-- sequence; a reserved number is later bound to an invoice or voided, never reused.
+
+CREATE TABLE invoice_number_sequences (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    last_number BIGINT NOT NULL DEFAULT 0 CHECK (last_number >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE invoice_number_reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    sequence_number BIGINT NOT NULL CHECK (sequence_number > 0),
+    invoice_number VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'reserved' CHECK (status IN ('reserved', 'bound', 'voided')),
+    reason VARCHAR(500) NOT NULL,
+    external_reference VARCHAR(100),
+    invoice_id UUID REFERENCES invoices(id),
+    void_reason VARCHAR(500),
+    reserved_by UUID NOT NULL REFERENCES users(id),
+    bound_by UUID REFERENCES users(id),
+    voided_by UUID REFERENCES users(id),
+    bound_at TIMESTAMP WITH TIME ZONE,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_invoice_number_reservations_tenant_sequence UNIQUE (tenant_id, sequence_number),
+    CONSTRAINT chk_invoice_number_reservations_bound CHECK (status <> 'bound' OR invoice_id IS NOT NULL),
+    CONSTRAINT chk_invoice_number_reservations_voided CHECK (status <> 'voided' OR void_reason IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX uk_invoice_number_reservations_invoice ON invoice_number_reservations(invoice_id) WHERE invoice_id IS NOT NULL;
+CREATE INDEX idx_invoice_number_reservations_tenant_status ON invoice_number_reservations(tenant_id, status);
+
+-- Enable Row Level Security
+ALTER TABLE invoice_number_sequences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invoice_number_reservations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_invoice_number_sequences ON invoice_number_sequences
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_invoice_number_reservations ON invoice_number_reservations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at triggers
+CREATE TRIGGER update_invoice_number_sequences_updated_at BEFORE UPDATE ON invoice_number_sequences FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_invoice_number_reservations_updated_at BEFORE UPDATE ON invoice_number_reservations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback printers
+
+DROP POLICY IF EXISTS tenant_isolation_printers ON printers;
+DROP TABLE IF EXISTS printers;
@@ -0,0 +1,32 @@
+-- Printers registered by tenants, so store terminals can print to their printer without
+-- naming it in every request
+
+CREATE TABLE printers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(500),
+    terminal VARCHAR(100),
+    is_thermal BOOLEAN NOT NULL DEFAULT false,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'unknown' CHECK (status IN ('unknown', 'ready', 'error')),
+    last_tested_at TIMESTAMP WITH TIME ZONE,
+    last_test_error TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_printers_tenant_name UNIQUE (tenant_id, name)
+);
+
+-- At most one default printer of each kind per terminal
+CREATE UNIQUE INDEX uk_printers_tenant_terminal_default ON printers(tenant_id, COALESCE(terminal, ''), is_thermal) WHERE is_default;
+CREATE INDEX idx_printers_tenant_terminal ON printers(tenant_id, terminal);
+
+-- Enable Row Level Security
+ALTER TABLE printers ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_printers ON printers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_printers_updated_at BEFORE UPDATE ON printers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback product units
+
+DROP POLICY IF EXISTS tenant_isolation_product_units ON product_units;
+DROP TABLE IF EXISTS product_units;
@@ -0,0 +1,31 @@
+-- Tenants' own product units, in addition to the built-in unit catalog (pcs, box, pack,
+-- dozen, kg, g, liter, ml, m). Names are display names keyed by language.
+
+CREATE TABLE product_units (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    names JSONB NOT NULL DEFAULT '{}',
+    decimal_places SMALLINT NOT NULL DEFAULT 0 CHECK (decimal_places BETWEEN 0 AND 3),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_product_units_tenant_code UNIQUE (tenant_id, code)
+);
+
+-- Enable Row Level Security
+ALTER TABLE product_units ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_product_units ON product_units
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_product_units_updated_at BEFORE UPDATE ON product_units FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Map free-text units onto the codes of the built-in catalog
+UPDATE products SET unit = LOWER(TRIM(unit)) WHERE unit <> LOWER(TRIM(unit));
+UPDATE products SET unit = 'pcs' WHERE unit IN ('pc', 'piece', 'pieces');
+UPDATE products SET unit = 'kg' WHERE unit IN ('kilogram', 'kgs');
+UPDATE products SET unit = 'g' WHERE unit IN ('gram', 'gr');
+UPDATE products SET unit = 'liter' WHERE unit IN ('l', 'ltr', 'litre');
+UPDATE products SET unit = 'm' WHERE unit = 'meter';
@@ -0,0 +1,7 @@
+-- Rollback register sessions
+
+DROP INDEX IF EXISTS idx_refunds_tenant_created_by;
+
+DROP POLICY IF EXISTS tenant_isolation_register_sessions ON register_sessions;
+
+DROP TABLE IF EXISTS register_sessions;
@@ -0,0 +1,42 @@
+-- Cash drawer shifts: one row per register session, from opening the drawer with a float to
+-- counting it at close. Cash movements are stored inline since they are only ever read and
+-- written together with their session.
+
+CREATE TABLE register_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    register VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    opening_float DECIMAL(15,2) NOT NULL CHECK (opening_float >= 0),
+    movements JSONB NOT NULL DEFAULT '[]',
+    cash_sales DECIMAL(15,2),
+    cash_refunds DECIMAL(15,2),
+    expected_cash DECIMAL(15,2),
+    counted_cash DECIMAL(15,2) CHECK (counted_cash >= 0),
+    notes VARCHAR(500) NOT NULL DEFAULT '',
+    opened_by UUID NOT NULL REFERENCES users(id),
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_by UUID REFERENCES users(id),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_register_sessions_closed CHECK ((status = 'open') = (closed_at IS NULL)),
+    CONSTRAINT chk_register_sessions_closed_at CHECK (closed_at IS NULL OR closed_at >= opened_at)
+);
+
+CREATE INDEX idx_register_sessions_tenant_opened_at ON register_sessions(tenant_id, opened_at);
+CREATE INDEX idx_register_sessions_opened_by ON register_sessions(opened_by);
+-- A register can only have one open session at a time
+CREATE UNIQUE INDEX uk_register_sessions_open_register ON register_sessions(tenant_id, register) WHERE status = 'open';
+
+-- Cash sales and refunds are attributed to a session by the cashier and time
+CREATE INDEX idx_refunds_tenant_created_by ON refunds(tenant_id, created_by, created_at);
+
+-- Enable Row Level Security
+ALTER TABLE register_sessions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_register_sessions ON register_sessions
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_register_sessions_updated_at BEFORE UPDATE ON register_sessions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
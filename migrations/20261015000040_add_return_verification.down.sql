@@ -0,0 +1,12 @@
+-- Rollback sales return fraud guard
+
+DELETE FROM manager_overrides WHERE action = 'late_return';
+ALTER TABLE manager_overrides DROP CONSTRAINT IF EXISTS manager_overrides_action_check;
+ALTER TABLE manager_overrides ADD CONSTRAINT manager_overrides_action_check
+    CHECK (action IN ('price_override', 'refund', 'sell_unavailable'));
+
+ALTER TABLE sales DROP COLUMN IF EXISTS receipt_token;
+
+ALTER TABLE sale_items
+    DROP CONSTRAINT IF EXISTS chk_sale_items_returned_quantity,
+    DROP COLUMN IF EXISTS returned_quantity;
@@ -0,0 +1,25 @@
+-- Sales return fraud guard
+-- Each sale line tracks the quantity returned so far, so returns can never exceed what was
+-- bought, and completed sales carry a receipt token printed as a QR code to verify returns.
+
+ALTER TABLE sale_items ADD COLUMN returned_quantity INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill from the refunds recorded so far
+UPDATE sale_items si
+SET returned_quantity = ri.quantity
+FROM (
+    SELECT sale_item_id, SUM(quantity) AS quantity
+    FROM refund_items
+    GROUP BY sale_item_id
+) ri
+WHERE ri.sale_item_id = si.id;
+
+ALTER TABLE sale_items ADD CONSTRAINT chk_sale_items_returned_quantity
+    CHECK (returned_quantity >= 0 AND returned_quantity <= quantity);
+
+ALTER TABLE sales ADD COLUMN receipt_token VARCHAR(64);
+
+-- Cashiers need a manager override to refund a sale after the tenant's return window
+ALTER TABLE manager_overrides DROP CONSTRAINT IF EXISTS manager_overrides_action_check;
+ALTER TABLE manager_overrides ADD CONSTRAINT manager_overrides_action_check
+    CHECK (action IN ('price_override', 'refund', 'sell_unavailable', 'late_return'));
@@ -0,0 +1,5 @@
+-- Rollback checkout sessions
+
+DROP POLICY IF EXISTS tenant_isolation_checkout_sessions ON checkout_sessions;
+
+DROP TABLE IF EXISTS checkout_sessions;
@@ -0,0 +1,33 @@
+-- Checkout sessions: server-held state of a pending sale going through checkout step by step,
+-- so thin clients can resume a checkout. The evaluation and payment intent are stored inline
+-- since they are only ever read and written together with their session.
+
+CREATE TABLE checkout_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    sale_id UUID NOT NULL REFERENCES sales(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled', 'expired')),
+    step VARCHAR(20) NOT NULL DEFAULT 'cart' CHECK (step IN ('cart', 'review', 'payment', 'done')),
+    evaluation JSONB,
+    payment_intent JSONB,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_checkout_sessions_completed CHECK ((status = 'completed') = (completed_at IS NOT NULL))
+);
+
+-- A sale goes through checkout in one session
+CREATE UNIQUE INDEX uk_checkout_sessions_sale ON checkout_sessions(sale_id);
+-- Idle sessions are expired by a background job
+CREATE INDEX idx_checkout_sessions_active_expires_at ON checkout_sessions(expires_at) WHERE status = 'active';
+
+-- Enable Row Level Security
+ALTER TABLE checkout_sessions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_checkout_sessions ON checkout_sessions
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_checkout_sessions_updated_at BEFORE UPDATE ON checkout_sessions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Rollback register Z-reports
+
+DROP POLICY IF EXISTS tenant_isolation_register_z_reports ON register_z_reports;
+
+DROP TABLE IF EXISTS register_z_reports;
@@ -0,0 +1,27 @@
+-- Z-reports: the closing report of each closed register session. The Z number counts up per
+-- register and the grand total carries the net sales of every Z-report of the register so far.
+-- The sales and drawer figures are stored inline as they are only ever printed as a whole.
+
+CREATE TABLE register_z_reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL REFERENCES register_sessions(id) ON DELETE CASCADE,
+    register VARCHAR(100) NOT NULL,
+    number INTEGER NOT NULL CHECK (number > 0),
+    grand_total DECIMAL(15,2) NOT NULL,
+    sales JSONB NOT NULL,
+    drawer JSONB NOT NULL,
+    generated_by UUID NOT NULL REFERENCES users(id),
+    generated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A session is closed with one Z-report
+CREATE UNIQUE INDEX uk_register_z_reports_session ON register_z_reports(session_id);
+-- Z numbers are not reused on a register
+CREATE UNIQUE INDEX uk_register_z_reports_number ON register_z_reports(tenant_id, register, number);
+
+-- Enable Row Level Security
+ALTER TABLE register_z_reports ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_register_z_reports ON register_z_reports
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
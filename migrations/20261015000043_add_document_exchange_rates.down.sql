@@ -0,0 +1,13 @@
+-- Rollback document exchange rates
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS exchange_rate_locked_at,
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS base_currency,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS exchange_rate_locked_at,
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS base_currency,
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,33 @@
+-- Document exchange rates
+-- Sales and invoices lock the rate between the currency they are made out in and the tenant's
+-- base currency when they are created. Reports and exports convert with the locked rate, so a
+-- document's figures never move with later rates.
+
+ALTER TABLE sales
+    ADD COLUMN currency VARCHAR(3),
+    ADD COLUMN base_currency VARCHAR(3),
+    ADD COLUMN exchange_rate DECIMAL(18,8) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0),
+    ADD COLUMN exchange_rate_locked_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE invoices
+    ADD COLUMN currency VARCHAR(3),
+    ADD COLUMN base_currency VARCHAR(3),
+    ADD COLUMN exchange_rate DECIMAL(18,8) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0),
+    ADD COLUMN exchange_rate_locked_at TIMESTAMP WITH TIME ZONE;
+
+-- Documents so far were all made out in their tenant's currency
+UPDATE sales s
+SET currency = c.currency, base_currency = c.currency, exchange_rate_locked_at = s.created_at
+FROM (
+    SELECT id, COALESCE(NULLIF(configuration->'business_info'->>'currency', ''), 'USD') AS currency
+    FROM tenants
+) c
+WHERE c.id = s.tenant_id;
+
+UPDATE invoices i
+SET currency = c.currency, base_currency = c.currency, exchange_rate_locked_at = i.created_at
+FROM (
+    SELECT id, COALESCE(NULLIF(configuration->'business_info'->>'currency', ''), 'USD') AS currency
+    FROM tenants
+) c
+WHERE c.id = i.tenant_id;
@@ -0,0 +1,7 @@
+-- Rollback stock counts
+
+DROP POLICY IF EXISTS tenant_isolation_stock_count_lines ON stock_count_lines;
+DROP POLICY IF EXISTS tenant_isolation_stock_counts ON stock_counts;
+
+DROP TABLE IF EXISTS stock_count_lines;
+DROP TABLE IF EXISTS stock_counts;
@@ -0,0 +1,54 @@
+-- Stock counts: stocktakes counted on handheld scanners. Each product counted has one line
+-- per count; rescans replace its counted quantity when scanned later on the scanner's clock.
+
+CREATE TABLE stock_counts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed', 'cancelled')),
+    notes VARCHAR(500) NOT NULL DEFAULT '',
+    started_by UUID NOT NULL REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_by UUID REFERENCES users(id),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_stock_counts_completed CHECK ((status = 'open') = (completed_at IS NULL))
+);
+
+CREATE TABLE stock_count_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    count_id UUID NOT NULL REFERENCES stock_counts(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    product_sku VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    barcode VARCHAR(100) NOT NULL,
+    counted_qty INTEGER NOT NULL CHECK (counted_qty >= 0),
+    expected_qty INTEGER NOT NULL,
+    variance INTEGER NOT NULL,
+    counted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    counted_by UUID NOT NULL REFERENCES users(id),
+    scan_count INTEGER NOT NULL DEFAULT 1 CHECK (scan_count > 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_stock_counts_tenant_status ON stock_counts(tenant_id, status);
+CREATE INDEX idx_stock_counts_tenant_started_at ON stock_counts(tenant_id, started_at);
+-- A product has one line per count
+CREATE UNIQUE INDEX uk_stock_count_lines_count_product ON stock_count_lines(count_id, product_id);
+CREATE INDEX idx_stock_count_lines_tenant_id ON stock_count_lines(tenant_id);
+
+CREATE TRIGGER update_stock_counts_updated_at BEFORE UPDATE ON stock_counts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER inherit_stock_count_lines_tenant_id BEFORE INSERT ON stock_count_lines
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('stock_counts', 'count_id');
+
+-- Enable Row Level Security
+ALTER TABLE stock_counts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE stock_count_lines ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_stock_counts ON stock_counts
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_stock_count_lines ON stock_count_lines
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,6 @@
+-- Rollback categories
+
+ALTER TABLE products DROP COLUMN IF EXISTS category_id;
+
+DROP POLICY IF EXISTS tenant_isolation_categories ON categories;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,57 @@
+-- Product categories as a tree per tenant. products.category keeps the name of the
+-- product's category, since reports and margin floors group products by category name.
+
+CREATE TABLE categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES categories(id) ON DELETE RESTRICT,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0 CHECK (sort_order >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT uk_categories_tenant_slug UNIQUE (tenant_id, slug),
+    CONSTRAINT chk_categories_parent CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+CREATE UNIQUE INDEX idx_categories_tenant_name ON categories(tenant_id, LOWER(name));
+CREATE INDEX idx_categories_parent ON categories(parent_id);
+
+-- Enable Row Level Security
+ALTER TABLE categories ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_categories ON categories
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_categories_updated_at BEFORE UPDATE ON categories FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- One top-level category per category name in use, ignoring case. Names slugifying to a
+-- slug already taken get a suffix.
+WITH names AS (
+    SELECT tenant_id, MIN(TRIM(category)) AS name,
+           (ARRAY_AGG(created_by ORDER BY created_at))[1] AS created_by
+    FROM products
+    WHERE TRIM(category) <> ''
+    GROUP BY tenant_id, LOWER(TRIM(category))
+), slugs AS (
+    SELECT tenant_id, name, created_by,
+           COALESCE(NULLIF(TRIM(BOTH '-' FROM LEFT(REGEXP_REPLACE(LOWER(name), '[^a-z0-9]+', '-', 'g'), 90)), ''), 'category') AS slug
+    FROM names
+)
+INSERT INTO categories (tenant_id, name, slug, updated_by)
+SELECT tenant_id, name,
+       CASE WHEN ROW_NUMBER() OVER w = 1 THEN slug ELSE slug || '-' || LEFT(MD5(name), 8) END,
+       created_by
+FROM slugs
+WINDOW w AS (PARTITION BY tenant_id, slug ORDER BY name);
+
+-- File products under the category of their name
+ALTER TABLE products ADD COLUMN category_id UUID REFERENCES categories(id) ON DELETE SET NULL;
+
+UPDATE products p SET category_id = c.id, category = c.name
+FROM categories c
+WHERE c.tenant_id = p.tenant_id AND LOWER(c.name) = LOWER(TRIM(p.category));
+
+CREATE INDEX idx_products_category_id ON products(category_id) WHERE deleted_at IS NULL;
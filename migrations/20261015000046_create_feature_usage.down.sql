@@ -0,0 +1,3 @@
+-- Rollback feature usage
+
+DROP TABLE IF EXISTS feature_usage;
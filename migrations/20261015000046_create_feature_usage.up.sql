@@ -0,0 +1,13 @@
+-- Daily counters of how often each tenant uses the major features, for feature adoption reports
+-- The table is platform-level (aggregated across tenants by the admin API), so it has no RLS policy.
+
+CREATE TABLE feature_usage (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    feature VARCHAR(50) NOT NULL,
+    day DATE NOT NULL,
+    events INTEGER NOT NULL DEFAULT 0 CHECK (events >= 0),
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, feature, day)
+);
+
+CREATE INDEX idx_feature_usage_day ON feature_usage(day, feature);
@@ -0,0 +1,7 @@
+-- Rollback coupons
+
+DROP POLICY IF EXISTS tenant_isolation_coupon_redemptions ON coupon_redemptions;
+DROP POLICY IF EXISTS tenant_isolation_coupons ON coupons;
+
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP TABLE IF EXISTS coupons;
@@ -0,0 +1,47 @@
+-- Coupons: discount codes customers present at checkout, and their redemptions on completed
+-- sales for reporting on coupon performance. A sale redeems at most one coupon.
+
+CREATE TABLE coupons (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    code VARCHAR(32) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percentage', 'fixed')),
+    value DECIMAL(15,2) NOT NULL CHECK (value > 0),
+    max_redemptions INTEGER NOT NULL DEFAULT 0 CHECK (max_redemptions >= 0),
+    redemption_count INTEGER NOT NULL DEFAULT 0 CHECK (redemption_count >= 0),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    CONSTRAINT chk_coupons_percentage CHECK (type <> 'percentage' OR value <= 100),
+    CONSTRAINT chk_coupons_redemption_limit CHECK (max_redemptions = 0 OR redemption_count <= max_redemptions)
+);
+
+CREATE TABLE coupon_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    coupon_id UUID NOT NULL REFERENCES coupons(id) ON DELETE CASCADE,
+    sale_id UUID NOT NULL REFERENCES sales(id) ON DELETE CASCADE,
+    code VARCHAR(32) NOT NULL,
+    discount_amount DECIMAL(15,2) NOT NULL CHECK (discount_amount >= 0),
+    sale_total DECIMAL(15,2) NOT NULL,
+    redeemed_by UUID NOT NULL REFERENCES users(id),
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uk_coupons_tenant_code ON coupons(tenant_id, code);
+CREATE UNIQUE INDEX uk_coupon_redemptions_sale_id ON coupon_redemptions(sale_id);
+CREATE INDEX idx_coupon_redemptions_coupon_id ON coupon_redemptions(coupon_id, redeemed_at DESC);
+CREATE INDEX idx_coupon_redemptions_tenant_redeemed_at ON coupon_redemptions(tenant_id, redeemed_at);
+
+CREATE TRIGGER update_coupons_updated_at BEFORE UPDATE ON coupons FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Enable Row Level Security
+ALTER TABLE coupons ENABLE ROW LEVEL SECURITY;
+ALTER TABLE coupon_redemptions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_coupons ON coupons
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_coupon_redemptions ON coupon_redemptions
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,20 @@
+-- Rollback tax rates
+
+ALTER TABLE invoice_items
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS tax_rate_id;
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS tax_summary,
+    DROP COLUMN IF EXISTS tax_inclusive;
+ALTER TABLE sale_items
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS tax_rate_id;
+ALTER TABLE sales DROP COLUMN IF EXISTS tax_inclusive;
+
+ALTER TABLE products DROP COLUMN IF EXISTS tax_rate_id;
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_rate_id;
+
+DROP POLICY IF EXISTS tenant_isolation_tax_rates ON tax_rates;
+DROP TABLE IF EXISTS tax_rates;
@@ -0,0 +1,65 @@
+-- Tax rates assignable per category and product, with tax computed per sale item. Items
+-- without a rate of their own are taxed at the sale's rate, so existing sales keep theirs.
+
+CREATE TABLE tax_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    rate DECIMAL(5,2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE UNIQUE INDEX idx_tax_rates_tenant_name ON tax_rates(tenant_id, LOWER(name));
+
+-- Enable Row Level Security
+ALTER TABLE tax_rates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tax_rates ON tax_rates
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_tax_rates_updated_at BEFORE UPDATE ON tax_rates FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE categories ADD COLUMN tax_rate_id UUID REFERENCES tax_rates(id) ON DELETE SET NULL;
+ALTER TABLE products ADD COLUMN tax_rate_id UUID REFERENCES tax_rates(id) ON DELETE SET NULL;
+
+-- Per-item tax. The rate ID is kept as it was at the time of sale, without a reference.
+ALTER TABLE sales ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE sale_items
+    ADD COLUMN tax_rate_id UUID,
+    ADD COLUMN tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE invoices
+    ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN tax_summary JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE invoice_items
+    ADD COLUMN tax_rate_id UUID,
+    ADD COLUMN tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+-- Existing items were taxed at their sale's rate after their share of the sale discount
+UPDATE sale_items si SET
+    tax_rate = s.tax_rate,
+    tax_amount = ROUND(si.total_price * (1 - s.discount_amount / s.subtotal) * s.tax_rate / 100, 2)
+FROM sales s
+WHERE s.id = si.sale_id AND s.subtotal > 0;
+
+UPDATE invoice_items ii SET
+    tax_rate = s.tax_rate,
+    tax_amount = ROUND(ii.total_price * (1 - i.discount_amount / i.subtotal) * s.tax_rate / 100, 2)
+FROM invoices i
+JOIN sales s ON s.id = i.sale_id
+WHERE i.id = ii.invoice_id AND i.subtotal > 0;
+
+-- Existing invoices had a single rate
+UPDATE invoices i SET tax_summary = jsonb_build_array(jsonb_build_object(
+    'tax_rate', s.tax_rate::TEXT,
+    'taxable_amount', (i.subtotal - i.discount_amount
+        + CASE WHEN i.surcharge_tax_amount > 0 THEN i.surcharge_amount ELSE 0 END)::TEXT,
+    'tax_amount', i.tax_amount::TEXT))
+FROM sales s
+WHERE s.id = i.sale_id;
@@ -0,0 +1,3 @@
+-- Rollback product currency
+
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
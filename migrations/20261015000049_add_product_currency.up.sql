@@ -0,0 +1,5 @@
+-- Product currency
+-- Products priced in another currency than their tenant's are converted to the currency of
+-- the sale they are added to. Products without a currency are priced in the tenant's.
+
+ALTER TABLE products ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+-- Rollback invoice payments
+
+DROP POLICY IF EXISTS tenant_isolation_invoice_payments ON invoice_payments;
+
+DROP TABLE IF EXISTS invoice_payments;
+
+UPDATE invoices SET status = 'sent' WHERE status = 'partially_paid';
+
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS invoices_status_check;
+ALTER TABLE invoices ADD CONSTRAINT invoices_status_check
+    CHECK (status IN ('draft', 'generated', 'sent', 'paid', 'cancelled'));
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS payment_schedule;
@@ -0,0 +1,33 @@
+-- Invoice payments
+-- Invoices can be paid in several partial payments, each recorded with its method and date.
+-- An invoice whose payments fall short of its total is partially paid. An optional payment
+-- schedule splits the total into installments; the invoice's due date follows the first
+-- installment its payments do not cover yet. Payments taken at the till before this are
+-- only reflected in paid_amount.
+
+ALTER TABLE invoices DROP CONSTRAINT invoices_status_check;
+ALTER TABLE invoices ADD CONSTRAINT invoices_status_check
+    CHECK (status IN ('draft', 'generated', 'sent', 'partially_paid', 'paid', 'cancelled'));
+
+ALTER TABLE invoices ADD COLUMN payment_schedule JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE invoice_payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    payment_method VARCHAR(50) NOT NULL CHECK (payment_method IN ('cash', 'card', 'digital_wallet', 'bank_transfer')),
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    paid_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_invoice_payments_invoice_id ON invoice_payments(invoice_id, paid_at);
+CREATE INDEX idx_invoice_payments_tenant_paid_at ON invoice_payments(tenant_id, paid_at);
+
+-- Enable Row Level Security
+ALTER TABLE invoice_payments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_invoice_payments ON invoice_payments
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
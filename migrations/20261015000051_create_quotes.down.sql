@@ -0,0 +1,13 @@
+-- Rollback quotes
+
+DELETE FROM email_templates WHERE kind = 'quote';
+ALTER TABLE email_templates DROP CONSTRAINT email_templates_kind_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_kind_check
+    CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice'));
+
+DROP TRIGGER IF EXISTS update_quotes_updated_at ON quotes;
+DROP POLICY IF EXISTS tenant_isolation_quote_items ON quote_items;
+DROP POLICY IF EXISTS tenant_isolation_quotes ON quotes;
+
+DROP TABLE IF EXISTS quote_items;
+DROP TABLE IF EXISTS quotes;
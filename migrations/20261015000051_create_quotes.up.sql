@@ -0,0 +1,68 @@
+-- Quotes
+-- A quote is an estimate of a sale given to a customer, emailed or printed as a PDF. Its
+-- prices hold until it expires; an accepted quote is converted into a pending sale charging
+-- the quoted prices, which links the sale back to the quote. Unanswered quotes are expired
+-- by a scheduled job.
+
+CREATE TABLE quotes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    quote_number VARCHAR(50) NOT NULL,
+    customer_name VARCHAR(255) NOT NULL,
+    customer_email VARCHAR(255),
+    customer_phone VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'sent', 'accepted', 'rejected', 'expired', 'converted')),
+    total_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (total_amount >= 0),
+    currency CHAR(3) NOT NULL,
+    notes TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE,
+    converted_at TIMESTAMP WITH TIME ZONE,
+    sale_id UUID REFERENCES sales(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL REFERENCES users(id)
+);
+
+CREATE TABLE quote_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    quote_id UUID NOT NULL REFERENCES quotes(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    product_sku VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(15,2) NOT NULL CHECK (unit_price > 0),
+    total_price DECIMAL(15,2) NOT NULL CHECK (total_price > 0),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- Create indexes for quotes
+CREATE UNIQUE INDEX uk_quotes_tenant_quote_number ON quotes(tenant_id, quote_number);
+CREATE INDEX idx_quotes_tenant_status ON quotes(tenant_id, status);
+CREATE INDEX idx_quotes_tenant_created_at ON quotes(tenant_id, created_at);
+CREATE INDEX idx_quotes_open_expires_at ON quotes(expires_at) WHERE status IN ('draft', 'sent');
+CREATE INDEX idx_quote_items_quote_id ON quote_items(quote_id);
+CREATE INDEX idx_quote_items_product_id ON quote_items(product_id);
+CREATE INDEX idx_quote_items_tenant_id ON quote_items(tenant_id);
+
+CREATE TRIGGER update_quotes_updated_at BEFORE UPDATE ON quotes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER inherit_quote_items_tenant_id BEFORE INSERT ON quote_items
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('quotes', 'quote_id');
+
+-- Enable Row Level Security
+ALTER TABLE quotes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE quote_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_quotes ON quotes
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_quote_items ON quote_items
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Tenants can override the quote email like the invoice emails
+ALTER TABLE email_templates DROP CONSTRAINT email_templates_kind_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_kind_check
+    CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice', 'quote'));
@@ -0,0 +1,20 @@
+-- Rollback locations and stock transfers
+
+DELETE FROM stock_movements WHERE reason = 'transfer';
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check
+    CHECK (reason IN ('purchase', 'sale', 'return', 'damage', 'expiry', 'adjustment', 'reservation', 'release'));
+DROP INDEX IF EXISTS idx_stock_movements_location_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS location_id;
+
+DROP TRIGGER IF EXISTS update_stock_transfers_updated_at ON stock_transfers;
+DROP TRIGGER IF EXISTS update_locations_updated_at ON locations;
+DROP POLICY IF EXISTS tenant_isolation_stock_transfer_items ON stock_transfer_items;
+DROP POLICY IF EXISTS tenant_isolation_stock_transfers ON stock_transfers;
+DROP POLICY IF EXISTS tenant_isolation_location_stock ON location_stock;
+DROP POLICY IF EXISTS tenant_isolation_locations ON locations;
+
+DROP TABLE IF EXISTS stock_transfer_items;
+DROP TABLE IF EXISTS stock_transfers;
+DROP TABLE IF EXISTS location_stock;
+DROP TABLE IF EXISTS locations;
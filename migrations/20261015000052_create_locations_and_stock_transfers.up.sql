@@ -0,0 +1,98 @@
+-- Locations and stock transfers
+-- Tenants keep stock at several locations, such as stores and warehouses. The stock table
+-- keeps each product's total across locations; location_stock records how much of it each
+-- location holds. Stock transfers move quantities between locations: shipping takes them out
+-- of the source location and receiving puts them into the destination, each recorded as a
+-- 'transfer' stock movement at its location.
+
+CREATE TABLE locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    address TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE location_stock (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE TABLE stock_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    transfer_number VARCHAR(50) NOT NULL,
+    from_location_id UUID NOT NULL REFERENCES locations(id),
+    to_location_id UUID NOT NULL REFERENCES locations(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'requested' CHECK (status IN ('requested', 'in_transit', 'received', 'cancelled')),
+    notes TEXT,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    shipped_by UUID REFERENCES users(id),
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    received_by UUID REFERENCES users(id),
+    received_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_stock_transfers_locations CHECK (from_location_id <> to_location_id)
+);
+
+CREATE TABLE stock_transfer_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_id UUID NOT NULL REFERENCES stock_transfers(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    product_sku VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+-- Stock movements of transfers record the location they happened at
+ALTER TABLE stock_movements ADD COLUMN location_id UUID REFERENCES locations(id) ON DELETE SET NULL;
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check
+    CHECK (reason IN ('purchase', 'sale', 'return', 'damage', 'expiry', 'adjustment', 'reservation', 'release', 'transfer'));
+
+-- Create indexes
+CREATE UNIQUE INDEX uk_locations_tenant_code ON locations(tenant_id, code);
+CREATE UNIQUE INDEX uk_location_stock_location_product ON location_stock(location_id, product_id);
+CREATE INDEX idx_location_stock_product_id ON location_stock(product_id);
+CREATE INDEX idx_location_stock_tenant_id ON location_stock(tenant_id);
+CREATE UNIQUE INDEX uk_stock_transfers_tenant_number ON stock_transfers(tenant_id, transfer_number);
+CREATE INDEX idx_stock_transfers_tenant_status ON stock_transfers(tenant_id, status);
+CREATE INDEX idx_stock_transfers_from_location ON stock_transfers(from_location_id);
+CREATE INDEX idx_stock_transfers_to_location ON stock_transfers(to_location_id);
+CREATE INDEX idx_stock_transfer_items_transfer_id ON stock_transfer_items(transfer_id);
+CREATE INDEX idx_stock_transfer_items_product_id ON stock_transfer_items(product_id);
+CREATE INDEX idx_stock_transfer_items_tenant_id ON stock_transfer_items(tenant_id);
+CREATE INDEX idx_stock_movements_location_id ON stock_movements(location_id) WHERE location_id IS NOT NULL;
+
+CREATE TRIGGER update_locations_updated_at BEFORE UPDATE ON locations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_stock_transfers_updated_at BEFORE UPDATE ON stock_transfers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER inherit_location_stock_tenant_id BEFORE INSERT ON location_stock
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('locations', 'location_id');
+CREATE TRIGGER inherit_stock_transfer_items_tenant_id BEFORE INSERT ON stock_transfer_items
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('stock_transfers', 'transfer_id');
+
+-- Enable Row Level Security
+ALTER TABLE locations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE location_stock ENABLE ROW LEVEL SECURITY;
+ALTER TABLE stock_transfers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE stock_transfer_items ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_locations ON locations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_location_stock ON location_stock
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_stock_transfers ON stock_transfers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_stock_transfer_items ON stock_transfer_items
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
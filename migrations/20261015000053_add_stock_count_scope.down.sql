@@ -0,0 +1,14 @@
+-- Rollback stock count scope
+
+-- Stocktake movements changed stock, so they are kept as adjustments
+UPDATE stock_movements SET reason = 'adjustment' WHERE reason = 'stocktake';
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check
+    CHECK (reason IN ('purchase', 'sale', 'return', 'damage', 'expiry', 'adjustment', 'reservation', 'release', 'transfer'));
+
+DROP INDEX IF EXISTS idx_stock_counts_location_id;
+
+ALTER TABLE stock_counts
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS category_id,
+    DROP COLUMN IF EXISTS location_id;
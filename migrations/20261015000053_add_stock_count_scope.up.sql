@@ -0,0 +1,13 @@
+-- Stock counts can be limited to a location and to a category with its subcategories.
+-- Variances posted by completed counts are recorded as stocktake movements.
+
+ALTER TABLE stock_counts
+    ADD COLUMN location_id UUID REFERENCES locations(id),
+    ADD COLUMN category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    ADD COLUMN category VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_stock_counts_location_id ON stock_counts(location_id) WHERE location_id IS NOT NULL;
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check
+    CHECK (reason IN ('purchase', 'sale', 'return', 'damage', 'expiry', 'adjustment', 'reservation', 'release', 'transfer', 'stocktake'));
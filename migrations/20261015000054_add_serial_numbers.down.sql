@@ -0,0 +1,8 @@
+-- Rollback serial number tracking
+
+DROP TRIGGER IF EXISTS update_serial_numbers_updated_at ON serial_numbers;
+DROP POLICY IF EXISTS tenant_isolation_serial_numbers ON serial_numbers;
+DROP TABLE IF EXISTS serial_numbers;
+
+ALTER TABLE sale_items DROP COLUMN IF EXISTS serial_numbers;
+ALTER TABLE products DROP COLUMN IF EXISTS is_serialized;
@@ -0,0 +1,34 @@
+-- Serial number tracking
+-- Products flagged as serialized can have the serial number of each unit captured on the
+-- sale item. Completing the sale records the serials as sold in the serial_numbers registry,
+-- which refuses to sell the same serial of a product twice and answers warranty lookups.
+
+ALTER TABLE products ADD COLUMN is_serialized BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE sale_items ADD COLUMN serial_numbers TEXT[];
+
+CREATE TABLE serial_numbers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    serial VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_stock' CHECK (status IN ('in_stock', 'sold', 'returned')),
+    sale_id UUID REFERENCES sales(id) ON DELETE SET NULL,
+    sale_item_id UUID REFERENCES sale_items(id) ON DELETE SET NULL,
+    sold_at TIMESTAMP WITH TIME ZONE,
+    returned_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX uk_serial_numbers_tenant_product_serial ON serial_numbers(tenant_id, product_id, serial);
+CREATE INDEX idx_serial_numbers_tenant_serial ON serial_numbers(tenant_id, serial);
+CREATE INDEX idx_serial_numbers_sale_id ON serial_numbers(sale_id) WHERE sale_id IS NOT NULL;
+
+CREATE TRIGGER update_serial_numbers_updated_at BEFORE UPDATE ON serial_numbers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Enable Row Level Security
+ALTER TABLE serial_numbers ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_serial_numbers ON serial_numbers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
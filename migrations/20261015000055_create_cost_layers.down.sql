@@ -0,0 +1,5 @@
+-- Rollback cost layers
+
+DROP TRIGGER IF EXISTS update_cost_layers_updated_at ON cost_layers;
+DROP POLICY IF EXISTS tenant_isolation_cost_layers ON cost_layers;
+DROP TABLE IF EXISTS cost_layers;
@@ -0,0 +1,28 @@
+-- Cost layers
+-- Every purchase receipt records the units received and their unit cost as a layer. Tenants
+-- costing sales first in, first out use up the oldest layers as units are sold; the sale item
+-- keeps the resulting unit cost so profit can be reported.
+
+CREATE TABLE cost_layers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    remaining_qty INTEGER NOT NULL CHECK (remaining_qty >= 0 AND remaining_qty <= quantity),
+    unit_cost DECIMAL(15,2) NOT NULL CHECK (unit_cost >= 0),
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_cost_layers_open ON cost_layers(product_id, received_at) WHERE remaining_qty > 0;
+CREATE INDEX idx_cost_layers_tenant_id ON cost_layers(tenant_id);
+
+CREATE TRIGGER update_cost_layers_updated_at BEFORE UPDATE ON cost_layers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Enable Row Level Security
+ALTER TABLE cost_layers ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_cost_layers ON cost_layers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
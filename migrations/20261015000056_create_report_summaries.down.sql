@@ -0,0 +1,11 @@
+-- Rollback report summaries
+
+DROP INDEX IF EXISTS idx_refunds_created_at;
+DROP INDEX IF EXISTS idx_invoices_updated_at;
+DROP INDEX IF EXISTS idx_invoices_tenant_created_at;
+DROP INDEX IF EXISTS idx_sales_updated_at;
+DROP INDEX IF EXISTS idx_sales_tenant_created_at;
+DROP POLICY IF EXISTS tenant_isolation_invoice_daily_summaries ON invoice_daily_summaries;
+DROP POLICY IF EXISTS tenant_isolation_sales_daily_summaries ON sales_daily_summaries;
+DROP TABLE IF EXISTS invoice_daily_summaries;
+DROP TABLE IF EXISTS sales_daily_summaries;
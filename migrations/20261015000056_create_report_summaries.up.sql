@@ -0,0 +1,61 @@
+-- Report summaries
+-- Daily sales and invoice totals per tenant, so the sales and invoice reports read a row per
+-- day instead of aggregating every sale and invoice on demand. A background job recomputes the
+-- days whose sales, refunds or invoices changed since they were last summarized, and completing
+-- a sale recomputes its day straight away.
+
+CREATE TABLE sales_daily_summaries (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    summary_date DATE NOT NULL,
+    total_sales INTEGER NOT NULL DEFAULT 0,
+    completed_sales INTEGER NOT NULL DEFAULT 0,
+    cancelled_sales INTEGER NOT NULL DEFAULT 0,
+    refunded_sales INTEGER NOT NULL DEFAULT 0,
+    total_revenue DECIMAL(15,2) NOT NULL DEFAULT 0,
+    completed_revenue DECIMAL(15,2) NOT NULL DEFAULT 0,
+    surcharge_revenue DECIMAL(15,2) NOT NULL DEFAULT 0,
+    items_sold INTEGER NOT NULL DEFAULT 0,
+    cost_of_goods DECIMAL(15,2) NOT NULL DEFAULT 0,
+    total_profit DECIMAL(15,2) NOT NULL DEFAULT 0,
+    refund_count INTEGER NOT NULL DEFAULT 0,
+    refunded_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, summary_date)
+);
+
+CREATE TABLE invoice_daily_summaries (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    summary_date DATE NOT NULL,
+    total_invoices INTEGER NOT NULL DEFAULT 0,
+    total_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    paid_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    unpaid_balance DECIMAL(15,2) NOT NULL DEFAULT 0,
+    partially_paid_balance DECIMAL(15,2) NOT NULL DEFAULT 0,
+    draft_invoices INTEGER NOT NULL DEFAULT 0,
+    generated_invoices INTEGER NOT NULL DEFAULT 0,
+    sent_invoices INTEGER NOT NULL DEFAULT 0,
+    partially_paid_invoices INTEGER NOT NULL DEFAULT 0,
+    paid_invoices INTEGER NOT NULL DEFAULT 0,
+    cancelled_invoices INTEGER NOT NULL DEFAULT 0,
+    timed_paid_invoices INTEGER NOT NULL DEFAULT 0, -- Paid invoices with a paid_at, for the average payment time
+    payment_days DECIMAL(15,2) NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, summary_date)
+);
+
+-- Create indexes
+CREATE INDEX idx_sales_tenant_created_at ON sales(tenant_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX idx_sales_updated_at ON sales(updated_at);
+CREATE INDEX idx_invoices_tenant_created_at ON invoices(tenant_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX idx_invoices_updated_at ON invoices(updated_at);
+CREATE INDEX idx_refunds_created_at ON refunds(created_at);
+
+-- Enable Row Level Security
+ALTER TABLE sales_daily_summaries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invoice_daily_summaries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_sales_daily_summaries ON sales_daily_summaries
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_invoice_daily_summaries ON invoice_daily_summaries
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,4 @@
+-- Rollback alert_channels
+
+DROP POLICY IF EXISTS tenant_isolation_alert_channels ON alert_channels;
+DROP TABLE IF EXISTS alert_channels;
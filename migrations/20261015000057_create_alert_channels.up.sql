@@ -0,0 +1,32 @@
+-- Destinations a tenant's monitoring alerts are sent to: email, Slack incoming webhooks and
+-- generic signed webhooks, each receiving alerts from a minimum severity up
+
+CREATE TABLE alert_channels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('email', 'slack', 'webhook')),
+    min_severity VARCHAR(20) NOT NULL DEFAULT 'warning' CHECK (min_severity IN ('info', 'warning', 'error', 'critical')),
+    recipients TEXT[] NOT NULL DEFAULT '{}',
+    url VARCHAR(2048),
+    secret VARCHAR(100),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    last_notified_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_alert_channels_tenant_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_alert_channels_url CHECK (type = 'email' OR url IS NOT NULL)
+);
+
+CREATE INDEX idx_alert_channels_tenant_active ON alert_channels(tenant_id) WHERE is_active;
+
+-- Enable Row Level Security
+ALTER TABLE alert_channels ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_alert_channels ON alert_channels
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_alert_channels_updated_at BEFORE UPDATE ON alert_channels FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,15 @@
+-- Rollback deleted invoice retention
+
+DROP INDEX IF EXISTS idx_invoices_tenant_deleted_at;
+DROP INDEX IF EXISTS idx_sales_tenant_deleted_at;
+
+DELETE FROM retention_holds WHERE entity = 'deleted_invoices';
+DELETE FROM retention_policies WHERE entity = 'deleted_invoices';
+
+ALTER TABLE retention_holds DROP CONSTRAINT IF EXISTS retention_holds_entity_check;
+ALTER TABLE retention_holds ADD CONSTRAINT retention_holds_entity_check
+    CHECK (entity IN ('audit_logs', 'stock_movements', 'archived_sales'));
+
+ALTER TABLE retention_policies DROP CONSTRAINT IF EXISTS retention_policies_entity_check;
+ALTER TABLE retention_policies ADD CONSTRAINT retention_policies_entity_check
+    CHECK (entity IN ('audit_logs', 'stock_movements', 'archived_sales'));
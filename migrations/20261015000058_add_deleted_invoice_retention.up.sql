@@ -0,0 +1,14 @@
+-- Let retention policies and holds cover soft-deleted invoices, so they can be purged once a
+-- tenant's retention period has passed, like soft-deleted sales
+
+ALTER TABLE retention_policies DROP CONSTRAINT retention_policies_entity_check;
+ALTER TABLE retention_policies ADD CONSTRAINT retention_policies_entity_check
+    CHECK (entity IN ('audit_logs', 'stock_movements', 'archived_sales', 'deleted_invoices'));
+
+ALTER TABLE retention_holds DROP CONSTRAINT retention_holds_entity_check;
+ALTER TABLE retention_holds ADD CONSTRAINT retention_holds_entity_check
+    CHECK (entity IN ('audit_logs', 'stock_movements', 'archived_sales', 'deleted_invoices'));
+
+-- Support listing a tenant's soft-deleted sales and invoices
+CREATE INDEX idx_sales_tenant_deleted_at ON sales(tenant_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_invoices_tenant_deleted_at ON invoices(tenant_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,15 @@
+-- Rollback audit log hash chain
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+DROP FUNCTION IF EXISTS prevent_audit_log_update();
+
+DROP TABLE IF EXISTS audit_chain_heads;
+
+DROP INDEX IF EXISTS idx_audit_logs_tenant_timestamp;
+DROP INDEX IF EXISTS uk_audit_logs_tenant_chain;
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS previous_hash,
+    DROP COLUMN IF EXISTS chain_sequence,
+    DROP COLUMN IF EXISTS tenant_id;
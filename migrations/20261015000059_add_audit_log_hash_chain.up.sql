@@ -0,0 +1,33 @@
+-- Tamper-evident audit trail
+-- Each tenant's audit events form a hash chain: every event stores the hash of the tenant's
+-- previous event, and the head of each chain is kept separately so removing the latest events
+-- is detected too. Events logged before chaining have no hash and are not verified.
+
+ALTER TABLE audit_logs
+    ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE,
+    ADD COLUMN chain_sequence BIGINT,
+    ADD COLUMN previous_hash VARCHAR(64),
+    ADD COLUMN hash VARCHAR(64);
+
+UPDATE audit_logs a SET tenant_id = u.tenant_id FROM users u WHERE u.id = a.user_id;
+
+CREATE UNIQUE INDEX uk_audit_logs_tenant_chain ON audit_logs(tenant_id, chain_sequence) WHERE chain_sequence IS NOT NULL;
+CREATE INDEX idx_audit_logs_tenant_timestamp ON audit_logs(tenant_id, timestamp);
+
+CREATE TABLE audit_chain_heads (
+    chain_key UUID PRIMARY KEY, -- Tenant ID, or the nil UUID for events without a tenant
+    sequence BIGINT NOT NULL DEFAULT 0,
+    hash VARCHAR(64) NOT NULL DEFAULT '',
+    logged_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Audit events are append-only; retention purges may still delete them
+CREATE OR REPLACE FUNCTION prevent_audit_log_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log events cannot be modified';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER audit_logs_append_only BEFORE UPDATE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_update();
@@ -0,0 +1,6 @@
+-- Rollback API keys
+
+DROP TRIGGER IF EXISTS update_api_keys_updated_at ON api_keys;
+DROP POLICY IF EXISTS tenant_isolation_api_keys ON api_keys;
+
+DROP TABLE IF EXISTS api_keys;
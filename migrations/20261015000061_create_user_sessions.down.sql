@@ -0,0 +1,6 @@
+-- Rollback user sessions
+
+DROP TRIGGER IF EXISTS update_user_sessions_updated_at ON user_sessions;
+DROP POLICY IF EXISTS tenant_isolation_user_sessions ON user_sessions;
+
+DROP TABLE IF EXISTS user_sessions;
@@ -0,0 +1,5 @@
+-- Rollback login lockouts
+
+DROP POLICY IF EXISTS tenant_isolation_login_lockouts ON login_lockouts;
+
+DROP TABLE IF EXISTS login_lockouts;
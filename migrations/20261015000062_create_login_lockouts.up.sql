@@ -0,0 +1,22 @@
+-- Failed login tracking for brute-force protection
+-- A user is locked out after repeated failures, for longer with each lockout in a row
+
+CREATE TABLE login_lockouts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    failed_attempts INTEGER NOT NULL DEFAULT 0 CHECK (failed_attempts >= 0),
+    lockouts INTEGER NOT NULL DEFAULT 0 CHECK (lockouts >= 0),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_failed_ip VARCHAR(45) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for login lockouts
+CREATE INDEX idx_login_lockouts_tenant_id ON login_lockouts(tenant_id);
+
+-- Enable Row Level Security
+ALTER TABLE login_lockouts ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_login_lockouts ON login_lockouts
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
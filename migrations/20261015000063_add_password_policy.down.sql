@@ -0,0 +1,7 @@
+-- Rollback password policy and forced rotation
+
+DROP POLICY IF EXISTS tenant_isolation_password_history ON password_history;
+DROP TABLE IF EXISTS password_history;
+
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
@@ -0,0 +1,25 @@
+-- Password policy and forced rotation
+-- Users record when their password last changed, so passwords older than the configured
+-- maximum age expire, and whether they must change it before signing in again, which admins
+-- set in bulk. password_history keeps the hashes of previous passwords so they are not reused.
+
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+ALTER TABLE users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE password_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for password history
+CREATE INDEX idx_password_history_user_created_at ON password_history(user_id, created_at DESC);
+CREATE INDEX idx_password_history_tenant_id ON password_history(tenant_id);
+
+-- Enable Row Level Security
+ALTER TABLE password_history ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_password_history ON password_history
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,16 @@
+-- Rollback user invitations
+
+DELETE FROM email_templates WHERE kind = 'user_invitation';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_kind_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_kind_check
+    CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice', 'quote'));
+
+DROP TRIGGER IF EXISTS update_user_invitations_updated_at ON user_invitations;
+DROP POLICY IF EXISTS tenant_isolation_user_invitations ON user_invitations;
+DROP TABLE IF EXISTS user_invitations;
+
+-- Invited users never chose a password, so they cannot be kept as active users
+UPDATE users SET status = 'inactive' WHERE status = 'invited';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended'));
@@ -0,0 +1,42 @@
+-- User invitations
+-- Admins invite users instead of setting their password. An invited user has no password and
+-- cannot sign in until they accept the invitation through the signed activation link emailed
+-- to them, choosing their password. Resending an invitation extends its expiry, which
+-- invalidates the links sent before; revoking it invalidates them all.
+
+ALTER TABLE users DROP CONSTRAINT users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended', 'invited'));
+
+CREATE TABLE user_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    invited_by UUID NOT NULL REFERENCES users(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_count INTEGER NOT NULL DEFAULT 1 CHECK (sent_count >= 0),
+    last_sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for user invitations
+CREATE INDEX idx_user_invitations_user_id ON user_invitations(user_id, created_at DESC);
+CREATE INDEX idx_user_invitations_tenant_id ON user_invitations(tenant_id);
+
+CREATE TRIGGER update_user_invitations_updated_at BEFORE UPDATE ON user_invitations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Enable Row Level Security
+ALTER TABLE user_invitations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_user_invitations ON user_invitations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Tenants can override the invitation email like the customer emails
+ALTER TABLE email_templates DROP CONSTRAINT email_templates_kind_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_kind_check
+    CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice', 'quote', 'user_invitation'));
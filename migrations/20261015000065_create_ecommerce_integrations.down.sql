@@ -0,0 +1,11 @@
+-- Rollback e-commerce integrations
+
+DROP TRIGGER IF EXISTS update_ecommerce_product_links_updated_at ON ecommerce_product_links;
+DROP TRIGGER IF EXISTS update_ecommerce_orders_updated_at ON ecommerce_orders;
+DROP TRIGGER IF EXISTS update_ecommerce_integrations_updated_at ON ecommerce_integrations;
+DROP POLICY IF EXISTS tenant_isolation_ecommerce_product_links ON ecommerce_product_links;
+DROP POLICY IF EXISTS tenant_isolation_ecommerce_orders ON ecommerce_orders;
+DROP POLICY IF EXISTS tenant_isolation_ecommerce_integrations ON ecommerce_integrations;
+DROP TABLE IF EXISTS ecommerce_product_links;
+DROP TABLE IF EXISTS ecommerce_orders;
+DROP TABLE IF EXISTS ecommerce_integrations;
@@ -0,0 +1,82 @@
+-- Shopify and WooCommerce store integrations: order webhooks become online sales with reserved
+-- stock, and stock levels of linked products are pushed back to the store
+
+CREATE TABLE ecommerce_integrations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL CHECK (platform IN ('shopify', 'woocommerce')),
+    name VARCHAR(100) NOT NULL,
+    store_url VARCHAR(2048) NOT NULL,
+    webhook_secret VARCHAR(255) NOT NULL,
+    access_token VARCHAR(255),
+    consumer_key VARCHAR(255),
+    consumer_secret VARCHAR(255),
+    location_id VARCHAR(50),
+    push_stock BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    last_order_at TIMESTAMP WITH TIME ZONE,
+    last_stock_push_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_ecommerce_integrations_tenant_name UNIQUE (tenant_id, name)
+);
+
+CREATE INDEX idx_ecommerce_integrations_stock_push ON ecommerce_integrations(tenant_id) WHERE is_active AND push_stock;
+
+-- Orders received from the store, one per store order
+CREATE TABLE ecommerce_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    integration_id UUID NOT NULL REFERENCES ecommerce_integrations(id) ON DELETE CASCADE,
+    external_id VARCHAR(100) NOT NULL,
+    order_number VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'fulfilled', 'cancelled', 'failed')),
+    sale_id UUID REFERENCES sales(id) ON DELETE SET NULL,
+    reservation_id UUID REFERENCES stock_reservations(id) ON DELETE SET NULL,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_ecommerce_orders_integration_external UNIQUE (integration_id, external_id)
+);
+
+CREATE INDEX idx_ecommerce_orders_integration_created ON ecommerce_orders(integration_id, created_at DESC);
+CREATE INDEX idx_ecommerce_orders_tenant_id ON ecommerce_orders(tenant_id);
+
+-- Products linked to their product or variant on the store
+CREATE TABLE ecommerce_product_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    integration_id UUID NOT NULL REFERENCES ecommerce_integrations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    external_product_id VARCHAR(100) NOT NULL,
+    external_variant_id VARCHAR(100),
+    inventory_item_id VARCHAR(100),
+    last_pushed_qty INTEGER,
+    last_pushed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_ecommerce_product_links_integration_product UNIQUE (integration_id, product_id)
+);
+
+CREATE INDEX idx_ecommerce_product_links_tenant_id ON ecommerce_product_links(tenant_id);
+
+-- Enable Row Level Security
+ALTER TABLE ecommerce_integrations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ecommerce_orders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ecommerce_product_links ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_ecommerce_integrations ON ecommerce_integrations
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_ecommerce_orders ON ecommerce_orders
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_ecommerce_product_links ON ecommerce_product_links
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at triggers
+CREATE TRIGGER update_ecommerce_integrations_updated_at BEFORE UPDATE ON ecommerce_integrations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_ecommerce_orders_updated_at BEFORE UPDATE ON ecommerce_orders FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_ecommerce_product_links_updated_at BEFORE UPDATE ON ecommerce_product_links FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
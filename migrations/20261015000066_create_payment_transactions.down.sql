@@ -0,0 +1,5 @@
+-- Rollback payment transactions
+
+DROP TRIGGER IF EXISTS update_payment_transactions_updated_at ON payment_transactions;
+DROP POLICY IF EXISTS tenant_isolation_payment_transactions ON payment_transactions;
+DROP TABLE IF EXISTS payment_transactions;
@@ -0,0 +1,41 @@
+-- Card and digital wallet payments of sales and invoices collected through Stripe or Midtrans.
+-- The gateway confirms a payment by webhook, which completes the sale or pays the invoice.
+
+CREATE TABLE payment_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('stripe', 'midtrans')),
+    sale_id UUID REFERENCES sales(id) ON DELETE CASCADE,
+    invoice_id UUID REFERENCES invoices(id) ON DELETE CASCADE,
+    payment_method VARCHAR(20) NOT NULL CHECK (payment_method IN ('card', 'digital_wallet')),
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    coupon_code VARCHAR(32),
+    tax_percentage DECIMAL(5,2) NOT NULL DEFAULT 0,
+    external_id VARCHAR(255),
+    client_secret VARCHAR(255),
+    redirect_url VARCHAR(2048),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    gateway_status VARCHAR(50),
+    error TEXT,
+    succeeded_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_payment_transactions_document CHECK ((sale_id IS NULL) <> (invoice_id IS NULL)),
+    CONSTRAINT uk_payment_transactions_provider_external UNIQUE (provider, external_id)
+);
+
+CREATE INDEX idx_payment_transactions_tenant_created ON payment_transactions(tenant_id, created_at DESC);
+CREATE INDEX idx_payment_transactions_sale_id ON payment_transactions(sale_id) WHERE sale_id IS NOT NULL;
+CREATE INDEX idx_payment_transactions_invoice_id ON payment_transactions(invoice_id) WHERE invoice_id IS NOT NULL;
+
+-- Enable Row Level Security
+ALTER TABLE payment_transactions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_payment_transactions ON payment_transactions
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_payment_transactions_updated_at BEFORE UPDATE ON payment_transactions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
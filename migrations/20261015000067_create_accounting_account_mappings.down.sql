@@ -0,0 +1,6 @@
+-- Rollback accounting account mappings
+
+DROP TRIGGER IF EXISTS update_accounting_account_mappings_updated_at ON accounting_account_mappings;
+DROP POLICY IF EXISTS tenant_isolation_accounting_account_mappings ON accounting_account_mappings;
+
+DROP TABLE IF EXISTS accounting_account_mappings;
@@ -0,0 +1,28 @@
+-- Accounts of each tenant's chart of accounts that exported journal entries are posted to
+
+CREATE TABLE accounting_account_mappings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL UNIQUE REFERENCES tenants(id) ON DELETE CASCADE,
+    sales_revenue_account VARCHAR(50) NOT NULL,
+    sales_returns_account VARCHAR(50) NOT NULL,
+    tax_payable_account VARCHAR(50) NOT NULL,
+    cost_of_goods_sold_account VARCHAR(50) NOT NULL,
+    inventory_account VARCHAR(50) NOT NULL,
+    cash_account VARCHAR(50) NOT NULL,
+    card_account VARCHAR(50) NOT NULL,
+    digital_wallet_account VARCHAR(50) NOT NULL,
+    bank_transfer_account VARCHAR(50) NOT NULL,
+    zero_tax_rate VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID NOT NULL REFERENCES users(id)
+);
+
+-- Enable Row Level Security
+ALTER TABLE accounting_account_mappings ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_accounting_account_mappings ON accounting_account_mappings
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_accounting_account_mappings_updated_at BEFORE UPDATE ON accounting_account_mappings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
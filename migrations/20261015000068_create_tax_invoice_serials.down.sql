@@ -0,0 +1,11 @@
+-- Rollback tax invoice serials
+
+DROP TRIGGER IF EXISTS update_tax_invoice_serial_ranges_updated_at ON tax_invoice_serial_ranges;
+DROP POLICY IF EXISTS tenant_isolation_tax_invoice_serials ON tax_invoice_serials;
+DROP POLICY IF EXISTS tenant_isolation_tax_invoice_serial_ranges ON tax_invoice_serial_ranges;
+
+DROP INDEX IF EXISTS idx_invoices_tenant_paid_at;
+DROP TABLE IF EXISTS tax_invoice_serials;
+DROP TABLE IF EXISTS tax_invoice_serial_ranges;
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS customer_tax_id;
@@ -0,0 +1,48 @@
+-- Customer tax IDs on invoices and the tax invoice serial numbers (e.g. Indonesian NSFP)
+-- allocated to tenants by the tax authority and assigned to exported tax invoices
+
+ALTER TABLE invoices ADD COLUMN customer_tax_id VARCHAR(30) NOT NULL DEFAULT '';
+
+CREATE TABLE tax_invoice_serial_ranges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    branch_code CHAR(3) NOT NULL DEFAULT '000',
+    year INTEGER NOT NULL CHECK (year >= 2000 AND year <= 2099),
+    first_number BIGINT NOT NULL CHECK (first_number >= 1),
+    last_number BIGINT NOT NULL CHECK (last_number <= 99999999),
+    next_number BIGINT NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_tax_invoice_serial_ranges_bounds CHECK (first_number <= last_number),
+    CONSTRAINT chk_tax_invoice_serial_ranges_next CHECK (next_number >= first_number AND next_number <= last_number + 1)
+);
+
+CREATE TABLE tax_invoice_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL UNIQUE REFERENCES invoices(id),
+    range_id UUID NOT NULL REFERENCES tax_invoice_serial_ranges(id),
+    serial VARCHAR(20) NOT NULL,
+    assigned_by UUID NOT NULL REFERENCES users(id),
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_tax_invoice_serials_tenant_serial UNIQUE (tenant_id, serial)
+);
+
+-- Create indexes for tax invoice serials
+CREATE INDEX idx_tax_invoice_serial_ranges_tenant_year ON tax_invoice_serial_ranges(tenant_id, year);
+CREATE INDEX idx_tax_invoice_serials_range_id ON tax_invoice_serials(range_id);
+CREATE INDEX idx_invoices_tenant_paid_at ON invoices(tenant_id, paid_at) WHERE paid_at IS NOT NULL;
+
+-- Enable Row Level Security
+ALTER TABLE tax_invoice_serial_ranges ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tax_invoice_serials ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_tax_invoice_serial_ranges ON tax_invoice_serial_ranges
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+CREATE POLICY tenant_isolation_tax_invoice_serials ON tax_invoice_serials
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_tax_invoice_serial_ranges_updated_at BEFORE UPDATE ON tax_invoice_serial_ranges FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Rollback invoice share links
+
+DROP TRIGGER IF EXISTS update_invoice_share_links_updated_at ON invoice_share_links;
+DROP POLICY IF EXISTS tenant_isolation_invoice_share_links ON invoice_share_links;
+
+DROP TABLE IF EXISTS invoice_share_links;
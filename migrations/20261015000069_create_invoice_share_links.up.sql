@@ -0,0 +1,30 @@
+-- Public share links of invoices. Anyone holding a link's URL can view the invoice, download
+-- its PDF and, when a payment gateway is set, pay it, until the link expires or is revoked.
+
+CREATE TABLE invoice_share_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    payment_provider VARCHAR(20) NOT NULL DEFAULT '' CHECK (payment_provider IN ('', 'stripe', 'midtrans')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for invoice share links
+CREATE UNIQUE INDEX idx_invoice_share_links_token_hash ON invoice_share_links(token_hash);
+CREATE INDEX idx_invoice_share_links_invoice_id ON invoice_share_links(invoice_id, created_at DESC);
+
+-- Enable Row Level Security
+ALTER TABLE invoice_share_links ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_invoice_share_links ON invoice_share_links
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_invoice_share_links_updated_at BEFORE UPDATE ON invoice_share_links FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Rollback sale receipt email template
+
+DELETE FROM email_templates WHERE kind = 'sale_receipt';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_kind_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_kind_check
+    CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice', 'quote', 'user_invitation'));
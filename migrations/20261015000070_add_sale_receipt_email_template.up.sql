@@ -0,0 +1,5 @@
+-- Receipts of sales without an invoice are emailed with their own template, which tenants
+-- can override like the invoice emails
+ALTER TABLE email_templates DROP CONSTRAINT email_templates_kind_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_kind_check
+    CHECK (kind IN ('invoice', 'receipt', 'payment_confirmation', 'reminder', 'overdue_notice', 'quote', 'user_invitation', 'sale_receipt'));
@@ -0,0 +1,6 @@
+-- Rollback invoice templates
+
+DROP TRIGGER IF EXISTS update_invoice_templates_updated_at ON invoice_templates;
+DROP POLICY IF EXISTS tenant_isolation_invoice_templates ON invoice_templates;
+
+DROP TABLE IF EXISTS invoice_templates;
@@ -0,0 +1,31 @@
+-- Invoice templates tenants design and store under a name. The design holds the paper size,
+-- company details, header and footer, accent color, hidden columns and custom fields; the
+-- logo is stored apart from it. A tenant's default template renders invoices that do not
+-- name one.
+
+CREATE TABLE invoice_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    design JSONB NOT NULL,
+    logo BYTEA,
+    logo_content_type VARCHAR(50) NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_invoice_templates_tenant_name UNIQUE (tenant_id, name)
+);
+
+-- A tenant has at most one default template
+CREATE UNIQUE INDEX idx_invoice_templates_tenant_default ON invoice_templates(tenant_id) WHERE is_default;
+
+-- Enable Row Level Security
+ALTER TABLE invoice_templates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_invoice_templates ON invoice_templates
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+
+-- Create updated_at trigger
+CREATE TRIGGER update_invoice_templates_updated_at BEFORE UPDATE ON invoice_templates FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
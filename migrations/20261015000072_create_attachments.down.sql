@@ -0,0 +1,5 @@
+-- Rollback attachments
+
+DROP POLICY IF EXISTS tenant_isolation_attachments ON attachments;
+
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,24 @@
+-- Files attached to invoices and sales, such as delivery notes and signed proofs. The content
+-- is kept in file storage under storage_path; the owner is an invoice or a sale by owner_type.
+
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    owner_type VARCHAR(20) NOT NULL CHECK (owner_type IN ('invoice', 'sale')),
+    owner_id UUID NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    storage_path VARCHAR(500) NOT NULL UNIQUE,
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_owner ON attachments(owner_type, owner_id, created_at);
+CREATE INDEX idx_attachments_tenant_id ON attachments(tenant_id);
+
+-- Enable Row Level Security
+ALTER TABLE attachments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_attachments ON attachments
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,5 @@
+-- Rollback stock movement reversals
+
+DROP INDEX IF EXISTS idx_stock_movements_reversal_of;
+
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS reversal_of;
@@ -0,0 +1,6 @@
+-- A mistaken stock movement is undone by a movement in the opposite direction pointing back
+-- to it. A movement can be reversed only once.
+
+ALTER TABLE stock_movements ADD COLUMN reversal_of UUID REFERENCES stock_movements(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_stock_movements_reversal_of ON stock_movements(reversal_of) WHERE reversal_of IS NOT NULL;
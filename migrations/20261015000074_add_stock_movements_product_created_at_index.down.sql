@@ -0,0 +1,3 @@
+-- Rollback stock movement product ledger index
+
+DROP INDEX IF EXISTS idx_stock_movements_product_created_at;
@@ -0,0 +1,3 @@
+-- Product stock ledgers walk a product's movements in the order they were recorded
+
+CREATE INDEX idx_stock_movements_product_created_at ON stock_movements(product_id, created_at, id);
@@ -0,0 +1,5 @@
+-- Rollback sale payment references
+
+DROP INDEX IF EXISTS idx_sales_payment_reference;
+
+ALTER TABLE sales DROP COLUMN IF EXISTS payment_reference;
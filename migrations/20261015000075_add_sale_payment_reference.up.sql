@@ -0,0 +1,7 @@
+-- A sale paid through a payment gateway records the gateway's reference to the payment, e.g.
+-- "stripe:pi_123". A gateway payment completes at most one sale, so retried webhooks can tell a
+-- sale they already completed.
+
+ALTER TABLE sales ADD COLUMN payment_reference VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_sales_payment_reference ON sales(payment_reference) WHERE payment_reference IS NOT NULL;
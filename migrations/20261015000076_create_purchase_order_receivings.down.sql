@@ -0,0 +1,7 @@
+-- Rollback purchase order receiving sessions
+
+DROP POLICY IF EXISTS tenant_isolation_purchase_order_receiving_lines ON purchase_order_receiving_lines;
+DROP POLICY IF EXISTS tenant_isolation_purchase_order_receivings ON purchase_order_receivings;
+
+DROP TABLE IF EXISTS purchase_order_receiving_lines;
+DROP TABLE IF EXISTS purchase_order_receivings;
@@ -0,0 +1,55 @@
+-- Purchase order receiving sessions: deliveries received by scanning product barcodes. Each
+-- scan adds to the line of its purchase order item. A purchase order may be received over
+-- several sessions, one open at a time; completing a session receives its scanned quantities.
+
+CREATE TABLE purchase_order_receivings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed', 'cancelled')),
+    notes VARCHAR(500) NOT NULL DEFAULT '',
+    started_by UUID NOT NULL REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_by UUID REFERENCES users(id),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_purchase_order_receivings_completed CHECK ((status = 'open') = (completed_at IS NULL))
+);
+
+-- item_id is not a foreign key: purchase order items are replaced, under the same IDs, when
+-- their order is saved
+CREATE TABLE purchase_order_receiving_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    receiving_id UUID NOT NULL REFERENCES purchase_order_receivings(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id),
+    product_sku VARCHAR(255) NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    barcode VARCHAR(100) NOT NULL,
+    scanned_qty INTEGER NOT NULL CHECK (scanned_qty >= 0),
+    scan_count INTEGER NOT NULL DEFAULT 1 CHECK (scan_count > 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_purchase_order_receivings_purchase_order_id ON purchase_order_receivings(purchase_order_id, started_at);
+-- A purchase order has one open receiving session at a time
+CREATE UNIQUE INDEX uk_purchase_order_receivings_open ON purchase_order_receivings(purchase_order_id) WHERE status = 'open';
+-- An item has one line per session
+CREATE UNIQUE INDEX uk_purchase_order_receiving_lines_receiving_item ON purchase_order_receiving_lines(receiving_id, item_id);
+CREATE INDEX idx_purchase_order_receiving_lines_tenant_id ON purchase_order_receiving_lines(tenant_id);
+
+CREATE TRIGGER update_purchase_order_receivings_updated_at BEFORE UPDATE ON purchase_order_receivings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER inherit_purchase_order_receiving_lines_tenant_id BEFORE INSERT ON purchase_order_receiving_lines
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant_id('purchase_order_receivings', 'receiving_id');
+
+-- Enable Row Level Security
+ALTER TABLE purchase_order_receivings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE purchase_order_receiving_lines ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_purchase_order_receivings ON purchase_order_receivings
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_purchase_order_receiving_lines ON purchase_order_receiving_lines
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,13 @@
+-- Rollback customers
+
+DROP INDEX IF EXISTS idx_invoices_customer_id;
+DROP INDEX IF EXISTS idx_sales_customer_id;
+
+ALTER TABLE invoices DROP COLUMN IF EXISTS customer_id;
+ALTER TABLE sales DROP COLUMN IF EXISTS customer_id;
+
+DROP POLICY IF EXISTS tenant_isolation_customer_duplicates ON customer_duplicates;
+DROP POLICY IF EXISTS tenant_isolation_customers ON customers;
+
+DROP TABLE IF EXISTS customer_duplicates;
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,68 @@
+-- Customers: the people sales and invoices are made out to. Sales and invoices keep their own
+-- snapshot of the customer's details; a scheduled job links settled ones to a customer by their
+-- normalized email or phone number, existing documents included, and suggests customers that
+-- look like the same person for merging. Merging re-links the merged customer's documents to
+-- the survivor.
+
+CREATE TABLE customers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(255) NOT NULL DEFAULT '',
+    email_key VARCHAR(255) NOT NULL DEFAULT '',
+    phone_key VARCHAR(255) NOT NULL DEFAULT '',
+    merged_into UUID REFERENCES customers(id),
+    merged_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_customers_contact CHECK (email_key <> '' OR phone_key <> ''),
+    CONSTRAINT chk_customers_merged CHECK ((merged_into IS NULL) = (merged_at IS NULL) AND merged_into IS DISTINCT FROM id)
+);
+
+-- A tenant has one customer per email, and one per phone number among customers without an email
+CREATE UNIQUE INDEX uk_customers_email_key ON customers(tenant_id, email_key)
+    WHERE email_key <> '' AND merged_into IS NULL;
+CREATE UNIQUE INDEX uk_customers_phone_key ON customers(tenant_id, phone_key)
+    WHERE email_key = '' AND phone_key <> '' AND merged_into IS NULL;
+CREATE INDEX idx_customers_phone_key ON customers(tenant_id, phone_key) WHERE phone_key <> '';
+CREATE INDEX idx_customers_merged_into ON customers(merged_into) WHERE merged_into IS NOT NULL;
+
+CREATE TABLE customer_duplicates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    duplicate_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    score NUMERIC(3, 2) NOT NULL CHECK (score > 0 AND score <= 1),
+    reasons TEXT[] NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'merged', 'dismissed')),
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_customer_duplicates_pair CHECK (customer_id <> duplicate_id)
+);
+
+-- A pair of customers is suggested once, in either order
+CREATE UNIQUE INDEX uk_customer_duplicates_pair ON customer_duplicates(
+    LEAST(customer_id, duplicate_id), GREATEST(customer_id, duplicate_id));
+CREATE INDEX idx_customer_duplicates_pending ON customer_duplicates(tenant_id, score DESC) WHERE status = 'pending';
+CREATE INDEX idx_customer_duplicates_duplicate_id ON customer_duplicates(duplicate_id);
+
+ALTER TABLE sales ADD COLUMN customer_id UUID REFERENCES customers(id);
+ALTER TABLE invoices ADD COLUMN customer_id UUID REFERENCES customers(id);
+
+CREATE INDEX idx_sales_customer_id ON sales(customer_id) WHERE customer_id IS NOT NULL;
+CREATE INDEX idx_invoices_customer_id ON invoices(customer_id) WHERE customer_id IS NOT NULL;
+
+CREATE TRIGGER update_customers_updated_at BEFORE UPDATE ON customers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_customer_duplicates_updated_at BEFORE UPDATE ON customer_duplicates FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Enable Row Level Security
+ALTER TABLE customers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE customer_duplicates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation_customers ON customers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
+CREATE POLICY tenant_isolation_customer_duplicates ON customer_duplicates
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::UUID);
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Record is the content of a stored audit event that its chain hash covers. JSON values are
+// hashed in canonical form, so the hash survives the database normalizing them.
+type Record struct {
+	Sequence     int64
+	ID           string
+	TenantID     string
+	UserID       string
+	Action       string
+	Resource     string
+	ResourceID   string
+	OldValue     []byte
+	NewValue     []byte
+	Changes      []byte
+	IPAddress    string
+	UserAgent    string
+	Timestamp    time.Time
+	Success      bool
+	ErrorMessage string
+}
+
+// ChainTimestamp returns t at the precision audit events are stored with, so an event hashes
+// the same before it is stored and after it is read back
+func ChainTimestamp(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// Hash returns the hash of a record chained to the hash of the record logged before it, which
+// is empty for the first record of a chain. Changing, removing or reordering any record changes
+// the hashes of all the records after it.
+func Hash(previous string, record Record) (string, error) {
+	oldValue, err := canonicalJSON(record.OldValue)
+	if err != nil {
+		return "", err
+	}
+	newValue, err := canonicalJSON(record.NewValue)
+	if err != nil {
+		return "", err
+	}
+	changes, err := canonicalJSON(record.Changes)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal([]interface{}{
+		previous,
+		record.Sequence,
+		record.ID,
+		record.TenantID,
+		record.UserID,
+		record.Action,
+		record.Resource,
+		record.ResourceID,
+		oldValue,
+		newValue,
+		changes,
+		record.IPAddress,
+		record.UserAgent,
+		ChainTimestamp(record.Timestamp).Format(time.RFC3339Nano),
+		record.Success,
+		record.ErrorMessage,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes a JSON document with sorted object keys and no insignificant
+// whitespace. Empty documents are treated as null.
+func canonicalJSON(data []byte) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return json.RawMessage("null"), nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return canonical, nil
+}
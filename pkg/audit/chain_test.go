@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord() Record {
+	return Record{
+		Sequence:   1,
+		ID:         "0b6f0c4e-4c1a-4d7e-9a55-2f0f3f1f7a01",
+		TenantID:   "7d1f4a2b-0c3e-4b5a-8f6d-9e8c7b6a5d40",
+		UserID:     "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d",
+		Action:     "update",
+		Resource:   "product",
+		ResourceID: "3f2e1d0c-9b8a-4765-8432-10fedcba9876",
+		OldValue:   []byte(`{"price": 10, "name": "Coffee"}`),
+		NewValue:   []byte(`{"name":"Coffee","price":12}`),
+		IPAddress:  "203.0.113.7",
+		UserAgent:  "pos-terminal/2.1",
+		Timestamp:  time.Date(2026, 10, 15, 9, 30, 0, 123456789, time.UTC),
+		Success:    true,
+	}
+}
+
+func TestHash(t *testing.T) {
+	record := testRecord()
+
+	hash, err := Hash("", record)
+	require.NoError(t, err)
+	require.Len(t, hash, 64)
+
+	again, err := Hash("", record)
+	require.NoError(t, err)
+	require.Equal(t, hash, again)
+
+	t.Run("survives JSON and timestamp normalization", func(t *testing.T) {
+		stored := record
+		stored.OldValue = []byte(`{"name": "Coffee", "price": 10}`)
+		stored.NewValue = []byte(`{"name": "Coffee", "price": 12}`)
+		stored.Changes = []byte{}
+		stored.Timestamp = time.Date(2026, 10, 15, 16, 30, 0, 123456000, time.FixedZone("WIB", 7*3600))
+
+		storedHash, err := Hash("", stored)
+		require.NoError(t, err)
+		require.Equal(t, hash, storedHash)
+	})
+
+	t.Run("covers the content", func(t *testing.T) {
+		tampered := record
+		tampered.NewValue = []byte(`{"name":"Coffee","price":1}`)
+
+		tamperedHash, err := Hash("", tampered)
+		require.NoError(t, err)
+		require.NotEqual(t, hash, tamperedHash)
+
+		tampered = record
+		tampered.Success = false
+
+		tamperedHash, err = Hash("", tampered)
+		require.NoError(t, err)
+		require.NotEqual(t, hash, tamperedHash)
+	})
+
+	t.Run("covers the previous hash", func(t *testing.T) {
+		chained, err := Hash(hash, record)
+		require.NoError(t, err)
+		require.NotEqual(t, hash, chained)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		invalid := record
+		invalid.OldValue = []byte(`{"price":`)
+
+		_, err := Hash("", invalid)
+		require.Error(t, err)
+	})
+}
+
+func TestOriginFromContext(t *testing.T) {
+	_, ok := OriginFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := WithOrigin(context.Background(), Origin{IPAddress: "203.0.113.7", UserAgent: "pos-terminal/2.1"})
+	origin, ok := OriginFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "203.0.113.7", origin.IPAddress)
+	require.Equal(t, "pos-terminal/2.1", origin.UserAgent)
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Change represents a single field-level change between two entity snapshots
+type Change struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// Snapshot is a flattened, JSON-shaped copy of an entity taken at a point in time.
+// Nested objects are flattened into dotted field names (e.g. "address.city").
+type Snapshot map[string]interface{}
+
+// ignoredFields are bookkeeping fields that change on every update and carry no audit value
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// TakeSnapshot captures the current state of an entity using its JSON representation,
+// so fields hidden from JSON (e.g. password hashes) never reach the audit trail.
+// It returns nil if the entity cannot be marshalled.
+func TakeSnapshot(entity interface{}) Snapshot {
+	if entity == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	snapshot := Snapshot{}
+	flatten("", fields, snapshot)
+	return snapshot
+}
+
+// Diff computes the field-level changes between two snapshots, ordered by field name
+func Diff(before, after Snapshot) []Change {
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []Change
+	for field := range fields {
+		if ignoredFields[field] {
+			continue
+		}
+
+		oldValue, newValue := before[field], after[field]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Field:    field,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// DiffEntities snapshots both entities and computes the field-level changes between them
+func DiffEntities(before, after interface{}) []Change {
+	return Diff(TakeSnapshot(before), TakeSnapshot(after))
+}
+
+// flatten copies nested objects into the snapshot using dotted field names
+func flatten(prefix string, fields map[string]interface{}, snapshot Snapshot) {
+	for key, value := range fields {
+		field := key
+		if prefix != "" {
+			field = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flatten(field, nested, snapshot)
+			continue
+		}
+
+		snapshot[field] = value
+	}
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type testEntity struct {
+	Name      string      `json:"name"`
+	Price     float64     `json:"price"`
+	Secret    string      `json:"-"`
+	Address   testAddress `json:"address"`
+	Tags      []string    `json:"tags"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	snapshot := TakeSnapshot(testEntity{
+		Name:    "Coffee",
+		Price:   2.5,
+		Secret:  "hidden",
+		Address: testAddress{City: "Bandung", Zip: "40111"},
+	})
+
+	require.Equal(t, "Coffee", snapshot["name"])
+	require.Equal(t, 2.5, snapshot["price"])
+	require.Equal(t, "Bandung", snapshot["address.city"])
+	require.NotContains(t, snapshot, "Secret")
+	require.NotContains(t, snapshot, "address")
+
+	require.Nil(t, TakeSnapshot(nil))
+	require.Nil(t, TakeSnapshot(make(chan int)))
+}
+
+func TestDiff(t *testing.T) {
+	before := testEntity{
+		Name:      "Coffee",
+		Price:     2.5,
+		Address:   testAddress{City: "Bandung", Zip: "40111"},
+		Tags:      []string{"drink"},
+		UpdatedAt: time.Now(),
+	}
+	after := before
+	after.Price = 3
+	after.Secret = "changed"
+	after.Address.City = "Jakarta"
+	after.Tags = []string{"drink", "hot"}
+	after.UpdatedAt = before.UpdatedAt.Add(time.Minute)
+
+	changes := DiffEntities(before, after)
+
+	require.Equal(t, []Change{
+		{Field: "address.city", OldValue: "Bandung", NewValue: "Jakarta"},
+		{Field: "price", OldValue: 2.5, NewValue: float64(3)},
+		{Field: "tags", OldValue: []interface{}{"drink"}, NewValue: []interface{}{"drink", "hot"}},
+	}, changes)
+
+	require.Empty(t, DiffEntities(before, before))
+}
+
+func TestDiff_AddedAndRemovedFields(t *testing.T) {
+	changes := Diff(Snapshot{"name": "Coffee"}, Snapshot{"sku": "CF-01"})
+
+	require.Equal(t, []Change{
+		{Field: "name", OldValue: "Coffee", NewValue: nil},
+		{Field: "sku", OldValue: nil, NewValue: "CF-01"},
+	}, changes)
+}
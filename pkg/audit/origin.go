@@ -0,0 +1,23 @@
+package audit
+
+import "context"
+
+// Origin is where the request that caused an audit event came from
+type Origin struct {
+	IPAddress string
+	UserAgent string
+}
+
+// originKey is the context key carrying the request origin
+type originKey struct{}
+
+// WithOrigin returns a copy of ctx carrying the origin of the request it serves
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFromContext returns the origin of the request ctx serves, if it was captured
+func OriginFromContext(ctx context.Context) (Origin, bool) {
+	origin, ok := ctx.Value(originKey{}).(Origin)
+	return origin, ok
+}
@@ -0,0 +1,77 @@
+package barcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	for value, pattern := range code128Patterns {
+		expected := 11
+		if value == code128Stop {
+			expected = 13
+		}
+		assert.Equal(t, expected, sumWidths(pattern), "pattern %d", value)
+	}
+}
+
+func TestCode128(t *testing.T) {
+	t.Run("code set B", func(t *testing.T) {
+		widths, err := Code128("AB")
+
+		require.NoError(t, err)
+		// start, two characters, checksum and stop
+		assert.Equal(t, 4*11+13, sum(widths))
+		assert.Equal(t, toWidths(code128Patterns[code128StartB]), widths[:6])
+		// (104 + 1*33 + 2*34) mod 103 = 102
+		assert.Equal(t, toWidths(code128Patterns[102]), widths[18:24])
+		assert.Equal(t, toWidths(code128Patterns[code128Stop]), widths[24:])
+	})
+
+	t.Run("code set C for even digits", func(t *testing.T) {
+		widths, err := Code128("8991234")
+
+		require.NoError(t, err)
+		assert.Equal(t, toWidths(code128Patterns[code128StartB]), widths[:6])
+
+		widths, err = Code128("899123")
+
+		require.NoError(t, err)
+		assert.Equal(t, toWidths(code128Patterns[code128StartC]), widths[:6])
+		assert.Equal(t, 5*11+13, sum(widths))
+	})
+
+	t.Run("empty data", func(t *testing.T) {
+		_, err := Code128("")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported character", func(t *testing.T) {
+		_, err := Code128("café")
+
+		assert.Error(t, err)
+	})
+}
+
+func sumWidths(pattern string) int {
+	return sum(toWidths(pattern))
+}
+
+func toWidths(pattern string) []int {
+	widths := make([]int, len(pattern))
+	for i, w := range pattern {
+		widths[i] = int(w - '0')
+	}
+	return widths
+}
+
+func sum(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	return total
+}
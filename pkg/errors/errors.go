@@ -26,6 +26,7 @@ const (
 	ErrorTypeInvalidQuantity   ErrorType = "INVALID_QUANTITY"
 	ErrorTypeProductNotActive  ErrorType = "PRODUCT_NOT_ACTIVE"
 	ErrorTypeUserNotActive     ErrorType = "USER_NOT_ACTIVE"
+	ErrorTypeRuleViolation     ErrorType = "RULE_VIOLATION"
 )
 
 // AppError represents an application error
@@ -45,6 +46,11 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.Message)
 }
 
+// Unwrap returns the internal error, so callers can inspect the cause of the failure
+func (e *AppError) Unwrap() error {
+	return e.Internal
+}
+
 // NewAppError creates a new application error
 func NewAppError(errorType ErrorType, message string, internal error) *AppError {
 	return &AppError{
@@ -141,6 +147,16 @@ func NewInvalidQuantityError(quantity int) *AppError {
 	}
 }
 
+// NewRuleViolationError creates a business rule violation error
+func NewRuleViolationError(message string, details string) *AppError {
+	return &AppError{
+		Type:    ErrorTypeRuleViolation,
+		Message: message,
+		Details: details,
+		Code:    http.StatusUnprocessableEntity,
+	}
+}
+
 // getHTTPStatusCode returns the appropriate HTTP status code for an error type
 func getHTTPStatusCode(errorType ErrorType) int {
 	switch errorType {
@@ -158,6 +174,8 @@ func getHTTPStatusCode(errorType ErrorType) int {
 		return http.StatusRequestTimeout
 	case ErrorTypeRateLimit:
 		return http.StatusTooManyRequests
+	case ErrorTypeRuleViolation:
+		return http.StatusUnprocessableEntity
 	default:
 		return http.StatusInternalServerError
 	}
@@ -96,4 +96,17 @@ func (l *logrusLogger) WithFields(fields map[string]interface{}) Logger {
 		logger: l.logger,
 		entry:  l.entry.WithFields(fields),
 	}
-}
\ No newline at end of file
+}
+// AddHook attaches a logrus hook to a logger created by this package and every logger derived
+// from it, e.g. to forward entries to an external system. It reports false for other loggers.
+func AddHook(l Logger, hook logrus.Hook) bool {
+	switch l := l.(type) {
+	case *logrusLogger:
+		l.logger.AddHook(hook)
+	case *enhancedLogrusLogger:
+		l.logger.AddHook(hook)
+	default:
+		return false
+	}
+	return true
+}
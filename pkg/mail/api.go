@@ -0,0 +1,416 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// responseLimit caps how much of a provider's response body is read
+const responseLimit = 1 << 20
+
+// SendGridProvider sends messages through the SendGrid v3 mail send API
+type SendGridProvider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewSendGridProvider creates a SendGrid provider. baseURL defaults to the SendGrid API.
+func NewSendGridProvider(baseURL, apiKey string, timeout time.Duration) (*SendGridProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("SendGrid API key is required")
+	}
+	if baseURL == "" {
+		baseURL = "https://api.sendgrid.com"
+	}
+	return &SendGridProvider{
+		url:    strings.TrimRight(baseURL, "/") + "/v3/mail/send",
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Provider
+func (p *SendGridProvider) Name() string {
+	return "sendgrid"
+}
+
+// sendGridRequest is the body of a mail send request
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type,omitempty"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+// Send implements Provider
+func (p *SendGridProvider) Send(ctx context.Context, msg *Message) (*Result, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: err.Error(), Permanent: true}
+	}
+
+	body := sendGridRequest{
+		From:    sendGridAddress{Email: msg.From.Email, Name: msg.From.Name},
+		Subject: msg.Subject,
+		Content: []sendGridContent{{Type: "text/plain", Value: msg.Text}},
+	}
+	if msg.HTML != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+	var personalization sendGridPersonalization
+	for _, to := range msg.To {
+		personalization.To = append(personalization.To, sendGridAddress{Email: to})
+	}
+	body.Personalizations = []sendGridPersonalization{personalization}
+	for _, attachment := range msg.Attachments {
+		body.Attachments = append(body.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.Data),
+			Type:        attachment.ContentType,
+			Filename:    attachment.Filename,
+			Disposition: "attachment",
+		})
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: "failed to encode message", Permanent: true, Err: err}
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + p.apiKey}
+	resp, respBody, err := post(ctx, p.client, p.Name(), p.url, "application/json", payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// {"errors": [{"message": "...", "field": "...", "help": "..."}]}
+		var failure struct {
+			Errors []struct {
+				Message string `json:"message"`
+				Field   string `json:"field"`
+			} `json:"errors"`
+		}
+		json.Unmarshal(respBody, &failure)
+
+		var messages []string
+		for _, e := range failure.Errors {
+			if e.Field != "" {
+				messages = append(messages, e.Field+": "+e.Message)
+			} else {
+				messages = append(messages, e.Message)
+			}
+		}
+		return nil, httpError(p.Name(), resp.StatusCode, "", strings.Join(messages, "; "))
+	}
+
+	return &Result{Provider: p.Name(), MessageID: resp.Header.Get("X-Message-Id"), Status: StatusQueued}, nil
+}
+
+// SESProvider sends messages through the Amazon SES v2 API as raw MIME, signed with
+// Signature Version 4
+type SESProvider struct {
+	url             string
+	host            string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+// NewSESProvider creates an SES provider. endpoint defaults to the SES API of the region.
+func NewSESProvider(endpoint, region, accessKeyID, secretAccessKey string, timeout time.Duration) (*SESProvider, error) {
+	if region == "" {
+		return nil, fmt.Errorf("SES region is required")
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("SES access key ID and secret access key are required")
+	}
+	if endpoint == "" {
+		endpoint = "https://email." + region + ".amazonaws.com"
+	}
+	parsed, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("SES endpoint must be an absolute URL")
+	}
+
+	return &SESProvider{
+		url:             parsed.String() + "/v2/email/outbound-emails",
+		host:            parsed.Host,
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Provider
+func (p *SESProvider) Name() string {
+	return "ses"
+}
+
+// sesPermanentErrors lists the SES error codes rejecting the message itself. Other codes,
+// such as a paused account or throttling, clear up without changing the message.
+var sesPermanentErrors = map[string]bool{
+	"BadRequestException":                true,
+	"MessageRejected":                    true,
+	"MailFromDomainNotVerifiedException": true,
+}
+
+// Send implements Provider
+func (p *SESProvider) Send(ctx context.Context, msg *Message) (*Result, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: err.Error(), Permanent: true}
+	}
+
+	var body struct {
+		FromEmailAddress string `json:"FromEmailAddress"`
+		Destination      struct {
+			ToAddresses []string `json:"ToAddresses"`
+		} `json:"Destination"`
+		Content struct {
+			Raw struct {
+				Data []byte `json:"Data"` // Encoded as base64
+			} `json:"Raw"`
+		} `json:"Content"`
+	}
+	body.FromEmailAddress = msg.From.String()
+	body.Destination.ToAddresses = msg.To
+	body.Content.Raw.Data = buildMIME(msg, newMessageID(msg.From.Email))
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: "failed to encode message", Permanent: true, Err: err}
+	}
+
+	resp, respBody, err := post(ctx, p.client, p.Name(), p.url, "application/json", payload, p.signedHeaders(payload, time.Now().UTC()))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var failure struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(respBody, &failure)
+
+		// The error type may carry a namespace and details, e.g. aws.ses#MessageRejected:http://...
+		code := resp.Header.Get("X-Amzn-ErrorType")
+		if i := strings.LastIndex(code, "#"); i >= 0 {
+			code = code[i+1:]
+		}
+		if i := strings.Index(code, ":"); i >= 0 {
+			code = code[:i]
+		}
+
+		sendErr := httpError(p.Name(), resp.StatusCode, code, failure.Message)
+		if code != "" {
+			sendErr.Permanent = sesPermanentErrors[code]
+		}
+		return nil, sendErr
+	}
+
+	var accepted struct {
+		MessageID string `json:"MessageId"`
+	}
+	json.Unmarshal(respBody, &accepted)
+
+	return &Result{Provider: p.Name(), MessageID: accepted.MessageID, Status: StatusQueued}, nil
+}
+
+// signedHeaders returns the headers authenticating a request with Signature Version 4
+func (p *SESProvider) signedHeaders(payload []byte, now time.Time) map[string]string {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	scope := day + "/" + p.region + "/ses/aws4_request"
+	payloadHash := sha256Hex(payload)
+
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/v2/email/outbound-emails",
+		"",
+		"content-type:application/json\n" +
+			"host:" + p.host + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+p.secretAccessKey), day)
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, "ses")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return map[string]string{
+		"X-Amz-Date": amzDate,
+		"Authorization": fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+			p.accessKeyID, scope, signedHeaders, signature),
+	}
+}
+
+// MailgunProvider sends messages through the Mailgun messages API
+type MailgunProvider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewMailgunProvider creates a Mailgun provider sending from domain. baseURL defaults to the
+// US region API; EU domains use https://api.eu.mailgun.net.
+func NewMailgunProvider(baseURL, domain, apiKey string, timeout time.Duration) (*MailgunProvider, error) {
+	if domain == "" {
+		return nil, fmt.Errorf("Mailgun domain is required")
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("Mailgun API key is required")
+	}
+	if baseURL == "" {
+		baseURL = "https://api.mailgun.net"
+	}
+	return &MailgunProvider{
+		url:    strings.TrimRight(baseURL, "/") + "/v3/" + url.PathEscape(domain) + "/messages",
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements Provider
+func (p *MailgunProvider) Name() string {
+	return "mailgun"
+}
+
+// Send implements Provider
+func (p *MailgunProvider) Send(ctx context.Context, msg *Message) (*Result, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: err.Error(), Permanent: true}
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("from", msg.From.String())
+	for _, to := range msg.To {
+		form.WriteField("to", to)
+	}
+	form.WriteField("subject", msg.Subject)
+	form.WriteField("text", msg.Text)
+	if msg.HTML != "" {
+		form.WriteField("html", msg.HTML)
+	}
+	for _, attachment := range msg.Attachments {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="attachment"; filename=%q`, attachment.Filename))
+		if attachment.ContentType != "" {
+			header.Set("Content-Type", attachment.ContentType)
+		}
+		part, err := form.CreatePart(header)
+		if err != nil {
+			return nil, &SendError{Provider: p.Name(), Message: "failed to encode message", Permanent: true, Err: err}
+		}
+		part.Write(attachment.Data)
+	}
+	if err := form.Close(); err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: "failed to encode message", Permanent: true, Err: err}
+	}
+
+	headers := map[string]string{"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte("api:"+p.apiKey))}
+	resp, respBody, err := post(ctx, p.client, p.Name(), p.url, form.FormDataContentType(), body.Bytes(), headers)
+	if err != nil {
+		return nil, err
+	}
+
+	// {"id": "<...@domain>", "message": "Queued. Thank you."}
+	var reply struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	json.Unmarshal(respBody, &reply)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if reply.Message == "" {
+			reply.Message = strings.TrimSpace(string(respBody))
+		}
+		return nil, httpError(p.Name(), resp.StatusCode, "", reply.Message)
+	}
+
+	return &Result{Provider: p.Name(), MessageID: reply.ID, Status: StatusQueued}, nil
+}
+
+// post sends a request to a provider API and returns its response and body. Only a failure
+// to get a response is returned as an error; the caller maps rejected requests.
+func post(ctx context.Context, client *http.Client, provider, url, contentType string, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, &SendError{Provider: provider, Message: "failed to build request", Permanent: true, Err: err}
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, transportError(provider, "request failed", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, responseLimit))
+	if err != nil {
+		return nil, nil, transportError(provider, "failed to read response", err)
+	}
+
+	return resp, respBody, nil
+}
+
+// sha256Hex returns the hex encoded SHA-256 hash of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 signs data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
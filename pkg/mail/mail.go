@@ -0,0 +1,241 @@
+// Package mail sends email through a pluggable provider: an SMTP server or the HTTP APIs of
+// SendGrid, Amazon SES and Mailgun. Providers map their failures onto SendError so callers
+// can tell a message that will never be accepted from one worth retrying, and report the
+// provider's message ID of every accepted message for tracing its delivery.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/http"
+	netmail "net/mail"
+	"strings"
+	"time"
+)
+
+// Delivery statuses reported for an accepted message
+const (
+	StatusSent   = "sent"   // Handed to the recipient's mail server or a relay
+	StatusQueued = "queued" // Accepted by the provider, which delivers it asynchronously
+)
+
+// Provider sends email messages
+type Provider interface {
+	// Name returns the name of the provider, e.g. smtp or sendgrid
+	Name() string
+
+	// Send sends a message. A failure is returned as a *SendError.
+	Send(ctx context.Context, msg *Message) (*Result, error)
+}
+
+// Address is a sender or recipient
+type Address struct {
+	Name  string
+	Email string
+}
+
+// String formats the address for a message header
+func (a Address) String() string {
+	return (&netmail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is a plain text email with an optional HTML alternative and attachments
+type Message struct {
+	From        Address
+	To          []string
+	Subject     string
+	Text        string
+	HTML        string // Shown instead of Text by clients that render HTML
+	Attachments []Attachment
+}
+
+// Validate checks the message has a sender, recipients and a subject
+func (m *Message) Validate() error {
+	if m.From.Email == "" {
+		return fmt.Errorf("sender address is required")
+	}
+	if len(m.To) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+	if m.Subject == "" {
+		return fmt.Errorf("subject is required")
+	}
+	return nil
+}
+
+// Result reports a message accepted by a provider
+type Result struct {
+	Provider  string
+	MessageID string // ID the provider assigned to the message, used to trace its delivery
+	Status    string // StatusSent or StatusQueued
+}
+
+// SendError is a message a provider failed to send
+type SendError struct {
+	Provider   string
+	StatusCode int    // HTTP status or SMTP reply code, 0 if the provider was not reached
+	Code       string // Provider specific error code, if any
+	Message    string
+	Permanent  bool // Resending the same message will fail again, e.g. a rejected recipient
+	Err        error
+}
+
+// Error implements error
+func (e *SendError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Provider)
+	b.WriteString(": ")
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "status %d: ", e.StatusCode)
+	}
+	if e.Code != "" {
+		b.WriteString(e.Code)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Message)
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent reports whether err is a failure that resending the message cannot fix
+func IsPermanent(err error) bool {
+	var sendErr *SendError
+	return errors.As(err, &sendErr) && sendErr.Permanent
+}
+
+// transportError wraps a failure to reach a provider, which is always worth retrying
+func transportError(provider, message string, err error) *SendError {
+	return &SendError{Provider: provider, Message: message, Err: err}
+}
+
+// httpError maps a rejected API request onto a SendError. A 4xx response means the provider
+// will not accept the message as it is, except for authentication failures and unknown
+// sending domains, which need the provider configuration fixed, and timeouts and rate
+// limiting, which pass.
+func httpError(provider string, status int, code, message string) *SendError {
+	permanent := status >= 400 && status < 500
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		permanent = false
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &SendError{Provider: provider, StatusCode: status, Code: code, Message: message, Permanent: permanent}
+}
+
+// newMessageID generates a Message-ID for the sender's domain
+func newMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), randomHex(8), domain)
+}
+
+// randomHex returns n random bytes as hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// buildMIME encodes a message as RFC 5322. The text and HTML alternatives are sent as a
+// multipart/alternative body, wrapped in a multipart/mixed body with the attachments if
+// there are any. messageID is set as its Message-ID header.
+func buildMIME(msg *Message, messageID string) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("From: " + msg.From.String() + "\r\n")
+	b.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("Message-ID: " + messageID + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(msg.Attachments) == 0 {
+		writeBody(&b, msg)
+		return b.Bytes()
+	}
+
+	boundary := "adol-" + randomHex(12)
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary))
+
+	b.WriteString("--" + boundary + "\r\n")
+	writeBody(&b, msg)
+
+	for _, attachment := range msg.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		b.WriteString("\r\n--" + boundary + "\r\n")
+		b.WriteString("Content-Type: " + contentType + "\r\n")
+		b.WriteString(fmt.Sprintf("Content-Disposition: attachment; filename=%q\r\n", attachment.Filename))
+		b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			b.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		b.WriteString(encoded + "\r\n")
+	}
+
+	b.WriteString("--" + boundary + "--\r\n")
+
+	return b.Bytes()
+}
+
+// writeBody writes the text part of a message, or a multipart/alternative part holding its
+// text and HTML alternatives
+func writeBody(b *bytes.Buffer, msg *Message) {
+	if msg.HTML == "" {
+		writeTextPart(b, "text/plain", msg.Text)
+		return
+	}
+
+	boundary := "adol-alt-" + randomHex(12)
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary))
+
+	// Clients show the last alternative they can render, so the HTML part goes last
+	b.WriteString("--" + boundary + "\r\n")
+	writeTextPart(b, "text/plain", msg.Text)
+	b.WriteString("\r\n--" + boundary + "\r\n")
+	writeTextPart(b, "text/html", msg.HTML)
+	b.WriteString("--" + boundary + "--\r\n")
+}
+
+// writeTextPart writes the headers and quoted-printable body of a text part
+func writeTextPart(b *bytes.Buffer, contentType, text string) {
+	b.WriteString("Content-Type: " + contentType + "; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(b)
+	w.Write([]byte(strings.ReplaceAll(text, "\n", "\r\n")))
+	w.Close()
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,278 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage() *Message {
+	return &Message{
+		From:        Address{Name: "ADOL POS", Email: "billing@adol.test"},
+		To:          []string{"customer@example.com"},
+		Subject:     "Invoice INV-001",
+		Text:        "Please find your invoice attached.",
+		Attachments: []Attachment{{Filename: "invoice_INV-001.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}},
+	}
+}
+
+func TestBuildMIME(t *testing.T) {
+	raw := string(buildMIME(testMessage(), "<1@adol.test>"))
+
+	require.Contains(t, raw, `From: "ADOL POS" <billing@adol.test>`)
+	require.Contains(t, raw, "To: customer@example.com\r\n")
+	require.Contains(t, raw, "Message-ID: <1@adol.test>\r\n")
+	require.Contains(t, raw, "Content-Type: multipart/mixed;")
+	require.Contains(t, raw, "Please find your invoice attached.")
+	require.Contains(t, raw, `filename="invoice_INV-001.pdf"`)
+	require.Contains(t, raw, base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")))
+
+	plain := testMessage()
+	plain.Attachments = nil
+	require.NotContains(t, string(buildMIME(plain, "<2@adol.test>")), "multipart")
+
+	html := testMessage()
+	html.HTML = "<p>Please find your invoice attached.</p>"
+	raw = string(buildMIME(html, "<3@adol.test>"))
+	require.Contains(t, raw, "Content-Type: multipart/mixed;")
+	require.Contains(t, raw, "Content-Type: multipart/alternative;")
+	require.Less(t, strings.Index(raw, "Content-Type: text/plain"), strings.Index(raw, "Content-Type: text/html"))
+	require.Contains(t, raw, "<p>Please find your invoice attached.</p>")
+
+	html.Attachments = nil
+	raw = string(buildMIME(html, "<4@adol.test>"))
+	require.NotContains(t, raw, "multipart/mixed")
+	require.Contains(t, raw, "Content-Type: multipart/alternative;")
+}
+
+func TestHTTPError(t *testing.T) {
+	require.True(t, IsPermanent(httpError("sendgrid", http.StatusBadRequest, "", "invalid email")))
+	require.False(t, IsPermanent(httpError("sendgrid", http.StatusUnauthorized, "", "")))
+	require.False(t, IsPermanent(httpError("sendgrid", http.StatusTooManyRequests, "", "")))
+	require.False(t, IsPermanent(httpError("sendgrid", http.StatusBadGateway, "", "")))
+	require.False(t, IsPermanent(fmt.Errorf("unrelated")))
+}
+
+func TestSendGridProvider(t *testing.T) {
+	status := http.StatusAccepted
+	var request sendGridRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v3/mail/send", r.URL.Path)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		if status != http.StatusAccepted {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"errors":[{"message":"Does not contain a valid address.","field":"personalizations.0.to"}]}`))
+			return
+		}
+		w.Header().Set("X-Message-Id", "sg-123")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	provider, err := NewSendGridProvider(server.URL, "secret", time.Second)
+	require.NoError(t, err)
+
+	result, err := provider.Send(context.Background(), testMessage())
+	require.NoError(t, err)
+	require.Equal(t, &Result{Provider: "sendgrid", MessageID: "sg-123", Status: StatusQueued}, result)
+	require.Equal(t, "customer@example.com", request.Personalizations[0].To[0].Email)
+	require.Equal(t, "invoice_INV-001.pdf", request.Attachments[0].Filename)
+
+	status = http.StatusBadRequest
+	_, err = provider.Send(context.Background(), testMessage())
+	require.ErrorContains(t, err, "personalizations.0.to: Does not contain a valid address.")
+	require.True(t, IsPermanent(err))
+
+	_, err = NewSendGridProvider("", "", time.Second)
+	require.Error(t, err)
+}
+
+func TestSESProvider(t *testing.T) {
+	errorType := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/email/outbound-emails", r.URL.Path)
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		require.Contains(t, r.Header.Get("Authorization"), "/ap-southeast-1/ses/aws4_request")
+		require.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+
+		var body struct {
+			Destination struct {
+				ToAddresses []string
+			}
+			Content struct {
+				Raw struct {
+					Data []byte
+				}
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, []string{"customer@example.com"}, body.Destination.ToAddresses)
+		require.Contains(t, string(body.Content.Raw.Data), "Subject: Invoice INV-001")
+
+		if errorType != "" {
+			w.Header().Set("X-Amzn-ErrorType", errorType)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"Email address is not verified."}`))
+			return
+		}
+		w.Write([]byte(`{"MessageId":"ses-123"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewSESProvider(server.URL, "ap-southeast-1", "AKID", "secret", time.Second)
+	require.NoError(t, err)
+
+	result, err := provider.Send(context.Background(), testMessage())
+	require.NoError(t, err)
+	require.Equal(t, "ses-123", result.MessageID)
+
+	errorType = "MessageRejected:http://internal.amazon.com/coral/com.amazonaws.ses/"
+	_, err = provider.Send(context.Background(), testMessage())
+	require.ErrorContains(t, err, "MessageRejected: Email address is not verified.")
+	require.True(t, IsPermanent(err))
+
+	errorType = "SendingPausedException"
+	_, err = provider.Send(context.Background(), testMessage())
+	require.Error(t, err)
+	require.False(t, IsPermanent(err))
+}
+
+func TestMailgunProvider(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v3/mg.adol.test/messages", r.URL.Path)
+		username, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "api", username)
+		require.Equal(t, "secret", password)
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Equal(t, "customer@example.com", r.FormValue("to"))
+		require.Equal(t, "Invoice INV-001", r.FormValue("subject"))
+		file, header, err := r.FormFile("attachment")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		require.Equal(t, "invoice_INV-001.pdf", header.Filename)
+		require.Equal(t, "%PDF-1.4", string(data))
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"id":"<mg-123@mg.adol.test>","message":"Queued. Thank you."}`))
+		} else {
+			w.Write([]byte(`{"message":"Domain not found: mg.adol.test"}`))
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewMailgunProvider(server.URL, "mg.adol.test", "secret", time.Second)
+	require.NoError(t, err)
+
+	result, err := provider.Send(context.Background(), testMessage())
+	require.NoError(t, err)
+	require.Equal(t, "<mg-123@mg.adol.test>", result.MessageID)
+
+	status = http.StatusNotFound
+	_, err = provider.Send(context.Background(), testMessage())
+	require.ErrorContains(t, err, "Domain not found")
+	require.False(t, IsPermanent(err))
+}
+
+// serveSMTP runs a minimal SMTP server accepting one session. rcptReply is the reply to
+// RCPT TO; the received message data is sent on the returned channel.
+func serveSMTP(t *testing.T, rcptReply string) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM"):
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO"):
+				reply(rcptReply)
+			case command == "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				received <- data.String()
+				reply("250 Queued")
+			case command == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSMTPProvider(t *testing.T) {
+	address, received := serveSMTP(t, "250 OK")
+	host, port, _ := net.SplitHostPort(address)
+
+	provider, err := NewSMTPProvider(host, port, "", "", time.Second)
+	require.NoError(t, err)
+
+	result, err := provider.Send(context.Background(), testMessage())
+	require.NoError(t, err)
+	require.Equal(t, StatusSent, result.Status)
+	require.Contains(t, result.MessageID, "@adol.test>")
+
+	data := <-received
+	require.Contains(t, data, "Subject: Invoice INV-001")
+	require.Contains(t, data, "Message-ID: "+result.MessageID)
+
+	address, _ = serveSMTP(t, "550 5.1.1 No such user")
+	host, port, _ = net.SplitHostPort(address)
+	provider, err = NewSMTPProvider(host, port, "", "", time.Second)
+	require.NoError(t, err)
+
+	_, err = provider.Send(context.Background(), testMessage())
+	require.ErrorContains(t, err, "status 550")
+	require.True(t, IsPermanent(err))
+
+	_, err = NewSMTPProvider("", "587", "", "", time.Second)
+	require.Error(t, err)
+}
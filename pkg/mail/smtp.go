@@ -0,0 +1,143 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// SMTPProvider sends messages through an SMTP server. Port 465 uses implicit TLS, other
+// ports upgrade the connection with STARTTLS when the server offers it.
+type SMTPProvider struct {
+	host     string
+	port     string
+	username string
+	password string
+	timeout  time.Duration
+}
+
+// NewSMTPProvider creates an SMTP provider. Authentication is skipped without a username.
+func NewSMTPProvider(host, port, username, password string, timeout time.Duration) (*SMTPProvider, error) {
+	if host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	if port == "" {
+		return nil, fmt.Errorf("SMTP port is required")
+	}
+	return &SMTPProvider{host: host, port: port, username: username, password: password, timeout: timeout}, nil
+}
+
+// Name implements Provider
+func (p *SMTPProvider) Name() string {
+	return "smtp"
+}
+
+// Send implements Provider
+func (p *SMTPProvider) Send(ctx context.Context, msg *Message) (*Result, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, &SendError{Provider: p.Name(), Message: err.Error(), Permanent: true}
+	}
+
+	messageID := newMessageID(msg.From.Email)
+	raw := buildMIME(msg, messageID)
+
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return nil, transportError(p.Name(), "failed to connect to SMTP server", err)
+	}
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return nil, p.replyError("failed to greet SMTP server", err)
+	}
+	defer client.Close()
+
+	if _, isTLS := conn.(*tls.Conn); !isTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: p.host}); err != nil {
+				return nil, p.replyError("failed to start TLS", err)
+			}
+		}
+	}
+
+	if p.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+			return nil, p.replyError("authentication failed", err)
+		}
+	}
+
+	if err := client.Mail(msg.From.Email); err != nil {
+		return nil, p.replyError("sender rejected", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return nil, p.replyError("recipient "+to+" rejected", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return nil, p.replyError("failed to start message data", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		return nil, p.replyError("failed to write message", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, p.replyError("message rejected", err)
+	}
+
+	// The message is accepted once the data is; a failed QUIT does not undo that
+	client.Quit()
+
+	return &Result{Provider: p.Name(), MessageID: messageID, Status: StatusSent}, nil
+}
+
+// dial connects to the server, bounding the whole conversation by the context deadline or
+// the timeout
+func (p *SMTPProvider) dial(ctx context.Context) (net.Conn, error) {
+	address := net.JoinHostPort(p.host, p.port)
+	dialer := &net.Dialer{Timeout: p.timeout}
+
+	var conn net.Conn
+	var err error
+	if p.port == "465" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: p.host}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else if p.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(p.timeout))
+	}
+
+	return conn, nil
+}
+
+// replyError maps a failed SMTP command onto a SendError. 5xx replies are permanent, except
+// for authentication failures which need the configuration fixed; 4xx replies are transient.
+func (p *SMTPProvider) replyError(message string, err error) *SendError {
+	var reply *textproto.Error
+	if !errors.As(err, &reply) {
+		return transportError(p.Name(), message, err)
+	}
+
+	return &SendError{
+		Provider:   p.Name(),
+		StatusCode: reply.Code,
+		Message:    message,
+		Permanent:  reply.Code >= 500 && reply.Code != 530 && reply.Code != 535,
+		Err:        err,
+	}
+}
@@ -2,6 +2,7 @@ package monitoring
 
 import (
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,9 +39,10 @@ type HealthChecker struct {
 type MetricType string
 
 const (
-	CounterMetric MetricType = "counter"
-	GaugeMetric   MetricType = "gauge"
-	TimerMetric   MetricType = "timer"
+	CounterMetric   MetricType = "counter"
+	GaugeMetric     MetricType = "gauge"
+	TimerMetric     MetricType = "timer"
+	HistogramMetric MetricType = "histogram"
 )
 
 // Metric represents a single metric
@@ -55,6 +57,7 @@ type Metric struct {
 
 // Counter represents a counter metric
 type Counter struct {
+	name   string
 	value  int64
 	labels map[string]string
 	mutex  sync.RWMutex
@@ -62,6 +65,7 @@ type Counter struct {
 
 // Gauge represents a gauge metric
 type Gauge struct {
+	name   string
 	value  float64
 	labels map[string]string
 	mutex  sync.RWMutex
@@ -69,6 +73,7 @@ type Gauge struct {
 
 // Timer represents a timer metric
 type Timer struct {
+	name      string
 	durations []time.Duration
 	sum       time.Duration
 	count     int64
@@ -78,20 +83,22 @@ type Timer struct {
 
 // MetricsCollector collects and manages metrics
 type MetricsCollector struct {
-	counters map[string]*Counter
-	gauges   map[string]*Gauge
-	timers   map[string]*Timer
-	logger   logger.EnhancedLogger
-	mutex    sync.RWMutex
+	counters   map[string]*Counter
+	gauges     map[string]*Gauge
+	timers     map[string]*Timer
+	histograms map[string]*Histogram
+	logger     logger.EnhancedLogger
+	mutex      sync.RWMutex
 }
 
 // NewMetricsCollector creates a new metrics collector
 func NewMetricsCollector(logger logger.EnhancedLogger) *MetricsCollector {
 	return &MetricsCollector{
-		counters: make(map[string]*Counter),
-		gauges:   make(map[string]*Gauge),
-		timers:   make(map[string]*Timer),
-		logger:   logger,
+		counters:   make(map[string]*Counter),
+		gauges:     make(map[string]*Gauge),
+		timers:     make(map[string]*Timer),
+		histograms: make(map[string]*Histogram),
+		logger:     logger,
 	}
 }
 
@@ -105,7 +112,7 @@ func (mc *MetricsCollector) Counter(name string, labels map[string]string) *Coun
 		return counter
 	}
 
-	counter := &Counter{labels: labels}
+	counter := &Counter{name: name, labels: labels}
 	mc.counters[key] = counter
 	return counter
 }
@@ -132,7 +139,7 @@ func (mc *MetricsCollector) Gauge(name string, labels map[string]string) *Gauge
 		return gauge
 	}
 
-	gauge := &Gauge{labels: labels}
+	gauge := &Gauge{name: name, labels: labels}
 	mc.gauges[key] = gauge
 	return gauge
 }
@@ -160,6 +167,7 @@ func (mc *MetricsCollector) Timer(name string, labels map[string]string) *Timer
 	}
 
 	timer := &Timer{
+		name:      name,
 		durations: make([]time.Duration, 0),
 		labels:    labels,
 	}
@@ -226,9 +234,16 @@ func (mc *MetricsCollector) GetAllMetrics() []Metric {
 }
 
 func (mc *MetricsCollector) buildKey(name string, labels map[string]string) string {
+	// Sorted so the same labels always make the same key
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	key := name
-	for k, v := range labels {
-		key += ":" + k + "=" + v
+	for _, k := range keys {
+		key += ":" + k + "=" + labels[k]
 	}
 	return key
 }
@@ -0,0 +1,265 @@
+package monitoring
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of request latency histograms
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram counts observations into buckets by upper bound
+type Histogram struct {
+	name    string
+	buckets []float64
+	counts  []uint64 // Per bucket, not cumulative; the last one counts observations above every bound
+	sum     float64
+	count   uint64
+	labels  map[string]string
+	mutex   sync.RWMutex
+}
+
+// Histogram returns the histogram registered under name and labels, registering it with the
+// given bucket bounds if it does not exist yet
+func (mc *MetricsCollector) Histogram(name string, labels map[string]string, buckets []float64) *Histogram {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	key := mc.buildKey(name, labels)
+	if histogram, exists := mc.histograms[key]; exists {
+		return histogram
+	}
+
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	histogram := &Histogram{
+		name:    name,
+		buckets: bounds,
+		counts:  make([]uint64, len(bounds)+1),
+		labels:  labels,
+	}
+	mc.histograms[key] = histogram
+	return histogram
+}
+
+// Observe records a value in the histogram
+func (h *Histogram) Observe(value float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	i := sort.SearchFloat64s(h.buckets, value)
+	h.counts[i]++
+	h.sum += value
+	h.count++
+}
+
+// Family is a set of samples of one metric, such as one per tenant
+type Family struct {
+	Name    string
+	Help    string
+	Type    MetricType // CounterMetric or GaugeMetric
+	Samples []Sample
+}
+
+// Sample is one labelled value of a metric family
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// WritePrometheus writes every metric collected, followed by the extra families, in the
+// Prometheus text exposition format. Timers are written as summaries in seconds.
+func (mc *MetricsCollector) WritePrometheus(w io.Writer, extra ...Family) error {
+	mc.mutex.RLock()
+	families := make(map[string]*exposedFamily)
+	family := func(name string, metricType string) *exposedFamily {
+		name = sanitizeMetricName(name)
+		if f, exists := families[name]; exists {
+			return f
+		}
+		f := &exposedFamily{name: name, metricType: metricType}
+		families[name] = f
+		return f
+	}
+
+	for _, counter := range mc.counters {
+		f := family(counter.name, "counter")
+		f.add("", counter.labels, float64(counter.Value()))
+	}
+	for _, gauge := range mc.gauges {
+		f := family(gauge.name, "gauge")
+		f.add("", gauge.labels, gauge.Value())
+	}
+	for _, timer := range mc.timers {
+		f := family(timer.name, "summary")
+		timer.mutex.RLock()
+		f.add("_sum", timer.labels, timer.sum.Seconds())
+		f.add("_count", timer.labels, float64(timer.count))
+		timer.mutex.RUnlock()
+	}
+	for _, histogram := range mc.histograms {
+		f := family(histogram.name, "histogram")
+		histogram.mutex.RLock()
+		var cumulative uint64
+		for i, bound := range histogram.buckets {
+			cumulative += histogram.counts[i]
+			f.add("_bucket", withLabel(histogram.labels, "le", formatFloat(bound)), float64(cumulative))
+		}
+		f.add("_bucket", withLabel(histogram.labels, "le", "+Inf"), float64(histogram.count))
+		f.add("_sum", histogram.labels, histogram.sum)
+		f.add("_count", histogram.labels, float64(histogram.count))
+		histogram.mutex.RUnlock()
+	}
+	mc.mutex.RUnlock()
+
+	for _, e := range extra {
+		f := family(e.Name, string(e.Type))
+		f.help = e.Help
+		for _, sample := range e.Samples {
+			f.add("", sample.Labels, sample.Value)
+		}
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := bufio.NewWriter(w)
+	for _, name := range names {
+		families[name].write(buf)
+	}
+	return buf.Flush()
+}
+
+// exposedFamily collects the lines written for one metric family
+type exposedFamily struct {
+	name       string
+	help       string
+	metricType string
+	lines      []exposedLine
+}
+
+type exposedLine struct {
+	series string // Name suffix and labels, which orders the lines
+	value  float64
+}
+
+func (f *exposedFamily) add(suffix string, labels map[string]string, value float64) {
+	f.lines = append(f.lines, exposedLine{series: f.name + suffix + formatLabels(labels), value: value})
+}
+
+func (f *exposedFamily) write(w *bufio.Writer) {
+	if f.help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.metricType)
+
+	// Keep the buckets of a histogram in bound order rather than sorting them as text
+	sort.SliceStable(f.lines, func(i, j int) bool {
+		return seriesKey(f.lines[i].series) < seriesKey(f.lines[j].series)
+	})
+	for _, line := range f.lines {
+		fmt.Fprintf(w, "%s %s\n", line.series, formatFloat(line.value))
+	}
+}
+
+// seriesKey orders the lines of a family by their series without the le label
+func seriesKey(series string) string {
+	if i := strings.Index(series, `le="`); i >= 0 {
+		end := strings.Index(series[i+4:], `"`)
+		return series[:i] + series[i+4+end+1:]
+	}
+	return series
+}
+
+// formatLabels renders labels sorted by name, with an le label last as Prometheus expects
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != "le" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := labels["le"]; ok {
+		keys = append(keys, "le")
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(sanitizeMetricName(k))
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(labels[k]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	merged := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		merged[k] = v
+	}
+	merged[name] = value
+	return merged
+}
+
+// sanitizeMetricName replaces the characters Prometheus does not allow in metric and label names
+func sanitizeMetricName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9' && i > 0:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
+
+func escapeHelp(help string) string {
+	help = strings.ReplaceAll(help, `\`, `\\`)
+	return strings.ReplaceAll(help, "\n", `\n`)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,85 @@
+package monitoring
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollector_WritePrometheus(t *testing.T) {
+	t.Run("writes counters, gauges and timers", func(t *testing.T) {
+		mc := NewMetricsCollector(nil)
+		mc.Counter("logins_total", map[string]string{"result": "ok"}).Inc()
+		mc.Counter("logins_total", map[string]string{"result": "ok"}).Inc()
+		mc.Gauge("system_goroutines", nil).Set(12)
+		mc.Timer("db_query", nil).Record(1500 * time.Millisecond)
+
+		var buf bytes.Buffer
+		require.NoError(t, mc.WritePrometheus(&buf))
+
+		assert.Equal(t, `# TYPE db_query summary
+db_query_count 1
+db_query_sum 1.5
+# TYPE logins_total counter
+logins_total{result="ok"} 2
+# TYPE system_goroutines gauge
+system_goroutines 12
+`, buf.String())
+	})
+
+	t.Run("writes cumulative histogram buckets in bound order", func(t *testing.T) {
+		mc := NewMetricsCollector(nil)
+		histogram := mc.Histogram("http_request_duration_seconds", map[string]string{"method": "GET"}, []float64{0.5, 0.1, 1})
+		histogram.Observe(0.05)
+		histogram.Observe(0.1)
+		histogram.Observe(0.7)
+		histogram.Observe(3)
+
+		var buf bytes.Buffer
+		require.NoError(t, mc.WritePrometheus(&buf))
+
+		assert.Equal(t, `# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{method="GET",le="0.1"} 2
+http_request_duration_seconds_bucket{method="GET",le="0.5"} 2
+http_request_duration_seconds_bucket{method="GET",le="1"} 3
+http_request_duration_seconds_bucket{method="GET",le="+Inf"} 4
+http_request_duration_seconds_count{method="GET"} 4
+http_request_duration_seconds_sum{method="GET"} 3.85
+`, buf.String())
+	})
+
+	t.Run("same labels in any order are one series", func(t *testing.T) {
+		mc := NewMetricsCollector(nil)
+		labels := map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"}
+		for i := 0; i < 20; i++ {
+			mc.Counter("requests_total", labels).Inc()
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, mc.WritePrometheus(&buf))
+
+		assert.Equal(t, "# TYPE requests_total counter\nrequests_total{a=\"1\",b=\"2\",c=\"3\",d=\"4\"} 20\n", buf.String())
+	})
+
+	t.Run("writes extra families with help and escaped labels", func(t *testing.T) {
+		mc := NewMetricsCollector(nil)
+
+		var buf bytes.Buffer
+		require.NoError(t, mc.WritePrometheus(&buf, Family{
+			Name: "tenant-usage",
+			Help: "Resource usage\nper tenant",
+			Type: GaugeMetric,
+			Samples: []Sample{
+				{Labels: map[string]string{"resource": `say "hi"`}, Value: 3},
+			},
+		}))
+
+		assert.Equal(t, `# HELP tenant_usage Resource usage\nper tenant
+# TYPE tenant_usage gauge
+tenant_usage{resource="say \"hi\""} 3
+`, buf.String())
+	})
+}
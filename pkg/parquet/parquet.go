@@ -0,0 +1,398 @@
+// Package parquet writes flat Apache Parquet files.
+//
+// The writer covers what the warehouse export needs and nothing more: a flat
+// schema of optional columns, a single row group, one PLAIN encoded data page
+// per column and no compression. The output is readable by Athena, BigQuery,
+// Spark, DuckDB and pyarrow.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Type is the logical type of a column
+type Type int
+
+const (
+	// String is a UTF-8 string stored as BYTE_ARRAY
+	String Type = iota
+	// Int64 is a signed 64-bit integer
+	Int64
+	// Double is a 64-bit floating point number
+	Double
+	// Boolean is a true/false value
+	Boolean
+	// Timestamp is a UTC instant stored as INT64 milliseconds since the epoch
+	Timestamp
+	// Decimal is a fixed point number stored as INT64 with DecimalScale digits
+	Decimal
+)
+
+// DecimalScale is the scale used for Decimal columns
+const DecimalScale = 2
+
+// decimalPrecision is the maximum number of digits an INT64 decimal can hold
+const decimalPrecision = 18
+
+// Parquet physical types
+const (
+	physicalBoolean   int32 = 0
+	physicalInt64     int32 = 2
+	physicalDouble    int32 = 5
+	physicalByteArray int32 = 6
+)
+
+// Parquet converted types
+const (
+	convertedUTF8            int32 = 0
+	convertedDecimal         int32 = 5
+	convertedTimestampMillis int32 = 9
+)
+
+// Parquet enums used in the metadata
+const (
+	repetitionOptional int32 = 1
+	encodingPlain      int32 = 0
+	encodingRLE        int32 = 3
+	codecUncompressed  int32 = 0
+	pageTypeData       int32 = 0
+)
+
+var magic = []byte("PAR1")
+
+// Column describes a column of the file schema
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Writer buffers rows in memory and encodes them as a Parquet file
+type Writer struct {
+	columns []Column
+	values  [][]interface{} // Column-major values; nil marks a null
+	rows    int
+}
+
+// NewWriter creates a writer for the given schema
+func NewWriter(columns []Column) *Writer {
+	return &Writer{
+		columns: columns,
+		values:  make([][]interface{}, len(columns)),
+	}
+}
+
+// Columns returns the schema of the writer
+func (w *Writer) Columns() []Column {
+	return w.columns
+}
+
+// Rows returns the number of rows written so far
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Write appends a row; values must be given in schema order and nil marks a null
+func (w *Writer) Write(values ...interface{}) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("parquet: row has %d values, schema has %d columns", len(values), len(w.columns))
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		normalized, err := normalize(w.columns[i], value)
+		if err != nil {
+			return err
+		}
+		row[i] = normalized
+	}
+
+	for i, value := range row {
+		w.values[i] = append(w.values[i], value)
+	}
+	w.rows++
+
+	return nil
+}
+
+// Bytes encodes the buffered rows as a complete Parquet file
+func (w *Writer) Bytes() []byte {
+	var file bytes.Buffer
+	file.Write(magic)
+
+	chunks := make([]columnChunk, len(w.columns))
+	for i, column := range w.columns {
+		offset := int64(file.Len())
+		page := w.encodePage(column, w.values[i])
+		file.Write(page)
+		chunks[i] = columnChunk{offset: offset, size: int64(len(page))}
+	}
+
+	footer := w.encodeFooter(chunks)
+	file.Write(footer)
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	file.Write(length[:])
+	file.Write(magic)
+
+	return file.Bytes()
+}
+
+// columnChunk records where a column's page was written
+type columnChunk struct {
+	offset int64
+	size   int64
+}
+
+// encodePage encodes the values of a column as a page header followed by a data page
+func (w *Writer) encodePage(column Column, values []interface{}) []byte {
+	var data bytes.Buffer
+
+	// Definition levels: 1 for present values, 0 for nulls
+	levels := encodeDefinitionLevels(values)
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(levels)))
+	data.Write(length[:])
+	data.Write(levels)
+
+	encodePlain(&data, column.Type, values)
+
+	header := thriftWriter{}
+	header.structBegin()
+	header.i32Field(1, pageTypeData)
+	header.i32Field(2, int32(data.Len()))
+	header.i32Field(3, int32(data.Len()))
+	header.structField(5)
+	header.i32Field(1, int32(len(values)))
+	header.i32Field(2, encodingPlain)
+	header.i32Field(3, encodingRLE)
+	header.i32Field(4, encodingRLE)
+	header.structEnd()
+	header.structEnd()
+
+	return append(header.buf.Bytes(), data.Bytes()...)
+}
+
+// encodeFooter encodes the FileMetaData structure
+func (w *Writer) encodeFooter(chunks []columnChunk) []byte {
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size
+	}
+
+	meta := thriftWriter{}
+	meta.structBegin()
+	meta.i32Field(1, 1)
+
+	// Schema: a root group followed by one element per column
+	meta.listField(2, thriftStruct, len(w.columns)+1)
+	meta.structBegin()
+	meta.stringField(4, "schema")
+	meta.i32Field(5, int32(len(w.columns)))
+	meta.structEnd()
+	for _, column := range w.columns {
+		physical, converted, hasConverted := columnTypes(column.Type)
+		meta.structBegin()
+		meta.i32Field(1, physical)
+		meta.i32Field(3, repetitionOptional)
+		meta.stringField(4, column.Name)
+		if hasConverted {
+			meta.i32Field(6, converted)
+		}
+		if column.Type == Decimal {
+			meta.i32Field(7, DecimalScale)
+			meta.i32Field(8, decimalPrecision)
+		}
+		meta.structEnd()
+	}
+
+	meta.i64Field(3, int64(w.rows))
+
+	// A single row group holding every column chunk
+	meta.listField(4, thriftStruct, 1)
+	meta.structBegin()
+	meta.listField(1, thriftStruct, len(w.columns))
+	for i, column := range w.columns {
+		physical, _, _ := columnTypes(column.Type)
+		meta.structBegin()
+		meta.i64Field(2, chunks[i].offset)
+		meta.structField(3)
+		meta.i32Field(1, physical)
+		meta.listField(2, thriftI32, 2)
+		meta.i32(encodingPlain)
+		meta.i32(encodingRLE)
+		meta.listField(3, thriftBinary, 1)
+		meta.string(column.Name)
+		meta.i32Field(4, codecUncompressed)
+		meta.i64Field(5, int64(w.rows))
+		meta.i64Field(6, chunks[i].size)
+		meta.i64Field(7, chunks[i].size)
+		meta.i64Field(9, chunks[i].offset)
+		meta.structEnd()
+		meta.structEnd()
+	}
+	meta.i64Field(2, totalSize)
+	meta.i64Field(3, int64(w.rows))
+	meta.structEnd()
+
+	meta.stringField(6, "adol parquet writer")
+	meta.structEnd()
+
+	return meta.buf.Bytes()
+}
+
+// columnTypes maps a logical column type to its physical and converted types
+func columnTypes(t Type) (physical int32, converted int32, hasConverted bool) {
+	switch t {
+	case String:
+		return physicalByteArray, convertedUTF8, true
+	case Int64:
+		return physicalInt64, 0, false
+	case Double:
+		return physicalDouble, 0, false
+	case Boolean:
+		return physicalBoolean, 0, false
+	case Timestamp:
+		return physicalInt64, convertedTimestampMillis, true
+	case Decimal:
+		return physicalInt64, convertedDecimal, true
+	default:
+		return physicalByteArray, 0, false
+	}
+}
+
+// normalize converts a Go value to the representation stored for the column type
+func normalize(column Column, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	invalid := func() error {
+		return fmt.Errorf("parquet: invalid value %T for column %s", value, column.Name)
+	}
+
+	switch column.Type {
+	case String:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case *string:
+			if v == nil {
+				return nil, nil
+			}
+			return *v, nil
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+	case Int64:
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case *int:
+			if v == nil {
+				return nil, nil
+			}
+			return int64(*v), nil
+		}
+	case Double:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		}
+	case Boolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case Timestamp:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UnixMilli(), nil
+		case *time.Time:
+			if v == nil {
+				return nil, nil
+			}
+			return v.UnixMilli(), nil
+		}
+	case Decimal:
+		switch v := value.(type) {
+		case decimal.Decimal:
+			return v.Shift(DecimalScale).Round(0).IntPart(), nil
+		case *decimal.Decimal:
+			if v == nil {
+				return nil, nil
+			}
+			return v.Shift(DecimalScale).Round(0).IntPart(), nil
+		}
+	}
+
+	return nil, invalid()
+}
+
+// encodeDefinitionLevels encodes definition levels as a single bit-packed run
+func encodeDefinitionLevels(values []interface{}) []byte {
+	groups := (len(values) + 7) / 8
+
+	var buf bytes.Buffer
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64(groups)<<1|1)
+	buf.Write(tmp[:n])
+
+	packed := make([]byte, groups)
+	for i, value := range values {
+		if value != nil {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	buf.Write(packed)
+
+	return buf.Bytes()
+}
+
+// encodePlain writes the non-null values of a column with PLAIN encoding
+func encodePlain(buf *bytes.Buffer, t Type, values []interface{}) {
+	var bits []bool
+	var scratch [8]byte
+
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		switch t {
+		case String:
+			s := value.(string)
+			binary.LittleEndian.PutUint32(scratch[:4], uint32(len(s)))
+			buf.Write(scratch[:4])
+			buf.WriteString(s)
+		case Int64, Timestamp, Decimal:
+			binary.LittleEndian.PutUint64(scratch[:], uint64(value.(int64)))
+			buf.Write(scratch[:])
+		case Double:
+			binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(value.(float64)))
+			buf.Write(scratch[:])
+		case Boolean:
+			bits = append(bits, value.(bool))
+		}
+	}
+
+	if t == Boolean {
+		packed := make([]byte, (len(bits)+7)/8)
+		for i, bit := range bits {
+			if bit {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf.Write(packed)
+	}
+}
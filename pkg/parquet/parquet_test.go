@@ -0,0 +1,224 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Bytes(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: String},
+		{Name: "quantity", Type: Int64},
+		{Name: "ratio", Type: Double},
+		{Name: "is_void", Type: Boolean},
+		{Name: "created_at", Type: Timestamp},
+		{Name: "total", Type: Decimal},
+	}
+	createdAt := time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC)
+	id := uuid.New()
+
+	w := NewWriter(columns)
+	require.NoError(t, w.Write(id, 3, 0.5, true, createdAt, decimal.RequireFromString("12.345")))
+	require.NoError(t, w.Write("plain", nil, nil, false, nil, nil))
+	require.NoError(t, w.Write(nil, int64(-7), 1.25, nil, &createdAt, decimal.NewFromInt(-4)))
+
+	out := w.Bytes()
+
+	require.True(t, bytes.HasPrefix(out, magic))
+	require.True(t, bytes.HasSuffix(out, magic))
+
+	footerLen := int(binary.LittleEndian.Uint32(out[len(out)-8 : len(out)-4]))
+	footer := out[len(out)-8-footerLen : len(out)-8]
+	meta := newThriftReader(footer).readStruct()
+
+	assert.Equal(t, int64(1), meta[1])
+	assert.Equal(t, int64(3), meta[3])
+
+	schema := meta[2].([]interface{})
+	require.Len(t, schema, len(columns)+1)
+	assert.Equal(t, int64(len(columns)), schema[0].(map[int16]interface{})[5])
+	for i, column := range columns {
+		element := schema[i+1].(map[int16]interface{})
+		assert.Equal(t, column.Name, element[4])
+		assert.Equal(t, int64(repetitionOptional), element[3])
+	}
+	decimalElement := schema[6].(map[int16]interface{})
+	assert.Equal(t, int64(convertedDecimal), decimalElement[6])
+	assert.Equal(t, int64(DecimalScale), decimalElement[7])
+
+	rowGroups := meta[4].([]interface{})
+	require.Len(t, rowGroups, 1)
+	chunks := rowGroups[0].(map[int16]interface{})[1].([]interface{})
+	require.Len(t, chunks, len(columns))
+
+	values := make([][]interface{}, len(columns))
+	for i, chunk := range chunks {
+		columnMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		assert.Equal(t, int64(3), columnMeta[5])
+		values[i] = readColumn(t, out[columnMeta[9].(int64):], columns[i].Type)
+	}
+
+	assert.Equal(t, []interface{}{id.String(), "plain", nil}, values[0])
+	assert.Equal(t, []interface{}{int64(3), nil, int64(-7)}, values[1])
+	assert.Equal(t, []interface{}{0.5, nil, 1.25}, values[2])
+	assert.Equal(t, []interface{}{true, false, nil}, values[3])
+	assert.Equal(t, []interface{}{createdAt.UnixMilli(), nil, createdAt.UnixMilli()}, values[4])
+	assert.Equal(t, []interface{}{int64(1235), nil, int64(-400)}, values[5])
+}
+
+func TestWriter_Write(t *testing.T) {
+	w := NewWriter([]Column{{Name: "quantity", Type: Int64}})
+
+	assert.Error(t, w.Write(1, 2))
+	assert.Error(t, w.Write("one"))
+	assert.Equal(t, 0, w.Rows())
+
+	require.NoError(t, w.Write(1))
+	assert.Equal(t, 1, w.Rows())
+}
+
+func TestWriter_ManyRows(t *testing.T) {
+	w := NewWriter([]Column{{Name: "n", Type: Int64}})
+	for i := 0; i < 100; i++ {
+		var value interface{}
+		if i%3 != 0 {
+			value = i
+		}
+		require.NoError(t, w.Write(value))
+	}
+
+	out := w.Bytes()
+	footerLen := int(binary.LittleEndian.Uint32(out[len(out)-8 : len(out)-4]))
+	meta := newThriftReader(out[len(out)-8-footerLen : len(out)-8]).readStruct()
+	chunk := meta[4].([]interface{})[0].(map[int16]interface{})[1].([]interface{})[0]
+	offset := chunk.(map[int16]interface{})[3].(map[int16]interface{})[9].(int64)
+
+	values := readColumn(t, out[offset:], Int64)
+	require.Len(t, values, 100)
+	assert.Nil(t, values[0])
+	assert.Equal(t, int64(1), values[1])
+	assert.Equal(t, int64(98), values[98])
+	assert.Nil(t, values[99])
+}
+
+// readColumn decodes a single data page written by the writer
+func readColumn(t *testing.T, data []byte, columnType Type) []interface{} {
+	reader := newThriftReader(data)
+	header := reader.readStruct()
+	pageHeader := header[5].(map[int16]interface{})
+	count := int(pageHeader[1].(int64))
+	page := data[reader.pos : reader.pos+int(header[3].(int64))]
+
+	levelsLen := int(binary.LittleEndian.Uint32(page[:4]))
+	levels := page[4 : 4+levelsLen]
+	_, n := binary.Uvarint(levels)
+	packed := levels[n:]
+	body := page[4+levelsLen:]
+
+	values := make([]interface{}, count)
+	bit := 0
+	for i := 0; i < count; i++ {
+		if packed[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		switch columnType {
+		case String:
+			size := int(binary.LittleEndian.Uint32(body[:4]))
+			values[i] = string(body[4 : 4+size])
+			body = body[4+size:]
+		case Int64, Timestamp, Decimal:
+			values[i] = int64(binary.LittleEndian.Uint64(body[:8]))
+			body = body[8:]
+		case Double:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(body[:8]))
+			body = body[8:]
+		case Boolean:
+			values[i] = body[bit/8]&(1<<(bit%8)) != 0
+			bit++
+		default:
+			t.Fatalf("unsupported column type %d", columnType)
+		}
+	}
+
+	return values
+}
+
+// thriftReader decodes Thrift compact structures into field ID keyed maps
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func newThriftReader(data []byte) *thriftReader {
+	return &thriftReader{data: data}
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		header := r.data[r.pos]
+		r.pos++
+		if header == 0 {
+			return fields
+		}
+		fieldType := header & 0x0F
+		if delta := int16(header >> 4); delta != 0 {
+			last += delta
+		} else {
+			last = int16(r.readZigzag())
+		}
+		fields[last] = r.readValue(fieldType)
+	}
+}
+
+func (r *thriftReader) readValue(fieldType byte) interface{} {
+	switch fieldType {
+	case 1:
+		return true
+	case 2:
+		return false
+	case thriftI32, thriftI64:
+		return r.readZigzag()
+	case thriftBinary:
+		size := int(r.readVarint())
+		value := string(r.data[r.pos : r.pos+size])
+		r.pos += size
+		return value
+	case thriftList:
+		header := r.data[r.pos]
+		r.pos++
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.readVarint())
+		}
+		items := make([]interface{}, size)
+		for i := range items {
+			items[i] = r.readValue(header & 0x0F)
+		}
+		return items
+	case thriftStruct:
+		return r.readStruct()
+	default:
+		panic("unsupported thrift type")
+	}
+}
+
+func (r *thriftReader) readVarint() uint64 {
+	value, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return value
+}
+
+func (r *thriftReader) readZigzag() int64 {
+	value := r.readVarint()
+	return int64(value>>1) ^ -int64(value&1)
+}
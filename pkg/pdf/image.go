@@ -0,0 +1,73 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // Registers the JPEG format with image.DecodeConfig
+	"image/png"
+)
+
+// Image is a PNG or JPEG image that can be drawn on the pages of a document
+type Image struct {
+	width      int // pixels
+	height     int // pixels
+	filter     string
+	colorSpace string
+	data       []byte
+}
+
+// NewImage prepares a PNG or JPEG image for drawing. JPEG data is embedded as is; PNG images
+// are decoded and re-encoded, with transparent pixels blended onto white.
+func NewImage(data []byte) (*Image, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %w", err)
+	}
+
+	switch format {
+	case "jpeg":
+		colorSpace := "/DeviceRGB"
+		switch config.ColorModel {
+		case color.GrayModel:
+			colorSpace = "/DeviceGray"
+		case color.CMYKModel:
+			colorSpace = "/DeviceCMYK"
+		}
+		return &Image{width: config.Width, height: config.Height, filter: "/DCTDecode", colorSpace: colorSpace, data: data}, nil
+	case "png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PNG image: %w", err)
+		}
+		return &Image{width: config.Width, height: config.Height, filter: "/FlateDecode", colorSpace: "/DeviceRGB", data: deflateRGB(img)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// AspectRatio returns the width of the image divided by its height
+func (i *Image) AspectRatio() float64 {
+	return float64(i.width) / float64(i.height)
+}
+
+// deflateRGB compresses the pixels of an image as 8-bit RGB, blending transparency onto white
+func deflateRGB(img image.Image) []byte {
+	bounds := img.Bounds()
+	var out bytes.Buffer
+	w := zlib.NewWriter(&out)
+	row := make([]byte, 0, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			// Colors are alpha-premultiplied, so adding the missing coverage blends onto white
+			r, g, b, a := img.At(x, y).RGBA()
+			row = append(row, byte((r+0xffff-a)>>8), byte((g+0xffff-a)>>8), byte((b+0xffff-a)>>8))
+		}
+		w.Write(row)
+	}
+	w.Close()
+	return out.Bytes()
+}
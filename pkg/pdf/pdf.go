@@ -0,0 +1,217 @@
+// Package pdf writes simple PDF documents made of text, filled rectangles and images, enough
+// for labels, receipts and other fixed-layout printouts. Positions and sizes are given in
+// millimeters from the top-left corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// pointsPerMM converts millimeters to PDF points
+const pointsPerMM = 72 / 25.4
+
+// Font is one of the standard fonts every PDF viewer provides
+type Font string
+
+const (
+	FontRegular Font = "F1" // Helvetica
+	FontBold    Font = "F2" // Helvetica-Bold
+)
+
+// averageCharWidth is the approximate width of a Helvetica character relative to the font size
+var averageCharWidth = map[Font]float64{
+	FontRegular: 0.52,
+	FontBold:    0.56,
+}
+
+// Document is a PDF document under construction. All pages share the same size.
+type Document struct {
+	width  float64 // points
+	height float64 // points
+	pages  []*bytes.Buffer
+	images []*Image // Drawn images, named /Im1, /Im2, ... in the order first drawn
+}
+
+// New creates an empty document with pages of the given size in millimeters
+func New(widthMM, heightMM float64) *Document {
+	return &Document{
+		width:  widthMM * pointsPerMM,
+		height: heightMM * pointsPerMM,
+	}
+}
+
+// AddPage starts a new page; subsequent drawing goes to it
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount returns the number of pages in the document
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws a single line of text with its baseline at y
+func (d *Document) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(d.page(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font, num(size), num(x*pointsPerMM), num(d.height-y*pointsPerMM), escape(text))
+}
+
+// Rect draws a filled black rectangle
+func (d *Document) Rect(x, y, width, height float64) {
+	fmt.Fprintf(d.page(), "%s %s %s %s re f\n",
+		num(x*pointsPerMM), num(d.height-(y+height)*pointsPerMM), num(width*pointsPerMM), num(height*pointsPerMM))
+}
+
+// StrokeRect draws the outline of a rectangle with a hairline
+func (d *Document) StrokeRect(x, y, width, height float64) {
+	fmt.Fprintf(d.page(), "0.25 w %s %s %s %s re S\n",
+		num(x*pointsPerMM), num(d.height-(y+height)*pointsPerMM), num(width*pointsPerMM), num(height*pointsPerMM))
+}
+
+// FillColor sets the color of the text and rectangles drawn after it on the current page.
+// Each page starts out black.
+func (d *Document) FillColor(r, g, b uint8) {
+	fmt.Fprintf(d.page(), "%s %s %s rg\n", num(float64(r)/255), num(float64(g)/255), num(float64(b)/255))
+}
+
+// Image draws an image scaled to the given box
+func (d *Document) Image(img *Image, x, y, width, height float64) {
+	index := -1
+	for i, drawn := range d.images {
+		if drawn == img {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		d.images = append(d.images, img)
+		index = len(d.images) - 1
+	}
+
+	fmt.Fprintf(d.page(), "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(width*pointsPerMM), num(height*pointsPerMM), num(x*pointsPerMM), num(d.height-(y+height)*pointsPerMM), index+1)
+}
+
+// TextWidth estimates the width in millimeters of text set in font at size points
+func TextWidth(font Font, size float64, text string) float64 {
+	return float64(len([]rune(text))) * size * averageCharWidth[font] / pointsPerMM
+}
+
+// FitText shortens text with an ellipsis so it fits within width millimeters
+func FitText(font Font, size float64, text string, width float64) string {
+	if TextWidth(font, size, text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && TextWidth(font, size, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	startObject := func() int {
+		offsets = append(offsets, out.Len())
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; pages and their contents follow, then
+	// the images all pages share
+	startObject()
+	out.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	startObject()
+	out.WriteString("2 0 obj\n<< /Type /Pages /Kids [")
+	for i := range d.pages {
+		fmt.Fprintf(&out, " %d 0 R", 5+2*i)
+	}
+	fmt.Fprintf(&out, " ] /Count %d /MediaBox [0 0 %s %s] >>\nendobj\n", len(d.pages), num(d.width), num(d.height))
+
+	startObject()
+	out.WriteString("3 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+	startObject()
+	out.WriteString("4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	var xObjects bytes.Buffer
+	if len(d.images) > 0 {
+		xObjects.WriteString(" /XObject <<")
+		for i := range d.images {
+			fmt.Fprintf(&xObjects, " /Im%d %d 0 R", i+1, 5+2*len(d.pages)+i)
+		}
+		xObjects.WriteString(" >>")
+	}
+
+	for _, content := range d.pages {
+		page := startObject()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 3 0 R /F2 4 0 R >>%s >> /Contents %d 0 R >>\nendobj\n", page, xObjects.String(), page+1)
+
+		stream := startObject()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d >>\nstream\n", stream, content.Len())
+		out.Write(content.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	for _, img := range d.images {
+		object := startObject()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter %s /Length %d >>\nstream\n",
+			object, img.width, img.height, img.colorSpace, img.filter, len(img.data))
+		out.Write(img.data)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// page returns the page being drawn on, starting the first page if needed
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// num formats a number for a content stream
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// escape encodes text as the body of a PDF literal string in WinAnsi encoding. Characters
+// outside Latin-1, other than the euro sign, cannot be shown with the standard fonts and are
+// replaced with '?'.
+func escape(text string) string {
+	var buf bytes.Buffer
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r >= 32 && r <= 126:
+			buf.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&buf, "\\%03o", r)
+		case r == '€':
+			buf.WriteString(`\200`)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
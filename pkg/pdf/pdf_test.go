@@ -0,0 +1,104 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	t.Run("renders pages with valid cross-reference table", func(t *testing.T) {
+		doc := New(210, 297)
+		doc.Text(10, 20, FontBold, 12, "Hello (world)")
+		doc.Rect(10, 30, 0.5, 15)
+		doc.AddPage()
+		doc.StrokeRect(5, 5, 50, 30)
+
+		out := doc.Bytes()
+
+		assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+		assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+		assert.Contains(t, string(out), "/Count 2")
+		assert.Contains(t, string(out), `(Hello \(world\)) Tj`)
+		assert.Equal(t, 2, doc.PageCount())
+
+		// Every xref entry must point at the start of its object
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+		require.NotNil(t, startxref)
+		xrefOffset, err := strconv.Atoi(string(startxref[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")))
+
+		entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xrefOffset:], -1)
+		require.Len(t, entries, 8)
+		for i, entry := range entries {
+			offset, err := strconv.Atoi(string(entry[1]))
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+		}
+	})
+
+	t.Run("empty document has one blank page", func(t *testing.T) {
+		out := New(100, 50).Bytes()
+
+		assert.Contains(t, string(out), "/Count 1")
+	})
+}
+
+func TestDocument_Image(t *testing.T) {
+	logo := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	logo.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	var pngData, jpegData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, logo))
+	require.NoError(t, jpeg.Encode(&jpegData, logo, nil))
+
+	pngImage, err := NewImage(pngData.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, pngImage.AspectRatio())
+
+	jpegImage, err := NewImage(jpegData.Bytes())
+	require.NoError(t, err)
+
+	doc := New(210, 297)
+	doc.FillColor(46, 134, 171)
+	doc.Image(pngImage, 10, 10, 40, 20)
+	doc.AddPage()
+	doc.Image(pngImage, 10, 10, 40, 20)
+	doc.Image(jpegImage, 60, 10, 40, 20)
+
+	out := string(doc.Bytes())
+	assert.Contains(t, out, "0.18 0.53 0.67 rg")
+	assert.Contains(t, out, "/XObject << /Im1 9 0 R /Im2 10 0 R >>")
+	assert.Contains(t, out, "/Filter /FlateDecode")
+	assert.Contains(t, out, "/Filter /DCTDecode")
+	assert.Contains(t, out, "/Im2 Do Q")
+
+	_, err = NewImage([]byte("GIF89a"))
+	assert.Error(t, err)
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `a\\b`, escape(`a\b`))
+	assert.Equal(t, `Caf\351`, escape("Café"))
+	assert.Equal(t, "Rp ?", escape("Rp ₹"))
+	assert.Equal(t, `\2001,50`, escape("€1,50"))
+}
+
+func TestFitText(t *testing.T) {
+	assert.Equal(t, "Milk", FitText(FontRegular, 10, "Milk", 50))
+
+	fitted := FitText(FontRegular, 10, "Organic full cream milk 1 litre carton", 30)
+
+	assert.True(t, TextWidth(FontRegular, 10, fitted) <= 30)
+	assert.Contains(t, fitted, "...")
+}
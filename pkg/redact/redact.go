@@ -0,0 +1,132 @@
+package redact
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Mask replaces the value of a redacted field
+const Mask = "[REDACTED]"
+
+// defaultFields are redacted from every logged payload: credentials, secrets and customer PII
+var defaultFields = []string{
+	"password", "current_password", "new_password", "password_hash",
+	"token", "access_token", "refresh_token", "device_token", "api_key", "secret", "client_secret",
+	"authorization", "cookie",
+	"email", "customer_email", "phone", "customer_phone", "address", "tax_id", "npwp",
+}
+
+// pciFields mark a payload as carrying cardholder data. Such payloads are never stored,
+// not even in redacted form.
+var pciFields = []string{
+	"card_number", "pan", "cvv", "cvc", "cvv2", "card_expiry", "expiry_month", "expiry_year", "track_data", "pin_block",
+}
+
+// sensitiveHeaders are request headers whose values are always masked
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Device-Token", "X-Manager-Override"}
+
+// Rules decides which payload fields are masked and which make a payload PCI-sensitive.
+// Field names match regardless of case, underscores and hyphens, so "cardNumber",
+// "card_number" and "Card-Number" are the same field.
+type Rules struct {
+	fields map[string]bool
+	pci    map[string]bool
+}
+
+// NewRules creates redaction rules from the default field list plus any extra fields
+func NewRules(extraFields ...string) *Rules {
+	rules := &Rules{
+		fields: make(map[string]bool),
+		pci:    make(map[string]bool),
+	}
+	for _, field := range append(defaultFields, extraFields...) {
+		if key := normalize(field); key != "" {
+			rules.fields[key] = true
+		}
+	}
+	for _, field := range pciFields {
+		rules.pci[normalize(field)] = true
+	}
+	return rules
+}
+
+// JSON returns a copy of a JSON body with sensitive fields masked. It reports pci as true,
+// and returns no body, when the payload contains cardholder data. Bodies that are not
+// valid JSON are returned as nil because their contents cannot be redacted safely.
+func (r *Rules) JSON(body []byte) (redacted []byte, pci bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false
+	}
+
+	payload, pci = r.walk(payload)
+	if pci {
+		return nil, true
+	}
+
+	redacted, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return redacted, false
+}
+
+// Headers returns a flattened copy of the headers with credentials masked
+func (r *Rules) Headers(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, values := range headers {
+		result[name] = strings.Join(values, ", ")
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := result[name]; ok {
+			result[name] = Mask
+		}
+	}
+	return result
+}
+
+// walk masks sensitive fields in a decoded JSON value and reports whether it holds PCI data
+func (r *Rules) walk(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			normalized := normalize(key)
+			if r.pci[normalized] {
+				return nil, true
+			}
+			if r.fields[normalized] {
+				v[key] = Mask
+				continue
+			}
+
+			redacted, pci := r.walk(child)
+			if pci {
+				return nil, true
+			}
+			v[key] = redacted
+		}
+		return v, false
+	case []interface{}:
+		for i, child := range v {
+			redacted, pci := r.walk(child)
+			if pci {
+				return nil, true
+			}
+			v[i] = redacted
+		}
+		return v, false
+	default:
+		return v, false
+	}
+}
+
+// normalize lowercases a field name and strips separators
+func normalize(field string) string {
+	field = strings.ToLower(strings.TrimSpace(field))
+	return strings.NewReplacer("_", "", "-", "").Replace(field)
+}
@@ -0,0 +1,49 @@
+package redact
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_JSON(t *testing.T) {
+	rules := NewRules("loyalty_number")
+
+	t.Run("masks sensitive fields at any depth", func(t *testing.T) {
+		body := []byte(`{"username":"budi","password":"s3cret","customer":{"customerEmail":"budi@example.com","Loyalty-Number":"123"},"items":[{"sku":"A1","token":"abc"}]}`)
+
+		redacted, pci := rules.JSON(body)
+
+		require.False(t, pci)
+		assert.JSONEq(t, `{"username":"budi","password":"[REDACTED]","customer":{"customerEmail":"[REDACTED]","Loyalty-Number":"[REDACTED]"},"items":[{"sku":"A1","token":"[REDACTED]"}]}`, string(redacted))
+	})
+
+	t.Run("drops PCI payloads entirely", func(t *testing.T) {
+		body := []byte(`{"amount":100,"payment":{"cardNumber":"4111111111111111","cvv":"123"}}`)
+
+		redacted, pci := rules.JSON(body)
+
+		assert.True(t, pci)
+		assert.Nil(t, redacted)
+	})
+
+	t.Run("non-JSON body is not stored", func(t *testing.T) {
+		redacted, pci := rules.JSON([]byte("password=s3cret"))
+
+		assert.False(t, pci)
+		assert.Nil(t, redacted)
+	})
+}
+
+func TestRules_Headers(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer abc")
+	headers.Set("Content-Type", "application/json")
+
+	result := NewRules().Headers(headers)
+
+	assert.Equal(t, Mask, result["Authorization"])
+	assert.Equal(t, "application/json", result["Content-Type"])
+}
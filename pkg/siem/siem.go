@@ -0,0 +1,270 @@
+// Package siem ships security events to an external SIEM. Events are buffered in memory and
+// sent in batches by a background worker that retries failed batches with exponential
+// backoff. When the buffer is full, callers wait for room up to a configured timeout and the
+// event is dropped after that, so a slow or unreachable SIEM never stalls the application.
+package siem
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types
+const (
+	TypeAudit = "audit" // An audit trail entry
+	TypeLog   = "log"   // A security-relevant application log entry
+)
+
+// Severity levels, ordered from most to least severe as in syslog
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityNotice   = "notice"
+	SeverityInfo     = "info"
+)
+
+// Event is a single entry shipped to the SIEM
+type Event struct {
+	ID        string                 `json:"id"`
+	Timestamp time.Time              `json:"@timestamp"`
+	Type      string                 `json:"type"`
+	Severity  string                 `json:"severity"`
+	Message   string                 `json:"message"`
+	Host      string                 `json:"host,omitempty"`
+	Service   string                 `json:"service,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Sink delivers a batch of events to a SIEM. A batch either succeeds as a whole or is retried
+// as a whole, so sinks should let the SIEM deduplicate events by ID where it can.
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// Options tunes buffering, batching and retries of a Shipper
+type Options struct {
+	Host          string        // Set on events that do not carry a host
+	Service       string        // Set on events that do not carry a service
+	BufferSize    int           // Events held in memory waiting to be shipped
+	BatchSize     int           // Most events sent in one batch
+	FlushInterval time.Duration // Longest time an event waits for its batch to fill
+	MaxRetries    int           // Retries of a failed batch before it is given up
+	RetryBackoff  time.Duration // Wait before the first retry, doubled on every further retry
+	BlockTimeout  time.Duration // How long Ship waits for room in a full buffer, zero drops at once
+	OnError       func(err error)
+}
+
+// Default options
+const (
+	DefaultBufferSize    = 10000
+	DefaultBatchSize     = 500
+	DefaultFlushInterval = 5 * time.Second
+	DefaultRetryBackoff  = time.Second
+)
+
+// Stats counts what happened to the events handed to a Shipper
+type Stats struct {
+	Queued  int    `json:"queued"`  // Waiting in the buffer
+	Shipped uint64 `json:"shipped"` // Accepted by the SIEM
+	Dropped uint64 `json:"dropped"` // Rejected because the buffer was full or the shipper closed
+	Failed  uint64 `json:"failed"`  // Given up after all retries
+}
+
+// Shipper buffers events and ships them to a sink in the background
+type Shipper struct {
+	sink  Sink
+	opts  Options
+	queue chan Event
+
+	mu     sync.RWMutex
+	closed bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	shipped atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// NewShipper creates a shipper and starts its background worker. Zero options fall back to
+// the defaults.
+func NewShipper(sink Sink, opts Options) *Shipper {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultBufferSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Shipper{
+		sink:   sink,
+		opts:   opts,
+		queue:  make(chan Event, opts.BufferSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go s.run()
+
+	return s
+}
+
+// Ship queues an event and reports whether it was accepted. It fills in a missing ID,
+// timestamp, host and service.
+func (s *Shipper) Ship(event Event) bool {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	event.Timestamp = event.Timestamp.UTC()
+	if event.Host == "" {
+		event.Host = s.opts.Host
+	}
+	if event.Service == "" {
+		event.Service = s.opts.Service
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		s.dropped.Add(1)
+		return false
+	}
+
+	select {
+	case s.queue <- event:
+		return true
+	default:
+	}
+
+	if s.opts.BlockTimeout > 0 {
+		timer := time.NewTimer(s.opts.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case s.queue <- event:
+			return true
+		case <-timer.C:
+		}
+	}
+
+	s.dropped.Add(1)
+	return false
+}
+
+// Stats returns the shipper's counters
+func (s *Shipper) Stats() Stats {
+	return Stats{
+		Queued:  len(s.queue),
+		Shipped: s.shipped.Load(),
+		Dropped: s.dropped.Load(),
+		Failed:  s.failed.Load(),
+	}
+}
+
+// Close stops accepting events and ships what is still buffered. When ctx expires first,
+// in-flight sends and retries are abandoned and the remaining events count as failed.
+func (s *Shipper) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	var err error
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		err = ctx.Err()
+	}
+	s.cancel()
+
+	if closeErr := s.sink.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// run collects events into batches and sends them until the queue is closed and drained
+func (s *Shipper) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, s.opts.BatchSize)
+	for {
+		select {
+		case event, ok := <-s.queue:
+			if !ok {
+				s.send(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= s.opts.BatchSize {
+				s.send(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			s.send(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// send delivers a batch, retrying with exponential backoff
+func (s *Shipper) send(batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	backoff := s.opts.RetryBackoff
+	var err error
+	for attempt := 0; attempt <= s.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-s.ctx.Done():
+			}
+			backoff *= 2
+		}
+		if s.ctx.Err() != nil {
+			err = s.ctx.Err()
+			break
+		}
+
+		if err = s.sink.Send(s.ctx, batch); err == nil {
+			s.shipped.Add(uint64(len(batch)))
+			return
+		}
+	}
+
+	s.failed.Add(uint64(len(batch)))
+	if s.opts.OnError != nil {
+		s.opts.OnError(fmt.Errorf("failed to ship %d events: %w", len(batch), err))
+	}
+}
@@ -0,0 +1,278 @@
+package siem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memorySink records sent batches. It fails the first failures sends and waits for block
+// to be closed before each send when set.
+type memorySink struct {
+	mu       sync.Mutex
+	batches  [][]Event
+	failures int
+	attempts int
+	block    chan struct{}
+}
+
+func (s *memorySink) Send(ctx context.Context, events []Event) error {
+	if s.block != nil {
+		select {
+		case <-s.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts++
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	s.batches = append(s.batches, append([]Event(nil), events...))
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func (s *memorySink) events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []Event
+	for _, batch := range s.batches {
+		events = append(events, batch...)
+	}
+	return events
+}
+
+func TestShipper(t *testing.T) {
+	t.Run("batches events and fills in defaults", func(t *testing.T) {
+		sink := &memorySink{}
+		shipper := NewShipper(sink, Options{Host: "pos-1", Service: "adol-pos", BatchSize: 2, FlushInterval: time.Hour})
+
+		for i := 0; i < 5; i++ {
+			require.True(t, shipper.Ship(Event{Type: TypeAudit, Message: "login"}))
+		}
+		require.NoError(t, shipper.Close(context.Background()))
+
+		require.Len(t, sink.batches, 3)
+		events := sink.events()
+		require.Len(t, events, 5)
+		require.NotEmpty(t, events[0].ID)
+		require.NotEqual(t, events[0].ID, events[1].ID)
+		require.False(t, events[0].Timestamp.IsZero())
+		require.Equal(t, "pos-1", events[0].Host)
+		require.Equal(t, "adol-pos", events[0].Service)
+		require.Equal(t, Stats{Shipped: 5}, shipper.Stats())
+	})
+
+	t.Run("flushes partial batches on the interval", func(t *testing.T) {
+		sink := &memorySink{}
+		shipper := NewShipper(sink, Options{BatchSize: 100, FlushInterval: 10 * time.Millisecond})
+		defer shipper.Close(context.Background())
+
+		shipper.Ship(Event{Type: TypeLog})
+		require.Eventually(t, func() bool { return len(sink.events()) == 1 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("retries failed batches", func(t *testing.T) {
+		sink := &memorySink{failures: 2}
+		shipper := NewShipper(sink, Options{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+		shipper.Ship(Event{Type: TypeAudit})
+		require.NoError(t, shipper.Close(context.Background()))
+
+		require.Equal(t, 3, sink.attempts)
+		require.Len(t, sink.events(), 1)
+		require.Equal(t, uint64(1), shipper.Stats().Shipped)
+	})
+
+	t.Run("gives up after the last retry", func(t *testing.T) {
+		var reported error
+		sink := &memorySink{failures: 10}
+		shipper := NewShipper(sink, Options{MaxRetries: 1, RetryBackoff: time.Millisecond, OnError: func(err error) { reported = err }})
+
+		shipper.Ship(Event{Type: TypeAudit})
+		shipper.Ship(Event{Type: TypeAudit})
+		require.NoError(t, shipper.Close(context.Background()))
+
+		require.Equal(t, 2, sink.attempts)
+		require.Equal(t, uint64(2), shipper.Stats().Failed)
+		require.ErrorContains(t, reported, "failed to ship 2 events")
+	})
+
+	t.Run("drops events when the buffer is full", func(t *testing.T) {
+		sink := &memorySink{block: make(chan struct{})}
+		shipper := NewShipper(sink, Options{BufferSize: 1, BatchSize: 1, BlockTimeout: 5 * time.Millisecond})
+
+		// The worker holds the first event while the sink blocks, the second fills the buffer
+		require.True(t, shipper.Ship(Event{Type: TypeLog}))
+		require.Eventually(t, func() bool { return shipper.Stats().Queued == 0 }, time.Second, time.Millisecond)
+		require.True(t, shipper.Ship(Event{Type: TypeLog}))
+		require.False(t, shipper.Ship(Event{Type: TypeLog}))
+		require.Equal(t, uint64(1), shipper.Stats().Dropped)
+
+		close(sink.block)
+		require.NoError(t, shipper.Close(context.Background()))
+		require.Len(t, sink.events(), 2)
+		require.False(t, shipper.Ship(Event{Type: TypeLog}))
+	})
+
+	t.Run("abandons sends when closing times out", func(t *testing.T) {
+		sink := &memorySink{block: make(chan struct{})}
+		shipper := NewShipper(sink, Options{BatchSize: 1})
+
+		shipper.Ship(Event{Type: TypeLog})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, shipper.Close(ctx), context.DeadlineExceeded)
+		require.Equal(t, uint64(1), shipper.Stats().Failed)
+	})
+}
+
+func TestFormatSyslog(t *testing.T) {
+	event := Event{
+		ID:        "1",
+		Timestamp: time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC),
+		Type:      TypeAudit,
+		Severity:  SeverityWarning,
+		Message:   "login failed",
+		Host:      "pos 1",
+	}
+
+	message, err := FormatSyslog(event, "adol-pos")
+	require.NoError(t, err)
+
+	header, body, found := strings.Cut(string(message), " - {")
+	require.True(t, found)
+	require.Equal(t, "<132>1 2026-10-15T08:30:00Z pos1 adol-pos - audit", header)
+
+	var decoded Event
+	require.NoError(t, json.Unmarshal([]byte("{"+body), &decoded))
+	require.Equal(t, event, decoded)
+
+	message, err = FormatSyslog(Event{Severity: "unknown"}, "")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(message), "<134>1 0001-01-01T00:00:00Z - - - - - "))
+}
+
+func TestSyslogSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		for {
+			prefix, err := reader.ReadString(' ')
+			if err != nil {
+				return
+			}
+			length, err := strconv.Atoi(strings.TrimSpace(prefix))
+			if err != nil {
+				return
+			}
+			message := make([]byte, length)
+			if _, err := io.ReadFull(reader, message); err != nil {
+				return
+			}
+			received <- string(message)
+		}
+	}()
+
+	sink, err := NewSyslogSink("tcp", listener.Addr().String(), "adol-pos", time.Second)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(context.Background(), []Event{{Message: "first"}, {Message: "second"}}))
+	require.Contains(t, <-received, `"message":"first"`)
+	require.Contains(t, <-received, `"message":"second"`)
+
+	_, err = NewSyslogSink("unix", "/tmp/log", "", time.Second)
+	require.Error(t, err)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var lines []string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSpace(string(body)), "\n")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink, err := NewHTTPSink(server.URL, map[string]string{"Authorization": "Bearer secret"}, time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), []Event{{ID: "1"}, {ID: "2"}}))
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[1], `"id":"2"`)
+
+	status = http.StatusServiceUnavailable
+	require.ErrorContains(t, sink.Send(context.Background(), []Event{{ID: "3"}}), "status 503")
+}
+
+func TestOpenSearchSink(t *testing.T) {
+	response := `{"errors":false,"items":[]}`
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/_bulk", r.URL.Path)
+		username, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "shipper", username)
+		require.Equal(t, "secret", password)
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSpace(string(body)), "\n")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	sink, err := NewOpenSearchSink(server.URL+"/", "adol-security", "shipper", "secret", time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), []Event{{ID: "1", Message: "login"}}))
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"create":{"_index":"adol-security","_id":"1"}}`, lines[0])
+	require.Contains(t, lines[1], `"message":"login"`)
+
+	t.Run("existing documents count as indexed", func(t *testing.T) {
+		response = `{"errors":true,"items":[{"create":{"status":201}},{"create":{"status":409,"error":{"type":"version_conflict_engine_exception"}}}]}`
+		require.NoError(t, sink.Send(context.Background(), []Event{{ID: "1"}, {ID: "2"}}))
+	})
+
+	t.Run("rejected documents fail the batch", func(t *testing.T) {
+		response = `{"errors":true,"items":[{"create":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad field"}}}]}`
+		require.ErrorContains(t, sink.Send(context.Background(), []Event{{ID: "1"}}), "mapper_parsing_exception: bad field")
+	})
+
+	_, err = NewOpenSearchSink(server.URL, "", "", "", time.Second)
+	require.Error(t, err)
+}
@@ -0,0 +1,317 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseLimit caps how much of a SIEM's response body is read
+const responseLimit = 1 << 20
+
+// syslogFacility is local0, the facility conventionally left to applications
+const syslogFacility = 16
+
+// syslogSeverities maps event severities to syslog severity codes
+var syslogSeverities = map[string]int{
+	SeverityCritical: 2,
+	SeverityError:    3,
+	SeverityWarning:  4,
+	SeverityNotice:   5,
+	SeverityInfo:     6,
+}
+
+// SyslogSink sends events as RFC 5424 syslog messages whose body is the JSON encoded event.
+// Over TCP messages are framed with octet counting (RFC 6587), over UDP each message is one
+// datagram. The connection is opened lazily and reopened after a failed write.
+type SyslogSink struct {
+	network string
+	address string
+	appName string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink creates a syslog sink. network is tcp or udp.
+func NewSyslogSink(network, address, appName string, timeout time.Duration) (*SyslogSink, error) {
+	if network != "tcp" && network != "udp" {
+		return nil, fmt.Errorf("unsupported syslog network %q", network)
+	}
+	if address == "" {
+		return nil, fmt.Errorf("syslog address is required")
+	}
+	return &SyslogSink{network: network, address: address, appName: appName, timeout: timeout}, nil
+}
+
+// Send writes every event of the batch to the syslog server
+func (s *SyslogSink) Send(ctx context.Context, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		dialer := net.Dialer{Timeout: s.timeout}
+		conn, err := dialer.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog server: %w", err)
+		}
+		s.conn = conn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		s.conn.SetWriteDeadline(deadline)
+	} else if s.timeout > 0 {
+		s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	}
+
+	for _, event := range events {
+		message, err := FormatSyslog(event, s.appName)
+		if err != nil {
+			return err
+		}
+		if s.network == "tcp" {
+			message = append([]byte(fmt.Sprintf("%d ", len(message))), message...)
+		}
+		if _, err := s.conn.Write(message); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return fmt.Errorf("failed to write to syslog server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the syslog server
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// FormatSyslog formats an event as an RFC 5424 message
+func FormatSyslog(event Event, appName string) ([]byte, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	severity, ok := syslogSeverities[event.Severity]
+	if !ok {
+		severity = syslogSeverities[SeverityInfo]
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s - %s - ",
+		syslogFacility*8+severity,
+		event.Timestamp.UTC().Format(time.RFC3339Nano),
+		syslogField(event.Host),
+		syslogField(appName),
+		syslogField(event.Type),
+	)
+	b.Write(body)
+
+	return b.Bytes(), nil
+}
+
+// syslogField returns the NILVALUE for empty header fields and strips characters the header
+// does not allow
+func syslogField(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// HTTPSink posts batches as newline-delimited JSON to an HTTP collector, such as the HTTP
+// event inputs most SIEMs offer. Any 2xx response accepts the batch.
+type HTTPSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewHTTPSink creates an HTTP sink. headers are sent with every request, e.g. Authorization.
+func NewHTTPSink(url string, headers map[string]string, timeout time.Duration) (*HTTPSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("collector URL is required")
+	}
+	return &HTTPSink{url: url, headers: headers, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// Send posts the batch to the collector
+func (s *HTTPSink) Send(ctx context.Context, events []Event) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+
+	_, err := post(ctx, s.client, s.url, "application/x-ndjson", body.Bytes(), s.headers)
+	return err
+}
+
+// Close releases idle connections
+func (s *HTTPSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// OpenSearchSink indexes batches through the OpenSearch (or Elasticsearch) bulk API. Events
+// are created with their ID as document ID, so a retried batch does not index duplicates.
+type OpenSearchSink struct {
+	url      string
+	index    string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewOpenSearchSink creates an OpenSearch sink writing to index, which may also be an alias
+// or data stream
+func NewOpenSearchSink(url, index, username, password string, timeout time.Duration) (*OpenSearchSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("OpenSearch URL is required")
+	}
+	if index == "" {
+		return nil, fmt.Errorf("OpenSearch index is required")
+	}
+	return &OpenSearchSink{
+		url:      strings.TrimRight(url, "/") + "/_bulk",
+		index:    index,
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// bulkAction is the action line preceding each document of a bulk request
+type bulkAction struct {
+	Create struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id"`
+	} `json:"create"`
+}
+
+// bulkResponse is the part of a bulk response needed to find rejected documents
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// Send indexes the batch. Documents that already exist count as indexed.
+func (s *OpenSearchSink) Send(ctx context.Context, events []Event) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range events {
+		var action bulkAction
+		action.Create.Index = s.index
+		action.Create.ID = event.ID
+		if err := encoder.Encode(action); err != nil {
+			return fmt.Errorf("failed to encode bulk action: %w", err)
+		}
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+
+	headers := map[string]string{}
+	if s.username != "" {
+		headers["Authorization"] = "Basic " + basicAuth(s.username, s.password)
+	}
+
+	respBody, err := post(ctx, s.client, s.url, "application/x-ndjson", body.Bytes(), headers)
+	if err != nil {
+		return err
+	}
+
+	var resp bulkResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status == http.StatusConflict || (result.Status >= 200 && result.Status < 300) {
+				continue
+			}
+			if result.Error != nil {
+				return fmt.Errorf("bulk indexing rejected a document with status %d: %s: %s", result.Status, result.Error.Type, result.Error.Reason)
+			}
+			return fmt.Errorf("bulk indexing rejected a document with status %d", result.Status)
+		}
+	}
+
+	return nil
+}
+
+// Close releases idle connections
+func (s *OpenSearchSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// basicAuth encodes HTTP basic authentication credentials
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// post sends a request and returns the body of a 2xx response
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, responseLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("SIEM responded with status %d", resp.StatusCode)
+	}
+
+	return respBody, nil
+}
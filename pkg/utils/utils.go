@@ -6,6 +6,8 @@ import (
 	"math/big"
 	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // GenerateInvoiceNumber generates a unique invoice number
@@ -36,6 +38,58 @@ func GenerateSaleNumber() string {
 	return fmt.Sprintf("SALE-%04d%02d%02d-%02d%02d-%03d", year, month, day, hour, minute, randomNum.Int64())
 }
 
+// GenerateRefundNumber generates a unique refund number
+func GenerateRefundNumber() string {
+	now := time.Now()
+	year := now.Year()
+	month := int(now.Month())
+	day := now.Day()
+	
+	// Generate random 4-digit number
+	randomNum, _ := rand.Int(rand.Reader, big.NewInt(9999))
+	
+	return fmt.Sprintf("RFD-%04d%02d%02d-%04d", year, month, day, randomNum.Int64())
+}
+
+// GeneratePurchaseOrderNumber generates a unique purchase order number
+func GeneratePurchaseOrderNumber() string {
+	now := time.Now()
+	year := now.Year()
+	month := int(now.Month())
+	day := now.Day()
+	
+	// Generate random 4-digit number
+	randomNum, _ := rand.Int(rand.Reader, big.NewInt(9999))
+	
+	return fmt.Sprintf("PO-%04d%02d%02d-%04d", year, month, day, randomNum.Int64())
+}
+
+// GenerateQuoteNumber generates a unique quote number
+func GenerateQuoteNumber() string {
+	now := time.Now()
+	year := now.Year()
+	month := int(now.Month())
+	day := now.Day()
+	
+	// Generate random 4-digit number
+	randomNum, _ := rand.Int(rand.Reader, big.NewInt(9999))
+	
+	return fmt.Sprintf("Q-%04d%02d%02d-%04d", year, month, day, randomNum.Int64())
+}
+
+// GenerateStockTransferNumber generates a unique stock transfer number
+func GenerateStockTransferNumber() string {
+	now := time.Now()
+	year := now.Year()
+	month := int(now.Month())
+	day := now.Day()
+	
+	// Generate random 4-digit number
+	randomNum, _ := rand.Int(rand.Reader, big.NewInt(9999))
+	
+	return fmt.Sprintf("TR-%04d%02d%02d-%04d", year, month, day, randomNum.Int64())
+}
+
 // GenerateReceiptNumber generates a unique receipt number
 func GenerateReceiptNumber() string {
 	now := time.Now()
@@ -79,18 +133,28 @@ func IsValidSKU(sku string) bool {
 	return true
 }
 
-// FormatCurrency formats a decimal value as currency
-func FormatCurrency(value float64, currency string) string {
-	switch currency {
-	case "USD":
-		return fmt.Sprintf("$%.2f", value)
-	case "EUR":
-		return fmt.Sprintf("€%.2f", value)
-	case "IDR":
-		return fmt.Sprintf("Rp %.0f", value)
-	default:
-		return fmt.Sprintf("%.2f %s", value, currency)
+// FormatDecimal formats a decimal value rounded to the given decimal places, grouping the
+// integer digits in thousands. Currency display is described by entities.CurrencyFormat.
+func FormatDecimal(value decimal.Decimal, places int, thousandsSeparator, decimalSeparator string) string {
+	text := value.Abs().StringFixed(int32(places))
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	var result strings.Builder
+	if value.Round(int32(places)).IsNegative() {
+		result.WriteString("-")
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			result.WriteString(thousandsSeparator)
+		}
+		result.WriteRune(digit)
 	}
+	if fraction != "" {
+		result.WriteString(decimalSeparator)
+		result.WriteString(fraction)
+	}
+
+	return result.String()
 }
 
 // IsBusinessHours checks if the current time is within business hours
@@ -0,0 +1,90 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSheet struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			T      string `xml:"t,attr"`
+			V      string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readPart(t *testing.T, archive *zip.Reader, name string) []byte {
+	t.Helper()
+	f, err := archive.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return data
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Products & Stock")
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow("SKU", "Name", "Price", "Stock"))
+	require.NoError(t, w.WriteRow("SKU-1", "Kopi <Susu>", 12500.5, 12))
+	require.NoError(t, w.WriteRow("SKU-2", nil, int64(-3), "bell\x07"))
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		var doc struct{}
+		require.NoError(t, xml.Unmarshal(readPart(t, archive, name), &doc), name)
+	}
+	require.Contains(t, string(readPart(t, archive, "xl/workbook.xml")), `name="Products &amp; Stock"`)
+
+	var sheet testSheet
+	require.NoError(t, xml.Unmarshal(readPart(t, archive, "xl/worksheets/sheet1.xml"), &sheet))
+	require.Len(t, sheet.Rows, 3)
+
+	row := sheet.Rows[1]
+	require.Equal(t, "2", row.R)
+	require.Len(t, row.Cells, 4)
+	require.Equal(t, "B2", row.Cells[1].R)
+	require.Equal(t, "inlineStr", row.Cells[1].T)
+	require.Equal(t, "Kopi <Susu>", row.Cells[1].Inline)
+	require.Equal(t, "", row.Cells[2].T)
+	require.Equal(t, "12500.5", row.Cells[2].V)
+	require.Equal(t, "12", row.Cells[3].V)
+
+	row = sheet.Rows[2]
+	require.Len(t, row.Cells, 3)
+	require.Equal(t, "C3", row.Cells[1].R)
+	require.Equal(t, "-3", row.Cells[1].V)
+	require.Equal(t, "bell", row.Cells[2].Inline)
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", ColumnName(0))
+	require.Equal(t, "Z", ColumnName(25))
+	require.Equal(t, "AA", ColumnName(26))
+	require.Equal(t, "AZ", ColumnName(51))
+	require.Equal(t, "BA", ColumnName(52))
+	require.Equal(t, "ZZ", ColumnName(701))
+	require.Equal(t, "AAA", ColumnName(702))
+}
+
+func TestSheetName(t *testing.T) {
+	require.Equal(t, "Sheet1", SheetName(""))
+	require.Equal(t, "Sales_2026_10", SheetName("Sales/2026:10"))
+	require.Len(t, SheetName("A very long sheet name that Excel would reject"), 31)
+}
@@ -0,0 +1,529 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: adol/v1/invoices.proto
+
+package adolv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Invoice struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InvoiceNumber   string                 `protobuf:"bytes,2,opt,name=invoice_number,json=invoiceNumber,proto3" json:"invoice_number,omitempty"`
+	SaleId          string                 `protobuf:"bytes,3,opt,name=sale_id,json=saleId,proto3" json:"sale_id,omitempty"`
+	CustomerName    string                 `protobuf:"bytes,4,opt,name=customer_name,json=customerName,proto3" json:"customer_name,omitempty"`
+	CustomerEmail   string                 `protobuf:"bytes,5,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`
+	CustomerPhone   string                 `protobuf:"bytes,6,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	CustomerAddress string                 `protobuf:"bytes,7,opt,name=customer_address,json=customerAddress,proto3" json:"customer_address,omitempty"`
+	Items           []*InvoiceItem         `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	Subtotal        string                 `protobuf:"bytes,9,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	TaxAmount       string                 `protobuf:"bytes,10,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	DiscountAmount  string                 `protobuf:"bytes,11,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	TotalAmount     string                 `protobuf:"bytes,12,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	PaidAmount      string                 `protobuf:"bytes,13,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	PaymentMethod   string                 `protobuf:"bytes,14,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Status          string                 `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	Notes           string                 `protobuf:"bytes,16,opt,name=notes,proto3" json:"notes,omitempty"`
+	DueDate         *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	PaidAt          *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_adol_v1_invoices_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_invoices_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_adol_v1_invoices_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Invoice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invoice) GetInvoiceNumber() string {
+	if x != nil {
+		return x.InvoiceNumber
+	}
+	return ""
+}
+
+func (x *Invoice) GetSaleId() string {
+	if x != nil {
+		return x.SaleId
+	}
+	return ""
+}
+
+func (x *Invoice) GetCustomerName() string {
+	if x != nil {
+		return x.CustomerName
+	}
+	return ""
+}
+
+func (x *Invoice) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *Invoice) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *Invoice) GetCustomerAddress() string {
+	if x != nil {
+		return x.CustomerAddress
+	}
+	return ""
+}
+
+func (x *Invoice) GetItems() []*InvoiceItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Invoice) GetSubtotal() string {
+	if x != nil {
+		return x.Subtotal
+	}
+	return ""
+}
+
+func (x *Invoice) GetTaxAmount() string {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetDiscountAmount() string {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetTotalAmount() string {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaidAmount() string {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *Invoice) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Invoice) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Invoice) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Invoice) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type InvoiceItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductSku    string                 `protobuf:"bytes,3,opt,name=product_sku,json=productSku,proto3" json:"product_sku,omitempty"`
+	ProductName   string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     string                 `protobuf:"bytes,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	TotalPrice    string                 `protobuf:"bytes,7,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceItem) Reset() {
+	*x = InvoiceItem{}
+	mi := &file_adol_v1_invoices_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceItem) ProtoMessage() {}
+
+func (x *InvoiceItem) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_invoices_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceItem.ProtoReflect.Descriptor instead.
+func (*InvoiceItem) Descriptor() ([]byte, []int) {
+	return file_adol_v1_invoices_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InvoiceItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InvoiceItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InvoiceItem) GetProductSku() string {
+	if x != nil {
+		return x.ProductSku
+	}
+	return ""
+}
+
+func (x *InvoiceItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *InvoiceItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceItem) GetUnitPrice() string {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return ""
+}
+
+func (x *InvoiceItem) GetTotalPrice() string {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return ""
+}
+
+type CreateInvoiceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SaleId          string                 `protobuf:"bytes,1,opt,name=sale_id,json=saleId,proto3" json:"sale_id,omitempty"`
+	CustomerAddress string                 `protobuf:"bytes,2,opt,name=customer_address,json=customerAddress,proto3" json:"customer_address,omitempty"`
+	DueDate         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Notes           string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	mi := &file_adol_v1_invoices_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_invoices_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_invoices_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateInvoiceRequest) GetSaleId() string {
+	if x != nil {
+		return x.SaleId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCustomerAddress() string {
+	if x != nil {
+		return x.CustomerAddress
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_adol_v1_invoices_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_invoices_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_invoices_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_adol_v1_invoices_proto protoreflect.FileDescriptor
+
+const file_adol_v1_invoices_proto_rawDesc = "" +
+	"\n" +
+	"\x16adol/v1/invoices.proto\x12\aadol.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x05\n" +
+	"\aInvoice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0einvoice_number\x18\x02 \x01(\tR\rinvoiceNumber\x12\x17\n" +
+	"\asale_id\x18\x03 \x01(\tR\x06saleId\x12#\n" +
+	"\rcustomer_name\x18\x04 \x01(\tR\fcustomerName\x12%\n" +
+	"\x0ecustomer_email\x18\x05 \x01(\tR\rcustomerEmail\x12%\n" +
+	"\x0ecustomer_phone\x18\x06 \x01(\tR\rcustomerPhone\x12)\n" +
+	"\x10customer_address\x18\a \x01(\tR\x0fcustomerAddress\x12*\n" +
+	"\x05items\x18\b \x03(\v2\x14.adol.v1.InvoiceItemR\x05items\x12\x1a\n" +
+	"\bsubtotal\x18\t \x01(\tR\bsubtotal\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\n" +
+	" \x01(\tR\ttaxAmount\x12'\n" +
+	"\x0fdiscount_amount\x18\v \x01(\tR\x0ediscountAmount\x12!\n" +
+	"\ftotal_amount\x18\f \x01(\tR\vtotalAmount\x12\x1f\n" +
+	"\vpaid_amount\x18\r \x01(\tR\n" +
+	"paidAmount\x12%\n" +
+	"\x0epayment_method\x18\x0e \x01(\tR\rpaymentMethod\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x12\x14\n" +
+	"\x05notes\x18\x10 \x01(\tR\x05notes\x125\n" +
+	"\bdue_date\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x123\n" +
+	"\apaid_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xdc\x01\n" +
+	"\vInvoiceItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vproduct_sku\x18\x03 \x01(\tR\n" +
+	"productSku\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\tR\tunitPrice\x12\x1f\n" +
+	"\vtotal_price\x18\a \x01(\tR\n" +
+	"totalPrice\"\xa7\x01\n" +
+	"\x14CreateInvoiceRequest\x12\x17\n" +
+	"\asale_id\x18\x01 \x01(\tR\x06saleId\x12)\n" +
+	"\x10customer_address\x18\x02 \x01(\tR\x0fcustomerAddress\x125\n" +
+	"\bdue_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\"#\n" +
+	"\x11GetInvoiceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x8e\x01\n" +
+	"\x0eInvoiceService\x12@\n" +
+	"\rCreateInvoice\x12\x1d.adol.v1.CreateInvoiceRequest\x1a\x10.adol.v1.Invoice\x12:\n" +
+	"\n" +
+	"GetInvoice\x12\x1a.adol.v1.GetInvoiceRequest\x1a\x10.adol.v1.InvoiceB0Z.github.com/nicklaros/adol/proto/adol/v1;adolv1b\x06proto3"
+
+var (
+	file_adol_v1_invoices_proto_rawDescOnce sync.Once
+	file_adol_v1_invoices_proto_rawDescData []byte
+)
+
+func file_adol_v1_invoices_proto_rawDescGZIP() []byte {
+	file_adol_v1_invoices_proto_rawDescOnce.Do(func() {
+		file_adol_v1_invoices_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_adol_v1_invoices_proto_rawDesc), len(file_adol_v1_invoices_proto_rawDesc)))
+	})
+	return file_adol_v1_invoices_proto_rawDescData
+}
+
+var file_adol_v1_invoices_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_adol_v1_invoices_proto_goTypes = []any{
+	(*Invoice)(nil),               // 0: adol.v1.Invoice
+	(*InvoiceItem)(nil),           // 1: adol.v1.InvoiceItem
+	(*CreateInvoiceRequest)(nil),  // 2: adol.v1.CreateInvoiceRequest
+	(*GetInvoiceRequest)(nil),     // 3: adol.v1.GetInvoiceRequest
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_adol_v1_invoices_proto_depIdxs = []int32{
+	1, // 0: adol.v1.Invoice.items:type_name -> adol.v1.InvoiceItem
+	4, // 1: adol.v1.Invoice.due_date:type_name -> google.protobuf.Timestamp
+	4, // 2: adol.v1.Invoice.paid_at:type_name -> google.protobuf.Timestamp
+	4, // 3: adol.v1.Invoice.created_at:type_name -> google.protobuf.Timestamp
+	4, // 4: adol.v1.CreateInvoiceRequest.due_date:type_name -> google.protobuf.Timestamp
+	2, // 5: adol.v1.InvoiceService.CreateInvoice:input_type -> adol.v1.CreateInvoiceRequest
+	3, // 6: adol.v1.InvoiceService.GetInvoice:input_type -> adol.v1.GetInvoiceRequest
+	0, // 7: adol.v1.InvoiceService.CreateInvoice:output_type -> adol.v1.Invoice
+	0, // 8: adol.v1.InvoiceService.GetInvoice:output_type -> adol.v1.Invoice
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_adol_v1_invoices_proto_init() }
+func file_adol_v1_invoices_proto_init() {
+	if File_adol_v1_invoices_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_adol_v1_invoices_proto_rawDesc), len(file_adol_v1_invoices_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_adol_v1_invoices_proto_goTypes,
+		DependencyIndexes: file_adol_v1_invoices_proto_depIdxs,
+		MessageInfos:      file_adol_v1_invoices_proto_msgTypes,
+	}.Build()
+	File_adol_v1_invoices_proto = out.File
+	file_adol_v1_invoices_proto_goTypes = nil
+	file_adol_v1_invoices_proto_depIdxs = nil
+}
@@ -0,0 +1,703 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: adol/v1/sales.proto
+
+package adolv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Sale struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SaleNumber      string                 `protobuf:"bytes,2,opt,name=sale_number,json=saleNumber,proto3" json:"sale_number,omitempty"`
+	CustomerName    string                 `protobuf:"bytes,3,opt,name=customer_name,json=customerName,proto3" json:"customer_name,omitempty"`
+	CustomerEmail   string                 `protobuf:"bytes,4,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`
+	CustomerPhone   string                 `protobuf:"bytes,5,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	Items           []*SaleItem            `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	Subtotal        string                 `protobuf:"bytes,7,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	TaxAmount       string                 `protobuf:"bytes,8,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	DiscountAmount  string                 `protobuf:"bytes,9,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	SurchargeAmount string                 `protobuf:"bytes,10,opt,name=surcharge_amount,json=surchargeAmount,proto3" json:"surcharge_amount,omitempty"`
+	TotalAmount     string                 `protobuf:"bytes,11,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	PaidAmount      string                 `protobuf:"bytes,12,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	ChangeAmount    string                 `protobuf:"bytes,13,opt,name=change_amount,json=changeAmount,proto3" json:"change_amount,omitempty"`
+	PaymentMethod   string                 `protobuf:"bytes,14,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Channel         string                 `protobuf:"bytes,15,opt,name=channel,proto3" json:"channel,omitempty"`
+	Status          string                 `protobuf:"bytes,16,opt,name=status,proto3" json:"status,omitempty"`
+	Notes           string                 `protobuf:"bytes,17,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt     *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Sale) Reset() {
+	*x = Sale{}
+	mi := &file_adol_v1_sales_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sale) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sale) ProtoMessage() {}
+
+func (x *Sale) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_sales_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sale.ProtoReflect.Descriptor instead.
+func (*Sale) Descriptor() ([]byte, []int) {
+	return file_adol_v1_sales_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Sale) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Sale) GetSaleNumber() string {
+	if x != nil {
+		return x.SaleNumber
+	}
+	return ""
+}
+
+func (x *Sale) GetCustomerName() string {
+	if x != nil {
+		return x.CustomerName
+	}
+	return ""
+}
+
+func (x *Sale) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *Sale) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *Sale) GetItems() []*SaleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Sale) GetSubtotal() string {
+	if x != nil {
+		return x.Subtotal
+	}
+	return ""
+}
+
+func (x *Sale) GetTaxAmount() string {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return ""
+}
+
+func (x *Sale) GetDiscountAmount() string {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return ""
+}
+
+func (x *Sale) GetSurchargeAmount() string {
+	if x != nil {
+		return x.SurchargeAmount
+	}
+	return ""
+}
+
+func (x *Sale) GetTotalAmount() string {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return ""
+}
+
+func (x *Sale) GetPaidAmount() string {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return ""
+}
+
+func (x *Sale) GetChangeAmount() string {
+	if x != nil {
+		return x.ChangeAmount
+	}
+	return ""
+}
+
+func (x *Sale) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *Sale) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Sale) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Sale) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Sale) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Sale) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type SaleItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductSku    string                 `protobuf:"bytes,3,opt,name=product_sku,json=productSku,proto3" json:"product_sku,omitempty"`
+	ProductName   string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     string                 `protobuf:"bytes,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	TotalPrice    string                 `protobuf:"bytes,7,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	ListPrice     string                 `protobuf:"bytes,8,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaleItem) Reset() {
+	*x = SaleItem{}
+	mi := &file_adol_v1_sales_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaleItem) ProtoMessage() {}
+
+func (x *SaleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_sales_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaleItem.ProtoReflect.Descriptor instead.
+func (*SaleItem) Descriptor() ([]byte, []int) {
+	return file_adol_v1_sales_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SaleItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SaleItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SaleItem) GetProductSku() string {
+	if x != nil {
+		return x.ProductSku
+	}
+	return ""
+}
+
+func (x *SaleItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *SaleItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SaleItem) GetUnitPrice() string {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return ""
+}
+
+func (x *SaleItem) GetTotalPrice() string {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return ""
+}
+
+func (x *SaleItem) GetListPrice() string {
+	if x != nil {
+		return x.ListPrice
+	}
+	return ""
+}
+
+type CreateSaleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerName  string                 `protobuf:"bytes,1,opt,name=customer_name,json=customerName,proto3" json:"customer_name,omitempty"`
+	CustomerEmail string                 `protobuf:"bytes,2,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`
+	CustomerPhone string                 `protobuf:"bytes,3,opt,name=customer_phone,json=customerPhone,proto3" json:"customer_phone,omitempty"`
+	Channel       string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"` // Defaults to in_store
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSaleRequest) Reset() {
+	*x = CreateSaleRequest{}
+	mi := &file_adol_v1_sales_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSaleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSaleRequest) ProtoMessage() {}
+
+func (x *CreateSaleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_sales_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSaleRequest.ProtoReflect.Descriptor instead.
+func (*CreateSaleRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_sales_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSaleRequest) GetCustomerName() string {
+	if x != nil {
+		return x.CustomerName
+	}
+	return ""
+}
+
+func (x *CreateSaleRequest) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *CreateSaleRequest) GetCustomerPhone() string {
+	if x != nil {
+		return x.CustomerPhone
+	}
+	return ""
+}
+
+func (x *CreateSaleRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type GetSaleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSaleRequest) Reset() {
+	*x = GetSaleRequest{}
+	mi := &file_adol_v1_sales_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSaleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSaleRequest) ProtoMessage() {}
+
+func (x *GetSaleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_sales_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSaleRequest.ProtoReflect.Descriptor instead.
+func (*GetSaleRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_sales_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSaleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AddSaleItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SaleId        string                 `protobuf:"bytes,1,opt,name=sale_id,json=saleId,proto3" json:"sale_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSaleItemRequest) Reset() {
+	*x = AddSaleItemRequest{}
+	mi := &file_adol_v1_sales_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSaleItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSaleItemRequest) ProtoMessage() {}
+
+func (x *AddSaleItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_sales_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSaleItemRequest.ProtoReflect.Descriptor instead.
+func (*AddSaleItemRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_sales_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddSaleItemRequest) GetSaleId() string {
+	if x != nil {
+		return x.SaleId
+	}
+	return ""
+}
+
+func (x *AddSaleItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddSaleItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type CompleteSaleRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SaleId         string                 `protobuf:"bytes,1,opt,name=sale_id,json=saleId,proto3" json:"sale_id,omitempty"`
+	PaidAmount     string                 `protobuf:"bytes,2,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	PaymentMethod  string                 `protobuf:"bytes,3,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	DiscountAmount string                 `protobuf:"bytes,4,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	TaxPercentage  string                 `protobuf:"bytes,5,opt,name=tax_percentage,json=taxPercentage,proto3" json:"tax_percentage,omitempty"`
+	Notes          string                 `protobuf:"bytes,6,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompleteSaleRequest) Reset() {
+	*x = CompleteSaleRequest{}
+	mi := &file_adol_v1_sales_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteSaleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteSaleRequest) ProtoMessage() {}
+
+func (x *CompleteSaleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_sales_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteSaleRequest.ProtoReflect.Descriptor instead.
+func (*CompleteSaleRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_sales_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompleteSaleRequest) GetSaleId() string {
+	if x != nil {
+		return x.SaleId
+	}
+	return ""
+}
+
+func (x *CompleteSaleRequest) GetPaidAmount() string {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return ""
+}
+
+func (x *CompleteSaleRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *CompleteSaleRequest) GetDiscountAmount() string {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return ""
+}
+
+func (x *CompleteSaleRequest) GetTaxPercentage() string {
+	if x != nil {
+		return x.TaxPercentage
+	}
+	return ""
+}
+
+func (x *CompleteSaleRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+var File_adol_v1_sales_proto protoreflect.FileDescriptor
+
+const file_adol_v1_sales_proto_rawDesc = "" +
+	"\n" +
+	"\x13adol/v1/sales.proto\x12\aadol.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\x05\n" +
+	"\x04Sale\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vsale_number\x18\x02 \x01(\tR\n" +
+	"saleNumber\x12#\n" +
+	"\rcustomer_name\x18\x03 \x01(\tR\fcustomerName\x12%\n" +
+	"\x0ecustomer_email\x18\x04 \x01(\tR\rcustomerEmail\x12%\n" +
+	"\x0ecustomer_phone\x18\x05 \x01(\tR\rcustomerPhone\x12'\n" +
+	"\x05items\x18\x06 \x03(\v2\x11.adol.v1.SaleItemR\x05items\x12\x1a\n" +
+	"\bsubtotal\x18\a \x01(\tR\bsubtotal\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\b \x01(\tR\ttaxAmount\x12'\n" +
+	"\x0fdiscount_amount\x18\t \x01(\tR\x0ediscountAmount\x12)\n" +
+	"\x10surcharge_amount\x18\n" +
+	" \x01(\tR\x0fsurchargeAmount\x12!\n" +
+	"\ftotal_amount\x18\v \x01(\tR\vtotalAmount\x12\x1f\n" +
+	"\vpaid_amount\x18\f \x01(\tR\n" +
+	"paidAmount\x12#\n" +
+	"\rchange_amount\x18\r \x01(\tR\fchangeAmount\x12%\n" +
+	"\x0epayment_method\x18\x0e \x01(\tR\rpaymentMethod\x12\x18\n" +
+	"\achannel\x18\x0f \x01(\tR\achannel\x12\x16\n" +
+	"\x06status\x18\x10 \x01(\tR\x06status\x12\x14\n" +
+	"\x05notes\x18\x11 \x01(\tR\x05notes\x129\n" +
+	"\n" +
+	"created_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xf8\x01\n" +
+	"\bSaleItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vproduct_sku\x18\x03 \x01(\tR\n" +
+	"productSku\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\tR\tunitPrice\x12\x1f\n" +
+	"\vtotal_price\x18\a \x01(\tR\n" +
+	"totalPrice\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\b \x01(\tR\tlistPrice\"\xa0\x01\n" +
+	"\x11CreateSaleRequest\x12#\n" +
+	"\rcustomer_name\x18\x01 \x01(\tR\fcustomerName\x12%\n" +
+	"\x0ecustomer_email\x18\x02 \x01(\tR\rcustomerEmail\x12%\n" +
+	"\x0ecustomer_phone\x18\x03 \x01(\tR\rcustomerPhone\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\" \n" +
+	"\x0eGetSaleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"h\n" +
+	"\x12AddSaleItemRequest\x12\x17\n" +
+	"\asale_id\x18\x01 \x01(\tR\x06saleId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\xdc\x01\n" +
+	"\x13CompleteSaleRequest\x12\x17\n" +
+	"\asale_id\x18\x01 \x01(\tR\x06saleId\x12\x1f\n" +
+	"\vpaid_amount\x18\x02 \x01(\tR\n" +
+	"paidAmount\x12%\n" +
+	"\x0epayment_method\x18\x03 \x01(\tR\rpaymentMethod\x12'\n" +
+	"\x0fdiscount_amount\x18\x04 \x01(\tR\x0ediscountAmount\x12%\n" +
+	"\x0etax_percentage\x18\x05 \x01(\tR\rtaxPercentage\x12\x14\n" +
+	"\x05notes\x18\x06 \x01(\tR\x05notes2\xf1\x01\n" +
+	"\vSaleService\x127\n" +
+	"\n" +
+	"CreateSale\x12\x1a.adol.v1.CreateSaleRequest\x1a\r.adol.v1.Sale\x121\n" +
+	"\aGetSale\x12\x17.adol.v1.GetSaleRequest\x1a\r.adol.v1.Sale\x129\n" +
+	"\vAddSaleItem\x12\x1b.adol.v1.AddSaleItemRequest\x1a\r.adol.v1.Sale\x12;\n" +
+	"\fCompleteSale\x12\x1c.adol.v1.CompleteSaleRequest\x1a\r.adol.v1.SaleB0Z.github.com/nicklaros/adol/proto/adol/v1;adolv1b\x06proto3"
+
+var (
+	file_adol_v1_sales_proto_rawDescOnce sync.Once
+	file_adol_v1_sales_proto_rawDescData []byte
+)
+
+func file_adol_v1_sales_proto_rawDescGZIP() []byte {
+	file_adol_v1_sales_proto_rawDescOnce.Do(func() {
+		file_adol_v1_sales_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_adol_v1_sales_proto_rawDesc), len(file_adol_v1_sales_proto_rawDesc)))
+	})
+	return file_adol_v1_sales_proto_rawDescData
+}
+
+var file_adol_v1_sales_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_adol_v1_sales_proto_goTypes = []any{
+	(*Sale)(nil),                  // 0: adol.v1.Sale
+	(*SaleItem)(nil),              // 1: adol.v1.SaleItem
+	(*CreateSaleRequest)(nil),     // 2: adol.v1.CreateSaleRequest
+	(*GetSaleRequest)(nil),        // 3: adol.v1.GetSaleRequest
+	(*AddSaleItemRequest)(nil),    // 4: adol.v1.AddSaleItemRequest
+	(*CompleteSaleRequest)(nil),   // 5: adol.v1.CompleteSaleRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_adol_v1_sales_proto_depIdxs = []int32{
+	1, // 0: adol.v1.Sale.items:type_name -> adol.v1.SaleItem
+	6, // 1: adol.v1.Sale.created_at:type_name -> google.protobuf.Timestamp
+	6, // 2: adol.v1.Sale.completed_at:type_name -> google.protobuf.Timestamp
+	2, // 3: adol.v1.SaleService.CreateSale:input_type -> adol.v1.CreateSaleRequest
+	3, // 4: adol.v1.SaleService.GetSale:input_type -> adol.v1.GetSaleRequest
+	4, // 5: adol.v1.SaleService.AddSaleItem:input_type -> adol.v1.AddSaleItemRequest
+	5, // 6: adol.v1.SaleService.CompleteSale:input_type -> adol.v1.CompleteSaleRequest
+	0, // 7: adol.v1.SaleService.CreateSale:output_type -> adol.v1.Sale
+	0, // 8: adol.v1.SaleService.GetSale:output_type -> adol.v1.Sale
+	0, // 9: adol.v1.SaleService.AddSaleItem:output_type -> adol.v1.Sale
+	0, // 10: adol.v1.SaleService.CompleteSale:output_type -> adol.v1.Sale
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_adol_v1_sales_proto_init() }
+func file_adol_v1_sales_proto_init() {
+	if File_adol_v1_sales_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_adol_v1_sales_proto_rawDesc), len(file_adol_v1_sales_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_adol_v1_sales_proto_goTypes,
+		DependencyIndexes: file_adol_v1_sales_proto_depIdxs,
+		MessageInfos:      file_adol_v1_sales_proto_msgTypes,
+	}.Build()
+	File_adol_v1_sales_proto = out.File
+	file_adol_v1_sales_proto_goTypes = nil
+	file_adol_v1_sales_proto_depIdxs = nil
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: adol/v1/sales.proto
+
+package adolv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SaleService_CreateSale_FullMethodName   = "/adol.v1.SaleService/CreateSale"
+	SaleService_GetSale_FullMethodName      = "/adol.v1.SaleService/GetSale"
+	SaleService_AddSaleItem_FullMethodName  = "/adol.v1.SaleService/AddSaleItem"
+	SaleService_CompleteSale_FullMethodName = "/adol.v1.SaleService/CompleteSale"
+)
+
+// SaleServiceClient is the client API for SaleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SaleService records sales. GetSale requires sales:read, CreateSale requires
+// sales:create and the other methods require sales:update.
+type SaleServiceClient interface {
+	CreateSale(ctx context.Context, in *CreateSaleRequest, opts ...grpc.CallOption) (*Sale, error)
+	GetSale(ctx context.Context, in *GetSaleRequest, opts ...grpc.CallOption) (*Sale, error)
+	AddSaleItem(ctx context.Context, in *AddSaleItemRequest, opts ...grpc.CallOption) (*Sale, error)
+	CompleteSale(ctx context.Context, in *CompleteSaleRequest, opts ...grpc.CallOption) (*Sale, error)
+}
+
+type saleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSaleServiceClient(cc grpc.ClientConnInterface) SaleServiceClient {
+	return &saleServiceClient{cc}
+}
+
+func (c *saleServiceClient) CreateSale(ctx context.Context, in *CreateSaleRequest, opts ...grpc.CallOption) (*Sale, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sale)
+	err := c.cc.Invoke(ctx, SaleService_CreateSale_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saleServiceClient) GetSale(ctx context.Context, in *GetSaleRequest, opts ...grpc.CallOption) (*Sale, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sale)
+	err := c.cc.Invoke(ctx, SaleService_GetSale_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saleServiceClient) AddSaleItem(ctx context.Context, in *AddSaleItemRequest, opts ...grpc.CallOption) (*Sale, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sale)
+	err := c.cc.Invoke(ctx, SaleService_AddSaleItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saleServiceClient) CompleteSale(ctx context.Context, in *CompleteSaleRequest, opts ...grpc.CallOption) (*Sale, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sale)
+	err := c.cc.Invoke(ctx, SaleService_CompleteSale_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SaleServiceServer is the server API for SaleService service.
+// All implementations must embed UnimplementedSaleServiceServer
+// for forward compatibility.
+//
+// SaleService records sales. GetSale requires sales:read, CreateSale requires
+// sales:create and the other methods require sales:update.
+type SaleServiceServer interface {
+	CreateSale(context.Context, *CreateSaleRequest) (*Sale, error)
+	GetSale(context.Context, *GetSaleRequest) (*Sale, error)
+	AddSaleItem(context.Context, *AddSaleItemRequest) (*Sale, error)
+	CompleteSale(context.Context, *CompleteSaleRequest) (*Sale, error)
+	mustEmbedUnimplementedSaleServiceServer()
+}
+
+// UnimplementedSaleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSaleServiceServer struct{}
+
+func (UnimplementedSaleServiceServer) CreateSale(context.Context, *CreateSaleRequest) (*Sale, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSale not implemented")
+}
+func (UnimplementedSaleServiceServer) GetSale(context.Context, *GetSaleRequest) (*Sale, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSale not implemented")
+}
+func (UnimplementedSaleServiceServer) AddSaleItem(context.Context, *AddSaleItemRequest) (*Sale, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSaleItem not implemented")
+}
+func (UnimplementedSaleServiceServer) CompleteSale(context.Context, *CompleteSaleRequest) (*Sale, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteSale not implemented")
+}
+func (UnimplementedSaleServiceServer) mustEmbedUnimplementedSaleServiceServer() {}
+func (UnimplementedSaleServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeSaleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SaleServiceServer will
+// result in compilation errors.
+type UnsafeSaleServiceServer interface {
+	mustEmbedUnimplementedSaleServiceServer()
+}
+
+func RegisterSaleServiceServer(s grpc.ServiceRegistrar, srv SaleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSaleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SaleService_ServiceDesc, srv)
+}
+
+func _SaleService_CreateSale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaleServiceServer).CreateSale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaleService_CreateSale_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaleServiceServer).CreateSale(ctx, req.(*CreateSaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaleService_GetSale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaleServiceServer).GetSale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaleService_GetSale_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaleServiceServer).GetSale(ctx, req.(*GetSaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaleService_AddSaleItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSaleItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaleServiceServer).AddSaleItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaleService_AddSaleItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaleServiceServer).AddSaleItem(ctx, req.(*AddSaleItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaleService_CompleteSale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteSaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaleServiceServer).CompleteSale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaleService_CompleteSale_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaleServiceServer).CompleteSale(ctx, req.(*CompleteSaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SaleService_ServiceDesc is the grpc.ServiceDesc for SaleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SaleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "adol.v1.SaleService",
+	HandlerType: (*SaleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSale",
+			Handler:    _SaleService_CreateSale_Handler,
+		},
+		{
+			MethodName: "GetSale",
+			Handler:    _SaleService_GetSale_Handler,
+		},
+		{
+			MethodName: "AddSaleItem",
+			Handler:    _SaleService_AddSaleItem_Handler,
+		},
+		{
+			MethodName: "CompleteSale",
+			Handler:    _SaleService_CompleteSale_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "adol/v1/sales.proto",
+}
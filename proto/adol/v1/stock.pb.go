@@ -0,0 +1,368 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: adol/v1/stock.proto
+
+package adolv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Stock struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId      string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductSku     string                 `protobuf:"bytes,3,opt,name=product_sku,json=productSku,proto3" json:"product_sku,omitempty"`
+	ProductName    string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	AvailableQty   int32                  `protobuf:"varint,5,opt,name=available_qty,json=availableQty,proto3" json:"available_qty,omitempty"`
+	ReservedQty    int32                  `protobuf:"varint,6,opt,name=reserved_qty,json=reservedQty,proto3" json:"reserved_qty,omitempty"`
+	TotalQty       int32                  `protobuf:"varint,7,opt,name=total_qty,json=totalQty,proto3" json:"total_qty,omitempty"`
+	ReorderLevel   int32                  `protobuf:"varint,8,opt,name=reorder_level,json=reorderLevel,proto3" json:"reorder_level,omitempty"`
+	StockStatus    string                 `protobuf:"bytes,9,opt,name=stock_status,json=stockStatus,proto3" json:"stock_status,omitempty"`
+	LastMovementAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_movement_at,json=lastMovementAt,proto3" json:"last_movement_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_adol_v1_stock_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_stock_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_adol_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Stock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Stock) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Stock) GetProductSku() string {
+	if x != nil {
+		return x.ProductSku
+	}
+	return ""
+}
+
+func (x *Stock) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *Stock) GetAvailableQty() int32 {
+	if x != nil {
+		return x.AvailableQty
+	}
+	return 0
+}
+
+func (x *Stock) GetReservedQty() int32 {
+	if x != nil {
+		return x.ReservedQty
+	}
+	return 0
+}
+
+func (x *Stock) GetTotalQty() int32 {
+	if x != nil {
+		return x.TotalQty
+	}
+	return 0
+}
+
+func (x *Stock) GetReorderLevel() int32 {
+	if x != nil {
+		return x.ReorderLevel
+	}
+	return 0
+}
+
+func (x *Stock) GetStockStatus() string {
+	if x != nil {
+		return x.StockStatus
+	}
+	return ""
+}
+
+func (x *Stock) GetLastMovementAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMovementAt
+	}
+	return nil
+}
+
+func (x *Stock) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_adol_v1_stock_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_stock_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type AdjustStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // in, out or adjustment
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Stock movement reason, e.g. purchase, damage, correction
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reference     string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	Notes         string                 `protobuf:"bytes,6,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_adol_v1_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adol_v1_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_adol_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AdjustStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+var File_adol_v1_stock_proto protoreflect.FileDescriptor
+
+const file_adol_v1_stock_proto_rawDesc = "" +
+	"\n" +
+	"\x13adol/v1/stock.proto\x12\aadol.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x03\n" +
+	"\x05Stock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vproduct_sku\x18\x03 \x01(\tR\n" +
+	"productSku\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12#\n" +
+	"\ravailable_qty\x18\x05 \x01(\x05R\favailableQty\x12!\n" +
+	"\freserved_qty\x18\x06 \x01(\x05R\vreservedQty\x12\x1b\n" +
+	"\ttotal_qty\x18\a \x01(\x05R\btotalQty\x12#\n" +
+	"\rreorder_level\x18\b \x01(\x05R\freorderLevel\x12!\n" +
+	"\fstock_status\x18\t \x01(\tR\vstockStatus\x12D\n" +
+	"\x10last_movement_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0elastMovementAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"0\n" +
+	"\x0fGetStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"\xaf\x01\n" +
+	"\x12AdjustStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12\x14\n" +
+	"\x05notes\x18\x06 \x01(\tR\x05notes2\x80\x01\n" +
+	"\fStockService\x124\n" +
+	"\bGetStock\x12\x18.adol.v1.GetStockRequest\x1a\x0e.adol.v1.Stock\x12:\n" +
+	"\vAdjustStock\x12\x1b.adol.v1.AdjustStockRequest\x1a\x0e.adol.v1.StockB0Z.github.com/nicklaros/adol/proto/adol/v1;adolv1b\x06proto3"
+
+var (
+	file_adol_v1_stock_proto_rawDescOnce sync.Once
+	file_adol_v1_stock_proto_rawDescData []byte
+)
+
+func file_adol_v1_stock_proto_rawDescGZIP() []byte {
+	file_adol_v1_stock_proto_rawDescOnce.Do(func() {
+		file_adol_v1_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_adol_v1_stock_proto_rawDesc), len(file_adol_v1_stock_proto_rawDesc)))
+	})
+	return file_adol_v1_stock_proto_rawDescData
+}
+
+var file_adol_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_adol_v1_stock_proto_goTypes = []any{
+	(*Stock)(nil),                 // 0: adol.v1.Stock
+	(*GetStockRequest)(nil),       // 1: adol.v1.GetStockRequest
+	(*AdjustStockRequest)(nil),    // 2: adol.v1.AdjustStockRequest
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_adol_v1_stock_proto_depIdxs = []int32{
+	3, // 0: adol.v1.Stock.last_movement_at:type_name -> google.protobuf.Timestamp
+	3, // 1: adol.v1.Stock.updated_at:type_name -> google.protobuf.Timestamp
+	1, // 2: adol.v1.StockService.GetStock:input_type -> adol.v1.GetStockRequest
+	2, // 3: adol.v1.StockService.AdjustStock:input_type -> adol.v1.AdjustStockRequest
+	0, // 4: adol.v1.StockService.GetStock:output_type -> adol.v1.Stock
+	0, // 5: adol.v1.StockService.AdjustStock:output_type -> adol.v1.Stock
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_adol_v1_stock_proto_init() }
+func file_adol_v1_stock_proto_init() {
+	if File_adol_v1_stock_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_adol_v1_stock_proto_rawDesc), len(file_adol_v1_stock_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_adol_v1_stock_proto_goTypes,
+		DependencyIndexes: file_adol_v1_stock_proto_depIdxs,
+		MessageInfos:      file_adol_v1_stock_proto_msgTypes,
+	}.Build()
+	File_adol_v1_stock_proto = out.File
+	file_adol_v1_stock_proto_goTypes = nil
+	file_adol_v1_stock_proto_depIdxs = nil
+}
@@ -340,6 +340,64 @@ func TestProductRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("SKU Uniqueness Per Tenant", func(t *testing.T) {
+		// Create two tenants
+		tenantIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		for i, tenantID := range tenantIDs {
+			_, err := testDB.DB.Exec(`INSERT INTO tenants (id, name, slug) VALUES ($1, $2, $3)`,
+				tenantID, fmt.Sprintf("SKU Tenant %d", i+1), "sku-tenant-"+tenantID.String())
+			require.NoError(t, err)
+		}
+		defer func() {
+			for _, tenantID := range tenantIDs {
+				_, err := testDB.DB.Exec("DELETE FROM tenants WHERE id = $1", tenantID)
+				assert.NoError(t, err)
+			}
+		}()
+
+		// Create a product with the same SKU in each tenant
+		products := make([]*entities.Product, len(tenantIDs))
+		for i, tenantID := range tenantIDs {
+			products[i] = &entities.Product{
+				ID:          uuid.New(),
+				TenantID:    tenantID,
+				SKU:         "SHARED-SKU-001",
+				Name:        fmt.Sprintf("Tenant %d Product", i+1),
+				Description: "Shared SKU",
+				Category:    "Category1",
+				Price:       decimal.NewFromFloat(10.00),
+				Cost:        decimal.NewFromFloat(5.00),
+				Unit:        "piece",
+				MinStock:    5,
+				Status:      entities.ProductStatusActive,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+				CreatedBy:   uuid.MustParse(userID),
+			}
+
+			err := productRepo.Create(ctx, products[i])
+			require.NoError(t, err)
+		}
+
+		// Each tenant gets its own product
+		for i, tenantID := range tenantIDs {
+			product, err := productRepo.GetByTenantAndSKU(ctx, tenantID, "SHARED-SKU-001")
+			require.NoError(t, err)
+			assert.Equal(t, products[i].ID, product.ID)
+			assert.Equal(t, tenantID, product.TenantID)
+		}
+
+		// A tenant without the SKU gets none
+		_, err := productRepo.GetByTenantAndSKU(ctx, uuid.New(), "SHARED-SKU-001")
+		assert.Error(t, err)
+
+		// Cleanup
+		for _, product := range products {
+			err = productRepo.Delete(ctx, product.ID)
+			require.NoError(t, err)
+		}
+	})
+
 	t.Run("Soft Delete", func(t *testing.T) {
 		// Create test product
 		product := &entities.Product{